@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/timmaaaz/ichor/api/domain/http/agentapi/catalogapi"
 	"github.com/timmaaaz/ichor/api/domain/http/agentapi/chatapi"
 	"github.com/timmaaaz/ichor/api/domain/http/assets/approvalstatusapi"
@@ -28,6 +30,7 @@ import (
 	"github.com/timmaaaz/ichor/api/domain/http/config/settingsapi"
 	"github.com/timmaaaz/ichor/api/domain/http/core/contactinfosapi"
 	"github.com/timmaaaz/ichor/api/domain/http/core/currencyapi"
+	"github.com/timmaaaz/ichor/api/domain/http/core/datascopeapi"
 	"github.com/timmaaaz/ichor/api/domain/http/core/pageapi"
 	"github.com/timmaaaz/ichor/api/domain/http/core/paymenttermapi"
 	"github.com/timmaaaz/ichor/api/domain/http/core/roleapi"
@@ -42,8 +45,10 @@ import (
 	"github.com/timmaaaz/ichor/api/domain/http/hr/officeapi"
 	"github.com/timmaaaz/ichor/api/domain/http/hr/reportstoapi"
 	"github.com/timmaaaz/ichor/api/domain/http/hr/titleapi"
+	"github.com/timmaaaz/ichor/api/domain/http/inventory/countplanapi"
 	"github.com/timmaaaz/ichor/api/domain/http/inventory/cyclecountitemapi"
 	"github.com/timmaaaz/ichor/api/domain/http/inventory/cyclecountsessionapi"
+	"github.com/timmaaaz/ichor/api/domain/http/inventory/demandforecastapi"
 	"github.com/timmaaaz/ichor/api/domain/http/inventory/inspectionapi"
 	"github.com/timmaaaz/ichor/api/domain/http/inventory/inventoryadjustmentapi"
 	"github.com/timmaaaz/ichor/api/domain/http/inventory/inventoryitemapi"
@@ -52,7 +57,9 @@ import (
 	"github.com/timmaaaz/ichor/api/domain/http/inventory/lotlocationapi"
 	"github.com/timmaaaz/ichor/api/domain/http/inventory/lottrackingsapi"
 	"github.com/timmaaaz/ichor/api/domain/http/inventory/picktaskapi"
+	"github.com/timmaaaz/ichor/api/domain/http/inventory/pickwaveapi"
 	"github.com/timmaaaz/ichor/api/domain/http/inventory/putawaytaskapi"
+	"github.com/timmaaaz/ichor/api/domain/http/inventory/replenishmenttaskapi"
 	"github.com/timmaaaz/ichor/api/domain/http/inventory/scanapi"
 	"github.com/timmaaaz/ichor/api/domain/http/inventory/serialnumberapi"
 	"github.com/timmaaaz/ichor/api/domain/http/inventory/supervisorkpiapi"
@@ -61,11 +68,14 @@ import (
 	"github.com/timmaaaz/ichor/api/domain/http/inventory/zoneapi"
 	"github.com/timmaaaz/ichor/api/domain/http/labels/labelapi"
 	"github.com/timmaaaz/ichor/api/domain/http/paperwork/paperworkapi"
+	"github.com/timmaaaz/ichor/api/domain/http/procurement/landedcostapi"
 	"github.com/timmaaaz/ichor/api/domain/http/procurement/purchaseorderapi"
 	"github.com/timmaaaz/ichor/api/domain/http/procurement/purchaseorderlineitemapi"
 	"github.com/timmaaaz/ichor/api/domain/http/procurement/purchaseorderlineitemstatusapi"
 	"github.com/timmaaaz/ichor/api/domain/http/procurement/purchaseorderstatusapi"
+	"github.com/timmaaaz/ichor/api/domain/http/procurement/purchasesuggestionapi"
 	"github.com/timmaaaz/ichor/api/domain/http/procurement/supplierapi"
+	"github.com/timmaaaz/ichor/api/domain/http/procurement/supplierinvoiceapi"
 	"github.com/timmaaaz/ichor/api/domain/http/procurement/supplierproductapi"
 	"github.com/timmaaaz/ichor/api/domain/http/products/brandapi"
 	"github.com/timmaaaz/ichor/api/domain/http/products/costhistoryapi"
@@ -78,6 +88,7 @@ import (
 	"github.com/timmaaaz/ichor/api/domain/http/sales/orderfulfillmentstatusapi"
 	"github.com/timmaaaz/ichor/api/domain/http/sales/orderlineitemsapi"
 	"github.com/timmaaaz/ichor/api/domain/http/sales/ordersapi"
+	"github.com/timmaaaz/ichor/api/domain/http/sales/shipmentapi"
 	"github.com/timmaaaz/ichor/api/domain/http/scenarios/scenarioapi"
 	"github.com/timmaaaz/ichor/api/domain/http/workflow/actionapi"
 	"github.com/timmaaaz/ichor/api/domain/http/workflow/alertapi"
@@ -196,6 +207,8 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/core/currencybus"
 	"github.com/timmaaaz/ichor/business/domain/core/currencybus/stores/currencycache"
 	"github.com/timmaaaz/ichor/business/domain/core/currencybus/stores/currencydb"
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus"
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus/stores/datascopedb"
 	"github.com/timmaaaz/ichor/business/domain/core/pagebus"
 	"github.com/timmaaaz/ichor/business/domain/core/pagebus/stores/pagedb"
 	"github.com/timmaaaz/ichor/business/domain/core/paymenttermbus"
@@ -215,10 +228,14 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/core/userrolebus/stores/userrolecache"
 	"github.com/timmaaaz/ichor/business/domain/core/userrolebus/stores/userroledb"
 	"github.com/timmaaaz/ichor/business/domain/introspectionbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus/stores/countplandb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountitembus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountitembus/stores/cyclecountitemdb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountsessionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountsessionbus/stores/cyclecountsessiondb"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus/stores/demandforecastdb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inspectionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inspectionbus/stores/inspectiondb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryadjustmentbus"
@@ -227,6 +244,8 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus/stores/inventoryitemdb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus/stores/inventorylocationdb"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryreservationbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryreservationbus/stores/inventoryreservationdb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus/stores/inventorytransactiondb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/lotlocationbus"
@@ -235,8 +254,12 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/inventory/lottrackingsbus/stores/lottrackingsdb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/picktaskbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/picktaskbus/stores/picktaskdb"
+	"github.com/timmaaaz/ichor/business/domain/inventory/pickwavebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/pickwavebus/stores/pickwavedb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/putawaytaskbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/putawaytaskbus/stores/putawaytaskdb"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus/stores/replenishmenttaskdb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/serialnumberbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/serialnumberbus/stores/serialnumberdb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/transferorderbus"
@@ -248,6 +271,8 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/labels/labelbus"
 	"github.com/timmaaaz/ichor/business/domain/labels/labelbus/stores/labeldb"
 	"github.com/timmaaaz/ichor/business/domain/labels/labelbus/tcpprint"
+	"github.com/timmaaaz/ichor/business/domain/procurement/landedcostbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/landedcostbus/stores/landedcostdb"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus/stores/purchaseorderdb"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitembus"
@@ -256,8 +281,12 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitemstatusbus/stores/purchaseorderlineitemstatusdb"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderstatusbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderstatusbus/stores/purchaseorderstatusdb"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus/stores/purchasesuggestiondb"
 	"github.com/timmaaaz/ichor/business/domain/procurement/supplierbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/supplierbus/stores/supplierdb"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus/stores/supplierinvoicedb"
 	"github.com/timmaaaz/ichor/business/domain/procurement/supplierproductbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/supplierproductbus/stores/supplierproductdb"
 	"github.com/timmaaaz/ichor/business/domain/products/brandbus"
@@ -282,6 +311,10 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/sales/orderlineitemsbus/stores/orderlineitemsdb"
 	"github.com/timmaaaz/ichor/business/domain/sales/ordersbus"
 	"github.com/timmaaaz/ichor/business/domain/sales/ordersbus/stores/ordersdb"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus/flatrate"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus/httpcarrier"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus/stores/shipmentdb"
 	"github.com/timmaaaz/ichor/business/domain/scenarios/scenariobus"
 	"github.com/timmaaaz/ichor/business/domain/scenarios/scenariobus/stores/scenariodb"
 
@@ -357,6 +390,7 @@ import (
 	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/approval"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/communication"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/data"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/inventory"
 
 	"github.com/timmaaaz/ichor/business/sdk/workflowdomains"
 
@@ -364,6 +398,7 @@ import (
 	"github.com/timmaaaz/ichor/foundation/rabbitmq"
 	"github.com/timmaaaz/ichor/foundation/web"
 	foundationws "github.com/timmaaaz/ichor/foundation/websocket"
+	"github.com/timmaaaz/ichor/foundation/websocket/pgbackplane"
 )
 
 // Routes constructs the add value which provides the implementation of
@@ -468,6 +503,9 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 	supplierProductBus := supplierproductbus.NewBusiness(cfg.Log, delegate, supplierproductdb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter)
 	purchaseOrderBus := purchaseorderbus.NewBusiness(cfg.Log, delegate, purchaseorderdb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter)
 	purchaseOrderLineItemBus := purchaseorderlineitembus.NewBusiness(cfg.Log, delegate, purchaseorderlineitemdb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter)
+	purchaseSuggestionBus := purchasesuggestionbus.NewBusiness(cfg.Log, delegate, purchasesuggestiondb.NewStore(cfg.Log, cfg.DB), purchaseOrderBus, purchaseOrderLineItemBus).WithOutbox(outboxWriter)
+	supplierInvoiceBus := supplierinvoicebus.NewBusiness(cfg.Log, delegate, supplierinvoicedb.NewStore(cfg.Log, cfg.DB), purchaseOrderBus).WithOutbox(outboxWriter)
+	landedCostBus := landedcostbus.NewBusiness(cfg.Log, delegate, landedcostdb.NewStore(cfg.Log, cfg.DB), purchaseOrderBus, productCostBus, costHistoryBus).WithOutbox(outboxWriter)
 
 	metricsBus := metricsbus.NewBusiness(cfg.Log, delegate, metricsdb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter)
 	inspectionBus := inspectionbus.NewBusiness(cfg.Log, delegate, inspectiondb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter)
@@ -482,18 +520,24 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 	currencyBus := currencybus.NewBusiness(cfg.Log, delegate, currencycache.NewStore(cfg.Log, currencydb.NewStore(cfg.Log, cfg.DB), 60*time.Minute)).WithOutbox(outboxWriter)
 	rolePageBus := rolepagebus.NewBusiness(cfg.Log, delegate, rolepagedb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter)
 	userRoleBus := userrolebus.NewBusiness(cfg.Log, delegate, userrolecache.NewStore(cfg.Log, userroledb.NewStore(cfg.Log, cfg.DB), 60*time.Minute)).WithOutbox(outboxWriter)
+	dataScopeBus := datascopebus.NewBusiness(cfg.Log, delegate, datascopedb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter)
 	tableAccessBus := tableaccessbus.NewBusiness(cfg.Log, delegate, tableaccesscache.NewStore(cfg.Log, tableaccessdb.NewStore(cfg.Log, cfg.DB), 60*time.Minute)).WithOutbox(outboxWriter)
 
-	permissionsBus := permissionsbus.NewBusiness(cfg.Log, delegate, permissionscache.NewStore(cfg.Log, permissionsdb.NewStore(cfg.Log, cfg.DB), 60*time.Minute), userRoleBus, tableAccessBus, roleBus)
+	permissionsBus := permissionsbus.NewBusiness(cfg.Log, delegate, permissionscache.NewStore(cfg.Log, permissionsdb.NewStore(cfg.Log, cfg.DB), 60*time.Minute), userRoleBus, tableAccessBus, roleBus).WithDataScopes(dataScopeBus)
 
 	introspectionBus := introspectionbus.NewBusiness(cfg.Log, cfg.DB)
 
 	inventoryTransactionBus := inventorytransactionbus.NewBusiness(cfg.Log, delegate, inventorytransactiondb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter)
+	inventoryReservationBus := inventoryreservationbus.NewBusiness(cfg.Log, delegate, inventoryreservationdb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter)
 	inventoryAdjustmentBus := inventoryadjustmentbus.NewBusiness(cfg.Log, delegate, inventoryadjustmentdb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter)
 	putAwayTaskBus := putawaytaskbus.NewBusiness(cfg.Log, delegate, putawaytaskdb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter)
 	pickTaskBus := picktaskbus.NewBusiness(cfg.Log, delegate, picktaskdb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter)
 	cycleCountSessionBus := cyclecountsessionbus.NewBusiness(cfg.Log, delegate, cyclecountsessiondb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter)
 	cycleCountItemBus := cyclecountitembus.NewBusiness(cfg.Log, delegate, cyclecountitemdb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter)
+	countPlanBus := countplanbus.NewBusiness(cfg.Log, delegate, countplandb.NewStore(cfg.Log, cfg.DB), cycleCountSessionBus, cycleCountItemBus).WithOutbox(outboxWriter)
+	pickWaveBus := pickwavebus.NewBusiness(cfg.Log, delegate, pickwavedb.NewStore(cfg.Log, cfg.DB), pickTaskBus).WithOutbox(outboxWriter)
+	replenishmentTaskBus := replenishmenttaskbus.NewBusiness(cfg.Log, delegate, replenishmenttaskdb.NewStore(cfg.Log, cfg.DB), inventoryItemBus, inventoryTransactionBus, lotLocationBus).WithOutbox(outboxWriter)
+	demandForecastBus := demandforecastbus.NewBusiness(cfg.Log, delegate, demandforecastdb.NewStore(cfg.Log, cfg.DB), inventoryItemBus).WithOutbox(outboxWriter)
 
 	transferOrderBus := transferorderbus.NewBusiness(cfg.Log, delegate, transferorderdb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter)
 
@@ -502,7 +546,38 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 	ordersBus := ordersbus.NewBusiness(cfg.Log, delegate, ordersdb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter)
 	orderLineItemsBus := orderlineitemsbus.NewBusiness(cfg.Log, delegate, orderlineitemsdb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter)
 
-	pickingApp := pickingapp.NewApp(cfg.Log, cfg.DB, ordersBus, orderLineItemsBus, inventoryItemBus, inventoryTransactionBus, orderFulfillmentStatusBus, lineItemFulfillmentStatusBus)
+	// Shipping labels are bought through the offline flat-rate carrier unless
+	// an HTTP carrier service is configured (ICHOR_CARRIER_PROVIDER=http).
+	var shippingCarrier shipmentbus.Carrier = flatrate.New(nil)
+	if cfg.CarrierProvider == "http" {
+		if c := httpcarrier.New(httpcarrier.Config{
+			Name:    cfg.CarrierName,
+			BaseURL: cfg.CarrierBaseURL,
+			APIKey:  cfg.CarrierAPIKey,
+			Timeout: cfg.CarrierTimeout,
+		}); c != nil {
+			shippingCarrier = c
+		} else {
+			cfg.Log.Info(context.Background(), "carrier: http provider has no base URL, using flatrate")
+		}
+	}
+	shipmentBus := shipmentbus.NewBusiness(cfg.Log, delegate, shipmentdb.NewStore(cfg.Log, cfg.DB)).WithOutbox(outboxWriter).WithCarrier(shippingCarrier)
+
+	// Label subsystem (Phase 0b) — catalog + transaction-label printing
+	// via ZPL over TCP to a Zebra-compatible printer. PrinterHostPort
+	// comes from ICHOR_PRINTER_HOSTPORT via mux.Config (Phase 0a). Tests
+	// substitute a recording printer through cfg.LabelPrinter to assert
+	// ZPL dispatch.
+	labelStorer := labeldb.NewStore(cfg.Log, cfg.DB)
+	var labelPrinter labelbus.Printer
+	if cfg.LabelPrinter != nil {
+		labelPrinter = cfg.LabelPrinter
+	} else {
+		labelPrinter = tcpprint.New(cfg.PrinterHostPort, 5*time.Second)
+	}
+	labelBus := labelbus.NewBusiness(cfg.Log, delegate, labelStorer, labelPrinter).WithOutbox(outboxWriter)
+
+	pickingApp := pickingapp.NewApp(cfg.Log, cfg.DB, ordersBus, orderLineItemsBus, inventoryItemBus, inventoryTransactionBus, orderFulfillmentStatusBus, lineItemFulfillmentStatusBus, replenishmentTaskBus)
 
 	configStore := tablebuilder.NewConfigStore(cfg.Log, cfg.DB)
 	tableStore := tablebuilder.NewStore(cfg.Log, cfg.DB)
@@ -544,16 +619,24 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 			InventoryItem:          inventoryItemBus,
 			InventoryTransaction:   inventoryTransactionBus,
 			InventoryAdjustment:    inventoryAdjustmentBus,
+			InventoryReservation:   inventoryReservationBus,
 			TransferOrder:          transferOrderBus,
 			PutAwayTask:            putAwayTaskBus,
 			SupplierProduct:        supplierProductBus,
 			PurchaseOrder:          purchaseOrderBus,
 			PurchaseOrderLineItem:  purchaseOrderLineItemBus,
+			PurchaseSuggestion:     purchaseSuggestionBus,
+			SupplierInvoice:        supplierInvoiceBus,
 			Workflow:               workflowBus,
 			Orders:                 ordersBus,
 			OrderLineItems:         orderLineItemsBus,
 			PickTask:               pickTaskBus,
 			OrderFulfillmentStatus: orderFulfillmentStatusBus,
+			Shipment:               shipmentBus,
+			Label:                  labelBus,
+			CountPlan:              countPlanBus,
+			ReplenishmentTask:      replenishmentTaskBus,
+			DemandForecast:         demandForecastBus,
 		},
 	}
 	workflowactions.RegisterGranularInventoryActions(actionRegistry, inventoryAndProcurementConfig)
@@ -561,6 +644,10 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 	// Register procurement actions (create_purchase_order).
 	workflowactions.RegisterProcurementActions(actionRegistry, inventoryAndProcurementConfig)
 
+	// Upgrade create_shipping_label with the carrier-backed shipment bus and the
+	// label printer (the core registration uses nil buses).
+	workflowactions.RegisterShippingActions(actionRegistry, inventoryAndProcurementConfig)
+
 	// Upgrade seek_approval handler with real approval and alert buses.
 	// The core registration uses nil buses (graceful degradation); replace it here
 	// so that manual execution via POST /v1/workflow/actions/seek_approval/execute
@@ -634,7 +721,9 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 			// DelegateHandler + its workflowdomains.Registrations() RegisterDomain loop)
 			// is removed in this same commit, so the delegate path and the relay path are
 			// never live at once — no double-dispatch window (hard_rule).
-			relay := temporalpkg.NewRelay(cfg.Log, cfg.DB, workflowTrigger, temporalpkg.RelayConfig{})
+			relay := temporalpkg.NewRelay(cfg.Log, cfg.DB, workflowTrigger, temporalpkg.RelayConfig{
+				PublishedRowWindow: cfg.OutboxRetention,
+			})
 			go func() {
 				if err := relay.Run(context.Background()); err != nil && err != context.Canceled {
 					cfg.Log.Error(context.Background(), "cascade relay exited", "error", err)
@@ -652,7 +741,37 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 				}
 			}()
 
-			cfg.Log.Info(context.Background(), "temporal workflow infrastructure initialized (cascade relay + execution reaper started)")
+			// Reservation reaper: gives expired inventory reservations back to
+			// available stock. Server-only, like the execution reaper; the
+			// outbox event it writes is drained by the relay above.
+			// Reservations made by scheduled rules have no creator; their
+			// release is attributed to the configured system user.
+			var reaperCfg inventory.ReservationReaperConfig
+			if cfg.SystemUserID != "" {
+				systemUserID, err := uuid.Parse(cfg.SystemUserID)
+				if err != nil {
+					cfg.Log.Error(context.Background(), "reservation reaper: invalid system user id", "system_user_id", cfg.SystemUserID, "error", err)
+				}
+				reaperCfg.SystemUserID = systemUserID
+			}
+			reservationReaper := inventory.NewReservationReaper(cfg.Log, cfg.DB, inventoryReservationBus, inventoryItemBus, inventoryTransactionBus, reaperCfg)
+			go func() {
+				if err := reservationReaper.Run(context.Background()); err != nil && err != context.Canceled {
+					cfg.Log.Error(context.Background(), "reservation reaper exited", "error", err)
+				}
+			}()
+
+			// Rule scheduler: fires "scheduled" trigger-type rules from
+			// workflow.rule_schedules. Server-only, like the relay; dispatch goes
+			// through the same trigger so executions get the usual dedup + record.
+			scheduler := temporalpkg.NewScheduler(cfg.Log, workflowStore, workflowTrigger, temporalpkg.SchedulerConfig{})
+			go func() {
+				if err := scheduler.Run(context.Background()); err != nil && err != context.Canceled {
+					cfg.Log.Error(context.Background(), "rule scheduler exited", "error", err)
+				}
+			}()
+
+			cfg.Log.Info(context.Background(), "temporal workflow infrastructure initialized (cascade relay + execution reaper + reservation reaper + rule scheduler started)")
 		}
 	} else {
 		cfg.Log.Info(context.Background(),
@@ -835,19 +954,6 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 		PermissionsBus: permissionsBus,
 	})
 
-	// Label subsystem (Phase 0b) — catalog + transaction-label printing
-	// via ZPL over TCP to a Zebra-compatible printer. PrinterHostPort
-	// comes from ICHOR_PRINTER_HOSTPORT via mux.Config (Phase 0a). Tests
-	// substitute a recording printer through cfg.LabelPrinter to assert
-	// ZPL dispatch.
-	labelStorer := labeldb.NewStore(cfg.Log, cfg.DB)
-	var labelPrinter labelbus.Printer
-	if cfg.LabelPrinter != nil {
-		labelPrinter = cfg.LabelPrinter
-	} else {
-		labelPrinter = tcpprint.New(cfg.PrinterHostPort, 5*time.Second)
-	}
-	labelBus := labelbus.NewBusiness(cfg.Log, delegate, labelStorer, labelPrinter).WithOutbox(outboxWriter)
 	labelapi.Routes(app, labelapi.Config{
 		Log:            cfg.Log,
 		LabelBus:       labelBus,
@@ -872,6 +978,7 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 		WarehousesBus:         warehouseBus,
 		InventoryLocationsBus: inventoryLocationBus,
 		ProductsBus:           productBus,
+		PickWavesBus:          pickWaveBus,
 		AuthClient:            cfg.AuthClient,
 		PermissionsBus:        permissionsBus,
 	})
@@ -985,6 +1092,13 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 		PermissionsBus: permissionsBus,
 	})
 
+	datascopeapi.Routes(app, datascopeapi.Config{
+		Log:            cfg.Log,
+		DataScopeBus:   dataScopeBus,
+		AuthClient:     cfg.AuthClient,
+		PermissionsBus: permissionsBus,
+	})
+
 	productapi.Routes(app, productapi.Config{
 		ProductBus:     productBus,
 		AuthClient:     cfg.AuthClient,
@@ -1062,6 +1176,27 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 		PermissionsBus:           permissionsBus,
 	})
 
+	purchasesuggestionapi.Routes(app, purchasesuggestionapi.Config{
+		Log:                   cfg.Log,
+		PurchaseSuggestionBus: purchaseSuggestionBus,
+		AuthClient:            cfg.AuthClient,
+		PermissionsBus:        permissionsBus,
+	})
+
+	supplierinvoiceapi.Routes(app, supplierinvoiceapi.Config{
+		Log:                cfg.Log,
+		SupplierInvoiceBus: supplierInvoiceBus,
+		AuthClient:         cfg.AuthClient,
+		PermissionsBus:     permissionsBus,
+	})
+
+	landedcostapi.Routes(app, landedcostapi.Config{
+		Log:            cfg.Log,
+		LandedCostBus:  landedCostBus,
+		AuthClient:     cfg.AuthClient,
+		PermissionsBus: permissionsBus,
+	})
+
 	metricsapi.Routes(app, metricsapi.Config{
 		Log:            cfg.Log,
 		AuthClient:     cfg.AuthClient,
@@ -1157,6 +1292,7 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 		PutAwayTaskBus:    putAwayTaskBus,
 		InvTransactionBus: inventoryTransactionBus,
 		InvItemBus:        inventoryItemBus,
+		InvLocationBus:    inventoryLocationBus,
 		DB:                cfg.DB,
 		AuthClient:        cfg.AuthClient,
 		PermissionsBus:    permissionsBus,
@@ -1177,18 +1313,50 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 		CycleCountSessionBus: cycleCountSessionBus,
 		CycleCountItemBus:    cycleCountItemBus,
 		InvAdjustmentBus:     inventoryAdjustmentBus,
+		InvTransactionBus:    inventoryTransactionBus,
+		InvItemBus:           inventoryItemBus,
 		DB:                   cfg.DB,
 		AuthClient:           cfg.AuthClient,
 		PermissionsBus:       permissionsBus,
 	})
 
-	cyclecountitemapi.Routes(app, cyclecountitemapi.Config{
+	countplanapi.Routes(app, countplanapi.Config{
+		Log:            cfg.Log,
+		CountPlanBus:   countPlanBus,
+		AuthClient:     cfg.AuthClient,
+		PermissionsBus: permissionsBus,
+	})
+
+	pickwaveapi.Routes(app, pickwaveapi.Config{
+		Log:            cfg.Log,
+		PickWaveBus:    pickWaveBus,
+		AuthClient:     cfg.AuthClient,
+		PermissionsBus: permissionsBus,
+	})
+
+	replenishmenttaskapi.Routes(app, replenishmenttaskapi.Config{
+		Log:                  cfg.Log,
+		ReplenishmentTaskBus: replenishmentTaskBus,
+		AuthClient:           cfg.AuthClient,
+		PermissionsBus:       permissionsBus,
+	})
+
+	demandforecastapi.Routes(app, demandforecastapi.Config{
 		Log:               cfg.Log,
-		CycleCountItemBus: cycleCountItemBus,
+		DemandForecastBus: demandForecastBus,
 		AuthClient:        cfg.AuthClient,
 		PermissionsBus:    permissionsBus,
 	})
 
+	cyclecountitemapi.Routes(app, cyclecountitemapi.Config{
+		Log:                  cfg.Log,
+		CycleCountItemBus:    cycleCountItemBus,
+		CycleCountSessionBus: cycleCountSessionBus,
+		DB:                   cfg.DB,
+		AuthClient:           cfg.AuthClient,
+		PermissionsBus:       permissionsBus,
+	})
+
 	supervisorkpiapi.Routes(app, supervisorkpiapi.Config{
 		Log:                    cfg.Log,
 		ApprovalRequestBus:     approvalRequestBus,
@@ -1241,6 +1409,20 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 		PermissionsBus:    permissionsBus,
 	})
 
+	shipmentapi.Routes(app, shipmentapi.Config{
+		Log:                       cfg.Log,
+		DB:                        cfg.DB,
+		ShipmentBus:               shipmentBus,
+		OrdersBus:                 ordersBus,
+		OrderLineItemsBus:         orderLineItemsBus,
+		InventoryItemBus:          inventoryItemBus,
+		InventoryTransactionBus:   inventoryTransactionBus,
+		OrderFulfillmentStatusBus: orderFulfillmentStatusBus,
+		LabelBus:                  labelBus,
+		AuthClient:                cfg.AuthClient,
+		PermissionsBus:            permissionsBus,
+	})
+
 	// data
 	dataapi.Routes(app, dataapi.Config{
 		Log:            cfg.Log,
@@ -1424,6 +1606,24 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 	var asyncCompleter *temporalpkg.AsyncCompleter
 	if cfg.TemporalClient != nil {
 		asyncCompleter = temporalpkg.NewAsyncCompleter(cfg.TemporalClient)
+
+		// Approval escalator: reassigns pending seek_approval requests past their
+		// escalation window and auto-resolves them at timeout, resuming the
+		// workflow through the "timed_out" port. Server-only, like the relay.
+		escalator := approval.NewEscalator(approval.EscalatorConfig{
+			Log:           cfg.Log,
+			ApprovalBus:   approvalRequestBus,
+			AlertBus:      alertBus,
+			WorkflowQueue: workflowQueue,
+			Directory:     approval.NewBusDirectory(reportsToBus, roleBus, userRoleBus),
+			Audit:         approval.NewDBAuditRecorder(cfg.Log, cfg.DB),
+			Completer:     asyncCompleter,
+		})
+		go func() {
+			if err := escalator.Run(context.Background()); err != nil && err != context.Canceled {
+				cfg.Log.Error(context.Background(), "approval escalator exited", "error", err)
+			}
+		}()
 	}
 
 	workflowapprovalapi.Routes(app, workflowapprovalapi.Config{
@@ -1490,8 +1690,9 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 	// Initialize WebSocket Infrastructure for Real-time Alerts
 	// =========================================================================
 
-	// Create foundation Hub (generic, no business logic)
-	wsHub := foundationws.NewHub(cfg.Log)
+	// Create foundation Hub (generic, no business logic). Broadcasts and
+	// presence are relayed between replicas over Postgres LISTEN/NOTIFY.
+	wsHub := foundationws.NewHub(cfg.Log, foundationws.WithBackplane(pgbackplane.New(cfg.Log, cfg.DB, "ichor_websocket")))
 
 	// Create AlertHub (app layer with user/role semantics)
 	alertHub := alertws.NewAlertHub(wsHub, userRoleBus, cfg.Log)
@@ -1552,6 +1753,7 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 			inspectionBus,
 			transferOrderBus,
 			ordersBus,
+			replenishmentTaskBus,
 		),
 		AuthClient: cfg.AuthClient,
 	})
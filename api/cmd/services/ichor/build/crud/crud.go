@@ -11,6 +11,7 @@ import (
 	"github.com/timmaaaz/ichor/api/domain/http/assets/userassetapi"
 	"github.com/timmaaaz/ichor/api/domain/http/assets/validassetapi"
 	"github.com/timmaaaz/ichor/api/domain/http/core/contactinfosapi"
+	"github.com/timmaaaz/ichor/api/domain/http/core/datascopeapi"
 	"github.com/timmaaaz/ichor/api/domain/http/core/roleapi"
 	"github.com/timmaaaz/ichor/api/domain/http/core/tableaccessapi"
 	"github.com/timmaaaz/ichor/api/domain/http/core/userroleapi"
@@ -45,6 +46,7 @@ import (
 	"github.com/timmaaaz/ichor/api/domain/http/sales/orderfulfillmentstatusapi"
 	"github.com/timmaaaz/ichor/api/domain/http/sales/orderlineitemsapi"
 	"github.com/timmaaaz/ichor/api/domain/http/sales/ordersapi"
+	"github.com/timmaaaz/ichor/api/domain/http/sales/shipmentapi"
 
 	"github.com/timmaaaz/ichor/api/domain/http/assets/assetconditionapi"
 	"github.com/timmaaaz/ichor/api/domain/http/assets/assettypeapi"
@@ -69,6 +71,8 @@ import (
 	validassetdb "github.com/timmaaaz/ichor/business/domain/assets/validassetbus/stores/assetdb"
 	"github.com/timmaaaz/ichor/business/domain/core/contactinfosbus"
 	"github.com/timmaaaz/ichor/business/domain/core/contactinfosbus/stores/contactinfosdb"
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus"
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus/stores/datascopedb"
 	"github.com/timmaaaz/ichor/business/domain/core/permissionsbus"
 	"github.com/timmaaaz/ichor/business/domain/core/permissionsbus/stores/permissionscache"
 	"github.com/timmaaaz/ichor/business/domain/core/permissionsbus/stores/permissionsdb"
@@ -134,6 +138,8 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/sales/orderlineitemsbus/stores/orderlineitemsdb"
 	"github.com/timmaaaz/ichor/business/domain/sales/ordersbus"
 	"github.com/timmaaaz/ichor/business/domain/sales/ordersbus/stores/ordersdb"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus/stores/shipmentdb"
 
 	"github.com/timmaaaz/ichor/business/domain/assets/assettagbus"
 	"github.com/timmaaaz/ichor/business/domain/assets/assettagbus/store/assettagdb"
@@ -266,9 +272,10 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 
 	roleBus := rolebus.NewBusiness(cfg.Log, delegate, rolecache.NewStore(cfg.Log, roledb.NewStore(cfg.Log, cfg.DB), 60*time.Minute))
 	userRoleBus := userrolebus.NewBusiness(cfg.Log, delegate, userrolecache.NewStore(cfg.Log, userroledb.NewStore(cfg.Log, cfg.DB), 60*time.Minute))
+	dataScopeBus := datascopebus.NewBusiness(cfg.Log, delegate, datascopedb.NewStore(cfg.Log, cfg.DB))
 	tableAccessBus := tableaccessbus.NewBusiness(cfg.Log, delegate, tableaccesscache.NewStore(cfg.Log, tableaccessdb.NewStore(cfg.Log, cfg.DB), 60*time.Minute))
 
-	permissionsBus := permissionsbus.NewBusiness(cfg.Log, delegate, permissionscache.NewStore(cfg.Log, permissionsdb.NewStore(cfg.Log, cfg.DB), 60*time.Minute), userRoleBus, tableAccessBus, roleBus)
+	permissionsBus := permissionsbus.NewBusiness(cfg.Log, delegate, permissionscache.NewStore(cfg.Log, permissionsdb.NewStore(cfg.Log, cfg.DB), 60*time.Minute), userRoleBus, tableAccessBus, roleBus).WithDataScopes(dataScopeBus)
 
 	inventoryTransactionBus := inventorytransactionbus.NewBusiness(cfg.Log, delegate, inventorytransactiondb.NewStore(cfg.Log, cfg.DB))
 	inventoryAdjustmentBus := inventoryadjustmentbus.NewBusiness(cfg.Log, delegate, inventoryadjustmentdb.NewStore(cfg.Log, cfg.DB))
@@ -278,6 +285,7 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 	lineItemFulfillmentStatusBus := lineitemfulfillmentstatusbus.NewBusiness(cfg.Log, delegate, lineitemfulfillmentstatusdb.NewStore(cfg.Log, cfg.DB))
 	ordersBus := ordersbus.NewBusiness(cfg.Log, delegate, ordersdb.NewStore(cfg.Log, cfg.DB))
 	orderLineItemsBus := orderlineitemsbus.NewBusiness(cfg.Log, delegate, orderlineitemsdb.NewStore(cfg.Log, cfg.DB))
+	shipmentBus := shipmentbus.NewBusiness(cfg.Log, delegate, shipmentdb.NewStore(cfg.Log, cfg.DB))
 
 	configStore := tablebuilder.NewConfigStore(cfg.Log, cfg.DB)
 	tableStore := tablebuilder.NewStore(cfg.Log, cfg.DB)
@@ -457,6 +465,13 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 		AuthClient:     cfg.AuthClient,
 		PermissionsBus: permissionsBus,
 	})
+
+	datascopeapi.Routes(app, datascopeapi.Config{
+		Log:            cfg.Log,
+		DataScopeBus:   dataScopeBus,
+		AuthClient:     cfg.AuthClient,
+		PermissionsBus: permissionsBus,
+	})
 	productapi.Routes(app, productapi.Config{
 		ProductBus: productBus,
 		AuthClient: cfg.AuthClient,
@@ -609,6 +624,19 @@ func (a add) Add(app *web.App, cfg mux.Config) {
 		PermissionsBus:    permissionsBus,
 	})
 
+	shipmentapi.Routes(app, shipmentapi.Config{
+		Log:                       cfg.Log,
+		DB:                        cfg.DB,
+		ShipmentBus:               shipmentBus,
+		OrdersBus:                 ordersBus,
+		OrderLineItemsBus:         orderLineItemsBus,
+		InventoryItemBus:          inventoryItemBus,
+		InventoryTransactionBus:   inventoryTransactionBus,
+		OrderFulfillmentStatusBus: orderFulfillmentStatusBus,
+		AuthClient:                cfg.AuthClient,
+		PermissionsBus:            permissionsBus,
+	})
+
 	settingsapi.Routes(app, settingsapi.Config{
 		Log:            cfg.Log,
 		SettingsBus:    settingsBus,
@@ -133,6 +133,10 @@ func run(ctx context.Context, log *logger.Logger) error {
 		}
 		Temporal struct {
 			HostPort string `conf:"default:temporal-service.ichor-system.svc.cluster.local:7233"`
+			// OutboxRetention keeps published cascade_outbox rows as event history
+			// the workflow simulator can replay. Zero deletes rows on publish.
+			// Env: ICHOR_TEMPORAL_OUTBOXRETENTION
+			OutboxRetention time.Duration `conf:"default:720h"`
 		}
 		RateLimit struct {
 			// LoginInterval is the token refill period for the login endpoint.
@@ -172,6 +176,16 @@ func run(ctx context.Context, log *logger.Logger) error {
 		Printer struct {
 			HostPort string `conf:"default:172.16.60.116:9100"`
 		}
+		Carrier struct {
+			// Provider selects the shipping-label carrier: "flatrate" (offline,
+			// no external calls) or "http" (JSON adapter at BaseURL, e.g. a
+			// local stub or an aggregator). Env: ICHOR_CARRIER_PROVIDER.
+			Provider string `conf:"default:flatrate"`
+			Name     string `conf:"default:http"`
+			BaseURL  string
+			APIKey   string        `conf:"mask"`
+			Timeout  time.Duration `conf:"default:10s"`
+		}
 		Resend struct {
 			APIKey string `conf:"mask"` // ICHOR_RESEND_APIKEY — mask prevents logging
 			From   string              // ICHOR_RESEND_FROM e.g. "Ichor ERP <noreply@yourco.com>"
@@ -183,6 +197,12 @@ func run(ctx context.Context, log *logger.Logger) error {
 			// the per-request scenarios_active lookup. Env: ICHOR_SCENARIOS_ENABLED.
 			Enabled bool `conf:"default:true"`
 		}
+		Inventory struct {
+			// SystemUserID attributes background inventory writes that have no
+			// user of their own, such as releasing an expired reservation made
+			// by a scheduled rule. Env: ICHOR_INVENTORY_SYSTEMUSERID.
+			SystemUserID string
+		}
 	}{
 		Version: conf.Version{
 			Build: build,
@@ -360,26 +380,33 @@ func run(ctx context.Context, log *logger.Logger) error {
 	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
 
 	cfgMux := mux.Config{
-		Build:          build,
-		Log:            log,
-		Auth:           oauthAuth,
-		AuthClient:     authClient,
-		DB:             db,
-		Tracer:         tracer,
-		RabbitClient:   rabbitClient,
-		TemporalClient: temporalClient,
-		LLMProvider:       cfg.LLM.Provider,
-		LLMAPIKey:         cfg.LLM.APIKey,
-		LLMModel:          cfg.LLM.Model,
-		LLMMaxTokens:      cfg.LLM.MaxTokens,
-		LLMBaseURL:        cfg.LLM.BaseURL,
-		LLMHost:           cfg.LLM.Host,
-		LLMThinkingEffort: cfg.LLM.ThinkingEffort,
+		Build:              build,
+		Log:                log,
+		Auth:               oauthAuth,
+		AuthClient:         authClient,
+		DB:                 db,
+		Tracer:             tracer,
+		RabbitClient:       rabbitClient,
+		TemporalClient:     temporalClient,
+		OutboxRetention:    cfg.Temporal.OutboxRetention,
+		LLMProvider:        cfg.LLM.Provider,
+		LLMAPIKey:          cfg.LLM.APIKey,
+		LLMModel:           cfg.LLM.Model,
+		LLMMaxTokens:       cfg.LLM.MaxTokens,
+		LLMBaseURL:         cfg.LLM.BaseURL,
+		LLMHost:            cfg.LLM.Host,
+		LLMThinkingEffort:  cfg.LLM.ThinkingEffort,
 		ResendAPIKey:       cfg.Resend.APIKey,
 		ResendFrom:         cfg.Resend.From,
 		PrinterHostPort:    cfg.Printer.HostPort,
+		CarrierProvider:    cfg.Carrier.Provider,
+		CarrierName:        cfg.Carrier.Name,
+		CarrierBaseURL:     cfg.Carrier.BaseURL,
+		CarrierAPIKey:      cfg.Carrier.APIKey,
+		CarrierTimeout:     cfg.Carrier.Timeout,
 		ScenariosEnabled:   cfg.Scenarios.Enabled,
 		CORSAllowedOrigins: cfg.Web.CORSAllowedOrigins,
+		SystemUserID:       cfg.Inventory.SystemUserID,
 	}
 
 	routes, userBus := buildRoutes(cfgMux)
@@ -0,0 +1,233 @@
+package datascopeapi_test
+
+import (
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/core/datascopeapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/inventorylocationapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/zoneapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+)
+
+func create200(sd DataScopeSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "role-scope",
+			URL:        "/v1/core/data-scopes",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input: &datascopeapp.NewDataScope{
+				RoleID:       sd.Roles[3].ID,
+				WarehouseIDs: []string{sd.Warehouses[2].ID},
+			},
+			GotResp: &datascopeapp.DataScope{},
+			ExpResp: &datascopeapp.DataScope{
+				RoleID:       sd.Roles[3].ID,
+				WarehouseIDs: []string{sd.Warehouses[2].ID},
+				ZoneIDs:      []string{},
+			},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*datascopeapp.DataScope)
+				if !exists {
+					return "error occurred"
+				}
+
+				expResp := exp.(*datascopeapp.DataScope)
+				expResp.ID = gotResp.ID
+				expResp.CreatedDate = gotResp.CreatedDate
+				expResp.UpdatedDate = gotResp.UpdatedDate
+
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func create400(sd DataScopeSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "role-and-user",
+			URL:        "/v1/core/data-scopes",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input: &datascopeapp.NewDataScope{
+				RoleID: sd.Roles[3].ID,
+				UserID: sd.Users[0].ID.String(),
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.InvalidArgument, "data scope must belong to exactly one of a role or a user"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "neither-role-nor-user",
+			URL:        "/v1/core/data-scopes",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input: &datascopeapp.NewDataScope{
+				WarehouseIDs: []string{sd.Warehouses[0].ID},
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.InvalidArgument, "data scope must belong to exactly one of a role or a user"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func create401(sd DataScopeSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        "/v1/core/data-scopes",
+			Token:      "&nbsp;",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "bad-sig",
+			URL:        "/v1/core/data-scopes",
+			Token:      sd.Admins[0].Token + "A",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "authentication failed : bindings results[[{[true] map[x:false]}]] ok[true]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "not-admin",
+			URL:        "/v1/core/data-scopes",
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			Input: &datascopeapp.NewDataScope{
+				RoleID: sd.Roles[3].ID,
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.Unauthenticated, "authorize: you are not authorized for that action, claims[[USER]] rule[rule_admin_only]: rego evaluation failed : bindings results[[{[true] map[x:false]}]] ok[true]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func create409(sd DataScopeSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "role-already-scoped",
+			URL:        "/v1/core/data-scopes",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusConflict,
+			Input: &datascopeapp.NewDataScope{
+				RoleID:       sd.Roles[2].ID,
+				WarehouseIDs: []string{sd.Warehouses[2].ID},
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.AlreadyExists, "create: namedexeccontext: role or user already has a data scope"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+// scopedCreate200 checks that a user may still create rows inside their data
+// scope.
+func scopedCreate200(sd DataScopeSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "zone-in-scope",
+			URL:        "/v1/inventory/zones",
+			Token:      sd.Scoped.Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input: &zoneapp.NewZone{
+				WarehouseID: sd.Warehouses[0].ID,
+				Name:        "Scoped Zone",
+			},
+			GotResp: &zoneapp.Zone{},
+			ExpResp: &zoneapp.Zone{
+				WarehouseID: sd.Warehouses[0].ID,
+				Name:        "Scoped Zone",
+			},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*zoneapp.Zone)
+				if !exists {
+					return "error occurred"
+				}
+
+				expResp := exp.(*zoneapp.Zone)
+				expResp.ZoneID = gotResp.ZoneID
+				expResp.ZoneCode = gotResp.ZoneCode
+				expResp.Stage = gotResp.Stage
+				expResp.CreatedDate = gotResp.CreatedDate
+				expResp.UpdatedDate = gotResp.UpdatedDate
+
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+// scopedCreate403 checks that a user cannot create rows in a warehouse
+// outside their data scope.
+func scopedCreate403(sd DataScopeSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "zone-out-of-scope",
+			URL:        "/v1/inventory/zones",
+			Token:      sd.Scoped.Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusForbidden,
+			Input: &zoneapp.NewZone{
+				WarehouseID: sd.Warehouses[2].ID,
+				Name:        "Out Of Scope Zone",
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.PermissionDenied, "create: checkdatascope: outside the caller's data scope"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "location-out-of-scope",
+			URL:        "/v1/inventory/inventory-locations",
+			Token:      sd.Scoped.Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusForbidden,
+			Input: &inventorylocationapp.NewInventoryLocation{
+				WarehouseID:        sd.Warehouses[2].ID,
+				ZoneID:             sd.Zones[1].ZoneID,
+				Aisle:              "A",
+				Rack:               "R",
+				Shelf:              "S",
+				Bin:                "B",
+				IsPickLocation:     "true",
+				IsReserveLocation:  "false",
+				MaxCapacity:        "100",
+				CurrentUtilization: "0",
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.PermissionDenied, "create: checkdatascope: outside the caller's data scope"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package datascopeapi_test
+
+import (
+	"testing"
+
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+)
+
+func Test_DataScope(t *testing.T) {
+	t.Parallel()
+
+	test := apitest.StartTest(t, "Test_DataScope")
+	sd, err := insertSeedData(test.DB, test.Auth)
+	if err != nil {
+		t.Fatalf("seeding error %s", err)
+	}
+
+	test.Run(t, query200(sd), "query-200")
+	test.Run(t, queryByID200(sd), "queryByID-200")
+	test.Run(t, queryByID404(sd), "queryByID-404")
+	test.Run(t, query401(sd), "query-401")
+	test.Run(t, scopedRead200(sd), "scoped-read-200")
+	test.Run(t, scopedRead404(sd), "scoped-read-404")
+
+	test.Run(t, create200(sd), "create-200")
+	test.Run(t, create400(sd), "create-400")
+	test.Run(t, create401(sd), "create-401")
+	test.Run(t, create409(sd), "create-409")
+	test.Run(t, scopedCreate200(sd), "scoped-create-200")
+	test.Run(t, scopedCreate403(sd), "scoped-create-403")
+
+	test.Run(t, update200(sd), "update-200")
+	test.Run(t, update401(sd), "update-401")
+	test.Run(t, update404(sd), "update-404")
+	test.Run(t, scopedUpdate403(sd), "scoped-update-403")
+	test.Run(t, scopedUpdate404(sd), "scoped-update-404")
+
+	test.Run(t, delete200(sd), "delete-200")
+	test.Run(t, delete401(sd), "delete-401")
+	test.Run(t, scopedDelete404(sd), "scoped-delete-404")
+}
@@ -0,0 +1,81 @@
+package datascopeapi_test
+
+import (
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+)
+
+func delete200(sd DataScopeSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "basic",
+			URL:        "/v1/core/data-scopes/" + sd.DataScopes[1].ID,
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodDelete,
+			StatusCode: http.StatusNoContent,
+		},
+	}
+}
+
+func delete401(sd DataScopeSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        "/v1/core/data-scopes/" + sd.DataScopes[0].ID,
+			Token:      "&nbsp;",
+			Method:     http.MethodDelete,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "not-admin",
+			URL:        "/v1/core/data-scopes/" + sd.DataScopes[0].ID,
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodDelete,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "authorize: you are not authorized for that action, claims[[USER]] rule[rule_admin_only]: rego evaluation failed : bindings results[[{[true] map[x:false]}]] ok[true]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+// scopedDelete404 checks that a user cannot delete a row outside their data
+// scope.
+func scopedDelete404(sd DataScopeSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "warehouse-out-of-scope",
+			URL:        "/v1/inventory/warehouses/" + sd.Warehouses[2].ID,
+			Token:      sd.Scoped.Token,
+			Method:     http.MethodDelete,
+			StatusCode: http.StatusNotFound,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.NotFound, "warehouse not found"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "zone-out-of-scope",
+			URL:        "/v1/inventory/zones/" + sd.Zones[1].ZoneID,
+			Token:      sd.Scoped.Token,
+			Method:     http.MethodDelete,
+			StatusCode: http.StatusNotFound,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.NotFound, "querybyID [zone]: namedexeccontext: zone not found"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
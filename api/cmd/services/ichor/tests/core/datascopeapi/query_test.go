@@ -0,0 +1,195 @@
+package datascopeapi_test
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/core/datascopeapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/warehouseapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/app/sdk/query"
+)
+
+func query200(sd DataScopeSeedData) []apitest.Table {
+	exp := make([]datascopeapp.DataScope, len(sd.DataScopes))
+	copy(exp, sd.DataScopes)
+	sort.Slice(exp, func(i, j int) bool {
+		return exp[i].ID < exp[j].ID
+	})
+
+	return []apitest.Table{
+		{
+			Name:       "basic",
+			URL:        "/v1/core/data-scopes?page=1&rows=10&orderBy=id,ASC",
+			Token:      sd.Admins[0].Token,
+			StatusCode: http.StatusOK,
+			Method:     http.MethodGet,
+			GotResp:    &query.Result[datascopeapp.DataScope]{},
+			ExpResp: &query.Result[datascopeapp.DataScope]{
+				Page:        1,
+				RowsPerPage: 10,
+				Total:       len(exp),
+				Items:       exp,
+			},
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "by-user",
+			URL:        fmt.Sprintf("/v1/core/data-scopes?page=1&rows=10&user_id=%s", sd.Scoped.ID),
+			Token:      sd.Admins[0].Token,
+			StatusCode: http.StatusOK,
+			Method:     http.MethodGet,
+			GotResp:    &query.Result[datascopeapp.DataScope]{},
+			ExpResp: &query.Result[datascopeapp.DataScope]{
+				Page:        1,
+				RowsPerPage: 10,
+				Total:       1,
+				Items:       []datascopeapp.DataScope{sd.DataScopes[0]},
+			},
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func queryByID200(sd DataScopeSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "basic",
+			URL:        "/v1/core/data-scopes/" + sd.DataScopes[1].ID,
+			Token:      sd.Admins[0].Token,
+			StatusCode: http.StatusOK,
+			Method:     http.MethodGet,
+			GotResp:    &datascopeapp.DataScope{},
+			ExpResp:    &sd.DataScopes[1],
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func queryByID404(sd DataScopeSeedData) []apitest.Table {
+	id := uuid.New()
+
+	return []apitest.Table{
+		{
+			Name:       "not-found",
+			URL:        "/v1/core/data-scopes/" + id.String(),
+			Token:      sd.Admins[0].Token,
+			StatusCode: http.StatusNotFound,
+			Method:     http.MethodGet,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.NotFound, "query: datascopeID[%s]: namedquerystruct: data scope not found", id),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func query401(sd DataScopeSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        "/v1/core/data-scopes?page=1&rows=10",
+			Token:      "&nbsp;",
+			StatusCode: http.StatusUnauthorized,
+			Method:     http.MethodGet,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "bad-sig",
+			URL:        "/v1/core/data-scopes?page=1&rows=10",
+			Token:      sd.Admins[0].Token + "A",
+			StatusCode: http.StatusUnauthorized,
+			Method:     http.MethodGet,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "authentication failed : bindings results[[{[true] map[x:false]}]] ok[true]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "no-read-permission",
+			URL:        "/v1/core/data-scopes?page=1&rows=10",
+			Token:      sd.Users[0].Token,
+			StatusCode: http.StatusForbidden,
+			Method:     http.MethodGet,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission READ for table: core.data_scopes"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+// scopedRead200 checks that a user's data scope limits the warehouses they
+// can read.
+func scopedRead200(sd DataScopeSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "scoped-user-sees-own-warehouse",
+			URL:        "/v1/inventory/warehouses?page=1&rows=10",
+			Token:      sd.Scoped.Token,
+			StatusCode: http.StatusOK,
+			Method:     http.MethodGet,
+			GotResp:    &query.Result[warehouseapp.Warehouse]{},
+			ExpResp:    &query.Result[warehouseapp.Warehouse]{},
+			CmpFunc: func(got, exp any) string {
+				gotResp := got.(*query.Result[warehouseapp.Warehouse])
+
+				if gotResp.Total != 1 || len(gotResp.Items) != 1 {
+					return fmt.Sprintf("got %d warehouses (total %d), want only the scoped one", len(gotResp.Items), gotResp.Total)
+				}
+				if gotResp.Items[0].ID != sd.Warehouses[0].ID {
+					return fmt.Sprintf("got warehouse %s, want %s", gotResp.Items[0].ID, sd.Warehouses[0].ID)
+				}
+				return ""
+			},
+		},
+	}
+}
+
+// scopedRead404 checks that a warehouse and zone outside a user's data scope
+// cannot be fetched by id.
+func scopedRead404(sd DataScopeSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "warehouse-out-of-scope",
+			URL:        "/v1/inventory/warehouses/" + sd.Warehouses[2].ID,
+			Token:      sd.Scoped.Token,
+			StatusCode: http.StatusNotFound,
+			Method:     http.MethodGet,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.NotFound, "query by id: db: warehouse not found"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "zone-out-of-scope",
+			URL:        "/v1/inventory/zones/" + sd.Zones[1].ZoneID,
+			Token:      sd.Scoped.Token,
+			StatusCode: http.StatusNotFound,
+			Method:     http.MethodGet,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.NotFound, "querybyID [zone]: namedexeccontext: zone not found"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
@@ -0,0 +1,202 @@
+package datascopeapi_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/domain/http/core/datascopeapi"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/core/datascopeapp"
+	"github.com/timmaaaz/ichor/app/domain/core/roleapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/warehouseapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/zoneapp"
+	"github.com/timmaaaz/ichor/app/sdk/auth"
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus"
+	"github.com/timmaaaz/ichor/business/domain/core/rolebus"
+	"github.com/timmaaaz/ichor/business/domain/core/tableaccessbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userrolebus"
+	"github.com/timmaaaz/ichor/business/domain/geography/citybus"
+	"github.com/timmaaaz/ichor/business/domain/geography/regionbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/streetbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/warehousebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/zonebus"
+	"github.com/timmaaaz/ichor/business/sdk/dbtest"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// DataScopeSeedData holds data scope specific test state.
+type DataScopeSeedData struct {
+	apitest.SeedData
+
+	// Scoped is a user whose own data scope limits them to Warehouses[0].
+	Scoped apitest.User
+
+	// DataScopes are the scopes seeded for Scoped and for Roles[2].
+	DataScopes []datascopeapp.DataScope
+}
+
+func insertSeedData(db *dbtest.Database, ath *auth.Auth) (DataScopeSeedData, error) {
+	ctx := context.Background()
+	busDomain := db.BusDomain
+
+	const warehouseCount = 3
+
+	usrs, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.User, busDomain.User)
+	if err != nil {
+		return DataScopeSeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+	tu1 := apitest.User{
+		User:  usrs[0],
+		Token: apitest.Token(busDomain.User, ath, usrs[0].Email.Address),
+	}
+
+	usrs, err = userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.Admin, busDomain.User)
+	if err != nil {
+		return DataScopeSeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+	tu2 := apitest.User{
+		User:  usrs[0],
+		Token: apitest.Token(busDomain.User, ath, usrs[0].Email.Address),
+	}
+
+	usrs, err = userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.User, busDomain.User)
+	if err != nil {
+		return DataScopeSeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+	scoped := apitest.User{
+		User:  usrs[0],
+		Token: apitest.Token(busDomain.User, ath, usrs[0].Email.Address),
+	}
+
+	// =========================================================================
+	// Warehouses to scope to
+	// =========================================================================
+
+	regions, err := busDomain.Region.Query(ctx, regionbus.QueryFilter{}, regionbus.DefaultOrderBy, page.MustParse("1", "5"))
+	if err != nil {
+		return DataScopeSeedData{}, fmt.Errorf("querying regions : %w", err)
+	}
+
+	regionIDs := make([]uuid.UUID, 0, len(regions))
+	for _, r := range regions {
+		regionIDs = append(regionIDs, r.ID)
+	}
+
+	ctys, err := citybus.TestSeedCities(ctx, warehouseCount, regionIDs, busDomain.City)
+	if err != nil {
+		return DataScopeSeedData{}, fmt.Errorf("seeding cities : %w", err)
+	}
+
+	ctyIDs := make([]uuid.UUID, 0, len(ctys))
+	for _, c := range ctys {
+		ctyIDs = append(ctyIDs, c.ID)
+	}
+
+	strs, err := streetbus.TestSeedStreets(ctx, warehouseCount, ctyIDs, busDomain.Street)
+	if err != nil {
+		return DataScopeSeedData{}, fmt.Errorf("seeding streets : %w", err)
+	}
+
+	strIDs := make([]uuid.UUID, 0, len(strs))
+	for _, s := range strs {
+		strIDs = append(strIDs, s.ID)
+	}
+
+	warehouses, err := warehousebus.TestSeedWarehouses(ctx, warehouseCount, tu2.ID, strIDs, busDomain.Warehouse)
+	if err != nil {
+		return DataScopeSeedData{}, fmt.Errorf("seeding warehouses : %w", err)
+	}
+
+	// Zones[0] sits inside Scoped's warehouse, Zones[1] outside it.
+	var zones []zonebus.Zone
+	for _, w := range []warehousebus.Warehouse{warehouses[0], warehouses[2]} {
+		zs, err := zonebus.TestSeedZone(ctx, 1, []uuid.UUID{w.ID}, busDomain.Zones)
+		if err != nil {
+			return DataScopeSeedData{}, fmt.Errorf("seeding zones : %w", err)
+		}
+		zones = append(zones, zs...)
+	}
+
+	// =========================================================================
+	// Permissions stuff
+	// =========================================================================
+
+	// Users get Roles[0..2] in order; Roles[3] is left free for create.
+	roles, err := rolebus.TestSeedRoles(ctx, 4, busDomain.Role)
+	if err != nil {
+		return DataScopeSeedData{}, fmt.Errorf("seeding roles : %w", err)
+	}
+
+	roleIDs := make(uuid.UUIDs, len(roles))
+	for i, r := range roles {
+		roleIDs[i] = r.ID
+	}
+
+	userIDs := uuid.UUIDs{tu1.ID, tu2.ID, scoped.ID}
+
+	_, err = userrolebus.TestSeedUserRoles(ctx, userIDs, roleIDs, busDomain.UserRole)
+	if err != nil {
+		return DataScopeSeedData{}, fmt.Errorf("seeding user roles : %w", err)
+	}
+
+	_, err = tableaccessbus.TestSeedTableAccess(ctx, roleIDs, busDomain.TableAccess)
+	if err != nil {
+		return DataScopeSeedData{}, fmt.Errorf("seeding table access : %w", err)
+	}
+
+	tas, err := busDomain.TableAccess.QueryByRoleIDs(ctx, uuid.UUIDs{roles[0].ID})
+	if err != nil {
+		return DataScopeSeedData{}, fmt.Errorf("querying table access : %w", err)
+	}
+
+	// tu1 may not touch data scopes at all.
+	for _, ta := range tas {
+		if ta.TableName == datascopeapi.RouteTable {
+			update := tableaccessbus.UpdateTableAccess{
+				CanCreate: dbtest.BoolPointer(false),
+				CanUpdate: dbtest.BoolPointer(false),
+				CanDelete: dbtest.BoolPointer(false),
+				CanRead:   dbtest.BoolPointer(false),
+			}
+			if _, err := busDomain.TableAccess.Update(ctx, ta, update); err != nil {
+				return DataScopeSeedData{}, fmt.Errorf("updating table access : %w", err)
+			}
+		}
+	}
+
+	// =========================================================================
+	// Data scopes
+	// =========================================================================
+
+	newScopes := []datascopebus.NewDataScope{
+		{UserID: &scoped.ID, WarehouseIDs: []uuid.UUID{warehouses[0].ID}},
+		{RoleID: &roles[2].ID, WarehouseIDs: []uuid.UUID{warehouses[0].ID, warehouses[1].ID}},
+	}
+
+	scopes := make([]datascopebus.DataScope, len(newScopes))
+	for i, nds := range newScopes {
+		ds, err := busDomain.DataScope.Create(ctx, nds)
+		if err != nil {
+			return DataScopeSeedData{}, fmt.Errorf("seeding data scope : %w", err)
+		}
+
+		// Read back so dates match what the API returns.
+		if scopes[i], err = busDomain.DataScope.QueryByID(ctx, ds.ID); err != nil {
+			return DataScopeSeedData{}, fmt.Errorf("querying data scope : %w", err)
+		}
+	}
+
+	return DataScopeSeedData{
+		SeedData: apitest.SeedData{
+			Users:      []apitest.User{tu1},
+			Admins:     []apitest.User{tu2},
+			Roles:      roleapp.ToAppRoles(roles),
+			Warehouses: warehouseapp.ToAppWarehouses(warehouses),
+			Zones:      zoneapp.ToAppZones(zones),
+		},
+		Scoped:     scoped,
+		DataScopes: datascopeapp.ToAppDataScopes(scopes),
+	}, nil
+}
@@ -0,0 +1,144 @@
+package datascopeapi_test
+
+import (
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/core/datascopeapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/warehouseapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/zoneapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+)
+
+func update200(sd DataScopeSeedData) []apitest.Table {
+	warehouses := []string{sd.Warehouses[2].ID}
+
+	return []apitest.Table{
+		{
+			Name:       "replace-warehouses",
+			URL:        "/v1/core/data-scopes/" + sd.DataScopes[1].ID,
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPut,
+			StatusCode: http.StatusOK,
+			Input: &datascopeapp.UpdateDataScope{
+				WarehouseIDs: &warehouses,
+			},
+			GotResp: &datascopeapp.DataScope{},
+			ExpResp: &datascopeapp.DataScope{
+				ID:           sd.DataScopes[1].ID,
+				RoleID:       sd.DataScopes[1].RoleID,
+				WarehouseIDs: warehouses,
+				ZoneIDs:      sd.DataScopes[1].ZoneIDs,
+				CreatedDate:  sd.DataScopes[1].CreatedDate,
+			},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*datascopeapp.DataScope)
+				if !exists {
+					return "error occurred"
+				}
+
+				expResp := exp.(*datascopeapp.DataScope)
+				expResp.UpdatedDate = gotResp.UpdatedDate
+
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func update401(sd DataScopeSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        "/v1/core/data-scopes/" + sd.DataScopes[1].ID,
+			Token:      "&nbsp;",
+			Method:     http.MethodPut,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "not-admin",
+			URL:        "/v1/core/data-scopes/" + sd.DataScopes[1].ID,
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPut,
+			StatusCode: http.StatusUnauthorized,
+			Input:      &datascopeapp.UpdateDataScope{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "authorize: you are not authorized for that action, claims[[USER]] rule[rule_admin_only]: rego evaluation failed : bindings results[[{[true] map[x:false]}]] ok[true]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func update404(sd DataScopeSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "not-found",
+			URL:        "/v1/core/data-scopes/" + uuid.NewString(),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPut,
+			StatusCode: http.StatusNotFound,
+			Input:      &datascopeapp.UpdateDataScope{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.NotFound, "data scope not found"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+// scopedUpdate403 checks that a user cannot move a row they can see out of
+// their data scope.
+func scopedUpdate403(sd DataScopeSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "zone-moved-out-of-scope",
+			URL:        "/v1/inventory/zones/" + sd.Zones[0].ZoneID,
+			Token:      sd.Scoped.Token,
+			Method:     http.MethodPut,
+			StatusCode: http.StatusForbidden,
+			Input: &zoneapp.UpdateZone{
+				WarehouseID: &sd.Warehouses[2].ID,
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.PermissionDenied, "update: checkdatascope: outside the caller's data scope"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+// scopedUpdate404 checks that a user cannot update a row outside their data
+// scope.
+func scopedUpdate404(sd DataScopeSeedData) []apitest.Table {
+	name := "Renamed"
+
+	return []apitest.Table{
+		{
+			Name:       "warehouse-out-of-scope",
+			URL:        "/v1/inventory/warehouses/" + sd.Warehouses[2].ID,
+			Token:      sd.Scoped.Token,
+			Method:     http.MethodPut,
+			StatusCode: http.StatusNotFound,
+			Input: &warehouseapp.UpdateWarehouse{
+				Name:      &name,
+				UpdatedBy: sd.Scoped.ID.String(),
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.NotFound, "warehouse not found"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
@@ -0,0 +1,64 @@
+package countplanapi_test
+
+import (
+	"testing"
+
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/countplanapp"
+)
+
+func Test_CountPlan(t *testing.T) {
+	t.Parallel()
+
+	test := apitest.StartTest(t, "Test_CountPlan")
+
+	sd, err := insertSeedData(test.DB, test.Auth)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	test.Run(t, query200(sd), "query-200")
+	test.Run(t, queryByID200(sd), "query-by-id-200")
+	test.Run(t, queryByID404(sd), "query-by-id-404")
+	test.Run(t, query401(sd), "query-401")
+
+	test.Run(t, create200(sd), "create-200")
+	test.Run(t, create400(sd), "create-400")
+	test.Run(t, create401(sd), "create-401")
+
+	test.Run(t, update200(sd), "update-200")
+	test.Run(t, update400(sd), "update-400")
+	test.Run(t, update401(sd), "update-401")
+	test.Run(t, update404(sd), "update-404")
+
+	test.Run(t, preview200(sd), "preview-200")
+	test.Run(t, generate200(sd), "generate-200")
+	test.Run(t, generate400(sd), "generate-400")
+	test.Run(t, generate401(sd), "generate-401")
+	test.Run(t, queryRuns200(sd), "query-runs-200")
+
+	test.Run(t, delete200(sd), "delete-200")
+	test.Run(t, delete401(sd), "delete-401")
+	test.Run(t, delete404(sd), "delete-404")
+	test.Run(t, delete409(sd), "delete-409")
+}
+
+// planByRule returns the first active seeded plan with the given rule.
+func planByRule(sd CountPlanSeedData, rule string) countplanapp.CountPlan {
+	for _, p := range sd.CountPlans {
+		if p.Rule == rule && p.IsActive {
+			return p
+		}
+	}
+	return countplanapp.CountPlan{}
+}
+
+// inactivePlan returns the seeded inactive plan.
+func inactivePlan(sd CountPlanSeedData) countplanapp.CountPlan {
+	for _, p := range sd.CountPlans {
+		if !p.IsActive {
+			return p
+		}
+	}
+	return countplanapp.CountPlan{}
+}
@@ -0,0 +1,130 @@
+package countplanapi_test
+
+import (
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/countplanapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+)
+
+func create200(sd CountPlanSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "defaults",
+			URL:        "/v1/inventory/count-plans",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input: &countplanapp.NewCountPlan{
+				Name:        "Zone Sample",
+				Rule:        "random_sample",
+				WarehouseID: sd.Warehouses[0].ID,
+				SampleSize:  "3",
+			},
+			GotResp: &countplanapp.CountPlan{},
+			ExpResp: &countplanapp.CountPlan{
+				Name:         "Zone Sample",
+				Rule:         "random_sample",
+				WarehouseID:  sd.Warehouses[0].ID,
+				ADays:        "30",
+				BDays:        "90",
+				CDays:        "365",
+				LookbackDays: "30",
+				SampleSize:   "3",
+				MaxItems:     "0",
+				IsActive:     true,
+				CreatedBy:    sd.Admins[0].ID.String(),
+			},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*countplanapp.CountPlan)
+				if !exists {
+					return "error occurred"
+				}
+				expResp := exp.(*countplanapp.CountPlan)
+				expResp.ID = gotResp.ID
+				expResp.CreatedDate = gotResp.CreatedDate
+				expResp.UpdatedDate = gotResp.UpdatedDate
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func create400(sd CountPlanSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "missing-name",
+			URL:        "/v1/inventory/count-plans",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input: &countplanapp.NewCountPlan{
+				Rule: "abc",
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.InvalidArgument, `validate: [{"field":"name","error":"name is a required field"}]`),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "unknown-rule",
+			URL:        "/v1/inventory/count-plans",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input: &countplanapp.NewCountPlan{
+				Name: "Bad Rule",
+				Rule: "weekly",
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.InvalidArgument, `validate: [{"field":"rule","error":"rule must be one of [abc zero_on_hand negative_adjustment random_sample]"}]`),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func create401(sd CountPlanSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        "/v1/inventory/count-plans",
+			Token:      "&nbsp;",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "bad-sig",
+			URL:        "/v1/inventory/count-plans",
+			Token:      sd.Admins[0].Token + "A",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "authentication failed : bindings results[[{[true] map[x:false]}]] ok[true]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "no-create-permission",
+			URL:        "/v1/inventory/count-plans",
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusForbidden,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission CREATE for table: inventory.count_plans"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
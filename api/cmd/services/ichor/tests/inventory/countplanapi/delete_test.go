@@ -0,0 +1,98 @@
+package countplanapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+)
+
+func delete200(sd CountPlanSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "never-generated",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s", planByRule(sd, "zero_on_hand").ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodDelete,
+			StatusCode: http.StatusNoContent,
+		},
+	}
+}
+
+func delete401(sd CountPlanSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s", sd.CountPlans[0].ID),
+			Token:      "&nbsp;",
+			Method:     http.MethodDelete,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "bad-sig",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s", sd.CountPlans[0].ID),
+			Token:      sd.Admins[0].Token + "A",
+			Method:     http.MethodDelete,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "authentication failed : bindings results[[{[true] map[x:false]}]] ok[true]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "no-delete-permission",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s", sd.CountPlans[0].ID),
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodDelete,
+			StatusCode: http.StatusForbidden,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission DELETE for table: inventory.count_plans"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func delete404(sd CountPlanSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "not-found",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s", uuid.NewString()),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodDelete,
+			StatusCode: http.StatusNotFound,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.NotFound, "count plan not found"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func delete409(sd CountPlanSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "has-runs",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s", planByRule(sd, "random_sample").ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodDelete,
+			StatusCode: http.StatusConflict,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Aborted, "count plan has generated sessions; deactivate it instead"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
@@ -0,0 +1,141 @@
+package countplanapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/countplanapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/app/sdk/query"
+)
+
+func preview200(sd CountPlanSeedData) []apitest.Table {
+	plan := planByRule(sd, "random_sample")
+
+	return []apitest.Table{
+		{
+			Name:       "random-sample",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s/preview", plan.ID),
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodGet,
+			StatusCode: http.StatusOK,
+			GotResp:    &countplanapp.Preview{},
+			ExpResp:    &countplanapp.Preview{PlanID: plan.ID},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*countplanapp.Preview)
+				if !exists {
+					return "error occurred"
+				}
+				expResp := exp.(*countplanapp.Preview)
+				if gotResp.PlanID != expResp.PlanID {
+					return fmt.Sprintf("plan_id: expected %s, got %s", expResp.PlanID, gotResp.PlanID)
+				}
+				if gotResp.ItemCount == 0 || gotResp.ItemCount != len(gotResp.Candidates) {
+					return fmt.Sprintf("expected a non-empty preview, got item_count=%d candidates=%d", gotResp.ItemCount, len(gotResp.Candidates))
+				}
+				return ""
+			},
+		},
+	}
+}
+
+func generate200(sd CountPlanSeedData) []apitest.Table {
+	plan := planByRule(sd, "random_sample")
+
+	return []apitest.Table{
+		{
+			Name:       "random-sample",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s/generate", plan.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			GotResp:    &countplanapp.Run{},
+			ExpResp: &countplanapp.Run{
+				PlanID:      plan.ID,
+				GeneratedBy: sd.Admins[0].ID.String(),
+			},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*countplanapp.Run)
+				if !exists {
+					return "error occurred"
+				}
+				if gotResp.SessionID == "" || gotResp.ItemCount == 0 {
+					return fmt.Sprintf("expected a session with items, got session_id=%q item_count=%d", gotResp.SessionID, gotResp.ItemCount)
+				}
+				expResp := exp.(*countplanapp.Run)
+				expResp.ID = gotResp.ID
+				expResp.SessionID = gotResp.SessionID
+				expResp.ItemCount = gotResp.ItemCount
+				expResp.GeneratedDate = gotResp.GeneratedDate
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func generate400(sd CountPlanSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "inactive",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s/generate", inactivePlan(sd).ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.FailedPrecondition, "generate: count plan is not active"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func generate401(sd CountPlanSeedData) []apitest.Table {
+	plan := planByRule(sd, "random_sample")
+
+	return []apitest.Table{
+		{
+			Name:       "no-create-permission",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s/generate", plan.ID),
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusForbidden,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission CREATE for table: inventory.count_plans"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func queryRuns200(sd CountPlanSeedData) []apitest.Table {
+	plan := planByRule(sd, "random_sample")
+
+	return []apitest.Table{
+		{
+			Name:       "after-generate",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s/runs?rows=10&page=1", plan.ID),
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodGet,
+			StatusCode: http.StatusOK,
+			GotResp:    &query.Result[countplanapp.Run]{},
+			ExpResp:    &query.Result[countplanapp.Run]{},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*query.Result[countplanapp.Run])
+				if !exists {
+					return "error occurred"
+				}
+				if gotResp.Total != 1 || len(gotResp.Items) != 1 {
+					return fmt.Sprintf("expected 1 run, got total=%d items=%d", gotResp.Total, len(gotResp.Items))
+				}
+				if gotResp.Items[0].PlanID != plan.ID {
+					return fmt.Sprintf("plan_id: expected %s, got %s", plan.ID, gotResp.Items[0].PlanID)
+				}
+				return ""
+			},
+		},
+	}
+}
@@ -0,0 +1,115 @@
+package countplanapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/countplanapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/app/sdk/query"
+)
+
+func query200(sd CountPlanSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "all",
+			URL:        "/v1/inventory/count-plans?rows=10&page=1&orderBy=id",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodGet,
+			StatusCode: http.StatusOK,
+			GotResp:    &query.Result[countplanapp.CountPlan]{},
+			ExpResp: &query.Result[countplanapp.CountPlan]{
+				Items:       sd.CountPlans,
+				Total:       len(sd.CountPlans),
+				Page:        1,
+				RowsPerPage: 10,
+			},
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "inactive",
+			URL:        "/v1/inventory/count-plans?rows=10&page=1&is_active=false",
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodGet,
+			StatusCode: http.StatusOK,
+			GotResp:    &query.Result[countplanapp.CountPlan]{},
+			ExpResp: &query.Result[countplanapp.CountPlan]{
+				Items:       []countplanapp.CountPlan{inactivePlan(sd)},
+				Total:       1,
+				Page:        1,
+				RowsPerPage: 10,
+			},
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func queryByID200(sd CountPlanSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "basic",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s", sd.CountPlans[0].ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodGet,
+			StatusCode: http.StatusOK,
+			GotResp:    &countplanapp.CountPlan{},
+			ExpResp:    &sd.CountPlans[0],
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func queryByID404(sd CountPlanSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "not-found",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s", uuid.NewString()),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodGet,
+			StatusCode: http.StatusNotFound,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.NotFound, "count plan not found"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func query401(sd CountPlanSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        "/v1/inventory/count-plans?rows=10&page=1",
+			Token:      "&nbsp;",
+			Method:     http.MethodGet,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "bad-sig",
+			URL:        "/v1/inventory/count-plans?rows=10&page=1",
+			Token:      sd.Admins[0].Token + "A",
+			Method:     http.MethodGet,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "authentication failed : bindings results[[{[true] map[x:false]}]] ok[true]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
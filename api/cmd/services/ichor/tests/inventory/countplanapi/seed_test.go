@@ -0,0 +1,292 @@
+package countplanapi_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/domain/http/inventory/countplanapi"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/countplanapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/inventorylocationapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/warehouseapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/zoneapp"
+	"github.com/timmaaaz/ichor/app/domain/products/productapp"
+	"github.com/timmaaaz/ichor/app/sdk/auth"
+	"github.com/timmaaaz/ichor/business/domain/core/contactinfosbus"
+	"github.com/timmaaaz/ichor/business/domain/core/rolebus"
+	"github.com/timmaaaz/ichor/business/domain/core/tableaccessbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userrolebus"
+	"github.com/timmaaaz/ichor/business/domain/geography/citybus"
+	"github.com/timmaaaz/ichor/business/domain/geography/regionbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/streetbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/timezonebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/warehousebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/zonebus"
+	"github.com/timmaaaz/ichor/business/domain/products/brandbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productcategorybus"
+	"github.com/timmaaaz/ichor/business/sdk/dbtest"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// CountPlanSeedData holds count plan specific test state.
+type CountPlanSeedData struct {
+	apitest.SeedData
+
+	// CountPlans are sorted by ID. Exactly one is inactive.
+	CountPlans []countplanapp.CountPlan
+}
+
+func insertSeedData(db *dbtest.Database, ath *auth.Auth) (CountPlanSeedData, error) {
+	ctx := context.Background()
+	busDomain := db.BusDomain
+
+	// =========================================================================
+	// Users
+	// =========================================================================
+
+	usrs, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.User, busDomain.User)
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	tu1 := apitest.User{
+		User:  usrs[0],
+		Token: apitest.Token(busDomain.User, ath, usrs[0].Email.Address),
+	}
+
+	admins, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.Admin, busDomain.User)
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("seeding admin : %w", err)
+	}
+
+	tu2 := apitest.User{
+		User:  admins[0],
+		Token: apitest.Token(busDomain.User, ath, admins[0].Email.Address),
+	}
+
+	// =========================================================================
+	// Geography
+	// =========================================================================
+
+	const warehouseCount = 2
+
+	regions, err := busDomain.Region.Query(ctx, regionbus.QueryFilter{}, regionbus.DefaultOrderBy, page.MustParse("1", "5"))
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("querying regions : %w", err)
+	}
+
+	regionIDs := make([]uuid.UUID, 0, len(regions))
+	for _, r := range regions {
+		regionIDs = append(regionIDs, r.ID)
+	}
+
+	ctys, err := citybus.TestSeedCities(ctx, warehouseCount, regionIDs, busDomain.City)
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("seeding cities : %w", err)
+	}
+
+	ctyIDs := make([]uuid.UUID, 0, len(ctys))
+	for _, c := range ctys {
+		ctyIDs = append(ctyIDs, c.ID)
+	}
+
+	strs, err := streetbus.TestSeedStreets(ctx, warehouseCount, ctyIDs, busDomain.Street)
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("seeding streets : %w", err)
+	}
+
+	strIDs := make([]uuid.UUID, 0, len(strs))
+	for _, s := range strs {
+		strIDs = append(strIDs, s.ID)
+	}
+
+	// =========================================================================
+	// Warehouse Infrastructure
+	// =========================================================================
+
+	warehouses, err := warehousebus.TestSeedWarehouses(ctx, warehouseCount, tu1.ID, strIDs, busDomain.Warehouse)
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("seeding warehouses : %w", err)
+	}
+
+	warehouseIDs := make(uuid.UUIDs, len(warehouses))
+	for i, w := range warehouses {
+		warehouseIDs[i] = w.ID
+	}
+
+	zones, err := zonebus.TestSeedZone(ctx, 4, warehouseIDs, busDomain.Zones)
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("seeding zones : %w", err)
+	}
+
+	inventoryLocations, err := inventorylocationbus.TestSeedInventoryLocations(ctx, 5, warehouseIDs, zones, busDomain.InventoryLocation)
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("seeding inventory locations : %w", err)
+	}
+
+	locationIDs := make([]uuid.UUID, len(inventoryLocations))
+	for i, il := range inventoryLocations {
+		locationIDs[i] = il.LocationID
+	}
+
+	// =========================================================================
+	// Products
+	// =========================================================================
+
+	tzs, err := busDomain.Timezone.Query(ctx, timezonebus.QueryFilter{}, timezonebus.DefaultOrderBy, page.MustParse("1", "100"))
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("querying timezones : %w", err)
+	}
+
+	tzIDs := make([]uuid.UUID, 0, len(tzs))
+	for _, tz := range tzs {
+		tzIDs = append(tzIDs, tz.ID)
+	}
+
+	contacts, err := contactinfosbus.TestSeedContactInfos(ctx, 2, strIDs, tzIDs, busDomain.ContactInfos)
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("seeding contact info : %w", err)
+	}
+
+	contactIDs := make(uuid.UUIDs, len(contacts))
+	for i, c := range contacts {
+		contactIDs[i] = c.ID
+	}
+
+	brands, err := brandbus.TestSeedBrands(ctx, 2, contactIDs, busDomain.Brand)
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("seeding brands : %w", err)
+	}
+
+	brandIDs := make(uuid.UUIDs, len(brands))
+	for i, b := range brands {
+		brandIDs[i] = b.BrandID
+	}
+
+	pc, err := productcategorybus.TestSeedProductCategories(ctx, 2, busDomain.ProductCategory)
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("seeding product categories : %w", err)
+	}
+
+	pcIDs := make(uuid.UUIDs, len(pc))
+	for i, p := range pc {
+		pcIDs[i] = p.ProductCategoryID
+	}
+
+	products, err := productbus.TestSeedProducts(ctx, 5, brandIDs, pcIDs, busDomain.Product)
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("seeding products : %w", err)
+	}
+
+	productIDs := make(uuid.UUIDs, len(products))
+	for i, p := range products {
+		productIDs[i] = p.ProductID
+	}
+
+	// =========================================================================
+	// Inventory Items (stock for the plans to select from)
+	// =========================================================================
+
+	if _, err := inventoryitembus.TestSeedInventoryItems(ctx, 10, locationIDs, productIDs, busDomain.InventoryItem); err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("seeding inventory items : %w", err)
+	}
+
+	// =========================================================================
+	// Count Plans: one per rule, plus an inactive plan
+	// =========================================================================
+
+	plans, err := countplanbus.TestSeedCountPlans(ctx, 4, uuid.UUIDs{tu2.ID}, busDomain.CountPlan)
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("seeding count plans : %w", err)
+	}
+
+	inactive, err := busDomain.CountPlan.Create(ctx, countplanbus.NewCountPlan{
+		Name:      "Inactive Plan",
+		Rule:      countplanbus.Rules.RandomSample,
+		IsActive:  false,
+		CreatedBy: tu2.ID,
+	})
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("seeding inactive count plan : %w", err)
+	}
+	plans = append(plans, inactive)
+
+	sort.Slice(plans, func(i, j int) bool {
+		return plans[i].ID.String() < plans[j].ID.String()
+	})
+
+	// =========================================================================
+	// Permissions
+	// =========================================================================
+
+	roles, err := rolebus.TestSeedRoles(ctx, 2, busDomain.Role)
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("seeding roles : %w", err)
+	}
+
+	roleIDs := make(uuid.UUIDs, len(roles))
+	for i, r := range roles {
+		roleIDs[i] = r.ID
+	}
+
+	userIDs := uuid.UUIDs{tu1.ID, tu2.ID}
+
+	_, err = userrolebus.TestSeedUserRoles(ctx, userIDs, roleIDs, busDomain.UserRole)
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("seeding user roles : %w", err)
+	}
+
+	_, err = tableaccessbus.TestSeedTableAccess(ctx, roleIDs, busDomain.TableAccess)
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("seeding table access : %w", err)
+	}
+
+	ur1, err := busDomain.UserRole.QueryByUserID(ctx, tu1.ID)
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("querying user1 roles : %w", err)
+	}
+
+	usrRoleIDs := make(uuid.UUIDs, len(ur1))
+	for i, r := range ur1 {
+		usrRoleIDs[i] = r.RoleID
+	}
+
+	tas, err := busDomain.TableAccess.QueryByRoleIDs(ctx, usrRoleIDs)
+	if err != nil {
+		return CountPlanSeedData{}, fmt.Errorf("querying table access : %w", err)
+	}
+
+	for _, ta := range tas {
+		if ta.TableName == countplanapi.RouteTable {
+			update := tableaccessbus.UpdateTableAccess{
+				CanCreate: dbtest.BoolPointer(false),
+				CanUpdate: dbtest.BoolPointer(false),
+				CanDelete: dbtest.BoolPointer(false),
+				CanRead:   dbtest.BoolPointer(true),
+			}
+			_, err := busDomain.TableAccess.Update(ctx, ta, update)
+			if err != nil {
+				return CountPlanSeedData{}, fmt.Errorf("updating table access : %w", err)
+			}
+		}
+	}
+
+	return CountPlanSeedData{
+		SeedData: apitest.SeedData{
+			Admins:             []apitest.User{tu2},
+			Users:              []apitest.User{tu1},
+			Warehouses:         warehouseapp.ToAppWarehouses(warehouses),
+			Zones:              zoneapp.ToAppZones(zones),
+			InventoryLocations: inventorylocationapp.ToAppInventoryLocations(inventoryLocations),
+			Products:           productapp.ToAppProducts(products),
+		},
+		CountPlans: countplanapp.ToAppCountPlans(plans),
+	}, nil
+}
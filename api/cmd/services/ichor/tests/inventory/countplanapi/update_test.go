@@ -0,0 +1,130 @@
+package countplanapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/countplanapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/sdk/dbtest"
+)
+
+func update200(sd CountPlanSeedData) []apitest.Table {
+	plan := planByRule(sd, "abc")
+
+	exp := plan
+	exp.Name = "ABC Weekly"
+	exp.ADays = "7"
+
+	return []apitest.Table{
+		{
+			Name:       "name-and-a-days",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s", plan.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPut,
+			StatusCode: http.StatusOK,
+			Input: &countplanapp.UpdateCountPlan{
+				Name:  dbtest.StringPointer("ABC Weekly"),
+				ADays: dbtest.StringPointer("7"),
+			},
+			GotResp: &countplanapp.CountPlan{},
+			ExpResp: &exp,
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*countplanapp.CountPlan)
+				if !exists {
+					return "error occurred"
+				}
+				expResp := exp.(*countplanapp.CountPlan)
+				expResp.UpdatedDate = gotResp.UpdatedDate
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func update400(sd CountPlanSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "non-positive-a-days",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s", sd.CountPlans[0].ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPut,
+			StatusCode: http.StatusBadRequest,
+			Input: &countplanapp.UpdateCountPlan{
+				ADays: dbtest.StringPointer("0"),
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.InvalidArgument, "update: invalid count plan: a_days, b_days and c_days must be positive"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func update401(sd CountPlanSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s", sd.CountPlans[0].ID),
+			Token:      "&nbsp;",
+			Method:     http.MethodPut,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "bad-sig",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s", sd.CountPlans[0].ID),
+			Token:      sd.Admins[0].Token + "A",
+			Method:     http.MethodPut,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "authentication failed : bindings results[[{[true] map[x:false]}]] ok[true]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "no-update-permission",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s", sd.CountPlans[0].ID),
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPut,
+			StatusCode: http.StatusForbidden,
+			Input: &countplanapp.UpdateCountPlan{
+				Name: dbtest.StringPointer("Nope"),
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.PermissionDenied, "user does not have permission UPDATE for table: inventory.count_plans"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func update404(sd CountPlanSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "not-found",
+			URL:        fmt.Sprintf("/v1/inventory/count-plans/%s", uuid.NewString()),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPut,
+			StatusCode: http.StatusNotFound,
+			Input: &countplanapp.UpdateCountPlan{
+				Name: dbtest.StringPointer("Nope"),
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.NotFound, "count plan not found"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
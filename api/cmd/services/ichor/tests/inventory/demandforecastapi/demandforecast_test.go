@@ -0,0 +1,57 @@
+package demandforecastapi_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/demandforecastapp"
+)
+
+func Test_DemandForecast(t *testing.T) {
+	t.Parallel()
+
+	test := apitest.StartTest(t, "Test_DemandForecast")
+
+	sd, err := insertSeedData(test.DB, test.Auth)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	test.Run(t, query200(sd), "query-200")
+	test.Run(t, queryByID200(sd), "query-by-id-200")
+	test.Run(t, queryByID404(sd), "query-by-id-404")
+	test.Run(t, query401(sd), "query-401")
+
+	test.Run(t, queryPolicies200(sd), "query-policies-200")
+	test.Run(t, createPolicy200(sd), "create-policy-200")
+	test.Run(t, createPolicy401(sd), "create-policy-401")
+	test.Run(t, createPolicy409(sd), "create-policy-409")
+
+	test.Run(t, approve200(sd), "approve-200")
+	checkApplied(t, test, sd.Forecasts[0])
+	test.Run(t, approve401(sd), "approve-401")
+	test.Run(t, approve409(sd), "approve-409")
+	test.Run(t, reject200(sd), "reject-200")
+	test.Run(t, reject409(sd), "reject-409")
+
+	test.Run(t, run200(sd), "run-200")
+	test.Run(t, run400(sd), "run-400")
+	test.Run(t, run401(sd), "run-401")
+}
+
+// checkApplied checks an approved forecast's recommended reorder point was
+// written to its inventory item.
+func checkApplied(t *testing.T, test *apitest.Test, forecast demandforecastapp.Forecast) {
+	t.Helper()
+
+	item, err := test.DB.BusDomain.InventoryItem.QueryByID(context.Background(), uuid.MustParse(forecast.InventoryItemID))
+	if err != nil {
+		t.Fatalf("querying inventory item: %s", err)
+	}
+	if got := strconv.Itoa(item.ReorderPoint); got != forecast.RecommendedReorderPoint {
+		t.Fatalf("reorder point: expected %s, got %s", forecast.RecommendedReorderPoint, got)
+	}
+}
@@ -0,0 +1,110 @@
+package demandforecastapi_test
+
+import (
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/demandforecastapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/app/sdk/query"
+)
+
+func queryPolicies200(sd DemandForecastSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "all",
+			URL:        "/v1/inventory/forecast-policies?rows=10&page=1",
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodGet,
+			StatusCode: http.StatusOK,
+			GotResp:    &query.Result[demandforecastapp.Policy]{},
+			ExpResp: &query.Result[demandforecastapp.Policy]{
+				Items:       sd.Policies,
+				Total:       len(sd.Policies),
+				Page:        1,
+				RowsPerPage: 10,
+			},
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func createPolicy200(sd DemandForecastSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "category-defaults",
+			URL:        "/v1/inventory/forecast-policies",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input: &demandforecastapp.NewPolicy{
+				CategoryID: sd.Products[0].ProductCategoryID,
+				Method:     "moving_average",
+			},
+			GotResp: &demandforecastapp.Policy{},
+			ExpResp: &demandforecastapp.Policy{
+				CategoryID:       sd.Products[0].ProductCategoryID,
+				Method:           "moving_average",
+				HistoryDays:      "90",
+				WindowDays:       "28",
+				SmoothingAlpha:   "0.3",
+				SeasonLengthDays: "7",
+				ServiceLevel:     "0.95",
+				LeadTimeDays:     "7",
+				OrderingCost:     "0.00",
+				HoldingCostRate:  "0",
+				CreatedBy:        sd.Admins[0].ID.String(),
+			},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*demandforecastapp.Policy)
+				if !exists {
+					return "error occurred"
+				}
+				expResp := exp.(*demandforecastapp.Policy)
+				expResp.ID = gotResp.ID
+				expResp.CreatedDate = gotResp.CreatedDate
+				expResp.UpdatedDate = gotResp.UpdatedDate
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func createPolicy401(sd DemandForecastSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "no-create-permission",
+			URL:        "/v1/inventory/forecast-policies",
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusForbidden,
+			Input:      &demandforecastapp.NewPolicy{Method: "moving_average"},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission CREATE for table: inventory.forecast_policies"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func createPolicy409(sd DemandForecastSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "second-default",
+			URL:        "/v1/inventory/forecast-policies",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusConflict,
+			Input:      &demandforecastapp.NewPolicy{Method: "seasonal_naive"},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Aborted, "createpolicy: namedexeccontext: forecast policy entry is not unique"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
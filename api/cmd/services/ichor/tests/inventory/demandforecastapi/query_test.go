@@ -0,0 +1,98 @@
+package demandforecastapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/demandforecastapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/app/sdk/query"
+)
+
+func query200(sd DemandForecastSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "all",
+			URL:        "/v1/inventory/demand-forecasts?rows=10&page=1&orderBy=id,ASC",
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodGet,
+			StatusCode: http.StatusOK,
+			GotResp:    &query.Result[demandforecastapp.Forecast]{},
+			ExpResp: &query.Result[demandforecastapp.Forecast]{
+				Items:       sd.Forecasts,
+				Total:       len(sd.Forecasts),
+				Page:        1,
+				RowsPerPage: 10,
+			},
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func queryByID200(sd DemandForecastSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "basic",
+			URL:        fmt.Sprintf("/v1/inventory/demand-forecasts/%s", sd.Forecasts[0].ID),
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodGet,
+			StatusCode: http.StatusOK,
+			GotResp:    &demandforecastapp.Forecast{},
+			ExpResp:    &sd.Forecasts[0],
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func queryByID404(sd DemandForecastSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "not-found",
+			URL:        fmt.Sprintf("/v1/inventory/demand-forecasts/%s", uuid.NewString()),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodGet,
+			StatusCode: http.StatusNotFound,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.NotFound, "demand forecast not found"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func query401(sd DemandForecastSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        "/v1/inventory/demand-forecasts?rows=10&page=1",
+			Token:      "&nbsp;",
+			Method:     http.MethodGet,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "bad-sig",
+			URL:        "/v1/inventory/demand-forecasts?rows=10&page=1",
+			Token:      sd.Admins[0].Token + "A",
+			Method:     http.MethodGet,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "authentication failed : bindings results[[{[true] map[x:false]}]] ok[true]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
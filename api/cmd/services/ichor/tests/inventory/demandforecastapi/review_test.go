@@ -0,0 +1,124 @@
+package demandforecastapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/demandforecastapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
+)
+
+func approve200(sd DemandForecastSeedData) []apitest.Table {
+	return []apitest.Table{reviewed200(sd, "approve", sd.Forecasts[0], demandforecastbus.StatusApplied)}
+}
+
+func reject200(sd DemandForecastSeedData) []apitest.Table {
+	return []apitest.Table{reviewed200(sd, "reject", sd.Forecasts[1], demandforecastbus.StatusRejected)}
+}
+
+func reviewed200(sd DemandForecastSeedData, action string, forecast demandforecastapp.Forecast, status string) apitest.Table {
+	return apitest.Table{
+		Name:       "pending",
+		URL:        fmt.Sprintf("/v1/inventory/demand-forecasts/%s/%s", forecast.ID, action),
+		Token:      sd.Admins[0].Token,
+		Method:     http.MethodPost,
+		StatusCode: http.StatusOK,
+		GotResp:    &demandforecastapp.Forecast{},
+		ExpResp:    &forecast,
+		CmpFunc: func(got, exp any) string {
+			gotResp, exists := got.(*demandforecastapp.Forecast)
+			if !exists {
+				return "error occurred"
+			}
+			if gotResp.ReviewedDate == "" {
+				return "expected reviewed_date to be set"
+			}
+			expResp := exp.(*demandforecastapp.Forecast)
+			expResp.Status = status
+			expResp.ReviewedBy = sd.Admins[0].ID.String()
+			expResp.ReviewedDate = gotResp.ReviewedDate
+			expResp.UpdatedDate = gotResp.UpdatedDate
+			return cmp.Diff(gotResp, expResp)
+		},
+	}
+}
+
+func approve401(sd DemandForecastSeedData) []apitest.Table {
+	url := fmt.Sprintf("/v1/inventory/demand-forecasts/%s/approve", sd.Forecasts[1].ID)
+
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        url,
+			Token:      "&nbsp;",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "no-update-permission",
+			URL:        url,
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusForbidden,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission UPDATE for table: inventory.demand_forecasts"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func approve409(sd DemandForecastSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "already-applied",
+			URL:        fmt.Sprintf("/v1/inventory/demand-forecasts/%s/approve", sd.Forecasts[0].ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusConflict,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Aborted, "approve: demand forecast is not pending"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func reject409(sd DemandForecastSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "already-rejected",
+			URL:        fmt.Sprintf("/v1/inventory/demand-forecasts/%s/reject", sd.Forecasts[1].ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusConflict,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Aborted, "reject: demand forecast is not pending"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "already-applied",
+			URL:        fmt.Sprintf("/v1/inventory/demand-forecasts/%s/reject", sd.Forecasts[0].ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusConflict,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Aborted, "reject: demand forecast is not pending"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
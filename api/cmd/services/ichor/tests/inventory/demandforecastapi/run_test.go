@@ -0,0 +1,95 @@
+package demandforecastapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/demandforecastapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+)
+
+func run200(sd DemandForecastSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "shipments-not-demand",
+			URL:        "/v1/inventory/demand-forecasts/run",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input:      &demandforecastapp.NewRun{},
+			GotResp:    &demandforecastapp.Forecasts{},
+			ExpResp:    &demandforecastapp.Forecasts{},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*demandforecastapp.Forecasts)
+				if !exists {
+					return "error occurred"
+				}
+				if len(*gotResp) != len(sd.ItemIDs) {
+					return fmt.Sprintf("expected %d forecasts, got %d", len(sd.ItemIDs), len(*gotResp))
+				}
+
+				// Both items picked the same quantity; the shipment of the
+				// second must not raise its forecast.
+				a, b := (*gotResp)[0], (*gotResp)[1]
+				if a.ForecastDailyUsage == "0.0000" {
+					return "expected the seeded picks to count as demand"
+				}
+				if a.ForecastDailyUsage != b.ForecastDailyUsage {
+					return fmt.Sprintf("expected equal daily usage, got %s and %s", a.ForecastDailyUsage, b.ForecastDailyUsage)
+				}
+				return ""
+			},
+		},
+	}
+}
+
+func run400(sd DemandForecastSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "product-without-items",
+			URL:        "/v1/inventory/demand-forecasts/run",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input:      &demandforecastapp.NewRun{ProductID: sd.Products[len(sd.Products)-1].ProductID},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.FailedPrecondition, "run: no inventory item has a forecast policy"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func run401(sd DemandForecastSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        "/v1/inventory/demand-forecasts/run",
+			Token:      "&nbsp;",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			Input:      &demandforecastapp.NewRun{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "no-create-permission",
+			URL:        "/v1/inventory/demand-forecasts/run",
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusForbidden,
+			Input:      &demandforecastapp.NewRun{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission CREATE for table: inventory.demand_forecasts"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
@@ -0,0 +1,346 @@
+package demandforecastapi_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/domain/http/inventory/demandforecastapi"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/demandforecastapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/inventorylocationapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/warehouseapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/zoneapp"
+	"github.com/timmaaaz/ichor/app/domain/products/productapp"
+	"github.com/timmaaaz/ichor/app/sdk/auth"
+	"github.com/timmaaaz/ichor/business/domain/core/contactinfosbus"
+	"github.com/timmaaaz/ichor/business/domain/core/rolebus"
+	"github.com/timmaaaz/ichor/business/domain/core/tableaccessbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userrolebus"
+	"github.com/timmaaaz/ichor/business/domain/geography/citybus"
+	"github.com/timmaaaz/ichor/business/domain/geography/regionbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/streetbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/timezonebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/warehousebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/zonebus"
+	"github.com/timmaaaz/ichor/business/domain/products/brandbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productcategorybus"
+	"github.com/timmaaaz/ichor/business/sdk/dbtest"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// DemandForecastSeedData is the seed data plus the default forecast policy
+// and the pending forecasts of one run over two inventory items. Both items
+// picked the same quantity yesterday; the second also shipped it, which must
+// not count as more demand. Products holds only active products, and the
+// last one has no inventory item.
+type DemandForecastSeedData struct {
+	apitest.SeedData
+	Policies  []demandforecastapp.Policy
+	Forecasts []demandforecastapp.Forecast // by id
+	ItemIDs   []uuid.UUID
+}
+
+// Current values of the seeded items. With the little demand seeded, a
+// forecast recommends different ones, so it is queued for approval.
+const (
+	itemAvgDailyUsage = 5
+	itemSafetyStock   = 5
+	itemReorderPoint  = 40
+	pickedQuantity    = 14
+)
+
+func insertSeedData(db *dbtest.Database, ath *auth.Auth) (DemandForecastSeedData, error) {
+	ctx := context.Background()
+	busDomain := db.BusDomain
+
+	// =========================================================================
+	// Users
+	// =========================================================================
+
+	usrs, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.User, busDomain.User)
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	tu1 := apitest.User{
+		User:  usrs[0],
+		Token: apitest.Token(busDomain.User, ath, usrs[0].Email.Address),
+	}
+
+	admins, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.Admin, busDomain.User)
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("seeding admin : %w", err)
+	}
+
+	tu2 := apitest.User{
+		User:  admins[0],
+		Token: apitest.Token(busDomain.User, ath, admins[0].Email.Address),
+	}
+
+	// =========================================================================
+	// Geography
+	// =========================================================================
+
+	const warehouseCount = 2
+
+	regions, err := busDomain.Region.Query(ctx, regionbus.QueryFilter{}, regionbus.DefaultOrderBy, page.MustParse("1", "5"))
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("querying regions : %w", err)
+	}
+
+	regionIDs := make([]uuid.UUID, 0, len(regions))
+	for _, r := range regions {
+		regionIDs = append(regionIDs, r.ID)
+	}
+
+	ctys, err := citybus.TestSeedCities(ctx, warehouseCount, regionIDs, busDomain.City)
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("seeding cities : %w", err)
+	}
+
+	ctyIDs := make([]uuid.UUID, 0, len(ctys))
+	for _, c := range ctys {
+		ctyIDs = append(ctyIDs, c.ID)
+	}
+
+	strs, err := streetbus.TestSeedStreets(ctx, warehouseCount, ctyIDs, busDomain.Street)
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("seeding streets : %w", err)
+	}
+
+	strIDs := make([]uuid.UUID, 0, len(strs))
+	for _, s := range strs {
+		strIDs = append(strIDs, s.ID)
+	}
+
+	// =========================================================================
+	// Warehouse Infrastructure
+	// =========================================================================
+
+	warehouses, err := warehousebus.TestSeedWarehouses(ctx, warehouseCount, tu1.ID, strIDs, busDomain.Warehouse)
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("seeding warehouses : %w", err)
+	}
+
+	warehouseIDs := make(uuid.UUIDs, len(warehouses))
+	for i, w := range warehouses {
+		warehouseIDs[i] = w.ID
+	}
+
+	zones, err := zonebus.TestSeedZone(ctx, 4, warehouseIDs, busDomain.Zones)
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("seeding zones : %w", err)
+	}
+
+	inventoryLocations, err := inventorylocationbus.TestSeedInventoryLocations(ctx, 5, warehouseIDs, zones, busDomain.InventoryLocation)
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("seeding inventory locations : %w", err)
+	}
+
+	// =========================================================================
+	// Products
+	// =========================================================================
+
+	tzs, err := busDomain.Timezone.Query(ctx, timezonebus.QueryFilter{}, timezonebus.DefaultOrderBy, page.MustParse("1", "100"))
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("querying timezones : %w", err)
+	}
+
+	tzIDs := make([]uuid.UUID, 0, len(tzs))
+	for _, tz := range tzs {
+		tzIDs = append(tzIDs, tz.ID)
+	}
+
+	contacts, err := contactinfosbus.TestSeedContactInfos(ctx, 2, strIDs, tzIDs, busDomain.ContactInfos)
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("seeding contact info : %w", err)
+	}
+
+	contactIDs := make(uuid.UUIDs, len(contacts))
+	for i, c := range contacts {
+		contactIDs[i] = c.ID
+	}
+
+	brands, err := brandbus.TestSeedBrands(ctx, 2, contactIDs, busDomain.Brand)
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("seeding brands : %w", err)
+	}
+
+	brandIDs := make(uuid.UUIDs, len(brands))
+	for i, b := range brands {
+		brandIDs[i] = b.BrandID
+	}
+
+	pc, err := productcategorybus.TestSeedProductCategories(ctx, 2, busDomain.ProductCategory)
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("seeding product categories : %w", err)
+	}
+
+	pcIDs := make(uuid.UUIDs, len(pc))
+	for i, p := range pc {
+		pcIDs[i] = p.ProductCategoryID
+	}
+
+	// Every other seeded product is inactive, and forecasts only cover
+	// active ones.
+	seeded, err := productbus.TestSeedProducts(ctx, 6, brandIDs, pcIDs, busDomain.Product)
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("seeding products : %w", err)
+	}
+
+	var products []productbus.Product
+	for _, p := range seeded {
+		if p.IsActive {
+			products = append(products, p)
+		}
+	}
+	if len(products) < 3 {
+		return DemandForecastSeedData{}, fmt.Errorf("seeding products : expected 3 active products, got %d", len(products))
+	}
+
+	// =========================================================================
+	// Inventory Items and Demand
+	// =========================================================================
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	itemIDs := make([]uuid.UUID, 2)
+	for i := range itemIDs {
+		item, err := busDomain.InventoryItem.Create(ctx, inventoryitembus.NewInventoryItem{
+			ProductID:     products[i].ProductID,
+			LocationID:    inventoryLocations[i].LocationID,
+			Quantity:      100,
+			AvgDailyUsage: itemAvgDailyUsage,
+			SafetyStock:   itemSafetyStock,
+			ReorderPoint:  itemReorderPoint,
+		})
+		if err != nil {
+			return DemandForecastSeedData{}, fmt.Errorf("seeding inventory item %d : %w", i, err)
+		}
+		itemIDs[i] = item.ID
+
+		types := []string{"PICK"}
+		if i == 1 {
+			types = append(types, "SHIP")
+		}
+		for _, typ := range types {
+			if _, err := busDomain.InventoryTransaction.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
+				ProductID:       products[i].ProductID,
+				LocationID:      inventoryLocations[i].LocationID,
+				UserID:          tu2.ID,
+				Quantity:        -pickedQuantity,
+				TransactionType: typ,
+				ReferenceNumber: fmt.Sprintf("SEED-%s-%d", typ, i),
+				TransactionDate: yesterday,
+			}); err != nil {
+				return DemandForecastSeedData{}, fmt.Errorf("seeding %s transaction %d : %w", typ, i, err)
+			}
+		}
+	}
+
+	// =========================================================================
+	// Policy and Forecasts
+	// =========================================================================
+
+	policy, err := busDomain.DemandForecast.CreatePolicy(ctx, demandforecastbus.NewPolicy{
+		Method:    demandforecastbus.Methods.MovingAverage,
+		CreatedBy: tu2.ID,
+	})
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("seeding forecast policy : %w", err)
+	}
+
+	// Read the policy back so the timestamps carry the database's precision.
+	if policy, err = busDomain.DemandForecast.QueryPolicyByID(ctx, policy.ID); err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("querying forecast policy : %w", err)
+	}
+
+	if _, err := busDomain.DemandForecast.Run(ctx, demandforecastbus.RunRequest{CreatedBy: tu2.ID}, time.Now()); err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("seeding forecasts : %w", err)
+	}
+
+	// The forecasts of one run share a created date, so order them by id.
+	forecasts, err := busDomain.DemandForecast.Query(ctx, demandforecastbus.QueryFilter{}, order.NewBy(demandforecastbus.OrderByID, order.ASC), page.MustParse("1", "10"))
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("querying forecasts : %w", err)
+	}
+
+	// =========================================================================
+	// Permissions
+	// =========================================================================
+
+	roles, err := rolebus.TestSeedRoles(ctx, 2, busDomain.Role)
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("seeding roles : %w", err)
+	}
+
+	roleIDs := make(uuid.UUIDs, len(roles))
+	for i, r := range roles {
+		roleIDs[i] = r.ID
+	}
+
+	userIDs := uuid.UUIDs{tu1.ID, tu2.ID}
+
+	_, err = userrolebus.TestSeedUserRoles(ctx, userIDs, roleIDs, busDomain.UserRole)
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("seeding user roles : %w", err)
+	}
+
+	_, err = tableaccessbus.TestSeedTableAccess(ctx, roleIDs, busDomain.TableAccess)
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("seeding table access : %w", err)
+	}
+
+	ur1, err := busDomain.UserRole.QueryByUserID(ctx, tu1.ID)
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("querying user1 roles : %w", err)
+	}
+
+	usrRoleIDs := make(uuid.UUIDs, len(ur1))
+	for i, r := range ur1 {
+		usrRoleIDs[i] = r.RoleID
+	}
+
+	tas, err := busDomain.TableAccess.QueryByRoleIDs(ctx, usrRoleIDs)
+	if err != nil {
+		return DemandForecastSeedData{}, fmt.Errorf("querying table access : %w", err)
+	}
+
+	for _, ta := range tas {
+		if ta.TableName == demandforecastapi.RouteTable || ta.TableName == demandforecastapi.PolicyRouteTable {
+			update := tableaccessbus.UpdateTableAccess{
+				CanCreate: dbtest.BoolPointer(false),
+				CanUpdate: dbtest.BoolPointer(false),
+				CanDelete: dbtest.BoolPointer(false),
+				CanRead:   dbtest.BoolPointer(true),
+			}
+			_, err := busDomain.TableAccess.Update(ctx, ta, update)
+			if err != nil {
+				return DemandForecastSeedData{}, fmt.Errorf("updating table access : %w", err)
+			}
+		}
+	}
+
+	return DemandForecastSeedData{
+		SeedData: apitest.SeedData{
+			Admins:             []apitest.User{tu2},
+			Users:              []apitest.User{tu1},
+			Warehouses:         warehouseapp.ToAppWarehouses(warehouses),
+			Zones:              zoneapp.ToAppZones(zones),
+			InventoryLocations: inventorylocationapp.ToAppInventoryLocations(inventoryLocations),
+			Products:           productapp.ToAppProducts(products),
+		},
+		Policies:  demandforecastapp.ToAppPolicies([]demandforecastbus.Policy{policy}),
+		Forecasts: demandforecastapp.ToAppForecasts(forecasts),
+		ItemIDs:   itemIDs,
+	}, nil
+}
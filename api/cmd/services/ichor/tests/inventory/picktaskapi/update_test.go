@@ -307,6 +307,57 @@ func TestUpdate400ShortPickedMissingFields(t *testing.T) {
 	}, "missing-short-pick-reason")
 }
 
+// TestUpdate400StagingLocation verifies that completing a task into a location
+// outside an outbound staging zone is rejected and nothing is picked.
+func TestUpdate400StagingLocation(t *testing.T) {
+	t.Parallel()
+
+	test := apitest.StartTest(t, "Test_PickTask_StagingLocation")
+
+	sd, err := insertSeedData(test.DB, test.Auth)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	task := sd.PickTasks[2]
+
+	test.Run(t, []apitest.Table{
+		{
+			Name:       "not-a-staging-location",
+			URL:        fmt.Sprintf("/v1/inventory/pick-tasks/%s", task.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPut,
+			StatusCode: http.StatusBadRequest,
+			Input: &picktaskapp.UpdatePickTask{
+				Status:            dbtest.StringPointer("completed"),
+				StagingLocationID: dbtest.StringPointer(task.LocationID),
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.InvalidArgument, "stage quantity: location is not in an outbound staging zone"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}, "not-a-staging-location")
+
+	productID := uuid.MustParse(task.ProductID)
+	txType := "PICK"
+	txns, err := test.DB.BusDomain.InventoryTransaction.Query(context.Background(),
+		inventorytransactionbus.QueryFilter{
+			ProductID:       &productID,
+			TransactionType: &txType,
+		},
+		inventorytransactionbus.DefaultOrderBy,
+		page.MustParse("1", "10"),
+	)
+	if err != nil {
+		t.Fatalf("query inventory transactions: %v", err)
+	}
+	if len(txns) != 0 {
+		t.Errorf("expected the rejected pick to roll back, got %d PICK transactions", len(txns))
+	}
+}
+
 // TestUpdate400TerminalState verifies that transitioning out of a terminal state returns 400.
 func TestUpdate400TerminalState(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,218 @@
+package pickwaveapi_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/pickwaveapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/inventory/picktaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/pickwavebus"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+func claim200(sd PickWaveSeedData) []apitest.Table {
+	return claimBatch200(sd, firstBatch(sd))
+}
+
+func claimBatch200(sd PickWaveSeedData, batch pickwaveapp.Batch) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "open-batch",
+			URL:        fmt.Sprintf("/v1/inventory/pick-batches/%s/claim", batch.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			GotResp:    &pickwaveapp.Batch{},
+			ExpResp:    &batch,
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*pickwaveapp.Batch)
+				if !exists {
+					return "error occurred"
+				}
+				if gotResp.AssignedAt == "" || len(gotResp.Stops) == 0 {
+					return fmt.Sprintf("expected an assigned batch with stops, got assigned_at=%q stops=%d", gotResp.AssignedAt, len(gotResp.Stops))
+				}
+				expResp := exp.(*pickwaveapp.Batch)
+				expResp.Status = pickwavebus.BatchStatusInProgress
+				expResp.AssignedTo = sd.Admins[0].ID.String()
+				expResp.AssignedAt = gotResp.AssignedAt
+				expResp.UpdatedDate = gotResp.UpdatedDate
+				expResp.Stops = gotResp.Stops
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func claim401(sd PickWaveSeedData) []apitest.Table {
+	url := fmt.Sprintf("/v1/inventory/pick-batches/%s/claim", firstBatch(sd).ID)
+
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        url,
+			Token:      "&nbsp;",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "bad-sig",
+			URL:        url,
+			Token:      sd.Admins[0].Token + "A",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "authentication failed : bindings results[[{[true] map[x:false]}]] ok[true]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "no-update-permission",
+			URL:        url,
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusForbidden,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission UPDATE for table: inventory.pick_waves"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func claim409(sd PickWaveSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "already-claimed",
+			URL:        fmt.Sprintf("/v1/inventory/pick-batches/%s/claim", firstBatch(sd).ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusConflict,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Aborted, "claim: pick batch is already claimed"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func complete400(sd PickWaveSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "stops-open",
+			URL:        fmt.Sprintf("/v1/inventory/pick-batches/%s/complete", firstBatch(sd).ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.FailedPrecondition, "complete: pick batch has stops still to pick"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "not-claimed",
+			URL:        fmt.Sprintf("/v1/inventory/pick-batches/%s/complete", sd.Batches[sd.Waves[0].ID][0].ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.FailedPrecondition, "complete: pick batch is not in progress"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+// =============================================================================
+
+// Test_PickWave_ClaimComplete claims and completes every batch of a wave,
+// picking the stops in between, and checks the wave completes with its last
+// batch.
+func Test_PickWave_ClaimComplete(t *testing.T) {
+	t.Parallel()
+
+	test := apitest.StartTest(t, "Test_PickWave_ClaimComplete")
+
+	sd, err := insertSeedData(test.DB, test.Auth)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	ctx := context.Background()
+	wave := oldestWave(sd)
+
+	for _, batch := range sd.Batches[wave.ID] {
+		test.Run(t, claimBatch200(sd, batch), "claim-200")
+
+		stops, err := test.DB.BusDomain.PickTask.Query(ctx, picktaskbus.QueryFilter{BatchID: mustParseID(t, batch.ID)}, picktaskbus.DefaultOrderBy, page.MustParse("1", "100"))
+		if err != nil {
+			t.Fatalf("querying stops: %s", err)
+		}
+		completed := picktaskbus.Statuses.Completed
+		for _, task := range stops {
+			if _, err := test.DB.BusDomain.PickTask.Update(ctx, task, picktaskbus.UpdatePickTask{
+				Status:         &completed,
+				QuantityPicked: &task.QuantityToPick,
+			}); err != nil {
+				t.Fatalf("picking stop %s: %s", task.ID, err)
+			}
+		}
+
+		test.Run(t, []apitest.Table{
+			{
+				Name:       "picked",
+				URL:        fmt.Sprintf("/v1/inventory/pick-batches/%s/complete", batch.ID),
+				Token:      sd.Admins[0].Token,
+				Method:     http.MethodPost,
+				StatusCode: http.StatusOK,
+				GotResp:    &pickwaveapp.Batch{},
+				ExpResp:    &pickwaveapp.Batch{ID: batch.ID, Status: pickwavebus.BatchStatusCompleted},
+				CmpFunc: func(got, exp any) string {
+					gotResp, exists := got.(*pickwaveapp.Batch)
+					if !exists {
+						return "error occurred"
+					}
+					expResp := exp.(*pickwaveapp.Batch)
+					if gotResp.ID != expResp.ID || gotResp.Status != expResp.Status {
+						return fmt.Sprintf("expected batch %s %s, got %s %s", expResp.ID, expResp.Status, gotResp.ID, gotResp.Status)
+					}
+					return ""
+				},
+			},
+		}, "complete-200")
+	}
+
+	got, err := test.DB.BusDomain.PickWave.QueryByID(ctx, *mustParseID(t, wave.ID))
+	if err != nil {
+		t.Fatalf("querying wave: %s", err)
+	}
+	if got.Status != pickwavebus.WaveStatusCompleted {
+		t.Fatalf("wave status: expected %s, got %s", pickwavebus.WaveStatusCompleted, got.Status)
+	}
+}
+
+func mustParseID(t *testing.T, s string) *uuid.UUID {
+	t.Helper()
+
+	id, err := uuid.Parse(s)
+	if err != nil {
+		t.Fatalf("parsing id %q: %s", s, err)
+	}
+	return &id
+}
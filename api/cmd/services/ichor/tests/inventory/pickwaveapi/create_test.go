@@ -0,0 +1,145 @@
+package pickwaveapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/pickwaveapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+)
+
+func plan200(sd PickWaveSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "pending-tasks",
+			URL:        "/v1/inventory/pick-waves/plan",
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input:      &pickwaveapp.NewRelease{},
+			GotResp:    &pickwaveapp.Plan{},
+			ExpResp:    &pickwaveapp.Plan{},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*pickwaveapp.Plan)
+				if !exists {
+					return "error occurred"
+				}
+				stops := 0
+				for _, w := range gotResp.Waves {
+					for _, b := range w.Batches {
+						stops += len(b.Stops)
+					}
+				}
+				if stops != sd.PendingTasks {
+					return fmt.Sprintf("stops: expected %d, got %d", sd.PendingTasks, stops)
+				}
+				return ""
+			},
+		},
+	}
+}
+
+func create200(sd PickWaveSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "release",
+			URL:        "/v1/inventory/pick-waves",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input:      &pickwaveapp.NewRelease{ToteCount: "4"},
+			GotResp:    &pickwaveapp.Waves{},
+			ExpResp:    &pickwaveapp.Waves{},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*pickwaveapp.Waves)
+				if !exists {
+					return "error occurred"
+				}
+				if len(*gotResp) == 0 {
+					return "expected at least one wave"
+				}
+				for _, w := range *gotResp {
+					if w.Status != "open" || w.ToteCount != "4" || w.CreatedBy != sd.Admins[0].ID.String() {
+						return fmt.Sprintf("wave %s: status %s tote_count %s created_by %s", w.ID, w.Status, w.ToteCount, w.CreatedBy)
+					}
+				}
+				return ""
+			},
+		},
+	}
+}
+
+func create400(sd PickWaveSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "nothing-to-plan",
+			URL:        "/v1/inventory/pick-waves",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input:      &pickwaveapp.NewRelease{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.FailedPrecondition, "create: no pick tasks are waiting for a wave"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "bad-tote-count",
+			URL:        "/v1/inventory/pick-waves",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input:      &pickwaveapp.NewRelease{ToteCount: "0"},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.InvalidArgument, "tote_count must be positive"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func create401(sd PickWaveSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        "/v1/inventory/pick-waves",
+			Token:      "&nbsp;",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "bad-sig",
+			URL:        "/v1/inventory/pick-waves",
+			Token:      sd.Admins[0].Token + "A",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "authentication failed : bindings results[[{[true] map[x:false]}]] ok[true]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "no-create-permission",
+			URL:        "/v1/inventory/pick-waves",
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusForbidden,
+			Input:      &pickwaveapp.NewRelease{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission CREATE for table: inventory.pick_waves"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
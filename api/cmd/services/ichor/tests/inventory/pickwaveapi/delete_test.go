@@ -0,0 +1,100 @@
+package pickwaveapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+)
+
+func delete200(sd PickWaveSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "unclaimed",
+			URL:        fmt.Sprintf("/v1/inventory/pick-waves/%s", sd.Waves[0].ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodDelete,
+			StatusCode: http.StatusNoContent,
+		},
+	}
+}
+
+func delete401(sd PickWaveSeedData) []apitest.Table {
+	url := fmt.Sprintf("/v1/inventory/pick-waves/%s", oldestWave(sd).ID)
+
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        url,
+			Token:      "&nbsp;",
+			Method:     http.MethodDelete,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "bad-sig",
+			URL:        url,
+			Token:      sd.Admins[0].Token + "A",
+			Method:     http.MethodDelete,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "authentication failed : bindings results[[{[true] map[x:false]}]] ok[true]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "no-delete-permission",
+			URL:        url,
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodDelete,
+			StatusCode: http.StatusForbidden,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission DELETE for table: inventory.pick_waves"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func delete404(sd PickWaveSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "not-found",
+			URL:        fmt.Sprintf("/v1/inventory/pick-waves/%s", uuid.NewString()),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodDelete,
+			StatusCode: http.StatusNotFound,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.NotFound, "pick wave not found"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func delete409(sd PickWaveSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "batch-claimed",
+			URL:        fmt.Sprintf("/v1/inventory/pick-waves/%s", oldestWave(sd).ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodDelete,
+			StatusCode: http.StatusConflict,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Aborted, "delete: pick wave has batches already claimed"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
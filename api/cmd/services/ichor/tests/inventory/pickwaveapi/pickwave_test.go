@@ -0,0 +1,53 @@
+package pickwaveapi_test
+
+import (
+	"testing"
+
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/pickwaveapp"
+)
+
+func Test_PickWave(t *testing.T) {
+	t.Parallel()
+
+	test := apitest.StartTest(t, "Test_PickWave")
+
+	sd, err := insertSeedData(test.DB, test.Auth)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	test.Run(t, query200(sd), "query-200")
+	test.Run(t, queryByID200(sd), "query-by-id-200")
+	test.Run(t, queryByID404(sd), "query-by-id-404")
+	test.Run(t, query401(sd), "query-401")
+
+	test.Run(t, queryBatches200(sd), "query-batches-200")
+	test.Run(t, queryBatchByID200(sd), "query-batch-by-id-200")
+	test.Run(t, queryBatchByID404(sd), "query-batch-by-id-404")
+
+	test.Run(t, plan200(sd), "plan-200")
+	test.Run(t, create200(sd), "create-200")
+	test.Run(t, create400(sd), "create-400")
+	test.Run(t, create401(sd), "create-401")
+
+	test.Run(t, claim200(sd), "claim-200")
+	test.Run(t, claim401(sd), "claim-401")
+	test.Run(t, claim409(sd), "claim-409")
+	test.Run(t, complete400(sd), "complete-400")
+
+	test.Run(t, delete200(sd), "delete-200")
+	test.Run(t, delete401(sd), "delete-401")
+	test.Run(t, delete404(sd), "delete-404")
+	test.Run(t, delete409(sd), "delete-409")
+}
+
+// firstBatch returns the first batch of the oldest seeded wave.
+func firstBatch(sd PickWaveSeedData) pickwaveapp.Batch {
+	return sd.Batches[oldestWave(sd).ID][0]
+}
+
+// oldestWave returns the first seeded wave; the seeded waves are newest first.
+func oldestWave(sd PickWaveSeedData) pickwaveapp.Wave {
+	return sd.Waves[len(sd.Waves)-1]
+}
@@ -0,0 +1,173 @@
+package pickwaveapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/pickwaveapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/app/sdk/query"
+)
+
+func query200(sd PickWaveSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "all",
+			URL:        "/v1/inventory/pick-waves?rows=10&page=1",
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodGet,
+			StatusCode: http.StatusOK,
+			GotResp:    &query.Result[pickwaveapp.Wave]{},
+			ExpResp: &query.Result[pickwaveapp.Wave]{
+				Items:       sd.Waves,
+				Total:       len(sd.Waves),
+				Page:        1,
+				RowsPerPage: 10,
+			},
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func queryByID200(sd PickWaveSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "basic",
+			URL:        fmt.Sprintf("/v1/inventory/pick-waves/%s", sd.Waves[0].ID),
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodGet,
+			StatusCode: http.StatusOK,
+			GotResp:    &pickwaveapp.Wave{},
+			ExpResp:    &sd.Waves[0],
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func queryByID404(sd PickWaveSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "not-found",
+			URL:        fmt.Sprintf("/v1/inventory/pick-waves/%s", uuid.NewString()),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodGet,
+			StatusCode: http.StatusNotFound,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.NotFound, "pick wave not found"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func query401(sd PickWaveSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        "/v1/inventory/pick-waves?rows=10&page=1",
+			Token:      "&nbsp;",
+			Method:     http.MethodGet,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "bad-sig",
+			URL:        "/v1/inventory/pick-waves?rows=10&page=1",
+			Token:      sd.Admins[0].Token + "A",
+			Method:     http.MethodGet,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "authentication failed : bindings results[[{[true] map[x:false]}]] ok[true]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func queryBatches200(sd PickWaveSeedData) []apitest.Table {
+	wave := oldestWave(sd)
+	batches := sd.Batches[wave.ID]
+
+	return []apitest.Table{
+		{
+			Name:       "by-wave",
+			URL:        fmt.Sprintf("/v1/inventory/pick-batches?rows=50&page=1&wave_id=%s", wave.ID),
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodGet,
+			StatusCode: http.StatusOK,
+			GotResp:    &query.Result[pickwaveapp.Batch]{},
+			ExpResp: &query.Result[pickwaveapp.Batch]{
+				Items:       batches,
+				Total:       len(batches),
+				Page:        1,
+				RowsPerPage: 50,
+			},
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func queryBatchByID200(sd PickWaveSeedData) []apitest.Table {
+	batch := firstBatch(sd)
+
+	return []apitest.Table{
+		{
+			Name:       "with-stops",
+			URL:        fmt.Sprintf("/v1/inventory/pick-batches/%s", batch.ID),
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodGet,
+			StatusCode: http.StatusOK,
+			GotResp:    &pickwaveapp.Batch{},
+			ExpResp:    &batch,
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*pickwaveapp.Batch)
+				if !exists {
+					return "error occurred"
+				}
+				if n := fmt.Sprint(len(gotResp.Stops)); n != gotResp.StopCount {
+					return fmt.Sprintf("stops: expected %s, got %s", gotResp.StopCount, n)
+				}
+				for _, s := range gotResp.Stops {
+					if s.Status != "pending" {
+						return fmt.Sprintf("stop %s: expected pending, got %s", s.PickTaskID, s.Status)
+					}
+				}
+				expResp := exp.(*pickwaveapp.Batch)
+				expResp.Stops = gotResp.Stops
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func queryBatchByID404(sd PickWaveSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "not-found",
+			URL:        fmt.Sprintf("/v1/inventory/pick-batches/%s", uuid.NewString()),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodGet,
+			StatusCode: http.StatusNotFound,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.NotFound, "pick batch not found"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
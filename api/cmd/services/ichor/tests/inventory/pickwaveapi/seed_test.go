@@ -0,0 +1,398 @@
+package pickwaveapi_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/domain/http/inventory/pickwaveapi"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/inventorylocationapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/pickwaveapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/warehouseapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/zoneapp"
+	"github.com/timmaaaz/ichor/app/domain/products/productapp"
+	"github.com/timmaaaz/ichor/app/domain/sales/ordersapp"
+	"github.com/timmaaaz/ichor/app/sdk/auth"
+	"github.com/timmaaaz/ichor/business/domain/core/contactinfosbus"
+	"github.com/timmaaaz/ichor/business/domain/core/currencybus"
+	"github.com/timmaaaz/ichor/business/domain/core/rolebus"
+	"github.com/timmaaaz/ichor/business/domain/core/tableaccessbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userrolebus"
+	"github.com/timmaaaz/ichor/business/domain/geography/citybus"
+	"github.com/timmaaaz/ichor/business/domain/geography/regionbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/streetbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/timezonebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/picktaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/pickwavebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/warehousebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/zonebus"
+	"github.com/timmaaaz/ichor/business/domain/products/brandbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productcategorybus"
+	"github.com/timmaaaz/ichor/business/domain/sales/customersbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/lineitemfulfillmentstatusbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/orderfulfillmentstatusbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/orderlineitemsbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/ordersbus"
+	"github.com/timmaaaz/ichor/business/sdk/dbtest"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// PickWaveSeedData is the seed data plus the released waves and their
+// batches. PendingTasks is the number of pick tasks still waiting for a wave.
+type PickWaveSeedData struct {
+	apitest.SeedData
+	Waves        []pickwaveapp.Wave
+	Batches      map[string][]pickwaveapp.Batch // by wave id
+	PendingTasks int
+}
+
+func insertSeedData(db *dbtest.Database, ath *auth.Auth) (PickWaveSeedData, error) {
+	ctx := context.Background()
+	busDomain := db.BusDomain
+
+	// =========================================================================
+	// Users
+	// =========================================================================
+
+	usrs, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.User, busDomain.User)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	tu1 := apitest.User{
+		User:  usrs[0],
+		Token: apitest.Token(busDomain.User, ath, usrs[0].Email.Address),
+	}
+
+	admins, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.Admin, busDomain.User)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding admin : %w", err)
+	}
+
+	tu2 := apitest.User{
+		User:  admins[0],
+		Token: apitest.Token(busDomain.User, ath, admins[0].Email.Address),
+	}
+
+	// =========================================================================
+	// Geography
+	// =========================================================================
+
+	const warehouseCount = 2
+
+	regions, err := busDomain.Region.Query(ctx, regionbus.QueryFilter{}, regionbus.DefaultOrderBy, page.MustParse("1", "5"))
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("querying regions : %w", err)
+	}
+
+	regionIDs := make([]uuid.UUID, 0, len(regions))
+	for _, r := range regions {
+		regionIDs = append(regionIDs, r.ID)
+	}
+
+	ctys, err := citybus.TestSeedCities(ctx, warehouseCount, regionIDs, busDomain.City)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding cities : %w", err)
+	}
+
+	ctyIDs := make([]uuid.UUID, 0, len(ctys))
+	for _, c := range ctys {
+		ctyIDs = append(ctyIDs, c.ID)
+	}
+
+	strs, err := streetbus.TestSeedStreets(ctx, warehouseCount, ctyIDs, busDomain.Street)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding streets : %w", err)
+	}
+
+	strIDs := make([]uuid.UUID, 0, len(strs))
+	for _, s := range strs {
+		strIDs = append(strIDs, s.ID)
+	}
+
+	// =========================================================================
+	// Warehouse Infrastructure
+	// =========================================================================
+
+	warehouses, err := warehousebus.TestSeedWarehouses(ctx, warehouseCount, tu1.ID, strIDs, busDomain.Warehouse)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding warehouses : %w", err)
+	}
+
+	warehouseIDs := make(uuid.UUIDs, len(warehouses))
+	for i, w := range warehouses {
+		warehouseIDs[i] = w.ID
+	}
+
+	zones, err := zonebus.TestSeedZone(ctx, 4, warehouseIDs, busDomain.Zones)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding zones : %w", err)
+	}
+
+	inventoryLocations, err := inventorylocationbus.TestSeedInventoryLocations(ctx, 5, warehouseIDs, zones, busDomain.InventoryLocation)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding inventory locations : %w", err)
+	}
+
+	locationIDs := make([]uuid.UUID, len(inventoryLocations))
+	for i, il := range inventoryLocations {
+		locationIDs[i] = il.LocationID
+	}
+
+	// =========================================================================
+	// Products
+	// =========================================================================
+
+	tzs, err := busDomain.Timezone.Query(ctx, timezonebus.QueryFilter{}, timezonebus.DefaultOrderBy, page.MustParse("1", "100"))
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("querying timezones : %w", err)
+	}
+
+	tzIDs := make([]uuid.UUID, 0, len(tzs))
+	for _, tz := range tzs {
+		tzIDs = append(tzIDs, tz.ID)
+	}
+
+	contacts, err := contactinfosbus.TestSeedContactInfos(ctx, 2, strIDs, tzIDs, busDomain.ContactInfos)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding contact info : %w", err)
+	}
+
+	contactIDs := make(uuid.UUIDs, len(contacts))
+	for i, c := range contacts {
+		contactIDs[i] = c.ID
+	}
+
+	brands, err := brandbus.TestSeedBrands(ctx, 2, contactIDs, busDomain.Brand)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding brands : %w", err)
+	}
+
+	brandIDs := make(uuid.UUIDs, len(brands))
+	for i, b := range brands {
+		brandIDs[i] = b.BrandID
+	}
+
+	pc, err := productcategorybus.TestSeedProductCategories(ctx, 2, busDomain.ProductCategory)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding product categories : %w", err)
+	}
+
+	pcIDs := make(uuid.UUIDs, len(pc))
+	for i, p := range pc {
+		pcIDs[i] = p.ProductCategoryID
+	}
+
+	products, err := productbus.TestSeedProducts(ctx, 5, brandIDs, pcIDs, busDomain.Product)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding products : %w", err)
+	}
+
+	productIDs := make(uuid.UUIDs, len(products))
+	for i, p := range products {
+		productIDs[i] = p.ProductID
+	}
+
+	// =========================================================================
+	// Sales: Customers → Orders → Order Line Items
+	// =========================================================================
+
+	customers, err := customersbus.TestSeedCustomers(ctx, 2, strIDs, contactIDs, uuid.UUIDs{tu1.ID, tu2.ID}, busDomain.Customers)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding customers : %w", err)
+	}
+
+	customerIDs := make(uuid.UUIDs, len(customers))
+	for i, c := range customers {
+		customerIDs[i] = c.ID
+	}
+
+	ofStatuses, err := orderfulfillmentstatusbus.TestSeedOrderFulfillmentStatuses(ctx, busDomain.OrderFulfillmentStatus)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding order fulfillment statuses : %w", err)
+	}
+
+	ofIDs := make(uuid.UUIDs, len(ofStatuses))
+	for i, s := range ofStatuses {
+		ofIDs[i] = s.ID
+	}
+
+	currencies, err := currencybus.TestSeedCurrencies(ctx, 2, busDomain.Currency)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding currencies : %w", err)
+	}
+
+	currencyIDs := make(uuid.UUIDs, len(currencies))
+	for i, c := range currencies {
+		currencyIDs[i] = c.ID
+	}
+
+	orders, err := ordersbus.TestSeedOrders(ctx, 3, uuid.UUIDs{tu1.ID, tu2.ID}, customerIDs, ofIDs, currencyIDs, busDomain.Order)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding orders : %w", err)
+	}
+
+	orderIDs := make(uuid.UUIDs, len(orders))
+	for i, o := range orders {
+		orderIDs[i] = o.ID
+	}
+
+	liStatuses, err := lineitemfulfillmentstatusbus.TestSeedLineItemFulfillmentStatuses(ctx, busDomain.LineItemFulfillmentStatus)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding line item fulfillment statuses : %w", err)
+	}
+
+	liStatusIDs := make(uuid.UUIDs, len(liStatuses))
+	for i, s := range liStatuses {
+		liStatusIDs[i] = s.ID
+	}
+
+	lineItems, err := orderlineitemsbus.TestSeedOrderLineItems(ctx, 5, orderIDs, productIDs, liStatusIDs, uuid.UUIDs{tu1.ID, tu2.ID}, busDomain.OrderLineItem)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding order line items : %w", err)
+	}
+
+	lineItemIDs := make(uuid.UUIDs, len(lineItems))
+	for i, li := range lineItems {
+		lineItemIDs[i] = li.ID
+	}
+
+	// =========================================================================
+	// Pick Tasks → Waves
+	// =========================================================================
+
+	createdByIDs := []uuid.UUID{tu1.ID, tu2.ID}
+
+	// Map line items back to their order IDs for FK consistency.
+	salesOrderIDs := make(uuid.UUIDs, len(lineItems))
+	for i, li := range lineItems {
+		salesOrderIDs[i] = li.OrderID
+	}
+
+	// Two releases of four tasks each give two waves, one cart per order so
+	// each wave has several batches. The last three tasks stay pending for
+	// the plan and create tests.
+	now := time.Now().UTC()
+
+	var waveIDs []uuid.UUID
+	for i, at := range []time.Time{now.Add(-2 * time.Hour), now.Add(-time.Hour)} {
+		if _, err := picktaskbus.TestSeedPickTasks(ctx, 4, salesOrderIDs, lineItemIDs, productIDs, locationIDs, createdByIDs, nil, busDomain.PickTask); err != nil {
+			return PickWaveSeedData{}, fmt.Errorf("seeding pick tasks for wave %d : %w", i, err)
+		}
+
+		ws, err := busDomain.PickWave.Create(ctx, pickwavebus.PlanRequest{ToteCount: 1, CreatedBy: tu2.ID}, at)
+		if err != nil {
+			return PickWaveSeedData{}, fmt.Errorf("seeding wave %d : %w", i, err)
+		}
+		for _, w := range ws {
+			waveIDs = append(waveIDs, w.ID)
+		}
+	}
+
+	const pendingTasks = 3
+	if _, err := picktaskbus.TestSeedPickTasks(ctx, pendingTasks, salesOrderIDs, lineItemIDs, productIDs, locationIDs, createdByIDs, nil, busDomain.PickTask); err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding pending pick tasks : %w", err)
+	}
+
+	// Read the waves and batches back so the timestamps match what the API
+	// returns.
+	waves := make([]pickwavebus.Wave, 0, len(waveIDs))
+	batches := make(map[string][]pickwaveapp.Batch, len(waveIDs))
+	for _, id := range waveIDs {
+		w, err := busDomain.PickWave.QueryByID(ctx, id)
+		if err != nil {
+			return PickWaveSeedData{}, fmt.Errorf("querying wave : %w", err)
+		}
+		waves = append(waves, w)
+
+		bs, err := busDomain.PickWave.QueryBatches(ctx, pickwavebus.BatchFilter{WaveID: &id}, page.MustParse("1", "100"))
+		if err != nil {
+			return PickWaveSeedData{}, fmt.Errorf("querying batches : %w", err)
+		}
+		batches[id.String()] = pickwaveapp.ToAppBatches(bs)
+	}
+
+	// Newest first, matching the default wave order.
+	sort.Slice(waves, func(i, j int) bool {
+		return waves[i].CreatedDate.After(waves[j].CreatedDate)
+	})
+
+	// =========================================================================
+	// Permissions
+	// =========================================================================
+
+	roles, err := rolebus.TestSeedRoles(ctx, 2, busDomain.Role)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding roles : %w", err)
+	}
+
+	roleIDs := make(uuid.UUIDs, len(roles))
+	for i, r := range roles {
+		roleIDs[i] = r.ID
+	}
+
+	userIDs := uuid.UUIDs{tu1.ID, tu2.ID}
+
+	_, err = userrolebus.TestSeedUserRoles(ctx, userIDs, roleIDs, busDomain.UserRole)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding user roles : %w", err)
+	}
+
+	_, err = tableaccessbus.TestSeedTableAccess(ctx, roleIDs, busDomain.TableAccess)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("seeding table access : %w", err)
+	}
+
+	ur1, err := busDomain.UserRole.QueryByUserID(ctx, tu1.ID)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("querying user1 roles : %w", err)
+	}
+
+	usrRoleIDs := make(uuid.UUIDs, len(ur1))
+	for i, r := range ur1 {
+		usrRoleIDs[i] = r.RoleID
+	}
+
+	tas, err := busDomain.TableAccess.QueryByRoleIDs(ctx, usrRoleIDs)
+	if err != nil {
+		return PickWaveSeedData{}, fmt.Errorf("querying table access : %w", err)
+	}
+
+	for _, ta := range tas {
+		if ta.TableName == pickwaveapi.RouteTable {
+			update := tableaccessbus.UpdateTableAccess{
+				CanCreate: dbtest.BoolPointer(false),
+				CanUpdate: dbtest.BoolPointer(false),
+				CanDelete: dbtest.BoolPointer(false),
+				CanRead:   dbtest.BoolPointer(true),
+			}
+			_, err := busDomain.TableAccess.Update(ctx, ta, update)
+			if err != nil {
+				return PickWaveSeedData{}, fmt.Errorf("updating table access : %w", err)
+			}
+		}
+	}
+
+	sd := apitest.SeedData{
+		Admins:             []apitest.User{tu2},
+		Users:              []apitest.User{tu1},
+		Warehouses:         warehouseapp.ToAppWarehouses(warehouses),
+		Zones:              zoneapp.ToAppZones(zones),
+		InventoryLocations: inventorylocationapp.ToAppInventoryLocations(inventoryLocations),
+		Products:           productapp.ToAppProducts(products),
+		Orders:             ordersapp.ToAppOrders(orders),
+	}
+
+	return PickWaveSeedData{
+		SeedData:     sd,
+		Waves:        pickwaveapp.ToAppWaves(waves),
+		Batches:      batches,
+		PendingTasks: pendingTasks,
+	}, nil
+}
@@ -0,0 +1,146 @@
+package replenishmenttaskapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/replenishmenttaskapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
+)
+
+// duePlan is the one move still due after seeding: the last face filled up to
+// its maximum from the reserve location.
+func duePlan(sd ReplenishmentSeedData) replenishmenttaskapp.Plan {
+	last := len(sd.Faces) - 1
+	face := sd.Faces[last]
+
+	return replenishmenttaskapp.Plan{
+		Tasks: []replenishmenttaskapp.PlannedTask{
+			{
+				ProductID:        sd.Products[last].ProductID,
+				FromLocationID:   sd.Reserve.LocationID,
+				FromLocationCode: sd.Reserve.LocationCode,
+				ToLocationID:     face.LocationID,
+				ToLocationCode:   face.LocationCode,
+				Quantity:         faceMaximums[last] - faceQuantity,
+				Reason:           replenishmenttaskbus.ReasonMinMax,
+			},
+		},
+	}
+}
+
+func plan200(sd ReplenishmentSeedData) []apitest.Table {
+	exp := duePlan(sd)
+
+	return []apitest.Table{
+		{
+			Name:       "last-face-due",
+			URL:        "/v1/inventory/replenishment-tasks/plan",
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input:      &replenishmenttaskapp.NewReplenishment{},
+			GotResp:    &replenishmenttaskapp.Plan{},
+			ExpResp:    &exp,
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func generate200(sd ReplenishmentSeedData) []apitest.Table {
+	planned := duePlan(sd).Tasks[0]
+
+	return []apitest.Table{
+		{
+			Name:       "last-face-due",
+			URL:        "/v1/inventory/replenishment-tasks/generate",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input:      &replenishmenttaskapp.NewReplenishment{WarehouseID: sd.Reserve.WarehouseID},
+			GotResp:    &replenishmenttaskapp.Tasks{},
+			ExpResp: &replenishmenttaskapp.Tasks{
+				{
+					ProductID:      planned.ProductID,
+					FromLocationID: planned.FromLocationID,
+					ToLocationID:   planned.ToLocationID,
+					Quantity:       fmt.Sprint(planned.Quantity),
+					QuantityMoved:  "0",
+					Reason:         planned.Reason,
+					Status:         replenishmenttaskbus.StatusPending,
+					CreatedBy:      sd.Admins[0].ID.String(),
+				},
+			},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*replenishmenttaskapp.Tasks)
+				if !exists {
+					return "error occurred"
+				}
+				expResp := exp.(*replenishmenttaskapp.Tasks)
+				if len(*gotResp) != len(*expResp) {
+					return fmt.Sprintf("expected %d tasks, got %d", len(*expResp), len(*gotResp))
+				}
+				for i := range *expResp {
+					(*expResp)[i].ID = (*gotResp)[i].ID
+					(*expResp)[i].CreatedDate = (*gotResp)[i].CreatedDate
+					(*expResp)[i].UpdatedDate = (*gotResp)[i].UpdatedDate
+				}
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func generate400(sd ReplenishmentSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "nothing-due",
+			URL:        "/v1/inventory/replenishment-tasks/generate",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input:      &replenishmenttaskapp.NewReplenishment{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.FailedPrecondition, "generate: no pick face needs replenishment"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func generate401(sd ReplenishmentSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        "/v1/inventory/replenishment-tasks/generate",
+			Token:      "&nbsp;",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			Input:      &replenishmenttaskapp.NewReplenishment{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "no-create-permission",
+			URL:        "/v1/inventory/replenishment-tasks/generate",
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusForbidden,
+			Input:      &replenishmenttaskapp.NewReplenishment{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission CREATE for table: inventory.replenishment_tasks"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package replenishmenttaskapi_test
+
+import (
+	"testing"
+
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+)
+
+func Test_ReplenishmentTask(t *testing.T) {
+	t.Parallel()
+
+	test := apitest.StartTest(t, "Test_ReplenishmentTask")
+
+	sd, err := insertSeedData(test.DB, test.Auth)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	test.Run(t, query200(sd), "query-200")
+	test.Run(t, queryByID200(sd), "query-by-id-200")
+	test.Run(t, queryByID404(sd), "query-by-id-404")
+	test.Run(t, query401(sd), "query-401")
+
+	test.Run(t, plan200(sd), "plan-200")
+	test.Run(t, generate200(sd), "generate-200")
+	test.Run(t, generate400(sd), "generate-400")
+	test.Run(t, generate401(sd), "generate-401")
+
+	test.Run(t, claim200(sd), "claim-200")
+	test.Run(t, claim401(sd), "claim-401")
+	test.Run(t, claim409(sd), "claim-409")
+	test.Run(t, complete200(sd), "complete-200")
+	test.Run(t, complete400(sd), "complete-400")
+
+	test.Run(t, cancel200(sd), "cancel-200")
+	test.Run(t, cancel409(sd), "cancel-409")
+}
@@ -0,0 +1,338 @@
+package replenishmenttaskapi_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/domain/http/inventory/replenishmenttaskapi"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/inventorylocationapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/replenishmenttaskapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/warehouseapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/zoneapp"
+	"github.com/timmaaaz/ichor/app/domain/products/productapp"
+	"github.com/timmaaaz/ichor/app/sdk/auth"
+	"github.com/timmaaaz/ichor/business/domain/core/contactinfosbus"
+	"github.com/timmaaaz/ichor/business/domain/core/rolebus"
+	"github.com/timmaaaz/ichor/business/domain/core/tableaccessbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userrolebus"
+	"github.com/timmaaaz/ichor/business/domain/geography/citybus"
+	"github.com/timmaaaz/ichor/business/domain/geography/regionbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/streetbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/timezonebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/warehousebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/zonebus"
+	"github.com/timmaaaz/ichor/business/domain/products/brandbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productcategorybus"
+	"github.com/timmaaaz/ichor/business/sdk/dbtest"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// ReplenishmentSeedData is the seed data plus the replenishment setup: three
+// pick faces below their minimum and one reserve location holding stock for
+// all of them. Tasks holds the tasks generated for the first two faces, newest
+// first; the last face is left due so plan and generate have work to find.
+type ReplenishmentSeedData struct {
+	apitest.SeedData
+	Faces   []inventorylocationapp.InventoryLocation
+	Reserve inventorylocationapp.InventoryLocation
+	Tasks   []replenishmenttaskapp.Task
+}
+
+// Stock levels for the seeded faces. Each face is filled up to its maximum
+// from the reserve location.
+const (
+	faceQuantity    = 2
+	faceMinimum     = 10
+	reserveQuantity = 100
+)
+
+var faceMaximums = []int{40, 30, 20}
+
+func insertSeedData(db *dbtest.Database, ath *auth.Auth) (ReplenishmentSeedData, error) {
+	ctx := context.Background()
+	busDomain := db.BusDomain
+
+	// =========================================================================
+	// Users
+	// =========================================================================
+
+	usrs, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.User, busDomain.User)
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	tu1 := apitest.User{
+		User:  usrs[0],
+		Token: apitest.Token(busDomain.User, ath, usrs[0].Email.Address),
+	}
+
+	admins, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.Admin, busDomain.User)
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("seeding admin : %w", err)
+	}
+
+	tu2 := apitest.User{
+		User:  admins[0],
+		Token: apitest.Token(busDomain.User, ath, admins[0].Email.Address),
+	}
+
+	// =========================================================================
+	// Geography
+	// =========================================================================
+
+	const warehouseCount = 2
+
+	regions, err := busDomain.Region.Query(ctx, regionbus.QueryFilter{}, regionbus.DefaultOrderBy, page.MustParse("1", "5"))
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("querying regions : %w", err)
+	}
+
+	regionIDs := make([]uuid.UUID, 0, len(regions))
+	for _, r := range regions {
+		regionIDs = append(regionIDs, r.ID)
+	}
+
+	ctys, err := citybus.TestSeedCities(ctx, warehouseCount, regionIDs, busDomain.City)
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("seeding cities : %w", err)
+	}
+
+	ctyIDs := make([]uuid.UUID, 0, len(ctys))
+	for _, c := range ctys {
+		ctyIDs = append(ctyIDs, c.ID)
+	}
+
+	strs, err := streetbus.TestSeedStreets(ctx, warehouseCount, ctyIDs, busDomain.Street)
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("seeding streets : %w", err)
+	}
+
+	strIDs := make([]uuid.UUID, 0, len(strs))
+	for _, s := range strs {
+		strIDs = append(strIDs, s.ID)
+	}
+
+	// =========================================================================
+	// Warehouse Infrastructure
+	// =========================================================================
+
+	warehouses, err := warehousebus.TestSeedWarehouses(ctx, warehouseCount, tu1.ID, strIDs, busDomain.Warehouse)
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("seeding warehouses : %w", err)
+	}
+
+	warehouseIDs := make(uuid.UUIDs, len(warehouses))
+	for i, w := range warehouses {
+		warehouseIDs[i] = w.ID
+	}
+
+	zones, err := zonebus.TestSeedZone(ctx, 4, warehouseIDs, busDomain.Zones)
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("seeding zones : %w", err)
+	}
+
+	// The faces and the reserve location share the first zone, so they share
+	// a warehouse.
+	newLocation := func(code string, pick bool) (inventorylocationbus.InventoryLocation, error) {
+		return busDomain.InventoryLocation.Create(ctx, inventorylocationbus.NewInventoryLocation{
+			WarehouseID:       zones[0].WarehouseID,
+			ZoneID:            zones[0].ZoneID,
+			Aisle:             "R",
+			Rack:              code,
+			Shelf:             "1",
+			Bin:               "1",
+			LocationCode:      &code,
+			IsPickLocation:    pick,
+			IsReserveLocation: !pick,
+			MaxCapacity:       1000,
+		})
+	}
+
+	faces := make([]inventorylocationbus.InventoryLocation, len(faceMaximums))
+	for i := range faces {
+		if faces[i], err = newLocation(fmt.Sprintf("RPL-FACE-%d", i+1), true); err != nil {
+			return ReplenishmentSeedData{}, fmt.Errorf("seeding pick face %d : %w", i, err)
+		}
+	}
+
+	reserve, err := newLocation("RPL-RESERVE", false)
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("seeding reserve location : %w", err)
+	}
+
+	// =========================================================================
+	// Products
+	// =========================================================================
+
+	tzs, err := busDomain.Timezone.Query(ctx, timezonebus.QueryFilter{}, timezonebus.DefaultOrderBy, page.MustParse("1", "100"))
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("querying timezones : %w", err)
+	}
+
+	tzIDs := make([]uuid.UUID, 0, len(tzs))
+	for _, tz := range tzs {
+		tzIDs = append(tzIDs, tz.ID)
+	}
+
+	contacts, err := contactinfosbus.TestSeedContactInfos(ctx, 2, strIDs, tzIDs, busDomain.ContactInfos)
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("seeding contact info : %w", err)
+	}
+
+	contactIDs := make(uuid.UUIDs, len(contacts))
+	for i, c := range contacts {
+		contactIDs[i] = c.ID
+	}
+
+	brands, err := brandbus.TestSeedBrands(ctx, 2, contactIDs, busDomain.Brand)
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("seeding brands : %w", err)
+	}
+
+	brandIDs := make(uuid.UUIDs, len(brands))
+	for i, b := range brands {
+		brandIDs[i] = b.BrandID
+	}
+
+	pc, err := productcategorybus.TestSeedProductCategories(ctx, 2, busDomain.ProductCategory)
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("seeding product categories : %w", err)
+	}
+
+	pcIDs := make(uuid.UUIDs, len(pc))
+	for i, p := range pc {
+		pcIDs[i] = p.ProductCategoryID
+	}
+
+	products, err := productbus.TestSeedProducts(ctx, len(faces), brandIDs, pcIDs, busDomain.Product)
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("seeding products : %w", err)
+	}
+
+	// =========================================================================
+	// Inventory Items: face i holds product i, the reserve holds both
+	// =========================================================================
+
+	for i, face := range faces {
+		if _, err := busDomain.InventoryItem.Create(ctx, inventoryitembus.NewInventoryItem{
+			ProductID:    products[i].ProductID,
+			LocationID:   face.LocationID,
+			Quantity:     faceQuantity,
+			MinimumStock: faceMinimum,
+			MaximumStock: faceMaximums[i],
+		}); err != nil {
+			return ReplenishmentSeedData{}, fmt.Errorf("seeding pick face stock %d : %w", i, err)
+		}
+
+		if _, err := busDomain.InventoryItem.Create(ctx, inventoryitembus.NewInventoryItem{
+			ProductID:  products[i].ProductID,
+			LocationID: reserve.LocationID,
+			Quantity:   reserveQuantity,
+		}); err != nil {
+			return ReplenishmentSeedData{}, fmt.Errorf("seeding reserve stock %d : %w", i, err)
+		}
+	}
+
+	// =========================================================================
+	// Replenishment Tasks
+	// =========================================================================
+
+	var tasks []replenishmenttaskbus.Task
+	for i := range len(faces) - 1 {
+		at := time.Now().Add(time.Duration(i-len(faces)) * time.Hour)
+
+		generated, err := busDomain.ReplenishmentTask.Generate(ctx, replenishmenttaskbus.PlanRequest{
+			ProductID: &products[i].ProductID,
+			CreatedBy: tu2.ID,
+		}, at)
+		if err != nil {
+			return ReplenishmentSeedData{}, fmt.Errorf("seeding replenishment task %d : %w", i, err)
+		}
+
+		// Read the task back so the timestamps carry the database's precision,
+		// and keep the list newest first to match the default order.
+		task, err := busDomain.ReplenishmentTask.QueryByID(ctx, generated[0].ID)
+		if err != nil {
+			return ReplenishmentSeedData{}, fmt.Errorf("querying replenishment task %d : %w", i, err)
+		}
+		tasks = append([]replenishmenttaskbus.Task{task}, tasks...)
+	}
+
+	// =========================================================================
+	// Permissions
+	// =========================================================================
+
+	roles, err := rolebus.TestSeedRoles(ctx, 2, busDomain.Role)
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("seeding roles : %w", err)
+	}
+
+	roleIDs := make(uuid.UUIDs, len(roles))
+	for i, r := range roles {
+		roleIDs[i] = r.ID
+	}
+
+	userIDs := uuid.UUIDs{tu1.ID, tu2.ID}
+
+	_, err = userrolebus.TestSeedUserRoles(ctx, userIDs, roleIDs, busDomain.UserRole)
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("seeding user roles : %w", err)
+	}
+
+	_, err = tableaccessbus.TestSeedTableAccess(ctx, roleIDs, busDomain.TableAccess)
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("seeding table access : %w", err)
+	}
+
+	ur1, err := busDomain.UserRole.QueryByUserID(ctx, tu1.ID)
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("querying user1 roles : %w", err)
+	}
+
+	usrRoleIDs := make(uuid.UUIDs, len(ur1))
+	for i, r := range ur1 {
+		usrRoleIDs[i] = r.RoleID
+	}
+
+	tas, err := busDomain.TableAccess.QueryByRoleIDs(ctx, usrRoleIDs)
+	if err != nil {
+		return ReplenishmentSeedData{}, fmt.Errorf("querying table access : %w", err)
+	}
+
+	for _, ta := range tas {
+		if ta.TableName == replenishmenttaskapi.RouteTable {
+			update := tableaccessbus.UpdateTableAccess{
+				CanCreate: dbtest.BoolPointer(false),
+				CanUpdate: dbtest.BoolPointer(false),
+				CanDelete: dbtest.BoolPointer(false),
+				CanRead:   dbtest.BoolPointer(true),
+			}
+			_, err := busDomain.TableAccess.Update(ctx, ta, update)
+			if err != nil {
+				return ReplenishmentSeedData{}, fmt.Errorf("updating table access : %w", err)
+			}
+		}
+	}
+
+	return ReplenishmentSeedData{
+		SeedData: apitest.SeedData{
+			Admins:             []apitest.User{tu2},
+			Users:              []apitest.User{tu1},
+			Warehouses:         warehouseapp.ToAppWarehouses(warehouses),
+			Zones:              zoneapp.ToAppZones(zones),
+			InventoryLocations: inventorylocationapp.ToAppInventoryLocations(append(faces, reserve)),
+			Products:           productapp.ToAppProducts(products),
+		},
+		Faces:   inventorylocationapp.ToAppInventoryLocations(faces),
+		Reserve: inventorylocationapp.ToAppInventoryLocation(reserve),
+		Tasks:   replenishmenttaskapp.ToAppTasks(tasks),
+	}, nil
+}
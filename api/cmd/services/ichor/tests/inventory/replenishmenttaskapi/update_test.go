@@ -0,0 +1,287 @@
+package replenishmenttaskapi_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/replenishmenttaskapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
+	"github.com/timmaaaz/ichor/business/sdk/dbtest"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// claimedTask is the seeded task the claim and complete tests work on; the
+// other seeded task is left pending for the cancel tests.
+func claimedTask(sd ReplenishmentSeedData) replenishmenttaskapp.Task {
+	return sd.Tasks[len(sd.Tasks)-1]
+}
+
+func claim200(sd ReplenishmentSeedData) []apitest.Table {
+	return claimTask200(sd, claimedTask(sd))
+}
+
+func claimTask200(sd ReplenishmentSeedData, task replenishmenttaskapp.Task) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "pending",
+			URL:        fmt.Sprintf("/v1/inventory/replenishment-tasks/%s/claim", task.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			GotResp:    &replenishmenttaskapp.Task{},
+			ExpResp:    &task,
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*replenishmenttaskapp.Task)
+				if !exists {
+					return "error occurred"
+				}
+				if gotResp.AssignedAt == "" {
+					return "expected assigned_at to be set"
+				}
+				expResp := exp.(*replenishmenttaskapp.Task)
+				expResp.Status = replenishmenttaskbus.StatusInProgress
+				expResp.AssignedTo = sd.Admins[0].ID.String()
+				expResp.AssignedAt = gotResp.AssignedAt
+				expResp.UpdatedDate = gotResp.UpdatedDate
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func claim401(sd ReplenishmentSeedData) []apitest.Table {
+	url := fmt.Sprintf("/v1/inventory/replenishment-tasks/%s/claim", claimedTask(sd).ID)
+
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        url,
+			Token:      "&nbsp;",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "bad-sig",
+			URL:        url,
+			Token:      sd.Admins[0].Token + "A",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "authentication failed : bindings results[[{[true] map[x:false]}]] ok[true]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "no-update-permission",
+			URL:        url,
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusForbidden,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission UPDATE for table: inventory.replenishment_tasks"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func claim409(sd ReplenishmentSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "already-claimed",
+			URL:        fmt.Sprintf("/v1/inventory/replenishment-tasks/%s/claim", claimedTask(sd).ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusConflict,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Aborted, "claim: replenishment task is already claimed"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func complete200(sd ReplenishmentSeedData) []apitest.Table {
+	return completeTask200(sd, claimedTask(sd), "")
+}
+
+// completeTask200 completes a claimed task; an empty quantity moves the whole
+// task.
+func completeTask200(sd ReplenishmentSeedData, task replenishmenttaskapp.Task, quantity string) []apitest.Table {
+	moved := quantity
+	if moved == "" {
+		moved = task.Quantity
+	}
+
+	return []apitest.Table{
+		{
+			Name:       "claimed",
+			URL:        fmt.Sprintf("/v1/inventory/replenishment-tasks/%s/complete", task.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input:      &replenishmenttaskapp.CompleteTask{Quantity: quantity},
+			GotResp:    &replenishmenttaskapp.Task{},
+			ExpResp:    &task,
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*replenishmenttaskapp.Task)
+				if !exists {
+					return "error occurred"
+				}
+				if gotResp.CompletedAt == "" {
+					return "expected completed_at to be set"
+				}
+				expResp := exp.(*replenishmenttaskapp.Task)
+				expResp.Status = replenishmenttaskbus.StatusCompleted
+				expResp.QuantityMoved = moved
+				expResp.AssignedTo = gotResp.AssignedTo
+				expResp.AssignedAt = gotResp.AssignedAt
+				expResp.CompletedBy = sd.Admins[0].ID.String()
+				expResp.CompletedAt = gotResp.CompletedAt
+				expResp.UpdatedDate = gotResp.UpdatedDate
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func complete400(sd ReplenishmentSeedData) []apitest.Table {
+	return completeTask400(sd, claimedTask(sd))
+}
+
+func completeTask400(sd ReplenishmentSeedData, task replenishmenttaskapp.Task) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "already-completed",
+			URL:        fmt.Sprintf("/v1/inventory/replenishment-tasks/%s/complete", task.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input:      &replenishmenttaskapp.CompleteTask{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.FailedPrecondition, "complete: replenishment task is not in progress"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func cancel200(sd ReplenishmentSeedData) []apitest.Table {
+	task := sd.Tasks[0]
+
+	return []apitest.Table{
+		{
+			Name:       "pending",
+			URL:        fmt.Sprintf("/v1/inventory/replenishment-tasks/%s/cancel", task.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			GotResp:    &replenishmenttaskapp.Task{},
+			ExpResp:    &task,
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*replenishmenttaskapp.Task)
+				if !exists {
+					return "error occurred"
+				}
+				expResp := exp.(*replenishmenttaskapp.Task)
+				expResp.Status = replenishmenttaskbus.StatusCancelled
+				expResp.UpdatedDate = gotResp.UpdatedDate
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func cancel409(sd ReplenishmentSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "already-cancelled",
+			URL:        fmt.Sprintf("/v1/inventory/replenishment-tasks/%s/cancel", sd.Tasks[0].ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusConflict,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Aborted, "cancel: replenishment task is already completed or cancelled"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "already-completed",
+			URL:        fmt.Sprintf("/v1/inventory/replenishment-tasks/%s/cancel", claimedTask(sd).ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusConflict,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Aborted, "cancel: replenishment task is already completed or cancelled"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+// =============================================================================
+
+// Test_ReplenishmentTask_Complete completes a task for part of its quantity
+// and checks the stock moved from reserve to the pick face exactly once, even
+// when the completion is sent again.
+func Test_ReplenishmentTask_Complete(t *testing.T) {
+	t.Parallel()
+
+	test := apitest.StartTest(t, "Test_ReplenishmentTask_Complete")
+
+	sd, err := insertSeedData(test.DB, test.Auth)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	task := claimedTask(sd)
+	full, err := strconv.Atoi(task.Quantity)
+	if err != nil {
+		t.Fatalf("parsing task quantity %q: %s", task.Quantity, err)
+	}
+	moved := full - 5
+
+	test.Run(t, claimTask200(sd, task), "claim-200")
+	test.Run(t, completeTask200(sd, task, strconv.Itoa(moved)), "complete-200")
+	test.Run(t, completeTask400(sd, task), "complete-400")
+
+	ctx := context.Background()
+	checkQuantity(ctx, t, test.DB, task.ProductID, task.ToLocationID, faceQuantity+moved)
+	checkQuantity(ctx, t, test.DB, task.ProductID, task.FromLocationID, reserveQuantity-moved)
+}
+
+func checkQuantity(ctx context.Context, t *testing.T, db *dbtest.Database, productID, locationID string, want int) {
+	t.Helper()
+
+	pid, lid := uuid.MustParse(productID), uuid.MustParse(locationID)
+
+	items, err := db.BusDomain.InventoryItem.Query(ctx, inventoryitembus.QueryFilter{ProductID: &pid, LocationID: &lid}, inventoryitembus.DefaultOrderBy, page.MustParse("1", "10"))
+	if err != nil {
+		t.Fatalf("querying stock at %s: %s", locationID, err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("stock at %s: expected 1 item, got %d", locationID, len(items))
+	}
+	if items[0].Quantity != want {
+		t.Fatalf("stock at %s: expected %d, got %d", locationID, want, items[0].Quantity)
+	}
+}
@@ -67,7 +67,7 @@ func Test_TransferOrderExecute_NoPhantomOnRollback(t *testing.T) {
 	approverID := sd.Admins[0].ID
 	approved, err := db.BusDomain.TransferOrder.Approve(ctx, to, approverID, "")
 	require.NoError(t, err, "approve pending -> approved")
-	inTransit, err := db.BusDomain.TransferOrder.Claim(ctx, approved, approverID)
+	inTransit, _, err := db.BusDomain.TransferOrder.Claim(ctx, approved, approverID)
 	require.NoError(t, err, "claim approved -> in_transit")
 	require.Equal(t, "in_transit", inTransit.Status, "transfer order must be in_transit before execute")
 
@@ -75,7 +75,8 @@ func Test_TransferOrderExecute_NoPhantomOnRollback(t *testing.T) {
 	before := countCascadeOutbox(t, db)
 
 	// Execute via HTTP so the real auth middleware supplies the userID mid.GetUserID requires.
-	// No inventory exists at the source location -> DecrementQuantity returns ErrInsufficientStock
+	// The bus-level Claim above moved no stock, so nothing exists at the in-transit location
+	// -> DecrementQuantity returns ErrInsufficientStock
 	// (FailedPrecondition -> 400) and the handler transaction rolls back.
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodPost,
@@ -0,0 +1,177 @@
+package landedcostapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/procurement/landedcostapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/procurement/landedcostbus"
+)
+
+func preview200(sd LandedCostSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "draft",
+			URL:        fmt.Sprintf("/v1/procurement/landed-costs/%s/preview", sd.Freight.ID),
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodGet,
+			StatusCode: http.StatusOK,
+			GotResp:    &landedcostapp.Preview{},
+			ExpResp:    &sd.Preview,
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*landedcostapp.Preview)
+				if !exists {
+					return "error occurred"
+				}
+				// Previewed allocations are not stored, so they get new ids
+				// each time.
+				expResp := exp.(*landedcostapp.Preview)
+				if len(gotResp.LandedCost.Allocations) != len(expResp.LandedCost.Allocations) {
+					return fmt.Sprintf("expected %d allocations, got %d", len(expResp.LandedCost.Allocations), len(gotResp.LandedCost.Allocations))
+				}
+				for i := range gotResp.LandedCost.Allocations {
+					gotResp.LandedCost.Allocations[i].ID = expResp.LandedCost.Allocations[i].ID
+				}
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func apply200(sd LandedCostSeedData) []apitest.Table {
+	lc := sd.Freight
+
+	return []apitest.Table{
+		{
+			Name:       "draft",
+			URL:        fmt.Sprintf("/v1/procurement/landed-costs/%s/apply", lc.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			GotResp:    &landedcostapp.LandedCost{},
+			ExpResp:    &lc,
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*landedcostapp.LandedCost)
+				if !exists {
+					return "error occurred"
+				}
+				if gotResp.AppliedDate == "" {
+					return "expected applied_date to be set"
+				}
+				if len(gotResp.Allocations) != len(sd.Preview.LandedCost.Allocations) {
+					return fmt.Sprintf("expected %d allocations, got %d", len(sd.Preview.LandedCost.Allocations), len(gotResp.Allocations))
+				}
+				expResp := exp.(*landedcostapp.LandedCost)
+				expResp.Status = landedcostbus.StatusApplied
+				expResp.AppliedBy = sd.Admins[0].ID.String()
+				expResp.AppliedDate = gotResp.AppliedDate
+				expResp.UpdatedBy = sd.Admins[0].ID.String()
+				expResp.UpdatedDate = gotResp.UpdatedDate
+				expResp.Allocations = make([]landedcostapp.Allocation, len(sd.Preview.LandedCost.Allocations))
+				for i, a := range sd.Preview.LandedCost.Allocations {
+					a.ID = gotResp.Allocations[i].ID
+					expResp.Allocations[i] = a
+				}
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func apply400(sd LandedCostSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "no-product-cost",
+			URL:        fmt.Sprintf("/v1/procurement/landed-costs/%s/apply", sd.Uncosted.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.FailedPrecondition, "apply: product[%s]: product has no cost record to land the cost on", sd.Products[2].ProductID),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func apply401(sd LandedCostSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "no-update-permission",
+			URL:        fmt.Sprintf("/v1/procurement/landed-costs/%s/apply", sd.Freight.ID),
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusForbidden,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission UPDATE for table: procurement.landed_costs"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func apply409(sd LandedCostSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "already-applied",
+			URL:        fmt.Sprintf("/v1/procurement/landed-costs/%s/apply", sd.Freight.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusConflict,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Aborted, "apply: landed cost is already applied or cancelled"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func cancel200(sd LandedCostSeedData) []apitest.Table {
+	lc := sd.Spare
+
+	return []apitest.Table{
+		{
+			Name:       "draft",
+			URL:        fmt.Sprintf("/v1/procurement/landed-costs/%s/cancel", lc.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			GotResp:    &landedcostapp.LandedCost{},
+			ExpResp:    &lc,
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*landedcostapp.LandedCost)
+				if !exists {
+					return "error occurred"
+				}
+				expResp := exp.(*landedcostapp.LandedCost)
+				expResp.Status = landedcostbus.StatusCancelled
+				expResp.UpdatedBy = sd.Admins[0].ID.String()
+				expResp.UpdatedDate = gotResp.UpdatedDate
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func cancel409(sd LandedCostSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "already-cancelled",
+			URL:        fmt.Sprintf("/v1/procurement/landed-costs/%s/cancel", sd.Spare.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusConflict,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Aborted, "cancel: landed cost is already applied or cancelled"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
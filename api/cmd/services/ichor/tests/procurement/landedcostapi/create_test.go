@@ -0,0 +1,137 @@
+package landedcostapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/procurement/landedcostapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/procurement/landedcostbus"
+)
+
+func newLandedCost(sd LandedCostSeedData, amount string, lineItemIDs ...string) *landedcostapp.NewLandedCost {
+	return &landedcostapp.NewLandedCost{
+		PurchaseOrderID: sd.PurchaseOrders[0].ID,
+		ReferenceNumber: "LC-CREATE",
+		LineItemIDs:     lineItemIDs,
+		Charges: []landedcostapp.NewCharge{{
+			ChargeType:       "duty",
+			AllocationMethod: landedcostbus.AllocateByQuantity,
+			Amount:           amount,
+		}},
+	}
+}
+
+func create200(sd LandedCostSeedData) []apitest.Table {
+	lineItemID := sd.PurchaseOrderLineItems[0].ID
+
+	return []apitest.Table{
+		{
+			Name:       "basic",
+			URL:        "/v1/procurement/landed-costs",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input:      newLandedCost(sd, "25.50", lineItemID),
+			GotResp:    &landedcostapp.LandedCost{},
+			ExpResp: &landedcostapp.LandedCost{
+				PurchaseOrderID: sd.PurchaseOrders[0].ID,
+				ReferenceNumber: "LC-CREATE",
+				CurrencyID:      sd.PurchaseOrders[0].CurrencyID,
+				LineItemIDs:     []string{lineItemID},
+				Status:          landedcostbus.StatusDraft,
+				TotalCharges:    "25.50",
+				CreatedBy:       sd.Admins[0].ID.String(),
+				UpdatedBy:       sd.Admins[0].ID.String(),
+				Charges: []landedcostapp.Charge{{
+					ChargeType:       "duty",
+					AllocationMethod: landedcostbus.AllocateByQuantity,
+					Amount:           "25.50",
+				}},
+				Allocations: []landedcostapp.Allocation{},
+			},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*landedcostapp.LandedCost)
+				if !exists {
+					return "error occurred"
+				}
+				if len(gotResp.Charges) != 1 {
+					return fmt.Sprintf("expected 1 charge, got %d", len(gotResp.Charges))
+				}
+				expResp := exp.(*landedcostapp.LandedCost)
+				expResp.ID = gotResp.ID
+				expResp.CreatedDate = gotResp.CreatedDate
+				expResp.UpdatedDate = gotResp.UpdatedDate
+				expResp.Charges[0].ID = gotResp.Charges[0].ID
+				expResp.Charges[0].LandedCostID = gotResp.ID
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func create400(sd LandedCostSeedData) []apitest.Table {
+	otherLineItemID := sd.PurchaseOrderLineItems[2].ID
+
+	return []apitest.Table{
+		{
+			Name:       "non-positive-charge",
+			URL:        "/v1/procurement/landed-costs",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input:      newLandedCost(sd, "0"),
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.InvalidArgument, "create: invalid landed cost: charge amount must be positive"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "line-not-on-order",
+			URL:        "/v1/procurement/landed-costs",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input:      newLandedCost(sd, "10", otherLineItemID),
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.InvalidArgument, "create: invalid landed cost: line item %s is not on the purchase order", otherLineItemID),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func create401(sd LandedCostSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        "/v1/procurement/landed-costs",
+			Token:      "&nbsp;",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			Input:      newLandedCost(sd, "10"),
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "no-create-permission",
+			URL:        "/v1/procurement/landed-costs",
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusForbidden,
+			Input:      newLandedCost(sd, "10"),
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission CREATE for table: procurement.landed_costs"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
@@ -0,0 +1,64 @@
+package landedcostapi_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/business/domain/products/productcostbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+func Test_LandedCost(t *testing.T) {
+	t.Parallel()
+
+	test := apitest.StartTest(t, "Test_LandedCost")
+
+	sd, err := insertSeedData(test.DB, test.Auth)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	test.Run(t, query200(sd), "query-200")
+	test.Run(t, queryByID200(sd), "query-by-id-200")
+	test.Run(t, queryByID404(sd), "query-by-id-404")
+	test.Run(t, query401(sd), "query-401")
+
+	test.Run(t, create200(sd), "create-200")
+	test.Run(t, create400(sd), "create-400")
+	test.Run(t, create401(sd), "create-401")
+
+	test.Run(t, preview200(sd), "preview-200")
+
+	test.Run(t, apply401(sd), "apply-401")
+	test.Run(t, apply200(sd), "apply-200")
+	test.Run(t, apply400(sd), "apply-400")
+	test.Run(t, apply409(sd), "apply-409")
+	checkProductCosts(t, test, sd)
+
+	test.Run(t, cancel200(sd), "cancel-200")
+	test.Run(t, cancel409(sd), "cancel-409")
+}
+
+// checkProductCosts checks the applied landed cost set the landed cost of
+// each product's cost record to the unit cost the preview gave it.
+func checkProductCosts(t *testing.T, test *apitest.Test, sd LandedCostSeedData) {
+	t.Helper()
+
+	for _, p := range sd.Preview.Products {
+		productID := uuid.MustParse(p.ProductID)
+		costs, err := test.DB.BusDomain.ProductCost.Query(context.Background(), productcostbus.QueryFilter{ProductID: &productID},
+			order.NewBy(productcostbus.OrderByEffectiveDate, order.DESC), page.MustParse("1", "1"))
+		if err != nil {
+			t.Fatalf("querying product costs: %s", err)
+		}
+		if len(costs) != 1 {
+			t.Fatalf("product[%s] costs: expected 1, got %d", p.ProductID, len(costs))
+		}
+		if got := costs[0].LandedCost.Value(); got != p.UnitCost {
+			t.Fatalf("product[%s] landed cost: expected %s, got %s", p.ProductID, p.UnitCost, got)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+package landedcostapi_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/domain/http/procurement/landedcostapi"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/procurement/landedcostapp"
+	"github.com/timmaaaz/ichor/app/domain/procurement/purchaseorderapp"
+	"github.com/timmaaaz/ichor/app/domain/procurement/purchaseorderlineitemapp"
+	"github.com/timmaaaz/ichor/app/domain/products/productapp"
+	"github.com/timmaaaz/ichor/app/sdk/auth"
+	"github.com/timmaaaz/ichor/business/domain/core/contactinfosbus"
+	"github.com/timmaaaz/ichor/business/domain/core/currencybus"
+	"github.com/timmaaaz/ichor/business/domain/core/rolebus"
+	"github.com/timmaaaz/ichor/business/domain/core/tableaccessbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userrolebus"
+	"github.com/timmaaaz/ichor/business/domain/geography/citybus"
+	"github.com/timmaaaz/ichor/business/domain/geography/regionbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/streetbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/timezonebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/warehousebus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/landedcostbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitembus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitemstatusbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderstatusbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierproductbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierproductbus/types"
+	"github.com/timmaaaz/ichor/business/domain/products/brandbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productcategorybus"
+	"github.com/timmaaaz/ichor/business/domain/products/productcostbus"
+	"github.com/timmaaaz/ichor/business/sdk/dbtest"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// LandedCostSeedData is the seed data plus three draft landed costs. The
+// first purchase order has two lines, fully received, whose products have
+// cost records; the second has one received line whose product has none.
+// Freight and Spare are drafts on the first order and Uncosted is a draft on
+// the second. Preview is what applying Freight now would produce.
+type LandedCostSeedData struct {
+	apitest.SeedData
+	LandedCosts []landedcostapp.LandedCost // by id
+	Freight     landedcostapp.LandedCost
+	Spare       landedcostapp.LandedCost
+	Uncosted    landedcostapp.LandedCost
+	Preview     landedcostapp.Preview
+}
+
+func insertSeedData(db *dbtest.Database, ath *auth.Auth) (LandedCostSeedData, error) {
+	ctx := context.Background()
+	busDomain := db.BusDomain
+
+	// =========================================================================
+	// Users
+	// =========================================================================
+
+	usrs, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.User, busDomain.User)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	tu1 := apitest.User{
+		User:  usrs[0],
+		Token: apitest.Token(busDomain.User, ath, usrs[0].Email.Address),
+	}
+
+	admins, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.Admin, busDomain.User)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding admin : %w", err)
+	}
+
+	tu2 := apitest.User{
+		User:  admins[0],
+		Token: apitest.Token(busDomain.User, ath, admins[0].Email.Address),
+	}
+
+	// =========================================================================
+	// Geography
+	// =========================================================================
+
+	regions, err := busDomain.Region.Query(ctx, regionbus.QueryFilter{}, regionbus.DefaultOrderBy, page.MustParse("1", "5"))
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("querying regions : %w", err)
+	}
+
+	regionIDs := make([]uuid.UUID, 0, len(regions))
+	for _, r := range regions {
+		regionIDs = append(regionIDs, r.ID)
+	}
+
+	ctys, err := citybus.TestSeedCities(ctx, 2, regionIDs, busDomain.City)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding cities : %w", err)
+	}
+
+	ctyIDs := make([]uuid.UUID, 0, len(ctys))
+	for _, c := range ctys {
+		ctyIDs = append(ctyIDs, c.ID)
+	}
+
+	strs, err := streetbus.TestSeedStreets(ctx, 2, ctyIDs, busDomain.Street)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding streets : %w", err)
+	}
+
+	strIDs := make([]uuid.UUID, 0, len(strs))
+	for _, s := range strs {
+		strIDs = append(strIDs, s.ID)
+	}
+
+	tzs, err := busDomain.Timezone.Query(ctx, timezonebus.QueryFilter{}, timezonebus.DefaultOrderBy, page.MustParse("1", "100"))
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("querying timezones : %w", err)
+	}
+
+	tzIDs := make([]uuid.UUID, 0, len(tzs))
+	for _, tz := range tzs {
+		tzIDs = append(tzIDs, tz.ID)
+	}
+
+	contacts, err := contactinfosbus.TestSeedContactInfos(ctx, 2, strIDs, tzIDs, busDomain.ContactInfos)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding contact info : %w", err)
+	}
+
+	contactIDs := make(uuid.UUIDs, len(contacts))
+	for i, c := range contacts {
+		contactIDs[i] = c.ID
+	}
+
+	warehouses, err := warehousebus.TestSeedWarehouses(ctx, 1, tu1.ID, strIDs, busDomain.Warehouse)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding warehouses : %w", err)
+	}
+
+	warehouseIDs := make(uuid.UUIDs, len(warehouses))
+	for i, w := range warehouses {
+		warehouseIDs[i] = w.ID
+	}
+
+	// =========================================================================
+	// Products and Suppliers
+	// =========================================================================
+
+	brands, err := brandbus.TestSeedBrands(ctx, 2, contactIDs, busDomain.Brand)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding brands : %w", err)
+	}
+
+	brandIDs := make(uuid.UUIDs, len(brands))
+	for i, b := range brands {
+		brandIDs[i] = b.BrandID
+	}
+
+	pc, err := productcategorybus.TestSeedProductCategories(ctx, 2, busDomain.ProductCategory)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding product categories : %w", err)
+	}
+
+	pcIDs := make(uuid.UUIDs, len(pc))
+	for i, p := range pc {
+		pcIDs[i] = p.ProductCategoryID
+	}
+
+	products, err := productbus.TestSeedProducts(ctx, 3, brandIDs, pcIDs, busDomain.Product)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding products : %w", err)
+	}
+
+	suppliers, err := supplierbus.TestSeedSuppliers(ctx, 1, contactIDs, busDomain.Supplier)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding suppliers : %w", err)
+	}
+
+	supplierProductIDs := make(uuid.UUIDs, len(products))
+	for i, p := range products {
+		sp, err := busDomain.SupplierProduct.Create(ctx, supplierproductbus.NewSupplierProduct{
+			SupplierID:         suppliers[0].SupplierID,
+			ProductID:          p.ProductID,
+			SupplierPartNumber: fmt.Sprintf("LC-SEED-%d", i),
+			MinOrderQuantity:   1,
+			LeadTimeDays:       5,
+			UnitCost:           types.MustParseMoney("10.00"),
+		})
+		if err != nil {
+			return LandedCostSeedData{}, fmt.Errorf("seeding supplier product %d : %w", i, err)
+		}
+		supplierProductIDs[i] = sp.SupplierProductID
+	}
+
+	// =========================================================================
+	// Purchase Orders
+	// =========================================================================
+
+	poStatuses, err := purchaseorderstatusbus.TestSeedPurchaseOrderStatuses(ctx, 1, busDomain.PurchaseOrderStatus)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding purchase order statuses : %w", err)
+	}
+
+	lineItemStatuses, err := purchaseorderlineitemstatusbus.TestSeedPurchaseOrderLineItemStatuses(ctx, 1, busDomain.PurchaseOrderLineItemStatus)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding line item statuses : %w", err)
+	}
+
+	currencies, err := currencybus.TestSeedCurrencies(ctx, 1, busDomain.Currency)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding currencies : %w", err)
+	}
+
+	currencyIDs := uuid.UUIDs{currencies[0].ID}
+
+	purchaseOrders, err := purchaseorderbus.TestSeedPurchaseOrders(ctx, 2, uuid.UUIDs{suppliers[0].SupplierID}, uuid.UUIDs{poStatuses[0].ID}, warehouseIDs, strIDs, uuid.UUIDs{tu2.ID}, currencyIDs, busDomain.PurchaseOrder)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding purchase orders : %w", err)
+	}
+
+	// Lines 0 and 1 are on the first order, line 2 on the second.
+	poIDs := uuid.UUIDs{purchaseOrders[0].ID, purchaseOrders[0].ID, purchaseOrders[1].ID}
+
+	seededLines, err := purchaseorderlineitembus.TestSeedPurchaseOrderLineItems(ctx, 3, poIDs, supplierProductIDs, uuid.UUIDs{lineItemStatuses[0].ID}, uuid.UUIDs{tu2.ID}, busDomain.PurchaseOrderLineItem)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding purchase order line items : %w", err)
+	}
+
+	lineItems := make([]purchaseorderlineitembus.PurchaseOrderLineItem, len(seededLines))
+	for i, li := range seededLines {
+		received := li.QuantityOrdered
+		updated, err := busDomain.PurchaseOrderLineItem.Update(ctx, li, purchaseorderlineitembus.UpdatePurchaseOrderLineItem{
+			QuantityReceived: &received,
+			UpdatedBy:        &tu2.ID,
+		})
+		if err != nil {
+			return LandedCostSeedData{}, fmt.Errorf("receiving purchase order line item %d : %w", i, err)
+		}
+		lineItems[i] = updated
+	}
+
+	// Only the products on the first order have cost records.
+	if _, err := productcostbus.TestSeedProductCosts(ctx, 2, uuid.UUIDs{products[0].ProductID, products[1].ProductID}, currencyIDs, busDomain.ProductCost); err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding product costs : %w", err)
+	}
+
+	// =========================================================================
+	// Landed Costs
+	// =========================================================================
+
+	newLandedCost := func(po purchaseorderbus.PurchaseOrder, ref string) (landedcostbus.LandedCost, error) {
+		lc, err := busDomain.LandedCost.Create(ctx, landedcostbus.NewLandedCost{
+			PurchaseOrderID: po.ID,
+			ReferenceNumber: ref,
+			Charges: []landedcostbus.NewCharge{{
+				ChargeType:       "freight",
+				AllocationMethod: landedcostbus.AllocateByValue,
+				Amount:           100,
+			}},
+			CreatedBy: tu2.ID,
+		}, time.Now())
+		if err != nil {
+			return landedcostbus.LandedCost{}, fmt.Errorf("seeding landed cost %s : %w", ref, err)
+		}
+		return lc, nil
+	}
+
+	freight, err := newLandedCost(purchaseOrders[0], "LC-FREIGHT")
+	if err != nil {
+		return LandedCostSeedData{}, err
+	}
+
+	spare, err := newLandedCost(purchaseOrders[0], "LC-SPARE")
+	if err != nil {
+		return LandedCostSeedData{}, err
+	}
+
+	uncosted, err := newLandedCost(purchaseOrders[1], "LC-UNCOSTED")
+	if err != nil {
+		return LandedCostSeedData{}, err
+	}
+
+	// Reading the landed costs back gives the timestamps the database's
+	// precision.
+	stored := make(map[uuid.UUID]landedcostbus.LandedCost, 3)
+	for _, lc := range []landedcostbus.LandedCost{freight, spare, uncosted} {
+		got, err := busDomain.LandedCost.QueryByID(ctx, lc.ID)
+		if err != nil {
+			return LandedCostSeedData{}, fmt.Errorf("querying landed cost : %w", err)
+		}
+		stored[lc.ID] = got
+	}
+
+	sorted, err := busDomain.LandedCost.Query(ctx, landedcostbus.QueryFilter{}, order.NewBy(landedcostbus.OrderByID, order.ASC), page.MustParse("1", "10"))
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("querying landed costs : %w", err)
+	}
+
+	previewLC, previewProducts, err := busDomain.LandedCost.Preview(ctx, stored[freight.ID])
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("previewing landed cost : %w", err)
+	}
+
+	// =========================================================================
+	// Permissions
+	// =========================================================================
+
+	roles, err := rolebus.TestSeedRoles(ctx, 2, busDomain.Role)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding roles : %w", err)
+	}
+
+	roleIDs := make(uuid.UUIDs, len(roles))
+	for i, r := range roles {
+		roleIDs[i] = r.ID
+	}
+
+	userIDs := uuid.UUIDs{tu1.ID, tu2.ID}
+
+	_, err = userrolebus.TestSeedUserRoles(ctx, userIDs, roleIDs, busDomain.UserRole)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding user roles : %w", err)
+	}
+
+	_, err = tableaccessbus.TestSeedTableAccess(ctx, roleIDs, busDomain.TableAccess)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("seeding table access : %w", err)
+	}
+
+	ur1, err := busDomain.UserRole.QueryByUserID(ctx, tu1.ID)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("querying user1 roles : %w", err)
+	}
+
+	usrRoleIDs := make(uuid.UUIDs, len(ur1))
+	for i, r := range ur1 {
+		usrRoleIDs[i] = r.RoleID
+	}
+
+	tas, err := busDomain.TableAccess.QueryByRoleIDs(ctx, usrRoleIDs)
+	if err != nil {
+		return LandedCostSeedData{}, fmt.Errorf("querying table access : %w", err)
+	}
+
+	for _, ta := range tas {
+		if ta.TableName == landedcostapi.RouteTable {
+			update := tableaccessbus.UpdateTableAccess{
+				CanCreate: dbtest.BoolPointer(false),
+				CanUpdate: dbtest.BoolPointer(false),
+				CanDelete: dbtest.BoolPointer(false),
+				CanRead:   dbtest.BoolPointer(true),
+			}
+			_, err := busDomain.TableAccess.Update(ctx, ta, update)
+			if err != nil {
+				return LandedCostSeedData{}, fmt.Errorf("updating table access : %w", err)
+			}
+		}
+	}
+
+	return LandedCostSeedData{
+		SeedData: apitest.SeedData{
+			Admins:                 []apitest.User{tu2},
+			Users:                  []apitest.User{tu1},
+			Products:               productapp.ToAppProducts(products),
+			PurchaseOrders:         purchaseorderapp.ToAppPurchaseOrders(purchaseOrders),
+			PurchaseOrderLineItems: purchaseorderlineitemapp.ToAppPurchaseOrderLineItems(lineItems),
+		},
+		LandedCosts: landedcostapp.ToAppLandedCosts(sorted),
+		Freight:     landedcostapp.ToAppLandedCost(stored[freight.ID]),
+		Spare:       landedcostapp.ToAppLandedCost(stored[spare.ID]),
+		Uncosted:    landedcostapp.ToAppLandedCost(stored[uncosted.ID]),
+		Preview:     landedcostapp.ToAppPreview(previewLC, previewProducts),
+	}, nil
+}
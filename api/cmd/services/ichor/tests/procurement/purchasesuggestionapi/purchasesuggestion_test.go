@@ -0,0 +1,54 @@
+package purchasesuggestionapi_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/procurement/purchasesuggestionapp"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus"
+)
+
+func Test_PurchaseSuggestion(t *testing.T) {
+	t.Parallel()
+
+	test := apitest.StartTest(t, "Test_PurchaseSuggestion")
+
+	sd, err := insertSeedData(test.DB, test.Auth)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	test.Run(t, query200(sd), "query-200")
+	test.Run(t, queryByID200(sd), "query-by-id-200")
+	test.Run(t, queryByID404(sd), "query-by-id-404")
+	test.Run(t, query401(sd), "query-401")
+
+	test.Run(t, run200(sd), "run-200")
+	test.Run(t, run400(sd), "run-400")
+	test.Run(t, run401(sd), "run-401")
+
+	test.Run(t, release200(sd), "release-200")
+	test.Run(t, release401(sd), "release-401")
+	test.Run(t, release409(sd), "release-409")
+	checkReleasedOnce(t, test, sd.Suggestions[0])
+
+	test.Run(t, dismiss200(sd), "dismiss-200")
+	test.Run(t, dismiss409(sd), "dismiss-409")
+}
+
+// checkReleasedOnce checks a suggestion released more than once still became
+// a single purchase order.
+func checkReleasedOnce(t *testing.T, test *apitest.Test, suggestion purchasesuggestionapp.Suggestion) {
+	t.Helper()
+
+	supplierID := uuid.MustParse(suggestion.SupplierID)
+	count, err := test.DB.BusDomain.PurchaseOrder.Count(context.Background(), purchaseorderbus.QueryFilter{SupplierID: &supplierID})
+	if err != nil {
+		t.Fatalf("counting purchase orders: %s", err)
+	}
+	if count != 1 {
+		t.Fatalf("purchase orders: expected 1, got %d", count)
+	}
+}
@@ -0,0 +1,161 @@
+package purchasesuggestionapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/procurement/purchasesuggestionapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
+)
+
+func newRelease(sd PurchaseSuggestionSeedData) *purchasesuggestionapp.NewRelease {
+	return &purchasesuggestionapp.NewRelease{
+		PurchaseOrderStatusID: sd.PurchaseOrderStatuses[0].ID,
+		LineItemStatusID:      sd.PurchaseOrderLineItemStatuses[0].ID,
+		CurrencyID:            sd.Currencies[0].ID.String(),
+	}
+}
+
+func release200(sd PurchaseSuggestionSeedData) []apitest.Table {
+	suggestion := sd.Suggestions[0]
+
+	return []apitest.Table{
+		{
+			Name:       "draft",
+			URL:        fmt.Sprintf("/v1/procurement/purchase-suggestions/%s/release", suggestion.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input:      newRelease(sd),
+			GotResp:    &purchasesuggestionapp.Suggestion{},
+			ExpResp:    &suggestion,
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*purchasesuggestionapp.Suggestion)
+				if !exists {
+					return "error occurred"
+				}
+				if gotResp.PurchaseOrderID == "" || gotResp.ReleasedDate == "" {
+					return "expected purchase_order_id and released_date to be set"
+				}
+				expResp := exp.(*purchasesuggestionapp.Suggestion)
+				expResp.Status = purchasesuggestionbus.StatusReleased
+				expResp.PurchaseOrderID = gotResp.PurchaseOrderID
+				expResp.ReleasedBy = sd.Admins[0].ID.String()
+				expResp.ReleasedDate = gotResp.ReleasedDate
+				expResp.UpdatedBy = sd.Admins[0].ID.String()
+				expResp.UpdatedDate = gotResp.UpdatedDate
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func release401(sd PurchaseSuggestionSeedData) []apitest.Table {
+	url := fmt.Sprintf("/v1/procurement/purchase-suggestions/%s/release", sd.Suggestions[1].ID)
+
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        url,
+			Token:      "&nbsp;",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			Input:      newRelease(sd),
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "no-update-permission",
+			URL:        url,
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusForbidden,
+			Input:      newRelease(sd),
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission UPDATE for table: procurement.purchase_suggestions"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func release409(sd PurchaseSuggestionSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "already-released",
+			URL:        fmt.Sprintf("/v1/procurement/purchase-suggestions/%s/release", sd.Suggestions[0].ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusConflict,
+			Input:      newRelease(sd),
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Aborted, "release: purchase suggestion is not a draft"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func dismiss200(sd PurchaseSuggestionSeedData) []apitest.Table {
+	suggestion := sd.Suggestions[1]
+
+	return []apitest.Table{
+		{
+			Name:       "draft",
+			URL:        fmt.Sprintf("/v1/procurement/purchase-suggestions/%s/dismiss", suggestion.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			GotResp:    &purchasesuggestionapp.Suggestion{},
+			ExpResp:    &suggestion,
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*purchasesuggestionapp.Suggestion)
+				if !exists {
+					return "error occurred"
+				}
+				expResp := exp.(*purchasesuggestionapp.Suggestion)
+				expResp.Status = purchasesuggestionbus.StatusDismissed
+				expResp.UpdatedBy = sd.Admins[0].ID.String()
+				expResp.UpdatedDate = gotResp.UpdatedDate
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func dismiss409(sd PurchaseSuggestionSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "already-dismissed",
+			URL:        fmt.Sprintf("/v1/procurement/purchase-suggestions/%s/dismiss", sd.Suggestions[1].ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusConflict,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Aborted, "dismiss: purchase suggestion is not a draft"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "already-released",
+			URL:        fmt.Sprintf("/v1/procurement/purchase-suggestions/%s/dismiss", sd.Suggestions[0].ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusConflict,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Aborted, "dismiss: purchase suggestion is not a draft"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
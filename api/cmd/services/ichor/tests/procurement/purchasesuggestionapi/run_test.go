@@ -0,0 +1,114 @@
+package purchasesuggestionapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/procurement/purchasesuggestionapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+)
+
+func run200(sd PurchaseSuggestionSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "drafted-products-left-out",
+			URL:        "/v1/procurement/purchase-suggestions/run",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input:      &purchasesuggestionapp.NewRun{},
+			GotResp:    &purchasesuggestionapp.RunResult{},
+			ExpResp:    &purchasesuggestionapp.RunResult{},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*purchasesuggestionapp.RunResult)
+				if !exists {
+					return "error occurred"
+				}
+
+				// The seeded drafts already hold the first two products, so
+				// only the third is suggested, from the supplier selling it.
+				if len(gotResp.Suggestions) != 1 {
+					return fmt.Sprintf("expected 1 suggestion, got %d", len(gotResp.Suggestions))
+				}
+				s := gotResp.Suggestions[0]
+				if s.SupplierID != sd.Suppliers[0].SupplierID {
+					return fmt.Sprintf("expected supplier %s, got %s", sd.Suppliers[0].SupplierID, s.SupplierID)
+				}
+				if len(s.Lines) != 1 || s.Lines[0].ProductID != sd.Products[2].ProductID {
+					return fmt.Sprintf("expected one line for product %s, got %+v", sd.Products[2].ProductID, s.Lines)
+				}
+				if s.Lines[0].Quantity != s.Lines[0].SuggestedQuantity {
+					return fmt.Sprintf("expected quantity %s, got %s", s.Lines[0].SuggestedQuantity, s.Lines[0].Quantity)
+				}
+				if len(gotResp.Unsourced) != 0 {
+					return fmt.Sprintf("expected no unsourced needs, got %d", len(gotResp.Unsourced))
+				}
+				return ""
+			},
+		},
+	}
+}
+
+func run400(sd PurchaseSuggestionSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "nothing-to-suggest",
+			URL:        "/v1/procurement/purchase-suggestions/run",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input:      &purchasesuggestionapp.NewRun{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.FailedPrecondition, "run: no inventory item is below its reorder point"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "negative-weight",
+			URL:        "/v1/procurement/purchase-suggestions/run",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input:      &purchasesuggestionapp.NewRun{PriceWeight: "-1"},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.InvalidArgument, "run: invalid supplier weights: weights must not be negative"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func run401(sd PurchaseSuggestionSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        "/v1/procurement/purchase-suggestions/run",
+			Token:      "&nbsp;",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			Input:      &purchasesuggestionapp.NewRun{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "no-create-permission",
+			URL:        "/v1/procurement/purchase-suggestions/run",
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusForbidden,
+			Input:      &purchasesuggestionapp.NewRun{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission CREATE for table: procurement.purchase_suggestions"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
@@ -0,0 +1,376 @@
+package purchasesuggestionapi_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/domain/http/procurement/purchasesuggestionapi"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/inventorylocationapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/warehouseapp"
+	"github.com/timmaaaz/ichor/app/domain/procurement/purchaseorderlineitemstatusapp"
+	"github.com/timmaaaz/ichor/app/domain/procurement/purchaseorderstatusapp"
+	"github.com/timmaaaz/ichor/app/domain/procurement/purchasesuggestionapp"
+	"github.com/timmaaaz/ichor/app/domain/procurement/supplierapp"
+	"github.com/timmaaaz/ichor/app/domain/procurement/supplierproductapp"
+	"github.com/timmaaaz/ichor/app/domain/products/productapp"
+	"github.com/timmaaaz/ichor/app/sdk/auth"
+	"github.com/timmaaaz/ichor/business/domain/core/contactinfosbus"
+	"github.com/timmaaaz/ichor/business/domain/core/currencybus"
+	"github.com/timmaaaz/ichor/business/domain/core/rolebus"
+	"github.com/timmaaaz/ichor/business/domain/core/tableaccessbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userrolebus"
+	"github.com/timmaaaz/ichor/business/domain/geography/citybus"
+	"github.com/timmaaaz/ichor/business/domain/geography/regionbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/streetbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/timezonebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/warehousebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/zonebus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitemstatusbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderstatusbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierproductbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierproductbus/types"
+	"github.com/timmaaaz/ichor/business/domain/products/brandbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productcategorybus"
+	"github.com/timmaaaz/ichor/business/sdk/dbtest"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// PurchaseSuggestionSeedData is the seed data plus the draft suggestions of
+// one run: the first two active products are below their reorder point and
+// each is sold by a different active supplier, so the run drafts one
+// suggestion per supplier. The third product drops below its reorder point
+// after the run and is sold by the first supplier, so the next run suggests
+// it alone. Products and Suppliers hold only active ones.
+type PurchaseSuggestionSeedData struct {
+	apitest.SeedData
+	Suggestions []purchasesuggestionapp.Suggestion // by id
+}
+
+// Levels of the seeded inventory items. Every item is below its reorder
+// point.
+const (
+	itemQuantity     = 5
+	itemReorderPoint = 20
+	itemMaximumStock = 50
+	minOrderQuantity = 10
+)
+
+func insertSeedData(db *dbtest.Database, ath *auth.Auth) (PurchaseSuggestionSeedData, error) {
+	ctx := context.Background()
+	busDomain := db.BusDomain
+
+	// =========================================================================
+	// Users
+	// =========================================================================
+
+	usrs, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.User, busDomain.User)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	tu1 := apitest.User{
+		User:  usrs[0],
+		Token: apitest.Token(busDomain.User, ath, usrs[0].Email.Address),
+	}
+
+	admins, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.Admin, busDomain.User)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding admin : %w", err)
+	}
+
+	tu2 := apitest.User{
+		User:  admins[0],
+		Token: apitest.Token(busDomain.User, ath, admins[0].Email.Address),
+	}
+
+	// =========================================================================
+	// Geography
+	// =========================================================================
+
+	regions, err := busDomain.Region.Query(ctx, regionbus.QueryFilter{}, regionbus.DefaultOrderBy, page.MustParse("1", "5"))
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("querying regions : %w", err)
+	}
+
+	regionIDs := make([]uuid.UUID, 0, len(regions))
+	for _, r := range regions {
+		regionIDs = append(regionIDs, r.ID)
+	}
+
+	ctys, err := citybus.TestSeedCities(ctx, 2, regionIDs, busDomain.City)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding cities : %w", err)
+	}
+
+	ctyIDs := make([]uuid.UUID, 0, len(ctys))
+	for _, c := range ctys {
+		ctyIDs = append(ctyIDs, c.ID)
+	}
+
+	strs, err := streetbus.TestSeedStreets(ctx, 2, ctyIDs, busDomain.Street)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding streets : %w", err)
+	}
+
+	strIDs := make([]uuid.UUID, 0, len(strs))
+	for _, s := range strs {
+		strIDs = append(strIDs, s.ID)
+	}
+
+	tzs, err := busDomain.Timezone.Query(ctx, timezonebus.QueryFilter{}, timezonebus.DefaultOrderBy, page.MustParse("1", "100"))
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("querying timezones : %w", err)
+	}
+
+	tzIDs := make([]uuid.UUID, 0, len(tzs))
+	for _, tz := range tzs {
+		tzIDs = append(tzIDs, tz.ID)
+	}
+
+	contacts, err := contactinfosbus.TestSeedContactInfos(ctx, 2, strIDs, tzIDs, busDomain.ContactInfos)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding contact info : %w", err)
+	}
+
+	contactIDs := make(uuid.UUIDs, len(contacts))
+	for i, c := range contacts {
+		contactIDs[i] = c.ID
+	}
+
+	// =========================================================================
+	// Warehouse Infrastructure
+	// =========================================================================
+
+	warehouses, err := warehousebus.TestSeedWarehouses(ctx, 1, tu1.ID, strIDs, busDomain.Warehouse)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding warehouses : %w", err)
+	}
+
+	warehouseIDs := make(uuid.UUIDs, len(warehouses))
+	for i, w := range warehouses {
+		warehouseIDs[i] = w.ID
+	}
+
+	zones, err := zonebus.TestSeedZone(ctx, 1, warehouseIDs, busDomain.Zones)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding zones : %w", err)
+	}
+
+	inventoryLocations, err := inventorylocationbus.TestSeedInventoryLocations(ctx, 3, warehouseIDs, zones, busDomain.InventoryLocation)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding inventory locations : %w", err)
+	}
+
+	// =========================================================================
+	// Products and Suppliers
+	// =========================================================================
+
+	brands, err := brandbus.TestSeedBrands(ctx, 2, contactIDs, busDomain.Brand)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding brands : %w", err)
+	}
+
+	brandIDs := make(uuid.UUIDs, len(brands))
+	for i, b := range brands {
+		brandIDs[i] = b.BrandID
+	}
+
+	pc, err := productcategorybus.TestSeedProductCategories(ctx, 2, busDomain.ProductCategory)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding product categories : %w", err)
+	}
+
+	pcIDs := make(uuid.UUIDs, len(pc))
+	for i, p := range pc {
+		pcIDs[i] = p.ProductCategoryID
+	}
+
+	// Every other seeded product and supplier is inactive, and runs only
+	// consider active ones.
+	seededProducts, err := productbus.TestSeedProducts(ctx, 6, brandIDs, pcIDs, busDomain.Product)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding products : %w", err)
+	}
+
+	var products []productbus.Product
+	for _, p := range seededProducts {
+		if p.IsActive {
+			products = append(products, p)
+		}
+	}
+	if len(products) < 3 {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding products : expected 3 active products, got %d", len(products))
+	}
+
+	seededSuppliers, err := supplierbus.TestSeedSuppliers(ctx, 4, contactIDs, busDomain.Supplier)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding suppliers : %w", err)
+	}
+
+	var suppliers []supplierbus.Supplier
+	for _, s := range seededSuppliers {
+		if s.IsActive {
+			suppliers = append(suppliers, s)
+		}
+	}
+	if len(suppliers) < 2 {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding suppliers : expected 2 active suppliers, got %d", len(suppliers))
+	}
+
+	supplierProducts := make([]supplierproductbus.SupplierProduct, 3)
+	for i := range supplierProducts {
+		sp, err := busDomain.SupplierProduct.Create(ctx, supplierproductbus.NewSupplierProduct{
+			SupplierID:         suppliers[i%2].SupplierID,
+			ProductID:          products[i].ProductID,
+			SupplierPartNumber: fmt.Sprintf("PS-SEED-%d", i),
+			MinOrderQuantity:   minOrderQuantity,
+			LeadTimeDays:       5,
+			UnitCost:           types.MustParseMoney("2.50"),
+			IsPrimarySupplier:  true,
+		})
+		if err != nil {
+			return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding supplier product %d : %w", i, err)
+		}
+		supplierProducts[i] = sp
+	}
+
+	// =========================================================================
+	// Release Lookups
+	// =========================================================================
+
+	poStatuses, err := purchaseorderstatusbus.TestSeedPurchaseOrderStatuses(ctx, 1, busDomain.PurchaseOrderStatus)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding purchase order statuses : %w", err)
+	}
+
+	lineItemStatuses, err := purchaseorderlineitemstatusbus.TestSeedPurchaseOrderLineItemStatuses(ctx, 1, busDomain.PurchaseOrderLineItemStatus)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding line item statuses : %w", err)
+	}
+
+	currencies, err := currencybus.TestSeedCurrencies(ctx, 1, busDomain.Currency)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding currencies : %w", err)
+	}
+
+	// =========================================================================
+	// Inventory Items and Suggestions
+	// =========================================================================
+
+	newItem := func(i int) error {
+		_, err := busDomain.InventoryItem.Create(ctx, inventoryitembus.NewInventoryItem{
+			ProductID:    products[i].ProductID,
+			LocationID:   inventoryLocations[i].LocationID,
+			Quantity:     itemQuantity,
+			MaximumStock: itemMaximumStock,
+			ReorderPoint: itemReorderPoint,
+		})
+		if err != nil {
+			return fmt.Errorf("seeding inventory item %d : %w", i, err)
+		}
+		return nil
+	}
+
+	for i := range 2 {
+		if err := newItem(i); err != nil {
+			return PurchaseSuggestionSeedData{}, err
+		}
+	}
+
+	if _, err := busDomain.PurchaseSuggestion.Run(ctx, purchasesuggestionbus.RunRequest{CreatedBy: tu2.ID}, time.Now()); err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding suggestions : %w", err)
+	}
+
+	if err := newItem(2); err != nil {
+		return PurchaseSuggestionSeedData{}, err
+	}
+
+	// The suggestions of one run share a created date, so order them by id.
+	// Reading them back also gives the timestamps the database's precision.
+	suggestions, err := busDomain.PurchaseSuggestion.Query(ctx, purchasesuggestionbus.QueryFilter{}, order.NewBy(purchasesuggestionbus.OrderByID, order.ASC), page.MustParse("1", "10"))
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("querying suggestions : %w", err)
+	}
+
+	// =========================================================================
+	// Permissions
+	// =========================================================================
+
+	roles, err := rolebus.TestSeedRoles(ctx, 2, busDomain.Role)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding roles : %w", err)
+	}
+
+	roleIDs := make(uuid.UUIDs, len(roles))
+	for i, r := range roles {
+		roleIDs[i] = r.ID
+	}
+
+	userIDs := uuid.UUIDs{tu1.ID, tu2.ID}
+
+	_, err = userrolebus.TestSeedUserRoles(ctx, userIDs, roleIDs, busDomain.UserRole)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding user roles : %w", err)
+	}
+
+	_, err = tableaccessbus.TestSeedTableAccess(ctx, roleIDs, busDomain.TableAccess)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("seeding table access : %w", err)
+	}
+
+	ur1, err := busDomain.UserRole.QueryByUserID(ctx, tu1.ID)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("querying user1 roles : %w", err)
+	}
+
+	usrRoleIDs := make(uuid.UUIDs, len(ur1))
+	for i, r := range ur1 {
+		usrRoleIDs[i] = r.RoleID
+	}
+
+	tas, err := busDomain.TableAccess.QueryByRoleIDs(ctx, usrRoleIDs)
+	if err != nil {
+		return PurchaseSuggestionSeedData{}, fmt.Errorf("querying table access : %w", err)
+	}
+
+	for _, ta := range tas {
+		if ta.TableName == purchasesuggestionapi.RouteTable {
+			update := tableaccessbus.UpdateTableAccess{
+				CanCreate: dbtest.BoolPointer(false),
+				CanUpdate: dbtest.BoolPointer(false),
+				CanDelete: dbtest.BoolPointer(false),
+				CanRead:   dbtest.BoolPointer(true),
+			}
+			_, err := busDomain.TableAccess.Update(ctx, ta, update)
+			if err != nil {
+				return PurchaseSuggestionSeedData{}, fmt.Errorf("updating table access : %w", err)
+			}
+		}
+	}
+
+	return PurchaseSuggestionSeedData{
+		SeedData: apitest.SeedData{
+			Admins:                        []apitest.User{tu2},
+			Users:                         []apitest.User{tu1},
+			Warehouses:                    warehouseapp.ToAppWarehouses(warehouses),
+			InventoryLocations:            inventorylocationapp.ToAppInventoryLocations(inventoryLocations),
+			Products:                      productapp.ToAppProducts(products),
+			Suppliers:                     supplierapp.ToAppSuppliers(suppliers),
+			SupplierProducts:              supplierproductapp.ToAppSupplierProducts(supplierProducts),
+			PurchaseOrderStatuses:         purchaseorderstatusapp.ToAppPurchaseOrderStatuses(poStatuses),
+			PurchaseOrderLineItemStatuses: purchaseorderlineitemstatusapp.ToAppPurchaseOrderLineItemStatuses(lineItemStatuses),
+			Currencies:                    currencies,
+		},
+		Suggestions: purchasesuggestionapp.ToAppSuggestions(suggestions),
+	}, nil
+}
@@ -0,0 +1,162 @@
+package supplierinvoiceapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/procurement/supplierinvoiceapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+)
+
+func newInvoice(sd SupplierInvoiceSeedData, lineItemIdx int) *supplierinvoiceapp.NewSupplierInvoice {
+	return &supplierinvoiceapp.NewSupplierInvoice{
+		InvoiceNumber:   "INV-CREATE",
+		SupplierID:      sd.Suppliers[0].SupplierID,
+		PurchaseOrderID: sd.PurchaseOrders[0].ID,
+		InvoiceDate:     "2026-01-15",
+		DueDate:         "2026-02-14",
+		Lines: []supplierinvoiceapp.NewLine{{
+			PurchaseOrderLineItemID: sd.PurchaseOrderLineItems[lineItemIdx].ID,
+			Quantity:                "1",
+			UnitPrice:               sd.PurchaseOrderLineItems[lineItemIdx].UnitCost,
+		}},
+	}
+}
+
+func create200(sd SupplierInvoiceSeedData) []apitest.Table {
+	lineItem := sd.PurchaseOrderLineItems[0]
+
+	return []apitest.Table{
+		{
+			Name:       "basic",
+			URL:        "/v1/procurement/supplier-invoices",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input:      newInvoice(sd, 0),
+			GotResp:    &supplierinvoiceapp.SupplierInvoice{},
+			ExpResp: &supplierinvoiceapp.SupplierInvoice{
+				InvoiceNumber:   "INV-CREATE",
+				SupplierID:      sd.Suppliers[0].SupplierID,
+				PurchaseOrderID: sd.PurchaseOrders[0].ID,
+				Subtotal:        lineItem.UnitCost,
+				TaxAmount:       "0.00",
+				FreightAmount:   "0.00",
+				TotalAmount:     lineItem.UnitCost,
+				Status:          supplierinvoicebus.StatusOpen,
+				MatchStatus:     supplierinvoicebus.MatchUnmatched,
+				Tolerance: supplierinvoiceapp.Tolerance{
+					PricePercent:    "2.00",
+					PriceAmount:     "0.01",
+					QuantityPercent: "0.00",
+					QuantityUnits:   "0",
+				},
+				CreatedBy: sd.Admins[0].ID.String(),
+				UpdatedBy: sd.Admins[0].ID.String(),
+				Lines: []supplierinvoiceapp.Line{{
+					PurchaseOrderLineItemID: lineItem.ID,
+					Quantity:                "1",
+					UnitPrice:               lineItem.UnitCost,
+					LineTotal:               lineItem.UnitCost,
+					MatchStatus:             supplierinvoicebus.MatchUnmatched,
+					ExpectedUnitCost:        "0.0000",
+					ReceivedQuantity:        "0",
+					PreviouslyInvoiced:      "0",
+					PriceVariance:           "0.00",
+					QuantityVariance:        "0",
+				}},
+			},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*supplierinvoiceapp.SupplierInvoice)
+				if !exists {
+					return "error occurred"
+				}
+				if len(gotResp.Lines) != 1 {
+					return fmt.Sprintf("expected 1 line, got %d", len(gotResp.Lines))
+				}
+				expResp := exp.(*supplierinvoiceapp.SupplierInvoice)
+				expResp.ID = gotResp.ID
+				expResp.InvoiceDate = gotResp.InvoiceDate
+				expResp.DueDate = gotResp.DueDate
+				expResp.CreatedDate = gotResp.CreatedDate
+				expResp.UpdatedDate = gotResp.UpdatedDate
+				expResp.Lines[0].ID = gotResp.Lines[0].ID
+				expResp.Lines[0].InvoiceID = gotResp.ID
+				expResp.Lines[0].CreatedDate = gotResp.Lines[0].CreatedDate
+				expResp.Lines[0].UpdatedDate = gotResp.Lines[0].UpdatedDate
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func create400(sd SupplierInvoiceSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "missing-lines",
+			URL:        "/v1/procurement/supplier-invoices",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input: &supplierinvoiceapp.NewSupplierInvoice{
+				InvoiceNumber:   "INV-NO-LINES",
+				SupplierID:      sd.Suppliers[0].SupplierID,
+				PurchaseOrderID: sd.PurchaseOrders[0].ID,
+				InvoiceDate:     "2026-01-15",
+				DueDate:         "2026-02-14",
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.InvalidArgument, "validate: [{\"field\":\"lines\",\"error\":\"lines is a required field\"}]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "line-not-on-order",
+			URL:        "/v1/procurement/supplier-invoices",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input:      newInvoice(sd, 1),
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.InvalidArgument, "create: invalid supplier invoice: line item %s is not on the purchase order", sd.PurchaseOrderLineItems[1].ID),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func create401(sd SupplierInvoiceSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        "/v1/procurement/supplier-invoices",
+			Token:      "&nbsp;",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			Input:      newInvoice(sd, 0),
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "no-create-permission",
+			URL:        "/v1/procurement/supplier-invoices",
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusForbidden,
+			Input:      newInvoice(sd, 0),
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission CREATE for table: procurement.supplier_invoices"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
@@ -0,0 +1,151 @@
+package supplierinvoiceapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/procurement/supplierinvoiceapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+)
+
+// adminOnly is the error a non-admin gets approving or rejecting an invoice.
+const adminOnly = "authorize: you are not authorized for that action, claims[[USER]] rule[rule_admin_only]: rego evaluation failed : bindings results[[{[true] map[x:false]}]] ok[true]"
+
+func match200(sd SupplierInvoiceSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "quantity-variance",
+			URL:        fmt.Sprintf("/v1/procurement/supplier-invoices/%s/match", sd.Open.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			GotResp:    &supplierinvoiceapp.SupplierInvoice{},
+			ExpResp:    &supplierinvoiceapp.SupplierInvoice{},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*supplierinvoiceapp.SupplierInvoice)
+				if !exists {
+					return "error occurred"
+				}
+				if gotResp.MatchedDate == "" {
+					return "expected matched_date to be set"
+				}
+				if gotResp.Status != supplierinvoicebus.StatusPendingApproval {
+					return fmt.Sprintf("status: expected %s, got %s", supplierinvoicebus.StatusPendingApproval, gotResp.Status)
+				}
+				if gotResp.MatchStatus != supplierinvoicebus.MatchQuantityVariance {
+					return fmt.Sprintf("match_status: expected %s, got %s", supplierinvoicebus.MatchQuantityVariance, gotResp.MatchStatus)
+				}
+				return ""
+			},
+		},
+	}
+}
+
+func approve200(sd SupplierInvoiceSeedData) []apitest.Table {
+	invoice := sd.Pending
+
+	return []apitest.Table{
+		{
+			Name:       "pending-approval",
+			URL:        fmt.Sprintf("/v1/procurement/supplier-invoices/%s/approve", invoice.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input:      &supplierinvoiceapp.Decision{Reason: "short shipment to follow"},
+			GotResp:    &supplierinvoiceapp.SupplierInvoice{},
+			ExpResp:    &invoice,
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*supplierinvoiceapp.SupplierInvoice)
+				if !exists {
+					return "error occurred"
+				}
+				if gotResp.ApprovedDate == "" {
+					return "expected approved_date to be set"
+				}
+				expResp := exp.(*supplierinvoiceapp.SupplierInvoice)
+				expResp.Status = supplierinvoicebus.StatusApproved
+				expResp.ApprovedBy = sd.Admins[0].ID.String()
+				expResp.ApprovedDate = gotResp.ApprovedDate
+				expResp.ApprovalReason = "short shipment to follow"
+				expResp.UpdatedBy = sd.Admins[0].ID.String()
+				expResp.UpdatedDate = gotResp.UpdatedDate
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func approve401(sd SupplierInvoiceSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "not-admin",
+			URL:        fmt.Sprintf("/v1/procurement/supplier-invoices/%s/approve", sd.Pending.ID),
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			Input:      &supplierinvoiceapp.Decision{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, adminOnly),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func approve409(sd SupplierInvoiceSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "already-rejected",
+			URL:        fmt.Sprintf("/v1/procurement/supplier-invoices/%s/approve", sd.Rejected.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusConflict,
+			Input:      &supplierinvoiceapp.Decision{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Aborted, "approve: supplier invoice already rejected"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func reject401(sd SupplierInvoiceSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "not-admin",
+			URL:        fmt.Sprintf("/v1/procurement/supplier-invoices/%s/reject", sd.Open.ID),
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			Input:      &supplierinvoiceapp.Decision{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, adminOnly),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func reject409(sd SupplierInvoiceSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "already-approved",
+			URL:        fmt.Sprintf("/v1/procurement/supplier-invoices/%s/reject", sd.Pending.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusConflict,
+			Input:      &supplierinvoiceapp.Decision{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Aborted, "reject: supplier invoice already approved"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
@@ -0,0 +1,359 @@
+package supplierinvoiceapi_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/domain/http/procurement/supplierinvoiceapi"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/procurement/purchaseorderapp"
+	"github.com/timmaaaz/ichor/app/domain/procurement/purchaseorderlineitemapp"
+	"github.com/timmaaaz/ichor/app/domain/procurement/supplierapp"
+	"github.com/timmaaaz/ichor/app/domain/procurement/supplierinvoiceapp"
+	"github.com/timmaaaz/ichor/app/sdk/auth"
+	"github.com/timmaaaz/ichor/business/domain/core/contactinfosbus"
+	"github.com/timmaaaz/ichor/business/domain/core/currencybus"
+	"github.com/timmaaaz/ichor/business/domain/core/rolebus"
+	"github.com/timmaaaz/ichor/business/domain/core/tableaccessbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userrolebus"
+	"github.com/timmaaaz/ichor/business/domain/geography/citybus"
+	"github.com/timmaaaz/ichor/business/domain/geography/regionbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/streetbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/timezonebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/warehousebus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitembus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitemstatusbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderstatusbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierproductbus"
+	"github.com/timmaaaz/ichor/business/domain/products/brandbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productcategorybus"
+	"github.com/timmaaaz/ichor/business/sdk/dbtest"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// SupplierInvoiceSeedData is the seed data plus one invoice per purchase
+// order, each billing two units of the order's only line at its unit cost.
+// Nothing is received on the orders, so a match is a quantity variance left
+// pending approval.
+type SupplierInvoiceSeedData struct {
+	apitest.SeedData
+	Invoices []supplierinvoiceapp.SupplierInvoice // by id
+	Open     supplierinvoiceapp.SupplierInvoice   // never matched
+	Pending  supplierinvoiceapp.SupplierInvoice   // matched, pending approval
+	Rejected supplierinvoiceapp.SupplierInvoice   // matched, then rejected
+}
+
+// invoicedQuantity is the quantity every seeded invoice bills.
+const invoicedQuantity = 2
+
+func insertSeedData(db *dbtest.Database, ath *auth.Auth) (SupplierInvoiceSeedData, error) {
+	ctx := context.Background()
+	busDomain := db.BusDomain
+
+	// =========================================================================
+	// Users
+	// =========================================================================
+
+	usrs, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.User, busDomain.User)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	tu1 := apitest.User{
+		User:  usrs[0],
+		Token: apitest.Token(busDomain.User, ath, usrs[0].Email.Address),
+	}
+
+	admins, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.Admin, busDomain.User)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding admin : %w", err)
+	}
+
+	tu2 := apitest.User{
+		User:  admins[0],
+		Token: apitest.Token(busDomain.User, ath, admins[0].Email.Address),
+	}
+
+	// =========================================================================
+	// Geography
+	// =========================================================================
+
+	regions, err := busDomain.Region.Query(ctx, regionbus.QueryFilter{}, regionbus.DefaultOrderBy, page.MustParse("1", "5"))
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("querying regions : %w", err)
+	}
+
+	regionIDs := make([]uuid.UUID, 0, len(regions))
+	for _, r := range regions {
+		regionIDs = append(regionIDs, r.ID)
+	}
+
+	ctys, err := citybus.TestSeedCities(ctx, 2, regionIDs, busDomain.City)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding cities : %w", err)
+	}
+
+	ctyIDs := make([]uuid.UUID, 0, len(ctys))
+	for _, c := range ctys {
+		ctyIDs = append(ctyIDs, c.ID)
+	}
+
+	strs, err := streetbus.TestSeedStreets(ctx, 2, ctyIDs, busDomain.Street)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding streets : %w", err)
+	}
+
+	strIDs := make([]uuid.UUID, 0, len(strs))
+	for _, s := range strs {
+		strIDs = append(strIDs, s.ID)
+	}
+
+	tzs, err := busDomain.Timezone.Query(ctx, timezonebus.QueryFilter{}, timezonebus.DefaultOrderBy, page.MustParse("1", "100"))
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("querying timezones : %w", err)
+	}
+
+	tzIDs := make([]uuid.UUID, 0, len(tzs))
+	for _, tz := range tzs {
+		tzIDs = append(tzIDs, tz.ID)
+	}
+
+	contacts, err := contactinfosbus.TestSeedContactInfos(ctx, 2, strIDs, tzIDs, busDomain.ContactInfos)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding contact info : %w", err)
+	}
+
+	contactIDs := make(uuid.UUIDs, len(contacts))
+	for i, c := range contacts {
+		contactIDs[i] = c.ID
+	}
+
+	warehouses, err := warehousebus.TestSeedWarehouses(ctx, 1, tu1.ID, strIDs, busDomain.Warehouse)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding warehouses : %w", err)
+	}
+
+	warehouseIDs := make(uuid.UUIDs, len(warehouses))
+	for i, w := range warehouses {
+		warehouseIDs[i] = w.ID
+	}
+
+	// =========================================================================
+	// Products and Suppliers
+	// =========================================================================
+
+	brands, err := brandbus.TestSeedBrands(ctx, 2, contactIDs, busDomain.Brand)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding brands : %w", err)
+	}
+
+	brandIDs := make(uuid.UUIDs, len(brands))
+	for i, b := range brands {
+		brandIDs[i] = b.BrandID
+	}
+
+	pc, err := productcategorybus.TestSeedProductCategories(ctx, 2, busDomain.ProductCategory)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding product categories : %w", err)
+	}
+
+	pcIDs := make(uuid.UUIDs, len(pc))
+	for i, p := range pc {
+		pcIDs[i] = p.ProductCategoryID
+	}
+
+	products, err := productbus.TestSeedProducts(ctx, 3, brandIDs, pcIDs, busDomain.Product)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding products : %w", err)
+	}
+
+	productIDs := make(uuid.UUIDs, len(products))
+	for i, p := range products {
+		productIDs[i] = p.ProductID
+	}
+
+	suppliers, err := supplierbus.TestSeedSuppliers(ctx, 1, contactIDs, busDomain.Supplier)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding suppliers : %w", err)
+	}
+
+	supplierIDs := uuid.UUIDs{suppliers[0].SupplierID}
+
+	supplierProducts, err := supplierproductbus.TestSeedSupplierProducts(ctx, 3, productIDs, supplierIDs, busDomain.SupplierProduct)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding supplier products : %w", err)
+	}
+
+	supplierProductIDs := make(uuid.UUIDs, len(supplierProducts))
+	for i, sp := range supplierProducts {
+		supplierProductIDs[i] = sp.SupplierProductID
+	}
+
+	// =========================================================================
+	// Purchase Orders
+	// =========================================================================
+
+	poStatuses, err := purchaseorderstatusbus.TestSeedPurchaseOrderStatuses(ctx, 1, busDomain.PurchaseOrderStatus)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding purchase order statuses : %w", err)
+	}
+
+	lineItemStatuses, err := purchaseorderlineitemstatusbus.TestSeedPurchaseOrderLineItemStatuses(ctx, 1, busDomain.PurchaseOrderLineItemStatus)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding line item statuses : %w", err)
+	}
+
+	currencies, err := currencybus.TestSeedCurrencies(ctx, 1, busDomain.Currency)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding currencies : %w", err)
+	}
+
+	purchaseOrders, err := purchaseorderbus.TestSeedPurchaseOrders(ctx, 3, supplierIDs, uuid.UUIDs{poStatuses[0].ID}, warehouseIDs, strIDs, uuid.UUIDs{tu2.ID}, uuid.UUIDs{currencies[0].ID}, busDomain.PurchaseOrder)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding purchase orders : %w", err)
+	}
+
+	poIDs := make(uuid.UUIDs, len(purchaseOrders))
+	for i, po := range purchaseOrders {
+		poIDs[i] = po.ID
+	}
+
+	lineItems, err := purchaseorderlineitembus.TestSeedPurchaseOrderLineItems(ctx, 3, poIDs, supplierProductIDs, uuid.UUIDs{lineItemStatuses[0].ID}, uuid.UUIDs{tu2.ID}, busDomain.PurchaseOrderLineItem)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding purchase order line items : %w", err)
+	}
+
+	// =========================================================================
+	// Supplier Invoices
+	// =========================================================================
+
+	now := time.Now()
+	invoices := make([]supplierinvoicebus.SupplierInvoice, len(lineItems))
+	for i, li := range lineItems {
+		inv, err := busDomain.SupplierInvoice.Create(ctx, supplierinvoicebus.NewSupplierInvoice{
+			InvoiceNumber:   fmt.Sprintf("INV-SEED-%d", i),
+			SupplierID:      suppliers[0].SupplierID,
+			PurchaseOrderID: li.PurchaseOrderID,
+			InvoiceDate:     now,
+			DueDate:         now.AddDate(0, 0, 30),
+			Lines: []supplierinvoicebus.NewLine{{
+				PurchaseOrderLineItemID: li.ID,
+				Quantity:                invoicedQuantity,
+				UnitPrice:               li.UnitCost,
+			}},
+			CreatedBy: tu2.ID,
+		}, now)
+		if err != nil {
+			return SupplierInvoiceSeedData{}, fmt.Errorf("seeding supplier invoice %d : %w", i, err)
+		}
+		invoices[i] = inv
+	}
+
+	for _, inv := range invoices[1:] {
+		if _, err := busDomain.SupplierInvoice.Match(ctx, inv, tu2.ID, now); err != nil {
+			return SupplierInvoiceSeedData{}, fmt.Errorf("matching supplier invoice : %w", err)
+		}
+	}
+
+	rejected, err := busDomain.SupplierInvoice.QueryByID(ctx, invoices[2].ID)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("querying supplier invoice : %w", err)
+	}
+
+	if _, err := busDomain.SupplierInvoice.Reject(ctx, rejected, tu2.ID, "overbilled", now); err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("rejecting supplier invoice : %w", err)
+	}
+
+	// Reading the invoices back gives the timestamps the database's precision.
+	byID := make(map[uuid.UUID]supplierinvoiceapp.SupplierInvoice, len(invoices))
+	for _, inv := range invoices {
+		stored, err := busDomain.SupplierInvoice.QueryByID(ctx, inv.ID)
+		if err != nil {
+			return SupplierInvoiceSeedData{}, fmt.Errorf("querying supplier invoice : %w", err)
+		}
+		byID[inv.ID] = supplierinvoiceapp.ToAppSupplierInvoice(stored)
+	}
+
+	sorted, err := busDomain.SupplierInvoice.Query(ctx, supplierinvoicebus.QueryFilter{}, order.NewBy(supplierinvoicebus.OrderByID, order.ASC), page.MustParse("1", "10"))
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("querying supplier invoices : %w", err)
+	}
+
+	// =========================================================================
+	// Permissions
+	// =========================================================================
+
+	roles, err := rolebus.TestSeedRoles(ctx, 2, busDomain.Role)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding roles : %w", err)
+	}
+
+	roleIDs := make(uuid.UUIDs, len(roles))
+	for i, r := range roles {
+		roleIDs[i] = r.ID
+	}
+
+	userIDs := uuid.UUIDs{tu1.ID, tu2.ID}
+
+	_, err = userrolebus.TestSeedUserRoles(ctx, userIDs, roleIDs, busDomain.UserRole)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding user roles : %w", err)
+	}
+
+	_, err = tableaccessbus.TestSeedTableAccess(ctx, roleIDs, busDomain.TableAccess)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("seeding table access : %w", err)
+	}
+
+	ur1, err := busDomain.UserRole.QueryByUserID(ctx, tu1.ID)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("querying user1 roles : %w", err)
+	}
+
+	usrRoleIDs := make(uuid.UUIDs, len(ur1))
+	for i, r := range ur1 {
+		usrRoleIDs[i] = r.RoleID
+	}
+
+	tas, err := busDomain.TableAccess.QueryByRoleIDs(ctx, usrRoleIDs)
+	if err != nil {
+		return SupplierInvoiceSeedData{}, fmt.Errorf("querying table access : %w", err)
+	}
+
+	for _, ta := range tas {
+		if ta.TableName == supplierinvoiceapi.RouteTable {
+			update := tableaccessbus.UpdateTableAccess{
+				CanCreate: dbtest.BoolPointer(false),
+				CanUpdate: dbtest.BoolPointer(false),
+				CanDelete: dbtest.BoolPointer(false),
+				CanRead:   dbtest.BoolPointer(true),
+			}
+			_, err := busDomain.TableAccess.Update(ctx, ta, update)
+			if err != nil {
+				return SupplierInvoiceSeedData{}, fmt.Errorf("updating table access : %w", err)
+			}
+		}
+	}
+
+	return SupplierInvoiceSeedData{
+		SeedData: apitest.SeedData{
+			Admins:                 []apitest.User{tu2},
+			Users:                  []apitest.User{tu1},
+			Suppliers:              supplierapp.ToAppSuppliers(suppliers),
+			PurchaseOrders:         purchaseorderapp.ToAppPurchaseOrders(purchaseOrders),
+			PurchaseOrderLineItems: purchaseorderlineitemapp.ToAppPurchaseOrderLineItems(lineItems),
+		},
+		Invoices: supplierinvoiceapp.ToAppSupplierInvoices(sorted),
+		Open:     byID[invoices[0].ID],
+		Pending:  byID[invoices[1].ID],
+		Rejected: byID[invoices[2].ID],
+	}, nil
+}
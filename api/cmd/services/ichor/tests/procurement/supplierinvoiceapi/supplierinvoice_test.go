@@ -0,0 +1,36 @@
+package supplierinvoiceapi_test
+
+import (
+	"testing"
+
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+)
+
+func Test_SupplierInvoice(t *testing.T) {
+	t.Parallel()
+
+	test := apitest.StartTest(t, "Test_SupplierInvoice")
+
+	sd, err := insertSeedData(test.DB, test.Auth)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	test.Run(t, query200(sd), "query-200")
+	test.Run(t, queryByID200(sd), "query-by-id-200")
+	test.Run(t, queryByID404(sd), "query-by-id-404")
+	test.Run(t, query401(sd), "query-401")
+
+	test.Run(t, create200(sd), "create-200")
+	test.Run(t, create400(sd), "create-400")
+	test.Run(t, create401(sd), "create-401")
+
+	test.Run(t, match200(sd), "match-200")
+
+	test.Run(t, approve401(sd), "approve-401")
+	test.Run(t, approve200(sd), "approve-200")
+	test.Run(t, approve409(sd), "approve-409")
+
+	test.Run(t, reject401(sd), "reject-401")
+	test.Run(t, reject409(sd), "reject-409")
+}
@@ -0,0 +1,127 @@
+package shipmentapi_test
+
+import (
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/sales/shipmentapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+)
+
+func create200(sd ShipmentSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "basic",
+			URL:        "/v1/sales/shipments",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input: &shipmentapp.NewShipment{
+				ShipmentNumber: "SHP-TEST-0001",
+				OrderID:        sd.Orders[0].ID,
+				Carrier:        "UPS",
+				ServiceLevel:   "Ground",
+				Notes:          "created by test",
+			},
+			GotResp: &shipmentapp.Shipment{},
+			ExpResp: &shipmentapp.Shipment{
+				ShipmentNumber: "SHP-TEST-0001",
+				OrderID:        sd.Orders[0].ID,
+				Status:         "packing",
+				Carrier:        "UPS",
+				ServiceLevel:   "Ground",
+				Notes:          "created by test",
+				CreatedBy:      sd.Admins[0].ID.String(),
+				UpdatedBy:      sd.Admins[0].ID.String(),
+			},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*shipmentapp.Shipment)
+				if !exists {
+					return "error occurred"
+				}
+				expResp := exp.(*shipmentapp.Shipment)
+				expResp.ID = gotResp.ID
+				expResp.CreatedDate = gotResp.CreatedDate
+				expResp.UpdatedDate = gotResp.UpdatedDate
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func create400(sd ShipmentSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "missing-shipment-number",
+			URL:        "/v1/sales/shipments",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input: &shipmentapp.NewShipment{
+				OrderID: sd.Orders[0].ID,
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.InvalidArgument, `validate: [{"field":"shipment_number","error":"shipment_number is a required field"}]`),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "missing-order-id",
+			URL:        "/v1/sales/shipments",
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input: &shipmentapp.NewShipment{
+				ShipmentNumber: "SHP-TEST-0002",
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.InvalidArgument, `validate: [{"field":"order_id","error":"order_id is a required field"}]`),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func create401(sd ShipmentSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        "/v1/sales/shipments",
+			Token:      "&nbsp;",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "bad-sig",
+			URL:        "/v1/sales/shipments",
+			Token:      sd.Admins[0].Token + "A",
+			Method:     http.MethodPost,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "authentication failed : bindings results[[{[true] map[x:false]}]] ok[true]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "no-create-permission",
+			URL:        "/v1/sales/shipments",
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusForbidden,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.PermissionDenied, "user does not have permission CREATE for table: sales.shipments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
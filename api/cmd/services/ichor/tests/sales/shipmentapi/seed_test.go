@@ -0,0 +1,461 @@
+package shipmentapi_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/domain/http/sales/shipmentapi"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/inventoryitemapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/inventorylocationapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/picktaskapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/warehouseapp"
+	"github.com/timmaaaz/ichor/app/domain/inventory/zoneapp"
+	"github.com/timmaaaz/ichor/app/domain/products/productapp"
+	"github.com/timmaaaz/ichor/app/domain/sales/orderlineitemsapp"
+	"github.com/timmaaaz/ichor/app/domain/sales/ordersapp"
+	"github.com/timmaaaz/ichor/app/domain/sales/shipmentapp"
+	"github.com/timmaaaz/ichor/app/sdk/auth"
+	"github.com/timmaaaz/ichor/business/domain/core/contactinfosbus"
+	"github.com/timmaaaz/ichor/business/domain/core/currencybus"
+	"github.com/timmaaaz/ichor/business/domain/core/rolebus"
+	"github.com/timmaaaz/ichor/business/domain/core/tableaccessbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userbus"
+	"github.com/timmaaaz/ichor/business/domain/core/userrolebus"
+	"github.com/timmaaaz/ichor/business/domain/geography/citybus"
+	"github.com/timmaaaz/ichor/business/domain/geography/regionbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/streetbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/timezonebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/picktaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/warehousebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/zonebus"
+	"github.com/timmaaaz/ichor/business/domain/products/brandbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productcategorybus"
+	"github.com/timmaaaz/ichor/business/domain/sales/customersbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/lineitemfulfillmentstatusbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/orderfulfillmentstatusbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/orderlineitemsbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/ordersbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+	"github.com/timmaaaz/ichor/business/sdk/dbtest"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// ShipmentSeedData holds shipment specific test state.
+type ShipmentSeedData struct {
+	apitest.SeedData
+
+	// Shipments are packing shipments, sorted by ID.
+	Shipments []shipmentapp.Shipment
+
+	// StagingLocation sits in an outbound staging zone in the warehouse of
+	// the pick location.
+	StagingLocation inventorylocationapp.InventoryLocation
+}
+
+func insertSeedData(db *dbtest.Database, ath *auth.Auth) (ShipmentSeedData, error) {
+	ctx := context.Background()
+	busDomain := db.BusDomain
+
+	// =========================================================================
+	// Users
+	// =========================================================================
+
+	usrs, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.User, busDomain.User)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	tu1 := apitest.User{
+		User:  usrs[0],
+		Token: apitest.Token(busDomain.User, ath, usrs[0].Email.Address),
+	}
+
+	admins, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.Admin, busDomain.User)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding admin : %w", err)
+	}
+
+	tu2 := apitest.User{
+		User:  admins[0],
+		Token: apitest.Token(busDomain.User, ath, admins[0].Email.Address),
+	}
+
+	// =========================================================================
+	// Geography
+	// =========================================================================
+
+	const warehouseCount = 2
+
+	regions, err := busDomain.Region.Query(ctx, regionbus.QueryFilter{}, regionbus.DefaultOrderBy, page.MustParse("1", "5"))
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("querying regions : %w", err)
+	}
+
+	regionIDs := make([]uuid.UUID, 0, len(regions))
+	for _, r := range regions {
+		regionIDs = append(regionIDs, r.ID)
+	}
+
+	ctys, err := citybus.TestSeedCities(ctx, warehouseCount, regionIDs, busDomain.City)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding cities : %w", err)
+	}
+
+	ctyIDs := make([]uuid.UUID, 0, len(ctys))
+	for _, c := range ctys {
+		ctyIDs = append(ctyIDs, c.ID)
+	}
+
+	strs, err := streetbus.TestSeedStreets(ctx, warehouseCount, ctyIDs, busDomain.Street)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding streets : %w", err)
+	}
+
+	strIDs := make([]uuid.UUID, 0, len(strs))
+	for _, s := range strs {
+		strIDs = append(strIDs, s.ID)
+	}
+
+	// =========================================================================
+	// Warehouse Infrastructure
+	// =========================================================================
+
+	warehouses, err := warehousebus.TestSeedWarehouses(ctx, warehouseCount, tu1.ID, strIDs, busDomain.Warehouse)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding warehouses : %w", err)
+	}
+
+	warehouseIDs := make(uuid.UUIDs, len(warehouses))
+	for i, w := range warehouses {
+		warehouseIDs[i] = w.ID
+	}
+
+	zones, err := zonebus.TestSeedZone(ctx, 4, warehouseIDs, busDomain.Zones)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding zones : %w", err)
+	}
+
+	inventoryLocations, err := inventorylocationbus.TestSeedInventoryLocations(ctx, 5, warehouseIDs, zones, busDomain.InventoryLocation)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding inventory locations : %w", err)
+	}
+
+	locationIDs := make([]uuid.UUID, len(inventoryLocations))
+	for i, il := range inventoryLocations {
+		locationIDs[i] = il.LocationID
+	}
+
+	outbound := zonebus.Stages.Outbound
+	stagingZone, err := busDomain.Zones.Create(ctx, zonebus.NewZone{
+		WarehouseID: inventoryLocations[0].WarehouseID,
+		Name:        "Outbound Staging",
+		Description: "Picked stock waiting to ship",
+		Stage:       &outbound,
+	})
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding staging zone : %w", err)
+	}
+
+	stagingLocation, err := busDomain.InventoryLocation.Create(ctx, inventorylocationbus.NewInventoryLocation{
+		WarehouseID: inventoryLocations[0].WarehouseID,
+		ZoneID:      stagingZone.ZoneID,
+		Aisle:       "STG",
+		Rack:        "01",
+		Shelf:       "01",
+		Bin:         "01",
+		MaxCapacity: 500,
+	})
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding staging location : %w", err)
+	}
+
+	// =========================================================================
+	// Products
+	// =========================================================================
+
+	tzs, err := busDomain.Timezone.Query(ctx, timezonebus.QueryFilter{}, timezonebus.DefaultOrderBy, page.MustParse("1", "100"))
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("querying timezones : %w", err)
+	}
+
+	tzIDs := make([]uuid.UUID, 0, len(tzs))
+	for _, tz := range tzs {
+		tzIDs = append(tzIDs, tz.ID)
+	}
+
+	contacts, err := contactinfosbus.TestSeedContactInfos(ctx, 2, strIDs, tzIDs, busDomain.ContactInfos)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding contact info : %w", err)
+	}
+
+	contactIDs := make(uuid.UUIDs, len(contacts))
+	for i, c := range contacts {
+		contactIDs[i] = c.ID
+	}
+
+	brands, err := brandbus.TestSeedBrands(ctx, 2, contactIDs, busDomain.Brand)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding brands : %w", err)
+	}
+
+	brandIDs := make(uuid.UUIDs, len(brands))
+	for i, b := range brands {
+		brandIDs[i] = b.BrandID
+	}
+
+	pc, err := productcategorybus.TestSeedProductCategories(ctx, 2, busDomain.ProductCategory)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding product categories : %w", err)
+	}
+
+	pcIDs := make(uuid.UUIDs, len(pc))
+	for i, p := range pc {
+		pcIDs[i] = p.ProductCategoryID
+	}
+
+	products, err := productbus.TestSeedProducts(ctx, 5, brandIDs, pcIDs, busDomain.Product)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding products : %w", err)
+	}
+
+	productIDs := make(uuid.UUIDs, len(products))
+	for i, p := range products {
+		productIDs[i] = p.ProductID
+	}
+
+	// =========================================================================
+	// Sales: Customers → Orders → Order Line Items
+	// =========================================================================
+
+	customers, err := customersbus.TestSeedCustomers(ctx, 2, strIDs, contactIDs, uuid.UUIDs{tu1.ID, tu2.ID}, busDomain.Customers)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding customers : %w", err)
+	}
+
+	customerIDs := make(uuid.UUIDs, len(customers))
+	for i, c := range customers {
+		customerIDs[i] = c.ID
+	}
+
+	ofStatuses, err := orderfulfillmentstatusbus.TestSeedOrderFulfillmentStatuses(ctx, busDomain.OrderFulfillmentStatus)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding order fulfillment statuses : %w", err)
+	}
+
+	ofIDs := make(uuid.UUIDs, len(ofStatuses))
+	for i, s := range ofStatuses {
+		ofIDs[i] = s.ID
+	}
+
+	// Ship advances an order only out of READY_TO_SHIP, which the standard
+	// status seed does not include.
+	readyToShip, err := busDomain.OrderFulfillmentStatus.Create(ctx, orderfulfillmentstatusbus.NewOrderFulfillmentStatus{
+		Name:        "READY_TO_SHIP",
+		Description: "Packed and waiting for the carrier",
+	})
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding ready to ship status : %w", err)
+	}
+
+	currencies, err := currencybus.TestSeedCurrencies(ctx, 2, busDomain.Currency)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding currencies : %w", err)
+	}
+
+	currencyIDs := make(uuid.UUIDs, len(currencies))
+	for i, c := range currencies {
+		currencyIDs[i] = c.ID
+	}
+
+	orders, err := ordersbus.TestSeedOrders(ctx, 3, uuid.UUIDs{tu1.ID, tu2.ID}, customerIDs, ofIDs, currencyIDs, busDomain.Order)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding orders : %w", err)
+	}
+
+	orderIDs := make(uuid.UUIDs, len(orders))
+	for i, o := range orders {
+		orderIDs[i] = o.ID
+	}
+
+	liStatuses, err := lineitemfulfillmentstatusbus.TestSeedLineItemFulfillmentStatuses(ctx, busDomain.LineItemFulfillmentStatus)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding line item fulfillment statuses : %w", err)
+	}
+
+	liStatusIDs := make(uuid.UUIDs, len(liStatuses))
+	for i, s := range liStatuses {
+		liStatusIDs[i] = s.ID
+	}
+
+	lineItems, err := orderlineitemsbus.TestSeedOrderLineItems(ctx, 5, orderIDs, productIDs, liStatusIDs, uuid.UUIDs{tu1.ID, tu2.ID}, busDomain.OrderLineItem)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding order line items : %w", err)
+	}
+
+	lineItemIDs := make(uuid.UUIDs, len(lineItems))
+	for i, li := range lineItems {
+		lineItemIDs[i] = li.ID
+	}
+
+	// =========================================================================
+	// Pick Flow: Order 0 is ready to ship, line item 0 is allocated and has a
+	// pending pick task at location 0. A second order holds an allocation of
+	// the same product at the same location, with its own pending pick task.
+	// =========================================================================
+
+	flowLine := lineItems[0]
+
+	for _, o := range orders {
+		if o.ID != flowLine.OrderID {
+			continue
+		}
+		if _, err := busDomain.Order.Update(ctx, o, ordersbus.UpdateOrder{FulfillmentStatusID: &readyToShip.ID}); err != nil {
+			return ShipmentSeedData{}, fmt.Errorf("updating order status : %w", err)
+		}
+	}
+
+	var otherOrderID uuid.UUID
+	for _, o := range orders {
+		if o.ID != flowLine.OrderID {
+			otherOrderID = o.ID
+			break
+		}
+	}
+
+	const pickQty = 10
+
+	otherLine, err := busDomain.OrderLineItem.Create(ctx, orderlineitemsbus.NewOrderLineItem{
+		OrderID:                       otherOrderID,
+		ProductID:                     flowLine.ProductID,
+		Description:                   flowLine.Description,
+		Quantity:                      pickQty,
+		UnitPrice:                     flowLine.UnitPrice,
+		Discount:                      flowLine.Discount,
+		DiscountType:                  flowLine.DiscountType,
+		LineTotal:                     flowLine.LineTotal,
+		LineItemFulfillmentStatusesID: flowLine.LineItemFulfillmentStatusesID,
+		CreatedBy:                     tu2.ID,
+	})
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding other order line item : %w", err)
+	}
+	lineItems = append(lineItems, otherLine)
+
+	item, err := busDomain.InventoryItem.Create(ctx, inventoryitembus.NewInventoryItem{
+		ProductID:             flowLine.ProductID,
+		LocationID:            locationIDs[0],
+		Quantity:              50,
+		AllocatedQuantity:     2 * pickQty,
+		MinimumStock:          10,
+		MaximumStock:          100,
+		ReorderPoint:          20,
+		EconomicOrderQuantity: 50,
+		SafetyStock:           15,
+		AvgDailyUsage:         5,
+	})
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding inventory item : %w", err)
+	}
+
+	tasks := make([]picktaskbus.PickTask, 0, 2)
+	for _, line := range []orderlineitemsbus.OrderLineItem{flowLine, otherLine} {
+		task, err := busDomain.PickTask.Create(ctx, picktaskbus.NewPickTask{
+			SalesOrderID:         line.OrderID,
+			SalesOrderLineItemID: line.ID,
+			ProductID:            line.ProductID,
+			LocationID:           locationIDs[0],
+			QuantityToPick:       pickQty,
+			CreatedBy:            tu2.ID,
+		})
+		if err != nil {
+			return ShipmentSeedData{}, fmt.Errorf("seeding pick task : %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	// =========================================================================
+	// Shipments
+	// =========================================================================
+
+	shipments, err := shipmentbus.TestSeedShipments(ctx, 4, orderIDs, tu2.ID, busDomain.Shipment)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding shipments : %w", err)
+	}
+
+	// =========================================================================
+	// Permissions
+	// =========================================================================
+	roles, err := rolebus.TestSeedRoles(ctx, 2, busDomain.Role)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding roles : %w", err)
+	}
+
+	roleIDs := make(uuid.UUIDs, len(roles))
+	for i, r := range roles {
+		roleIDs[i] = r.ID
+	}
+
+	userIDs := uuid.UUIDs{tu1.ID, tu2.ID}
+
+	_, err = userrolebus.TestSeedUserRoles(ctx, userIDs, roleIDs, busDomain.UserRole)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding user roles : %w", err)
+	}
+
+	_, err = tableaccessbus.TestSeedTableAccess(ctx, roleIDs, busDomain.TableAccess)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("seeding table access : %w", err)
+	}
+
+	ur1, err := busDomain.UserRole.QueryByUserID(ctx, tu1.ID)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("querying user1 roles : %w", err)
+	}
+
+	usrRoleIDs := make(uuid.UUIDs, len(ur1))
+	for i, r := range ur1 {
+		usrRoleIDs[i] = r.RoleID
+	}
+
+	tas, err := busDomain.TableAccess.QueryByRoleIDs(ctx, usrRoleIDs)
+	if err != nil {
+		return ShipmentSeedData{}, fmt.Errorf("querying table access : %w", err)
+	}
+
+	for _, ta := range tas {
+		if ta.TableName == shipmentapi.RouteTable {
+			update := tableaccessbus.UpdateTableAccess{
+				CanCreate: dbtest.BoolPointer(false),
+				CanUpdate: dbtest.BoolPointer(false),
+				CanDelete: dbtest.BoolPointer(false),
+				CanRead:   dbtest.BoolPointer(true),
+			}
+			_, err := busDomain.TableAccess.Update(ctx, ta, update)
+			if err != nil {
+				return ShipmentSeedData{}, fmt.Errorf("updating table access : %w", err)
+			}
+		}
+	}
+
+	return ShipmentSeedData{
+		SeedData: apitest.SeedData{
+			Admins:             []apitest.User{tu2},
+			Users:              []apitest.User{tu1},
+			Warehouses:         warehouseapp.ToAppWarehouses(warehouses),
+			Zones:              zoneapp.ToAppZones(zones),
+			InventoryLocations: inventorylocationapp.ToAppInventoryLocations(inventoryLocations),
+			InventoryItems:     []inventoryitemapp.InventoryItem{inventoryitemapp.ToAppInventoryItem(item)},
+			Products:           productapp.ToAppProducts(products),
+			Orders:             ordersapp.ToAppOrders(orders),
+			OrderLineItems:     orderlineitemsapp.ToAppOrderLineItems(lineItems),
+			PickTasks:          picktaskapp.ToAppPickTasks(tasks),
+		},
+		Shipments:       shipmentapp.ToAppShipments(shipments),
+		StagingLocation: inventorylocationapp.ToAppInventoryLocation(stagingLocation),
+	}, nil
+}
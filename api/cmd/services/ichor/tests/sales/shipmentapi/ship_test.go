@@ -0,0 +1,361 @@
+package shipmentapi_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/inventory/picktaskapp"
+	"github.com/timmaaaz/ichor/app/domain/sales/shipmentapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
+	"github.com/timmaaaz/ichor/business/sdk/dbtest"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+func ship400(sd ShipmentSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "no-cartons",
+			URL:        fmt.Sprintf("/v1/sales/shipments/%s/ship", sd.Shipments[1].ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input:      &shipmentapp.ShipRequest{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.FailedPrecondition, "ship: shipment has no cartons"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func cancel200(sd ShipmentSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "basic",
+			URL:        fmt.Sprintf("/v1/sales/shipments/%s/cancel", sd.Shipments[2].ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			GotResp:    &shipmentapp.Shipment{},
+			ExpResp:    &shipmentapp.Shipment{},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*shipmentapp.Shipment)
+				if !exists {
+					return "error occurred"
+				}
+				if gotResp.Status != "cancelled" {
+					return fmt.Sprintf("expected status=cancelled, got %s", gotResp.Status)
+				}
+				return ""
+			},
+		},
+	}
+}
+
+func cancel400(sd ShipmentSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "already-cancelled",
+			URL:        fmt.Sprintf("/v1/sales/shipments/%s/cancel", sd.Shipments[2].ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.FailedPrecondition, "cancel: shipment is not in a state that allows this transition: must be packing, got cancelled"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "ship-cancelled",
+			URL:        fmt.Sprintf("/v1/sales/shipments/%s/ship", sd.Shipments[2].ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusBadRequest,
+			Input:      &shipmentapp.ShipRequest{},
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.FailedPrecondition, "ship: shipment is not in a state that allows this transition: must be packing, got cancelled"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+// Test_Shipment_PickPackShip walks an allocated line through pick, pack and
+// ship and verifies the stock moves exactly once per step: picking takes the
+// units and their allocation off the pick face and stages them, shipping takes
+// them out of staging. The other order's allocation at the pick face is left
+// alone.
+func Test_Shipment_PickPackShip(t *testing.T) {
+	t.Parallel()
+
+	test := apitest.StartTest(t, "Test_Shipment_PickPackShip")
+
+	sd, err := insertSeedData(test.DB, test.Auth)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	ctx := context.Background()
+	task := sd.PickTasks[0]
+	before := sd.InventoryItems[0]
+	picked := mustAtoi(t, task.QuantityToPick)
+
+	// Step 1: Pick into staging.
+	completePick(t, test, sd, task)
+
+	// Step 2: Pack the staged units and ship.
+	packAndShip(t, test, sd, task)
+
+	// Step 3: The pick face dropped once, by the pick, and still holds the
+	// other order's allocation; staging is empty again.
+	productID := uuid.MustParse(task.ProductID)
+	locationID := uuid.MustParse(task.LocationID)
+	stagingID := uuid.MustParse(sd.StagingLocation.LocationID)
+
+	face := queryInventoryItem(t, test, productID, locationID)
+	if exp := mustAtoi(t, before.Quantity) - picked; face.Quantity != exp {
+		t.Errorf("pick face quantity: expected %d, got %d", exp, face.Quantity)
+	}
+	if exp := mustAtoi(t, before.AllocatedQuantity) - picked; face.AllocatedQuantity != exp {
+		t.Errorf("pick face allocated_quantity: expected %d, got %d", exp, face.AllocatedQuantity)
+	}
+
+	staged := queryInventoryItem(t, test, productID, stagingID)
+	if staged.Quantity != 0 || staged.AllocatedQuantity != 0 {
+		t.Errorf("staging: expected 0/0, got %d/%d", staged.Quantity, staged.AllocatedQuantity)
+	}
+
+	// Step 4: The ledger holds the PICK at the pick face, and the STAGE and
+	// SHIP at the staging location.
+	txns, err := test.DB.BusDomain.InventoryTransaction.Query(ctx,
+		inventorytransactionbus.QueryFilter{
+			ProductID: &productID,
+		},
+		inventorytransactionbus.DefaultOrderBy,
+		page.MustParse("1", "10"),
+	)
+	if err != nil {
+		t.Fatalf("query inventory transactions: %v", err)
+	}
+
+	got := make(map[string]inventorytransactionbus.InventoryTransaction)
+	for _, txn := range txns {
+		got[txn.TransactionType] = txn
+	}
+	if len(txns) != 3 || len(got) != 3 {
+		t.Fatalf("expected PICK, STAGE and SHIP transactions, got %d", len(txns))
+	}
+
+	exp := []struct {
+		typ      string
+		location uuid.UUID
+		quantity int
+	}{
+		{"PICK", locationID, -picked},
+		{"STAGE", stagingID, picked},
+		{"SHIP", stagingID, -picked},
+	}
+	for _, e := range exp {
+		txn, ok := got[e.typ]
+		if !ok {
+			t.Errorf("missing %s transaction", e.typ)
+			continue
+		}
+		if txn.LocationID != e.location {
+			t.Errorf("%s location: expected %s, got %s", e.typ, e.location, txn.LocationID)
+		}
+		if txn.Quantity != e.quantity {
+			t.Errorf("%s quantity: expected %d, got %d", e.typ, e.quantity, txn.Quantity)
+		}
+	}
+}
+
+// Test_Shipment_ShipLeavesOtherOrders stages two orders allocated at the same
+// pick face and ships one of them. Only the shipped order's units may leave
+// staging, and the pick face keeps what was not picked.
+func Test_Shipment_ShipLeavesOtherOrders(t *testing.T) {
+	t.Parallel()
+
+	test := apitest.StartTest(t, "Test_Shipment_ShipLeavesOtherOrders")
+
+	sd, err := insertSeedData(test.DB, test.Auth)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	taskA, taskB := sd.PickTasks[0], sd.PickTasks[1]
+	if taskA.SalesOrderID == taskB.SalesOrderID || taskA.LocationID != taskB.LocationID {
+		t.Fatalf("expected two orders picking at one location")
+	}
+	before := sd.InventoryItems[0]
+	pickedA := mustAtoi(t, taskA.QuantityToPick)
+	pickedB := mustAtoi(t, taskB.QuantityToPick)
+
+	completePick(t, test, sd, taskA)
+	completePick(t, test, sd, taskB)
+
+	packAndShip(t, test, sd, taskA)
+
+	productID := uuid.MustParse(taskA.ProductID)
+
+	face := queryInventoryItem(t, test, productID, uuid.MustParse(taskA.LocationID))
+	if exp := mustAtoi(t, before.Quantity) - pickedA - pickedB; face.Quantity != exp {
+		t.Errorf("pick face quantity: expected %d, got %d", exp, face.Quantity)
+	}
+	if exp := mustAtoi(t, before.AllocatedQuantity) - pickedA - pickedB; face.AllocatedQuantity != exp {
+		t.Errorf("pick face allocated_quantity: expected %d, got %d", exp, face.AllocatedQuantity)
+	}
+
+	staged := queryInventoryItem(t, test, productID, uuid.MustParse(sd.StagingLocation.LocationID))
+	if staged.Quantity != pickedB {
+		t.Errorf("staging quantity: expected %d, got %d", pickedB, staged.Quantity)
+	}
+	if staged.AllocatedQuantity != pickedB {
+		t.Errorf("staging allocated_quantity: expected %d, got %d", pickedB, staged.AllocatedQuantity)
+	}
+}
+
+// completePick completes the pick task, staging the picked units at the seeded
+// staging location.
+func completePick(t *testing.T, test *apitest.Test, sd ShipmentSeedData, task picktaskapp.PickTask) {
+	t.Helper()
+
+	test.Run(t, []apitest.Table{
+		{
+			Name:       "complete",
+			URL:        fmt.Sprintf("/v1/inventory/pick-tasks/%s", task.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPut,
+			StatusCode: http.StatusOK,
+			Input: &picktaskapp.UpdatePickTask{
+				Status:            dbtest.StringPointer("completed"),
+				StagingLocationID: dbtest.StringPointer(sd.StagingLocation.LocationID),
+			},
+			GotResp: &picktaskapp.PickTask{},
+			ExpResp: &picktaskapp.PickTask{},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*picktaskapp.PickTask)
+				if !exists {
+					return "error occurred"
+				}
+				if gotResp.Status != "completed" {
+					return fmt.Sprintf("expected status=completed, got %s", gotResp.Status)
+				}
+				return ""
+			},
+		},
+	}, "pick")
+}
+
+// packAndShip packs the task's units from the staging location into a carton
+// on the task order's shipment and ships it.
+func packAndShip(t *testing.T, test *apitest.Test, sd ShipmentSeedData, task picktaskapp.PickTask) {
+	t.Helper()
+
+	var shipment shipmentapp.Shipment
+	for _, sh := range sd.Shipments {
+		if sh.OrderID == task.SalesOrderID {
+			shipment = sh
+			break
+		}
+	}
+	if shipment.ID == "" {
+		t.Fatalf("no seeded shipment for order %s", task.SalesOrderID)
+	}
+
+	test.Run(t, []apitest.Table{
+		{
+			Name:       "add-carton",
+			URL:        fmt.Sprintf("/v1/sales/shipments/%s/cartons", shipment.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input: &shipmentapp.NewCarton{
+				Lines: []shipmentapp.NewCartonLine{
+					{
+						OrderLineItemID: task.SalesOrderLineItemID,
+						LocationID:      sd.StagingLocation.LocationID,
+						Quantity:        task.QuantityToPick,
+					},
+				},
+			},
+			GotResp: &shipmentapp.Carton{},
+			ExpResp: &shipmentapp.Carton{},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*shipmentapp.Carton)
+				if !exists {
+					return "error occurred"
+				}
+				if len(gotResp.Lines) != 1 {
+					return fmt.Sprintf("expected 1 carton line, got %d", len(gotResp.Lines))
+				}
+				return ""
+			},
+		},
+	}, "pack")
+
+	test.Run(t, []apitest.Table{
+		{
+			Name:       "ship",
+			URL:        fmt.Sprintf("/v1/sales/shipments/%s/ship", shipment.ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPost,
+			StatusCode: http.StatusOK,
+			Input:      &shipmentapp.ShipRequest{},
+			GotResp:    &shipmentapp.Shipment{},
+			ExpResp:    &shipmentapp.Shipment{},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*shipmentapp.Shipment)
+				if !exists {
+					return "error occurred"
+				}
+				if gotResp.Status != "shipped" {
+					return fmt.Sprintf("expected status=shipped, got %s", gotResp.Status)
+				}
+				return ""
+			},
+		},
+	}, "ship")
+}
+
+func queryInventoryItem(t *testing.T, test *apitest.Test, productID, locationID uuid.UUID) inventoryitembus.InventoryItem {
+	t.Helper()
+
+	items, err := test.DB.BusDomain.InventoryItem.Query(context.Background(),
+		inventoryitembus.QueryFilter{
+			ProductID:  &productID,
+			LocationID: &locationID,
+		},
+		inventoryitembus.DefaultOrderBy,
+		page.MustParse("1", "10"),
+	)
+	if err != nil {
+		t.Fatalf("query inventory items: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected 1 inventory item at location %s, got %d", locationID, len(items))
+	}
+
+	return items[0]
+}
+
+func mustAtoi(t *testing.T, s string) int {
+	t.Helper()
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		t.Fatalf("parse %q: %v", s, err)
+	}
+	return n
+}
@@ -0,0 +1,118 @@
+package shipmentapi_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/api/sdk/http/apitest"
+	"github.com/timmaaaz/ichor/app/domain/sales/shipmentapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/sdk/dbtest"
+)
+
+func update200(sd ShipmentSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "carrier-and-tracking",
+			URL:        fmt.Sprintf("/v1/sales/shipments/%s", sd.Shipments[0].ID),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPut,
+			StatusCode: http.StatusOK,
+			Input: &shipmentapp.UpdateShipment{
+				Carrier:        dbtest.StringPointer("FedEx"),
+				TrackingNumber: dbtest.StringPointer("1Z999"),
+			},
+			GotResp: &shipmentapp.Shipment{},
+			ExpResp: &shipmentapp.Shipment{
+				ID:             sd.Shipments[0].ID,
+				ShipmentNumber: sd.Shipments[0].ShipmentNumber,
+				OrderID:        sd.Shipments[0].OrderID,
+				Status:         sd.Shipments[0].Status,
+				Carrier:        "FedEx",
+				ServiceLevel:   sd.Shipments[0].ServiceLevel,
+				TrackingNumber: "1Z999",
+				Notes:          sd.Shipments[0].Notes,
+				CreatedBy:      sd.Shipments[0].CreatedBy,
+				UpdatedBy:      sd.Admins[0].ID.String(),
+				CreatedDate:    sd.Shipments[0].CreatedDate,
+			},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*shipmentapp.Shipment)
+				if !exists {
+					return "error occurred"
+				}
+				expResp := exp.(*shipmentapp.Shipment)
+				expResp.UpdatedDate = gotResp.UpdatedDate
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func update401(sd ShipmentSeedData) []apitest.Table {
+	return []apitest.Table{
+		{
+			Name:       "empty-token",
+			URL:        fmt.Sprintf("/v1/sales/shipments/%s", sd.Shipments[0].ID),
+			Token:      "&nbsp;",
+			Method:     http.MethodPut,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "error parsing token: token contains an invalid number of segments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "bad-sig",
+			URL:        fmt.Sprintf("/v1/sales/shipments/%s", sd.Shipments[0].ID),
+			Token:      sd.Admins[0].Token + "A",
+			Method:     http.MethodPut,
+			StatusCode: http.StatusUnauthorized,
+			GotResp:    &errs.Error{},
+			ExpResp:    errs.Newf(errs.Unauthenticated, "authentication failed : bindings results[[{[true] map[x:false]}]] ok[true]"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:       "no-update-permission",
+			URL:        fmt.Sprintf("/v1/sales/shipments/%s", sd.Shipments[0].ID),
+			Token:      sd.Users[0].Token,
+			Method:     http.MethodPut,
+			StatusCode: http.StatusForbidden,
+			Input: &shipmentapp.UpdateShipment{
+				Carrier: dbtest.StringPointer("DHL"),
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.PermissionDenied, "user does not have permission UPDATE for table: sales.shipments"),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func update404(sd ShipmentSeedData) []apitest.Table {
+	id := uuid.NewString()
+
+	return []apitest.Table{
+		{
+			Name:       "not-found",
+			URL:        fmt.Sprintf("/v1/sales/shipments/%s", id),
+			Token:      sd.Admins[0].Token,
+			Method:     http.MethodPut,
+			StatusCode: http.StatusNotFound,
+			Input: &shipmentapp.UpdateShipment{
+				Carrier: dbtest.StringPointer("DHL"),
+			},
+			GotResp: &errs.Error{},
+			ExpResp: errs.Newf(errs.NotFound, "querybyid: shipmentID[%s]: namedquerystruct: shipment not found", id),
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
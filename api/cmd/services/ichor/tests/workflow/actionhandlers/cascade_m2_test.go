@@ -80,7 +80,7 @@ func TestCascade_M2_EventContract(t *testing.T) {
 			})
 
 		h := inventory.NewAllocateInventoryHandler(db.Log, db.DB, db.BusDomain.InventoryItem,
-			db.BusDomain.InventoryLocation, db.BusDomain.InventoryTransaction, db.BusDomain.Product, db.BusDomain.Workflow)
+			db.BusDomain.InventoryLocation, db.BusDomain.InventoryTransaction, db.BusDomain.Product, db.BusDomain.Workflow, db.BusDomain.InventoryReservation)
 		cfg := mustJSON(t, map[string]any{
 			"inventory_items":     []map[string]any{{"product_id": base.productIDs[0].String(), "quantity": 10}},
 			"allocation_mode":     "allocate",
@@ -185,7 +185,7 @@ func TestCascade_M2_LiveCascade(t *testing.T) {
 		t.Fatalf("seeding inventory item: %v", err)
 	}
 	h := inventory.NewAllocateInventoryHandler(db.Log, db.DB, db.BusDomain.InventoryItem,
-		db.BusDomain.InventoryLocation, db.BusDomain.InventoryTransaction, db.BusDomain.Product, db.BusDomain.Workflow)
+		db.BusDomain.InventoryLocation, db.BusDomain.InventoryTransaction, db.BusDomain.Product, db.BusDomain.Workflow, db.BusDomain.InventoryReservation)
 	cfg := mustJSON(t, map[string]any{
 		"inventory_items":     []map[string]any{{"product_id": base.productIDs[0].String(), "quantity": 10}},
 		"allocation_mode":     "allocate",
@@ -24,6 +24,7 @@ package actionhandlers_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -35,12 +36,17 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/geography/citybus"
 	"github.com/timmaaaz/ichor/business/domain/geography/regionbus"
 	"github.com/timmaaaz/ichor/business/domain/geography/streetbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountsessionbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryadjustmentbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/picktaskbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/putawaytaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/transferorderbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/warehousebus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/zonebus"
@@ -48,13 +54,18 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitembus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitemstatusbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderstatusbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/supplierbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/supplierproductbus"
 	"github.com/timmaaaz/ichor/business/domain/products/brandbus"
 	"github.com/timmaaaz/ichor/business/domain/products/productbus"
 	"github.com/timmaaaz/ichor/business/domain/products/productcategorybus"
 	"github.com/timmaaaz/ichor/business/domain/sales/orderfulfillmentstatusbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/orderlineitemsbus"
 	"github.com/timmaaaz/ichor/business/domain/sales/ordersbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus/flatrate"
 	"github.com/timmaaaz/ichor/business/domain/workflow/approvalrequestbus"
 	"github.com/timmaaaz/ichor/business/sdk/dbtest"
 	"github.com/timmaaaz/ichor/business/sdk/delegate"
@@ -64,6 +75,7 @@ import (
 	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/data"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/inventory"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/procurement"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/shipping"
 
 	"github.com/timmaaaz/ichor/business/sdk/workflowdomains"
 )
@@ -134,8 +146,16 @@ var entityDomain = map[string]string{
 	"inventory.put_away_tasks":              putawaytaskbus.DomainName,
 	"inventory.pick_tasks":                  picktaskbus.DomainName,              // release_to_picking fan-out
 	"sales.orders":                          ordersbus.DomainName,                // release_to_picking status flip
+	"sales.shipments":                       shipmentbus.DomainName,              // create_shipping_label tracking number
 	"products.product_categories":           productcategorybus.DomainName,       // P4 M1 (create_entity/transition_status)
 	"allocation_results":                    workflow.AllocationResultDomainName, // P4 M2
+	"inventory.cycle_count_sessions":        cyclecountsessionbus.DomainName,     // generate_cycle_counts
+	"inventory.cycle_count_items":           cyclecountitembus.DomainName,        // generate_cycle_counts
+	"inventory.count_plans":                 countplanbus.DomainName,             // generate_cycle_counts
+	"inventory.replenishment_tasks":         replenishmenttaskbus.DomainName,     // generate_replenishment
+	"inventory.demand_forecasts":            demandforecastbus.DomainName,        // forecast_demand
+	"procurement.purchase_suggestions":      purchasesuggestionbus.DomainName,    // suggest_purchases
+	"procurement.supplier_invoices":         supplierinvoicebus.DomainName,       // match/approve/reject_supplier_invoice
 }
 
 // knownSilentEntities are declared by a handler but have no delegate. P4 closed the last
@@ -352,7 +372,10 @@ func Test_ManifestConsistency_DeclaredEqualsFired(t *testing.T) {
 		approvalrequestbus.DomainName, inventoryadjustmentbus.DomainName, transferorderbus.DomainName,
 		purchaseorderbus.DomainName, purchaseorderlineitembus.DomainName, inventoryitembus.DomainName,
 		putawaytaskbus.DomainName, productcategorybus.DomainName, workflow.AllocationResultDomainName,
-		ordersbus.DomainName, picktaskbus.DomainName,
+		ordersbus.DomainName, picktaskbus.DomainName, shipmentbus.DomainName,
+		cyclecountsessionbus.DomainName, cyclecountitembus.DomainName, countplanbus.DomainName,
+		replenishmenttaskbus.DomainName, demandforecastbus.DomainName, purchasesuggestionbus.DomainName,
+		supplierinvoicebus.DomainName,
 	} {
 		rec.registerOn(db.BusDomain.Delegate, d)
 	}
@@ -487,7 +510,7 @@ func Test_ManifestConsistency_DeclaredEqualsFired(t *testing.T) {
 		if _, err := inventoryitembus.TestSeedInventoryItems(ctx, 1, []uuid.UUID{base.loc0}, []uuid.UUID{base.productIDs[0]}, db.BusDomain.InventoryItem); err != nil {
 			t.Fatalf("seeding inventory item: %v", err)
 		}
-		h := inventory.NewAllocateInventoryHandler(db.Log, db.DB, db.BusDomain.InventoryItem, db.BusDomain.InventoryLocation, db.BusDomain.InventoryTransaction, db.BusDomain.Product, db.BusDomain.Workflow)
+		h := inventory.NewAllocateInventoryHandler(db.Log, db.DB, db.BusDomain.InventoryItem, db.BusDomain.InventoryLocation, db.BusDomain.InventoryTransaction, db.BusDomain.Product, db.BusDomain.Workflow, db.BusDomain.InventoryReservation)
 		cfg := mustJSON(t, map[string]any{
 			"inventory_items":     []map[string]any{{"product_id": base.productIDs[0].String(), "quantity": 10}},
 			"allocation_mode":     "allocate",
@@ -504,7 +527,7 @@ func Test_ManifestConsistency_DeclaredEqualsFired(t *testing.T) {
 		if _, err := inventoryitembus.TestSeedInventoryItems(ctx, 1, []uuid.UUID{base.loc1}, []uuid.UUID{base.productIDs[1]}, db.BusDomain.InventoryItem); err != nil {
 			t.Fatalf("seeding inventory item: %v", err)
 		}
-		h := inventory.NewReserveInventoryHandler(db.Log, db.DB, db.BusDomain.InventoryItem, db.BusDomain.Workflow)
+		h := inventory.NewReserveInventoryHandler(db.Log, db.DB, db.BusDomain.InventoryItem, db.BusDomain.Workflow, db.BusDomain.InventoryReservation)
 		cfg := mustJSON(t, map[string]any{
 			"product_id":                 base.productIDs[1].String(),
 			"quantity":                   10,
@@ -540,7 +563,7 @@ func Test_ManifestConsistency_DeclaredEqualsFired(t *testing.T) {
 
 	// 11. create_put_away_task → putawaytask.created (needs quantity_received delta > 0).
 	t.Run("create_put_away_task", func(t *testing.T) {
-		h := inventory.NewCreatePutAwayTaskHandler(db.Log, db.DB, db.BusDomain.PutAwayTask, db.BusDomain.SupplierProduct, db.BusDomain.PurchaseOrder)
+		h := inventory.NewCreatePutAwayTaskHandler(db.Log, db.DB, db.BusDomain.PutAwayTask, db.BusDomain.SupplierProduct, db.BusDomain.PurchaseOrder, db.BusDomain.InventoryLocation)
 		cfg := mustJSON(t, map[string]any{
 			"source_from_po":    false,
 			"product_id":        base.productIDs[0].String(),
@@ -724,7 +747,9 @@ func Test_ManifestConsistency_DeclaredEqualsFired(t *testing.T) {
 		if err != nil {
 			t.Fatalf("seeding approved transfer order: %v", err)
 		}
-		h := inventory.NewClaimTransferOrderHandler(db.Log, db.BusDomain.TransferOrder)
+		// Claim ships the lines into the source warehouse's in-transit location, so it needs
+		// stock at the source; subtest #8 (allocate) seeded (loc0, productIDs[0]) at qty 100.
+		h := inventory.NewClaimTransferOrderHandler(db.Log, db.DB, db.BusDomain.TransferOrder, db.BusDomain.InventoryTransaction, db.BusDomain.InventoryItem)
 		cfg := mustJSON(t, map[string]any{"transfer_order_id": to.TransferID.String()})
 		run(t, "claim_transfer_order", h, cfg, workflow.ActionExecutionContext{UserID: uid})
 	})
@@ -746,12 +771,190 @@ func Test_ManifestConsistency_DeclaredEqualsFired(t *testing.T) {
 		cfg := mustJSON(t, map[string]any{"transfer_order_id": to.TransferID.String()})
 		run(t, "execute_transfer_order", h, cfg, workflow.ActionExecutionContext{UserID: uid})
 	})
+
+	// 20. create_shipping_label → shipments.updated. The shipment needs a packed carton
+	// for the carrier to rate; the flat-rate carrier buys the label offline.
+	t.Run("create_shipping_label", func(t *testing.T) {
+		orderID := seedOrderWithLineItem(t, ctx, db, base, base.productIDs[1])
+		lines, err := db.BusDomain.OrderLineItem.Query(ctx, orderlineitemsbus.QueryFilter{OrderID: &orderID}, orderlineitemsbus.DefaultOrderBy, page.MustParse("1", "1"))
+		if err != nil || len(lines) == 0 {
+			t.Fatalf("querying order line items: %v", err)
+		}
+		sh, err := db.BusDomain.Shipment.Create(ctx, shipmentbus.NewShipment{
+			ShipmentNumber: "SHP-CONSISTENCY", OrderID: orderID, CreatedBy: uid,
+		})
+		if err != nil {
+			t.Fatalf("seeding shipment: %v", err)
+		}
+		if _, err := db.BusDomain.Shipment.AddCarton(ctx, sh, shipmentbus.NewCarton{
+			Weight: 1.5, WeightUnit: "lb",
+			Lines: []shipmentbus.NewCartonLine{{
+				OrderLineItemID: lines[0].ID, ProductID: lines[0].ProductID, LocationID: base.loc0, Quantity: 1,
+			}},
+		}); err != nil {
+			t.Fatalf("seeding carton: %v", err)
+		}
+		h := shipping.NewCreateShippingLabelHandler(db.Log, db.BusDomain.Shipment.WithCarrier(flatrate.New(nil)), nil)
+		cfg := mustJSON(t, map[string]any{"shipment_id": sh.ID.String(), "service_level": "ground"})
+		run(t, "create_shipping_label", h, cfg, workflow.ActionExecutionContext{UserID: uid})
+	})
+
+	// 21. generate_cycle_counts → cycle_count_sessions.created + cycle_count_items.created +
+	// count_plans.updated. A zero_on_hand plan needs a zero-quantity item to select; the
+	// (productIDs[1], loc1) combo is unused by sibling subtests.
+	t.Run("generate_cycle_counts", func(t *testing.T) {
+		if _, err := db.BusDomain.InventoryItem.Create(ctx, inventoryitembus.NewInventoryItem{
+			ProductID: base.productIDs[1], LocationID: base.loc1, Quantity: 0,
+		}); err != nil {
+			t.Fatalf("seeding zero-quantity inventory item: %v", err)
+		}
+		plan, err := db.BusDomain.CountPlan.Create(ctx, countplanbus.NewCountPlan{
+			Name: "Zero on hand", Rule: countplanbus.Rules.ZeroOnHand, IsActive: true, CreatedBy: uid,
+		})
+		if err != nil {
+			t.Fatalf("seeding count plan: %v", err)
+		}
+		h := inventory.NewGenerateCycleCountsHandler(db.Log, db.BusDomain.CountPlan)
+		cfg := mustJSON(t, map[string]any{"plan_id": plan.ID.String()})
+		run(t, "generate_cycle_counts", h, cfg, workflow.ActionExecutionContext{UserID: uid})
+	})
+
+	// 22. generate_replenishment → replenishment_tasks.created. Needs an empty pick face with a
+	// maximum and reserve stock of the same product in the same warehouse; both locations are
+	// created here so sibling subtests' locations keep their flags.
+	t.Run("generate_replenishment", func(t *testing.T) {
+		loc, err := db.BusDomain.InventoryLocation.QueryByID(ctx, base.loc0)
+		if err != nil {
+			t.Fatalf("querying location: %v", err)
+		}
+		pick, err := db.BusDomain.InventoryLocation.Create(ctx, inventorylocationbus.NewInventoryLocation{
+			WarehouseID: base.warehouseID, ZoneID: loc.ZoneID, Aisle: "R", Rack: "01", Shelf: "01", Bin: "01",
+			IsPickLocation: true, MaxCapacity: 100,
+		})
+		if err != nil {
+			t.Fatalf("seeding pick location: %v", err)
+		}
+		reserve, err := db.BusDomain.InventoryLocation.Create(ctx, inventorylocationbus.NewInventoryLocation{
+			WarehouseID: base.warehouseID, ZoneID: loc.ZoneID, Aisle: "R", Rack: "02", Shelf: "01", Bin: "01",
+			IsReserveLocation: true, MaxCapacity: 100,
+		})
+		if err != nil {
+			t.Fatalf("seeding reserve location: %v", err)
+		}
+		for _, item := range []inventoryitembus.NewInventoryItem{
+			{ProductID: base.productIDs[1], LocationID: pick.LocationID, Quantity: 0, MinimumStock: 2, MaximumStock: 10},
+			{ProductID: base.productIDs[1], LocationID: reserve.LocationID, Quantity: 50},
+		} {
+			if _, err := db.BusDomain.InventoryItem.Create(ctx, item); err != nil {
+				t.Fatalf("seeding inventory item: %v", err)
+			}
+		}
+		h := inventory.NewGenerateReplenishmentHandler(db.Log, db.BusDomain.ReplenishmentTask)
+		cfg := mustJSON(t, map[string]any{"location_id": pick.LocationID.String()})
+		run(t, "generate_replenishment", h, cfg, workflow.ActionExecutionContext{UserID: uid})
+	})
+
+	// 23. forecast_demand → demand_forecasts.created + inventory_items.updated. An auto-applying
+	// default policy and an item with no demand but a typed-in reorder point: the forecast
+	// recommends zero and writes it to the item. The item sits at its own location so the run
+	// touches nothing sibling subtests seeded.
+	t.Run("forecast_demand", func(t *testing.T) {
+		loc, err := db.BusDomain.InventoryLocation.QueryByID(ctx, base.loc0)
+		if err != nil {
+			t.Fatalf("querying location: %v", err)
+		}
+		bin, err := db.BusDomain.InventoryLocation.Create(ctx, inventorylocationbus.NewInventoryLocation{
+			WarehouseID: base.warehouseID, ZoneID: loc.ZoneID, Aisle: "F", Rack: "01", Shelf: "01", Bin: "01",
+			MaxCapacity: 100,
+		})
+		if err != nil {
+			t.Fatalf("seeding location: %v", err)
+		}
+		if _, err := db.BusDomain.InventoryItem.Create(ctx, inventoryitembus.NewInventoryItem{
+			ProductID: base.productIDs[0], LocationID: bin.LocationID, Quantity: 10, ReorderPoint: 5, SafetyStock: 2,
+		}); err != nil {
+			t.Fatalf("seeding inventory item: %v", err)
+		}
+		if _, err := db.BusDomain.DemandForecast.CreatePolicy(ctx, demandforecastbus.NewPolicy{
+			Method: demandforecastbus.Methods.MovingAverage, AutoApply: true, CreatedBy: uid,
+		}); err != nil {
+			t.Fatalf("seeding forecast policy: %v", err)
+		}
+		h := inventory.NewForecastDemandHandler(db.Log, db.BusDomain.DemandForecast)
+		cfg := mustJSON(t, map[string]any{"location_id": bin.LocationID.String()})
+		run(t, "forecast_demand", h, cfg, workflow.ActionExecutionContext{UserID: uid})
+	})
+
+	// 24. suggest_purchases → purchasesuggestion.created. An empty bin of the seeded supplier's
+	// product with a reorder point far above anything earlier subtests put on order, and the
+	// supplier made active since seeding picks is_active at random.
+	t.Run("suggest_purchases", func(t *testing.T) {
+		active := true
+		sup, err := db.BusDomain.Supplier.QueryByID(ctx, base.supplierID)
+		if err != nil {
+			t.Fatalf("querying supplier: %v", err)
+		}
+		if _, err := db.BusDomain.Supplier.Update(ctx, sup, supplierbus.UpdateSupplier{IsActive: &active}); err != nil {
+			t.Fatalf("activating supplier: %v", err)
+		}
+		loc, err := db.BusDomain.InventoryLocation.QueryByID(ctx, base.loc0)
+		if err != nil {
+			t.Fatalf("querying location: %v", err)
+		}
+		bin, err := db.BusDomain.InventoryLocation.Create(ctx, inventorylocationbus.NewInventoryLocation{
+			WarehouseID: base.warehouseID, ZoneID: loc.ZoneID, Aisle: "P", Rack: "01", Shelf: "01", Bin: "01",
+			MaxCapacity: 100,
+		})
+		if err != nil {
+			t.Fatalf("seeding location: %v", err)
+		}
+		if _, err := db.BusDomain.InventoryItem.Create(ctx, inventoryitembus.NewInventoryItem{
+			ProductID: base.productIDs[0], LocationID: bin.LocationID, ReorderPoint: 100000,
+		}); err != nil {
+			t.Fatalf("seeding inventory item: %v", err)
+		}
+		h := procurement.NewSuggestPurchasesHandler(db.Log, db.BusDomain.PurchaseSuggestion)
+		cfg := mustJSON(t, map[string]any{"warehouse_id": base.warehouseID.String(), "product_id": base.productIDs[0].String()})
+		run(t, "suggest_purchases", h, cfg, workflow.ActionExecutionContext{UserID: uid})
+	})
+
+	// 25. match_supplier_invoice → supplierinvoice.updated. Nothing is received on the
+	// order, so the invoice is a quantity variance left pending approval.
+	t.Run("match_supplier_invoice", func(t *testing.T) {
+		inv := seedSupplierInvoice(t, ctx, db, base, "MATCH")
+		h := procurement.NewMatchSupplierInvoiceHandler(db.Log, db.BusDomain.SupplierInvoice)
+		cfg := mustJSON(t, map[string]any{"supplier_invoice_id": inv.ID.String()})
+		run(t, "match_supplier_invoice", h, cfg, workflow.ActionExecutionContext{UserID: uid})
+	})
+
+	// 26. approve_supplier_invoice → supplierinvoice.updated
+	t.Run("approve_supplier_invoice", func(t *testing.T) {
+		inv := seedSupplierInvoice(t, ctx, db, base, "APPROVE")
+		if _, err := db.BusDomain.SupplierInvoice.Match(ctx, inv, uid, time.Now()); err != nil {
+			t.Fatalf("matching supplier invoice: %v", err)
+		}
+		req := seedResolvedApproval(t, ctx, db, uid, approvalrequestbus.StatusApproved)
+		h := procurement.NewApproveSupplierInvoiceHandler(db.Log, db.BusDomain.SupplierInvoice, db.BusDomain.ApprovalRequest)
+		cfg := mustJSON(t, map[string]any{"supplier_invoice_id": inv.ID.String(), "approval_request_id": req.ID.String(), "approval_reason": "ok"})
+		run(t, "approve_supplier_invoice", h, cfg, workflow.ActionExecutionContext{UserID: uid})
+	})
+
+	// 27. reject_supplier_invoice → supplierinvoice.updated
+	t.Run("reject_supplier_invoice", func(t *testing.T) {
+		inv := seedSupplierInvoice(t, ctx, db, base, "REJECT")
+		req := seedResolvedApproval(t, ctx, db, uid, approvalrequestbus.StatusRejected)
+		h := procurement.NewRejectSupplierInvoiceHandler(db.Log, db.BusDomain.SupplierInvoice, db.BusDomain.ApprovalRequest)
+		cfg := mustJSON(t, map[string]any{"supplier_invoice_id": inv.ID.String(), "approval_request_id": req.ID.String(), "rejection_reason": "overbilled"})
+		run(t, "reject_supplier_invoice", h, cfg, workflow.ActionExecutionContext{UserID: uid})
+	})
 }
 
 // Test_ExecuteTransferOrder_MovesStock proves the execute_transfer_order BUTTON path performs
 // the same atomic inventory move as the REST transferorderapp.Execute: a TRANSFER_OUT/IN ledger
 // pair plus a source decrement and destination increment, not just the status flip. Before the
 // fix the handler called the status-only transferorderbus.Execute and left inventory untouched.
+// The transfer is seeded in_transit without a transit location, so stock moves straight from
+// the source as it did before transfers shipped through transit.
 func Test_ExecuteTransferOrder_MovesStock(t *testing.T) {
 	t.Parallel()
 
@@ -806,6 +1009,118 @@ func Test_ExecuteTransferOrder_MovesStock(t *testing.T) {
 	}
 }
 
+// Test_TransferOrder_ShipsThroughTransit walks a two-line transfer through the button path:
+// claim moves every line from the source into the source warehouse's in-transit location,
+// a partial receipt moves part of one line on to the destination, and a closing receipt
+// writes the short-shipped remainder off as a discrepancy.
+func Test_TransferOrder_ShipsThroughTransit(t *testing.T) {
+	t.Parallel()
+
+	db := dbtest.NewDatabase(t, "Test_TransferOrder_ShipsThroughTransit")
+	ctx := context.Background()
+
+	base := seedConsistencyBase(t, ctx, db)
+	uid := base.userID
+	p0, p1 := base.productIDs[0], base.productIDs[1]
+
+	// TestSeedInventoryItems seeds at least 100 of each product at the source.
+	if _, err := inventoryitembus.TestSeedInventoryItems(ctx, 2, []uuid.UUID{base.loc0}, []uuid.UUID{p0, p1}, db.BusDomain.InventoryItem); err != nil {
+		t.Fatalf("seeding source inventory: %v", err)
+	}
+
+	to, err := db.BusDomain.TransferOrder.Create(ctx, transferorderbus.NewTransferOrder{
+		FromLocationID: base.loc0, ToLocationID: base.loc1, RequestedByID: uid,
+		Status: transferorderbus.StatusApproved, TransferDate: time.Now(),
+		Lines: []transferorderbus.NewTransferOrderLine{
+			{ProductID: p0, Quantity: 4},
+			{ProductID: p1, Quantity: 3},
+		},
+	})
+	if err != nil {
+		t.Fatalf("seeding approved transfer: %v", err)
+	}
+
+	srcBefore := qtyAt(t, ctx, db, p0, base.loc0)
+
+	claim := inventory.NewClaimTransferOrderHandler(db.Log, db.DB, db.BusDomain.TransferOrder, db.BusDomain.InventoryTransaction, db.BusDomain.InventoryItem)
+	out, err := claim.Execute(ctx, mustJSON(t, map[string]any{"transfer_order_id": to.TransferID.String()}), workflow.ActionExecutionContext{UserID: uid})
+	if err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	m, _ := out.(map[string]any)
+	if m["output"] != "claimed" {
+		t.Fatalf("expected output 'claimed', got %v", out)
+	}
+	transitID, err := uuid.Parse(fmt.Sprint(m["transit_location_id"]))
+	if err != nil {
+		t.Fatalf("parsing transit_location_id %v: %v", m["transit_location_id"], err)
+	}
+
+	if got := srcBefore - qtyAt(t, ctx, db, p0, base.loc0); got != 4 {
+		t.Errorf("source not decremented by 4 on claim, got %d", got)
+	}
+	if got := qtyAt(t, ctx, db, p0, transitID); got != 4 {
+		t.Errorf("expected 4 in transit for line 1, got %d", got)
+	}
+	if got := qtyAt(t, ctx, db, p1, transitID); got != 3 {
+		t.Errorf("expected 3 in transit for line 2, got %d", got)
+	}
+
+	lines, err := db.BusDomain.TransferOrder.QueryLines(ctx, to.TransferID)
+	if err != nil || len(lines) != 2 {
+		t.Fatalf("querying lines: %v (got %d)", err, len(lines))
+	}
+
+	exec := inventory.NewExecuteTransferOrderHandler(db.Log, db.DB, db.BusDomain.TransferOrder, db.BusDomain.InventoryTransaction, db.BusDomain.InventoryItem)
+
+	// Partial receipt: 3 of line 1 arrive.
+	out, err = exec.Execute(ctx, mustJSON(t, map[string]any{
+		"transfer_order_id": to.TransferID.String(),
+		"lines":             []map[string]any{{"line_id": lines[0].ID.String(), "quantity": 3}},
+	}), workflow.ActionExecutionContext{UserID: uid})
+	if err != nil {
+		t.Fatalf("partial receipt: %v", err)
+	}
+	if m, _ := out.(map[string]any); m["output"] != "partially_received" {
+		t.Fatalf("expected output 'partially_received', got %v", out)
+	}
+	if got := qtyAt(t, ctx, db, p0, base.loc1); got != 3 {
+		t.Errorf("expected 3 received at destination, got %d", got)
+	}
+
+	// Closing receipt: all of line 2 arrives, the missing unit of line 1 is written off.
+	out, err = exec.Execute(ctx, mustJSON(t, map[string]any{
+		"transfer_order_id": to.TransferID.String(),
+		"lines":             []map[string]any{{"line_id": lines[1].ID.String(), "quantity": 3}},
+		"close":             true,
+		"reason":            "damaged in transit",
+	}), workflow.ActionExecutionContext{UserID: uid})
+	if err != nil {
+		t.Fatalf("closing receipt: %v", err)
+	}
+	if m, _ := out.(map[string]any); m["output"] != "executed" || m["discrepancy_quantity"] != 1 {
+		t.Fatalf("expected output 'executed' with discrepancy 1, got %v", out)
+	}
+
+	if got := qtyAt(t, ctx, db, p0, transitID); got != 0 {
+		t.Errorf("expected line 1 transit stock cleared, got %d", got)
+	}
+	if got := qtyAt(t, ctx, db, p1, base.loc1); got != 3 {
+		t.Errorf("expected 3 received at destination for line 2, got %d", got)
+	}
+
+	lines, err = db.BusDomain.TransferOrder.QueryLines(ctx, to.TransferID)
+	if err != nil {
+		t.Fatalf("querying lines: %v", err)
+	}
+	if lines[0].QuantityReceived != 3 || lines[0].DiscrepancyQuantity != 1 || lines[0].DiscrepancyReason != "damaged in transit" {
+		t.Errorf("unexpected line 1 after close: %+v", lines[0])
+	}
+	if n := txnCount(t, ctx, db, "ADJUSTMENT", to.TransferID.String()); n != 1 {
+		t.Errorf("expected 1 ADJUSTMENT ledger row for the discrepancy, got %d", n)
+	}
+}
+
 func qtyAt(t *testing.T, ctx context.Context, db *dbtest.Database, productID, locationID uuid.UUID) int {
 	t.Helper()
 	items, err := db.BusDomain.InventoryItem.Query(ctx,
@@ -832,6 +1147,69 @@ func txnCount(t *testing.T, ctx context.Context, db *dbtest.Database, txType, re
 	return len(txns)
 }
 
+// seedSupplierInvoice enters an open invoice billing one line of a fresh PO of the seeded
+// supplier. Nothing is received on the PO, so matching it is a quantity variance.
+func seedSupplierInvoice(t *testing.T, ctx context.Context, db *dbtest.Database, base baseFixtures, tag string) supplierinvoicebus.SupplierInvoice {
+	t.Helper()
+
+	po, err := db.BusDomain.PurchaseOrder.Create(ctx, newPendingPO(base, "INVOICE-"+tag))
+	if err != nil {
+		t.Fatalf("seeding PO: %v", err)
+	}
+	line, err := db.BusDomain.PurchaseOrderLineItem.Create(ctx, purchaseorderlineitembus.NewPurchaseOrderLineItem{
+		PurchaseOrderID: po.ID, SupplierProductID: base.supplierProductID, QuantityOrdered: 5, UnitCost: 10,
+		LineTotal: 50, LineItemStatusID: base.lineItemStatusID, ExpectedDeliveryDate: po.ExpectedDeliveryDate,
+		CreatedBy: base.userID,
+	})
+	if err != nil {
+		t.Fatalf("seeding PO line item: %v", err)
+	}
+
+	now := time.Now().UTC()
+	inv, err := db.BusDomain.SupplierInvoice.Create(ctx, supplierinvoicebus.NewSupplierInvoice{
+		InvoiceNumber: "INV-CONSISTENCY-" + tag + "-" + uuid.NewString()[:8], SupplierID: base.supplierID,
+		PurchaseOrderID: po.ID, InvoiceDate: now, DueDate: now.Add(30 * 24 * time.Hour),
+		Lines:     []supplierinvoicebus.NewLine{{PurchaseOrderLineItemID: line.ID, Quantity: 2, UnitPrice: 10}},
+		CreatedBy: base.userID,
+	}, now)
+	if err != nil {
+		t.Fatalf("seeding supplier invoice: %v", err)
+	}
+	return inv
+}
+
+// seedResolvedApproval seeds an approval request already resolved to status by
+// the given user, the gate approve/reject_supplier_invoice check before acting.
+func seedResolvedApproval(t *testing.T, ctx context.Context, db *dbtest.Database, userID uuid.UUID, status string) approvalrequestbus.ApprovalRequest {
+	t.Helper()
+
+	wf, err := workflow.TestSeedFullWorkflow(ctx, userID, db.BusDomain.Workflow)
+	if err != nil {
+		t.Fatalf("seeding full workflow: %v", err)
+	}
+	if len(wf.AutomationExecutions) == 0 || len(wf.AutomationRules) == 0 {
+		t.Fatalf("TestSeedFullWorkflow produced no executions/rules")
+	}
+	req, err := db.BusDomain.ApprovalRequest.Create(ctx, approvalrequestbus.NewApprovalRequest{
+		ExecutionID:     wf.AutomationExecutions[0].ID,
+		RuleID:          wf.AutomationRules[0].ID,
+		ActionName:      "invoice_variance_" + status,
+		Approvers:       []uuid.UUID{userID},
+		ApprovalType:    approvalrequestbus.ApprovalTypeAny,
+		TimeoutHours:    48,
+		TaskToken:       "tok-invoice-" + uuid.NewString()[:8],
+		ApprovalMessage: "invoice variance",
+	})
+	if err != nil {
+		t.Fatalf("seeding approval request: %v", err)
+	}
+	req, err = db.BusDomain.ApprovalRequest.Resolve(ctx, req.ID, userID, status, "consistency test")
+	if err != nil {
+		t.Fatalf("resolving approval request: %v", err)
+	}
+	return req
+}
+
 // newPendingPO builds an unapproved/unrejected PO (the approve/reject precondition).
 func newPendingPO(base baseFixtures, tag string) purchaseorderbus.NewPurchaseOrder {
 	now := time.Now().UTC()
@@ -16,6 +16,7 @@ var expectedActionTypes = []string{
 	"approve_purchase_order",
 	"approve_transfer_order",
 	"call_webhook",
+	"call_workflow",
 	"check_inventory",
 	"check_reorder_point",
 	"commit_allocation",
@@ -25,6 +26,7 @@ var expectedActionTypes = []string{
 	"create_put_away_task",
 	"delay",
 	"evaluate_condition",
+	"for_each",
 	"log_audit_entry",
 	"lookup_entity",
 	"receive_inventory",
@@ -24,8 +24,10 @@ func Test_ActionTypeSchemas_MatchExpectedStructure(t *testing.T) {
 		"allocate_inventory",
 		"approve_inventory_adjustment",
 		"approve_purchase_order",
+		"approve_supplier_invoice",
 		"approve_transfer_order",
 		"call_webhook",
+		"call_workflow",
 		"check_inventory",
 		"check_reorder_point",
 		"commit_allocation",
@@ -33,13 +35,20 @@ func Test_ActionTypeSchemas_MatchExpectedStructure(t *testing.T) {
 		"create_entity",
 		"create_purchase_order",
 		"create_put_away_task",
+		"create_shipping_label",
 		"delay",
 		"evaluate_condition",
+		"for_each",
+		"forecast_demand",
+		"generate_cycle_counts",
+		"generate_replenishment",
 		"log_audit_entry",
 		"lookup_entity",
+		"match_supplier_invoice",
 		"receive_inventory",
 		"reject_inventory_adjustment",
 		"reject_purchase_order",
+		"reject_supplier_invoice",
 		"reject_transfer_order",
 		"release_reservation",
 		"reserve_inventory",
@@ -47,6 +56,7 @@ func Test_ActionTypeSchemas_MatchExpectedStructure(t *testing.T) {
 		"seek_approval",
 		"send_email",
 		"send_notification",
+		"suggest_purchases",
 		"transition_status",
 		"update_field",
 	}
@@ -149,14 +159,15 @@ func Test_ActionTypeSchemas_CategoryConsistency(t *testing.T) {
 		"inventory": {
 			"allocate_inventory", "approve_inventory_adjustment", "approve_transfer_order",
 			"check_inventory", "check_reorder_point", "commit_allocation", "create_put_away_task",
-			"receive_inventory", "reject_inventory_adjustment", "reject_transfer_order",
-			"release_reservation", "reserve_inventory",
+			"forecast_demand", "generate_cycle_counts", "generate_replenishment", "receive_inventory", "reject_inventory_adjustment",
+			"reject_transfer_order", "release_reservation", "reserve_inventory",
 		},
 		"approval":     {"resolve_approval_request", "seek_approval"},
 		"data":         {"create_entity", "log_audit_entry", "lookup_entity", "transition_status", "update_field"},
-		"control":      {"delay", "evaluate_condition"},
+		"control":      {"call_workflow", "delay", "evaluate_condition", "for_each"},
 		"integration":  {"call_webhook"},
-		"procurement":  {"approve_purchase_order", "create_purchase_order", "reject_purchase_order"},
+		"procurement":  {"approve_purchase_order", "approve_supplier_invoice", "create_purchase_order", "match_supplier_invoice", "reject_purchase_order", "reject_supplier_invoice", "suggest_purchases"},
+		"shipping":     {"create_shipping_label"},
 	}
 
 	for category, expectedTypes := range expectedCategories {
@@ -197,8 +208,10 @@ func Test_ActionTypeSchemas_AsyncFlags(t *testing.T) {
 		"allocate_inventory":           true,
 		"approve_inventory_adjustment": false,
 		"approve_purchase_order":       false,
+		"approve_supplier_invoice":     false,
 		"approve_transfer_order":       false,
 		"call_webhook":                 false,
+		"call_workflow":                false,
 		"check_inventory":              false,
 		"check_reorder_point":          false,
 		"commit_allocation":            false,
@@ -206,13 +219,20 @@ func Test_ActionTypeSchemas_AsyncFlags(t *testing.T) {
 		"create_entity":                false,
 		"create_purchase_order":        false,
 		"create_put_away_task":         false,
+		"create_shipping_label":        false,
 		"delay":                        false,
 		"evaluate_condition":           false,
+		"for_each":                     false,
+		"forecast_demand":              false,
+		"generate_cycle_counts":        false,
+		"generate_replenishment":       false,
 		"log_audit_entry":              false,
 		"lookup_entity":                false,
+		"match_supplier_invoice":       false,
 		"receive_inventory":            false,
 		"reject_inventory_adjustment":  false,
 		"reject_purchase_order":        false,
+		"reject_supplier_invoice":      false,
 		"reject_transfer_order":        false,
 		"release_reservation":          false,
 		"reserve_inventory":            false,
@@ -220,6 +240,7 @@ func Test_ActionTypeSchemas_AsyncFlags(t *testing.T) {
 		"seek_approval":                true,
 		"send_email":                   true,
 		"send_notification":            false,
+		"suggest_purchases":            false,
 		"transition_status":            false,
 		"update_field":                 false,
 	}
@@ -50,6 +50,10 @@ type CascadeSeedData struct {
 	// as the PrimaryRule - should be excluded from cascade results
 	InactiveDownstreamRule workflow.AutomationRule
 
+	// CallerRule has a call_workflow action that runs the PrimaryRule
+	CallerRule        workflow.AutomationRule
+	CallerRuleActions []workflow.RuleAction
+
 	// TargetEntity is the entity used for testing (what PrimaryRule modifies)
 	TargetEntity workflow.Entity
 }
@@ -398,6 +402,56 @@ func insertCascadeSeedData(db *dbtest.Database, ath *auth.Auth) (CascadeSeedData
 		return CascadeSeedData{}, fmt.Errorf("creating inactive rule: %w", err)
 	}
 
+	// =========================================================================
+	// Create CallerRule - runs the PrimaryRule through call_workflow
+	// =========================================================================
+	callWorkflowTemplate, err := busDomain.Workflow.CreateActionTemplate(ctx, workflow.NewActionTemplate{
+		Name:          "Call Workflow Template",
+		Description:   "Template for call_workflow actions",
+		ActionType:    "call_workflow",
+		DefaultConfig: json.RawMessage(`{}`),
+		CreatedBy:     admins[0].ID,
+	})
+	if err != nil {
+		return CascadeSeedData{}, fmt.Errorf("creating call_workflow template: %w", err)
+	}
+
+	callerRule, err := busDomain.Workflow.CreateRule(ctx, workflow.NewAutomationRule{
+		Name:          "Caller Rule",
+		Description:   "Rule that calls the primary rule as a sub-workflow",
+		EntityID:      targetEntity.ID,
+		EntityTypeID:  entityTypes[0].ID,
+		TriggerTypeID: onUpdateTriggerType.ID,
+		IsActive:      false, // inactive so it never shows up as a downstream rule
+		CreatedBy:     admins[0].ID,
+	})
+	if err != nil {
+		return CascadeSeedData{}, fmt.Errorf("creating caller rule: %w", err)
+	}
+
+	callerAction, err := busDomain.Workflow.CreateRuleAction(ctx, workflow.NewRuleAction{
+		AutomationRuleID: callerRule.ID,
+		Name:             "Call Primary Rule",
+		Description:      "Runs the primary rule",
+		ActionConfig:     json.RawMessage(`{"rule_id": "` + primaryRule.ID.String() + `"}`),
+		IsActive:         true,
+		TemplateID:       &callWorkflowTemplate.ID,
+	})
+	if err != nil {
+		return CascadeSeedData{}, fmt.Errorf("creating caller action: %w", err)
+	}
+
+	_, err = busDomain.Workflow.CreateActionEdge(ctx, workflow.NewActionEdge{
+		RuleID:         callerRule.ID,
+		SourceActionID: nil,
+		TargetActionID: callerAction.ID,
+		EdgeType:       workflow.EdgeTypeStart,
+		EdgeOrder:      0,
+	})
+	if err != nil {
+		return CascadeSeedData{}, fmt.Errorf("creating edge for caller action: %w", err)
+	}
+
 	// =========================================================================
 	// Table Permissions - Grant admin user full access
 	// =========================================================================
@@ -444,6 +498,8 @@ func insertCascadeSeedData(db *dbtest.Database, ath *auth.Auth) (CascadeSeedData
 		SelfTriggerRule:         selfTriggerRule,
 		SelfTriggerRuleActions:  []workflow.RuleAction{selfTriggerAction},
 		InactiveDownstreamRule:  inactiveRule,
+		CallerRule:              callerRule,
+		CallerRuleActions:       []workflow.RuleAction{callerAction},
 		TargetEntity:            targetEntity,
 	}, nil
 }
@@ -37,6 +37,7 @@ func Test_CascadeAPI(t *testing.T) {
 	test.Run(t, cascadeMapOnlyActiveRules(sd), "cascadeMap-only-active-200")
 	test.Run(t, cascadeMapResponseStructure(sd), "cascadeMap-response-structure-200")
 	test.Run(t, cascadeMapEmptyActions(sd), "cascadeMap-empty-actions-200")
+	test.Run(t, cascadeMapCallsWorkflow(sd), "cascadeMap-calls-workflow-200")
 
 	// ============================================================
 	// Cascade Map Tests - Error Cases
@@ -422,3 +423,45 @@ func cascadeMapEmptyActions(sd CascadeSeedData) []apitest.Table {
 
 	return table
 }
+
+// cascadeMapCallsWorkflow tests that a call_workflow action reports the rule it
+// runs as a sub-workflow.
+func cascadeMapCallsWorkflow(sd CascadeSeedData) []apitest.Table {
+	ruleID := sd.CallerRule.ID
+
+	table := []apitest.Table{
+		{
+			Name:       "calls-workflow",
+			URL:        "/v1/workflow/rules/" + ruleID.String() + "/cascade-map",
+			Token:      sd.Users[0].Token,
+			StatusCode: http.StatusOK,
+			Method:     http.MethodGet,
+			GotResp:    &ruleapi.CascadeResponse{},
+			ExpResp:    &ruleapi.CascadeResponse{},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(*ruleapi.CascadeResponse)
+				if !exists {
+					return "error occurred: failed to cast response"
+				}
+
+				if len(gotResp.Actions) != 1 {
+					return fmt.Sprintf("expected 1 action, got %d", len(gotResp.Actions))
+				}
+
+				call := gotResp.Actions[0].CallsWorkflow
+				if call == nil {
+					return "expected calls_workflow on the call_workflow action"
+				}
+
+				if call.RuleID != sd.PrimaryRule.ID.String() || call.RuleName != sd.PrimaryRule.Name {
+					return fmt.Sprintf("expected call to %s (%s), got %s (%s)",
+						sd.PrimaryRule.Name, sd.PrimaryRule.ID, call.RuleName, call.RuleID)
+				}
+
+				return ""
+			},
+		},
+	}
+
+	return table
+}
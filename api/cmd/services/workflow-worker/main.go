@@ -16,14 +16,43 @@ import (
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/worker"
 
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus/stores/countplandb"
+	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountitembus/stores/cyclecountitemdb"
+	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountsessionbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountsessionbus/stores/cyclecountsessiondb"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus/stores/demandforecastdb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus/stores/inventoryitemdb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus/stores/inventorylocationdb"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryreservationbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryreservationbus/stores/inventoryreservationdb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus/stores/inventorytransactiondb"
+	"github.com/timmaaaz/ichor/business/domain/inventory/lotlocationbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/lotlocationbus/stores/lotlocationdb"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus/stores/replenishmenttaskdb"
+	"github.com/timmaaaz/ichor/business/domain/labels/labelbus"
+	"github.com/timmaaaz/ichor/business/domain/labels/labelbus/stores/labeldb"
+	"github.com/timmaaaz/ichor/business/domain/labels/labelbus/tcpprint"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus/stores/purchaseorderdb"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitembus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitembus/stores/purchaseorderlineitemdb"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus/stores/purchasesuggestiondb"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus/stores/supplierinvoicedb"
 	"github.com/timmaaaz/ichor/business/domain/products/productbus"
 	"github.com/timmaaaz/ichor/business/domain/products/productbus/stores/productdb"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus/flatrate"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus/httpcarrier"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus/stores/shipmentdb"
 	"github.com/timmaaaz/ichor/business/domain/workflow/alertbus"
 	"github.com/timmaaaz/ichor/business/domain/workflow/alertbus/stores/alertdb"
 	"github.com/timmaaaz/ichor/business/domain/workflow/approvalrequestbus"
@@ -34,6 +63,7 @@ import (
 	"github.com/timmaaaz/ichor/business/sdk/workflow"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/stores/workflowdb"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/temporal"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/temporal/stores/edgedb"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/approval"
 	"github.com/timmaaaz/ichor/business/sdk/workflowdomains"
@@ -78,6 +108,18 @@ func run(log *logger.Logger) error {
 			RetryDelay    time.Duration `conf:"default:5s"`
 			PrefetchCount int           `conf:"default:10"`
 		}
+		// Printer and Carrier mirror the ichor service config so
+		// create_shipping_label behaves the same from a rule as from the API.
+		Printer struct {
+			HostPort string `conf:"default:172.16.60.116:9100"`
+		}
+		Carrier struct {
+			Provider string `conf:"default:flatrate"`
+			Name     string `conf:"default:http"`
+			BaseURL  string
+			APIKey   string        `conf:"mask"`
+			Timeout  time.Duration `conf:"default:10s"`
+		}
 	}{
 		Version: conf.Version{
 			Build: build,
@@ -183,6 +225,29 @@ func run(log *logger.Logger) error {
 	inventoryItemBus := inventoryitembus.NewBusiness(log, del, inventoryitemdb.NewStore(log, db)).WithOutbox(outboxWriter)
 	inventoryLocationBus := inventorylocationbus.NewBusiness(log, del, inventorylocationdb.NewStore(log, db)).WithOutbox(outboxWriter)
 	inventoryTransactionBus := inventorytransactionbus.NewBusiness(log, del, inventorytransactiondb.NewStore(log, db)).WithOutbox(outboxWriter)
+	inventoryReservationBus := inventoryreservationbus.NewBusiness(log, del, inventoryreservationdb.NewStore(log, db)).WithOutbox(outboxWriter)
+
+	// Count plan bus - required for generate_cycle_counts, which scheduled rules
+	// call to run the cycle count program.
+	cycleCountSessionBus := cyclecountsessionbus.NewBusiness(log, del, cyclecountsessiondb.NewStore(log, db)).WithOutbox(outboxWriter)
+	cycleCountItemBus := cyclecountitembus.NewBusiness(log, del, cyclecountitemdb.NewStore(log, db)).WithOutbox(outboxWriter)
+	countPlanBus := countplanbus.NewBusiness(log, del, countplandb.NewStore(log, db), cycleCountSessionBus, cycleCountItemBus).WithOutbox(outboxWriter)
+
+	// Replenishment task bus - required for generate_replenishment, which scheduled
+	// rules call to top up pick faces from reserve.
+	lotLocationBus := lotlocationbus.NewBusiness(log, del, lotlocationdb.NewStore(log, db)).WithOutbox(outboxWriter)
+	replenishmentTaskBus := replenishmenttaskbus.NewBusiness(log, del, replenishmenttaskdb.NewStore(log, db), inventoryItemBus, inventoryTransactionBus, lotLocationBus).WithOutbox(outboxWriter)
+
+	// Demand forecast bus - required for forecast_demand, which scheduled rules
+	// call to refresh the planning values check_reorder_point reads.
+	demandForecastBus := demandforecastbus.NewBusiness(log, del, demandforecastdb.NewStore(log, db), inventoryItemBus).WithOutbox(outboxWriter)
+
+	// Purchase suggestion bus - required for suggest_purchases, which scheduled
+	// rules call to turn reorder needs into draft purchase orders.
+	purchaseOrderBus := purchaseorderbus.NewBusiness(log, del, purchaseorderdb.NewStore(log, db)).WithOutbox(outboxWriter)
+	purchaseOrderLineItemBus := purchaseorderlineitembus.NewBusiness(log, del, purchaseorderlineitemdb.NewStore(log, db)).WithOutbox(outboxWriter)
+	purchaseSuggestionBus := purchasesuggestionbus.NewBusiness(log, del, purchasesuggestiondb.NewStore(log, db), purchaseOrderBus, purchaseOrderLineItemBus).WithOutbox(outboxWriter)
+	supplierInvoiceBus := supplierinvoicebus.NewBusiness(log, del, supplierinvoicedb.NewStore(log, db), purchaseOrderBus).WithOutbox(outboxWriter)
 
 	// Product bus - required for allocation validation.
 	productBus := productbus.NewBusiness(log, del, productdb.NewStore(log, db)).WithOutbox(outboxWriter)
@@ -199,6 +264,22 @@ func run(log *logger.Logger) error {
 	// Approval request bus - required for seek_approval action.
 	approvalRequestBus := approvalrequestbus.NewBusiness(log, del, approvalrequestdb.NewStore(log, db))
 
+	// Shipment bus - required for create_shipping_label, with the configured
+	// carrier and the label printer for printing the bought label.
+	var shippingCarrier shipmentbus.Carrier = flatrate.New(nil)
+	if cfg.Carrier.Provider == "http" {
+		if c := httpcarrier.New(httpcarrier.Config{
+			Name:    cfg.Carrier.Name,
+			BaseURL: cfg.Carrier.BaseURL,
+			APIKey:  cfg.Carrier.APIKey,
+			Timeout: cfg.Carrier.Timeout,
+		}); c != nil {
+			shippingCarrier = c
+		}
+	}
+	shipmentBus := shipmentbus.NewBusiness(log, del, shipmentdb.NewStore(log, db)).WithOutbox(outboxWriter).WithCarrier(shippingCarrier)
+	labelBus := labelbus.NewBusiness(log, del, labeldb.NewStore(log, db), tcpprint.New(cfg.Printer.HostPort, 5*time.Second)).WithOutbox(outboxWriter)
+
 	// =========================================================================
 	// Action Registry
 	// =========================================================================
@@ -223,10 +304,18 @@ func run(log *logger.Logger) error {
 			InventoryItem:        inventoryItemBus,
 			InventoryLocation:    inventoryLocationBus,
 			InventoryTransaction: inventoryTransactionBus,
+			InventoryReservation: inventoryReservationBus,
 			Product:              productBus,
 			Workflow:             workflowBus,
 			Alert:                alertBus,
 			ApprovalRequest:      approvalRequestBus,
+			Shipment:             shipmentBus,
+			Label:                labelBus,
+			CountPlan:            countPlanBus,
+			ReplenishmentTask:    replenishmentTaskBus,
+			DemandForecast:       demandForecastBus,
+			PurchaseSuggestion:   purchaseSuggestionBus,
+			SupplierInvoice:      supplierInvoiceBus,
 		},
 	})
 
@@ -261,9 +350,11 @@ func run(log *logger.Logger) error {
 	})
 
 	// Register workflows (package-level functions).
-	// Temporal resolves by name: "ExecuteGraphWorkflow", "ExecuteBranchUntilConvergence".
+	// Temporal resolves by name: "ExecuteGraphWorkflow", "ExecuteBranchUntilConvergence",
+	// "ExecuteCalledWorkflow".
 	w.RegisterWorkflow(temporal.ExecuteGraphWorkflow)
 	w.RegisterWorkflow(temporal.ExecuteBranchUntilConvergence)
+	w.RegisterWorkflow(temporal.ExecuteCalledWorkflow)
 
 	// Register activities via Activities struct.
 	// Temporal resolves struct method names by string: "ExecuteActionActivity" / "ExecuteAsyncActionActivity".
@@ -271,6 +362,7 @@ func run(log *logger.Logger) error {
 		Registry:       actionRegistry,
 		AsyncRegistry:  asyncRegistry,
 		ExecutionStore: workflowStore,
+		GraphStore:     edgedb.NewStore(log, db),
 	})
 
 	log.Info(context.Background(), "starting workflow worker",
@@ -0,0 +1,92 @@
+package datascopeapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/domain/core/datascopeapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/foundation/web"
+)
+
+type api struct {
+	datascopeapp *datascopeapp.App
+}
+
+func newAPI(datascopeapp *datascopeapp.App) *api {
+	return &api{
+		datascopeapp: datascopeapp,
+	}
+}
+
+func (api *api) create(ctx context.Context, r *http.Request) web.Encoder {
+	var app datascopeapp.NewDataScope
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	ds, err := api.datascopeapp.Create(ctx, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return ds
+}
+
+func (api *api) update(ctx context.Context, r *http.Request) web.Encoder {
+	var app datascopeapp.UpdateDataScope
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	parsed, err := uuid.Parse(web.Param(r, "data_scope_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	ds, err := api.datascopeapp.Update(ctx, app, parsed)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return ds
+}
+
+func (api *api) delete(ctx context.Context, r *http.Request) web.Encoder {
+	parsed, err := uuid.Parse(web.Param(r, "data_scope_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	if err := api.datascopeapp.Delete(ctx, parsed); err != nil {
+		return errs.NewError(err)
+	}
+
+	return nil
+}
+
+func (api *api) query(ctx context.Context, r *http.Request) web.Encoder {
+	qp := parseQueryParams(r)
+
+	scopes, err := api.datascopeapp.Query(ctx, qp)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return scopes
+}
+
+func (api *api) queryByID(ctx context.Context, r *http.Request) web.Encoder {
+	parsed, err := uuid.Parse(web.Param(r, "data_scope_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	ds, err := api.datascopeapp.QueryByID(ctx, parsed)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return ds
+}
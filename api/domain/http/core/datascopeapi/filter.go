@@ -0,0 +1,20 @@
+package datascopeapi
+
+import (
+	"net/http"
+
+	"github.com/timmaaaz/ichor/app/domain/core/datascopeapp"
+)
+
+func parseQueryParams(r *http.Request) datascopeapp.QueryParams {
+	values := r.URL.Query()
+
+	return datascopeapp.QueryParams{
+		Page:    values.Get("page"),
+		Rows:    values.Get("rows"),
+		OrderBy: values.Get("orderBy"),
+		ID:      values.Get("id"),
+		RoleID:  values.Get("role_id"),
+		UserID:  values.Get("user_id"),
+	}
+}
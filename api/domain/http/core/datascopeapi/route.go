@@ -0,0 +1,43 @@
+package datascopeapi
+
+import (
+	"net/http"
+
+	"github.com/timmaaaz/ichor/api/sdk/http/mid"
+	"github.com/timmaaaz/ichor/app/domain/core/datascopeapp"
+	"github.com/timmaaaz/ichor/app/sdk/auth"
+	"github.com/timmaaaz/ichor/app/sdk/authclient"
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus"
+	"github.com/timmaaaz/ichor/business/domain/core/permissionsbus"
+	"github.com/timmaaaz/ichor/foundation/logger"
+	"github.com/timmaaaz/ichor/foundation/web"
+)
+
+type Config struct {
+	Log            *logger.Logger
+	DataScopeBus   *datascopebus.Business
+	AuthClient     *authclient.Client
+	PermissionsBus *permissionsbus.Business
+}
+
+const (
+	RouteTable = "core.data_scopes"
+)
+
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	api := newAPI(datascopeapp.NewApp(cfg.DataScopeBus))
+	authen := mid.Authenticate(cfg.AuthClient)
+
+	app.HandlerFunc(http.MethodGet, version, "/core/data-scopes", api.query, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAny))
+	app.HandlerFunc(http.MethodGet, version, "/core/data-scopes/{data_scope_id}", api.queryByID, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAny))
+	app.HandlerFunc(http.MethodPost, version, "/core/data-scopes", api.create, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Create, auth.RuleAdminOnly))
+	app.HandlerFunc(http.MethodPut, version, "/core/data-scopes/{data_scope_id}", api.update, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Update, auth.RuleAdminOnly))
+	app.HandlerFunc(http.MethodDelete, version, "/core/data-scopes/{data_scope_id}", api.delete, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Delete, auth.RuleAdminOnly))
+}
@@ -0,0 +1,142 @@
+package countplanapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/domain/inventory/countplanapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/foundation/web"
+)
+
+type api struct {
+	countplanapp *countplanapp.App
+}
+
+func newAPI(countplanapp *countplanapp.App) *api {
+	return &api{
+		countplanapp: countplanapp,
+	}
+}
+
+func (api *api) create(ctx context.Context, r *http.Request) web.Encoder {
+	var app countplanapp.NewCountPlan
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	plan, err := api.countplanapp.Create(ctx, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return plan
+}
+
+func (api *api) update(ctx context.Context, r *http.Request) web.Encoder {
+	var app countplanapp.UpdateCountPlan
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	planID, err := uuid.Parse(web.Param(r, "plan_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	plan, err := api.countplanapp.Update(ctx, planID, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return plan
+}
+
+func (api *api) delete(ctx context.Context, r *http.Request) web.Encoder {
+	planID, err := uuid.Parse(web.Param(r, "plan_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	if err := api.countplanapp.Delete(ctx, planID); err != nil {
+		return errs.NewError(err)
+	}
+
+	return nil
+}
+
+func (api *api) query(ctx context.Context, r *http.Request) web.Encoder {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	plans, err := api.countplanapp.Query(ctx, qp)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return plans
+}
+
+func (api *api) queryByID(ctx context.Context, r *http.Request) web.Encoder {
+	planID, err := uuid.Parse(web.Param(r, "plan_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	plan, err := api.countplanapp.QueryByID(ctx, planID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return plan
+}
+
+func (api *api) preview(ctx context.Context, r *http.Request) web.Encoder {
+	planID, err := uuid.Parse(web.Param(r, "plan_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	preview, err := api.countplanapp.Preview(ctx, planID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return preview
+}
+
+func (api *api) generate(ctx context.Context, r *http.Request) web.Encoder {
+	planID, err := uuid.Parse(web.Param(r, "plan_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	run, err := api.countplanapp.Generate(ctx, planID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return run
+}
+
+func (api *api) queryRuns(ctx context.Context, r *http.Request) web.Encoder {
+	planID, err := uuid.Parse(web.Param(r, "plan_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	runs, err := api.countplanapp.QueryRuns(ctx, planID, qp)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return runs
+}
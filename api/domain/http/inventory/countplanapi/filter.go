@@ -0,0 +1,24 @@
+package countplanapi
+
+import (
+	"net/http"
+
+	"github.com/timmaaaz/ichor/app/domain/inventory/countplanapp"
+)
+
+func parseQueryParams(r *http.Request) (countplanapp.QueryParams, error) {
+	values := r.URL.Query()
+
+	qp := countplanapp.QueryParams{
+		Page:        values.Get("page"),
+		Rows:        values.Get("rows"),
+		OrderBy:     values.Get("orderBy"),
+		ID:          values.Get("id"),
+		Name:        values.Get("name"),
+		Rule:        values.Get("rule"),
+		WarehouseID: values.Get("warehouse_id"),
+		IsActive:    values.Get("is_active"),
+	}
+
+	return qp, nil
+}
@@ -0,0 +1,56 @@
+package countplanapi
+
+import (
+	"net/http"
+
+	"github.com/timmaaaz/ichor/api/sdk/http/mid"
+	"github.com/timmaaaz/ichor/app/domain/inventory/countplanapp"
+	"github.com/timmaaaz/ichor/app/sdk/auth"
+	"github.com/timmaaaz/ichor/app/sdk/authclient"
+	"github.com/timmaaaz/ichor/business/domain/core/permissionsbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+	"github.com/timmaaaz/ichor/foundation/logger"
+	"github.com/timmaaaz/ichor/foundation/web"
+)
+
+type Config struct {
+	Log            *logger.Logger
+	CountPlanBus   *countplanbus.Business
+	AuthClient     *authclient.Client
+	PermissionsBus *permissionsbus.Business
+}
+
+const (
+	RouteTable = "inventory.count_plans"
+)
+
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.AuthClient)
+	api := newAPI(countplanapp.NewApp(cfg.CountPlanBus))
+
+	app.HandlerFunc(http.MethodGet, version, "/inventory/count-plans", api.query, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodGet, version, "/inventory/count-plans/{plan_id}", api.queryByID, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodPost, version, "/inventory/count-plans", api.create, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Create, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodPut, version, "/inventory/count-plans/{plan_id}", api.update, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Update, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodDelete, version, "/inventory/count-plans/{plan_id}", api.delete, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Delete, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodGet, version, "/inventory/count-plans/{plan_id}/preview", api.preview, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodPost, version, "/inventory/count-plans/{plan_id}/generate", api.generate, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Create, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodGet, version, "/inventory/count-plans/{plan_id}/runs", api.queryRuns, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAny))
+}
@@ -19,6 +19,8 @@ func parseQueryParams(r *http.Request) (cyclecountitemapp.QueryParams, error) {
 		LocationID: values.Get("location_id"),
 		Status:     values.Get("status"),
 		CountedBy:  values.Get("counted_by"),
+		AssignedTo: values.Get("assigned_to"),
+		RecountOf:  values.Get("recount_of"),
 	}
 
 	return qp, nil
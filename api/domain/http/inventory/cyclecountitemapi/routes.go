@@ -3,21 +3,25 @@ package cyclecountitemapi
 import (
 	"net/http"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/timmaaaz/ichor/api/sdk/http/mid"
 	"github.com/timmaaaz/ichor/app/domain/inventory/cyclecountitemapp"
 	"github.com/timmaaaz/ichor/app/sdk/auth"
 	"github.com/timmaaaz/ichor/app/sdk/authclient"
 	"github.com/timmaaaz/ichor/business/domain/core/permissionsbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountsessionbus"
 	"github.com/timmaaaz/ichor/foundation/logger"
 	"github.com/timmaaaz/ichor/foundation/web"
 )
 
 type Config struct {
-	Log               *logger.Logger
-	CycleCountItemBus *cyclecountitembus.Business
-	AuthClient        *authclient.Client
-	PermissionsBus    *permissionsbus.Business
+	Log                  *logger.Logger
+	CycleCountItemBus    *cyclecountitembus.Business
+	CycleCountSessionBus *cyclecountsessionbus.Business
+	DB                   *sqlx.DB
+	AuthClient           *authclient.Client
+	PermissionsBus       *permissionsbus.Business
 }
 
 const (
@@ -28,7 +32,7 @@ func Routes(app *web.App, cfg Config) {
 	const version = "v1"
 
 	authen := mid.Authenticate(cfg.AuthClient)
-	api := newAPI(cyclecountitemapp.NewApp(cfg.CycleCountItemBus))
+	api := newAPI(cyclecountitemapp.NewApp(cfg.CycleCountItemBus, cfg.CycleCountSessionBus, cfg.DB))
 
 	app.HandlerFunc(http.MethodGet, version, "/inventory/cycle-count-items", api.query, authen,
 		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAny))
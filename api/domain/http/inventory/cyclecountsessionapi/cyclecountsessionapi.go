@@ -96,3 +96,18 @@ func (api *api) queryByID(ctx context.Context, r *http.Request) web.Encoder {
 
 	return session
 }
+
+func (api *api) querySummary(ctx context.Context, r *http.Request) web.Encoder {
+	sessionID := web.Param(r, "session_id")
+	parsed, err := uuid.Parse(sessionID)
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	summary, err := api.cyclecountsessionapp.QuerySummary(ctx, parsed)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return summary
+}
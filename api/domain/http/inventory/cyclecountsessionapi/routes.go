@@ -12,6 +12,8 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountitembus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountsessionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryadjustmentbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
 	"github.com/timmaaaz/ichor/foundation/logger"
 	"github.com/timmaaaz/ichor/foundation/web"
 )
@@ -21,6 +23,8 @@ type Config struct {
 	CycleCountSessionBus *cyclecountsessionbus.Business
 	CycleCountItemBus    *cyclecountitembus.Business
 	InvAdjustmentBus     *inventoryadjustmentbus.Business
+	InvTransactionBus    *inventorytransactionbus.Business
+	InvItemBus           *inventoryitembus.Business
 	DB                   *sqlx.DB
 	AuthClient           *authclient.Client
 	PermissionsBus       *permissionsbus.Business
@@ -34,7 +38,7 @@ func Routes(app *web.App, cfg Config) {
 	const version = "v1"
 
 	authen := mid.Authenticate(cfg.AuthClient)
-	api := newAPI(cyclecountsessionapp.NewApp(cfg.CycleCountSessionBus, cfg.CycleCountItemBus, cfg.InvAdjustmentBus, cfg.DB))
+	api := newAPI(cyclecountsessionapp.NewApp(cfg.CycleCountSessionBus, cfg.CycleCountItemBus, cfg.InvAdjustmentBus, cfg.InvTransactionBus, cfg.InvItemBus, cfg.DB))
 
 	app.HandlerFunc(http.MethodGet, version, "/inventory/cycle-count-sessions", api.query, authen,
 		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAny))
@@ -42,6 +46,9 @@ func Routes(app *web.App, cfg Config) {
 	app.HandlerFunc(http.MethodGet, version, "/inventory/cycle-count-sessions/{session_id}", api.queryByID, authen,
 		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAny))
 
+	app.HandlerFunc(http.MethodGet, version, "/inventory/cycle-count-sessions/{session_id}/summary", api.querySummary, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAny))
+
 	app.HandlerFunc(http.MethodPost, version, "/inventory/cycle-count-sessions", api.create, authen,
 		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Create, auth.RuleAny))
 
@@ -0,0 +1,185 @@
+package demandforecastapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/domain/inventory/demandforecastapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/foundation/web"
+)
+
+type api struct {
+	demandforecastapp *demandforecastapp.App
+}
+
+func newAPI(demandforecastapp *demandforecastapp.App) *api {
+	return &api{
+		demandforecastapp: demandforecastapp,
+	}
+}
+
+// =============================================================================
+// Policies
+
+func (api *api) createPolicy(ctx context.Context, r *http.Request) web.Encoder {
+	var app demandforecastapp.NewPolicy
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	policy, err := api.demandforecastapp.CreatePolicy(ctx, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return policy
+}
+
+func (api *api) updatePolicy(ctx context.Context, r *http.Request) web.Encoder {
+	var app demandforecastapp.UpdatePolicy
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	policyID, err := uuid.Parse(web.Param(r, "policy_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	policy, err := api.demandforecastapp.UpdatePolicy(ctx, policyID, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return policy
+}
+
+func (api *api) deletePolicy(ctx context.Context, r *http.Request) web.Encoder {
+	policyID, err := uuid.Parse(web.Param(r, "policy_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	if err := api.demandforecastapp.DeletePolicy(ctx, policyID); err != nil {
+		return errs.NewError(err)
+	}
+
+	return nil
+}
+
+func (api *api) queryPolicies(ctx context.Context, r *http.Request) web.Encoder {
+	qp, err := parsePolicyQueryParams(r)
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	policies, err := api.demandforecastapp.QueryPolicies(ctx, qp)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return policies
+}
+
+func (api *api) queryPolicyByID(ctx context.Context, r *http.Request) web.Encoder {
+	policyID, err := uuid.Parse(web.Param(r, "policy_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	policy, err := api.demandforecastapp.QueryPolicyByID(ctx, policyID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return policy
+}
+
+// =============================================================================
+// Forecasts
+
+func (api *api) run(ctx context.Context, r *http.Request) web.Encoder {
+	var app demandforecastapp.NewRun
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	forecasts, err := api.demandforecastapp.Run(ctx, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return forecasts
+}
+
+func (api *api) approve(ctx context.Context, r *http.Request) web.Encoder {
+	forecastID, err := uuid.Parse(web.Param(r, "forecast_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	forecast, err := api.demandforecastapp.Approve(ctx, forecastID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return forecast
+}
+
+func (api *api) reject(ctx context.Context, r *http.Request) web.Encoder {
+	forecastID, err := uuid.Parse(web.Param(r, "forecast_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	forecast, err := api.demandforecastapp.Reject(ctx, forecastID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return forecast
+}
+
+func (api *api) query(ctx context.Context, r *http.Request) web.Encoder {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	forecasts, err := api.demandforecastapp.Query(ctx, qp)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return forecasts
+}
+
+func (api *api) queryByID(ctx context.Context, r *http.Request) web.Encoder {
+	forecastID, err := uuid.Parse(web.Param(r, "forecast_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	forecast, err := api.demandforecastapp.QueryByID(ctx, forecastID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return forecast
+}
+
+func (api *api) accuracy(ctx context.Context, r *http.Request) web.Encoder {
+	ap, err := parseAccuracyParams(r)
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	report, err := api.demandforecastapp.QueryAccuracy(ctx, ap)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return report
+}
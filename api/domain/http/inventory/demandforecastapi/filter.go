@@ -0,0 +1,54 @@
+package demandforecastapi
+
+import (
+	"net/http"
+
+	"github.com/timmaaaz/ichor/app/domain/inventory/demandforecastapp"
+)
+
+func parseQueryParams(r *http.Request) (demandforecastapp.QueryParams, error) {
+	values := r.URL.Query()
+
+	qp := demandforecastapp.QueryParams{
+		Page:            values.Get("page"),
+		Rows:            values.Get("rows"),
+		OrderBy:         values.Get("orderBy"),
+		ID:              values.Get("id"),
+		InventoryItemID: values.Get("inventory_item_id"),
+		ProductID:       values.Get("product_id"),
+		LocationID:      values.Get("location_id"),
+		PolicyID:        values.Get("policy_id"),
+		Method:          values.Get("method"),
+		Status:          values.Get("status"),
+	}
+
+	return qp, nil
+}
+
+func parsePolicyQueryParams(r *http.Request) (demandforecastapp.PolicyQueryParams, error) {
+	values := r.URL.Query()
+
+	qp := demandforecastapp.PolicyQueryParams{
+		Page:       values.Get("page"),
+		Rows:       values.Get("rows"),
+		OrderBy:    values.Get("orderBy"),
+		ID:         values.Get("id"),
+		CategoryID: values.Get("category_id"),
+		Method:     values.Get("method"),
+		AutoApply:  values.Get("auto_apply"),
+	}
+
+	return qp, nil
+}
+
+func parseAccuracyParams(r *http.Request) (demandforecastapp.AccuracyParams, error) {
+	values := r.URL.Query()
+
+	ap := demandforecastapp.AccuracyParams{
+		From:        values.Get("from"),
+		CategoryID:  values.Get("category_id"),
+		HorizonDays: values.Get("horizon_days"),
+	}
+
+	return ap, nil
+}
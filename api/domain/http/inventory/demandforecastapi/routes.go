@@ -0,0 +1,66 @@
+package demandforecastapi
+
+import (
+	"net/http"
+
+	"github.com/timmaaaz/ichor/api/sdk/http/mid"
+	"github.com/timmaaaz/ichor/app/domain/inventory/demandforecastapp"
+	"github.com/timmaaaz/ichor/app/sdk/auth"
+	"github.com/timmaaaz/ichor/app/sdk/authclient"
+	"github.com/timmaaaz/ichor/business/domain/core/permissionsbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
+	"github.com/timmaaaz/ichor/foundation/logger"
+	"github.com/timmaaaz/ichor/foundation/web"
+)
+
+type Config struct {
+	Log               *logger.Logger
+	DemandForecastBus *demandforecastbus.Business
+	AuthClient        *authclient.Client
+	PermissionsBus    *permissionsbus.Business
+}
+
+const (
+	RouteTable       = "inventory.demand_forecasts"
+	PolicyRouteTable = "inventory.forecast_policies"
+)
+
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.AuthClient)
+	api := newAPI(demandforecastapp.NewApp(cfg.DemandForecastBus))
+
+	app.HandlerFunc(http.MethodGet, version, "/inventory/forecast-policies", api.queryPolicies, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, PolicyRouteTable, permissionsbus.Actions.Read, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodGet, version, "/inventory/forecast-policies/{policy_id}", api.queryPolicyByID, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, PolicyRouteTable, permissionsbus.Actions.Read, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodPost, version, "/inventory/forecast-policies", api.createPolicy, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, PolicyRouteTable, permissionsbus.Actions.Create, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodPut, version, "/inventory/forecast-policies/{policy_id}", api.updatePolicy, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, PolicyRouteTable, permissionsbus.Actions.Update, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodDelete, version, "/inventory/forecast-policies/{policy_id}", api.deletePolicy, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, PolicyRouteTable, permissionsbus.Actions.Delete, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodGet, version, "/inventory/demand-forecasts", api.query, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodGet, version, "/inventory/demand-forecasts/accuracy", api.accuracy, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodGet, version, "/inventory/demand-forecasts/{forecast_id}", api.queryByID, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodPost, version, "/inventory/demand-forecasts/run", api.run, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Create, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodPost, version, "/inventory/demand-forecasts/{forecast_id}/approve", api.approve, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Update, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodPost, version, "/inventory/demand-forecasts/{forecast_id}/reject", api.reject, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Update, auth.RuleAny))
+}
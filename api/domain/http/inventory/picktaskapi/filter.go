@@ -20,6 +20,7 @@ func parseQueryParams(r *http.Request) (picktaskapp.QueryParams, error) {
 		LocationID:           values.Get("location_id"),
 		Status:               values.Get("status"),
 		AssignedTo:           values.Get("assigned_to"),
+		BatchID:              values.Get("batch_id"),
 		CreatedBy:            values.Get("created_by"),
 	}
 
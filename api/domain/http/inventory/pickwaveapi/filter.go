@@ -0,0 +1,36 @@
+package pickwaveapi
+
+import (
+	"net/http"
+
+	"github.com/timmaaaz/ichor/app/domain/inventory/pickwaveapp"
+)
+
+func parseQueryParams(r *http.Request) (pickwaveapp.QueryParams, error) {
+	values := r.URL.Query()
+
+	qp := pickwaveapp.QueryParams{
+		Page:        values.Get("page"),
+		Rows:        values.Get("rows"),
+		OrderBy:     values.Get("orderBy"),
+		ID:          values.Get("id"),
+		Status:      values.Get("status"),
+		WarehouseID: values.Get("warehouse_id"),
+	}
+
+	return qp, nil
+}
+
+func parseBatchQueryParams(r *http.Request) pickwaveapp.BatchQueryParams {
+	values := r.URL.Query()
+
+	return pickwaveapp.BatchQueryParams{
+		Page:       values.Get("page"),
+		Rows:       values.Get("rows"),
+		ID:         values.Get("id"),
+		WaveID:     values.Get("wave_id"),
+		ZoneID:     values.Get("zone_id"),
+		Status:     values.Get("status"),
+		AssignedTo: values.Get("assigned_to"),
+	}
+}
@@ -0,0 +1,143 @@
+package pickwaveapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/domain/inventory/pickwaveapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/foundation/web"
+)
+
+type api struct {
+	pickwaveapp *pickwaveapp.App
+}
+
+func newAPI(pickwaveapp *pickwaveapp.App) *api {
+	return &api{
+		pickwaveapp: pickwaveapp,
+	}
+}
+
+func (api *api) plan(ctx context.Context, r *http.Request) web.Encoder {
+	var app pickwaveapp.NewRelease
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	plan, err := api.pickwaveapp.Plan(ctx, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return plan
+}
+
+func (api *api) create(ctx context.Context, r *http.Request) web.Encoder {
+	var app pickwaveapp.NewRelease
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	waves, err := api.pickwaveapp.Create(ctx, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return waves
+}
+
+func (api *api) delete(ctx context.Context, r *http.Request) web.Encoder {
+	waveID, err := uuid.Parse(web.Param(r, "wave_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	if err := api.pickwaveapp.Delete(ctx, waveID); err != nil {
+		return errs.NewError(err)
+	}
+
+	return nil
+}
+
+func (api *api) query(ctx context.Context, r *http.Request) web.Encoder {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	waves, err := api.pickwaveapp.Query(ctx, qp)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return waves
+}
+
+func (api *api) queryByID(ctx context.Context, r *http.Request) web.Encoder {
+	waveID, err := uuid.Parse(web.Param(r, "wave_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	wave, err := api.pickwaveapp.QueryByID(ctx, waveID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return wave
+}
+
+func (api *api) queryBatches(ctx context.Context, r *http.Request) web.Encoder {
+	qp := parseBatchQueryParams(r)
+
+	batches, err := api.pickwaveapp.QueryBatches(ctx, qp)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return batches
+}
+
+func (api *api) queryBatchByID(ctx context.Context, r *http.Request) web.Encoder {
+	batchID, err := uuid.Parse(web.Param(r, "batch_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	batch, err := api.pickwaveapp.QueryBatchByID(ctx, batchID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return batch
+}
+
+func (api *api) claim(ctx context.Context, r *http.Request) web.Encoder {
+	batchID, err := uuid.Parse(web.Param(r, "batch_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	batch, err := api.pickwaveapp.Claim(ctx, batchID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return batch
+}
+
+func (api *api) complete(ctx context.Context, r *http.Request) web.Encoder {
+	batchID, err := uuid.Parse(web.Param(r, "batch_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	batch, err := api.pickwaveapp.Complete(ctx, batchID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return batch
+}
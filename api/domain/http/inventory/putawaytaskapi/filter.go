@@ -24,3 +24,17 @@ func parseQueryParams(r *http.Request) (putawaytaskapp.QueryParams, error) {
 
 	return qp, nil
 }
+
+func parseSlottingParams(r *http.Request) putawaytaskapp.SlottingParams {
+	values := r.URL.Query()
+
+	return putawaytaskapp.SlottingParams{
+		ProductID:     values.Get("product_id"),
+		Quantity:      values.Get("quantity"),
+		LotID:         values.Get("lot_id"),
+		WarehouseID:   values.Get("warehouse_id"),
+		ZoneStages:    values.Get("zone_stages"),
+		BinPreference: values.Get("bin_preference"),
+		Limit:         values.Get("limit"),
+	}
+}
@@ -96,3 +96,12 @@ func (api *api) queryByID(ctx context.Context, r *http.Request) web.Encoder {
 
 	return task
 }
+
+func (api *api) slot(ctx context.Context, r *http.Request) web.Encoder {
+	slotting, err := api.putawaytaskapp.Slot(ctx, parseSlottingParams(r))
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return slotting
+}
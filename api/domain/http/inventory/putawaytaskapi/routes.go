@@ -10,6 +10,7 @@ import (
 	"github.com/timmaaaz/ichor/app/sdk/authclient"
 	"github.com/timmaaaz/ichor/business/domain/core/permissionsbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/putawaytaskbus"
 	"github.com/timmaaaz/ichor/foundation/logger"
@@ -22,6 +23,7 @@ type Config struct {
 	PutAwayTaskBus    *putawaytaskbus.Business
 	InvTransactionBus *inventorytransactionbus.Business
 	InvItemBus        *inventoryitembus.Business
+	InvLocationBus    *inventorylocationbus.Business
 	DB                *sqlx.DB
 	AuthClient        *authclient.Client
 	PermissionsBus    *permissionsbus.Business
@@ -41,12 +43,16 @@ func Routes(app *web.App, cfg Config) {
 		cfg.PutAwayTaskBus,
 		cfg.InvTransactionBus,
 		cfg.InvItemBus,
+		cfg.InvLocationBus,
 		cfg.DB,
 	))
 
 	app.HandlerFunc(http.MethodGet, version, "/inventory/put-away-tasks", a.query, authen,
 		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAny))
 
+	app.HandlerFunc(http.MethodGet, version, "/inventory/put-away-tasks/slotting", a.slot, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAny))
+
 	app.HandlerFunc(http.MethodGet, version, "/inventory/put-away-tasks/{task_id}", a.queryByID, authen,
 		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAny))
 
@@ -0,0 +1,26 @@
+package replenishmenttaskapi
+
+import (
+	"net/http"
+
+	"github.com/timmaaaz/ichor/app/domain/inventory/replenishmenttaskapp"
+)
+
+func parseQueryParams(r *http.Request) (replenishmenttaskapp.QueryParams, error) {
+	values := r.URL.Query()
+
+	qp := replenishmenttaskapp.QueryParams{
+		Page:           values.Get("page"),
+		Rows:           values.Get("rows"),
+		OrderBy:        values.Get("orderBy"),
+		ID:             values.Get("id"),
+		ProductID:      values.Get("product_id"),
+		FromLocationID: values.Get("from_location_id"),
+		ToLocationID:   values.Get("to_location_id"),
+		Reason:         values.Get("reason"),
+		Status:         values.Get("status"),
+		AssignedTo:     values.Get("assigned_to"),
+	}
+
+	return qp, nil
+}
@@ -0,0 +1,124 @@
+package replenishmenttaskapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/domain/inventory/replenishmenttaskapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/foundation/web"
+)
+
+type api struct {
+	replenishmenttaskapp *replenishmenttaskapp.App
+}
+
+func newAPI(replenishmenttaskapp *replenishmenttaskapp.App) *api {
+	return &api{
+		replenishmenttaskapp: replenishmenttaskapp,
+	}
+}
+
+func (api *api) plan(ctx context.Context, r *http.Request) web.Encoder {
+	var app replenishmenttaskapp.NewReplenishment
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	plan, err := api.replenishmenttaskapp.Plan(ctx, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return plan
+}
+
+func (api *api) generate(ctx context.Context, r *http.Request) web.Encoder {
+	var app replenishmenttaskapp.NewReplenishment
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	tasks, err := api.replenishmenttaskapp.Generate(ctx, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return tasks
+}
+
+func (api *api) query(ctx context.Context, r *http.Request) web.Encoder {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	tasks, err := api.replenishmenttaskapp.Query(ctx, qp)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return tasks
+}
+
+func (api *api) queryByID(ctx context.Context, r *http.Request) web.Encoder {
+	taskID, err := uuid.Parse(web.Param(r, "task_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	task, err := api.replenishmenttaskapp.QueryByID(ctx, taskID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return task
+}
+
+func (api *api) claim(ctx context.Context, r *http.Request) web.Encoder {
+	taskID, err := uuid.Parse(web.Param(r, "task_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	task, err := api.replenishmenttaskapp.Claim(ctx, taskID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return task
+}
+
+func (api *api) complete(ctx context.Context, r *http.Request) web.Encoder {
+	taskID, err := uuid.Parse(web.Param(r, "task_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	var app replenishmenttaskapp.CompleteTask
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	task, err := api.replenishmenttaskapp.Complete(ctx, taskID, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return task
+}
+
+func (api *api) cancel(ctx context.Context, r *http.Request) web.Encoder {
+	taskID, err := uuid.Parse(web.Param(r, "task_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	task, err := api.replenishmenttaskapp.Cancel(ctx, taskID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return task
+}
@@ -166,12 +166,48 @@ func (api *api) execute(ctx context.Context, r *http.Request) web.Encoder {
 	return to
 }
 
+func (api *api) receive(ctx context.Context, r *http.Request) web.Encoder {
+	var app transferorderapp.ReceiveRequest
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	toID := web.Param(r, "transfer_id")
+	parsed, err := uuid.Parse(toID)
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	to, err := api.transferorderapp.Receive(ctx, parsed, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return to
+}
+
+func (api *api) queryLines(ctx context.Context, r *http.Request) web.Encoder {
+	toID := web.Param(r, "transfer_id")
+	parsed, err := uuid.Parse(toID)
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	lines, err := api.transferorderapp.QueryLines(ctx, parsed)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return lines
+}
+
 func (api *api) queryStatuses(ctx context.Context, r *http.Request) web.Encoder {
 	return transferStatuses{
 		transferorderbus.StatusPending,
 		transferorderbus.StatusApproved,
 		transferorderbus.StatusRejected,
 		transferorderbus.StatusInTransit,
+		transferorderbus.StatusPartiallyReceived,
 		transferorderbus.StatusCompleted,
 	}
 }
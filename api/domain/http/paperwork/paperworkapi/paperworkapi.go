@@ -75,3 +75,20 @@ func (api *api) transferSheet(ctx context.Context, r *http.Request) web.Encoder
 	}
 	return pdfResponse{bytes: pdf}
 }
+
+// batchPickSheet handles GET /v1/paperwork/batch-pick-sheet?batch_id=&zone=
+func (api *api) batchPickSheet(ctx context.Context, r *http.Request) web.Encoder {
+	batchID, err := uuid.Parse(r.URL.Query().Get("batch_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, errors.New("batch_id must be a valid uuid"))
+	}
+	req := paperworkapp.BatchPickSheetRequest{
+		BatchID: batchID,
+		Zone:    r.URL.Query().Get("zone"),
+	}
+	pdf, err := api.app.BuildBatchPickSheet(ctx, req)
+	if err != nil {
+		return errs.NewError(err)
+	}
+	return pdfResponse{bytes: pdf}
+}
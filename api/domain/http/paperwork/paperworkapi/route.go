@@ -11,6 +11,7 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/core/permissionsbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/picktaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/pickwavebus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/transferorderbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/warehousebus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus"
@@ -25,13 +26,14 @@ import (
 )
 
 // Per-route table_access constants — paperwork endpoints render data from
-// three different domain tables, and authorization piggybacks on existing
+// four different domain tables, and authorization piggybacks on existing
 // table-level Read permissions for each. Tests reference these constants
 // when downgrading non-admin role permissions to assert 403.
 const (
-	RouteTablePickSheet     = "sales.orders"
-	RouteTableReceiveCover  = "procurement.purchase_orders"
-	RouteTableTransferSheet = "inventory.transfer_orders"
+	RouteTablePickSheet      = "sales.orders"
+	RouteTableReceiveCover   = "procurement.purchase_orders"
+	RouteTableTransferSheet  = "inventory.transfer_orders"
+	RouteTableBatchPickSheet = "inventory.pick_waves"
 )
 
 // Config carries the dependencies for the paperwork API.
@@ -49,6 +51,7 @@ type Config struct {
 	WarehousesBus        *warehousebus.Business
 	InventoryLocationsBus *inventorylocationbus.Business
 	ProductsBus          *productbus.Business
+	PickWavesBus         *pickwavebus.Business
 	AuthClient           *authclient.Client
 	PermissionsBus       *permissionsbus.Business
 }
@@ -72,6 +75,7 @@ func Routes(app *web.App, cfg Config) {
 		cfg.WarehousesBus,
 		cfg.InventoryLocationsBus,
 		cfg.ProductsBus,
+		cfg.PickWavesBus,
 	))
 
 	app.HandlerFunc(http.MethodGet, version, "/paperwork/pick-sheet", api.pickSheet, authen,
@@ -82,4 +86,7 @@ func Routes(app *web.App, cfg Config) {
 
 	app.HandlerFunc(http.MethodGet, version, "/paperwork/transfer-sheet", api.transferSheet, authen,
 		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTableTransferSheet, permissionsbus.Actions.Read, auth.RuleAny))
+
+	app.HandlerFunc(http.MethodGet, version, "/paperwork/batch-pick-sheet", api.batchPickSheet, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTableBatchPickSheet, permissionsbus.Actions.Read, auth.RuleAny))
 }
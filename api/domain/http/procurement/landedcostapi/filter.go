@@ -0,0 +1,23 @@
+package landedcostapi
+
+import (
+	"net/http"
+
+	"github.com/timmaaaz/ichor/app/domain/procurement/landedcostapp"
+)
+
+func parseQueryParams(r *http.Request) (landedcostapp.QueryParams, error) {
+	values := r.URL.Query()
+
+	qp := landedcostapp.QueryParams{
+		Page:            values.Get("page"),
+		Rows:            values.Get("rows"),
+		OrderBy:         values.Get("orderBy"),
+		ID:              values.Get("id"),
+		PurchaseOrderID: values.Get("purchase_order_id"),
+		ReferenceNumber: values.Get("reference_number"),
+		Status:          values.Get("status"),
+	}
+
+	return qp, nil
+}
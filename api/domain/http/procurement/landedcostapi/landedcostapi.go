@@ -0,0 +1,124 @@
+package landedcostapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/domain/procurement/landedcostapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/foundation/web"
+)
+
+type api struct {
+	landedcostapp *landedcostapp.App
+}
+
+func newAPI(landedcostapp *landedcostapp.App) *api {
+	return &api{
+		landedcostapp: landedcostapp,
+	}
+}
+
+func (api *api) create(ctx context.Context, r *http.Request) web.Encoder {
+	var app landedcostapp.NewLandedCost
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	lc, err := api.landedcostapp.Create(ctx, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return lc
+}
+
+func (api *api) update(ctx context.Context, r *http.Request) web.Encoder {
+	var app landedcostapp.UpdateLandedCost
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	landedCostID, err := uuid.Parse(web.Param(r, "landed_cost_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	lc, err := api.landedcostapp.Update(ctx, landedCostID, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return lc
+}
+
+func (api *api) preview(ctx context.Context, r *http.Request) web.Encoder {
+	landedCostID, err := uuid.Parse(web.Param(r, "landed_cost_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	preview, err := api.landedcostapp.Preview(ctx, landedCostID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return preview
+}
+
+func (api *api) apply(ctx context.Context, r *http.Request) web.Encoder {
+	landedCostID, err := uuid.Parse(web.Param(r, "landed_cost_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	lc, err := api.landedcostapp.Apply(ctx, landedCostID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return lc
+}
+
+func (api *api) cancel(ctx context.Context, r *http.Request) web.Encoder {
+	landedCostID, err := uuid.Parse(web.Param(r, "landed_cost_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	lc, err := api.landedcostapp.Cancel(ctx, landedCostID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return lc
+}
+
+func (api *api) query(ctx context.Context, r *http.Request) web.Encoder {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	lcs, err := api.landedcostapp.Query(ctx, qp)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return lcs
+}
+
+func (api *api) queryByID(ctx context.Context, r *http.Request) web.Encoder {
+	landedCostID, err := uuid.Parse(web.Param(r, "landed_cost_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	lc, err := api.landedcostapp.QueryByID(ctx, landedCostID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return lc
+}
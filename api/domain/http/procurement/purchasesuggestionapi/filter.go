@@ -0,0 +1,25 @@
+package purchasesuggestionapi
+
+import (
+	"net/http"
+
+	"github.com/timmaaaz/ichor/app/domain/procurement/purchasesuggestionapp"
+)
+
+func parseQueryParams(r *http.Request) (purchasesuggestionapp.QueryParams, error) {
+	values := r.URL.Query()
+
+	qp := purchasesuggestionapp.QueryParams{
+		Page:                values.Get("page"),
+		Rows:                values.Get("rows"),
+		OrderBy:             values.Get("orderBy"),
+		ID:                  values.Get("id"),
+		SupplierID:          values.Get("supplier_id"),
+		DeliveryWarehouseID: values.Get("delivery_warehouse_id"),
+		ProductID:           values.Get("product_id"),
+		PurchaseOrderID:     values.Get("purchase_order_id"),
+		Status:              values.Get("status"),
+	}
+
+	return qp, nil
+}
@@ -0,0 +1,158 @@
+package purchasesuggestionapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/domain/procurement/purchasesuggestionapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/foundation/web"
+)
+
+type api struct {
+	purchasesuggestionapp *purchasesuggestionapp.App
+}
+
+func newAPI(purchasesuggestionapp *purchasesuggestionapp.App) *api {
+	return &api{
+		purchasesuggestionapp: purchasesuggestionapp,
+	}
+}
+
+func (api *api) run(ctx context.Context, r *http.Request) web.Encoder {
+	var app purchasesuggestionapp.NewRun
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	result, err := api.purchasesuggestionapp.Run(ctx, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return result
+}
+
+func (api *api) update(ctx context.Context, r *http.Request) web.Encoder {
+	var app purchasesuggestionapp.UpdateSuggestion
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	suggestionID, err := uuid.Parse(web.Param(r, "suggestion_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	suggestion, err := api.purchasesuggestionapp.Update(ctx, suggestionID, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return suggestion
+}
+
+func (api *api) updateLine(ctx context.Context, r *http.Request) web.Encoder {
+	var app purchasesuggestionapp.UpdateLine
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	suggestionID, err := uuid.Parse(web.Param(r, "suggestion_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	lineID, err := uuid.Parse(web.Param(r, "line_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	suggestion, err := api.purchasesuggestionapp.UpdateLine(ctx, suggestionID, lineID, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return suggestion
+}
+
+func (api *api) removeLine(ctx context.Context, r *http.Request) web.Encoder {
+	suggestionID, err := uuid.Parse(web.Param(r, "suggestion_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	lineID, err := uuid.Parse(web.Param(r, "line_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	suggestion, err := api.purchasesuggestionapp.RemoveLine(ctx, suggestionID, lineID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return suggestion
+}
+
+func (api *api) release(ctx context.Context, r *http.Request) web.Encoder {
+	var app purchasesuggestionapp.NewRelease
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	suggestionID, err := uuid.Parse(web.Param(r, "suggestion_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	suggestion, err := api.purchasesuggestionapp.Release(ctx, suggestionID, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return suggestion
+}
+
+func (api *api) dismiss(ctx context.Context, r *http.Request) web.Encoder {
+	suggestionID, err := uuid.Parse(web.Param(r, "suggestion_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	suggestion, err := api.purchasesuggestionapp.Dismiss(ctx, suggestionID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return suggestion
+}
+
+func (api *api) query(ctx context.Context, r *http.Request) web.Encoder {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	suggestions, err := api.purchasesuggestionapp.Query(ctx, qp)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return suggestions
+}
+
+func (api *api) queryByID(ctx context.Context, r *http.Request) web.Encoder {
+	suggestionID, err := uuid.Parse(web.Param(r, "suggestion_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	suggestion, err := api.purchasesuggestionapp.QueryByID(ctx, suggestionID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return suggestion
+}
@@ -0,0 +1,25 @@
+package supplierinvoiceapi
+
+import (
+	"net/http"
+
+	"github.com/timmaaaz/ichor/app/domain/procurement/supplierinvoiceapp"
+)
+
+func parseQueryParams(r *http.Request) (supplierinvoiceapp.QueryParams, error) {
+	values := r.URL.Query()
+
+	qp := supplierinvoiceapp.QueryParams{
+		Page:            values.Get("page"),
+		Rows:            values.Get("rows"),
+		OrderBy:         values.Get("orderBy"),
+		ID:              values.Get("id"),
+		InvoiceNumber:   values.Get("invoice_number"),
+		SupplierID:      values.Get("supplier_id"),
+		PurchaseOrderID: values.Get("purchase_order_id"),
+		Status:          values.Get("status"),
+		MatchStatus:     values.Get("match_status"),
+	}
+
+	return qp, nil
+}
@@ -0,0 +1,134 @@
+package supplierinvoiceapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/domain/procurement/supplierinvoiceapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/foundation/web"
+)
+
+type api struct {
+	supplierinvoiceapp *supplierinvoiceapp.App
+}
+
+func newAPI(supplierinvoiceapp *supplierinvoiceapp.App) *api {
+	return &api{
+		supplierinvoiceapp: supplierinvoiceapp,
+	}
+}
+
+func (api *api) create(ctx context.Context, r *http.Request) web.Encoder {
+	var app supplierinvoiceapp.NewSupplierInvoice
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	invoice, err := api.supplierinvoiceapp.Create(ctx, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return invoice
+}
+
+func (api *api) update(ctx context.Context, r *http.Request) web.Encoder {
+	var app supplierinvoiceapp.UpdateSupplierInvoice
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	invoiceID, err := uuid.Parse(web.Param(r, "invoice_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	invoice, err := api.supplierinvoiceapp.Update(ctx, invoiceID, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return invoice
+}
+
+func (api *api) match(ctx context.Context, r *http.Request) web.Encoder {
+	invoiceID, err := uuid.Parse(web.Param(r, "invoice_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	invoice, err := api.supplierinvoiceapp.Match(ctx, invoiceID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return invoice
+}
+
+func (api *api) approve(ctx context.Context, r *http.Request) web.Encoder {
+	var app supplierinvoiceapp.Decision
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	invoiceID, err := uuid.Parse(web.Param(r, "invoice_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	invoice, err := api.supplierinvoiceapp.Approve(ctx, invoiceID, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return invoice
+}
+
+func (api *api) reject(ctx context.Context, r *http.Request) web.Encoder {
+	var app supplierinvoiceapp.Decision
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	invoiceID, err := uuid.Parse(web.Param(r, "invoice_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	invoice, err := api.supplierinvoiceapp.Reject(ctx, invoiceID, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return invoice
+}
+
+func (api *api) query(ctx context.Context, r *http.Request) web.Encoder {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	invoices, err := api.supplierinvoiceapp.Query(ctx, qp)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return invoices
+}
+
+func (api *api) queryByID(ctx context.Context, r *http.Request) web.Encoder {
+	invoiceID, err := uuid.Parse(web.Param(r, "invoice_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	invoice, err := api.supplierinvoiceapp.QueryByID(ctx, invoiceID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return invoice
+}
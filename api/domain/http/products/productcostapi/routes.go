@@ -30,6 +30,9 @@ func Routes(app *web.App, cfg Config) {
 	authen := mid.Authenticate(cfg.AuthClient)
 	api := newAPI(productcostapp.NewApp(cfg.ProductCostBus))
 
+	// The row id is served as cost_id; every other column keeps its name.
+	mid.RegisterFieldNames(RouteTable, map[string]string{"id": "cost_id"})
+
 	app.HandlerFunc(http.MethodGet, version, "/products/product-costs", api.query, authen,
 		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAny))
 
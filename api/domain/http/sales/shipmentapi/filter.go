@@ -0,0 +1,29 @@
+package shipmentapi
+
+import (
+	"net/http"
+
+	"github.com/timmaaaz/ichor/app/domain/sales/shipmentapp"
+)
+
+func parseQueryParams(r *http.Request) (shipmentapp.QueryParams, error) {
+	values := r.URL.Query()
+
+	filter := shipmentapp.QueryParams{
+		Page:             values.Get("page"),
+		Rows:             values.Get("rows"),
+		OrderBy:          values.Get("orderBy"),
+		ID:               values.Get("id"),
+		ShipmentNumber:   values.Get("shipment_number"),
+		OrderID:          values.Get("order_id"),
+		Status:           values.Get("status"),
+		Carrier:          values.Get("carrier"),
+		TrackingNumber:   values.Get("tracking_number"),
+		StartShipDate:    values.Get("start_ship_date"),
+		EndShipDate:      values.Get("end_ship_date"),
+		StartCreatedDate: values.Get("start_created_date"),
+		EndCreatedDate:   values.Get("end_created_date"),
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,226 @@
+package shipmentapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/domain/sales/shipmentapp"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/foundation/web"
+)
+
+type api struct {
+	shipmentapp *shipmentapp.App
+}
+
+func newAPI(shipmentapp *shipmentapp.App) *api {
+	return &api{
+		shipmentapp: shipmentapp,
+	}
+}
+
+func (api *api) create(ctx context.Context, r *http.Request) web.Encoder {
+	var app shipmentapp.NewShipment
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	sh, err := api.shipmentapp.Create(ctx, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return sh
+}
+
+func (api *api) update(ctx context.Context, r *http.Request) web.Encoder {
+	var app shipmentapp.UpdateShipment
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	shipmentID, err := uuid.Parse(web.Param(r, "shipment_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	sh, err := api.shipmentapp.Update(ctx, shipmentID, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return sh
+}
+
+func (api *api) delete(ctx context.Context, r *http.Request) web.Encoder {
+	shipmentID, err := uuid.Parse(web.Param(r, "shipment_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	if err := api.shipmentapp.Delete(ctx, shipmentID); err != nil {
+		return errs.NewError(err)
+	}
+
+	return nil
+}
+
+func (api *api) query(ctx context.Context, r *http.Request) web.Encoder {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	shipments, err := api.shipmentapp.Query(ctx, qp)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return shipments
+}
+
+func (api *api) queryByID(ctx context.Context, r *http.Request) web.Encoder {
+	shipmentID, err := uuid.Parse(web.Param(r, "shipment_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	sh, err := api.shipmentapp.QueryByID(ctx, shipmentID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return sh
+}
+
+func (api *api) queryCartons(ctx context.Context, r *http.Request) web.Encoder {
+	shipmentID, err := uuid.Parse(web.Param(r, "shipment_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	cartons, err := api.shipmentapp.QueryCartons(ctx, shipmentID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return cartons
+}
+
+func (api *api) addCarton(ctx context.Context, r *http.Request) web.Encoder {
+	var app shipmentapp.NewCarton
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	shipmentID, err := uuid.Parse(web.Param(r, "shipment_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	carton, err := api.shipmentapp.AddCarton(ctx, shipmentID, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return carton
+}
+
+func (api *api) removeCarton(ctx context.Context, r *http.Request) web.Encoder {
+	shipmentID, err := uuid.Parse(web.Param(r, "shipment_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	cartonID, err := uuid.Parse(web.Param(r, "carton_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	if err := api.shipmentapp.RemoveCarton(ctx, shipmentID, cartonID); err != nil {
+		return errs.NewError(err)
+	}
+
+	return nil
+}
+
+func (api *api) ship(ctx context.Context, r *http.Request) web.Encoder {
+	var app shipmentapp.ShipRequest
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	shipmentID, err := uuid.Parse(web.Param(r, "shipment_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	sh, err := api.shipmentapp.Ship(ctx, shipmentID, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return sh
+}
+
+func (api *api) cancel(ctx context.Context, r *http.Request) web.Encoder {
+	shipmentID, err := uuid.Parse(web.Param(r, "shipment_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	sh, err := api.shipmentapp.Cancel(ctx, shipmentID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return sh
+}
+
+func (api *api) rates(ctx context.Context, r *http.Request) web.Encoder {
+	shipmentID, err := uuid.Parse(web.Param(r, "shipment_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	rates, err := api.shipmentapp.Rates(ctx, shipmentID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return rates
+}
+
+func (api *api) createLabel(ctx context.Context, r *http.Request) web.Encoder {
+	var app shipmentapp.LabelRequest
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	shipmentID, err := uuid.Parse(web.Param(r, "shipment_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	lbl, err := api.shipmentapp.CreateLabel(ctx, shipmentID, app)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return lbl
+}
+
+func (api *api) voidLabel(ctx context.Context, r *http.Request) web.Encoder {
+	shipmentID, err := uuid.Parse(web.Param(r, "shipment_id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	sh, err := api.shipmentapp.VoidLabel(ctx, shipmentID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return sh
+}
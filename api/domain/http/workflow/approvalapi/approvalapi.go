@@ -139,12 +139,19 @@ func (a *api) queryByID(ctx context.Context, r *http.Request) web.Encoder {
 
 	app := toAppApproval(req)
 	a.enrichSingleApproval(ctx, req, &app)
+	a.attachVotes(ctx, req, &app)
 
 	return app
 }
 
 // resolve handles the approval/rejection of a pending approval request.
 // Authorization: user must be an approver or have ADMIN role in claims.
+//
+// An approver's call records their vote; the request only resolves (and the
+// Temporal activity only completes) once the approval type's quorum is met or
+// becomes unreachable. Until then the response carries the partial progress.
+// An ADMIN who is not on the approver list overrides the vote and resolves the
+// request directly.
 func (a *api) resolve(ctx context.Context, r *http.Request) web.Encoder {
 	id, err := uuid.Parse(web.Param(r, "id"))
 	if err != nil {
@@ -178,8 +185,17 @@ func (a *api) resolve(ctx context.Context, r *http.Request) web.Encoder {
 		}
 	}
 
-	// Resolve in DB (atomic, checks pending status).
-	approval, err := a.approvalBus.Resolve(ctx, id, userID, req.Resolution, req.Reason)
+	// Vote, or resolve directly for an admin override (both atomic on pending status).
+	var (
+		approval approvalrequestbus.ApprovalRequest
+		decided  bool
+	)
+	if isApprover {
+		approval, _, decided, err = a.approvalBus.CastVote(ctx, id, userID, req.Resolution, req.Reason)
+	} else {
+		approval, err = a.approvalBus.Resolve(ctx, id, userID, req.Resolution, req.Reason)
+		decided = err == nil
+	}
 	if err != nil {
 		if errors.Is(err, approvalrequestbus.ErrNotFound) {
 			return errs.New(errs.NotFound, err)
@@ -188,15 +204,61 @@ func (a *api) resolve(ctx context.Context, r *http.Request) web.Encoder {
 			// Idempotent: already resolved — retry Temporal completion and return 200.
 			return a.retryTemporalCompletion(ctx, id)
 		}
+		if errors.Is(err, approvalrequestbus.ErrAlreadyVoted) {
+			return errs.New(errs.AlreadyExists, err)
+		}
 		return errs.Newf(errs.Internal, "resolve: %s", err)
 	}
 
+	// Quorum not met yet (or a concurrent voter's call resolved it and owns the
+	// completion): report progress without touching Temporal.
+	if !decided {
+		app := toAppApproval(approval)
+		a.enrichSingleApproval(ctx, approval, &app)
+		a.attachVotes(ctx, approval, &app)
+		return app
+	}
+
+	// The outcome is the request's status, not this caller's decision: they
+	// match for every quorum rule, but the status is what was persisted.
+	req.Resolution = approval.Status
+
 	// Complete the Temporal activity and clear the task token from DB.
 	a.completeAndClear(ctx, id, approval, req, userID)
 
 	a.publishApprovalResolved(ctx, approval, userID)
 
-	return toAppApproval(approval)
+	app := toAppApproval(approval)
+	a.attachVotes(ctx, approval, &app)
+
+	return app
+}
+
+// attachVotes adds the recorded votes and the quorum progress to a single
+// approval response. Best-effort: a failed lookup is logged and the response
+// is returned without them.
+func (a *api) attachVotes(ctx context.Context, bus approvalrequestbus.ApprovalRequest, app *Approval) {
+	votes, err := a.approvalBus.QueryVotes(ctx, bus.ID)
+	if err != nil {
+		a.log.Error(ctx, "failed to query approval votes", "approval_id", bus.ID, "error", err)
+		return
+	}
+
+	tally := approvalrequestbus.EvaluateQuorum(bus.ApprovalType, bus.Required, bus.Approvers, votes)
+	app.Progress = toAppProgress(tally)
+
+	if len(votes) == 0 {
+		return
+	}
+
+	names := make(map[uuid.UUID]string, len(app.ApproverDetails))
+	for _, d := range app.ApproverDetails {
+		if id, err := uuid.Parse(d.ID); err == nil && d.Name != "" {
+			names[id] = d.Name
+		}
+	}
+
+	app.Votes = toAppVotes(votes, names)
 }
 
 // buildResolveResult is the single source of truth for the Temporal activity
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/timmaaaz/ichor/business/domain/workflow/approvalrequestbus"
 )
 
@@ -32,6 +33,31 @@ type Approval struct {
 	CreatedDate      string       `json:"createdDate"`
 	ResolvedDate     string       `json:"resolvedDate,omitempty"`
 	ScenarioID       string       `json:"scenario_id,omitempty"`
+	TimeoutAction    string       `json:"timeoutAction,omitempty"`
+	EscalatedDate    string       `json:"escalatedDate,omitempty"`
+
+	// Progress and Votes are populated on single-request responses (lookup
+	// and resolve) so the UI can show how far a multi-approver request is.
+	Progress *ApprovalProgress `json:"progress,omitempty"`
+	Votes    []VoteVM          `json:"votes,omitempty"`
+}
+
+// ApprovalProgress is the quorum state of an approval request.
+type ApprovalProgress struct {
+	Required   int `json:"required"`
+	Eligible   int `json:"eligible"`
+	Approvals  int `json:"approvals"`
+	Rejections int `json:"rejections"`
+	Remaining  int `json:"remaining"`
+}
+
+// VoteVM represents one approver's recorded vote.
+type VoteVM struct {
+	ApproverID   string `json:"approverId"`
+	ApproverName string `json:"approverName,omitempty"`
+	Decision     string `json:"decision"`
+	Reason       string `json:"reason,omitempty"`
+	VotedDate    string `json:"votedDate"`
 }
 
 // Encode implements the web.Encoder interface.
@@ -57,6 +83,12 @@ func toAppApproval(bus approvalrequestbus.ApprovalRequest) Approval {
 		Status:       bus.Status,
 		TimeoutHours: bus.TimeoutHours,
 		CreatedDate:  bus.CreatedDate.Format(time.RFC3339),
+
+		TimeoutAction: bus.TimeoutAction,
+	}
+
+	if bus.EscalatedDate != nil {
+		app.EscalatedDate = bus.EscalatedDate.Format(time.RFC3339)
 	}
 
 	if bus.RuleName != "" {
@@ -81,6 +113,33 @@ func toAppApproval(bus approvalrequestbus.ApprovalRequest) Approval {
 	return app
 }
 
+// toAppProgress converts a business tally to the API progress model.
+func toAppProgress(t approvalrequestbus.Tally) *ApprovalProgress {
+	return &ApprovalProgress{
+		Required:   t.Required,
+		Eligible:   t.Eligible,
+		Approvals:  t.Approvals,
+		Rejections: t.Rejections,
+		Remaining:  t.Remaining,
+	}
+}
+
+// toAppVotes converts business votes to API models, filling in approver names
+// that are already known.
+func toAppVotes(votes []approvalrequestbus.Vote, names map[uuid.UUID]string) []VoteVM {
+	app := make([]VoteVM, len(votes))
+	for i, v := range votes {
+		app[i] = VoteVM{
+			ApproverID:   v.ApproverID.String(),
+			ApproverName: names[v.ApproverID],
+			Decision:     v.Decision,
+			Reason:       v.Reason,
+			VotedDate:    v.VotedDate.Format(time.RFC3339),
+		}
+	}
+	return app
+}
+
 // toAppApprovals converts a slice of business approval requests to API models.
 func toAppApprovals(bus []approvalrequestbus.ApprovalRequest) []Approval {
 	app := make([]Approval, len(bus))
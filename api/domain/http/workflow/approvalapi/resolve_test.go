@@ -54,6 +54,24 @@ func (s *mockStorer) ClearTaskToken(_ context.Context, _ uuid.UUID) error {
 	s.clearCalled = true
 	return s.clearErr
 }
+func (s *mockStorer) CreateVote(_ context.Context, _ approvalrequestbus.Vote) error {
+	return nil
+}
+func (s *mockStorer) QueryVotes(_ context.Context, _ uuid.UUID) ([]approvalrequestbus.Vote, error) {
+	return nil, nil
+}
+func (s *mockStorer) QueryDueEscalations(_ context.Context, _ time.Time, _ int) ([]approvalrequestbus.ApprovalRequest, error) {
+	return nil, nil
+}
+func (s *mockStorer) QueryDueTimeouts(_ context.Context, _ time.Time, _ int) ([]approvalrequestbus.ApprovalRequest, error) {
+	return nil, nil
+}
+func (s *mockStorer) Escalate(_ context.Context, _ uuid.UUID, _ []uuid.UUID, _ time.Time) (approvalrequestbus.ApprovalRequest, error) {
+	return approvalrequestbus.ApprovalRequest{}, nil
+}
+func (s *mockStorer) TimeOut(_ context.Context, _ uuid.UUID, _ string) (approvalrequestbus.ApprovalRequest, error) {
+	return approvalrequestbus.ApprovalRequest{}, nil
+}
 
 // mockActivityCompleter controls the Temporal CompleteActivity call.
 type mockActivityCompleter struct {
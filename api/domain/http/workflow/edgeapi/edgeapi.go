@@ -93,6 +93,11 @@ func (a *api) create(ctx context.Context, r *http.Request) web.Encoder {
 
 	a.log.Info(ctx, "edge created", "edge_id", edge.ID, "rule_id", ruleID, "created_by", userID)
 
+	// The trigger runs the published revision, so every edit is published.
+	if _, err := a.workflowBus.PublishLiveRevision(ctx, ruleID, userID, "edge created"); err != nil {
+		return errs.Newf(errs.Internal, "publish revision: %s", err)
+	}
+
 	return toEdgeResponse(edge)
 }
 
@@ -203,6 +208,10 @@ func (a *api) delete(ctx context.Context, r *http.Request) web.Encoder {
 
 	a.log.Info(ctx, "edge deleted", "edge_id", edgeID, "rule_id", ruleID, "deleted_by", userID)
 
+	if _, err := a.workflowBus.PublishLiveRevision(ctx, ruleID, userID, "edge deleted"); err != nil {
+		return errs.Newf(errs.Internal, "publish revision: %s", err)
+	}
+
 	return nil
 }
 
@@ -235,5 +244,9 @@ func (a *api) deleteAll(ctx context.Context, r *http.Request) web.Encoder {
 
 	a.log.Info(ctx, "all edges deleted for rule", "rule_id", ruleID, "deleted_by", userID)
 
+	if _, err := a.workflowBus.PublishLiveRevision(ctx, ruleID, userID, "all edges deleted"); err != nil {
+		return errs.Newf(errs.Internal, "publish revision: %s", err)
+	}
+
 	return nil
 }
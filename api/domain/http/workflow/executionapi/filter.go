@@ -15,6 +15,7 @@ type QueryParams struct {
 	OrderBy       string
 	ID            string
 	RuleID        string
+	RevisionID    string
 	Status        string
 	TriggerSource string
 	DateFrom      string
@@ -29,6 +30,7 @@ func parseQueryParams(r *http.Request) QueryParams {
 		OrderBy:       r.URL.Query().Get("orderBy"),
 		ID:            r.URL.Query().Get("id"),
 		RuleID:        r.URL.Query().Get("rule_id"),
+		RevisionID:    r.URL.Query().Get("revision_id"),
 		Status:        r.URL.Query().Get("status"),
 		TriggerSource: r.URL.Query().Get("trigger_source"),
 		DateFrom:      r.URL.Query().Get("date_from"),
@@ -56,6 +58,14 @@ func parseFilter(qp QueryParams) (workflow.ExecutionFilter, error) {
 		filter.RuleID = &ruleID
 	}
 
+	if qp.RevisionID != "" {
+		revisionID, err := uuid.Parse(qp.RevisionID)
+		if err != nil {
+			return filter, err
+		}
+		filter.RevisionID = &revisionID
+	}
+
 	if qp.Status != "" {
 		status := workflow.ExecutionStatus(qp.Status)
 		filter.Status = &status
@@ -27,6 +27,8 @@ type ExecutionResponse struct {
 	TriggerSource   string          `json:"trigger_source"`
 	ExecutedBy      *uuid.UUID      `json:"executed_by,omitempty"`
 	ActionType      string          `json:"action_type,omitempty"`
+	RevisionID      *uuid.UUID      `json:"revision_id,omitempty"`     // Rule revision the execution ran
+	RevisionNumber  int             `json:"revision_number,omitempty"`
 }
 
 // Encode implements web.Encoder for ExecutionResponse.
@@ -50,6 +52,8 @@ type ExecutionDetail struct {
 	ExecutedBy       *uuid.UUID            `json:"executed_by,omitempty"`
 	ExecutedByName   string                `json:"executed_by_name,omitempty"` // Joined executor name from core.users
 	ActionType       string                `json:"action_type,omitempty"`
+	RevisionID       *uuid.UUID            `json:"revision_id,omitempty"` // Rule revision the execution ran
+	RevisionNumber   int                   `json:"revision_number,omitempty"`
 	ActionResults    []ActionResultDetail  `json:"action_results"`
 }
 
@@ -99,6 +103,8 @@ func toExecutionResponse(exec workflow.AutomationExecution) ExecutionResponse {
 		TriggerSource:    exec.TriggerSource,
 		ExecutedBy:       exec.ExecutedBy,
 		ActionType:       exec.ActionType,
+		RevisionID:       exec.RevisionID,
+		RevisionNumber:   exec.RevisionNumber,
 	}
 }
 
@@ -127,6 +133,8 @@ func toExecutionDetail(exec workflow.AutomationExecution) ExecutionDetail {
 		ExecutedBy:       exec.ExecutedBy,
 		ExecutedByName:   exec.ExecutedByName,
 		ActionType:       exec.ActionType,
+		RevisionID:       exec.RevisionID,
+		RevisionNumber:   exec.RevisionNumber,
 		ActionResults:    parseActionResults(exec.ActionsExecuted),
 	}
 }
@@ -98,6 +98,20 @@ var actionTypeMetadata = map[string]actionTypeMeta{
 		SupportsManual: false,
 		IsAsync:        false,
 	},
+	"for_each": {
+		Name:           "For Each",
+		Description:    "Run a branch of the workflow once for each item in a list, sequentially or in parallel",
+		Category:       "control",
+		SupportsManual: false,
+		IsAsync:        false,
+	},
+	"call_workflow": {
+		Name:           "Call Workflow",
+		Description:    "Run another automation rule's actions as a sub-workflow, passing inputs and reading back outputs",
+		Category:       "control",
+		SupportsManual: false,
+		IsAsync:        false,
+	},
 	"evaluate_condition": {
 		Name:           "Evaluate Condition",
 		Description:    "Evaluates conditions against entity data and determines branch direction for workflow execution",
@@ -224,6 +238,62 @@ var actionTypeMetadata = map[string]actionTypeMeta{
 		SupportsManual: true,
 		IsAsync:        false,
 	},
+	"create_shipping_label": {
+		Name:           "Create Shipping Label",
+		Description:    "Buy a carrier shipping label for a packed shipment, record its tracking number and optionally print it",
+		Category:       "shipping",
+		SupportsManual: true,
+		IsAsync:        false,
+	},
+	"generate_cycle_counts": {
+		Name:           "Generate Cycle Counts",
+		Description:    "Generate cycle count sessions from a count plan, or from every active plan",
+		Category:       "inventory",
+		SupportsManual: true,
+		IsAsync:        false,
+	},
+	"generate_replenishment": {
+		Name:           "Generate Replenishment",
+		Description:    "Generate replenishment tasks that move stock from reserve to pick locations running low",
+		Category:       "inventory",
+		SupportsManual: true,
+		IsAsync:        false,
+	},
+	"forecast_demand": {
+		Name:           "Forecast Demand",
+		Description:    "Forecast item demand and recommend avg daily usage, safety stock, reorder point and EOQ",
+		Category:       "inventory",
+		SupportsManual: true,
+		IsAsync:        false,
+	},
+	"suggest_purchases": {
+		Name:           "Suggest Purchases",
+		Description:    "Suggest draft purchase orders per supplier for inventory below its reorder point",
+		Category:       "procurement",
+		SupportsManual: true,
+		IsAsync:        false,
+	},
+	"match_supplier_invoice": {
+		Name:           "Match Supplier Invoice",
+		Description:    "Three-way match a supplier invoice against its purchase order and receipts, leaving a variance pending approval",
+		Category:       "procurement",
+		SupportsManual: true,
+		IsAsync:        false,
+	},
+	"approve_supplier_invoice": {
+		Name:           "Approve Supplier Invoice",
+		Description:    "Approve a supplier invoice pending approval once its approval request is approved",
+		Category:       "procurement",
+		SupportsManual: true,
+		IsAsync:        false,
+	},
+	"reject_supplier_invoice": {
+		Name:           "Reject Supplier Invoice",
+		Description:    "Reject a supplier invoice once its approval request is rejected or times out",
+		Category:       "procurement",
+		SupportsManual: true,
+		IsAsync:        false,
+	},
 	"create_put_away_task": {
 		Name:           "Create Put-Away Task",
 		Description:    "Creates a put-away task directing floor workers to shelve received goods at a designated location",
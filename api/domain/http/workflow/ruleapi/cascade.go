@@ -37,6 +37,14 @@ type ActionCascadeInfo struct {
 	TriggersEvent       string                   `json:"triggers_event,omitempty"`
 	ModifiedFields      []string                 `json:"modified_fields,omitempty"`
 	DownstreamWorkflows []DownstreamWorkflowInfo `json:"downstream_workflows"`
+	CallsWorkflow       *CalledWorkflowInfo      `json:"calls_workflow,omitempty"`
+}
+
+// CalledWorkflowInfo describes the rule a call_workflow action runs as a sub-workflow.
+// RuleName is empty when the called rule no longer exists.
+type CalledWorkflowInfo struct {
+	RuleID   string `json:"rule_id"`
+	RuleName string `json:"rule_name"`
 }
 
 // DownstreamWorkflowInfo describes a workflow that may be triggered by an action.
@@ -88,6 +96,13 @@ func (a *api) cascadeMap(ctx context.Context, r *http.Request) web.Encoder {
 			})
 		}
 
+		if e.CallsRule != nil {
+			info.CallsWorkflow = &CalledWorkflowInfo{
+				RuleID:   e.CallsRule.RuleID.String(),
+				RuleName: e.CallsRule.RuleName,
+			}
+		}
+
 		response.Actions = append(response.Actions, info)
 	}
 
@@ -167,6 +167,11 @@ func (a *api) create(ctx context.Context, r *http.Request) web.Encoder {
 		a.log.Info(ctx, "action created", "action_id", action.ID, "rule_id", rule.ID)
 	}
 
+	// The trigger runs the published revision, so every edit is published.
+	if _, err := a.workflowBus.PublishLiveRevision(ctx, rule.ID, userID, "rule created"); err != nil {
+		return errs.Newf(errs.Internal, "publish revision: %s", err)
+	}
+
 	// Fetch the view for response (includes joined fields)
 	filter := workflow.AutomationRuleFilter{ID: &rule.ID}
 	views, err := a.workflowBus.QueryAutomationRulesViewPaginated(ctx, filter, workflow.DefaultOrderBy, page.MustParse("1", "1"))
@@ -231,6 +236,10 @@ func (a *api) update(ctx context.Context, r *http.Request) web.Encoder {
 
 	a.log.Info(ctx, "rule updated", "rule_id", updatedRule.ID, "updated_by", userID)
 
+	if _, err := a.workflowBus.PublishLiveRevision(ctx, id, userID, "rule updated"); err != nil {
+		return errs.Newf(errs.Internal, "publish revision: %s", err)
+	}
+
 	// Fetch updated view for response
 	filter := workflow.AutomationRuleFilter{ID: &id}
 	views, err := a.workflowBus.QueryAutomationRulesViewPaginated(ctx, filter, workflow.DefaultOrderBy, page.MustParse("1", "1"))
@@ -425,6 +434,10 @@ func (a *api) createAction(ctx context.Context, r *http.Request) web.Encoder {
 	// Audit logging
 	a.log.Info(ctx, "action created", "action_id", action.ID, "rule_id", ruleID, "created_by", userID)
 
+	if _, err := a.workflowBus.PublishLiveRevision(ctx, ruleID, userID, "action created"); err != nil {
+		return errs.Newf(errs.Internal, "publish revision: %s", err)
+	}
+
 	// Fetch view for response (includes template name if applicable)
 	actionView, err := a.workflowBus.QueryActionViewByID(ctx, action.ID)
 	if err != nil {
@@ -490,6 +503,10 @@ func (a *api) updateAction(ctx context.Context, r *http.Request) web.Encoder {
 	// Audit logging
 	a.log.Info(ctx, "action updated", "action_id", actionID, "rule_id", ruleID, "updated_by", userID)
 
+	if _, err := a.workflowBus.PublishLiveRevision(ctx, ruleID, userID, "action updated"); err != nil {
+		return errs.Newf(errs.Internal, "publish revision: %s", err)
+	}
+
 	// Fetch view for response
 	actionView, err := a.workflowBus.QueryActionViewByID(ctx, updatedAction.ID)
 	if err != nil {
@@ -539,6 +556,10 @@ func (a *api) deleteAction(ctx context.Context, r *http.Request) web.Encoder {
 	// Audit logging
 	a.log.Info(ctx, "action deactivated", "action_id", actionID, "rule_id", ruleID, "deactivated_by", userID)
 
+	if _, err := a.workflowBus.PublishLiveRevision(ctx, ruleID, userID, "action deactivated"); err != nil {
+		return errs.Newf(errs.Internal, "publish revision: %s", err)
+	}
+
 	return nil
 }
 
@@ -49,4 +49,26 @@ func Routes(app *web.App, cfg Config) {
 	app.HandlerFunc(http.MethodPost, version, "/workflow/rules/{id}/duplicate", api.duplicate, authen,
 		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Create, auth.RuleAdminOnly))
 
+	// Revision history, diff and draft/publish/rollback
+	app.HandlerFunc(http.MethodGet, version, "/workflow/rules/{id}/revisions", api.queryRevisions, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAdminOnly))
+
+	app.HandlerFunc(http.MethodGet, version, "/workflow/rules/{id}/revisions/diff", api.diffRevisions, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAdminOnly))
+
+	app.HandlerFunc(http.MethodGet, version, "/workflow/rules/{id}/revisions/{revision_id}", api.queryRevisionByID, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAdminOnly))
+
+	app.HandlerFunc(http.MethodPost, version, "/workflow/rules/{id}/revisions", api.saveDraft, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Update, auth.RuleAdminOnly))
+
+	app.HandlerFunc(http.MethodPost, version, "/workflow/rules/{id}/revisions/{revision_id}/publish", api.publishRevision, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Update, auth.RuleAdminOnly))
+
+	app.HandlerFunc(http.MethodPost, version, "/workflow/rules/{id}/revisions/{revision_id}/rollback", api.rollbackRevision, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Update, auth.RuleAdminOnly))
+
+	// Simulation - replays past events without side effects, so read permission
+	app.HandlerFunc(http.MethodPost, version, "/workflow/rules/{id}/simulate", api.simulate, authen,
+		mid.Authorize(cfg.AuthClient, cfg.PermissionsBus, RouteTable, permissionsbus.Actions.Read, auth.RuleAdminOnly))
 }
@@ -23,7 +23,8 @@ func newAPI(app *workflowsaveapp.App) *api {
 }
 
 // save handles PUT /v1/workflow/rules/{id}/full
-// Updates an existing workflow atomically (rule + actions + edges).
+// Updates an existing workflow atomically (rule + actions + edges) and
+// publishes the result as a new revision.
 // Supports ?dry_run=true to validate without committing.
 func (a *api) save(ctx context.Context, r *http.Request) web.Encoder {
 	ruleID, err := uuid.Parse(web.Param(r, "id"))
@@ -40,7 +41,12 @@ func (a *api) save(ctx context.Context, r *http.Request) web.Encoder {
 		return a.app.DryRunValidate(ctx, ruleID, req)
 	}
 
-	resp, err := a.app.SaveWorkflow(ctx, ruleID, req)
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return errs.New(errs.Unauthenticated, err)
+	}
+
+	resp, err := a.app.SaveWorkflow(ctx, ruleID, userID, req)
 	if err != nil {
 		return errs.NewError(err)
 	}
@@ -94,3 +100,167 @@ func (a *api) create(ctx context.Context, r *http.Request) web.Encoder {
 
 	return resp
 }
+
+// saveDraft handles POST /v1/workflow/rules/{id}/revisions
+// Records a workflow as a draft revision without changing the live rule.
+func (a *api) saveDraft(ctx context.Context, r *http.Request) web.Encoder {
+	ruleID, err := uuid.Parse(web.Param(r, "id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	var req workflowsaveapp.SaveWorkflowRequest
+	if err := web.Decode(r, &req); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return errs.New(errs.Unauthenticated, err)
+	}
+
+	resp, err := a.app.SaveDraft(ctx, ruleID, userID, req)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return resp
+}
+
+// queryRevisions handles GET /v1/workflow/rules/{id}/revisions
+// Lists a workflow's revisions, newest first.
+func (a *api) queryRevisions(ctx context.Context, r *http.Request) web.Encoder {
+	ruleID, err := uuid.Parse(web.Param(r, "id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	resp, err := a.app.QueryRevisions(ctx, ruleID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return resp
+}
+
+// queryRevisionByID handles GET /v1/workflow/rules/{id}/revisions/{revision_id}
+func (a *api) queryRevisionByID(ctx context.Context, r *http.Request) web.Encoder {
+	ruleID, revisionID, err := revisionParams(r)
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	resp, err := a.app.QueryRevisionByID(ctx, ruleID, revisionID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return resp
+}
+
+// diffRevisions handles GET /v1/workflow/rules/{id}/revisions/diff?from={revision_id}&to={revision_id}
+// Compares two revisions; without to, it compares against the published revision.
+func (a *api) diffRevisions(ctx context.Context, r *http.Request) web.Encoder {
+	ruleID, err := uuid.Parse(web.Param(r, "id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	fromID, err := uuid.Parse(r.URL.Query().Get("from"))
+	if err != nil {
+		return errs.Newf(errs.InvalidArgument, "from: %s", err)
+	}
+
+	var toID uuid.UUID
+	if to := r.URL.Query().Get("to"); to != "" {
+		toID, err = uuid.Parse(to)
+		if err != nil {
+			return errs.Newf(errs.InvalidArgument, "to: %s", err)
+		}
+	}
+
+	resp, err := a.app.DiffRevisions(ctx, ruleID, fromID, toID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return resp
+}
+
+// publishRevision handles POST /v1/workflow/rules/{id}/revisions/{revision_id}/publish
+// Makes the live workflow match a draft revision.
+func (a *api) publishRevision(ctx context.Context, r *http.Request) web.Encoder {
+	ruleID, revisionID, err := revisionParams(r)
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return errs.New(errs.Unauthenticated, err)
+	}
+
+	resp, err := a.app.PublishRevision(ctx, ruleID, revisionID, userID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return resp
+}
+
+// rollbackRevision handles POST /v1/workflow/rules/{id}/revisions/{revision_id}/rollback
+// Republishes an older revision of the workflow as a new revision.
+func (a *api) rollbackRevision(ctx context.Context, r *http.Request) web.Encoder {
+	ruleID, revisionID, err := revisionParams(r)
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return errs.New(errs.Unauthenticated, err)
+	}
+
+	resp, err := a.app.RollbackRevision(ctx, ruleID, revisionID, userID)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return resp
+}
+
+// simulate handles POST /v1/workflow/rules/{id}/simulate
+// Replays past events against a revision, an unsaved workflow or the live rule
+// and reports what it would have done, without executing any action.
+func (a *api) simulate(ctx context.Context, r *http.Request) web.Encoder {
+	ruleID, err := uuid.Parse(web.Param(r, "id"))
+	if err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	var req workflowsaveapp.SimulateRequest
+	if err := web.Decode(r, &req); err != nil {
+		return errs.New(errs.InvalidArgument, err)
+	}
+
+	resp, err := a.app.Simulate(ctx, ruleID, req)
+	if err != nil {
+		return errs.NewError(err)
+	}
+
+	return resp
+}
+
+func revisionParams(r *http.Request) (uuid.UUID, uuid.UUID, error) {
+	ruleID, err := uuid.Parse(web.Param(r, "id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	revisionID, err := uuid.Parse(web.Param(r, "revision_id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return ruleID, revisionID, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/timmaaaz/ichor/business/sdk/workflow"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/stores/workflowdb"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/temporal"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/temporal/stores/edgedb"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/approval"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/communication"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/control"
@@ -154,6 +155,7 @@ func startTestWithTemporal(
 	w := worker.New(tc, temporal.TaskQueue, worker.Options{})
 	w.RegisterWorkflow(temporal.ExecuteGraphWorkflow)
 	w.RegisterWorkflow(temporal.ExecuteBranchUntilConvergence)
+	w.RegisterWorkflow(temporal.ExecuteCalledWorkflow)
 	registry := workflow.NewActionRegistry()
 	asyncRegistry := temporal.NewAsyncRegistry()
 	execStore := registerActions(db, registry, asyncRegistry)
@@ -161,6 +163,7 @@ func startTestWithTemporal(
 		Registry:       registry,
 		AsyncRegistry:  asyncRegistry,
 		ExecutionStore: execStore, // nil for the core path -> MarkExecution* no-op
+		GraphStore:     edgedb.NewStore(db.Log, db.DB),
 	}
 	w.RegisterActivity(activities)
 	if err := w.Start(); err != nil {
@@ -218,7 +221,7 @@ func registerGranularWorkerActions(db *dbtest.Database, reg *workflow.ActionRegi
 	// after restock.
 	reg.Register(inventory.NewCheckInventoryHandler(db.Log, db.BusDomain.InventoryItem))
 	reg.Register(inventory.NewCheckReorderPointHandler(db.Log, db.BusDomain.InventoryItem))
-	reg.Register(inventory.NewReserveInventoryHandler(db.Log, db.DB, db.BusDomain.InventoryItem, db.BusDomain.Workflow))
+	reg.Register(inventory.NewReserveInventoryHandler(db.Log, db.DB, db.BusDomain.InventoryItem, db.BusDomain.Workflow, db.BusDomain.InventoryReservation))
 
 	// create_alert with the real alert bus so the over_order alert row persists
 	// (scoped/queryable by SourceRuleID). Orders + Product buses let the over-order
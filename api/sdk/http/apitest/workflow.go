@@ -76,12 +76,14 @@ func InitWorkflowInfra(t *testing.T, db *dbtest.Database) *WorkflowInfra {
 	w := worker.New(tc, taskQueue, worker.Options{})
 	w.RegisterWorkflow(temporal.ExecuteGraphWorkflow)
 	w.RegisterWorkflow(temporal.ExecuteBranchUntilConvergence)
+	w.RegisterWorkflow(temporal.ExecuteCalledWorkflow)
 	asyncRegistry := temporal.NewAsyncRegistry()
 	asyncRegistry.Register("seek_approval", approval.NewSeekApprovalHandler(db.Log, db.DB, approvalBus, alertBus, nil))
 
 	activities := &temporal.Activities{
 		Registry:      registry,
 		AsyncRegistry: asyncRegistry,
+		GraphStore:    edgedb.NewStore(db.Log, db.DB),
 	}
 	w.RegisterActivity(activities)
 
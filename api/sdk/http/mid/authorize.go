@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/timmaaaz/ichor/app/sdk/authclient"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
 	"github.com/timmaaaz/ichor/app/sdk/mid"
 	"github.com/timmaaaz/ichor/business/domain/core/permissionsbus"
 	"github.com/timmaaaz/ichor/business/domain/hr/homebus"
@@ -22,8 +23,21 @@ func Authorize(client *authclient.Client, permissionsBus *permissionsbus.Busines
 			Action: action,
 		}
 
+		// Column restrictions are only known once the caller is authorized,
+		// so field restriction runs inside the table authorization.
+		var fields []string
+		if action == permissionsbus.Actions.Create || action == permissionsbus.Actions.Update {
+			var err error
+			if fields, err = writtenFields(r); err != nil {
+				return errs.New(errs.InvalidArgument, err)
+			}
+		}
+		restricted := func(ctx context.Context) mid.Encoder {
+			return mid.RestrictFields(ctx, fields, next)
+		}
+
 		// Call the standard Authorize middleware with the enhanced context
-		return mid.AuthorizeTable(ctx, client, permissionsBus, tableInfo, rule, next)
+		return mid.AuthorizeTable(ctx, client, permissionsBus, tableInfo, rule, restricted)
 	}
 
 	return addMidFunc(midFunc)
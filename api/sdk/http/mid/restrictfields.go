@@ -1,18 +1,73 @@
 package mid
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"slices"
 
+	"github.com/timmaaaz/ichor/app/sdk/errs"
 	"github.com/timmaaaz/ichor/app/sdk/mid"
 	"github.com/timmaaaz/ichor/foundation/web"
 )
 
-// RestrictFields restricts the fields in the response.
+// RestrictFields enforces the column restrictions of the authorized table:
+// writes to hidden or read-only fields are denied and hidden fields are
+// removed from the response. Authorize already applies it, so routes only
+// need it when they authorize some other way.
 func RestrictFields() web.MidFunc {
 	midFunc := func(ctx context.Context, r *http.Request, next mid.HandlerFunc) mid.Encoder {
-		return mid.RestrictFields(ctx, next)
+		fields, err := writtenFields(r)
+		if err != nil {
+			return errs.New(errs.InvalidArgument, err)
+		}
+
+		return mid.RestrictFields(ctx, fields, next)
 	}
 
 	return addMidFunc(midFunc)
 }
+
+// writtenFields returns the top-level keys of a JSON request body, restoring
+// the body for the handler. Requests without a JSON object body write nothing.
+// The body is read up to web.MaxBodyBytes, the most a handler would decode.
+func writtenFields(r *http.Request) ([]string, error) {
+	if r.Body == nil || r.Method == http.MethodGet || r.Method == http.MethodDelete {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, web.MaxBodyBytes))
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, fmt.Errorf("request: payload exceeds %d byte limit", tooLarge.Limit)
+		}
+		return nil, fmt.Errorf("request: unable to read payload: %w", err)
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, nil
+	}
+
+	fields := make([]string, 0, len(obj))
+	for k := range obj {
+		fields = append(fields, k)
+	}
+	slices.Sort(fields)
+
+	return fields, nil
+}
+
+// RegisterFieldNames records the JSON field names a table's API serves
+// columns under where they differ from the column names, so column
+// restrictions apply to the right fields.
+func RegisterFieldNames(table string, fields map[string]string) {
+	mid.RegisterFieldNames(table, fields)
+}
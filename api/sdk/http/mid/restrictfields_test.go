@@ -0,0 +1,39 @@
+package mid
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/timmaaaz/ichor/foundation/web"
+)
+
+func TestWrittenFields(t *testing.T) {
+	body := `{"selling_price":"12.75","currency_id":"c"}`
+	r := httptest.NewRequest(http.MethodPut, "/v1/products/product-costs/1", strings.NewReader(body))
+
+	fields, err := writtenFields(r)
+	if err != nil {
+		t.Fatalf("written fields: %v", err)
+	}
+	if want := []string{"currency_id", "selling_price"}; !slices.Equal(fields, want) {
+		t.Errorf("fields = %v, want %v", fields, want)
+	}
+
+	restored, err := io.ReadAll(r.Body)
+	if err != nil || string(restored) != body {
+		t.Errorf("body = %q (err %v), want it restored for the handler", restored, err)
+	}
+}
+
+func TestWrittenFields_RejectsOversizedBody(t *testing.T) {
+	body := strings.NewReader(`{"note":"` + strings.Repeat("x", web.MaxBodyBytes) + `"}`)
+	r := httptest.NewRequest(http.MethodPost, "/v1/products/product-costs", body)
+
+	if _, err := writtenFields(r); err == nil {
+		t.Fatal("expected an error for a body over the limit")
+	}
+}
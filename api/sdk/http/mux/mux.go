@@ -6,6 +6,7 @@ import (
 	"context"
 	"embed"
 	"net/http"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/timmaaaz/ichor/api/sdk/http/mid"
@@ -51,6 +52,10 @@ type Config struct {
 	RabbitClient   *rabbitmq.Client
 	TemporalClient client.Client // nil means Temporal disabled
 
+	// OutboxRetention is how long the cascade relay keeps published outbox rows
+	// as replayable event history for workflow simulation. Zero deletes on publish.
+	OutboxRetention time.Duration
+
 	// LLM provider configuration for agent chat.
 	LLMProvider       string
 	LLMAPIKey         string
@@ -77,6 +82,15 @@ type Config struct {
 	// without touching real hardware. Production callers leave it nil.
 	LabelPrinter LabelPrinter
 
+	// Shipping-label carrier configuration. CarrierProvider "http" forwards
+	// rating and label purchase to CarrierBaseURL; anything else (including
+	// empty) uses the offline flat-rate carrier.
+	CarrierProvider string
+	CarrierName     string
+	CarrierBaseURL  string
+	CarrierAPIKey   string
+	CarrierTimeout  time.Duration
+
 	// ScenariosEnabled gates the ActiveScenario middleware (Phase 0d).
 	// True in dev/KIND so floor testing scenarios can drive fixture filtering;
 	// false in production to avoid a per-request scenarios_active lookup.
@@ -85,6 +99,11 @@ type Config struct {
 	// CORSAllowedOrigins for WebSocket and SSE upgrade routes.
 	// Defaults to "*" if empty (open — set from ICHOR_WEB_CORS_ALLOWED_ORIGINS).
 	CORSAllowedOrigins []string
+
+	// SystemUserID attributes background inventory writes that carry no user,
+	// such as the reservation reaper's ledger rows. Empty leaves reservations
+	// without a creator held rather than released unaudited.
+	SystemUserID string
 }
 
 // LabelPrinter is the narrow contract for dispatching ZPL bytes. Defined
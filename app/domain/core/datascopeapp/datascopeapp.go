@@ -0,0 +1,124 @@
+// Package datascopeapp maintains the app layer api for the datascope domain.
+package datascopeapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/app/sdk/query"
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// App manages the set of app layer api functions for the datascope domain.
+type App struct {
+	datascopebus *datascopebus.Business
+}
+
+// NewApp constructs a datascope app API for use.
+func NewApp(datascopebus *datascopebus.Business) *App {
+	return &App{
+		datascopebus: datascopebus,
+	}
+}
+
+// Create adds a new data scope to the system.
+func (a *App) Create(ctx context.Context, app NewDataScope) (DataScope, error) {
+	nds, err := toBusNewDataScope(app)
+	if err != nil {
+		return DataScope{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	ds, err := a.datascopebus.Create(ctx, nds)
+	if err != nil {
+		switch {
+		case errors.Is(err, datascopebus.ErrInvalidOwner):
+			return DataScope{}, errs.New(errs.InvalidArgument, err)
+		case errors.Is(err, datascopebus.ErrUnique):
+			return DataScope{}, errs.New(errs.AlreadyExists, err)
+		}
+		return DataScope{}, errs.Newf(errs.Internal, "create: datascope[%+v]: %s", nds, err)
+	}
+
+	return ToAppDataScope(ds), nil
+}
+
+// Update replaces the warehouses or zones of an existing data scope.
+func (a *App) Update(ctx context.Context, app UpdateDataScope, id uuid.UUID) (DataScope, error) {
+	uds, err := toBusUpdateDataScope(app)
+	if err != nil {
+		return DataScope{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	ds, err := a.datascopebus.QueryByID(ctx, id)
+	if err != nil {
+		return DataScope{}, errs.New(errs.NotFound, datascopebus.ErrNotFound)
+	}
+
+	updated, err := a.datascopebus.Update(ctx, ds, uds)
+	if err != nil {
+		return DataScope{}, errs.Newf(errs.Internal, "update: datascope[%s]: %s", id, err)
+	}
+
+	return ToAppDataScope(updated), nil
+}
+
+// Delete removes a data scope from the system.
+func (a *App) Delete(ctx context.Context, id uuid.UUID) error {
+	ds, err := a.datascopebus.QueryByID(ctx, id)
+	if err != nil {
+		return errs.New(errs.NotFound, datascopebus.ErrNotFound)
+	}
+
+	if err := a.datascopebus.Delete(ctx, ds); err != nil {
+		return errs.Newf(errs.Internal, "delete: datascope[%s]: %s", id, err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of data scopes from the system.
+func (a *App) Query(ctx context.Context, qp QueryParams) (query.Result[DataScope], error) {
+	page, err := page.Parse(qp.Page, qp.Rows)
+	if err != nil {
+		return query.Result[DataScope]{}, errs.NewFieldsError("page", err)
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return query.Result[DataScope]{}, err
+	}
+
+	orderBy, err := order.Parse(orderByFields, qp.OrderBy, defaultOrderBy)
+	if err != nil {
+		return query.Result[DataScope]{}, errs.NewFieldsError("orderby", err)
+	}
+
+	scopes, err := a.datascopebus.Query(ctx, filter, orderBy, page)
+	if err != nil {
+		return query.Result[DataScope]{}, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	total, err := a.datascopebus.Count(ctx, filter)
+	if err != nil {
+		return query.Result[DataScope]{}, errs.Newf(errs.Internal, "count: %s", err)
+	}
+
+	return query.NewResult(ToAppDataScopes(scopes), total, page), nil
+}
+
+// QueryByID retrieves a single data scope by its ID.
+func (a *App) QueryByID(ctx context.Context, id uuid.UUID) (DataScope, error) {
+	ds, err := a.datascopebus.QueryByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, datascopebus.ErrNotFound) {
+			return DataScope{}, errs.New(errs.NotFound, err)
+		}
+		return DataScope{}, errs.Newf(errs.Internal, "querybyid: %s", err)
+	}
+
+	return ToAppDataScope(ds), nil
+}
@@ -0,0 +1,37 @@
+package datascopeapp
+
+import (
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus"
+)
+
+func parseFilter(qp QueryParams) (datascopebus.QueryFilter, error) {
+	var filter datascopebus.QueryFilter
+
+	if qp.ID != "" {
+		id, err := uuid.Parse(qp.ID)
+		if err != nil {
+			return datascopebus.QueryFilter{}, errs.NewFieldsError("data_scope_id", err)
+		}
+		filter.ID = &id
+	}
+
+	if qp.RoleID != "" {
+		id, err := uuid.Parse(qp.RoleID)
+		if err != nil {
+			return datascopebus.QueryFilter{}, errs.NewFieldsError("role_id", err)
+		}
+		filter.RoleID = &id
+	}
+
+	if qp.UserID != "" {
+		id, err := uuid.Parse(qp.UserID)
+		if err != nil {
+			return datascopebus.QueryFilter{}, errs.NewFieldsError("user_id", err)
+		}
+		filter.UserID = &id
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,172 @@
+package datascopeapp
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus"
+)
+
+type QueryParams struct {
+	Page    string
+	Rows    string
+	OrderBy string
+	ID      string
+	RoleID  string
+	UserID  string
+}
+
+type DataScope struct {
+	ID           string   `json:"id"`
+	RoleID       string   `json:"role_id,omitempty"`
+	UserID       string   `json:"user_id,omitempty"`
+	WarehouseIDs []string `json:"warehouse_ids"`
+	ZoneIDs      []string `json:"zone_ids"`
+	CreatedDate  string   `json:"created_date"`
+	UpdatedDate  string   `json:"updated_date"`
+}
+
+func (app DataScope) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func ToAppDataScope(bus datascopebus.DataScope) DataScope {
+	ds := DataScope{
+		ID:           bus.ID.String(),
+		WarehouseIDs: toAppIDs(bus.WarehouseIDs),
+		ZoneIDs:      toAppIDs(bus.ZoneIDs),
+		CreatedDate:  bus.CreatedDate.Format(time.RFC3339),
+		UpdatedDate:  bus.UpdatedDate.Format(time.RFC3339),
+	}
+	if bus.RoleID != nil {
+		ds.RoleID = bus.RoleID.String()
+	}
+	if bus.UserID != nil {
+		ds.UserID = bus.UserID.String()
+	}
+	return ds
+}
+
+func ToAppDataScopes(bus []datascopebus.DataScope) []DataScope {
+	app := make([]DataScope, len(bus))
+	for i, v := range bus {
+		app[i] = ToAppDataScope(v)
+	}
+	return app
+}
+
+func toAppIDs(ids []uuid.UUID) []string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.String()
+	}
+	return strs
+}
+
+func toBusIDs(field string, strs []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, len(strs))
+	for i, s := range strs {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, errs.NewFieldsError(field, err)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// =============================================================================
+
+// NewDataScope scopes either a role or a single user, never both. An empty
+// warehouse_ids or zone_ids leaves that dimension unrestricted.
+type NewDataScope struct {
+	RoleID       string   `json:"role_id" validate:"omitempty,uuid"`
+	UserID       string   `json:"user_id" validate:"omitempty,uuid"`
+	WarehouseIDs []string `json:"warehouse_ids" validate:"dive,uuid"`
+	ZoneIDs      []string `json:"zone_ids" validate:"dive,uuid"`
+}
+
+func (app *NewDataScope) Decode(data []byte) error {
+	return json.Unmarshal(data, &app)
+}
+
+func (app NewDataScope) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusNewDataScope(app NewDataScope) (datascopebus.NewDataScope, error) {
+	var nds datascopebus.NewDataScope
+
+	if app.RoleID != "" {
+		id, err := uuid.Parse(app.RoleID)
+		if err != nil {
+			return datascopebus.NewDataScope{}, errs.NewFieldsError("role_id", err)
+		}
+		nds.RoleID = &id
+	}
+
+	if app.UserID != "" {
+		id, err := uuid.Parse(app.UserID)
+		if err != nil {
+			return datascopebus.NewDataScope{}, errs.NewFieldsError("user_id", err)
+		}
+		nds.UserID = &id
+	}
+
+	var err error
+	if nds.WarehouseIDs, err = toBusIDs("warehouse_ids", app.WarehouseIDs); err != nil {
+		return datascopebus.NewDataScope{}, err
+	}
+	if nds.ZoneIDs, err = toBusIDs("zone_ids", app.ZoneIDs); err != nil {
+		return datascopebus.NewDataScope{}, err
+	}
+
+	return nds, nil
+}
+
+// =============================================================================
+
+type UpdateDataScope struct {
+	WarehouseIDs *[]string `json:"warehouse_ids" validate:"omitempty,dive,uuid"`
+	ZoneIDs      *[]string `json:"zone_ids" validate:"omitempty,dive,uuid"`
+}
+
+func (app *UpdateDataScope) Decode(data []byte) error {
+	return json.Unmarshal(data, &app)
+}
+
+func (app UpdateDataScope) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusUpdateDataScope(app UpdateDataScope) (datascopebus.UpdateDataScope, error) {
+	var uds datascopebus.UpdateDataScope
+
+	// Lists replace the existing ones when provided.
+	if app.WarehouseIDs != nil {
+		ids, err := toBusIDs("warehouse_ids", *app.WarehouseIDs)
+		if err != nil {
+			return datascopebus.UpdateDataScope{}, err
+		}
+		uds.WarehouseIDs = &ids
+	}
+
+	if app.ZoneIDs != nil {
+		ids, err := toBusIDs("zone_ids", *app.ZoneIDs)
+		if err != nil {
+			return datascopebus.UpdateDataScope{}, err
+		}
+		uds.ZoneIDs = &ids
+	}
+
+	return uds, nil
+}
@@ -0,0 +1,15 @@
+package datascopeapp
+
+import (
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+)
+
+var defaultOrderBy = order.NewBy(datascopebus.OrderByCreatedDate, order.ASC)
+
+var orderByFields = map[string]string{
+	"id":           datascopebus.OrderByID,
+	"role_id":      datascopebus.OrderByRoleID,
+	"user_id":      datascopebus.OrderByUserID,
+	"created_date": datascopebus.OrderByCreatedDate,
+}
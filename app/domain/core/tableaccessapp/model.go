@@ -22,13 +22,15 @@ type QueryParams struct {
 }
 
 type TableAccess struct {
-	ID        string `json:"id"`
-	RoleID    string `json:"role_id"`
-	TableName string `json:"table_name"`
-	CanCreate bool   `json:"can_create"`
-	CanRead   bool   `json:"can_read"`
-	CanUpdate bool   `json:"can_update"`
-	CanDelete bool   `json:"can_delete"`
+	ID              string   `json:"id"`
+	RoleID          string   `json:"role_id"`
+	TableName       string   `json:"table_name"`
+	CanCreate       bool     `json:"can_create"`
+	CanRead         bool     `json:"can_read"`
+	CanUpdate       bool     `json:"can_update"`
+	CanDelete       bool     `json:"can_delete"`
+	HiddenColumns   []string `json:"hidden_columns,omitempty"`
+	ReadOnlyColumns []string `json:"read_only_columns,omitempty"`
 }
 
 func (app TableAccess) Encode() ([]byte, string, error) {
@@ -52,6 +54,9 @@ func ToAppTableAccess(bus tableaccessbus.TableAccess) TableAccess {
 		CanRead:   bus.CanRead,
 		CanUpdate: bus.CanUpdate,
 		CanDelete: bus.CanDelete,
+
+		HiddenColumns:   bus.HiddenColumns,
+		ReadOnlyColumns: bus.ReadOnlyColumns,
 	}
 }
 
@@ -66,12 +71,14 @@ func ToAppTableAccesses(bus []tableaccessbus.TableAccess) []TableAccess {
 // =============================================================================
 
 type NewTableAccess struct {
-	RoleID    string `json:"role_id" validate:"required"`
-	TableName string `json:"table_name" validate:"required"`
-	CanCreate bool   `json:"can_create"`
-	CanRead   bool   `json:"can_read"`
-	CanUpdate bool   `json:"can_update"`
-	CanDelete bool   `json:"can_delete"`
+	RoleID          string   `json:"role_id" validate:"required"`
+	TableName       string   `json:"table_name" validate:"required"`
+	CanCreate       bool     `json:"can_create"`
+	CanRead         bool     `json:"can_read"`
+	CanUpdate       bool     `json:"can_update"`
+	CanDelete       bool     `json:"can_delete"`
+	HiddenColumns   []string `json:"hidden_columns"`
+	ReadOnlyColumns []string `json:"read_only_columns"`
 }
 
 func (app *NewTableAccess) Decode(data []byte) error {
@@ -94,6 +101,9 @@ func toBusNewTableAccess(app NewTableAccess) (tableaccessbus.NewTableAccess, err
 		CanRead:   app.CanRead,
 		CanUpdate: app.CanUpdate,
 		CanDelete: app.CanDelete,
+
+		HiddenColumns:   app.HiddenColumns,
+		ReadOnlyColumns: app.ReadOnlyColumns,
 	}, nil
 
 }
@@ -101,12 +111,14 @@ func toBusNewTableAccess(app NewTableAccess) (tableaccessbus.NewTableAccess, err
 // =============================================================================
 
 type UpdateTableAccess struct {
-	RoleID    *string `json:"role_id" validate:"omitempty,uuid"`
-	TableName *string `json:"table_name"`
-	CanCreate *bool   `json:"can_create"`
-	CanRead   *bool   `json:"can_read"`
-	CanUpdate *bool   `json:"can_update"`
-	CanDelete *bool   `json:"can_delete"`
+	RoleID          *string   `json:"role_id" validate:"omitempty,uuid"`
+	TableName       *string   `json:"table_name"`
+	CanCreate       *bool     `json:"can_create"`
+	CanRead         *bool     `json:"can_read"`
+	CanUpdate       *bool     `json:"can_update"`
+	CanDelete       *bool     `json:"can_delete"`
+	HiddenColumns   *[]string `json:"hidden_columns"`
+	ReadOnlyColumns *[]string `json:"read_only_columns"`
 }
 
 func (app *UpdateTableAccess) Decode(data []byte) error {
@@ -153,5 +165,9 @@ func toBusUpdateTableAccess(app UpdateTableAccess) (tableaccessbus.UpdateTableAc
 		update.CanDelete = app.CanDelete
 	}
 
+	// Column restrictions replace the existing lists when provided
+	update.HiddenColumns = app.HiddenColumns
+	update.ReadOnlyColumns = app.ReadOnlyColumns
+
 	return update, nil
 }
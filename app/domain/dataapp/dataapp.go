@@ -300,7 +300,7 @@ func (a *App) ExecuteQuery(ctx context.Context, id uuid.UUID, app TableQuery) (T
 	// Execute the query
 	data, err := a.tableStore.FetchTableData(ctx, config, params)
 	if err != nil {
-		return TableData{}, errs.Newf(errs.Internal, "execute query: %s", err)
+		return TableData{}, fetchError("execute query", err)
 	}
 
 	return toAppTableData(data), nil
@@ -323,7 +323,7 @@ func (a *App) ExecuteQueryByName(ctx context.Context, name string, app TableQuer
 	// Execute the query
 	data, err := a.tableStore.FetchTableData(ctx, config, params)
 	if err != nil {
-		return TableData{}, errs.Newf(errs.Internal, "execute query: %s", err)
+		return TableData{}, fetchError("execute query", err)
 	}
 
 	return toAppTableData(data), nil
@@ -345,7 +345,7 @@ func (a *App) ExecuteQueryCountByID(ctx context.Context, id uuid.UUID, app Table
 	// Execute the query
 	count, err := a.tableStore.FetchTableDataCount(ctx, config, params)
 	if err != nil {
-		return Count{}, errs.Newf(errs.Internal, "execute query count: %s", err)
+		return Count{}, fetchError("execute query count", err)
 	}
 
 	return Count{Count: count}, nil
@@ -372,7 +372,7 @@ func (a *App) ExecuteQueryCountByName(ctx context.Context, name string, app Tabl
 	// Execute the query
 	count, err := a.tableStore.FetchTableDataCount(ctx, config, params)
 	if err != nil {
-		return Count{}, errs.Newf(errs.Internal, "execute query count: %s", err)
+		return Count{}, fetchError("execute query count", err)
 	}
 
 	return Count{Count: count}, nil
@@ -573,7 +573,7 @@ func (a *App) ExecuteChartQuery(ctx context.Context, id uuid.UUID, app TableQuer
 	// Execute the table query first
 	tableData, err := a.tableStore.FetchTableData(ctx, config, params)
 	if err != nil {
-		return ChartResponse{}, errs.Newf(errs.Internal, "execute query: %s", err)
+		return ChartResponse{}, fetchError("execute query", err)
 	}
 
 	// Transform table data to chart data
@@ -608,7 +608,7 @@ func (a *App) ExecuteChartQueryByName(ctx context.Context, name string, app Tabl
 	// Execute the table query first
 	tableData, err := a.tableStore.FetchTableData(ctx, config, params)
 	if err != nil {
-		return ChartResponse{}, errs.Newf(errs.Internal, "execute query: %s", err)
+		return ChartResponse{}, fetchError("execute query", err)
 	}
 
 	// Transform table data to chart data
@@ -641,7 +641,7 @@ func (a *App) PreviewChartData(ctx context.Context, configJSON json.RawMessage,
 	// Execute the table query
 	tableData, err := a.tableStore.FetchTableData(ctx, &config, params)
 	if err != nil {
-		return ChartResponse{}, errs.Newf(errs.Internal, "execute query: %s", err)
+		return ChartResponse{}, fetchError("execute query", err)
 	}
 
 	// Transform table data to chart data
@@ -653,3 +653,12 @@ func (a *App) PreviewChartData(ctx context.Context, configJSON json.RawMessage,
 
 	return toAppChartResponse(chartData), nil
 }
+
+// fetchError maps a table store error to an app error. Queries that touch a
+// column hidden by the caller's table access are denied rather than failing.
+func fetchError(op string, err error) error {
+	if errors.Is(err, tablebuilder.ErrHiddenColumn) {
+		return errs.Newf(errs.PermissionDenied, "%s: %s", op, err)
+	}
+	return errs.Newf(errs.Internal, "%s: %s", op, err)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/inventory/inspectionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/picktaskbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/putawaytaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/transferorderbus"
 	"github.com/timmaaaz/ichor/business/domain/sales/ordersbus"
 	"github.com/timmaaaz/ichor/business/sdk/order"
@@ -25,13 +26,14 @@ const maxWorkerTasksPerDomain = 500
 
 // App manages the application logic for the floor directed-work feature.
 type App struct {
-	log               *logger.Logger
-	pickTaskBus       *picktaskbus.Business
-	putAwayTaskBus    *putawaytaskbus.Business
-	cycleCountItemBus *cyclecountitembus.Business
-	inspectionBus     *inspectionbus.Business
-	transferOrderBus  *transferorderbus.Business
-	ordersBus         *ordersbus.Business
+	log                  *logger.Logger
+	pickTaskBus          *picktaskbus.Business
+	putAwayTaskBus       *putawaytaskbus.Business
+	cycleCountItemBus    *cyclecountitembus.Business
+	inspectionBus        *inspectionbus.Business
+	transferOrderBus     *transferorderbus.Business
+	ordersBus            *ordersbus.Business
+	replenishmentTaskBus *replenishmenttaskbus.Business
 }
 
 // NewApp constructs a directed-work app for use.
@@ -43,15 +45,17 @@ func NewApp(
 	inspectionBus *inspectionbus.Business,
 	transferOrderBus *transferorderbus.Business,
 	ordersBus *ordersbus.Business,
+	replenishmentTaskBus *replenishmenttaskbus.Business,
 ) *App {
 	return &App{
-		log:               log,
-		pickTaskBus:       pickTaskBus,
-		putAwayTaskBus:    putAwayTaskBus,
-		cycleCountItemBus: cycleCountItemBus,
-		inspectionBus:     inspectionBus,
-		transferOrderBus:  transferOrderBus,
-		ordersBus:         ordersBus,
+		log:                  log,
+		pickTaskBus:          pickTaskBus,
+		putAwayTaskBus:       putAwayTaskBus,
+		cycleCountItemBus:    cycleCountItemBus,
+		inspectionBus:        inspectionBus,
+		transferOrderBus:     transferOrderBus,
+		ordersBus:            ordersBus,
+		replenishmentTaskBus: replenishmentTaskBus,
 	}
 }
 
@@ -127,16 +131,21 @@ func (a *App) QueryNext(ctx context.Context, userID uuid.UUID) (*WorkItem, error
 	items = append(items, normalizePutaways(putaways)...)
 
 	// --- Cycle count items ---
-	// cycle_count_items has no assigned_to column. CountedBy is set only
-	// AFTER a worker finishes counting (not before). V1 surfaces all
-	// pending cycle count items regardless of worker so the dispatcher
-	// can direct any available worker to them.
+	// Most pending counts are unassigned and open to any worker; recounts
+	// are assigned to a worker other than the original counter, so counts
+	// assigned to someone else are skipped. A recount left unassigned is
+	// still skipped for the worker whose count it re-checks.
 	pendingStatus := cyclecountitembus.Statuses.Pending
 	counts, err := a.cycleCountItemBus.Query(ctx, cyclecountitembus.QueryFilter{Status: &pendingStatus}, asc, pg)
 	if err != nil {
 		return nil, errs.Newf(errs.Internal, "query cycle counts: %s", err)
 	}
-	items = append(items, normalizeCounts(counts)...)
+	recountStatus := cyclecountitembus.Statuses.Recount
+	recounted, err := a.cycleCountItemBus.Query(ctx, cyclecountitembus.QueryFilter{Status: &recountStatus, CountedBy: &userID}, asc, pg)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "query recounted counts: %s", err)
+	}
+	items = append(items, normalizeCounts(countsFor(counts, recounted, userID))...)
 
 	// --- Inspections ---
 	inspections, err := a.inspectionBus.Query(ctx, inspectionbus.QueryFilter{InspectorID: &userID}, asc, pg)
@@ -163,5 +172,21 @@ func (a *App) QueryNext(ctx context.Context, userID uuid.UUID) (*WorkItem, error
 	allTransfers := append(approvedTransfers, ownTransfers...)
 	items = append(items, normalizeTransfers(allTransfers)...)
 
+	// --- Replenishments ---
+	// Pending replenishment tasks are unassigned and visible to all workers,
+	// like approved transfers; in-progress tasks only to the worker who
+	// claimed them.
+	pendingReplenish := replenishmenttaskbus.StatusPending
+	openReplenishments, err := a.replenishmentTaskBus.Query(ctx, replenishmenttaskbus.QueryFilter{Status: &pendingReplenish}, asc, pg)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "query pending replenishments: %s", err)
+	}
+	inProgressReplenish := replenishmenttaskbus.StatusInProgress
+	ownReplenishments, err := a.replenishmentTaskBus.Query(ctx, replenishmenttaskbus.QueryFilter{AssignedTo: &userID, Status: &inProgressReplenish}, asc, pg)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "query in-progress replenishments: %s", err)
+	}
+	items = append(items, normalizeReplenishments(append(openReplenishments, ownReplenishments...))...)
+
 	return selectNext(items), nil
 }
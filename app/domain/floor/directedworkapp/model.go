@@ -1,7 +1,7 @@
 // Package directedworkapp provides the application logic for the floor
 // directed-work feature. GET /v1/floor/work/next returns the single best
 // next task for the authenticated worker, unified across picks, putaways,
-// cycle counts, inspections, and transfers.
+// cycle counts, inspections, transfers, and replenishments.
 package directedworkapp
 
 import (
@@ -12,11 +12,12 @@ import (
 type WorkItemType string
 
 const (
-	WorkItemTypePick     WorkItemType = "pick"
-	WorkItemTypePutaway  WorkItemType = "putaway"
-	WorkItemTypeCount    WorkItemType = "count"
-	WorkItemTypeInspect  WorkItemType = "inspect"
-	WorkItemTypeTransfer WorkItemType = "transfer"
+	WorkItemTypePick      WorkItemType = "pick"
+	WorkItemTypePutaway   WorkItemType = "putaway"
+	WorkItemTypeCount     WorkItemType = "count"
+	WorkItemTypeInspect   WorkItemType = "inspect"
+	WorkItemTypeTransfer  WorkItemType = "transfer"
+	WorkItemTypeReplenish WorkItemType = "replenish"
 )
 
 // WorkItemStatus is the unified (non-terminal-only) status surface for
@@ -69,4 +70,11 @@ type WorkItem struct {
 	Priority   WorkItemPriority `json:"priority"`
 	DueAt      *time.Time       `json:"due_at,omitempty"`
 	LocationID *string          `json:"location_id,omitempty"`
+
+	// BatchID, Stop and Tote are set on picks that belong to a claimed pick
+	// batch: Stop is the pick's place on the batch's route and Tote the cart
+	// tote its order is picked into.
+	BatchID *string `json:"batch_id,omitempty"`
+	Stop    *int    `json:"stop,omitempty"`
+	Tote    *int    `json:"tote,omitempty"`
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/inventory/inspectionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/picktaskbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/putawaytaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/transferorderbus"
 	"github.com/timmaaaz/ichor/business/domain/sales/ordersbus"
 )
@@ -19,10 +20,14 @@ import (
 // Policy (from spec):
 //  1. If any items are in_progress → return the most recently
 //     updated one. Done.
-//  2. Else filter to pending items → return the highest-priority one.
+//  2. Else if any pending items belong to a pick batch → return the
+//     earliest stop on the route. A batch was ranked and sequenced when
+//     its wave was planned, so its stops are served in walk order
+//     rather than re-ranked.
+//  3. Else filter to pending items → return the highest-priority one.
 //     Tiebreak: earliest DueAt (nil DueAt loses to a set DueAt).
 //     Final tiebreak: earliest UpdatedAt.
-//  3. Else return nil.
+//  4. Else return nil.
 //
 // This function is pure (no DB, no context) so unit tests drive it
 // table-style with plain slices.
@@ -45,7 +50,21 @@ func selectNext(items []WorkItem) *WorkItem {
 		return bestInProgress
 	}
 
-	// Step 2: highest-priority pending.
+	// Step 2: next stop on a claimed batch's route.
+	var nextStop *WorkItem
+	for i := range items {
+		if items[i].Status != WorkItemStatusPending || items[i].BatchID == nil || items[i].Stop == nil {
+			continue
+		}
+		if nextStop == nil || stopBeats(items[i], *nextStop) {
+			nextStop = &items[i]
+		}
+	}
+	if nextStop != nil {
+		return nextStop
+	}
+
+	// Step 3: highest-priority pending.
 	var best *WorkItem
 	for i := range items {
 		if items[i].Status != WorkItemStatusPending {
@@ -62,6 +81,15 @@ func selectNext(items []WorkItem) *WorkItem {
 	return best
 }
 
+// stopBeats reports whether candidate comes before current on a batch
+// route: lower stop first, batch ID breaking ties between batches.
+func stopBeats(candidate, current WorkItem) bool {
+	if *candidate.Stop != *current.Stop {
+		return *candidate.Stop < *current.Stop
+	}
+	return *candidate.BatchID < *current.BatchID
+}
+
 // pendingBeats reports whether candidate outranks current under the
 // pending-ordering rules: priority desc, then DueAt asc (nil loses),
 // then UpdatedAt asc.
@@ -155,7 +183,7 @@ func normalizePicks(tasks []picktaskbus.PickTask, ordersByID map[uuid.UUID]order
 			due := order.DueDate
 			dueAt = &due
 		}
-		out = append(out, WorkItem{
+		item := WorkItem{
 			ID:         t.ID.String(),
 			Type:       WorkItemTypePick,
 			Status:     status,
@@ -165,7 +193,14 @@ func normalizePicks(tasks []picktaskbus.PickTask, ordersByID map[uuid.UUID]order
 			Priority:   parsePriority(order.Priority),
 			DueAt:      dueAt,
 			LocationID: &locID,
-		})
+		}
+		if t.BatchID != nil {
+			batchID := t.BatchID.String()
+			item.BatchID = &batchID
+			item.Stop = t.BatchSequence
+			item.Tote = t.ToteNumber
+		}
+		out = append(out, item)
 	}
 	return out
 }
@@ -231,10 +266,36 @@ func mapCountStatus(s cyclecountitembus.Status) (WorkItemStatus, bool) {
 	return "", false
 }
 
+// countsFor drops count items assigned to a worker other than userID, and
+// recounts of the items in recountedByUser, which userID counted and so may
+// not recount.
+func countsFor(items []cyclecountitembus.CycleCountItem, recountedByUser []cyclecountitembus.CycleCountItem, userID uuid.UUID) []cyclecountitembus.CycleCountItem {
+	counted := make(map[uuid.UUID]struct{}, len(recountedByUser))
+	for _, it := range recountedByUser {
+		counted[it.ID] = struct{}{}
+	}
+
+	out := make([]cyclecountitembus.CycleCountItem, 0, len(items))
+	for _, it := range items {
+		if it.AssignedTo != nil && *it.AssignedTo != userID {
+			continue
+		}
+		if it.RecountOf != nil {
+			if _, ok := counted[*it.RecountOf]; ok {
+				continue
+			}
+		}
+		out = append(out, it)
+	}
+	return out
+}
+
 // normalizeCounts maps CycleCountItem → WorkItem. Cycle counts have no
 // in_progress state (pending → counted → variance_*), so all non-pending
-// items are dropped. Title uses an ID-substring fallback because no
-// batch location-name lookup exists in V1 (see spec open question #2).
+// items are dropped. Recounts are raised to high priority so an
+// out-of-tolerance count is settled before the session closes. Title uses
+// an ID-substring fallback because no batch location-name lookup exists in
+// V1 (see spec open question #2).
 func normalizeCounts(items []cyclecountitembus.CycleCountItem) []WorkItem {
 	out := make([]WorkItem, 0, len(items))
 	for _, it := range items {
@@ -247,14 +308,18 @@ func normalizeCounts(items []cyclecountitembus.CycleCountItem) []WorkItem {
 		if len(titleSuffix) > 8 {
 			titleSuffix = titleSuffix[:8]
 		}
+		title, priority := "Cycle Count ", WorkItemPriorityMedium
+		if it.RecountOf != nil {
+			title, priority = "Recount ", WorkItemPriorityHigh
+		}
 		out = append(out, WorkItem{
 			ID:         it.ID.String(),
 			Type:       WorkItemTypeCount,
 			Status:     WorkItemStatusPending,
-			Title:      "Cycle Count " + titleSuffix,
+			Title:      title + titleSuffix,
 			DetailPath: "/floor/cycle-count/" + locIDStr,
 			UpdatedAt:  it.UpdatedDate,
-			Priority:   WorkItemPriorityMedium,
+			Priority:   priority,
 			DueAt:      nil,
 			LocationID: &locIDStr,
 		})
@@ -356,3 +421,46 @@ func normalizeTransfers(items []transferorderbus.TransferOrder) []WorkItem {
 	}
 	return out
 }
+
+func mapReplenishStatus(s string) (WorkItemStatus, bool) {
+	switch s {
+	case replenishmenttaskbus.StatusPending:
+		return WorkItemStatusPending, true
+	case replenishmenttaskbus.StatusInProgress:
+		return WorkItemStatusInProgress, true
+	default:
+		return "", false
+	}
+}
+
+// normalizeReplenishments maps replenishmenttaskbus.Task → WorkItem. The
+// location is the reserve bin the stock is taken from, since that is
+// where the worker goes first. A face found short by a picker is raised
+// to high priority so the next pick there is not short as well.
+func normalizeReplenishments(tasks []replenishmenttaskbus.Task) []WorkItem {
+	out := make([]WorkItem, 0, len(tasks))
+	for _, t := range tasks {
+		status, ok := mapReplenishStatus(t.Status)
+		if !ok {
+			continue
+		}
+		idStr := t.ID.String()
+		fromLoc := t.FromLocationID.String()
+		priority := WorkItemPriorityMedium
+		if t.Reason == replenishmenttaskbus.ReasonShortPick {
+			priority = WorkItemPriorityHigh
+		}
+		out = append(out, WorkItem{
+			ID:         idStr,
+			Type:       WorkItemTypeReplenish,
+			Status:     status,
+			Title:      "Replenish " + idStr[:8],
+			DetailPath: "/floor/replenish/" + idStr,
+			UpdatedAt:  t.UpdatedDate,
+			Priority:   priority,
+			DueAt:      nil,
+			LocationID: &fromLoc,
+		})
+	}
+	return out
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/inventory/inspectionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/picktaskbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/putawaytaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/transferorderbus"
 	"github.com/timmaaaz/ichor/business/domain/sales/ordersbus"
 )
@@ -102,6 +103,35 @@ func TestSelectNext_DueAtTieBrokenByEarliestUpdatedAt(t *testing.T) {
 	}
 }
 
+func TestSelectNext_BatchStopsServedInRouteOrder(t *testing.T) {
+	now := time.Now()
+	batch := "batch-1"
+	stop := func(n int) *int { return &n }
+	items := []WorkItem{
+		{ID: "stop-3", Status: WorkItemStatusPending, Priority: WorkItemPriorityCritical, UpdatedAt: now, BatchID: &batch, Stop: stop(3)},
+		{ID: "stop-2", Status: WorkItemStatusPending, Priority: WorkItemPriorityLow, UpdatedAt: now, BatchID: &batch, Stop: stop(2)},
+		{ID: "unbatched-critical", Status: WorkItemStatusPending, Priority: WorkItemPriorityCritical, DueAt: ptrTime(now), UpdatedAt: now},
+	}
+	got := selectNext(items)
+	if got == nil || got.ID != "stop-2" {
+		t.Fatalf("expected the earliest remaining stop, got %+v", got)
+	}
+}
+
+func TestSelectNext_InProgressBeatsBatchStop(t *testing.T) {
+	now := time.Now()
+	batch := "batch-1"
+	first := 1
+	items := []WorkItem{
+		{ID: "stop-1", Status: WorkItemStatusPending, Priority: WorkItemPriorityMedium, UpdatedAt: now, BatchID: &batch, Stop: &first},
+		{ID: "inprog", Status: WorkItemStatusInProgress, Priority: WorkItemPriorityLow, UpdatedAt: now.Add(-time.Hour)},
+	}
+	got := selectNext(items)
+	if got == nil || got.ID != "inprog" {
+		t.Fatalf("expected in-progress to win over the next stop, got %+v", got)
+	}
+}
+
 func TestNormalizePicks(t *testing.T) {
 	orderID := uuid.New()
 	locID := uuid.New()
@@ -230,6 +260,34 @@ func TestNormalizePicks_MissingParentOrderSkips(t *testing.T) {
 	}
 }
 
+func TestNormalizePicks_BatchStop(t *testing.T) {
+	orderID := uuid.New()
+	batchID := uuid.New()
+	seq, tote := 4, 2
+
+	ordersByID := map[uuid.UUID]ordersbus.Order{
+		orderID: {ID: orderID, Number: "SO-1", Priority: "medium"},
+	}
+	tasks := []picktaskbus.PickTask{
+		{ID: uuid.New(), SalesOrderID: orderID, Status: picktaskbus.Statuses.Pending, BatchID: &batchID, BatchSequence: &seq, ToteNumber: &tote},
+		{ID: uuid.New(), SalesOrderID: orderID, Status: picktaskbus.Statuses.Pending},
+	}
+
+	got := normalizePicks(tasks, ordersByID)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(got))
+	}
+	if got[0].BatchID == nil || *got[0].BatchID != batchID.String() {
+		t.Errorf("expected BatchID=%s, got %v", batchID, got[0].BatchID)
+	}
+	if got[0].Stop == nil || *got[0].Stop != seq || got[0].Tote == nil || *got[0].Tote != tote {
+		t.Errorf("expected stop %d tote %d, got %v %v", seq, tote, got[0].Stop, got[0].Tote)
+	}
+	if got[1].BatchID != nil || got[1].Stop != nil || got[1].Tote != nil {
+		t.Errorf("expected unbatched pick to carry no batch fields, got %+v", got[1])
+	}
+}
+
 func TestNormalizePutaways(t *testing.T) {
 	userID := uuid.New()
 	locID := uuid.New()
@@ -359,6 +417,57 @@ func TestNormalizeCounts(t *testing.T) {
 	}
 }
 
+func TestNormalizeCounts_Recount(t *testing.T) {
+	originalID := uuid.New()
+	items := []cyclecountitembus.CycleCountItem{
+		{
+			ID:          uuid.New(),
+			LocationID:  uuid.New(),
+			Status:      cyclecountitembus.Statuses.Pending,
+			RecountOf:   &originalID,
+			UpdatedDate: time.Now(),
+		},
+	}
+
+	got := normalizeCounts(items)
+	if len(got) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(got))
+	}
+	if got[0].Priority != WorkItemPriorityHigh {
+		t.Errorf("expected high priority for a recount, got %s", got[0].Priority)
+	}
+	wantTitlePrefix := "Recount "
+	if len(got[0].Title) < len(wantTitlePrefix) || got[0].Title[:len(wantTitlePrefix)] != wantTitlePrefix {
+		t.Errorf("expected title to start with %q, got %q", wantTitlePrefix, got[0].Title)
+	}
+}
+
+func TestCountsFor(t *testing.T) {
+	me, other := uuid.New(), uuid.New()
+	mine, theirs := uuid.New(), uuid.New()
+	items := []cyclecountitembus.CycleCountItem{
+		{ID: uuid.New()},                     // unassigned → kept
+		{ID: uuid.New(), AssignedTo: &me},    // mine → kept
+		{ID: uuid.New(), AssignedTo: &other}, // someone else's → dropped
+		{ID: uuid.New(), RecountOf: &mine},   // recount of my count → dropped
+		{ID: uuid.New(), RecountOf: &theirs}, // recount of another's count → kept
+	}
+	recounted := []cyclecountitembus.CycleCountItem{{ID: mine, CountedBy: me}}
+
+	got := countsFor(items, recounted, me)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(got))
+	}
+	for _, it := range got {
+		if it.AssignedTo != nil && *it.AssignedTo != me {
+			t.Errorf("item %s assigned to another worker was not dropped", it.ID)
+		}
+		if it.RecountOf != nil && *it.RecountOf == mine {
+			t.Errorf("item %s recounts this worker's count and was not dropped", it.ID)
+		}
+	}
+}
+
 func TestNormalizeInspections(t *testing.T) {
 	next := time.Now().Add(24 * time.Hour)
 	inspections := []inspectionbus.Inspection{
@@ -500,3 +609,35 @@ func TestNormalizeTransfers(t *testing.T) {
 		t.Errorf("expected medium priority, got %s", readyToStart.Priority)
 	}
 }
+
+func TestNormalizeReplenishments(t *testing.T) {
+	fromLoc := uuid.New()
+	userID := uuid.New()
+	tasks := []replenishmenttaskbus.Task{
+		{ID: uuid.New(), FromLocationID: fromLoc, Reason: replenishmenttaskbus.ReasonMinMax, Status: replenishmenttaskbus.StatusPending, UpdatedDate: time.Now()},
+		{ID: uuid.New(), FromLocationID: fromLoc, Reason: replenishmenttaskbus.ReasonShortPick, Status: replenishmenttaskbus.StatusInProgress, AssignedTo: &userID, UpdatedDate: time.Now()},
+		{ID: uuid.New(), FromLocationID: fromLoc, Reason: replenishmenttaskbus.ReasonDemand, Status: replenishmenttaskbus.StatusCompleted, UpdatedDate: time.Now()},
+		{ID: uuid.New(), FromLocationID: fromLoc, Reason: replenishmenttaskbus.ReasonDemand, Status: replenishmenttaskbus.StatusCancelled, UpdatedDate: time.Now()},
+	}
+
+	got := normalizeReplenishments(tasks)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 items (completed + cancelled filtered), got %d", len(got))
+	}
+
+	if got[0].Type != WorkItemTypeReplenish || got[0].Status != WorkItemStatusPending {
+		t.Errorf("expected pending replenish, got %s %s", got[0].Type, got[0].Status)
+	}
+	if got[0].Priority != WorkItemPriorityMedium {
+		t.Errorf("expected medium priority for min/max, got %s", got[0].Priority)
+	}
+	if got[0].LocationID == nil || *got[0].LocationID != fromLoc.String() {
+		t.Errorf("expected LocationID from FromLocationID, got %v", got[0].LocationID)
+	}
+	if want := "/floor/replenish/" + tasks[0].ID.String(); got[0].DetailPath != want {
+		t.Errorf("expected DetailPath %q, got %q", want, got[0].DetailPath)
+	}
+	if got[1].Status != WorkItemStatusInProgress || got[1].Priority != WorkItemPriorityHigh {
+		t.Errorf("expected in-progress short pick at high priority, got %s %s", got[1].Status, got[1].Priority)
+	}
+}
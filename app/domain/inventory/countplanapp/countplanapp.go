@@ -0,0 +1,215 @@
+// Package countplanapp maintains the app layer api for count plans: the
+// policies that generate cycle count sessions on a schedule.
+package countplanapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/app/sdk/mid"
+	"github.com/timmaaaz/ichor/app/sdk/query"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// App manages the set of app layer APIs for count plan access.
+type App struct {
+	countPlanBus *countplanbus.Business
+}
+
+// NewApp constructs a count plan app.
+func NewApp(countPlanBus *countplanbus.Business) *App {
+	return &App{
+		countPlanBus: countPlanBus,
+	}
+}
+
+// Create adds a new count plan to the system.
+func (a *App) Create(ctx context.Context, app NewCountPlan) (CountPlan, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return CountPlan{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	ncp, err := toBusNewCountPlan(app, userID)
+	if err != nil {
+		return CountPlan{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	plan, err := a.countPlanBus.Create(ctx, ncp)
+	if err != nil {
+		return CountPlan{}, toAppError("create", err)
+	}
+
+	return ToAppCountPlan(plan), nil
+}
+
+// Update modifies an existing count plan.
+func (a *App) Update(ctx context.Context, planID uuid.UUID, app UpdateCountPlan) (CountPlan, error) {
+	ucp, err := toBusUpdateCountPlan(app)
+	if err != nil {
+		return CountPlan{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	plan, err := a.queryByID(ctx, planID)
+	if err != nil {
+		return CountPlan{}, err
+	}
+
+	updated, err := a.countPlanBus.Update(ctx, plan, ucp)
+	if err != nil {
+		return CountPlan{}, toAppError("update", err)
+	}
+
+	return ToAppCountPlan(updated), nil
+}
+
+// Delete removes a count plan from the system. A plan that has generated
+// sessions keeps its run history and cannot be deleted; deactivate it instead.
+func (a *App) Delete(ctx context.Context, planID uuid.UUID) error {
+	plan, err := a.queryByID(ctx, planID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.countPlanBus.Delete(ctx, plan); err != nil {
+		if errors.Is(err, countplanbus.ErrForeignKeyViolation) {
+			return errs.Newf(errs.Aborted, "count plan has generated sessions; deactivate it instead")
+		}
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of count plans based on query parameters.
+func (a *App) Query(ctx context.Context, qp QueryParams) (query.Result[CountPlan], error) {
+	pg, err := page.Parse(qp.Page, qp.Rows)
+	if err != nil {
+		return query.Result[CountPlan]{}, errs.NewFieldsError("page", err)
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return query.Result[CountPlan]{}, errs.NewFieldsError("filter", err)
+	}
+
+	orderBy, err := order.Parse(orderByFields, qp.OrderBy, defaultOrderBy)
+	if err != nil {
+		return query.Result[CountPlan]{}, errs.NewFieldsError("orderBy", err)
+	}
+
+	plans, err := a.countPlanBus.Query(ctx, filter, orderBy, pg)
+	if err != nil {
+		return query.Result[CountPlan]{}, errs.Newf(errs.Internal, "query: %v", err)
+	}
+
+	total, err := a.countPlanBus.Count(ctx, filter)
+	if err != nil {
+		return query.Result[CountPlan]{}, errs.Newf(errs.Internal, "count: %v", err)
+	}
+
+	return query.NewResult(ToAppCountPlans(plans), total, pg), nil
+}
+
+// QueryByID retrieves a single count plan by ID.
+func (a *App) QueryByID(ctx context.Context, planID uuid.UUID) (CountPlan, error) {
+	plan, err := a.queryByID(ctx, planID)
+	if err != nil {
+		return CountPlan{}, err
+	}
+
+	return ToAppCountPlan(plan), nil
+}
+
+// Preview returns the items the plan would put in a session if it were
+// generated now, without writing anything.
+func (a *App) Preview(ctx context.Context, planID uuid.UUID) (Preview, error) {
+	plan, err := a.queryByID(ctx, planID)
+	if err != nil {
+		return Preview{}, err
+	}
+
+	cands, err := a.countPlanBus.Preview(ctx, plan, time.Now())
+	if err != nil {
+		return Preview{}, fmt.Errorf("preview: %w", err)
+	}
+
+	return toAppPreview(plan.ID, cands), nil
+}
+
+// Generate creates a cycle count session from the plan's current candidates
+// and records the run.
+func (a *App) Generate(ctx context.Context, planID uuid.UUID) (Run, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Run{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	plan, err := a.queryByID(ctx, planID)
+	if err != nil {
+		return Run{}, err
+	}
+
+	run, err := a.countPlanBus.Generate(ctx, plan, userID, time.Now())
+	if err != nil {
+		return Run{}, toAppError("generate", err)
+	}
+
+	return toAppRun(run), nil
+}
+
+// QueryRuns retrieves the generation history of a count plan, newest first.
+func (a *App) QueryRuns(ctx context.Context, planID uuid.UUID, qp QueryParams) (query.Result[Run], error) {
+	pg, err := page.Parse(qp.Page, qp.Rows)
+	if err != nil {
+		return query.Result[Run]{}, errs.NewFieldsError("page", err)
+	}
+
+	if _, err := a.queryByID(ctx, planID); err != nil {
+		return query.Result[Run]{}, err
+	}
+
+	runs, err := a.countPlanBus.QueryRuns(ctx, planID, pg)
+	if err != nil {
+		return query.Result[Run]{}, errs.Newf(errs.Internal, "queryruns: %v", err)
+	}
+
+	total, err := a.countPlanBus.CountRuns(ctx, planID)
+	if err != nil {
+		return query.Result[Run]{}, errs.Newf(errs.Internal, "countruns: %v", err)
+	}
+
+	return query.NewResult(toAppRuns(runs), total, pg), nil
+}
+
+// =============================================================================
+
+func (a *App) queryByID(ctx context.Context, planID uuid.UUID) (countplanbus.CountPlan, error) {
+	plan, err := a.countPlanBus.QueryByID(ctx, planID)
+	if err != nil {
+		if errors.Is(err, countplanbus.ErrNotFound) {
+			return countplanbus.CountPlan{}, errs.New(errs.NotFound, err)
+		}
+		return countplanbus.CountPlan{}, fmt.Errorf("querybyid: %w", err)
+	}
+
+	return plan, nil
+}
+
+func toAppError(op string, err error) error {
+	switch {
+	case errors.Is(err, countplanbus.ErrInvalidPlan):
+		return errs.New(errs.InvalidArgument, err)
+	case errors.Is(err, countplanbus.ErrInactive), errors.Is(err, countplanbus.ErrNothingDue):
+		return errs.New(errs.FailedPrecondition, err)
+	case errors.Is(err, countplanbus.ErrUniqueEntry), errors.Is(err, countplanbus.ErrForeignKeyViolation):
+		return errs.New(errs.Aborted, err)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
@@ -0,0 +1,50 @@
+package countplanapp
+
+import (
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+)
+
+func parseFilter(qp QueryParams) (countplanbus.QueryFilter, error) {
+	var filter countplanbus.QueryFilter
+
+	if qp.ID != "" {
+		id, err := uuid.Parse(qp.ID)
+		if err != nil {
+			return countplanbus.QueryFilter{}, err
+		}
+		filter.ID = &id
+	}
+
+	if qp.Name != "" {
+		filter.Name = &qp.Name
+	}
+
+	if qp.Rule != "" {
+		rule, err := countplanbus.ParseRule(qp.Rule)
+		if err != nil {
+			return countplanbus.QueryFilter{}, err
+		}
+		filter.Rule = &rule
+	}
+
+	if qp.WarehouseID != "" {
+		id, err := uuid.Parse(qp.WarehouseID)
+		if err != nil {
+			return countplanbus.QueryFilter{}, err
+		}
+		filter.WarehouseID = &id
+	}
+
+	if qp.IsActive != "" {
+		active, err := strconv.ParseBool(qp.IsActive)
+		if err != nil {
+			return countplanbus.QueryFilter{}, err
+		}
+		filter.IsActive = &active
+	}
+
+	return filter, nil
+}
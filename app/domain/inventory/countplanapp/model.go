@@ -0,0 +1,368 @@
+package countplanapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+	"github.com/timmaaaz/ichor/foundation/timeutil"
+)
+
+// QueryParams holds the raw query parameters from the HTTP request.
+type QueryParams struct {
+	Page        string
+	Rows        string
+	OrderBy     string
+	ID          string
+	Name        string
+	Rule        string
+	WarehouseID string
+	IsActive    string
+}
+
+// =============================================================================
+// Response model
+// =============================================================================
+
+// CountPlan is the app-layer response model.
+type CountPlan struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	Rule              string `json:"rule"`
+	WarehouseID       string `json:"warehouse_id"`
+	ZoneID            string `json:"zone_id"`
+	ADays             string `json:"a_days"`
+	BDays             string `json:"b_days"`
+	CDays             string `json:"c_days"`
+	LookbackDays      string `json:"lookback_days"`
+	SampleSize        string `json:"sample_size"`
+	MaxItems          string `json:"max_items"`
+	IsActive          bool   `json:"is_active"`
+	LastGeneratedDate string `json:"last_generated_date"`
+	CreatedBy         string `json:"created_by"`
+	CreatedDate       string `json:"created_date"`
+	UpdatedDate       string `json:"updated_date"`
+}
+
+// Encode implements the encoder interface.
+func (app CountPlan) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// ToAppCountPlan converts a bus model to an app-layer response model.
+func ToAppCountPlan(bus countplanbus.CountPlan) CountPlan {
+	warehouseID := ""
+	if bus.WarehouseID != nil {
+		warehouseID = bus.WarehouseID.String()
+	}
+
+	zoneID := ""
+	if bus.ZoneID != nil {
+		zoneID = bus.ZoneID.String()
+	}
+
+	lastGenerated := ""
+	if bus.LastGeneratedDate != nil {
+		lastGenerated = bus.LastGeneratedDate.Format(timeutil.FORMAT)
+	}
+
+	return CountPlan{
+		ID:                bus.ID.String(),
+		Name:              bus.Name,
+		Rule:              bus.Rule.String(),
+		WarehouseID:       warehouseID,
+		ZoneID:            zoneID,
+		ADays:             strconv.Itoa(bus.ADays),
+		BDays:             strconv.Itoa(bus.BDays),
+		CDays:             strconv.Itoa(bus.CDays),
+		LookbackDays:      strconv.Itoa(bus.LookbackDays),
+		SampleSize:        strconv.Itoa(bus.SampleSize),
+		MaxItems:          strconv.Itoa(bus.MaxItems),
+		IsActive:          bus.IsActive,
+		LastGeneratedDate: lastGenerated,
+		CreatedBy:         bus.CreatedBy.String(),
+		CreatedDate:       bus.CreatedDate.Format(timeutil.FORMAT),
+		UpdatedDate:       bus.UpdatedDate.Format(timeutil.FORMAT),
+	}
+}
+
+// ToAppCountPlans converts a slice of bus models to app-layer response models.
+func ToAppCountPlans(bus []countplanbus.CountPlan) []CountPlan {
+	app := make([]CountPlan, len(bus))
+	for i, v := range bus {
+		app[i] = ToAppCountPlan(v)
+	}
+	return app
+}
+
+// =============================================================================
+// Create model
+// =============================================================================
+
+// NewCountPlan is the app-layer create request model. Rule parameters left
+// empty take the business defaults. CreatedBy is injected from the
+// authenticated user — not accepted from the client.
+type NewCountPlan struct {
+	Name         string `json:"name" validate:"required,max=180"`
+	Rule         string `json:"rule" validate:"required,oneof=abc zero_on_hand negative_adjustment random_sample"`
+	WarehouseID  string `json:"warehouse_id" validate:"omitempty,min=36,max=36"`
+	ZoneID       string `json:"zone_id" validate:"omitempty,min=36,max=36"`
+	ADays        string `json:"a_days" validate:"omitempty,number"`
+	BDays        string `json:"b_days" validate:"omitempty,number"`
+	CDays        string `json:"c_days" validate:"omitempty,number"`
+	LookbackDays string `json:"lookback_days" validate:"omitempty,number"`
+	SampleSize   string `json:"sample_size" validate:"omitempty,number"`
+	MaxItems     string `json:"max_items" validate:"omitempty,number"`
+	IsActive     *bool  `json:"is_active"`
+}
+
+// Decode implements the decoder interface.
+func (app *NewCountPlan) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewCountPlan) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusNewCountPlan(app NewCountPlan, createdBy uuid.UUID) (countplanbus.NewCountPlan, error) {
+	rule, err := countplanbus.ParseRule(app.Rule)
+	if err != nil {
+		return countplanbus.NewCountPlan{}, fmt.Errorf("parse rule: %w", err)
+	}
+
+	bus := countplanbus.NewCountPlan{
+		Name:      app.Name,
+		Rule:      rule,
+		IsActive:  true,
+		CreatedBy: createdBy,
+	}
+
+	if app.IsActive != nil {
+		bus.IsActive = *app.IsActive
+	}
+
+	if bus.WarehouseID, err = parseOptionalUUID(app.WarehouseID); err != nil {
+		return countplanbus.NewCountPlan{}, fmt.Errorf("parse warehouse_id: %w", err)
+	}
+	if bus.ZoneID, err = parseOptionalUUID(app.ZoneID); err != nil {
+		return countplanbus.NewCountPlan{}, fmt.Errorf("parse zone_id: %w", err)
+	}
+
+	for _, f := range []struct {
+		name string
+		in   string
+		out  *int
+	}{
+		{"a_days", app.ADays, &bus.ADays},
+		{"b_days", app.BDays, &bus.BDays},
+		{"c_days", app.CDays, &bus.CDays},
+		{"lookback_days", app.LookbackDays, &bus.LookbackDays},
+		{"sample_size", app.SampleSize, &bus.SampleSize},
+		{"max_items", app.MaxItems, &bus.MaxItems},
+	} {
+		if f.in == "" {
+			continue
+		}
+		if *f.out, err = strconv.Atoi(f.in); err != nil {
+			return countplanbus.NewCountPlan{}, fmt.Errorf("parse %s: %w", f.name, err)
+		}
+	}
+
+	return bus, nil
+}
+
+// =============================================================================
+// Update model
+// =============================================================================
+
+// UpdateCountPlan is the app-layer update request model.
+type UpdateCountPlan struct {
+	Name         *string `json:"name" validate:"omitempty,max=180"`
+	Rule         *string `json:"rule" validate:"omitempty,oneof=abc zero_on_hand negative_adjustment random_sample"`
+	WarehouseID  *string `json:"warehouse_id" validate:"omitempty,min=36,max=36"`
+	ZoneID       *string `json:"zone_id" validate:"omitempty,min=36,max=36"`
+	ADays        *string `json:"a_days" validate:"omitempty,number"`
+	BDays        *string `json:"b_days" validate:"omitempty,number"`
+	CDays        *string `json:"c_days" validate:"omitempty,number"`
+	LookbackDays *string `json:"lookback_days" validate:"omitempty,number"`
+	SampleSize   *string `json:"sample_size" validate:"omitempty,number"`
+	MaxItems     *string `json:"max_items" validate:"omitempty,number"`
+	IsActive     *bool   `json:"is_active"`
+}
+
+// Decode implements the decoder interface.
+func (app *UpdateCountPlan) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app UpdateCountPlan) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusUpdateCountPlan(app UpdateCountPlan) (countplanbus.UpdateCountPlan, error) {
+	bus := countplanbus.UpdateCountPlan{
+		Name:     app.Name,
+		IsActive: app.IsActive,
+	}
+
+	if app.Rule != nil {
+		rule, err := countplanbus.ParseRule(*app.Rule)
+		if err != nil {
+			return countplanbus.UpdateCountPlan{}, fmt.Errorf("parse rule: %w", err)
+		}
+		bus.Rule = &rule
+	}
+
+	if app.WarehouseID != nil {
+		id, err := uuid.Parse(*app.WarehouseID)
+		if err != nil {
+			return countplanbus.UpdateCountPlan{}, fmt.Errorf("parse warehouse_id: %w", err)
+		}
+		bus.WarehouseID = &id
+	}
+
+	if app.ZoneID != nil {
+		id, err := uuid.Parse(*app.ZoneID)
+		if err != nil {
+			return countplanbus.UpdateCountPlan{}, fmt.Errorf("parse zone_id: %w", err)
+		}
+		bus.ZoneID = &id
+	}
+
+	for _, f := range []struct {
+		name string
+		in   *string
+		out  **int
+	}{
+		{"a_days", app.ADays, &bus.ADays},
+		{"b_days", app.BDays, &bus.BDays},
+		{"c_days", app.CDays, &bus.CDays},
+		{"lookback_days", app.LookbackDays, &bus.LookbackDays},
+		{"sample_size", app.SampleSize, &bus.SampleSize},
+		{"max_items", app.MaxItems, &bus.MaxItems},
+	} {
+		if f.in == nil {
+			continue
+		}
+		n, err := strconv.Atoi(*f.in)
+		if err != nil {
+			return countplanbus.UpdateCountPlan{}, fmt.Errorf("parse %s: %w", f.name, err)
+		}
+		*f.out = &n
+	}
+
+	return bus, nil
+}
+
+// =============================================================================
+// Preview and generation models
+// =============================================================================
+
+// Candidate is an item a plan would put in a session.
+type Candidate struct {
+	ProductID      string `json:"product_id"`
+	LocationID     string `json:"location_id"`
+	ZoneID         string `json:"zone_id"`
+	WarehouseID    string `json:"warehouse_id"`
+	SystemQuantity int    `json:"system_quantity"`
+	Reason         string `json:"reason"`
+}
+
+// Preview is the set of items a plan would count if generated now.
+type Preview struct {
+	PlanID     string      `json:"plan_id"`
+	ItemCount  int         `json:"item_count"`
+	Candidates []Candidate `json:"candidates"`
+}
+
+// Encode implements the encoder interface.
+func (app Preview) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppPreview(planID uuid.UUID, bus []countplanbus.Candidate) Preview {
+	cands := make([]Candidate, len(bus))
+	for i, c := range bus {
+		cands[i] = Candidate{
+			ProductID:      c.ProductID.String(),
+			LocationID:     c.LocationID.String(),
+			ZoneID:         c.ZoneID.String(),
+			WarehouseID:    c.WarehouseID.String(),
+			SystemQuantity: c.SystemQuantity,
+			Reason:         c.Reason,
+		}
+	}
+
+	return Preview{
+		PlanID:     planID.String(),
+		ItemCount:  len(cands),
+		Candidates: cands,
+	}
+}
+
+// Run is one generation of a plan.
+type Run struct {
+	ID            string `json:"id"`
+	PlanID        string `json:"plan_id"`
+	SessionID     string `json:"session_id"`
+	ItemCount     int    `json:"item_count"`
+	GeneratedBy   string `json:"generated_by"`
+	GeneratedDate string `json:"generated_date"`
+}
+
+// Encode implements the encoder interface.
+func (app Run) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppRun(bus countplanbus.Run) Run {
+	sessionID := ""
+	if bus.SessionID != uuid.Nil {
+		sessionID = bus.SessionID.String()
+	}
+
+	return Run{
+		ID:            bus.ID.String(),
+		PlanID:        bus.PlanID.String(),
+		SessionID:     sessionID,
+		ItemCount:     bus.ItemCount,
+		GeneratedBy:   bus.GeneratedBy.String(),
+		GeneratedDate: bus.GeneratedDate.Format(timeutil.FORMAT),
+	}
+}
+
+func toAppRuns(bus []countplanbus.Run) []Run {
+	app := make([]Run, len(bus))
+	for i, v := range bus {
+		app[i] = toAppRun(v)
+	}
+	return app
+}
+
+// =============================================================================
+
+func parseOptionalUUID(s string) (*uuid.UUID, error) {
+	if s == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
@@ -0,0 +1,16 @@
+package countplanapp
+
+import (
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+)
+
+var defaultOrderBy = order.NewBy(countplanbus.OrderByName, order.ASC)
+
+var orderByFields = map[string]string{
+	countplanbus.OrderByID:                countplanbus.OrderByID,
+	countplanbus.OrderByName:              countplanbus.OrderByName,
+	countplanbus.OrderByRule:              countplanbus.OrderByRule,
+	countplanbus.OrderByLastGeneratedDate: countplanbus.OrderByLastGeneratedDate,
+	countplanbus.OrderByCreatedDate:       countplanbus.OrderByCreatedDate,
+}
@@ -2,27 +2,39 @@ package cyclecountitemapp
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"github.com/timmaaaz/ichor/app/sdk/errs"
 	"github.com/timmaaaz/ichor/app/sdk/mid"
 	"github.com/timmaaaz/ichor/app/sdk/query"
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountsessionbus"
 	"github.com/timmaaaz/ichor/business/sdk/order"
 	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
 )
 
 // App manages the set of app layer APIs for cycle count item access.
 type App struct {
-	cycleCountItemBus *cyclecountitembus.Business
+	cycleCountItemBus    *cyclecountitembus.Business
+	cycleCountSessionBus *cyclecountsessionbus.Business
+	db                   *sqlx.DB
 }
 
 // NewApp constructs a cycle count item app.
-func NewApp(cycleCountItemBus *cyclecountitembus.Business) *App {
+func NewApp(
+	cycleCountItemBus *cyclecountitembus.Business,
+	cycleCountSessionBus *cyclecountsessionbus.Business,
+	db *sqlx.DB,
+) *App {
 	return &App{
-		cycleCountItemBus: cycleCountItemBus,
+		cycleCountItemBus:    cycleCountItemBus,
+		cycleCountSessionBus: cycleCountSessionBus,
+		db:                   db,
 	}
 }
 
@@ -35,6 +47,9 @@ func (a *App) Create(ctx context.Context, app NewCycleCountItem) (CycleCountItem
 
 	item, err := a.cycleCountItemBus.Create(ctx, ncci)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return CycleCountItem{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, cyclecountitembus.ErrUniqueEntry) {
 			return CycleCountItem{}, errs.New(errs.Aborted, err)
 		}
@@ -44,10 +59,19 @@ func (a *App) Create(ctx context.Context, app NewCycleCountItem) (CycleCountItem
 		return CycleCountItem{}, fmt.Errorf("create: %w", err)
 	}
 
-	return ToAppCycleCountItem(item), nil
+	return a.toAppItem(ctx, item)
 }
 
 // Update modifies an existing cycle count item.
+//
+// Recording a count enforces the session's count rules:
+//   - an item assigned to a worker can only be counted by that worker
+//   - a recount cannot be counted by the worker whose count it re-checks
+//   - in a blind session an item can only be counted once
+//
+// When the variance of a first count exceeds the session's recount
+// thresholds, the item moves to recount and a new pending item re-checking
+// it is assigned to another worker, in the same transaction as the count.
 func (a *App) Update(ctx context.Context, itemID uuid.UUID, app UpdateCycleCountItem) (CycleCountItem, error) {
 	userID, err := mid.GetUserID(ctx)
 	if err != nil {
@@ -67,6 +91,10 @@ func (a *App) Update(ctx context.Context, itemID uuid.UUID, app UpdateCycleCount
 		return CycleCountItem{}, errs.New(errs.InvalidArgument, err)
 	}
 
+	if ucci.CountedQuantity != nil {
+		return a.count(ctx, item, ucci, userID)
+	}
+
 	updated, err := a.cycleCountItemBus.Update(ctx, item, ucci)
 	if err != nil {
 		if errors.Is(err, cyclecountitembus.ErrForeignKeyViolation) {
@@ -75,7 +103,150 @@ func (a *App) Update(ctx context.Context, itemID uuid.UUID, app UpdateCycleCount
 		return CycleCountItem{}, fmt.Errorf("update: %w", err)
 	}
 
-	return ToAppCycleCountItem(updated), nil
+	return a.toAppItem(ctx, updated)
+}
+
+// count records a counted quantity and, when the variance is out of the
+// session's tolerance, sends the item for recount.
+func (a *App) count(ctx context.Context, item cyclecountitembus.CycleCountItem, ucci cyclecountitembus.UpdateCycleCountItem, userID uuid.UUID) (CycleCountItem, error) {
+	session, err := a.cycleCountSessionBus.QueryByID(ctx, item.SessionID)
+	if err != nil {
+		return CycleCountItem{}, fmt.Errorf("query session: %w", err)
+	}
+
+	tx, err := a.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return CycleCountItem{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Enroll the tx on ctx so cascade outbox.Emit rides the same transaction as the entity
+	// write (they commit or roll back together) instead of falling back to the base pool.
+	ctx = sqldb.WithTx(ctx, tx)
+
+	itemBusTx, err := a.cycleCountItemBus.NewWithTx(tx)
+	if err != nil {
+		return CycleCountItem{}, fmt.Errorf("new item tx: %w", err)
+	}
+
+	// Check the count rules against the locked row so two workers counting
+	// the same item serialize here instead of both passing the checks.
+	item, err = itemBusTx.QueryByIDForUpdate(ctx, item.ID)
+	if err != nil {
+		if errors.Is(err, cyclecountitembus.ErrNotFound) {
+			return CycleCountItem{}, errs.New(errs.NotFound, err)
+		}
+		return CycleCountItem{}, fmt.Errorf("query item for update: %w", err)
+	}
+
+	if item.Status == cyclecountitembus.Statuses.Recount {
+		return CycleCountItem{}, errs.Newf(errs.FailedPrecondition, "item %s has been sent for recount", item.ID)
+	}
+
+	if session.Blind && item.Status != cyclecountitembus.Statuses.Pending {
+		return CycleCountItem{}, errs.Newf(errs.FailedPrecondition, "item %s has already been counted in a blind session", item.ID)
+	}
+
+	if item.AssignedTo != nil && *item.AssignedTo != userID {
+		return CycleCountItem{}, errs.Newf(errs.FailedPrecondition, "item %s is assigned to another worker", item.ID)
+	}
+
+	if item.RecountOf != nil {
+		original, err := itemBusTx.QueryByID(ctx, *item.RecountOf)
+		if err != nil {
+			return CycleCountItem{}, fmt.Errorf("query recounted item: %w", err)
+		}
+		if original.CountedBy == userID {
+			return CycleCountItem{}, errs.Newf(errs.FailedPrecondition, "item %s must be recounted by a different worker than the original count", item.ID)
+		}
+	}
+
+	updated, err := itemBusTx.Update(ctx, item, ucci)
+	if err != nil {
+		if errors.Is(err, cyclecountitembus.ErrForeignKeyViolation) {
+			return CycleCountItem{}, errs.New(errs.Aborted, err)
+		}
+		return CycleCountItem{}, fmt.Errorf("update: %w", err)
+	}
+
+	// A recount stands as counted; only a first count can trigger another.
+	if updated.RecountOf == nil && updated.Variance != nil {
+		var unitCost float64
+		if session.Recount.Value != nil {
+			if unitCost, err = a.cycleCountSessionBus.QueryUnitCost(ctx, updated.ProductID); err != nil {
+				return CycleCountItem{}, fmt.Errorf("query unit cost: %w", err)
+			}
+		}
+
+		if session.Recount.Exceeded(updated.SystemQuantity, *updated.Variance, unitCost) {
+			if updated, err = a.sendForRecount(ctx, itemBusTx, updated, userID); err != nil {
+				return CycleCountItem{}, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return CycleCountItem{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return toAppItem(updated, session), nil
+}
+
+// sendForRecount moves a counted item to recount and creates the pending
+// item that re-checks it, assigned to another worker who has counted in the
+// session. With no such worker the recount is left unassigned; the count
+// rules keep the original counter from taking it and directed work does not
+// offer it to them.
+func (a *App) sendForRecount(ctx context.Context, itemBus *cyclecountitembus.Business, item cyclecountitembus.CycleCountItem, counterID uuid.UUID) (cyclecountitembus.CycleCountItem, error) {
+	recount := cyclecountitembus.Statuses.Recount
+	item, err := itemBus.Update(ctx, item, cyclecountitembus.UpdateCycleCountItem{Status: &recount})
+	if err != nil {
+		return cyclecountitembus.CycleCountItem{}, fmt.Errorf("mark recount: %w", err)
+	}
+
+	assignee, err := a.otherCounter(ctx, itemBus, item.SessionID, counterID)
+	if err != nil {
+		return cyclecountitembus.CycleCountItem{}, err
+	}
+
+	_, err = itemBus.Create(ctx, cyclecountitembus.NewCycleCountItem{
+		ItemCode:       item.ItemCode,
+		SessionID:      item.SessionID,
+		ProductID:      item.ProductID,
+		LocationID:     item.LocationID,
+		SystemQuantity: item.SystemQuantity,
+		AssignedTo:     assignee,
+		RecountOf:      &item.ID,
+	})
+	if err != nil {
+		return cyclecountitembus.CycleCountItem{}, fmt.Errorf("create recount: %w", err)
+	}
+
+	return item, nil
+}
+
+// otherCounter returns a worker other than exclude who has counted an item
+// in the session, or nil when there is none.
+func (a *App) otherCounter(ctx context.Context, itemBus *cyclecountitembus.Business, sessionID uuid.UUID, exclude uuid.UUID) (*uuid.UUID, error) {
+	const pageSize = 1000
+	filter := cyclecountitembus.QueryFilter{SessionID: &sessionID}
+
+	for pageNum := 1; ; pageNum++ {
+		pg := page.MustParse(fmt.Sprintf("%d", pageNum), fmt.Sprintf("%d", pageSize))
+		batch, err := itemBus.Query(ctx, filter, cyclecountitembus.DefaultOrderBy, pg)
+		if err != nil {
+			return nil, fmt.Errorf("query session items page %d: %w", pageNum, err)
+		}
+		for _, it := range batch {
+			if it.CountedBy != uuid.Nil && it.CountedBy != exclude {
+				id := it.CountedBy
+				return &id, nil
+			}
+		}
+		if len(batch) < pageSize {
+			return nil, nil
+		}
+	}
 }
 
 // Delete removes a cycle count item from the system.
@@ -122,7 +293,12 @@ func (a *App) Query(ctx context.Context, qp QueryParams) (query.Result[CycleCoun
 		return query.Result[CycleCountItem]{}, errs.Newf(errs.Internal, "count: %v", err)
 	}
 
-	return query.NewResult(ToAppCycleCountItems(items), total, pg), nil
+	appItems, err := a.toAppItems(ctx, items)
+	if err != nil {
+		return query.Result[CycleCountItem]{}, errs.Newf(errs.Internal, "query sessions: %v", err)
+	}
+
+	return query.NewResult(appItems, total, pg), nil
 }
 
 // QueryByID retrieves a single cycle count item by ID.
@@ -135,5 +311,34 @@ func (a *App) QueryByID(ctx context.Context, itemID uuid.UUID) (CycleCountItem,
 		return CycleCountItem{}, fmt.Errorf("querybyid: %w", err)
 	}
 
-	return ToAppCycleCountItem(item), nil
+	return a.toAppItem(ctx, item)
+}
+
+// toAppItem converts an item for a response, hiding what its session's
+// count rules keep from counters.
+func (a *App) toAppItem(ctx context.Context, item cyclecountitembus.CycleCountItem) (CycleCountItem, error) {
+	session, err := a.cycleCountSessionBus.QueryByID(ctx, item.SessionID)
+	if err != nil {
+		return CycleCountItem{}, fmt.Errorf("query session: %w", err)
+	}
+
+	return toAppItem(item, session), nil
+}
+
+// toAppItems converts items for a response, loading each distinct session once.
+func (a *App) toAppItems(ctx context.Context, items []cyclecountitembus.CycleCountItem) ([]CycleCountItem, error) {
+	sessions := make(map[uuid.UUID]cyclecountsessionbus.CycleCountSession)
+	app := make([]CycleCountItem, len(items))
+	for i, item := range items {
+		session, ok := sessions[item.SessionID]
+		if !ok {
+			var err error
+			if session, err = a.cycleCountSessionBus.QueryByID(ctx, item.SessionID); err != nil {
+				return nil, fmt.Errorf("query session %s: %w", item.SessionID, err)
+			}
+			sessions[item.SessionID] = session
+		}
+		app[i] = toAppItem(item, session)
+	}
+	return app, nil
 }
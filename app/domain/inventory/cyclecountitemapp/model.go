@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/timmaaaz/ichor/app/sdk/errs"
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountsessionbus"
 	"github.com/timmaaaz/ichor/foundation/timeutil"
 )
 
@@ -23,6 +24,8 @@ type QueryParams struct {
 	LocationID string
 	Status     string
 	CountedBy  string
+	AssignedTo string
+	RecountOf  string
 }
 
 // =============================================================================
@@ -45,6 +48,8 @@ type CycleCountItem struct {
 	CreatedDate     string `json:"createdDate"`
 	UpdatedDate     string `json:"updatedDate"`
 	ScenarioID      string `json:"scenario_id,omitempty"`
+	AssignedTo      string `json:"assignedTo"`
+	RecountOf       string `json:"recountOf"`
 }
 
 func (app CycleCountItem) Encode() ([]byte, string, error) {
@@ -84,6 +89,16 @@ func ToAppCycleCountItem(bus cyclecountitembus.CycleCountItem) CycleCountItem {
 		scenarioID = bus.ScenarioID.String()
 	}
 
+	assignedTo := ""
+	if bus.AssignedTo != nil {
+		assignedTo = bus.AssignedTo.String()
+	}
+
+	recountOf := ""
+	if bus.RecountOf != nil {
+		recountOf = bus.RecountOf.String()
+	}
+
 	return CycleCountItem{
 		ID:              bus.ID.String(),
 		ItemCode:        itemCode,
@@ -99,9 +114,29 @@ func ToAppCycleCountItem(bus cyclecountitembus.CycleCountItem) CycleCountItem {
 		CreatedDate:     bus.CreatedDate.Format(timeutil.FORMAT),
 		UpdatedDate:     bus.UpdatedDate.Format(timeutil.FORMAT),
 		ScenarioID:      scenarioID,
+		AssignedTo:      assignedTo,
+		RecountOf:       recountOf,
 	}
 }
 
+// toAppItem converts a bus model for a response under its session's count
+// rules. Until a blind session completes, an item that is still to be counted
+// (pending or sent for recount) hides its system quantity and variance so the
+// counter cannot anchor on the expected quantity.
+func toAppItem(bus cyclecountitembus.CycleCountItem, session cyclecountsessionbus.CycleCountSession) CycleCountItem {
+	app := ToAppCycleCountItem(bus)
+
+	if session.Blind && session.Status != cyclecountsessionbus.Statuses.Completed {
+		switch bus.Status {
+		case cyclecountitembus.Statuses.Pending, cyclecountitembus.Statuses.Recount:
+			app.SystemQuantity = ""
+			app.Variance = ""
+		}
+	}
+
+	return app
+}
+
 // ToAppCycleCountItems converts a slice of bus models to app-layer response models.
 func ToAppCycleCountItems(bus []cyclecountitembus.CycleCountItem) []CycleCountItem {
 	app := make([]CycleCountItem, len(bus))
@@ -275,5 +310,21 @@ func parseFilter(qp QueryParams) (cyclecountitembus.QueryFilter, error) {
 		filter.CountedBy = &id
 	}
 
+	if qp.AssignedTo != "" {
+		id, err := uuid.Parse(qp.AssignedTo)
+		if err != nil {
+			return cyclecountitembus.QueryFilter{}, fmt.Errorf("parse assignedTo: %w", err)
+		}
+		filter.AssignedTo = &id
+	}
+
+	if qp.RecountOf != "" {
+		id, err := uuid.Parse(qp.RecountOf)
+		if err != nil {
+			return cyclecountitembus.QueryFilter{}, fmt.Errorf("parse recountOf: %w", err)
+		}
+		filter.RecountOf = &id
+	}
+
 	return filter, nil
 }
@@ -15,6 +15,8 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountitembus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountsessionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryadjustmentbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
 	"github.com/timmaaaz/ichor/business/sdk/order"
 	"github.com/timmaaaz/ichor/business/sdk/page"
 	"github.com/timmaaaz/ichor/business/sdk/sqldb"
@@ -25,6 +27,8 @@ type App struct {
 	cycleCountSessionBus *cyclecountsessionbus.Business
 	cycleCountItemBus    *cyclecountitembus.Business
 	invAdjustmentBus     *inventoryadjustmentbus.Business
+	invTransactionBus    *inventorytransactionbus.Business
+	invItemBus           *inventoryitembus.Business
 	db                   *sqlx.DB
 }
 
@@ -33,12 +37,16 @@ func NewApp(
 	sessionBus *cyclecountsessionbus.Business,
 	itemBus *cyclecountitembus.Business,
 	adjBus *inventoryadjustmentbus.Business,
+	invTransactionBus *inventorytransactionbus.Business,
+	invItemBus *inventoryitembus.Business,
 	db *sqlx.DB,
 ) *App {
 	return &App{
 		cycleCountSessionBus: sessionBus,
 		cycleCountItemBus:    itemBus,
 		invAdjustmentBus:     adjBus,
+		invTransactionBus:    invTransactionBus,
+		invItemBus:           invItemBus,
 		db:                   db,
 	}
 }
@@ -50,7 +58,12 @@ func (a *App) Create(ctx context.Context, app NewCycleCountSession) (CycleCountS
 		return CycleCountSession{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
 	}
 
-	session, err := a.cycleCountSessionBus.Create(ctx, toBusNewCycleCountSession(app, userID))
+	nccs, err := toBusNewCycleCountSession(app, userID)
+	if err != nil {
+		return CycleCountSession{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	session, err := a.cycleCountSessionBus.Create(ctx, nccs)
 	if err != nil {
 		if errors.Is(err, cyclecountsessionbus.ErrForeignKeyViolation) {
 			return CycleCountSession{}, errs.New(errs.Aborted, err)
@@ -67,11 +80,6 @@ func (a *App) Create(ctx context.Context, app NewCycleCountSession) (CycleCountS
 //   - → completed: atomic write (session update + inventory adjustments for all variance-approved items)
 //   - Terminal states (completed, cancelled): no further transitions allowed
 func (a *App) Update(ctx context.Context, sessionID uuid.UUID, app UpdateCycleCountSession) (CycleCountSession, error) {
-	ucs, err := toBusUpdateCycleCountSession(app)
-	if err != nil {
-		return CycleCountSession{}, errs.New(errs.InvalidArgument, err)
-	}
-
 	session, err := a.cycleCountSessionBus.QueryByID(ctx, sessionID)
 	if err != nil {
 		if errors.Is(err, cyclecountsessionbus.ErrNotFound) {
@@ -80,6 +88,19 @@ func (a *App) Update(ctx context.Context, sessionID uuid.UUID, app UpdateCycleCo
 		return CycleCountSession{}, fmt.Errorf("querybyid: %w", err)
 	}
 
+	ucs, err := toBusUpdateCycleCountSession(app, session.Recount)
+	if err != nil {
+		return CycleCountSession{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	// Blind mode and recount thresholds are fixed once the session is closed.
+	if app.changesCountRules() &&
+		(session.Status == cyclecountsessionbus.Statuses.Completed ||
+			session.Status == cyclecountsessionbus.Statuses.Cancelled) {
+		return CycleCountSession{}, errs.Newf(errs.FailedPrecondition,
+			"session is already %s and its count rules cannot be changed", session.Status)
+	}
+
 	// Guard against transitioning out of terminal states.
 	if ucs.Status != nil {
 		if session.Status == cyclecountsessionbus.Statuses.Completed ||
@@ -113,8 +134,10 @@ func (a *App) Update(ctx context.Context, sessionID uuid.UUID, app UpdateCycleCo
 // complete handles the atomic write when a session is completed:
 //  1. Re-query the session inside the transaction to prevent TOCTOU races
 //  2. Update session status to Completed with completed_date = now, merging any other fields from ucs
-//  3. Page through all variance_approved items for this session
-//  4. Create inventory adjustments for items with non-zero variance and immediately approve them
+//  3. Load all variance_approved items with non-zero variance, valued at current product cost
+//  4. Post each one as an approved cycle_count adjustment with its ADJUSTMENT ledger entry
+//     and on-hand quantity change
+//  5. Store the session's variance summary
 //
 // All writes are wrapped in a single DB transaction.
 func (a *App) complete(ctx context.Context, session cyclecountsessionbus.CycleCountSession, ucs cyclecountsessionbus.UpdateCycleCountSession) (CycleCountSession, error) {
@@ -166,69 +189,76 @@ func (a *App) complete(ctx context.Context, session cyclecountsessionbus.CycleCo
 		return CycleCountSession{}, fmt.Errorf("update session: %w", err)
 	}
 
-	// 3. Page through all variance_approved items for this session.
-	itemBusTx, err := a.cycleCountItemBus.NewWithTx(tx)
+	// 3. Load every approved, non-zero variance valued at its product's current cost.
+	lines, err := sessionBusTx.QueryVarianceLines(ctx, session.ID)
 	if err != nil {
-		return CycleCountSession{}, fmt.Errorf("new item tx: %w", err)
-	}
-
-	varianceApproved := cyclecountitembus.Statuses.VarianceApproved
-	itemFilter := cyclecountitembus.QueryFilter{
-		SessionID: &session.ID,
-		Status:    &varianceApproved,
-	}
-	itemOrder := order.NewBy("id", order.ASC)
-
-	var allItems []cyclecountitembus.CycleCountItem
-	const pageSize = 1000
-	for pageNum := 1; ; pageNum++ {
-		pg := page.MustParse(fmt.Sprintf("%d", pageNum), fmt.Sprintf("%d", pageSize))
-		batch, err := itemBusTx.Query(ctx, itemFilter, itemOrder, pg)
-		if err != nil {
-			return CycleCountSession{}, fmt.Errorf("query items page %d: %w", pageNum, err)
-		}
-		allItems = append(allItems, batch...)
-		if len(batch) < pageSize {
-			break
-		}
+		return CycleCountSession{}, fmt.Errorf("query variance lines: %w", err)
 	}
 
-	// 4. Create inventory adjustments for items with non-zero variance, then approve them.
+	// 4. Post each variance: an approved cycle_count adjustment, its ADJUSTMENT
+	// ledger entry, and the on-hand quantity change.
 	adjBusTx, err := a.invAdjustmentBus.NewWithTx(tx)
 	if err != nil {
 		return CycleCountSession{}, fmt.Errorf("new adjustment tx: %w", err)
 	}
 
-	for _, item := range allItems {
-		if item.Variance == nil || *item.Variance == 0 {
-			continue
-		}
+	txBusTx, err := a.invTransactionBus.NewWithTx(tx)
+	if err != nil {
+		return CycleCountSession{}, fmt.Errorf("new invtransaction tx: %w", err)
+	}
 
-		countedQty := 0
-		if item.CountedQuantity != nil {
-			countedQty = *item.CountedQuantity
-		}
+	invItemBusTx, err := a.invItemBus.NewWithTx(tx)
+	if err != nil {
+		return CycleCountSession{}, fmt.Errorf("new invitem tx: %w", err)
+	}
 
+	for _, line := range lines {
 		notes := fmt.Sprintf("Cycle count session %s: system_qty=%d, counted_qty=%d",
-			session.Name, item.SystemQuantity, countedQty)
+			session.Name, line.SystemQuantity, line.CountedQuantity)
 
 		// Create always sets ApprovalStatus=pending; call Approve to set it to approved.
 		adj, err := adjBusTx.Create(ctx, inventoryadjustmentbus.NewInventoryAdjustment{
-			ProductID:      item.ProductID,
-			LocationID:     item.LocationID,
+			ProductID:      line.ProductID,
+			LocationID:     line.LocationID,
 			AdjustedBy:     userID,
-			QuantityChange: *item.Variance,
+			QuantityChange: line.Variance,
 			ReasonCode:     inventoryadjustmentbus.ReasonCodeCycleCount,
 			Notes:          notes,
 			AdjustmentDate: now,
 		})
 		if err != nil {
-			return CycleCountSession{}, fmt.Errorf("create adjustment for item %s: %w", item.ID, err)
+			return CycleCountSession{}, fmt.Errorf("create adjustment for item %s: %w", line.ItemID, err)
 		}
 
 		if _, err = adjBusTx.Approve(ctx, adj, userID, "cycle count session completed"); err != nil {
-			return CycleCountSession{}, fmt.Errorf("approve adjustment for item %s: %w", item.ID, err)
+			return CycleCountSession{}, fmt.Errorf("approve adjustment for item %s: %w", line.ItemID, err)
+		}
+
+		_, err = txBusTx.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
+			ProductID:       line.ProductID,
+			LocationID:      line.LocationID,
+			UserID:          userID,
+			Quantity:        line.Variance,
+			TransactionType: "ADJUSTMENT",
+			ReferenceNumber: adj.InventoryAdjustmentID.String(),
+			TransactionDate: now,
+		})
+		if err != nil {
+			return CycleCountSession{}, fmt.Errorf("create inventory transaction for item %s: %w", line.ItemID, err)
 		}
+
+		if err := invItemBusTx.AdjustQuantity(ctx, line.ProductID, line.LocationID, line.Variance); err != nil {
+			return CycleCountSession{}, fmt.Errorf("adjust inventory quantity for item %s: %w", line.ItemID, err)
+		}
+	}
+
+	// 5. Freeze the session's financial impact.
+	summary := cyclecountsessionbus.Summarize(lines)
+	updated, err = sessionBusTx.Update(ctx, updated, cyclecountsessionbus.UpdateCycleCountSession{
+		Summary: &summary,
+	})
+	if err != nil {
+		return CycleCountSession{}, fmt.Errorf("update session summary: %w", err)
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -297,3 +327,28 @@ func (a *App) QueryByID(ctx context.Context, sessionID uuid.UUID) (CycleCountSes
 
 	return ToAppCycleCountSession(session), nil
 }
+
+// QuerySummary returns the financial impact of a session's approved variances.
+// A completed session reports the summary frozen when it was posted; any other
+// session reports what completing it now would post.
+func (a *App) QuerySummary(ctx context.Context, sessionID uuid.UUID) (VarianceSummary, error) {
+	session, err := a.cycleCountSessionBus.QueryByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, cyclecountsessionbus.ErrNotFound) {
+			return VarianceSummary{}, errs.New(errs.NotFound, err)
+		}
+		return VarianceSummary{}, fmt.Errorf("querybyid: %w", err)
+	}
+
+	lines, err := a.cycleCountSessionBus.QueryVarianceLines(ctx, sessionID)
+	if err != nil {
+		return VarianceSummary{}, fmt.Errorf("query variance lines: %w", err)
+	}
+
+	summary := cyclecountsessionbus.Summarize(lines)
+	if session.Summary != nil {
+		summary = *session.Summary
+	}
+
+	return toAppVarianceSummary(session, summary, lines), nil
+}
@@ -2,6 +2,8 @@ package cyclecountsessionapp
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/timmaaaz/ichor/app/sdk/errs"
@@ -27,14 +29,27 @@ type QueryParams struct {
 
 // CycleCountSession is the app-layer response model. All fields are strings for JSON serialization.
 type CycleCountSession struct {
-	ID            string `json:"id"`
-	Name          string `json:"name"`
-	Status        string `json:"status"`
-	CreatedBy     string `json:"createdBy"`
-	CreatedDate   string `json:"createdDate"`
-	UpdatedDate   string `json:"updatedDate"`
-	CompletedDate string `json:"completedDate"`
-	ScenarioID    string `json:"scenario_id,omitempty"`
+	ID             string          `json:"id"`
+	Name           string          `json:"name"`
+	Status         string          `json:"status"`
+	CreatedBy      string          `json:"createdBy"`
+	CreatedDate    string          `json:"createdDate"`
+	UpdatedDate    string          `json:"updatedDate"`
+	CompletedDate  string          `json:"completedDate"`
+	ScenarioID     string          `json:"scenario_id,omitempty"`
+	Blind          bool            `json:"blind"`
+	RecountUnits   string          `json:"recountUnits"`
+	RecountPercent string          `json:"recountPercent"`
+	RecountValue   string          `json:"recountValue"`
+	Summary        *SessionSummary `json:"summary,omitempty"`
+}
+
+// SessionSummary is the financial impact posted when a session completed.
+type SessionSummary struct {
+	AdjustmentCount    string `json:"adjustmentCount"`
+	NetVarianceUnits   string `json:"netVarianceUnits"`
+	NetVarianceValue   string `json:"netVarianceValue"`
+	GrossVarianceValue string `json:"grossVarianceValue"`
 }
 
 func (app CycleCountSession) Encode() ([]byte, string, error) {
@@ -54,18 +69,56 @@ func ToAppCycleCountSession(bus cyclecountsessionbus.CycleCountSession) CycleCou
 		scenarioID = bus.ScenarioID.String()
 	}
 
+	var summary *SessionSummary
+	if bus.Summary != nil {
+		s := toAppSessionSummary(*bus.Summary)
+		summary = &s
+	}
+
 	return CycleCountSession{
-		ID:            bus.ID.String(),
-		Name:          bus.Name,
-		Status:        bus.Status.String(),
-		CreatedBy:     bus.CreatedBy.String(),
-		CreatedDate:   bus.CreatedDate.Format(timeutil.FORMAT),
-		UpdatedDate:   bus.UpdatedDate.Format(timeutil.FORMAT),
-		CompletedDate: completedDate,
-		ScenarioID:    scenarioID,
+		ID:             bus.ID.String(),
+		Name:           bus.Name,
+		Status:         bus.Status.String(),
+		CreatedBy:      bus.CreatedBy.String(),
+		CreatedDate:    bus.CreatedDate.Format(timeutil.FORMAT),
+		UpdatedDate:    bus.UpdatedDate.Format(timeutil.FORMAT),
+		CompletedDate:  completedDate,
+		ScenarioID:     scenarioID,
+		Blind:          bus.Blind,
+		RecountUnits:   formatIntPtr(bus.Recount.Units),
+		RecountPercent: formatFloatPtr(bus.Recount.Percent),
+		RecountValue:   formatFloatPtr(bus.Recount.Value),
+		Summary:        summary,
+	}
+}
+
+func toAppSessionSummary(bus cyclecountsessionbus.VarianceSummary) SessionSummary {
+	return SessionSummary{
+		AdjustmentCount:    strconv.Itoa(bus.AdjustmentCount),
+		NetVarianceUnits:   strconv.Itoa(bus.NetVarianceUnits),
+		NetVarianceValue:   formatMoney(bus.NetVarianceValue),
+		GrossVarianceValue: formatMoney(bus.GrossVarianceValue),
 	}
 }
 
+func formatIntPtr(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func formatFloatPtr(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
+func formatMoney(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
 // ToAppCycleCountSessions converts a slice of bus models to app-layer response models.
 func ToAppCycleCountSessions(bus []cyclecountsessionbus.CycleCountSession) []CycleCountSession {
 	app := make([]CycleCountSession, len(bus))
@@ -81,8 +134,17 @@ func ToAppCycleCountSessions(bus []cyclecountsessionbus.CycleCountSession) []Cyc
 
 // NewCycleCountSession is the app-layer create request model.
 // CreatedBy is injected from the authenticated user — not accepted from the client.
+//
+// A blind session hides system quantities from counters until it completes.
+// A count whose variance exceeds any recount threshold (units, percent of the
+// system quantity, or value at current product cost) is recounted by a
+// different worker.
 type NewCycleCountSession struct {
-	Name string `json:"name" validate:"required"`
+	Name           string  `json:"name" validate:"required"`
+	Blind          bool    `json:"blind"`
+	RecountUnits   *string `json:"recountUnits"`
+	RecountPercent *string `json:"recountPercent"`
+	RecountValue   *string `json:"recountValue"`
 }
 
 func (app *NewCycleCountSession) Decode(data []byte) error {
@@ -96,11 +158,60 @@ func (app NewCycleCountSession) Validate() error {
 	return nil
 }
 
-func toBusNewCycleCountSession(app NewCycleCountSession, createdBy uuid.UUID) cyclecountsessionbus.NewCycleCountSession {
+func toBusNewCycleCountSession(app NewCycleCountSession, createdBy uuid.UUID) (cyclecountsessionbus.NewCycleCountSession, error) {
+	recount, err := parseRecount(cyclecountsessionbus.RecountThreshold{}, app.RecountUnits, app.RecountPercent, app.RecountValue)
+	if err != nil {
+		return cyclecountsessionbus.NewCycleCountSession{}, err
+	}
+
 	return cyclecountsessionbus.NewCycleCountSession{
 		Name:      app.Name,
 		CreatedBy: createdBy,
+		Blind:     app.Blind,
+		Recount:   recount,
+	}, nil
+}
+
+// parseRecount overlays the given thresholds onto base. A nil value keeps the
+// base tolerance; an empty string clears it.
+func parseRecount(base cyclecountsessionbus.RecountThreshold, units, percent, value *string) (cyclecountsessionbus.RecountThreshold, error) {
+	rt := base
+
+	if units != nil {
+		rt.Units = nil
+		if *units != "" {
+			n, err := strconv.Atoi(*units)
+			if err != nil || n < 0 {
+				return cyclecountsessionbus.RecountThreshold{}, fmt.Errorf("parse recountUnits: %q is not a non-negative integer", *units)
+			}
+			rt.Units = &n
+		}
+	}
+
+	parseFloat := func(field string, v *string, dst **float64) error {
+		if v == nil {
+			return nil
+		}
+		*dst = nil
+		if *v == "" {
+			return nil
+		}
+		f, err := strconv.ParseFloat(*v, 64)
+		if err != nil || f < 0 {
+			return fmt.Errorf("parse %s: %q is not a non-negative number", field, *v)
+		}
+		*dst = &f
+		return nil
+	}
+
+	if err := parseFloat("recountPercent", percent, &rt.Percent); err != nil {
+		return cyclecountsessionbus.RecountThreshold{}, err
 	}
+	if err := parseFloat("recountValue", value, &rt.Value); err != nil {
+		return cyclecountsessionbus.RecountThreshold{}, err
+	}
+
+	return rt, nil
 }
 
 // =============================================================================
@@ -108,9 +219,18 @@ func toBusNewCycleCountSession(app NewCycleCountSession, createdBy uuid.UUID) cy
 // =============================================================================
 
 // UpdateCycleCountSession is the app-layer update request model.
+// Recount thresholds sent as an empty string are cleared.
 type UpdateCycleCountSession struct {
-	Name   *string `json:"name" validate:"omitempty"`
-	Status *string `json:"status" validate:"omitempty"`
+	Name           *string `json:"name" validate:"omitempty"`
+	Status         *string `json:"status" validate:"omitempty"`
+	Blind          *bool   `json:"blind"`
+	RecountUnits   *string `json:"recountUnits"`
+	RecountPercent *string `json:"recountPercent"`
+	RecountValue   *string `json:"recountValue"`
+}
+
+func (app UpdateCycleCountSession) changesCountRules() bool {
+	return app.Blind != nil || app.RecountUnits != nil || app.RecountPercent != nil || app.RecountValue != nil
 }
 
 func (app *UpdateCycleCountSession) Decode(data []byte) error {
@@ -124,13 +244,25 @@ func (app UpdateCycleCountSession) Validate() error {
 	return nil
 }
 
-func toBusUpdateCycleCountSession(app UpdateCycleCountSession) (cyclecountsessionbus.UpdateCycleCountSession, error) {
+func toBusUpdateCycleCountSession(app UpdateCycleCountSession, current cyclecountsessionbus.RecountThreshold) (cyclecountsessionbus.UpdateCycleCountSession, error) {
 	bus := cyclecountsessionbus.UpdateCycleCountSession{}
 
 	if app.Name != nil {
 		bus.Name = app.Name
 	}
 
+	if app.Blind != nil {
+		bus.Blind = app.Blind
+	}
+
+	if app.RecountUnits != nil || app.RecountPercent != nil || app.RecountValue != nil {
+		rt, err := parseRecount(current, app.RecountUnits, app.RecountPercent, app.RecountValue)
+		if err != nil {
+			return cyclecountsessionbus.UpdateCycleCountSession{}, errs.New(errs.InvalidArgument, err)
+		}
+		bus.Recount = &rt
+	}
+
 	if app.Status != nil {
 		st, err := cyclecountsessionbus.ParseStatus(*app.Status)
 		if err != nil {
@@ -141,3 +273,59 @@ func toBusUpdateCycleCountSession(app UpdateCycleCountSession) (cyclecountsessio
 
 	return bus, nil
 }
+
+// =============================================================================
+// Summary model
+// =============================================================================
+
+// VarianceSummary reports a session's approved variances and their financial
+// impact. Posted is true once the session has completed and the variances
+// have been posted as inventory adjustments.
+type VarianceSummary struct {
+	SessionID string         `json:"sessionId"`
+	Status    string         `json:"status"`
+	Posted    bool           `json:"posted"`
+	Summary   SessionSummary `json:"summary"`
+	Lines     []VarianceLine `json:"lines"`
+}
+
+// VarianceLine is one approved variance valued at its product's current cost.
+type VarianceLine struct {
+	ItemID          string `json:"itemId"`
+	ProductID       string `json:"productId"`
+	LocationID      string `json:"locationId"`
+	SystemQuantity  string `json:"systemQuantity"`
+	CountedQuantity string `json:"countedQuantity"`
+	Variance        string `json:"variance"`
+	UnitCost        string `json:"unitCost"`
+	VarianceValue   string `json:"varianceValue"`
+}
+
+func (app VarianceSummary) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppVarianceSummary(session cyclecountsessionbus.CycleCountSession, summary cyclecountsessionbus.VarianceSummary, lines []cyclecountsessionbus.VarianceLine) VarianceSummary {
+	appLines := make([]VarianceLine, len(lines))
+	for i, l := range lines {
+		appLines[i] = VarianceLine{
+			ItemID:          l.ItemID.String(),
+			ProductID:       l.ProductID.String(),
+			LocationID:      l.LocationID.String(),
+			SystemQuantity:  strconv.Itoa(l.SystemQuantity),
+			CountedQuantity: strconv.Itoa(l.CountedQuantity),
+			Variance:        strconv.Itoa(l.Variance),
+			UnitCost:        formatMoney(l.UnitCost),
+			VarianceValue:   formatMoney(float64(l.Variance) * l.UnitCost),
+		}
+	}
+
+	return VarianceSummary{
+		SessionID: session.ID.String(),
+		Status:    session.Status.String(),
+		Posted:    session.Summary != nil,
+		Summary:   toAppSessionSummary(summary),
+		Lines:     appLines,
+	}
+}
@@ -0,0 +1,289 @@
+// Package demandforecastapp maintains the app layer api for demand forecasts:
+// the per-category policies that forecast item demand, forecast runs, the
+// review of recommended planning values and the forecast accuracy report.
+package demandforecastapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/app/sdk/mid"
+	"github.com/timmaaaz/ichor/app/sdk/query"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// App manages the set of app layer APIs for demand forecast access.
+type App struct {
+	demandForecastBus *demandforecastbus.Business
+}
+
+// NewApp constructs a demand forecast app.
+func NewApp(demandForecastBus *demandforecastbus.Business) *App {
+	return &App{
+		demandForecastBus: demandForecastBus,
+	}
+}
+
+// =============================================================================
+// Policies
+
+// CreatePolicy adds a new forecast policy to the system.
+func (a *App) CreatePolicy(ctx context.Context, app NewPolicy) (Policy, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Policy{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	np, err := toBusNewPolicy(app, userID)
+	if err != nil {
+		return Policy{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	policy, err := a.demandForecastBus.CreatePolicy(ctx, np)
+	if err != nil {
+		return Policy{}, toAppError("createpolicy", err)
+	}
+
+	return ToAppPolicy(policy), nil
+}
+
+// UpdatePolicy modifies an existing forecast policy.
+func (a *App) UpdatePolicy(ctx context.Context, policyID uuid.UUID, app UpdatePolicy) (Policy, error) {
+	up, err := toBusUpdatePolicy(app)
+	if err != nil {
+		return Policy{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	policy, err := a.queryPolicyByID(ctx, policyID)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	updated, err := a.demandForecastBus.UpdatePolicy(ctx, policy, up)
+	if err != nil {
+		return Policy{}, toAppError("updatepolicy", err)
+	}
+
+	return ToAppPolicy(updated), nil
+}
+
+// DeletePolicy removes a forecast policy from the system.
+func (a *App) DeletePolicy(ctx context.Context, policyID uuid.UUID) error {
+	policy, err := a.queryPolicyByID(ctx, policyID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.demandForecastBus.DeletePolicy(ctx, policy); err != nil {
+		return fmt.Errorf("deletepolicy: %w", err)
+	}
+
+	return nil
+}
+
+// QueryPolicies retrieves a list of forecast policies based on query
+// parameters.
+func (a *App) QueryPolicies(ctx context.Context, qp PolicyQueryParams) (query.Result[Policy], error) {
+	pg, err := page.Parse(qp.Page, qp.Rows)
+	if err != nil {
+		return query.Result[Policy]{}, errs.NewFieldsError("page", err)
+	}
+
+	filter, err := parsePolicyFilter(qp)
+	if err != nil {
+		return query.Result[Policy]{}, errs.NewFieldsError("filter", err)
+	}
+
+	orderBy, err := order.Parse(policyOrderByFields, qp.OrderBy, defaultPolicyOrderBy)
+	if err != nil {
+		return query.Result[Policy]{}, errs.NewFieldsError("orderBy", err)
+	}
+
+	policies, err := a.demandForecastBus.QueryPolicies(ctx, filter, orderBy, pg)
+	if err != nil {
+		return query.Result[Policy]{}, errs.Newf(errs.Internal, "querypolicies: %v", err)
+	}
+
+	total, err := a.demandForecastBus.CountPolicies(ctx, filter)
+	if err != nil {
+		return query.Result[Policy]{}, errs.Newf(errs.Internal, "countpolicies: %v", err)
+	}
+
+	return query.NewResult(ToAppPolicies(policies), total, pg), nil
+}
+
+// QueryPolicyByID retrieves a single forecast policy by ID.
+func (a *App) QueryPolicyByID(ctx context.Context, policyID uuid.UUID) (Policy, error) {
+	policy, err := a.queryPolicyByID(ctx, policyID)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	return ToAppPolicy(policy), nil
+}
+
+// =============================================================================
+// Forecasts
+
+// Run forecasts the selected inventory items now and records the
+// recommendations, applying them where the policy auto-applies.
+func (a *App) Run(ctx context.Context, app NewRun) (Forecasts, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return nil, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	req, err := toBusRunRequest(app, userID)
+	if err != nil {
+		return nil, errs.New(errs.InvalidArgument, err)
+	}
+
+	forecasts, err := a.demandForecastBus.Run(ctx, req, time.Now())
+	if err != nil {
+		return nil, toAppError("run", err)
+	}
+
+	return ToAppForecasts(forecasts), nil
+}
+
+// Approve writes a pending forecast's recommended values to its inventory
+// item.
+func (a *App) Approve(ctx context.Context, forecastID uuid.UUID) (Forecast, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Forecast{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	forecast, err := a.queryByID(ctx, forecastID)
+	if err != nil {
+		return Forecast{}, err
+	}
+
+	approved, err := a.demandForecastBus.Approve(ctx, forecast, userID, time.Now())
+	if err != nil {
+		return Forecast{}, toAppError("approve", err)
+	}
+
+	return ToAppForecast(approved), nil
+}
+
+// Reject closes a pending forecast without changing its inventory item.
+func (a *App) Reject(ctx context.Context, forecastID uuid.UUID) (Forecast, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Forecast{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	forecast, err := a.queryByID(ctx, forecastID)
+	if err != nil {
+		return Forecast{}, err
+	}
+
+	rejected, err := a.demandForecastBus.Reject(ctx, forecast, userID, time.Now())
+	if err != nil {
+		return Forecast{}, toAppError("reject", err)
+	}
+
+	return ToAppForecast(rejected), nil
+}
+
+// Query retrieves a list of forecasts based on query parameters.
+func (a *App) Query(ctx context.Context, qp QueryParams) (query.Result[Forecast], error) {
+	pg, err := page.Parse(qp.Page, qp.Rows)
+	if err != nil {
+		return query.Result[Forecast]{}, errs.NewFieldsError("page", err)
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return query.Result[Forecast]{}, errs.NewFieldsError("filter", err)
+	}
+
+	orderBy, err := order.Parse(orderByFields, qp.OrderBy, defaultOrderBy)
+	if err != nil {
+		return query.Result[Forecast]{}, errs.NewFieldsError("orderBy", err)
+	}
+
+	forecasts, err := a.demandForecastBus.Query(ctx, filter, orderBy, pg)
+	if err != nil {
+		return query.Result[Forecast]{}, errs.Newf(errs.Internal, "query: %v", err)
+	}
+
+	total, err := a.demandForecastBus.Count(ctx, filter)
+	if err != nil {
+		return query.Result[Forecast]{}, errs.Newf(errs.Internal, "count: %v", err)
+	}
+
+	return query.NewResult(ToAppForecasts(forecasts), total, pg), nil
+}
+
+// QueryByID retrieves a single forecast by ID.
+func (a *App) QueryByID(ctx context.Context, forecastID uuid.UUID) (Forecast, error) {
+	forecast, err := a.queryByID(ctx, forecastID)
+	if err != nil {
+		return Forecast{}, err
+	}
+
+	return ToAppForecast(forecast), nil
+}
+
+// QueryAccuracy scores past forecasts against the demand that followed them.
+func (a *App) QueryAccuracy(ctx context.Context, ap AccuracyParams) (AccuracyReport, error) {
+	filter, err := parseAccuracyFilter(ap)
+	if err != nil {
+		return AccuracyReport{}, errs.NewFieldsError("filter", err)
+	}
+
+	report, err := a.demandForecastBus.QueryAccuracy(ctx, filter, time.Now())
+	if err != nil {
+		return AccuracyReport{}, errs.Newf(errs.Internal, "queryaccuracy: %v", err)
+	}
+
+	return toAppAccuracyReport(report), nil
+}
+
+// =============================================================================
+
+func (a *App) queryPolicyByID(ctx context.Context, policyID uuid.UUID) (demandforecastbus.Policy, error) {
+	policy, err := a.demandForecastBus.QueryPolicyByID(ctx, policyID)
+	if err != nil {
+		if errors.Is(err, demandforecastbus.ErrPolicyNotFound) {
+			return demandforecastbus.Policy{}, errs.New(errs.NotFound, err)
+		}
+		return demandforecastbus.Policy{}, fmt.Errorf("querypolicybyid: %w", err)
+	}
+
+	return policy, nil
+}
+
+func (a *App) queryByID(ctx context.Context, forecastID uuid.UUID) (demandforecastbus.Forecast, error) {
+	forecast, err := a.demandForecastBus.QueryByID(ctx, forecastID)
+	if err != nil {
+		if errors.Is(err, demandforecastbus.ErrNotFound) {
+			return demandforecastbus.Forecast{}, errs.New(errs.NotFound, err)
+		}
+		return demandforecastbus.Forecast{}, fmt.Errorf("querybyid: %w", err)
+	}
+
+	return forecast, nil
+}
+
+func toAppError(op string, err error) error {
+	switch {
+	case errors.Is(err, demandforecastbus.ErrInvalidPolicy):
+		return errs.New(errs.InvalidArgument, err)
+	case errors.Is(err, demandforecastbus.ErrNothingToForecast):
+		return errs.New(errs.FailedPrecondition, err)
+	case errors.Is(err, demandforecastbus.ErrForecastClosed),
+		errors.Is(err, demandforecastbus.ErrUniqueEntry),
+		errors.Is(err, demandforecastbus.ErrForeignKeyViolation):
+		return errs.New(errs.Aborted, err)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
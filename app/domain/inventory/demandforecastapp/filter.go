@@ -0,0 +1,85 @@
+package demandforecastapp
+
+import (
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
+)
+
+func parseFilter(qp QueryParams) (demandforecastbus.QueryFilter, error) {
+	var filter demandforecastbus.QueryFilter
+
+	for _, f := range []struct {
+		in  string
+		out **uuid.UUID
+	}{
+		{qp.ID, &filter.ID},
+		{qp.InventoryItemID, &filter.InventoryItemID},
+		{qp.ProductID, &filter.ProductID},
+		{qp.LocationID, &filter.LocationID},
+		{qp.PolicyID, &filter.PolicyID},
+	} {
+		if f.in == "" {
+			continue
+		}
+		id, err := uuid.Parse(f.in)
+		if err != nil {
+			return demandforecastbus.QueryFilter{}, err
+		}
+		*f.out = &id
+	}
+
+	if qp.Method != "" {
+		method, err := demandforecastbus.ParseMethod(qp.Method)
+		if err != nil {
+			return demandforecastbus.QueryFilter{}, err
+		}
+		filter.Method = &method
+	}
+
+	if qp.Status != "" {
+		filter.Status = &qp.Status
+	}
+
+	return filter, nil
+}
+
+func parsePolicyFilter(qp PolicyQueryParams) (demandforecastbus.PolicyFilter, error) {
+	var filter demandforecastbus.PolicyFilter
+
+	for _, f := range []struct {
+		in  string
+		out **uuid.UUID
+	}{
+		{qp.ID, &filter.ID},
+		{qp.CategoryID, &filter.CategoryID},
+	} {
+		if f.in == "" {
+			continue
+		}
+		id, err := uuid.Parse(f.in)
+		if err != nil {
+			return demandforecastbus.PolicyFilter{}, err
+		}
+		*f.out = &id
+	}
+
+	if qp.Method != "" {
+		method, err := demandforecastbus.ParseMethod(qp.Method)
+		if err != nil {
+			return demandforecastbus.PolicyFilter{}, err
+		}
+		filter.Method = &method
+	}
+
+	if qp.AutoApply != "" {
+		autoApply, err := strconv.ParseBool(qp.AutoApply)
+		if err != nil {
+			return demandforecastbus.PolicyFilter{}, err
+		}
+		filter.AutoApply = &autoApply
+	}
+
+	return filter, nil
+}
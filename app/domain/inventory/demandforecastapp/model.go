@@ -0,0 +1,526 @@
+package demandforecastapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
+	"github.com/timmaaaz/ichor/foundation/timeutil"
+)
+
+// QueryParams holds the raw query parameters for listing forecasts.
+type QueryParams struct {
+	Page            string
+	Rows            string
+	OrderBy         string
+	ID              string
+	InventoryItemID string
+	ProductID       string
+	LocationID      string
+	PolicyID        string
+	Method          string
+	Status          string
+}
+
+// PolicyQueryParams holds the raw query parameters for listing policies.
+type PolicyQueryParams struct {
+	Page       string
+	Rows       string
+	OrderBy    string
+	ID         string
+	CategoryID string
+	Method     string
+	AutoApply  string
+}
+
+// AccuracyParams holds the raw query parameters of the accuracy report.
+type AccuracyParams struct {
+	From        string
+	CategoryID  string
+	HorizonDays string
+}
+
+// =============================================================================
+// Policy response model
+// =============================================================================
+
+// Policy is the app-layer response model for a forecast policy.
+type Policy struct {
+	ID               string `json:"id"`
+	CategoryID       string `json:"category_id"`
+	Method           string `json:"method"`
+	HistoryDays      string `json:"history_days"`
+	WindowDays       string `json:"window_days"`
+	SmoothingAlpha   string `json:"smoothing_alpha"`
+	SeasonLengthDays string `json:"season_length_days"`
+	ServiceLevel     string `json:"service_level"`
+	LeadTimeDays     string `json:"lead_time_days"`
+	OrderingCost     string `json:"ordering_cost"`
+	HoldingCostRate  string `json:"holding_cost_rate"`
+	AutoApply        bool   `json:"auto_apply"`
+	CreatedBy        string `json:"created_by"`
+	CreatedDate      string `json:"created_date"`
+	UpdatedDate      string `json:"updated_date"`
+}
+
+// Encode implements the encoder interface.
+func (app Policy) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// ToAppPolicy converts a bus model to an app-layer response model.
+func ToAppPolicy(bus demandforecastbus.Policy) Policy {
+	return Policy{
+		ID:               bus.ID.String(),
+		CategoryID:       optionalID(bus.CategoryID),
+		Method:           bus.Method.String(),
+		HistoryDays:      strconv.Itoa(bus.HistoryDays),
+		WindowDays:       strconv.Itoa(bus.WindowDays),
+		SmoothingAlpha:   strconv.FormatFloat(bus.SmoothingAlpha, 'f', -1, 64),
+		SeasonLengthDays: strconv.Itoa(bus.SeasonLengthDays),
+		ServiceLevel:     strconv.FormatFloat(bus.ServiceLevel, 'f', -1, 64),
+		LeadTimeDays:     strconv.Itoa(bus.LeadTimeDays),
+		OrderingCost:     strconv.FormatFloat(bus.OrderingCost, 'f', 2, 64),
+		HoldingCostRate:  strconv.FormatFloat(bus.HoldingCostRate, 'f', -1, 64),
+		AutoApply:        bus.AutoApply,
+		CreatedBy:        bus.CreatedBy.String(),
+		CreatedDate:      bus.CreatedDate.Format(timeutil.FORMAT),
+		UpdatedDate:      bus.UpdatedDate.Format(timeutil.FORMAT),
+	}
+}
+
+// ToAppPolicies converts a slice of bus models to app-layer response models.
+func ToAppPolicies(bus []demandforecastbus.Policy) []Policy {
+	app := make([]Policy, len(bus))
+	for i, v := range bus {
+		app[i] = ToAppPolicy(v)
+	}
+	return app
+}
+
+// =============================================================================
+// Policy create model
+// =============================================================================
+
+// NewPolicy is the app-layer create request model for a forecast policy. An
+// empty CategoryID makes it the default policy. Settings left empty take the
+// business defaults. CreatedBy is injected from the authenticated user — not
+// accepted from the client.
+type NewPolicy struct {
+	CategoryID       string `json:"category_id" validate:"omitempty,min=36,max=36"`
+	Method           string `json:"method" validate:"required,oneof=moving_average exponential_smoothing seasonal_naive"`
+	HistoryDays      string `json:"history_days" validate:"omitempty,number"`
+	WindowDays       string `json:"window_days" validate:"omitempty,number"`
+	SmoothingAlpha   string `json:"smoothing_alpha" validate:"omitempty,numeric"`
+	SeasonLengthDays string `json:"season_length_days" validate:"omitempty,number"`
+	ServiceLevel     string `json:"service_level" validate:"omitempty,numeric"`
+	LeadTimeDays     string `json:"lead_time_days" validate:"omitempty,number"`
+	OrderingCost     string `json:"ordering_cost" validate:"omitempty,numeric"`
+	HoldingCostRate  string `json:"holding_cost_rate" validate:"omitempty,numeric"`
+	AutoApply        bool   `json:"auto_apply"`
+}
+
+// Decode implements the decoder interface.
+func (app *NewPolicy) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewPolicy) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusNewPolicy(app NewPolicy, createdBy uuid.UUID) (demandforecastbus.NewPolicy, error) {
+	method, err := demandforecastbus.ParseMethod(app.Method)
+	if err != nil {
+		return demandforecastbus.NewPolicy{}, fmt.Errorf("parse method: %w", err)
+	}
+
+	bus := demandforecastbus.NewPolicy{
+		Method:    method,
+		AutoApply: app.AutoApply,
+		CreatedBy: createdBy,
+	}
+
+	if bus.CategoryID, err = parseOptionalUUID(app.CategoryID); err != nil {
+		return demandforecastbus.NewPolicy{}, fmt.Errorf("parse category_id: %w", err)
+	}
+
+	for _, f := range []struct {
+		name string
+		in   string
+		out  *int
+	}{
+		{"history_days", app.HistoryDays, &bus.HistoryDays},
+		{"window_days", app.WindowDays, &bus.WindowDays},
+		{"season_length_days", app.SeasonLengthDays, &bus.SeasonLengthDays},
+		{"lead_time_days", app.LeadTimeDays, &bus.LeadTimeDays},
+	} {
+		if f.in == "" {
+			continue
+		}
+		if *f.out, err = strconv.Atoi(f.in); err != nil {
+			return demandforecastbus.NewPolicy{}, fmt.Errorf("parse %s: %w", f.name, err)
+		}
+	}
+
+	for _, f := range []struct {
+		name string
+		in   string
+		out  *float64
+	}{
+		{"smoothing_alpha", app.SmoothingAlpha, &bus.SmoothingAlpha},
+		{"service_level", app.ServiceLevel, &bus.ServiceLevel},
+		{"ordering_cost", app.OrderingCost, &bus.OrderingCost},
+		{"holding_cost_rate", app.HoldingCostRate, &bus.HoldingCostRate},
+	} {
+		if f.in == "" {
+			continue
+		}
+		if *f.out, err = strconv.ParseFloat(f.in, 64); err != nil {
+			return demandforecastbus.NewPolicy{}, fmt.Errorf("parse %s: %w", f.name, err)
+		}
+	}
+
+	return bus, nil
+}
+
+// =============================================================================
+// Policy update model
+// =============================================================================
+
+// UpdatePolicy is the app-layer update request model for a forecast policy.
+// A policy's category cannot change.
+type UpdatePolicy struct {
+	Method           *string `json:"method" validate:"omitempty,oneof=moving_average exponential_smoothing seasonal_naive"`
+	HistoryDays      *string `json:"history_days" validate:"omitempty,number"`
+	WindowDays       *string `json:"window_days" validate:"omitempty,number"`
+	SmoothingAlpha   *string `json:"smoothing_alpha" validate:"omitempty,numeric"`
+	SeasonLengthDays *string `json:"season_length_days" validate:"omitempty,number"`
+	ServiceLevel     *string `json:"service_level" validate:"omitempty,numeric"`
+	LeadTimeDays     *string `json:"lead_time_days" validate:"omitempty,number"`
+	OrderingCost     *string `json:"ordering_cost" validate:"omitempty,numeric"`
+	HoldingCostRate  *string `json:"holding_cost_rate" validate:"omitempty,numeric"`
+	AutoApply        *bool   `json:"auto_apply"`
+}
+
+// Decode implements the decoder interface.
+func (app *UpdatePolicy) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app UpdatePolicy) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusUpdatePolicy(app UpdatePolicy) (demandforecastbus.UpdatePolicy, error) {
+	bus := demandforecastbus.UpdatePolicy{
+		AutoApply: app.AutoApply,
+	}
+
+	if app.Method != nil {
+		method, err := demandforecastbus.ParseMethod(*app.Method)
+		if err != nil {
+			return demandforecastbus.UpdatePolicy{}, fmt.Errorf("parse method: %w", err)
+		}
+		bus.Method = &method
+	}
+
+	for _, f := range []struct {
+		name string
+		in   *string
+		out  **int
+	}{
+		{"history_days", app.HistoryDays, &bus.HistoryDays},
+		{"window_days", app.WindowDays, &bus.WindowDays},
+		{"season_length_days", app.SeasonLengthDays, &bus.SeasonLengthDays},
+		{"lead_time_days", app.LeadTimeDays, &bus.LeadTimeDays},
+	} {
+		if f.in == nil {
+			continue
+		}
+		n, err := strconv.Atoi(*f.in)
+		if err != nil {
+			return demandforecastbus.UpdatePolicy{}, fmt.Errorf("parse %s: %w", f.name, err)
+		}
+		*f.out = &n
+	}
+
+	for _, f := range []struct {
+		name string
+		in   *string
+		out  **float64
+	}{
+		{"smoothing_alpha", app.SmoothingAlpha, &bus.SmoothingAlpha},
+		{"service_level", app.ServiceLevel, &bus.ServiceLevel},
+		{"ordering_cost", app.OrderingCost, &bus.OrderingCost},
+		{"holding_cost_rate", app.HoldingCostRate, &bus.HoldingCostRate},
+	} {
+		if f.in == nil {
+			continue
+		}
+		v, err := strconv.ParseFloat(*f.in, 64)
+		if err != nil {
+			return demandforecastbus.UpdatePolicy{}, fmt.Errorf("parse %s: %w", f.name, err)
+		}
+		*f.out = &v
+	}
+
+	return bus, nil
+}
+
+// =============================================================================
+// Forecast response model
+// =============================================================================
+
+// Forecast is the app-layer response model for a demand forecast.
+type Forecast struct {
+	ID                       string `json:"id"`
+	InventoryItemID          string `json:"inventory_item_id"`
+	ProductID                string `json:"product_id"`
+	LocationID               string `json:"location_id"`
+	PolicyID                 string `json:"policy_id"`
+	Method                   string `json:"method"`
+	HistoryDays              string `json:"history_days"`
+	ForecastDailyUsage       string `json:"forecast_daily_usage"`
+	DemandStdDev             string `json:"demand_std_dev"`
+	LeadTimeDays             string `json:"lead_time_days"`
+	CurrentAvgDailyUsage     string `json:"current_avg_daily_usage"`
+	CurrentSafetyStock       string `json:"current_safety_stock"`
+	CurrentReorderPoint      string `json:"current_reorder_point"`
+	CurrentEOQ               string `json:"current_eoq"`
+	RecommendedAvgDailyUsage string `json:"recommended_avg_daily_usage"`
+	RecommendedSafetyStock   string `json:"recommended_safety_stock"`
+	RecommendedReorderPoint  string `json:"recommended_reorder_point"`
+	RecommendedEOQ           string `json:"recommended_eoq"`
+	Status                   string `json:"status"`
+	ReviewedBy               string `json:"reviewed_by"`
+	ReviewedDate             string `json:"reviewed_date"`
+	CreatedBy                string `json:"created_by"`
+	CreatedDate              string `json:"created_date"`
+	UpdatedDate              string `json:"updated_date"`
+}
+
+// Encode implements the encoder interface.
+func (app Forecast) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// ToAppForecast converts a bus model to an app-layer response model.
+func ToAppForecast(bus demandforecastbus.Forecast) Forecast {
+	return Forecast{
+		ID:                       bus.ID.String(),
+		InventoryItemID:          bus.InventoryItemID.String(),
+		ProductID:                bus.ProductID.String(),
+		LocationID:               bus.LocationID.String(),
+		PolicyID:                 optionalID(bus.PolicyID),
+		Method:                   bus.Method.String(),
+		HistoryDays:              strconv.Itoa(bus.HistoryDays),
+		ForecastDailyUsage:       strconv.FormatFloat(bus.ForecastDailyUsage, 'f', 4, 64),
+		DemandStdDev:             strconv.FormatFloat(bus.DemandStdDev, 'f', 4, 64),
+		LeadTimeDays:             strconv.Itoa(bus.LeadTimeDays),
+		CurrentAvgDailyUsage:     strconv.Itoa(bus.CurrentAvgDailyUsage),
+		CurrentSafetyStock:       strconv.Itoa(bus.CurrentSafetyStock),
+		CurrentReorderPoint:      strconv.Itoa(bus.CurrentReorderPoint),
+		CurrentEOQ:               strconv.Itoa(bus.CurrentEOQ),
+		RecommendedAvgDailyUsage: strconv.Itoa(bus.RecommendedAvgDailyUsage),
+		RecommendedSafetyStock:   strconv.Itoa(bus.RecommendedSafetyStock),
+		RecommendedReorderPoint:  strconv.Itoa(bus.RecommendedReorderPoint),
+		RecommendedEOQ:           strconv.Itoa(bus.RecommendedEOQ),
+		Status:                   bus.Status,
+		ReviewedBy:               optionalID(bus.ReviewedBy),
+		ReviewedDate:             optionalTime(bus.ReviewedDate),
+		CreatedBy:                bus.CreatedBy.String(),
+		CreatedDate:              bus.CreatedDate.Format(timeutil.FORMAT),
+		UpdatedDate:              bus.UpdatedDate.Format(timeutil.FORMAT),
+	}
+}
+
+// Forecasts is a slice wrapper so it implements web.Encoder directly.
+type Forecasts []Forecast
+
+// Encode implements the encoder interface.
+func (app Forecasts) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// ToAppForecasts converts a slice of bus models to app-layer response models.
+func ToAppForecasts(bus []demandforecastbus.Forecast) Forecasts {
+	app := make(Forecasts, len(bus))
+	for i, v := range bus {
+		app[i] = ToAppForecast(v)
+	}
+	return app
+}
+
+// =============================================================================
+// Run request model
+// =============================================================================
+
+// NewRun is the app-layer request to forecast inventory items now.
+// CategoryID, ProductID and LocationID narrow the items forecast.
+type NewRun struct {
+	CategoryID string `json:"category_id" validate:"omitempty,min=36,max=36"`
+	ProductID  string `json:"product_id" validate:"omitempty,min=36,max=36"`
+	LocationID string `json:"location_id" validate:"omitempty,min=36,max=36"`
+}
+
+// Decode implements the decoder interface.
+func (app *NewRun) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewRun) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusRunRequest(app NewRun, createdBy uuid.UUID) (demandforecastbus.RunRequest, error) {
+	req := demandforecastbus.RunRequest{
+		CreatedBy: createdBy,
+	}
+
+	var err error
+	if req.CategoryID, err = parseOptionalUUID(app.CategoryID); err != nil {
+		return demandforecastbus.RunRequest{}, fmt.Errorf("parse category_id: %w", err)
+	}
+	if req.ProductID, err = parseOptionalUUID(app.ProductID); err != nil {
+		return demandforecastbus.RunRequest{}, fmt.Errorf("parse product_id: %w", err)
+	}
+	if req.LocationID, err = parseOptionalUUID(app.LocationID); err != nil {
+		return demandforecastbus.RunRequest{}, fmt.Errorf("parse location_id: %w", err)
+	}
+
+	return req, nil
+}
+
+// =============================================================================
+// Accuracy report model
+// =============================================================================
+
+// AccuracyRow scores the forecasts of one category and method. WAPE is empty
+// when there was no demand to compare against.
+type AccuracyRow struct {
+	CategoryID string `json:"category_id,omitempty"`
+	Method     string `json:"method,omitempty"`
+	Count      int    `json:"count"`
+	MAE        string `json:"mae"`
+	Bias       string `json:"bias"`
+	WAPE       string `json:"wape"`
+}
+
+// AccuracyReport scores past forecasts against the demand that followed them.
+type AccuracyReport struct {
+	HorizonDays int           `json:"horizon_days"`
+	Rows        []AccuracyRow `json:"rows"`
+	Overall     AccuracyRow   `json:"overall"`
+}
+
+// Encode implements the encoder interface.
+func (app AccuracyReport) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppAccuracyRow(bus demandforecastbus.AccuracyRow) AccuracyRow {
+	wape := ""
+	if bus.WAPE != nil {
+		wape = strconv.FormatFloat(*bus.WAPE, 'f', 4, 64)
+	}
+
+	return AccuracyRow{
+		CategoryID: optionalID(bus.CategoryID),
+		Method:     bus.Method,
+		Count:      bus.Count,
+		MAE:        strconv.FormatFloat(bus.MAE, 'f', 4, 64),
+		Bias:       strconv.FormatFloat(bus.Bias, 'f', 4, 64),
+		WAPE:       wape,
+	}
+}
+
+func toAppAccuracyReport(bus demandforecastbus.AccuracyReport) AccuracyReport {
+	rows := make([]AccuracyRow, len(bus.Rows))
+	for i, r := range bus.Rows {
+		rows[i] = toAppAccuracyRow(r)
+	}
+
+	return AccuracyReport{
+		HorizonDays: bus.HorizonDays,
+		Rows:        rows,
+		Overall:     toAppAccuracyRow(bus.Overall),
+	}
+}
+
+func parseAccuracyFilter(ap AccuracyParams) (demandforecastbus.AccuracyFilter, error) {
+	var filter demandforecastbus.AccuracyFilter
+
+	if ap.From != "" {
+		t, err := time.Parse(timeutil.FORMAT, ap.From)
+		if err != nil {
+			return demandforecastbus.AccuracyFilter{}, fmt.Errorf("parse from: %w", err)
+		}
+		filter.From = &t
+	}
+
+	var err error
+	if filter.CategoryID, err = parseOptionalUUID(ap.CategoryID); err != nil {
+		return demandforecastbus.AccuracyFilter{}, fmt.Errorf("parse category_id: %w", err)
+	}
+
+	if ap.HorizonDays != "" {
+		n, err := strconv.Atoi(ap.HorizonDays)
+		if err != nil {
+			return demandforecastbus.AccuracyFilter{}, fmt.Errorf("parse horizon_days: %w", err)
+		}
+		if n <= 0 {
+			return demandforecastbus.AccuracyFilter{}, fmt.Errorf("horizon_days must be positive")
+		}
+		filter.HorizonDays = n
+	}
+
+	return filter, nil
+}
+
+// =============================================================================
+
+func parseOptionalUUID(s string) (*uuid.UUID, error) {
+	if s == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func optionalID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func optionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(timeutil.FORMAT)
+}
@@ -0,0 +1,28 @@
+package demandforecastapp
+
+import (
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
+)
+
+var defaultOrderBy = demandforecastbus.DefaultOrderBy
+
+var orderByFields = map[string]string{
+	demandforecastbus.OrderByID:              demandforecastbus.OrderByID,
+	demandforecastbus.OrderByInventoryItemID: demandforecastbus.OrderByInventoryItemID,
+	demandforecastbus.OrderByProductID:       demandforecastbus.OrderByProductID,
+	demandforecastbus.OrderByLocationID:      demandforecastbus.OrderByLocationID,
+	demandforecastbus.OrderByMethod:          demandforecastbus.OrderByMethod,
+	demandforecastbus.OrderByStatus:          demandforecastbus.OrderByStatus,
+	demandforecastbus.OrderByCreatedDate:     demandforecastbus.OrderByCreatedDate,
+	demandforecastbus.OrderByUpdatedDate:     demandforecastbus.OrderByUpdatedDate,
+}
+
+var defaultPolicyOrderBy = demandforecastbus.DefaultPolicyOrderBy
+
+var policyOrderByFields = map[string]string{
+	demandforecastbus.OrderByID:          demandforecastbus.OrderByID,
+	demandforecastbus.OrderByCategoryID:  demandforecastbus.OrderByCategoryID,
+	demandforecastbus.OrderByMethod:      demandforecastbus.OrderByMethod,
+	demandforecastbus.OrderByCreatedDate: demandforecastbus.OrderByCreatedDate,
+	demandforecastbus.OrderByUpdatedDate: demandforecastbus.OrderByUpdatedDate,
+}
@@ -94,6 +94,9 @@ func (a *App) Create(ctx context.Context, app NewInventoryAdjustment) (Inventory
 
 	adjustment, err := a.inventoryadjustmentbus.Create(ctx, newAdjustment)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return InventoryAdjustment{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, inventoryadjustmentbus.ErrUniqueEntry) {
 			return InventoryAdjustment{}, errs.New(errs.AlreadyExists, err)
 		}
@@ -126,6 +129,9 @@ func (a *App) Update(ctx context.Context, id uuid.UUID, app UpdateInventoryAdjus
 
 	adjustment, err = a.inventoryadjustmentbus.Update(ctx, adjustment, updateAdjustment)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return InventoryAdjustment{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, inventoryadjustmentbus.ErrUniqueEntry) {
 			return InventoryAdjustment{}, errs.New(errs.AlreadyExists, err)
 		}
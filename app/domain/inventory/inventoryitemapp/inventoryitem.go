@@ -58,6 +58,9 @@ func (a *App) Create(ctx context.Context, app NewInventoryItem) (InventoryItem,
 
 	item, err := a.inventoryitembus.Create(ctx, newItem)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return InventoryItem{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, inventoryitembus.ErrUniqueEntry) {
 			return InventoryItem{}, errs.New(errs.AlreadyExists, err)
 		}
@@ -87,6 +90,9 @@ func (a *App) Update(ctx context.Context, app UpdateInventoryItem, id uuid.UUID)
 
 	inventoryItem, err := a.inventoryitembus.Update(ctx, item, uii)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return InventoryItem{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, inventoryitembus.ErrForeignKeyViolation) {
 			return InventoryItem{}, errs.New(errs.Aborted, err)
 		}
@@ -80,6 +80,9 @@ func (a *App) Create(ctx context.Context, app NewInventoryLocation) (InventoryLo
 
 	il, err := a.inventorylocationbus.Create(ctx, nl)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return InventoryLocation{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, inventorylocationbus.ErrForeignKeyViolation) {
 			return InventoryLocation{}, errs.New(errs.Aborted, err)
 		}
@@ -108,6 +111,9 @@ func (a *App) Update(ctx context.Context, app UpdateInventoryLocation, id uuid.U
 
 	il, err = a.inventorylocationbus.Update(ctx, il, ul)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return InventoryLocation{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, inventorylocationbus.ErrForeignKeyViolation) {
 			return InventoryLocation{}, errs.New(errs.Aborted, err)
 		}
@@ -55,6 +55,9 @@ func (a *App) Create(ctx context.Context, app NewLotLocation) (LotLocation, erro
 
 	ll, err := a.lotlocationbus.Create(ctx, nll)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return LotLocation{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, lotlocationbus.ErrUniqueEntry) {
 			return LotLocation{}, errs.New(errs.AlreadyExists, err)
 		}
@@ -83,6 +86,9 @@ func (a *App) Update(ctx context.Context, app UpdateLotLocation, id uuid.UUID) (
 
 	lotLocation, err := a.lotlocationbus.Update(ctx, ll, ull)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return LotLocation{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, lotlocationbus.ErrUniqueEntry) {
 			return LotLocation{}, errs.New(errs.AlreadyExists, err)
 		}
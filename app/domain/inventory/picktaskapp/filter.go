@@ -64,6 +64,14 @@ func parseFilter(qp QueryParams) (picktaskbus.QueryFilter, error) {
 		filter.AssignedTo = &id
 	}
 
+	if qp.BatchID != "" {
+		id, err := uuid.Parse(qp.BatchID)
+		if err != nil {
+			return picktaskbus.QueryFilter{}, err
+		}
+		filter.BatchID = &id
+	}
+
 	if qp.CreatedBy != "" {
 		id, err := uuid.Parse(qp.CreatedBy)
 		if err != nil {
@@ -23,6 +23,7 @@ type QueryParams struct {
 	LocationID           string
 	Status               string
 	AssignedTo           string
+	BatchID              string
 	CreatedBy            string
 }
 
@@ -48,6 +49,9 @@ type PickTask struct {
 	CompletedBy          string `json:"completed_by"`
 	CompletedAt          string `json:"completed_at"`
 	ShortPickReason      string `json:"short_pick_reason"`
+	BatchID              string `json:"batch_id"`
+	BatchSequence        string `json:"batch_sequence"`
+	ToteNumber           string `json:"tote_number"`
 	CreatedBy            string `json:"created_by"`
 	CreatedDate          string `json:"created_date"`
 	UpdatedDate          string `json:"updated_date"`
@@ -96,6 +100,21 @@ func ToAppPickTask(bus picktaskbus.PickTask) PickTask {
 		completedAt = bus.CompletedAt.Format(timeutil.FORMAT)
 	}
 
+	batchID := ""
+	if bus.BatchID != nil {
+		batchID = bus.BatchID.String()
+	}
+
+	batchSequence := ""
+	if bus.BatchSequence != nil {
+		batchSequence = strconv.Itoa(*bus.BatchSequence)
+	}
+
+	toteNumber := ""
+	if bus.ToteNumber != nil {
+		toteNumber = strconv.Itoa(*bus.ToteNumber)
+	}
+
 	scenarioID := ""
 	if bus.ScenarioID != nil {
 		scenarioID = bus.ScenarioID.String()
@@ -118,6 +137,9 @@ func ToAppPickTask(bus picktaskbus.PickTask) PickTask {
 		CompletedBy:          completedBy,
 		CompletedAt:          completedAt,
 		ShortPickReason:      bus.ShortPickReason,
+		BatchID:              batchID,
+		BatchSequence:        batchSequence,
+		ToteNumber:           toteNumber,
 		CreatedBy:            bus.CreatedBy.String(),
 		CreatedDate:          bus.CreatedDate.Format(timeutil.FORMAT),
 		UpdatedDate:          bus.UpdatedDate.Format(timeutil.FORMAT),
@@ -238,6 +260,11 @@ type UpdatePickTask struct {
 	QuantityPicked  *string `json:"quantity_picked" validate:"omitempty"`
 	Status          *string `json:"status" validate:"omitempty"`
 	ShortPickReason *string `json:"short_pick_reason" validate:"omitempty"`
+
+	// StagingLocationID is only read when completing or short-picking a task;
+	// the picked units are put at that outbound staging location to await
+	// shipment.
+	StagingLocationID *string `json:"staging_location_id" validate:"omitempty,min=36,max=36"`
 }
 
 func (app *UpdatePickTask) Decode(data []byte) error {
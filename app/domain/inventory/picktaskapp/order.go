@@ -18,4 +18,5 @@ var orderByFields = map[string]string{
 	picktaskbus.OrderByCreatedBy:      "created_by",
 	picktaskbus.OrderByCreatedDate:    "created_date",
 	picktaskbus.OrderByUpdatedDate:    "updated_date",
+	picktaskbus.OrderByBatchSequence:  "batch_sequence",
 }
@@ -57,6 +57,9 @@ func (a *App) Create(ctx context.Context, app NewPickTask) (PickTask, error) {
 
 	task, err := a.pickTaskBus.Create(ctx, npt)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return PickTask{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, picktaskbus.ErrForeignKeyViolation) {
 			return PickTask{}, errs.New(errs.Aborted, err)
 		}
@@ -70,8 +73,9 @@ func (a *App) Create(ctx context.Context, app NewPickTask) (PickTask, error) {
 //
 // Status transitions have special handling:
 //   - → in_progress: auto-sets assigned_to from the authenticated user + assigned_at = now
-//   - → completed:   atomic 3-way write (task update + PICK transaction + inventory decrement)
-//   - → short_picked: same atomic 3-way write as completed, with partial quantity
+//   - → completed:   atomic write of task update + PICK transaction + inventory decrement,
+//     plus STAGE transaction + staging increment when staging_location_id is set
+//   - → short_picked: same atomic write as completed, with partial quantity
 //   - → cancelled:   plain update, no side effects
 func (a *App) Update(ctx context.Context, taskID uuid.UUID, app UpdatePickTask) (PickTask, error) {
 	upt, err := toBusUpdatePickTask(app)
@@ -122,13 +126,23 @@ func (a *App) Update(ctx context.Context, taskID uuid.UUID, app UpdatePickTask)
 					return PickTask{}, errs.Newf(errs.InvalidArgument, "short_pick_reason is required when status is short_picked")
 				}
 			}
-			return a.complete(ctx, task, upt)
+			stagingLocationID := uuid.Nil
+			if app.StagingLocationID != nil {
+				stagingLocationID, err = uuid.Parse(*app.StagingLocationID)
+				if err != nil {
+					return PickTask{}, errs.Newf(errs.InvalidArgument, "parse stagingLocationID: %s", err)
+				}
+			}
+			return a.complete(ctx, task, upt, stagingLocationID)
 		}
 	}
 
 	// Plain update (pending field edits, cancel, claim).
 	updated, err := a.pickTaskBus.Update(ctx, task, upt)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return PickTask{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, picktaskbus.ErrForeignKeyViolation) {
 			return PickTask{}, errs.New(errs.Aborted, err)
 		}
@@ -138,13 +152,15 @@ func (a *App) Update(ctx context.Context, taskID uuid.UUID, app UpdatePickTask)
 	return ToAppPickTask(updated), nil
 }
 
-// complete handles the atomic 3-way write when a task is completed or short-picked:
+// complete handles the atomic write when a task is completed or short-picked:
 //  1. Update pick task (status, completed_by, completed_at, quantity_picked)
 //  2. Create PICK inventory transaction (ledger entry)
-//  3. Decrement inventory_item quantity at the source location
+//  3. Decrement inventory_item quantity and allocation at the source location
+//  4. When stagingLocationID is set, stage the picked units there and create
+//     the matching STAGE inventory transaction
 //
-// All three writes are wrapped in a single DB transaction.
-func (a *App) complete(ctx context.Context, task picktaskbus.PickTask, upt picktaskbus.UpdatePickTask) (PickTask, error) {
+// All writes are wrapped in a single DB transaction.
+func (a *App) complete(ctx context.Context, task picktaskbus.PickTask, upt picktaskbus.UpdatePickTask, stagingLocationID uuid.UUID) (PickTask, error) {
 	userID, err := mid.GetUserID(ctx)
 	if err != nil {
 		return PickTask{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
@@ -203,16 +219,40 @@ func (a *App) complete(ctx context.Context, task picktaskbus.PickTask, upt pickt
 		return PickTask{}, fmt.Errorf("create inventory transaction: %w", err)
 	}
 
-	// 3. Decrement inventory_item quantity at the source location.
+	// 3. Decrement inventory_item quantity and allocation at the source location.
 	itemBusTx, err := a.invItemBus.NewWithTx(tx)
 	if err != nil {
 		return PickTask{}, fmt.Errorf("new invitem tx: %w", err)
 	}
 
-	if err := itemBusTx.DecrementQuantity(ctx, task.ProductID, task.LocationID, quantityPicked); err != nil {
+	if err := itemBusTx.PickQuantity(ctx, task.ProductID, task.LocationID, quantityPicked); err != nil {
 		return PickTask{}, fmt.Errorf("decrement inventory quantity: %w", err)
 	}
 
+	// 4. Stage the picked units for shipment.
+	if stagingLocationID != uuid.Nil {
+		if err := itemBusTx.StageQuantity(ctx, task.ProductID, stagingLocationID, quantityPicked); err != nil {
+			if errors.Is(err, inventoryitembus.ErrNotStagingLocation) {
+				return PickTask{}, errs.New(errs.InvalidArgument, err)
+			}
+			return PickTask{}, fmt.Errorf("stage picked stock: %w", err)
+		}
+
+		_, err = txBusTx.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
+			ProductID:       task.ProductID,
+			LocationID:      stagingLocationID,
+			UserID:          userID,
+			LotID:           task.LotID,
+			Quantity:        quantityPicked,
+			TransactionType: "STAGE",
+			ReferenceNumber: task.SalesOrderID.String(),
+			TransactionDate: now,
+		})
+		if err != nil {
+			return PickTask{}, fmt.Errorf("create stage inventory transaction: %w", err)
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return PickTask{}, fmt.Errorf("commit transaction: %w", err)
 	}
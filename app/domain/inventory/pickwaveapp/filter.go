@@ -0,0 +1,61 @@
+package pickwaveapp
+
+import (
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/pickwavebus"
+)
+
+func parseFilter(qp QueryParams) (pickwavebus.QueryFilter, error) {
+	var filter pickwavebus.QueryFilter
+
+	if qp.ID != "" {
+		id, err := uuid.Parse(qp.ID)
+		if err != nil {
+			return pickwavebus.QueryFilter{}, err
+		}
+		filter.ID = &id
+	}
+
+	if qp.Status != "" {
+		filter.Status = &qp.Status
+	}
+
+	if qp.WarehouseID != "" {
+		id, err := uuid.Parse(qp.WarehouseID)
+		if err != nil {
+			return pickwavebus.QueryFilter{}, err
+		}
+		filter.WarehouseID = &id
+	}
+
+	return filter, nil
+}
+
+func parseBatchFilter(qp BatchQueryParams) (pickwavebus.BatchFilter, error) {
+	var filter pickwavebus.BatchFilter
+
+	for _, f := range []struct {
+		in  string
+		out **uuid.UUID
+	}{
+		{qp.ID, &filter.ID},
+		{qp.WaveID, &filter.WaveID},
+		{qp.ZoneID, &filter.ZoneID},
+		{qp.AssignedTo, &filter.AssignedTo},
+	} {
+		if f.in == "" {
+			continue
+		}
+		id, err := uuid.Parse(f.in)
+		if err != nil {
+			return pickwavebus.BatchFilter{}, err
+		}
+		*f.out = &id
+	}
+
+	if qp.Status != "" {
+		filter.Status = &qp.Status
+	}
+
+	return filter, nil
+}
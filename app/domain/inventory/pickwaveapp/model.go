@@ -0,0 +1,344 @@
+package pickwaveapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/inventory/picktaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/pickwavebus"
+	"github.com/timmaaaz/ichor/foundation/timeutil"
+)
+
+// QueryParams holds the raw query parameters for listing waves.
+type QueryParams struct {
+	Page        string
+	Rows        string
+	OrderBy     string
+	ID          string
+	Status      string
+	WarehouseID string
+}
+
+// BatchQueryParams holds the raw query parameters for listing batches.
+type BatchQueryParams struct {
+	Page       string
+	Rows       string
+	ID         string
+	WaveID     string
+	ZoneID     string
+	Status     string
+	AssignedTo string
+}
+
+// =============================================================================
+// Wave response model
+// =============================================================================
+
+// Wave is the app-layer response model for a pick wave.
+type Wave struct {
+	ID            string `json:"id"`
+	WaveNumber    string `json:"wave_number"`
+	Status        string `json:"status"`
+	WarehouseID   string `json:"warehouse_id"`
+	CarrierCutoff string `json:"carrier_cutoff"`
+	ToteCount     string `json:"tote_count"`
+	CreatedBy     string `json:"created_by"`
+	CreatedDate   string `json:"created_date"`
+	UpdatedDate   string `json:"updated_date"`
+}
+
+// Encode implements the encoder interface.
+func (app Wave) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// ToAppWave converts a bus model to an app-layer response model.
+func ToAppWave(bus pickwavebus.Wave) Wave {
+	warehouseID := ""
+	if bus.WarehouseID != nil {
+		warehouseID = bus.WarehouseID.String()
+	}
+
+	cutoff := ""
+	if bus.CarrierCutoff != nil {
+		cutoff = bus.CarrierCutoff.Format(timeutil.FORMAT)
+	}
+
+	return Wave{
+		ID:            bus.ID.String(),
+		WaveNumber:    bus.WaveNumber,
+		Status:        bus.Status,
+		WarehouseID:   warehouseID,
+		CarrierCutoff: cutoff,
+		ToteCount:     strconv.Itoa(bus.ToteCount),
+		CreatedBy:     bus.CreatedBy.String(),
+		CreatedDate:   bus.CreatedDate.Format(timeutil.FORMAT),
+		UpdatedDate:   bus.UpdatedDate.Format(timeutil.FORMAT),
+	}
+}
+
+// Waves is a slice wrapper so it implements web.Encoder directly.
+type Waves []Wave
+
+// Encode implements the encoder interface.
+func (app Waves) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// ToAppWaves converts a slice of bus models to app-layer response models.
+func ToAppWaves(bus []pickwavebus.Wave) Waves {
+	app := make(Waves, len(bus))
+	for i, v := range bus {
+		app[i] = ToAppWave(v)
+	}
+	return app
+}
+
+// =============================================================================
+// Batch response model
+// =============================================================================
+
+// Stop is one pick on a batch's route.
+type Stop struct {
+	Sequence       string `json:"sequence"`
+	Tote           string `json:"tote"`
+	PickTaskID     string `json:"pick_task_id"`
+	SalesOrderID   string `json:"sales_order_id"`
+	ProductID      string `json:"product_id"`
+	LocationID     string `json:"location_id"`
+	QuantityToPick string `json:"quantity_to_pick"`
+	QuantityPicked string `json:"quantity_picked"`
+	Status         string `json:"status"`
+}
+
+// Batch is the app-layer response model for a pick batch. Stops is filled
+// on single-batch reads and left out of lists.
+type Batch struct {
+	ID          string `json:"id"`
+	WaveID      string `json:"wave_id"`
+	BatchNumber string `json:"batch_number"`
+	ZoneID      string `json:"zone_id"`
+	Status      string `json:"status"`
+	OrderCount  string `json:"order_count"`
+	StopCount   string `json:"stop_count"`
+	AssignedTo  string `json:"assigned_to"`
+	AssignedAt  string `json:"assigned_at"`
+	CreatedDate string `json:"created_date"`
+	UpdatedDate string `json:"updated_date"`
+	Stops       []Stop `json:"stops,omitempty"`
+}
+
+// Encode implements the encoder interface.
+func (app Batch) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// ToAppBatch converts a bus model to an app-layer response model.
+func ToAppBatch(bus pickwavebus.Batch) Batch {
+	assignedTo := ""
+	if bus.AssignedTo != nil {
+		assignedTo = bus.AssignedTo.String()
+	}
+
+	assignedAt := ""
+	if bus.AssignedAt != nil {
+		assignedAt = bus.AssignedAt.Format(timeutil.FORMAT)
+	}
+
+	return Batch{
+		ID:          bus.ID.String(),
+		WaveID:      bus.WaveID.String(),
+		BatchNumber: bus.BatchNumber,
+		ZoneID:      bus.ZoneID.String(),
+		Status:      bus.Status,
+		OrderCount:  strconv.Itoa(bus.OrderCount),
+		StopCount:   strconv.Itoa(bus.StopCount),
+		AssignedTo:  assignedTo,
+		AssignedAt:  assignedAt,
+		CreatedDate: bus.CreatedDate.Format(timeutil.FORMAT),
+		UpdatedDate: bus.UpdatedDate.Format(timeutil.FORMAT),
+	}
+}
+
+// ToAppBatches converts a slice of bus models to app-layer response models.
+func ToAppBatches(bus []pickwavebus.Batch) []Batch {
+	app := make([]Batch, len(bus))
+	for i, v := range bus {
+		app[i] = ToAppBatch(v)
+	}
+	return app
+}
+
+func toAppStops(tasks []picktaskbus.PickTask) []Stop {
+	stops := make([]Stop, len(tasks))
+	for i, t := range tasks {
+		stops[i] = Stop{
+			Sequence:       optionalInt(t.BatchSequence),
+			Tote:           optionalInt(t.ToteNumber),
+			PickTaskID:     t.ID.String(),
+			SalesOrderID:   t.SalesOrderID.String(),
+			ProductID:      t.ProductID.String(),
+			LocationID:     t.LocationID.String(),
+			QuantityToPick: strconv.Itoa(t.QuantityToPick),
+			QuantityPicked: strconv.Itoa(t.QuantityPicked),
+			Status:         t.Status.String(),
+		}
+	}
+	return stops
+}
+
+// =============================================================================
+// Release request and plan models
+// =============================================================================
+
+// NewRelease is the app-layer request to plan or release pick waves. ToteCount
+// is the orders per batch and defaults to the standard cart. CarrierCutoffs
+// maps a carrier name to its next cutoff time (RFC 3339); orders shipping with
+// any other carrier go in a wave without a cutoff.
+type NewRelease struct {
+	WarehouseID    string            `json:"warehouse_id" validate:"omitempty,min=36,max=36"`
+	ToteCount      string            `json:"tote_count" validate:"omitempty,number"`
+	CarrierCutoffs map[string]string `json:"carrier_cutoffs"`
+}
+
+// Decode implements the decoder interface.
+func (app *NewRelease) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewRelease) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusPlanRequest(app NewRelease, createdBy uuid.UUID) (pickwavebus.PlanRequest, error) {
+	req := pickwavebus.PlanRequest{
+		CreatedBy: createdBy,
+	}
+
+	if app.WarehouseID != "" {
+		id, err := uuid.Parse(app.WarehouseID)
+		if err != nil {
+			return pickwavebus.PlanRequest{}, fmt.Errorf("parse warehouse_id: %w", err)
+		}
+		req.WarehouseID = &id
+	}
+
+	if app.ToteCount != "" {
+		n, err := strconv.Atoi(app.ToteCount)
+		if err != nil {
+			return pickwavebus.PlanRequest{}, fmt.Errorf("parse tote_count: %w", err)
+		}
+		if n <= 0 {
+			return pickwavebus.PlanRequest{}, fmt.Errorf("tote_count must be positive")
+		}
+		req.ToteCount = n
+	}
+
+	if len(app.CarrierCutoffs) > 0 {
+		req.CarrierCutoffs = make(map[string]time.Time, len(app.CarrierCutoffs))
+		for carrier, s := range app.CarrierCutoffs {
+			at, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				return pickwavebus.PlanRequest{}, fmt.Errorf("parse carrier_cutoffs[%s]: %w", carrier, err)
+			}
+			req.CarrierCutoffs[carrier] = at
+		}
+	}
+
+	return req, nil
+}
+
+// PlannedStop is one pick on a planned batch's route.
+type PlannedStop struct {
+	Sequence     int    `json:"sequence"`
+	Tote         int    `json:"tote"`
+	PickTaskID   string `json:"pick_task_id"`
+	SalesOrderID string `json:"sales_order_id"`
+	OrderNumber  string `json:"order_number"`
+	LocationID   string `json:"location_id"`
+	LocationCode string `json:"location_code"`
+}
+
+// PlannedBatch is one cart's worth of orders in a zone.
+type PlannedBatch struct {
+	ZoneID     string        `json:"zone_id"`
+	OrderCount int           `json:"order_count"`
+	Stops      []PlannedStop `json:"stops"`
+}
+
+// PlannedWave is the batches a release would create against one cutoff.
+type PlannedWave struct {
+	CarrierCutoff string         `json:"carrier_cutoff"`
+	Carriers      []string       `json:"carriers"`
+	Batches       []PlannedBatch `json:"batches"`
+}
+
+// Plan is the set of waves a release would create now.
+type Plan struct {
+	Waves []PlannedWave `json:"waves"`
+}
+
+// Encode implements the encoder interface.
+func (app Plan) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppPlan(bus []pickwavebus.PlannedWave) Plan {
+	waves := make([]PlannedWave, len(bus))
+	for i, w := range bus {
+		cutoff := ""
+		if w.CarrierCutoff != nil {
+			cutoff = w.CarrierCutoff.Format(timeutil.FORMAT)
+		}
+
+		batches := make([]PlannedBatch, len(w.Batches))
+		for j, b := range w.Batches {
+			stops := make([]PlannedStop, len(b.Stops))
+			for k, s := range b.Stops {
+				stops[k] = PlannedStop{
+					Sequence:     s.Sequence,
+					Tote:         s.Tote,
+					PickTaskID:   s.TaskID.String(),
+					SalesOrderID: s.SalesOrderID.String(),
+					OrderNumber:  s.OrderNumber,
+					LocationID:   s.LocationID.String(),
+					LocationCode: s.LocationCode,
+				}
+			}
+			batches[j] = PlannedBatch{
+				ZoneID:     b.ZoneID.String(),
+				OrderCount: len(b.Orders),
+				Stops:      stops,
+			}
+		}
+
+		waves[i] = PlannedWave{
+			CarrierCutoff: cutoff,
+			Carriers:      w.Carriers,
+			Batches:       batches,
+		}
+	}
+
+	return Plan{Waves: waves}
+}
+
+// =============================================================================
+
+func optionalInt(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
@@ -0,0 +1,15 @@
+package pickwaveapp
+
+import (
+	"github.com/timmaaaz/ichor/business/domain/inventory/pickwavebus"
+)
+
+var defaultOrderBy = pickwavebus.DefaultOrderBy
+
+var orderByFields = map[string]string{
+	pickwavebus.OrderByID:            pickwavebus.OrderByID,
+	pickwavebus.OrderByWaveNumber:    pickwavebus.OrderByWaveNumber,
+	pickwavebus.OrderByStatus:        pickwavebus.OrderByStatus,
+	pickwavebus.OrderByCarrierCutoff: pickwavebus.OrderByCarrierCutoff,
+	pickwavebus.OrderByCreatedDate:   pickwavebus.OrderByCreatedDate,
+}
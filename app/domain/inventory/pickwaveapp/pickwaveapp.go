@@ -0,0 +1,247 @@
+// Package pickwaveapp maintains the app layer api for pick waves: releasing
+// pending pick tasks as zone batches sized to a cart, and handing a batch to a
+// picker as one route.
+package pickwaveapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/app/sdk/mid"
+	"github.com/timmaaaz/ichor/app/sdk/query"
+	"github.com/timmaaaz/ichor/business/domain/inventory/pickwavebus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// App manages the set of app layer APIs for pick wave access.
+type App struct {
+	pickWaveBus *pickwavebus.Business
+}
+
+// NewApp constructs a pick wave app.
+func NewApp(pickWaveBus *pickwavebus.Business) *App {
+	return &App{
+		pickWaveBus: pickWaveBus,
+	}
+}
+
+// Plan returns the waves a release would create now, without writing
+// anything.
+func (a *App) Plan(ctx context.Context, app NewRelease) (Plan, error) {
+	req, err := toBusPlanRequest(app, uuid.Nil)
+	if err != nil {
+		return Plan{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	waves, err := a.pickWaveBus.Plan(ctx, req)
+	if err != nil {
+		return Plan{}, fmt.Errorf("plan: %w", err)
+	}
+
+	return toAppPlan(waves), nil
+}
+
+// Create releases every pick task waiting for a wave into waves and batches.
+func (a *App) Create(ctx context.Context, app NewRelease) (Waves, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return nil, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	req, err := toBusPlanRequest(app, userID)
+	if err != nil {
+		return nil, errs.New(errs.InvalidArgument, err)
+	}
+
+	waves, err := a.pickWaveBus.Create(ctx, req, time.Now())
+	if err != nil {
+		return nil, toAppError("create", err)
+	}
+
+	return ToAppWaves(waves), nil
+}
+
+// Delete removes a wave no picker has started; its pick tasks wait for the
+// next release.
+func (a *App) Delete(ctx context.Context, waveID uuid.UUID) error {
+	wave, err := a.queryByID(ctx, waveID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.pickWaveBus.Delete(ctx, wave); err != nil {
+		return toAppError("delete", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of waves based on query parameters.
+func (a *App) Query(ctx context.Context, qp QueryParams) (query.Result[Wave], error) {
+	pg, err := page.Parse(qp.Page, qp.Rows)
+	if err != nil {
+		return query.Result[Wave]{}, errs.NewFieldsError("page", err)
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return query.Result[Wave]{}, errs.NewFieldsError("filter", err)
+	}
+
+	orderBy, err := order.Parse(orderByFields, qp.OrderBy, defaultOrderBy)
+	if err != nil {
+		return query.Result[Wave]{}, errs.NewFieldsError("orderBy", err)
+	}
+
+	waves, err := a.pickWaveBus.Query(ctx, filter, orderBy, pg)
+	if err != nil {
+		return query.Result[Wave]{}, errs.Newf(errs.Internal, "query: %v", err)
+	}
+
+	total, err := a.pickWaveBus.Count(ctx, filter)
+	if err != nil {
+		return query.Result[Wave]{}, errs.Newf(errs.Internal, "count: %v", err)
+	}
+
+	return query.NewResult(ToAppWaves(waves), total, pg), nil
+}
+
+// QueryByID retrieves a single wave by ID.
+func (a *App) QueryByID(ctx context.Context, waveID uuid.UUID) (Wave, error) {
+	wave, err := a.queryByID(ctx, waveID)
+	if err != nil {
+		return Wave{}, err
+	}
+
+	return ToAppWave(wave), nil
+}
+
+// QueryBatches retrieves batches based on query parameters, ordered by batch
+// number.
+func (a *App) QueryBatches(ctx context.Context, qp BatchQueryParams) (query.Result[Batch], error) {
+	pg, err := page.Parse(qp.Page, qp.Rows)
+	if err != nil {
+		return query.Result[Batch]{}, errs.NewFieldsError("page", err)
+	}
+
+	filter, err := parseBatchFilter(qp)
+	if err != nil {
+		return query.Result[Batch]{}, errs.NewFieldsError("filter", err)
+	}
+
+	batches, err := a.pickWaveBus.QueryBatches(ctx, filter, pg)
+	if err != nil {
+		return query.Result[Batch]{}, errs.Newf(errs.Internal, "querybatches: %v", err)
+	}
+
+	total, err := a.pickWaveBus.CountBatches(ctx, filter)
+	if err != nil {
+		return query.Result[Batch]{}, errs.Newf(errs.Internal, "countbatches: %v", err)
+	}
+
+	return query.NewResult(ToAppBatches(batches), total, pg), nil
+}
+
+// QueryBatchByID retrieves a single batch with its stops in route order.
+func (a *App) QueryBatchByID(ctx context.Context, batchID uuid.UUID) (Batch, error) {
+	batch, err := a.queryBatchByID(ctx, batchID)
+	if err != nil {
+		return Batch{}, err
+	}
+
+	return a.withStops(ctx, batch)
+}
+
+// Claim hands the whole batch to the calling user.
+func (a *App) Claim(ctx context.Context, batchID uuid.UUID) (Batch, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Batch{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	batch, err := a.queryBatchByID(ctx, batchID)
+	if err != nil {
+		return Batch{}, err
+	}
+
+	claimed, err := a.pickWaveBus.Claim(ctx, batch, userID, time.Now())
+	if err != nil {
+		return Batch{}, toAppError("claim", err)
+	}
+
+	return a.withStops(ctx, claimed)
+}
+
+// Complete closes a batch once every stop has been picked, short picked or
+// cancelled.
+func (a *App) Complete(ctx context.Context, batchID uuid.UUID) (Batch, error) {
+	batch, err := a.queryBatchByID(ctx, batchID)
+	if err != nil {
+		return Batch{}, err
+	}
+
+	completed, err := a.pickWaveBus.Complete(ctx, batch, time.Now())
+	if err != nil {
+		return Batch{}, toAppError("complete", err)
+	}
+
+	return a.withStops(ctx, completed)
+}
+
+// =============================================================================
+
+func (a *App) withStops(ctx context.Context, batch pickwavebus.Batch) (Batch, error) {
+	stops, err := a.pickWaveBus.QueryStops(ctx, batch)
+	if err != nil {
+		return Batch{}, fmt.Errorf("stops: %w", err)
+	}
+
+	app := ToAppBatch(batch)
+	app.Stops = toAppStops(stops)
+
+	return app, nil
+}
+
+func (a *App) queryByID(ctx context.Context, waveID uuid.UUID) (pickwavebus.Wave, error) {
+	wave, err := a.pickWaveBus.QueryByID(ctx, waveID)
+	if err != nil {
+		if errors.Is(err, pickwavebus.ErrNotFound) {
+			return pickwavebus.Wave{}, errs.New(errs.NotFound, err)
+		}
+		return pickwavebus.Wave{}, fmt.Errorf("querybyid: %w", err)
+	}
+
+	return wave, nil
+}
+
+func (a *App) queryBatchByID(ctx context.Context, batchID uuid.UUID) (pickwavebus.Batch, error) {
+	batch, err := a.pickWaveBus.QueryBatchByID(ctx, batchID)
+	if err != nil {
+		if errors.Is(err, pickwavebus.ErrBatchNotFound) {
+			return pickwavebus.Batch{}, errs.New(errs.NotFound, err)
+		}
+		return pickwavebus.Batch{}, fmt.Errorf("querybatchbyid: %w", err)
+	}
+
+	return batch, nil
+}
+
+func toAppError(op string, err error) error {
+	switch {
+	case errors.Is(err, pickwavebus.ErrNothingToPlan),
+		errors.Is(err, pickwavebus.ErrBatchNotClaimed),
+		errors.Is(err, pickwavebus.ErrStopsOpen):
+		return errs.New(errs.FailedPrecondition, err)
+	case errors.Is(err, pickwavebus.ErrBatchClaimed),
+		errors.Is(err, pickwavebus.ErrWaveStarted),
+		errors.Is(err, pickwavebus.ErrUniqueEntry),
+		errors.Is(err, pickwavebus.ErrForeignKeyViolation):
+		return errs.New(errs.Aborted, err)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
@@ -4,10 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/putawaytaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/zonebus"
 	"github.com/timmaaaz/ichor/foundation/timeutil"
 )
 
@@ -222,3 +225,133 @@ func toBusUpdatePutAwayTask(app UpdatePutAwayTask) (putawaytaskbus.UpdatePutAway
 
 	return bus, nil
 }
+
+// =============================================================================
+// Slotting model
+// =============================================================================
+
+// SlottingParams holds the raw query parameters for a slotting suggestion.
+// ZoneStages is a comma-separated list.
+type SlottingParams struct {
+	ProductID     string
+	Quantity      string
+	LotID         string
+	WarehouseID   string
+	ZoneStages    string
+	BinPreference string
+	Limit         string
+}
+
+// SlotCandidate is a bin ranked for a put-away.
+type SlotCandidate struct {
+	LocationID         string   `json:"location_id"`
+	LocationCode       string   `json:"location_code"`
+	WarehouseID        string   `json:"warehouse_id"`
+	ZoneID             string   `json:"zone_id"`
+	ZoneStage          string   `json:"zone_stage"`
+	IsPickLocation     bool     `json:"is_pick_location"`
+	CurrentUtilization string   `json:"current_utilization"`
+	Room               string   `json:"room"`
+	FitsAll            bool     `json:"fits_all"`
+	Score              string   `json:"score"`
+	Reasons            []string `json:"reasons"`
+}
+
+// Slotting is the ranked slotting suggestion, best candidate first. Rejected
+// counts the bins left out, by reason.
+type Slotting struct {
+	Quantity   string          `json:"quantity"`
+	Candidates []SlotCandidate `json:"candidates"`
+	Rejected   map[string]int  `json:"rejected"`
+}
+
+func (app Slotting) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toBusSlotRequest(sp SlottingParams) (inventorylocationbus.SlotRequest, error) {
+	var req inventorylocationbus.SlotRequest
+
+	productID, err := uuid.Parse(sp.ProductID)
+	if err != nil {
+		return inventorylocationbus.SlotRequest{}, fmt.Errorf("parse product_id: %s", err)
+	}
+	req.ProductID = productID
+
+	req.Quantity, err = strconv.Atoi(sp.Quantity)
+	if err != nil {
+		return inventorylocationbus.SlotRequest{}, fmt.Errorf("parse quantity: %s", err)
+	}
+
+	if sp.LotID != "" {
+		id, err := uuid.Parse(sp.LotID)
+		if err != nil {
+			return inventorylocationbus.SlotRequest{}, fmt.Errorf("parse lot_id: %s", err)
+		}
+		req.LotID = &id
+	}
+
+	if sp.WarehouseID != "" {
+		id, err := uuid.Parse(sp.WarehouseID)
+		if err != nil {
+			return inventorylocationbus.SlotRequest{}, fmt.Errorf("parse warehouse_id: %s", err)
+		}
+		req.WarehouseID = &id
+	}
+
+	if sp.ZoneStages != "" {
+		for _, s := range strings.Split(sp.ZoneStages, ",") {
+			st, err := zonebus.ParseStage(strings.TrimSpace(s))
+			if err != nil {
+				return inventorylocationbus.SlotRequest{}, fmt.Errorf("parse zone_stages: %s", err)
+			}
+			req.Stages = append(req.Stages, st)
+		}
+	}
+
+	req.BinPreference = sp.BinPreference
+
+	if sp.Limit != "" {
+		req.Limit, err = strconv.Atoi(sp.Limit)
+		if err != nil {
+			return inventorylocationbus.SlotRequest{}, fmt.Errorf("parse limit: %s", err)
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		return inventorylocationbus.SlotRequest{}, err
+	}
+
+	return req, nil
+}
+
+func toAppSlotting(bus inventorylocationbus.Slotting) Slotting {
+	candidates := make([]SlotCandidate, len(bus.Candidates))
+	for i, c := range bus.Candidates {
+		candidates[i] = SlotCandidate{
+			LocationID:         c.LocationID.String(),
+			LocationCode:       c.LocationCode,
+			WarehouseID:        c.WarehouseID.String(),
+			ZoneID:             c.ZoneID.String(),
+			ZoneStage:          c.ZoneStage,
+			IsPickLocation:     c.IsPickLocation,
+			CurrentUtilization: fmt.Sprintf("%.2f", c.CurrentUtilization),
+			Room:               strconv.Itoa(c.Room),
+			FitsAll:            c.FitsAll,
+			Score:              strconv.Itoa(c.Score),
+			Reasons:            c.Reasons,
+		}
+	}
+
+	rejected := bus.Rejected
+	if rejected == nil {
+		rejected = map[string]int{}
+	}
+
+	return Slotting{
+		Quantity:   strconv.Itoa(bus.Quantity),
+		Candidates: candidates,
+		Rejected:   rejected,
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/timmaaaz/ichor/app/sdk/mid"
 	"github.com/timmaaaz/ichor/app/sdk/query"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/putawaytaskbus"
 	"github.com/timmaaaz/ichor/business/sdk/order"
@@ -25,6 +26,7 @@ type App struct {
 	putAwayTaskBus    *putawaytaskbus.Business
 	invTransactionBus *inventorytransactionbus.Business
 	invItemBus        *inventoryitembus.Business
+	invLocationBus    *inventorylocationbus.Business
 	db                *sqlx.DB
 }
 
@@ -33,12 +35,14 @@ func NewApp(
 	putAwayTaskBus *putawaytaskbus.Business,
 	invTransactionBus *inventorytransactionbus.Business,
 	invItemBus *inventoryitembus.Business,
+	invLocationBus *inventorylocationbus.Business,
 	db *sqlx.DB,
 ) *App {
 	return &App{
 		putAwayTaskBus:    putAwayTaskBus,
 		invTransactionBus: invTransactionBus,
 		invItemBus:        invItemBus,
+		invLocationBus:    invLocationBus,
 		db:                db,
 	}
 }
@@ -57,6 +61,9 @@ func (a *App) Create(ctx context.Context, app NewPutAwayTask) (PutAwayTask, erro
 
 	task, err := a.putAwayTaskBus.Create(ctx, npt)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return PutAwayTask{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, putawaytaskbus.ErrForeignKeyViolation) {
 			return PutAwayTask{}, errs.New(errs.Aborted, err)
 		}
@@ -117,6 +124,9 @@ func (a *App) Update(ctx context.Context, taskID uuid.UUID, app UpdatePutAwayTas
 	// Plain update (pending field edits, cancel, claim).
 	updated, err := a.putAwayTaskBus.Update(ctx, task, upt)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return PutAwayTask{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, putawaytaskbus.ErrForeignKeyViolation) {
 			return PutAwayTask{}, errs.New(errs.Aborted, err)
 		}
@@ -264,3 +274,20 @@ func (a *App) QueryByID(ctx context.Context, taskID uuid.UUID) (PutAwayTask, err
 
 	return ToAppPutAwayTask(task), nil
 }
+
+// Slot ranks the bins a receiver could put stock away into. It returns the
+// candidates with the reasons for their rank even when none has room for the
+// whole quantity, so the receiver can split the put-away or pick overflow.
+func (a *App) Slot(ctx context.Context, sp SlottingParams) (Slotting, error) {
+	req, err := toBusSlotRequest(sp)
+	if err != nil {
+		return Slotting{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	slotting, err := a.invLocationBus.Slot(ctx, req)
+	if err != nil {
+		return Slotting{}, errs.Newf(errs.Internal, "slot: %v", err)
+	}
+
+	return toAppSlotting(slotting), nil
+}
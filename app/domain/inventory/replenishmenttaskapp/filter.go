@@ -0,0 +1,40 @@
+package replenishmenttaskapp
+
+import (
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
+)
+
+func parseFilter(qp QueryParams) (replenishmenttaskbus.QueryFilter, error) {
+	var filter replenishmenttaskbus.QueryFilter
+
+	for _, f := range []struct {
+		in  string
+		out **uuid.UUID
+	}{
+		{qp.ID, &filter.ID},
+		{qp.ProductID, &filter.ProductID},
+		{qp.FromLocationID, &filter.FromLocationID},
+		{qp.ToLocationID, &filter.ToLocationID},
+		{qp.AssignedTo, &filter.AssignedTo},
+	} {
+		if f.in == "" {
+			continue
+		}
+		id, err := uuid.Parse(f.in)
+		if err != nil {
+			return replenishmenttaskbus.QueryFilter{}, err
+		}
+		*f.out = &id
+	}
+
+	if qp.Reason != "" {
+		filter.Reason = &qp.Reason
+	}
+
+	if qp.Status != "" {
+		filter.Status = &qp.Status
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,262 @@
+package replenishmenttaskapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
+	"github.com/timmaaaz/ichor/foundation/timeutil"
+)
+
+// QueryParams holds the raw query parameters for listing replenishment tasks.
+type QueryParams struct {
+	Page           string
+	Rows           string
+	OrderBy        string
+	ID             string
+	ProductID      string
+	FromLocationID string
+	ToLocationID   string
+	Reason         string
+	Status         string
+	AssignedTo     string
+}
+
+// =============================================================================
+// Task response model
+// =============================================================================
+
+// Task is the app-layer response model for a replenishment task.
+type Task struct {
+	ID             string `json:"id"`
+	ProductID      string `json:"product_id"`
+	LotID          string `json:"lot_id"`
+	FromLocationID string `json:"from_location_id"`
+	ToLocationID   string `json:"to_location_id"`
+	Quantity       string `json:"quantity"`
+	QuantityMoved  string `json:"quantity_moved"`
+	Reason         string `json:"reason"`
+	Status         string `json:"status"`
+	AssignedTo     string `json:"assigned_to"`
+	AssignedAt     string `json:"assigned_at"`
+	CompletedBy    string `json:"completed_by"`
+	CompletedAt    string `json:"completed_at"`
+	CreatedBy      string `json:"created_by"`
+	CreatedDate    string `json:"created_date"`
+	UpdatedDate    string `json:"updated_date"`
+}
+
+// Encode implements the encoder interface.
+func (app Task) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// ToAppTask converts a bus model to an app-layer response model.
+func ToAppTask(bus replenishmenttaskbus.Task) Task {
+	return Task{
+		ID:             bus.ID.String(),
+		ProductID:      bus.ProductID.String(),
+		LotID:          optionalID(bus.LotID),
+		FromLocationID: bus.FromLocationID.String(),
+		ToLocationID:   bus.ToLocationID.String(),
+		Quantity:       strconv.Itoa(bus.Quantity),
+		QuantityMoved:  strconv.Itoa(bus.QuantityMoved),
+		Reason:         bus.Reason,
+		Status:         bus.Status,
+		AssignedTo:     optionalID(bus.AssignedTo),
+		AssignedAt:     optionalTime(bus.AssignedAt),
+		CompletedBy:    optionalID(bus.CompletedBy),
+		CompletedAt:    optionalTime(bus.CompletedAt),
+		CreatedBy:      bus.CreatedBy.String(),
+		CreatedDate:    bus.CreatedDate.Format(timeutil.FORMAT),
+		UpdatedDate:    bus.UpdatedDate.Format(timeutil.FORMAT),
+	}
+}
+
+// Tasks is a slice wrapper so it implements web.Encoder directly.
+type Tasks []Task
+
+// Encode implements the encoder interface.
+func (app Tasks) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// ToAppTasks converts a slice of bus models to app-layer response models.
+func ToAppTasks(bus []replenishmenttaskbus.Task) Tasks {
+	app := make(Tasks, len(bus))
+	for i, v := range bus {
+		app[i] = ToAppTask(v)
+	}
+	return app
+}
+
+// =============================================================================
+// Plan / generate request model
+// =============================================================================
+
+// NewReplenishment is the app-layer request to plan or generate replenishment
+// tasks. WarehouseID, ProductID and LocationID narrow the pick faces
+// considered; CoverDays is how many days of average usage a face should hold
+// on top of its open picks.
+type NewReplenishment struct {
+	WarehouseID string `json:"warehouse_id" validate:"omitempty,min=36,max=36"`
+	ProductID   string `json:"product_id" validate:"omitempty,min=36,max=36"`
+	LocationID  string `json:"location_id" validate:"omitempty,min=36,max=36"`
+	CoverDays   string `json:"cover_days" validate:"omitempty,number"`
+}
+
+// Decode implements the decoder interface.
+func (app *NewReplenishment) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewReplenishment) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusPlanRequest(app NewReplenishment, createdBy uuid.UUID) (replenishmenttaskbus.PlanRequest, error) {
+	req := replenishmenttaskbus.PlanRequest{
+		CreatedBy: createdBy,
+	}
+
+	for _, f := range []struct {
+		name string
+		in   string
+		out  **uuid.UUID
+	}{
+		{"warehouse_id", app.WarehouseID, &req.WarehouseID},
+		{"product_id", app.ProductID, &req.ProductID},
+		{"location_id", app.LocationID, &req.LocationID},
+	} {
+		if f.in == "" {
+			continue
+		}
+		id, err := uuid.Parse(f.in)
+		if err != nil {
+			return replenishmenttaskbus.PlanRequest{}, fmt.Errorf("parse %s: %w", f.name, err)
+		}
+		*f.out = &id
+	}
+
+	if app.CoverDays != "" {
+		n, err := strconv.Atoi(app.CoverDays)
+		if err != nil {
+			return replenishmenttaskbus.PlanRequest{}, fmt.Errorf("parse cover_days: %w", err)
+		}
+		if n < 0 {
+			return replenishmenttaskbus.PlanRequest{}, fmt.Errorf("cover_days must not be negative")
+		}
+		req.CoverDays = n
+	}
+
+	return req, nil
+}
+
+// PlannedTask is one move a generate would create.
+type PlannedTask struct {
+	ProductID        string `json:"product_id"`
+	LotID            string `json:"lot_id"`
+	LotExpiration    string `json:"lot_expiration"`
+	FromLocationID   string `json:"from_location_id"`
+	FromLocationCode string `json:"from_location_code"`
+	ToLocationID     string `json:"to_location_id"`
+	ToLocationCode   string `json:"to_location_code"`
+	Quantity         int    `json:"quantity"`
+	Reason           string `json:"reason"`
+}
+
+// Plan is the set of moves a generate would create now.
+type Plan struct {
+	Tasks []PlannedTask `json:"tasks"`
+}
+
+// Encode implements the encoder interface.
+func (app Plan) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppPlan(bus []replenishmenttaskbus.PlannedTask) Plan {
+	tasks := make([]PlannedTask, len(bus))
+	for i, t := range bus {
+		tasks[i] = PlannedTask{
+			ProductID:        t.ProductID.String(),
+			LotID:            optionalID(t.LotID),
+			LotExpiration:    optionalTime(t.LotExpiration),
+			FromLocationID:   t.FromLocationID.String(),
+			FromLocationCode: t.FromLocationCode,
+			ToLocationID:     t.ToLocationID.String(),
+			ToLocationCode:   t.ToLocationCode,
+			Quantity:         t.Quantity,
+			Reason:           t.Reason,
+		}
+	}
+
+	return Plan{Tasks: tasks}
+}
+
+// =============================================================================
+// Complete request model
+// =============================================================================
+
+// CompleteTask is the app-layer request to complete a replenishment task.
+// Quantity is what was put on the pick face; empty means the full task.
+type CompleteTask struct {
+	Quantity string `json:"quantity" validate:"omitempty,number"`
+}
+
+// Decode implements the decoder interface. The body is optional.
+func (app *CompleteTask) Decode(data []byte) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app CompleteTask) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func (app CompleteTask) quantity(full int) (int, error) {
+	if app.Quantity == "" {
+		return full, nil
+	}
+
+	n, err := strconv.Atoi(app.Quantity)
+	if err != nil {
+		return 0, fmt.Errorf("parse quantity: %w", err)
+	}
+
+	return n, nil
+}
+
+// =============================================================================
+
+func optionalID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func optionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(timeutil.FORMAT)
+}
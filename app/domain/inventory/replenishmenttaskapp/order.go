@@ -0,0 +1,16 @@
+package replenishmenttaskapp
+
+import (
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
+)
+
+var defaultOrderBy = replenishmenttaskbus.DefaultOrderBy
+
+var orderByFields = map[string]string{
+	replenishmenttaskbus.OrderByID:          replenishmenttaskbus.OrderByID,
+	replenishmenttaskbus.OrderByProductID:   replenishmenttaskbus.OrderByProductID,
+	replenishmenttaskbus.OrderByReason:      replenishmenttaskbus.OrderByReason,
+	replenishmenttaskbus.OrderByStatus:      replenishmenttaskbus.OrderByStatus,
+	replenishmenttaskbus.OrderByCreatedDate: replenishmenttaskbus.OrderByCreatedDate,
+	replenishmenttaskbus.OrderByUpdatedDate: replenishmenttaskbus.OrderByUpdatedDate,
+}
@@ -0,0 +1,198 @@
+// Package replenishmenttaskapp maintains the app layer api for replenishment
+// tasks: planning and generating moves from reserve bins to pick faces that
+// are running low, and carrying those moves out on the floor.
+package replenishmenttaskapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/app/sdk/mid"
+	"github.com/timmaaaz/ichor/app/sdk/query"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// App manages the set of app layer APIs for replenishment task access.
+type App struct {
+	replenishmentTaskBus *replenishmenttaskbus.Business
+}
+
+// NewApp constructs a replenishment task app.
+func NewApp(replenishmentTaskBus *replenishmenttaskbus.Business) *App {
+	return &App{
+		replenishmentTaskBus: replenishmentTaskBus,
+	}
+}
+
+// Plan returns the tasks a generate would create now, without writing
+// anything.
+func (a *App) Plan(ctx context.Context, app NewReplenishment) (Plan, error) {
+	req, err := toBusPlanRequest(app, uuid.Nil)
+	if err != nil {
+		return Plan{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	tasks, err := a.replenishmentTaskBus.Plan(ctx, req, time.Now())
+	if err != nil {
+		return Plan{}, fmt.Errorf("plan: %w", err)
+	}
+
+	return toAppPlan(tasks), nil
+}
+
+// Generate creates a replenishment task for every move the plan calls for.
+func (a *App) Generate(ctx context.Context, app NewReplenishment) (Tasks, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return nil, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	req, err := toBusPlanRequest(app, userID)
+	if err != nil {
+		return nil, errs.New(errs.InvalidArgument, err)
+	}
+
+	tasks, err := a.replenishmentTaskBus.Generate(ctx, req, time.Now())
+	if err != nil {
+		return nil, toAppError("generate", err)
+	}
+
+	return ToAppTasks(tasks), nil
+}
+
+// Query retrieves a list of replenishment tasks based on query parameters.
+func (a *App) Query(ctx context.Context, qp QueryParams) (query.Result[Task], error) {
+	pg, err := page.Parse(qp.Page, qp.Rows)
+	if err != nil {
+		return query.Result[Task]{}, errs.NewFieldsError("page", err)
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return query.Result[Task]{}, errs.NewFieldsError("filter", err)
+	}
+
+	orderBy, err := order.Parse(orderByFields, qp.OrderBy, defaultOrderBy)
+	if err != nil {
+		return query.Result[Task]{}, errs.NewFieldsError("orderBy", err)
+	}
+
+	tasks, err := a.replenishmentTaskBus.Query(ctx, filter, orderBy, pg)
+	if err != nil {
+		return query.Result[Task]{}, errs.Newf(errs.Internal, "query: %v", err)
+	}
+
+	total, err := a.replenishmentTaskBus.Count(ctx, filter)
+	if err != nil {
+		return query.Result[Task]{}, errs.Newf(errs.Internal, "count: %v", err)
+	}
+
+	return query.NewResult(ToAppTasks(tasks), total, pg), nil
+}
+
+// QueryByID retrieves a single replenishment task by ID.
+func (a *App) QueryByID(ctx context.Context, taskID uuid.UUID) (Task, error) {
+	task, err := a.queryByID(ctx, taskID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	return ToAppTask(task), nil
+}
+
+// Claim hands the task to the calling user and starts it.
+func (a *App) Claim(ctx context.Context, taskID uuid.UUID) (Task, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Task{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	task, err := a.queryByID(ctx, taskID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	claimed, err := a.replenishmentTaskBus.Claim(ctx, task, userID, time.Now())
+	if err != nil {
+		return Task{}, toAppError("claim", err)
+	}
+
+	return ToAppTask(claimed), nil
+}
+
+// Complete records the quantity put on the pick face and moves it in
+// inventory. An empty quantity means the whole task was moved.
+func (a *App) Complete(ctx context.Context, taskID uuid.UUID, app CompleteTask) (Task, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Task{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	task, err := a.queryByID(ctx, taskID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	quantity, err := app.quantity(task.Quantity)
+	if err != nil {
+		return Task{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	completed, err := a.replenishmentTaskBus.Complete(ctx, task, userID, quantity, time.Now())
+	if err != nil {
+		return Task{}, toAppError("complete", err)
+	}
+
+	return ToAppTask(completed), nil
+}
+
+// Cancel closes a task that will not be carried out.
+func (a *App) Cancel(ctx context.Context, taskID uuid.UUID) (Task, error) {
+	task, err := a.queryByID(ctx, taskID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	cancelled, err := a.replenishmentTaskBus.Cancel(ctx, task, time.Now())
+	if err != nil {
+		return Task{}, toAppError("cancel", err)
+	}
+
+	return ToAppTask(cancelled), nil
+}
+
+// =============================================================================
+
+func (a *App) queryByID(ctx context.Context, taskID uuid.UUID) (replenishmenttaskbus.Task, error) {
+	task, err := a.replenishmentTaskBus.QueryByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, replenishmenttaskbus.ErrNotFound) {
+			return replenishmenttaskbus.Task{}, errs.New(errs.NotFound, err)
+		}
+		return replenishmenttaskbus.Task{}, fmt.Errorf("querybyid: %w", err)
+	}
+
+	return task, nil
+}
+
+func toAppError(op string, err error) error {
+	switch {
+	case errors.Is(err, replenishmenttaskbus.ErrInvalidQuantity):
+		return errs.New(errs.InvalidArgument, err)
+	case errors.Is(err, replenishmenttaskbus.ErrNothingToReplenish),
+		errors.Is(err, replenishmenttaskbus.ErrTaskNotClaimed):
+		return errs.New(errs.FailedPrecondition, err)
+	case errors.Is(err, replenishmenttaskbus.ErrTaskClaimed),
+		errors.Is(err, replenishmenttaskbus.ErrTaskClosed),
+		errors.Is(err, replenishmenttaskbus.ErrUniqueEntry),
+		errors.Is(err, replenishmenttaskbus.ErrForeignKeyViolation):
+		return errs.New(errs.Aborted, err)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
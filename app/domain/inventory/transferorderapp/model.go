@@ -32,26 +32,27 @@ type QueryParams struct {
 }
 
 type TransferOrder struct {
-	TransferID      string `json:"transfer_id"`
-	TransferNumber  string `json:"transfer_number"`
-	ProductID       string `json:"product_id"`
-	FromLocationID  string `json:"from_location_id"`
-	ToLocationID    string `json:"to_location_id"`
-	RequestedByID   string `json:"requested_by"`
-	ApprovedByID    string `json:"approved_by"`
-	RejectedByID    string `json:"rejected_by_id"`
-	ApprovalReason  string `json:"approval_reason"`
-	RejectionReason string `json:"rejection_reason"`
-	ClaimedByID     string `json:"claimed_by_id"`
-	ClaimedAt       string `json:"claimed_at"`
-	CompletedByID   string `json:"completed_by_id"`
-	CompletedAt     string `json:"completed_at"`
-	Quantity        string `json:"quantity"`
-	Status          string `json:"status"`
-	TransferDate    string `json:"transfer_date"`
-	CreatedDate     string `json:"created_date"`
-	UpdatedDate     string `json:"updated_date"`
-	ScenarioID      string `json:"scenario_id,omitempty"`
+	TransferID        string `json:"transfer_id"`
+	TransferNumber    string `json:"transfer_number"`
+	ProductID         string `json:"product_id"`
+	FromLocationID    string `json:"from_location_id"`
+	ToLocationID      string `json:"to_location_id"`
+	TransitLocationID string `json:"transit_location_id"`
+	RequestedByID     string `json:"requested_by"`
+	ApprovedByID      string `json:"approved_by"`
+	RejectedByID      string `json:"rejected_by_id"`
+	ApprovalReason    string `json:"approval_reason"`
+	RejectionReason   string `json:"rejection_reason"`
+	ClaimedByID       string `json:"claimed_by_id"`
+	ClaimedAt         string `json:"claimed_at"`
+	CompletedByID     string `json:"completed_by_id"`
+	CompletedAt       string `json:"completed_at"`
+	Quantity          string `json:"quantity"`
+	Status            string `json:"status"`
+	TransferDate      string `json:"transfer_date"`
+	CreatedDate       string `json:"created_date"`
+	UpdatedDate       string `json:"updated_date"`
+	ScenarioID        string `json:"scenario_id,omitempty"`
 }
 
 func (app TransferOrder) Encode() ([]byte, string, error) {
@@ -65,6 +66,16 @@ func ToAppTransferOrder(bus transferorderbus.TransferOrder) TransferOrder {
 		transferNumber = *bus.TransferNumber
 	}
 
+	productID := ""
+	if bus.ProductID != uuid.Nil {
+		productID = bus.ProductID.String()
+	}
+
+	transitLocationID := ""
+	if bus.TransitLocationID != nil {
+		transitLocationID = bus.TransitLocationID.String()
+	}
+
 	approvedByID := ""
 	if bus.ApprovedByID != nil {
 		approvedByID = bus.ApprovedByID.String()
@@ -101,26 +112,27 @@ func ToAppTransferOrder(bus transferorderbus.TransferOrder) TransferOrder {
 	}
 
 	return TransferOrder{
-		TransferID:      bus.TransferID.String(),
-		TransferNumber:  transferNumber,
-		ProductID:       bus.ProductID.String(),
-		FromLocationID:  bus.FromLocationID.String(),
-		ToLocationID:    bus.ToLocationID.String(),
-		RequestedByID:   bus.RequestedByID.String(),
-		ApprovedByID:    approvedByID,
-		RejectedByID:    rejectedByID,
-		ApprovalReason:  bus.ApprovalReason,
-		RejectionReason: bus.RejectionReason,
-		ClaimedByID:     claimedByID,
-		ClaimedAt:       claimedAt,
-		CompletedByID:   completedByID,
-		CompletedAt:     completedAt,
-		Quantity:        fmt.Sprintf("%d", bus.Quantity),
-		Status:          bus.Status,
-		TransferDate:    bus.TransferDate.Format(timeutil.FORMAT),
-		CreatedDate:     bus.CreatedDate.Format(timeutil.FORMAT),
-		UpdatedDate:     bus.UpdatedDate.Format(timeutil.FORMAT),
-		ScenarioID:      scenarioID,
+		TransferID:        bus.TransferID.String(),
+		TransferNumber:    transferNumber,
+		ProductID:         productID,
+		FromLocationID:    bus.FromLocationID.String(),
+		ToLocationID:      bus.ToLocationID.String(),
+		TransitLocationID: transitLocationID,
+		RequestedByID:     bus.RequestedByID.String(),
+		ApprovedByID:      approvedByID,
+		RejectedByID:      rejectedByID,
+		ApprovalReason:    bus.ApprovalReason,
+		RejectionReason:   bus.RejectionReason,
+		ClaimedByID:       claimedByID,
+		ClaimedAt:         claimedAt,
+		CompletedByID:     completedByID,
+		CompletedAt:       completedAt,
+		Quantity:          fmt.Sprintf("%d", bus.Quantity),
+		Status:            bus.Status,
+		TransferDate:      bus.TransferDate.Format(timeutil.FORMAT),
+		CreatedDate:       bus.CreatedDate.Format(timeutil.FORMAT),
+		UpdatedDate:       bus.UpdatedDate.Format(timeutil.FORMAT),
+		ScenarioID:        scenarioID,
 	}
 }
 
@@ -132,16 +144,25 @@ func ToAppTransferOrders(bus []transferorderbus.TransferOrder) []TransferOrder {
 	return app
 }
 
+// NewTransferOrder is what we require to create a transfer. Either Lines or
+// ProductID and Quantity (a single-line transfer) must be given.
 type NewTransferOrder struct {
-	TransferNumber *string `json:"transfer_number" validate:"omitempty,min=1,max=32"`
-	ProductID      string  `json:"product_id" validate:"required,min=36,max=36"`
-	FromLocationID string  `json:"from_location_id" validate:"required,min=36,max=36"`
-	ToLocationID   string  `json:"to_location_id" validate:"required,min=36,max=36"`
-	RequestedByID  string  `json:"requested_by" validate:"required,min=36,max=36"`
-	ApprovedByID   *string `json:"approved_by" validate:"omitempty,min=36,max=36"`
-	Quantity       string  `json:"quantity" validate:"required"`
-	Status         string  `json:"status" validate:"required,oneof=pending approved rejected in_transit completed"`
-	TransferDate   string  `json:"transfer_date" validate:"required"`
+	TransferNumber *string                `json:"transfer_number" validate:"omitempty,min=1,max=32"`
+	ProductID      string                 `json:"product_id" validate:"required_without=Lines,omitempty,min=36,max=36"`
+	FromLocationID string                 `json:"from_location_id" validate:"required,min=36,max=36"`
+	ToLocationID   string                 `json:"to_location_id" validate:"required,min=36,max=36"`
+	RequestedByID  string                 `json:"requested_by" validate:"required,min=36,max=36"`
+	ApprovedByID   *string                `json:"approved_by" validate:"omitempty,min=36,max=36"`
+	Quantity       string                 `json:"quantity" validate:"required_without=Lines"`
+	Status         string                 `json:"status" validate:"required,oneof=pending approved rejected in_transit partially_received completed"`
+	TransferDate   string                 `json:"transfer_date" validate:"required"`
+	Lines          []NewTransferOrderLine `json:"lines" validate:"omitempty,dive"`
+}
+
+// NewTransferOrderLine is one product on a new transfer.
+type NewTransferOrderLine struct {
+	ProductID string `json:"product_id" validate:"required,min=36,max=36"`
+	Quantity  int    `json:"quantity" validate:"required,gt=0"`
 }
 
 func (app *NewTransferOrder) Decode(data []byte) error {
@@ -156,9 +177,13 @@ func (app NewTransferOrder) Validate() error {
 }
 
 func toBusNewTransferOrder(app NewTransferOrder) (transferorderbus.NewTransferOrder, error) {
-	productID, err := uuid.Parse(app.ProductID)
-	if err != nil {
-		return transferorderbus.NewTransferOrder{}, errs.Newf(errs.InvalidArgument, "parse productID: %s", err)
+	var productID uuid.UUID
+	if app.ProductID != "" {
+		var err error
+		productID, err = uuid.Parse(app.ProductID)
+		if err != nil {
+			return transferorderbus.NewTransferOrder{}, errs.Newf(errs.InvalidArgument, "parse productID: %s", err)
+		}
 	}
 
 	fromLocationID, err := uuid.Parse(app.FromLocationID)
@@ -185,9 +210,24 @@ func toBusNewTransferOrder(app NewTransferOrder) (transferorderbus.NewTransferOr
 		approvedByID = &parsed
 	}
 
-	quantity, err := strconv.Atoi(app.Quantity)
-	if err != nil {
-		return transferorderbus.NewTransferOrder{}, errs.Newf(errs.InvalidArgument, "parse quantity: %s", err)
+	var quantity int
+	if app.Quantity != "" {
+		quantity, err = strconv.Atoi(app.Quantity)
+		if err != nil {
+			return transferorderbus.NewTransferOrder{}, errs.Newf(errs.InvalidArgument, "parse quantity: %s", err)
+		}
+	}
+
+	lines := make([]transferorderbus.NewTransferOrderLine, len(app.Lines))
+	for i, line := range app.Lines {
+		lineProductID, err := uuid.Parse(line.ProductID)
+		if err != nil {
+			return transferorderbus.NewTransferOrder{}, errs.Newf(errs.InvalidArgument, "parse lines[%d].productID: %s", i, err)
+		}
+		lines[i] = transferorderbus.NewTransferOrderLine{
+			ProductID: lineProductID,
+			Quantity:  line.Quantity,
+		}
 	}
 
 	transferDate, err := time.Parse(timeutil.FORMAT, app.TransferDate)
@@ -205,6 +245,7 @@ func toBusNewTransferOrder(app NewTransferOrder) (transferorderbus.NewTransferOr
 		Quantity:       quantity,
 		Status:         app.Status,
 		TransferDate:   transferDate,
+		Lines:          lines,
 	}
 	return bus, nil
 }
@@ -217,7 +258,7 @@ type UpdateTransferOrder struct {
 	RequestedByID  *string `json:"requested_by" validate:"omitempty,min=36,max=36"`
 	ApprovedByID   *string `json:"approved_by" validate:"omitempty,min=36,max=36"`
 	Quantity       *string `json:"quantity" validate:"omitempty"`
-	Status         *string `json:"status" validate:"omitempty,oneof=pending approved rejected in_transit completed"`
+	Status         *string `json:"status" validate:"omitempty,oneof=pending approved rejected in_transit partially_received completed"`
 	TransferDate   *string `json:"transfer_date" validate:"omitempty"`
 }
 
@@ -296,3 +337,107 @@ func toBusUpdateTransferOrder(app UpdateTransferOrder) (transferorderbus.UpdateT
 
 	return bus, nil
 }
+
+// =============================================================================
+
+// TransferOrderLine is one product on a transfer with its shipped, received
+// and written-off quantities.
+type TransferOrderLine struct {
+	ID                  string `json:"id"`
+	TransferID          string `json:"transfer_id"`
+	LineNumber          int    `json:"line_number"`
+	ProductID           string `json:"product_id"`
+	Quantity            int    `json:"quantity"`
+	QuantityShipped     int    `json:"quantity_shipped"`
+	QuantityReceived    int    `json:"quantity_received"`
+	DiscrepancyQuantity int    `json:"discrepancy_quantity"`
+	DiscrepancyReason   string `json:"discrepancy_reason"`
+	CreatedDate         string `json:"created_date"`
+	UpdatedDate         string `json:"updated_date"`
+}
+
+func toAppTransferOrderLine(bus transferorderbus.TransferOrderLine) TransferOrderLine {
+	return TransferOrderLine{
+		ID:                  bus.ID.String(),
+		TransferID:          bus.TransferID.String(),
+		LineNumber:          bus.LineNumber,
+		ProductID:           bus.ProductID.String(),
+		Quantity:            bus.Quantity,
+		QuantityShipped:     bus.QuantityShipped,
+		QuantityReceived:    bus.QuantityReceived,
+		DiscrepancyQuantity: bus.DiscrepancyQuantity,
+		DiscrepancyReason:   bus.DiscrepancyReason,
+		CreatedDate:         bus.CreatedDate.Format(timeutil.FORMAT),
+		UpdatedDate:         bus.UpdatedDate.Format(timeutil.FORMAT),
+	}
+}
+
+// TransferOrderLines is the set of lines of a transfer.
+type TransferOrderLines []TransferOrderLine
+
+// Encode implements the encoder interface.
+func (app TransferOrderLines) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// ToAppTransferOrderLines converts a slice of bus lines to app lines.
+func ToAppTransferOrderLines(bus []transferorderbus.TransferOrderLine) TransferOrderLines {
+	app := make(TransferOrderLines, len(bus))
+	for i, v := range bus {
+		app[i] = toAppTransferOrderLine(v)
+	}
+	return app
+}
+
+// ReceiveRequest books goods arriving at the destination of a shipped
+// transfer. Close writes off whatever is still outstanding after the receipt
+// and completes the transfer.
+type ReceiveRequest struct {
+	Lines  []ReceiveLine `json:"lines" validate:"omitempty,dive"`
+	Close  bool          `json:"close"`
+	Reason string        `json:"reason" validate:"omitempty,max=500"`
+}
+
+// ReceiveLine is the quantity of one transfer line received.
+type ReceiveLine struct {
+	LineID   string `json:"line_id" validate:"required,min=36,max=36"`
+	Quantity int    `json:"quantity" validate:"gte=0"`
+	Reason   string `json:"reason" validate:"omitempty,max=500"`
+}
+
+// Decode implements the decoder interface.
+func (app *ReceiveRequest) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app ReceiveRequest) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusReceipt(app ReceiveRequest, receivedBy uuid.UUID) (transferorderbus.Receipt, error) {
+	rc := transferorderbus.Receipt{
+		ReceivedBy: receivedBy,
+		Lines:      make([]transferorderbus.ReceiptLine, len(app.Lines)),
+		Close:      app.Close,
+		Reason:     app.Reason,
+	}
+
+	for i, line := range app.Lines {
+		lineID, err := uuid.Parse(line.LineID)
+		if err != nil {
+			return transferorderbus.Receipt{}, fmt.Errorf("parse lines[%d].lineID: %w", i, err)
+		}
+		rc.Lines[i] = transferorderbus.ReceiptLine{
+			LineID:   lineID,
+			Quantity: line.Quantity,
+			Reason:   line.Reason,
+		}
+	}
+
+	return rc, nil
+}
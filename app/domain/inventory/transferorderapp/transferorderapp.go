@@ -91,6 +91,9 @@ func (a *App) Create(ctx context.Context, app NewTransferOrder) (TransferOrder,
 
 	to, err := a.transferorderbus.Create(ctx, nt)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return TransferOrder{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, transferorderbus.ErrUniqueEntry) {
 			return TransferOrder{}, errs.New(errs.AlreadyExists, err)
 		}
@@ -119,6 +122,9 @@ func (a *App) Update(ctx context.Context, id uuid.UUID, app UpdateTransferOrder)
 
 	to, err = a.transferorderbus.Update(ctx, to, uto)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return TransferOrder{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, transferorderbus.ErrUniqueEntry) {
 			return TransferOrder{}, errs.New(errs.AlreadyExists, err)
 		}
@@ -239,6 +245,9 @@ func (a *App) Reject(ctx context.Context, id uuid.UUID) (TransferOrder, error) {
 	return ToAppTransferOrder(rejected), nil
 }
 
+// Claim ships an approved transfer: it moves to in_transit and every line's
+// stock moves atomically from the source location into the in-transit
+// location, with a TRANSFER_OUT/TRANSFER_IN ledger pair per line.
 func (a *App) Claim(ctx context.Context, id uuid.UUID) (TransferOrder, error) {
 	userID, err := mid.GetUserID(ctx)
 	if err != nil {
@@ -253,32 +262,96 @@ func (a *App) Claim(ctx context.Context, id uuid.UUID) (TransferOrder, error) {
 		return TransferOrder{}, fmt.Errorf("claim [querybyid]: %w", err)
 	}
 
-	claimed, err := a.transferorderbus.Claim(ctx, to, userID)
+	tx, err := a.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
 	if err != nil {
-		if errors.Is(err, transferorderbus.ErrInvalidTransferStatus) {
+		return TransferOrder{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Enroll the tx on ctx so cascade outbox.Emit rides the same transaction as the entity
+	// write (they commit or roll back together) instead of falling back to the base pool.
+	ctx = sqldb.WithTx(ctx, tx)
+
+	toBusTx, err := a.transferorderbus.NewWithTx(tx)
+	if err != nil {
+		return TransferOrder{}, fmt.Errorf("new transferorder tx: %w", err)
+	}
+
+	claimed, moves, err := toBusTx.Claim(ctx, to, userID)
+	if err != nil {
+		if errors.Is(err, transferorderbus.ErrInvalidTransferStatus) || errors.Is(err, transferorderbus.ErrNoLines) {
 			return TransferOrder{}, errs.New(errs.FailedPrecondition, err)
 		}
 		return TransferOrder{}, fmt.Errorf("claim: %w", err)
 	}
 
+	if err := a.moveStock(ctx, tx, claimed, userID, to.FromLocationID, claimed.InTransitLocationID(), moves); err != nil {
+		return TransferOrder{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return TransferOrder{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
 	return ToAppTransferOrder(claimed), nil
 }
 
-// Execute atomically completes a transfer: marks the order completed, creates
-// two inventory transactions (TRANSFER_OUT and TRANSFER_IN), decrements source
-// location stock, and increments destination location stock.
+// Execute atomically completes a shipped transfer: every line receives what is
+// still outstanding, moving stock from the in-transit location to the
+// destination with a TRANSFER_OUT/TRANSFER_IN ledger pair per line.
 func (a *App) Execute(ctx context.Context, id uuid.UUID) (TransferOrder, error) {
 	userID, err := mid.GetUserID(ctx)
 	if err != nil {
 		return TransferOrder{}, errs.New(errs.Unauthenticated, err)
 	}
 
+	return a.receive(ctx, id, userID, func(ctx context.Context, bus *transferorderbus.Business, to transferorderbus.TransferOrder) (transferorderbus.TransferOrder, []transferorderbus.Movement, error) {
+		return bus.Execute(ctx, to, userID)
+	})
+}
+
+// Receive books a (possibly partial) receipt against a shipped transfer,
+// moving the received stock from the in-transit location to the destination
+// and writing off any shortfall when the receipt closes the transfer.
+func (a *App) Receive(ctx context.Context, id uuid.UUID, app ReceiveRequest) (TransferOrder, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return TransferOrder{}, errs.New(errs.Unauthenticated, err)
+	}
+
+	rc, err := toBusReceipt(app, userID)
+	if err != nil {
+		return TransferOrder{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	return a.receive(ctx, id, userID, func(ctx context.Context, bus *transferorderbus.Business, to transferorderbus.TransferOrder) (transferorderbus.TransferOrder, []transferorderbus.Movement, error) {
+		return bus.Receive(ctx, to, rc)
+	})
+}
+
+// QueryLines returns the lines of a transfer.
+func (a *App) QueryLines(ctx context.Context, id uuid.UUID) (TransferOrderLines, error) {
+	if _, err := a.QueryByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	lines, err := a.transferorderbus.QueryLines(ctx, id)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "querylines: %s", err)
+	}
+
+	return ToAppTransferOrderLines(lines), nil
+}
+
+// receive runs a receipt against a transfer inside one transaction together
+// with the stock it moves.
+func (a *App) receive(ctx context.Context, id uuid.UUID, userID uuid.UUID, fn func(context.Context, *transferorderbus.Business, transferorderbus.TransferOrder) (transferorderbus.TransferOrder, []transferorderbus.Movement, error)) (TransferOrder, error) {
 	to, err := a.transferorderbus.QueryByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, transferorderbus.ErrNotFound) {
 			return TransferOrder{}, errs.New(errs.NotFound, err)
 		}
-		return TransferOrder{}, fmt.Errorf("execute [querybyid]: %w", err)
+		return TransferOrder{}, fmt.Errorf("receive [querybyid]: %w", err)
 	}
 
 	tx, err := a.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
@@ -291,77 +364,113 @@ func (a *App) Execute(ctx context.Context, id uuid.UUID) (TransferOrder, error)
 	// write (they commit or roll back together) instead of falling back to the base pool.
 	ctx = sqldb.WithTx(ctx, tx)
 
-	// 1. Mark transfer order as completed.
 	toBusTx, err := a.transferorderbus.NewWithTx(tx)
 	if err != nil {
 		return TransferOrder{}, fmt.Errorf("new transferorder tx: %w", err)
 	}
 
-	completed, err := toBusTx.Execute(ctx, to, userID)
+	received, moves, err := fn(ctx, toBusTx, to)
 	if err != nil {
-		if errors.Is(err, transferorderbus.ErrInvalidTransferStatus) {
+		switch {
+		case errors.Is(err, transferorderbus.ErrInvalidTransferStatus):
 			return TransferOrder{}, errs.New(errs.FailedPrecondition, err)
+		case errors.Is(err, transferorderbus.ErrLineNotFound),
+			errors.Is(err, transferorderbus.ErrInvalidQuantity),
+			errors.Is(err, transferorderbus.ErrOverReceipt),
+			errors.Is(err, transferorderbus.ErrEmptyReceipt):
+			return TransferOrder{}, errs.New(errs.InvalidArgument, err)
 		}
-		return TransferOrder{}, fmt.Errorf("execute transfer: %w", err)
+		return TransferOrder{}, fmt.Errorf("receive: %w", err)
 	}
 
-	// 2. Create TRANSFER_OUT inventory transaction (source location).
-	txBusTx, err := a.invTransactionBus.NewWithTx(tx)
-	if err != nil {
-		return TransferOrder{}, fmt.Errorf("new invtransaction tx: %w", err)
+	if err := a.moveStock(ctx, tx, received, userID, to.InTransitLocationID(), to.ToLocationID, moves); err != nil {
+		return TransferOrder{}, err
 	}
 
-	refNum := to.TransferID.String()
-	now := time.Now()
-
-	_, err = txBusTx.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
-		ProductID:       to.ProductID,
-		LocationID:      to.FromLocationID,
-		UserID:          userID,
-		Quantity:        -to.Quantity,
-		TransactionType: "TRANSFER_OUT",
-		ReferenceNumber: refNum,
-		TransactionDate: now,
-	})
-	if err != nil {
-		return TransferOrder{}, fmt.Errorf("create transfer_out transaction: %w", err)
+	if err := tx.Commit(); err != nil {
+		return TransferOrder{}, fmt.Errorf("commit transaction: %w", err)
 	}
 
-	// 3. Create TRANSFER_IN inventory transaction (destination location).
-	_, err = txBusTx.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
-		ProductID:       to.ProductID,
-		LocationID:      to.ToLocationID,
-		UserID:          userID,
-		Quantity:        to.Quantity,
-		TransactionType: "TRANSFER_IN",
-		ReferenceNumber: refNum,
-		TransactionDate: now,
-	})
+	return ToAppTransferOrder(received), nil
+}
+
+// moveStock applies the movements of a claim or receipt inside tx: each line's
+// quantity is decremented at from and incremented at dest with a
+// TRANSFER_OUT/TRANSFER_IN ledger pair, and any discrepancy is written off at
+// from with an ADJUSTMENT.
+func (a *App) moveStock(ctx context.Context, tx sqldb.CommitRollbacker, to transferorderbus.TransferOrder, userID uuid.UUID, from uuid.UUID, dest uuid.UUID, moves []transferorderbus.Movement) error {
+	txBusTx, err := a.invTransactionBus.NewWithTx(tx)
 	if err != nil {
-		return TransferOrder{}, fmt.Errorf("create transfer_in transaction: %w", err)
+		return fmt.Errorf("new invtransaction tx: %w", err)
 	}
 
-	// 4. Decrement source location stock.
 	itemBusTx, err := a.invItemBus.NewWithTx(tx)
 	if err != nil {
-		return TransferOrder{}, fmt.Errorf("new invitem tx: %w", err)
+		return fmt.Errorf("new invitem tx: %w", err)
 	}
 
-	if err := itemBusTx.DecrementQuantity(ctx, to.ProductID, to.FromLocationID, to.Quantity); err != nil {
-		if errors.Is(err, inventoryitembus.ErrInsufficientStock) {
-			return TransferOrder{}, errs.New(errs.FailedPrecondition, err)
-		}
-		return TransferOrder{}, fmt.Errorf("decrement source inventory: %w", err)
-	}
+	refNum := to.TransferID.String()
+	now := time.Now()
 
-	// 5. Increment destination location stock.
-	if err := itemBusTx.UpsertQuantity(ctx, to.ProductID, to.ToLocationID, to.Quantity); err != nil {
-		return TransferOrder{}, fmt.Errorf("upsert destination inventory: %w", err)
-	}
+	for _, m := range moves {
+		if m.Quantity > 0 {
+			if _, err := txBusTx.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
+				ProductID:       m.ProductID,
+				LocationID:      from,
+				UserID:          userID,
+				Quantity:        -m.Quantity,
+				TransactionType: "TRANSFER_OUT",
+				ReferenceNumber: refNum,
+				TransactionDate: now,
+			}); err != nil {
+				return fmt.Errorf("create transfer_out transaction: %w", err)
+			}
+
+			if _, err := txBusTx.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
+				ProductID:       m.ProductID,
+				LocationID:      dest,
+				UserID:          userID,
+				Quantity:        m.Quantity,
+				TransactionType: "TRANSFER_IN",
+				ReferenceNumber: refNum,
+				TransactionDate: now,
+			}); err != nil {
+				return fmt.Errorf("create transfer_in transaction: %w", err)
+			}
+
+			if err := itemBusTx.DecrementQuantity(ctx, m.ProductID, from, m.Quantity); err != nil {
+				if errors.Is(err, inventoryitembus.ErrInsufficientStock) {
+					return errs.New(errs.FailedPrecondition, err)
+				}
+				return fmt.Errorf("decrement source inventory: %w", err)
+			}
+
+			if err := itemBusTx.UpsertQuantity(ctx, m.ProductID, dest, m.Quantity); err != nil {
+				return fmt.Errorf("upsert destination inventory: %w", err)
+			}
+		}
 
-	if err := tx.Commit(); err != nil {
-		return TransferOrder{}, fmt.Errorf("commit transaction: %w", err)
+		if m.Discrepancy > 0 {
+			if _, err := txBusTx.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
+				ProductID:       m.ProductID,
+				LocationID:      from,
+				UserID:          userID,
+				Quantity:        -m.Discrepancy,
+				TransactionType: "ADJUSTMENT",
+				ReferenceNumber: refNum,
+				TransactionDate: now,
+			}); err != nil {
+				return fmt.Errorf("create discrepancy transaction: %w", err)
+			}
+
+			if err := itemBusTx.DecrementQuantity(ctx, m.ProductID, from, m.Discrepancy); err != nil {
+				if errors.Is(err, inventoryitembus.ErrInsufficientStock) {
+					return errs.New(errs.FailedPrecondition, err)
+				}
+				return fmt.Errorf("write off in-transit inventory: %w", err)
+			}
+		}
 	}
 
-	return ToAppTransferOrder(completed), nil
+	return nil
 }
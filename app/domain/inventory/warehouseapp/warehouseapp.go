@@ -58,6 +58,9 @@ func (a *App) Create(ctx context.Context, app NewWarehouse) (Warehouse, error) {
 
 	wa, err := a.warehouseBus.Create(ctx, nw)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return Warehouse{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, warehousebus.ErrUniqueEntry) {
 			return Warehouse{}, errs.New(errs.Aborted, warehousebus.ErrUniqueEntry)
 		}
@@ -81,6 +84,9 @@ func (a *App) Update(ctx context.Context, app UpdateWarehouse, id uuid.UUID) (Wa
 
 	warehouse, err := a.warehouseBus.Update(ctx, wa, uw)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return Warehouse{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, warehousebus.ErrNotFound) {
 			return Warehouse{}, errs.New(errs.NotFound, err)
 		}
@@ -139,6 +145,9 @@ func (a *App) Query(ctx context.Context, qp QueryParams) (query.Result[Warehouse
 func (a *App) QueryByID(ctx context.Context, id uuid.UUID) (Warehouse, error) {
 	warehouse, err := a.warehouseBus.QueryByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, warehousebus.ErrNotFound) {
+			return Warehouse{}, errs.New(errs.NotFound, err)
+		}
 		return Warehouse{}, errs.Newf(errs.Internal, "querybyid: %s", err)
 	}
 	return ToAppWarehouse(warehouse), nil
@@ -55,6 +55,9 @@ func (a *App) Create(ctx context.Context, app NewZone) (Zone, error) {
 
 	z, err := a.zonebus.Create(ctx, nz)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return Zone{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, zonebus.ErrUniqueEntry) {
 			return Zone{}, errs.New(errs.AlreadyExists, err)
 		}
@@ -83,6 +86,9 @@ func (a *App) Update(ctx context.Context, app UpdateZone, id uuid.UUID) (Zone, e
 
 	updated, err := a.zonebus.Update(ctx, z, uz)
 	if err != nil {
+		if errors.Is(err, sqldb.ErrOutOfDataScope) {
+			return Zone{}, errs.New(errs.PermissionDenied, err)
+		}
 		if errors.Is(err, zonebus.ErrUniqueEntry) {
 			return Zone{}, errs.New(errs.AlreadyExists, err)
 		}
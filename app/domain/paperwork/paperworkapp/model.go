@@ -18,6 +18,13 @@ type PickSheetRequest struct {
 	Zone    string
 }
 
+// BatchPickSheetRequest carries inputs for rendering a pick sheet for a whole
+// pick batch. Zone is optional and rendered in the header only.
+type BatchPickSheetRequest struct {
+	BatchID uuid.UUID
+	Zone    string
+}
+
 // ReceiveCoverRequest carries inputs for rendering a receive-cover PDF.
 type ReceiveCoverRequest struct {
 	PurchaseOrderID uuid.UUID
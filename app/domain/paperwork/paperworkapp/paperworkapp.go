@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/google/uuid"
 	"github.com/timmaaaz/ichor/app/sdk/errs"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/picktaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/pickwavebus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/transferorderbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/warehousebus"
 	"github.com/timmaaaz/ichor/business/domain/paperwork/pdf"
@@ -32,6 +34,7 @@ var (
 	ErrPONotFound            = errors.New("paperwork: purchase order not found")
 	ErrTransferNotFound      = errors.New("paperwork: transfer order not found")
 	ErrTransferNumberMissing = errors.New("paperwork: transfer order has no transfer_number")
+	ErrBatchNotFound         = errors.New("paperwork: pick batch not found")
 )
 
 // App orchestrates cross-domain reads for paperwork rendering. Per the
@@ -51,6 +54,7 @@ type App struct {
 	warehouses         *warehousebus.Business
 	inventoryLocations *inventorylocationbus.Business
 	products           *productbus.Business
+	pickWaves          *pickwavebus.Business
 }
 
 // NewApp constructs the paperwork app.
@@ -67,6 +71,7 @@ func NewApp(
 	warehouses *warehousebus.Business,
 	inventoryLocations *inventorylocationbus.Business,
 	products *productbus.Business,
+	pickWaves *pickwavebus.Business,
 ) *App {
 	return &App{
 		log:                log,
@@ -81,6 +86,7 @@ func NewApp(
 		warehouses:         warehouses,
 		inventoryLocations: inventoryLocations,
 		products:           products,
+		pickWaves:          pickWaves,
 	}
 }
 
@@ -166,6 +172,107 @@ func (a *App) BuildPickSheet(ctx context.Context, req PickSheetRequest) ([]byte,
 	return out, nil
 }
 
+// BuildBatchPickSheet renders one pick sheet for a whole pick batch: a tote
+// legend mapping each tote on the cart to its order, then every stop still to
+// pick in route order.
+func (a *App) BuildBatchPickSheet(ctx context.Context, req BatchPickSheetRequest) ([]byte, error) {
+	batch, err := a.pickWaves.QueryBatchByID(ctx, req.BatchID)
+	if err != nil {
+		if errors.Is(err, pickwavebus.ErrBatchNotFound) {
+			return nil, errs.New(errs.NotFound, ErrBatchNotFound)
+		}
+		return nil, a.internal(ctx, "buildbatchpicksheet: batch", err)
+	}
+
+	tasks, err := a.pickWaves.QueryStops(ctx, batch)
+	if err != nil {
+		return nil, a.internal(ctx, "buildbatchpicksheet: stops", err)
+	}
+
+	// Every order keeps its tote for the life of the batch, so the legend is
+	// built from all stops; only the table skips terminal ones.
+	toteByOrder := make(map[uuid.UUID]int)
+	orderIDs := make([]uuid.UUID, 0, batch.OrderCount)
+	active := make([]picktaskbus.PickTask, 0, len(tasks))
+	productIDs := make([]uuid.UUID, 0, len(tasks))
+	locationIDs := make([]uuid.UUID, 0, len(tasks))
+	for _, tk := range tasks {
+		if _, ok := toteByOrder[tk.SalesOrderID]; !ok {
+			toteByOrder[tk.SalesOrderID] = derefInt(tk.ToteNumber)
+			orderIDs = append(orderIDs, tk.SalesOrderID)
+		}
+		switch tk.Status {
+		case picktaskbus.Statuses.Completed, picktaskbus.Statuses.ShortPicked, picktaskbus.Statuses.Cancelled:
+			continue
+		}
+		active = append(active, tk)
+		productIDs = append(productIDs, tk.ProductID)
+		locationIDs = append(locationIDs, tk.LocationID)
+	}
+
+	orders, err := a.orders.QueryByIDs(ctx, orderIDs)
+	if err != nil {
+		return nil, a.internal(ctx, "buildbatchpicksheet: orders", err)
+	}
+
+	// A batch holds at most one cart of orders, so resolving customers one
+	// order at a time stays bounded by the tote count.
+	totes := make([]pdf.PickSheetTote, 0, len(orders))
+	for _, o := range orders {
+		customer, err := a.customers.QueryByID(ctx, o.CustomerID)
+		if err != nil {
+			return nil, a.internal(ctx, "buildbatchpicksheet: customer", err)
+		}
+		totes = append(totes, pdf.PickSheetTote{
+			Tote:         toteByOrder[o.ID],
+			OrderNumber:  o.Number,
+			CustomerName: customer.Name,
+		})
+	}
+	sort.Slice(totes, func(i, j int) bool { return totes[i].Tote < totes[j].Tote })
+
+	productByID, err := a.productsByID(ctx, "buildbatchpicksheet", productIDs)
+	if err != nil {
+		return nil, err
+	}
+	locationByID, err := a.locationsByID(ctx, "buildbatchpicksheet", locationIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]pdf.PickSheetLine, 0, len(active))
+	for _, tk := range active {
+		prod, ok := productByID[tk.ProductID]
+		if !ok {
+			return nil, a.internal(ctx, "buildbatchpicksheet: product", fmt.Errorf("product %s not found for pick task %s", tk.ProductID, tk.ID))
+		}
+		loc, ok := locationByID[tk.LocationID]
+		if !ok {
+			return nil, a.internal(ctx, "buildbatchpicksheet: location", fmt.Errorf("location %s not found for pick task %s", tk.LocationID, tk.ID))
+		}
+		lines = append(lines, pdf.PickSheetLine{
+			Stop:         derefInt(tk.BatchSequence),
+			Tote:         derefInt(tk.ToteNumber),
+			LocationCode: derefStr(loc.LocationCode),
+			SKU:          prod.SKU,
+			ProductName:  prod.Name,
+			Quantity:     tk.QuantityToPick,
+		})
+	}
+
+	data := pdf.PickSheetData{
+		TaskCode: taskCodeFor("BATCH", batch.BatchNumber),
+		Zone:     req.Zone,
+		Totes:    totes,
+		Lines:    lines,
+	}
+	out, err := pdf.PickSheet(data)
+	if err != nil {
+		return nil, a.internal(ctx, "buildbatchpicksheet: render", err)
+	}
+	return out, nil
+}
+
 // BuildReceiveCover renders a receive-cover PDF for the given purchase order.
 func (a *App) BuildReceiveCover(ctx context.Context, req ReceiveCoverRequest) ([]byte, error) {
 	po, err := a.purchaseOrders.QueryByID(ctx, req.PurchaseOrderID)
@@ -380,6 +487,14 @@ func dedupe(ids []uuid.UUID) []uuid.UUID {
 	return out
 }
 
+// derefInt returns the pointed-to int or 0 if nil.
+func derefInt(n *int) int {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
+
 // derefStr returns the pointed-to string or "" if nil.
 func derefStr(s *string) string {
 	if s == nil {
@@ -89,6 +89,7 @@ func newTestApp(db *dbtest.Database) *paperworkapp.App {
 		db.BusDomain.Warehouse,
 		db.BusDomain.InventoryLocation,
 		db.BusDomain.Product,
+		db.BusDomain.PickWave,
 	)
 }
 
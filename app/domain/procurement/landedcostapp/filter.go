@@ -0,0 +1,37 @@
+package landedcostapp
+
+import (
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/procurement/landedcostbus"
+)
+
+func parseFilter(qp QueryParams) (landedcostbus.QueryFilter, error) {
+	var filter landedcostbus.QueryFilter
+
+	for _, f := range []struct {
+		in  string
+		out **uuid.UUID
+	}{
+		{qp.ID, &filter.ID},
+		{qp.PurchaseOrderID, &filter.PurchaseOrderID},
+	} {
+		if f.in == "" {
+			continue
+		}
+		id, err := uuid.Parse(f.in)
+		if err != nil {
+			return landedcostbus.QueryFilter{}, err
+		}
+		*f.out = &id
+	}
+
+	if qp.ReferenceNumber != "" {
+		filter.ReferenceNumber = &qp.ReferenceNumber
+	}
+
+	if qp.Status != "" {
+		filter.Status = &qp.Status
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,202 @@
+// Package landedcostapp maintains the app layer api for landed costs: the
+// freight, duty, brokerage and insurance charges of a purchase order and
+// their allocation onto the landed cost of the products received.
+package landedcostapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/app/sdk/mid"
+	"github.com/timmaaaz/ichor/app/sdk/query"
+	"github.com/timmaaaz/ichor/business/domain/procurement/landedcostbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// App manages the set of app layer APIs for landed cost access.
+type App struct {
+	landedCostBus *landedcostbus.Business
+}
+
+// NewApp constructs a landed cost app.
+func NewApp(landedCostBus *landedcostbus.Business) *App {
+	return &App{
+		landedCostBus: landedCostBus,
+	}
+}
+
+// Create enters a draft landed cost on a purchase order.
+func (a *App) Create(ctx context.Context, app NewLandedCost) (LandedCost, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return LandedCost{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	nlc, err := toBusNewLandedCost(app, userID)
+	if err != nil {
+		return LandedCost{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	lc, err := a.landedCostBus.Create(ctx, nlc, time.Now())
+	if err != nil {
+		return LandedCost{}, toAppError("create", err)
+	}
+
+	return ToAppLandedCost(lc), nil
+}
+
+// Update modifies a draft landed cost.
+func (a *App) Update(ctx context.Context, landedCostID uuid.UUID, app UpdateLandedCost) (LandedCost, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return LandedCost{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	ulc, err := toBusUpdateLandedCost(app, userID)
+	if err != nil {
+		return LandedCost{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	lc, err := a.queryByID(ctx, landedCostID)
+	if err != nil {
+		return LandedCost{}, err
+	}
+
+	updated, err := a.landedCostBus.Update(ctx, lc, ulc, time.Now())
+	if err != nil {
+		return LandedCost{}, toAppError("update", err)
+	}
+
+	return ToAppLandedCost(updated), nil
+}
+
+// Preview shows how a draft's charges would be allocated if it were applied
+// now, without changing anything.
+func (a *App) Preview(ctx context.Context, landedCostID uuid.UUID) (Preview, error) {
+	lc, err := a.queryByID(ctx, landedCostID)
+	if err != nil {
+		return Preview{}, err
+	}
+
+	previewed, products, err := a.landedCostBus.Preview(ctx, lc)
+	if err != nil {
+		return Preview{}, toAppError("preview", err)
+	}
+
+	return ToAppPreview(previewed, products), nil
+}
+
+// Apply allocates a draft's charges across what has been received and
+// updates the landed cost of the products received.
+func (a *App) Apply(ctx context.Context, landedCostID uuid.UUID) (LandedCost, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return LandedCost{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	lc, err := a.queryByID(ctx, landedCostID)
+	if err != nil {
+		return LandedCost{}, err
+	}
+
+	applied, err := a.landedCostBus.Apply(ctx, lc, userID, time.Now())
+	if err != nil {
+		return LandedCost{}, toAppError("apply", err)
+	}
+
+	return ToAppLandedCost(applied), nil
+}
+
+// Cancel abandons a draft landed cost.
+func (a *App) Cancel(ctx context.Context, landedCostID uuid.UUID) (LandedCost, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return LandedCost{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	lc, err := a.queryByID(ctx, landedCostID)
+	if err != nil {
+		return LandedCost{}, err
+	}
+
+	cancelled, err := a.landedCostBus.Cancel(ctx, lc, userID, time.Now())
+	if err != nil {
+		return LandedCost{}, toAppError("cancel", err)
+	}
+
+	return ToAppLandedCost(cancelled), nil
+}
+
+// Query retrieves a list of landed costs based on query parameters.
+func (a *App) Query(ctx context.Context, qp QueryParams) (query.Result[LandedCost], error) {
+	pg, err := page.Parse(qp.Page, qp.Rows)
+	if err != nil {
+		return query.Result[LandedCost]{}, errs.NewFieldsError("page", err)
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return query.Result[LandedCost]{}, errs.NewFieldsError("filter", err)
+	}
+
+	orderBy, err := order.Parse(orderByFields, qp.OrderBy, defaultOrderBy)
+	if err != nil {
+		return query.Result[LandedCost]{}, errs.NewFieldsError("orderBy", err)
+	}
+
+	lcs, err := a.landedCostBus.Query(ctx, filter, orderBy, pg)
+	if err != nil {
+		return query.Result[LandedCost]{}, errs.Newf(errs.Internal, "query: %v", err)
+	}
+
+	total, err := a.landedCostBus.Count(ctx, filter)
+	if err != nil {
+		return query.Result[LandedCost]{}, errs.Newf(errs.Internal, "count: %v", err)
+	}
+
+	return query.NewResult(ToAppLandedCosts(lcs), total, pg), nil
+}
+
+// QueryByID retrieves a single landed cost by ID.
+func (a *App) QueryByID(ctx context.Context, landedCostID uuid.UUID) (LandedCost, error) {
+	lc, err := a.queryByID(ctx, landedCostID)
+	if err != nil {
+		return LandedCost{}, err
+	}
+
+	return ToAppLandedCost(lc), nil
+}
+
+// =============================================================================
+
+func (a *App) queryByID(ctx context.Context, landedCostID uuid.UUID) (landedcostbus.LandedCost, error) {
+	lc, err := a.landedCostBus.QueryByID(ctx, landedCostID)
+	if err != nil {
+		if errors.Is(err, landedcostbus.ErrNotFound) {
+			return landedcostbus.LandedCost{}, errs.New(errs.NotFound, err)
+		}
+		return landedcostbus.LandedCost{}, fmt.Errorf("querybyid: %w", err)
+	}
+
+	return lc, nil
+}
+
+func toAppError(op string, err error) error {
+	switch {
+	case errors.Is(err, landedcostbus.ErrInvalidLandedCost):
+		return errs.New(errs.InvalidArgument, err)
+	case errors.Is(err, landedcostbus.ErrNothingReceived),
+		errors.Is(err, landedcostbus.ErrNoAllocationBasis),
+		errors.Is(err, landedcostbus.ErrNoProductCost):
+		return errs.New(errs.FailedPrecondition, err)
+	case errors.Is(err, landedcostbus.ErrNotDraft),
+		errors.Is(err, landedcostbus.ErrForeignKeyViolation):
+		return errs.New(errs.Aborted, err)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
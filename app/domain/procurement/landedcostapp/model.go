@@ -0,0 +1,353 @@
+package landedcostapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/procurement/landedcostbus"
+	"github.com/timmaaaz/ichor/foundation/timeutil"
+)
+
+// QueryParams holds the raw query parameters for listing landed costs.
+type QueryParams struct {
+	Page            string
+	Rows            string
+	OrderBy         string
+	ID              string
+	PurchaseOrderID string
+	ReferenceNumber string
+	Status          string
+}
+
+// =============================================================================
+// Response models
+// =============================================================================
+
+// Charge is the app-layer response model for a landed cost charge.
+type Charge struct {
+	ID               string `json:"id"`
+	LandedCostID     string `json:"landed_cost_id"`
+	ChargeType       string `json:"charge_type"`
+	AllocationMethod string `json:"allocation_method"`
+	Amount           string `json:"amount"`
+	Description      string `json:"description"`
+}
+
+func toAppCharge(bus landedcostbus.Charge) Charge {
+	return Charge{
+		ID:               bus.ID.String(),
+		LandedCostID:     bus.LandedCostID.String(),
+		ChargeType:       bus.ChargeType,
+		AllocationMethod: bus.AllocationMethod,
+		Amount:           strconv.FormatFloat(bus.Amount, 'f', 2, 64),
+		Description:      bus.Description,
+	}
+}
+
+// Allocation is the app-layer response model for the share of a charge one
+// received line carries.
+type Allocation struct {
+	ID                      string `json:"id"`
+	LandedCostID            string `json:"landed_cost_id"`
+	ChargeID                string `json:"charge_id"`
+	PurchaseOrderLineItemID string `json:"purchase_order_line_item_id"`
+	ProductID               string `json:"product_id"`
+	Quantity                string `json:"quantity"`
+	Basis                   string `json:"basis"`
+	Amount                  string `json:"amount"`
+}
+
+func toAppAllocation(bus landedcostbus.Allocation) Allocation {
+	return Allocation{
+		ID:                      bus.ID.String(),
+		LandedCostID:            bus.LandedCostID.String(),
+		ChargeID:                bus.ChargeID.String(),
+		PurchaseOrderLineItemID: bus.PurchaseOrderLineItemID.String(),
+		ProductID:               bus.ProductID.String(),
+		Quantity:                strconv.Itoa(bus.Quantity),
+		Basis:                   strconv.FormatFloat(bus.Basis, 'f', 4, 64),
+		Amount:                  strconv.FormatFloat(bus.Amount, 'f', 2, 64),
+	}
+}
+
+// LandedCost is the app-layer response model for a landed cost with its
+// charges and, once applied, their allocations.
+type LandedCost struct {
+	ID              string       `json:"id"`
+	PurchaseOrderID string       `json:"purchase_order_id"`
+	ReferenceNumber string       `json:"reference_number"`
+	CurrencyID      string       `json:"currency_id"`
+	LineItemIDs     []string     `json:"line_item_ids"`
+	Status          string       `json:"status"`
+	TotalCharges    string       `json:"total_charges"`
+	Notes           string       `json:"notes"`
+	AppliedBy       string       `json:"applied_by"`
+	AppliedDate     string       `json:"applied_date"`
+	CreatedBy       string       `json:"created_by"`
+	UpdatedBy       string       `json:"updated_by"`
+	CreatedDate     string       `json:"created_date"`
+	UpdatedDate     string       `json:"updated_date"`
+	Charges         []Charge     `json:"charges"`
+	Allocations     []Allocation `json:"allocations"`
+}
+
+// Encode implements the encoder interface.
+func (app LandedCost) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// ToAppLandedCost converts a bus model to an app-layer response model.
+func ToAppLandedCost(bus landedcostbus.LandedCost) LandedCost {
+	lineItemIDs := make([]string, len(bus.LineItemIDs))
+	for i, id := range bus.LineItemIDs {
+		lineItemIDs[i] = id.String()
+	}
+
+	charges := make([]Charge, len(bus.Charges))
+	for i, c := range bus.Charges {
+		charges[i] = toAppCharge(c)
+	}
+
+	allocations := make([]Allocation, len(bus.Allocations))
+	for i, a := range bus.Allocations {
+		allocations[i] = toAppAllocation(a)
+	}
+
+	return LandedCost{
+		ID:              bus.ID.String(),
+		PurchaseOrderID: bus.PurchaseOrderID.String(),
+		ReferenceNumber: bus.ReferenceNumber,
+		CurrencyID:      bus.CurrencyID.String(),
+		LineItemIDs:     lineItemIDs,
+		Status:          bus.Status,
+		TotalCharges:    strconv.FormatFloat(bus.TotalCharges, 'f', 2, 64),
+		Notes:           bus.Notes,
+		AppliedBy:       optionalID(bus.AppliedBy),
+		AppliedDate:     optionalTime(bus.AppliedDate),
+		CreatedBy:       bus.CreatedBy.String(),
+		UpdatedBy:       bus.UpdatedBy.String(),
+		CreatedDate:     bus.CreatedDate.Format(timeutil.FORMAT),
+		UpdatedDate:     bus.UpdatedDate.Format(timeutil.FORMAT),
+		Charges:         charges,
+		Allocations:     allocations,
+	}
+}
+
+// ToAppLandedCosts converts a slice of bus models to app-layer response
+// models.
+func ToAppLandedCosts(bus []landedcostbus.LandedCost) []LandedCost {
+	app := make([]LandedCost, len(bus))
+	for i, v := range bus {
+		app[i] = ToAppLandedCost(v)
+	}
+	return app
+}
+
+// ProductLandedCost is the landed unit cost a product gets from the received
+// lines a landed cost is allocated to.
+type ProductLandedCost struct {
+	ProductID string `json:"product_id"`
+	Quantity  string `json:"quantity"`
+	Value     string `json:"value"`
+	Charges   string `json:"charges"`
+	UnitCost  string `json:"unit_cost"`
+}
+
+func toAppProductLandedCost(bus landedcostbus.ProductLandedCost) ProductLandedCost {
+	return ProductLandedCost{
+		ProductID: bus.ProductID.String(),
+		Quantity:  strconv.Itoa(bus.Quantity),
+		Value:     strconv.FormatFloat(bus.Value, 'f', 2, 64),
+		Charges:   strconv.FormatFloat(bus.Charges, 'f', 2, 64),
+		UnitCost:  strconv.FormatFloat(bus.UnitCost, 'f', 2, 64),
+	}
+}
+
+// Preview is a draft landed cost with the allocations and product landed
+// costs applying it now would produce.
+type Preview struct {
+	LandedCost LandedCost          `json:"landed_cost"`
+	Products   []ProductLandedCost `json:"products"`
+}
+
+// Encode implements the encoder interface.
+func (app Preview) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// ToAppPreview converts a previewed landed cost and the product landed costs
+// it would produce to an app-layer response model.
+func ToAppPreview(lc landedcostbus.LandedCost, products []landedcostbus.ProductLandedCost) Preview {
+	app := Preview{
+		LandedCost: ToAppLandedCost(lc),
+		Products:   make([]ProductLandedCost, len(products)),
+	}
+	for i, p := range products {
+		app.Products[i] = toAppProductLandedCost(p)
+	}
+	return app
+}
+
+// =============================================================================
+// Create models
+// =============================================================================
+
+// NewCharge is the app-layer request model for a charge.
+type NewCharge struct {
+	ChargeType       string `json:"charge_type" validate:"required,oneof=freight duty brokerage insurance other"`
+	AllocationMethod string `json:"allocation_method" validate:"required,oneof=value quantity weight volume"`
+	Amount           string `json:"amount" validate:"required,numeric"`
+	Description      string `json:"description"`
+}
+
+func toBusNewCharges(app []NewCharge) ([]landedcostbus.NewCharge, error) {
+	bus := make([]landedcostbus.NewCharge, len(app))
+	for i, c := range app {
+		amount, err := strconv.ParseFloat(c.Amount, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse charges[%d].amount: %w", i, err)
+		}
+		bus[i] = landedcostbus.NewCharge{
+			ChargeType:       c.ChargeType,
+			AllocationMethod: c.AllocationMethod,
+			Amount:           amount,
+			Description:      c.Description,
+		}
+	}
+	return bus, nil
+}
+
+// NewLandedCost is the app-layer request model to enter a landed cost. An
+// empty line_item_ids lands the charges on every line item of the purchase
+// order; an empty currency_id uses the order's currency.
+type NewLandedCost struct {
+	PurchaseOrderID string      `json:"purchase_order_id" validate:"required,uuid"`
+	ReferenceNumber string      `json:"reference_number" validate:"omitempty,max=100"`
+	CurrencyID      string      `json:"currency_id" validate:"omitempty,uuid"`
+	LineItemIDs     []string    `json:"line_item_ids" validate:"omitempty,dive,uuid"`
+	Charges         []NewCharge `json:"charges" validate:"required,min=1,dive"`
+	Notes           string      `json:"notes"`
+}
+
+// Decode implements the decoder interface.
+func (app *NewLandedCost) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewLandedCost) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusNewLandedCost(app NewLandedCost, createdBy uuid.UUID) (landedcostbus.NewLandedCost, error) {
+	bus := landedcostbus.NewLandedCost{
+		ReferenceNumber: app.ReferenceNumber,
+		Notes:           app.Notes,
+		CreatedBy:       createdBy,
+	}
+
+	var err error
+	if bus.PurchaseOrderID, err = uuid.Parse(app.PurchaseOrderID); err != nil {
+		return landedcostbus.NewLandedCost{}, fmt.Errorf("parse purchase_order_id: %w", err)
+	}
+	if app.CurrencyID != "" {
+		id, err := uuid.Parse(app.CurrencyID)
+		if err != nil {
+			return landedcostbus.NewLandedCost{}, fmt.Errorf("parse currency_id: %w", err)
+		}
+		bus.CurrencyID = &id
+	}
+	if bus.LineItemIDs, err = parseIDs(app.LineItemIDs); err != nil {
+		return landedcostbus.NewLandedCost{}, err
+	}
+	if bus.Charges, err = toBusNewCharges(app.Charges); err != nil {
+		return landedcostbus.NewLandedCost{}, err
+	}
+
+	return bus, nil
+}
+
+// =============================================================================
+// Update models
+// =============================================================================
+
+// UpdateLandedCost is the app-layer update request model for a draft landed
+// cost. Charges and line_item_ids replace the existing ones when present.
+type UpdateLandedCost struct {
+	ReferenceNumber *string     `json:"reference_number" validate:"omitempty,max=100"`
+	LineItemIDs     []string    `json:"line_item_ids" validate:"omitempty,dive,uuid"`
+	Charges         []NewCharge `json:"charges" validate:"omitempty,min=1,dive"`
+	Notes           *string     `json:"notes"`
+}
+
+// Decode implements the decoder interface.
+func (app *UpdateLandedCost) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app UpdateLandedCost) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusUpdateLandedCost(app UpdateLandedCost, updatedBy uuid.UUID) (landedcostbus.UpdateLandedCost, error) {
+	bus := landedcostbus.UpdateLandedCost{
+		ReferenceNumber: app.ReferenceNumber,
+		Notes:           app.Notes,
+		UpdatedBy:       updatedBy,
+	}
+
+	var err error
+	if app.LineItemIDs != nil {
+		if bus.LineItemIDs, err = parseIDs(app.LineItemIDs); err != nil {
+			return landedcostbus.UpdateLandedCost{}, err
+		}
+	}
+	if app.Charges != nil {
+		if bus.Charges, err = toBusNewCharges(app.Charges); err != nil {
+			return landedcostbus.UpdateLandedCost{}, err
+		}
+	}
+
+	return bus, nil
+}
+
+// =============================================================================
+
+func parseIDs(in []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, len(in))
+	for i, s := range in {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("parse line_item_ids[%d]: %w", i, err)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+func optionalID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func optionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(timeutil.FORMAT)
+}
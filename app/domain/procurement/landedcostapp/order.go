@@ -0,0 +1,17 @@
+package landedcostapp
+
+import (
+	"github.com/timmaaaz/ichor/business/domain/procurement/landedcostbus"
+)
+
+var defaultOrderBy = landedcostbus.DefaultOrderBy
+
+var orderByFields = map[string]string{
+	landedcostbus.OrderByID:              landedcostbus.OrderByID,
+	landedcostbus.OrderByPurchaseOrderID: landedcostbus.OrderByPurchaseOrderID,
+	landedcostbus.OrderByReferenceNumber: landedcostbus.OrderByReferenceNumber,
+	landedcostbus.OrderByStatus:          landedcostbus.OrderByStatus,
+	landedcostbus.OrderByTotalCharges:    landedcostbus.OrderByTotalCharges,
+	landedcostbus.OrderByAppliedDate:     landedcostbus.OrderByAppliedDate,
+	landedcostbus.OrderByCreatedDate:     landedcostbus.OrderByCreatedDate,
+}
@@ -0,0 +1,36 @@
+package purchasesuggestionapp
+
+import (
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
+)
+
+func parseFilter(qp QueryParams) (purchasesuggestionbus.QueryFilter, error) {
+	var filter purchasesuggestionbus.QueryFilter
+
+	for _, f := range []struct {
+		in  string
+		out **uuid.UUID
+	}{
+		{qp.ID, &filter.ID},
+		{qp.SupplierID, &filter.SupplierID},
+		{qp.DeliveryWarehouseID, &filter.DeliveryWarehouseID},
+		{qp.ProductID, &filter.ProductID},
+		{qp.PurchaseOrderID, &filter.PurchaseOrderID},
+	} {
+		if f.in == "" {
+			continue
+		}
+		id, err := uuid.Parse(f.in)
+		if err != nil {
+			return purchasesuggestionbus.QueryFilter{}, err
+		}
+		*f.out = &id
+	}
+
+	if qp.Status != "" {
+		filter.Status = &qp.Status
+	}
+
+	return filter, nil
+}
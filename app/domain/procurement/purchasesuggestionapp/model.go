@@ -0,0 +1,408 @@
+package purchasesuggestionapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
+	"github.com/timmaaaz/ichor/foundation/timeutil"
+)
+
+// QueryParams holds the raw query parameters for listing suggestions.
+type QueryParams struct {
+	Page                string
+	Rows                string
+	OrderBy             string
+	ID                  string
+	SupplierID          string
+	DeliveryWarehouseID string
+	ProductID           string
+	PurchaseOrderID     string
+	Status              string
+}
+
+// =============================================================================
+// Response models
+// =============================================================================
+
+// Line is the app-layer response model for a purchase suggestion line.
+type Line struct {
+	ID                string `json:"id"`
+	SuggestionID      string `json:"suggestion_id"`
+	ProductID         string `json:"product_id"`
+	SupplierProductID string `json:"supplier_product_id"`
+	AvailableQuantity string `json:"available_quantity"`
+	OnOrderQuantity   string `json:"on_order_quantity"`
+	ReorderPoint      string `json:"reorder_point"`
+	TargetQuantity    string `json:"target_quantity"`
+	NeededQuantity    string `json:"needed_quantity"`
+	UnitsPerCase      string `json:"units_per_case"`
+	MinOrderQuantity  string `json:"min_order_quantity"`
+	SuggestedQuantity string `json:"suggested_quantity"`
+	Quantity          string `json:"quantity"`
+	UnitCost          string `json:"unit_cost"`
+	LineTotal         string `json:"line_total"`
+	LeadTimeDays      string `json:"lead_time_days"`
+	Score             string `json:"score"`
+	CreatedDate       string `json:"created_date"`
+	UpdatedDate       string `json:"updated_date"`
+}
+
+func toAppLine(bus purchasesuggestionbus.Line) Line {
+	return Line{
+		ID:                bus.ID.String(),
+		SuggestionID:      bus.SuggestionID.String(),
+		ProductID:         bus.ProductID.String(),
+		SupplierProductID: bus.SupplierProductID.String(),
+		AvailableQuantity: strconv.Itoa(bus.AvailableQuantity),
+		OnOrderQuantity:   strconv.Itoa(bus.OnOrderQuantity),
+		ReorderPoint:      strconv.Itoa(bus.ReorderPoint),
+		TargetQuantity:    strconv.Itoa(bus.TargetQuantity),
+		NeededQuantity:    strconv.Itoa(bus.NeededQuantity),
+		UnitsPerCase:      strconv.Itoa(bus.UnitsPerCase),
+		MinOrderQuantity:  strconv.Itoa(bus.MinOrderQuantity),
+		SuggestedQuantity: strconv.Itoa(bus.SuggestedQuantity),
+		Quantity:          strconv.Itoa(bus.Quantity),
+		UnitCost:          strconv.FormatFloat(bus.UnitCost, 'f', 2, 64),
+		LineTotal:         strconv.FormatFloat(bus.LineTotal, 'f', 2, 64),
+		LeadTimeDays:      strconv.Itoa(bus.LeadTimeDays),
+		Score:             strconv.FormatFloat(bus.Score, 'f', 4, 64),
+		CreatedDate:       bus.CreatedDate.Format(timeutil.FORMAT),
+		UpdatedDate:       bus.UpdatedDate.Format(timeutil.FORMAT),
+	}
+}
+
+// Suggestion is the app-layer response model for a purchase suggestion.
+type Suggestion struct {
+	ID                  string `json:"id"`
+	SupplierID          string `json:"supplier_id"`
+	DeliveryWarehouseID string `json:"delivery_warehouse_id"`
+	Status              string `json:"status"`
+	LeadTimeDays        string `json:"lead_time_days"`
+	Subtotal            string `json:"subtotal"`
+	Notes               string `json:"notes"`
+	PurchaseOrderID     string `json:"purchase_order_id"`
+	ReleasedBy          string `json:"released_by"`
+	ReleasedDate        string `json:"released_date"`
+	CreatedBy           string `json:"created_by"`
+	UpdatedBy           string `json:"updated_by"`
+	CreatedDate         string `json:"created_date"`
+	UpdatedDate         string `json:"updated_date"`
+	Lines               []Line `json:"lines"`
+}
+
+// Encode implements the encoder interface.
+func (app Suggestion) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// ToAppSuggestion converts a bus model to an app-layer response model.
+func ToAppSuggestion(bus purchasesuggestionbus.Suggestion) Suggestion {
+	lines := make([]Line, len(bus.Lines))
+	for i, l := range bus.Lines {
+		lines[i] = toAppLine(l)
+	}
+
+	return Suggestion{
+		ID:                  bus.ID.String(),
+		SupplierID:          bus.SupplierID.String(),
+		DeliveryWarehouseID: bus.DeliveryWarehouseID.String(),
+		Status:              bus.Status,
+		LeadTimeDays:        strconv.Itoa(bus.LeadTimeDays),
+		Subtotal:            strconv.FormatFloat(bus.Subtotal, 'f', 2, 64),
+		Notes:               bus.Notes,
+		PurchaseOrderID:     optionalID(bus.PurchaseOrderID),
+		ReleasedBy:          optionalID(bus.ReleasedBy),
+		ReleasedDate:        optionalTime(bus.ReleasedDate),
+		CreatedBy:           bus.CreatedBy.String(),
+		UpdatedBy:           bus.UpdatedBy.String(),
+		CreatedDate:         bus.CreatedDate.Format(timeutil.FORMAT),
+		UpdatedDate:         bus.UpdatedDate.Format(timeutil.FORMAT),
+		Lines:               lines,
+	}
+}
+
+// ToAppSuggestions converts a slice of bus models to app-layer response models.
+func ToAppSuggestions(bus []purchasesuggestionbus.Suggestion) []Suggestion {
+	app := make([]Suggestion, len(bus))
+	for i, v := range bus {
+		app[i] = ToAppSuggestion(v)
+	}
+	return app
+}
+
+// Need is a product a run could not place because no active supplier sells
+// it.
+type Need struct {
+	ProductID    string `json:"product_id"`
+	WarehouseID  string `json:"warehouse_id"`
+	Available    string `json:"available"`
+	OnOrder      string `json:"on_order"`
+	ReorderPoint string `json:"reorder_point"`
+	Target       string `json:"target"`
+	Needed       string `json:"needed"`
+}
+
+// RunResult is the app-layer response to a suggestion run.
+type RunResult struct {
+	Suggestions []Suggestion `json:"suggestions"`
+	Unsourced   []Need       `json:"unsourced"`
+}
+
+// Encode implements the encoder interface.
+func (app RunResult) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppRunResult(bus purchasesuggestionbus.RunResult) RunResult {
+	unsourced := make([]Need, len(bus.Unsourced))
+	for i, n := range bus.Unsourced {
+		unsourced[i] = Need{
+			ProductID:    n.ProductID.String(),
+			WarehouseID:  n.WarehouseID.String(),
+			Available:    strconv.Itoa(n.Available),
+			OnOrder:      strconv.Itoa(n.OnOrder),
+			ReorderPoint: strconv.Itoa(n.ReorderPoint),
+			Target:       strconv.Itoa(n.Target),
+			Needed:       strconv.Itoa(n.Needed),
+		}
+	}
+
+	return RunResult{
+		Suggestions: ToAppSuggestions(bus.Suggestions),
+		Unsourced:   unsourced,
+	}
+}
+
+// =============================================================================
+// Run request model
+// =============================================================================
+
+// NewRun is the app-layer request to suggest purchases now. WarehouseID,
+// CategoryID and ProductID narrow the inventory items considered. The weights
+// say how much price, lead time, minimum order overbuy and supplier rating
+// count when a supplier is picked; leaving all of them empty uses the
+// defaults.
+type NewRun struct {
+	WarehouseID    string `json:"warehouse_id" validate:"omitempty,min=36,max=36"`
+	CategoryID     string `json:"category_id" validate:"omitempty,min=36,max=36"`
+	ProductID      string `json:"product_id" validate:"omitempty,min=36,max=36"`
+	PriceWeight    string `json:"price_weight" validate:"omitempty,numeric"`
+	LeadTimeWeight string `json:"lead_time_weight" validate:"omitempty,numeric"`
+	MinOrderWeight string `json:"min_order_weight" validate:"omitempty,numeric"`
+	RatingWeight   string `json:"rating_weight" validate:"omitempty,numeric"`
+}
+
+// Decode implements the decoder interface.
+func (app *NewRun) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewRun) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusRunRequest(app NewRun, createdBy uuid.UUID) (purchasesuggestionbus.RunRequest, error) {
+	req := purchasesuggestionbus.RunRequest{
+		CreatedBy: createdBy,
+	}
+
+	var err error
+	if req.WarehouseID, err = parseOptionalUUID(app.WarehouseID); err != nil {
+		return purchasesuggestionbus.RunRequest{}, fmt.Errorf("parse warehouse_id: %w", err)
+	}
+	if req.CategoryID, err = parseOptionalUUID(app.CategoryID); err != nil {
+		return purchasesuggestionbus.RunRequest{}, fmt.Errorf("parse category_id: %w", err)
+	}
+	if req.ProductID, err = parseOptionalUUID(app.ProductID); err != nil {
+		return purchasesuggestionbus.RunRequest{}, fmt.Errorf("parse product_id: %w", err)
+	}
+
+	for _, f := range []struct {
+		name string
+		in   string
+		out  *float64
+	}{
+		{"price_weight", app.PriceWeight, &req.Weights.Price},
+		{"lead_time_weight", app.LeadTimeWeight, &req.Weights.LeadTime},
+		{"min_order_weight", app.MinOrderWeight, &req.Weights.MinOrder},
+		{"rating_weight", app.RatingWeight, &req.Weights.Rating},
+	} {
+		if f.in == "" {
+			continue
+		}
+		if *f.out, err = strconv.ParseFloat(f.in, 64); err != nil {
+			return purchasesuggestionbus.RunRequest{}, fmt.Errorf("parse %s: %w", f.name, err)
+		}
+	}
+
+	return req, nil
+}
+
+// =============================================================================
+// Update models
+// =============================================================================
+
+// UpdateSuggestion is the app-layer update request model for a draft
+// suggestion.
+type UpdateSuggestion struct {
+	Notes *string `json:"notes"`
+}
+
+// Decode implements the decoder interface.
+func (app *UpdateSuggestion) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app UpdateSuggestion) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusUpdateSuggestion(app UpdateSuggestion, updatedBy uuid.UUID) purchasesuggestionbus.UpdateSuggestion {
+	return purchasesuggestionbus.UpdateSuggestion{
+		Notes:     app.Notes,
+		UpdatedBy: updatedBy,
+	}
+}
+
+// UpdateLine is the app-layer update request model for a line of a draft
+// suggestion.
+type UpdateLine struct {
+	Quantity *string `json:"quantity" validate:"omitempty,number"`
+	UnitCost *string `json:"unit_cost" validate:"omitempty,numeric"`
+}
+
+// Decode implements the decoder interface.
+func (app *UpdateLine) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app UpdateLine) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusUpdateLine(app UpdateLine, updatedBy uuid.UUID) (purchasesuggestionbus.UpdateLine, error) {
+	bus := purchasesuggestionbus.UpdateLine{
+		UpdatedBy: updatedBy,
+	}
+
+	if app.Quantity != nil {
+		q, err := strconv.Atoi(*app.Quantity)
+		if err != nil {
+			return purchasesuggestionbus.UpdateLine{}, fmt.Errorf("parse quantity: %w", err)
+		}
+		bus.Quantity = &q
+	}
+
+	if app.UnitCost != nil {
+		c, err := strconv.ParseFloat(*app.UnitCost, 64)
+		if err != nil {
+			return purchasesuggestionbus.UpdateLine{}, fmt.Errorf("parse unit_cost: %w", err)
+		}
+		bus.UnitCost = &c
+	}
+
+	return bus, nil
+}
+
+// =============================================================================
+// Release request model
+// =============================================================================
+
+// NewRelease is the app-layer request to turn a draft suggestion into a
+// purchase order. An empty OrderNumber is generated.
+type NewRelease struct {
+	PurchaseOrderStatusID string `json:"purchase_order_status_id" validate:"required,min=36,max=36"`
+	LineItemStatusID      string `json:"line_item_status_id" validate:"required,min=36,max=36"`
+	CurrencyID            string `json:"currency_id" validate:"required,min=36,max=36"`
+	DeliveryLocationID    string `json:"delivery_location_id" validate:"omitempty,min=36,max=36"`
+	OrderNumber           string `json:"order_number" validate:"omitempty,max=100"`
+}
+
+// Decode implements the decoder interface.
+func (app *NewRelease) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewRelease) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusReleaseRequest(app NewRelease, releasedBy uuid.UUID) (purchasesuggestionbus.ReleaseRequest, error) {
+	req := purchasesuggestionbus.ReleaseRequest{
+		OrderNumber: app.OrderNumber,
+		ReleasedBy:  releasedBy,
+	}
+
+	for _, f := range []struct {
+		name string
+		in   string
+		out  *uuid.UUID
+	}{
+		{"purchase_order_status_id", app.PurchaseOrderStatusID, &req.PurchaseOrderStatusID},
+		{"line_item_status_id", app.LineItemStatusID, &req.LineItemStatusID},
+		{"currency_id", app.CurrencyID, &req.CurrencyID},
+	} {
+		id, err := uuid.Parse(f.in)
+		if err != nil {
+			return purchasesuggestionbus.ReleaseRequest{}, fmt.Errorf("parse %s: %w", f.name, err)
+		}
+		*f.out = id
+	}
+
+	var err error
+	if req.DeliveryLocationID, err = parseOptionalUUID(app.DeliveryLocationID); err != nil {
+		return purchasesuggestionbus.ReleaseRequest{}, fmt.Errorf("parse delivery_location_id: %w", err)
+	}
+
+	return req, nil
+}
+
+// =============================================================================
+
+func parseOptionalUUID(s string) (*uuid.UUID, error) {
+	if s == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func optionalID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func optionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(timeutil.FORMAT)
+}
@@ -0,0 +1,17 @@
+package purchasesuggestionapp
+
+import (
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
+)
+
+var defaultOrderBy = purchasesuggestionbus.DefaultOrderBy
+
+var orderByFields = map[string]string{
+	purchasesuggestionbus.OrderByID:                  purchasesuggestionbus.OrderByID,
+	purchasesuggestionbus.OrderBySupplierID:          purchasesuggestionbus.OrderBySupplierID,
+	purchasesuggestionbus.OrderByDeliveryWarehouseID: purchasesuggestionbus.OrderByDeliveryWarehouseID,
+	purchasesuggestionbus.OrderByStatus:              purchasesuggestionbus.OrderByStatus,
+	purchasesuggestionbus.OrderBySubtotal:            purchasesuggestionbus.OrderBySubtotal,
+	purchasesuggestionbus.OrderByCreatedDate:         purchasesuggestionbus.OrderByCreatedDate,
+	purchasesuggestionbus.OrderByUpdatedDate:         purchasesuggestionbus.OrderByUpdatedDate,
+}
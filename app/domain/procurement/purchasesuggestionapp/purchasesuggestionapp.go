@@ -0,0 +1,236 @@
+// Package purchasesuggestionapp maintains the app layer api for purchase
+// suggestions: suggestion runs, the review and editing of the draft purchase
+// orders they build, and their release into purchase orders.
+package purchasesuggestionapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/app/sdk/mid"
+	"github.com/timmaaaz/ichor/app/sdk/query"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// App manages the set of app layer APIs for purchase suggestion access.
+type App struct {
+	purchaseSuggestionBus *purchasesuggestionbus.Business
+}
+
+// NewApp constructs a purchase suggestion app.
+func NewApp(purchaseSuggestionBus *purchasesuggestionbus.Business) *App {
+	return &App{
+		purchaseSuggestionBus: purchaseSuggestionBus,
+	}
+}
+
+// Run suggests purchases now for the selected inventory items below their
+// reorder point.
+func (a *App) Run(ctx context.Context, app NewRun) (RunResult, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return RunResult{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	req, err := toBusRunRequest(app, userID)
+	if err != nil {
+		return RunResult{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	result, err := a.purchaseSuggestionBus.Run(ctx, req, time.Now())
+	if err != nil {
+		return RunResult{}, toAppError("run", err)
+	}
+
+	return toAppRunResult(result), nil
+}
+
+// Update modifies a draft suggestion.
+func (a *App) Update(ctx context.Context, suggestionID uuid.UUID, app UpdateSuggestion) (Suggestion, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Suggestion{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	suggestion, err := a.queryByID(ctx, suggestionID)
+	if err != nil {
+		return Suggestion{}, err
+	}
+
+	updated, err := a.purchaseSuggestionBus.Update(ctx, suggestion, toBusUpdateSuggestion(app, userID), time.Now())
+	if err != nil {
+		return Suggestion{}, toAppError("update", err)
+	}
+
+	return ToAppSuggestion(updated), nil
+}
+
+// UpdateLine modifies the quantity or unit cost of a line of a draft
+// suggestion.
+func (a *App) UpdateLine(ctx context.Context, suggestionID uuid.UUID, lineID uuid.UUID, app UpdateLine) (Suggestion, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Suggestion{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	ul, err := toBusUpdateLine(app, userID)
+	if err != nil {
+		return Suggestion{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	suggestion, err := a.queryByID(ctx, suggestionID)
+	if err != nil {
+		return Suggestion{}, err
+	}
+
+	updated, err := a.purchaseSuggestionBus.UpdateLine(ctx, suggestion, lineID, ul, time.Now())
+	if err != nil {
+		return Suggestion{}, toAppError("updateline", err)
+	}
+
+	return ToAppSuggestion(updated), nil
+}
+
+// RemoveLine takes a line off a draft suggestion.
+func (a *App) RemoveLine(ctx context.Context, suggestionID uuid.UUID, lineID uuid.UUID) (Suggestion, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Suggestion{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	suggestion, err := a.queryByID(ctx, suggestionID)
+	if err != nil {
+		return Suggestion{}, err
+	}
+
+	updated, err := a.purchaseSuggestionBus.RemoveLine(ctx, suggestion, lineID, userID, time.Now())
+	if err != nil {
+		return Suggestion{}, toAppError("removeline", err)
+	}
+
+	return ToAppSuggestion(updated), nil
+}
+
+// Release turns a draft suggestion into a purchase order.
+func (a *App) Release(ctx context.Context, suggestionID uuid.UUID, app NewRelease) (Suggestion, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Suggestion{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	req, err := toBusReleaseRequest(app, userID)
+	if err != nil {
+		return Suggestion{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	suggestion, err := a.queryByID(ctx, suggestionID)
+	if err != nil {
+		return Suggestion{}, err
+	}
+
+	released, err := a.purchaseSuggestionBus.Release(ctx, suggestion, req, time.Now())
+	if err != nil {
+		return Suggestion{}, toAppError("release", err)
+	}
+
+	return ToAppSuggestion(released), nil
+}
+
+// Dismiss drops a draft suggestion.
+func (a *App) Dismiss(ctx context.Context, suggestionID uuid.UUID) (Suggestion, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Suggestion{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	suggestion, err := a.queryByID(ctx, suggestionID)
+	if err != nil {
+		return Suggestion{}, err
+	}
+
+	dismissed, err := a.purchaseSuggestionBus.Dismiss(ctx, suggestion, userID, time.Now())
+	if err != nil {
+		return Suggestion{}, toAppError("dismiss", err)
+	}
+
+	return ToAppSuggestion(dismissed), nil
+}
+
+// Query retrieves a list of suggestions based on query parameters.
+func (a *App) Query(ctx context.Context, qp QueryParams) (query.Result[Suggestion], error) {
+	pg, err := page.Parse(qp.Page, qp.Rows)
+	if err != nil {
+		return query.Result[Suggestion]{}, errs.NewFieldsError("page", err)
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return query.Result[Suggestion]{}, errs.NewFieldsError("filter", err)
+	}
+
+	orderBy, err := order.Parse(orderByFields, qp.OrderBy, defaultOrderBy)
+	if err != nil {
+		return query.Result[Suggestion]{}, errs.NewFieldsError("orderBy", err)
+	}
+
+	suggestions, err := a.purchaseSuggestionBus.Query(ctx, filter, orderBy, pg)
+	if err != nil {
+		return query.Result[Suggestion]{}, errs.Newf(errs.Internal, "query: %v", err)
+	}
+
+	total, err := a.purchaseSuggestionBus.Count(ctx, filter)
+	if err != nil {
+		return query.Result[Suggestion]{}, errs.Newf(errs.Internal, "count: %v", err)
+	}
+
+	return query.NewResult(ToAppSuggestions(suggestions), total, pg), nil
+}
+
+// QueryByID retrieves a single suggestion by ID.
+func (a *App) QueryByID(ctx context.Context, suggestionID uuid.UUID) (Suggestion, error) {
+	suggestion, err := a.queryByID(ctx, suggestionID)
+	if err != nil {
+		return Suggestion{}, err
+	}
+
+	return ToAppSuggestion(suggestion), nil
+}
+
+// =============================================================================
+
+func (a *App) queryByID(ctx context.Context, suggestionID uuid.UUID) (purchasesuggestionbus.Suggestion, error) {
+	suggestion, err := a.purchaseSuggestionBus.QueryByID(ctx, suggestionID)
+	if err != nil {
+		if errors.Is(err, purchasesuggestionbus.ErrNotFound) {
+			return purchasesuggestionbus.Suggestion{}, errs.New(errs.NotFound, err)
+		}
+		return purchasesuggestionbus.Suggestion{}, fmt.Errorf("querybyid: %w", err)
+	}
+
+	return suggestion, nil
+}
+
+func toAppError(op string, err error) error {
+	switch {
+	case errors.Is(err, purchasesuggestionbus.ErrLineNotFound):
+		return errs.New(errs.NotFound, err)
+	case errors.Is(err, purchasesuggestionbus.ErrInvalidLine),
+		errors.Is(err, purchasesuggestionbus.ErrInvalidWeights),
+		errors.Is(err, purchaseorderbus.ErrInvalidPriority):
+		return errs.New(errs.InvalidArgument, err)
+	case errors.Is(err, purchasesuggestionbus.ErrNothingToSuggest):
+		return errs.New(errs.FailedPrecondition, err)
+	case errors.Is(err, purchasesuggestionbus.ErrSuggestionClosed),
+		errors.Is(err, purchasesuggestionbus.ErrForeignKeyViolation),
+		errors.Is(err, purchaseorderbus.ErrUnique):
+		return errs.New(errs.Aborted, err)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
@@ -0,0 +1,42 @@
+package supplierinvoiceapp
+
+import (
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+)
+
+func parseFilter(qp QueryParams) (supplierinvoicebus.QueryFilter, error) {
+	var filter supplierinvoicebus.QueryFilter
+
+	for _, f := range []struct {
+		in  string
+		out **uuid.UUID
+	}{
+		{qp.ID, &filter.ID},
+		{qp.SupplierID, &filter.SupplierID},
+		{qp.PurchaseOrderID, &filter.PurchaseOrderID},
+	} {
+		if f.in == "" {
+			continue
+		}
+		id, err := uuid.Parse(f.in)
+		if err != nil {
+			return supplierinvoicebus.QueryFilter{}, err
+		}
+		*f.out = &id
+	}
+
+	if qp.InvoiceNumber != "" {
+		filter.InvoiceNumber = &qp.InvoiceNumber
+	}
+
+	if qp.Status != "" {
+		filter.Status = &qp.Status
+	}
+
+	if qp.MatchStatus != "" {
+		filter.MatchStatus = &qp.MatchStatus
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,447 @@
+package supplierinvoiceapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+	"github.com/timmaaaz/ichor/foundation/timeutil"
+)
+
+// QueryParams holds the raw query parameters for listing supplier invoices.
+type QueryParams struct {
+	Page            string
+	Rows            string
+	OrderBy         string
+	ID              string
+	InvoiceNumber   string
+	SupplierID      string
+	PurchaseOrderID string
+	Status          string
+	MatchStatus     string
+}
+
+// =============================================================================
+// Response models
+// =============================================================================
+
+// Tolerance is how far over its purchase order an invoice may bill and still
+// match.
+type Tolerance struct {
+	PricePercent    string `json:"price_percent"`
+	PriceAmount     string `json:"price_amount"`
+	QuantityPercent string `json:"quantity_percent"`
+	QuantityUnits   string `json:"quantity_units"`
+}
+
+func toAppTolerance(bus supplierinvoicebus.Tolerance) Tolerance {
+	return Tolerance{
+		PricePercent:    strconv.FormatFloat(bus.PricePercent, 'f', 2, 64),
+		PriceAmount:     strconv.FormatFloat(bus.PriceAmount, 'f', 2, 64),
+		QuantityPercent: strconv.FormatFloat(bus.QuantityPercent, 'f', 2, 64),
+		QuantityUnits:   strconv.Itoa(bus.QuantityUnits),
+	}
+}
+
+// Line is the app-layer response model for an invoice line and its match.
+type Line struct {
+	ID                      string `json:"id"`
+	InvoiceID               string `json:"invoice_id"`
+	PurchaseOrderLineItemID string `json:"purchase_order_line_item_id"`
+	Description             string `json:"description"`
+	Quantity                string `json:"quantity"`
+	UnitPrice               string `json:"unit_price"`
+	LineTotal               string `json:"line_total"`
+	MatchStatus             string `json:"match_status"`
+	ExpectedUnitCost        string `json:"expected_unit_cost"`
+	ReceivedQuantity        string `json:"received_quantity"`
+	PreviouslyInvoiced      string `json:"previously_invoiced"`
+	PriceVariance           string `json:"price_variance"`
+	QuantityVariance        string `json:"quantity_variance"`
+	CreatedDate             string `json:"created_date"`
+	UpdatedDate             string `json:"updated_date"`
+}
+
+func toAppLine(bus supplierinvoicebus.Line) Line {
+	return Line{
+		ID:                      bus.ID.String(),
+		InvoiceID:               bus.InvoiceID.String(),
+		PurchaseOrderLineItemID: bus.PurchaseOrderLineItemID.String(),
+		Description:             bus.Description,
+		Quantity:                strconv.Itoa(bus.Quantity),
+		UnitPrice:               strconv.FormatFloat(bus.UnitPrice, 'f', 2, 64),
+		LineTotal:               strconv.FormatFloat(bus.LineTotal, 'f', 2, 64),
+		MatchStatus:             bus.MatchStatus,
+		ExpectedUnitCost:        strconv.FormatFloat(bus.ExpectedUnitCost, 'f', 4, 64),
+		ReceivedQuantity:        strconv.Itoa(bus.ReceivedQuantity),
+		PreviouslyInvoiced:      strconv.Itoa(bus.PreviouslyInvoiced),
+		PriceVariance:           strconv.FormatFloat(bus.PriceVariance, 'f', 2, 64),
+		QuantityVariance:        strconv.Itoa(bus.QuantityVariance),
+		CreatedDate:             bus.CreatedDate.Format(timeutil.FORMAT),
+		UpdatedDate:             bus.UpdatedDate.Format(timeutil.FORMAT),
+	}
+}
+
+// SupplierInvoice is the app-layer response model for a supplier invoice
+// with its lines.
+type SupplierInvoice struct {
+	ID              string    `json:"id"`
+	InvoiceNumber   string    `json:"invoice_number"`
+	SupplierID      string    `json:"supplier_id"`
+	PurchaseOrderID string    `json:"purchase_order_id"`
+	InvoiceDate     string    `json:"invoice_date"`
+	DueDate         string    `json:"due_date"`
+	Subtotal        string    `json:"subtotal"`
+	TaxAmount       string    `json:"tax_amount"`
+	FreightAmount   string    `json:"freight_amount"`
+	TotalAmount     string    `json:"total_amount"`
+	Status          string    `json:"status"`
+	MatchStatus     string    `json:"match_status"`
+	Tolerance       Tolerance `json:"tolerance"`
+	MatchedDate     string    `json:"matched_date"`
+	ApprovedBy      string    `json:"approved_by"`
+	ApprovedDate    string    `json:"approved_date"`
+	ApprovalReason  string    `json:"approval_reason"`
+	RejectedBy      string    `json:"rejected_by"`
+	RejectedDate    string    `json:"rejected_date"`
+	RejectionReason string    `json:"rejection_reason"`
+	Notes           string    `json:"notes"`
+	CreatedBy       string    `json:"created_by"`
+	UpdatedBy       string    `json:"updated_by"`
+	CreatedDate     string    `json:"created_date"`
+	UpdatedDate     string    `json:"updated_date"`
+	Lines           []Line    `json:"lines"`
+}
+
+// Encode implements the encoder interface.
+func (app SupplierInvoice) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// ToAppSupplierInvoice converts a bus model to an app-layer response model.
+func ToAppSupplierInvoice(bus supplierinvoicebus.SupplierInvoice) SupplierInvoice {
+	lines := make([]Line, len(bus.Lines))
+	for i, l := range bus.Lines {
+		lines[i] = toAppLine(l)
+	}
+
+	return SupplierInvoice{
+		ID:              bus.ID.String(),
+		InvoiceNumber:   bus.InvoiceNumber,
+		SupplierID:      bus.SupplierID.String(),
+		PurchaseOrderID: bus.PurchaseOrderID.String(),
+		InvoiceDate:     bus.InvoiceDate.Format(timeutil.FORMAT),
+		DueDate:         bus.DueDate.Format(timeutil.FORMAT),
+		Subtotal:        strconv.FormatFloat(bus.Subtotal, 'f', 2, 64),
+		TaxAmount:       strconv.FormatFloat(bus.TaxAmount, 'f', 2, 64),
+		FreightAmount:   strconv.FormatFloat(bus.FreightAmount, 'f', 2, 64),
+		TotalAmount:     strconv.FormatFloat(bus.TotalAmount, 'f', 2, 64),
+		Status:          bus.Status,
+		MatchStatus:     bus.MatchStatus,
+		Tolerance:       toAppTolerance(bus.Tolerance),
+		MatchedDate:     optionalTime(bus.MatchedDate),
+		ApprovedBy:      optionalID(bus.ApprovedBy),
+		ApprovedDate:    optionalTime(bus.ApprovedDate),
+		ApprovalReason:  bus.ApprovalReason,
+		RejectedBy:      optionalID(bus.RejectedBy),
+		RejectedDate:    optionalTime(bus.RejectedDate),
+		RejectionReason: bus.RejectionReason,
+		Notes:           bus.Notes,
+		CreatedBy:       bus.CreatedBy.String(),
+		UpdatedBy:       bus.UpdatedBy.String(),
+		CreatedDate:     bus.CreatedDate.Format(timeutil.FORMAT),
+		UpdatedDate:     bus.UpdatedDate.Format(timeutil.FORMAT),
+		Lines:           lines,
+	}
+}
+
+// ToAppSupplierInvoices converts a slice of bus models to app-layer response
+// models.
+func ToAppSupplierInvoices(bus []supplierinvoicebus.SupplierInvoice) []SupplierInvoice {
+	app := make([]SupplierInvoice, len(bus))
+	for i, v := range bus {
+		app[i] = ToAppSupplierInvoice(v)
+	}
+	return app
+}
+
+// =============================================================================
+// Create models
+// =============================================================================
+
+// NewTolerance is the app-layer request model for invoice tolerances. Empty
+// fields are zero; omitting the tolerance altogether uses the defaults.
+type NewTolerance struct {
+	PricePercent    string `json:"price_percent" validate:"omitempty,numeric"`
+	PriceAmount     string `json:"price_amount" validate:"omitempty,numeric"`
+	QuantityPercent string `json:"quantity_percent" validate:"omitempty,numeric"`
+	QuantityUnits   string `json:"quantity_units" validate:"omitempty,number"`
+}
+
+func toBusTolerance(app *NewTolerance) (*supplierinvoicebus.Tolerance, error) {
+	if app == nil {
+		return nil, nil
+	}
+
+	var bus supplierinvoicebus.Tolerance
+	for _, f := range []struct {
+		name string
+		in   string
+		out  *float64
+	}{
+		{"price_percent", app.PricePercent, &bus.PricePercent},
+		{"price_amount", app.PriceAmount, &bus.PriceAmount},
+		{"quantity_percent", app.QuantityPercent, &bus.QuantityPercent},
+	} {
+		if f.in == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(f.in, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse tolerance %s: %w", f.name, err)
+		}
+		*f.out = v
+	}
+
+	if app.QuantityUnits != "" {
+		v, err := strconv.Atoi(app.QuantityUnits)
+		if err != nil {
+			return nil, fmt.Errorf("parse tolerance quantity_units: %w", err)
+		}
+		bus.QuantityUnits = v
+	}
+
+	return &bus, nil
+}
+
+// NewLine is the app-layer request model for a line billing a purchase order
+// line item.
+type NewLine struct {
+	PurchaseOrderLineItemID string `json:"purchase_order_line_item_id" validate:"required,uuid"`
+	Description             string `json:"description"`
+	Quantity                string `json:"quantity" validate:"required,number"`
+	UnitPrice               string `json:"unit_price" validate:"required,numeric"`
+}
+
+// NewSupplierInvoice is the app-layer request model to enter a supplier
+// invoice.
+type NewSupplierInvoice struct {
+	InvoiceNumber   string        `json:"invoice_number" validate:"required,max=100"`
+	SupplierID      string        `json:"supplier_id" validate:"required,uuid"`
+	PurchaseOrderID string        `json:"purchase_order_id" validate:"required,uuid"`
+	InvoiceDate     string        `json:"invoice_date" validate:"required"`
+	DueDate         string        `json:"due_date" validate:"required"`
+	TaxAmount       string        `json:"tax_amount" validate:"omitempty,numeric"`
+	FreightAmount   string        `json:"freight_amount" validate:"omitempty,numeric"`
+	Tolerance       *NewTolerance `json:"tolerance"`
+	Notes           string        `json:"notes"`
+	Lines           []NewLine     `json:"lines" validate:"required,min=1,dive"`
+}
+
+// Decode implements the decoder interface.
+func (app *NewSupplierInvoice) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewSupplierInvoice) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusNewSupplierInvoice(app NewSupplierInvoice, createdBy uuid.UUID) (supplierinvoicebus.NewSupplierInvoice, error) {
+	bus := supplierinvoicebus.NewSupplierInvoice{
+		InvoiceNumber: app.InvoiceNumber,
+		Notes:         app.Notes,
+		CreatedBy:     createdBy,
+	}
+
+	var err error
+	if bus.SupplierID, err = uuid.Parse(app.SupplierID); err != nil {
+		return supplierinvoicebus.NewSupplierInvoice{}, fmt.Errorf("parse supplier_id: %w", err)
+	}
+	if bus.PurchaseOrderID, err = uuid.Parse(app.PurchaseOrderID); err != nil {
+		return supplierinvoicebus.NewSupplierInvoice{}, fmt.Errorf("parse purchase_order_id: %w", err)
+	}
+	if bus.InvoiceDate, err = parseFlexibleDate(app.InvoiceDate); err != nil {
+		return supplierinvoicebus.NewSupplierInvoice{}, fmt.Errorf("parse invoice_date: %w", err)
+	}
+	if bus.DueDate, err = parseFlexibleDate(app.DueDate); err != nil {
+		return supplierinvoicebus.NewSupplierInvoice{}, fmt.Errorf("parse due_date: %w", err)
+	}
+	if bus.TaxAmount, err = parseOptionalFloat(app.TaxAmount); err != nil {
+		return supplierinvoicebus.NewSupplierInvoice{}, fmt.Errorf("parse tax_amount: %w", err)
+	}
+	if bus.FreightAmount, err = parseOptionalFloat(app.FreightAmount); err != nil {
+		return supplierinvoicebus.NewSupplierInvoice{}, fmt.Errorf("parse freight_amount: %w", err)
+	}
+	if bus.Tolerance, err = toBusTolerance(app.Tolerance); err != nil {
+		return supplierinvoicebus.NewSupplierInvoice{}, err
+	}
+
+	bus.Lines = make([]supplierinvoicebus.NewLine, len(app.Lines))
+	for i, l := range app.Lines {
+		nl := supplierinvoicebus.NewLine{
+			Description: l.Description,
+		}
+		if nl.PurchaseOrderLineItemID, err = uuid.Parse(l.PurchaseOrderLineItemID); err != nil {
+			return supplierinvoicebus.NewSupplierInvoice{}, fmt.Errorf("parse lines[%d].purchase_order_line_item_id: %w", i, err)
+		}
+		if nl.Quantity, err = strconv.Atoi(l.Quantity); err != nil {
+			return supplierinvoicebus.NewSupplierInvoice{}, fmt.Errorf("parse lines[%d].quantity: %w", i, err)
+		}
+		if nl.UnitPrice, err = strconv.ParseFloat(l.UnitPrice, 64); err != nil {
+			return supplierinvoicebus.NewSupplierInvoice{}, fmt.Errorf("parse lines[%d].unit_price: %w", i, err)
+		}
+		bus.Lines[i] = nl
+	}
+
+	return bus, nil
+}
+
+// =============================================================================
+// Update models
+// =============================================================================
+
+// UpdateSupplierInvoice is the app-layer update request model for the header
+// of an unresolved invoice.
+type UpdateSupplierInvoice struct {
+	InvoiceNumber *string       `json:"invoice_number" validate:"omitempty,max=100"`
+	InvoiceDate   *string       `json:"invoice_date"`
+	DueDate       *string       `json:"due_date"`
+	TaxAmount     *string       `json:"tax_amount" validate:"omitempty,numeric"`
+	FreightAmount *string       `json:"freight_amount" validate:"omitempty,numeric"`
+	Tolerance     *NewTolerance `json:"tolerance"`
+	Notes         *string       `json:"notes"`
+}
+
+// Decode implements the decoder interface.
+func (app *UpdateSupplierInvoice) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app UpdateSupplierInvoice) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusUpdateSupplierInvoice(app UpdateSupplierInvoice, updatedBy uuid.UUID) (supplierinvoicebus.UpdateSupplierInvoice, error) {
+	bus := supplierinvoicebus.UpdateSupplierInvoice{
+		InvoiceNumber: app.InvoiceNumber,
+		Notes:         app.Notes,
+		UpdatedBy:     updatedBy,
+	}
+
+	for _, f := range []struct {
+		name string
+		in   *string
+		out  **time.Time
+	}{
+		{"invoice_date", app.InvoiceDate, &bus.InvoiceDate},
+		{"due_date", app.DueDate, &bus.DueDate},
+	} {
+		if f.in == nil {
+			continue
+		}
+		t, err := parseFlexibleDate(*f.in)
+		if err != nil {
+			return supplierinvoicebus.UpdateSupplierInvoice{}, fmt.Errorf("parse %s: %w", f.name, err)
+		}
+		*f.out = &t
+	}
+
+	for _, f := range []struct {
+		name string
+		in   *string
+		out  **float64
+	}{
+		{"tax_amount", app.TaxAmount, &bus.TaxAmount},
+		{"freight_amount", app.FreightAmount, &bus.FreightAmount},
+	} {
+		if f.in == nil {
+			continue
+		}
+		v, err := strconv.ParseFloat(*f.in, 64)
+		if err != nil {
+			return supplierinvoicebus.UpdateSupplierInvoice{}, fmt.Errorf("parse %s: %w", f.name, err)
+		}
+		*f.out = &v
+	}
+
+	var err error
+	if bus.Tolerance, err = toBusTolerance(app.Tolerance); err != nil {
+		return supplierinvoicebus.UpdateSupplierInvoice{}, err
+	}
+
+	return bus, nil
+}
+
+// =============================================================================
+// Decision model
+// =============================================================================
+
+// Decision is the app-layer request to approve or reject an invoice.
+type Decision struct {
+	Reason string `json:"reason" validate:"omitempty,max=1000"`
+}
+
+// Decode implements the decoder interface.
+func (app *Decision) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app Decision) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+// =============================================================================
+
+// parseFlexibleDate accepts timeutil.FORMAT, RFC3339 and YYYY-MM-DD.
+func parseFlexibleDate(s string) (time.Time, error) {
+	formats := []string{
+		timeutil.FORMAT,
+		time.RFC3339,
+		"2006-01-02",
+	}
+	for _, f := range formats {
+		if t, err := time.Parse(f, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as a date (tried formats: %v)", s, formats)
+}
+
+func parseOptionalFloat(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func optionalID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func optionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(timeutil.FORMAT)
+}
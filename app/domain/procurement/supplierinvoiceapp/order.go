@@ -0,0 +1,20 @@
+package supplierinvoiceapp
+
+import (
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+)
+
+var defaultOrderBy = supplierinvoicebus.DefaultOrderBy
+
+var orderByFields = map[string]string{
+	supplierinvoicebus.OrderByID:              supplierinvoicebus.OrderByID,
+	supplierinvoicebus.OrderByInvoiceNumber:   supplierinvoicebus.OrderByInvoiceNumber,
+	supplierinvoicebus.OrderBySupplierID:      supplierinvoicebus.OrderBySupplierID,
+	supplierinvoicebus.OrderByPurchaseOrderID: supplierinvoicebus.OrderByPurchaseOrderID,
+	supplierinvoicebus.OrderByInvoiceDate:     supplierinvoicebus.OrderByInvoiceDate,
+	supplierinvoicebus.OrderByDueDate:         supplierinvoicebus.OrderByDueDate,
+	supplierinvoicebus.OrderByTotalAmount:     supplierinvoicebus.OrderByTotalAmount,
+	supplierinvoicebus.OrderByStatus:          supplierinvoicebus.OrderByStatus,
+	supplierinvoicebus.OrderByMatchStatus:     supplierinvoicebus.OrderByMatchStatus,
+	supplierinvoicebus.OrderByCreatedDate:     supplierinvoicebus.OrderByCreatedDate,
+}
@@ -0,0 +1,208 @@
+// Package supplierinvoiceapp maintains the app layer api for supplier
+// invoices: their entry, the three-way match against the purchase order and
+// its receipts, and the approval or rejection of invoices that do not match.
+package supplierinvoiceapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/app/sdk/mid"
+	"github.com/timmaaaz/ichor/app/sdk/query"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+)
+
+// App manages the set of app layer APIs for supplier invoice access.
+type App struct {
+	supplierInvoiceBus *supplierinvoicebus.Business
+}
+
+// NewApp constructs a supplier invoice app.
+func NewApp(supplierInvoiceBus *supplierinvoicebus.Business) *App {
+	return &App{
+		supplierInvoiceBus: supplierInvoiceBus,
+	}
+}
+
+// Create enters a new supplier invoice against a purchase order.
+func (a *App) Create(ctx context.Context, app NewSupplierInvoice) (SupplierInvoice, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return SupplierInvoice{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	nsi, err := toBusNewSupplierInvoice(app, userID)
+	if err != nil {
+		return SupplierInvoice{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	invoice, err := a.supplierInvoiceBus.Create(ctx, nsi, time.Now())
+	if err != nil {
+		return SupplierInvoice{}, toAppError("create", err)
+	}
+
+	return ToAppSupplierInvoice(invoice), nil
+}
+
+// Update modifies the header of an unresolved invoice, which must then be
+// matched again.
+func (a *App) Update(ctx context.Context, invoiceID uuid.UUID, app UpdateSupplierInvoice) (SupplierInvoice, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return SupplierInvoice{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	usi, err := toBusUpdateSupplierInvoice(app, userID)
+	if err != nil {
+		return SupplierInvoice{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	invoice, err := a.queryByID(ctx, invoiceID)
+	if err != nil {
+		return SupplierInvoice{}, err
+	}
+
+	updated, err := a.supplierInvoiceBus.Update(ctx, invoice, usi, time.Now())
+	if err != nil {
+		return SupplierInvoice{}, toAppError("update", err)
+	}
+
+	return ToAppSupplierInvoice(updated), nil
+}
+
+// Match runs the three-way match of an invoice. A matched invoice is approved
+// for payment; any other result sends it for approval.
+func (a *App) Match(ctx context.Context, invoiceID uuid.UUID) (SupplierInvoice, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return SupplierInvoice{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	invoice, err := a.queryByID(ctx, invoiceID)
+	if err != nil {
+		return SupplierInvoice{}, err
+	}
+
+	matched, err := a.supplierInvoiceBus.Match(ctx, invoice, userID, time.Now())
+	if err != nil {
+		return SupplierInvoice{}, toAppError("match", err)
+	}
+
+	return ToAppSupplierInvoice(matched), nil
+}
+
+// Approve accepts an invoice pending approval for payment.
+func (a *App) Approve(ctx context.Context, invoiceID uuid.UUID, app Decision) (SupplierInvoice, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return SupplierInvoice{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	invoice, err := a.queryByID(ctx, invoiceID)
+	if err != nil {
+		return SupplierInvoice{}, err
+	}
+
+	approved, err := a.supplierInvoiceBus.Approve(ctx, invoice, userID, app.Reason, time.Now())
+	if err != nil {
+		return SupplierInvoice{}, toAppError("approve", err)
+	}
+
+	return ToAppSupplierInvoice(approved), nil
+}
+
+// Reject refuses an unresolved invoice.
+func (a *App) Reject(ctx context.Context, invoiceID uuid.UUID, app Decision) (SupplierInvoice, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return SupplierInvoice{}, errs.Newf(errs.Unauthenticated, "get user id: %s", err)
+	}
+
+	invoice, err := a.queryByID(ctx, invoiceID)
+	if err != nil {
+		return SupplierInvoice{}, err
+	}
+
+	rejected, err := a.supplierInvoiceBus.Reject(ctx, invoice, userID, app.Reason, time.Now())
+	if err != nil {
+		return SupplierInvoice{}, toAppError("reject", err)
+	}
+
+	return ToAppSupplierInvoice(rejected), nil
+}
+
+// Query retrieves a list of supplier invoices based on query parameters.
+func (a *App) Query(ctx context.Context, qp QueryParams) (query.Result[SupplierInvoice], error) {
+	pg, err := page.Parse(qp.Page, qp.Rows)
+	if err != nil {
+		return query.Result[SupplierInvoice]{}, errs.NewFieldsError("page", err)
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return query.Result[SupplierInvoice]{}, errs.NewFieldsError("filter", err)
+	}
+
+	orderBy, err := order.Parse(orderByFields, qp.OrderBy, defaultOrderBy)
+	if err != nil {
+		return query.Result[SupplierInvoice]{}, errs.NewFieldsError("orderBy", err)
+	}
+
+	invoices, err := a.supplierInvoiceBus.Query(ctx, filter, orderBy, pg)
+	if err != nil {
+		return query.Result[SupplierInvoice]{}, errs.Newf(errs.Internal, "query: %v", err)
+	}
+
+	total, err := a.supplierInvoiceBus.Count(ctx, filter)
+	if err != nil {
+		return query.Result[SupplierInvoice]{}, errs.Newf(errs.Internal, "count: %v", err)
+	}
+
+	return query.NewResult(ToAppSupplierInvoices(invoices), total, pg), nil
+}
+
+// QueryByID retrieves a single supplier invoice by ID.
+func (a *App) QueryByID(ctx context.Context, invoiceID uuid.UUID) (SupplierInvoice, error) {
+	invoice, err := a.queryByID(ctx, invoiceID)
+	if err != nil {
+		return SupplierInvoice{}, err
+	}
+
+	return ToAppSupplierInvoice(invoice), nil
+}
+
+// =============================================================================
+
+func (a *App) queryByID(ctx context.Context, invoiceID uuid.UUID) (supplierinvoicebus.SupplierInvoice, error) {
+	invoice, err := a.supplierInvoiceBus.QueryByID(ctx, invoiceID)
+	if err != nil {
+		if errors.Is(err, supplierinvoicebus.ErrNotFound) {
+			return supplierinvoicebus.SupplierInvoice{}, errs.New(errs.NotFound, err)
+		}
+		return supplierinvoicebus.SupplierInvoice{}, fmt.Errorf("querybyid: %w", err)
+	}
+
+	return invoice, nil
+}
+
+func toAppError(op string, err error) error {
+	switch {
+	case errors.Is(err, supplierinvoicebus.ErrInvalidInvoice):
+		return errs.New(errs.InvalidArgument, err)
+	case errors.Is(err, supplierinvoicebus.ErrNotMatched):
+		return errs.New(errs.FailedPrecondition, err)
+	case errors.Is(err, supplierinvoicebus.ErrInvoiceClosed),
+		errors.Is(err, supplierinvoicebus.ErrAlreadyApproved),
+		errors.Is(err, supplierinvoicebus.ErrAlreadyRejected),
+		errors.Is(err, supplierinvoicebus.ErrUnique),
+		errors.Is(err, supplierinvoicebus.ErrForeignKeyViolation):
+		return errs.New(errs.Aborted, err)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
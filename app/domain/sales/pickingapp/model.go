@@ -9,10 +9,13 @@ import (
 )
 
 // PickQuantityRequest is the request body for the pick-quantity endpoint.
+// StagingLocationID is optional; when set, the picked units are put at that
+// outbound staging location to await shipment.
 type PickQuantityRequest struct {
-	Quantity   string `json:"quantity"   validate:"required,numeric"`
-	PickedBy   string `json:"picked_by"  validate:"required,uuid"`
-	LocationID string `json:"location_id" validate:"required,uuid"`
+	Quantity          string `json:"quantity"            validate:"required,numeric"`
+	PickedBy          string `json:"picked_by"           validate:"required,uuid"`
+	LocationID        string `json:"location_id"         validate:"required,uuid"`
+	StagingLocationID string `json:"staging_location_id" validate:"omitempty,uuid"`
 }
 
 func (r *PickQuantityRequest) Decode(data []byte) error {
@@ -31,13 +34,15 @@ type PickQuantityResponse = orderlineitemsapp.OrderLineItem
 
 // ShortPickRequest is the request body for the short-pick endpoint.
 // LocationID is optional for the "backorder" type (no inventory is touched),
-// but required for "partial", "substitute", and "skip" types.
+// but required for "partial", "substitute", and "skip" types. StagingLocationID
+// is optional and stages whatever was picked, as for PickQuantityRequest.
 type ShortPickRequest struct {
 	PickedQuantity       string  `json:"picked_quantity"        validate:"required,numeric"`
 	ShortPickType        string  `json:"short_pick_type"        validate:"required,oneof=partial backorder substitute skip"`
 	ShortPickReason      string  `json:"short_pick_reason"      validate:"omitempty"`
 	PickedBy             string  `json:"picked_by"              validate:"required,uuid"`
 	LocationID           string  `json:"location_id"            validate:"omitempty,uuid"`
+	StagingLocationID    string  `json:"staging_location_id"    validate:"omitempty,uuid"`
 	SubstituteProductID  *string `json:"substitute_product_id"  validate:"omitempty,uuid"`
 	SubstituteQuantity   *string `json:"substitute_quantity"    validate:"omitempty,numeric"`
 }
@@ -6,6 +6,7 @@ package pickingapp
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"github.com/timmaaaz/ichor/app/sdk/errs"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
 	"github.com/timmaaaz/ichor/business/domain/sales/lineitemfulfillmentstatusbus"
 	"github.com/timmaaaz/ichor/business/domain/sales/orderfulfillmentstatusbus"
 	"github.com/timmaaaz/ichor/business/domain/sales/orderlineitemsbus"
@@ -38,6 +40,7 @@ type App struct {
 	inventoryTransactionBus      *inventorytransactionbus.Business
 	orderFulfillmentStatusBus    *orderfulfillmentstatusbus.Business
 	lineItemFulfillmentStatusBus *lineitemfulfillmentstatusbus.Business
+	replenishmentTaskBus         *replenishmenttaskbus.Business
 }
 
 // NewApp constructs a picking app API for use.
@@ -50,6 +53,7 @@ func NewApp(
 	inventoryTransactionBus *inventorytransactionbus.Business,
 	orderFulfillmentStatusBus *orderfulfillmentstatusbus.Business,
 	lineItemFulfillmentStatusBus *lineitemfulfillmentstatusbus.Business,
+	replenishmentTaskBus *replenishmenttaskbus.Business,
 ) *App {
 	return &App{
 		log:                          log,
@@ -60,6 +64,7 @@ func NewApp(
 		inventoryTransactionBus:      inventoryTransactionBus,
 		orderFulfillmentStatusBus:    orderFulfillmentStatusBus,
 		lineItemFulfillmentStatusBus: lineItemFulfillmentStatusBus,
+		replenishmentTaskBus:         replenishmentTaskBus,
 	}
 }
 
@@ -80,6 +85,11 @@ func (a *App) PickQuantity(ctx context.Context, lineItemID uuid.UUID, req PickQu
 		return orderlineitemsapp.OrderLineItem{}, errs.Newf(errs.InvalidArgument, "invalid location_id uuid")
 	}
 
+	stagingLocationID, err := parseStagingLocationID(req.StagingLocationID)
+	if err != nil {
+		return orderlineitemsapp.OrderLineItem{}, err
+	}
+
 	// Fetch line item.
 	lineItem, err := a.orderLineItemsBus.QueryByID(ctx, lineItemID)
 	if err != nil {
@@ -192,6 +202,10 @@ func (a *App) PickQuantity(ctx context.Context, lineItemID uuid.UUID, req PickQu
 		return orderlineitemsapp.OrderLineItem{}, errs.Newf(errs.Internal, "create inventory transaction: %s", err)
 	}
 
+	if err := stagePicked(ctx, txInventoryItemBus, txInventoryTransactionBus, lineItem.ProductID, stagingLocationID, quantity, pickedBy, order.Number); err != nil {
+		return orderlineitemsapp.OrderLineItem{}, err
+	}
+
 	// Update line item: increment picked_quantity and set status to PICKED.
 	newPickedQty := lineItem.PickedQuantity + quantity
 	updatedLineItem, err := txOrderLineItemsBus.Update(ctx, lineItem, orderlineitemsbus.UpdateOrderLineItem{
@@ -248,6 +262,11 @@ func (a *App) ShortPick(ctx context.Context, lineItemID uuid.UUID, req ShortPick
 		return orderlineitemsapp.OrderLineItem{}, errs.Newf(errs.InvalidArgument, "location_id is required for %s picks", req.ShortPickType)
 	}
 
+	stagingLocationID, err := parseStagingLocationID(req.StagingLocationID)
+	if err != nil {
+		return orderlineitemsapp.OrderLineItem{}, err
+	}
+
 	// Validate substitute fields for "substitute" type.
 	if req.ShortPickType == "substitute" {
 		if req.SubstituteProductID == nil || *req.SubstituteProductID == "" {
@@ -311,6 +330,8 @@ func (a *App) ShortPick(ctx context.Context, lineItemID uuid.UUID, req ShortPick
 
 	// Enroll the tx on ctx so cascade outbox.Emit rides the same transaction as the entity
 	// write (they commit or roll back together) instead of falling back to the base pool.
+	// The replenishment after commit runs on the caller's ctx, outside this tx.
+	baseCtx := ctx
 	ctx = sqldb.WithTx(ctx, tx)
 
 	txInventoryItemBus, err := a.inventoryItemBus.NewWithTx(tx)
@@ -339,7 +360,7 @@ func (a *App) ShortPick(ctx context.Context, lineItemID uuid.UUID, req ShortPick
 		updatedLineItem, shouldAdvanceOrder, err = a.doPartialOrBackorderPick(
 			ctx, txInventoryItemBus, txInventoryTransactionBus, txOrderLineItemsBus,
 			lineItem, order, pickedQty, backorderedQty, partiallyPickedStatusID,
-			locationID, pickedBy, req.ShortPickReason,
+			locationID, stagingLocationID, pickedBy, req.ShortPickReason,
 		)
 		if err != nil {
 			return orderlineitemsapp.OrderLineItem{}, err
@@ -349,7 +370,7 @@ func (a *App) ShortPick(ctx context.Context, lineItemID uuid.UUID, req ShortPick
 		updatedLineItem, shouldAdvanceOrder, err = a.doPartialOrBackorderPick(
 			ctx, txInventoryItemBus, txInventoryTransactionBus, txOrderLineItemsBus,
 			lineItem, order, 0, lineItem.Quantity, backorderedStatusID,
-			locationID, pickedBy, req.ShortPickReason,
+			locationID, stagingLocationID, pickedBy, req.ShortPickReason,
 		)
 		if err != nil {
 			return orderlineitemsapp.OrderLineItem{}, err
@@ -370,7 +391,7 @@ func (a *App) ShortPick(ctx context.Context, lineItemID uuid.UUID, req ShortPick
 		updatedLineItem, shouldAdvanceOrder, err = a.doSubstitutePick(
 			ctx, txInventoryItemBus, txInventoryTransactionBus, txOrderLineItemsBus,
 			lineItem, order, subProductID, subQty, backorderedStatusID, pickedStatusID,
-			locationID, pickedBy, req.ShortPickReason,
+			locationID, stagingLocationID, pickedBy, req.ShortPickReason,
 		)
 		if err != nil {
 			return orderlineitemsapp.OrderLineItem{}, err
@@ -410,9 +431,33 @@ func (a *App) ShortPick(ctx context.Context, lineItemID uuid.UUID, req ShortPick
 		return orderlineitemsapp.OrderLineItem{}, errs.Newf(errs.Internal, "commit tx: %s", err)
 	}
 
+	// A short pick at a location means the pick face ran dry; top it up from reserve.
+	if locationID != uuid.Nil {
+		a.replenishShortFace(baseCtx, lineItem.ProductID, locationID, pickedBy)
+	}
+
 	return orderlineitemsapp.ToAppOrderLineItem(updatedLineItem), nil
 }
 
+// replenishShortFace generates replenishment tasks for a pick face a picker
+// found short. The short pick is already recorded, so a failure here is logged
+// rather than returned; the scheduled run will pick the face up later.
+func (a *App) replenishShortFace(ctx context.Context, productID, locationID, pickedBy uuid.UUID) {
+	if a.replenishmentTaskBus == nil {
+		return
+	}
+
+	_, err := a.replenishmentTaskBus.Generate(ctx, replenishmenttaskbus.PlanRequest{
+		ProductID:  &productID,
+		LocationID: &locationID,
+		ShortPick:  true,
+		CreatedBy:  pickedBy,
+	}, time.Now())
+	if err != nil && !errors.Is(err, replenishmenttaskbus.ErrNothingToReplenish) {
+		a.log.Error(ctx, "short pick replenishment", "product_id", productID, "location_id", locationID, "err", err)
+	}
+}
+
 // CompletePacking advances an order from PACKING to READY_TO_SHIP.
 func (a *App) CompletePacking(ctx context.Context, orderID uuid.UUID, req CompletePackingRequest) (ordersapp.Order, error) {
 	packedBy, err := uuid.Parse(req.PackedBy)
@@ -528,6 +573,7 @@ func (a *App) doPartialOrBackorderPick(
 	backorderedQty int,
 	lineItemStatusID uuid.UUID,
 	locationID uuid.UUID,
+	stagingLocationID uuid.UUID,
 	pickedBy uuid.UUID,
 	reason string,
 ) (orderlineitemsbus.OrderLineItem, bool, error) {
@@ -565,6 +611,10 @@ func (a *App) doPartialOrBackorderPick(
 		}); err != nil {
 			return orderlineitemsbus.OrderLineItem{}, false, errs.Newf(errs.Internal, "create inventory transaction: %s", err)
 		}
+
+		if err := stagePicked(ctx, txInventoryItemBus, txInventoryTransactionBus, lineItem.ProductID, stagingLocationID, pickedQty, pickedBy, order.Number); err != nil {
+			return orderlineitemsbus.OrderLineItem{}, false, err
+		}
 	}
 
 	newPickedQty := lineItem.PickedQuantity + pickedQty
@@ -595,6 +645,7 @@ func (a *App) doSubstitutePick(
 	backorderedStatusID uuid.UUID,
 	pickedStatusID uuid.UUID,
 	locationID uuid.UUID,
+	stagingLocationID uuid.UUID,
 	pickedBy uuid.UUID,
 	reason string,
 ) (orderlineitemsbus.OrderLineItem, bool, error) {
@@ -646,6 +697,10 @@ func (a *App) doSubstitutePick(
 		return orderlineitemsbus.OrderLineItem{}, false, errs.Newf(errs.Internal, "create substitute inventory transaction: %s", err)
 	}
 
+	if err := stagePicked(ctx, txInventoryItemBus, txInventoryTransactionBus, subProductID, stagingLocationID, subQty, pickedBy, order.Number); err != nil {
+		return orderlineitemsbus.OrderLineItem{}, false, err
+	}
+
 	// Compute line total for the substitute: unitPrice * subQty - discount.
 	unitDec, _ := decimal.NewFromString(lineItem.UnitPrice.Value())
 	discDec, _ := decimal.NewFromString(lineItem.Discount.Value())
@@ -680,6 +735,59 @@ func (a *App) doSubstitutePick(
 	return updatedSub, true, nil
 }
 
+// parseStagingLocationID parses the optional staging_location_id of a pick
+// request, returning uuid.Nil when none was given.
+func parseStagingLocationID(s string) (uuid.UUID, error) {
+	if s == "" {
+		return uuid.Nil, nil
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, errs.Newf(errs.InvalidArgument, "invalid staging_location_id uuid")
+	}
+	return id, nil
+}
+
+// stagePicked puts picked units at an outbound staging location and records a
+// STAGE transaction there, so shipping can later take them out of staging
+// rather than out of the pick face. It does nothing when stagingLocationID is
+// uuid.Nil.
+func stagePicked(
+	ctx context.Context,
+	txInventoryItemBus *inventoryitembus.Business,
+	txInventoryTransactionBus *inventorytransactionbus.Business,
+	productID uuid.UUID,
+	stagingLocationID uuid.UUID,
+	quantity int,
+	pickedBy uuid.UUID,
+	reference string,
+) error {
+	if stagingLocationID == uuid.Nil {
+		return nil
+	}
+
+	if err := txInventoryItemBus.StageQuantity(ctx, productID, stagingLocationID, quantity); err != nil {
+		if errors.Is(err, inventoryitembus.ErrNotStagingLocation) {
+			return errs.New(errs.InvalidArgument, err)
+		}
+		return errs.Newf(errs.Internal, "stage picked stock: %s", err)
+	}
+
+	if _, err := txInventoryTransactionBus.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
+		ProductID:       productID,
+		LocationID:      stagingLocationID,
+		UserID:          pickedBy,
+		TransactionType: "STAGE",
+		Quantity:        quantity,
+		ReferenceNumber: reference,
+		TransactionDate: time.Now().UTC(),
+	}); err != nil {
+		return errs.Newf(errs.Internal, "create stage inventory transaction: %s", err)
+	}
+
+	return nil
+}
+
 // allItemsPickingComplete returns true if all order line items are PICKED or CANCELLED.
 // Returns false for empty item lists to prevent spurious order advancement.
 func allItemsPickingComplete(items []orderlineitemsbus.OrderLineItem, pickedStatusID, cancelledStatusID uuid.UUID) bool {
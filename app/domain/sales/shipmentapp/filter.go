@@ -0,0 +1,79 @@
+package shipmentapp
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+	"github.com/timmaaaz/ichor/foundation/timeutil"
+)
+
+func parseFilter(qp QueryParams) (shipmentbus.QueryFilter, error) {
+	var filter shipmentbus.QueryFilter
+
+	if qp.ID != "" {
+		id, err := uuid.Parse(qp.ID)
+		if err != nil {
+			return shipmentbus.QueryFilter{}, err
+		}
+		filter.ID = &id
+	}
+
+	if qp.ShipmentNumber != "" {
+		filter.ShipmentNumber = &qp.ShipmentNumber
+	}
+
+	if qp.OrderID != "" {
+		id, err := uuid.Parse(qp.OrderID)
+		if err != nil {
+			return shipmentbus.QueryFilter{}, err
+		}
+		filter.OrderID = &id
+	}
+
+	if qp.Status != "" {
+		filter.Status = &qp.Status
+	}
+
+	if qp.Carrier != "" {
+		filter.Carrier = &qp.Carrier
+	}
+
+	if qp.TrackingNumber != "" {
+		filter.TrackingNumber = &qp.TrackingNumber
+	}
+
+	if qp.StartShipDate != "" {
+		t, err := time.Parse(timeutil.FORMAT, qp.StartShipDate)
+		if err != nil {
+			return shipmentbus.QueryFilter{}, err
+		}
+		filter.StartShipDate = &t
+	}
+
+	if qp.EndShipDate != "" {
+		t, err := time.Parse(timeutil.FORMAT, qp.EndShipDate)
+		if err != nil {
+			return shipmentbus.QueryFilter{}, err
+		}
+		filter.EndShipDate = &t
+	}
+
+	if qp.StartCreatedDate != "" {
+		t, err := time.Parse(timeutil.FORMAT, qp.StartCreatedDate)
+		if err != nil {
+			return shipmentbus.QueryFilter{}, err
+		}
+		filter.StartCreatedDate = &t
+	}
+
+	if qp.EndCreatedDate != "" {
+		t, err := time.Parse(timeutil.FORMAT, qp.EndCreatedDate)
+		if err != nil {
+			return shipmentbus.QueryFilter{}, err
+		}
+		filter.EndCreatedDate = &t
+	}
+
+	return filter, nil
+}
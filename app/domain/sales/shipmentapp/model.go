@@ -0,0 +1,471 @@
+package shipmentapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+	"github.com/timmaaaz/ichor/foundation/timeutil"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page    string
+	Rows    string
+	OrderBy string
+
+	ID               string
+	ShipmentNumber   string
+	OrderID          string
+	Status           string
+	Carrier          string
+	TrackingNumber   string
+	StartShipDate    string
+	EndShipDate      string
+	StartCreatedDate string
+	EndCreatedDate   string
+}
+
+// Shipment represents information about an individual shipment.
+type Shipment struct {
+	ID             string `json:"id"`
+	ShipmentNumber string `json:"shipment_number"`
+	OrderID        string `json:"order_id"`
+	Status         string `json:"status"`
+	Carrier        string `json:"carrier"`
+	ServiceLevel   string `json:"service_level"`
+	TrackingNumber string `json:"tracking_number"`
+	ShipDate       string `json:"ship_date"`
+	ShippedBy      string `json:"shipped_by"`
+	Notes          string `json:"notes"`
+	CreatedBy      string `json:"created_by"`
+	UpdatedBy      string `json:"updated_by"`
+	CreatedDate    string `json:"created_date"`
+	UpdatedDate    string `json:"updated_date"`
+	ScenarioID     string `json:"scenario_id,omitempty"`
+}
+
+// Encode implements the encoder interface.
+func (app Shipment) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// ToAppShipment converts a bus shipment to its API shape.
+func ToAppShipment(bus shipmentbus.Shipment) Shipment {
+	app := Shipment{
+		ID:             bus.ID.String(),
+		ShipmentNumber: bus.ShipmentNumber,
+		OrderID:        bus.OrderID.String(),
+		Status:         bus.Status,
+		Carrier:        bus.Carrier,
+		ServiceLevel:   bus.ServiceLevel,
+		TrackingNumber: bus.TrackingNumber,
+		Notes:          bus.Notes,
+		CreatedBy:      bus.CreatedBy.String(),
+		UpdatedBy:      bus.UpdatedBy.String(),
+		CreatedDate:    bus.CreatedDate.Format(timeutil.FORMAT),
+		UpdatedDate:    bus.UpdatedDate.Format(timeutil.FORMAT),
+	}
+
+	if bus.ShipDate != nil {
+		app.ShipDate = bus.ShipDate.Format(timeutil.FORMAT)
+	}
+	if bus.ShippedBy != nil {
+		app.ShippedBy = bus.ShippedBy.String()
+	}
+	if bus.ScenarioID != nil {
+		app.ScenarioID = bus.ScenarioID.String()
+	}
+
+	return app
+}
+
+// ToAppShipments converts a slice of bus shipments.
+func ToAppShipments(bus []shipmentbus.Shipment) []Shipment {
+	app := make([]Shipment, len(bus))
+	for i, v := range bus {
+		app[i] = ToAppShipment(v)
+	}
+	return app
+}
+
+// =============================================================================
+
+// NewShipment defines the data needed to open a shipment.
+type NewShipment struct {
+	ShipmentNumber string `json:"shipment_number" validate:"required,max=50"`
+	OrderID        string `json:"order_id" validate:"required,uuid"`
+	Carrier        string `json:"carrier" validate:"omitempty,max=100"`
+	ServiceLevel   string `json:"service_level" validate:"omitempty,max=100"`
+	Notes          string `json:"notes"`
+}
+
+// Decode implements the decoder interface.
+func (app *NewShipment) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewShipment) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusNewShipment(app NewShipment, createdBy uuid.UUID) (shipmentbus.NewShipment, error) {
+	orderID, err := uuid.Parse(app.OrderID)
+	if err != nil {
+		return shipmentbus.NewShipment{}, fmt.Errorf("parse orderID: %w", err)
+	}
+
+	return shipmentbus.NewShipment{
+		ShipmentNumber: app.ShipmentNumber,
+		OrderID:        orderID,
+		Carrier:        app.Carrier,
+		ServiceLevel:   app.ServiceLevel,
+		Notes:          app.Notes,
+		CreatedBy:      createdBy,
+	}, nil
+}
+
+// =============================================================================
+
+// UpdateShipment defines the shipment fields that can be changed.
+type UpdateShipment struct {
+	ShipmentNumber *string `json:"shipment_number" validate:"omitempty,max=50"`
+	Carrier        *string `json:"carrier" validate:"omitempty,max=100"`
+	ServiceLevel   *string `json:"service_level" validate:"omitempty,max=100"`
+	TrackingNumber *string `json:"tracking_number" validate:"omitempty,max=100"`
+	Notes          *string `json:"notes"`
+}
+
+// Decode implements the decoder interface.
+func (app *UpdateShipment) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app UpdateShipment) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusUpdateShipment(app UpdateShipment, updatedBy uuid.UUID) shipmentbus.UpdateShipment {
+	return shipmentbus.UpdateShipment{
+		ShipmentNumber: app.ShipmentNumber,
+		Carrier:        app.Carrier,
+		ServiceLevel:   app.ServiceLevel,
+		TrackingNumber: app.TrackingNumber,
+		Notes:          app.Notes,
+		UpdatedBy:      &updatedBy,
+	}
+}
+
+// =============================================================================
+
+// ShipRequest confirms a shipment left the building. ShipDate defaults to
+// now; Carrier and TrackingNumber override the shipment's values when set.
+type ShipRequest struct {
+	ShipDate       string  `json:"ship_date"`
+	Carrier        *string `json:"carrier" validate:"omitempty,max=100"`
+	TrackingNumber *string `json:"tracking_number" validate:"omitempty,max=100"`
+}
+
+// Decode implements the decoder interface.
+func (app *ShipRequest) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app ShipRequest) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+func toBusShipConfirmation(app ShipRequest, shippedBy uuid.UUID) (shipmentbus.ShipConfirmation, error) {
+	shipDate := time.Now()
+	if app.ShipDate != "" {
+		t, err := time.Parse(timeutil.FORMAT, app.ShipDate)
+		if err != nil {
+			return shipmentbus.ShipConfirmation{}, fmt.Errorf("parse shipDate: %w", err)
+		}
+		shipDate = t
+	}
+
+	return shipmentbus.ShipConfirmation{
+		ShippedBy:      shippedBy,
+		ShipDate:       shipDate,
+		Carrier:        app.Carrier,
+		TrackingNumber: app.TrackingNumber,
+	}, nil
+}
+
+// =============================================================================
+
+// Carton is the API shape of a packed carton.
+type Carton struct {
+	ID                 string       `json:"id"`
+	ShipmentID         string       `json:"shipment_id"`
+	CartonNumber       int          `json:"carton_number"`
+	ContainerBindingID string       `json:"container_binding_id,omitempty"`
+	Weight             string       `json:"weight"`
+	WeightUnit         string       `json:"weight_unit"`
+	TrackingNumber     string       `json:"tracking_number"`
+	Lines              []CartonLine `json:"lines"`
+	CreatedDate        string       `json:"created_date"`
+}
+
+// Encode implements the encoder interface.
+func (app Carton) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// CartonLine is the API shape of one line of a carton.
+type CartonLine struct {
+	ID              string `json:"id"`
+	OrderLineItemID string `json:"order_line_item_id"`
+	ProductID       string `json:"product_id"`
+	LocationID      string `json:"location_id"`
+	LotID           string `json:"lot_id,omitempty"`
+	Quantity        string `json:"quantity"`
+}
+
+// Cartons is a slice wrapper so it implements web.Encoder directly.
+type Cartons []Carton
+
+// Encode implements the encoder interface.
+func (app Cartons) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// ToAppCarton converts a bus carton to its API shape.
+func ToAppCarton(bus shipmentbus.Carton) Carton {
+	app := Carton{
+		ID:             bus.ID.String(),
+		ShipmentID:     bus.ShipmentID.String(),
+		CartonNumber:   bus.CartonNumber,
+		Weight:         strconv.FormatFloat(bus.Weight, 'f', -1, 64),
+		WeightUnit:     bus.WeightUnit,
+		TrackingNumber: bus.TrackingNumber,
+		Lines:          make([]CartonLine, len(bus.Lines)),
+		CreatedDate:    bus.CreatedDate.Format(timeutil.FORMAT),
+	}
+
+	if bus.ContainerBindingID != nil {
+		app.ContainerBindingID = bus.ContainerBindingID.String()
+	}
+
+	for i, line := range bus.Lines {
+		app.Lines[i] = CartonLine{
+			ID:              line.ID.String(),
+			OrderLineItemID: line.OrderLineItemID.String(),
+			ProductID:       line.ProductID.String(),
+			LocationID:      line.LocationID.String(),
+			Quantity:        strconv.Itoa(line.Quantity),
+		}
+		if line.LotID != nil {
+			app.Lines[i].LotID = line.LotID.String()
+		}
+	}
+
+	return app
+}
+
+// ToAppCartons converts a slice of bus cartons.
+func ToAppCartons(bus []shipmentbus.Carton) Cartons {
+	app := make(Cartons, len(bus))
+	for i, v := range bus {
+		app[i] = ToAppCarton(v)
+	}
+	return app
+}
+
+// NewCarton defines the data needed to pack a carton. LocationID on each
+// line is where the stock was picked from; its allocation there is released
+// at ship confirmation.
+type NewCarton struct {
+	ContainerBindingID string          `json:"container_binding_id" validate:"omitempty,uuid"`
+	Weight             string          `json:"weight" validate:"omitempty,numeric"`
+	WeightUnit         string          `json:"weight_unit" validate:"omitempty,max=10"`
+	TrackingNumber     string          `json:"tracking_number" validate:"omitempty,max=100"`
+	Lines              []NewCartonLine `json:"lines" validate:"required,min=1,dive"`
+}
+
+// NewCartonLine is one line of a NewCarton.
+type NewCartonLine struct {
+	OrderLineItemID string `json:"order_line_item_id" validate:"required,uuid"`
+	LocationID      string `json:"location_id" validate:"required,uuid"`
+	LotID           string `json:"lot_id" validate:"omitempty,uuid"`
+	Quantity        string `json:"quantity" validate:"required,numeric"`
+}
+
+// Decode implements the decoder interface.
+func (app *NewCarton) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewCarton) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+// toBusNewCarton parses a NewCarton. Line product IDs are filled in by the
+// caller from the referenced order lines.
+func toBusNewCarton(app NewCarton) (shipmentbus.NewCarton, error) {
+	nc := shipmentbus.NewCarton{
+		WeightUnit:     app.WeightUnit,
+		TrackingNumber: app.TrackingNumber,
+		Lines:          make([]shipmentbus.NewCartonLine, len(app.Lines)),
+	}
+
+	if app.ContainerBindingID != "" {
+		id, err := uuid.Parse(app.ContainerBindingID)
+		if err != nil {
+			return shipmentbus.NewCarton{}, fmt.Errorf("parse containerBindingID: %w", err)
+		}
+		nc.ContainerBindingID = &id
+	}
+
+	if app.Weight != "" {
+		weight, err := strconv.ParseFloat(app.Weight, 64)
+		if err != nil {
+			return shipmentbus.NewCarton{}, fmt.Errorf("parse weight: %w", err)
+		}
+		nc.Weight = weight
+	}
+
+	for i, line := range app.Lines {
+		lineItemID, err := uuid.Parse(line.OrderLineItemID)
+		if err != nil {
+			return shipmentbus.NewCarton{}, fmt.Errorf("parse lines[%d].orderLineItemID: %w", i, err)
+		}
+
+		locationID, err := uuid.Parse(line.LocationID)
+		if err != nil {
+			return shipmentbus.NewCarton{}, fmt.Errorf("parse lines[%d].locationID: %w", i, err)
+		}
+
+		quantity, err := strconv.Atoi(line.Quantity)
+		if err != nil {
+			return shipmentbus.NewCarton{}, fmt.Errorf("parse lines[%d].quantity: %w", i, err)
+		}
+
+		nc.Lines[i] = shipmentbus.NewCartonLine{
+			OrderLineItemID: lineItemID,
+			LocationID:      locationID,
+			Quantity:        quantity,
+		}
+
+		if line.LotID != "" {
+			lotID, err := uuid.Parse(line.LotID)
+			if err != nil {
+				return shipmentbus.NewCarton{}, fmt.Errorf("parse lines[%d].lotID: %w", i, err)
+			}
+			nc.Lines[i].LotID = &lotID
+		}
+	}
+
+	return nc, nil
+}
+
+// =============================================================================
+
+// Rate is the API shape of a carrier quote.
+type Rate struct {
+	Carrier       string `json:"carrier"`
+	ServiceLevel  string `json:"service_level"`
+	Cost          string `json:"cost"`
+	Currency      string `json:"currency"`
+	EstimatedDays int    `json:"estimated_days"`
+}
+
+// Rates is a slice wrapper so it implements web.Encoder directly.
+type Rates []Rate
+
+// Encode implements the encoder interface.
+func (app Rates) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+// ToAppRates converts carrier quotes to their API shape.
+func ToAppRates(bus []shipmentbus.Rate) Rates {
+	app := make(Rates, len(bus))
+	for i, r := range bus {
+		app[i] = Rate{
+			Carrier:       r.Carrier,
+			ServiceLevel:  r.ServiceLevel,
+			Cost:          strconv.FormatFloat(r.Cost, 'f', 2, 64),
+			Currency:      r.Currency,
+			EstimatedDays: r.EstimatedDays,
+		}
+	}
+	return app
+}
+
+// LabelRequest buys a shipping label at a service level. When Print is set
+// the label is sent to the configured label printer.
+type LabelRequest struct {
+	ServiceLevel string `json:"service_level" validate:"required,max=100"`
+	Print        bool   `json:"print"`
+}
+
+// Decode implements the decoder interface.
+func (app *LabelRequest) Decode(data []byte) error {
+	return json.Unmarshal(data, app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app LabelRequest) Validate() error {
+	if err := errs.Check(app); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	return nil
+}
+
+// Label is the API shape of a purchased label. A failed print does not undo
+// the purchase; PrintError reports it and the ZPL can be re-sent.
+type Label struct {
+	Shipment       Shipment `json:"shipment"`
+	Carrier        string   `json:"carrier"`
+	ServiceLevel   string   `json:"service_level"`
+	TrackingNumber string   `json:"tracking_number"`
+	Cost           string   `json:"cost"`
+	Currency       string   `json:"currency"`
+	ZPL            string   `json:"zpl"`
+	Printed        bool     `json:"printed"`
+	PrintError     string   `json:"print_error,omitempty"`
+}
+
+// Encode implements the encoder interface.
+func (app Label) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppLabel(sh shipmentbus.Shipment, lbl shipmentbus.Label) Label {
+	return Label{
+		Shipment:       ToAppShipment(sh),
+		Carrier:        lbl.Carrier,
+		ServiceLevel:   lbl.ServiceLevel,
+		TrackingNumber: lbl.TrackingNumber,
+		Cost:           strconv.FormatFloat(lbl.Cost, 'f', 2, 64),
+		Currency:       lbl.Currency,
+		ZPL:            string(lbl.ZPL),
+	}
+}
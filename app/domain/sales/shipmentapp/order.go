@@ -0,0 +1,19 @@
+package shipmentapp
+
+import (
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+)
+
+var defaultOrderBy = order.NewBy("created_date", order.DESC)
+
+var orderByFields = map[string]string{
+	"id":              shipmentbus.OrderByID,
+	"shipment_number": shipmentbus.OrderByShipmentNumber,
+	"order_id":        shipmentbus.OrderByOrderID,
+	"status":          shipmentbus.OrderByStatus,
+	"carrier":         shipmentbus.OrderByCarrier,
+	"ship_date":       shipmentbus.OrderByShipDate,
+	"created_date":    shipmentbus.OrderByCreatedDate,
+	"updated_date":    shipmentbus.OrderByUpdatedDate,
+}
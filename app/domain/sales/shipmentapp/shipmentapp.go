@@ -0,0 +1,541 @@
+// Package shipmentapp provides the application layer for outbound shipments.
+//
+// Picking relieves on-hand and allocation at the pick location and stages the
+// picked units at an outbound staging location, where they stay allocated.
+// Each carton line names the staging location its goods were packed from;
+// ship confirmation takes those units out of staging with a SHIP transaction,
+// releases the cartons' container bindings and moves a READY_TO_SHIP order to
+// SHIPPED, all in one transaction.
+package shipmentapp
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/app/sdk/mid"
+	"github.com/timmaaaz/ichor/app/sdk/query"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
+	"github.com/timmaaaz/ichor/business/domain/labels/labelbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/orderfulfillmentstatusbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/orderlineitemsbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/ordersbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// App manages the set of app layer API functions for shipments.
+type App struct {
+	log                       *logger.Logger
+	db                        *sqlx.DB
+	shipmentBus               *shipmentbus.Business
+	ordersBus                 *ordersbus.Business
+	orderLineItemsBus         *orderlineitemsbus.Business
+	inventoryItemBus          *inventoryitembus.Business
+	inventoryTransactionBus   *inventorytransactionbus.Business
+	orderFulfillmentStatusBus *orderfulfillmentstatusbus.Business
+	labelBus                  *labelbus.Business
+}
+
+// NewApp constructs a shipment app API for use.
+func NewApp(
+	log *logger.Logger,
+	db *sqlx.DB,
+	shipmentBus *shipmentbus.Business,
+	ordersBus *ordersbus.Business,
+	orderLineItemsBus *orderlineitemsbus.Business,
+	inventoryItemBus *inventoryitembus.Business,
+	inventoryTransactionBus *inventorytransactionbus.Business,
+	orderFulfillmentStatusBus *orderfulfillmentstatusbus.Business,
+	labelBus *labelbus.Business,
+) *App {
+	return &App{
+		log:                       log,
+		db:                        db,
+		shipmentBus:               shipmentBus,
+		ordersBus:                 ordersBus,
+		orderLineItemsBus:         orderLineItemsBus,
+		inventoryItemBus:          inventoryItemBus,
+		inventoryTransactionBus:   inventoryTransactionBus,
+		orderFulfillmentStatusBus: orderFulfillmentStatusBus,
+		labelBus:                  labelBus,
+	}
+}
+
+// Create opens a new shipment in packing status.
+func (a *App) Create(ctx context.Context, app NewShipment) (Shipment, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Shipment{}, errs.New(errs.Unauthenticated, err)
+	}
+
+	ns, err := toBusNewShipment(app, userID)
+	if err != nil {
+		return Shipment{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	sh, err := a.shipmentBus.Create(ctx, ns)
+	if err != nil {
+		if errors.Is(err, shipmentbus.ErrUniqueEntry) {
+			return Shipment{}, errs.New(errs.AlreadyExists, err)
+		}
+		if errors.Is(err, shipmentbus.ErrForeignKeyViolation) {
+			return Shipment{}, errs.New(errs.Aborted, err)
+		}
+		return Shipment{}, fmt.Errorf("create: %w", err)
+	}
+
+	return ToAppShipment(sh), nil
+}
+
+// Update modifies the descriptive fields of a shipment.
+func (a *App) Update(ctx context.Context, id uuid.UUID, app UpdateShipment) (Shipment, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Shipment{}, errs.New(errs.Unauthenticated, err)
+	}
+
+	sh, err := a.queryShipment(ctx, id)
+	if err != nil {
+		return Shipment{}, err
+	}
+
+	sh, err = a.shipmentBus.Update(ctx, sh, toBusUpdateShipment(app, userID))
+	if err != nil {
+		if errors.Is(err, shipmentbus.ErrInvalidShipmentStatus) {
+			return Shipment{}, errs.New(errs.FailedPrecondition, err)
+		}
+		if errors.Is(err, shipmentbus.ErrUniqueEntry) {
+			return Shipment{}, errs.New(errs.AlreadyExists, err)
+		}
+		return Shipment{}, fmt.Errorf("update: %w", err)
+	}
+
+	return ToAppShipment(sh), nil
+}
+
+// Delete removes a shipment that has not shipped.
+func (a *App) Delete(ctx context.Context, id uuid.UUID) error {
+	sh, err := a.queryShipment(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := a.shipmentBus.Delete(ctx, sh); err != nil {
+		if errors.Is(err, shipmentbus.ErrInvalidShipmentStatus) {
+			return errs.New(errs.FailedPrecondition, err)
+		}
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	return nil
+}
+
+// Query returns a list of shipments.
+func (a *App) Query(ctx context.Context, qp QueryParams) (query.Result[Shipment], error) {
+	pg, err := page.Parse(qp.Page, qp.Rows)
+	if err != nil {
+		return query.Result[Shipment]{}, errs.NewFieldsError("page", err)
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return query.Result[Shipment]{}, errs.NewFieldsError("filter", err)
+	}
+
+	orderBy, err := order.Parse(orderByFields, qp.OrderBy, defaultOrderBy)
+	if err != nil {
+		return query.Result[Shipment]{}, errs.NewFieldsError("orderBy", err)
+	}
+
+	shipments, err := a.shipmentBus.Query(ctx, filter, orderBy, pg)
+	if err != nil {
+		return query.Result[Shipment]{}, errs.Newf(errs.Internal, "query: %v", err)
+	}
+
+	total, err := a.shipmentBus.Count(ctx, filter)
+	if err != nil {
+		return query.Result[Shipment]{}, errs.Newf(errs.Internal, "count: %v", err)
+	}
+
+	return query.NewResult(ToAppShipments(shipments), total, pg), nil
+}
+
+// QueryByID returns a single shipment.
+func (a *App) QueryByID(ctx context.Context, id uuid.UUID) (Shipment, error) {
+	sh, err := a.queryShipment(ctx, id)
+	if err != nil {
+		return Shipment{}, err
+	}
+
+	return ToAppShipment(sh), nil
+}
+
+// QueryCartons returns the cartons packed into a shipment.
+func (a *App) QueryCartons(ctx context.Context, id uuid.UUID) (Cartons, error) {
+	if _, err := a.queryShipment(ctx, id); err != nil {
+		return nil, err
+	}
+
+	cartons, err := a.shipmentBus.QueryCartons(ctx, id)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "querycartons: %s", err)
+	}
+
+	return ToAppCartons(cartons), nil
+}
+
+// AddCarton packs a carton into a shipment. Any container binding must be an
+// active binding of the shipment's order, and every line must reference a
+// line item of that order.
+func (a *App) AddCarton(ctx context.Context, id uuid.UUID, app NewCarton) (Carton, error) {
+	nc, err := toBusNewCarton(app)
+	if err != nil {
+		return Carton{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	sh, err := a.queryShipment(ctx, id)
+	if err != nil {
+		return Carton{}, err
+	}
+
+	if nc.ContainerBindingID != nil {
+		binding, err := a.ordersBus.QueryBindingByID(ctx, *nc.ContainerBindingID)
+		if err != nil {
+			if errors.Is(err, ordersbus.ErrBindingNotFound) {
+				return Carton{}, errs.New(errs.InvalidArgument, err)
+			}
+			return Carton{}, fmt.Errorf("addcarton [querybindingbyid]: %w", err)
+		}
+		if binding.OrderID != sh.OrderID {
+			return Carton{}, errs.Newf(errs.InvalidArgument, "container binding %s belongs to a different order", binding.ID)
+		}
+		if binding.UnboundAt != nil {
+			return Carton{}, errs.Newf(errs.FailedPrecondition, "container binding %s is no longer active", binding.ID)
+		}
+	}
+
+	for i, line := range nc.Lines {
+		lineItem, err := a.orderLineItemsBus.QueryByID(ctx, line.OrderLineItemID)
+		if err != nil {
+			if errors.Is(err, orderlineitemsbus.ErrNotFound) {
+				return Carton{}, errs.New(errs.InvalidArgument, err)
+			}
+			return Carton{}, fmt.Errorf("addcarton [querylineitem]: %w", err)
+		}
+		if lineItem.OrderID != sh.OrderID {
+			return Carton{}, errs.Newf(errs.InvalidArgument, "line item %s belongs to a different order", lineItem.ID)
+		}
+		nc.Lines[i].ProductID = lineItem.ProductID
+	}
+
+	carton, err := a.shipmentBus.AddCarton(ctx, sh, nc)
+	if err != nil {
+		switch {
+		case errors.Is(err, shipmentbus.ErrInvalidShipmentStatus):
+			return Carton{}, errs.New(errs.FailedPrecondition, err)
+		case errors.Is(err, shipmentbus.ErrEmptyCarton):
+			return Carton{}, errs.New(errs.InvalidArgument, err)
+		case errors.Is(err, shipmentbus.ErrForeignKeyViolation):
+			return Carton{}, errs.New(errs.Aborted, err)
+		}
+		return Carton{}, fmt.Errorf("addcarton: %w", err)
+	}
+
+	return ToAppCarton(carton), nil
+}
+
+// RemoveCarton unpacks a carton from a shipment that is still packing.
+func (a *App) RemoveCarton(ctx context.Context, id uuid.UUID, cartonID uuid.UUID) error {
+	sh, err := a.queryShipment(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := a.shipmentBus.RemoveCarton(ctx, sh, cartonID); err != nil {
+		switch {
+		case errors.Is(err, shipmentbus.ErrInvalidShipmentStatus):
+			return errs.New(errs.FailedPrecondition, err)
+		case errors.Is(err, shipmentbus.ErrCartonNotFound):
+			return errs.New(errs.NotFound, err)
+		}
+		return fmt.Errorf("removecarton: %w", err)
+	}
+
+	return nil
+}
+
+// Ship confirms the shipment left the building. See the package doc for the
+// inventory side effects.
+func (a *App) Ship(ctx context.Context, id uuid.UUID, app ShipRequest) (Shipment, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Shipment{}, errs.New(errs.Unauthenticated, err)
+	}
+
+	sc, err := toBusShipConfirmation(app, userID)
+	if err != nil {
+		return Shipment{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	sh, err := a.queryShipment(ctx, id)
+	if err != nil {
+		return Shipment{}, err
+	}
+
+	// Resolve status IDs outside the transaction (read-only).
+	readyToShipStatusID, err := a.resolveOrderFulfillmentStatusID(ctx, "READY_TO_SHIP")
+	if err != nil {
+		return Shipment{}, errs.Newf(errs.Internal, "resolve READY_TO_SHIP status: %s", err)
+	}
+	shippedStatusID, err := a.resolveOrderFulfillmentStatusID(ctx, "SHIPPED")
+	if err != nil {
+		return Shipment{}, errs.Newf(errs.Internal, "resolve SHIPPED status: %s", err)
+	}
+
+	tx, err := a.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return Shipment{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Enroll the tx on ctx so cascade outbox.Emit rides the same transaction as the entity
+	// write (they commit or roll back together) instead of falling back to the base pool.
+	ctx = sqldb.WithTx(ctx, tx)
+
+	shipmentBusTx, err := a.shipmentBus.NewWithTx(tx)
+	if err != nil {
+		return Shipment{}, fmt.Errorf("new shipment tx: %w", err)
+	}
+	invItemBusTx, err := a.inventoryItemBus.NewWithTx(tx)
+	if err != nil {
+		return Shipment{}, fmt.Errorf("new invitem tx: %w", err)
+	}
+	invTransactionBusTx, err := a.inventoryTransactionBus.NewWithTx(tx)
+	if err != nil {
+		return Shipment{}, fmt.Errorf("new invtransaction tx: %w", err)
+	}
+	ordersBusTx, err := a.ordersBus.NewWithTx(tx)
+	if err != nil {
+		return Shipment{}, fmt.Errorf("new orders tx: %w", err)
+	}
+
+	// 1. Mark the shipment shipped.
+	shipped, err := shipmentBusTx.Ship(ctx, sh, sc)
+	if err != nil {
+		switch {
+		case errors.Is(err, shipmentbus.ErrInvalidShipmentStatus), errors.Is(err, shipmentbus.ErrNoCartons):
+			return Shipment{}, errs.New(errs.FailedPrecondition, err)
+		}
+		return Shipment{}, fmt.Errorf("ship: %w", err)
+	}
+
+	cartons, err := shipmentBusTx.QueryCartons(ctx, sh.ID)
+	if err != nil {
+		return Shipment{}, fmt.Errorf("querycartons: %w", err)
+	}
+
+	// 2. The staged stock has left the building: take it out of staging and
+	// record the SHIP transaction (negative quantity = outbound).
+	for _, carton := range cartons {
+		for _, line := range carton.Lines {
+			if err := invItemBusTx.ShipStagedQuantity(ctx, line.ProductID, line.LocationID, line.Quantity); err != nil {
+				if errors.Is(err, inventoryitembus.ErrNotStaged) {
+					return Shipment{}, errs.Newf(errs.FailedPrecondition, "%d units of product %s are not staged at location %s", line.Quantity, line.ProductID, line.LocationID)
+				}
+				return Shipment{}, fmt.Errorf("ship staged quantity: %w", err)
+			}
+
+			if _, err := invTransactionBusTx.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
+				ProductID:       line.ProductID,
+				LocationID:      line.LocationID,
+				UserID:          userID,
+				LotID:           line.LotID,
+				Quantity:        -line.Quantity,
+				TransactionType: "SHIP",
+				ReferenceNumber: shipped.ShipmentNumber,
+				TransactionDate: sc.ShipDate,
+			}); err != nil {
+				return Shipment{}, fmt.Errorf("create inventory transaction: %w", err)
+			}
+		}
+
+		// 3. The physical container left with the carton; release its binding.
+		if carton.ContainerBindingID != nil {
+			if err := ordersBusTx.UnbindContainer(ctx, *carton.ContainerBindingID); err != nil {
+				return Shipment{}, fmt.Errorf("unbind container: %w", err)
+			}
+		}
+	}
+
+	// 4. Advance the order once it is ready to ship.
+	ord, err := ordersBusTx.QueryByID(ctx, sh.OrderID)
+	if err != nil {
+		return Shipment{}, fmt.Errorf("query order: %w", err)
+	}
+	if ord.FulfillmentStatusID == readyToShipStatusID {
+		if _, err := ordersBusTx.Update(ctx, ord, ordersbus.UpdateOrder{
+			FulfillmentStatusID: &shippedStatusID,
+			UpdatedBy:           &userID,
+		}); err != nil {
+			return Shipment{}, fmt.Errorf("update order: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Shipment{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return ToAppShipment(shipped), nil
+}
+
+// Cancel abandons a shipment that has not shipped.
+func (a *App) Cancel(ctx context.Context, id uuid.UUID) (Shipment, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Shipment{}, errs.New(errs.Unauthenticated, err)
+	}
+
+	sh, err := a.queryShipment(ctx, id)
+	if err != nil {
+		return Shipment{}, err
+	}
+
+	cancelled, err := a.shipmentBus.Cancel(ctx, sh, userID)
+	if err != nil {
+		if errors.Is(err, shipmentbus.ErrInvalidShipmentStatus) {
+			return Shipment{}, errs.New(errs.FailedPrecondition, err)
+		}
+		return Shipment{}, fmt.Errorf("cancel: %w", err)
+	}
+
+	return ToAppShipment(cancelled), nil
+}
+
+// =============================================================================
+// Carrier
+
+// Rates quotes the shipment's cartons with the configured carrier.
+func (a *App) Rates(ctx context.Context, id uuid.UUID) (Rates, error) {
+	sh, err := a.queryShipment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := a.shipmentBus.RateShop(ctx, sh)
+	if err != nil {
+		return nil, carrierError("rates", err)
+	}
+
+	return ToAppRates(rates), nil
+}
+
+// CreateLabel buys a label for the shipment and, when asked, prints it on
+// the label printer the same way location and tote labels are printed.
+func (a *App) CreateLabel(ctx context.Context, id uuid.UUID, app LabelRequest) (Label, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Label{}, errs.New(errs.Unauthenticated, err)
+	}
+
+	sh, err := a.queryShipment(ctx, id)
+	if err != nil {
+		return Label{}, err
+	}
+
+	sh, lbl, err := a.shipmentBus.CreateLabel(ctx, sh, app.ServiceLevel, userID)
+	if err != nil {
+		return Label{}, carrierError("createlabel", err)
+	}
+
+	result := toAppLabel(sh, lbl)
+
+	if app.Print && a.labelBus != nil {
+		if err := a.labelBus.PrintZPL(ctx, lbl.ZPL); err != nil {
+			a.log.Error(ctx, "shipmentapp: print shipping label", "shipment_id", sh.ID, "tracking_number", lbl.TrackingNumber, "err", err)
+			result.PrintError = err.Error()
+		} else {
+			result.Printed = true
+		}
+	}
+
+	return result, nil
+}
+
+// VoidLabel cancels the shipment's label with the carrier.
+func (a *App) VoidLabel(ctx context.Context, id uuid.UUID) (Shipment, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Shipment{}, errs.New(errs.Unauthenticated, err)
+	}
+
+	sh, err := a.queryShipment(ctx, id)
+	if err != nil {
+		return Shipment{}, err
+	}
+
+	sh, err = a.shipmentBus.VoidLabel(ctx, sh, userID)
+	if err != nil {
+		return Shipment{}, carrierError("voidlabel", err)
+	}
+
+	return ToAppShipment(sh), nil
+}
+
+// =============================================================================
+// helpers
+
+func (a *App) queryShipment(ctx context.Context, id uuid.UUID) (shipmentbus.Shipment, error) {
+	sh, err := a.shipmentBus.QueryByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, shipmentbus.ErrNotFound) {
+			return shipmentbus.Shipment{}, errs.New(errs.NotFound, err)
+		}
+		return shipmentbus.Shipment{}, fmt.Errorf("querybyid: %w", err)
+	}
+
+	return sh, nil
+}
+
+func (a *App) resolveOrderFulfillmentStatusID(ctx context.Context, name string) (uuid.UUID, error) {
+	statuses, err := a.orderFulfillmentStatusBus.Query(ctx,
+		orderfulfillmentstatusbus.QueryFilter{Name: &name},
+		orderfulfillmentstatusbus.DefaultOrderBy,
+		page.MustParse("1", "1"),
+	)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("query: %w", err)
+	}
+	if len(statuses) == 0 {
+		return uuid.UUID{}, fmt.Errorf("order fulfillment status %q not found", name)
+	}
+	return statuses[0].ID, nil
+}
+
+// carrierError maps the carrier-path business errors to app errors. Any
+// other error is the carrier itself failing and is reported as unavailable.
+func carrierError(op string, err error) error {
+	switch {
+	case errors.Is(err, shipmentbus.ErrNoCarrier):
+		return errs.New(errs.Unimplemented, err)
+	case errors.Is(err, shipmentbus.ErrInvalidShipmentStatus),
+		errors.Is(err, shipmentbus.ErrNoCartons),
+		errors.Is(err, shipmentbus.ErrLabelExists),
+		errors.Is(err, shipmentbus.ErrNoLabel):
+		return errs.New(errs.FailedPrecondition, err)
+	case errors.Is(err, shipmentbus.ErrUnknownServiceLevel):
+		return errs.New(errs.InvalidArgument, err)
+	case errors.Is(err, shipmentbus.ErrNotFound):
+		return errs.New(errs.NotFound, err)
+	default:
+		return errs.Newf(errs.Unavailable, "%s: %s", op, err)
+	}
+}
@@ -116,6 +116,14 @@ type CascadeMapEntry struct {
 	TriggersEvent  string
 	ModifiedFields []string
 	Downstream     []DownstreamRule
+	CallsRule      *CalledRule
+}
+
+// CalledRule is the rule a call_workflow action runs as a sub-workflow. RuleName is empty
+// when the rule no longer exists.
+type CalledRule struct {
+	RuleID   uuid.UUID
+	RuleName string
 }
 
 // DownstreamRule is one rule whose trigger an action's mutation could satisfy.
@@ -185,6 +193,18 @@ func (a *App) CascadeMap(ctx context.Context, ruleID uuid.UUID) (workflow.Automa
 			}
 		}
 
+		if calledID, ok := workflow.CalledRuleID(action.TemplateActionType, action.ActionConfig); ok {
+			entry.CallsRule = &CalledRule{RuleID: calledID}
+			called, err := a.workflowBus.QueryRuleByID(ctx, calledID)
+			switch {
+			case err == nil:
+				entry.CallsRule.RuleName = called.Name
+			case !errors.Is(err, workflow.ErrNotFound):
+				// Fail-soft, like the downstream lookup: show the call without its name.
+				a.log.Error(ctx, "ruleapp: cascade map query called rule", "rule_id", calledID, "error", err)
+			}
+		}
+
 		entries = append(entries, entry)
 	}
 
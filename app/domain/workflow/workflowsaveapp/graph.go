@@ -14,6 +14,7 @@ import (
 //  3. Exactly one start edge is required.
 //  4. No cycles are allowed in the graph.
 //  5. All actions must be reachable from the start edge.
+//  6. A for_each action's "each" port leads to exactly one action.
 func ValidateGraph(actions []SaveActionRequest, edges []SaveEdgeRequest) error {
 	if len(actions) == 0 {
 		return nil // No actions means no graph to validate
@@ -95,6 +96,35 @@ func ValidateGraph(actions []SaveActionRequest, edges []SaveEdgeRequest) error {
 		return err
 	}
 
+	if err := checkForEachBodies(actions, edges, actionRefToNodeID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkForEachBodies verifies every for_each action has exactly one edge out
+// of its "each" port: that edge is the entry of the branch run per item.
+func checkForEachBodies(actions []SaveActionRequest, edges []SaveEdgeRequest, actionRefToNodeID map[string]string) error {
+	bodyEdges := make(map[string]int)
+	for _, edge := range edges {
+		if edge.EdgeType == "start" || edge.SourceOutput != "each" {
+			continue
+		}
+		if sourceID, ok := actionRefToNodeID[edge.SourceActionID]; ok {
+			bodyEdges[sourceID]++
+		}
+	}
+
+	for i, action := range actions {
+		if action.ActionType != ActionTypeForEach {
+			continue
+		}
+		if n := bodyEdges[fmt.Sprintf("temp:%d", i)]; n != 1 {
+			return fmt.Errorf("for_each action %q must have exactly one edge from its \"each\" output, found %d", action.Name, n)
+		}
+	}
+
 	return nil
 }
 
@@ -191,6 +191,50 @@ func TestValidateGraph_UUIDReferences(t *testing.T) {
 	}
 }
 
+func TestValidateGraph_ForEachBody(t *testing.T) {
+	loop := SaveActionRequest{
+		Name:         "loop",
+		ActionType:   ActionTypeForEach,
+		ActionConfig: []byte(`{"items":"{{line_items}}"}`),
+		IsActive:     true,
+	}
+	portEdge := func(source, target, port string) SaveEdgeRequest {
+		e := seqEdge(source, target)
+		e.SourceOutput = port
+		return e
+	}
+
+	actions := []SaveActionRequest{loop, action("body"), action("after"), action("other")}
+	edges := []SaveEdgeRequest{
+		startEdge("temp:0"),
+		portEdge("temp:0", "temp:1", "each"),
+		portEdge("temp:0", "temp:2", "done"),
+		seqEdge("temp:1", "temp:3"),
+	}
+	if err := ValidateGraph(actions, edges); err != nil {
+		t.Fatalf("for_each with one each edge should be valid: %s", err)
+	}
+
+	noBody := []SaveEdgeRequest{
+		startEdge("temp:0"),
+		portEdge("temp:0", "temp:1", "done"),
+	}
+	err := ValidateGraph(actions[:2], noBody)
+	if err == nil || !strings.Contains(err.Error(), "exactly one edge") {
+		t.Fatalf("expected error for for_each without an each edge, got: %v", err)
+	}
+
+	twoBodies := []SaveEdgeRequest{
+		startEdge("temp:0"),
+		portEdge("temp:0", "temp:1", "each"),
+		portEdge("temp:0", "temp:2", "each"),
+	}
+	err = ValidateGraph(actions[:3], twoBodies)
+	if err == nil || !strings.Contains(err.Error(), "exactly one edge") {
+		t.Fatalf("expected error for for_each with two each edges, got: %v", err)
+	}
+}
+
 func TestResolveActionRef(t *testing.T) {
 	refMap := map[string]string{
 		"temp:0":    "temp:0",
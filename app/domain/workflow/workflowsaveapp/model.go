@@ -3,9 +3,11 @@ package workflowsaveapp
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/timmaaaz/ichor/app/sdk/errs"
 	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/temporal"
 )
 
 // SaveWorkflowRequest represents a complete workflow save request including
@@ -23,6 +25,13 @@ type SaveWorkflowRequest struct {
 	Actions []SaveActionRequest `json:"actions" validate:"dive"`
 	Edges   []SaveEdgeRequest   `json:"edges" validate:"dive"`
 	CanvasLayout      json.RawMessage     `json:"canvas_layout"`
+
+	// Schedule is required when the trigger type is "scheduled" and rejected
+	// otherwise. Saving a non-scheduled rule removes any existing schedule.
+	Schedule *SaveScheduleRequest `json:"schedule,omitempty"`
+
+	// RevisionNotes is stored on the revision the save records.
+	RevisionNotes string `json:"revision_notes" validate:"max=1000"`
 }
 
 // Decode implements the Decoder interface.
@@ -35,9 +44,28 @@ func (r SaveWorkflowRequest) Validate() error {
 	if err := errs.Check(r); err != nil {
 		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
 	}
+	if len(r.TriggerConditions) > 0 {
+		if err := workflow.ValidateTriggerConditions(r.TriggerConditions); err != nil {
+			return errs.Newf(errs.InvalidArgument, "trigger_conditions: %s", err)
+		}
+	}
+	if r.Schedule != nil {
+		if err := workflow.ValidateScheduleSpec(r.Schedule.CronExpression, r.Schedule.IntervalSeconds, r.Schedule.Timezone); err != nil {
+			return errs.Newf(errs.InvalidArgument, "schedule: %s", err)
+		}
+	}
 	return nil
 }
 
+// SaveScheduleRequest is the firing schedule of a "scheduled" workflow. Set
+// exactly one of CronExpression (standard 5-field, evaluated in Timezone) or
+// IntervalSeconds. Timezone is an IANA name; empty means UTC.
+type SaveScheduleRequest struct {
+	CronExpression  string `json:"cron_expression"`
+	IntervalSeconds int    `json:"interval_seconds" validate:"min=0"`
+	Timezone        string `json:"timezone"`
+}
+
 // SaveActionRequest represents an action to save within a workflow.
 // If ID is nil or empty, a new action will be created.
 // If ID contains a UUID, the existing action will be updated.
@@ -45,7 +73,7 @@ type SaveActionRequest struct {
 	ID             *string         `json:"id"`
 	Name           string          `json:"name" validate:"required,min=1,max=255"`
 	Description    string          `json:"description" validate:"max=1000"`
-	ActionType     string          `json:"action_type" validate:"required,oneof=allocate_inventory check_inventory check_reorder_point commit_allocation create_alert create_entity delay evaluate_condition log_audit_entry lookup_entity release_reservation reserve_inventory seek_approval send_email send_notification transition_status update_field"`
+	ActionType     string          `json:"action_type" validate:"required,oneof=allocate_inventory call_workflow check_inventory check_reorder_point commit_allocation create_alert create_entity delay evaluate_condition for_each log_audit_entry lookup_entity release_reservation reserve_inventory seek_approval send_email send_notification transition_status update_field"`
 	ActionConfig   json.RawMessage `json:"action_config" validate:"required"`
 	IsActive       bool            `json:"is_active"`
 }
@@ -63,18 +91,23 @@ type SaveEdgeRequest struct {
 
 // SaveWorkflowResponse represents the complete saved workflow.
 type SaveWorkflowResponse struct {
-	ID                string               `json:"id"`
-	Name              string               `json:"name"`
-	Description       string               `json:"description"`
-	IsActive          bool                 `json:"is_active"`
-	EntityID          string               `json:"entity_id"`
-	TriggerTypeID     string               `json:"trigger_type_id"`
-	TriggerConditions json.RawMessage      `json:"trigger_conditions"`
-	Actions           []SaveActionResponse `json:"actions"`
-	Edges             []SaveEdgeResponse   `json:"edges"`
-	CanvasLayout      json.RawMessage      `json:"canvas_layout"`
-	CreatedDate       string               `json:"created_date"`
-	UpdatedDate       string               `json:"updated_date"`
+	ID                string                `json:"id"`
+	Name              string                `json:"name"`
+	Description       string                `json:"description"`
+	IsActive          bool                  `json:"is_active"`
+	EntityID          string                `json:"entity_id"`
+	TriggerTypeID     string                `json:"trigger_type_id"`
+	TriggerConditions json.RawMessage       `json:"trigger_conditions"`
+	Actions           []SaveActionResponse  `json:"actions"`
+	Edges             []SaveEdgeResponse    `json:"edges"`
+	CanvasLayout      json.RawMessage       `json:"canvas_layout"`
+	Schedule          *SaveScheduleResponse `json:"schedule,omitempty"`
+	CreatedDate       string                `json:"created_date"`
+	UpdatedDate       string                `json:"updated_date"`
+
+	// RevisionID and RevisionNumber identify the revision the save published.
+	RevisionID     string `json:"revision_id,omitempty"`
+	RevisionNumber int    `json:"revision_number,omitempty"`
 }
 
 // Encode implements the Encoder interface.
@@ -93,6 +126,16 @@ type SaveActionResponse struct {
 	IsActive       bool            `json:"is_active"`
 }
 
+// SaveScheduleResponse represents a saved workflow schedule in the response.
+type SaveScheduleResponse struct {
+	ID              string `json:"id"`
+	CronExpression  string `json:"cron_expression,omitempty"`
+	IntervalSeconds int    `json:"interval_seconds,omitempty"`
+	Timezone        string `json:"timezone,omitempty"`
+	NextRunAt       string `json:"next_run_at"`
+	LastRunAt       string `json:"last_run_at,omitempty"`
+}
+
 // SaveEdgeResponse represents a saved edge in the response.
 type SaveEdgeResponse struct {
 	ID             string `json:"id"`
@@ -121,3 +164,133 @@ func (r ValidationResult) Encode() ([]byte, string, error) {
 	data, err := json.Marshal(r)
 	return data, "application/json", err
 }
+
+// RuleRevision is an immutable snapshot of a workflow. Definition uses the
+// same ids as the live actions, so it can be read next to the saved workflow.
+type RuleRevision struct {
+	ID             string                  `json:"id"`
+	RuleID         string                  `json:"rule_id"`
+	RevisionNumber int                     `json:"revision_number"`
+	Status         string                  `json:"status"`
+	Definition     workflow.RuleDefinition `json:"definition"`
+	RestoredFromID string                  `json:"restored_from_id,omitempty"`
+	Notes          string                  `json:"notes,omitempty"`
+	CreatedBy      string                  `json:"created_by"`
+	CreatedDate    string                  `json:"created_date"`
+	PublishedBy    string                  `json:"published_by,omitempty"`
+	PublishedDate  string                  `json:"published_date,omitempty"`
+}
+
+// Encode implements the Encoder interface.
+func (r RuleRevision) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(r)
+	return data, "application/json", err
+}
+
+func toAppRuleRevision(rev workflow.RuleRevision) RuleRevision {
+	r := RuleRevision{
+		ID:             rev.ID.String(),
+		RuleID:         rev.RuleID.String(),
+		RevisionNumber: rev.RevisionNumber,
+		Status:         rev.Status,
+		Definition:     rev.Definition,
+		Notes:          rev.Notes,
+		CreatedBy:      rev.CreatedBy.String(),
+		CreatedDate:    rev.CreatedDate.Format(time.RFC3339),
+	}
+	if rev.RestoredFromID != nil {
+		r.RestoredFromID = rev.RestoredFromID.String()
+	}
+	if rev.PublishedBy != nil {
+		r.PublishedBy = rev.PublishedBy.String()
+	}
+	if rev.PublishedDate != nil {
+		r.PublishedDate = rev.PublishedDate.Format(time.RFC3339)
+	}
+	return r
+}
+
+// RuleRevisions is the revision history of a workflow, newest first.
+type RuleRevisions []RuleRevision
+
+// Encode implements the Encoder interface.
+func (r RuleRevisions) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(r)
+	return data, "application/json", err
+}
+
+func toAppRuleRevisions(revs []workflow.RuleRevision) RuleRevisions {
+	items := make(RuleRevisions, len(revs))
+	for i, rev := range revs {
+		items[i] = toAppRuleRevision(rev)
+	}
+	return items
+}
+
+// RevisionDiff is what changed between two revisions of a workflow.
+type RevisionDiff struct {
+	workflow.RevisionDiff
+}
+
+// Encode implements the Encoder interface.
+func (r RevisionDiff) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(r)
+	return data, "application/json", err
+}
+
+// SimulateRequest selects the workflow to simulate and the events to replay.
+// Set at most one of RevisionID and Workflow; with neither, the live rule is
+// simulated. With no Events, the rule's past events are read from the cascade
+// outbox history between Since and Until (default: the last 30 days).
+type SimulateRequest struct {
+	RevisionID string               `json:"revision_id" validate:"omitempty,uuid"`
+	Workflow   *SaveWorkflowRequest `json:"workflow,omitempty"`
+
+	Events    []SimulateEvent `json:"events" validate:"omitempty,max=5000,dive"`
+	Since     string          `json:"since"`
+	Until     string          `json:"until"`
+	EventType string          `json:"event_type" validate:"omitempty,oneof=on_create on_update on_delete"`
+	Limit     int             `json:"limit" validate:"omitempty,min=1,max=5000"`
+}
+
+// Decode implements the Decoder interface.
+func (r *SimulateRequest) Decode(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+// Validate checks the SimulateRequest for validity.
+func (r SimulateRequest) Validate() error {
+	if err := errs.Check(r); err != nil {
+		return errs.Newf(errs.InvalidArgument, "validate: %s", err)
+	}
+	if r.RevisionID != "" && r.Workflow != nil {
+		return errs.Newf(errs.InvalidArgument, "set at most one of revision_id and workflow")
+	}
+	return nil
+}
+
+// SimulateEvent is a hand-written event to replay instead of past events.
+type SimulateEvent struct {
+	EventType    string                          `json:"event_type" validate:"required,oneof=on_create on_update on_delete"`
+	EntityID     string                          `json:"entity_id" validate:"omitempty,uuid"`
+	Timestamp    string                          `json:"timestamp"`
+	RawData      map[string]any                  `json:"raw_data"`
+	FieldChanges map[string]workflow.FieldChange `json:"field_changes"`
+}
+
+// SimulationReport is what the workflow would have done for each replayed
+// event. RevisionID is empty when an unsaved workflow or the live rule was
+// simulated.
+type SimulationReport struct {
+	RuleID     string `json:"rule_id"`
+	RevisionID string `json:"revision_id,omitempty"`
+	Since      string `json:"since,omitempty"`
+	Until      string `json:"until,omitempty"`
+	temporal.SimulationReport
+}
+
+// Encode implements the Encoder interface.
+func (r SimulationReport) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(r)
+	return data, "application/json", err
+}
@@ -0,0 +1,528 @@
+package workflowsaveapp
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+)
+
+// SaveDraft records the request as a draft revision of the rule without
+// touching the live rule. The draft is validated like a save, except for the
+// cascade analysis, which runs when the draft is published against the rule
+// set of that moment.
+func (a *App) SaveDraft(ctx context.Context, ruleID uuid.UUID, userID uuid.UUID, req SaveWorkflowRequest) (RuleRevision, error) {
+	if err := a.prepareRequest(&req); err != nil {
+		return RuleRevision{}, err
+	}
+
+	if err := a.checkSchedule(ctx, req); err != nil {
+		return RuleRevision{}, err
+	}
+
+	rule, err := a.workflowBus.QueryRuleByID(ctx, ruleID)
+	if err != nil {
+		return RuleRevision{}, errs.Newf(errs.NotFound, "rule not found: %s", err)
+	}
+
+	if rule.IsDefault {
+		return RuleRevision{}, errs.Newf(errs.PermissionDenied, "cannot modify default workflow: use POST /v1/workflow/rules/{id}/duplicate to create an editable copy")
+	}
+
+	existing, err := a.workflowBus.QueryActionsByRule(ctx, ruleID)
+	if err != nil {
+		return RuleRevision{}, errs.Newf(errs.Internal, "query existing actions: %s", err)
+	}
+
+	def, err := definitionFromRequest(req, existing)
+	if err != nil {
+		return RuleRevision{}, err
+	}
+
+	tx, err := a.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return RuleRevision{}, errs.Newf(errs.Internal, "begin tx: %s", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	txBus, err := a.workflowBus.NewWithTx(tx)
+	if err != nil {
+		return RuleRevision{}, errs.Newf(errs.Internal, "new with tx: %s", err)
+	}
+
+	rev, err := txBus.CreateRuleRevision(ctx, workflow.NewRuleRevision{
+		RuleID:     ruleID,
+		Definition: def,
+		Notes:      req.RevisionNotes,
+		CreatedBy:  userID,
+	})
+	if err != nil {
+		return RuleRevision{}, errs.Newf(errs.Internal, "create revision: %s", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return RuleRevision{}, errs.Newf(errs.Internal, "commit: %s", err)
+	}
+
+	return toAppRuleRevision(rev), nil
+}
+
+// PublishRevision makes the live rule match a draft revision and marks the
+// draft published, superseding the revision that was live before.
+func (a *App) PublishRevision(ctx context.Context, ruleID uuid.UUID, revisionID uuid.UUID, userID uuid.UUID) (SaveWorkflowResponse, error) {
+	rev, err := a.queryRevision(ctx, ruleID, revisionID)
+	if err != nil {
+		return SaveWorkflowResponse{}, err
+	}
+
+	if rev.Status != workflow.RevisionDraft {
+		return SaveWorkflowResponse{}, errs.Newf(errs.FailedPrecondition, "revision %d is %s: %s", rev.RevisionNumber, rev.Status, workflow.ErrRevisionNotDraft)
+	}
+
+	return a.publish(ctx, ruleID, userID, rev.Definition, func(bus *workflow.Business) (workflow.RuleRevision, error) {
+		// Re-read under the rule lock taken by applyDefinition: a concurrent
+		// publish of the same draft finds it no longer a draft.
+		return bus.QueryRuleRevisionByID(ctx, rev.ID)
+	})
+}
+
+// RollbackRevision republishes the definition of an older revision. The
+// definition is copied into a new revision that records where it came from,
+// so the revision history keeps reading forwards.
+func (a *App) RollbackRevision(ctx context.Context, ruleID uuid.UUID, revisionID uuid.UUID, userID uuid.UUID) (SaveWorkflowResponse, error) {
+	rev, err := a.queryRevision(ctx, ruleID, revisionID)
+	if err != nil {
+		return SaveWorkflowResponse{}, err
+	}
+
+	if rev.Status != workflow.RevisionSuperseded {
+		return SaveWorkflowResponse{}, errs.Newf(errs.FailedPrecondition, "revision %d is %s: %s", rev.RevisionNumber, rev.Status, workflow.ErrRevisionNotSuperseded)
+	}
+
+	return a.publish(ctx, ruleID, userID, rev.Definition, func(bus *workflow.Business) (workflow.RuleRevision, error) {
+		return bus.CreateRuleRevision(ctx, workflow.NewRuleRevision{
+			RuleID:         ruleID,
+			Definition:     rev.Definition,
+			RestoredFromID: &rev.ID,
+			Notes:          fmt.Sprintf("rollback to revision %d", rev.RevisionNumber),
+			CreatedBy:      userID,
+		})
+	})
+}
+
+// QueryRevisions returns every revision of a rule, newest first.
+func (a *App) QueryRevisions(ctx context.Context, ruleID uuid.UUID) (RuleRevisions, error) {
+	if _, err := a.workflowBus.QueryRuleByID(ctx, ruleID); err != nil {
+		return nil, errs.Newf(errs.NotFound, "rule not found: %s", err)
+	}
+
+	revs, err := a.workflowBus.QueryRuleRevisionsByRuleID(ctx, ruleID)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "query revisions: %s", err)
+	}
+
+	return toAppRuleRevisions(revs), nil
+}
+
+// QueryRevisionByID returns a single revision of a rule.
+func (a *App) QueryRevisionByID(ctx context.Context, ruleID uuid.UUID, revisionID uuid.UUID) (RuleRevision, error) {
+	rev, err := a.queryRevision(ctx, ruleID, revisionID)
+	if err != nil {
+		return RuleRevision{}, err
+	}
+
+	return toAppRuleRevision(rev), nil
+}
+
+// DiffRevisions compares two revisions of a rule. A nil toID compares against
+// the revision that is published now.
+func (a *App) DiffRevisions(ctx context.Context, ruleID uuid.UUID, fromID uuid.UUID, toID uuid.UUID) (RevisionDiff, error) {
+	from, err := a.queryRevision(ctx, ruleID, fromID)
+	if err != nil {
+		return RevisionDiff{}, err
+	}
+
+	var to workflow.RuleRevision
+	switch toID {
+	case uuid.Nil:
+		revs, err := a.workflowBus.QueryRuleRevisionsByRuleID(ctx, ruleID)
+		if err != nil {
+			return RevisionDiff{}, errs.Newf(errs.Internal, "query revisions: %s", err)
+		}
+		found := false
+		for _, rev := range revs {
+			if rev.Status == workflow.RevisionPublished {
+				to, found = rev, true
+				break
+			}
+		}
+		if !found {
+			return RevisionDiff{}, errs.Newf(errs.FailedPrecondition, "rule %s has no published revision to compare against", ruleID)
+		}
+	default:
+		to, err = a.queryRevision(ctx, ruleID, toID)
+		if err != nil {
+			return RevisionDiff{}, err
+		}
+	}
+
+	return RevisionDiff{RevisionDiff: workflow.DiffRuleRevisions(from, to)}, nil
+}
+
+// =============================================================================
+
+// queryRevision loads a revision and checks it belongs to the rule.
+func (a *App) queryRevision(ctx context.Context, ruleID uuid.UUID, revisionID uuid.UUID) (workflow.RuleRevision, error) {
+	rev, err := a.workflowBus.QueryRuleRevisionByID(ctx, revisionID)
+	if err != nil {
+		if errors.Is(err, workflow.ErrNotFound) {
+			return workflow.RuleRevision{}, errs.Newf(errs.NotFound, "revision not found: %s", err)
+		}
+		return workflow.RuleRevision{}, errs.Newf(errs.Internal, "query revision: %s", err)
+	}
+
+	if rev.RuleID != ruleID {
+		return workflow.RuleRevision{}, errs.Newf(errs.NotFound, "revision %s does not belong to rule %s", revisionID, ruleID)
+	}
+
+	return rev, nil
+}
+
+// publish applies def to the live rule and publishes the draft revision that
+// draft returns, all in one transaction. def goes through the same checks as
+// a save, cascade analysis included, since publishing changes what runs.
+func (a *App) publish(ctx context.Context, ruleID uuid.UUID, userID uuid.UUID, def workflow.RuleDefinition, draft func(bus *workflow.Business) (workflow.RuleRevision, error)) (SaveWorkflowResponse, error) {
+	req := requestFromDefinition(def)
+
+	if err := a.prepareRequest(&req); err != nil {
+		return SaveWorkflowResponse{}, err
+	}
+
+	if err := a.checkSchedule(ctx, req); err != nil {
+		return SaveWorkflowResponse{}, err
+	}
+
+	if err := a.enforceCascades(ctx, ruleID, req); err != nil {
+		return SaveWorkflowResponse{}, err
+	}
+
+	tx, err := a.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return SaveWorkflowResponse{}, errs.Newf(errs.Internal, "begin tx: %s", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	txBus, err := a.workflowBus.NewWithTx(tx)
+	if err != nil {
+		return SaveWorkflowResponse{}, errs.Newf(errs.Internal, "new with tx: %s", err)
+	}
+
+	rule, savedActions, savedEdges, schedule, err := a.applyDefinition(ctx, txBus, ruleID, def, req)
+	if err != nil {
+		return SaveWorkflowResponse{}, err
+	}
+
+	rev, err := draft(txBus)
+	if err != nil {
+		return SaveWorkflowResponse{}, errs.Newf(errs.Internal, "revision: %s", err)
+	}
+
+	published, err := txBus.PublishRuleRevision(ctx, rev, userID)
+	if err != nil {
+		if errors.Is(err, workflow.ErrRevisionNotDraft) {
+			return SaveWorkflowResponse{}, errs.Newf(errs.FailedPrecondition, "publish revision: %s", err)
+		}
+		return SaveWorkflowResponse{}, errs.Newf(errs.Internal, "publish revision: %s", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return SaveWorkflowResponse{}, errs.Newf(errs.Internal, "commit: %s", err)
+	}
+
+	if a.delegate != nil {
+		a.log.Info(ctx, "workflowsaveapp: revision published, firing delegate event", "ruleID", ruleID, "revision", published.RevisionNumber)
+		if err := a.delegate.Call(ctx, workflow.ActionRuleChangedData(workflow.ActionRuleUpdated, ruleID)); err != nil {
+			a.log.Error(ctx, "workflowsaveapp: delegate call failed", "action", workflow.ActionRuleUpdated, "err", err)
+		}
+	}
+
+	resp := buildResponse(rule, savedActions, savedEdges, def.CanvasLayout, schedule)
+	resp.RevisionID = published.ID.String()
+	resp.RevisionNumber = published.RevisionNumber
+
+	return resp, nil
+}
+
+// applyDefinition makes the live rule, actions, edges and schedule match def.
+// Actions keep the ids def gives them: existing ones are updated in place
+// (reactivating any a later save had removed), missing ones are created under
+// that id, and live actions def leaves out are deactivated.
+func (a *App) applyDefinition(ctx context.Context, bus *workflow.Business, ruleID uuid.UUID, def workflow.RuleDefinition, req SaveWorkflowRequest) (workflow.AutomationRule, []workflow.RuleAction, []workflow.ActionEdge, *workflow.RuleSchedule, error) {
+	rule, err := a.updateRule(ctx, bus, ruleID, req)
+	if err != nil {
+		return workflow.AutomationRule{}, nil, nil, nil, err
+	}
+
+	existingActions, err := bus.QueryActionsByRule(ctx, ruleID)
+	if err != nil {
+		return workflow.AutomationRule{}, nil, nil, nil, errs.Newf(errs.Internal, "query existing actions: %s", err)
+	}
+
+	existingMap := make(map[uuid.UUID]workflow.RuleAction, len(existingActions))
+	for _, action := range existingActions {
+		existingMap[action.ID] = action
+	}
+
+	referencedIDs := make(map[uuid.UUID]bool, len(def.Actions))
+	savedActions := make([]workflow.RuleAction, 0, len(def.Actions))
+
+	for _, ra := range def.Actions {
+		referencedIDs[ra.ID] = true
+
+		if existing, ok := existingMap[ra.ID]; ok {
+			updated, err := bus.UpdateRuleAction(ctx, existing, workflow.UpdateRuleAction{
+				Name:         &ra.Name,
+				Description:  &ra.Description,
+				ActionConfig: &ra.ActionConfig,
+				IsActive:     &ra.IsActive,
+			})
+			if err != nil {
+				return workflow.AutomationRule{}, nil, nil, nil, errs.Newf(errs.Internal, "update action: %s", err)
+			}
+			savedActions = append(savedActions, updated)
+			continue
+		}
+
+		created, err := bus.CreateRuleAction(ctx, workflow.NewRuleAction{
+			ID:               ra.ID,
+			AutomationRuleID: ruleID,
+			Name:             ra.Name,
+			Description:      ra.Description,
+			ActionConfig:     ra.ActionConfig,
+			IsActive:         ra.IsActive,
+			TemplateID:       ra.TemplateID,
+		})
+		if err != nil {
+			return workflow.AutomationRule{}, nil, nil, nil, errs.Newf(errs.Internal, "create action: %s", err)
+		}
+		savedActions = append(savedActions, created)
+	}
+
+	for id, action := range existingMap {
+		if !referencedIDs[id] {
+			if err := bus.DeactivateRuleAction(ctx, action); err != nil {
+				return workflow.AutomationRule{}, nil, nil, nil, errs.Newf(errs.Internal, "delete action: %s", err)
+			}
+		}
+	}
+
+	if err := bus.DeleteEdgesByRuleID(ctx, ruleID); err != nil {
+		return workflow.AutomationRule{}, nil, nil, nil, errs.Newf(errs.Internal, "delete edges: %s", err)
+	}
+
+	// The request's edges reference actions by their definition ids, which
+	// resolveActionID passes through unchanged.
+	savedEdges, err := a.createEdges(ctx, bus, ruleID, req.Edges, map[string]uuid.UUID{})
+	if err != nil {
+		return workflow.AutomationRule{}, nil, nil, nil, err
+	}
+
+	schedule, err := a.syncSchedule(ctx, bus, ruleID, req.Schedule)
+	if err != nil {
+		return workflow.AutomationRule{}, nil, nil, nil, err
+	}
+
+	return rule, savedActions, savedEdges, schedule, nil
+}
+
+// recordRevision snapshots the rule as a save just left it into a new
+// revision and publishes it, so the live graph always has a published
+// revision for executions to pin to.
+func (a *App) recordRevision(ctx context.Context, bus *workflow.Business, userID uuid.UUID, notes string, rule workflow.AutomationRule, actions []workflow.RuleAction, edges []workflow.ActionEdge, schedule *workflow.RuleSchedule) (workflow.RuleRevision, error) {
+	rev, err := bus.CreateRuleRevision(ctx, workflow.NewRuleRevision{
+		RuleID:     rule.ID,
+		Definition: workflow.NewRuleDefinition(rule, actions, edges, schedule),
+		Notes:      notes,
+		CreatedBy:  userID,
+	})
+	if err != nil {
+		return workflow.RuleRevision{}, errs.Newf(errs.Internal, "create revision: %s", err)
+	}
+
+	published, err := bus.PublishRuleRevision(ctx, rev, userID)
+	if err != nil {
+		return workflow.RuleRevision{}, errs.Newf(errs.Internal, "publish revision: %s", err)
+	}
+
+	return published, nil
+}
+
+// definitionFromRequest builds a draft definition from a save request. New
+// actions are given their ids now, so the draft's edges and any later diff
+// refer to the same ids the actions get when the draft is published.
+// Existing action ids must belong to the rule.
+func definitionFromRequest(req SaveWorkflowRequest, existing []workflow.RuleAction) (workflow.RuleDefinition, error) {
+	entityID, err := uuid.Parse(req.EntityID)
+	if err != nil {
+		return workflow.RuleDefinition{}, errs.Newf(errs.InvalidArgument, "invalid entity_id: %s", err)
+	}
+
+	triggerTypeID, err := uuid.Parse(req.TriggerTypeID)
+	if err != nil {
+		return workflow.RuleDefinition{}, errs.Newf(errs.InvalidArgument, "invalid trigger_type_id: %s", err)
+	}
+
+	existingMap := make(map[uuid.UUID]workflow.RuleAction, len(existing))
+	for _, action := range existing {
+		existingMap[action.ID] = action
+	}
+
+	def := workflow.RuleDefinition{
+		Name:              req.Name,
+		Description:       req.Description,
+		IsActive:          req.IsActive,
+		EntityID:          entityID,
+		TriggerTypeID:     triggerTypeID,
+		TriggerConditions: req.TriggerConditions,
+		CanvasLayout:      req.CanvasLayout,
+		Actions:           make([]workflow.RevisionAction, len(req.Actions)),
+		Edges:             make([]workflow.RevisionEdge, len(req.Edges)),
+	}
+
+	actionIDMap := make(map[string]uuid.UUID, len(req.Actions))
+
+	for i, reqAction := range req.Actions {
+		id := uuid.New()
+		var templateID *uuid.UUID
+
+		if reqAction.ID != nil && *reqAction.ID != "" {
+			id, err = uuid.Parse(*reqAction.ID)
+			if err != nil {
+				return workflow.RuleDefinition{}, errs.Newf(errs.InvalidArgument, "invalid action id: %s", err)
+			}
+
+			action, exists := existingMap[id]
+			if !exists {
+				return workflow.RuleDefinition{}, errs.Newf(errs.InvalidArgument, "action %s does not belong to this rule", id)
+			}
+			templateID = action.TemplateID
+		}
+
+		configWithType, err := ensureActionTypeInConfig(reqAction.ActionConfig, reqAction.ActionType)
+		if err != nil {
+			return workflow.RuleDefinition{}, errs.Newf(errs.InvalidArgument, "prepare action config: %s", err)
+		}
+
+		actionIDMap[fmt.Sprintf("temp:%d", i)] = id
+		actionIDMap[id.String()] = id
+
+		def.Actions[i] = workflow.RevisionAction{
+			ID:           id,
+			Name:         reqAction.Name,
+			Description:  reqAction.Description,
+			ActionConfig: configWithType,
+			IsActive:     reqAction.IsActive,
+			TemplateID:   templateID,
+		}
+	}
+
+	for i, reqEdge := range req.Edges {
+		targetID, err := resolveActionID(reqEdge.TargetActionID, actionIDMap)
+		if err != nil {
+			return workflow.RuleDefinition{}, errs.Newf(errs.InvalidArgument, "edge[%d]: %s", i, err)
+		}
+
+		edge := workflow.RevisionEdge{
+			TargetActionID: targetID,
+			EdgeType:       reqEdge.EdgeType,
+			EdgeOrder:      reqEdge.EdgeOrder,
+		}
+
+		if reqEdge.SourceActionID != "" {
+			sourceID, err := resolveActionID(reqEdge.SourceActionID, actionIDMap)
+			if err != nil {
+				return workflow.RuleDefinition{}, errs.Newf(errs.InvalidArgument, "edge[%d]: %s", i, err)
+			}
+			edge.SourceActionID = &sourceID
+		}
+
+		if reqEdge.SourceOutput != "" {
+			s := reqEdge.SourceOutput
+			edge.SourceOutput = &s
+		}
+
+		def.Edges[i] = edge
+	}
+
+	if req.Schedule != nil {
+		def.Schedule = &workflow.RevisionSchedule{
+			CronExpression:  req.Schedule.CronExpression,
+			IntervalSeconds: req.Schedule.IntervalSeconds,
+			Timezone:        req.Schedule.Timezone,
+		}
+	}
+
+	return def, nil
+}
+
+// requestFromDefinition turns a revision definition back into a save request,
+// with actions and edges referring to each other by the definition's ids, so a
+// revision can be validated and analyzed exactly like a save.
+func requestFromDefinition(def workflow.RuleDefinition) SaveWorkflowRequest {
+	req := SaveWorkflowRequest{
+		Name:              def.Name,
+		Description:       def.Description,
+		IsActive:          def.IsActive,
+		EntityID:          def.EntityID.String(),
+		TriggerTypeID:     def.TriggerTypeID.String(),
+		TriggerConditions: def.TriggerConditions,
+		CanvasLayout:      def.CanvasLayout,
+		Actions:           make([]SaveActionRequest, len(def.Actions)),
+		Edges:             make([]SaveEdgeRequest, len(def.Edges)),
+	}
+
+	for i, ra := range def.Actions {
+		id := ra.ID.String()
+		req.Actions[i] = SaveActionRequest{
+			ID:           &id,
+			Name:         ra.Name,
+			Description:  ra.Description,
+			ActionType:   getActionTypeFromConfig(ra.ActionConfig),
+			ActionConfig: ra.ActionConfig,
+			IsActive:     ra.IsActive,
+		}
+	}
+
+	for i, re := range def.Edges {
+		edge := SaveEdgeRequest{
+			TargetActionID: re.TargetActionID.String(),
+			EdgeType:       re.EdgeType,
+			EdgeOrder:      re.EdgeOrder,
+		}
+		if re.SourceActionID != nil {
+			edge.SourceActionID = re.SourceActionID.String()
+		}
+		if re.SourceOutput != nil {
+			edge.SourceOutput = *re.SourceOutput
+		}
+		req.Edges[i] = edge
+	}
+
+	if def.Schedule != nil {
+		req.Schedule = &SaveScheduleRequest{
+			CronExpression:  def.Schedule.CronExpression,
+			IntervalSeconds: def.Schedule.IntervalSeconds,
+			Timezone:        def.Schedule.Timezone,
+		}
+	}
+
+	return req
+}
@@ -0,0 +1,90 @@
+package workflowsaveapp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+)
+
+func TestDefinitionFromRequest(t *testing.T) {
+	existing := workflow.RuleAction{ID: uuid.New(), Name: "Notify", TemplateID: ptrUUID(uuid.New())}
+	existingID := existing.ID.String()
+
+	req := SaveWorkflowRequest{
+		Name:          "Low stock",
+		IsActive:      true,
+		EntityID:      uuid.NewString(),
+		TriggerTypeID: uuid.NewString(),
+		Actions: []SaveActionRequest{
+			{ID: &existingID, Name: "Notify", ActionType: "send_notification", ActionConfig: json.RawMessage(`{"title":"Low"}`), IsActive: true},
+			{Name: "Audit", ActionType: "log_audit_entry", ActionConfig: json.RawMessage(`{}`), IsActive: true},
+		},
+		Edges: []SaveEdgeRequest{
+			{TargetActionID: existingID, EdgeType: "start"},
+			{SourceActionID: existingID, TargetActionID: "temp:1", EdgeType: "sequence", EdgeOrder: 1},
+		},
+		Schedule: &SaveScheduleRequest{CronExpression: "0 2 * * *"},
+	}
+
+	def, err := definitionFromRequest(req, []workflow.RuleAction{existing})
+	if err != nil {
+		t.Fatalf("definition: %s", err)
+	}
+
+	if def.Actions[0].ID != existing.ID || def.Actions[0].TemplateID != existing.TemplateID {
+		t.Fatalf("existing action = %+v, want its id and template kept", def.Actions[0])
+	}
+	minted := def.Actions[1].ID
+	if minted == uuid.Nil || minted == existing.ID {
+		t.Fatalf("new action id = %s, want a freshly minted id", minted)
+	}
+	if got := getActionTypeFromConfig(def.Actions[1].ActionConfig); got != "log_audit_entry" {
+		t.Fatalf("new action type in config = %q, want log_audit_entry", got)
+	}
+	if e := def.Edges[1]; e.SourceActionID == nil || *e.SourceActionID != existing.ID || e.TargetActionID != minted {
+		t.Fatalf("sequence edge = %+v, want notify -> the minted audit id", e)
+	}
+
+	back := requestFromDefinition(def)
+	if err := ValidateGraph(back.Actions, back.Edges); err != nil {
+		t.Fatalf("round-tripped graph: %s", err)
+	}
+	if *back.Actions[1].ID != minted.String() || back.Edges[1].TargetActionID != minted.String() {
+		t.Fatalf("round-tripped request refers to %s/%s, want the minted id %s", *back.Actions[1].ID, back.Edges[1].TargetActionID, minted)
+	}
+	if back.Actions[1].ActionType != "log_audit_entry" || back.Schedule == nil || back.Schedule.CronExpression != "0 2 * * *" {
+		t.Fatalf("round-tripped request = %+v, want action type and schedule kept", back)
+	}
+	if diff := workflow.DiffRuleDefinitions(def, mustDefinition(t, back, def)); !diff.Empty() {
+		t.Fatalf("round trip changed the definition: %+v", diff)
+	}
+
+	stranger := uuid.NewString()
+	req.Actions[0].ID = &stranger
+	if _, err := definitionFromRequest(req, []workflow.RuleAction{existing}); err == nil {
+		t.Fatal("want an error for an action id that does not belong to the rule")
+	}
+}
+
+// mustDefinition rebuilds a definition from a request whose actions all
+// carry the ids of def.
+func mustDefinition(t *testing.T, req SaveWorkflowRequest, def workflow.RuleDefinition) workflow.RuleDefinition {
+	t.Helper()
+
+	existing := make([]workflow.RuleAction, len(def.Actions))
+	for i, a := range def.Actions {
+		existing[i] = workflow.RuleAction{ID: a.ID, TemplateID: a.TemplateID}
+	}
+
+	got, err := definitionFromRequest(req, existing)
+	if err != nil {
+		t.Fatalf("definition: %s", err)
+	}
+	return got
+}
+
+func ptrUUID(id uuid.UUID) *uuid.UUID {
+	return &id
+}
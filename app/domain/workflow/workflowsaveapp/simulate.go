@@ -0,0 +1,316 @@
+package workflowsaveapp
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/sdk/outbox"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/temporal"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/temporal/stores/edgedb"
+)
+
+const (
+	// simulationWindow is how far back past events are read by default.
+	simulationWindow = 30 * 24 * time.Hour
+
+	// simulationLimit is how many past events are replayed by default.
+	simulationLimit = 500
+)
+
+// Simulate replays events against a workflow without executing any action and
+// reports what the workflow would have done. The workflow is a revision of the
+// rule, an unsaved workflow, or the live rule, in that order of preference.
+// Only rules triggered by entity events can be simulated.
+func (a *App) Simulate(ctx context.Context, ruleID uuid.UUID, req SimulateRequest) (SimulationReport, error) {
+	if err := req.Validate(); err != nil {
+		return SimulationReport{}, err
+	}
+
+	now := time.Now().UTC()
+	since, until, err := simulationRange(req, now)
+	if err != nil {
+		return SimulationReport{}, err
+	}
+
+	rule, err := a.workflowBus.QueryRuleByID(ctx, ruleID)
+	if err != nil {
+		return SimulationReport{}, errs.Newf(errs.NotFound, "rule not found: %s", err)
+	}
+
+	def, revisionID, err := a.simulationDefinition(ctx, rule, req)
+	if err != nil {
+		return SimulationReport{}, err
+	}
+
+	simRule, err := a.simulationRule(ctx, ruleID, def)
+	if err != nil {
+		return SimulationReport{}, err
+	}
+
+	report := SimulationReport{
+		RuleID: ruleID.String(),
+	}
+	if revisionID != uuid.Nil {
+		report.RevisionID = revisionID.String()
+	}
+
+	var events []workflow.TriggerEvent
+	switch len(req.Events) {
+	case 0:
+		eventType := req.EventType
+		if eventType == "" {
+			eventType = simRule.TriggerType
+		}
+
+		limit := req.Limit
+		if limit == 0 {
+			limit = simulationLimit
+		}
+
+		events, err = a.pastEvents(ctx, outbox.EventFilter{
+			EntityName: simRule.EntityName,
+			EventType:  eventType,
+			Since:      &since,
+			Until:      &until,
+			Limit:      limit,
+		})
+		if err != nil {
+			return SimulationReport{}, err
+		}
+
+		report.Since = since.Format(time.RFC3339)
+		report.Until = until.Format(time.RFC3339)
+
+	default:
+		events, err = toSimulationEvents(simRule.EntityName, req.Events, now)
+		if err != nil {
+			return SimulationReport{}, err
+		}
+	}
+
+	simulator := temporal.NewSimulator(a.log, a.registry, edgedb.NewStore(a.log, a.db))
+
+	result, err := simulator.Simulate(ctx, simRule, events)
+	if err != nil {
+		return SimulationReport{}, errs.Newf(errs.InvalidArgument, "simulate: %s", err)
+	}
+
+	report.SimulationReport = result
+
+	return report, nil
+}
+
+// =============================================================================
+
+// simulationDefinition picks the definition to simulate and the revision it
+// came from, if any.
+func (a *App) simulationDefinition(ctx context.Context, rule workflow.AutomationRule, req SimulateRequest) (workflow.RuleDefinition, uuid.UUID, error) {
+	switch {
+	case req.RevisionID != "":
+		revisionID, err := uuid.Parse(req.RevisionID)
+		if err != nil {
+			return workflow.RuleDefinition{}, uuid.Nil, errs.Newf(errs.InvalidArgument, "revision_id: %s", err)
+		}
+
+		rev, err := a.queryRevision(ctx, rule.ID, revisionID)
+		if err != nil {
+			return workflow.RuleDefinition{}, uuid.Nil, err
+		}
+
+		return rev.Definition, rev.ID, nil
+
+	case req.Workflow != nil:
+		wf := *req.Workflow
+		if err := a.prepareRequest(&wf); err != nil {
+			return workflow.RuleDefinition{}, uuid.Nil, err
+		}
+
+		existing, err := a.workflowBus.QueryActionsByRule(ctx, rule.ID)
+		if err != nil {
+			return workflow.RuleDefinition{}, uuid.Nil, errs.Newf(errs.Internal, "query existing actions: %s", err)
+		}
+
+		def, err := definitionFromRequest(wf, existing)
+		if err != nil {
+			return workflow.RuleDefinition{}, uuid.Nil, err
+		}
+
+		return def, uuid.Nil, nil
+
+	default:
+		actions, err := a.workflowBus.QueryActionsByRule(ctx, rule.ID)
+		if err != nil {
+			return workflow.RuleDefinition{}, uuid.Nil, errs.Newf(errs.Internal, "query actions: %s", err)
+		}
+
+		edges, err := a.workflowBus.QueryEdgesByRuleID(ctx, rule.ID)
+		if err != nil {
+			return workflow.RuleDefinition{}, uuid.Nil, errs.Newf(errs.Internal, "query edges: %s", err)
+		}
+
+		return workflow.NewRuleDefinition(rule, actions, edges, nil), uuid.Nil, nil
+	}
+}
+
+// simulationRule resolves the names the trigger matches on and builds the
+// graph the simulator walks. Action types come from the action's template, or
+// from action_config when there is none, as they do when the rule runs.
+func (a *App) simulationRule(ctx context.Context, ruleID uuid.UUID, def workflow.RuleDefinition) (temporal.SimulationRule, error) {
+	entity, err := a.getEntityType(ctx, a.workflowBus, def.EntityID)
+	if err != nil {
+		return temporal.SimulationRule{}, err
+	}
+
+	triggerTypes, err := a.workflowBus.QueryTriggerTypes(ctx)
+	if err != nil {
+		return temporal.SimulationRule{}, errs.Newf(errs.Internal, "query trigger types: %s", err)
+	}
+
+	var triggerType string
+	for _, tt := range triggerTypes {
+		if tt.ID == def.TriggerTypeID {
+			triggerType = tt.Name
+			break
+		}
+	}
+
+	switch triggerType {
+	case workflow.EventTypeOnCreate, workflow.EventTypeOnUpdate, workflow.EventTypeOnDelete:
+	case "":
+		return temporal.SimulationRule{}, errs.Newf(errs.NotFound, "trigger type not found: %s", def.TriggerTypeID)
+	default:
+		return temporal.SimulationRule{}, errs.Newf(errs.FailedPrecondition, "only rules triggered by entity events can be simulated, this rule is triggered by %q", triggerType)
+	}
+
+	graph := temporal.GraphDefinition{
+		Actions: make([]temporal.ActionNode, len(def.Actions)),
+		Edges:   make([]temporal.ActionEdge, len(def.Edges)),
+	}
+
+	for i, action := range def.Actions {
+		actionType := workflow.ConfigActionType(action.ActionConfig)
+		if action.TemplateID != nil {
+			tmpl, err := a.workflowBus.QueryTemplateByID(ctx, *action.TemplateID)
+			if err != nil {
+				return temporal.SimulationRule{}, errs.Newf(errs.Internal, "query template for action %q: %s", action.Name, err)
+			}
+			actionType = tmpl.ActionType
+		}
+
+		graph.Actions[i] = temporal.ActionNode{
+			ID:          action.ID,
+			Name:        action.Name,
+			Description: action.Description,
+			ActionType:  actionType,
+			Config:      action.ActionConfig,
+			IsActive:    action.IsActive,
+		}
+	}
+
+	for i, edge := range def.Edges {
+		graph.Edges[i] = temporal.ActionEdge{
+			ID:             uuid.New(),
+			SourceActionID: edge.SourceActionID,
+			TargetActionID: edge.TargetActionID,
+			EdgeType:       edge.EdgeType,
+			SourceOutput:   edge.SourceOutput,
+			SortOrder:      edge.EdgeOrder,
+		}
+	}
+
+	return temporal.SimulationRule{
+		RuleID:            ruleID,
+		RuleName:          def.Name,
+		EntityName:        entity.Name,
+		TriggerType:       triggerType,
+		TriggerConditions: def.TriggerConditions,
+		Graph:             graph,
+	}, nil
+}
+
+// pastEvents reads past events from the cascade outbox history, oldest first.
+func (a *App) pastEvents(ctx context.Context, filter outbox.EventFilter) ([]workflow.TriggerEvent, error) {
+	rows, err := outbox.NewStore(a.log).QueryEvents(ctx, a.db, filter)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "query past events: %s", err)
+	}
+
+	events := make([]workflow.TriggerEvent, 0, len(rows))
+	for _, row := range rows {
+		event, err := temporal.OutboxEvent(row)
+		if err != nil {
+			a.log.Warn(ctx, "workflowsaveapp: skipping unreadable outbox row", "id", row.ID, "error", err)
+			continue
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// =============================================================================
+
+// simulationRange returns the window past events are read from.
+func simulationRange(req SimulateRequest, now time.Time) (time.Time, time.Time, error) {
+	until := now
+	if req.Until != "" {
+		t, err := time.Parse(time.RFC3339, req.Until)
+		if err != nil {
+			return time.Time{}, time.Time{}, errs.Newf(errs.InvalidArgument, "until: %s", err)
+		}
+		until = t
+	}
+
+	since := until.Add(-simulationWindow)
+	if req.Since != "" {
+		t, err := time.Parse(time.RFC3339, req.Since)
+		if err != nil {
+			return time.Time{}, time.Time{}, errs.Newf(errs.InvalidArgument, "since: %s", err)
+		}
+		since = t
+	}
+
+	if !since.Before(until) {
+		return time.Time{}, time.Time{}, errs.Newf(errs.InvalidArgument, "since %s is not before until %s", since.Format(time.RFC3339), until.Format(time.RFC3339))
+	}
+
+	return since, until, nil
+}
+
+// toSimulationEvents converts hand-written events into trigger events for the
+// rule's entity. Events without a timestamp happen now.
+func toSimulationEvents(entityName string, reqEvents []SimulateEvent, now time.Time) ([]workflow.TriggerEvent, error) {
+	events := make([]workflow.TriggerEvent, len(reqEvents))
+	for i, re := range reqEvents {
+		event := workflow.TriggerEvent{
+			EventType:    re.EventType,
+			EntityName:   entityName,
+			Timestamp:    now,
+			RawData:      re.RawData,
+			FieldChanges: re.FieldChanges,
+		}
+
+		if re.EntityID != "" {
+			id, err := uuid.Parse(re.EntityID)
+			if err != nil {
+				return nil, errs.Newf(errs.InvalidArgument, "events[%d].entity_id: %s", i, err)
+			}
+			event.EntityID = id
+		}
+
+		if re.Timestamp != "" {
+			t, err := time.Parse(time.RFC3339, re.Timestamp)
+			if err != nil {
+				return nil, errs.Newf(errs.InvalidArgument, "events[%d].timestamp: %s", i, err)
+			}
+			event.Timestamp = t
+		}
+
+		events[i] = event
+	}
+
+	return events, nil
+}
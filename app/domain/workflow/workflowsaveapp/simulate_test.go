@@ -0,0 +1,73 @@
+package workflowsaveapp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+)
+
+func TestSimulationRange(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	since, until, err := simulationRange(SimulateRequest{}, now)
+	if err != nil {
+		t.Fatalf("default range: %s", err)
+	}
+	if !until.Equal(now) || !since.Equal(now.Add(-simulationWindow)) {
+		t.Fatalf("default range = %s..%s, want the %s before %s", since, until, simulationWindow, now)
+	}
+
+	since, until, err = simulationRange(SimulateRequest{Until: "2026-03-01T00:00:00Z"}, now)
+	if err != nil {
+		t.Fatalf("until only: %s", err)
+	}
+	if want := time.Date(2026, 1, 30, 0, 0, 0, 0, time.UTC); !since.Equal(want) || until.Month() != time.March {
+		t.Fatalf("until only = %s..%s, want the window to end at until", since, until)
+	}
+
+	for name, req := range map[string]SimulateRequest{
+		"bad since": {Since: "yesterday"},
+		"bad until": {Until: "2026-03-01"},
+		"reversed":  {Since: "2026-03-02T00:00:00Z", Until: "2026-03-01T00:00:00Z"},
+	} {
+		if _, _, err := simulationRange(req, now); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestToSimulationEvents(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	entityID := uuid.New()
+
+	events, err := toSimulationEvents("orders", []SimulateEvent{
+		{
+			EventType:    workflow.EventTypeOnUpdate,
+			EntityID:     entityID.String(),
+			Timestamp:    "2026-03-30T08:00:00Z",
+			RawData:      map[string]any{"status": "shipped"},
+			FieldChanges: map[string]workflow.FieldChange{"status": {OldValue: "packed", NewValue: "shipped"}},
+		},
+		{EventType: workflow.EventTypeOnCreate},
+	}, now)
+	if err != nil {
+		t.Fatalf("convert: %s", err)
+	}
+
+	first := events[0]
+	if first.EntityName != "orders" || first.EntityID != entityID || first.EventType != workflow.EventTypeOnUpdate {
+		t.Fatalf("first event = %+v, want the rule's entity and the given id and type", first)
+	}
+	if first.Timestamp.Day() != 30 || first.FieldChanges["status"].NewValue != "shipped" {
+		t.Fatalf("first event = %+v, want the given timestamp and field changes", first)
+	}
+	if !events[1].Timestamp.Equal(now) {
+		t.Fatalf("second event timestamp = %s, want now", events[1].Timestamp)
+	}
+
+	if _, err := toSimulationEvents("orders", []SimulateEvent{{EventType: workflow.EventTypeOnCreate, Timestamp: "noon"}}, now); err == nil {
+		t.Fatal("expected an error for a bad timestamp")
+	}
+}
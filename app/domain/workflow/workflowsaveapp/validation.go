@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/google/uuid"
+
 	"github.com/timmaaaz/ichor/business/sdk/workflow"
 )
 
@@ -66,6 +68,7 @@ func validateOutputPorts(actions []SaveActionRequest, edges []SaveEdgeRequest, r
 // Action type constants
 const (
 	ActionTypeAllocateInventory   = "allocate_inventory"
+	ActionTypeCallWorkflow        = "call_workflow"
 	ActionTypeCheckInventory      = "check_inventory"
 	ActionTypeCheckReorderPoint   = "check_reorder_point"
 	ActionTypeCommitAllocation    = "commit_allocation"
@@ -73,6 +76,7 @@ const (
 	ActionTypeCreateEntity        = "create_entity"
 	ActionTypeDelay               = "delay"
 	ActionTypeEvaluateCondition   = "evaluate_condition"
+	ActionTypeForEach             = "for_each"
 	ActionTypeLogAuditEntry       = "log_audit_entry"
 	ActionTypeLookupEntity        = "lookup_entity"
 	ActionTypeReleaseReservation  = "release_reservation"
@@ -91,6 +95,9 @@ func ValidateActionConfigs(actions []SaveActionRequest) error {
 		if err := validateActionConfig(action.ActionType, action.ActionConfig); err != nil {
 			return fmt.Errorf("action[%d] (%s): %w", i, action.Name, err)
 		}
+		if err := workflow.ValidateTemplateExprs(action.ActionConfig); err != nil {
+			return fmt.Errorf("action[%d] (%s): %w", i, action.Name, err)
+		}
 	}
 	return nil
 }
@@ -115,6 +122,10 @@ func validateActionConfig(actionType string, config json.RawMessage) error {
 		return validateAllocateInventoryConfig(config)
 	case ActionTypeEvaluateCondition:
 		return validateEvaluateConditionConfig(config)
+	case ActionTypeForEach:
+		return validateForEachConfig(config)
+	case ActionTypeCallWorkflow:
+		return validateCallWorkflowConfig(config)
 	case ActionTypeCheckInventory,
 		ActionTypeCheckReorderPoint,
 		ActionTypeCommitAllocation,
@@ -228,8 +239,15 @@ func validateUpdateFieldConfig(config json.RawMessage) error {
 
 // SeekApprovalConfig defines the required fields for seek_approval action.
 type SeekApprovalConfig struct {
-	Approvers    []string `json:"approvers"`
-	ApprovalType string   `json:"approval_type"`
+	Approvers     []string `json:"approvers"`
+	ApprovalType  string   `json:"approval_type"`
+	TimeoutHours  int      `json:"timeout_hours"`
+	TimeoutAction string   `json:"timeout_action"`
+	Escalation    *struct {
+		AfterHours int    `json:"after_hours"`
+		To         string `json:"to"`
+		Role       string `json:"role"`
+	} `json:"escalation"`
 }
 
 func validateSeekApprovalConfig(config json.RawMessage) error {
@@ -243,6 +261,32 @@ func validateSeekApprovalConfig(config json.RawMessage) error {
 	if c.ApprovalType == "" {
 		return fmt.Errorf("approval_type is required")
 	}
+	// Timeout/escalation checks match the runtime validation in
+	// workflowactions/approval/seek.go.
+	if c.TimeoutHours < 0 || c.TimeoutHours > 168 {
+		return fmt.Errorf("timeout_hours must be between 1 and 168")
+	}
+	if c.TimeoutAction != "" && c.TimeoutAction != "approve" && c.TimeoutAction != "reject" {
+		return fmt.Errorf("timeout_action must be approve or reject")
+	}
+	if c.Escalation != nil {
+		timeout := c.TimeoutHours
+		if timeout == 0 {
+			timeout = 72
+		}
+		if c.Escalation.AfterHours <= 0 || c.Escalation.AfterHours >= timeout {
+			return fmt.Errorf("escalation.after_hours must be positive and less than timeout_hours (%d)", timeout)
+		}
+		switch c.Escalation.To {
+		case "manager":
+		case "role":
+			if c.Escalation.Role == "" {
+				return fmt.Errorf("escalation.role is required when escalation.to is role")
+			}
+		default:
+			return fmt.Errorf("escalation.to must be manager or role")
+		}
+	}
 	return nil
 }
 
@@ -271,7 +315,8 @@ func validateAllocateInventoryConfig(config json.RawMessage) error {
 
 // EvaluateConditionConfig defines the required fields for evaluate_condition action.
 type EvaluateConditionConfig struct {
-	Conditions []any `json:"conditions"`
+	Conditions []any  `json:"conditions"`
+	Expression string `json:"expression"`
 }
 
 func validateEvaluateConditionConfig(config json.RawMessage) error {
@@ -279,8 +324,63 @@ func validateEvaluateConditionConfig(config json.RawMessage) error {
 	if err := json.Unmarshal(config, &c); err != nil {
 		return fmt.Errorf("invalid config JSON: %w", err)
 	}
+	if c.Expression != "" {
+		if len(c.Conditions) > 0 {
+			return fmt.Errorf("set either conditions or expression, not both")
+		}
+		if _, err := workflow.ParseExpr(c.Expression); err != nil {
+			return fmt.Errorf("expression: %w", err)
+		}
+		return nil
+	}
 	if len(c.Conditions) == 0 {
 		return fmt.Errorf("conditions is required")
 	}
+	// Operator and field checks run in the handler's Validate, see
+	// workflowactions/control/condition.go.
+	return nil
+}
+
+// ForEachConfig defines the required fields for for_each action.
+type ForEachConfig struct {
+	Items json.RawMessage `json:"items"`
+	Mode  string          `json:"mode"`
+}
+
+func validateForEachConfig(config json.RawMessage) error {
+	var c ForEachConfig
+	if err := json.Unmarshal(config, &c); err != nil {
+		return fmt.Errorf("invalid config JSON: %w", err)
+	}
+	if len(c.Items) == 0 {
+		return fmt.Errorf("items is required")
+	}
+	// Item reference and concurrency checks run in the handler's Validate,
+	// see workflowactions/control/foreach.go.
+	if c.Mode != "" && c.Mode != "sequential" && c.Mode != "parallel" {
+		return fmt.Errorf("mode must be sequential or parallel")
+	}
+	return nil
+}
+
+// CallWorkflowConfig defines the required fields for call_workflow action.
+type CallWorkflowConfig struct {
+	RuleID string `json:"rule_id"`
+}
+
+func validateCallWorkflowConfig(config json.RawMessage) error {
+	var c CallWorkflowConfig
+	if err := json.Unmarshal(config, &c); err != nil {
+		return fmt.Errorf("invalid config JSON: %w", err)
+	}
+	if c.RuleID == "" {
+		return fmt.Errorf("rule_id is required")
+	}
+	// Input and output name checks run in the handler's Validate, see
+	// workflowactions/control/callworkflow.go. Call cycles are reported by
+	// cascade detection.
+	if _, err := uuid.Parse(c.RuleID); err != nil {
+		return fmt.Errorf("rule_id must be a valid UUID")
+	}
 	return nil
 }
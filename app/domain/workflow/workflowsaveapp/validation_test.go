@@ -106,6 +106,11 @@ func TestValidateActionConfig_SeekApproval(t *testing.T) {
 		{"empty approvers", `{"approvers":[],"approval_type":"manager"}`, "approvers is required"},
 		{"missing approval_type", `{"approvers":["role1"]}`, "approval_type is required"},
 		{"invalid json", `{bad`, "invalid config JSON"},
+		{"valid escalation", `{"approvers":["role1"],"approval_type":"any","timeout_hours":48,"escalation":{"after_hours":24,"to":"manager"},"timeout_action":"approve"}`, ""},
+		{"timeout too long", `{"approvers":["role1"],"approval_type":"any","timeout_hours":500}`, "timeout_hours must be between"},
+		{"bad timeout_action", `{"approvers":["role1"],"approval_type":"any","timeout_action":"ignore"}`, "timeout_action must be"},
+		{"escalation after timeout", `{"approvers":["role1"],"approval_type":"any","escalation":{"after_hours":72,"to":"manager"}}`, "escalation.after_hours"},
+		{"escalation role missing", `{"approvers":["role1"],"approval_type":"any","escalation":{"after_hours":4,"to":"role"}}`, "escalation.role is required"},
 	}
 
 	for _, tt := range tests {
@@ -146,6 +151,9 @@ func TestValidateActionConfig_EvaluateCondition(t *testing.T) {
 		{"valid", `{"conditions":[{"field":"status","op":"equals","value":"active"}]}`, ""},
 		{"empty conditions", `{"conditions":[]}`, "conditions is required"},
 		{"missing conditions", `{}`, "conditions is required"},
+		{"expression", `{"expression":"status == 'active' and any(lines, .quantity > 10)"}`, ""},
+		{"bad expression", `{"expression":"status = 'active'"}`, "position 7"},
+		{"conditions and expression", `{"conditions":[{"field_name":"status","operator":"equals","value":"x"}],"expression":"true"}`, "not both"},
 		{"invalid json", `{bad`, "invalid config JSON"},
 	}
 
@@ -349,6 +357,79 @@ func TestValidateOutputPorts_UnknownSourceAction(t *testing.T) {
 	}
 }
 
+func TestSaveWorkflowRequest_ValidateSchedule(t *testing.T) {
+	base := SaveWorkflowRequest{
+		Name:          "nightly",
+		EntityID:      "5b1a1f0e-4b3f-4f5e-8b61-0c3a6a3f2e11",
+		TriggerTypeID: "9d6e3c1a-2f4b-4c8d-a1e5-7b0f3d2c6e44",
+	}
+
+	tests := []struct {
+		name     string
+		schedule *SaveScheduleRequest
+		wantErr  string
+	}{
+		{"no schedule", nil, ""},
+		{"cron", &SaveScheduleRequest{CronExpression: "0 2 * * *", Timezone: "America/Chicago"}, ""},
+		{"interval", &SaveScheduleRequest{IntervalSeconds: 3600}, ""},
+		{"empty", &SaveScheduleRequest{}, "exactly one of cron_expression or interval_seconds"},
+		{"interval too short", &SaveScheduleRequest{IntervalSeconds: 10}, "below the minimum"},
+		{"bad cron", &SaveScheduleRequest{CronExpression: "nightly"}, "invalid cron expression"},
+		{"bad timezone", &SaveScheduleRequest{CronExpression: "@daily", Timezone: "Nowhere/City"}, "invalid schedule timezone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := base
+			req.Schedule = tt.schedule
+			assertValidationError(t, req.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestSaveWorkflowRequest_ValidateTriggerConditions(t *testing.T) {
+	base := SaveWorkflowRequest{
+		Name:          "late orders",
+		EntityID:      "5b1a1f0e-4b3f-4f5e-8b61-0c3a6a3f2e11",
+		TriggerTypeID: "9d6e3c1a-2f4b-4c8d-a1e5-7b0f3d2c6e44",
+	}
+
+	tests := []struct {
+		name       string
+		conditions string
+		wantErr    string
+	}{
+		{"none", ``, ""},
+		{"field conditions", `{"field_conditions":[{"field_name":"status","operator":"changed_to","value":"shipped"}]}`, ""},
+		{"expression", `{"expression":"due_date < now() + 2d and (priority ?? 0) > 3"}`, ""},
+		{"expression condition", `{"field_conditions":[{"operator":"expression","expression":"any(line_items, .quantity > 10)"}]}`, ""},
+		{"unknown operator", `{"field_conditions":[{"field_name":"status","operator":"starts_with","value":"x"}]}`, "invalid operator"},
+		{"missing field", `{"field_conditions":[{"operator":"equals","value":"x"}]}`, "field_name is required"},
+		{"bad expression", `{"expression":"due_date < now( + 2d"}`, "position 16"},
+		{"unknown function", `{"expression":"lenght(name) > 3"}`, `position 0: unknown function "lenght"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := base
+			req.TriggerConditions = json.RawMessage(tt.conditions)
+			assertValidationError(t, req.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateActionConfigs_TemplateExpressions(t *testing.T) {
+	actions := []SaveActionRequest{{
+		Name:         "audit",
+		ActionType:   ActionTypeLogAuditEntry,
+		ActionConfig: json.RawMessage(`{"message":"Total {{expr: sum(lines, .qty * .price) | currency:USD}}"}`),
+	}}
+	assertValidationError(t, ValidateActionConfigs(actions), "")
+
+	actions[0].ActionConfig = json.RawMessage(`{"message":"Total {{expr: sum(lines, .qty *) }}"}`)
+	assertValidationError(t, ValidateActionConfigs(actions), "position")
+}
+
 // assertValidationError checks that an error matches expectations.
 func assertValidationError(t *testing.T, err error, wantErr string) {
 	t.Helper()
@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -161,6 +162,10 @@ func (a *App) DryRunValidate(ctx context.Context, ruleID uuid.UUID, req SaveWork
 		}
 	}
 
+	if err := a.checkSchedule(ctx, req); err != nil {
+		validationErrors = append(validationErrors, err.Error())
+	}
+
 	// Static cascade-loop analysis (active-only; no-op for draft/inactive rules). Provable
 	// loops fail validation; warnings/info are attached for the editor without blocking.
 	var cascade *workflow.CascadeAnalysis
@@ -183,12 +188,17 @@ func (a *App) DryRunValidate(ctx context.Context, ruleID uuid.UUID, req SaveWork
 }
 
 // SaveWorkflow updates an existing workflow atomically (rule + actions + edges).
-// This performs all operations within a single database transaction.
-func (a *App) SaveWorkflow(ctx context.Context, ruleID uuid.UUID, req SaveWorkflowRequest) (SaveWorkflowResponse, error) {
+// This performs all operations within a single database transaction, which
+// also records the result as the rule's newly published revision.
+func (a *App) SaveWorkflow(ctx context.Context, ruleID uuid.UUID, userID uuid.UUID, req SaveWorkflowRequest) (SaveWorkflowResponse, error) {
 	if err := a.prepareRequest(&req); err != nil {
 		return SaveWorkflowResponse{}, err
 	}
 
+	if err := a.checkSchedule(ctx, req); err != nil {
+		return SaveWorkflowResponse{}, err
+	}
+
 	// Block provable cascade loops before touching the DB (active-only; no-op for drafts).
 	if err := a.enforceCascades(ctx, ruleID, req); err != nil {
 		return SaveWorkflowResponse{}, err
@@ -231,12 +241,24 @@ func (a *App) SaveWorkflow(ctx context.Context, ruleID uuid.UUID, req SaveWorkfl
 		return SaveWorkflowResponse{}, err
 	}
 
-	// 9. Commit transaction
+	// 9. Upsert or remove the schedule
+	schedule, err := a.syncSchedule(ctx, txBus, ruleID, req.Schedule)
+	if err != nil {
+		return SaveWorkflowResponse{}, err
+	}
+
+	// 10. Snapshot the saved rule as its published revision
+	rev, err := a.recordRevision(ctx, txBus, userID, req.RevisionNotes, rule, savedActions, savedEdges, schedule)
+	if err != nil {
+		return SaveWorkflowResponse{}, err
+	}
+
+	// 11. Commit transaction
 	if err := tx.Commit(); err != nil {
 		return SaveWorkflowResponse{}, errs.Newf(errs.Internal, "commit: %s", err)
 	}
 
-	// 10. Fire delegate event AFTER commit to invalidate cache
+	// 12. Fire delegate event AFTER commit to invalidate cache
 	if a.delegate != nil {
 		a.log.Info(ctx, "workflowsaveapp: transaction committed, firing delegate event", "ruleID", ruleID, "action", "updated")
 		if err := a.delegate.Call(ctx, workflow.ActionRuleChangedData(workflow.ActionRuleUpdated, ruleID)); err != nil {
@@ -244,7 +266,11 @@ func (a *App) SaveWorkflow(ctx context.Context, ruleID uuid.UUID, req SaveWorkfl
 		}
 	}
 
-	return buildResponse(rule, savedActions, savedEdges, req.CanvasLayout), nil
+	resp := buildResponse(rule, savedActions, savedEdges, req.CanvasLayout, schedule)
+	resp.RevisionID = rev.ID.String()
+	resp.RevisionNumber = rev.RevisionNumber
+
+	return resp, nil
 }
 
 // CreateWorkflow creates a new workflow atomically (rule + actions + edges).
@@ -254,6 +280,10 @@ func (a *App) CreateWorkflow(ctx context.Context, userID uuid.UUID, req SaveWork
 		return SaveWorkflowResponse{}, err
 	}
 
+	if err := a.checkSchedule(ctx, req); err != nil {
+		return SaveWorkflowResponse{}, err
+	}
+
 	// Block provable cascade loops before touching the DB (uuid.Nil = brand-new candidate).
 	if err := a.enforceCascades(ctx, uuid.Nil, req); err != nil {
 		return SaveWorkflowResponse{}, err
@@ -292,12 +322,24 @@ func (a *App) CreateWorkflow(ctx context.Context, userID uuid.UUID, req SaveWork
 		return SaveWorkflowResponse{}, err
 	}
 
-	// 9. Commit transaction
+	// 9. Create the schedule (scheduled trigger type only)
+	schedule, err := a.syncSchedule(ctx, txBus, rule.ID, req.Schedule)
+	if err != nil {
+		return SaveWorkflowResponse{}, err
+	}
+
+	// 10. Snapshot the new rule as its first published revision
+	rev, err := a.recordRevision(ctx, txBus, userID, req.RevisionNotes, rule, savedActions, savedEdges, schedule)
+	if err != nil {
+		return SaveWorkflowResponse{}, err
+	}
+
+	// 11. Commit transaction
 	if err := tx.Commit(); err != nil {
 		return SaveWorkflowResponse{}, errs.Newf(errs.Internal, "commit: %s", err)
 	}
 
-	// 10. Fire delegate event AFTER commit to invalidate cache
+	// 12. Fire delegate event AFTER commit to invalidate cache
 	if a.delegate != nil {
 		a.log.Info(ctx, "workflowsaveapp: transaction committed, firing delegate event", "ruleID", rule.ID, "action", "created")
 		if err := a.delegate.Call(ctx, workflow.ActionRuleChangedData(workflow.ActionRuleCreated, rule.ID)); err != nil {
@@ -305,7 +347,94 @@ func (a *App) CreateWorkflow(ctx context.Context, userID uuid.UUID, req SaveWork
 		}
 	}
 
-	return buildResponse(rule, savedActions, savedEdges, req.CanvasLayout), nil
+	resp := buildResponse(rule, savedActions, savedEdges, req.CanvasLayout, schedule)
+	resp.RevisionID = rev.ID.String()
+	resp.RevisionNumber = rev.RevisionNumber
+
+	return resp, nil
+}
+
+// checkSchedule enforces that a schedule is supplied exactly when the request's
+// trigger type is "scheduled".
+func (a *App) checkSchedule(ctx context.Context, req SaveWorkflowRequest) error {
+	scheduled, err := a.isScheduledTrigger(ctx, req.TriggerTypeID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case scheduled && req.Schedule == nil:
+		return errs.Newf(errs.InvalidArgument, "schedule: required for the %q trigger type", workflow.EventTypeScheduled)
+	case !scheduled && req.Schedule != nil:
+		return errs.Newf(errs.InvalidArgument, "schedule: only valid for the %q trigger type", workflow.EventTypeScheduled)
+	}
+
+	return nil
+}
+
+// isScheduledTrigger reports whether triggerTypeID names the "scheduled"
+// trigger type. An unknown id is not an error here; the rule FK rejects it.
+func (a *App) isScheduledTrigger(ctx context.Context, triggerTypeID string) (bool, error) {
+	id, err := uuid.Parse(triggerTypeID)
+	if err != nil {
+		return false, errs.Newf(errs.InvalidArgument, "invalid trigger_type_id: %s", err)
+	}
+
+	triggerTypes, err := a.workflowBus.QueryTriggerTypes(ctx)
+	if err != nil {
+		return false, errs.Newf(errs.Internal, "query trigger types: %s", err)
+	}
+
+	for _, tt := range triggerTypes {
+		if tt.ID == id {
+			return tt.Name == workflow.EventTypeScheduled, nil
+		}
+	}
+
+	return false, nil
+}
+
+// syncSchedule upserts the rule's schedule, or removes it when the request no
+// longer carries one (the rule moved off the "scheduled" trigger type).
+func (a *App) syncSchedule(ctx context.Context, bus *workflow.Business, ruleID uuid.UUID, req *SaveScheduleRequest) (*workflow.RuleSchedule, error) {
+	existing, err := bus.QueryRuleScheduleByRuleID(ctx, ruleID)
+	hasExisting := err == nil
+	if err != nil && !errors.Is(err, workflow.ErrNotFound) {
+		return nil, errs.Newf(errs.Internal, "query schedule: %s", err)
+	}
+
+	if req == nil {
+		if hasExisting {
+			if err := bus.DeleteRuleSchedule(ctx, existing); err != nil {
+				return nil, errs.Newf(errs.Internal, "delete schedule: %s", err)
+			}
+		}
+		return nil, nil
+	}
+
+	if hasExisting {
+		updated, err := bus.UpdateRuleSchedule(ctx, existing, workflow.UpdateRuleSchedule{
+			CronExpression:  &req.CronExpression,
+			IntervalSeconds: &req.IntervalSeconds,
+			Timezone:        &req.Timezone,
+		})
+		if err != nil {
+			return nil, errs.Newf(errs.Internal, "update schedule: %s", err)
+		}
+		return &updated, nil
+	}
+
+	created, err := bus.CreateRuleSchedule(ctx, workflow.NewRuleSchedule{
+		RuleID:          ruleID,
+		CronExpression:  req.CronExpression,
+		IntervalSeconds: req.IntervalSeconds,
+		Timezone:        req.Timezone,
+	})
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "create schedule: %s", err)
+	}
+
+	return &created, nil
 }
 
 // updateRule updates the automation rule metadata.
@@ -697,7 +826,20 @@ func (a *App) DuplicateWorkflow(ctx context.Context, ruleID uuid.UUID, userID uu
 		return SaveWorkflowResponse{}, errs.Newf(errs.Internal, "query edges: %s", err)
 	}
 
-	// 4. Begin transaction
+	// 4. Fetch schedule (scheduled rules only)
+	var srcSchedule *SaveScheduleRequest
+	switch rs, err := a.workflowBus.QueryRuleScheduleByRuleID(ctx, ruleID); {
+	case err == nil:
+		srcSchedule = &SaveScheduleRequest{
+			CronExpression:  rs.CronExpression,
+			IntervalSeconds: rs.IntervalSeconds,
+			Timezone:        rs.Timezone,
+		}
+	case !errors.Is(err, workflow.ErrNotFound):
+		return SaveWorkflowResponse{}, errs.Newf(errs.Internal, "query schedule: %s", err)
+	}
+
+	// 5. Begin transaction
 	tx, err := a.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
 	if err != nil {
 		return SaveWorkflowResponse{}, errs.Newf(errs.Internal, "begin tx: %s", err)
@@ -711,7 +853,7 @@ func (a *App) DuplicateWorkflow(ctx context.Context, ruleID uuid.UUID, userID uu
 		return SaveWorkflowResponse{}, errs.Newf(errs.Internal, "new with tx: %s", err)
 	}
 
-	// 5. Create duplicate rule
+	// 6. Create duplicate rule
 	newRule := workflow.NewAutomationRule{
 		Name:              sourceRule.Name + "-DUPLICATE",
 		Description:       sourceRule.Description,
@@ -730,7 +872,7 @@ func (a *App) DuplicateWorkflow(ctx context.Context, ruleID uuid.UUID, userID uu
 		return SaveWorkflowResponse{}, errs.Newf(errs.Internal, "create rule: %s", err)
 	}
 
-	// 6. Create actions and build oldID→newID map
+	// 7. Create actions and build oldID→newID map
 	oldToNewID := make(map[uuid.UUID]uuid.UUID)
 	var savedActions []workflow.RuleAction
 
@@ -753,7 +895,7 @@ func (a *App) DuplicateWorkflow(ctx context.Context, ruleID uuid.UUID, userID uu
 		savedActions = append(savedActions, created)
 	}
 
-	// 7. Create edges, remapping IDs; skip edges whose source or target was inactive
+	// 8. Create edges, remapping IDs; skip edges whose source or target was inactive
 	var savedEdges []workflow.ActionEdge
 
 	for _, edge := range edges {
@@ -790,12 +932,24 @@ func (a *App) DuplicateWorkflow(ctx context.Context, ruleID uuid.UUID, userID uu
 		savedEdges = append(savedEdges, created)
 	}
 
-	// 8. Commit transaction
+	// 9. Copy the schedule; the duplicate is inactive, so it will not fire until enabled
+	schedule, err := a.syncSchedule(ctx, txBus, rule.ID, srcSchedule)
+	if err != nil {
+		return SaveWorkflowResponse{}, err
+	}
+
+	// 10. Snapshot the duplicate as its first published revision
+	rev, err := a.recordRevision(ctx, txBus, userID, fmt.Sprintf("duplicate of %s", sourceRule.Name), rule, savedActions, savedEdges, schedule)
+	if err != nil {
+		return SaveWorkflowResponse{}, err
+	}
+
+	// 11. Commit transaction
 	if err := tx.Commit(); err != nil {
 		return SaveWorkflowResponse{}, errs.Newf(errs.Internal, "commit: %s", err)
 	}
 
-	// 9. Fire delegate event AFTER commit
+	// 12. Fire delegate event AFTER commit
 	if a.delegate != nil {
 		a.log.Info(ctx, "workflowsaveapp: duplicate committed, firing delegate event", "ruleID", rule.ID, "action", "created")
 		if err := a.delegate.Call(ctx, workflow.ActionRuleChangedData(workflow.ActionRuleCreated, rule.ID)); err != nil {
@@ -803,12 +957,16 @@ func (a *App) DuplicateWorkflow(ctx context.Context, ruleID uuid.UUID, userID uu
 		}
 	}
 
-	// 10. Return response
-	return buildResponse(rule, savedActions, savedEdges, sourceRule.CanvasLayout), nil
+	// 13. Return response
+	resp := buildResponse(rule, savedActions, savedEdges, sourceRule.CanvasLayout, schedule)
+	resp.RevisionID = rev.ID.String()
+	resp.RevisionNumber = rev.RevisionNumber
+
+	return resp, nil
 }
 
 // buildResponse constructs the SaveWorkflowResponse from business layer objects.
-func buildResponse(rule workflow.AutomationRule, actions []workflow.RuleAction, edges []workflow.ActionEdge, canvasLayout json.RawMessage) SaveWorkflowResponse {
+func buildResponse(rule workflow.AutomationRule, actions []workflow.RuleAction, edges []workflow.ActionEdge, canvasLayout json.RawMessage, schedule *workflow.RuleSchedule) SaveWorkflowResponse {
 	// Convert actions
 	actionResponses := make([]SaveActionResponse, len(actions))
 	for i, action := range actions {
@@ -857,6 +1015,20 @@ func buildResponse(rule workflow.AutomationRule, actions []workflow.RuleAction,
 		layout = rule.CanvasLayout
 	}
 
+	var scheduleResponse *SaveScheduleResponse
+	if schedule != nil {
+		scheduleResponse = &SaveScheduleResponse{
+			ID:              schedule.ID.String(),
+			CronExpression:  schedule.CronExpression,
+			IntervalSeconds: schedule.IntervalSeconds,
+			Timezone:        schedule.Timezone,
+			NextRunAt:       schedule.NextRunAt.Format(time.RFC3339),
+		}
+		if schedule.LastRunAt != nil {
+			scheduleResponse.LastRunAt = schedule.LastRunAt.Format(time.RFC3339)
+		}
+	}
+
 	return SaveWorkflowResponse{
 		ID:                rule.ID.String(),
 		Name:              rule.Name,
@@ -868,6 +1040,7 @@ func buildResponse(rule workflow.AutomationRule, actions []workflow.RuleAction,
 		Actions:           actionResponses,
 		Edges:             edgeResponses,
 		CanvasLayout:      layout,
+		Schedule:          scheduleResponse,
 		CreatedDate:       rule.CreatedDate.Format(time.RFC3339),
 		UpdatedDate:       rule.UpdatedDate.Format(time.RFC3339),
 	}
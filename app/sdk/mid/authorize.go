@@ -14,6 +14,7 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/core/permissionsbus"
 	"github.com/timmaaaz/ichor/business/domain/core/userbus"
 	"github.com/timmaaaz/ichor/business/domain/hr/homebus"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
 )
 
 // ErrInvalidID represents a condition where the id is not a uuid.
@@ -74,6 +75,19 @@ func AuthorizeTable(ctx context.Context, client *authclient.Client, permissionsB
 		return errs.New(errs.PermissionDenied, fmt.Errorf("user does not have permission %s for table: %s", tableInfo.Action, tableInfo.Name))
 	}
 
+	// Carry the caller's column restrictions: the authorized table's for
+	// RestrictFields, and every table's hidden columns for generic readers
+	// such as the table builder that may join other tables.
+	ctx = setTableInfo(ctx, tableInfo)
+	ctx = setColumnRestrictions(ctx, columnRestrictions(perms, tableInfo.Name))
+	ctx = sqldb.SetHiddenColumns(ctx, hiddenColumns(perms))
+
+	// Limit warehouse and zone bound reads to the caller's data scope.
+	ctx = sqldb.SetDataScope(ctx, sqldb.DataScope{
+		WarehouseIDs: perms.DataScope.WarehouseIDs,
+		ZoneIDs:      perms.DataScope.ZoneIDs,
+	})
+
 	return next(ctx)
 }
 
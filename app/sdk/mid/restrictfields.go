@@ -1,11 +1,199 @@
 package mid
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+	"github.com/timmaaaz/ichor/business/domain/core/permissionsbus"
 )
 
-func RestrictFields(ctx context.Context, next HandlerFunc) Encoder {
+// ColumnRestrictions are the columns of the authorized table the caller may
+// not read (Hidden) or may read but not write (ReadOnly).
+type ColumnRestrictions struct {
+	Table    string
+	Hidden   []string
+	ReadOnly []string
+}
+
+// unwritable returns the fields that may not be written, in request order.
+// Fields are JSON names; restrictions name columns.
+func (cr ColumnRestrictions) unwritable(fields []string) []string {
+	hidden := fieldNames(cr.Table, cr.Hidden)
+	readOnly := fieldNames(cr.Table, cr.ReadOnly)
+
+	var denied []string
+	for _, f := range fields {
+		if slices.Contains(hidden, f) || slices.Contains(readOnly, f) {
+			denied = append(denied, f)
+		}
+	}
+	return denied
+}
+
+// =============================================================================
+
+var columnFields = struct {
+	sync.RWMutex
+	tables map[string]map[string]string
+}{
+	tables: make(map[string]map[string]string),
+}
+
+// RegisterFieldNames records the JSON field names a table's API uses for
+// columns whose field name differs from the column name, e.g. "id" served as
+// "cost_id". Columns not listed are served under their own name.
+func RegisterFieldNames(table string, fields map[string]string) {
+	columnFields.Lock()
+	defer columnFields.Unlock()
+
+	columnFields.tables[strings.ToLower(table)] = fields
+}
+
+// fieldNames returns the JSON field names of the table's columns.
+func fieldNames(table string, columns []string) []string {
+	columnFields.RLock()
+	defer columnFields.RUnlock()
+
+	names := columnFields.tables[strings.ToLower(table)]
+
+	fields := make([]string, len(columns))
+	for i, c := range columns {
+		fields[i] = c
+		if f, ok := names[c]; ok {
+			fields[i] = f
+		}
+	}
+	return fields
+}
+
+// =============================================================================
+
+func setColumnRestrictions(ctx context.Context, cr ColumnRestrictions) context.Context {
+	return context.WithValue(ctx, restrictedColumnKey, cr)
+}
+
+// GetColumnRestrictions returns the column restrictions of the table
+// authorized by AuthorizeTable and ok=false when the table has none.
+func GetColumnRestrictions(ctx context.Context) (ColumnRestrictions, bool) {
+	v, ok := ctx.Value(restrictedColumnKey).(ColumnRestrictions)
+	if !ok || len(v.Hidden)+len(v.ReadOnly) == 0 {
+		return ColumnRestrictions{}, false
+	}
+	return v, true
+}
+
+// columnRestrictions returns the restrictions of table from the caller's
+// combined table access.
+func columnRestrictions(perms permissionsbus.UserPermissions, table string) ColumnRestrictions {
+	cr := ColumnRestrictions{Table: table}
+	for _, ta := range perms.TableAccess {
+		if strings.EqualFold(ta.TableName, table) {
+			cr.Hidden = ta.HiddenColumns
+			cr.ReadOnly = ta.ReadOnlyColumns
+			break
+		}
+	}
+	return cr
+}
+
+// hiddenColumns returns the hidden columns of every table the caller has
+// access to, keyed by table name.
+func hiddenColumns(perms permissionsbus.UserPermissions) map[string][]string {
+	hidden := make(map[string][]string)
+	for _, ta := range perms.TableAccess {
+		if len(ta.HiddenColumns) > 0 {
+			hidden[ta.TableName] = ta.HiddenColumns
+		}
+	}
+	return hidden
+}
+
+// RestrictFields enforces the column restrictions set by AuthorizeTable.
+// A request writing any of fields that is hidden or read-only is denied
+// with the offending fields listed; otherwise hidden columns are removed from
+// the response before it is encoded. Only the authorized table's own rows
+// are masked: the response object, the items of a page, or the elements of
+// an array. Nested objects belong to other tables and are left alone.
+func RestrictFields(ctx context.Context, fields []string, next HandlerFunc) Encoder {
+	cr, ok := GetColumnRestrictions(ctx)
+	if !ok {
+		return next(ctx)
+	}
+
+	if denied := cr.unwritable(fields); len(denied) > 0 {
+		return errs.Newf(errs.PermissionDenied, "fields of %s cannot be written: %s", cr.Table, strings.Join(denied, ", "))
+	}
+
 	resp := next(ctx)
+	if resp == nil || len(cr.Hidden) == 0 || isError(resp) != nil {
+		return resp
+	}
+
+	return maskedEncoder{enc: resp, hidden: fieldNames(cr.Table, cr.Hidden)}
+}
+
+// maskedEncoder encodes a response without the hidden fields.
+type maskedEncoder struct {
+	enc    Encoder
+	hidden []string
+}
+
+func (m maskedEncoder) Encode() ([]byte, string, error) {
+	data, contentType, err := m.enc.Encode()
+	if err != nil || !strings.HasPrefix(contentType, "application/json") {
+		return data, contentType, err
+	}
+
+	// Decode numbers as json.Number so re-encoding does not change them.
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, "", fmt.Errorf("restrict fields: decode: %w", err)
+	}
+
+	masked, err := json.Marshal(stripFields(v, m.hidden))
+	if err != nil {
+		return nil, "", fmt.Errorf("restrict fields: encode: %w", err)
+	}
+
+	return masked, contentType, nil
+}
+
+// stripFields removes the hidden keys from the rows in v: v itself when it
+// is an object, each element when it is an array, and each element of its
+// items when it is a page.
+func stripFields(v any, hidden []string) any {
+	switch t := v.(type) {
+	case map[string]any:
+		if items, ok := t["items"].([]any); ok {
+			stripRows(items, hidden)
+			break
+		}
+		stripRow(t, hidden)
+	case []any:
+		stripRows(t, hidden)
+	}
+	return v
+}
+
+func stripRows(rows []any, hidden []string) {
+	for _, row := range rows {
+		if m, ok := row.(map[string]any); ok {
+			stripRow(m, hidden)
+		}
+	}
+}
 
-	return resp
+func stripRow(row map[string]any, hidden []string) {
+	for _, h := range hidden {
+		delete(row, h)
+	}
 }
@@ -0,0 +1,168 @@
+package mid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/timmaaaz/ichor/app/sdk/errs"
+)
+
+type rawJSON string
+
+func (r rawJSON) Encode() ([]byte, string, error) {
+	return []byte(r), "application/json", nil
+}
+
+func costRestrictions() ColumnRestrictions {
+	return ColumnRestrictions{
+		Table:    "products.product_costs",
+		Hidden:   []string{"purchase_cost", "landed_cost"},
+		ReadOnly: []string{"currency_id"},
+	}
+}
+
+func TestRestrictFields_MasksHiddenFields(t *testing.T) {
+	ctx := setColumnRestrictions(context.Background(), costRestrictions())
+
+	next := func(ctx context.Context) Encoder {
+		return rawJSON(`{"items":[{"id":"a","purchase_cost":"9.50","selling_price":12.75,"landed_cost":"10.10"}],"total":1}`)
+	}
+
+	resp := RestrictFields(ctx, nil, next)
+	if err := isError(resp); err != nil {
+		t.Fatalf("unexpected error response: %v", err)
+	}
+
+	data, contentType, err := resp.Encode()
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if contentType != "application/json" {
+		t.Errorf("content type = %q, want application/json", contentType)
+	}
+
+	var got struct {
+		Items []map[string]any `json:"items"`
+		Total int              `json:"total"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal %s: %v", data, err)
+	}
+
+	if len(got.Items) != 1 || got.Total != 1 {
+		t.Fatalf("unexpected shape: %s", data)
+	}
+	for _, hidden := range []string{"purchase_cost", "landed_cost"} {
+		if _, ok := got.Items[0][hidden]; ok {
+			t.Errorf("%s not masked: %s", hidden, data)
+		}
+	}
+	if got.Items[0]["selling_price"] != 12.75 {
+		t.Errorf("selling_price = %v, want 12.75", got.Items[0]["selling_price"])
+	}
+}
+
+func TestRestrictFields_DeniesRestrictedWrites(t *testing.T) {
+	ctx := setColumnRestrictions(context.Background(), costRestrictions())
+
+	called := false
+	next := func(ctx context.Context) Encoder {
+		called = true
+		return nil
+	}
+
+	resp := RestrictFields(ctx, []string{"selling_price", "currency_id", "landed_cost"}, next)
+
+	err := isError(resp)
+	if err == nil {
+		t.Fatal("expected permission denied")
+	}
+	if called {
+		t.Error("handler ran for a denied write")
+	}
+
+	var appErr *errs.Error
+	if !errors.As(err, &appErr) || appErr.Code != errs.PermissionDenied {
+		t.Fatalf("error = %v, want PermissionDenied", err)
+	}
+	if want := "fields of products.product_costs cannot be written: currency_id, landed_cost"; appErr.Message != want {
+		t.Errorf("message = %q, want %q", appErr.Message, want)
+	}
+}
+
+func TestRestrictFields_NoRestrictionsPassesThrough(t *testing.T) {
+	want := rawJSON(`{"purchase_cost":"9.50"}`)
+	next := func(ctx context.Context) Encoder { return want }
+
+	if got := RestrictFields(context.Background(), []string{"purchase_cost"}, next); got != want {
+		t.Errorf("response = %#v, want it unchanged", got)
+	}
+}
+
+func TestRestrictFields_MasksOnlyTheTablesRows(t *testing.T) {
+	ctx := setColumnRestrictions(context.Background(), costRestrictions())
+
+	next := func(ctx context.Context) Encoder {
+		return rawJSON(`{"purchase_cost":"9.50","supplier":{"purchase_cost":"8.00"}}`)
+	}
+
+	data, _, err := RestrictFields(ctx, nil, next).Encode()
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal %s: %v", data, err)
+	}
+
+	if _, ok := got["purchase_cost"]; ok {
+		t.Errorf("purchase_cost not masked: %s", data)
+	}
+	if supplier, _ := got["supplier"].(map[string]any); supplier["purchase_cost"] != "8.00" {
+		t.Errorf("nested purchase_cost of another table was masked: %s", data)
+	}
+}
+
+func TestRestrictFields_UsesRegisteredFieldNames(t *testing.T) {
+	RegisterFieldNames("test.restricted_names", map[string]string{"id": "cost_id"})
+
+	ctx := setColumnRestrictions(context.Background(), ColumnRestrictions{
+		Table:    "test.restricted_names",
+		Hidden:   []string{"id"},
+		ReadOnly: []string{"currency_id"},
+	})
+
+	next := func(ctx context.Context) Encoder {
+		return rawJSON(`{"cost_id":"a","id":"b","currency_id":"c"}`)
+	}
+
+	data, _, err := RestrictFields(ctx, nil, next).Encode()
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal %s: %v", data, err)
+	}
+
+	if _, ok := got["cost_id"]; ok {
+		t.Errorf("cost_id not masked: %s", data)
+	}
+	if got["id"] != "b" || got["currency_id"] != "c" {
+		t.Errorf("unrelated fields changed: %s", data)
+	}
+
+	resp := RestrictFields(ctx, []string{"cost_id", "currency_id"}, next)
+
+	var appErr *errs.Error
+	if !errors.As(isError(resp), &appErr) || appErr.Code != errs.PermissionDenied {
+		t.Fatalf("response = %#v, want PermissionDenied", resp)
+	}
+	if want := "fields of test.restricted_names cannot be written: cost_id, currency_id"; appErr.Message != want {
+		t.Errorf("message = %q, want %q", appErr.Message, want)
+	}
+}
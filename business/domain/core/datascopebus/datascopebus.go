@@ -0,0 +1,285 @@
+// Package datascopebus provides business access to warehouse and zone data
+// scopes of roles and users.
+package datascopebus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/delegate"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/outbox"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+	"github.com/timmaaaz/ichor/foundation/otel"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound     = errors.New("data scope not found")
+	ErrUnique       = errors.New("role or user already has a data scope")
+	ErrInvalidOwner = errors.New("data scope must belong to exactly one of a role or a user")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	NewWithTx(tx sqldb.CommitRollbacker) (Storer, error)
+	Create(ctx context.Context, ds DataScope) error
+	Update(ctx context.Context, ds DataScope) error
+	Delete(ctx context.Context, ds DataScope) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]DataScope, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, id uuid.UUID) (DataScope, error)
+	QueryForUser(ctx context.Context, userID uuid.UUID, roleIDs []uuid.UUID) ([]DataScope, error)
+}
+
+// Business manages the set of APIs for data scope access.
+type Business struct {
+	log    *logger.Logger
+	del    *delegate.Delegate
+	outbox *outbox.Writer
+	storer Storer
+}
+
+// NewBusiness constructs a data scope business API for use.
+func NewBusiness(log *logger.Logger, del *delegate.Delegate, storer Storer) *Business {
+	return &Business{
+		log:    log,
+		del:    del,
+		storer: storer,
+	}
+}
+
+// WithOutbox returns a copy of the Business wired to the cascade outbox Writer.
+// Inert until the Writer is injected at the F2 cutover (nil Writer -> Emit no-ops).
+func (b *Business) WithOutbox(w *outbox.Writer) *Business {
+	nb := *b
+	nb.outbox = w
+	return &nb
+}
+
+// NewWithTx constructs a new business value that will use the
+// specified transaction in any store related calls.
+func (b *Business) NewWithTx(tx sqldb.CommitRollbacker) (*Business, error) {
+	storer, err := b.storer.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	nb := *b
+	nb.storer = storer
+	return &nb, nil
+}
+
+// Create adds a new data scope to the system.
+func (b *Business) Create(ctx context.Context, nds NewDataScope) (DataScope, error) {
+	ctx, span := otel.AddSpan(ctx, "business.datascope.create")
+	defer span.End()
+
+	if (nds.RoleID == nil) == (nds.UserID == nil) {
+		return DataScope{}, ErrInvalidOwner
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (DataScope, error) {
+			now := time.Now()
+
+			ds := DataScope{
+				ID:           uuid.New(),
+				RoleID:       nds.RoleID,
+				UserID:       nds.UserID,
+				WarehouseIDs: nds.WarehouseIDs,
+				ZoneIDs:      nds.ZoneIDs,
+				CreatedDate:  now,
+				UpdatedDate:  now,
+			}
+
+			if err := b.storer.Create(ctx, ds); err != nil {
+				return DataScope{}, fmt.Errorf("create: %w", err)
+			}
+
+			evtData := ActionCreatedData(ds)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return DataScope{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.del.Call(ctx, ActionCreatedData(ds)); err != nil {
+				b.log.Error(ctx, "datascopebus: delegate call failed", "action", ActionCreated, "err", err)
+			}
+
+			return ds, nil
+		})
+}
+
+// Update modifies the warehouses and zones of a data scope.
+func (b *Business) Update(ctx context.Context, ds DataScope, uds UpdateDataScope) (DataScope, error) {
+	ctx, span := otel.AddSpan(ctx, "business.datascope.update")
+	defer span.End()
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (DataScope, error) {
+			before := ds
+
+			if uds.WarehouseIDs != nil {
+				ds.WarehouseIDs = *uds.WarehouseIDs
+			}
+			if uds.ZoneIDs != nil {
+				ds.ZoneIDs = *uds.ZoneIDs
+			}
+			ds.UpdatedDate = time.Now()
+
+			if err := b.storer.Update(ctx, ds); err != nil {
+				return DataScope{}, fmt.Errorf("update: %w", err)
+			}
+
+			evtData := ActionUpdatedData(before, ds)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return DataScope{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.del.Call(ctx, ActionUpdatedData(before, ds)); err != nil {
+				b.log.Error(ctx, "datascopebus: delegate call failed", "action", ActionUpdated, "err", err)
+			}
+
+			return ds, nil
+		})
+}
+
+// Delete removes a data scope from the system.
+func (b *Business) Delete(ctx context.Context, ds DataScope) error {
+	ctx, span := otel.AddSpan(ctx, "business.datascope.delete")
+	defer span.End()
+
+	return outbox.WriteAtomicVoid(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) error {
+			if err := b.storer.Delete(ctx, ds); err != nil {
+				return fmt.Errorf("delete: %w", err)
+			}
+
+			evtData := ActionDeletedData(ds)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.del.Call(ctx, ActionDeletedData(ds)); err != nil {
+				b.log.Error(ctx, "datascopebus: delegate call failed", "action", ActionDeleted, "err", err)
+			}
+
+			return nil
+		})
+}
+
+// Query retrieves a list of data scopes from the system.
+func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]DataScope, error) {
+	ctx, span := otel.AddSpan(ctx, "business.datascope.query")
+	defer span.End()
+
+	scopes, err := b.storer.Query(ctx, filter, orderBy, page)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return scopes, nil
+}
+
+// Count returns the number of data scopes in the system.
+func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.datascope.count")
+	defer span.End()
+
+	return b.storer.Count(ctx, filter)
+}
+
+// QueryByID retrieves a data scope by its ID.
+func (b *Business) QueryByID(ctx context.Context, id uuid.UUID) (DataScope, error) {
+	ctx, span := otel.AddSpan(ctx, "business.datascope.querybyid")
+	defer span.End()
+
+	ds, err := b.storer.QueryByID(ctx, id)
+	if err != nil {
+		return DataScope{}, fmt.Errorf("query: datascopeID[%s]: %w", id, err)
+	}
+
+	return ds, nil
+}
+
+// Resolve returns the effective scope of a user holding roleIDs.
+func (b *Business) Resolve(ctx context.Context, userID uuid.UUID, roleIDs []uuid.UUID) (Scope, error) {
+	ctx, span := otel.AddSpan(ctx, "business.datascope.resolve")
+	defer span.End()
+
+	scopes, err := b.storer.QueryForUser(ctx, userID, roleIDs)
+	if err != nil {
+		return Scope{}, fmt.Errorf("query for user: %w", err)
+	}
+
+	return EffectiveScope(userID, roleIDs, scopes), nil
+}
+
+// EffectiveScope combines the data scopes that apply to a user. A scope set on
+// the user replaces those of their roles. Otherwise roles combine the way
+// table access does, by granting: the user reaches the union of their roles'
+// warehouses and zones, and a role without a scope, or without a limit on a
+// dimension, lifts the limit on that dimension entirely.
+func EffectiveScope(userID uuid.UUID, roleIDs []uuid.UUID, scopes []DataScope) Scope {
+	byRole := make(map[uuid.UUID]DataScope, len(scopes))
+	for _, ds := range scopes {
+		if ds.UserID != nil && *ds.UserID == userID {
+			return Scope{WarehouseIDs: nonEmpty(ds.WarehouseIDs), ZoneIDs: nonEmpty(ds.ZoneIDs)}
+		}
+		if ds.RoleID != nil {
+			byRole[*ds.RoleID] = ds
+		}
+	}
+
+	if len(roleIDs) == 0 {
+		return Scope{}
+	}
+
+	var warehouses, zones []uuid.UUID
+	openWarehouses, openZones := false, false
+	for _, roleID := range roleIDs {
+		ds, ok := byRole[roleID]
+		if !ok {
+			return Scope{}
+		}
+
+		if len(ds.WarehouseIDs) == 0 {
+			openWarehouses = true
+		}
+		warehouses = appendUnique(warehouses, ds.WarehouseIDs)
+
+		if len(ds.ZoneIDs) == 0 {
+			openZones = true
+		}
+		zones = appendUnique(zones, ds.ZoneIDs)
+	}
+
+	var scope Scope
+	if !openWarehouses {
+		scope.WarehouseIDs = warehouses
+	}
+	if !openZones {
+		scope.ZoneIDs = zones
+	}
+	return scope
+}
+
+func nonEmpty(ids []uuid.UUID) []uuid.UUID {
+	if len(ids) == 0 {
+		return nil
+	}
+	return ids
+}
+
+func appendUnique(dst, src []uuid.UUID) []uuid.UUID {
+	for _, id := range src {
+		if !slices.Contains(dst, id) {
+			dst = append(dst, id)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,136 @@
+package datascopebus
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/delegate"
+)
+
+// DomainName represents the name of this domain for delegate events.
+const DomainName = "datascope"
+
+// EntityName is the workflow entity name used for event matching.
+// This should match the entity name in workflow.entities table.
+// The entity is stored as just the table name (not schema-qualified).
+const EntityName = "data_scopes"
+
+// Delegate action constants.
+const (
+	ActionCreated = "created"
+	ActionUpdated = "updated"
+	ActionDeleted = "deleted"
+)
+
+// =============================================================================
+// Created Event
+// =============================================================================
+
+// ActionCreatedParms represents the parameters for the created action.
+// Note: data scopes record no created_by or updated_by user, so UserID is
+// uuid.Nil. A scope's own UserID, when set, is the user it restricts and is
+// carried in Entity.
+type ActionCreatedParms struct {
+	EntityID uuid.UUID `json:"entityID"`
+	UserID   uuid.UUID `json:"userID"`
+	Entity   DataScope `json:"entity"`
+}
+
+// Marshal returns the event parameters encoded as JSON.
+func (p *ActionCreatedParms) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// ActionCreatedData constructs delegate data for data scope creation events.
+func ActionCreatedData(dataScope DataScope) delegate.Data {
+	params := ActionCreatedParms{
+		EntityID: dataScope.ID,
+		UserID:   uuid.Nil, // Data scopes record no acting user
+		Entity:   dataScope,
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    DomainName,
+		Action:    ActionCreated,
+		RawParams: rawParams,
+	}
+}
+
+// =============================================================================
+// Updated Event
+// =============================================================================
+
+// ActionUpdatedParms represents the parameters for the updated action.
+type ActionUpdatedParms struct {
+	EntityID     uuid.UUID `json:"entityID"`
+	UserID       uuid.UUID `json:"userID"`
+	Entity       DataScope `json:"entity"`
+	BeforeEntity DataScope `json:"beforeEntity,omitempty"`
+}
+
+// Marshal returns the event parameters encoded as JSON.
+func (p *ActionUpdatedParms) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// ActionUpdatedData constructs delegate data for data scope update events.
+func ActionUpdatedData(before, after DataScope) delegate.Data {
+	params := ActionUpdatedParms{
+		EntityID:     after.ID,
+		UserID:       uuid.Nil, // Data scopes record no acting user
+		Entity:       after,
+		BeforeEntity: before,
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    DomainName,
+		Action:    ActionUpdated,
+		RawParams: rawParams,
+	}
+}
+
+// =============================================================================
+// Deleted Event
+// =============================================================================
+
+// ActionDeletedParms represents the parameters for the deleted action.
+type ActionDeletedParms struct {
+	EntityID uuid.UUID `json:"entityID"`
+	UserID   uuid.UUID `json:"userID"`
+	Entity   DataScope `json:"entity"`
+}
+
+// Marshal returns the event parameters encoded as JSON.
+func (p *ActionDeletedParms) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// ActionDeletedData constructs delegate data for data scope deletion events.
+func ActionDeletedData(dataScope DataScope) delegate.Data {
+	params := ActionDeletedParms{
+		EntityID: dataScope.ID,
+		UserID:   uuid.Nil, // Data scopes record no acting user
+		Entity:   dataScope,
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    DomainName,
+		Action:    ActionDeleted,
+		RawParams: rawParams,
+	}
+}
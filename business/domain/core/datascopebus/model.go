@@ -0,0 +1,52 @@
+package datascopebus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JSON tags are required for workflow event serialization. The workflow system
+// (via EventPublisher) marshals business models to JSON for RawData in TriggerEvents.
+// Without these tags, Go defaults to PascalCase keys, but workflow action handlers
+// expect snake_case keys to match API conventions.
+
+// DataScope limits the warehouse and zone rows a role, or a single user, can
+// reach. Exactly one of RoleID and UserID is set. An empty WarehouseIDs or
+// ZoneIDs leaves that dimension unrestricted.
+type DataScope struct {
+	ID           uuid.UUID   `json:"id"`
+	RoleID       *uuid.UUID  `json:"role_id,omitempty"`
+	UserID       *uuid.UUID  `json:"user_id,omitempty"`
+	WarehouseIDs []uuid.UUID `json:"warehouse_ids"`
+	ZoneIDs      []uuid.UUID `json:"zone_ids"`
+	CreatedDate  time.Time   `json:"created_date"`
+	UpdatedDate  time.Time   `json:"updated_date"`
+}
+
+// NewDataScope is what we require from clients when adding a DataScope.
+type NewDataScope struct {
+	RoleID       *uuid.UUID  `json:"role_id,omitempty"`
+	UserID       *uuid.UUID  `json:"user_id,omitempty"`
+	WarehouseIDs []uuid.UUID `json:"warehouse_ids"`
+	ZoneIDs      []uuid.UUID `json:"zone_ids"`
+}
+
+// UpdateDataScope contains information needed to update a DataScope. The
+// role or user a scope belongs to cannot be changed.
+type UpdateDataScope struct {
+	WarehouseIDs *[]uuid.UUID `json:"warehouse_ids,omitempty"`
+	ZoneIDs      *[]uuid.UUID `json:"zone_ids,omitempty"`
+}
+
+// Scope is the effective data scope of a user. Unrestricted dimensions have a
+// nil list; a zero Scope is unrestricted.
+type Scope struct {
+	WarehouseIDs []uuid.UUID `json:"warehouse_ids,omitempty"`
+	ZoneIDs      []uuid.UUID `json:"zone_ids,omitempty"`
+}
+
+// Restricted reports whether the scope limits any dimension.
+func (s Scope) Restricted() bool {
+	return len(s.WarehouseIDs) > 0 || len(s.ZoneIDs) > 0
+}
@@ -0,0 +1,14 @@
+package datascopebus
+
+import "github.com/timmaaaz/ichor/business/sdk/order"
+
+// DefaultOrderBy represents the default way we sort.
+var DefaultOrderBy = order.NewBy(OrderByCreatedDate, order.ASC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID          = "id"
+	OrderByRoleID      = "role_id"
+	OrderByUserID      = "user_id"
+	OrderByCreatedDate = "created_date"
+)
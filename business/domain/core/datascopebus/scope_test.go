@@ -0,0 +1,81 @@
+package datascopebus_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus"
+)
+
+func TestEffectiveScope(t *testing.T) {
+	userID := uuid.New()
+	roleA, roleB := uuid.New(), uuid.New()
+	wh1, wh2 := uuid.New(), uuid.New()
+	zone1 := uuid.New()
+
+	roleScope := func(roleID uuid.UUID, warehouses, zones []uuid.UUID) datascopebus.DataScope {
+		return datascopebus.DataScope{ID: uuid.New(), RoleID: &roleID, WarehouseIDs: warehouses, ZoneIDs: zones}
+	}
+
+	tests := []struct {
+		name    string
+		roleIDs []uuid.UUID
+		scopes  []datascopebus.DataScope
+		want    datascopebus.Scope
+	}{
+		{
+			name:    "no scopes",
+			roleIDs: []uuid.UUID{roleA},
+			want:    datascopebus.Scope{},
+		},
+		{
+			name:    "single role",
+			roleIDs: []uuid.UUID{roleA},
+			scopes:  []datascopebus.DataScope{roleScope(roleA, []uuid.UUID{wh1}, nil)},
+			want:    datascopebus.Scope{WarehouseIDs: []uuid.UUID{wh1}},
+		},
+		{
+			name:    "roles combine by union",
+			roleIDs: []uuid.UUID{roleA, roleB},
+			scopes: []datascopebus.DataScope{
+				roleScope(roleA, []uuid.UUID{wh1}, []uuid.UUID{zone1}),
+				roleScope(roleB, []uuid.UUID{wh1, wh2}, []uuid.UUID{zone1}),
+			},
+			want: datascopebus.Scope{WarehouseIDs: []uuid.UUID{wh1, wh2}, ZoneIDs: []uuid.UUID{zone1}},
+		},
+		{
+			name:    "unscoped role lifts the scope",
+			roleIDs: []uuid.UUID{roleA, roleB},
+			scopes:  []datascopebus.DataScope{roleScope(roleA, []uuid.UUID{wh1}, nil)},
+			want:    datascopebus.Scope{},
+		},
+		{
+			name:    "open dimension in one role",
+			roleIDs: []uuid.UUID{roleA, roleB},
+			scopes: []datascopebus.DataScope{
+				roleScope(roleA, []uuid.UUID{wh1}, []uuid.UUID{zone1}),
+				roleScope(roleB, []uuid.UUID{wh2}, nil),
+			},
+			want: datascopebus.Scope{WarehouseIDs: []uuid.UUID{wh1, wh2}},
+		},
+		{
+			name:    "user scope replaces role scopes",
+			roleIDs: []uuid.UUID{roleA},
+			scopes: []datascopebus.DataScope{
+				roleScope(roleA, []uuid.UUID{wh1}, nil),
+				{ID: uuid.New(), UserID: &userID, WarehouseIDs: []uuid.UUID{wh2}},
+			},
+			want: datascopebus.Scope{WarehouseIDs: []uuid.UUID{wh2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := datascopebus.EffectiveScope(userID, tt.roleIDs, tt.scopes)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("EffectiveScope mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
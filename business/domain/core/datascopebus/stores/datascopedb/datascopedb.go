@@ -0,0 +1,208 @@
+// Package datascopedb contains data scope related CRUD functionality.
+package datascopedb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// Store manages the set of APIs for data scope database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the api for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// NewWithTx constructs a new Store value replacing the sqlx DB
+// value with a sqlx DB value that is currently inside a transaction.
+func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (datascopebus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := Store{
+		log: s.log,
+		db:  ec,
+	}
+
+	return &store, nil
+}
+
+// Create inserts a new data scope into the database.
+func (s *Store) Create(ctx context.Context, ds datascopebus.DataScope) error {
+	const q = `
+	INSERT INTO core.data_scopes (
+		id, role_id, user_id, warehouse_ids, zone_ids, created_date, updated_date
+	) VALUES (
+		:id, :role_id, :user_id, :warehouse_ids, :zone_ids, :created_date, :updated_date
+	)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBDataScope(ds)); err != nil {
+		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+			return fmt.Errorf("namedexeccontext: %w", datascopebus.ErrUnique)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Update replaces the warehouses and zones of a data scope.
+func (s *Store) Update(ctx context.Context, ds datascopebus.DataScope) error {
+	const q = `
+	UPDATE
+		core.data_scopes
+	SET
+		warehouse_ids = :warehouse_ids,
+		zone_ids = :zone_ids,
+		updated_date = :updated_date
+	WHERE
+		id = :id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBDataScope(ds)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a data scope from the database.
+func (s *Store) Delete(ctx context.Context, ds datascopebus.DataScope) error {
+	const q = `
+	DELETE FROM
+		core.data_scopes
+	WHERE
+		id = :id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBDataScope(ds)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of data scopes from the database.
+func (s *Store) Query(ctx context.Context, filter datascopebus.QueryFilter, orderBy order.By, page page.Page) ([]datascopebus.DataScope, error) {
+	data := map[string]any{
+		"offset":        (page.Number() - 1) * page.RowsPerPage(),
+		"rows_per_page": page.RowsPerPage(),
+	}
+
+	const q = `
+	SELECT
+		id, role_id, user_id, warehouse_ids, zone_ids, created_date, updated_date
+	FROM
+		core.data_scopes`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbScopes []dataScope
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbScopes); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusDataScopes(dbScopes)
+}
+
+// Count returns the number of data scopes in the database.
+func (s *Store) Count(ctx context.Context, filter datascopebus.QueryFilter) (int, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		COUNT(1) AS count
+	FROM
+		core.data_scopes`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("namedquerystruct: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID retrieves a single data scope by its id.
+func (s *Store) QueryByID(ctx context.Context, id uuid.UUID) (datascopebus.DataScope, error) {
+	data := struct {
+		ID string `db:"id"`
+	}{
+		ID: id.String(),
+	}
+
+	const q = `
+	SELECT
+		id, role_id, user_id, warehouse_ids, zone_ids, created_date, updated_date
+	FROM
+		core.data_scopes
+	WHERE
+		id = :id`
+
+	var dbScope dataScope
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbScope); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return datascopebus.DataScope{}, fmt.Errorf("namedquerystruct: %w", datascopebus.ErrNotFound)
+		}
+		return datascopebus.DataScope{}, fmt.Errorf("namedquerystruct: %w", err)
+	}
+
+	return toBusDataScope(dbScope)
+}
+
+// QueryForUser retrieves the data scopes set on the user or on any of roleIDs.
+func (s *Store) QueryForUser(ctx context.Context, userID uuid.UUID, roleIDs []uuid.UUID) ([]datascopebus.DataScope, error) {
+	data := struct {
+		UserID  uuid.UUID   `db:"user_id"`
+		RoleIDs []uuid.UUID `db:"role_ids"`
+	}{
+		UserID:  userID,
+		RoleIDs: roleIDs,
+	}
+
+	const q = `
+	SELECT
+		id, role_id, user_id, warehouse_ids, zone_ids, created_date, updated_date
+	FROM
+		core.data_scopes
+	WHERE
+		user_id = :user_id OR role_id = ANY(:role_ids)`
+
+	var dbScopes []dataScope
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbScopes); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusDataScopes(dbScopes)
+}
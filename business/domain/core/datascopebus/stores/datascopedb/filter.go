@@ -0,0 +1,32 @@
+package datascopedb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus"
+)
+
+func applyFilter(filter datascopebus.QueryFilter, data map[string]any, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["id"] = *filter.ID
+		wc = append(wc, "id = :id")
+	}
+
+	if filter.RoleID != nil {
+		data["role_id"] = *filter.RoleID
+		wc = append(wc, "role_id = :role_id")
+	}
+
+	if filter.UserID != nil {
+		data["user_id"] = *filter.UserID
+		wc = append(wc, "user_id = :user_id")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
@@ -0,0 +1,105 @@
+package datascopedb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb/dbarray"
+)
+
+type dataScope struct {
+	ID           uuid.UUID      `db:"id"`
+	RoleID       uuid.NullUUID  `db:"role_id"`
+	UserID       uuid.NullUUID  `db:"user_id"`
+	WarehouseIDs dbarray.String `db:"warehouse_ids"`
+	ZoneIDs      dbarray.String `db:"zone_ids"`
+	CreatedDate  time.Time      `db:"created_date"`
+	UpdatedDate  time.Time      `db:"updated_date"`
+}
+
+func toDBDataScope(bus datascopebus.DataScope) dataScope {
+	db := dataScope{
+		ID:           bus.ID,
+		WarehouseIDs: toDBIDs(bus.WarehouseIDs),
+		ZoneIDs:      toDBIDs(bus.ZoneIDs),
+		CreatedDate:  bus.CreatedDate.UTC(),
+		UpdatedDate:  bus.UpdatedDate.UTC(),
+	}
+
+	if bus.RoleID != nil {
+		db.RoleID = uuid.NullUUID{UUID: *bus.RoleID, Valid: true}
+	}
+	if bus.UserID != nil {
+		db.UserID = uuid.NullUUID{UUID: *bus.UserID, Valid: true}
+	}
+
+	return db
+}
+
+func toBusDataScope(db dataScope) (datascopebus.DataScope, error) {
+	warehouseIDs, err := toBusIDs(db.WarehouseIDs)
+	if err != nil {
+		return datascopebus.DataScope{}, err
+	}
+
+	zoneIDs, err := toBusIDs(db.ZoneIDs)
+	if err != nil {
+		return datascopebus.DataScope{}, err
+	}
+
+	bus := datascopebus.DataScope{
+		ID:           db.ID,
+		WarehouseIDs: warehouseIDs,
+		ZoneIDs:      zoneIDs,
+		CreatedDate:  db.CreatedDate.In(time.Local),
+		UpdatedDate:  db.UpdatedDate.In(time.Local),
+	}
+
+	if db.RoleID.Valid {
+		bus.RoleID = &db.RoleID.UUID
+	}
+	if db.UserID.Valid {
+		bus.UserID = &db.UserID.UUID
+	}
+
+	return bus, nil
+}
+
+func toBusDataScopes(dbs []dataScope) ([]datascopebus.DataScope, error) {
+	bus := make([]datascopebus.DataScope, len(dbs))
+	for i, db := range dbs {
+		ds, err := toBusDataScope(db)
+		if err != nil {
+			return nil, err
+		}
+		bus[i] = ds
+	}
+	return bus, nil
+}
+
+// toDBIDs writes an empty array rather than NULL; the columns are NOT NULL.
+func toDBIDs(ids []uuid.UUID) dbarray.String {
+	arr := make(dbarray.String, len(ids))
+	for i, id := range ids {
+		arr[i] = id.String()
+	}
+	return arr
+}
+
+func toBusIDs(arr dbarray.String) ([]uuid.UUID, error) {
+	if len(arr) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, len(arr))
+	for i, s := range arr {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("parse id %q: %w", s, err)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
@@ -0,0 +1,24 @@
+package datascopedb
+
+import (
+	"fmt"
+
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+)
+
+var orderByFields = map[string]string{
+	datascopebus.OrderByID:          "id",
+	datascopebus.OrderByRoleID:      "role_id",
+	datascopebus.OrderByUserID:      "user_id",
+	datascopebus.OrderByCreatedDate: "created_date",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
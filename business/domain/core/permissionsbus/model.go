@@ -2,6 +2,7 @@ package permissionsbus
 
 import (
 	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus"
 	"github.com/timmaaaz/ichor/business/domain/core/tableaccessbus"
 	"github.com/timmaaaz/ichor/business/domain/core/userrolebus"
 )
@@ -18,6 +19,7 @@ type UserPermissions struct {
 	RoleNames   []string                              `json:"role_names"`
 	Roles       []userrolebus.UserRole                `json:"roles"`
 	TableAccess map[string]tableaccessbus.TableAccess `json:"table_access"`
+	DataScope   datascopebus.Scope                    `json:"data_scope"`
 }
 
 // UserRole represents a role assigned to a user and its associated permissions
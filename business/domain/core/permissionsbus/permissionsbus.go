@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus"
 	"github.com/timmaaaz/ichor/business/domain/core/rolebus"
 	"github.com/timmaaaz/ichor/business/domain/core/tableaccessbus"
 	"github.com/timmaaaz/ichor/business/domain/core/userrolebus"
@@ -38,6 +39,7 @@ type Business struct {
 	RolesBus       *rolebus.Business
 	UserRolesBus   *userrolebus.Business
 	TableAccessBus *tableaccessbus.Business
+	DataScopeBus   *datascopebus.Business
 }
 
 // NewBusiness constructs a user business API for use.
@@ -76,6 +78,15 @@ func NewBusiness(log *logger.Logger, del *delegate.Delegate, storer Storer, urb
 	return b
 }
 
+// WithDataScopes returns a copy of the Business that resolves the warehouse and
+// zone data scope of each user alongside their table access. Without it every
+// user is unrestricted.
+func (b *Business) WithDataScopes(dsb *datascopebus.Business) *Business {
+	nb := *b
+	nb.DataScopeBus = dsb
+	return &nb
+}
+
 // NewWithTx constructs a new business value that will use the
 // specified transaction in any store related calls.
 func (b *Business) NewWithTx(tx sqldb.CommitRollbacker) (*Business, error) {
@@ -154,16 +165,63 @@ func (b *Business) QueryUserPermissions(ctx context.Context, userID uuid.UUID) (
 			t.CanRead = t.CanRead || table.CanRead
 			t.CanUpdate = t.CanUpdate || table.CanUpdate
 			t.CanDelete = t.CanDelete || table.CanDelete
+			t.HiddenColumns, t.ReadOnlyColumns = combineColumnRestrictions(t, table)
 			combinedTableAccesses[table.TableName] = t
 		}
 	}
 
+	var scope datascopebus.Scope
+	if b.DataScopeBus != nil {
+		scope, err = b.DataScopeBus.Resolve(ctx, userID, roleIDs)
+		if err != nil {
+			return UserPermissions{}, err
+		}
+	}
+
 	userPerms := UserPermissions{
 		RoleNames:   roleNames,
 		UserID:      userID,
 		Roles:       userRoles,
 		TableAccess: combinedTableAccesses,
+		DataScope:   scope,
 	}
 
 	return userPerms, nil
 }
+
+// combineColumnRestrictions merges the column restrictions of two roles on the
+// same table the same way table flags are merged: a role that can see or write
+// a column grants it. A column stays hidden only if both roles hide it and
+// stays unwritable only if neither role may write it.
+func combineColumnRestrictions(a, b tableaccessbus.TableAccess) (hidden, readOnly []string) {
+	inBoth := func(x, y []string) []string {
+		set := make(map[string]bool, len(y))
+		for _, c := range y {
+			set[c] = true
+		}
+		var out []string
+		for _, c := range x {
+			if set[c] {
+				out = append(out, c)
+			}
+		}
+		return out
+	}
+
+	hidden = inBoth(a.HiddenColumns, b.HiddenColumns)
+
+	isHidden := make(map[string]bool, len(hidden))
+	for _, c := range hidden {
+		isHidden[c] = true
+	}
+
+	unwritableA := append(append([]string{}, a.HiddenColumns...), a.ReadOnlyColumns...)
+	unwritableB := append(append([]string{}, b.HiddenColumns...), b.ReadOnlyColumns...)
+	for _, c := range inBoth(unwritableA, unwritableB) {
+		if !isHidden[c] {
+			readOnly = append(readOnly, c)
+		}
+	}
+
+	return hidden, readOnly
+}
@@ -7,30 +7,39 @@ import "github.com/google/uuid"
 // Without these tags, Go defaults to PascalCase keys, but workflow action handlers
 // expect snake_case keys to match API conventions.
 
+// HiddenColumns are stripped from reads of the table and may not be written.
+// ReadOnlyColumns are returned on reads but may not be written. Both are
+// column names of TableName; empty means no column restriction.
 type TableAccess struct {
-	ID        uuid.UUID `json:"id"`
-	RoleID    uuid.UUID `json:"role_id"`
-	TableName string    `json:"table_name"`
-	CanCreate bool      `json:"can_create"`
-	CanRead   bool      `json:"can_read"`
-	CanUpdate bool      `json:"can_update"`
-	CanDelete bool      `json:"can_delete"`
+	ID              uuid.UUID `json:"id"`
+	RoleID          uuid.UUID `json:"role_id"`
+	TableName       string    `json:"table_name"`
+	CanCreate       bool      `json:"can_create"`
+	CanRead         bool      `json:"can_read"`
+	CanUpdate       bool      `json:"can_update"`
+	CanDelete       bool      `json:"can_delete"`
+	HiddenColumns   []string  `json:"hidden_columns,omitempty"`
+	ReadOnlyColumns []string  `json:"read_only_columns,omitempty"`
 }
 
 type NewTableAccess struct {
-	RoleID    uuid.UUID `json:"role_id"`
-	TableName string    `json:"table_name"`
-	CanCreate bool      `json:"can_create"`
-	CanRead   bool      `json:"can_read"`
-	CanUpdate bool      `json:"can_update"`
-	CanDelete bool      `json:"can_delete"`
+	RoleID          uuid.UUID `json:"role_id"`
+	TableName       string    `json:"table_name"`
+	CanCreate       bool      `json:"can_create"`
+	CanRead         bool      `json:"can_read"`
+	CanUpdate       bool      `json:"can_update"`
+	CanDelete       bool      `json:"can_delete"`
+	HiddenColumns   []string  `json:"hidden_columns,omitempty"`
+	ReadOnlyColumns []string  `json:"read_only_columns,omitempty"`
 }
 
 type UpdateTableAccess struct {
-	RoleID    *uuid.UUID `json:"role_id,omitempty"`
-	TableName *string    `json:"table_name,omitempty"`
-	CanCreate *bool      `json:"can_create,omitempty"`
-	CanRead   *bool      `json:"can_read,omitempty"`
-	CanUpdate *bool      `json:"can_update,omitempty"`
-	CanDelete *bool      `json:"can_delete,omitempty"`
+	RoleID          *uuid.UUID `json:"role_id,omitempty"`
+	TableName       *string    `json:"table_name,omitempty"`
+	CanCreate       *bool      `json:"can_create,omitempty"`
+	CanRead         *bool      `json:"can_read,omitempty"`
+	CanUpdate       *bool      `json:"can_update,omitempty"`
+	CanDelete       *bool      `json:"can_delete,omitempty"`
+	HiddenColumns   *[]string  `json:"hidden_columns,omitempty"`
+	ReadOnlyColumns *[]string  `json:"read_only_columns,omitempty"`
 }
@@ -3,39 +3,46 @@ package tableaccessdb
 import (
 	"github.com/google/uuid"
 	"github.com/timmaaaz/ichor/business/domain/core/tableaccessbus"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb/dbarray"
 )
 
 type tableAccess struct {
-	ID        uuid.UUID `db:"id"`
-	RoleID    uuid.UUID `db:"role_id"`
-	TableName string    `db:"table_name"`
-	CanCreate bool      `db:"can_create"`
-	CanRead   bool      `db:"can_read"`
-	CanUpdate bool      `db:"can_update"`
-	CanDelete bool      `db:"can_delete"`
+	ID              uuid.UUID      `db:"id"`
+	RoleID          uuid.UUID      `db:"role_id"`
+	TableName       string         `db:"table_name"`
+	CanCreate       bool           `db:"can_create"`
+	CanRead         bool           `db:"can_read"`
+	CanUpdate       bool           `db:"can_update"`
+	CanDelete       bool           `db:"can_delete"`
+	HiddenColumns   dbarray.String `db:"hidden_columns"`
+	ReadOnlyColumns dbarray.String `db:"read_only_columns"`
 }
 
 func toDBTableAccess(bus tableaccessbus.TableAccess) tableAccess {
 	return tableAccess{
-		ID:        bus.ID,
-		RoleID:    bus.RoleID,
-		TableName: bus.TableName,
-		CanCreate: bus.CanCreate,
-		CanRead:   bus.CanRead,
-		CanUpdate: bus.CanUpdate,
-		CanDelete: bus.CanDelete,
+		ID:              bus.ID,
+		RoleID:          bus.RoleID,
+		TableName:       bus.TableName,
+		CanCreate:       bus.CanCreate,
+		CanRead:         bus.CanRead,
+		CanUpdate:       bus.CanUpdate,
+		CanDelete:       bus.CanDelete,
+		HiddenColumns:   toDBColumns(bus.HiddenColumns),
+		ReadOnlyColumns: toDBColumns(bus.ReadOnlyColumns),
 	}
 }
 
 func toBusTableAccess(db tableAccess) tableaccessbus.TableAccess {
 	return tableaccessbus.TableAccess{
-		ID:        db.ID,
-		RoleID:    db.RoleID,
-		TableName: db.TableName,
-		CanCreate: db.CanCreate,
-		CanRead:   db.CanRead,
-		CanUpdate: db.CanUpdate,
-		CanDelete: db.CanDelete,
+		ID:              db.ID,
+		RoleID:          db.RoleID,
+		TableName:       db.TableName,
+		CanCreate:       db.CanCreate,
+		CanRead:         db.CanRead,
+		CanUpdate:       db.CanUpdate,
+		CanDelete:       db.CanDelete,
+		HiddenColumns:   toBusColumns(db.HiddenColumns),
+		ReadOnlyColumns: toBusColumns(db.ReadOnlyColumns),
 	}
 }
 
@@ -46,3 +53,20 @@ func toBusTableAccesses(dbs []tableAccess) []tableaccessbus.TableAccess {
 	}
 	return tableAccesses
 }
+
+// toDBColumns writes an empty array rather than NULL; the columns are NOT NULL.
+func toDBColumns(cols []string) dbarray.String {
+	if cols == nil {
+		return dbarray.String{}
+	}
+	return dbarray.String(cols)
+}
+
+// toBusColumns reads an empty array back as nil so unrestricted rows compare
+// equal to ones built without column lists.
+func toBusColumns(cols dbarray.String) []string {
+	if len(cols) == 0 {
+		return nil
+	}
+	return []string(cols)
+}
@@ -95,9 +95,9 @@ func (s *Store) Create(ctx context.Context, ta tableaccessbus.TableAccess) error
 	// Now we can insert
 	const q = `
 	INSERT INTO core.table_access (
-		id, role_id, table_name, can_create, can_read, can_update, can_delete
+		id, role_id, table_name, can_create, can_read, can_update, can_delete, hidden_columns, read_only_columns
 	) VALUES (
-		:id, :role_id, :table_name, :can_create, :can_read, :can_update, :can_delete
+		:id, :role_id, :table_name, :can_create, :can_read, :can_update, :can_delete, :hidden_columns, :read_only_columns
 	)
 	`
 
@@ -123,7 +123,9 @@ func (s *Store) Update(ctx context.Context, ta tableaccessbus.TableAccess) error
 		can_create = :can_create,
 		can_read = :can_read,
 		can_update = :can_update,
-		can_delete = :can_delete
+		can_delete = :can_delete,
+		hidden_columns = :hidden_columns,
+		read_only_columns = :read_only_columns
 	WHERE 
 		id = :id
 	`
@@ -160,7 +162,7 @@ func (s *Store) Query(ctx context.Context, filter tableaccessbus.QueryFilter, or
 
 	const q = `
 	SELECT
-		id, role_id, table_name, can_create, can_read, can_update, can_delete
+		id, role_id, table_name, can_create, can_read, can_update, can_delete, hidden_columns, read_only_columns
 	FROM
 		core.table_access`
 
@@ -211,7 +213,7 @@ func (s *Store) Count(ctx context.Context, filter tableaccessbus.QueryFilter) (i
 func (s *Store) QueryByID(ctx context.Context, tableAccessID uuid.UUID) (tableaccessbus.TableAccess, error) {
 	const q = `
 	SELECT
-		id, role_id, table_name, can_create, can_read, can_update, can_delete
+		id, role_id, table_name, can_create, can_read, can_update, can_delete, hidden_columns, read_only_columns
 	FROM
 		core.table_access
 	WHERE
@@ -234,7 +236,7 @@ func (s *Store) QueryByRoleIDs(ctx context.Context, roleIDs []uuid.UUID) ([]tabl
 
 	const q = `
 	SELECT
-		id, role_id, table_name, can_create, can_read, can_update, can_delete
+		id, role_id, table_name, can_create, can_read, can_update, can_delete, hidden_columns, read_only_columns
 	FROM
 		core.table_access
 	WHERE
@@ -253,7 +255,7 @@ func (s *Store) QueryByRoleIDs(ctx context.Context, roleIDs []uuid.UUID) ([]tabl
 func (s *Store) QueryAll(ctx context.Context) ([]tableaccessbus.TableAccess, error) {
 	const q = `
 	SELECT
-		id, role_id, table_name, can_create, can_read, can_update, can_delete
+		id, role_id, table_name, can_create, can_read, can_update, can_delete, hidden_columns, read_only_columns
 	FROM
 		core.table_access
 	`
@@ -97,6 +97,9 @@ func (b *Business) Create(ctx context.Context, nta NewTableAccess) (TableAccess,
 				CanRead:   nta.CanRead,
 				CanUpdate: nta.CanUpdate,
 				CanDelete: nta.CanDelete,
+
+				HiddenColumns:   nta.HiddenColumns,
+				ReadOnlyColumns: nta.ReadOnlyColumns,
 			}
 
 			if err := b.storer.Create(ctx, ta); err != nil {
@@ -143,6 +146,12 @@ func (b *Business) Update(ctx context.Context, ta TableAccess, uta UpdateTableAc
 			if uta.CanDelete != nil {
 				ta.CanDelete = *uta.CanDelete
 			}
+			if uta.HiddenColumns != nil {
+				ta.HiddenColumns = *uta.HiddenColumns
+			}
+			if uta.ReadOnlyColumns != nil {
+				ta.ReadOnlyColumns = *uta.ReadOnlyColumns
+			}
 
 			if err := b.storer.Update(ctx, ta); err != nil {
 				return TableAccess{}, fmt.Errorf("updating table access: %w", err)
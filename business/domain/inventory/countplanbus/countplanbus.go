@@ -0,0 +1,426 @@
+// Package countplanbus provides business access to count plans: standing
+// policies that generate cycle count sessions, so counting follows a
+// systematic program rather than ad-hoc sessions.
+package countplanbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountsessionbus"
+	"github.com/timmaaaz/ichor/business/sdk/delegate"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/outbox"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+	"github.com/timmaaaz/ichor/foundation/otel"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound            = errors.New("count plan not found")
+	ErrUniqueEntry         = errors.New("count plan entry is not unique")
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+	ErrInvalidPlan         = errors.New("invalid count plan")
+	ErrInactive            = errors.New("count plan is not active")
+	ErrNothingDue          = errors.New("nothing is due for counting")
+)
+
+// Default rule parameters applied when a new plan leaves them zero.
+const (
+	DefaultADays        = 30
+	DefaultBDays        = 90
+	DefaultCDays        = 365
+	DefaultLookbackDays = 30
+	DefaultSampleSize   = 5
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	NewWithTx(tx sqldb.CommitRollbacker) (Storer, error)
+	Create(ctx context.Context, plan CountPlan) error
+	Update(ctx context.Context, plan CountPlan) error
+	Delete(ctx context.Context, plan CountPlan) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]CountPlan, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, planID uuid.UUID) (CountPlan, error)
+	QueryCandidates(ctx context.Context, plan CountPlan, now time.Time) ([]Candidate, error)
+	LockGeneration(ctx context.Context) error
+	CreateRun(ctx context.Context, run Run) error
+	QueryRuns(ctx context.Context, planID uuid.UUID, page page.Page) ([]Run, error)
+	CountRuns(ctx context.Context, planID uuid.UUID) (int, error)
+}
+
+// Business manages the set of APIs for count plan access.
+type Business struct {
+	log        *logger.Logger
+	storer     Storer
+	delegate   *delegate.Delegate
+	outbox     *outbox.Writer
+	sessionBus *cyclecountsessionbus.Business
+	itemBus    *cyclecountitembus.Business
+}
+
+// NewBusiness constructs a count plan business API for use. The cycle count
+// session and item buses receive the sessions and items Generate produces.
+func NewBusiness(log *logger.Logger, delegate *delegate.Delegate, storer Storer, sessionBus *cyclecountsessionbus.Business, itemBus *cyclecountitembus.Business) *Business {
+	return &Business{
+		log:        log,
+		delegate:   delegate,
+		storer:     storer,
+		sessionBus: sessionBus,
+		itemBus:    itemBus,
+	}
+}
+
+// WithOutbox returns a copy of the Business wired to the cascade outbox Writer.
+// Inert until the Writer is injected at the F2 cutover (nil Writer -> Emit no-ops).
+func (b *Business) WithOutbox(w *outbox.Writer) *Business {
+	nb := *b
+	nb.outbox = w
+	return &nb
+}
+
+// NewWithTx constructs a new Business value replacing the Storer
+// value with a Storer value that is currently inside a transaction.
+func (b *Business) NewWithTx(tx sqldb.CommitRollbacker) (*Business, error) {
+	storer, err := b.storer.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionBus, err := b.sessionBus.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	itemBus, err := b.itemBus.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	nb := *b
+	nb.storer = storer
+	nb.sessionBus = sessionBus
+	nb.itemBus = itemBus
+	return &nb, nil
+}
+
+// Create adds a new count plan to the system.
+func (b *Business) Create(ctx context.Context, ncp NewCountPlan) (CountPlan, error) {
+	ctx, span := otel.AddSpan(ctx, "business.countplanbus.create")
+	defer span.End()
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (CountPlan, error) {
+			now := time.Now()
+
+			plan := CountPlan{
+				ID:           uuid.New(),
+				Name:         ncp.Name,
+				Rule:         ncp.Rule,
+				WarehouseID:  ncp.WarehouseID,
+				ZoneID:       ncp.ZoneID,
+				ADays:        orDefault(ncp.ADays, DefaultADays),
+				BDays:        orDefault(ncp.BDays, DefaultBDays),
+				CDays:        orDefault(ncp.CDays, DefaultCDays),
+				LookbackDays: orDefault(ncp.LookbackDays, DefaultLookbackDays),
+				SampleSize:   orDefault(ncp.SampleSize, DefaultSampleSize),
+				MaxItems:     ncp.MaxItems,
+				IsActive:     ncp.IsActive,
+				CreatedBy:    ncp.CreatedBy,
+				CreatedDate:  now,
+				UpdatedDate:  now,
+			}
+
+			if err := validate(plan); err != nil {
+				return CountPlan{}, fmt.Errorf("create: %w", err)
+			}
+
+			if err := b.storer.Create(ctx, plan); err != nil {
+				return CountPlan{}, fmt.Errorf("create: %w", err)
+			}
+
+			evtData := ActionCreatedData(plan)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return CountPlan{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionCreatedData(plan)); err != nil {
+				b.log.Error(ctx, "countplanbus: delegate call failed", "action", ActionCreated, "err", err)
+			}
+
+			return plan, nil
+		})
+}
+
+// Update modifies an existing count plan in the system.
+func (b *Business) Update(ctx context.Context, plan CountPlan, ucp UpdateCountPlan) (CountPlan, error) {
+	ctx, span := otel.AddSpan(ctx, "business.countplanbus.update")
+	defer span.End()
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (CountPlan, error) {
+			before := plan
+
+			if ucp.Name != nil {
+				plan.Name = *ucp.Name
+			}
+			if ucp.Rule != nil {
+				plan.Rule = *ucp.Rule
+			}
+			if ucp.WarehouseID != nil {
+				plan.WarehouseID = ucp.WarehouseID
+			}
+			if ucp.ZoneID != nil {
+				plan.ZoneID = ucp.ZoneID
+			}
+			if ucp.ADays != nil {
+				plan.ADays = *ucp.ADays
+			}
+			if ucp.BDays != nil {
+				plan.BDays = *ucp.BDays
+			}
+			if ucp.CDays != nil {
+				plan.CDays = *ucp.CDays
+			}
+			if ucp.LookbackDays != nil {
+				plan.LookbackDays = *ucp.LookbackDays
+			}
+			if ucp.SampleSize != nil {
+				plan.SampleSize = *ucp.SampleSize
+			}
+			if ucp.MaxItems != nil {
+				plan.MaxItems = *ucp.MaxItems
+			}
+			if ucp.IsActive != nil {
+				plan.IsActive = *ucp.IsActive
+			}
+
+			plan.UpdatedDate = time.Now()
+
+			if err := validate(plan); err != nil {
+				return CountPlan{}, fmt.Errorf("update: %w", err)
+			}
+
+			if err := b.storer.Update(ctx, plan); err != nil {
+				return CountPlan{}, fmt.Errorf("update: %w", err)
+			}
+
+			evtData := ActionUpdatedData(before, plan)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return CountPlan{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionUpdatedData(before, plan)); err != nil {
+				b.log.Error(ctx, "countplanbus: delegate call failed", "action", ActionUpdated, "err", err)
+			}
+
+			return plan, nil
+		})
+}
+
+// Delete removes a count plan from the system. A plan that has generated
+// sessions keeps its run history and cannot be deleted; deactivate it instead.
+func (b *Business) Delete(ctx context.Context, plan CountPlan) error {
+	ctx, span := otel.AddSpan(ctx, "business.countplanbus.delete")
+	defer span.End()
+
+	return outbox.WriteAtomicVoid(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) error {
+			if err := b.storer.Delete(ctx, plan); err != nil {
+				return fmt.Errorf("delete: %w", err)
+			}
+
+			evtData := ActionDeletedData(plan)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionDeletedData(plan)); err != nil {
+				b.log.Error(ctx, "countplanbus: delegate call failed", "action", ActionDeleted, "err", err)
+			}
+
+			return nil
+		})
+}
+
+// Query retrieves a list of count plans from the system.
+func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]CountPlan, error) {
+	ctx, span := otel.AddSpan(ctx, "business.countplanbus.query")
+	defer span.End()
+
+	plans, err := b.storer.Query(ctx, filter, orderBy, page)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return plans, nil
+}
+
+// Count returns the total number of count plans matching the filter.
+func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.countplanbus.count")
+	defer span.End()
+
+	return b.storer.Count(ctx, filter)
+}
+
+// QueryByID retrieves a single count plan by its ID.
+func (b *Business) QueryByID(ctx context.Context, planID uuid.UUID) (CountPlan, error) {
+	ctx, span := otel.AddSpan(ctx, "business.countplanbus.querybyid")
+	defer span.End()
+
+	plan, err := b.storer.QueryByID(ctx, planID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return CountPlan{}, err
+		}
+		return CountPlan{}, fmt.Errorf("queryByID: planID[%s]: %w", planID, err)
+	}
+
+	return plan, nil
+}
+
+// Preview returns the items the plan would put in a session generated at now,
+// without writing anything. Locations already in an open session are left out,
+// as Generate leaves them out.
+func (b *Business) Preview(ctx context.Context, plan CountPlan, now time.Time) ([]Candidate, error) {
+	ctx, span := otel.AddSpan(ctx, "business.countplanbus.preview")
+	defer span.End()
+
+	candidates, err := b.storer.QueryCandidates(ctx, plan, now)
+	if err != nil {
+		return nil, fmt.Errorf("preview: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// Generate creates a draft cycle count session holding one item per candidate,
+// each snapshotting the system quantity on hand now, and records the run on
+// the plan. Generations are serialized so two plans running at once cannot
+// both pick the same location. Returns ErrNothingDue when the plan selects no
+// items.
+func (b *Business) Generate(ctx context.Context, plan CountPlan, generatedBy uuid.UUID, now time.Time) (Run, error) {
+	ctx, span := otel.AddSpan(ctx, "business.countplanbus.generate")
+	defer span.End()
+
+	if !plan.IsActive {
+		return Run{}, fmt.Errorf("generate: %w", ErrInactive)
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Run, error) {
+			if err := b.storer.LockGeneration(ctx); err != nil {
+				return Run{}, fmt.Errorf("generate: lock: %w", err)
+			}
+
+			candidates, err := b.storer.QueryCandidates(ctx, plan, now)
+			if err != nil {
+				return Run{}, fmt.Errorf("generate: candidates: %w", err)
+			}
+			if len(candidates) == 0 {
+				return Run{}, fmt.Errorf("generate: %w", ErrNothingDue)
+			}
+
+			session, err := b.sessionBus.Create(ctx, cyclecountsessionbus.NewCycleCountSession{
+				Name:      fmt.Sprintf("%s %s", plan.Name, now.Format(time.DateOnly)),
+				CreatedBy: generatedBy,
+			})
+			if err != nil {
+				return Run{}, fmt.Errorf("generate: create session: %w", err)
+			}
+
+			for _, c := range candidates {
+				if _, err := b.itemBus.Create(ctx, cyclecountitembus.NewCycleCountItem{
+					SessionID:      session.ID,
+					ProductID:      c.ProductID,
+					LocationID:     c.LocationID,
+					SystemQuantity: c.SystemQuantity,
+				}); err != nil {
+					return Run{}, fmt.Errorf("generate: create item: %w", err)
+				}
+			}
+
+			run := Run{
+				ID:            uuid.New(),
+				PlanID:        plan.ID,
+				SessionID:     session.ID,
+				ItemCount:     len(candidates),
+				GeneratedBy:   generatedBy,
+				GeneratedDate: now,
+			}
+			if err := b.storer.CreateRun(ctx, run); err != nil {
+				return Run{}, fmt.Errorf("generate: create run: %w", err)
+			}
+
+			before := plan
+			plan.LastGeneratedDate = &now
+			plan.UpdatedDate = now
+			if err := b.storer.Update(ctx, plan); err != nil {
+				return Run{}, fmt.Errorf("generate: update plan: %w", err)
+			}
+
+			evtData := ActionUpdatedData(before, plan)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return Run{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionUpdatedData(before, plan)); err != nil {
+				b.log.Error(ctx, "countplanbus: delegate call failed", "action", ActionUpdated, "err", err)
+			}
+
+			return run, nil
+		})
+}
+
+// QueryRuns retrieves a plan's generation history, newest first.
+func (b *Business) QueryRuns(ctx context.Context, planID uuid.UUID, page page.Page) ([]Run, error) {
+	ctx, span := otel.AddSpan(ctx, "business.countplanbus.queryruns")
+	defer span.End()
+
+	runs, err := b.storer.QueryRuns(ctx, planID, page)
+	if err != nil {
+		return nil, fmt.Errorf("queryruns: %w", err)
+	}
+
+	return runs, nil
+}
+
+// CountRuns returns the number of times a plan has generated a session.
+func (b *Business) CountRuns(ctx context.Context, planID uuid.UUID) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.countplanbus.countruns")
+	defer span.End()
+
+	return b.storer.CountRuns(ctx, planID)
+}
+
+// =============================================================================
+
+func validate(plan CountPlan) error {
+	switch {
+	case plan.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidPlan)
+	case plan.Rule == Rule{}:
+		return fmt.Errorf("%w: rule is required", ErrInvalidPlan)
+	case plan.ADays <= 0 || plan.BDays <= 0 || plan.CDays <= 0:
+		return fmt.Errorf("%w: a_days, b_days and c_days must be positive", ErrInvalidPlan)
+	case plan.LookbackDays <= 0:
+		return fmt.Errorf("%w: lookback_days must be positive", ErrInvalidPlan)
+	case plan.SampleSize <= 0:
+		return fmt.Errorf("%w: sample_size must be positive", ErrInvalidPlan)
+	case plan.MaxItems < 0:
+		return fmt.Errorf("%w: max_items must not be negative", ErrInvalidPlan)
+	}
+	return nil
+}
+
+func orDefault(v, def int) int {
+	if v == 0 {
+		return def
+	}
+	return v
+}
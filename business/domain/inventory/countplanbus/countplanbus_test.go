@@ -0,0 +1,358 @@
+package countplanbus_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/core/contactinfosbus"
+	"github.com/timmaaaz/ichor/business/domain/core/currencybus"
+	"github.com/timmaaaz/ichor/business/domain/core/userbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/citybus"
+	"github.com/timmaaaz/ichor/business/domain/geography/regionbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/streetbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/warehousebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/zonebus"
+	"github.com/timmaaaz/ichor/business/domain/products/brandbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productcategorybus"
+	"github.com/timmaaaz/ichor/business/domain/products/productcostbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productcostbus/types"
+	"github.com/timmaaaz/ichor/business/sdk/dbtest"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/unitest"
+)
+
+// Test_CountPlans walks the count program: plans take their rule defaults,
+// preview selects without writing, generation snapshots the system quantity
+// into a draft session and records a run, and a location already in an open
+// session is not selected again.
+func Test_CountPlans(t *testing.T) {
+	t.Parallel()
+
+	db := dbtest.NewDatabase(t, "Test_CountPlans")
+
+	sd, err := insertSeedData(db.BusDomain)
+	if err != nil {
+		t.Fatalf("seeding: %s", err)
+	}
+
+	ctx := context.Background()
+	bus := db.BusDomain.CountPlan
+	userID := sd.Admins[0].ID
+	warehouseID := sd.InventoryLocations[0].WarehouseID
+	now := time.Now()
+
+	// loc0/p0 is empty; loc1/p1 holds 7 of an A item; loc2/p2 holds 12 of a C item.
+	stock := []struct {
+		loc, prod uuid.UUID
+		qty       int
+	}{
+		{sd.InventoryLocations[0].LocationID, sd.Products[0].ProductID, 0},
+		{sd.InventoryLocations[1].LocationID, sd.Products[1].ProductID, 7},
+		{sd.InventoryLocations[2].LocationID, sd.Products[2].ProductID, 12},
+	}
+	for _, s := range stock {
+		if _, err := db.BusDomain.InventoryItem.Create(ctx, inventoryitembus.NewInventoryItem{
+			ProductID: s.prod, LocationID: s.loc, Quantity: s.qty,
+		}); err != nil {
+			t.Fatalf("seeding inventory item: %s", err)
+		}
+	}
+
+	currencies, err := currencybus.TestSeedCurrencies(ctx, 1, db.BusDomain.Currency)
+	if err != nil {
+		t.Fatalf("seeding currencies: %s", err)
+	}
+	for prod, class := range map[uuid.UUID]string{sd.Products[1].ProductID: "A", sd.Products[2].ProductID: "C"} {
+		if _, err := db.BusDomain.ProductCost.Create(ctx, productcostbus.NewProductCost{
+			ProductID:         prod,
+			PurchaseCost:      types.MustParseMoney("1.00"),
+			SellingPrice:      types.MustParseMoney("2.00"),
+			CurrencyID:        currencies[0].ID,
+			MSRP:              types.MustParseMoney("2.00"),
+			LandedCost:        types.MustParseMoney("1.00"),
+			CarryingCost:      types.MustParseMoney("0.10"),
+			ABCClassification: class,
+			InsuranceValue:    types.MustParseMoney("0.00"),
+			EffectiveDate:     now.Add(-time.Hour),
+		}); err != nil {
+			t.Fatalf("seeding product cost: %s", err)
+		}
+	}
+
+	// -------------------------------------------------------------------------
+	// Defaults and validation.
+
+	zero, err := bus.Create(ctx, countplanbus.NewCountPlan{
+		Name: "Empty bins", Rule: countplanbus.Rules.ZeroOnHand, WarehouseID: &warehouseID,
+		IsActive: true, CreatedBy: userID,
+	})
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+	if zero.ADays != 30 || zero.BDays != 90 || zero.CDays != 365 || zero.LookbackDays != 30 || zero.SampleSize != 5 {
+		t.Fatalf("create: want rule defaults, got %+v", zero)
+	}
+
+	if _, err := bus.Create(ctx, countplanbus.NewCountPlan{
+		Name: "Bad sample", Rule: countplanbus.Rules.RandomSample, SampleSize: -1, CreatedBy: userID,
+	}); !errors.Is(err, countplanbus.ErrInvalidPlan) {
+		t.Fatalf("create: want ErrInvalidPlan, got %v", err)
+	}
+
+	// -------------------------------------------------------------------------
+	// zero_on_hand: preview, generate, then nothing is due while the session is open.
+
+	cands, err := bus.Preview(ctx, zero, now)
+	if err != nil {
+		t.Fatalf("preview: %s", err)
+	}
+	if len(cands) != 1 || cands[0].LocationID != stock[0].loc || cands[0].SystemQuantity != 0 {
+		t.Fatalf("preview: want the empty location only, got %+v", cands)
+	}
+
+	run, err := bus.Generate(ctx, zero, userID, now)
+	if err != nil {
+		t.Fatalf("generate: %s", err)
+	}
+	if run.ItemCount != 1 || run.SessionID == uuid.Nil {
+		t.Fatalf("generate: unexpected run %+v", run)
+	}
+
+	if _, err := bus.Generate(ctx, zero, userID, now); !errors.Is(err, countplanbus.ErrNothingDue) {
+		t.Fatalf("generate again: want ErrNothingDue, got %v", err)
+	}
+
+	runs, err := bus.QueryRuns(ctx, zero.ID, page.MustParse("1", "10"))
+	if err != nil {
+		t.Fatalf("query runs: %s", err)
+	}
+	if len(runs) != 1 || runs[0].ID != run.ID {
+		t.Fatalf("query runs: want the one run, got %+v", runs)
+	}
+
+	zero, err = bus.QueryByID(ctx, zero.ID)
+	if err != nil {
+		t.Fatalf("query by id: %s", err)
+	}
+	if zero.LastGeneratedDate == nil {
+		t.Fatal("generate: want last_generated_date set")
+	}
+
+	// -------------------------------------------------------------------------
+	// abc: both classified items are due (never counted); the empty, unclassified
+	// location is excluded twice over. Quantities are snapshotted into the items.
+
+	abc, err := bus.Create(ctx, countplanbus.NewCountPlan{
+		Name: "ABC", Rule: countplanbus.Rules.ABC, WarehouseID: &warehouseID, IsActive: true, CreatedBy: userID,
+	})
+	if err != nil {
+		t.Fatalf("create abc: %s", err)
+	}
+
+	run, err = bus.Generate(ctx, abc, userID, now)
+	if err != nil {
+		t.Fatalf("generate abc: %s", err)
+	}
+	if run.ItemCount != 2 {
+		t.Fatalf("generate abc: want 2 items, got %d", run.ItemCount)
+	}
+
+	items, err := db.BusDomain.CycleCountItem.Query(ctx, cyclecountitembus.QueryFilter{SessionID: &run.SessionID},
+		cyclecountitembus.DefaultOrderBy, page.MustParse("1", "10"))
+	if err != nil {
+		t.Fatalf("query items: %s", err)
+	}
+	got := map[uuid.UUID]int{}
+	for _, it := range items {
+		got[it.LocationID] = it.SystemQuantity
+	}
+	if got[stock[1].loc] != 7 || got[stock[2].loc] != 12 || len(got) != 2 {
+		t.Fatalf("generate abc: want snapshots 7 and 12, got %v", got)
+	}
+
+	// -------------------------------------------------------------------------
+	// Inactive plans do not generate; plans with runs cannot be deleted.
+
+	off := false
+	abc, err = bus.Update(ctx, abc, countplanbus.UpdateCountPlan{IsActive: &off})
+	if err != nil {
+		t.Fatalf("deactivate: %s", err)
+	}
+	if _, err := bus.Generate(ctx, abc, userID, now); !errors.Is(err, countplanbus.ErrInactive) {
+		t.Fatalf("generate inactive: want ErrInactive, got %v", err)
+	}
+
+	if err := bus.Delete(ctx, zero); !errors.Is(err, countplanbus.ErrForeignKeyViolation) {
+		t.Fatalf("delete with runs: want ErrForeignKeyViolation, got %v", err)
+	}
+}
+
+// Test_CountPlan_RandomSample checks the sample is capped per zone and stable
+// for a plan within a day, so a preview matches the generation that follows.
+func Test_CountPlan_RandomSample(t *testing.T) {
+	t.Parallel()
+
+	db := dbtest.NewDatabase(t, "Test_CountPlan_RandomSample")
+
+	sd, err := insertSeedData(db.BusDomain)
+	if err != nil {
+		t.Fatalf("seeding: %s", err)
+	}
+
+	ctx := context.Background()
+	userID := sd.Admins[0].ID
+	now := time.Now()
+
+	locIDs := make(uuid.UUIDs, len(sd.InventoryLocations))
+	zones := map[uuid.UUID]bool{}
+	for i, l := range sd.InventoryLocations {
+		locIDs[i] = l.LocationID
+		zones[l.ZoneID] = true
+	}
+	if _, err := inventoryitembus.TestSeedInventoryItems(ctx, len(locIDs), locIDs, uuid.UUIDs{sd.Products[0].ProductID}, db.BusDomain.InventoryItem); err != nil {
+		t.Fatalf("seeding inventory items: %s", err)
+	}
+
+	plan, err := db.BusDomain.CountPlan.Create(ctx, countplanbus.NewCountPlan{
+		Name: "Spot check", Rule: countplanbus.Rules.RandomSample, SampleSize: 1, IsActive: true, CreatedBy: userID,
+	})
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+
+	first, err := db.BusDomain.CountPlan.Preview(ctx, plan, now)
+	if err != nil {
+		t.Fatalf("preview: %s", err)
+	}
+	if len(first) == 0 || len(first) > len(zones) {
+		t.Fatalf("preview: want between 1 and %d candidates, got %d", len(zones), len(first))
+	}
+
+	perZone := map[uuid.UUID]int{}
+	for _, c := range first {
+		perZone[c.ZoneID]++
+		if perZone[c.ZoneID] > 1 {
+			t.Fatalf("preview: zone %s sampled more than once", c.ZoneID)
+		}
+		if !strings.HasPrefix(c.Reason, "random_sample") {
+			t.Fatalf("preview: unexpected reason %q", c.Reason)
+		}
+	}
+
+	second, err := db.BusDomain.CountPlan.Preview(ctx, plan, now)
+	if err != nil {
+		t.Fatalf("preview again: %s", err)
+	}
+	if fmt.Sprint(first) != fmt.Sprint(second) {
+		t.Fatalf("preview: want a stable sample, got %v then %v", first, second)
+	}
+}
+
+func insertSeedData(busDomain dbtest.BusDomain) (unitest.SeedData, error) {
+	ctx := context.Background()
+
+	admins, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.Admin, busDomain.User)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding user : %w", err)
+	}
+
+	regions, err := busDomain.Region.Query(ctx, regionbus.QueryFilter{}, regionbus.DefaultOrderBy, page.MustParse("1", "5"))
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("querying regions : %w", err)
+	}
+	regionIDs := make([]uuid.UUID, len(regions))
+	for i, r := range regions {
+		regionIDs[i] = r.ID
+	}
+
+	cities, err := citybus.TestSeedCities(ctx, 3, regionIDs, busDomain.City)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding cities : %w", err)
+	}
+	cityIDs := make([]uuid.UUID, len(cities))
+	for i, c := range cities {
+		cityIDs[i] = c.ID
+	}
+
+	streets, err := streetbus.TestSeedStreets(ctx, 3, cityIDs, busDomain.Street)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding streets : %w", err)
+	}
+	streetIDs := make([]uuid.UUID, len(streets))
+	for i, s := range streets {
+		streetIDs[i] = s.ID
+	}
+
+	tzs, err := busDomain.Timezone.QueryAll(ctx)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("querying timezones : %w", err)
+	}
+	tzIDs := make([]uuid.UUID, len(tzs))
+	for i, tz := range tzs {
+		tzIDs[i] = tz.ID
+	}
+
+	contactInfos, err := contactinfosbus.TestSeedContactInfos(ctx, 2, streetIDs, tzIDs, busDomain.ContactInfos)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding contact info : %w", err)
+	}
+	contactIDs := make(uuid.UUIDs, len(contactInfos))
+	for i, c := range contactInfos {
+		contactIDs[i] = c.ID
+	}
+
+	brands, err := brandbus.TestSeedBrands(ctx, 2, contactIDs, busDomain.Brand)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding brand : %w", err)
+	}
+	brandIDs := make(uuid.UUIDs, len(brands))
+	for i, b := range brands {
+		brandIDs[i] = b.BrandID
+	}
+
+	categories, err := productcategorybus.TestSeedProductCategories(ctx, 2, busDomain.ProductCategory)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding product category : %w", err)
+	}
+	categoryIDs := make(uuid.UUIDs, len(categories))
+	for i, pc := range categories {
+		categoryIDs[i] = pc.ProductCategoryID
+	}
+
+	products, err := productbus.TestSeedProducts(ctx, 5, brandIDs, categoryIDs, busDomain.Product)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding product : %w", err)
+	}
+
+	warehouses, err := warehousebus.TestSeedWarehouses(ctx, 1, admins[0].ID, streetIDs, busDomain.Warehouse)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding warehouses : %w", err)
+	}
+	warehouseIDs := []uuid.UUID{warehouses[0].ID}
+
+	zones, err := zonebus.TestSeedZone(ctx, 3, warehouseIDs, busDomain.Zones)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding zones : %w", err)
+	}
+
+	locations, err := inventorylocationbus.TestSeedInventoryLocations(ctx, 6, warehouseIDs, zones, busDomain.InventoryLocation)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding inventory locations : %w", err)
+	}
+
+	return unitest.SeedData{
+		Admins:             []unitest.User{{User: admins[0]}},
+		Products:           products,
+		InventoryLocations: locations,
+	}, nil
+}
@@ -0,0 +1,122 @@
+package countplanbus
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/delegate"
+)
+
+// DomainName represents the name of this domain for delegate events.
+const DomainName = "countplan"
+
+// EntityName is the workflow entity name used for event matching.
+const EntityName = "count_plans"
+
+// Delegate action constants.
+const (
+	ActionCreated = "created"
+	ActionUpdated = "updated"
+	ActionDeleted = "deleted"
+)
+
+// =============================================================================
+// Created Event
+// =============================================================================
+
+type ActionCreatedParms struct {
+	EntityID uuid.UUID `json:"entityID"`
+	UserID   uuid.UUID `json:"userID"`
+	Entity   CountPlan `json:"entity"`
+}
+
+func (p *ActionCreatedParms) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func ActionCreatedData(cp CountPlan) delegate.Data {
+	params := ActionCreatedParms{
+		EntityID: cp.ID,
+		UserID:   cp.CreatedBy,
+		Entity:   cp,
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    DomainName,
+		Action:    ActionCreated,
+		RawParams: rawParams,
+	}
+}
+
+// =============================================================================
+// Updated Event
+// =============================================================================
+
+type ActionUpdatedParms struct {
+	EntityID     uuid.UUID `json:"entityID"`
+	UserID       uuid.UUID `json:"userID"`
+	Entity       CountPlan `json:"entity"`
+	BeforeEntity CountPlan `json:"beforeEntity,omitempty"`
+}
+
+func (p *ActionUpdatedParms) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func ActionUpdatedData(before, after CountPlan) delegate.Data {
+	params := ActionUpdatedParms{
+		EntityID:     after.ID,
+		UserID:       after.CreatedBy,
+		Entity:       after,
+		BeforeEntity: before,
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    DomainName,
+		Action:    ActionUpdated,
+		RawParams: rawParams,
+	}
+}
+
+// =============================================================================
+// Deleted Event
+// =============================================================================
+
+type ActionDeletedParms struct {
+	EntityID uuid.UUID `json:"entityID"`
+	UserID   uuid.UUID `json:"userID"`
+	Entity   CountPlan `json:"entity"`
+}
+
+func (p *ActionDeletedParms) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func ActionDeletedData(cp CountPlan) delegate.Data {
+	params := ActionDeletedParms{
+		EntityID: cp.ID,
+		UserID:   cp.CreatedBy,
+		Entity:   cp,
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    DomainName,
+		Action:    ActionDeleted,
+		RawParams: rawParams,
+	}
+}
@@ -0,0 +1,12 @@
+package countplanbus
+
+import "github.com/google/uuid"
+
+// QueryFilter holds optional filters for querying count plans.
+type QueryFilter struct {
+	ID          *uuid.UUID
+	Name        *string
+	Rule        *Rule
+	WarehouseID *uuid.UUID
+	IsActive    *bool
+}
@@ -0,0 +1,91 @@
+package countplanbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JSON tags are required for workflow event serialization. The workflow system
+// marshals business models to JSON for RawData in TriggerEvents.
+
+// CountPlan is a standing policy that generates cycle count sessions. Which
+// parameters apply depends on Rule: ADays/BDays/CDays for Rules.ABC,
+// LookbackDays for Rules.NegativeAdjustment and SampleSize for
+// Rules.RandomSample. WarehouseID and ZoneID optionally narrow the plan's scope
+// and MaxItems, when positive, caps the items one session holds.
+type CountPlan struct {
+	ID                uuid.UUID  `json:"id"`
+	Name              string     `json:"name"`
+	Rule              Rule       `json:"rule"`
+	WarehouseID       *uuid.UUID `json:"warehouse_id,omitempty"`
+	ZoneID            *uuid.UUID `json:"zone_id,omitempty"`
+	ADays             int        `json:"a_days"`
+	BDays             int        `json:"b_days"`
+	CDays             int        `json:"c_days"`
+	LookbackDays      int        `json:"lookback_days"`
+	SampleSize        int        `json:"sample_size"`
+	MaxItems          int        `json:"max_items"`
+	IsActive          bool       `json:"is_active"`
+	LastGeneratedDate *time.Time `json:"last_generated_date,omitempty"`
+	CreatedBy         uuid.UUID  `json:"created_by"`
+	CreatedDate       time.Time  `json:"created_date"`
+	UpdatedDate       time.Time  `json:"updated_date"`
+}
+
+// NewCountPlan contains the information needed to create a new count plan.
+// Zero-valued rule parameters take the defaults: A monthly, B quarterly, C
+// yearly, a 30 day lookback and a sample of 5 locations per zone.
+type NewCountPlan struct {
+	Name         string     `json:"name"`
+	Rule         Rule       `json:"rule"`
+	WarehouseID  *uuid.UUID `json:"warehouse_id,omitempty"`
+	ZoneID       *uuid.UUID `json:"zone_id,omitempty"`
+	ADays        int        `json:"a_days"`
+	BDays        int        `json:"b_days"`
+	CDays        int        `json:"c_days"`
+	LookbackDays int        `json:"lookback_days"`
+	SampleSize   int        `json:"sample_size"`
+	MaxItems     int        `json:"max_items"`
+	IsActive     bool       `json:"is_active"`
+	CreatedBy    uuid.UUID  `json:"created_by"`
+}
+
+// UpdateCountPlan contains the information that can be changed on a count plan.
+// All fields are optional pointers; nil means "do not update this field."
+type UpdateCountPlan struct {
+	Name         *string    `json:"name,omitempty"`
+	Rule         *Rule      `json:"rule,omitempty"`
+	WarehouseID  *uuid.UUID `json:"warehouse_id,omitempty"`
+	ZoneID       *uuid.UUID `json:"zone_id,omitempty"`
+	ADays        *int       `json:"a_days,omitempty"`
+	BDays        *int       `json:"b_days,omitempty"`
+	CDays        *int       `json:"c_days,omitempty"`
+	LookbackDays *int       `json:"lookback_days,omitempty"`
+	SampleSize   *int       `json:"sample_size,omitempty"`
+	MaxItems     *int       `json:"max_items,omitempty"`
+	IsActive     *bool      `json:"is_active,omitempty"`
+}
+
+// Candidate is an inventory item a plan selects for counting, with the system
+// quantity it would be snapshotted at and why it was selected (the rule name,
+// or "abc:A" style for Rules.ABC).
+type Candidate struct {
+	ProductID      uuid.UUID `json:"product_id"`
+	LocationID     uuid.UUID `json:"location_id"`
+	ZoneID         uuid.UUID `json:"zone_id"`
+	WarehouseID    uuid.UUID `json:"warehouse_id"`
+	SystemQuantity int       `json:"system_quantity"`
+	Reason         string    `json:"reason"`
+}
+
+// Run records one generation of a plan: the session it produced and how many
+// items it holds. SessionID is uuid.Nil once that session has been deleted.
+type Run struct {
+	ID            uuid.UUID `json:"id"`
+	PlanID        uuid.UUID `json:"plan_id"`
+	SessionID     uuid.UUID `json:"session_id"`
+	ItemCount     int       `json:"item_count"`
+	GeneratedBy   uuid.UUID `json:"generated_by"`
+	GeneratedDate time.Time `json:"generated_date"`
+}
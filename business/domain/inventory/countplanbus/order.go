@@ -0,0 +1,14 @@
+package countplanbus
+
+import "github.com/timmaaaz/ichor/business/sdk/order"
+
+// DefaultOrderBy represents the default ordering for count plan queries.
+var DefaultOrderBy = order.NewBy(OrderByName, order.ASC)
+
+const (
+	OrderByID                = "id"
+	OrderByName              = "name"
+	OrderByRule              = "rule"
+	OrderByLastGeneratedDate = "last_generated_date"
+	OrderByCreatedDate       = "created_date"
+)
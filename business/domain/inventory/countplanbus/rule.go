@@ -0,0 +1,80 @@
+package countplanbus
+
+import "fmt"
+
+type ruleSet struct {
+	ABC                Rule
+	ZeroOnHand         Rule
+	NegativeAdjustment Rule
+	RandomSample       Rule
+}
+
+// Rules represents the set of policies a count plan can generate sessions from.
+var Rules = ruleSet{
+	ABC:                newRule("abc"),
+	ZeroOnHand:         newRule("zero_on_hand"),
+	NegativeAdjustment: newRule("negative_adjustment"),
+	RandomSample:       newRule("random_sample"),
+}
+
+// =============================================================================
+
+// Set of known rules.
+var rules = make(map[string]Rule)
+
+// Rule represents the policy a count plan selects items to count by.
+type Rule struct {
+	name string
+}
+
+func newRule(s string) Rule {
+	r := Rule{s}
+	rules[s] = r
+	return r
+}
+
+// String returns the name of the rule.
+func (r Rule) String() string {
+	return r.name
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (r Rule) Equal(r2 Rule) bool {
+	return r.name == r2.name
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r Rule) MarshalText() ([]byte, error) {
+	return []byte(r.name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *Rule) UnmarshalText(data []byte) error {
+	rule, err := ParseRule(string(data))
+	if err != nil {
+		return err
+	}
+	*r = rule
+	return nil
+}
+
+// =============================================================================
+
+// ParseRule parses the string value and returns a rule if one exists.
+func ParseRule(value string) (Rule, error) {
+	r, exists := rules[value]
+	if !exists {
+		return Rule{}, fmt.Errorf("invalid rule %q", value)
+	}
+	return r, nil
+}
+
+// MustParseRule parses the string value and returns a rule if one exists.
+// Panics if the rule is invalid.
+func MustParseRule(value string) Rule {
+	r, err := ParseRule(value)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
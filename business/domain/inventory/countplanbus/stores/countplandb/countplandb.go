@@ -0,0 +1,436 @@
+// Package countplandb contains count plan related CRUD functionality.
+package countplandb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// Store manages the set of APIs for count plan database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// NewWithTx constructs a new Store value replacing the sqlx.DB
+// value with a sqlx.Tx value that is currently inside a transaction.
+func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (countplanbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		log: s.log,
+		db:  ec,
+	}, nil
+}
+
+// Create inserts a new count plan into the database.
+func (s *Store) Create(ctx context.Context, plan countplanbus.CountPlan) error {
+	const q = `
+	INSERT INTO inventory.count_plans
+		(id, name, rule, warehouse_id, zone_id, a_days, b_days, c_days, lookback_days, sample_size,
+		 max_items, is_active, last_generated_date, created_by, created_date, updated_date)
+	VALUES
+		(:id, :name, :rule, :warehouse_id, :zone_id, :a_days, :b_days, :c_days, :lookback_days, :sample_size,
+		 :max_items, :is_active, :last_generated_date, :created_by, :created_date, :updated_date)
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBCountPlan(plan)); err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return fmt.Errorf("namedexeccontext: %w", countplanbus.ErrForeignKeyViolation)
+		}
+		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+			return fmt.Errorf("namedexeccontext: %w", countplanbus.ErrUniqueEntry)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Update modifies an existing count plan in the database.
+func (s *Store) Update(ctx context.Context, plan countplanbus.CountPlan) error {
+	const q = `
+	UPDATE inventory.count_plans
+	SET
+		name                = :name,
+		rule                = :rule,
+		warehouse_id        = :warehouse_id,
+		zone_id             = :zone_id,
+		a_days              = :a_days,
+		b_days              = :b_days,
+		c_days              = :c_days,
+		lookback_days       = :lookback_days,
+		sample_size         = :sample_size,
+		max_items           = :max_items,
+		is_active           = :is_active,
+		last_generated_date = :last_generated_date,
+		updated_date        = :updated_date
+	WHERE
+		id = :id
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBCountPlan(plan)); err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return fmt.Errorf("namedexeccontext: %w", countplanbus.ErrForeignKeyViolation)
+		}
+		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+			return fmt.Errorf("namedexeccontext: %w", countplanbus.ErrUniqueEntry)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a count plan from the database. A plan with recorded runs is
+// referenced by them and fails with ErrForeignKeyViolation.
+func (s *Store) Delete(ctx context.Context, plan countplanbus.CountPlan) error {
+	const q = `
+	DELETE FROM inventory.count_plans
+	WHERE id = :id
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBCountPlan(plan)); err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return fmt.Errorf("namedexeccontext: %w", countplanbus.ErrForeignKeyViolation)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of count plans from the database.
+func (s *Store) Query(ctx context.Context, filter countplanbus.QueryFilter, orderBy order.By, page page.Page) ([]countplanbus.CountPlan, error) {
+	data := map[string]any{
+		"offset":        (page.Number() - 1) * page.RowsPerPage(),
+		"rows_per_page": page.RowsPerPage(),
+	}
+
+	const q = `
+	SELECT
+		id, name, rule, warehouse_id, zone_id, a_days, b_days, c_days, lookback_days, sample_size,
+		max_items, is_active, last_generated_date, created_by, created_date, updated_date
+	FROM
+		inventory.count_plans
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbPlans []countPlan
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbPlans); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	plans, err := toBusCountPlans(dbPlans)
+	if err != nil {
+		return nil, fmt.Errorf("tobuscountplans: %w", err)
+	}
+
+	return plans, nil
+}
+
+// Count returns the total number of count plans matching the filter.
+func (s *Store) Count(ctx context.Context, filter countplanbus.QueryFilter) (int, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		COUNT(1) AS count
+	FROM
+		inventory.count_plans
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID retrieves a single count plan by its ID.
+func (s *Store) QueryByID(ctx context.Context, planID uuid.UUID) (countplanbus.CountPlan, error) {
+	data := map[string]any{
+		"id": planID.String(),
+	}
+
+	const q = `
+	SELECT
+		id, name, rule, warehouse_id, zone_id, a_days, b_days, c_days, lookback_days, sample_size,
+		max_items, is_active, last_generated_date, created_by, created_date, updated_date
+	FROM
+		inventory.count_plans
+	WHERE
+		id = :id
+	`
+
+	var dbPlan countPlan
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbPlan); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return countplanbus.CountPlan{}, countplanbus.ErrNotFound
+		}
+		return countplanbus.CountPlan{}, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	plan, err := toBusCountPlan(dbPlan)
+	if err != nil {
+		return countplanbus.CountPlan{}, fmt.Errorf("tobuscountplan: %w", err)
+	}
+
+	return plan, nil
+}
+
+// QueryCandidates returns the inventory items the plan selects for counting at
+// now, in walk order (warehouse, zone, aisle, rack, shelf, bin). Transit
+// locations and locations already in an open (draft or in_progress) session
+// are never selected. For Rules.ABC, A items come before B before C so that a
+// MaxItems cap keeps the most valuable counts.
+func (s *Store) QueryCandidates(ctx context.Context, plan countplanbus.CountPlan, now time.Time) ([]countplanbus.Candidate, error) {
+	data := map[string]any{
+		"now": now.UTC(),
+	}
+
+	// eligible restricts a location (aliased il) to the plan's scope: not a transit
+	// location, not already being counted, and inside the plan's warehouse/zone.
+	eligible := `
+		NOT il.is_transit
+		AND il.id NOT IN (
+			SELECT cci.location_id
+			FROM inventory.cycle_count_items cci
+			JOIN inventory.cycle_count_sessions ccs ON ccs.id = cci.session_id
+			WHERE ccs.status IN ('draft', 'in_progress')
+		)`
+	if plan.WarehouseID != nil {
+		data["warehouse_id"] = *plan.WarehouseID
+		eligible += " AND il.warehouse_id = :warehouse_id"
+	}
+	if plan.ZoneID != nil {
+		data["zone_id"] = *plan.ZoneID
+		eligible += " AND il.zone_id = :zone_id"
+	}
+
+	buf := bytes.NewBufferString(`
+	SELECT
+		ii.product_id, ii.location_id, il.zone_id, il.warehouse_id,
+		ii.quantity AS system_quantity, `)
+
+	switch plan.Rule {
+	case countplanbus.Rules.ABC:
+		data["a_days"] = plan.ADays
+		data["b_days"] = plan.BDays
+		data["c_days"] = plan.CDays
+		buf.WriteString(`'abc:' || abc.class AS reason
+	FROM inventory.inventory_items ii
+	JOIN inventory.inventory_locations il ON il.id = ii.location_id
+	JOIN LATERAL (
+		SELECT pc.abc_classification AS class
+		FROM products.product_costs pc
+		WHERE pc.product_id = ii.product_id
+		ORDER BY pc.effective_date DESC
+		LIMIT 1
+	) abc ON abc.class IN ('A', 'B', 'C')
+	WHERE` + eligible + `
+		AND NOT EXISTS (
+			SELECT 1
+			FROM inventory.cycle_count_items c
+			JOIN inventory.cycle_count_sessions cs ON cs.id = c.session_id
+			WHERE c.product_id = ii.product_id
+				AND c.location_id = ii.location_id
+				AND cs.status = 'completed'
+				AND cs.completed_date > CAST(:now AS TIMESTAMP) - make_interval(days => CASE abc.class
+					WHEN 'A' THEN CAST(:a_days AS INT)
+					WHEN 'B' THEN CAST(:b_days AS INT)
+					ELSE CAST(:c_days AS INT) END)
+		)`)
+
+	case countplanbus.Rules.ZeroOnHand:
+		buf.WriteString(`'zero_on_hand' AS reason
+	FROM inventory.inventory_items ii
+	JOIN inventory.inventory_locations il ON il.id = ii.location_id
+	WHERE` + eligible + `
+		AND ii.quantity = 0`)
+
+	case countplanbus.Rules.NegativeAdjustment:
+		data["lookback_days"] = plan.LookbackDays
+		buf.WriteString(`'negative_adjustment' AS reason
+	FROM inventory.inventory_items ii
+	JOIN inventory.inventory_locations il ON il.id = ii.location_id
+	WHERE` + eligible + `
+		AND ii.location_id IN (
+			SELECT ia.location_id
+			FROM inventory.inventory_adjustments ia
+			WHERE ia.quantity_change < 0
+				AND ia.adjustment_date >= CAST(:now AS TIMESTAMP) - make_interval(days => CAST(:lookback_days AS INT))
+		)`)
+
+	case countplanbus.Rules.RandomSample:
+		// Locations are shuffled by a hash seeded with the plan and the day, so a
+		// preview and the generation that follows it on the same day pick the
+		// same sample, while each day draws a fresh one.
+		data["sample_size"] = plan.SampleSize
+		data["seed"] = plan.ID.String() + now.UTC().Format(time.DateOnly)
+		buf.WriteString(`'random_sample' AS reason
+	FROM inventory.inventory_items ii
+	JOIN inventory.inventory_locations il ON il.id = ii.location_id
+	WHERE ii.location_id IN (
+		SELECT sample.id
+		FROM (
+			SELECT il.id, row_number() OVER (
+				PARTITION BY il.zone_id ORDER BY md5(CAST(il.id AS TEXT) || :seed)
+			) AS rn
+			FROM inventory.inventory_locations il
+			WHERE` + eligible + `
+				AND EXISTS (SELECT 1 FROM inventory.inventory_items x WHERE x.location_id = il.id)
+		) sample
+		WHERE sample.rn <= :sample_size
+	)`)
+
+	default:
+		return nil, fmt.Errorf("unknown rule %q", plan.Rule)
+	}
+
+	if id, ok := sqldb.GetScenarioFilter(ctx); ok {
+		data["scenario_id"] = id
+		buf.WriteString(" AND (ii.scenario_id IS NULL OR ii.scenario_id = :scenario_id)")
+	}
+
+	buf.WriteString(" ORDER BY ")
+	if plan.Rule == countplanbus.Rules.ABC {
+		buf.WriteString("reason, ")
+	}
+	buf.WriteString("il.warehouse_id, il.zone_id, il.aisle, il.rack, il.shelf, il.bin, ii.product_id")
+
+	if plan.MaxItems > 0 {
+		data["max_items"] = plan.MaxItems
+		buf.WriteString(" LIMIT :max_items")
+	}
+
+	var dbCands []candidate
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbCands); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusCandidates(dbCands), nil
+}
+
+// LockGeneration takes a transaction-scoped advisory lock that serializes
+// session generation, so concurrent plans cannot both select a location
+// before either has created its session.
+func (s *Store) LockGeneration(ctx context.Context) error {
+	const q = `SELECT pg_advisory_xact_lock(hashtext('inventory.count_plans'))`
+
+	if err := sqldb.ExecContext(ctx, s.log, s.db, q); err != nil {
+		return fmt.Errorf("execcontext: %w", err)
+	}
+
+	return nil
+}
+
+// CreateRun records a generation of a plan.
+func (s *Store) CreateRun(ctx context.Context, run countplanbus.Run) error {
+	const q = `
+	INSERT INTO inventory.count_plan_runs
+		(id, plan_id, session_id, item_count, generated_by, generated_date)
+	VALUES
+		(:id, :plan_id, :session_id, :item_count, :generated_by, :generated_date)
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBRun(run)); err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return fmt.Errorf("namedexeccontext: %w", countplanbus.ErrForeignKeyViolation)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// QueryRuns retrieves a plan's runs, newest first.
+func (s *Store) QueryRuns(ctx context.Context, planID uuid.UUID, page page.Page) ([]countplanbus.Run, error) {
+	data := map[string]any{
+		"plan_id":       planID,
+		"offset":        (page.Number() - 1) * page.RowsPerPage(),
+		"rows_per_page": page.RowsPerPage(),
+	}
+
+	const q = `
+	SELECT
+		id, plan_id, session_id, item_count, generated_by, generated_date
+	FROM
+		inventory.count_plan_runs
+	WHERE
+		plan_id = :plan_id
+	ORDER BY
+		generated_date DESC, id
+	OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY
+	`
+
+	var dbRuns []run
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbRuns); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusRuns(dbRuns), nil
+}
+
+// CountRuns returns the number of runs recorded for a plan.
+func (s *Store) CountRuns(ctx context.Context, planID uuid.UUID) (int, error) {
+	data := map[string]any{
+		"plan_id": planID,
+	}
+
+	const q = `
+	SELECT
+		COUNT(1) AS count
+	FROM
+		inventory.count_plan_runs
+	WHERE
+		plan_id = :plan_id
+	`
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &count); err != nil {
+		return 0, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	return count.Count, nil
+}
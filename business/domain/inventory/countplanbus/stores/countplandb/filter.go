@@ -0,0 +1,43 @@
+package countplandb
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+)
+
+func applyFilter(filter countplanbus.QueryFilter, data map[string]any, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["id"] = *filter.ID
+		wc = append(wc, "id = :id")
+	}
+
+	if filter.Name != nil {
+		data["name"] = fmt.Sprintf("%%%s%%", *filter.Name)
+		wc = append(wc, "name ILIKE :name")
+	}
+
+	if filter.Rule != nil {
+		data["rule"] = filter.Rule.String()
+		wc = append(wc, "rule = :rule")
+	}
+
+	if filter.WarehouseID != nil {
+		data["warehouse_id"] = *filter.WarehouseID
+		wc = append(wc, "warehouse_id = :warehouse_id")
+	}
+
+	if filter.IsActive != nil {
+		data["is_active"] = *filter.IsActive
+		wc = append(wc, "is_active = :is_active")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
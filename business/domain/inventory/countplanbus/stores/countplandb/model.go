@@ -0,0 +1,182 @@
+package countplandb
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+)
+
+// countPlan mirrors the inventory.count_plans DB row.
+type countPlan struct {
+	ID                uuid.UUID     `db:"id"`
+	Name              string        `db:"name"`
+	Rule              string        `db:"rule"`
+	WarehouseID       uuid.NullUUID `db:"warehouse_id"`
+	ZoneID            uuid.NullUUID `db:"zone_id"`
+	ADays             int           `db:"a_days"`
+	BDays             int           `db:"b_days"`
+	CDays             int           `db:"c_days"`
+	LookbackDays      int           `db:"lookback_days"`
+	SampleSize        int           `db:"sample_size"`
+	MaxItems          int           `db:"max_items"`
+	IsActive          bool          `db:"is_active"`
+	LastGeneratedDate sql.NullTime  `db:"last_generated_date"`
+	CreatedBy         uuid.UUID     `db:"created_by"`
+	CreatedDate       time.Time     `db:"created_date"`
+	UpdatedDate       time.Time     `db:"updated_date"`
+}
+
+func toDBCountPlan(bus countplanbus.CountPlan) countPlan {
+	var lastGenerated sql.NullTime
+	if bus.LastGeneratedDate != nil {
+		lastGenerated = sql.NullTime{Time: bus.LastGeneratedDate.UTC(), Valid: true}
+	}
+
+	return countPlan{
+		ID:                bus.ID,
+		Name:              bus.Name,
+		Rule:              bus.Rule.String(),
+		WarehouseID:       toNullUUID(bus.WarehouseID),
+		ZoneID:            toNullUUID(bus.ZoneID),
+		ADays:             bus.ADays,
+		BDays:             bus.BDays,
+		CDays:             bus.CDays,
+		LookbackDays:      bus.LookbackDays,
+		SampleSize:        bus.SampleSize,
+		MaxItems:          bus.MaxItems,
+		IsActive:          bus.IsActive,
+		LastGeneratedDate: lastGenerated,
+		CreatedBy:         bus.CreatedBy,
+		CreatedDate:       bus.CreatedDate,
+		UpdatedDate:       bus.UpdatedDate,
+	}
+}
+
+func toBusCountPlan(db countPlan) (countplanbus.CountPlan, error) {
+	rule, err := countplanbus.ParseRule(db.Rule)
+	if err != nil {
+		return countplanbus.CountPlan{}, fmt.Errorf("parse rule %q: %w", db.Rule, err)
+	}
+
+	var lastGenerated *time.Time
+	if db.LastGeneratedDate.Valid {
+		t := db.LastGeneratedDate.Time
+		lastGenerated = &t
+	}
+
+	return countplanbus.CountPlan{
+		ID:                db.ID,
+		Name:              db.Name,
+		Rule:              rule,
+		WarehouseID:       fromNullUUID(db.WarehouseID),
+		ZoneID:            fromNullUUID(db.ZoneID),
+		ADays:             db.ADays,
+		BDays:             db.BDays,
+		CDays:             db.CDays,
+		LookbackDays:      db.LookbackDays,
+		SampleSize:        db.SampleSize,
+		MaxItems:          db.MaxItems,
+		IsActive:          db.IsActive,
+		LastGeneratedDate: lastGenerated,
+		CreatedBy:         db.CreatedBy,
+		CreatedDate:       db.CreatedDate,
+		UpdatedDate:       db.UpdatedDate,
+	}, nil
+}
+
+func toBusCountPlans(dbs []countPlan) ([]countplanbus.CountPlan, error) {
+	plans := make([]countplanbus.CountPlan, len(dbs))
+	for i, db := range dbs {
+		p, err := toBusCountPlan(db)
+		if err != nil {
+			return nil, err
+		}
+		plans[i] = p
+	}
+	return plans, nil
+}
+
+// =============================================================================
+
+// candidate is one row of the candidate query.
+type candidate struct {
+	ProductID      uuid.UUID `db:"product_id"`
+	LocationID     uuid.UUID `db:"location_id"`
+	ZoneID         uuid.UUID `db:"zone_id"`
+	WarehouseID    uuid.UUID `db:"warehouse_id"`
+	SystemQuantity int       `db:"system_quantity"`
+	Reason         string    `db:"reason"`
+}
+
+func toBusCandidates(dbs []candidate) []countplanbus.Candidate {
+	cands := make([]countplanbus.Candidate, len(dbs))
+	for i, db := range dbs {
+		cands[i] = countplanbus.Candidate{
+			ProductID:      db.ProductID,
+			LocationID:     db.LocationID,
+			ZoneID:         db.ZoneID,
+			WarehouseID:    db.WarehouseID,
+			SystemQuantity: db.SystemQuantity,
+			Reason:         db.Reason,
+		}
+	}
+	return cands
+}
+
+// =============================================================================
+
+// run mirrors the inventory.count_plan_runs DB row.
+type run struct {
+	ID            uuid.UUID     `db:"id"`
+	PlanID        uuid.UUID     `db:"plan_id"`
+	SessionID     uuid.NullUUID `db:"session_id"`
+	ItemCount     int           `db:"item_count"`
+	GeneratedBy   uuid.UUID     `db:"generated_by"`
+	GeneratedDate time.Time     `db:"generated_date"`
+}
+
+func toDBRun(bus countplanbus.Run) run {
+	return run{
+		ID:            bus.ID,
+		PlanID:        bus.PlanID,
+		SessionID:     uuid.NullUUID{UUID: bus.SessionID, Valid: bus.SessionID != uuid.Nil},
+		ItemCount:     bus.ItemCount,
+		GeneratedBy:   bus.GeneratedBy,
+		GeneratedDate: bus.GeneratedDate.UTC(),
+	}
+}
+
+func toBusRuns(dbs []run) []countplanbus.Run {
+	runs := make([]countplanbus.Run, len(dbs))
+	for i, db := range dbs {
+		runs[i] = countplanbus.Run{
+			ID:            db.ID,
+			PlanID:        db.PlanID,
+			SessionID:     db.SessionID.UUID,
+			ItemCount:     db.ItemCount,
+			GeneratedBy:   db.GeneratedBy,
+			GeneratedDate: db.GeneratedDate,
+		}
+	}
+	return runs
+}
+
+// =============================================================================
+
+func toNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func fromNullUUID(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	v := id.UUID
+	return &v
+}
@@ -0,0 +1,25 @@
+package countplandb
+
+import (
+	"fmt"
+
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+)
+
+var orderByFields = map[string]string{
+	countplanbus.OrderByID:                "id",
+	countplanbus.OrderByName:              "name",
+	countplanbus.OrderByRule:              "rule",
+	countplanbus.OrderByLastGeneratedDate: "last_generated_date",
+	countplanbus.OrderByCreatedDate:       "created_date",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
@@ -0,0 +1,47 @@
+package countplanbus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// TestNewCountPlans generates n new active count plans for testing, cycling
+// through the rules.
+func TestNewCountPlans(n int, createdByIDs []uuid.UUID) []NewCountPlan {
+	all := []Rule{Rules.ABC, Rules.ZeroOnHand, Rules.NegativeAdjustment, Rules.RandomSample}
+
+	plans := make([]NewCountPlan, n)
+	for i := range n {
+		plans[i] = NewCountPlan{
+			Name:      fmt.Sprintf("Count Plan %d", i+1),
+			Rule:      all[i%len(all)],
+			IsActive:  true,
+			CreatedBy: createdByIDs[i%len(createdByIDs)],
+		}
+	}
+
+	return plans
+}
+
+// TestSeedCountPlans creates n count plans in the database for testing.
+func TestSeedCountPlans(ctx context.Context, n int, createdByIDs []uuid.UUID, api *Business) ([]CountPlan, error) {
+	newPlans := TestNewCountPlans(n, createdByIDs)
+
+	plans := make([]CountPlan, len(newPlans))
+	for i, ncp := range newPlans {
+		plan, err := api.Create(ctx, ncp)
+		if err != nil {
+			return nil, err
+		}
+		plans[i] = plan
+	}
+
+	sort.Slice(plans, func(i, j int) bool {
+		return plans[i].ID.String() < plans[j].ID.String()
+	})
+
+	return plans, nil
+}
@@ -33,6 +33,7 @@ type Storer interface {
 	Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]CycleCountItem, error)
 	Count(ctx context.Context, filter QueryFilter) (int, error)
 	QueryByID(ctx context.Context, itemID uuid.UUID) (CycleCountItem, error)
+	QueryByIDForUpdate(ctx context.Context, itemID uuid.UUID) (CycleCountItem, error)
 }
 
 // Business manages the set of APIs for cycle count item access.
@@ -90,6 +91,8 @@ func (b *Business) Create(ctx context.Context, ncci NewCycleCountItem) (CycleCou
 				LocationID:     ncci.LocationID,
 				SystemQuantity: ncci.SystemQuantity,
 				Status:         Statuses.Pending,
+				AssignedTo:     ncci.AssignedTo,
+				RecountOf:      ncci.RecountOf,
 				CreatedDate:    now,
 				UpdatedDate:    now,
 			}
@@ -141,6 +144,9 @@ func (b *Business) Update(ctx context.Context, item CycleCountItem, ucci UpdateC
 			if ucci.CountedDate != nil {
 				item.CountedDate = *ucci.CountedDate
 			}
+			if ucci.AssignedTo != nil {
+				item.AssignedTo = ucci.AssignedTo
+			}
 
 			item.UpdatedDate = time.Now()
 
@@ -219,3 +225,21 @@ func (b *Business) QueryByID(ctx context.Context, itemID uuid.UUID) (CycleCountI
 
 	return item, nil
 }
+
+// QueryByIDForUpdate finds the cycle count item by the specified ID and locks
+// the row until the surrounding transaction ends. Use it to check an item's
+// status before recording a count against it.
+func (b *Business) QueryByIDForUpdate(ctx context.Context, itemID uuid.UUID) (CycleCountItem, error) {
+	ctx, span := otel.AddSpan(ctx, "business.cyclecountitembus.querybyidforupdate")
+	defer span.End()
+
+	item, err := b.storer.QueryByIDForUpdate(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return CycleCountItem{}, err
+		}
+		return CycleCountItem{}, fmt.Errorf("queryByIDForUpdate: itemID[%s]: %w", itemID, err)
+	}
+
+	return item, nil
+}
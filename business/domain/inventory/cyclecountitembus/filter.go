@@ -10,4 +10,6 @@ type QueryFilter struct {
 	LocationID *uuid.UUID
 	Status     *Status
 	CountedBy  *uuid.UUID
+	AssignedTo *uuid.UUID
+	RecountOf  *uuid.UUID
 }
@@ -26,16 +26,24 @@ type CycleCountItem struct {
 	CreatedDate     time.Time  `json:"created_date"`
 	UpdatedDate     time.Time  `json:"updated_date"`
 	ScenarioID      *uuid.UUID `json:"scenario_id,omitempty"`
+
+	// AssignedTo restricts who may count the item; nil means anyone.
+	AssignedTo *uuid.UUID `json:"assigned_to,omitempty"`
+
+	// RecountOf points a recount at the item whose count it re-checks.
+	RecountOf *uuid.UUID `json:"recount_of,omitempty"`
 }
 
 // NewCycleCountItem contains the information needed to create a new cycle count item.
 // Status is always set to Statuses.Pending by the business layer.
 type NewCycleCountItem struct {
-	ItemCode       *string   `json:"item_code,omitempty"`
-	SessionID      uuid.UUID `json:"session_id"`
-	ProductID      uuid.UUID `json:"product_id"`
-	LocationID     uuid.UUID `json:"location_id"`
-	SystemQuantity int       `json:"system_quantity"`
+	ItemCode       *string    `json:"item_code,omitempty"`
+	SessionID      uuid.UUID  `json:"session_id"`
+	ProductID      uuid.UUID  `json:"product_id"`
+	LocationID     uuid.UUID  `json:"location_id"`
+	SystemQuantity int        `json:"system_quantity"`
+	AssignedTo     *uuid.UUID `json:"assigned_to,omitempty"`
+	RecountOf      *uuid.UUID `json:"recount_of,omitempty"`
 }
 
 // UpdateCycleCountItem contains the information that can be changed on a cycle count item.
@@ -47,4 +55,5 @@ type UpdateCycleCountItem struct {
 	Status          *Status    `json:"status,omitempty"`
 	CountedBy       *uuid.UUID `json:"counted_by,omitempty"`
 	CountedDate     *time.Time `json:"counted_date,omitempty"`
+	AssignedTo      *uuid.UUID `json:"assigned_to,omitempty"`
 }
@@ -5,6 +5,7 @@ import "fmt"
 type statusSet struct {
 	Pending          Status
 	Counted          Status
+	Recount          Status
 	VarianceApproved Status
 	VarianceRejected Status
 }
@@ -13,6 +14,7 @@ type statusSet struct {
 var Statuses = statusSet{
 	Pending:          newStatus("pending"),
 	Counted:          newStatus("counted"),
+	Recount:          newStatus("recount"),
 	VarianceApproved: newStatus("variance_approved"),
 	VarianceRejected: newStatus("variance_rejected"),
 }
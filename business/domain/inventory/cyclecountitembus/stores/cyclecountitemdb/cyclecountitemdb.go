@@ -45,11 +45,15 @@ func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (cyclecountitembus.Storer,
 
 // Create inserts a new cycle count item into the database.
 func (s *Store) Create(ctx context.Context, item cyclecountitembus.CycleCountItem) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, sqldb.ScopeByLocation, map[string]uuid.UUID{"location_id": item.LocationID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
 	INSERT INTO inventory.cycle_count_items
-		(id, item_code, session_id, product_id, location_id, system_quantity, counted_quantity, variance, status, counted_by, counted_date, created_date, updated_date, scenario_id)
+		(id, item_code, session_id, product_id, location_id, system_quantity, counted_quantity, variance, status, counted_by, counted_date, created_date, updated_date, scenario_id, assigned_to, recount_of)
 	VALUES
-		(:id, :item_code, :session_id, :product_id, :location_id, :system_quantity, :counted_quantity, :variance, :status, :counted_by, :counted_date, :created_date, :updated_date, :scenario_id)
+		(:id, :item_code, :session_id, :product_id, :location_id, :system_quantity, :counted_quantity, :variance, :status, :counted_by, :counted_date, :created_date, :updated_date, :scenario_id, :assigned_to, :recount_of)
 	`
 
 	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBCycleCountItem(item)); err != nil {
@@ -76,6 +80,7 @@ func (s *Store) Update(ctx context.Context, item cyclecountitembus.CycleCountIte
 		status           = :status,
 		counted_by       = :counted_by,
 		counted_date     = :counted_date,
+		assigned_to      = :assigned_to,
 		updated_date     = :updated_date
 	WHERE
 		id = :id
@@ -117,7 +122,7 @@ func (s *Store) Query(ctx context.Context, filter cyclecountitembus.QueryFilter,
 
 	const q = `
 	SELECT
-		id, item_code, session_id, product_id, location_id, system_quantity, counted_quantity, variance, status, counted_by, counted_date, created_date, updated_date, scenario_id
+		id, item_code, session_id, product_id, location_id, system_quantity, counted_quantity, variance, status, counted_by, counted_date, created_date, updated_date, scenario_id, assigned_to, recount_of
 	FROM
 		inventory.cycle_count_items
 	`
@@ -125,6 +130,7 @@ func (s *Store) Query(ctx context.Context, filter cyclecountitembus.QueryFilter,
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	orderByClause, err := orderByClause(orderBy)
 	if err != nil {
@@ -161,6 +167,7 @@ func (s *Store) Count(ctx context.Context, filter cyclecountitembus.QueryFilter)
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	var count struct {
 		Count int `db:"count"`
@@ -180,7 +187,43 @@ func (s *Store) QueryByID(ctx context.Context, itemID uuid.UUID) (cyclecountitem
 
 	const q = `
 	SELECT
-		id, item_code, session_id, product_id, location_id, system_quantity, counted_quantity, variance, status, counted_by, counted_date, created_date, updated_date, scenario_id
+		id, item_code, session_id, product_id, location_id, system_quantity, counted_quantity, variance, status, counted_by, counted_date, created_date, updated_date, scenario_id, assigned_to, recount_of
+	FROM
+		inventory.cycle_count_items
+	WHERE
+		id = :id
+	`
+
+	buf := bytes.NewBufferString(q)
+	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
+
+	var dbItem cycleCountItem
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &dbItem); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return cyclecountitembus.CycleCountItem{}, cyclecountitembus.ErrNotFound
+		}
+		return cyclecountitembus.CycleCountItem{}, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	item, err := toBusCycleCountItem(dbItem)
+	if err != nil {
+		return cyclecountitembus.CycleCountItem{}, fmt.Errorf("tobuscyclecountitem: %w", err)
+	}
+
+	return item, nil
+}
+
+// QueryByIDForUpdate is QueryByID with a row lock held until the transaction
+// ends.
+func (s *Store) QueryByIDForUpdate(ctx context.Context, itemID uuid.UUID) (cyclecountitembus.CycleCountItem, error) {
+	data := map[string]any{
+		"id": itemID.String(),
+	}
+
+	const q = `
+	SELECT
+		id, item_code, session_id, product_id, location_id, system_quantity, counted_quantity, variance, status, counted_by, counted_date, created_date, updated_date, scenario_id, assigned_to, recount_of
 	FROM
 		inventory.cycle_count_items
 	WHERE
@@ -189,6 +232,8 @@ func (s *Store) QueryByID(ctx context.Context, itemID uuid.UUID) (cyclecountitem
 
 	buf := bytes.NewBufferString(q)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
+	buf.WriteString(" FOR UPDATE")
 
 	var dbItem cycleCountItem
 	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &dbItem); err != nil {
@@ -40,6 +40,16 @@ func applyFilter(filter cyclecountitembus.QueryFilter, data map[string]any, buf
 		wc = append(wc, "counted_by = :counted_by")
 	}
 
+	if filter.AssignedTo != nil {
+		data["assigned_to"] = *filter.AssignedTo
+		wc = append(wc, "assigned_to = :assigned_to")
+	}
+
+	if filter.RecountOf != nil {
+		data["recount_of"] = *filter.RecountOf
+		wc = append(wc, "recount_of = :recount_of")
+	}
+
 	if len(wc) > 0 {
 		buf.WriteString(" WHERE ")
 		buf.WriteString(strings.Join(wc, " AND "))
@@ -26,6 +26,8 @@ type cycleCountItem struct {
 	CreatedDate     time.Time      `db:"created_date"`
 	UpdatedDate     time.Time      `db:"updated_date"`
 	ScenarioID      *uuid.UUID     `db:"scenario_id"`
+	AssignedTo      *uuid.UUID     `db:"assigned_to"`
+	RecountOf       *uuid.UUID     `db:"recount_of"`
 }
 
 func toBusCycleCountItem(db cycleCountItem) (cyclecountitembus.CycleCountItem, error) {
@@ -54,6 +56,8 @@ func toBusCycleCountItem(db cycleCountItem) (cyclecountitembus.CycleCountItem, e
 		CreatedDate:     db.CreatedDate,
 		UpdatedDate:     db.UpdatedDate,
 		ScenarioID:      db.ScenarioID,
+		AssignedTo:      db.AssignedTo,
+		RecountOf:       db.RecountOf,
 	}, nil
 }
 
@@ -90,5 +94,7 @@ func toDBCycleCountItem(bus cyclecountitembus.CycleCountItem) cycleCountItem {
 		CreatedDate:     bus.CreatedDate,
 		UpdatedDate:     bus.UpdatedDate,
 		ScenarioID:      bus.ScenarioID,
+		AssignedTo:      bus.AssignedTo,
+		RecountOf:       bus.RecountOf,
 	}
 }
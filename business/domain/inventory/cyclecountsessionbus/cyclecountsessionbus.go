@@ -34,6 +34,8 @@ type Storer interface {
 	Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]CycleCountSession, error)
 	Count(ctx context.Context, filter QueryFilter) (int, error)
 	QueryByID(ctx context.Context, sessionID uuid.UUID) (CycleCountSession, error)
+	QueryVarianceLines(ctx context.Context, sessionID uuid.UUID) ([]VarianceLine, error)
+	QueryUnitCost(ctx context.Context, productID uuid.UUID) (float64, error)
 }
 
 // Business manages the set of APIs for cycle count session access.
@@ -90,6 +92,8 @@ func (b *Business) Create(ctx context.Context, nccs NewCycleCountSession) (Cycle
 				CreatedBy:   nccs.CreatedBy,
 				CreatedDate: now,
 				UpdatedDate: now,
+				Blind:       nccs.Blind,
+				Recount:     nccs.Recount,
 			}
 
 			if sid, ok := sqldb.GetScenarioFilter(ctx); ok {
@@ -130,6 +134,15 @@ func (b *Business) Update(ctx context.Context, session CycleCountSession, uccs U
 			if uccs.CompletedDate != nil {
 				session.CompletedDate = uccs.CompletedDate
 			}
+			if uccs.Blind != nil {
+				session.Blind = *uccs.Blind
+			}
+			if uccs.Recount != nil {
+				session.Recount = *uccs.Recount
+			}
+			if uccs.Summary != nil {
+				session.Summary = uccs.Summary
+			}
 
 			session.UpdatedDate = time.Now()
 
@@ -208,3 +221,31 @@ func (b *Business) QueryByID(ctx context.Context, sessionID uuid.UUID) (CycleCou
 
 	return session, nil
 }
+
+// QueryVarianceLines returns the session's approved, non-zero variances
+// valued at each product's current unit cost.
+func (b *Business) QueryVarianceLines(ctx context.Context, sessionID uuid.UUID) ([]VarianceLine, error) {
+	ctx, span := otel.AddSpan(ctx, "business.cyclecountsessionbus.queryvariancelines")
+	defer span.End()
+
+	lines, err := b.storer.QueryVarianceLines(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("queryvariancelines: sessionID[%s]: %w", sessionID, err)
+	}
+
+	return lines, nil
+}
+
+// QueryUnitCost returns the product's current unit cost, preferring landed
+// cost over purchase cost. A product without costs is valued at zero.
+func (b *Business) QueryUnitCost(ctx context.Context, productID uuid.UUID) (float64, error) {
+	ctx, span := otel.AddSpan(ctx, "business.cyclecountsessionbus.queryunitcost")
+	defer span.End()
+
+	cost, err := b.storer.QueryUnitCost(ctx, productID)
+	if err != nil {
+		return 0, fmt.Errorf("queryunitcost: productID[%s]: %w", productID, err)
+	}
+
+	return cost, nil
+}
@@ -19,19 +19,111 @@ type CycleCountSession struct {
 	UpdatedDate   time.Time  `json:"updated_date"`
 	CompletedDate *time.Time `json:"completed_date"`
 	ScenarioID    *uuid.UUID `json:"scenario_id,omitempty"`
+
+	// Blind hides system quantities from counters until the session completes.
+	Blind bool `json:"blind"`
+
+	// Recount holds the tolerances beyond which a count is recounted.
+	Recount RecountThreshold `json:"recount"`
+
+	// Summary is the financial impact of the variances posted when the
+	// session completed; nil until then.
+	Summary *VarianceSummary `json:"summary,omitempty"`
+}
+
+// RecountThreshold is the tolerance a count must stay within to stand. A
+// count whose variance exceeds any set tolerance is recounted; nil
+// tolerances are not checked.
+type RecountThreshold struct {
+	Units   *int     `json:"units,omitempty"`
+	Percent *float64 `json:"percent,omitempty"`
+	Value   *float64 `json:"value,omitempty"`
+}
+
+// Exceeded reports whether a count of an item with the given system
+// quantity, variance and unit cost is out of tolerance. A variance against a
+// system quantity of zero exceeds any percent tolerance.
+func (rt RecountThreshold) Exceeded(systemQuantity, variance int, unitCost float64) bool {
+	if variance == 0 {
+		return false
+	}
+
+	abs := variance
+	if abs < 0 {
+		abs = -abs
+	}
+
+	if rt.Units != nil && abs > *rt.Units {
+		return true
+	}
+
+	if rt.Percent != nil {
+		if systemQuantity <= 0 || float64(abs)*100/float64(systemQuantity) > *rt.Percent {
+			return true
+		}
+	}
+
+	if rt.Value != nil && float64(abs)*unitCost > *rt.Value {
+		return true
+	}
+
+	return false
+}
+
+// VarianceSummary is the financial impact of a session's approved variances,
+// valued at each product's current cost.
+type VarianceSummary struct {
+	AdjustmentCount    int     `json:"adjustment_count"`
+	NetVarianceUnits   int     `json:"net_variance_units"`
+	NetVarianceValue   float64 `json:"net_variance_value"`
+	GrossVarianceValue float64 `json:"gross_variance_value"`
+}
+
+// VarianceLine is one approved, non-zero variance of a session with the unit
+// cost it is valued at.
+type VarianceLine struct {
+	ItemID          uuid.UUID `json:"item_id"`
+	ProductID       uuid.UUID `json:"product_id"`
+	LocationID      uuid.UUID `json:"location_id"`
+	SystemQuantity  int       `json:"system_quantity"`
+	CountedQuantity int       `json:"counted_quantity"`
+	Variance        int       `json:"variance"`
+	UnitCost        float64   `json:"unit_cost"`
+}
+
+// Summarize totals variance lines into a VarianceSummary.
+func Summarize(lines []VarianceLine) VarianceSummary {
+	var sum VarianceSummary
+	for _, l := range lines {
+		value := float64(l.Variance) * l.UnitCost
+
+		sum.AdjustmentCount++
+		sum.NetVarianceUnits += l.Variance
+		sum.NetVarianceValue += value
+		if value < 0 {
+			value = -value
+		}
+		sum.GrossVarianceValue += value
+	}
+	return sum
 }
 
 // NewCycleCountSession contains the information needed to create a new cycle count session.
 // Status is always set to Statuses.Draft by the business layer.
 type NewCycleCountSession struct {
-	Name      string    `json:"name"`
-	CreatedBy uuid.UUID `json:"created_by"`
+	Name      string           `json:"name"`
+	CreatedBy uuid.UUID        `json:"created_by"`
+	Blind     bool             `json:"blind"`
+	Recount   RecountThreshold `json:"recount"`
 }
 
 // UpdateCycleCountSession contains the information that can be changed on a cycle count session.
 // All fields are optional pointers; nil means "do not update this field."
 type UpdateCycleCountSession struct {
-	Name          *string    `json:"name,omitempty"`
-	Status        *Status    `json:"status,omitempty"`
-	CompletedDate *time.Time `json:"completed_date,omitempty"`
+	Name          *string           `json:"name,omitempty"`
+	Status        *Status           `json:"status,omitempty"`
+	CompletedDate *time.Time        `json:"completed_date,omitempty"`
+	Blind         *bool             `json:"blind,omitempty"`
+	Recount       *RecountThreshold `json:"recount,omitempty"`
+	Summary       *VarianceSummary  `json:"summary,omitempty"`
 }
@@ -0,0 +1,65 @@
+package cyclecountsessionbus_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountsessionbus"
+)
+
+func TestRecountThreshold_Exceeded(t *testing.T) {
+	units := 5
+	percent := 10.0
+	value := 100.0
+
+	tests := []struct {
+		name      string
+		threshold cyclecountsessionbus.RecountThreshold
+		system    int
+		variance  int
+		unitCost  float64
+		want      bool
+	}{
+		{name: "no thresholds", system: 100, variance: -50, want: false},
+		{name: "zero variance", threshold: cyclecountsessionbus.RecountThreshold{Units: &units}, system: 100, variance: 0, want: false},
+		{name: "units within", threshold: cyclecountsessionbus.RecountThreshold{Units: &units}, system: 100, variance: -5, want: false},
+		{name: "units over", threshold: cyclecountsessionbus.RecountThreshold{Units: &units}, system: 100, variance: -6, want: true},
+		{name: "percent within", threshold: cyclecountsessionbus.RecountThreshold{Percent: &percent}, system: 100, variance: 10, want: false},
+		{name: "percent over", threshold: cyclecountsessionbus.RecountThreshold{Percent: &percent}, system: 100, variance: 11, want: true},
+		{name: "percent against zero stock", threshold: cyclecountsessionbus.RecountThreshold{Percent: &percent}, system: 0, variance: 1, want: true},
+		{name: "value within", threshold: cyclecountsessionbus.RecountThreshold{Value: &value}, system: 100, variance: -4, unitCost: 25, want: false},
+		{name: "value over", threshold: cyclecountsessionbus.RecountThreshold{Value: &value}, system: 100, variance: -5, unitCost: 25, want: true},
+		{name: "any tolerance trips", threshold: cyclecountsessionbus.RecountThreshold{Units: &units, Value: &value}, system: 100, variance: 2, unitCost: 60, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.threshold.Exceeded(tt.system, tt.variance, tt.unitCost); got != tt.want {
+				t.Fatalf("Exceeded(%d, %d, %v) = %v, want %v", tt.system, tt.variance, tt.unitCost, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	lines := []cyclecountsessionbus.VarianceLine{
+		{Variance: -5, UnitCost: 10},
+		{Variance: 2, UnitCost: 7.5},
+		{Variance: -1, UnitCost: 0},
+	}
+
+	got := cyclecountsessionbus.Summarize(lines)
+
+	if got.AdjustmentCount != 3 {
+		t.Errorf("AdjustmentCount = %d, want 3", got.AdjustmentCount)
+	}
+	if got.NetVarianceUnits != -4 {
+		t.Errorf("NetVarianceUnits = %d, want -4", got.NetVarianceUnits)
+	}
+	if math.Abs(got.NetVarianceValue-(-35)) > 1e-9 {
+		t.Errorf("NetVarianceValue = %v, want -35", got.NetVarianceValue)
+	}
+	if math.Abs(got.GrossVarianceValue-65) > 1e-9 {
+		t.Errorf("GrossVarianceValue = %v, want 65", got.GrossVarianceValue)
+	}
+}
@@ -47,9 +47,11 @@ func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (cyclecountsessionbus.Store
 func (s *Store) Create(ctx context.Context, session cyclecountsessionbus.CycleCountSession) error {
 	const q = `
 	INSERT INTO inventory.cycle_count_sessions
-		(id, name, status, created_by, created_date, updated_date, completed_date, scenario_id)
+		(id, name, status, created_by, created_date, updated_date, completed_date, scenario_id,
+		 blind, recount_units, recount_percent, recount_value)
 	VALUES
-		(:id, :name, :status, :created_by, :created_date, :updated_date, :completed_date, :scenario_id)
+		(:id, :name, :status, :created_by, :created_date, :updated_date, :completed_date, :scenario_id,
+		 :blind, :recount_units, :recount_percent, :recount_value)
 	`
 
 	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBCycleCountSession(session)); err != nil {
@@ -73,7 +75,15 @@ func (s *Store) Update(ctx context.Context, session cyclecountsessionbus.CycleCo
 		name           = :name,
 		status         = :status,
 		updated_date   = :updated_date,
-		completed_date = :completed_date
+		completed_date = :completed_date,
+		blind          = :blind,
+		recount_units  = :recount_units,
+		recount_percent = :recount_percent,
+		recount_value  = :recount_value,
+		adjustment_count     = :adjustment_count,
+		net_variance_units   = :net_variance_units,
+		net_variance_value   = :net_variance_value,
+		gross_variance_value = :gross_variance_value
 	WHERE
 		id = :id
 	`
@@ -114,7 +124,9 @@ func (s *Store) Query(ctx context.Context, filter cyclecountsessionbus.QueryFilt
 
 	const q = `
 	SELECT
-		id, name, status, created_by, created_date, updated_date, completed_date, scenario_id
+		id, name, status, created_by, created_date, updated_date, completed_date, scenario_id,
+		blind, recount_units, recount_percent, recount_value,
+		adjustment_count, net_variance_units, net_variance_value, gross_variance_value
 	FROM
 		inventory.cycle_count_sessions
 	`
@@ -177,7 +189,9 @@ func (s *Store) QueryByID(ctx context.Context, sessionID uuid.UUID) (cyclecounts
 
 	const q = `
 	SELECT
-		id, name, status, created_by, created_date, updated_date, completed_date, scenario_id
+		id, name, status, created_by, created_date, updated_date, completed_date, scenario_id,
+		blind, recount_units, recount_percent, recount_value,
+		adjustment_count, net_variance_units, net_variance_value, gross_variance_value
 	FROM
 		inventory.cycle_count_sessions
 	WHERE
@@ -202,3 +216,69 @@ func (s *Store) QueryByID(ctx context.Context, sessionID uuid.UUID) (cyclecounts
 
 	return session, nil
 }
+
+// QueryVarianceLines retrieves a session's approved, non-zero variances with
+// each product's most recent unit cost.
+func (s *Store) QueryVarianceLines(ctx context.Context, sessionID uuid.UUID) ([]cyclecountsessionbus.VarianceLine, error) {
+	data := map[string]any{
+		"session_id": sessionID.String(),
+	}
+
+	const q = `
+	SELECT
+		cci.id AS item_id, cci.product_id, cci.location_id, cci.system_quantity,
+		COALESCE(cci.counted_quantity, 0) AS counted_quantity, cci.variance,
+		COALESCE(pc.unit_cost, 0) AS unit_cost
+	FROM
+		inventory.cycle_count_items cci
+	LEFT JOIN LATERAL (
+		SELECT COALESCE(NULLIF(landed_cost, 0), purchase_cost) AS unit_cost
+		FROM products.product_costs
+		WHERE product_id = cci.product_id
+		ORDER BY effective_date DESC
+		LIMIT 1
+	) pc ON true
+	WHERE
+		cci.session_id = :session_id
+		AND cci.status = 'variance_approved'
+		AND cci.variance IS NOT NULL
+		AND cci.variance <> 0
+	ORDER BY
+		cci.created_date, cci.id
+	`
+
+	var dbLines []varianceLine
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbLines); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusVarianceLines(dbLines), nil
+}
+
+// QueryUnitCost retrieves a product's most recent unit cost, preferring
+// landed cost over purchase cost. A product without costs costs nothing.
+func (s *Store) QueryUnitCost(ctx context.Context, productID uuid.UUID) (float64, error) {
+	data := map[string]any{
+		"product_id": productID.String(),
+	}
+
+	const q = `
+	SELECT
+		COALESCE((
+			SELECT COALESCE(NULLIF(landed_cost, 0), purchase_cost)
+			FROM products.product_costs
+			WHERE product_id = :product_id
+			ORDER BY effective_date DESC
+			LIMIT 1
+		), 0) AS unit_cost
+	`
+
+	var row struct {
+		UnitCost float64 `db:"unit_cost"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &row); err != nil {
+		return 0, fmt.Errorf("namedquerystruct: %w", err)
+	}
+
+	return row.UnitCost, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountsessionbus"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb/nulltypes"
 )
 
 // cycleCountSession mirrors the inventory.cycle_count_sessions DB row.
@@ -19,6 +20,35 @@ type cycleCountSession struct {
 	UpdatedDate   time.Time    `db:"updated_date"`
 	CompletedDate sql.NullTime `db:"completed_date"`
 	ScenarioID    *uuid.UUID   `db:"scenario_id"`
+
+	Blind          bool            `db:"blind"`
+	RecountUnits   sql.NullInt64   `db:"recount_units"`
+	RecountPercent sql.NullFloat64 `db:"recount_percent"`
+	RecountValue   sql.NullFloat64 `db:"recount_value"`
+
+	AdjustmentCount    sql.NullInt64   `db:"adjustment_count"`
+	NetVarianceUnits   sql.NullInt64   `db:"net_variance_units"`
+	NetVarianceValue   sql.NullFloat64 `db:"net_variance_value"`
+	GrossVarianceValue sql.NullFloat64 `db:"gross_variance_value"`
+}
+
+// varianceLine is an approved variance joined to its product's unit cost.
+type varianceLine struct {
+	ItemID          uuid.UUID `db:"item_id"`
+	ProductID       uuid.UUID `db:"product_id"`
+	LocationID      uuid.UUID `db:"location_id"`
+	SystemQuantity  int       `db:"system_quantity"`
+	CountedQuantity int       `db:"counted_quantity"`
+	Variance        int       `db:"variance"`
+	UnitCost        float64   `db:"unit_cost"`
+}
+
+func toBusVarianceLines(dbs []varianceLine) []cyclecountsessionbus.VarianceLine {
+	lines := make([]cyclecountsessionbus.VarianceLine, len(dbs))
+	for i, db := range dbs {
+		lines[i] = cyclecountsessionbus.VarianceLine(db)
+	}
+	return lines
 }
 
 func toBusCycleCountSession(db cycleCountSession) (cyclecountsessionbus.CycleCountSession, error) {
@@ -33,6 +63,16 @@ func toBusCycleCountSession(db cycleCountSession) (cyclecountsessionbus.CycleCou
 		completedDate = &t
 	}
 
+	var summary *cyclecountsessionbus.VarianceSummary
+	if db.AdjustmentCount.Valid {
+		summary = &cyclecountsessionbus.VarianceSummary{
+			AdjustmentCount:    int(db.AdjustmentCount.Int64),
+			NetVarianceUnits:   int(db.NetVarianceUnits.Int64),
+			NetVarianceValue:   db.NetVarianceValue.Float64,
+			GrossVarianceValue: db.GrossVarianceValue.Float64,
+		}
+	}
+
 	return cyclecountsessionbus.CycleCountSession{
 		ID:            db.ID,
 		Name:          db.Name,
@@ -42,6 +82,13 @@ func toBusCycleCountSession(db cycleCountSession) (cyclecountsessionbus.CycleCou
 		UpdatedDate:   db.UpdatedDate,
 		CompletedDate: completedDate,
 		ScenarioID:    db.ScenarioID,
+		Blind:         db.Blind,
+		Recount: cyclecountsessionbus.RecountThreshold{
+			Units:   nulltypes.Int64Ptr(db.RecountUnits),
+			Percent: nulltypes.Float64Ptr(db.RecountPercent),
+			Value:   nulltypes.Float64Ptr(db.RecountValue),
+		},
+		Summary: summary,
 	}, nil
 }
 
@@ -63,14 +110,27 @@ func toDBCycleCountSession(bus cyclecountsessionbus.CycleCountSession) cycleCoun
 		completedDate = sql.NullTime{Time: bus.CompletedDate.UTC(), Valid: true}
 	}
 
-	return cycleCountSession{
-		ID:            bus.ID,
-		Name:          bus.Name,
-		Status:        bus.Status.String(),
-		CreatedBy:     bus.CreatedBy,
-		CreatedDate:   bus.CreatedDate,
-		UpdatedDate:   bus.UpdatedDate,
-		CompletedDate: completedDate,
-		ScenarioID:    bus.ScenarioID,
+	db := cycleCountSession{
+		ID:             bus.ID,
+		Name:           bus.Name,
+		Status:         bus.Status.String(),
+		CreatedBy:      bus.CreatedBy,
+		CreatedDate:    bus.CreatedDate,
+		UpdatedDate:    bus.UpdatedDate,
+		CompletedDate:  completedDate,
+		ScenarioID:     bus.ScenarioID,
+		Blind:          bus.Blind,
+		RecountUnits:   nulltypes.ToNullInt64(bus.Recount.Units),
+		RecountPercent: nulltypes.ToNullFloat64(bus.Recount.Percent),
+		RecountValue:   nulltypes.ToNullFloat64(bus.Recount.Value),
 	}
+
+	if sum := bus.Summary; sum != nil {
+		db.AdjustmentCount = sql.NullInt64{Int64: int64(sum.AdjustmentCount), Valid: true}
+		db.NetVarianceUnits = sql.NullInt64{Int64: int64(sum.NetVarianceUnits), Valid: true}
+		db.NetVarianceValue = sql.NullFloat64{Float64: sum.NetVarianceValue, Valid: true}
+		db.GrossVarianceValue = sql.NullFloat64{Float64: sum.GrossVarianceValue, Valid: true}
+	}
+
+	return db
 }
@@ -0,0 +1,73 @@
+package demandforecastbus
+
+import (
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// ScoreAccuracy scores the samples by category and method, and overall. Rows
+// are ordered by category then method.
+func ScoreAccuracy(samples []AccuracySample, horizonDays int) AccuracyReport {
+	type key struct {
+		categoryID uuid.UUID
+		method     string
+	}
+
+	groups := make(map[key][]AccuracySample)
+	var keys []key
+	for _, s := range samples {
+		k := key{categoryID: s.CategoryID, method: s.Method.String()}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], s)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].categoryID != keys[j].categoryID {
+			return keys[i].categoryID.String() < keys[j].categoryID.String()
+		}
+		return keys[i].method < keys[j].method
+	})
+
+	rows := make([]AccuracyRow, len(keys))
+	for i, k := range keys {
+		categoryID := k.categoryID
+		rows[i] = scoreRow(groups[k])
+		rows[i].CategoryID = &categoryID
+		rows[i].Method = k.method
+	}
+
+	return AccuracyReport{
+		HorizonDays: horizonDays,
+		Rows:        rows,
+		Overall:     scoreRow(samples),
+	}
+}
+
+func scoreRow(samples []AccuracySample) AccuracyRow {
+	row := AccuracyRow{Count: len(samples)}
+	if len(samples) == 0 {
+		return row
+	}
+
+	var absErr, err, actual float64
+	for _, s := range samples {
+		e := s.ForecastDailyUsage - s.ActualDailyUsage
+		err += e
+		absErr += math.Abs(e)
+		actual += s.ActualDailyUsage
+	}
+
+	n := float64(len(samples))
+	row.MAE = absErr / n
+	row.Bias = err / n
+	if actual > 0 {
+		wape := absErr / actual
+		row.WAPE = &wape
+	}
+
+	return row
+}
@@ -0,0 +1,44 @@
+package demandforecastbus
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestScoreAccuracy(t *testing.T) {
+	tools := uuid.MustParse("00000000-0000-0000-0000-0000000000c1")
+	parts := uuid.MustParse("00000000-0000-0000-0000-0000000000c2")
+
+	samples := []AccuracySample{
+		{CategoryID: parts, Method: Methods.MovingAverage, ForecastDailyUsage: 0, ActualDailyUsage: 0},
+		{CategoryID: tools, Method: Methods.MovingAverage, ForecastDailyUsage: 12, ActualDailyUsage: 10},
+		{CategoryID: tools, Method: Methods.MovingAverage, ForecastDailyUsage: 6, ActualDailyUsage: 10},
+		{CategoryID: tools, Method: Methods.ExponentialSmoothing, ForecastDailyUsage: 5, ActualDailyUsage: 5},
+	}
+
+	report := ScoreAccuracy(samples, 7)
+
+	if report.HorizonDays != 7 || len(report.Rows) != 3 {
+		t.Fatalf("report = %+v, want 3 rows", report)
+	}
+
+	first := report.Rows[0]
+	if *first.CategoryID != tools || first.Method != "exponential_smoothing" || first.MAE != 0 {
+		t.Fatalf("first row = %+v, want tools by exponential smoothing", first)
+	}
+
+	ma := report.Rows[1]
+	if ma.Count != 2 || ma.MAE != 3 || ma.Bias != -1 || ma.WAPE == nil || *ma.WAPE != 0.3 {
+		t.Fatalf("moving average row = %+v", ma)
+	}
+
+	noDemand := report.Rows[2]
+	if *noDemand.CategoryID != parts || noDemand.WAPE != nil {
+		t.Fatalf("row without demand = %+v, want no WAPE", noDemand)
+	}
+
+	if report.Overall.Count != 4 || report.Overall.MAE != 1.5 || *report.Overall.WAPE != 0.24 {
+		t.Fatalf("overall = %+v", report.Overall)
+	}
+}
@@ -0,0 +1,596 @@
+// Package demandforecastbus provides business access to demand forecasts:
+// per-category policies that forecast each inventory item's daily usage from
+// its outbound transaction history and recommend the item's safety stock,
+// reorder point and economic order quantity from it.
+package demandforecastbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/sdk/delegate"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/outbox"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+	"github.com/timmaaaz/ichor/foundation/otel"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound            = errors.New("demand forecast not found")
+	ErrPolicyNotFound      = errors.New("forecast policy not found")
+	ErrUniqueEntry         = errors.New("forecast policy entry is not unique")
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+	ErrInvalidPolicy       = errors.New("invalid forecast policy")
+	ErrNothingToForecast   = errors.New("no inventory item has a forecast policy")
+	ErrForecastClosed      = errors.New("demand forecast is not pending")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	NewWithTx(tx sqldb.CommitRollbacker) (Storer, error)
+	CreatePolicy(ctx context.Context, policy Policy) error
+	UpdatePolicy(ctx context.Context, policy Policy) error
+	DeletePolicy(ctx context.Context, policy Policy) error
+	QueryPolicies(ctx context.Context, filter PolicyFilter, orderBy order.By, page page.Page) ([]Policy, error)
+	CountPolicies(ctx context.Context, filter PolicyFilter) (int, error)
+	QueryPolicyByID(ctx context.Context, policyID uuid.UUID) (Policy, error)
+	QueryAllPolicies(ctx context.Context) ([]Policy, error)
+	Create(ctx context.Context, forecast Forecast) error
+	UpdateWithStatusGuard(ctx context.Context, forecast Forecast, expectedStatus string) (int64, error)
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]Forecast, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, forecastID uuid.UUID) (Forecast, error)
+	QueryPending(ctx context.Context, itemIDs []uuid.UUID) ([]Forecast, error)
+	QueryItems(ctx context.Context, filter ItemFilter) ([]Item, error)
+	QueryDemand(ctx context.Context, productIDs []uuid.UUID, from, to time.Time) ([]DailyDemand, error)
+	QueryAccuracySamples(ctx context.Context, filter AccuracyFilter, now time.Time) ([]AccuracySample, error)
+	LockRun(ctx context.Context) error
+}
+
+// Business manages the set of APIs for demand forecast access.
+type Business struct {
+	log              *logger.Logger
+	storer           Storer
+	delegate         *delegate.Delegate
+	outbox           *outbox.Writer
+	inventoryItemBus *inventoryitembus.Business
+}
+
+// NewBusiness constructs a demand forecast business API for use. The
+// inventory item bus receives the values of applied forecasts.
+func NewBusiness(log *logger.Logger, delegate *delegate.Delegate, storer Storer, inventoryItemBus *inventoryitembus.Business) *Business {
+	return &Business{
+		log:              log,
+		delegate:         delegate,
+		storer:           storer,
+		inventoryItemBus: inventoryItemBus,
+	}
+}
+
+// WithOutbox returns a copy of the Business wired to the cascade outbox Writer.
+// Inert until the Writer is injected at the F2 cutover (nil Writer -> Emit no-ops).
+func (b *Business) WithOutbox(w *outbox.Writer) *Business {
+	nb := *b
+	nb.outbox = w
+	return &nb
+}
+
+// NewWithTx constructs a new Business value replacing the Storer
+// value with a Storer value that is currently inside a transaction.
+func (b *Business) NewWithTx(tx sqldb.CommitRollbacker) (*Business, error) {
+	storer, err := b.storer.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryItemBus, err := b.inventoryItemBus.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	nb := *b
+	nb.storer = storer
+	nb.inventoryItemBus = inventoryItemBus
+	return &nb, nil
+}
+
+// =============================================================================
+// Policies
+
+// CreatePolicy adds a new forecast policy to the system. A category has at
+// most one policy, and there is at most one default policy.
+func (b *Business) CreatePolicy(ctx context.Context, np NewPolicy) (Policy, error) {
+	ctx, span := otel.AddSpan(ctx, "business.demandforecastbus.createpolicy")
+	defer span.End()
+
+	now := time.Now()
+
+	policy := Policy{
+		ID:               uuid.New(),
+		CategoryID:       np.CategoryID,
+		Method:           np.Method,
+		HistoryDays:      orDefault(np.HistoryDays, DefaultHistoryDays),
+		WindowDays:       orDefault(np.WindowDays, DefaultWindowDays),
+		SmoothingAlpha:   orDefault(np.SmoothingAlpha, DefaultSmoothingAlpha),
+		SeasonLengthDays: orDefault(np.SeasonLengthDays, DefaultSeasonLengthDays),
+		ServiceLevel:     orDefault(np.ServiceLevel, DefaultServiceLevel),
+		LeadTimeDays:     orDefault(np.LeadTimeDays, DefaultLeadTimeDays),
+		OrderingCost:     np.OrderingCost,
+		HoldingCostRate:  np.HoldingCostRate,
+		AutoApply:        np.AutoApply,
+		CreatedBy:        np.CreatedBy,
+		CreatedDate:      now,
+		UpdatedDate:      now,
+	}
+
+	if err := validate(policy); err != nil {
+		return Policy{}, fmt.Errorf("createpolicy: %w", err)
+	}
+
+	if err := b.storer.CreatePolicy(ctx, policy); err != nil {
+		return Policy{}, fmt.Errorf("createpolicy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// UpdatePolicy modifies an existing forecast policy in the system.
+func (b *Business) UpdatePolicy(ctx context.Context, policy Policy, up UpdatePolicy) (Policy, error) {
+	ctx, span := otel.AddSpan(ctx, "business.demandforecastbus.updatepolicy")
+	defer span.End()
+
+	if up.Method != nil {
+		policy.Method = *up.Method
+	}
+	if up.HistoryDays != nil {
+		policy.HistoryDays = *up.HistoryDays
+	}
+	if up.WindowDays != nil {
+		policy.WindowDays = *up.WindowDays
+	}
+	if up.SmoothingAlpha != nil {
+		policy.SmoothingAlpha = *up.SmoothingAlpha
+	}
+	if up.SeasonLengthDays != nil {
+		policy.SeasonLengthDays = *up.SeasonLengthDays
+	}
+	if up.ServiceLevel != nil {
+		policy.ServiceLevel = *up.ServiceLevel
+	}
+	if up.LeadTimeDays != nil {
+		policy.LeadTimeDays = *up.LeadTimeDays
+	}
+	if up.OrderingCost != nil {
+		policy.OrderingCost = *up.OrderingCost
+	}
+	if up.HoldingCostRate != nil {
+		policy.HoldingCostRate = *up.HoldingCostRate
+	}
+	if up.AutoApply != nil {
+		policy.AutoApply = *up.AutoApply
+	}
+
+	policy.UpdatedDate = time.Now()
+
+	if err := validate(policy); err != nil {
+		return Policy{}, fmt.Errorf("updatepolicy: %w", err)
+	}
+
+	if err := b.storer.UpdatePolicy(ctx, policy); err != nil {
+		return Policy{}, fmt.Errorf("updatepolicy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// DeletePolicy removes a forecast policy from the system. Its forecasts are
+// kept.
+func (b *Business) DeletePolicy(ctx context.Context, policy Policy) error {
+	ctx, span := otel.AddSpan(ctx, "business.demandforecastbus.deletepolicy")
+	defer span.End()
+
+	if err := b.storer.DeletePolicy(ctx, policy); err != nil {
+		return fmt.Errorf("deletepolicy: %w", err)
+	}
+
+	return nil
+}
+
+// QueryPolicies retrieves a list of forecast policies from the system.
+func (b *Business) QueryPolicies(ctx context.Context, filter PolicyFilter, orderBy order.By, page page.Page) ([]Policy, error) {
+	ctx, span := otel.AddSpan(ctx, "business.demandforecastbus.querypolicies")
+	defer span.End()
+
+	policies, err := b.storer.QueryPolicies(ctx, filter, orderBy, page)
+	if err != nil {
+		return nil, fmt.Errorf("querypolicies: %w", err)
+	}
+
+	return policies, nil
+}
+
+// CountPolicies returns the total number of forecast policies matching the
+// filter.
+func (b *Business) CountPolicies(ctx context.Context, filter PolicyFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.demandforecastbus.countpolicies")
+	defer span.End()
+
+	return b.storer.CountPolicies(ctx, filter)
+}
+
+// QueryPolicyByID retrieves a single forecast policy by its ID.
+func (b *Business) QueryPolicyByID(ctx context.Context, policyID uuid.UUID) (Policy, error) {
+	ctx, span := otel.AddSpan(ctx, "business.demandforecastbus.querypolicybyid")
+	defer span.End()
+
+	policy, err := b.storer.QueryPolicyByID(ctx, policyID)
+	if err != nil {
+		if errors.Is(err, ErrPolicyNotFound) {
+			return Policy{}, err
+		}
+		return Policy{}, fmt.Errorf("queryPolicyByID: policyID[%s]: %w", policyID, err)
+	}
+
+	return policy, nil
+}
+
+// =============================================================================
+// Forecasts
+
+// Run forecasts every inventory item the request selects whose product
+// category has a policy, or that falls under the default policy, from the
+// demand in the full days before now. Each forecast is recorded: unchanged
+// when it recommends the values the item already has, applied to the item
+// at once when its policy auto-applies, and pending approval otherwise. A
+// forecast replaces the item's earlier pending ones, which are superseded.
+// Runs are serialized with each other and with reviews, so two cannot queue
+// forecasts for the same item and a run cannot supersede a forecast while it
+// is being approved.
+// Returns ErrNothingToForecast when no selected item has a policy.
+func (b *Business) Run(ctx context.Context, req RunRequest, now time.Time) ([]Forecast, error) {
+	ctx, span := otel.AddSpan(ctx, "business.demandforecastbus.run")
+	defer span.End()
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) ([]Forecast, error) {
+			if err := b.storer.LockRun(ctx); err != nil {
+				return nil, fmt.Errorf("run: lock: %w", err)
+			}
+
+			policies, err := b.storer.QueryAllPolicies(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("run: policies: %w", err)
+			}
+
+			items, err := b.storer.QueryItems(ctx, ItemFilter{
+				CategoryID: req.CategoryID,
+				ProductID:  req.ProductID,
+				LocationID: req.LocationID,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("run: items: %w", err)
+			}
+
+			items, itemPolicies := resolvePolicies(items, policies)
+			if len(items) == 0 {
+				return nil, fmt.Errorf("run: %w", ErrNothingToForecast)
+			}
+
+			today := now.UTC().Truncate(24 * time.Hour)
+
+			var history int
+			var productIDs, itemIDs []uuid.UUID
+			seen := make(map[uuid.UUID]bool)
+			for i, item := range items {
+				history = max(history, itemPolicies[i].HistoryDays)
+				itemIDs = append(itemIDs, item.ID)
+				if !seen[item.ProductID] {
+					seen[item.ProductID] = true
+					productIDs = append(productIDs, item.ProductID)
+				}
+			}
+
+			demand, err := b.storer.QueryDemand(ctx, productIDs, today.AddDate(0, 0, -history), today)
+			if err != nil {
+				return nil, fmt.Errorf("run: demand: %w", err)
+			}
+
+			type itemKey struct{ productID, locationID uuid.UUID }
+			demandByItem := make(map[itemKey][]DailyDemand)
+			for _, d := range demand {
+				k := itemKey{d.ProductID, d.LocationID}
+				demandByItem[k] = append(demandByItem[k], d)
+			}
+
+			pending, err := b.storer.QueryPending(ctx, itemIDs)
+			if err != nil {
+				return nil, fmt.Errorf("run: pending: %w", err)
+			}
+
+			for _, old := range pending {
+				if _, err := b.update(ctx, old, func(f *Forecast) {
+					f.Status = StatusSuperseded
+					f.UpdatedDate = now
+				}); err != nil {
+					return nil, fmt.Errorf("run: supersede: %w", err)
+				}
+			}
+
+			forecasts := make([]Forecast, 0, len(items))
+			for i, item := range items {
+				policy := itemPolicies[i]
+				series := DemandSeries(demandByItem[itemKey{item.ProductID, item.LocationID}], today, policy.HistoryDays)
+
+				forecast := ForecastItem(item, policy, series)
+				forecast.ID = uuid.New()
+				forecast.CreatedBy = req.CreatedBy
+				forecast.CreatedDate = now
+				forecast.UpdatedDate = now
+
+				switch {
+				case !forecast.Changed():
+					forecast.Status = StatusUnchanged
+
+				case policy.AutoApply:
+					if err := b.apply(ctx, forecast); err != nil {
+						return nil, fmt.Errorf("run: %w", err)
+					}
+					forecast.Status = StatusApplied
+
+				default:
+					forecast.Status = StatusPending
+				}
+
+				if err := b.storer.Create(ctx, forecast); err != nil {
+					return nil, fmt.Errorf("run: create: %w", err)
+				}
+
+				evtData := ActionCreatedData(forecast)
+				if err := b.outbox.Emit(ctx, evtData); err != nil {
+					return nil, fmt.Errorf("emit cascade event: %w", err)
+				}
+				if err := b.delegate.Call(ctx, ActionCreatedData(forecast)); err != nil {
+					b.log.Error(ctx, "demandforecastbus: delegate call failed", "action", ActionCreated, "err", err)
+				}
+
+				forecasts = append(forecasts, forecast)
+			}
+
+			return forecasts, nil
+		})
+}
+
+// Approve writes a pending forecast's recommended values to its inventory
+// item. Returns ErrForecastClosed when the forecast is not pending.
+func (b *Business) Approve(ctx context.Context, forecast Forecast, userID uuid.UUID, now time.Time) (Forecast, error) {
+	ctx, span := otel.AddSpan(ctx, "business.demandforecastbus.approve")
+	defer span.End()
+
+	if forecast.Status != StatusPending {
+		return Forecast{}, fmt.Errorf("approve: %w", ErrForecastClosed)
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Forecast, error) {
+			if err := b.storer.LockRun(ctx); err != nil {
+				return Forecast{}, fmt.Errorf("approve: lock: %w", err)
+			}
+
+			// Close the forecast first so a second approval fails on the
+			// status guard instead of writing the item again.
+			approved, err := b.update(ctx, forecast, func(f *Forecast) {
+				f.Status = StatusApplied
+				f.ReviewedBy = &userID
+				f.ReviewedDate = &now
+				f.UpdatedDate = now
+			})
+			if err != nil {
+				return Forecast{}, fmt.Errorf("approve: %w", err)
+			}
+
+			if err := b.apply(ctx, forecast); err != nil {
+				return Forecast{}, fmt.Errorf("approve: %w", err)
+			}
+
+			return approved, nil
+		})
+}
+
+// Reject closes a pending forecast without touching its inventory item.
+// Returns ErrForecastClosed when the forecast is not pending.
+func (b *Business) Reject(ctx context.Context, forecast Forecast, userID uuid.UUID, now time.Time) (Forecast, error) {
+	ctx, span := otel.AddSpan(ctx, "business.demandforecastbus.reject")
+	defer span.End()
+
+	if forecast.Status != StatusPending {
+		return Forecast{}, fmt.Errorf("reject: %w", ErrForecastClosed)
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Forecast, error) {
+			if err := b.storer.LockRun(ctx); err != nil {
+				return Forecast{}, fmt.Errorf("reject: lock: %w", err)
+			}
+
+			rejected, err := b.update(ctx, forecast, func(f *Forecast) {
+				f.Status = StatusRejected
+				f.ReviewedBy = &userID
+				f.ReviewedDate = &now
+				f.UpdatedDate = now
+			})
+			if err != nil {
+				return Forecast{}, fmt.Errorf("reject: %w", err)
+			}
+
+			return rejected, nil
+		})
+}
+
+// Query retrieves a list of forecasts from the system.
+func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]Forecast, error) {
+	ctx, span := otel.AddSpan(ctx, "business.demandforecastbus.query")
+	defer span.End()
+
+	forecasts, err := b.storer.Query(ctx, filter, orderBy, page)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return forecasts, nil
+}
+
+// Count returns the total number of forecasts matching the filter.
+func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.demandforecastbus.count")
+	defer span.End()
+
+	return b.storer.Count(ctx, filter)
+}
+
+// QueryByID retrieves a single forecast by its ID.
+func (b *Business) QueryByID(ctx context.Context, forecastID uuid.UUID) (Forecast, error) {
+	ctx, span := otel.AddSpan(ctx, "business.demandforecastbus.querybyid")
+	defer span.End()
+
+	forecast, err := b.storer.QueryByID(ctx, forecastID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return Forecast{}, err
+		}
+		return Forecast{}, fmt.Errorf("queryByID: forecastID[%s]: %w", forecastID, err)
+	}
+
+	return forecast, nil
+}
+
+// QueryAccuracy scores the forecasts whose horizon has passed against the
+// mean daily demand at their item over the horizon after each was taken. A
+// zero HorizonDays means DefaultAccuracyHorizonDays.
+func (b *Business) QueryAccuracy(ctx context.Context, filter AccuracyFilter, now time.Time) (AccuracyReport, error) {
+	ctx, span := otel.AddSpan(ctx, "business.demandforecastbus.queryaccuracy")
+	defer span.End()
+
+	filter.HorizonDays = orDefault(filter.HorizonDays, DefaultAccuracyHorizonDays)
+
+	samples, err := b.storer.QueryAccuracySamples(ctx, filter, now)
+	if err != nil {
+		return AccuracyReport{}, fmt.Errorf("queryaccuracy: %w", err)
+	}
+
+	return ScoreAccuracy(samples, filter.HorizonDays), nil
+}
+
+// =============================================================================
+
+// resolvePolicies pairs each item with the policy of its product's category,
+// or the default policy, dropping the items neither covers.
+func resolvePolicies(items []Item, policies []Policy) ([]Item, []Policy) {
+	byCategory := make(map[uuid.UUID]Policy)
+	var fallback *Policy
+	for i, p := range policies {
+		if p.CategoryID == nil {
+			fallback = &policies[i]
+			continue
+		}
+		byCategory[*p.CategoryID] = p
+	}
+
+	var covered []Item
+	var itemPolicies []Policy
+	for _, item := range items {
+		p, ok := byCategory[item.CategoryID]
+		if !ok {
+			if fallback == nil {
+				continue
+			}
+			p = *fallback
+		}
+		covered = append(covered, item)
+		itemPolicies = append(itemPolicies, p)
+	}
+
+	return covered, itemPolicies
+}
+
+// apply writes the forecast's recommended values to its inventory item. The
+// inventory item bus must already be bound to the caller's transaction.
+func (b *Business) apply(ctx context.Context, forecast Forecast) error {
+	item, err := b.inventoryItemBus.QueryByID(ctx, forecast.InventoryItemID)
+	if err != nil {
+		return fmt.Errorf("query inventory item: %w", err)
+	}
+
+	if _, err := b.inventoryItemBus.Update(ctx, item, inventoryitembus.UpdateInventoryItem{
+		AvgDailyUsage:         &forecast.RecommendedAvgDailyUsage,
+		SafetyStock:           &forecast.RecommendedSafetyStock,
+		ReorderPoint:          &forecast.RecommendedReorderPoint,
+		EconomicOrderQuantity: &forecast.RecommendedEOQ,
+	}); err != nil {
+		return fmt.Errorf("update inventory item: %w", err)
+	}
+
+	return nil
+}
+
+// update applies fn to the forecast, stores it and emits the updated event.
+// The store only takes the write while the forecast still has the status it
+// was read with; when another transition got there first, update returns
+// ErrForecastClosed.
+func (b *Business) update(ctx context.Context, forecast Forecast, fn func(*Forecast)) (Forecast, error) {
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Forecast, error) {
+			before := forecast
+			fn(&forecast)
+
+			rows, err := b.storer.UpdateWithStatusGuard(ctx, forecast, before.Status)
+			if err != nil {
+				return Forecast{}, fmt.Errorf("update: %w", err)
+			}
+			if rows == 0 {
+				return Forecast{}, fmt.Errorf("update: %w", ErrForecastClosed)
+			}
+
+			evtData := ActionUpdatedData(before, forecast)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return Forecast{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionUpdatedData(before, forecast)); err != nil {
+				b.log.Error(ctx, "demandforecastbus: delegate call failed", "action", ActionUpdated, "err", err)
+			}
+
+			return forecast, nil
+		})
+}
+
+func validate(policy Policy) error {
+	switch {
+	case policy.Method == Method{}:
+		return fmt.Errorf("%w: method is required", ErrInvalidPolicy)
+	case policy.HistoryDays <= 0 || policy.WindowDays <= 0 || policy.SeasonLengthDays <= 0:
+		return fmt.Errorf("%w: history_days, window_days and season_length_days must be positive", ErrInvalidPolicy)
+	case policy.SmoothingAlpha <= 0 || policy.SmoothingAlpha > 1:
+		return fmt.Errorf("%w: smoothing_alpha must be in (0, 1]", ErrInvalidPolicy)
+	case policy.ServiceLevel < 0.5 || policy.ServiceLevel >= 1:
+		return fmt.Errorf("%w: service_level must be in [0.5, 1)", ErrInvalidPolicy)
+	case policy.LeadTimeDays < 0:
+		return fmt.Errorf("%w: lead_time_days must not be negative", ErrInvalidPolicy)
+	case policy.OrderingCost < 0 || policy.HoldingCostRate < 0:
+		return fmt.Errorf("%w: ordering_cost and holding_cost_rate must not be negative", ErrInvalidPolicy)
+	}
+	return nil
+}
+
+func orDefault[T int | float64](v, def T) T {
+	if v == 0 {
+		return def
+	}
+	return v
+}
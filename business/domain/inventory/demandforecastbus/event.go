@@ -0,0 +1,88 @@
+package demandforecastbus
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/delegate"
+)
+
+// DomainName represents the name of this domain for delegate events.
+const DomainName = "demandforecast"
+
+// EntityName is the workflow entity name used for event matching.
+const EntityName = "demand_forecasts"
+
+// Delegate action constants.
+const (
+	ActionCreated = "created"
+	ActionUpdated = "updated"
+)
+
+// =============================================================================
+// Created Event
+// =============================================================================
+
+type ActionCreatedParms struct {
+	EntityID uuid.UUID `json:"entityID"`
+	UserID   uuid.UUID `json:"userID"`
+	Entity   Forecast  `json:"entity"`
+}
+
+func (p *ActionCreatedParms) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func ActionCreatedData(f Forecast) delegate.Data {
+	params := ActionCreatedParms{
+		EntityID: f.ID,
+		UserID:   f.CreatedBy,
+		Entity:   f,
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    DomainName,
+		Action:    ActionCreated,
+		RawParams: rawParams,
+	}
+}
+
+// =============================================================================
+// Updated Event
+// =============================================================================
+
+type ActionUpdatedParms struct {
+	EntityID     uuid.UUID `json:"entityID"`
+	UserID       uuid.UUID `json:"userID"`
+	Entity       Forecast  `json:"entity"`
+	BeforeEntity Forecast  `json:"beforeEntity,omitempty"`
+}
+
+func (p *ActionUpdatedParms) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func ActionUpdatedData(before, after Forecast) delegate.Data {
+	params := ActionUpdatedParms{
+		EntityID:     after.ID,
+		UserID:       after.CreatedBy,
+		Entity:       after,
+		BeforeEntity: before,
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    DomainName,
+		Action:    ActionUpdated,
+		RawParams: rawParams,
+	}
+}
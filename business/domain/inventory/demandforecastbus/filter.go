@@ -0,0 +1,22 @@
+package demandforecastbus
+
+import "github.com/google/uuid"
+
+// QueryFilter holds optional filters for querying forecasts.
+type QueryFilter struct {
+	ID              *uuid.UUID
+	InventoryItemID *uuid.UUID
+	ProductID       *uuid.UUID
+	LocationID      *uuid.UUID
+	PolicyID        *uuid.UUID
+	Method          *Method
+	Status          *string
+}
+
+// PolicyFilter holds optional filters for querying policies.
+type PolicyFilter struct {
+	ID         *uuid.UUID
+	CategoryID *uuid.UUID
+	Method     *Method
+	AutoApply  *bool
+}
@@ -0,0 +1,186 @@
+package demandforecastbus
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DemandSeries spreads the demand for one product at one location over the
+// days days before today, oldest first. Days without demand are zero.
+func DemandSeries(demand []DailyDemand, today time.Time, days int) []float64 {
+	series := make([]float64, days)
+	from := today.AddDate(0, 0, -days)
+
+	for _, d := range demand {
+		i := int(d.Day.Sub(from).Hours() / 24)
+		if i < 0 || i >= days {
+			continue
+		}
+		series[i] += d.Quantity
+	}
+
+	return series
+}
+
+// ForecastItem forecasts the item's daily usage from its demand series with
+// the policy's method and recommends the item's planning values from it:
+//
+//	safety stock  = ceil(z * σ * √L)
+//	reorder point = ceil(f * L) + safety stock
+//	EOQ           = round(√(2 * 365f * S / (h * unit cost)))
+//
+// where f is the forecast daily usage, σ the standard deviation of daily
+// demand, L the lead time in days, z the standard normal quantile of the
+// service level, S the ordering cost and h the holding cost rate. The item
+// keeps its EOQ when the policy or the item lacks the costs to compute one.
+// The returned forecast carries no ID, status or dates.
+func ForecastItem(item Item, policy Policy, series []float64) Forecast {
+	leadTime := item.SupplierLeadTimeDays
+	if leadTime <= 0 {
+		leadTime = policy.LeadTimeDays
+	}
+
+	f := forecastDailyUsage(policy, series, leadTime)
+	sigma := stdDev(series)
+
+	safety := ceil(serviceFactor(policy.ServiceLevel) * sigma * math.Sqrt(float64(leadTime)))
+	if safety < 0 {
+		safety = 0
+	}
+	reorder := ceil(f*float64(leadTime)) + safety
+
+	eoq := item.EconomicOrderQuantity
+	if f > 0 && policy.OrderingCost > 0 && policy.HoldingCostRate > 0 && item.UnitCost > 0 {
+		eoq = int(math.Round(math.Sqrt(2 * f * 365 * policy.OrderingCost / (policy.HoldingCostRate * item.UnitCost))))
+	}
+
+	var policyID *uuid.UUID
+	if policy.ID != uuid.Nil {
+		id := policy.ID
+		policyID = &id
+	}
+
+	return Forecast{
+		InventoryItemID:          item.ID,
+		ProductID:                item.ProductID,
+		LocationID:               item.LocationID,
+		PolicyID:                 policyID,
+		Method:                   policy.Method,
+		HistoryDays:              len(series),
+		ForecastDailyUsage:       f,
+		DemandStdDev:             sigma,
+		LeadTimeDays:             leadTime,
+		CurrentAvgDailyUsage:     item.AvgDailyUsage,
+		CurrentSafetyStock:       item.SafetyStock,
+		CurrentReorderPoint:      item.ReorderPoint,
+		CurrentEOQ:               item.EconomicOrderQuantity,
+		RecommendedAvgDailyUsage: int(math.Round(f)),
+		RecommendedSafetyStock:   safety,
+		RecommendedReorderPoint:  reorder,
+		RecommendedEOQ:           eoq,
+	}
+}
+
+// =============================================================================
+
+// forecastDailyUsage forecasts daily usage from the series, oldest first.
+func forecastDailyUsage(policy Policy, series []float64, leadTime int) float64 {
+	switch policy.Method {
+	case Methods.ExponentialSmoothing:
+		return exponentialSmoothing(series, policy.SmoothingAlpha)
+
+	case Methods.SeasonalNaive:
+		return seasonalNaive(series, policy.SeasonLengthDays, leadTime, policy.WindowDays)
+	}
+
+	return movingAverage(series, policy.WindowDays)
+}
+
+// movingAverage is the mean of the last window days of the series.
+func movingAverage(series []float64, window int) float64 {
+	if window <= 0 || window > len(series) {
+		window = len(series)
+	}
+	if window == 0 {
+		return 0
+	}
+
+	return mean(series[len(series)-window:])
+}
+
+// exponentialSmoothing is the smoothed level of the series, each day weighted
+// alpha against the level before it.
+func exponentialSmoothing(series []float64, alpha float64) float64 {
+	if len(series) == 0 {
+		return 0
+	}
+
+	level := series[0]
+	for _, y := range series[1:] {
+		level = alpha*y + (1-alpha)*level
+	}
+
+	return level
+}
+
+// seasonalNaive expects the coming days to repeat the same days one season
+// ago: it is the mean daily demand over the lead time (at least one day, at
+// most a season) starting one season back. Series shorter than a season fall
+// back to the moving average.
+func seasonalNaive(series []float64, season, leadTime, window int) float64 {
+	n := len(series)
+	if season <= 0 || n < season {
+		return movingAverage(series, window)
+	}
+
+	days := min(max(leadTime, 1), season)
+	start := n - season
+
+	return mean(series[start : start+days])
+}
+
+// stdDev is the sample standard deviation of the series.
+func stdDev(series []float64) float64 {
+	n := len(series)
+	if n < 2 {
+		return 0
+	}
+
+	m := mean(series)
+	var ss float64
+	for _, y := range series {
+		ss += (y - m) * (y - m)
+	}
+
+	return math.Sqrt(ss / float64(n-1))
+}
+
+// serviceFactor is the standard normal quantile of the service level: the
+// number of standard deviations of lead time demand safety stock covers.
+func serviceFactor(serviceLevel float64) float64 {
+	if serviceLevel <= 0 || serviceLevel >= 1 {
+		return 0
+	}
+
+	return math.Sqrt2 * math.Erfinv(2*serviceLevel-1)
+}
+
+func mean(ys []float64) float64 {
+	if len(ys) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, y := range ys {
+		sum += y
+	}
+
+	return sum / float64(len(ys))
+}
+
+// ceil rounds up, ignoring floating point noise just above a whole number.
+func ceil(x float64) int {
+	return int(math.Ceil(x - 1e-9))
+}
@@ -0,0 +1,154 @@
+package demandforecastbus
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var today = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func policy(method Method) Policy {
+	return Policy{
+		ID:               uuid.New(),
+		Method:           method,
+		HistoryDays:      14,
+		WindowDays:       7,
+		SmoothingAlpha:   0.5,
+		SeasonLengthDays: 7,
+		ServiceLevel:     0.95,
+		LeadTimeDays:     4,
+	}
+}
+
+func near(got, want float64) bool {
+	return math.Abs(got-want) < 1e-6
+}
+
+func TestDemandSeries(t *testing.T) {
+	product, location := uuid.New(), uuid.New()
+	demand := []DailyDemand{
+		{ProductID: product, LocationID: location, Day: today.AddDate(0, 0, -3), Quantity: 5},
+		{ProductID: product, LocationID: location, Day: today.AddDate(0, 0, -1), Quantity: 2},
+		{ProductID: product, LocationID: location, Day: today.AddDate(0, 0, -9), Quantity: 7},
+		{ProductID: product, LocationID: location, Day: today, Quantity: 100},
+	}
+
+	series := DemandSeries(demand, today, 4)
+
+	want := []float64{0, 5, 0, 2}
+	for i := range want {
+		if series[i] != want[i] {
+			t.Fatalf("series = %v, want %v", series, want)
+		}
+	}
+}
+
+func TestForecastItem_MovingAverage(t *testing.T) {
+	series := []float64{100, 100, 100, 100, 100, 100, 100, 2, 4, 6, 2, 4, 6, 4}
+	item := Item{ID: uuid.New(), AvgDailyUsage: 50, SafetyStock: 1, ReorderPoint: 10, EconomicOrderQuantity: 30}
+
+	f := ForecastItem(item, policy(Methods.MovingAverage), series)
+
+	if !near(f.ForecastDailyUsage, 4) {
+		t.Fatalf("forecast = %v, want the mean of the last 7 days", f.ForecastDailyUsage)
+	}
+	if f.LeadTimeDays != 4 {
+		t.Fatalf("lead time = %d, want the policy's without a supplier", f.LeadTimeDays)
+	}
+
+	wantSafety := int(math.Ceil(1.6448536269514722 * stdDev(series) * 2))
+	if f.RecommendedSafetyStock != wantSafety {
+		t.Fatalf("safety stock = %d, want %d", f.RecommendedSafetyStock, wantSafety)
+	}
+	if f.RecommendedReorderPoint != 16+wantSafety {
+		t.Fatalf("reorder point = %d, want lead time demand plus safety stock", f.RecommendedReorderPoint)
+	}
+	if f.RecommendedAvgDailyUsage != 4 || f.CurrentAvgDailyUsage != 50 {
+		t.Fatalf("avg daily usage = %d (was %d)", f.RecommendedAvgDailyUsage, f.CurrentAvgDailyUsage)
+	}
+	if f.RecommendedEOQ != 30 {
+		t.Fatalf("eoq = %d, want the current one without costs", f.RecommendedEOQ)
+	}
+	if !f.Changed() {
+		t.Fatal("expected the forecast to change the item")
+	}
+}
+
+func TestForecastItem_ExponentialSmoothing(t *testing.T) {
+	series := []float64{10, 20, 0, 8}
+
+	f := ForecastItem(Item{}, policy(Methods.ExponentialSmoothing), series)
+
+	// 10 -> 15 -> 7.5 -> 7.75
+	if !near(f.ForecastDailyUsage, 7.75) {
+		t.Fatalf("forecast = %v, want 7.75", f.ForecastDailyUsage)
+	}
+}
+
+func TestForecastItem_SeasonalNaive(t *testing.T) {
+	// Two weeks of a weekly pattern: busy at the start of the week.
+	week := []float64{10, 8, 1, 1, 1, 1, 0}
+	series := append(append([]float64{}, week...), week...)
+	item := Item{SupplierLeadTimeDays: 2}
+
+	f := ForecastItem(item, policy(Methods.SeasonalNaive), series)
+
+	if f.LeadTimeDays != 2 {
+		t.Fatalf("lead time = %d, want the supplier's", f.LeadTimeDays)
+	}
+	if !near(f.ForecastDailyUsage, 9) {
+		t.Fatalf("forecast = %v, want the first two days of last week", f.ForecastDailyUsage)
+	}
+
+	short := ForecastItem(item, policy(Methods.SeasonalNaive), []float64{3, 5})
+	if !near(short.ForecastDailyUsage, 4) {
+		t.Fatalf("forecast = %v, want the moving average of a series shorter than a season", short.ForecastDailyUsage)
+	}
+}
+
+func TestForecastItem_EOQ(t *testing.T) {
+	p := policy(Methods.MovingAverage)
+	p.OrderingCost = 50
+	p.HoldingCostRate = 0.25
+	item := Item{UnitCost: 10, EconomicOrderQuantity: 5}
+	series := []float64{10, 10, 10, 10, 10, 10, 10}
+
+	f := ForecastItem(item, p, series)
+
+	// √(2 * 3650 * 50 / 2.5) = √146000 ≈ 382.1
+	if f.RecommendedEOQ != 382 {
+		t.Fatalf("eoq = %d, want 382", f.RecommendedEOQ)
+	}
+	if f.RecommendedSafetyStock != 0 {
+		t.Fatalf("safety stock = %d, want 0 for steady demand", f.RecommendedSafetyStock)
+	}
+	if f.RecommendedReorderPoint != 40 {
+		t.Fatalf("reorder point = %d, want 40", f.RecommendedReorderPoint)
+	}
+}
+
+func TestForecastItem_NoDemand(t *testing.T) {
+	f := ForecastItem(Item{}, policy(Methods.MovingAverage), make([]float64, 14))
+
+	if f.Changed() {
+		t.Fatalf("expected an item with no demand and no values to be unchanged: %+v", f)
+	}
+}
+
+func TestServiceFactor(t *testing.T) {
+	for _, tt := range []struct {
+		level float64
+		want  float64
+	}{
+		{0.5, 0},
+		{0.95, 1.6448536},
+		{0.99, 2.3263479},
+	} {
+		if got := serviceFactor(tt.level); math.Abs(got-tt.want) > 1e-6 {
+			t.Fatalf("serviceFactor(%v) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package demandforecastbus
+
+import "fmt"
+
+type methodSet struct {
+	MovingAverage        Method
+	ExponentialSmoothing Method
+	SeasonalNaive        Method
+}
+
+// Methods represents the set of ways a policy can forecast daily demand.
+var Methods = methodSet{
+	MovingAverage:        newMethod("moving_average"),
+	ExponentialSmoothing: newMethod("exponential_smoothing"),
+	SeasonalNaive:        newMethod("seasonal_naive"),
+}
+
+// =============================================================================
+
+// Set of known methods.
+var methods = make(map[string]Method)
+
+// Method represents how a policy forecasts daily demand from history.
+type Method struct {
+	name string
+}
+
+func newMethod(s string) Method {
+	m := Method{s}
+	methods[s] = m
+	return m
+}
+
+// String returns the name of the method.
+func (m Method) String() string {
+	return m.name
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (m Method) Equal(m2 Method) bool {
+	return m.name == m2.name
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m Method) MarshalText() ([]byte, error) {
+	return []byte(m.name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *Method) UnmarshalText(data []byte) error {
+	method, err := ParseMethod(string(data))
+	if err != nil {
+		return err
+	}
+	*m = method
+	return nil
+}
+
+// =============================================================================
+
+// ParseMethod parses the string value and returns a method if one exists.
+func ParseMethod(value string) (Method, error) {
+	m, exists := methods[value]
+	if !exists {
+		return Method{}, fmt.Errorf("invalid method %q", value)
+	}
+	return m, nil
+}
+
+// MustParseMethod parses the string value and returns a method if one exists.
+// Panics if the method is invalid.
+func MustParseMethod(value string) Method {
+	m, err := ParseMethod(value)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
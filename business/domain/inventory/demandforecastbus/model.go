@@ -0,0 +1,223 @@
+package demandforecastbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JSON tags are required for workflow event serialization. The workflow system
+// marshals business models to JSON for RawData in TriggerEvents.
+
+// Forecast statuses. A forecast is pending until someone approves or rejects
+// it, applied once its values are written to the inventory item, superseded
+// when a newer forecast for the item is taken while it is still pending, and
+// unchanged when it recommends the values the item already has.
+const (
+	StatusPending    = "pending"
+	StatusApplied    = "applied"
+	StatusRejected   = "rejected"
+	StatusSuperseded = "superseded"
+	StatusUnchanged  = "unchanged"
+)
+
+// Policy defaults used when a new policy leaves a setting zero.
+const (
+	DefaultHistoryDays      = 90
+	DefaultWindowDays       = 28
+	DefaultSmoothingAlpha   = 0.3
+	DefaultSeasonLengthDays = 7
+	DefaultServiceLevel     = 0.95
+	DefaultLeadTimeDays     = 7
+)
+
+// DefaultAccuracyHorizonDays is how many days of demand after a forecast the
+// accuracy report compares it against when the caller does not say.
+const DefaultAccuracyHorizonDays = 7
+
+// DemandTransactionTypes are the inventory transaction types that count as
+// demand at a location. They are matched case-insensitively. Shipments are
+// left out: they carry stock that was already picked, so counting them too
+// would count the same demand twice.
+var DemandTransactionTypes = []string{"PICK"}
+
+// Policy says how the demand of the items in a product category is forecast.
+// A policy with no CategoryID is the default for every category without a
+// policy of its own.
+//
+// WindowDays is the moving average window, SmoothingAlpha the exponential
+// smoothing weight of the latest day and SeasonLengthDays the season the
+// seasonal naive method repeats. LeadTimeDays is used when the item's product
+// has no supplier lead time. OrderingCost and HoldingCostRate (the share of
+// unit cost it costs to hold one unit for a year) drive the economic order
+// quantity; while either is zero the item keeps its current one. AutoApply
+// writes recommendations to the item at once instead of queuing them for
+// approval.
+type Policy struct {
+	ID               uuid.UUID  `json:"id"`
+	CategoryID       *uuid.UUID `json:"category_id,omitempty"`
+	Method           Method     `json:"method"`
+	HistoryDays      int        `json:"history_days"`
+	WindowDays       int        `json:"window_days"`
+	SmoothingAlpha   float64    `json:"smoothing_alpha"`
+	SeasonLengthDays int        `json:"season_length_days"`
+	ServiceLevel     float64    `json:"service_level"`
+	LeadTimeDays     int        `json:"lead_time_days"`
+	OrderingCost     float64    `json:"ordering_cost"`
+	HoldingCostRate  float64    `json:"holding_cost_rate"`
+	AutoApply        bool       `json:"auto_apply"`
+	CreatedBy        uuid.UUID  `json:"created_by"`
+	CreatedDate      time.Time  `json:"created_date"`
+	UpdatedDate      time.Time  `json:"updated_date"`
+}
+
+// NewPolicy is what we require from clients when adding a Policy. Zero
+// settings take their defaults.
+type NewPolicy struct {
+	CategoryID       *uuid.UUID
+	Method           Method
+	HistoryDays      int
+	WindowDays       int
+	SmoothingAlpha   float64
+	SeasonLengthDays int
+	ServiceLevel     float64
+	LeadTimeDays     int
+	OrderingCost     float64
+	HoldingCostRate  float64
+	AutoApply        bool
+	CreatedBy        uuid.UUID
+}
+
+// UpdatePolicy defines what information may be provided to modify an
+// existing Policy. All fields are optional.
+type UpdatePolicy struct {
+	Method           *Method
+	HistoryDays      *int
+	WindowDays       *int
+	SmoothingAlpha   *float64
+	SeasonLengthDays *int
+	ServiceLevel     *float64
+	LeadTimeDays     *int
+	OrderingCost     *float64
+	HoldingCostRate  *float64
+	AutoApply        *bool
+}
+
+// Forecast records one forecast of an inventory item's demand: the daily usage
+// and its variability over HistoryDays, the lead time used, the item's values
+// when it was taken and the values recommended in their place.
+type Forecast struct {
+	ID                       uuid.UUID  `json:"id"`
+	InventoryItemID          uuid.UUID  `json:"inventory_item_id"`
+	ProductID                uuid.UUID  `json:"product_id"`
+	LocationID               uuid.UUID  `json:"location_id"`
+	PolicyID                 *uuid.UUID `json:"policy_id,omitempty"`
+	Method                   Method     `json:"method"`
+	HistoryDays              int        `json:"history_days"`
+	ForecastDailyUsage       float64    `json:"forecast_daily_usage"`
+	DemandStdDev             float64    `json:"demand_std_dev"`
+	LeadTimeDays             int        `json:"lead_time_days"`
+	CurrentAvgDailyUsage     int        `json:"current_avg_daily_usage"`
+	CurrentSafetyStock       int        `json:"current_safety_stock"`
+	CurrentReorderPoint      int        `json:"current_reorder_point"`
+	CurrentEOQ               int        `json:"current_eoq"`
+	RecommendedAvgDailyUsage int        `json:"recommended_avg_daily_usage"`
+	RecommendedSafetyStock   int        `json:"recommended_safety_stock"`
+	RecommendedReorderPoint  int        `json:"recommended_reorder_point"`
+	RecommendedEOQ           int        `json:"recommended_eoq"`
+	Status                   string     `json:"status"`
+	ReviewedBy               *uuid.UUID `json:"reviewed_by,omitempty"`
+	ReviewedDate             *time.Time `json:"reviewed_date,omitempty"`
+	CreatedBy                uuid.UUID  `json:"created_by"`
+	CreatedDate              time.Time  `json:"created_date"`
+	UpdatedDate              time.Time  `json:"updated_date"`
+}
+
+// Changed reports whether the forecast recommends different values from the
+// ones the item had when it was taken.
+func (f Forecast) Changed() bool {
+	return f.RecommendedAvgDailyUsage != f.CurrentAvgDailyUsage ||
+		f.RecommendedSafetyStock != f.CurrentSafetyStock ||
+		f.RecommendedReorderPoint != f.CurrentReorderPoint ||
+		f.RecommendedEOQ != f.CurrentEOQ
+}
+
+// RunRequest describes the inventory items to forecast. CategoryID, ProductID
+// and LocationID narrow the items considered.
+type RunRequest struct {
+	CategoryID *uuid.UUID
+	ProductID  *uuid.UUID
+	LocationID *uuid.UUID
+	CreatedBy  uuid.UUID
+}
+
+// ItemFilter narrows the inventory items a run considers.
+type ItemFilter struct {
+	CategoryID *uuid.UUID
+	ProductID  *uuid.UUID
+	LocationID *uuid.UUID
+}
+
+// Item is an inventory item as a forecast run sees it: its current planning
+// values, its product's category and the lead time and unit cost of the
+// product's primary supplier. SupplierLeadTimeDays is zero when the product
+// has no supplier.
+type Item struct {
+	ID                    uuid.UUID
+	ProductID             uuid.UUID
+	LocationID            uuid.UUID
+	CategoryID            uuid.UUID
+	AvgDailyUsage         int
+	SafetyStock           int
+	ReorderPoint          int
+	EconomicOrderQuantity int
+	SupplierLeadTimeDays  int
+	UnitCost              float64
+}
+
+// DailyDemand is the demand for a product at a location on one day.
+type DailyDemand struct {
+	ProductID  uuid.UUID
+	LocationID uuid.UUID
+	Day        time.Time
+	Quantity   float64
+}
+
+// AccuracyFilter narrows the forecasts the accuracy report scores. Only
+// forecasts taken on or after From whose horizon has passed are scored.
+type AccuracyFilter struct {
+	From        *time.Time
+	CategoryID  *uuid.UUID
+	HorizonDays int
+}
+
+// AccuracySample pairs a forecast with the demand that followed it: the mean
+// daily demand at the item over the horizon after the forecast was taken.
+type AccuracySample struct {
+	ForecastID         uuid.UUID
+	CategoryID         uuid.UUID
+	Method             Method
+	ForecastDailyUsage float64
+	ActualDailyUsage   float64
+}
+
+// AccuracyRow scores the forecasts of one category and method, or of every
+// forecast when CategoryID is nil and Method is empty. MAE is the mean
+// absolute error in units per day and Bias the mean signed error (positive
+// when forecasts ran high). WAPE is the absolute error as a share of actual
+// demand, nil when there was no demand.
+type AccuracyRow struct {
+	CategoryID *uuid.UUID
+	Method     string
+	Count      int
+	MAE        float64
+	Bias       float64
+	WAPE       *float64
+}
+
+// AccuracyReport scores past forecasts against the demand that followed them.
+type AccuracyReport struct {
+	HorizonDays int
+	Rows        []AccuracyRow
+	Overall     AccuracyRow
+}
@@ -0,0 +1,21 @@
+package demandforecastbus
+
+import "github.com/timmaaaz/ichor/business/sdk/order"
+
+// DefaultOrderBy represents the default ordering for forecast queries.
+var DefaultOrderBy = order.NewBy(OrderByCreatedDate, order.DESC)
+
+// DefaultPolicyOrderBy represents the default ordering for policy queries.
+var DefaultPolicyOrderBy = order.NewBy(OrderByCreatedDate, order.ASC)
+
+const (
+	OrderByID              = "id"
+	OrderByInventoryItemID = "inventory_item_id"
+	OrderByProductID       = "product_id"
+	OrderByLocationID      = "location_id"
+	OrderByCategoryID      = "category_id"
+	OrderByMethod          = "method"
+	OrderByStatus          = "status"
+	OrderByCreatedDate     = "created_date"
+	OrderByUpdatedDate     = "updated_date"
+)
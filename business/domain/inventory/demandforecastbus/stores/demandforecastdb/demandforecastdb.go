@@ -0,0 +1,551 @@
+// Package demandforecastdb contains demand forecast related CRUD
+// functionality.
+package demandforecastdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// demandTypes is the SQL list of the demand transaction types, upper cased.
+var demandTypes = "'" + strings.Join(demandforecastbus.DemandTransactionTypes, "','") + "'"
+
+// Store manages the set of APIs for demand forecast database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// NewWithTx constructs a new Store value replacing the sqlx.DB
+// value with a sqlx.Tx value that is currently inside a transaction.
+func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (demandforecastbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		log: s.log,
+		db:  ec,
+	}, nil
+}
+
+// =============================================================================
+// Policies
+
+// CreatePolicy inserts a new policy into the database.
+func (s *Store) CreatePolicy(ctx context.Context, p demandforecastbus.Policy) error {
+	const q = `
+	INSERT INTO inventory.forecast_policies
+		(id, category_id, method, history_days, window_days, smoothing_alpha, season_length_days, service_level,
+		 lead_time_days, ordering_cost, holding_cost_rate, auto_apply, created_by, created_date, updated_date)
+	VALUES
+		(:id, :category_id, :method, :history_days, :window_days, :smoothing_alpha, :season_length_days, :service_level,
+		 :lead_time_days, :ordering_cost, :holding_cost_rate, :auto_apply, :created_by, :created_date, :updated_date)
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBPolicy(p)); err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return fmt.Errorf("namedexeccontext: %w", demandforecastbus.ErrForeignKeyViolation)
+		}
+		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+			return fmt.Errorf("namedexeccontext: %w", demandforecastbus.ErrUniqueEntry)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// UpdatePolicy modifies an existing policy in the database.
+func (s *Store) UpdatePolicy(ctx context.Context, p demandforecastbus.Policy) error {
+	const q = `
+	UPDATE inventory.forecast_policies
+	SET
+		method             = :method,
+		history_days       = :history_days,
+		window_days        = :window_days,
+		smoothing_alpha    = :smoothing_alpha,
+		season_length_days = :season_length_days,
+		service_level      = :service_level,
+		lead_time_days     = :lead_time_days,
+		ordering_cost      = :ordering_cost,
+		holding_cost_rate  = :holding_cost_rate,
+		auto_apply         = :auto_apply,
+		updated_date       = :updated_date
+	WHERE
+		id = :id
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBPolicy(p)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePolicy removes a policy from the database.
+func (s *Store) DeletePolicy(ctx context.Context, p demandforecastbus.Policy) error {
+	const q = `
+	DELETE FROM
+		inventory.forecast_policies
+	WHERE
+		id = :id
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBPolicy(p)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// QueryPolicies retrieves a list of policies from the database.
+func (s *Store) QueryPolicies(ctx context.Context, filter demandforecastbus.PolicyFilter, orderBy order.By, page page.Page) ([]demandforecastbus.Policy, error) {
+	data := map[string]any{
+		"offset":        (page.Number() - 1) * page.RowsPerPage(),
+		"rows_per_page": page.RowsPerPage(),
+	}
+
+	const q = `
+	SELECT
+		id, category_id, method, history_days, window_days, smoothing_alpha, season_length_days, service_level,
+		lead_time_days, ordering_cost, holding_cost_rate, auto_apply, created_by, created_date, updated_date
+	FROM
+		inventory.forecast_policies
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyPolicyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(policyOrderByFields, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbPolicies []policy
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbPolicies); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusPolicies(dbPolicies)
+}
+
+// CountPolicies returns the total number of policies matching the filter.
+func (s *Store) CountPolicies(ctx context.Context, filter demandforecastbus.PolicyFilter) (int, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		COUNT(1) AS count
+	FROM
+		inventory.forecast_policies
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyPolicyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryPolicyByID retrieves a single policy by its ID.
+func (s *Store) QueryPolicyByID(ctx context.Context, policyID uuid.UUID) (demandforecastbus.Policy, error) {
+	data := map[string]any{
+		"id": policyID.String(),
+	}
+
+	const q = `
+	SELECT
+		id, category_id, method, history_days, window_days, smoothing_alpha, season_length_days, service_level,
+		lead_time_days, ordering_cost, holding_cost_rate, auto_apply, created_by, created_date, updated_date
+	FROM
+		inventory.forecast_policies
+	WHERE
+		id = :id
+	`
+
+	var dbPolicy policy
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbPolicy); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return demandforecastbus.Policy{}, demandforecastbus.ErrPolicyNotFound
+		}
+		return demandforecastbus.Policy{}, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	return toBusPolicy(dbPolicy)
+}
+
+// QueryAllPolicies retrieves every policy.
+func (s *Store) QueryAllPolicies(ctx context.Context) ([]demandforecastbus.Policy, error) {
+	const q = `
+	SELECT
+		id, category_id, method, history_days, window_days, smoothing_alpha, season_length_days, service_level,
+		lead_time_days, ordering_cost, holding_cost_rate, auto_apply, created_by, created_date, updated_date
+	FROM
+		inventory.forecast_policies
+	`
+
+	var dbPolicies []policy
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, map[string]any{}, &dbPolicies); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusPolicies(dbPolicies)
+}
+
+// =============================================================================
+// Forecasts
+
+// Create inserts a new forecast into the database.
+func (s *Store) Create(ctx context.Context, f demandforecastbus.Forecast) error {
+	const q = `
+	INSERT INTO inventory.demand_forecasts
+		(id, inventory_item_id, product_id, location_id, policy_id, method, history_days, forecast_daily_usage,
+		 demand_std_dev, lead_time_days, current_avg_daily_usage, current_safety_stock, current_reorder_point,
+		 current_eoq, recommended_avg_daily_usage, recommended_safety_stock, recommended_reorder_point,
+		 recommended_eoq, status, reviewed_by, reviewed_date, created_by, created_date, updated_date)
+	VALUES
+		(:id, :inventory_item_id, :product_id, :location_id, :policy_id, :method, :history_days, :forecast_daily_usage,
+		 :demand_std_dev, :lead_time_days, :current_avg_daily_usage, :current_safety_stock, :current_reorder_point,
+		 :current_eoq, :recommended_avg_daily_usage, :recommended_safety_stock, :recommended_reorder_point,
+		 :recommended_eoq, :status, :reviewed_by, :reviewed_date, :created_by, :created_date, :updated_date)
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBForecast(f)); err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return fmt.Errorf("namedexeccontext: %w", demandforecastbus.ErrForeignKeyViolation)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateWithStatusGuard modifies a forecast in the database only while its
+// status is still expectedStatus, returning the rows affected.
+func (s *Store) UpdateWithStatusGuard(ctx context.Context, f demandforecastbus.Forecast, expectedStatus string) (int64, error) {
+	const q = `
+	UPDATE inventory.demand_forecasts
+	SET
+		status        = :status,
+		reviewed_by   = :reviewed_by,
+		reviewed_date = :reviewed_date,
+		updated_date  = :updated_date
+	WHERE
+		id = :id AND status = :expected_status
+	`
+
+	data := struct {
+		forecast
+		ExpectedStatus string `db:"expected_status"`
+	}{
+		forecast:       toDBForecast(f),
+		ExpectedStatus: expectedStatus,
+	}
+
+	rows, err := sqldb.NamedExecContextWithCount(ctx, s.log, s.db, q, data)
+	if err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return 0, fmt.Errorf("namedexeccontextwithcount: %w", demandforecastbus.ErrForeignKeyViolation)
+		}
+		return 0, fmt.Errorf("namedexeccontextwithcount: %w", err)
+	}
+
+	return rows, nil
+}
+
+// Query retrieves a list of forecasts from the database.
+func (s *Store) Query(ctx context.Context, filter demandforecastbus.QueryFilter, orderBy order.By, page page.Page) ([]demandforecastbus.Forecast, error) {
+	data := map[string]any{
+		"offset":        (page.Number() - 1) * page.RowsPerPage(),
+		"rows_per_page": page.RowsPerPage(),
+	}
+
+	const q = `
+	SELECT
+		id, inventory_item_id, product_id, location_id, policy_id, method, history_days, forecast_daily_usage,
+		demand_std_dev, lead_time_days, current_avg_daily_usage, current_safety_stock, current_reorder_point,
+		current_eoq, recommended_avg_daily_usage, recommended_safety_stock, recommended_reorder_point,
+		recommended_eoq, status, reviewed_by, reviewed_date, created_by, created_date, updated_date
+	FROM
+		inventory.demand_forecasts
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
+
+	orderByClause, err := orderByClause(orderByFields, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbForecasts []forecast
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbForecasts); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusForecasts(dbForecasts)
+}
+
+// Count returns the total number of forecasts matching the filter.
+func (s *Store) Count(ctx context.Context, filter demandforecastbus.QueryFilter) (int, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		COUNT(1) AS count
+	FROM
+		inventory.demand_forecasts
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID retrieves a single forecast by its ID.
+func (s *Store) QueryByID(ctx context.Context, forecastID uuid.UUID) (demandforecastbus.Forecast, error) {
+	data := map[string]any{
+		"id": forecastID.String(),
+	}
+
+	const q = `
+	SELECT
+		id, inventory_item_id, product_id, location_id, policy_id, method, history_days, forecast_daily_usage,
+		demand_std_dev, lead_time_days, current_avg_daily_usage, current_safety_stock, current_reorder_point,
+		current_eoq, recommended_avg_daily_usage, recommended_safety_stock, recommended_reorder_point,
+		recommended_eoq, status, reviewed_by, reviewed_date, created_by, created_date, updated_date
+	FROM
+		inventory.demand_forecasts
+	WHERE
+		id = :id
+	`
+
+	buf := bytes.NewBufferString(q)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
+
+	var dbForecast forecast
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &dbForecast); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return demandforecastbus.Forecast{}, demandforecastbus.ErrNotFound
+		}
+		return demandforecastbus.Forecast{}, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	return toBusForecast(dbForecast)
+}
+
+// QueryPending retrieves the pending forecasts of the inventory items.
+func (s *Store) QueryPending(ctx context.Context, itemIDs []uuid.UUID) ([]demandforecastbus.Forecast, error) {
+	data := map[string]any{
+		"item_ids": itemIDs,
+		"status":   demandforecastbus.StatusPending,
+	}
+
+	const q = `
+	SELECT
+		id, inventory_item_id, product_id, location_id, policy_id, method, history_days, forecast_daily_usage,
+		demand_std_dev, lead_time_days, current_avg_daily_usage, current_safety_stock, current_reorder_point,
+		current_eoq, recommended_avg_daily_usage, recommended_safety_stock, recommended_reorder_point,
+		recommended_eoq, status, reviewed_by, reviewed_date, created_by, created_date, updated_date
+	FROM
+		inventory.demand_forecasts
+	WHERE
+		inventory_item_id = ANY(:item_ids)
+		AND status = :status
+	ORDER BY
+		created_date
+	`
+
+	var dbForecasts []forecast
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbForecasts); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusForecasts(dbForecasts)
+}
+
+// QueryItems retrieves the inventory items a run considers with their product
+// category and the lead time and unit cost of the product's primary supplier,
+// or of its cheapest supplier when none is primary. The supplier product's
+// lead time wins over the supplier's.
+func (s *Store) QueryItems(ctx context.Context, filter demandforecastbus.ItemFilter) ([]demandforecastbus.Item, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		ii.id, ii.product_id, ii.location_id, p.category_id, ii.avg_daily_usage, ii.safety_stock, ii.reorder_point,
+		ii.economic_order_quantity,
+		COALESCE(NULLIF(sp.lead_time_days, 0), sp.supplier_lead_time_days, 0) AS supplier_lead_time_days,
+		COALESCE(sp.unit_cost, 0) AS unit_cost
+	FROM inventory.inventory_items ii
+	JOIN products.products p ON p.id = ii.product_id
+	LEFT JOIN LATERAL (
+		SELECT spp.lead_time_days, s.lead_time_days AS supplier_lead_time_days, spp.unit_cost
+		FROM procurement.supplier_products spp
+		JOIN procurement.suppliers s ON s.id = spp.supplier_id
+		WHERE spp.product_id = ii.product_id
+		ORDER BY spp.is_primary_supplier DESC, spp.unit_cost, spp.id
+		LIMIT 1
+	) sp ON TRUE
+	WHERE p.is_active`
+
+	buf := bytes.NewBufferString(q)
+	if filter.CategoryID != nil {
+		data["category_id"] = *filter.CategoryID
+		buf.WriteString(" AND p.category_id = :category_id")
+	}
+	if filter.ProductID != nil {
+		data["product_id"] = *filter.ProductID
+		buf.WriteString(" AND ii.product_id = :product_id")
+	}
+	if filter.LocationID != nil {
+		data["location_id"] = *filter.LocationID
+		buf.WriteString(" AND ii.location_id = :location_id")
+	}
+	if id, ok := sqldb.GetScenarioFilter(ctx); ok {
+		data["scenario_id"] = id
+		buf.WriteString(" AND (ii.scenario_id IS NULL OR ii.scenario_id = :scenario_id)")
+	}
+	buf.WriteString(" ORDER BY ii.product_id, ii.location_id")
+
+	var dbItems []item
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbItems); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusItems(dbItems), nil
+}
+
+// QueryDemand retrieves the daily demand for the products at every location
+// from the outbound transactions dated in [from, to).
+func (s *Store) QueryDemand(ctx context.Context, productIDs []uuid.UUID, from, to time.Time) ([]demandforecastbus.DailyDemand, error) {
+	data := map[string]any{
+		"product_ids": productIDs,
+		"from":        from.UTC(),
+		"to":          to.UTC(),
+	}
+
+	scenarioClause := ""
+	if id, ok := sqldb.GetScenarioFilter(ctx); ok {
+		data["scenario_id"] = id
+		scenarioClause = " AND (scenario_id IS NULL OR scenario_id = :scenario_id)"
+	}
+
+	q := `
+	SELECT
+		product_id, location_id, date_trunc('day', transaction_date) AS day, SUM(ABS(quantity)) AS quantity
+	FROM inventory.inventory_transactions
+	WHERE product_id = ANY(:product_ids)
+		AND UPPER(transaction_type) IN (` + demandTypes + `)
+		AND transaction_date >= :from
+		AND transaction_date < :to` + scenarioClause + `
+	GROUP BY product_id, location_id, day
+	ORDER BY day`
+
+	var dbDemand []dailyDemand
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbDemand); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusDailyDemand(dbDemand), nil
+}
+
+// QueryAccuracySamples pairs each forecast whose horizon had passed by now
+// with the mean daily demand at its item over the horizon days starting the
+// day it was taken.
+func (s *Store) QueryAccuracySamples(ctx context.Context, filter demandforecastbus.AccuracyFilter, now time.Time) ([]demandforecastbus.AccuracySample, error) {
+	data := map[string]any{
+		"horizon_days": filter.HorizonDays,
+		"now":          now.UTC(),
+	}
+
+	q := `
+	SELECT
+		df.id AS forecast_id, p.category_id, df.method, df.forecast_daily_usage,
+		COALESCE((
+			SELECT SUM(ABS(it.quantity))
+			FROM inventory.inventory_transactions it
+			WHERE it.product_id = df.product_id AND it.location_id = df.location_id
+				AND UPPER(it.transaction_type) IN (` + demandTypes + `)
+				AND it.transaction_date >= date_trunc('day', df.created_date)
+				AND it.transaction_date < date_trunc('day', df.created_date) + make_interval(days => :horizon_days)
+		), 0)::float8 / :horizon_days AS actual_daily_usage
+	FROM inventory.demand_forecasts df
+	JOIN products.products p ON p.id = df.product_id
+	WHERE date_trunc('day', df.created_date) + make_interval(days => :horizon_days) <= :now`
+
+	buf := bytes.NewBufferString(q)
+	if filter.From != nil {
+		data["from"] = filter.From.UTC()
+		buf.WriteString(" AND df.created_date >= :from")
+	}
+	if filter.CategoryID != nil {
+		data["category_id"] = *filter.CategoryID
+		buf.WriteString(" AND p.category_id = :category_id")
+	}
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeColumns{Locations: []string{"df.location_id"}})
+
+	var dbSamples []accuracySample
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbSamples); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusAccuracySamples(dbSamples)
+}
+
+// LockRun takes a transaction-scoped advisory lock that serializes forecast
+// runs with each other and with reviews, so two runs cannot queue forecasts
+// for the same item and a run cannot supersede a forecast being reviewed.
+func (s *Store) LockRun(ctx context.Context) error {
+	const q = `SELECT pg_advisory_xact_lock(hashtext('inventory.demand_forecasts'))`
+
+	if err := sqldb.ExecContext(ctx, s.log, s.db, q); err != nil {
+		return fmt.Errorf("execcontext: %w", err)
+	}
+
+	return nil
+}
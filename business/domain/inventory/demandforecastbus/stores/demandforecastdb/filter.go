@@ -0,0 +1,81 @@
+package demandforecastdb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
+)
+
+func applyFilter(filter demandforecastbus.QueryFilter, data map[string]any, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["id"] = *filter.ID
+		wc = append(wc, "id = :id")
+	}
+
+	if filter.InventoryItemID != nil {
+		data["inventory_item_id"] = *filter.InventoryItemID
+		wc = append(wc, "inventory_item_id = :inventory_item_id")
+	}
+
+	if filter.ProductID != nil {
+		data["product_id"] = *filter.ProductID
+		wc = append(wc, "product_id = :product_id")
+	}
+
+	if filter.LocationID != nil {
+		data["location_id"] = *filter.LocationID
+		wc = append(wc, "location_id = :location_id")
+	}
+
+	if filter.PolicyID != nil {
+		data["policy_id"] = *filter.PolicyID
+		wc = append(wc, "policy_id = :policy_id")
+	}
+
+	if filter.Method != nil {
+		data["method"] = filter.Method.String()
+		wc = append(wc, "method = :method")
+	}
+
+	if filter.Status != nil {
+		data["status"] = *filter.Status
+		wc = append(wc, "status = :status")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
+
+func applyPolicyFilter(filter demandforecastbus.PolicyFilter, data map[string]any, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["id"] = *filter.ID
+		wc = append(wc, "id = :id")
+	}
+
+	if filter.CategoryID != nil {
+		data["category_id"] = *filter.CategoryID
+		wc = append(wc, "category_id = :category_id")
+	}
+
+	if filter.Method != nil {
+		data["method"] = filter.Method.String()
+		wc = append(wc, "method = :method")
+	}
+
+	if filter.AutoApply != nil {
+		data["auto_apply"] = *filter.AutoApply
+		wc = append(wc, "auto_apply = :auto_apply")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
@@ -0,0 +1,306 @@
+package demandforecastdb
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
+)
+
+// policy mirrors the inventory.forecast_policies DB row.
+type policy struct {
+	ID               uuid.UUID     `db:"id"`
+	CategoryID       uuid.NullUUID `db:"category_id"`
+	Method           string        `db:"method"`
+	HistoryDays      int           `db:"history_days"`
+	WindowDays       int           `db:"window_days"`
+	SmoothingAlpha   float64       `db:"smoothing_alpha"`
+	SeasonLengthDays int           `db:"season_length_days"`
+	ServiceLevel     float64       `db:"service_level"`
+	LeadTimeDays     int           `db:"lead_time_days"`
+	OrderingCost     float64       `db:"ordering_cost"`
+	HoldingCostRate  float64       `db:"holding_cost_rate"`
+	AutoApply        bool          `db:"auto_apply"`
+	CreatedBy        uuid.UUID     `db:"created_by"`
+	CreatedDate      time.Time     `db:"created_date"`
+	UpdatedDate      time.Time     `db:"updated_date"`
+}
+
+func toDBPolicy(bus demandforecastbus.Policy) policy {
+	return policy{
+		ID:               bus.ID,
+		CategoryID:       toNullUUID(bus.CategoryID),
+		Method:           bus.Method.String(),
+		HistoryDays:      bus.HistoryDays,
+		WindowDays:       bus.WindowDays,
+		SmoothingAlpha:   bus.SmoothingAlpha,
+		SeasonLengthDays: bus.SeasonLengthDays,
+		ServiceLevel:     bus.ServiceLevel,
+		LeadTimeDays:     bus.LeadTimeDays,
+		OrderingCost:     bus.OrderingCost,
+		HoldingCostRate:  bus.HoldingCostRate,
+		AutoApply:        bus.AutoApply,
+		CreatedBy:        bus.CreatedBy,
+		CreatedDate:      bus.CreatedDate.UTC(),
+		UpdatedDate:      bus.UpdatedDate.UTC(),
+	}
+}
+
+func toBusPolicy(db policy) (demandforecastbus.Policy, error) {
+	method, err := demandforecastbus.ParseMethod(db.Method)
+	if err != nil {
+		return demandforecastbus.Policy{}, fmt.Errorf("parse method %q: %w", db.Method, err)
+	}
+
+	return demandforecastbus.Policy{
+		ID:               db.ID,
+		CategoryID:       fromNullUUID(db.CategoryID),
+		Method:           method,
+		HistoryDays:      db.HistoryDays,
+		WindowDays:       db.WindowDays,
+		SmoothingAlpha:   db.SmoothingAlpha,
+		SeasonLengthDays: db.SeasonLengthDays,
+		ServiceLevel:     db.ServiceLevel,
+		LeadTimeDays:     db.LeadTimeDays,
+		OrderingCost:     db.OrderingCost,
+		HoldingCostRate:  db.HoldingCostRate,
+		AutoApply:        db.AutoApply,
+		CreatedBy:        db.CreatedBy,
+		CreatedDate:      db.CreatedDate.In(time.Local),
+		UpdatedDate:      db.UpdatedDate.In(time.Local),
+	}, nil
+}
+
+func toBusPolicies(dbs []policy) ([]demandforecastbus.Policy, error) {
+	policies := make([]demandforecastbus.Policy, len(dbs))
+	for i, db := range dbs {
+		p, err := toBusPolicy(db)
+		if err != nil {
+			return nil, err
+		}
+		policies[i] = p
+	}
+	return policies, nil
+}
+
+// =============================================================================
+
+// forecast mirrors the inventory.demand_forecasts DB row.
+type forecast struct {
+	ID                       uuid.UUID     `db:"id"`
+	InventoryItemID          uuid.UUID     `db:"inventory_item_id"`
+	ProductID                uuid.UUID     `db:"product_id"`
+	LocationID               uuid.UUID     `db:"location_id"`
+	PolicyID                 uuid.NullUUID `db:"policy_id"`
+	Method                   string        `db:"method"`
+	HistoryDays              int           `db:"history_days"`
+	ForecastDailyUsage       float64       `db:"forecast_daily_usage"`
+	DemandStdDev             float64       `db:"demand_std_dev"`
+	LeadTimeDays             int           `db:"lead_time_days"`
+	CurrentAvgDailyUsage     int           `db:"current_avg_daily_usage"`
+	CurrentSafetyStock       int           `db:"current_safety_stock"`
+	CurrentReorderPoint      int           `db:"current_reorder_point"`
+	CurrentEOQ               int           `db:"current_eoq"`
+	RecommendedAvgDailyUsage int           `db:"recommended_avg_daily_usage"`
+	RecommendedSafetyStock   int           `db:"recommended_safety_stock"`
+	RecommendedReorderPoint  int           `db:"recommended_reorder_point"`
+	RecommendedEOQ           int           `db:"recommended_eoq"`
+	Status                   string        `db:"status"`
+	ReviewedBy               uuid.NullUUID `db:"reviewed_by"`
+	ReviewedDate             sql.NullTime  `db:"reviewed_date"`
+	CreatedBy                uuid.UUID     `db:"created_by"`
+	CreatedDate              time.Time     `db:"created_date"`
+	UpdatedDate              time.Time     `db:"updated_date"`
+}
+
+func toDBForecast(bus demandforecastbus.Forecast) forecast {
+	return forecast{
+		ID:                       bus.ID,
+		InventoryItemID:          bus.InventoryItemID,
+		ProductID:                bus.ProductID,
+		LocationID:               bus.LocationID,
+		PolicyID:                 toNullUUID(bus.PolicyID),
+		Method:                   bus.Method.String(),
+		HistoryDays:              bus.HistoryDays,
+		ForecastDailyUsage:       bus.ForecastDailyUsage,
+		DemandStdDev:             bus.DemandStdDev,
+		LeadTimeDays:             bus.LeadTimeDays,
+		CurrentAvgDailyUsage:     bus.CurrentAvgDailyUsage,
+		CurrentSafetyStock:       bus.CurrentSafetyStock,
+		CurrentReorderPoint:      bus.CurrentReorderPoint,
+		CurrentEOQ:               bus.CurrentEOQ,
+		RecommendedAvgDailyUsage: bus.RecommendedAvgDailyUsage,
+		RecommendedSafetyStock:   bus.RecommendedSafetyStock,
+		RecommendedReorderPoint:  bus.RecommendedReorderPoint,
+		RecommendedEOQ:           bus.RecommendedEOQ,
+		Status:                   bus.Status,
+		ReviewedBy:               toNullUUID(bus.ReviewedBy),
+		ReviewedDate:             toNullTime(bus.ReviewedDate),
+		CreatedBy:                bus.CreatedBy,
+		CreatedDate:              bus.CreatedDate.UTC(),
+		UpdatedDate:              bus.UpdatedDate.UTC(),
+	}
+}
+
+func toBusForecast(db forecast) (demandforecastbus.Forecast, error) {
+	method, err := demandforecastbus.ParseMethod(db.Method)
+	if err != nil {
+		return demandforecastbus.Forecast{}, fmt.Errorf("parse method %q: %w", db.Method, err)
+	}
+
+	return demandforecastbus.Forecast{
+		ID:                       db.ID,
+		InventoryItemID:          db.InventoryItemID,
+		ProductID:                db.ProductID,
+		LocationID:               db.LocationID,
+		PolicyID:                 fromNullUUID(db.PolicyID),
+		Method:                   method,
+		HistoryDays:              db.HistoryDays,
+		ForecastDailyUsage:       db.ForecastDailyUsage,
+		DemandStdDev:             db.DemandStdDev,
+		LeadTimeDays:             db.LeadTimeDays,
+		CurrentAvgDailyUsage:     db.CurrentAvgDailyUsage,
+		CurrentSafetyStock:       db.CurrentSafetyStock,
+		CurrentReorderPoint:      db.CurrentReorderPoint,
+		CurrentEOQ:               db.CurrentEOQ,
+		RecommendedAvgDailyUsage: db.RecommendedAvgDailyUsage,
+		RecommendedSafetyStock:   db.RecommendedSafetyStock,
+		RecommendedReorderPoint:  db.RecommendedReorderPoint,
+		RecommendedEOQ:           db.RecommendedEOQ,
+		Status:                   db.Status,
+		ReviewedBy:               fromNullUUID(db.ReviewedBy),
+		ReviewedDate:             fromNullTime(db.ReviewedDate),
+		CreatedBy:                db.CreatedBy,
+		CreatedDate:              db.CreatedDate.In(time.Local),
+		UpdatedDate:              db.UpdatedDate.In(time.Local),
+	}, nil
+}
+
+func toBusForecasts(dbs []forecast) ([]demandforecastbus.Forecast, error) {
+	forecasts := make([]demandforecastbus.Forecast, len(dbs))
+	for i, db := range dbs {
+		f, err := toBusForecast(db)
+		if err != nil {
+			return nil, err
+		}
+		forecasts[i] = f
+	}
+	return forecasts, nil
+}
+
+// =============================================================================
+
+// item is one row of the item query.
+type item struct {
+	ID                    uuid.UUID `db:"id"`
+	ProductID             uuid.UUID `db:"product_id"`
+	LocationID            uuid.UUID `db:"location_id"`
+	CategoryID            uuid.UUID `db:"category_id"`
+	AvgDailyUsage         int       `db:"avg_daily_usage"`
+	SafetyStock           int       `db:"safety_stock"`
+	ReorderPoint          int       `db:"reorder_point"`
+	EconomicOrderQuantity int       `db:"economic_order_quantity"`
+	SupplierLeadTimeDays  int       `db:"supplier_lead_time_days"`
+	UnitCost              float64   `db:"unit_cost"`
+}
+
+func toBusItems(dbs []item) []demandforecastbus.Item {
+	items := make([]demandforecastbus.Item, len(dbs))
+	for i, db := range dbs {
+		items[i] = demandforecastbus.Item{
+			ID:                    db.ID,
+			ProductID:             db.ProductID,
+			LocationID:            db.LocationID,
+			CategoryID:            db.CategoryID,
+			AvgDailyUsage:         db.AvgDailyUsage,
+			SafetyStock:           db.SafetyStock,
+			ReorderPoint:          db.ReorderPoint,
+			EconomicOrderQuantity: db.EconomicOrderQuantity,
+			SupplierLeadTimeDays:  db.SupplierLeadTimeDays,
+			UnitCost:              db.UnitCost,
+		}
+	}
+	return items
+}
+
+// dailyDemand is one row of the demand query.
+type dailyDemand struct {
+	ProductID  uuid.UUID `db:"product_id"`
+	LocationID uuid.UUID `db:"location_id"`
+	Day        time.Time `db:"day"`
+	Quantity   float64   `db:"quantity"`
+}
+
+func toBusDailyDemand(dbs []dailyDemand) []demandforecastbus.DailyDemand {
+	demand := make([]demandforecastbus.DailyDemand, len(dbs))
+	for i, db := range dbs {
+		demand[i] = demandforecastbus.DailyDemand{
+			ProductID:  db.ProductID,
+			LocationID: db.LocationID,
+			Day:        db.Day.UTC(),
+			Quantity:   db.Quantity,
+		}
+	}
+	return demand
+}
+
+// accuracySample is one row of the accuracy query.
+type accuracySample struct {
+	ForecastID         uuid.UUID `db:"forecast_id"`
+	CategoryID         uuid.UUID `db:"category_id"`
+	Method             string    `db:"method"`
+	ForecastDailyUsage float64   `db:"forecast_daily_usage"`
+	ActualDailyUsage   float64   `db:"actual_daily_usage"`
+}
+
+func toBusAccuracySamples(dbs []accuracySample) ([]demandforecastbus.AccuracySample, error) {
+	samples := make([]demandforecastbus.AccuracySample, len(dbs))
+	for i, db := range dbs {
+		method, err := demandforecastbus.ParseMethod(db.Method)
+		if err != nil {
+			return nil, fmt.Errorf("parse method %q: %w", db.Method, err)
+		}
+		samples[i] = demandforecastbus.AccuracySample{
+			ForecastID:         db.ForecastID,
+			CategoryID:         db.CategoryID,
+			Method:             method,
+			ForecastDailyUsage: db.ForecastDailyUsage,
+			ActualDailyUsage:   db.ActualDailyUsage,
+		}
+	}
+	return samples, nil
+}
+
+// =============================================================================
+
+func toNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func fromNullUUID(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	v := id.UUID
+	return &v
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time.In(time.Local)
+	return &v
+}
@@ -0,0 +1,36 @@
+package demandforecastdb
+
+import (
+	"fmt"
+
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+)
+
+var orderByFields = map[string]string{
+	demandforecastbus.OrderByID:              "id",
+	demandforecastbus.OrderByInventoryItemID: "inventory_item_id",
+	demandforecastbus.OrderByProductID:       "product_id",
+	demandforecastbus.OrderByLocationID:      "location_id",
+	demandforecastbus.OrderByMethod:          "method",
+	demandforecastbus.OrderByStatus:          "status",
+	demandforecastbus.OrderByCreatedDate:     "created_date",
+	demandforecastbus.OrderByUpdatedDate:     "updated_date",
+}
+
+var policyOrderByFields = map[string]string{
+	demandforecastbus.OrderByID:          "id",
+	demandforecastbus.OrderByCategoryID:  "category_id",
+	demandforecastbus.OrderByMethod:      "method",
+	demandforecastbus.OrderByCreatedDate: "created_date",
+	demandforecastbus.OrderByUpdatedDate: "updated_date",
+}
+
+func orderByClause(fields map[string]string, orderBy order.By) (string, error) {
+	by, exists := fields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
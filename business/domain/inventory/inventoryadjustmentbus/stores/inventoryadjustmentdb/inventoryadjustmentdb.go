@@ -40,6 +40,10 @@ func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (inventoryadjustmentbus.Sto
 }
 
 func (s *Store) Create(ctx context.Context, ia inventoryadjustmentbus.InventoryAdjustment) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, sqldb.ScopeByLocation, map[string]uuid.UUID{"location_id": ia.LocationID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
     INSERT INTO inventory.inventory_adjustments (
         id, product_id, location_id, adjusted_by, approved_by, approval_status, approval_reason,
@@ -66,6 +70,10 @@ func (s *Store) Create(ctx context.Context, ia inventoryadjustmentbus.InventoryA
 }
 
 func (s *Store) Update(ctx context.Context, ia inventoryadjustmentbus.InventoryAdjustment) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, sqldb.ScopeByLocation, map[string]uuid.UUID{"location_id": ia.LocationID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
     UPDATE
         inventory.inventory_adjustments
@@ -132,6 +140,7 @@ func (s *Store) Query(ctx context.Context, filter inventoryadjustmentbus.QueryFi
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	orderByClause, err := orderByClause(orderBy)
 	if err != nil {
@@ -161,6 +170,7 @@ func (s *Store) Count(ctx context.Context, filter inventoryadjustmentbus.QueryFi
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	var count struct {
 		Count int `db:"count"`
@@ -191,6 +201,7 @@ func (s *Store) QueryByID(ctx context.Context, adjustmentID uuid.UUID) (inventor
 
 	buf := bytes.NewBufferString(q)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	var dbInvAdj inventoryAdjustment
 	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &dbInvAdj); err != nil {
@@ -22,6 +22,8 @@ var (
 	ErrUniqueEntry           = errors.New("inventoryItem entry is not unique")
 	ErrForeignKeyViolation   = errors.New("foreign key violation")
 	ErrAuthenticationFailure = errors.New("authentication failed")
+	ErrNotStagingLocation    = errors.New("location is not in an outbound staging zone")
+	ErrNotStaged             = errors.New("stock is not staged at location")
 )
 
 type Storer interface {
@@ -33,11 +35,17 @@ type Storer interface {
 	QueryWithLocationDetails(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]InventoryItemWithLocation, error)
 	QueryItemsWithProductAtLocation(ctx context.Context, locationID uuid.UUID) ([]ItemWithProduct, error)
 	QueryAvailableForAllocation(ctx context.Context, productID uuid.UUID, locationID *uuid.UUID, warehouseID *uuid.UUID, strategy string, limit int) ([]InventoryItem, error)
+	QueryAllocationCandidates(ctx context.Context, q AllocationQuery) ([]AllocationCandidate, error)
+	QueryAllocationOrder(ctx context.Context, orderID uuid.UUID) (AllocationOrder, error)
 	Count(ctx context.Context, filter QueryFilter) (int, error)
 	QueryByID(ctx context.Context, inventoryID uuid.UUID) (InventoryItem, error)
+	QueryByIDForUpdate(ctx context.Context, inventoryID uuid.UUID) (InventoryItem, error)
 	UpsertQuantity(ctx context.Context, newID, productID, locationID uuid.UUID, quantityDelta int) error
 	AdjustQuantity(ctx context.Context, newID, productID, locationID uuid.UUID, quantityDelta int) error
 	DecrementQuantity(ctx context.Context, productID, locationID uuid.UUID, quantity int) error
+	PickQuantity(ctx context.Context, productID, locationID uuid.UUID, quantity int) error
+	StageQuantity(ctx context.Context, newID, productID, locationID uuid.UUID, quantity int) error
+	ShipStagedQuantity(ctx context.Context, productID, locationID uuid.UUID, quantity int) error
 }
 
 type Business struct {
@@ -299,6 +307,63 @@ func (b *Business) DecrementQuantity(ctx context.Context, productID, locationID
 	return nil
 }
 
+// PickQuantity takes picked stock off hand at the pick location (productID,
+// locationID) and relieves the allocation it was picked against, never below
+// zero, so stock picked without an allocation relieves nothing. Returns
+// ErrInsufficientStock if fewer than quantity units are on hand.
+func (b *Business) PickQuantity(ctx context.Context, productID, locationID uuid.UUID, quantity int) error {
+	ctx, span := otel.AddSpan(ctx, "business.inventoryitembus.pickquantity")
+	defer span.End()
+
+	if quantity <= 0 {
+		return fmt.Errorf("pick quantity: quantity must be positive, got %d", quantity)
+	}
+
+	if err := b.storer.PickQuantity(ctx, productID, locationID, quantity); err != nil {
+		return fmt.Errorf("pick quantity: %w", err)
+	}
+
+	return nil
+}
+
+// StageQuantity puts picked stock at an outbound staging location. The units
+// count as on hand and as allocated, so allocation never hands them to another
+// order while they wait to ship. Returns ErrNotStagingLocation if the location
+// is not in a zone whose stage is outbound.
+func (b *Business) StageQuantity(ctx context.Context, productID, locationID uuid.UUID, quantity int) error {
+	ctx, span := otel.AddSpan(ctx, "business.inventoryitembus.stagequantity")
+	defer span.End()
+
+	if quantity <= 0 {
+		return fmt.Errorf("stage quantity: quantity must be positive, got %d", quantity)
+	}
+
+	if err := b.storer.StageQuantity(ctx, uuid.New(), productID, locationID, quantity); err != nil {
+		return fmt.Errorf("stage quantity: %w", err)
+	}
+
+	return nil
+}
+
+// ShipStagedQuantity removes shipped stock from an outbound staging location,
+// lowering on-hand and allocated quantity by exactly quantity. Returns
+// ErrNotStaged if fewer than quantity units are staged there, so a shipment
+// can never consume stock held for other orders.
+func (b *Business) ShipStagedQuantity(ctx context.Context, productID, locationID uuid.UUID, quantity int) error {
+	ctx, span := otel.AddSpan(ctx, "business.inventoryitembus.shipstagedquantity")
+	defer span.End()
+
+	if quantity <= 0 {
+		return fmt.Errorf("ship staged quantity: quantity must be positive, got %d", quantity)
+	}
+
+	if err := b.storer.ShipStagedQuantity(ctx, productID, locationID, quantity); err != nil {
+		return fmt.Errorf("ship staged quantity: %w", err)
+	}
+
+	return nil
+}
+
 // QueryAvailableForAllocation retrieves inventory items that have available quantity for allocation.
 func (b *Business) QueryAvailableForAllocation(ctx context.Context, productID uuid.UUID, locationID *uuid.UUID, warehouseID *uuid.UUID, strategy string, limit int) ([]InventoryItem, error) {
 	ctx, span := otel.AddSpan(ctx, "business.inventoryitembus.queryavailableforallocation")
@@ -312,6 +377,36 @@ func (b *Business) QueryAvailableForAllocation(ctx context.Context, productID uu
 	return inventoryItems, nil
 }
 
+// QueryAllocationCandidates locks (FOR UPDATE) one page of the product's
+// inventory items with available stock, in q.Sort order, and returns them with
+// their location, cost and lot attributes, for strategy-driven allocation.
+// Must run inside a transaction.
+func (b *Business) QueryAllocationCandidates(ctx context.Context, q AllocationQuery) ([]AllocationCandidate, error) {
+	ctx, span := otel.AddSpan(ctx, "business.inventoryitembus.queryallocationcandidates")
+	defer span.End()
+
+	candidates, err := b.storer.QueryAllocationCandidates(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("query allocation candidates: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// QueryAllocationOrder returns the priority and delivery address of the sales
+// order being allocated for.
+func (b *Business) QueryAllocationOrder(ctx context.Context, orderID uuid.UUID) (AllocationOrder, error) {
+	ctx, span := otel.AddSpan(ctx, "business.inventoryitembus.queryallocationorder")
+	defer span.End()
+
+	order, err := b.storer.QueryAllocationOrder(ctx, orderID)
+	if err != nil {
+		return AllocationOrder{}, fmt.Errorf("query allocation order: orderID[%s]: %w", orderID, err)
+	}
+
+	return order, nil
+}
+
 // Count returns the total number of inventoryItems in the system.
 func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
 	ctx, span := otel.AddSpan(ctx, "business.inventoryitembus.count")
@@ -332,3 +427,18 @@ func (b *Business) QueryByID(ctx context.Context, inventoryID uuid.UUID) (Invent
 
 	return inventoryItem, nil
 }
+
+// QueryByIDForUpdate retrieves an inventoryItem by its ID and locks the row
+// until the surrounding transaction ends. Use it before a read-modify-write of
+// the quantity columns.
+func (b *Business) QueryByIDForUpdate(ctx context.Context, inventoryID uuid.UUID) (InventoryItem, error) {
+	ctx, span := otel.AddSpan(ctx, "business.inventoryitembus.querybyidforupdate")
+	defer span.End()
+
+	inventoryItem, err := b.storer.QueryByIDForUpdate(ctx, inventoryID)
+	if err != nil {
+		return InventoryItem{}, fmt.Errorf("query by id for update: %w", err)
+	}
+
+	return inventoryItem, nil
+}
@@ -77,3 +77,78 @@ type UpdateInventoryItem struct {
 	SafetyStock           *int       `json:"safety_stock,omitempty"`
 	AvgDailyUsage         *int       `json:"avg_daily_usage,omitempty"`
 }
+
+// AllocationQuery scopes the candidates for allocating one product. OrderID,
+// when set, resolves the destination address and order priority.
+type AllocationQuery struct {
+	ProductID   uuid.UUID
+	LocationID  *uuid.UUID
+	WarehouseID *uuid.UUID
+	Sort        AllocationSort
+	Destination *AllocationAddress // ranks AllocationSortNearest
+	Offset      int
+	Limit       int
+}
+
+// AllocationSort is the order allocation candidates are locked and returned
+// in. Each strategy reads candidates in its own order so the pages it reads
+// before the requested quantity is covered are the ones it prefers. Ties fall
+// back to the oldest item first.
+type AllocationSort string
+
+// Allocation candidate sort orders.
+const (
+	AllocationSortOldest        AllocationSort = "oldest"
+	AllocationSortNewest        AllocationSort = "newest"
+	AllocationSortExpiry        AllocationSort = "expiry"          // earliest usable lot expiry
+	AllocationSortCost          AllocationSort = "cost"            // lowest landed cost
+	AllocationSortNearest       AllocationSort = "nearest"         // warehouse closest to Destination
+	AllocationSortLeastBusy     AllocationSort = "least_busy"      // fewest open picks, then least full
+	AllocationSortPickFaceFirst AllocationSort = "pick_face_first" // pick locations first
+	AllocationSortReserveFirst  AllocationSort = "reserve_first"   // reserve locations first
+)
+
+// AllocationOrder is what allocation needs to know about the sales order it
+// allocates for.
+type AllocationOrder struct {
+	Priority    string
+	Destination *AllocationAddress // order shipping address, else customer delivery address
+}
+
+// AllocationAddress identifies a street's place in the geography hierarchy.
+// Allocation ranks proximity by the deepest level two addresses share.
+type AllocationAddress struct {
+	PostalCode string
+	CityID     uuid.UUID
+	RegionID   uuid.UUID
+	CountryID  uuid.UUID
+}
+
+// AllocationLot is the quantity of one lot held at a location. Lots of every
+// quality status are returned so callers can tell a lot-tracked location whose
+// stock is all expired or quarantined from one that is not lot-tracked.
+type AllocationLot struct {
+	LotID          uuid.UUID
+	LotNumber      string
+	LocationID     uuid.UUID
+	Quantity       int
+	QualityStatus  string
+	ExpirationDate time.Time
+	ReceivedDate   time.Time
+	UnitCost       *float64 // landed cost effective when the lot was received
+}
+
+// AllocationCandidate is an inventory item with available stock plus the
+// location, cost and lot attributes the allocation strategies rank on.
+type AllocationCandidate struct {
+	Item                InventoryItem
+	WarehouseID         uuid.UUID
+	ZoneID              uuid.UUID
+	IsPickLocation      bool
+	IsReserveLocation   bool
+	CurrentUtilization  float64
+	PendingPickQuantity int      // open pick tasks at the location
+	UnitCost            *float64 // landed cost effective when the item was created
+	WarehouseAddress    AllocationAddress
+	Lots                []AllocationLot // earliest expiry first
+}
@@ -47,6 +47,10 @@ func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (inventoryitembus.Storer, e
 
 // Create inserts a new inventory product into the database.
 func (s *Store) Create(ctx context.Context, ip inventoryitembus.InventoryItem) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, sqldb.ScopeByLocation, map[string]uuid.UUID{"location_id": ip.LocationID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
 	INSERT INTO inventory.inventory_items (
 		id, product_id, location_id, quantity, reserved_quantity, allocated_quantity,
@@ -74,6 +78,10 @@ func (s *Store) Create(ctx context.Context, ip inventoryitembus.InventoryItem) e
 
 // Update updates an existing inventory product in the database.
 func (s *Store) Update(ctx context.Context, ip inventoryitembus.InventoryItem) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, sqldb.ScopeByLocation, map[string]uuid.UUID{"location_id": ip.LocationID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
     UPDATE
         inventory.inventory_items
@@ -143,6 +151,7 @@ func (s *Store) Query(ctx context.Context, filter inventoryitembus.QueryFilter,
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	orderByClause, err := orderByClause(orderBy)
 	if err != nil {
@@ -183,6 +192,7 @@ func (s *Store) QueryItemsWithProductAtLocation(ctx context.Context, locationID
 		buf.WriteString(" AND (ii.scenario_id IS NULL OR ii.scenario_id = :scenario_id)")
 		data["scenario_id"] = sid
 	}
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeColumns{Locations: []string{"ii.location_id"}})
 
 	buf.WriteString(" ORDER BY p.name")
 
@@ -230,6 +240,7 @@ func (s *Store) QueryWithLocationDetails(ctx context.Context, filter inventoryit
 		}
 		data["scenario_id"] = sid
 	}
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeColumns{Locations: []string{"ii.location_id"}})
 
 	orderByClause, err := orderByClause(orderBy)
 	if err != nil {
@@ -273,7 +284,8 @@ func (s *Store) QueryAvailableForAllocation(ctx context.Context, productID uuid.
         inventory.inventory_items ii
     WHERE
         ii.product_id = :product_id
-        AND (ii.quantity - ii.reserved_quantity - ii.allocated_quantity) > 0`
+        AND (ii.quantity - ii.reserved_quantity - ii.allocated_quantity) > 0
+        AND ii.location_id NOT IN (SELECT id FROM inventory.inventory_locations WHERE is_transit)`
 
 	// Add location filtering if specified
 	if locationID != nil {
@@ -324,7 +336,8 @@ func (s *Store) queryFEFO(ctx context.Context, args map[string]any, locationID *
 	// while leaving the sub side wide-open. The subquery then LIMIT 1'd to
 	// an arbitrary row, never matching the targeted product+location.
 	filters := `sub.product_id = :product_id
-        AND (sub.quantity - sub.reserved_quantity - sub.allocated_quantity) > 0`
+        AND (sub.quantity - sub.reserved_quantity - sub.allocated_quantity) > 0
+        AND sub.location_id NOT IN (SELECT id FROM inventory.inventory_locations WHERE is_transit)`
 
 	if locationID != nil {
 		filters += ` AND sub.location_id = :location_id`
@@ -396,6 +409,7 @@ func (s *Store) Count(ctx context.Context, filter inventoryitembus.QueryFilter)
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	var count struct {
 		Count int `db:"count"`
@@ -425,6 +439,40 @@ func (s *Store) QueryByID(ctx context.Context, itemID uuid.UUID) (inventoryitemb
 
 	buf := bytes.NewBufferString(q)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
+
+	var ip inventoryItem
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &ip); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return inventoryitembus.InventoryItem{}, inventoryitembus.ErrNotFound
+		}
+		return inventoryitembus.InventoryItem{}, fmt.Errorf("namedquerystruct: %w", err)
+	}
+
+	return toBusInventoryItem(ip), nil
+}
+
+// QueryByIDForUpdate is QueryByID with a row lock held until the transaction
+// ends.
+func (s *Store) QueryByIDForUpdate(ctx context.Context, itemID uuid.UUID) (inventoryitembus.InventoryItem, error) {
+	data := map[string]any{
+		"id": itemID.String(),
+	}
+
+	const q = `
+    SELECT
+        id, product_id, location_id, quantity, reserved_quantity, allocated_quantity,
+        minimum_stock, maximum_stock, reorder_point, economic_order_quantity, safety_stock,
+        avg_daily_usage, created_date, updated_date, scenario_id
+    FROM
+        inventory.inventory_items
+    WHERE
+        id = :id
+    `
+
+	buf := bytes.NewBufferString(q)
+	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	buf.WriteString(" FOR UPDATE")
 
 	var ip inventoryItem
 	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &ip); err != nil {
@@ -529,3 +577,359 @@ func (s *Store) DecrementQuantity(ctx context.Context, productID, locationID uui
 
 	return nil
 }
+
+// PickQuantity subtracts quantity from the inventory item at (product_id,
+// location_id) and lowers allocated_quantity by the same amount, clamped at
+// zero. The WHERE guard prevents quantity from going negative; if no row is
+// updated, ErrInsufficientStock is returned.
+func (s *Store) PickQuantity(ctx context.Context, productID, locationID uuid.UUID, quantity int) error {
+	data := struct {
+		ProductID  uuid.UUID `db:"product_id"`
+		LocationID uuid.UUID `db:"location_id"`
+		Quantity   int       `db:"quantity"`
+	}{
+		ProductID:  productID,
+		LocationID: locationID,
+		Quantity:   quantity,
+	}
+
+	const q = `
+	UPDATE inventory.inventory_items
+	SET
+		quantity           = quantity - :quantity,
+		allocated_quantity = GREATEST(allocated_quantity - :quantity, 0),
+		updated_date       = NOW()
+	WHERE
+		product_id  = :product_id
+		AND location_id = :location_id
+		AND quantity >= :quantity
+	`
+
+	rowsAffected, err := sqldb.NamedExecContextWithCount(ctx, s.log, s.db, q, data)
+	if err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return inventoryitembus.ErrInsufficientStock
+	}
+
+	return nil
+}
+
+// StageQuantity creates or updates the inventory item at (product_id,
+// location_id), adding quantity to both quantity and allocated_quantity. The
+// INSERT only selects a row when the location sits in an outbound-stage zone;
+// if nothing is written, ErrNotStagingLocation is returned.
+func (s *Store) StageQuantity(ctx context.Context, newID, productID, locationID uuid.UUID, quantity int) error {
+	data := map[string]any{
+		"id":          newID,
+		"product_id":  productID,
+		"location_id": locationID,
+		"quantity":    quantity,
+	}
+
+	if sid, ok := sqldb.GetScenarioFilter(ctx); ok {
+		data["scenario_id"] = sid
+	} else {
+		data["scenario_id"] = nil
+	}
+
+	const q = `
+	INSERT INTO inventory.inventory_items
+		(id, product_id, location_id, quantity,
+		 reserved_quantity, allocated_quantity,
+		 minimum_stock, maximum_stock, reorder_point,
+		 economic_order_quantity, safety_stock, avg_daily_usage,
+		 created_date, updated_date, scenario_id)
+	SELECT
+		CAST(:id AS UUID), CAST(:product_id AS UUID), il.id, CAST(:quantity AS INT),
+		0, CAST(:quantity AS INT), 0, 0, 0, 0, 0, 0,
+		NOW(), NOW(), CAST(:scenario_id AS UUID)
+	FROM inventory.inventory_locations il
+	JOIN inventory.zones z ON z.id = il.zone_id
+	WHERE
+		il.id = :location_id
+		AND z.stage = 'outbound'
+	ON CONFLICT (product_id, location_id)
+	DO UPDATE SET
+		quantity           = inventory.inventory_items.quantity + EXCLUDED.quantity,
+		allocated_quantity = inventory.inventory_items.allocated_quantity + EXCLUDED.allocated_quantity,
+		updated_date       = NOW()
+	`
+
+	rowsAffected, err := sqldb.NamedExecContextWithCount(ctx, s.log, s.db, q, data)
+	if err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return inventoryitembus.ErrNotStagingLocation
+	}
+
+	return nil
+}
+
+// ShipStagedQuantity subtracts quantity from both quantity and
+// allocated_quantity of the inventory item at (product_id, location_id). The
+// WHERE guard requires an outbound-stage location holding at least quantity
+// staged units; if no row is updated, ErrNotStaged is returned.
+func (s *Store) ShipStagedQuantity(ctx context.Context, productID, locationID uuid.UUID, quantity int) error {
+	data := struct {
+		ProductID  uuid.UUID `db:"product_id"`
+		LocationID uuid.UUID `db:"location_id"`
+		Quantity   int       `db:"quantity"`
+	}{
+		ProductID:  productID,
+		LocationID: locationID,
+		Quantity:   quantity,
+	}
+
+	const q = `
+	UPDATE inventory.inventory_items ii
+	SET
+		quantity           = ii.quantity - :quantity,
+		allocated_quantity = ii.allocated_quantity - :quantity,
+		updated_date       = NOW()
+	FROM inventory.inventory_locations il
+	JOIN inventory.zones z ON z.id = il.zone_id
+	WHERE
+		ii.product_id  = :product_id
+		AND ii.location_id = :location_id
+		AND il.id = ii.location_id
+		AND z.stage = 'outbound'
+		AND ii.quantity >= :quantity
+		AND ii.allocated_quantity >= :quantity
+	`
+
+	rowsAffected, err := sqldb.NamedExecContextWithCount(ctx, s.log, s.db, q, data)
+	if err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return inventoryitembus.ErrNotStaged
+	}
+
+	return nil
+}
+
+// QueryAllocationCandidates locks one page of the product's inventory items
+// that have available stock (FOR UPDATE OF ii), in q.Sort order, and returns
+// them with location, warehouse address, open-pick and cost attributes,
+// followed by a second read of the lots held at those locations.
+func (s *Store) QueryAllocationCandidates(ctx context.Context, q inventoryitembus.AllocationQuery) ([]inventoryitembus.AllocationCandidate, error) {
+	args := map[string]any{
+		"product_id": q.ProductID.String(),
+		"offset":     q.Offset,
+		"limit":      q.Limit,
+	}
+
+	query := `
+    SELECT
+        ii.id, ii.product_id, ii.location_id, ii.quantity, ii.reserved_quantity,
+        ii.allocated_quantity, ii.minimum_stock, ii.maximum_stock, ii.reorder_point,
+        ii.economic_order_quantity, ii.safety_stock, ii.avg_daily_usage,
+        ii.created_date, ii.updated_date,
+        il.warehouse_id, il.zone_id, il.is_pick_location, il.is_reserve_location,
+        il.current_utilization,
+        COALESCE((
+            SELECT SUM(pt.quantity_to_pick - pt.quantity_picked)
+            FROM inventory.pick_tasks pt
+            WHERE pt.location_id = ii.location_id AND pt.status IN ('pending', 'in_progress')
+        ), 0) AS pending_pick_quantity,
+        (
+            SELECT pc.landed_cost
+            FROM products.product_costs pc
+            WHERE pc.product_id = ii.product_id
+            ORDER BY (pc.effective_date <= ii.created_date) DESC, pc.effective_date DESC
+            LIMIT 1
+        ) AS unit_cost,
+        st.postal_code, ci.id AS city_id, ci.region_id, rg.country_id
+    FROM
+        inventory.inventory_items ii
+    JOIN inventory.inventory_locations il ON il.id = ii.location_id
+    JOIN inventory.warehouses w ON w.id = il.warehouse_id
+    LEFT JOIN geography.streets st ON st.id = w.street_id
+    LEFT JOIN geography.cities ci ON ci.id = st.city_id
+    LEFT JOIN geography.regions rg ON rg.id = ci.region_id
+    WHERE
+        ii.product_id = :product_id
+        AND (ii.quantity - ii.reserved_quantity - ii.allocated_quantity) > 0
+        AND NOT il.is_transit`
+
+	if q.LocationID != nil {
+		query += ` AND ii.location_id = :location_id`
+		args["location_id"] = q.LocationID.String()
+	}
+
+	if q.WarehouseID != nil {
+		query += ` AND il.warehouse_id = :warehouse_id`
+		args["warehouse_id"] = q.WarehouseID.String()
+	}
+
+	if sid, ok := sqldb.GetScenarioFilter(ctx); ok {
+		query += " AND (ii.scenario_id IS NULL OR ii.scenario_id = :scenario_id)"
+		args["scenario_id"] = sid
+	}
+
+	query += " ORDER BY " + allocationSortSQL(q, args) + "ii.created_date ASC, ii.id ASC"
+	query += " OFFSET :offset LIMIT :limit FOR UPDATE OF ii"
+
+	var dbCands []allocationCandidate
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, query, args, &dbCands); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	cands := make([]inventoryitembus.AllocationCandidate, len(dbCands))
+
+	locationIDs := make([]uuid.UUID, len(dbCands))
+	byLocation := make(map[uuid.UUID]int, len(dbCands))
+	for i, c := range dbCands {
+		cands[i] = toBusAllocationCandidate(c)
+		locationIDs[i] = c.LocationID
+		byLocation[c.LocationID] = i
+	}
+
+	if len(locationIDs) > 0 {
+		lots, err := s.queryAllocationLots(ctx, q.ProductID, locationIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, lot := range lots {
+			i := byLocation[lot.LocationID]
+			cands[i].Lots = append(cands[i].Lots, lot)
+		}
+	}
+
+	return cands, nil
+}
+
+// allocationSortSQL returns the leading ORDER BY terms for q.Sort, each
+// followed by a comma, adding any arguments they need to args. The terms
+// approximate the strategy's ranking closely enough to choose which candidates
+// are read; the strategy ranks the candidates it reads exactly.
+func allocationSortSQL(q inventoryitembus.AllocationQuery, args map[string]any) string {
+	switch q.Sort {
+	case inventoryitembus.AllocationSortNewest:
+		return "ii.created_date DESC, "
+
+	case inventoryitembus.AllocationSortExpiry:
+		return `(
+            SELECT MIN(lt.expiration_date)
+            FROM inventory.lot_locations ll
+            JOIN inventory.lot_trackings lt ON lt.id = ll.lot_id
+            JOIN procurement.supplier_products sp ON sp.id = lt.supplier_product_id
+            WHERE ll.location_id = ii.location_id AND sp.product_id = ii.product_id
+                AND ll.quantity > 0 AND lt.quality_status IN ('good', 'released')
+                AND lt.expiration_date > NOW()
+        ) ASC NULLS LAST, `
+
+	case inventoryitembus.AllocationSortCost:
+		return "unit_cost ASC NULLS LAST, "
+
+	case inventoryitembus.AllocationSortNearest:
+		if q.Destination == nil {
+			return ""
+		}
+		args["dest_postal_code"] = q.Destination.PostalCode
+		args["dest_city_id"] = q.Destination.CityID.String()
+		args["dest_region_id"] = q.Destination.RegionID.String()
+		args["dest_country_id"] = q.Destination.CountryID.String()
+		return `CASE
+            WHEN :dest_postal_code <> '' AND st.postal_code = :dest_postal_code AND ci.id = :dest_city_id THEN 0
+            WHEN ci.id = :dest_city_id THEN 1
+            WHEN ci.region_id = :dest_region_id THEN 2
+            WHEN rg.country_id = :dest_country_id THEN 3
+            ELSE 4
+        END ASC, `
+
+	case inventoryitembus.AllocationSortLeastBusy:
+		return "pending_pick_quantity ASC, il.current_utilization ASC, "
+
+	case inventoryitembus.AllocationSortPickFaceFirst:
+		return "il.is_pick_location DESC, "
+
+	case inventoryitembus.AllocationSortReserveFirst:
+		return "il.is_reserve_location DESC, "
+	}
+
+	return ""
+}
+
+// queryAllocationLots returns the lots of a product held at the given
+// locations, earliest expiry first.
+func (s *Store) queryAllocationLots(ctx context.Context, productID uuid.UUID, locationIDs []uuid.UUID) ([]inventoryitembus.AllocationLot, error) {
+	data := struct {
+		ProductID   string      `db:"product_id"`
+		LocationIDs []uuid.UUID `db:"location_ids"`
+	}{
+		ProductID:   productID.String(),
+		LocationIDs: locationIDs,
+	}
+
+	const q = `
+    SELECT
+        ll.lot_id, lt.lot_number, ll.location_id, ll.quantity, lt.quality_status,
+        lt.expiration_date, lt.received_date,
+        (
+            SELECT pc.landed_cost
+            FROM products.product_costs pc
+            WHERE pc.product_id = sp.product_id
+            ORDER BY (pc.effective_date <= lt.received_date) DESC, pc.effective_date DESC
+            LIMIT 1
+        ) AS unit_cost
+    FROM
+        inventory.lot_locations ll
+    JOIN inventory.lot_trackings lt ON lt.id = ll.lot_id
+    JOIN procurement.supplier_products sp ON sp.id = lt.supplier_product_id
+    WHERE
+        sp.product_id = :product_id
+        AND ll.location_id = ANY(:location_ids)
+        AND ll.quantity > 0
+    ORDER BY lt.expiration_date ASC, lt.received_date ASC, lt.lot_number ASC`
+
+	var dbLots []allocationLot
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbLots); err != nil {
+		return nil, fmt.Errorf("query allocation lots: %w", err)
+	}
+
+	lots := make([]inventoryitembus.AllocationLot, len(dbLots))
+	for i, l := range dbLots {
+		lots[i] = toBusAllocationLot(l)
+	}
+
+	return lots, nil
+}
+
+// QueryAllocationOrder returns the order's priority and destination address
+// (shipping address, else the customer's delivery address).
+func (s *Store) QueryAllocationOrder(ctx context.Context, orderID uuid.UUID) (inventoryitembus.AllocationOrder, error) {
+	data := struct {
+		ID string `db:"id"`
+	}{
+		ID: orderID.String(),
+	}
+
+	const q = `
+    SELECT
+        o.priority, st.postal_code, ci.id AS city_id, ci.region_id, rg.country_id
+    FROM
+        sales.orders o
+    JOIN sales.customers c ON c.id = o.customer_id
+    LEFT JOIN geography.streets st ON st.id = COALESCE(o.shipping_address_id, c.delivery_address_id)
+    LEFT JOIN geography.cities ci ON ci.id = st.city_id
+    LEFT JOIN geography.regions rg ON rg.id = ci.region_id
+    WHERE
+        o.id = :id`
+
+	var order allocationOrder
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &order); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return inventoryitembus.AllocationOrder{}, fmt.Errorf("namedquerystruct: %w", inventoryitembus.ErrNotFound)
+		}
+		return inventoryitembus.AllocationOrder{}, fmt.Errorf("namedquerystruct: %w", err)
+	}
+
+	return toBusAllocationOrder(order), nil
+}
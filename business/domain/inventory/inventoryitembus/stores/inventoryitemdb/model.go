@@ -1,6 +1,7 @@
 package inventoryitemdb
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
@@ -159,3 +160,97 @@ func toDBInventoryItem(bus inventoryitembus.InventoryItem) inventoryItem {
 		ScenarioID:            bus.ScenarioID,
 	}
 }
+
+type allocationCandidate struct {
+	inventoryItem
+	WarehouseID         uuid.UUID       `db:"warehouse_id"`
+	ZoneID              uuid.UUID       `db:"zone_id"`
+	IsPickLocation      bool            `db:"is_pick_location"`
+	IsReserveLocation   bool            `db:"is_reserve_location"`
+	CurrentUtilization  float64         `db:"current_utilization"`
+	PendingPickQuantity int             `db:"pending_pick_quantity"`
+	UnitCost            sql.NullFloat64 `db:"unit_cost"`
+	allocationAddress
+}
+
+type allocationAddress struct {
+	PostalCode sql.NullString `db:"postal_code"`
+	CityID     uuid.NullUUID  `db:"city_id"`
+	RegionID   uuid.NullUUID  `db:"region_id"`
+	CountryID  uuid.NullUUID  `db:"country_id"`
+}
+
+func toBusAllocationAddress(db allocationAddress) inventoryitembus.AllocationAddress {
+	return inventoryitembus.AllocationAddress{
+		PostalCode: db.PostalCode.String,
+		CityID:     db.CityID.UUID,
+		RegionID:   db.RegionID.UUID,
+		CountryID:  db.CountryID.UUID,
+	}
+}
+
+func toBusAllocationCandidate(db allocationCandidate) inventoryitembus.AllocationCandidate {
+	c := inventoryitembus.AllocationCandidate{
+		Item:                toBusInventoryItem(db.inventoryItem),
+		WarehouseID:         db.WarehouseID,
+		ZoneID:              db.ZoneID,
+		IsPickLocation:      db.IsPickLocation,
+		IsReserveLocation:   db.IsReserveLocation,
+		CurrentUtilization:  db.CurrentUtilization,
+		PendingPickQuantity: db.PendingPickQuantity,
+		WarehouseAddress:    toBusAllocationAddress(db.allocationAddress),
+	}
+
+	if db.UnitCost.Valid {
+		cost := db.UnitCost.Float64
+		c.UnitCost = &cost
+	}
+
+	return c
+}
+
+type allocationLot struct {
+	LotID          uuid.UUID       `db:"lot_id"`
+	LotNumber      string          `db:"lot_number"`
+	LocationID     uuid.UUID       `db:"location_id"`
+	Quantity       float64         `db:"quantity"`
+	QualityStatus  string          `db:"quality_status"`
+	ExpirationDate time.Time       `db:"expiration_date"`
+	ReceivedDate   time.Time       `db:"received_date"`
+	UnitCost       sql.NullFloat64 `db:"unit_cost"`
+}
+
+func toBusAllocationLot(db allocationLot) inventoryitembus.AllocationLot {
+	lot := inventoryitembus.AllocationLot{
+		LotID:          db.LotID,
+		LotNumber:      db.LotNumber,
+		LocationID:     db.LocationID,
+		Quantity:       int(db.Quantity),
+		QualityStatus:  db.QualityStatus,
+		ExpirationDate: db.ExpirationDate.UTC(),
+		ReceivedDate:   db.ReceivedDate.UTC(),
+	}
+
+	if db.UnitCost.Valid {
+		cost := db.UnitCost.Float64
+		lot.UnitCost = &cost
+	}
+
+	return lot
+}
+
+type allocationOrder struct {
+	Priority string `db:"priority"`
+	allocationAddress
+}
+
+func toBusAllocationOrder(db allocationOrder) inventoryitembus.AllocationOrder {
+	order := inventoryitembus.AllocationOrder{
+		Priority: db.Priority,
+	}
+	if db.CityID.Valid {
+		addr := toBusAllocationAddress(db.allocationAddress)
+		order.Destination = &addr
+	}
+	return order
+}
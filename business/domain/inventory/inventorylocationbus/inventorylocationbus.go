@@ -35,6 +35,7 @@ type Storer interface {
 	Count(ctx context.Context, filter QueryFilter) (int, error)
 	QueryByID(ctx context.Context, inventoryLocationID uuid.UUID) (InventoryLocation, error)
 	QueryByIDs(ctx context.Context, inventoryLocationIDs []uuid.UUID) ([]InventoryLocation, error)
+	QuerySlottingCandidates(ctx context.Context, q SlottingQuery) (SlottingData, error)
 }
 
 // Business manages the set of APIs for brand access.
@@ -279,3 +280,29 @@ func (b *Business) QueryByID(ctx context.Context, inventoryLocationID uuid.UUID)
 
 	return invLocation, nil
 }
+
+// Slot ranks the bins received stock could be put away into, best first, with
+// the reasons behind each rank. Bins are weighed on room for the product's
+// size and weight, consolidation with stock of the same product or lot, zone
+// stage and the requested pick-face or reserve preference. When nothing has
+// room for the whole quantity the partial fits are still ranked so the caller
+// can fall back.
+func (b *Business) Slot(ctx context.Context, req SlotRequest) (Slotting, error) {
+	ctx, span := otel.AddSpan(ctx, "business.inventorylocationbus.slot")
+	defer span.End()
+
+	if err := req.Validate(); err != nil {
+		return Slotting{}, fmt.Errorf("validate: %w", err)
+	}
+
+	data, err := b.storer.QuerySlottingCandidates(ctx, SlottingQuery{
+		ProductID:   req.ProductID,
+		LotID:       req.LotID,
+		WarehouseID: req.WarehouseID,
+	})
+	if err != nil {
+		return Slotting{}, fmt.Errorf("query slotting candidates: %w", err)
+	}
+
+	return rankSlots(req, data), nil
+}
@@ -0,0 +1,326 @@
+package inventorylocationbus
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/zonebus"
+)
+
+// Bin preferences accepted by Slot.
+const (
+	BinPreferenceNone     = ""          // no preference between pick faces and reserve
+	BinPreferencePickFace = "pick_face" // fill pick faces first
+	BinPreferenceReserve  = "reserve"   // keep pick faces for replenishment, put away to reserve
+)
+
+var binPreferences = map[string]bool{
+	BinPreferenceNone:     true,
+	BinPreferencePickFace: true,
+	BinPreferenceReserve:  true,
+}
+
+// DefaultPutawayStages are the zone stages stock may be put away into when a
+// request names none. Zones without a stage are always eligible; staged zones
+// (inbound docks, processing, QA, outbound staging) hold stock in flow, not
+// at rest.
+var DefaultPutawayStages = []zonebus.Stage{zonebus.Stages.Received}
+
+// defaultSlotLimit is how many ranked candidates Slot returns by default.
+const defaultSlotLimit = 5
+
+// unlimitedRoom is the room of a bin without a capacity on file.
+const unlimitedRoom = math.MaxInt32
+
+// Slotting score weights. Scores only order candidates that fit the same
+// share of the quantity; a bin with room for everything always outranks one
+// without.
+const (
+	scoreSameLot     = 40
+	scoreSameProduct = 30
+	scorePreference  = 20
+	scoreEmptyBin    = 10
+	scoreMixedBin    = -10
+)
+
+// SlotRequest describes received stock looking for a bin.
+type SlotRequest struct {
+	ProductID     uuid.UUID
+	Quantity      int
+	LotID         *uuid.UUID
+	WarehouseID   *uuid.UUID
+	Stages        []zonebus.Stage // nil means DefaultPutawayStages
+	BinPreference string
+	MaxBinWeight  *float64 // weight an empty bin holds, in the product's weight unit
+	Limit         int      // ranked candidates to return; <= 0 means 5
+}
+
+// Validate checks the request is complete.
+func (r SlotRequest) Validate() error {
+	if r.ProductID == uuid.Nil {
+		return fmt.Errorf("product_id is required")
+	}
+	if r.Quantity <= 0 {
+		return fmt.Errorf("quantity must be positive, got %d", r.Quantity)
+	}
+	if !binPreferences[r.BinPreference] {
+		return fmt.Errorf("bin_preference must be %q, %q or empty, got %q", BinPreferencePickFace, BinPreferenceReserve, r.BinPreference)
+	}
+	if r.MaxBinWeight != nil && *r.MaxBinWeight <= 0 {
+		return fmt.Errorf("max_bin_weight must be positive")
+	}
+	return nil
+}
+
+// SlottingQuery selects the bins a product could be put away into.
+type SlottingQuery struct {
+	ProductID   uuid.UUID
+	LotID       *uuid.UUID
+	WarehouseID *uuid.UUID
+}
+
+// SlottingProduct is the product's unit size and weight from its physical
+// attributes.
+type SlottingProduct struct {
+	Length     float64
+	Width      float64
+	Height     float64
+	Weight     float64
+	WeightUnit string
+}
+
+// SlottingBin is a bin with the stock already in it and the stock on its way
+// to it on open put-away tasks.
+type SlottingBin struct {
+	Location        InventoryLocation
+	ZoneStage       *zonebus.Stage
+	ProductQuantity int     // on hand of the product
+	LotQuantity     int     // on hand of the requested lot
+	OtherProducts   int     // distinct other products on hand
+	InboundQuantity int     // units on pending and in-progress put-away tasks
+	InboundVolume   float64 // volume of those units, for products with dimensions on file
+}
+
+// SlottingData is the result of QuerySlottingCandidates. Product is nil when
+// the product has no physical attributes on file.
+type SlottingData struct {
+	Product *SlottingProduct
+	Bins    []SlottingBin
+}
+
+// SlotCandidate is a bin ranked for a put-away with the reasons it ranked
+// where it did.
+type SlotCandidate struct {
+	LocationID         uuid.UUID `json:"location_id"`
+	LocationCode       string    `json:"location_code"`
+	WarehouseID        uuid.UUID `json:"warehouse_id"`
+	ZoneID             uuid.UUID `json:"zone_id"`
+	ZoneStage          string    `json:"zone_stage,omitempty"`
+	IsPickLocation     bool      `json:"is_pick_location"`
+	CurrentUtilization float64   `json:"current_utilization"`
+	Room               int       `json:"room"` // units that fit
+	FitsAll            bool      `json:"fits_all"`
+	Score              int       `json:"score"`
+	Reasons            []string  `json:"reasons"`
+}
+
+// Slotting is the ranked result of Slot. Candidates is best first; Rejected
+// counts the bins left out, by reason.
+type Slotting struct {
+	Quantity   int             `json:"quantity"`
+	Candidates []SlotCandidate `json:"candidates"`
+	Rejected   map[string]int  `json:"rejected,omitempty"`
+}
+
+// Best returns the top candidate when it has room for the whole quantity.
+func (s Slotting) Best() (SlotCandidate, bool) {
+	if len(s.Candidates) == 0 || !s.Candidates[0].FitsAll {
+		return SlotCandidate{}, false
+	}
+	return s.Candidates[0], true
+}
+
+// Reasons a bin is left out of the ranking.
+const (
+	rejectStage   = "zone stage not used for put-away"
+	rejectBinFull = "bin full"
+	rejectInbound = "remaining room taken by open put-aways"
+	rejectNoRoom  = "no room for a single unit"
+)
+
+// rankSlots ranks bins for a put-away. A bin is eligible when its zone is
+// unstaged or in one of the request's stages and it has room for at least one
+// unit once the stock on open put-away tasks to it has landed. Eligible bins rank by whether they fit the whole quantity, then score
+// (consolidation, bin preference, mixing), then lowest utilization.
+func rankSlots(req SlotRequest, data SlottingData) Slotting {
+	stages := req.Stages
+	if stages == nil {
+		stages = DefaultPutawayStages
+	}
+	allowed := make(map[string]bool, len(stages))
+	for _, st := range stages {
+		allowed[st.String()] = true
+	}
+
+	result := Slotting{
+		Quantity:   req.Quantity,
+		Candidates: []SlotCandidate{},
+	}
+	reject := func(reason string) {
+		if result.Rejected == nil {
+			result.Rejected = make(map[string]int)
+		}
+		result.Rejected[reason]++
+	}
+
+	for _, bin := range data.Bins {
+		loc := bin.Location
+
+		stage := ""
+		if bin.ZoneStage != nil {
+			stage = bin.ZoneStage.String()
+			if !allowed[stage] {
+				reject(rejectStage)
+				continue
+			}
+		}
+
+		room, roomReason := binRoom(bin, data.Product, req.MaxBinWeight)
+		if room == 0 {
+			switch {
+			case loc.CurrentUtilization.Value >= 100:
+				reject(rejectBinFull)
+			case bin.InboundQuantity > 0:
+				reject(rejectInbound)
+			default:
+				reject(rejectNoRoom)
+			}
+			continue
+		}
+
+		c := SlotCandidate{
+			LocationID:         loc.LocationID,
+			WarehouseID:        loc.WarehouseID,
+			ZoneID:             loc.ZoneID,
+			ZoneStage:          stage,
+			IsPickLocation:     loc.IsPickLocation,
+			CurrentUtilization: loc.CurrentUtilization.Value,
+			Room:               room,
+			FitsAll:            room >= req.Quantity,
+		}
+		if room == unlimitedRoom {
+			c.Room = req.Quantity
+		}
+		if loc.LocationCode != nil {
+			c.LocationCode = *loc.LocationCode
+		}
+
+		if c.FitsAll {
+			c.Reasons = append(c.Reasons, fmt.Sprintf("room for all %d units (%s)", req.Quantity, roomReason))
+		} else {
+			c.Reasons = append(c.Reasons, fmt.Sprintf("room for only %d of %d units (%s)", room, req.Quantity, roomReason))
+		}
+
+		switch {
+		case req.LotID != nil && bin.LotQuantity > 0:
+			c.Score += scoreSameLot
+			c.Reasons = append(c.Reasons, fmt.Sprintf("consolidates with %d units of the same lot", bin.LotQuantity))
+		case bin.ProductQuantity > 0:
+			c.Score += scoreSameProduct
+			c.Reasons = append(c.Reasons, fmt.Sprintf("consolidates with %d units of the product", bin.ProductQuantity))
+		case bin.OtherProducts == 0:
+			c.Score += scoreEmptyBin
+			c.Reasons = append(c.Reasons, "empty bin")
+		}
+
+		if bin.OtherProducts > 0 {
+			c.Score += scoreMixedBin
+			c.Reasons = append(c.Reasons, fmt.Sprintf("shared with %d other products", bin.OtherProducts))
+		}
+
+		switch {
+		case req.BinPreference == BinPreferencePickFace && loc.IsPickLocation:
+			c.Score += scorePreference
+			c.Reasons = append(c.Reasons, "pick face preferred")
+		case req.BinPreference == BinPreferenceReserve && loc.IsReserveLocation:
+			c.Score += scorePreference
+			c.Reasons = append(c.Reasons, "reserve bin preferred")
+		}
+
+		result.Candidates = append(result.Candidates, c)
+	}
+
+	sort.SliceStable(result.Candidates, func(i, j int) bool {
+		a, b := result.Candidates[i], result.Candidates[j]
+		if a.FitsAll != b.FitsAll {
+			return a.FitsAll
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.CurrentUtilization != b.CurrentUtilization {
+			return a.CurrentUtilization < b.CurrentUtilization
+		}
+		if a.LocationCode != b.LocationCode {
+			return a.LocationCode < b.LocationCode
+		}
+		return a.LocationID.String() < b.LocationID.String()
+	})
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSlotLimit
+	}
+	if len(result.Candidates) > limit {
+		result.Candidates = result.Candidates[:limit]
+	}
+
+	return result
+}
+
+// binRoom works out how many units of the product fit in the free share of a
+// bin, and which limit decided it. MaxCapacity is the bin's volume in the
+// product's dimension units and CurrentUtilization the percent of it in use;
+// without dimensions on file MaxCapacity is read as a unit count. Stock on open
+// put-away tasks to the bin is not in CurrentUtilization yet, so its volume
+// (or its units, when counting in units) comes off the free share first. The
+// weight limit applies to the free share before inbound stock. A bin without
+// a capacity is not limited.
+func binRoom(bin SlottingBin, product *SlottingProduct, maxWeight *float64) (int, string) {
+	loc := bin.Location
+	if loc.MaxCapacity <= 0 {
+		return unlimitedRoom, "no capacity limit on file"
+	}
+
+	used := math.Min(math.Max(loc.CurrentUtilization.Value, 0), 100)
+	free := float64(loc.MaxCapacity) * (100 - used) / 100
+
+	room := int(math.Floor(math.Max(free-float64(bin.InboundQuantity), 0)))
+	reason := "capacity counted in units; no dimensions on file"
+	if bin.InboundQuantity > 0 {
+		reason += fmt.Sprintf("; %d units inbound", bin.InboundQuantity)
+	}
+
+	if product != nil {
+		if volume := product.Length * product.Width * product.Height; volume > 0 {
+			free = math.Max(free-bin.InboundVolume, 0)
+			room = int(math.Floor(free / volume))
+			reason = fmt.Sprintf("%.4g free of %d by volume", free, loc.MaxCapacity)
+			if bin.InboundVolume > 0 {
+				reason += fmt.Sprintf(" after %d units inbound", bin.InboundQuantity)
+			}
+		}
+
+		if maxWeight != nil && product.Weight > 0 {
+			byWeight := int(math.Floor(*maxWeight * (100 - used) / 100 / product.Weight))
+			if byWeight < room {
+				room = byWeight
+				reason = fmt.Sprintf("weight limit %.4g %s", *maxWeight, product.WeightUnit)
+			}
+		}
+	}
+
+	return room, reason
+}
@@ -0,0 +1,184 @@
+package inventorylocationbus
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus/types"
+	"github.com/timmaaaz/ichor/business/domain/inventory/zonebus"
+)
+
+func testBin(code string, capacity int, util float64, pick bool) SlottingBin {
+	return SlottingBin{
+		Location: InventoryLocation{
+			LocationID:         uuid.New(),
+			LocationCode:       &code,
+			IsPickLocation:     pick,
+			IsReserveLocation:  !pick,
+			MaxCapacity:        capacity,
+			CurrentUtilization: types.RoundedFloat{Value: util},
+		},
+	}
+}
+
+func codes(s Slotting) []string {
+	out := make([]string, len(s.Candidates))
+	for i, c := range s.Candidates {
+		out[i] = c.LocationCode
+	}
+	return out
+}
+
+func TestRankSlots_FitFirst(t *testing.T) {
+	data := SlottingData{Bins: []SlottingBin{
+		testBin("A", 20, 50, false),  // 10 units free
+		testBin("B", 100, 90, false), // 10 units free
+		testBin("C", 100, 0, false),  // 100 units free
+	}}
+	data.Bins[0].ProductQuantity = 5
+
+	got := rankSlots(SlotRequest{ProductID: uuid.New(), Quantity: 50}, data)
+
+	if want := []string{"C", "A", "B"}; !slices.Equal(codes(got), want) {
+		t.Fatalf("order = %v, want %v", codes(got), want)
+	}
+	if best, ok := got.Best(); !ok || best.LocationCode != "C" {
+		t.Fatalf("Best() = %v, %v; want C", best.LocationCode, ok)
+	}
+	if got.Candidates[1].Room != 10 || got.Candidates[1].FitsAll {
+		t.Fatalf("partial fit = room %d fits %v, want room 10 fits false", got.Candidates[1].Room, got.Candidates[1].FitsAll)
+	}
+}
+
+func TestRankSlots_Consolidation(t *testing.T) {
+	lot := uuid.New()
+	data := SlottingData{Bins: []SlottingBin{
+		testBin("EMPTY", 100, 0, false),
+		testBin("PRODUCT", 100, 10, false),
+		testBin("LOT", 100, 20, false),
+		testBin("MIXED", 100, 0, false),
+	}}
+	data.Bins[1].ProductQuantity = 10
+	data.Bins[2].ProductQuantity = 20
+	data.Bins[2].LotQuantity = 20
+	data.Bins[3].OtherProducts = 2
+
+	got := rankSlots(SlotRequest{ProductID: uuid.New(), Quantity: 5, LotID: &lot}, data)
+
+	if want := []string{"LOT", "PRODUCT", "EMPTY", "MIXED"}; !slices.Equal(codes(got), want) {
+		t.Fatalf("order = %v, want %v", codes(got), want)
+	}
+}
+
+func TestRankSlots_Preference(t *testing.T) {
+	data := SlottingData{Bins: []SlottingBin{
+		testBin("PICK", 100, 0, true),
+		testBin("RESERVE", 100, 0, false),
+	}}
+
+	got := rankSlots(SlotRequest{ProductID: uuid.New(), Quantity: 5, BinPreference: BinPreferencePickFace}, data)
+	if codes(got)[0] != "PICK" {
+		t.Fatalf("pick_face: order = %v, want PICK first", codes(got))
+	}
+
+	got = rankSlots(SlotRequest{ProductID: uuid.New(), Quantity: 5, BinPreference: BinPreferenceReserve}, data)
+	if codes(got)[0] != "RESERVE" {
+		t.Fatalf("reserve: order = %v, want RESERVE first", codes(got))
+	}
+}
+
+func TestRankSlots_Rejections(t *testing.T) {
+	inbound := zonebus.Stages.Inbound
+	received := zonebus.Stages.Received
+
+	data := SlottingData{Bins: []SlottingBin{
+		testBin("INBOUND", 100, 0, false),
+		testBin("RECEIVED", 100, 0, false),
+		testBin("FULL", 100, 100, false),
+		testBin("TIGHT", 10, 95, false),
+		testBin("CLAIMED", 100, 50, false),
+	}}
+	data.Bins[0].ZoneStage = &inbound
+	data.Bins[1].ZoneStage = &received
+	data.Bins[4].InboundQuantity = 50
+
+	got := rankSlots(SlotRequest{ProductID: uuid.New(), Quantity: 5}, data)
+
+	if want := []string{"RECEIVED"}; !slices.Equal(codes(got), want) {
+		t.Fatalf("order = %v, want %v", codes(got), want)
+	}
+	if got.Rejected[rejectStage] != 1 || got.Rejected[rejectBinFull] != 1 || got.Rejected[rejectInbound] != 1 || got.Rejected[rejectNoRoom] != 1 {
+		t.Fatalf("rejected = %v", got.Rejected)
+	}
+
+	got = rankSlots(SlotRequest{ProductID: uuid.New(), Quantity: 5, Stages: []zonebus.Stage{inbound}}, data)
+	if want := []string{"INBOUND"}; !slices.Equal(codes(got), want) {
+		t.Fatalf("inbound stage: order = %v, want %v", codes(got), want)
+	}
+}
+
+func TestRankSlots_NothingFits(t *testing.T) {
+	data := SlottingData{Bins: []SlottingBin{
+		testBin("A", 10, 0, false),
+	}}
+
+	got := rankSlots(SlotRequest{ProductID: uuid.New(), Quantity: 50}, data)
+
+	if len(got.Candidates) != 1 {
+		t.Fatalf("candidates = %d, want 1 partial fit", len(got.Candidates))
+	}
+	if _, ok := got.Best(); ok {
+		t.Fatal("Best() reported a bin with room for all units")
+	}
+}
+
+func TestRankSlots_Limit(t *testing.T) {
+	var data SlottingData
+	for _, code := range []string{"A", "B", "C", "D", "E", "F", "G"} {
+		data.Bins = append(data.Bins, testBin(code, 0, 0, false))
+	}
+
+	got := rankSlots(SlotRequest{ProductID: uuid.New(), Quantity: 1}, data)
+	if len(got.Candidates) != defaultSlotLimit {
+		t.Fatalf("candidates = %d, want %d", len(got.Candidates), defaultSlotLimit)
+	}
+
+	got = rankSlots(SlotRequest{ProductID: uuid.New(), Quantity: 1, Limit: 2}, data)
+	if want := []string{"A", "B"}; !slices.Equal(codes(got), want) {
+		t.Fatalf("order = %v, want %v", codes(got), want)
+	}
+}
+
+func TestBinRoom(t *testing.T) {
+	weight := 100.0
+	product := &SlottingProduct{Length: 2, Width: 2, Height: 2, Weight: 5, WeightUnit: "kg"}
+
+	tests := []struct {
+		name       string
+		loc        InventoryLocation
+		inbound    int
+		inboundVol float64
+		product    *SlottingProduct
+		maxWeight  *float64
+		want       int
+	}{
+		{name: "no capacity", loc: InventoryLocation{}, want: unlimitedRoom},
+		{name: "units", loc: InventoryLocation{MaxCapacity: 50, CurrentUtilization: types.RoundedFloat{Value: 20}}, want: 40},
+		{name: "volume", loc: InventoryLocation{MaxCapacity: 800}, product: product, want: 100},
+		{name: "volume half used", loc: InventoryLocation{MaxCapacity: 800, CurrentUtilization: types.RoundedFloat{Value: 50}}, product: product, want: 50},
+		{name: "weight limited", loc: InventoryLocation{MaxCapacity: 800}, product: product, maxWeight: &weight, want: 20},
+		{name: "units inbound", loc: InventoryLocation{MaxCapacity: 50, CurrentUtilization: types.RoundedFloat{Value: 20}}, inbound: 15, want: 25},
+		{name: "volume inbound", loc: InventoryLocation{MaxCapacity: 800}, inbound: 30, inboundVol: 240, product: product, want: 70},
+		{name: "inbound over free", loc: InventoryLocation{MaxCapacity: 800, CurrentUtilization: types.RoundedFloat{Value: 50}}, inbound: 60, inboundVol: 480, product: product, want: 0},
+		{name: "over utilized", loc: InventoryLocation{MaxCapacity: 800, CurrentUtilization: types.RoundedFloat{Value: 120}}, product: product, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := binRoom(SlottingBin{Location: tt.loc, InboundQuantity: tt.inbound, InboundVolume: tt.inboundVol}, tt.product, tt.maxWeight); got != tt.want {
+				t.Fatalf("binRoom() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -15,6 +15,9 @@ import (
 	"github.com/timmaaaz/ichor/foundation/logger"
 )
 
+// scopeColumns places an inventory location in a data scope.
+var scopeColumns = sqldb.ScopeColumns{Warehouse: "warehouse_id", Zone: "zone_id"}
+
 // Store manages the set of APIs for zone database access.
 type Store struct {
 	log *logger.Logger
@@ -46,6 +49,10 @@ func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (inventorylocationbus.Store
 }
 
 func (s *Store) Create(ctx context.Context, il inventorylocationbus.InventoryLocation) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, scopeColumns, map[string]uuid.UUID{"warehouse_id": il.WarehouseID, "zone_id": il.ZoneID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
 	INSERT INTO inventory.inventory_locations (
 		id, zone_id, warehouse_id, aisle, rack, shelf, bin, location_code, is_pick_location,
@@ -71,6 +78,10 @@ func (s *Store) Create(ctx context.Context, il inventorylocationbus.InventoryLoc
 }
 
 func (s *Store) Update(ctx context.Context, il inventorylocationbus.InventoryLocation) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, scopeColumns, map[string]uuid.UUID{"warehouse_id": il.WarehouseID, "zone_id": il.ZoneID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
     UPDATE
         inventory.inventory_locations
@@ -137,6 +148,7 @@ func (s *Store) Query(ctx context.Context, filter inventorylocationbus.QueryFilt
 
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
 
 	orderByClause, err := orderByClause(orderBy)
 	if err != nil {
@@ -167,6 +179,7 @@ func (s *Store) Count(ctx context.Context, filter inventorylocationbus.QueryFilt
 
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
 	var count struct {
 		Count int `db:"count"`
 	}
@@ -179,10 +192,8 @@ func (s *Store) Count(ctx context.Context, filter inventorylocationbus.QueryFilt
 }
 
 func (s *Store) QueryByID(ctx context.Context, id uuid.UUID) (inventorylocationbus.InventoryLocation, error) {
-	data := struct {
-		ID string `db:"id"`
-	}{
-		ID: id.String(),
+	data := map[string]any{
+		"id": id.String(),
 	}
 
 	const q = `
@@ -195,9 +206,12 @@ func (s *Store) QueryByID(ctx context.Context, id uuid.UUID) (inventorylocationb
         id = :id
     `
 
+	buf := bytes.NewBufferString(q)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
+
 	var dbIL inventoryLocation
 
-	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbIL); err != nil {
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &dbIL); err != nil {
 		if errors.Is(err, sqldb.ErrDBNotFound) {
 			return inventorylocationbus.InventoryLocation{}, fmt.Errorf("namedexeccontext: %w", inventorylocationbus.ErrNotFound)
 		}
@@ -243,3 +257,113 @@ func (s *Store) QueryByIDs(ctx context.Context, ids []uuid.UUID) ([]inventoryloc
 
 	return toBusInvLocations(dbILS)
 }
+
+// QuerySlottingCandidates returns the non-transit locations of the warehouse
+// (every warehouse when none is given) with their zone stage, the stock
+// already in them and the stock on open put-away tasks to them, plus the
+// product's latest physical attributes. Bins already
+// holding the product or lot come first so a capped read keeps them.
+func (s *Store) QuerySlottingCandidates(ctx context.Context, q inventorylocationbus.SlottingQuery) (inventorylocationbus.SlottingData, error) {
+	const binLimit = 1000
+
+	data := map[string]any{
+		"product_id": q.ProductID.String(),
+		"lot_id":     nil,
+		"limit":      binLimit,
+	}
+	if q.LotID != nil {
+		data["lot_id"] = q.LotID.String()
+	}
+
+	query := `
+    SELECT
+        il.id, il.zone_id, il.warehouse_id, il.aisle, il.rack, il.shelf, il.bin, il.location_code,
+        il.is_pick_location, il.is_reserve_location, il.max_capacity, il.current_utilization,
+        il.created_date, il.updated_date,
+        z.stage AS zone_stage,
+        COALESCE((
+            SELECT SUM(ii.quantity)
+            FROM inventory.inventory_items ii
+            WHERE ii.location_id = il.id AND ii.product_id = :product_id
+        ), 0) AS product_quantity,
+        COALESCE((
+            SELECT SUM(ll.quantity)::INT
+            FROM inventory.lot_locations ll
+            WHERE ll.location_id = il.id AND ll.lot_id = CAST(:lot_id AS UUID)
+        ), 0) AS lot_quantity,
+        (
+            SELECT COUNT(DISTINCT ii.product_id)
+            FROM inventory.inventory_items ii
+            WHERE ii.location_id = il.id AND ii.product_id <> :product_id AND ii.quantity > 0
+        ) AS other_products,
+        COALESCE((
+            SELECT SUM(pt.quantity)
+            FROM inventory.put_away_tasks pt
+            WHERE pt.location_id = il.id AND pt.status IN ('pending', 'in_progress')
+        ), 0) AS inbound_quantity,
+        COALESCE((
+            SELECT SUM(pt.quantity * pa.length * pa.width * pa.height)::FLOAT8
+            FROM inventory.put_away_tasks pt
+            JOIN LATERAL (
+                SELECT length, width, height
+                FROM products.physical_attributes
+                WHERE product_id = pt.product_id
+                ORDER BY updated_date DESC
+                LIMIT 1
+            ) pa ON TRUE
+            WHERE pt.location_id = il.id AND pt.status IN ('pending', 'in_progress')
+        ), 0) AS inbound_volume
+    FROM
+        inventory.inventory_locations il
+    JOIN inventory.zones z ON z.id = il.zone_id
+    WHERE
+        NOT il.is_transit`
+
+	if q.WarehouseID != nil {
+		query += " AND il.warehouse_id = :warehouse_id"
+		data["warehouse_id"] = q.WarehouseID.String()
+	}
+
+	query += `
+    ORDER BY lot_quantity DESC, product_quantity DESC, il.current_utilization ASC, il.id ASC
+    LIMIT :limit`
+
+	var dbBins []slottingBin
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, query, data, &dbBins); err != nil {
+		return inventorylocationbus.SlottingData{}, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	result := inventorylocationbus.SlottingData{
+		Bins: make([]inventorylocationbus.SlottingBin, len(dbBins)),
+	}
+	for i, db := range dbBins {
+		bin, err := toBusSlottingBin(db)
+		if err != nil {
+			return inventorylocationbus.SlottingData{}, fmt.Errorf("tobusslottingbin: %w", err)
+		}
+		result.Bins[i] = bin
+	}
+
+	const productQ = `
+    SELECT
+        length, width, height, weight, weight_unit
+    FROM
+        products.physical_attributes
+    WHERE
+        product_id = :product_id
+    ORDER BY updated_date DESC
+    LIMIT 1`
+
+	var product slottingProduct
+	err := sqldb.NamedQueryStruct(ctx, s.log, s.db, productQ, map[string]any{"product_id": q.ProductID.String()}, &product)
+	switch {
+	case err == nil:
+		sp := inventorylocationbus.SlottingProduct(product)
+		result.Product = &sp
+	case errors.Is(err, sqldb.ErrDBNotFound):
+	default:
+		return inventorylocationbus.SlottingData{}, fmt.Errorf("namedquerystruct: %w", err)
+	}
+
+	return result, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus/types"
+	"github.com/timmaaaz/ichor/business/domain/inventory/zonebus"
 )
 
 type inventoryLocation struct {
@@ -97,3 +98,50 @@ func toBusInvLocations(db []inventoryLocation) ([]inventorylocationbus.Inventory
 
 	return busInvLocations, nil
 }
+
+// slottingBin is an inventory location joined to its zone stage, the stock
+// already in it and the stock on open put-away tasks to it.
+type slottingBin struct {
+	inventoryLocation
+	ZoneStage       sql.NullString `db:"zone_stage"`
+	ProductQuantity int            `db:"product_quantity"`
+	LotQuantity     int            `db:"lot_quantity"`
+	OtherProducts   int            `db:"other_products"`
+	InboundQuantity int            `db:"inbound_quantity"`
+	InboundVolume   float64        `db:"inbound_volume"`
+}
+
+// slottingProduct is the product's latest physical attributes.
+type slottingProduct struct {
+	Length     float64 `db:"length"`
+	Width      float64 `db:"width"`
+	Height     float64 `db:"height"`
+	Weight     float64 `db:"weight"`
+	WeightUnit string  `db:"weight_unit"`
+}
+
+func toBusSlottingBin(db slottingBin) (inventorylocationbus.SlottingBin, error) {
+	loc, err := toBusInvLocation(db.inventoryLocation)
+	if err != nil {
+		return inventorylocationbus.SlottingBin{}, err
+	}
+
+	var stage *zonebus.Stage
+	if db.ZoneStage.Valid && db.ZoneStage.String != "" {
+		st, err := zonebus.ParseStage(db.ZoneStage.String)
+		if err != nil {
+			return inventorylocationbus.SlottingBin{}, fmt.Errorf("parse zone stage: %w", err)
+		}
+		stage = &st
+	}
+
+	return inventorylocationbus.SlottingBin{
+		Location:        loc,
+		ZoneStage:       stage,
+		ProductQuantity: db.ProductQuantity,
+		LotQuantity:     db.LotQuantity,
+		OtherProducts:   db.OtherProducts,
+		InboundQuantity: db.InboundQuantity,
+		InboundVolume:   db.InboundVolume,
+	}, nil
+}
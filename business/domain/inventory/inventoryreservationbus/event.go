@@ -0,0 +1,103 @@
+package inventoryreservationbus
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/delegate"
+)
+
+// DomainName represents the name of this domain for delegate events.
+const DomainName = "inventoryreservation"
+
+// EntityName is the workflow entity name used for event matching.
+// This should match the entity name in workflow.entities table.
+const EntityName = "inventory_reservations"
+
+// Delegate action constants.
+const (
+	ActionCreated = "created"
+	ActionUpdated = "updated"
+)
+
+// =============================================================================
+// Created Event
+// =============================================================================
+
+// ActionCreatedParms represents the parameters for the created action.
+type ActionCreatedParms struct {
+	EntityID uuid.UUID   `json:"entityID"`
+	UserID   uuid.UUID   `json:"userID"`
+	Entity   Reservation `json:"entity"`
+}
+
+// Marshal returns the event parameters encoded as JSON.
+func (p *ActionCreatedParms) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// ActionCreatedData constructs delegate data for reservation creation events.
+func ActionCreatedData(r Reservation) delegate.Data {
+	params := ActionCreatedParms{
+		EntityID: r.ID,
+		UserID:   userID(r),
+		Entity:   r,
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    DomainName,
+		Action:    ActionCreated,
+		RawParams: rawParams,
+	}
+}
+
+// =============================================================================
+// Updated Event
+// =============================================================================
+
+// ActionUpdatedParms represents the parameters for the updated action. A
+// status change to expired is how rules react to a lapsed reservation.
+type ActionUpdatedParms struct {
+	EntityID     uuid.UUID   `json:"entityID"`
+	UserID       uuid.UUID   `json:"userID"`
+	Entity       Reservation `json:"entity"`
+	BeforeEntity Reservation `json:"beforeEntity,omitempty"`
+}
+
+// Marshal returns the event parameters encoded as JSON.
+func (p *ActionUpdatedParms) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// ActionUpdatedData constructs delegate data for reservation update events.
+func ActionUpdatedData(before, after Reservation) delegate.Data {
+	params := ActionUpdatedParms{
+		EntityID:     after.ID,
+		UserID:       userID(after),
+		Entity:       after,
+		BeforeEntity: before,
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    DomainName,
+		Action:    ActionUpdated,
+		RawParams: rawParams,
+	}
+}
+
+func userID(r Reservation) uuid.UUID {
+	if r.CreatedBy != nil {
+		return *r.CreatedBy
+	}
+	return uuid.Nil
+}
@@ -0,0 +1,254 @@
+// Package inventoryreservationbus provides business access to inventory
+// reservations: the time-limited holds behind inventory_items.reserved_quantity.
+package inventoryreservationbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/delegate"
+	"github.com/timmaaaz/ichor/business/sdk/outbox"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+	"github.com/timmaaaz/ichor/foundation/otel"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound            = errors.New("inventory reservation not found")
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	NewWithTx(tx sqldb.CommitRollbacker) (Storer, error)
+	Create(ctx context.Context, r Reservation) error
+	Update(ctx context.Context, r Reservation) error
+	QueryByID(ctx context.Context, id uuid.UUID) (Reservation, error)
+	QueryActiveByItem(ctx context.Context, inventoryItemID uuid.UUID) ([]Reservation, error)
+	QueryExpired(ctx context.Context, now time.Time, limit int) ([]Reservation, error)
+}
+
+// Business manages the set of APIs for inventory reservation access.
+type Business struct {
+	log      *logger.Logger
+	storer   Storer
+	delegate *delegate.Delegate
+	outbox   *outbox.Writer
+}
+
+// NewBusiness constructs an inventory reservation business API for use.
+func NewBusiness(log *logger.Logger, delegate *delegate.Delegate, storer Storer) *Business {
+	return &Business{
+		log:      log,
+		delegate: delegate,
+		storer:   storer,
+	}
+}
+
+// WithOutbox returns a copy of the Business wired to the cascade outbox Writer.
+// Inert until the Writer is injected at the F2 cutover (nil Writer -> Emit no-ops).
+func (b *Business) WithOutbox(w *outbox.Writer) *Business {
+	nb := *b
+	nb.outbox = w
+	return &nb
+}
+
+// NewWithTx constructs a new Business value replacing the Storer
+// value with a Storer value that is currently inside a transaction.
+func (b *Business) NewWithTx(tx sqldb.CommitRollbacker) (*Business, error) {
+	storer, err := b.storer.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	nb := *b
+	nb.storer = storer
+	return &nb, nil
+}
+
+// Create records a new active reservation. It does not touch the inventory
+// item; the caller reserves the quantity in the same transaction.
+func (b *Business) Create(ctx context.Context, nr NewReservation) (Reservation, error) {
+	ctx, span := otel.AddSpan(ctx, "business.inventoryreservationbus.create")
+	defer span.End()
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Reservation, error) {
+			now := time.Now()
+
+			r := Reservation{
+				ID:              uuid.New(),
+				InventoryItemID: nr.InventoryItemID,
+				ProductID:       nr.ProductID,
+				LocationID:      nr.LocationID,
+				Quantity:        nr.Quantity,
+				Status:          StatusActive,
+				Source:          nr.Source,
+				ReferenceID:     nr.ReferenceID,
+				ReferenceType:   nr.ReferenceType,
+				IdempotencyKey:  nr.IdempotencyKey,
+				ExpiresAt:       nr.ExpiresAt,
+				CreatedBy:       nr.CreatedBy,
+				CreatedDate:     now,
+				UpdatedDate:     now,
+			}
+
+			if sid, ok := sqldb.GetScenarioFilter(ctx); ok {
+				r.ScenarioID = &sid
+			}
+
+			if err := b.storer.Create(ctx, r); err != nil {
+				return Reservation{}, fmt.Errorf("create: %w", err)
+			}
+
+			evtData := ActionCreatedData(r)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return Reservation{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionCreatedData(r)); err != nil {
+				b.log.Error(ctx, "inventoryreservationbus: delegate call failed", "action", ActionCreated, "err", err)
+			}
+
+			return r, nil
+		})
+}
+
+// Expire marks an active reservation expired. The caller returns the quantity
+// to the inventory item in the same transaction.
+func (b *Business) Expire(ctx context.Context, r Reservation, now time.Time) (Reservation, error) {
+	ctx, span := otel.AddSpan(ctx, "business.inventoryreservationbus.expire")
+	defer span.End()
+
+	return b.close(ctx, r, StatusExpired, now)
+}
+
+// Consume closes active reservations on an inventory item, earliest expiry
+// first, until quantity is covered: status is StatusCommitted when a hold is
+// converted to an allocation and StatusReleased when it is given back. A hold
+// only partly consumed stays active with its quantity reduced. Quantity beyond
+// the recorded holds (stock reserved before reservations were recorded) is
+// ignored. Returns the reservations it changed.
+func (b *Business) Consume(ctx context.Context, inventoryItemID uuid.UUID, quantity int, status string) ([]Reservation, error) {
+	ctx, span := otel.AddSpan(ctx, "business.inventoryreservationbus.consume")
+	defer span.End()
+
+	active, err := b.storer.QueryActiveByItem(ctx, inventoryItemID)
+	if err != nil {
+		return nil, fmt.Errorf("query active: %w", err)
+	}
+
+	now := time.Now()
+	var changed []Reservation
+
+	for _, r := range active {
+		if quantity <= 0 {
+			break
+		}
+
+		if r.Quantity > quantity {
+			updated, err := b.reduce(ctx, r, quantity, now)
+			if err != nil {
+				return nil, err
+			}
+			changed = append(changed, updated)
+			break
+		}
+
+		updated, err := b.close(ctx, r, status, now)
+		if err != nil {
+			return nil, err
+		}
+		changed = append(changed, updated)
+		quantity -= r.Quantity
+	}
+
+	return changed, nil
+}
+
+// QueryByID finds the reservation by the specified ID.
+func (b *Business) QueryByID(ctx context.Context, id uuid.UUID) (Reservation, error) {
+	ctx, span := otel.AddSpan(ctx, "business.inventoryreservationbus.querybyid")
+	defer span.End()
+
+	r, err := b.storer.QueryByID(ctx, id)
+	if err != nil {
+		return Reservation{}, fmt.Errorf("query: reservationID[%s]: %w", id, err)
+	}
+
+	return r, nil
+}
+
+// QueryActiveByItem returns the active reservations on an inventory item,
+// earliest expiry first, locked for update.
+func (b *Business) QueryActiveByItem(ctx context.Context, inventoryItemID uuid.UUID) ([]Reservation, error) {
+	ctx, span := otel.AddSpan(ctx, "business.inventoryreservationbus.queryactivebyitem")
+	defer span.End()
+
+	rs, err := b.storer.QueryActiveByItem(ctx, inventoryItemID)
+	if err != nil {
+		return nil, fmt.Errorf("query active by item: %w", err)
+	}
+
+	return rs, nil
+}
+
+// QueryExpired returns up to limit active reservations whose expires_at is at
+// or before now, locked for update. Rows locked by a concurrent sweep are
+// skipped, so several replicas can sweep at once. Must run inside a
+// transaction.
+func (b *Business) QueryExpired(ctx context.Context, now time.Time, limit int) ([]Reservation, error) {
+	ctx, span := otel.AddSpan(ctx, "business.inventoryreservationbus.queryexpired")
+	defer span.End()
+
+	rs, err := b.storer.QueryExpired(ctx, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query expired: %w", err)
+	}
+
+	return rs, nil
+}
+
+// =============================================================================
+
+func (b *Business) close(ctx context.Context, r Reservation, status string, now time.Time) (Reservation, error) {
+	before := r
+
+	r.Status = status
+	r.ReleasedDate = &now
+	r.UpdatedDate = now
+
+	return b.update(ctx, before, r)
+}
+
+func (b *Business) reduce(ctx context.Context, r Reservation, by int, now time.Time) (Reservation, error) {
+	before := r
+
+	r.Quantity -= by
+	r.UpdatedDate = now
+
+	return b.update(ctx, before, r)
+}
+
+func (b *Business) update(ctx context.Context, before, r Reservation) (Reservation, error) {
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Reservation, error) {
+			if err := b.storer.Update(ctx, r); err != nil {
+				return Reservation{}, fmt.Errorf("update: %w", err)
+			}
+
+			evtData := ActionUpdatedData(before, r)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return Reservation{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionUpdatedData(before, r)); err != nil {
+				b.log.Error(ctx, "inventoryreservationbus: delegate call failed", "action", ActionUpdated, "err", err)
+			}
+
+			return r, nil
+		})
+}
@@ -0,0 +1,52 @@
+package inventoryreservationbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Set of reservation statuses. Only active reservations hold stock.
+const (
+	StatusActive    = "active"
+	StatusReleased  = "released"
+	StatusCommitted = "committed"
+	StatusExpired   = "expired"
+)
+
+// JSON tags are required for workflow event serialization.
+
+// Reservation is a time-limited hold on part of an inventory item's
+// reserved_quantity, recorded by the action that placed it.
+type Reservation struct {
+	ID              uuid.UUID  `json:"id"`
+	InventoryItemID uuid.UUID  `json:"inventory_item_id"`
+	ProductID       uuid.UUID  `json:"product_id"`
+	LocationID      uuid.UUID  `json:"location_id"`
+	Quantity        int        `json:"quantity"`
+	Status          string     `json:"status"`
+	Source          string     `json:"source"` // action type that placed the hold
+	ReferenceID     string     `json:"reference_id,omitempty"`
+	ReferenceType   string     `json:"reference_type,omitempty"`
+	IdempotencyKey  string     `json:"idempotency_key,omitempty"`
+	ExpiresAt       time.Time  `json:"expires_at"`
+	ReleasedDate    *time.Time `json:"released_date,omitempty"`
+	CreatedBy       *uuid.UUID `json:"created_by,omitempty"`
+	CreatedDate     time.Time  `json:"created_date"`
+	UpdatedDate     time.Time  `json:"updated_date"`
+	ScenarioID      *uuid.UUID `json:"scenario_id,omitempty"`
+}
+
+// NewReservation is what we require to record a reservation.
+type NewReservation struct {
+	InventoryItemID uuid.UUID  `json:"inventory_item_id"`
+	ProductID       uuid.UUID  `json:"product_id"`
+	LocationID      uuid.UUID  `json:"location_id"`
+	Quantity        int        `json:"quantity"`
+	Source          string     `json:"source"`
+	ReferenceID     string     `json:"reference_id,omitempty"`
+	ReferenceType   string     `json:"reference_type,omitempty"`
+	IdempotencyKey  string     `json:"idempotency_key,omitempty"`
+	ExpiresAt       time.Time  `json:"expires_at"`
+	CreatedBy       *uuid.UUID `json:"created_by,omitempty"`
+}
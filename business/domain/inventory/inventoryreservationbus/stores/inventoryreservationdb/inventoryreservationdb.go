@@ -0,0 +1,177 @@
+// Package inventoryreservationdb contains inventory reservation related CRUD
+// functionality.
+package inventoryreservationdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryreservationbus"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+const reservationColumns = `
+		id, inventory_item_id, product_id, location_id, quantity, status, source,
+		reference_id, reference_type, idempotency_key, expires_at, released_date,
+		created_by, created_date, updated_date, scenario_id`
+
+// Store manages the set of APIs for inventory reservation database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the api for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// NewWithTx constructs a new Store value replacing the sqlx DB
+// value with a sqlx DB value that is currently inside a transaction.
+func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (inventoryreservationbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := Store{
+		log: s.log,
+		db:  ec,
+	}
+
+	return &store, nil
+}
+
+// Create inserts a new reservation into the database.
+func (s *Store) Create(ctx context.Context, r inventoryreservationbus.Reservation) error {
+	const q = `
+	INSERT INTO inventory.inventory_reservations (` + reservationColumns + `
+	) VALUES (
+		:id, :inventory_item_id, :product_id, :location_id, :quantity, :status, :source,
+		:reference_id, :reference_type, :idempotency_key, :expires_at, :released_date,
+		:created_by, :created_date, :updated_date, :scenario_id
+	)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBReservation(r)); err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return fmt.Errorf("namedexeccontext: %w", inventoryreservationbus.ErrForeignKeyViolation)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Update replaces the mutable fields of a reservation.
+func (s *Store) Update(ctx context.Context, r inventoryreservationbus.Reservation) error {
+	const q = `
+	UPDATE
+		inventory.inventory_reservations
+	SET
+		quantity = :quantity,
+		status = :status,
+		released_date = :released_date,
+		updated_date = :updated_date
+	WHERE
+		id = :id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBReservation(r)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// QueryByID gets the specified reservation from the database.
+func (s *Store) QueryByID(ctx context.Context, id uuid.UUID) (inventoryreservationbus.Reservation, error) {
+	data := map[string]any{
+		"id": id.String(),
+	}
+
+	const q = `
+	SELECT` + reservationColumns + `
+	FROM
+		inventory.inventory_reservations
+	WHERE
+		id = :id`
+
+	buf := bytes.NewBufferString(q)
+	sqldb.ApplyScenarioFilter(ctx, buf, data)
+
+	var dbR reservation
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &dbR); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return inventoryreservationbus.Reservation{}, fmt.Errorf("namedquerystruct: %w", inventoryreservationbus.ErrNotFound)
+		}
+		return inventoryreservationbus.Reservation{}, fmt.Errorf("namedquerystruct: %w", err)
+	}
+
+	return toBusReservation(dbR), nil
+}
+
+// QueryActiveByItem returns the active reservations on an inventory item,
+// earliest expiry first, locked for update.
+func (s *Store) QueryActiveByItem(ctx context.Context, inventoryItemID uuid.UUID) ([]inventoryreservationbus.Reservation, error) {
+	data := struct {
+		InventoryItemID string `db:"inventory_item_id"`
+	}{
+		InventoryItemID: inventoryItemID.String(),
+	}
+
+	const q = `
+	SELECT` + reservationColumns + `
+	FROM
+		inventory.inventory_reservations
+	WHERE
+		inventory_item_id = :inventory_item_id
+		AND status = 'active'
+	ORDER BY expires_at ASC, created_date ASC
+	FOR UPDATE`
+
+	var dbRs []reservation
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbRs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusReservations(dbRs), nil
+}
+
+// QueryExpired returns up to limit active reservations that expired at or
+// before now, oldest first. FOR UPDATE SKIP LOCKED lets concurrent sweeps
+// (one per replica) partition the work instead of blocking on each other.
+func (s *Store) QueryExpired(ctx context.Context, now time.Time, limit int) ([]inventoryreservationbus.Reservation, error) {
+	data := struct {
+		Now   time.Time `db:"now"`
+		Limit int       `db:"limit"`
+	}{
+		Now:   now.UTC(),
+		Limit: limit,
+	}
+
+	const q = `
+	SELECT` + reservationColumns + `
+	FROM
+		inventory.inventory_reservations
+	WHERE
+		status = 'active'
+		AND expires_at <= :now
+	ORDER BY expires_at ASC
+	LIMIT :limit
+	FOR UPDATE SKIP LOCKED`
+
+	var dbRs []reservation
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbRs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusReservations(dbRs), nil
+}
@@ -0,0 +1,96 @@
+package inventoryreservationdb
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryreservationbus"
+)
+
+type reservation struct {
+	ID              uuid.UUID      `db:"id"`
+	InventoryItemID uuid.UUID      `db:"inventory_item_id"`
+	ProductID       uuid.UUID      `db:"product_id"`
+	LocationID      uuid.UUID      `db:"location_id"`
+	Quantity        int            `db:"quantity"`
+	Status          string         `db:"status"`
+	Source          string         `db:"source"`
+	ReferenceID     sql.NullString `db:"reference_id"`
+	ReferenceType   sql.NullString `db:"reference_type"`
+	IdempotencyKey  sql.NullString `db:"idempotency_key"`
+	ExpiresAt       time.Time      `db:"expires_at"`
+	ReleasedDate    sql.NullTime   `db:"released_date"`
+	CreatedBy       uuid.NullUUID  `db:"created_by"`
+	CreatedDate     time.Time      `db:"created_date"`
+	UpdatedDate     time.Time      `db:"updated_date"`
+	ScenarioID      *uuid.UUID     `db:"scenario_id"`
+}
+
+func toDBReservation(bus inventoryreservationbus.Reservation) reservation {
+	db := reservation{
+		ID:              bus.ID,
+		InventoryItemID: bus.InventoryItemID,
+		ProductID:       bus.ProductID,
+		LocationID:      bus.LocationID,
+		Quantity:        bus.Quantity,
+		Status:          bus.Status,
+		Source:          bus.Source,
+		ReferenceID:     sql.NullString{String: bus.ReferenceID, Valid: bus.ReferenceID != ""},
+		ReferenceType:   sql.NullString{String: bus.ReferenceType, Valid: bus.ReferenceType != ""},
+		IdempotencyKey:  sql.NullString{String: bus.IdempotencyKey, Valid: bus.IdempotencyKey != ""},
+		ExpiresAt:       bus.ExpiresAt.UTC(),
+		CreatedDate:     bus.CreatedDate.UTC(),
+		UpdatedDate:     bus.UpdatedDate.UTC(),
+		ScenarioID:      bus.ScenarioID,
+	}
+
+	if bus.ReleasedDate != nil {
+		db.ReleasedDate = sql.NullTime{Time: bus.ReleasedDate.UTC(), Valid: true}
+	}
+
+	if bus.CreatedBy != nil {
+		db.CreatedBy = uuid.NullUUID{UUID: *bus.CreatedBy, Valid: true}
+	}
+
+	return db
+}
+
+func toBusReservation(db reservation) inventoryreservationbus.Reservation {
+	bus := inventoryreservationbus.Reservation{
+		ID:              db.ID,
+		InventoryItemID: db.InventoryItemID,
+		ProductID:       db.ProductID,
+		LocationID:      db.LocationID,
+		Quantity:        db.Quantity,
+		Status:          db.Status,
+		Source:          db.Source,
+		ReferenceID:     db.ReferenceID.String,
+		ReferenceType:   db.ReferenceType.String,
+		IdempotencyKey:  db.IdempotencyKey.String,
+		ExpiresAt:       db.ExpiresAt.In(time.Local),
+		CreatedDate:     db.CreatedDate.In(time.Local),
+		UpdatedDate:     db.UpdatedDate.In(time.Local),
+		ScenarioID:      db.ScenarioID,
+	}
+
+	if db.ReleasedDate.Valid {
+		t := db.ReleasedDate.Time.In(time.Local)
+		bus.ReleasedDate = &t
+	}
+
+	if db.CreatedBy.Valid {
+		id := db.CreatedBy.UUID
+		bus.CreatedBy = &id
+	}
+
+	return bus
+}
+
+func toBusReservations(dbs []reservation) []inventoryreservationbus.Reservation {
+	bus := make([]inventoryreservationbus.Reservation, len(dbs))
+	for i, db := range dbs {
+		bus[i] = toBusReservation(db)
+	}
+	return bus
+}
@@ -130,6 +130,7 @@ func (s *Store) Query(ctx context.Context, filter inventorytransactionbus.QueryF
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	orderByClause, err := orderByClause(orderBy)
 	if err != nil {
@@ -162,6 +163,7 @@ func (s *Store) Count(ctx context.Context, filter inventorytransactionbus.QueryF
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	var count struct {
 		Count int `db:"count"`
@@ -191,6 +193,7 @@ func (s *Store) QueryByID(ctx context.Context, transactionID uuid.UUID) (invento
 
 	buf := bytes.NewBufferString(q)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	var dbInvTran inventoryTransaction
 
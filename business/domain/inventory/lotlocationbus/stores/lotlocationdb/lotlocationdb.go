@@ -46,6 +46,10 @@ func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (lotlocationbus.Storer, err
 }
 
 func (s *Store) Create(ctx context.Context, ll lotlocationbus.LotLocation) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, sqldb.ScopeByLocation, map[string]uuid.UUID{"location_id": ll.LocationID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
 	INSERT INTO inventory.lot_locations (
 		id, lot_id, location_id, quantity, created_date, updated_date, scenario_id
@@ -67,6 +71,10 @@ func (s *Store) Create(ctx context.Context, ll lotlocationbus.LotLocation) error
 }
 
 func (s *Store) Update(ctx context.Context, ll lotlocationbus.LotLocation) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, sqldb.ScopeByLocation, map[string]uuid.UUID{"location_id": ll.LocationID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
 	UPDATE
 		inventory.lot_locations
@@ -124,6 +132,7 @@ func (s *Store) Query(ctx context.Context, filter lotlocationbus.QueryFilter, or
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	orderByClause, err := orderByClause(orderBy)
 	if err != nil {
@@ -155,6 +164,7 @@ func (s *Store) Count(ctx context.Context, filter lotlocationbus.QueryFilter) (i
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	var count struct {
 		Count int `db:"count"`
@@ -182,6 +192,7 @@ func (s *Store) QueryByID(ctx context.Context, id uuid.UUID) (lotlocationbus.Lot
 
 	buf := bytes.NewBufferString(q)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	var dbLL lotLocation
 
@@ -15,6 +15,7 @@ type QueryFilter struct {
 	LocationID           *uuid.UUID
 	Status               *Status
 	AssignedTo           *uuid.UUID
+	BatchID              *uuid.UUID
 	CreatedBy            *uuid.UUID
 	CreatedDate          *time.Time
 	UpdatedDate          *time.Time
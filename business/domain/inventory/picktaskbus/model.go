@@ -11,6 +11,8 @@ import (
 
 // PickTask represents a single directed work instruction for a floor worker:
 // pick QuantityToPick units of ProductID from LocationID to fulfill a sales order line item.
+// A task planned into a pick batch carries the batch, its stop number on the
+// batch's route and the tote its order is picked into.
 type PickTask struct {
 	ID                   uuid.UUID  `json:"id"`
 	TaskNumber           *string    `json:"task_number,omitempty"`
@@ -28,6 +30,9 @@ type PickTask struct {
 	CompletedBy          uuid.UUID  `json:"completed_by"`
 	CompletedAt          time.Time  `json:"completed_at"`
 	ShortPickReason      string     `json:"short_pick_reason,omitempty"`
+	BatchID              *uuid.UUID `json:"batch_id,omitempty"`
+	BatchSequence        *int       `json:"batch_sequence,omitempty"`
+	ToteNumber           *int       `json:"tote_number,omitempty"`
 	CreatedBy            uuid.UUID  `json:"created_by"`
 	CreatedDate          time.Time  `json:"created_date"`
 	UpdatedDate          time.Time  `json:"updated_date"`
@@ -63,4 +68,7 @@ type UpdatePickTask struct {
 	CompletedBy     *uuid.UUID `json:"completed_by,omitempty"`
 	CompletedAt     *time.Time `json:"completed_at,omitempty"`
 	ShortPickReason *string    `json:"short_pick_reason,omitempty"`
+	BatchID         *uuid.UUID `json:"batch_id,omitempty"`
+	BatchSequence   *int       `json:"batch_sequence,omitempty"`
+	ToteNumber      *int       `json:"tote_number,omitempty"`
 }
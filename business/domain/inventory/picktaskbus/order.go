@@ -16,4 +16,5 @@ const (
 	OrderByCreatedBy      = "created_by"
 	OrderByCreatedDate    = "created_date"
 	OrderByUpdatedDate    = "updated_date"
+	OrderByBatchSequence  = "batch_sequence"
 )
@@ -164,6 +164,15 @@ func (b *Business) Update(ctx context.Context, task PickTask, upt UpdatePickTask
 			if upt.ShortPickReason != nil {
 				task.ShortPickReason = *upt.ShortPickReason
 			}
+			if upt.BatchID != nil {
+				task.BatchID = upt.BatchID
+			}
+			if upt.BatchSequence != nil {
+				task.BatchSequence = upt.BatchSequence
+			}
+			if upt.ToteNumber != nil {
+				task.ToteNumber = upt.ToteNumber
+			}
 
 			task.UpdatedDate = time.Now()
 
@@ -45,6 +45,11 @@ func applyFilter(filter picktaskbus.QueryFilter, data map[string]any, buf *bytes
 		wc = append(wc, "assigned_to = :assigned_to")
 	}
 
+	if filter.BatchID != nil {
+		data["batch_id"] = *filter.BatchID
+		wc = append(wc, "batch_id = :batch_id")
+	}
+
 	if filter.CreatedBy != nil {
 		data["created_by"] = *filter.CreatedBy
 		wc = append(wc, "created_by = :created_by")
@@ -28,6 +28,9 @@ type pickTask struct {
 	CompletedBy          sql.NullString `db:"completed_by"`
 	CompletedAt          sql.NullTime   `db:"completed_at"`
 	ShortPickReason      sql.NullString `db:"short_pick_reason"`
+	BatchID              uuid.NullUUID  `db:"batch_id"`
+	BatchSequence        sql.NullInt32  `db:"batch_sequence"`
+	ToteNumber           sql.NullInt32  `db:"tote_number"`
 	CreatedBy            uuid.UUID      `db:"created_by"`
 	CreatedDate          time.Time      `db:"created_date"`
 	UpdatedDate          time.Time      `db:"updated_date"`
@@ -68,6 +71,12 @@ func toBusPickTask(db pickTask) (picktaskbus.PickTask, error) {
 		shortPickReason = db.ShortPickReason.String
 	}
 
+	var batchID *uuid.UUID
+	if db.BatchID.Valid {
+		id := db.BatchID.UUID
+		batchID = &id
+	}
+
 	status, err := picktaskbus.ParseStatus(db.Status)
 	if err != nil {
 		return picktaskbus.PickTask{}, fmt.Errorf("parse status %q: %w", db.Status, err)
@@ -90,6 +99,9 @@ func toBusPickTask(db pickTask) (picktaskbus.PickTask, error) {
 		CompletedBy:          nulltypes.FromNullableUUID(db.CompletedBy),
 		CompletedAt:          completedAt,
 		ShortPickReason:      shortPickReason,
+		BatchID:              batchID,
+		BatchSequence:        nullInt(db.BatchSequence),
+		ToteNumber:           nullInt(db.ToteNumber),
 		CreatedBy:            db.CreatedBy,
 		CreatedDate:          db.CreatedDate,
 		UpdatedDate:          db.UpdatedDate,
@@ -140,6 +152,11 @@ func toDBPickTask(bus picktaskbus.PickTask) pickTask {
 		shortPickReason = sql.NullString{String: bus.ShortPickReason, Valid: true}
 	}
 
+	var batchID uuid.NullUUID
+	if bus.BatchID != nil {
+		batchID = uuid.NullUUID{UUID: *bus.BatchID, Valid: true}
+	}
+
 	return pickTask{
 		ID:                   bus.ID,
 		TaskNumber:           taskNumber,
@@ -157,9 +174,27 @@ func toDBPickTask(bus picktaskbus.PickTask) pickTask {
 		CompletedBy:          nulltypes.ToNullableUUID(bus.CompletedBy),
 		CompletedAt:          completedAt,
 		ShortPickReason:      shortPickReason,
+		BatchID:              batchID,
+		BatchSequence:        toNullInt(bus.BatchSequence),
+		ToteNumber:           toNullInt(bus.ToteNumber),
 		CreatedBy:            bus.CreatedBy,
 		CreatedDate:          bus.CreatedDate,
 		UpdatedDate:          bus.UpdatedDate,
 		ScenarioID:           bus.ScenarioID,
 	}
 }
+
+func nullInt(n sql.NullInt32) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int32)
+	return &v
+}
+
+func toNullInt(v *int) sql.NullInt32 {
+	if v == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(*v), Valid: true}
+}
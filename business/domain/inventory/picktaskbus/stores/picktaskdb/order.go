@@ -18,6 +18,7 @@ var orderByFields = map[string]string{
 	picktaskbus.OrderByCreatedBy:      "created_by",
 	picktaskbus.OrderByCreatedDate:    "created_date",
 	picktaskbus.OrderByUpdatedDate:    "updated_date",
+	picktaskbus.OrderByBatchSequence:  "batch_sequence",
 }
 
 func orderByClause(orderBy order.By) (string, error) {
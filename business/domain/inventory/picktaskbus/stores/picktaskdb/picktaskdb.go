@@ -45,17 +45,23 @@ func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (picktaskbus.Storer, error)
 
 // Create inserts a new pick task into the database.
 func (s *Store) Create(ctx context.Context, task picktaskbus.PickTask) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, sqldb.ScopeByLocation, map[string]uuid.UUID{"location_id": task.LocationID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
 	INSERT INTO inventory.pick_tasks
 		(id, task_number, sales_order_id, sales_order_line_item_id, product_id, lot_id, serial_id,
 		 location_id, quantity_to_pick, quantity_picked, status,
 		 assigned_to, assigned_at, completed_by, completed_at,
-		 short_pick_reason, created_by, created_date, updated_date, scenario_id)
+		 short_pick_reason, batch_id, batch_sequence, tote_number,
+		 created_by, created_date, updated_date, scenario_id)
 	VALUES
 		(:id, :task_number, :sales_order_id, :sales_order_line_item_id, :product_id, :lot_id, :serial_id,
 		 :location_id, :quantity_to_pick, :quantity_picked, :status,
 		 :assigned_to, :assigned_at, :completed_by, :completed_at,
-		 :short_pick_reason, :created_by, :created_date, :updated_date, :scenario_id)
+		 :short_pick_reason, :batch_id, :batch_sequence, :tote_number,
+		 :created_by, :created_date, :updated_date, :scenario_id)
 	`
 
 	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBPickTask(task)); err != nil {
@@ -73,6 +79,10 @@ func (s *Store) Create(ctx context.Context, task picktaskbus.PickTask) error {
 
 // Update modifies an existing pick task in the database.
 func (s *Store) Update(ctx context.Context, task picktaskbus.PickTask) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, sqldb.ScopeByLocation, map[string]uuid.UUID{"location_id": task.LocationID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
 	UPDATE inventory.pick_tasks
 	SET
@@ -91,6 +101,9 @@ func (s *Store) Update(ctx context.Context, task picktaskbus.PickTask) error {
 		completed_by              = :completed_by,
 		completed_at              = :completed_at,
 		short_pick_reason         = :short_pick_reason,
+		batch_id                  = :batch_id,
+		batch_sequence            = :batch_sequence,
+		tote_number               = :tote_number,
 		updated_date              = :updated_date
 	WHERE
 		id = :id
@@ -135,7 +148,8 @@ func (s *Store) Query(ctx context.Context, filter picktaskbus.QueryFilter, order
 		id, task_number, sales_order_id, sales_order_line_item_id, product_id, lot_id, serial_id,
 		location_id, quantity_to_pick, quantity_picked, status,
 		assigned_to, assigned_at, completed_by, completed_at,
-		short_pick_reason, created_by, created_date, updated_date, scenario_id
+		short_pick_reason, batch_id, batch_sequence, tote_number,
+		created_by, created_date, updated_date, scenario_id
 	FROM
 		inventory.pick_tasks
 	`
@@ -143,6 +157,7 @@ func (s *Store) Query(ctx context.Context, filter picktaskbus.QueryFilter, order
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	orderByClause, err := orderByClause(orderBy)
 	if err != nil {
@@ -179,6 +194,7 @@ func (s *Store) Count(ctx context.Context, filter picktaskbus.QueryFilter) (int,
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	var count struct {
 		Count int `db:"count"`
@@ -201,7 +217,8 @@ func (s *Store) QueryByID(ctx context.Context, taskID uuid.UUID) (picktaskbus.Pi
 		id, task_number, sales_order_id, sales_order_line_item_id, product_id, lot_id, serial_id,
 		location_id, quantity_to_pick, quantity_picked, status,
 		assigned_to, assigned_at, completed_by, completed_at,
-		short_pick_reason, created_by, created_date, updated_date, scenario_id
+		short_pick_reason, batch_id, batch_sequence, tote_number,
+		created_by, created_date, updated_date, scenario_id
 	FROM
 		inventory.pick_tasks
 	WHERE
@@ -210,6 +227,7 @@ func (s *Store) QueryByID(ctx context.Context, taskID uuid.UUID) (picktaskbus.Pi
 
 	buf := bytes.NewBufferString(q)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	var dbTask pickTask
 	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &dbTask); err != nil {
@@ -0,0 +1,19 @@
+package pickwavebus
+
+import "github.com/google/uuid"
+
+// QueryFilter holds optional filters for querying waves.
+type QueryFilter struct {
+	ID          *uuid.UUID
+	Status      *string
+	WarehouseID *uuid.UUID
+}
+
+// BatchFilter holds optional filters for querying batches.
+type BatchFilter struct {
+	ID         *uuid.UUID
+	WaveID     *uuid.UUID
+	ZoneID     *uuid.UUID
+	Status     *string
+	AssignedTo *uuid.UUID
+}
@@ -0,0 +1,67 @@
+package pickwavebus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JSON tags are required for workflow event serialization. The workflow system
+// marshals business models to JSON for RawData in TriggerEvents.
+
+// Wave statuses.
+const (
+	WaveStatusOpen      = "open"
+	WaveStatusCompleted = "completed"
+)
+
+// Batch statuses. A batch is open until a worker claims it, in progress while
+// it is being picked and completed once every stop is picked, short picked or
+// cancelled.
+const (
+	BatchStatusOpen       = "open"
+	BatchStatusInProgress = "in_progress"
+	BatchStatusCompleted  = "completed"
+)
+
+// Wave is a release of pick work against one carrier cutoff. CarrierCutoff is
+// nil for a wave of orders whose carrier has no cutoff. ToteCount is the
+// most orders any of its batches holds.
+type Wave struct {
+	ID            uuid.UUID  `json:"id"`
+	WaveNumber    string     `json:"wave_number"`
+	Status        string     `json:"status"`
+	WarehouseID   *uuid.UUID `json:"warehouse_id,omitempty"`
+	CarrierCutoff *time.Time `json:"carrier_cutoff,omitempty"`
+	ToteCount     int        `json:"tote_count"`
+	CreatedBy     uuid.UUID  `json:"created_by"`
+	CreatedDate   time.Time  `json:"created_date"`
+	UpdatedDate   time.Time  `json:"updated_date"`
+}
+
+// Batch is one cart's worth of a wave's orders in a single zone. Its stops are
+// the pick tasks carrying its ID, in batch sequence order.
+type Batch struct {
+	ID          uuid.UUID  `json:"id"`
+	WaveID      uuid.UUID  `json:"wave_id"`
+	BatchNumber string     `json:"batch_number"`
+	ZoneID      uuid.UUID  `json:"zone_id"`
+	Status      string     `json:"status"`
+	OrderCount  int        `json:"order_count"`
+	StopCount   int        `json:"stop_count"`
+	AssignedTo  *uuid.UUID `json:"assigned_to,omitempty"`
+	AssignedAt  *time.Time `json:"assigned_at,omitempty"`
+	CreatedDate time.Time  `json:"created_date"`
+	UpdatedDate time.Time  `json:"updated_date"`
+}
+
+// PlanRequest describes the pick work to release. WarehouseID narrows the
+// candidates to one warehouse. ToteCount is the orders per batch; zero means
+// DefaultToteCount. CarrierCutoffs maps a carrier to its next cutoff; orders
+// with a carrier missing from it are planned into a wave without a cutoff.
+type PlanRequest struct {
+	WarehouseID    *uuid.UUID
+	ToteCount      int
+	CarrierCutoffs map[string]time.Time
+	CreatedBy      uuid.UUID
+}
@@ -0,0 +1,14 @@
+package pickwavebus
+
+import "github.com/timmaaaz/ichor/business/sdk/order"
+
+// DefaultOrderBy represents the default ordering for wave queries.
+var DefaultOrderBy = order.NewBy(OrderByCreatedDate, order.DESC)
+
+const (
+	OrderByID            = "id"
+	OrderByWaveNumber    = "wave_number"
+	OrderByStatus        = "status"
+	OrderByCarrierCutoff = "carrier_cutoff"
+	OrderByCreatedDate   = "created_date"
+)
@@ -0,0 +1,459 @@
+// Package pickwavebus provides business access to pick waves: releases of
+// pick work that group pick tasks across orders into zone batches sized to a
+// cart, each sequenced along a walking route.
+package pickwavebus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/picktaskbus"
+	"github.com/timmaaaz/ichor/business/sdk/delegate"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/outbox"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+	"github.com/timmaaaz/ichor/foundation/otel"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound            = errors.New("pick wave not found")
+	ErrBatchNotFound       = errors.New("pick batch not found")
+	ErrUniqueEntry         = errors.New("pick wave entry is not unique")
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+	ErrNothingToPlan       = errors.New("no pick tasks are waiting for a wave")
+	ErrBatchClaimed        = errors.New("pick batch is already claimed")
+	ErrBatchNotClaimed     = errors.New("pick batch is not in progress")
+	ErrStopsOpen           = errors.New("pick batch has stops still to pick")
+	ErrWaveStarted         = errors.New("pick wave has batches already claimed")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	NewWithTx(tx sqldb.CommitRollbacker) (Storer, error)
+	Create(ctx context.Context, wave Wave) error
+	Update(ctx context.Context, wave Wave) error
+	Delete(ctx context.Context, wave Wave) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]Wave, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, waveID uuid.UUID) (Wave, error)
+	CreateBatch(ctx context.Context, batch Batch) error
+	// UpdateBatchWithStatusGuard updates the batch only while its current
+	// status equals expectedStatus. It returns the number of rows affected
+	// (0 means a concurrent transition won).
+	UpdateBatchWithStatusGuard(ctx context.Context, batch Batch, expectedStatus string) (int64, error)
+	QueryBatches(ctx context.Context, filter BatchFilter, page page.Page) ([]Batch, error)
+	CountBatches(ctx context.Context, filter BatchFilter) (int, error)
+	QueryBatchByID(ctx context.Context, batchID uuid.UUID) (Batch, error)
+	QueryCandidates(ctx context.Context, warehouseID *uuid.UUID) ([]Candidate, error)
+	LockPlanning(ctx context.Context) error
+}
+
+// Business manages the set of APIs for pick wave access.
+type Business struct {
+	log         *logger.Logger
+	storer      Storer
+	delegate    *delegate.Delegate
+	outbox      *outbox.Writer
+	pickTaskBus *picktaskbus.Business
+}
+
+// NewBusiness constructs a pick wave business API for use. The pick task bus
+// receives each planned task's batch, stop and tote.
+func NewBusiness(log *logger.Logger, delegate *delegate.Delegate, storer Storer, pickTaskBus *picktaskbus.Business) *Business {
+	return &Business{
+		log:         log,
+		delegate:    delegate,
+		storer:      storer,
+		pickTaskBus: pickTaskBus,
+	}
+}
+
+// WithOutbox returns a copy of the Business wired to the cascade outbox Writer.
+// Inert until the Writer is injected at the F2 cutover (nil Writer -> Emit no-ops).
+func (b *Business) WithOutbox(w *outbox.Writer) *Business {
+	nb := *b
+	nb.outbox = w
+	return &nb
+}
+
+// NewWithTx constructs a new Business value replacing the Storer
+// value with a Storer value that is currently inside a transaction.
+func (b *Business) NewWithTx(tx sqldb.CommitRollbacker) (*Business, error) {
+	storer, err := b.storer.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	pickTaskBus, err := b.pickTaskBus.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	nb := *b
+	nb.storer = storer
+	nb.pickTaskBus = pickTaskBus
+	return &nb, nil
+}
+
+// Plan returns the waves Create would release now, without writing anything.
+func (b *Business) Plan(ctx context.Context, req PlanRequest) ([]PlannedWave, error) {
+	ctx, span := otel.AddSpan(ctx, "business.pickwavebus.plan")
+	defer span.End()
+
+	candidates, err := b.storer.QueryCandidates(ctx, req.WarehouseID)
+	if err != nil {
+		return nil, fmt.Errorf("plan: candidates: %w", err)
+	}
+
+	return PlanWaves(candidates, req.ToteCount, req.CarrierCutoffs), nil
+}
+
+// Create plans every pending, unassigned, unbatched pick task into waves and
+// batches and stamps each task with its batch, stop and tote. Planning is
+// serialized so two releases cannot batch the same task. Returns
+// ErrNothingToPlan when no task is waiting.
+func (b *Business) Create(ctx context.Context, req PlanRequest, now time.Time) ([]Wave, error) {
+	ctx, span := otel.AddSpan(ctx, "business.pickwavebus.create")
+	defer span.End()
+
+	toteCount := req.ToteCount
+	if toteCount <= 0 {
+		toteCount = DefaultToteCount
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) ([]Wave, error) {
+			if err := b.storer.LockPlanning(ctx); err != nil {
+				return nil, fmt.Errorf("create: lock: %w", err)
+			}
+
+			candidates, err := b.storer.QueryCandidates(ctx, req.WarehouseID)
+			if err != nil {
+				return nil, fmt.Errorf("create: candidates: %w", err)
+			}
+			if len(candidates) == 0 {
+				return nil, fmt.Errorf("create: %w", ErrNothingToPlan)
+			}
+
+			planned := PlanWaves(candidates, toteCount, req.CarrierCutoffs)
+
+			waves := make([]Wave, 0, len(planned))
+			for i, pw := range planned {
+				wave := Wave{
+					ID:            uuid.New(),
+					Status:        WaveStatusOpen,
+					WarehouseID:   req.WarehouseID,
+					CarrierCutoff: pw.CarrierCutoff,
+					ToteCount:     toteCount,
+					CreatedBy:     req.CreatedBy,
+					CreatedDate:   now,
+					UpdatedDate:   now,
+				}
+				wave.WaveNumber = fmt.Sprintf("WAVE-%s-%s-%d", now.Format("060102-1504"), wave.ID.String()[:4], i+1)
+
+				if err := b.storer.Create(ctx, wave); err != nil {
+					return nil, fmt.Errorf("create: wave: %w", err)
+				}
+
+				for j, pb := range pw.Batches {
+					batch := Batch{
+						ID:          uuid.New(),
+						WaveID:      wave.ID,
+						BatchNumber: fmt.Sprintf("%s-B%d", wave.WaveNumber, j+1),
+						ZoneID:      pb.ZoneID,
+						Status:      BatchStatusOpen,
+						OrderCount:  len(pb.Orders),
+						StopCount:   len(pb.Stops),
+						CreatedDate: now,
+						UpdatedDate: now,
+					}
+					if err := b.storer.CreateBatch(ctx, batch); err != nil {
+						return nil, fmt.Errorf("create: batch: %w", err)
+					}
+
+					for _, stop := range pb.Stops {
+						task, err := b.pickTaskBus.QueryByID(ctx, stop.TaskID)
+						if err != nil {
+							return nil, fmt.Errorf("create: pick task[%s]: %w", stop.TaskID, err)
+						}
+						if _, err := b.pickTaskBus.Update(ctx, task, picktaskbus.UpdatePickTask{
+							BatchID:       &batch.ID,
+							BatchSequence: &stop.Sequence,
+							ToteNumber:    &stop.Tote,
+						}); err != nil {
+							return nil, fmt.Errorf("create: stamp pick task[%s]: %w", stop.TaskID, err)
+						}
+					}
+				}
+
+				evtData := ActionCreatedData(wave)
+				if err := b.outbox.Emit(ctx, evtData); err != nil {
+					return nil, fmt.Errorf("emit cascade event: %w", err)
+				}
+				if err := b.delegate.Call(ctx, ActionCreatedData(wave)); err != nil {
+					b.log.Error(ctx, "pickwavebus: delegate call failed", "action", ActionCreated, "err", err)
+				}
+
+				waves = append(waves, wave)
+			}
+
+			return waves, nil
+		})
+}
+
+// Delete removes a wave and its batches. The wave's pick tasks drop out of
+// their batches and wait for the next wave. A wave with a claimed batch
+// cannot be deleted.
+func (b *Business) Delete(ctx context.Context, wave Wave) error {
+	ctx, span := otel.AddSpan(ctx, "business.pickwavebus.delete")
+	defer span.End()
+
+	return outbox.WriteAtomicVoid(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) error {
+			open := BatchStatusOpen
+			total, err := b.storer.CountBatches(ctx, BatchFilter{WaveID: &wave.ID})
+			if err != nil {
+				return fmt.Errorf("delete: count batches: %w", err)
+			}
+			unclaimed, err := b.storer.CountBatches(ctx, BatchFilter{WaveID: &wave.ID, Status: &open})
+			if err != nil {
+				return fmt.Errorf("delete: count open batches: %w", err)
+			}
+			if unclaimed != total {
+				return fmt.Errorf("delete: %w", ErrWaveStarted)
+			}
+
+			if err := b.storer.Delete(ctx, wave); err != nil {
+				return fmt.Errorf("delete: %w", err)
+			}
+
+			evtData := ActionDeletedData(wave)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionDeletedData(wave)); err != nil {
+				b.log.Error(ctx, "pickwavebus: delegate call failed", "action", ActionDeleted, "err", err)
+			}
+
+			return nil
+		})
+}
+
+// Query retrieves a list of waves from the system.
+func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]Wave, error) {
+	ctx, span := otel.AddSpan(ctx, "business.pickwavebus.query")
+	defer span.End()
+
+	waves, err := b.storer.Query(ctx, filter, orderBy, page)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return waves, nil
+}
+
+// Count returns the total number of waves matching the filter.
+func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.pickwavebus.count")
+	defer span.End()
+
+	return b.storer.Count(ctx, filter)
+}
+
+// QueryByID retrieves a single wave by its ID.
+func (b *Business) QueryByID(ctx context.Context, waveID uuid.UUID) (Wave, error) {
+	ctx, span := otel.AddSpan(ctx, "business.pickwavebus.querybyid")
+	defer span.End()
+
+	wave, err := b.storer.QueryByID(ctx, waveID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return Wave{}, err
+		}
+		return Wave{}, fmt.Errorf("queryByID: waveID[%s]: %w", waveID, err)
+	}
+
+	return wave, nil
+}
+
+// QueryBatches retrieves batches, ordered by batch number.
+func (b *Business) QueryBatches(ctx context.Context, filter BatchFilter, page page.Page) ([]Batch, error) {
+	ctx, span := otel.AddSpan(ctx, "business.pickwavebus.querybatches")
+	defer span.End()
+
+	batches, err := b.storer.QueryBatches(ctx, filter, page)
+	if err != nil {
+		return nil, fmt.Errorf("querybatches: %w", err)
+	}
+
+	return batches, nil
+}
+
+// CountBatches returns the total number of batches matching the filter.
+func (b *Business) CountBatches(ctx context.Context, filter BatchFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.pickwavebus.countbatches")
+	defer span.End()
+
+	return b.storer.CountBatches(ctx, filter)
+}
+
+// QueryBatchByID retrieves a single batch by its ID.
+func (b *Business) QueryBatchByID(ctx context.Context, batchID uuid.UUID) (Batch, error) {
+	ctx, span := otel.AddSpan(ctx, "business.pickwavebus.querybatchbyid")
+	defer span.End()
+
+	batch, err := b.storer.QueryBatchByID(ctx, batchID)
+	if err != nil {
+		if errors.Is(err, ErrBatchNotFound) {
+			return Batch{}, err
+		}
+		return Batch{}, fmt.Errorf("querybatchbyid: batchID[%s]: %w", batchID, err)
+	}
+
+	return batch, nil
+}
+
+// QueryStops retrieves a batch's pick tasks in route order.
+func (b *Business) QueryStops(ctx context.Context, batch Batch) ([]picktaskbus.PickTask, error) {
+	ctx, span := otel.AddSpan(ctx, "business.pickwavebus.querystops")
+	defer span.End()
+
+	// A batch holds at most tote count orders in one zone; the ceiling keeps
+	// a route from silently losing stops.
+	tasks, err := b.pickTaskBus.Query(ctx, picktaskbus.QueryFilter{BatchID: &batch.ID},
+		order.NewBy(picktaskbus.OrderByBatchSequence, order.ASC), page.MustParse("1", "1000"))
+	if err != nil {
+		return nil, fmt.Errorf("querystops: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// Claim hands a whole batch to a worker: the batch moves to in progress and
+// every stop still pending is assigned to the worker, so directed work serves
+// the stops in route order. Returns ErrBatchClaimed when the batch is not
+// open.
+func (b *Business) Claim(ctx context.Context, batch Batch, userID uuid.UUID, now time.Time) (Batch, error) {
+	ctx, span := otel.AddSpan(ctx, "business.pickwavebus.claim")
+	defer span.End()
+
+	if batch.Status != BatchStatusOpen {
+		return Batch{}, fmt.Errorf("claim: %w", ErrBatchClaimed)
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Batch, error) {
+			batch.Status = BatchStatusInProgress
+			batch.AssignedTo = &userID
+			batch.AssignedAt = &now
+			batch.UpdatedDate = now
+
+			// Guard on the still-open DB state so two workers claiming the
+			// same batch cannot both take its stops.
+			rows, err := b.storer.UpdateBatchWithStatusGuard(ctx, batch, BatchStatusOpen)
+			if err != nil {
+				return Batch{}, fmt.Errorf("claim: %w", err)
+			}
+			if rows == 0 {
+				return Batch{}, fmt.Errorf("claim: %w", ErrBatchClaimed)
+			}
+
+			stops, err := b.QueryStops(ctx, batch)
+			if err != nil {
+				return Batch{}, fmt.Errorf("claim: %w", err)
+			}
+
+			for _, task := range stops {
+				if task.Status != picktaskbus.Statuses.Pending {
+					continue
+				}
+				if _, err := b.pickTaskBus.Update(ctx, task, picktaskbus.UpdatePickTask{
+					AssignedTo: &userID,
+					AssignedAt: &now,
+				}); err != nil {
+					return Batch{}, fmt.Errorf("claim: assign pick task[%s]: %w", task.ID, err)
+				}
+			}
+
+			return batch, nil
+		})
+}
+
+// Complete closes a batch once every stop is picked, short picked or
+// cancelled, and completes its wave when that was the wave's last open batch.
+// Returns ErrStopsOpen while any stop is still to pick.
+func (b *Business) Complete(ctx context.Context, batch Batch, now time.Time) (Batch, error) {
+	ctx, span := otel.AddSpan(ctx, "business.pickwavebus.complete")
+	defer span.End()
+
+	if batch.Status != BatchStatusInProgress {
+		return Batch{}, fmt.Errorf("complete: %w", ErrBatchNotClaimed)
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Batch, error) {
+			stops, err := b.QueryStops(ctx, batch)
+			if err != nil {
+				return Batch{}, fmt.Errorf("complete: %w", err)
+			}
+			for _, task := range stops {
+				switch task.Status {
+				case picktaskbus.Statuses.Pending, picktaskbus.Statuses.InProgress:
+					return Batch{}, fmt.Errorf("complete: %w", ErrStopsOpen)
+				}
+			}
+
+			batch.Status = BatchStatusCompleted
+			batch.UpdatedDate = now
+
+			rows, err := b.storer.UpdateBatchWithStatusGuard(ctx, batch, BatchStatusInProgress)
+			if err != nil {
+				return Batch{}, fmt.Errorf("complete: %w", err)
+			}
+			if rows == 0 {
+				return Batch{}, fmt.Errorf("complete: %w", ErrBatchNotClaimed)
+			}
+
+			total, err := b.storer.CountBatches(ctx, BatchFilter{WaveID: &batch.WaveID})
+			if err != nil {
+				return Batch{}, fmt.Errorf("complete: count batches: %w", err)
+			}
+			completed := BatchStatusCompleted
+			done, err := b.storer.CountBatches(ctx, BatchFilter{WaveID: &batch.WaveID, Status: &completed})
+			if err != nil {
+				return Batch{}, fmt.Errorf("complete: count completed batches: %w", err)
+			}
+			if done < total {
+				return batch, nil
+			}
+
+			wave, err := b.storer.QueryByID(ctx, batch.WaveID)
+			if err != nil {
+				return Batch{}, fmt.Errorf("complete: wave: %w", err)
+			}
+
+			before := wave
+			wave.Status = WaveStatusCompleted
+			wave.UpdatedDate = now
+			if err := b.storer.Update(ctx, wave); err != nil {
+				return Batch{}, fmt.Errorf("complete: wave: %w", err)
+			}
+
+			evtData := ActionUpdatedData(before, wave)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return Batch{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionUpdatedData(before, wave)); err != nil {
+				b.log.Error(ctx, "pickwavebus: delegate call failed", "action", ActionUpdated, "err", err)
+			}
+
+			return batch, nil
+		})
+}
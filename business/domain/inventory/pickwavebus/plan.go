@@ -0,0 +1,330 @@
+package pickwavebus
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultToteCount is how many orders a batch holds when a request names no
+// tote count: one tote per order on a standard pick cart.
+const DefaultToteCount = 8
+
+// Candidate is a pending, unassigned, unbatched pick task with what the
+// planner needs to place it: its order's priority, due date and carrier, and
+// where in the warehouse its location sits.
+type Candidate struct {
+	TaskID       uuid.UUID `json:"task_id"`
+	SalesOrderID uuid.UUID `json:"sales_order_id"`
+	OrderNumber  string    `json:"order_number"`
+	Priority     string    `json:"priority"`
+	DueDate      time.Time `json:"due_date"`
+	Carrier      string    `json:"carrier"`
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationCode string    `json:"location_code"`
+	ZoneID       uuid.UUID `json:"zone_id"`
+	Aisle        string    `json:"aisle"`
+	Rack         string    `json:"rack"`
+	Shelf        string    `json:"shelf"`
+	Bin          string    `json:"bin"`
+}
+
+// Stop is one pick on a batch's route. Sequence is the stop's position on
+// the walk, starting at 1; Tote is the tote the order is picked into.
+type Stop struct {
+	Sequence     int       `json:"sequence"`
+	Tote         int       `json:"tote"`
+	TaskID       uuid.UUID `json:"task_id"`
+	SalesOrderID uuid.UUID `json:"sales_order_id"`
+	OrderNumber  string    `json:"order_number"`
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationCode string    `json:"location_code"`
+}
+
+// PlannedBatch is one cart's worth of orders in a single zone, with its
+// stops in walk order. Orders is in tote order.
+type PlannedBatch struct {
+	ZoneID uuid.UUID   `json:"zone_id"`
+	Orders []uuid.UUID `json:"orders"`
+	Stops  []Stop      `json:"stops"`
+}
+
+// PlannedWave groups the batches released against one carrier cutoff.
+// CarrierCutoff is nil for orders whose carrier has no cutoff.
+type PlannedWave struct {
+	CarrierCutoff *time.Time     `json:"carrier_cutoff,omitempty"`
+	Carriers      []string       `json:"carriers"`
+	Batches       []PlannedBatch `json:"batches"`
+}
+
+// PlanWaves groups candidates into waves by carrier cutoff, earliest first,
+// then into batches by zone. Within a zone, orders are taken by priority, then
+// due date, then order number, toteCount at a time, and each batch's stops are
+// ordered along a serpentine walk. An order's tasks in different zones land in
+// a batch per zone.
+func PlanWaves(candidates []Candidate, toteCount int, cutoffs map[string]time.Time) []PlannedWave {
+	if toteCount <= 0 {
+		toteCount = DefaultToteCount
+	}
+
+	type waveKey struct {
+		set bool
+		at  time.Time
+	}
+	keyFor := func(c Candidate) waveKey {
+		if at, ok := cutoffs[c.Carrier]; ok && c.Carrier != "" {
+			return waveKey{set: true, at: at}
+		}
+		return waveKey{}
+	}
+
+	byWave := make(map[waveKey][]Candidate)
+	var keys []waveKey
+	for _, c := range candidates {
+		k := keyFor(c)
+		if _, ok := byWave[k]; !ok {
+			keys = append(keys, k)
+		}
+		byWave[k] = append(byWave[k], c)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].set != keys[j].set {
+			return keys[i].set
+		}
+		return keys[i].at.Before(keys[j].at)
+	})
+
+	waves := make([]PlannedWave, 0, len(keys))
+	for _, k := range keys {
+		wave := PlannedWave{Carriers: carriersOf(byWave[k])}
+		if k.set {
+			at := k.at
+			wave.CarrierCutoff = &at
+		}
+		wave.Batches = planBatches(byWave[k], toteCount)
+		waves = append(waves, wave)
+	}
+
+	return waves
+}
+
+// planBatches splits one wave's candidates into batches per zone.
+func planBatches(candidates []Candidate, toteCount int) []PlannedBatch {
+	byZone := make(map[uuid.UUID][]Candidate)
+	var zones []uuid.UUID
+	for _, c := range candidates {
+		if _, ok := byZone[c.ZoneID]; !ok {
+			zones = append(zones, c.ZoneID)
+		}
+		byZone[c.ZoneID] = append(byZone[c.ZoneID], c)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].String() < zones[j].String() })
+
+	var batches []PlannedBatch
+	for _, zone := range zones {
+		orders := rankOrders(byZone[zone])
+
+		for start := 0; start < len(orders); start += toteCount {
+			end := min(start+toteCount, len(orders))
+
+			batch := PlannedBatch{ZoneID: zone}
+			tote := make(map[uuid.UUID]int, end-start)
+			for i, o := range orders[start:end] {
+				batch.Orders = append(batch.Orders, o)
+				tote[o] = i + 1
+			}
+
+			var stops []Stop
+			var picks []Candidate
+			for _, c := range byZone[zone] {
+				if t, ok := tote[c.SalesOrderID]; ok {
+					picks = append(picks, c)
+					stops = append(stops, Stop{
+						Tote:         t,
+						TaskID:       c.TaskID,
+						SalesOrderID: c.SalesOrderID,
+						OrderNumber:  c.OrderNumber,
+						LocationID:   c.LocationID,
+						LocationCode: c.LocationCode,
+					})
+				}
+			}
+			batch.Stops = routeStops(picks, stops)
+			batches = append(batches, batch)
+		}
+	}
+
+	return batches
+}
+
+// rankOrders returns the distinct orders of candidates, most urgent first:
+// priority, then earliest due date (no due date last), then order number.
+func rankOrders(candidates []Candidate) []uuid.UUID {
+	type order struct {
+		id       uuid.UUID
+		number   string
+		priority int
+		due      time.Time
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var orders []order
+	for _, c := range candidates {
+		if seen[c.SalesOrderID] {
+			continue
+		}
+		seen[c.SalesOrderID] = true
+		orders = append(orders, order{id: c.SalesOrderID, number: c.OrderNumber, priority: priorityRank(c.Priority), due: c.DueDate})
+	}
+
+	sort.SliceStable(orders, func(i, j int) bool {
+		a, b := orders[i], orders[j]
+		if a.priority != b.priority {
+			return a.priority > b.priority
+		}
+		if a.due.IsZero() != b.due.IsZero() {
+			return !a.due.IsZero()
+		}
+		if !a.due.Equal(b.due) {
+			return a.due.Before(b.due)
+		}
+		return a.number < b.number
+	})
+
+	ids := make([]uuid.UUID, len(orders))
+	for i, o := range orders {
+		ids[i] = o.id
+	}
+	return ids
+}
+
+// routeStops orders a batch's stops along a serpentine walk: aisles in
+// ascending order, racks ascending in every other aisle and descending in the
+// rest, so the picker never walks back down an aisle; then shelf and bin.
+// picks[i] is the location of stops[i].
+func routeStops(picks []Candidate, stops []Stop) []Stop {
+	var aisles []string
+	seen := make(map[string]bool)
+	for _, p := range picks {
+		if !seen[p.Aisle] {
+			seen[p.Aisle] = true
+			aisles = append(aisles, p.Aisle)
+		}
+	}
+	sort.Slice(aisles, func(i, j int) bool { return compareNatural(aisles[i], aisles[j]) < 0 })
+	aisleIndex := make(map[string]int, len(aisles))
+	for i, a := range aisles {
+		aisleIndex[a] = i
+	}
+
+	idx := make([]int, len(stops))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		a, b := picks[idx[i]], picks[idx[j]]
+
+		ai, bi := aisleIndex[a.Aisle], aisleIndex[b.Aisle]
+		if ai != bi {
+			return ai < bi
+		}
+		if c := compareNatural(a.Rack, b.Rack); c != 0 {
+			if ai%2 == 1 {
+				return c > 0
+			}
+			return c < 0
+		}
+		if c := compareNatural(a.Shelf, b.Shelf); c != 0 {
+			return c < 0
+		}
+		if c := compareNatural(a.Bin, b.Bin); c != 0 {
+			return c < 0
+		}
+		if stops[idx[i]].Tote != stops[idx[j]].Tote {
+			return stops[idx[i]].Tote < stops[idx[j]].Tote
+		}
+		return stops[idx[i]].TaskID.String() < stops[idx[j]].TaskID.String()
+	})
+
+	out := make([]Stop, len(stops))
+	for seq, i := range idx {
+		out[seq] = stops[i]
+		out[seq].Sequence = seq + 1
+	}
+	return out
+}
+
+// carriersOf returns the distinct, non-empty carriers of candidates, sorted.
+func carriersOf(candidates []Candidate) []string {
+	seen := make(map[string]bool)
+	carriers := []string{}
+	for _, c := range candidates {
+		if c.Carrier != "" && !seen[c.Carrier] {
+			seen[c.Carrier] = true
+			carriers = append(carriers, c.Carrier)
+		}
+	}
+	sort.Strings(carriers)
+	return carriers
+}
+
+// priorityRank orders sales order priorities, higher is more urgent. An
+// empty or unknown priority ranks as medium.
+func priorityRank(p string) int {
+	switch p {
+	case "critical":
+		return 4
+	case "high":
+		return 3
+	case "low":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// compareNatural compares location segments so that numbered aisles, racks,
+// shelves and bins sort by number ("2" before "10", "A2" before "A10") and
+// everything else case-insensitively.
+func compareNatural(a, b string) int {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	for a != "" && b != "" {
+		ca, ra := leadingChunk(a)
+		cb, rb := leadingChunk(b)
+
+		if isDigit(ca[0]) && isDigit(cb[0]) {
+			na, nb := strings.TrimLeft(ca, "0"), strings.TrimLeft(cb, "0")
+			if len(na) != len(nb) {
+				if len(na) < len(nb) {
+					return -1
+				}
+				return 1
+			}
+			if c := strings.Compare(na, nb); c != 0 {
+				return c
+			}
+		} else if c := strings.Compare(ca, cb); c != 0 {
+			return c
+		}
+
+		a, b = ra, rb
+	}
+	return strings.Compare(a, b)
+}
+
+// leadingChunk splits s into its leading run of digits or non-digits and the
+// rest.
+func leadingChunk(s string) (string, string) {
+	digit := isDigit(s[0])
+	i := 1
+	for i < len(s) && isDigit(s[i]) == digit {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
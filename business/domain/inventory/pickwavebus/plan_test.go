@@ -0,0 +1,218 @@
+package pickwavebus
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	zoneA = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	zoneB = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+)
+
+type testOrder struct {
+	id       uuid.UUID
+	number   string
+	priority string
+	due      time.Time
+	carrier  string
+}
+
+func newOrder(number, priority string, due time.Time, carrier string) testOrder {
+	return testOrder{id: uuid.New(), number: number, priority: priority, due: due, carrier: carrier}
+}
+
+// pick returns a candidate for o at the location aisle-rack-shelf-bin in zone.
+func (o testOrder) pick(zone uuid.UUID, aisle, rack, shelf, bin string) Candidate {
+	return Candidate{
+		TaskID:       uuid.New(),
+		SalesOrderID: o.id,
+		OrderNumber:  o.number,
+		Priority:     o.priority,
+		DueDate:      o.due,
+		Carrier:      o.carrier,
+		LocationID:   uuid.New(),
+		LocationCode: aisle + "-" + rack + "-" + shelf + "-" + bin,
+		ZoneID:       zone,
+		Aisle:        aisle,
+		Rack:         rack,
+		Shelf:        shelf,
+		Bin:          bin,
+	}
+}
+
+func route(b PlannedBatch) []string {
+	out := make([]string, len(b.Stops))
+	for i, s := range b.Stops {
+		out[i] = s.LocationCode
+	}
+	return out
+}
+
+func TestPlanWaves_CarrierCutoffs(t *testing.T) {
+	due := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	early := time.Date(2026, 3, 1, 14, 0, 0, 0, time.UTC)
+	late := time.Date(2026, 3, 1, 17, 0, 0, 0, time.UTC)
+
+	ups := newOrder("SO-1", "medium", due, "UPS")
+	fedex := newOrder("SO-2", "medium", due, "FedEx")
+	willCall := newOrder("SO-3", "medium", due, "")
+	freight := newOrder("SO-4", "medium", due, "LTL")
+
+	waves := PlanWaves([]Candidate{
+		willCall.pick(zoneA, "1", "1", "1", "1"),
+		ups.pick(zoneA, "1", "1", "1", "2"),
+		freight.pick(zoneA, "1", "1", "1", "3"),
+		fedex.pick(zoneA, "1", "1", "1", "4"),
+	}, 4, map[string]time.Time{"UPS": late, "FedEx": early})
+
+	if len(waves) != 3 {
+		t.Fatalf("waves = %d, want 3", len(waves))
+	}
+	if waves[0].CarrierCutoff == nil || !waves[0].CarrierCutoff.Equal(early) {
+		t.Errorf("wave 1 cutoff = %v, want %v", waves[0].CarrierCutoff, early)
+	}
+	if !slices.Equal(waves[0].Carriers, []string{"FedEx"}) {
+		t.Errorf("wave 1 carriers = %v", waves[0].Carriers)
+	}
+	if waves[1].CarrierCutoff == nil || !waves[1].CarrierCutoff.Equal(late) {
+		t.Errorf("wave 2 cutoff = %v, want %v", waves[1].CarrierCutoff, late)
+	}
+	if waves[2].CarrierCutoff != nil {
+		t.Errorf("wave 3 cutoff = %v, want none", waves[2].CarrierCutoff)
+	}
+	if !slices.Equal(waves[2].Carriers, []string{"LTL"}) {
+		t.Errorf("wave 3 carriers = %v, want the carrier without a cutoff", waves[2].Carriers)
+	}
+	if got := waves[2].Batches[0].Orders; len(got) != 2 {
+		t.Errorf("wave 3 orders = %d, want the no-carrier and no-cutoff orders", len(got))
+	}
+}
+
+func TestPlanWaves_ZonesAndTotes(t *testing.T) {
+	due := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	var cands []Candidate
+	var orders []testOrder
+	for _, n := range []string{"SO-1", "SO-2", "SO-3", "SO-4", "SO-5"} {
+		o := newOrder(n, "medium", due, "")
+		orders = append(orders, o)
+		cands = append(cands, o.pick(zoneA, "1", "1", "1", n))
+	}
+	cands = append(cands, orders[0].pick(zoneB, "9", "1", "1", "1"))
+
+	waves := PlanWaves(cands, 2, nil)
+	if len(waves) != 1 {
+		t.Fatalf("waves = %d, want 1", len(waves))
+	}
+
+	batches := waves[0].Batches
+	if len(batches) != 4 {
+		t.Fatalf("batches = %d, want 3 in zone A and 1 in zone B", len(batches))
+	}
+	for i, want := range []int{2, 2, 1} {
+		if batches[i].ZoneID != zoneA || len(batches[i].Orders) != want {
+			t.Errorf("batch %d = zone %s with %d orders, want zone A with %d", i+1, batches[i].ZoneID, len(batches[i].Orders), want)
+		}
+	}
+	if batches[3].ZoneID != zoneB || batches[3].Orders[0] != orders[0].id {
+		t.Errorf("batch 4 should hold SO-1's zone B pick")
+	}
+
+	for _, s := range batches[0].Stops {
+		want := 1
+		if s.SalesOrderID == orders[1].id {
+			want = 2
+		}
+		if s.Tote != want {
+			t.Errorf("%s tote = %d, want %d", s.OrderNumber, s.Tote, want)
+		}
+	}
+}
+
+func TestPlanWaves_PriorityThenDueDate(t *testing.T) {
+	soon := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	lowSoon := newOrder("SO-1", "low", soon, "")
+	medLater := newOrder("SO-2", "medium", later, "")
+	medSoon := newOrder("SO-3", "medium", soon, "")
+	critical := newOrder("SO-4", "critical", later, "")
+	medNoDue := newOrder("SO-5", "medium", time.Time{}, "")
+
+	var cands []Candidate
+	for _, o := range []testOrder{lowSoon, medLater, medSoon, critical, medNoDue} {
+		cands = append(cands, o.pick(zoneA, "1", "1", "1", o.number))
+	}
+
+	waves := PlanWaves(cands, 10, nil)
+	got := waves[0].Batches[0].Orders
+	want := []uuid.UUID{critical.id, medSoon.id, medLater.id, medNoDue.id, lowSoon.id}
+	if !slices.Equal(got, want) {
+		t.Errorf("order ranking wrong:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestPlanWaves_SerpentineRoute(t *testing.T) {
+	o := newOrder("SO-1", "medium", time.Time{}, "")
+
+	waves := PlanWaves([]Candidate{
+		o.pick(zoneA, "2", "1", "1", "1"),
+		o.pick(zoneA, "1", "10", "1", "1"),
+		o.pick(zoneA, "2", "3", "1", "1"),
+		o.pick(zoneA, "1", "2", "2", "1"),
+		o.pick(zoneA, "1", "2", "1", "1"),
+		o.pick(zoneA, "10", "1", "1", "1"),
+		o.pick(zoneA, "10", "5", "1", "1"),
+	}, 8, nil)
+
+	b := waves[0].Batches[0]
+	want := []string{
+		"1-2-1-1", "1-2-2-1", "1-10-1-1", // aisle 1 walked up
+		"2-3-1-1", "2-1-1-1", // aisle 2 walked back down
+		"10-1-1-1", "10-5-1-1", // aisle 10 walked up again
+	}
+	if got := route(b); !slices.Equal(got, want) {
+		t.Errorf("route wrong:\n got %v\nwant %v", got, want)
+	}
+	for i, s := range b.Stops {
+		if s.Sequence != i+1 {
+			t.Errorf("stop %d sequence = %d", i+1, s.Sequence)
+		}
+	}
+}
+
+func TestPlanWaves_DefaultToteCount(t *testing.T) {
+	var cands []Candidate
+	for range DefaultToteCount + 1 {
+		cands = append(cands, newOrder("SO", "medium", time.Time{}, "").pick(zoneA, "1", "1", "1", "1"))
+	}
+
+	waves := PlanWaves(cands, 0, nil)
+	if got := len(waves[0].Batches); got != 2 {
+		t.Errorf("batches = %d, want 2", got)
+	}
+}
+
+func TestCompareNatural(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2", "10", -1},
+		{"A2", "a10", -1},
+		{"B1", "A9", 1},
+		{"07", "7", 0},
+		{"R1", "R1A", -1},
+		{"", "1", -1},
+	}
+
+	for _, tt := range tests {
+		if got := compareNatural(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareNatural(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
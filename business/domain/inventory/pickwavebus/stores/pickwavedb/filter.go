@@ -0,0 +1,66 @@
+package pickwavedb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/timmaaaz/ichor/business/domain/inventory/pickwavebus"
+)
+
+func applyFilter(filter pickwavebus.QueryFilter, data map[string]any, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["id"] = *filter.ID
+		wc = append(wc, "id = :id")
+	}
+
+	if filter.Status != nil {
+		data["status"] = *filter.Status
+		wc = append(wc, "status = :status")
+	}
+
+	if filter.WarehouseID != nil {
+		data["warehouse_id"] = *filter.WarehouseID
+		wc = append(wc, "warehouse_id = :warehouse_id")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
+
+func applyBatchFilter(filter pickwavebus.BatchFilter, data map[string]any, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["id"] = *filter.ID
+		wc = append(wc, "id = :id")
+	}
+
+	if filter.WaveID != nil {
+		data["wave_id"] = *filter.WaveID
+		wc = append(wc, "wave_id = :wave_id")
+	}
+
+	if filter.ZoneID != nil {
+		data["zone_id"] = *filter.ZoneID
+		wc = append(wc, "zone_id = :zone_id")
+	}
+
+	if filter.Status != nil {
+		data["status"] = *filter.Status
+		wc = append(wc, "status = :status")
+	}
+
+	if filter.AssignedTo != nil {
+		data["assigned_to"] = *filter.AssignedTo
+		wc = append(wc, "assigned_to = :assigned_to")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
@@ -0,0 +1,182 @@
+package pickwavedb
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/pickwavebus"
+)
+
+// wave mirrors the inventory.pick_waves DB row.
+type wave struct {
+	ID            uuid.UUID     `db:"id"`
+	WaveNumber    string        `db:"wave_number"`
+	Status        string        `db:"status"`
+	WarehouseID   uuid.NullUUID `db:"warehouse_id"`
+	CarrierCutoff sql.NullTime  `db:"carrier_cutoff"`
+	ToteCount     int           `db:"tote_count"`
+	CreatedBy     uuid.UUID     `db:"created_by"`
+	CreatedDate   time.Time     `db:"created_date"`
+	UpdatedDate   time.Time     `db:"updated_date"`
+}
+
+func toDBWave(bus pickwavebus.Wave) wave {
+	return wave{
+		ID:            bus.ID,
+		WaveNumber:    bus.WaveNumber,
+		Status:        bus.Status,
+		WarehouseID:   toNullUUID(bus.WarehouseID),
+		CarrierCutoff: toNullTime(bus.CarrierCutoff),
+		ToteCount:     bus.ToteCount,
+		CreatedBy:     bus.CreatedBy,
+		CreatedDate:   bus.CreatedDate.UTC(),
+		UpdatedDate:   bus.UpdatedDate.UTC(),
+	}
+}
+
+func toBusWave(db wave) pickwavebus.Wave {
+	return pickwavebus.Wave{
+		ID:            db.ID,
+		WaveNumber:    db.WaveNumber,
+		Status:        db.Status,
+		WarehouseID:   fromNullUUID(db.WarehouseID),
+		CarrierCutoff: fromNullTime(db.CarrierCutoff),
+		ToteCount:     db.ToteCount,
+		CreatedBy:     db.CreatedBy,
+		CreatedDate:   db.CreatedDate.In(time.Local),
+		UpdatedDate:   db.UpdatedDate.In(time.Local),
+	}
+}
+
+func toBusWaves(dbs []wave) []pickwavebus.Wave {
+	waves := make([]pickwavebus.Wave, len(dbs))
+	for i, db := range dbs {
+		waves[i] = toBusWave(db)
+	}
+	return waves
+}
+
+// batch mirrors the inventory.pick_batches DB row.
+type batch struct {
+	ID          uuid.UUID     `db:"id"`
+	WaveID      uuid.UUID     `db:"wave_id"`
+	BatchNumber string        `db:"batch_number"`
+	ZoneID      uuid.UUID     `db:"zone_id"`
+	Status      string        `db:"status"`
+	OrderCount  int           `db:"order_count"`
+	StopCount   int           `db:"stop_count"`
+	AssignedTo  uuid.NullUUID `db:"assigned_to"`
+	AssignedAt  sql.NullTime  `db:"assigned_at"`
+	CreatedDate time.Time     `db:"created_date"`
+	UpdatedDate time.Time     `db:"updated_date"`
+}
+
+func toDBBatch(bus pickwavebus.Batch) batch {
+	return batch{
+		ID:          bus.ID,
+		WaveID:      bus.WaveID,
+		BatchNumber: bus.BatchNumber,
+		ZoneID:      bus.ZoneID,
+		Status:      bus.Status,
+		OrderCount:  bus.OrderCount,
+		StopCount:   bus.StopCount,
+		AssignedTo:  toNullUUID(bus.AssignedTo),
+		AssignedAt:  toNullTime(bus.AssignedAt),
+		CreatedDate: bus.CreatedDate.UTC(),
+		UpdatedDate: bus.UpdatedDate.UTC(),
+	}
+}
+
+func toBusBatch(db batch) pickwavebus.Batch {
+	return pickwavebus.Batch{
+		ID:          db.ID,
+		WaveID:      db.WaveID,
+		BatchNumber: db.BatchNumber,
+		ZoneID:      db.ZoneID,
+		Status:      db.Status,
+		OrderCount:  db.OrderCount,
+		StopCount:   db.StopCount,
+		AssignedTo:  fromNullUUID(db.AssignedTo),
+		AssignedAt:  fromNullTime(db.AssignedAt),
+		CreatedDate: db.CreatedDate.In(time.Local),
+		UpdatedDate: db.UpdatedDate.In(time.Local),
+	}
+}
+
+func toBusBatches(dbs []batch) []pickwavebus.Batch {
+	batches := make([]pickwavebus.Batch, len(dbs))
+	for i, db := range dbs {
+		batches[i] = toBusBatch(db)
+	}
+	return batches
+}
+
+// candidate is one row of the candidate query.
+type candidate struct {
+	TaskID       uuid.UUID `db:"task_id"`
+	SalesOrderID uuid.UUID `db:"sales_order_id"`
+	OrderNumber  string    `db:"order_number"`
+	Priority     string    `db:"priority"`
+	DueDate      time.Time `db:"due_date"`
+	Carrier      string    `db:"carrier"`
+	LocationID   uuid.UUID `db:"location_id"`
+	LocationCode string    `db:"location_code"`
+	ZoneID       uuid.UUID `db:"zone_id"`
+	Aisle        string    `db:"aisle"`
+	Rack         string    `db:"rack"`
+	Shelf        string    `db:"shelf"`
+	Bin          string    `db:"bin"`
+}
+
+func toBusCandidates(dbs []candidate) []pickwavebus.Candidate {
+	cands := make([]pickwavebus.Candidate, len(dbs))
+	for i, db := range dbs {
+		cands[i] = pickwavebus.Candidate{
+			TaskID:       db.TaskID,
+			SalesOrderID: db.SalesOrderID,
+			OrderNumber:  db.OrderNumber,
+			Priority:     db.Priority,
+			DueDate:      db.DueDate,
+			Carrier:      db.Carrier,
+			LocationID:   db.LocationID,
+			LocationCode: db.LocationCode,
+			ZoneID:       db.ZoneID,
+			Aisle:        db.Aisle,
+			Rack:         db.Rack,
+			Shelf:        db.Shelf,
+			Bin:          db.Bin,
+		}
+	}
+	return cands
+}
+
+func toNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func fromNullUUID(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	v := id.UUID
+	return &v
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time.In(time.Local)
+	return &v
+}
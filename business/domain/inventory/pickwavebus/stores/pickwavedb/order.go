@@ -0,0 +1,25 @@
+package pickwavedb
+
+import (
+	"fmt"
+
+	"github.com/timmaaaz/ichor/business/domain/inventory/pickwavebus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+)
+
+var orderByFields = map[string]string{
+	pickwavebus.OrderByID:            "id",
+	pickwavebus.OrderByWaveNumber:    "wave_number",
+	pickwavebus.OrderByStatus:        "status",
+	pickwavebus.OrderByCarrierCutoff: "carrier_cutoff",
+	pickwavebus.OrderByCreatedDate:   "created_date",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
@@ -0,0 +1,389 @@
+// Package pickwavedb contains pick wave related CRUD functionality.
+package pickwavedb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/business/domain/inventory/pickwavebus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// Store manages the set of APIs for pick wave database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// NewWithTx constructs a new Store value replacing the sqlx.DB
+// value with a sqlx.Tx value that is currently inside a transaction.
+func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (pickwavebus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		log: s.log,
+		db:  ec,
+	}, nil
+}
+
+// Create inserts a new wave into the database.
+func (s *Store) Create(ctx context.Context, wave pickwavebus.Wave) error {
+	const q = `
+	INSERT INTO inventory.pick_waves
+		(id, wave_number, status, warehouse_id, carrier_cutoff, tote_count, created_by, created_date, updated_date)
+	VALUES
+		(:id, :wave_number, :status, :warehouse_id, :carrier_cutoff, :tote_count, :created_by, :created_date, :updated_date)
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBWave(wave)); err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return fmt.Errorf("namedexeccontext: %w", pickwavebus.ErrForeignKeyViolation)
+		}
+		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+			return fmt.Errorf("namedexeccontext: %w", pickwavebus.ErrUniqueEntry)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Update modifies an existing wave in the database.
+func (s *Store) Update(ctx context.Context, wave pickwavebus.Wave) error {
+	const q = `
+	UPDATE inventory.pick_waves
+	SET
+		status       = :status,
+		updated_date = :updated_date
+	WHERE
+		id = :id
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBWave(wave)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a wave and, by cascade, its batches from the database. The
+// wave's pick tasks keep their rows with their batch cleared.
+func (s *Store) Delete(ctx context.Context, wave pickwavebus.Wave) error {
+	data := map[string]any{
+		"id": wave.ID,
+	}
+
+	const clear = `
+	UPDATE inventory.pick_tasks
+	SET
+		batch_sequence = NULL,
+		tote_number    = NULL
+	WHERE
+		batch_id IN (SELECT id FROM inventory.pick_batches WHERE wave_id = :id)
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, clear, data); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	const q = `
+	DELETE FROM inventory.pick_waves
+	WHERE id = :id
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, data); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of waves from the database.
+func (s *Store) Query(ctx context.Context, filter pickwavebus.QueryFilter, orderBy order.By, page page.Page) ([]pickwavebus.Wave, error) {
+	data := map[string]any{
+		"offset":        (page.Number() - 1) * page.RowsPerPage(),
+		"rows_per_page": page.RowsPerPage(),
+	}
+
+	const q = `
+	SELECT
+		id, wave_number, status, warehouse_id, carrier_cutoff, tote_count, created_by, created_date, updated_date
+	FROM
+		inventory.pick_waves
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbWaves []wave
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbWaves); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusWaves(dbWaves), nil
+}
+
+// Count returns the total number of waves matching the filter.
+func (s *Store) Count(ctx context.Context, filter pickwavebus.QueryFilter) (int, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		COUNT(1) AS count
+	FROM
+		inventory.pick_waves
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID retrieves a single wave by its ID.
+func (s *Store) QueryByID(ctx context.Context, waveID uuid.UUID) (pickwavebus.Wave, error) {
+	data := map[string]any{
+		"id": waveID.String(),
+	}
+
+	const q = `
+	SELECT
+		id, wave_number, status, warehouse_id, carrier_cutoff, tote_count, created_by, created_date, updated_date
+	FROM
+		inventory.pick_waves
+	WHERE
+		id = :id
+	`
+
+	var dbWave wave
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbWave); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return pickwavebus.Wave{}, pickwavebus.ErrNotFound
+		}
+		return pickwavebus.Wave{}, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	return toBusWave(dbWave), nil
+}
+
+// CreateBatch inserts a new batch into the database.
+func (s *Store) CreateBatch(ctx context.Context, b pickwavebus.Batch) error {
+	const q = `
+	INSERT INTO inventory.pick_batches
+		(id, wave_id, batch_number, zone_id, status, order_count, stop_count, assigned_to, assigned_at, created_date, updated_date)
+	VALUES
+		(:id, :wave_id, :batch_number, :zone_id, :status, :order_count, :stop_count, :assigned_to, :assigned_at, :created_date, :updated_date)
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBBatch(b)); err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return fmt.Errorf("namedexeccontext: %w", pickwavebus.ErrForeignKeyViolation)
+		}
+		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+			return fmt.Errorf("namedexeccontext: %w", pickwavebus.ErrUniqueEntry)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBatchWithStatusGuard modifies an existing batch in the database only
+// while its status is still expectedStatus, returning the rows affected.
+func (s *Store) UpdateBatchWithStatusGuard(ctx context.Context, b pickwavebus.Batch, expectedStatus string) (int64, error) {
+	const q = `
+	UPDATE inventory.pick_batches
+	SET
+		status       = :status,
+		assigned_to  = :assigned_to,
+		assigned_at  = :assigned_at,
+		updated_date = :updated_date
+	WHERE
+		id = :id AND status = :expected_status
+	`
+
+	data := struct {
+		batch
+		ExpectedStatus string `db:"expected_status"`
+	}{
+		batch:          toDBBatch(b),
+		ExpectedStatus: expectedStatus,
+	}
+
+	rows, err := sqldb.NamedExecContextWithCount(ctx, s.log, s.db, q, data)
+	if err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return 0, fmt.Errorf("namedexeccontextwithcount: %w", pickwavebus.ErrForeignKeyViolation)
+		}
+		return 0, fmt.Errorf("namedexeccontextwithcount: %w", err)
+	}
+
+	return rows, nil
+}
+
+// QueryBatches retrieves batches from the database, ordered by batch number.
+func (s *Store) QueryBatches(ctx context.Context, filter pickwavebus.BatchFilter, page page.Page) ([]pickwavebus.Batch, error) {
+	data := map[string]any{
+		"offset":        (page.Number() - 1) * page.RowsPerPage(),
+		"rows_per_page": page.RowsPerPage(),
+	}
+
+	const q = `
+	SELECT
+		id, wave_id, batch_number, zone_id, status, order_count, stop_count, assigned_to, assigned_at, created_date, updated_date
+	FROM
+		inventory.pick_batches
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyBatchFilter(filter, data, buf)
+	buf.WriteString(" ORDER BY batch_number OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbBatches []batch
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbBatches); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusBatches(dbBatches), nil
+}
+
+// CountBatches returns the total number of batches matching the filter.
+func (s *Store) CountBatches(ctx context.Context, filter pickwavebus.BatchFilter) (int, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		COUNT(1) AS count
+	FROM
+		inventory.pick_batches
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyBatchFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryBatchByID retrieves a single batch by its ID.
+func (s *Store) QueryBatchByID(ctx context.Context, batchID uuid.UUID) (pickwavebus.Batch, error) {
+	data := map[string]any{
+		"id": batchID.String(),
+	}
+
+	const q = `
+	SELECT
+		id, wave_id, batch_number, zone_id, status, order_count, stop_count, assigned_to, assigned_at, created_date, updated_date
+	FROM
+		inventory.pick_batches
+	WHERE
+		id = :id
+	`
+
+	var dbBatch batch
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbBatch); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return pickwavebus.Batch{}, pickwavebus.ErrBatchNotFound
+		}
+		return pickwavebus.Batch{}, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	return toBusBatch(dbBatch), nil
+}
+
+// QueryCandidates returns the pick tasks waiting for a wave: pending,
+// unassigned and in no batch, with their order's priority, due date and
+// carrier and their location's place in the warehouse. The carrier comes from
+// the order's most recent shipment that is not cancelled; an order with no
+// shipment yet has none.
+func (s *Store) QueryCandidates(ctx context.Context, warehouseID *uuid.UUID) ([]pickwavebus.Candidate, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		pt.id AS task_id, pt.sales_order_id, so.number AS order_number, so.priority, so.due_date,
+		COALESCE((
+			SELECT sh.carrier
+			FROM sales.shipments sh
+			WHERE sh.order_id = so.id AND sh.status <> 'cancelled'
+			ORDER BY sh.created_date DESC
+			LIMIT 1
+		), '') AS carrier,
+		pt.location_id, COALESCE(il.location_code, '') AS location_code, il.zone_id,
+		il.aisle, il.rack, il.shelf, il.bin
+	FROM inventory.pick_tasks pt
+	JOIN sales.orders so ON so.id = pt.sales_order_id
+	JOIN inventory.inventory_locations il ON il.id = pt.location_id
+	WHERE pt.status = 'pending'
+		AND pt.assigned_to IS NULL
+		AND pt.batch_id IS NULL`
+
+	buf := bytes.NewBufferString(q)
+	if warehouseID != nil {
+		data["warehouse_id"] = *warehouseID
+		buf.WriteString(" AND il.warehouse_id = :warehouse_id")
+	}
+	if id, ok := sqldb.GetScenarioFilter(ctx); ok {
+		data["scenario_id"] = id
+		buf.WriteString(" AND (so.scenario_id IS NULL OR so.scenario_id = :scenario_id)")
+	}
+	buf.WriteString(" ORDER BY so.number, pt.id")
+
+	var dbCands []candidate
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbCands); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusCandidates(dbCands), nil
+}
+
+// LockPlanning takes a transaction-scoped advisory lock that serializes wave
+// planning, so two releases cannot batch the same pick task.
+func (s *Store) LockPlanning(ctx context.Context) error {
+	const q = `SELECT pg_advisory_xact_lock(hashtext('inventory.pick_waves'))`
+
+	if err := sqldb.ExecContext(ctx, s.log, s.db, q); err != nil {
+		return fmt.Errorf("execcontext: %w", err)
+	}
+
+	return nil
+}
@@ -45,6 +45,10 @@ func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (putawaytaskbus.Storer, err
 
 // Create inserts a new put-away task into the database.
 func (s *Store) Create(ctx context.Context, task putawaytaskbus.PutAwayTask) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, sqldb.ScopeByLocation, map[string]uuid.UUID{"location_id": task.LocationID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
 	INSERT INTO inventory.put_away_tasks
 		(id, product_id, location_id, quantity, reference_number, status,
@@ -71,6 +75,10 @@ func (s *Store) Create(ctx context.Context, task putawaytaskbus.PutAwayTask) err
 
 // Update modifies an existing put-away task in the database.
 func (s *Store) Update(ctx context.Context, task putawaytaskbus.PutAwayTask) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, sqldb.ScopeByLocation, map[string]uuid.UUID{"location_id": task.LocationID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
 	UPDATE inventory.put_away_tasks
 	SET
@@ -134,6 +142,7 @@ func (s *Store) Query(ctx context.Context, filter putawaytaskbus.QueryFilter, or
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	orderByClause, err := orderByClause(orderBy)
 	if err != nil {
@@ -170,6 +179,7 @@ func (s *Store) Count(ctx context.Context, filter putawaytaskbus.QueryFilter) (i
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	var count struct {
 		Count int `db:"count"`
@@ -200,6 +210,7 @@ func (s *Store) QueryByID(ctx context.Context, taskID uuid.UUID) (putawaytaskbus
 
 	buf := bytes.NewBufferString(q)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
 
 	var dbTask putAwayTask
 	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &dbTask); err != nil {
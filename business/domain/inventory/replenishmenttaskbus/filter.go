@@ -0,0 +1,21 @@
+package replenishmenttaskbus
+
+import "github.com/google/uuid"
+
+// QueryFilter holds optional filters for querying replenishment tasks.
+type QueryFilter struct {
+	ID             *uuid.UUID
+	ProductID      *uuid.UUID
+	FromLocationID *uuid.UUID
+	ToLocationID   *uuid.UUID
+	Reason         *string
+	Status         *string
+	AssignedTo     *uuid.UUID
+}
+
+// FaceFilter narrows the pick faces a plan considers.
+type FaceFilter struct {
+	WarehouseID *uuid.UUID
+	ProductID   *uuid.UUID
+	LocationID  *uuid.UUID
+}
@@ -0,0 +1,65 @@
+package replenishmenttaskbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JSON tags are required for workflow event serialization. The workflow system
+// marshals business models to JSON for RawData in TriggerEvents.
+
+// Task statuses. A task is pending until a worker claims it, in progress while
+// the stock is on the move, and completed once it is put on the pick face.
+const (
+	StatusPending    = "pending"
+	StatusInProgress = "in_progress"
+	StatusCompleted  = "completed"
+	StatusCancelled  = "cancelled"
+)
+
+// Reasons a pick face is replenished. ReasonMinMax fires when the face is at
+// or below its minimum; ReasonDemand when it cannot cover its open picks and
+// expected usage; ReasonShortPick when a picker found it short.
+const (
+	ReasonMinMax    = "min_max"
+	ReasonDemand    = "demand"
+	ReasonShortPick = "short_pick"
+)
+
+// Task is an instruction to move Quantity units of ProductID from a reserve
+// bin to a forward pick location. LotID names the lot to take when the
+// reserve stock is lot tracked.
+type Task struct {
+	ID             uuid.UUID  `json:"id"`
+	ProductID      uuid.UUID  `json:"product_id"`
+	LotID          *uuid.UUID `json:"lot_id,omitempty"`
+	FromLocationID uuid.UUID  `json:"from_location_id"`
+	ToLocationID   uuid.UUID  `json:"to_location_id"`
+	Quantity       int        `json:"quantity"`
+	QuantityMoved  int        `json:"quantity_moved"`
+	Reason         string     `json:"reason"`
+	Status         string     `json:"status"`
+	AssignedTo     *uuid.UUID `json:"assigned_to,omitempty"`
+	AssignedAt     *time.Time `json:"assigned_at,omitempty"`
+	CompletedBy    *uuid.UUID `json:"completed_by,omitempty"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	CreatedBy      uuid.UUID  `json:"created_by"`
+	CreatedDate    time.Time  `json:"created_date"`
+	UpdatedDate    time.Time  `json:"updated_date"`
+	ScenarioID     *uuid.UUID `json:"scenario_id,omitempty"`
+}
+
+// PlanRequest describes the pick faces to replenish. WarehouseID, ProductID
+// and LocationID narrow the faces considered. CoverDays is how many days of
+// average usage a face should hold on top of its open picks; zero means
+// DefaultCoverDays. ShortPick fills the named faces up to their target even
+// when they are above their minimum.
+type PlanRequest struct {
+	WarehouseID *uuid.UUID
+	ProductID   *uuid.UUID
+	LocationID  *uuid.UUID
+	CoverDays   int
+	ShortPick   bool
+	CreatedBy   uuid.UUID
+}
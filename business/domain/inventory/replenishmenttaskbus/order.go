@@ -0,0 +1,15 @@
+package replenishmenttaskbus
+
+import "github.com/timmaaaz/ichor/business/sdk/order"
+
+// DefaultOrderBy represents the default ordering for task queries.
+var DefaultOrderBy = order.NewBy(OrderByCreatedDate, order.DESC)
+
+const (
+	OrderByID          = "id"
+	OrderByProductID   = "product_id"
+	OrderByReason      = "reason"
+	OrderByStatus      = "status"
+	OrderByCreatedDate = "created_date"
+	OrderByUpdatedDate = "updated_date"
+)
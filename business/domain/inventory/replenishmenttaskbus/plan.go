@@ -0,0 +1,228 @@
+package replenishmenttaskbus
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultCoverDays is how many days of average usage a pick face is expected
+// to hold when a request names no cover.
+const DefaultCoverDays = 1
+
+// replenishableLotStatuses are the lot quality statuses that may be moved to a
+// pick face; held, quarantined and expired lots stay in reserve.
+var replenishableLotStatuses = map[string]bool{"good": true, "released": true}
+
+// Face is a product's stock at a forward pick location with its replenishment
+// settings. Minimum is the larger of the item's minimum stock and reorder
+// point. OpenDemand is the quantity still to pick there on open pick tasks;
+// Inbound is the quantity already on open replenishment tasks to it.
+type Face struct {
+	ProductID     uuid.UUID `json:"product_id"`
+	LocationID    uuid.UUID `json:"location_id"`
+	WarehouseID   uuid.UUID `json:"warehouse_id"`
+	LocationCode  string    `json:"location_code"`
+	Quantity      int       `json:"quantity"`
+	Minimum       int       `json:"minimum"`
+	Maximum       int       `json:"maximum"`
+	AvgDailyUsage int       `json:"avg_daily_usage"`
+	OpenDemand    int       `json:"open_demand"`
+	Inbound       int       `json:"inbound"`
+}
+
+// Lot is a lot held at a reserve location. Quantity is net of open
+// replenishment tasks already taking from it.
+type Lot struct {
+	ID             uuid.UUID `json:"id"`
+	ExpirationDate time.Time `json:"expiration_date"`
+	QualityStatus  string    `json:"quality_status"`
+	Quantity       int       `json:"quantity"`
+}
+
+// ReserveStock is a product's stock at a reserve location. Available is net
+// of reserved and allocated quantity and of open replenishment tasks already
+// taking from it. Lots are the lots that make up part of that stock; the rest
+// is untracked.
+type ReserveStock struct {
+	ProductID    uuid.UUID `json:"product_id"`
+	LocationID   uuid.UUID `json:"location_id"`
+	WarehouseID  uuid.UUID `json:"warehouse_id"`
+	LocationCode string    `json:"location_code"`
+	Available    int       `json:"available"`
+	Lots         []Lot     `json:"lots"`
+}
+
+// PlannedTask is one move Generate would create.
+type PlannedTask struct {
+	ProductID        uuid.UUID  `json:"product_id"`
+	LotID            *uuid.UUID `json:"lot_id,omitempty"`
+	LotExpiration    *time.Time `json:"lot_expiration,omitempty"`
+	FromLocationID   uuid.UUID  `json:"from_location_id"`
+	FromLocationCode string     `json:"from_location_code"`
+	ToLocationID     uuid.UUID  `json:"to_location_id"`
+	ToLocationCode   string     `json:"to_location_code"`
+	Quantity         int        `json:"quantity"`
+	Reason           string     `json:"reason"`
+}
+
+// PlanReplenishment decides which pick faces need stock and where it comes
+// from. A face with a maximum is due when its on-hand plus inbound quantity is
+// at or below its minimum; any face is due when that projection cannot cover
+// its open picks plus CoverDays of average usage. A due face is filled up to
+// the larger of its maximum and that demand. Stock is drawn from reserve
+// locations of the same product in the same warehouse, lots first in
+// expiration order (FEFO), then untracked stock, larger quantities first.
+// Faces are served emptiest first, so scarce reserve stock goes where it is
+// needed most.
+func PlanReplenishment(faces []Face, stock []ReserveStock, req PlanRequest, now time.Time) []PlannedTask {
+	coverDays := req.CoverDays
+	if coverDays <= 0 {
+		coverDays = DefaultCoverDays
+	}
+
+	type due struct {
+		face      Face
+		reason    string
+		projected int
+		target    int
+	}
+
+	var dues []due
+	for _, f := range faces {
+		projected := f.Quantity + f.Inbound
+		need := f.OpenDemand + f.AvgDailyUsage*coverDays
+		target := max(f.Maximum, need)
+
+		var reason string
+		switch {
+		case req.ShortPick:
+			reason = ReasonShortPick
+		case f.Maximum > 0 && projected <= f.Minimum:
+			reason = ReasonMinMax
+		case projected < need:
+			reason = ReasonDemand
+		default:
+			continue
+		}
+
+		if target <= projected {
+			continue
+		}
+		dues = append(dues, due{face: f, reason: reason, projected: projected, target: target})
+	}
+
+	// Emptiest first: compare projected/target without dividing.
+	sort.SliceStable(dues, func(i, j int) bool {
+		a, b := dues[i], dues[j]
+		if l, r := a.projected*b.target, b.projected*a.target; l != r {
+			return l < r
+		}
+		if a.face.LocationCode != b.face.LocationCode {
+			return a.face.LocationCode < b.face.LocationCode
+		}
+		return a.face.LocationID.String() < b.face.LocationID.String()
+	})
+
+	sources := fefoSources(stock, now)
+
+	var tasks []PlannedTask
+	for _, d := range dues {
+		want := d.target - d.projected
+
+		for _, s := range sources {
+			if want == 0 {
+				break
+			}
+			if s.stock.ProductID != d.face.ProductID || s.stock.WarehouseID != d.face.WarehouseID || s.stock.LocationID == d.face.LocationID {
+				continue
+			}
+
+			take := min(want, s.quantity, *s.remaining)
+			if take <= 0 {
+				continue
+			}
+			s.quantity -= take
+			*s.remaining -= take
+			want -= take
+
+			task := PlannedTask{
+				ProductID:        d.face.ProductID,
+				FromLocationID:   s.stock.LocationID,
+				FromLocationCode: s.stock.LocationCode,
+				ToLocationID:     d.face.LocationID,
+				ToLocationCode:   d.face.LocationCode,
+				Quantity:         take,
+				Reason:           d.reason,
+			}
+			if s.lot != nil {
+				id, exp := s.lot.ID, s.lot.ExpirationDate
+				task.LotID = &id
+				task.LotExpiration = &exp
+			}
+			tasks = append(tasks, task)
+		}
+	}
+
+	return tasks
+}
+
+// source is a quantity that can be drawn from one reserve location: one lot,
+// or the location's untracked stock when lot is nil. remaining is shared by
+// every source of the same location, so drawing a lot also draws down the
+// location's available stock.
+type source struct {
+	stock     ReserveStock
+	lot       *Lot
+	quantity  int
+	remaining *int
+}
+
+// fefoSources flattens reserve stock into sources in the order they should be
+// drawn: lots by earliest expiration, then untracked stock; ties go to the
+// larger quantity so a face is filled in as few trips as possible. Lots that
+// may not be picked, or that have expired, are left out, and their quantity is
+// not counted as untracked stock either.
+func fefoSources(stock []ReserveStock, now time.Time) []*source {
+	var sources []*source
+	for _, st := range stock {
+		if st.Available <= 0 {
+			continue
+		}
+		remaining := st.Available
+
+		tracked := 0
+		for i := range st.Lots {
+			lot := st.Lots[i]
+			tracked += lot.Quantity
+			if lot.Quantity <= 0 || !replenishableLotStatuses[lot.QualityStatus] || !lot.ExpirationDate.After(now) {
+				continue
+			}
+			sources = append(sources, &source{stock: st, lot: &lot, quantity: lot.Quantity, remaining: &remaining})
+		}
+
+		if untracked := st.Available - tracked; untracked > 0 {
+			sources = append(sources, &source{stock: st, quantity: untracked, remaining: &remaining})
+		}
+	}
+
+	sort.SliceStable(sources, func(i, j int) bool {
+		a, b := sources[i], sources[j]
+		if (a.lot != nil) != (b.lot != nil) {
+			return a.lot != nil
+		}
+		if a.lot != nil && !a.lot.ExpirationDate.Equal(b.lot.ExpirationDate) {
+			return a.lot.ExpirationDate.Before(b.lot.ExpirationDate)
+		}
+		if a.quantity != b.quantity {
+			return a.quantity > b.quantity
+		}
+		if a.stock.LocationCode != b.stock.LocationCode {
+			return a.stock.LocationCode < b.stock.LocationCode
+		}
+		return a.stock.LocationID.String() < b.stock.LocationID.String()
+	})
+
+	return sources
+}
@@ -0,0 +1,149 @@
+package replenishmenttaskbus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	now       = time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	warehouse = uuid.MustParse("00000000-0000-0000-0000-0000000000a1")
+	product   = uuid.MustParse("00000000-0000-0000-0000-0000000000b1")
+)
+
+func face(code string, qty, minimum, maximum int) Face {
+	return Face{
+		ProductID:    product,
+		LocationID:   uuid.New(),
+		WarehouseID:  warehouse,
+		LocationCode: code,
+		Quantity:     qty,
+		Minimum:      minimum,
+		Maximum:      maximum,
+	}
+}
+
+func reserve(code string, available int, lots ...Lot) ReserveStock {
+	return ReserveStock{
+		ProductID:    product,
+		LocationID:   uuid.New(),
+		WarehouseID:  warehouse,
+		LocationCode: code,
+		Available:    available,
+		Lots:         lots,
+	}
+}
+
+func lot(expiresInDays, qty int, status string) Lot {
+	return Lot{
+		ID:             uuid.New(),
+		ExpirationDate: now.AddDate(0, 0, expiresInDays),
+		QualityStatus:  status,
+		Quantity:       qty,
+	}
+}
+
+func TestPlanReplenishment_MinMax(t *testing.T) {
+	low := face("P-1", 4, 5, 20)
+	ok := face("P-2", 6, 5, 20)
+	inbound := face("P-3", 2, 5, 20)
+	inbound.Inbound = 10
+	unset := face("P-4", 0, 0, 0)
+
+	tasks := PlanReplenishment([]Face{low, ok, inbound, unset}, []ReserveStock{reserve("R-1", 100)}, PlanRequest{}, now)
+
+	if len(tasks) != 1 {
+		t.Fatalf("tasks = %d, want only the face at its minimum", len(tasks))
+	}
+	if tasks[0].ToLocationID != low.LocationID || tasks[0].Quantity != 16 || tasks[0].Reason != ReasonMinMax {
+		t.Errorf("task = %+v, want 16 to P-1 for min_max", tasks[0])
+	}
+}
+
+func TestPlanReplenishment_Demand(t *testing.T) {
+	f := face("P-1", 10, 2, 12)
+	f.OpenDemand = 8
+	f.AvgDailyUsage = 5
+
+	tasks := PlanReplenishment([]Face{f}, []ReserveStock{reserve("R-1", 100)}, PlanRequest{CoverDays: 2}, now)
+
+	if len(tasks) != 1 {
+		t.Fatalf("tasks = %d, want 1", len(tasks))
+	}
+	// Demand is 8 open + 2 days * 5 = 18, above the maximum of 12.
+	if tasks[0].Quantity != 8 || tasks[0].Reason != ReasonDemand {
+		t.Errorf("task = %+v, want 8 for demand", tasks[0])
+	}
+}
+
+func TestPlanReplenishment_ShortPick(t *testing.T) {
+	f := face("P-1", 15, 5, 20)
+
+	if tasks := PlanReplenishment([]Face{f}, []ReserveStock{reserve("R-1", 100)}, PlanRequest{}, now); len(tasks) != 0 {
+		t.Fatalf("tasks = %d, want none above the minimum", len(tasks))
+	}
+
+	tasks := PlanReplenishment([]Face{f}, []ReserveStock{reserve("R-1", 100)}, PlanRequest{ShortPick: true}, now)
+	if len(tasks) != 1 || tasks[0].Quantity != 5 || tasks[0].Reason != ReasonShortPick {
+		t.Errorf("tasks = %+v, want 5 for short_pick", tasks)
+	}
+}
+
+func TestPlanReplenishment_FEFO(t *testing.T) {
+	late := lot(90, 10, "good")
+	early := lot(10, 4, "good")
+	held := lot(5, 6, "on_hold")
+	expired := lot(-1, 3, "good")
+
+	// R-1 holds 30: the late lot, the held lot, and 14 untracked.
+	// R-2 holds 10: the early lot, the expired lot, and 3 untracked.
+	r1 := reserve("R-1", 30, late, held)
+	r2 := reserve("R-2", 10, early, expired)
+
+	tasks := PlanReplenishment([]Face{face("P-1", 0, 0, 25)}, []ReserveStock{r1, r2}, PlanRequest{}, now)
+
+	want := []struct {
+		from uuid.UUID
+		lot  *uuid.UUID
+		qty  int
+	}{
+		{r2.LocationID, &early.ID, 4},
+		{r1.LocationID, &late.ID, 10},
+		{r1.LocationID, nil, 11},
+	}
+	if len(tasks) != len(want) {
+		t.Fatalf("tasks = %+v, want %d", tasks, len(want))
+	}
+	for i, w := range want {
+		got := tasks[i]
+		if got.FromLocationID != w.from || got.Quantity != w.qty || (got.LotID == nil) != (w.lot == nil) || (w.lot != nil && *got.LotID != *w.lot) {
+			t.Errorf("task %d = %+v, want %d from %s lot %v", i+1, got, w.qty, w.from, w.lot)
+		}
+	}
+}
+
+func TestPlanReplenishment_SharedReserve(t *testing.T) {
+	empty := face("P-2", 0, 2, 10)
+	half := face("P-1", 5, 5, 10)
+
+	// Reserve is net of what is already spoken for and split across both lots
+	// and untracked stock; it must never be drawn twice.
+	r := reserve("R-1", 8, lot(30, 6, "good"))
+	other := reserve("R-9", 50)
+	other.WarehouseID = uuid.New()
+
+	tasks := PlanReplenishment([]Face{half, empty}, []ReserveStock{r, other}, PlanRequest{}, now)
+
+	got := map[uuid.UUID]int{}
+	for _, task := range tasks {
+		if task.FromLocationID != r.LocationID {
+			t.Fatalf("task drew from another warehouse: %+v", task)
+		}
+		got[task.ToLocationID] += task.Quantity
+	}
+	if got[empty.LocationID] != 8 || got[half.LocationID] != 0 {
+		t.Errorf("moved = %v, want all 8 to the empty face", got)
+	}
+}
@@ -0,0 +1,463 @@
+// Package replenishmenttaskbus provides business access to replenishment
+// tasks: moves of stock from reserve bins to forward pick locations, planned
+// from each pick face's min/max settings and demand.
+package replenishmenttaskbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/lotlocationbus"
+	"github.com/timmaaaz/ichor/business/sdk/delegate"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/outbox"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+	"github.com/timmaaaz/ichor/foundation/otel"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound            = errors.New("replenishment task not found")
+	ErrUniqueEntry         = errors.New("replenishment task entry is not unique")
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+	ErrNothingToReplenish  = errors.New("no pick face needs replenishment")
+	ErrTaskClaimed         = errors.New("replenishment task is already claimed")
+	ErrTaskNotClaimed      = errors.New("replenishment task is not in progress")
+	ErrTaskClosed          = errors.New("replenishment task is already completed or cancelled")
+	ErrInvalidQuantity     = errors.New("moved quantity must be between 1 and the task quantity")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	NewWithTx(tx sqldb.CommitRollbacker) (Storer, error)
+	Create(ctx context.Context, task Task) error
+	// UpdateWithStatusGuard updates the task only while its current status
+	// equals expectedStatus. It returns the number of rows affected (0 means a
+	// concurrent transition won).
+	UpdateWithStatusGuard(ctx context.Context, task Task, expectedStatus string) (int64, error)
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]Task, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, taskID uuid.UUID) (Task, error)
+	QueryFaces(ctx context.Context, filter FaceFilter) ([]Face, error)
+	QueryReserveStock(ctx context.Context, productIDs []uuid.UUID) ([]ReserveStock, error)
+	LockPlanning(ctx context.Context) error
+}
+
+// Business manages the set of APIs for replenishment task access.
+type Business struct {
+	log                     *logger.Logger
+	storer                  Storer
+	delegate                *delegate.Delegate
+	outbox                  *outbox.Writer
+	inventoryItemBus        *inventoryitembus.Business
+	inventoryTransactionBus *inventorytransactionbus.Business
+	lotLocationBus          *lotlocationbus.Business
+}
+
+// NewBusiness constructs a replenishment task business API for use. The
+// inventory item, transaction and lot location buses carry the stock movement
+// when a task is completed.
+func NewBusiness(
+	log *logger.Logger,
+	delegate *delegate.Delegate,
+	storer Storer,
+	inventoryItemBus *inventoryitembus.Business,
+	inventoryTransactionBus *inventorytransactionbus.Business,
+	lotLocationBus *lotlocationbus.Business,
+) *Business {
+	return &Business{
+		log:                     log,
+		delegate:                delegate,
+		storer:                  storer,
+		inventoryItemBus:        inventoryItemBus,
+		inventoryTransactionBus: inventoryTransactionBus,
+		lotLocationBus:          lotLocationBus,
+	}
+}
+
+// WithOutbox returns a copy of the Business wired to the cascade outbox Writer.
+// Inert until the Writer is injected at the F2 cutover (nil Writer -> Emit no-ops).
+func (b *Business) WithOutbox(w *outbox.Writer) *Business {
+	nb := *b
+	nb.outbox = w
+	return &nb
+}
+
+// NewWithTx constructs a new Business value replacing the Storer
+// value with a Storer value that is currently inside a transaction.
+func (b *Business) NewWithTx(tx sqldb.CommitRollbacker) (*Business, error) {
+	storer, err := b.storer.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryItemBus, err := b.inventoryItemBus.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryTransactionBus, err := b.inventoryTransactionBus.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	lotLocationBus, err := b.lotLocationBus.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	nb := *b
+	nb.storer = storer
+	nb.inventoryItemBus = inventoryItemBus
+	nb.inventoryTransactionBus = inventoryTransactionBus
+	nb.lotLocationBus = lotLocationBus
+	return &nb, nil
+}
+
+// Plan returns the tasks Generate would create now, without writing anything.
+func (b *Business) Plan(ctx context.Context, req PlanRequest, now time.Time) ([]PlannedTask, error) {
+	ctx, span := otel.AddSpan(ctx, "business.replenishmenttaskbus.plan")
+	defer span.End()
+
+	planned, err := b.plan(ctx, req, now)
+	if err != nil {
+		return nil, fmt.Errorf("plan: %w", err)
+	}
+
+	return planned, nil
+}
+
+// Generate creates a pending task for every move the plan calls for. Planning
+// is serialized so two runs cannot both fill the same face or draw the same
+// reserve stock. Returns ErrNothingToReplenish when no face is due or no
+// reserve stock can fill one.
+func (b *Business) Generate(ctx context.Context, req PlanRequest, now time.Time) ([]Task, error) {
+	ctx, span := otel.AddSpan(ctx, "business.replenishmenttaskbus.generate")
+	defer span.End()
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) ([]Task, error) {
+			if err := b.storer.LockPlanning(ctx); err != nil {
+				return nil, fmt.Errorf("generate: lock: %w", err)
+			}
+
+			planned, err := b.plan(ctx, req, now)
+			if err != nil {
+				return nil, fmt.Errorf("generate: %w", err)
+			}
+			if len(planned) == 0 {
+				return nil, fmt.Errorf("generate: %w", ErrNothingToReplenish)
+			}
+
+			tasks := make([]Task, 0, len(planned))
+			for _, p := range planned {
+				task := Task{
+					ID:             uuid.New(),
+					ProductID:      p.ProductID,
+					LotID:          p.LotID,
+					FromLocationID: p.FromLocationID,
+					ToLocationID:   p.ToLocationID,
+					Quantity:       p.Quantity,
+					Reason:         p.Reason,
+					Status:         StatusPending,
+					CreatedBy:      req.CreatedBy,
+					CreatedDate:    now,
+					UpdatedDate:    now,
+				}
+
+				if sid, ok := sqldb.GetScenarioFilter(ctx); ok {
+					task.ScenarioID = &sid
+				}
+
+				if err := b.storer.Create(ctx, task); err != nil {
+					return nil, fmt.Errorf("generate: create: %w", err)
+				}
+
+				evtData := ActionCreatedData(task)
+				if err := b.outbox.Emit(ctx, evtData); err != nil {
+					return nil, fmt.Errorf("emit cascade event: %w", err)
+				}
+				if err := b.delegate.Call(ctx, ActionCreatedData(task)); err != nil {
+					b.log.Error(ctx, "replenishmenttaskbus: delegate call failed", "action", ActionCreated, "err", err)
+				}
+
+				tasks = append(tasks, task)
+			}
+
+			return tasks, nil
+		})
+}
+
+// Query retrieves a list of tasks from the system.
+func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]Task, error) {
+	ctx, span := otel.AddSpan(ctx, "business.replenishmenttaskbus.query")
+	defer span.End()
+
+	tasks, err := b.storer.Query(ctx, filter, orderBy, page)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// Count returns the total number of tasks matching the filter.
+func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.replenishmenttaskbus.count")
+	defer span.End()
+
+	return b.storer.Count(ctx, filter)
+}
+
+// QueryByID retrieves a single task by its ID.
+func (b *Business) QueryByID(ctx context.Context, taskID uuid.UUID) (Task, error) {
+	ctx, span := otel.AddSpan(ctx, "business.replenishmenttaskbus.querybyid")
+	defer span.End()
+
+	task, err := b.storer.QueryByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return Task{}, err
+		}
+		return Task{}, fmt.Errorf("queryByID: taskID[%s]: %w", taskID, err)
+	}
+
+	return task, nil
+}
+
+// Claim assigns a pending task to a worker and starts it. Returns
+// ErrTaskClaimed when the task is not pending.
+func (b *Business) Claim(ctx context.Context, task Task, userID uuid.UUID, now time.Time) (Task, error) {
+	ctx, span := otel.AddSpan(ctx, "business.replenishmenttaskbus.claim")
+	defer span.End()
+
+	if task.Status != StatusPending {
+		return Task{}, fmt.Errorf("claim: %w", ErrTaskClaimed)
+	}
+
+	return b.update(ctx, task, ErrTaskClaimed, func(t *Task) {
+		t.Status = StatusInProgress
+		t.AssignedTo = &userID
+		t.AssignedAt = &now
+		t.UpdatedDate = now
+	})
+}
+
+// Complete records that quantity units were moved and moves them in
+// inventory: out of the reserve location and onto the pick face, with a
+// REPLENISH_OUT/REPLENISH_IN ledger pair, and between the lot's locations when
+// the task names a lot. quantity may fall short of the task when the reserve
+// bin held less than expected. Returns ErrTaskNotClaimed unless the task is in
+// progress.
+func (b *Business) Complete(ctx context.Context, task Task, userID uuid.UUID, quantity int, now time.Time) (Task, error) {
+	ctx, span := otel.AddSpan(ctx, "business.replenishmenttaskbus.complete")
+	defer span.End()
+
+	if task.Status != StatusInProgress {
+		return Task{}, fmt.Errorf("complete: %w", ErrTaskNotClaimed)
+	}
+	if quantity <= 0 || quantity > task.Quantity {
+		return Task{}, fmt.Errorf("complete: %w", ErrInvalidQuantity)
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Task, error) {
+			// Close the task first so a second completion fails on the
+			// status guard instead of moving the stock again.
+			completed, err := b.update(ctx, task, ErrTaskNotClaimed, func(t *Task) {
+				t.Status = StatusCompleted
+				t.QuantityMoved = quantity
+				t.CompletedBy = &userID
+				t.CompletedAt = &now
+				t.UpdatedDate = now
+			})
+			if err != nil {
+				return Task{}, fmt.Errorf("complete: %w", err)
+			}
+
+			if err := b.moveStock(ctx, task, userID, quantity, now); err != nil {
+				return Task{}, fmt.Errorf("complete: %w", err)
+			}
+
+			return completed, nil
+		})
+}
+
+// Cancel closes a task that will not be carried out; its quantity stops
+// counting against the reserve bin and toward the pick face. Returns
+// ErrTaskClosed when the task is already completed or cancelled.
+func (b *Business) Cancel(ctx context.Context, task Task, now time.Time) (Task, error) {
+	ctx, span := otel.AddSpan(ctx, "business.replenishmenttaskbus.cancel")
+	defer span.End()
+
+	if task.Status != StatusPending && task.Status != StatusInProgress {
+		return Task{}, fmt.Errorf("cancel: %w", ErrTaskClosed)
+	}
+
+	return b.update(ctx, task, ErrTaskClosed, func(t *Task) {
+		t.Status = StatusCancelled
+		t.UpdatedDate = now
+	})
+}
+
+// =============================================================================
+
+// plan loads the candidate faces and the reserve stock of their products and
+// runs the planner.
+func (b *Business) plan(ctx context.Context, req PlanRequest, now time.Time) ([]PlannedTask, error) {
+	faces, err := b.storer.QueryFaces(ctx, FaceFilter{
+		WarehouseID: req.WarehouseID,
+		ProductID:   req.ProductID,
+		LocationID:  req.LocationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("faces: %w", err)
+	}
+	if len(faces) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var productIDs []uuid.UUID
+	for _, f := range faces {
+		if !seen[f.ProductID] {
+			seen[f.ProductID] = true
+			productIDs = append(productIDs, f.ProductID)
+		}
+	}
+
+	stock, err := b.storer.QueryReserveStock(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("reserve stock: %w", err)
+	}
+
+	return PlanReplenishment(faces, stock, req, now), nil
+}
+
+// update applies fn to the task, stores it and emits the updated event. The
+// store only takes the write while the task still has the status it was read
+// with; when another transition got there first, update returns conflict.
+func (b *Business) update(ctx context.Context, task Task, conflict error, fn func(*Task)) (Task, error) {
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Task, error) {
+			before := task
+			fn(&task)
+
+			rows, err := b.storer.UpdateWithStatusGuard(ctx, task, before.Status)
+			if err != nil {
+				return Task{}, fmt.Errorf("update: %w", err)
+			}
+			if rows == 0 {
+				return Task{}, fmt.Errorf("update: %w", conflict)
+			}
+
+			evtData := ActionUpdatedData(before, task)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return Task{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionUpdatedData(before, task)); err != nil {
+				b.log.Error(ctx, "replenishmenttaskbus: delegate call failed", "action", ActionUpdated, "err", err)
+			}
+
+			return task, nil
+		})
+}
+
+// moveStock moves quantity of the task's product from its reserve location to
+// its pick face. The buses must already be bound to the caller's transaction.
+// A shortfall at the reserve location is returned wrapping
+// inventoryitembus.ErrInsufficientStock.
+func (b *Business) moveStock(ctx context.Context, task Task, userID uuid.UUID, quantity int, now time.Time) error {
+	refNum := task.ID.String()
+
+	if _, err := b.inventoryTransactionBus.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
+		ProductID:       task.ProductID,
+		LocationID:      task.FromLocationID,
+		UserID:          userID,
+		LotID:           task.LotID,
+		Quantity:        -quantity,
+		TransactionType: "REPLENISH_OUT",
+		ReferenceNumber: refNum,
+		TransactionDate: now,
+	}); err != nil {
+		return fmt.Errorf("create replenish_out transaction: %w", err)
+	}
+	if _, err := b.inventoryTransactionBus.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
+		ProductID:       task.ProductID,
+		LocationID:      task.ToLocationID,
+		UserID:          userID,
+		LotID:           task.LotID,
+		Quantity:        quantity,
+		TransactionType: "REPLENISH_IN",
+		ReferenceNumber: refNum,
+		TransactionDate: now,
+	}); err != nil {
+		return fmt.Errorf("create replenish_in transaction: %w", err)
+	}
+
+	if err := b.inventoryItemBus.DecrementQuantity(ctx, task.ProductID, task.FromLocationID, quantity); err != nil {
+		return fmt.Errorf("decrement reserve inventory: %w", err)
+	}
+	if err := b.inventoryItemBus.UpsertQuantity(ctx, task.ProductID, task.ToLocationID, quantity); err != nil {
+		return fmt.Errorf("upsert pick face inventory: %w", err)
+	}
+
+	if task.LotID == nil {
+		return nil
+	}
+
+	from, err := b.queryLotLocation(ctx, *task.LotID, task.FromLocationID)
+	if err != nil {
+		return fmt.Errorf("reserve lot location: %w", err)
+	}
+	if from == nil || from.Quantity < quantity {
+		return fmt.Errorf("reserve lot location: %w", inventoryitembus.ErrInsufficientStock)
+	}
+	left := from.Quantity - quantity
+	if _, err := b.lotLocationBus.Update(ctx, *from, lotlocationbus.UpdateLotLocation{Quantity: &left}); err != nil {
+		return fmt.Errorf("update reserve lot location: %w", err)
+	}
+
+	to, err := b.queryLotLocation(ctx, *task.LotID, task.ToLocationID)
+	if err != nil {
+		return fmt.Errorf("pick face lot location: %w", err)
+	}
+	if to == nil {
+		if _, err := b.lotLocationBus.Create(ctx, lotlocationbus.NewLotLocation{
+			LotID:      *task.LotID,
+			LocationID: task.ToLocationID,
+			Quantity:   quantity,
+		}); err != nil {
+			return fmt.Errorf("create pick face lot location: %w", err)
+		}
+		return nil
+	}
+	held := to.Quantity + quantity
+	if _, err := b.lotLocationBus.Update(ctx, *to, lotlocationbus.UpdateLotLocation{Quantity: &held}); err != nil {
+		return fmt.Errorf("update pick face lot location: %w", err)
+	}
+
+	return nil
+}
+
+// queryLotLocation returns the lot's record at a location, or nil when the
+// lot is not held there.
+func (b *Business) queryLotLocation(ctx context.Context, lotID, locationID uuid.UUID) (*lotlocationbus.LotLocation, error) {
+	lls, err := b.lotLocationBus.Query(ctx, lotlocationbus.QueryFilter{LotID: &lotID, LocationID: &locationID},
+		order.NewBy(lotlocationbus.OrderByID, order.ASC), page.MustParse("1", "1"))
+	if err != nil {
+		return nil, err
+	}
+	if len(lls) == 0 {
+		return nil, nil
+	}
+	return &lls[0], nil
+}
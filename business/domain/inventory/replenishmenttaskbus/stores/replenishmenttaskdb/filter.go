@@ -0,0 +1,52 @@
+package replenishmenttaskdb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
+)
+
+func applyFilter(filter replenishmenttaskbus.QueryFilter, data map[string]any, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["id"] = *filter.ID
+		wc = append(wc, "id = :id")
+	}
+
+	if filter.ProductID != nil {
+		data["product_id"] = *filter.ProductID
+		wc = append(wc, "product_id = :product_id")
+	}
+
+	if filter.FromLocationID != nil {
+		data["from_location_id"] = *filter.FromLocationID
+		wc = append(wc, "from_location_id = :from_location_id")
+	}
+
+	if filter.ToLocationID != nil {
+		data["to_location_id"] = *filter.ToLocationID
+		wc = append(wc, "to_location_id = :to_location_id")
+	}
+
+	if filter.Reason != nil {
+		data["reason"] = *filter.Reason
+		wc = append(wc, "reason = :reason")
+	}
+
+	if filter.Status != nil {
+		data["status"] = *filter.Status
+		wc = append(wc, "status = :status")
+	}
+
+	if filter.AssignedTo != nil {
+		data["assigned_to"] = *filter.AssignedTo
+		wc = append(wc, "assigned_to = :assigned_to")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
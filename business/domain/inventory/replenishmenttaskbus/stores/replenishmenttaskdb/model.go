@@ -0,0 +1,194 @@
+package replenishmenttaskdb
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
+)
+
+// task mirrors the inventory.replenishment_tasks DB row.
+type task struct {
+	ID             uuid.UUID     `db:"id"`
+	ProductID      uuid.UUID     `db:"product_id"`
+	LotID          uuid.NullUUID `db:"lot_id"`
+	FromLocationID uuid.UUID     `db:"from_location_id"`
+	ToLocationID   uuid.UUID     `db:"to_location_id"`
+	Quantity       int           `db:"quantity"`
+	QuantityMoved  int           `db:"quantity_moved"`
+	Reason         string        `db:"reason"`
+	Status         string        `db:"status"`
+	AssignedTo     uuid.NullUUID `db:"assigned_to"`
+	AssignedAt     sql.NullTime  `db:"assigned_at"`
+	CompletedBy    uuid.NullUUID `db:"completed_by"`
+	CompletedAt    sql.NullTime  `db:"completed_at"`
+	CreatedBy      uuid.UUID     `db:"created_by"`
+	CreatedDate    time.Time     `db:"created_date"`
+	UpdatedDate    time.Time     `db:"updated_date"`
+	ScenarioID     uuid.NullUUID `db:"scenario_id"`
+}
+
+func toDBTask(bus replenishmenttaskbus.Task) task {
+	return task{
+		ID:             bus.ID,
+		ProductID:      bus.ProductID,
+		LotID:          toNullUUID(bus.LotID),
+		FromLocationID: bus.FromLocationID,
+		ToLocationID:   bus.ToLocationID,
+		Quantity:       bus.Quantity,
+		QuantityMoved:  bus.QuantityMoved,
+		Reason:         bus.Reason,
+		Status:         bus.Status,
+		AssignedTo:     toNullUUID(bus.AssignedTo),
+		AssignedAt:     toNullTime(bus.AssignedAt),
+		CompletedBy:    toNullUUID(bus.CompletedBy),
+		CompletedAt:    toNullTime(bus.CompletedAt),
+		CreatedBy:      bus.CreatedBy,
+		CreatedDate:    bus.CreatedDate.UTC(),
+		UpdatedDate:    bus.UpdatedDate.UTC(),
+		ScenarioID:     toNullUUID(bus.ScenarioID),
+	}
+}
+
+func toBusTask(db task) replenishmenttaskbus.Task {
+	return replenishmenttaskbus.Task{
+		ID:             db.ID,
+		ProductID:      db.ProductID,
+		LotID:          fromNullUUID(db.LotID),
+		FromLocationID: db.FromLocationID,
+		ToLocationID:   db.ToLocationID,
+		Quantity:       db.Quantity,
+		QuantityMoved:  db.QuantityMoved,
+		Reason:         db.Reason,
+		Status:         db.Status,
+		AssignedTo:     fromNullUUID(db.AssignedTo),
+		AssignedAt:     fromNullTime(db.AssignedAt),
+		CompletedBy:    fromNullUUID(db.CompletedBy),
+		CompletedAt:    fromNullTime(db.CompletedAt),
+		CreatedBy:      db.CreatedBy,
+		CreatedDate:    db.CreatedDate.In(time.Local),
+		UpdatedDate:    db.UpdatedDate.In(time.Local),
+		ScenarioID:     fromNullUUID(db.ScenarioID),
+	}
+}
+
+func toBusTasks(dbs []task) []replenishmenttaskbus.Task {
+	tasks := make([]replenishmenttaskbus.Task, len(dbs))
+	for i, db := range dbs {
+		tasks[i] = toBusTask(db)
+	}
+	return tasks
+}
+
+// face is one row of the pick face query.
+type face struct {
+	ProductID     uuid.UUID `db:"product_id"`
+	LocationID    uuid.UUID `db:"location_id"`
+	WarehouseID   uuid.UUID `db:"warehouse_id"`
+	LocationCode  string    `db:"location_code"`
+	Quantity      int       `db:"quantity"`
+	Minimum       int       `db:"minimum_stock"`
+	Maximum       int       `db:"maximum_stock"`
+	AvgDailyUsage int       `db:"avg_daily_usage"`
+	OpenDemand    int       `db:"open_demand"`
+	Inbound       int       `db:"inbound"`
+}
+
+func toBusFaces(dbs []face) []replenishmenttaskbus.Face {
+	faces := make([]replenishmenttaskbus.Face, len(dbs))
+	for i, db := range dbs {
+		faces[i] = replenishmenttaskbus.Face{
+			ProductID:     db.ProductID,
+			LocationID:    db.LocationID,
+			WarehouseID:   db.WarehouseID,
+			LocationCode:  db.LocationCode,
+			Quantity:      db.Quantity,
+			Minimum:       db.Minimum,
+			Maximum:       db.Maximum,
+			AvgDailyUsage: db.AvgDailyUsage,
+			OpenDemand:    db.OpenDemand,
+			Inbound:       db.Inbound,
+		}
+	}
+	return faces
+}
+
+// reserveStock is one row of the reserve stock query.
+type reserveStock struct {
+	ProductID    uuid.UUID `db:"product_id"`
+	LocationID   uuid.UUID `db:"location_id"`
+	WarehouseID  uuid.UUID `db:"warehouse_id"`
+	LocationCode string    `db:"location_code"`
+	Available    int       `db:"available"`
+}
+
+// reserveLot is one row of the reserve lot query.
+type reserveLot struct {
+	ProductID      uuid.UUID `db:"product_id"`
+	LocationID     uuid.UUID `db:"location_id"`
+	LotID          uuid.UUID `db:"lot_id"`
+	ExpirationDate time.Time `db:"expiration_date"`
+	QualityStatus  string    `db:"quality_status"`
+	Quantity       int       `db:"quantity"`
+}
+
+// toBusReserveStock attaches each lot to the stock of its product and
+// location.
+func toBusReserveStock(dbs []reserveStock, lots []reserveLot) []replenishmenttaskbus.ReserveStock {
+	type key struct{ product, location uuid.UUID }
+
+	byKey := make(map[key][]replenishmenttaskbus.Lot)
+	for _, l := range lots {
+		k := key{l.ProductID, l.LocationID}
+		byKey[k] = append(byKey[k], replenishmenttaskbus.Lot{
+			ID:             l.LotID,
+			ExpirationDate: l.ExpirationDate.In(time.Local),
+			QualityStatus:  l.QualityStatus,
+			Quantity:       l.Quantity,
+		})
+	}
+
+	stock := make([]replenishmenttaskbus.ReserveStock, len(dbs))
+	for i, db := range dbs {
+		stock[i] = replenishmenttaskbus.ReserveStock{
+			ProductID:    db.ProductID,
+			LocationID:   db.LocationID,
+			WarehouseID:  db.WarehouseID,
+			LocationCode: db.LocationCode,
+			Available:    db.Available,
+			Lots:         byKey[key{db.ProductID, db.LocationID}],
+		}
+	}
+	return stock
+}
+
+func toNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func fromNullUUID(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	v := id.UUID
+	return &v
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time.In(time.Local)
+	return &v
+}
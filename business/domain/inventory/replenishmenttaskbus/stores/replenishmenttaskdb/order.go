@@ -0,0 +1,26 @@
+package replenishmenttaskdb
+
+import (
+	"fmt"
+
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+)
+
+var orderByFields = map[string]string{
+	replenishmenttaskbus.OrderByID:          "id",
+	replenishmenttaskbus.OrderByProductID:   "product_id",
+	replenishmenttaskbus.OrderByReason:      "reason",
+	replenishmenttaskbus.OrderByStatus:      "status",
+	replenishmenttaskbus.OrderByCreatedDate: "created_date",
+	replenishmenttaskbus.OrderByUpdatedDate: "updated_date",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
@@ -0,0 +1,336 @@
+// Package replenishmenttaskdb contains replenishment task related CRUD
+// functionality.
+package replenishmenttaskdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// scopeColumns places a replenishment task in the data scope of either end.
+var scopeColumns = sqldb.ScopeColumns{Locations: []string{"from_location_id", "to_location_id"}}
+
+// Store manages the set of APIs for replenishment task database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// NewWithTx constructs a new Store value replacing the sqlx.DB
+// value with a sqlx.Tx value that is currently inside a transaction.
+func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (replenishmenttaskbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		log: s.log,
+		db:  ec,
+	}, nil
+}
+
+// Create inserts a new task into the database.
+func (s *Store) Create(ctx context.Context, t replenishmenttaskbus.Task) error {
+	const q = `
+	INSERT INTO inventory.replenishment_tasks
+		(id, product_id, lot_id, from_location_id, to_location_id, quantity, quantity_moved, reason, status,
+		 assigned_to, assigned_at, completed_by, completed_at, created_by, created_date, updated_date, scenario_id)
+	VALUES
+		(:id, :product_id, :lot_id, :from_location_id, :to_location_id, :quantity, :quantity_moved, :reason, :status,
+		 :assigned_to, :assigned_at, :completed_by, :completed_at, :created_by, :created_date, :updated_date, :scenario_id)
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBTask(t)); err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return fmt.Errorf("namedexeccontext: %w", replenishmenttaskbus.ErrForeignKeyViolation)
+		}
+		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+			return fmt.Errorf("namedexeccontext: %w", replenishmenttaskbus.ErrUniqueEntry)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateWithStatusGuard modifies a task in the database only while its
+// status is still expectedStatus, returning the rows affected.
+func (s *Store) UpdateWithStatusGuard(ctx context.Context, t replenishmenttaskbus.Task, expectedStatus string) (int64, error) {
+	const q = `
+	UPDATE inventory.replenishment_tasks
+	SET
+		quantity_moved = :quantity_moved,
+		status         = :status,
+		assigned_to    = :assigned_to,
+		assigned_at    = :assigned_at,
+		completed_by   = :completed_by,
+		completed_at   = :completed_at,
+		updated_date   = :updated_date
+	WHERE
+		id = :id AND status = :expected_status
+	`
+
+	data := struct {
+		task
+		ExpectedStatus string `db:"expected_status"`
+	}{
+		task:           toDBTask(t),
+		ExpectedStatus: expectedStatus,
+	}
+
+	rows, err := sqldb.NamedExecContextWithCount(ctx, s.log, s.db, q, data)
+	if err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return 0, fmt.Errorf("namedexeccontextwithcount: %w", replenishmenttaskbus.ErrForeignKeyViolation)
+		}
+		return 0, fmt.Errorf("namedexeccontextwithcount: %w", err)
+	}
+
+	return rows, nil
+}
+
+// Query retrieves a list of tasks from the database.
+func (s *Store) Query(ctx context.Context, filter replenishmenttaskbus.QueryFilter, orderBy order.By, page page.Page) ([]replenishmenttaskbus.Task, error) {
+	data := map[string]any{
+		"offset":        (page.Number() - 1) * page.RowsPerPage(),
+		"rows_per_page": page.RowsPerPage(),
+	}
+
+	const q = `
+	SELECT
+		id, product_id, lot_id, from_location_id, to_location_id, quantity, quantity_moved, reason, status,
+		assigned_to, assigned_at, completed_by, completed_at, created_by, created_date, updated_date, scenario_id
+	FROM
+		inventory.replenishment_tasks
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbTasks []task
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbTasks); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusTasks(dbTasks), nil
+}
+
+// Count returns the total number of tasks matching the filter.
+func (s *Store) Count(ctx context.Context, filter replenishmenttaskbus.QueryFilter) (int, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		COUNT(1) AS count
+	FROM
+		inventory.replenishment_tasks
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID retrieves a single task by its ID.
+func (s *Store) QueryByID(ctx context.Context, taskID uuid.UUID) (replenishmenttaskbus.Task, error) {
+	data := map[string]any{
+		"id": taskID.String(),
+	}
+
+	const q = `
+	SELECT
+		id, product_id, lot_id, from_location_id, to_location_id, quantity, quantity_moved, reason, status,
+		assigned_to, assigned_at, completed_by, completed_at, created_by, created_date, updated_date, scenario_id
+	FROM
+		inventory.replenishment_tasks
+	WHERE
+		id = :id
+	`
+
+	buf := bytes.NewBufferString(q)
+	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
+
+	var dbTask task
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &dbTask); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return replenishmenttaskbus.Task{}, replenishmenttaskbus.ErrNotFound
+		}
+		return replenishmenttaskbus.Task{}, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	return toBusTask(dbTask), nil
+}
+
+// QueryFaces retrieves the stock held at forward pick locations with its
+// replenishment settings, the quantity still to pick there on open pick tasks
+// and the quantity already on its way on open replenishment tasks.
+func (s *Store) QueryFaces(ctx context.Context, filter replenishmenttaskbus.FaceFilter) ([]replenishmenttaskbus.Face, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		ii.product_id, ii.location_id, il.warehouse_id, COALESCE(il.location_code, '') AS location_code,
+		ii.quantity, GREATEST(ii.minimum_stock, ii.reorder_point) AS minimum_stock, ii.maximum_stock, ii.avg_daily_usage,
+		COALESCE((
+			SELECT SUM(pt.quantity_to_pick - pt.quantity_picked)
+			FROM inventory.pick_tasks pt
+			WHERE pt.product_id = ii.product_id AND pt.location_id = ii.location_id
+				AND pt.status IN ('pending', 'in_progress')
+		), 0) AS open_demand,
+		COALESCE((
+			SELECT SUM(rt.quantity)
+			FROM inventory.replenishment_tasks rt
+			WHERE rt.product_id = ii.product_id AND rt.to_location_id = ii.location_id
+				AND rt.status IN ('pending', 'in_progress')
+		), 0) AS inbound
+	FROM inventory.inventory_items ii
+	JOIN inventory.inventory_locations il ON il.id = ii.location_id
+	WHERE il.is_pick_location
+		AND NOT il.is_transit`
+
+	buf := bytes.NewBufferString(q)
+	if filter.WarehouseID != nil {
+		data["warehouse_id"] = *filter.WarehouseID
+		buf.WriteString(" AND il.warehouse_id = :warehouse_id")
+	}
+	if filter.ProductID != nil {
+		data["product_id"] = *filter.ProductID
+		buf.WriteString(" AND ii.product_id = :product_id")
+	}
+	if filter.LocationID != nil {
+		data["location_id"] = *filter.LocationID
+		buf.WriteString(" AND ii.location_id = :location_id")
+	}
+	if id, ok := sqldb.GetScenarioFilter(ctx); ok {
+		data["scenario_id"] = id
+		buf.WriteString(" AND (ii.scenario_id IS NULL OR ii.scenario_id = :scenario_id)")
+	}
+	buf.WriteString(" ORDER BY location_code, ii.location_id, ii.product_id")
+
+	var dbFaces []face
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbFaces); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusFaces(dbFaces), nil
+}
+
+// QueryReserveStock retrieves the products' stock at reserve locations, net
+// of reserved and allocated quantity and of open replenishment tasks taking
+// from it, with the lots that make it up.
+func (s *Store) QueryReserveStock(ctx context.Context, productIDs []uuid.UUID) ([]replenishmenttaskbus.ReserveStock, error) {
+	data := map[string]any{
+		"product_ids": productIDs,
+	}
+
+	scenarioClause := ""
+	if id, ok := sqldb.GetScenarioFilter(ctx); ok {
+		data["scenario_id"] = id
+		scenarioClause = " AND (ii.scenario_id IS NULL OR ii.scenario_id = :scenario_id)"
+	}
+
+	q := `
+	SELECT
+		ii.product_id, ii.location_id, il.warehouse_id, COALESCE(il.location_code, '') AS location_code,
+		ii.quantity - ii.reserved_quantity - ii.allocated_quantity - COALESCE((
+			SELECT SUM(rt.quantity)
+			FROM inventory.replenishment_tasks rt
+			WHERE rt.product_id = ii.product_id AND rt.from_location_id = ii.location_id
+				AND rt.status IN ('pending', 'in_progress')
+		), 0) AS available
+	FROM inventory.inventory_items ii
+	JOIN inventory.inventory_locations il ON il.id = ii.location_id
+	WHERE il.is_reserve_location
+		AND NOT il.is_pick_location
+		AND NOT il.is_transit
+		AND ii.product_id = ANY(:product_ids)` + scenarioClause
+
+	var dbStock []reserveStock
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbStock); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	lotScenarioClause := ""
+	if _, ok := data["scenario_id"]; ok {
+		lotScenarioClause = " AND (ll.scenario_id IS NULL OR ll.scenario_id = :scenario_id)"
+	}
+
+	lq := `
+	SELECT
+		sp.product_id, ll.location_id, ll.lot_id, lt.expiration_date, lt.quality_status,
+		ll.quantity::int - COALESCE((
+			SELECT SUM(rt.quantity)
+			FROM inventory.replenishment_tasks rt
+			WHERE rt.lot_id = ll.lot_id AND rt.from_location_id = ll.location_id
+				AND rt.status IN ('pending', 'in_progress')
+		), 0) AS quantity
+	FROM inventory.lot_locations ll
+	JOIN inventory.lot_trackings lt ON lt.id = ll.lot_id
+	JOIN procurement.supplier_products sp ON sp.id = lt.supplier_product_id
+	JOIN inventory.inventory_locations il ON il.id = ll.location_id
+	WHERE il.is_reserve_location
+		AND NOT il.is_pick_location
+		AND NOT il.is_transit
+		AND ll.quantity > 0
+		AND sp.product_id = ANY(:product_ids)` + lotScenarioClause + `
+	ORDER BY lt.expiration_date, ll.lot_id`
+
+	var dbLots []reserveLot
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, lq, data, &dbLots); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: lots: %w", err)
+	}
+
+	return toBusReserveStock(dbStock, dbLots), nil
+}
+
+// LockPlanning takes a transaction-scoped advisory lock that serializes
+// replenishment planning, so two runs cannot fill the same pick face.
+func (s *Store) LockPlanning(ctx context.Context) error {
+	const q = `SELECT pg_advisory_xact_lock(hashtext('inventory.replenishment_tasks'))`
+
+	if err := sqldb.ExecContext(ctx, s.log, s.db, q); err != nil {
+		return fmt.Errorf("execcontext: %w", err)
+	}
+
+	return nil
+}
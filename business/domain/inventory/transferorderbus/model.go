@@ -11,39 +11,59 @@ import (
 // Without these tags, Go defaults to PascalCase keys, but workflow action handlers
 // expect snake_case keys to match API conventions.
 
+// TransferOrder is the header of a stock transfer between two locations. The
+// goods being moved are its lines; ProductID and Quantity mirror the only line
+// of a single-line transfer (the original shape) and are uuid.Nil and the total
+// ordered quantity for a multi-line transfer. TransitLocationID is the virtual
+// location holding the stock once the transfer has shipped.
 type TransferOrder struct {
-	TransferID      uuid.UUID  `json:"transfer_id"` // TODO: these should be id
-	TransferNumber  *string    `json:"transfer_number,omitempty"`
-	ProductID       uuid.UUID  `json:"product_id"`
-	FromLocationID  uuid.UUID  `json:"from_location_id"`
-	ToLocationID    uuid.UUID  `json:"to_location_id"`
-	RequestedByID   uuid.UUID  `json:"requested_by_id"`
-	ApprovedByID    *uuid.UUID `json:"approved_by_id"`
-	RejectedByID    *uuid.UUID `json:"rejected_by_id"`
-	ApprovalReason  string     `json:"approval_reason"`
-	RejectionReason string     `json:"rejection_reason"`
-	ClaimedByID     *uuid.UUID `json:"claimed_by_id"`
-	ClaimedAt       *time.Time `json:"claimed_at"`
-	CompletedByID   *uuid.UUID `json:"completed_by_id"`
-	CompletedAt     *time.Time `json:"completed_at"`
-	Quantity        int        `json:"quantity"`
-	Status          string     `json:"status"`
-	TransferDate    time.Time  `json:"transfer_date"`
-	CreatedDate     time.Time  `json:"created_date"`
-	UpdatedDate     time.Time  `json:"updated_date"`
-	ScenarioID      *uuid.UUID `json:"scenario_id,omitempty"`
+	TransferID        uuid.UUID  `json:"transfer_id"` // TODO: these should be id
+	TransferNumber    *string    `json:"transfer_number,omitempty"`
+	ProductID         uuid.UUID  `json:"product_id"`
+	FromLocationID    uuid.UUID  `json:"from_location_id"`
+	ToLocationID      uuid.UUID  `json:"to_location_id"`
+	TransitLocationID *uuid.UUID `json:"transit_location_id,omitempty"`
+	RequestedByID     uuid.UUID  `json:"requested_by_id"`
+	ApprovedByID      *uuid.UUID `json:"approved_by_id"`
+	RejectedByID      *uuid.UUID `json:"rejected_by_id"`
+	ApprovalReason    string     `json:"approval_reason"`
+	RejectionReason   string     `json:"rejection_reason"`
+	ClaimedByID       *uuid.UUID `json:"claimed_by_id"`
+	ClaimedAt         *time.Time `json:"claimed_at"`
+	CompletedByID     *uuid.UUID `json:"completed_by_id"`
+	CompletedAt       *time.Time `json:"completed_at"`
+	Quantity          int        `json:"quantity"`
+	Status            string     `json:"status"`
+	TransferDate      time.Time  `json:"transfer_date"`
+	CreatedDate       time.Time  `json:"created_date"`
+	UpdatedDate       time.Time  `json:"updated_date"`
+	ScenarioID        *uuid.UUID `json:"scenario_id,omitempty"`
+}
+
+// InTransitLocationID returns the location a shipped transfer's stock is held
+// in: its transit location, or the source location for a transfer claimed
+// before transit locations existed, whose stock never left the source.
+func (to TransferOrder) InTransitLocationID() uuid.UUID {
+	if to.TransitLocationID != nil {
+		return *to.TransitLocationID
+	}
+	return to.FromLocationID
 }
 
+// NewTransferOrder is what we require to create a transfer. Lines may be
+// given directly; when they are empty the transfer is a single line built from
+// ProductID and Quantity.
 type NewTransferOrder struct {
-	TransferNumber *string    `json:"transfer_number,omitempty"`
-	ProductID      uuid.UUID  `json:"product_id"`
-	FromLocationID uuid.UUID  `json:"from_location_id"`
-	ToLocationID   uuid.UUID  `json:"to_location_id"`
-	RequestedByID  uuid.UUID  `json:"requested_by_id"`
-	ApprovedByID   *uuid.UUID `json:"approved_by_id"`
-	Quantity       int        `json:"quantity"`
-	Status         string     `json:"status"`
-	TransferDate   time.Time  `json:"transfer_date"`
+	TransferNumber *string                `json:"transfer_number,omitempty"`
+	ProductID      uuid.UUID              `json:"product_id"`
+	FromLocationID uuid.UUID              `json:"from_location_id"`
+	ToLocationID   uuid.UUID              `json:"to_location_id"`
+	RequestedByID  uuid.UUID              `json:"requested_by_id"`
+	ApprovedByID   *uuid.UUID             `json:"approved_by_id"`
+	Quantity       int                    `json:"quantity"`
+	Status         string                 `json:"status"`
+	TransferDate   time.Time              `json:"transfer_date"`
+	Lines          []NewTransferOrderLine `json:"lines,omitempty"`
 }
 
 type UpdateTransferOrder struct {
@@ -64,3 +84,68 @@ type UpdateTransferOrder struct {
 	Status          *string    `json:"status,omitempty"`
 	TransferDate    *time.Time `json:"transfer_date,omitempty"`
 }
+
+// =============================================================================
+
+// TransferOrderLine is one product moving on a transfer. QuantityShipped is
+// what left the source when the transfer was claimed, QuantityReceived what
+// has arrived at the destination so far, and DiscrepancyQuantity what was
+// written off as lost in transit when the transfer was closed short.
+type TransferOrderLine struct {
+	ID                  uuid.UUID `json:"id"`
+	TransferID          uuid.UUID `json:"transfer_id"`
+	LineNumber          int       `json:"line_number"`
+	ProductID           uuid.UUID `json:"product_id"`
+	Quantity            int       `json:"quantity"`
+	QuantityShipped     int       `json:"quantity_shipped"`
+	QuantityReceived    int       `json:"quantity_received"`
+	DiscrepancyQuantity int       `json:"discrepancy_quantity"`
+	DiscrepancyReason   string    `json:"discrepancy_reason"`
+	CreatedDate         time.Time `json:"created_date"`
+	UpdatedDate         time.Time `json:"updated_date"`
+}
+
+// Outstanding returns the shipped quantity that has neither been received nor
+// written off.
+func (l TransferOrderLine) Outstanding() int {
+	return l.QuantityShipped - l.QuantityReceived - l.DiscrepancyQuantity
+}
+
+// NewTransferOrderLine is one line of a NewTransferOrder. Line numbers are
+// assigned in order.
+type NewTransferOrderLine struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Quantity  int       `json:"quantity"`
+}
+
+// Receipt records goods arriving at the destination of a shipped transfer.
+// Lines not listed receive nothing. When Close is set, whatever is still
+// outstanding after the receipt is written off as a discrepancy and the
+// transfer completes; otherwise a receipt that leaves stock outstanding moves
+// the transfer to partially_received.
+type Receipt struct {
+	ReceivedBy uuid.UUID
+	Lines      []ReceiptLine
+	Close      bool
+	Reason     string
+}
+
+// ReceiptLine is the quantity of one transfer line received. Reason explains a
+// shortfall on this line when the receipt closes the transfer.
+type ReceiptLine struct {
+	LineID   uuid.UUID
+	Quantity int
+	Reason   string
+}
+
+// Movement is the stock a Claim, Receive or Execute moved for one line. For a
+// Claim Quantity left the source for the transit location; for a receipt it
+// left the transit location for the destination, and Discrepancy is the
+// quantity written off in transit. The caller applies movements to inventory
+// in the same transaction.
+type Movement struct {
+	LineID      uuid.UUID
+	ProductID   uuid.UUID
+	Quantity    int
+	Discrepancy int
+}
@@ -9,26 +9,27 @@ import (
 )
 
 type transferOrder struct {
-	TransferID      uuid.UUID      `db:"id"`
-	TransferNumber  sql.NullString `db:"transfer_number"`
-	ProductID       uuid.UUID      `db:"product_id"`
-	FromLocationID  uuid.UUID      `db:"from_location_id"`
-	ToLocationID    uuid.UUID      `db:"to_location_id"`
-	RequestedByID   uuid.UUID      `db:"requested_by"`
-	ApprovedByID    uuid.NullUUID  `db:"approved_by"`
-	RejectedByID    uuid.NullUUID  `db:"rejected_by_id"`
-	ApprovalReason  sql.NullString `db:"approval_reason"`
-	RejectionReason sql.NullString `db:"rejection_reason"`
-	ClaimedByID     uuid.NullUUID  `db:"claimed_by" protected:"true"`
-	ClaimedAt       sql.NullTime   `db:"claimed_at"`
-	CompletedByID   uuid.NullUUID  `db:"completed_by" protected:"true"`
-	CompletedAt     sql.NullTime   `db:"completed_at"`
-	Quantity        int            `db:"quantity" protected:"true"`
-	Status          string         `db:"status"`
-	TransferDate    time.Time      `db:"transfer_date"`
-	CreatedDate     time.Time      `db:"created_date"`
-	UpdatedDate     time.Time      `db:"updated_date"`
-	ScenarioID      *uuid.UUID     `db:"scenario_id"`
+	TransferID        uuid.UUID      `db:"id"`
+	TransferNumber    sql.NullString `db:"transfer_number"`
+	ProductID         uuid.NullUUID  `db:"product_id"`
+	FromLocationID    uuid.UUID      `db:"from_location_id"`
+	ToLocationID      uuid.UUID      `db:"to_location_id"`
+	TransitLocationID uuid.NullUUID  `db:"transit_location_id" protected:"true"`
+	RequestedByID     uuid.UUID      `db:"requested_by"`
+	ApprovedByID      uuid.NullUUID  `db:"approved_by"`
+	RejectedByID      uuid.NullUUID  `db:"rejected_by_id"`
+	ApprovalReason    sql.NullString `db:"approval_reason"`
+	RejectionReason   sql.NullString `db:"rejection_reason"`
+	ClaimedByID       uuid.NullUUID  `db:"claimed_by" protected:"true"`
+	ClaimedAt         sql.NullTime   `db:"claimed_at"`
+	CompletedByID     uuid.NullUUID  `db:"completed_by" protected:"true"`
+	CompletedAt       sql.NullTime   `db:"completed_at"`
+	Quantity          int            `db:"quantity" protected:"true"`
+	Status            string         `db:"status"`
+	TransferDate      time.Time      `db:"transfer_date"`
+	CreatedDate       time.Time      `db:"created_date"`
+	UpdatedDate       time.Time      `db:"updated_date"`
+	ScenarioID        *uuid.UUID     `db:"scenario_id"`
 }
 
 func toBusTransferOrder(db transferOrder) transferorderbus.TransferOrder {
@@ -41,7 +42,7 @@ func toBusTransferOrder(db transferOrder) transferorderbus.TransferOrder {
 	to := transferorderbus.TransferOrder{
 		TransferID:     db.TransferID,
 		TransferNumber: transferNumber,
-		ProductID:      db.ProductID,
+		ProductID:      db.ProductID.UUID,
 		FromLocationID: db.FromLocationID,
 		ToLocationID:   db.ToLocationID,
 		RequestedByID:  db.RequestedByID,
@@ -61,6 +62,10 @@ func toBusTransferOrder(db transferOrder) transferorderbus.TransferOrder {
 		to.RejectionReason = db.RejectionReason.String
 	}
 
+	if db.TransitLocationID.Valid {
+		to.TransitLocationID = &db.TransitLocationID.UUID
+	}
+
 	if db.ApprovedByID.Valid {
 		to.ApprovedByID = &db.ApprovedByID.UUID
 	}
@@ -103,7 +108,7 @@ func toDBTransferOrder(bus transferorderbus.TransferOrder) transferOrder {
 	db := transferOrder{
 		TransferID:     bus.TransferID,
 		TransferNumber: transferNumber,
-		ProductID:      bus.ProductID,
+		ProductID:      uuid.NullUUID{UUID: bus.ProductID, Valid: bus.ProductID != uuid.Nil},
 		FromLocationID: bus.FromLocationID,
 		ToLocationID:   bus.ToLocationID,
 		RequestedByID:  bus.RequestedByID,
@@ -123,6 +128,10 @@ func toDBTransferOrder(bus transferorderbus.TransferOrder) transferOrder {
 		db.RejectionReason = sql.NullString{String: bus.RejectionReason, Valid: true}
 	}
 
+	if bus.TransitLocationID != nil {
+		db.TransitLocationID = uuid.NullUUID{UUID: *bus.TransitLocationID, Valid: true}
+	}
+
 	if bus.ApprovedByID != nil {
 		db.ApprovedByID = uuid.NullUUID{UUID: *bus.ApprovedByID, Valid: true}
 	}
@@ -145,3 +154,59 @@ func toDBTransferOrder(bus transferorderbus.TransferOrder) transferOrder {
 
 	return db
 }
+
+// =============================================================================
+
+type transferOrderLine struct {
+	ID                  uuid.UUID      `db:"id"`
+	TransferID          uuid.UUID      `db:"transfer_id"`
+	LineNumber          int            `db:"line_number"`
+	ProductID           uuid.UUID      `db:"product_id"`
+	Quantity            int            `db:"quantity"`
+	QuantityShipped     int            `db:"quantity_shipped"`
+	QuantityReceived    int            `db:"quantity_received"`
+	DiscrepancyQuantity int            `db:"discrepancy_quantity"`
+	DiscrepancyReason   sql.NullString `db:"discrepancy_reason"`
+	CreatedDate         time.Time      `db:"created_date"`
+	UpdatedDate         time.Time      `db:"updated_date"`
+}
+
+func toDBTransferOrderLine(bus transferorderbus.TransferOrderLine) transferOrderLine {
+	return transferOrderLine{
+		ID:                  bus.ID,
+		TransferID:          bus.TransferID,
+		LineNumber:          bus.LineNumber,
+		ProductID:           bus.ProductID,
+		Quantity:            bus.Quantity,
+		QuantityShipped:     bus.QuantityShipped,
+		QuantityReceived:    bus.QuantityReceived,
+		DiscrepancyQuantity: bus.DiscrepancyQuantity,
+		DiscrepancyReason:   sql.NullString{String: bus.DiscrepancyReason, Valid: bus.DiscrepancyReason != ""},
+		CreatedDate:         bus.CreatedDate,
+		UpdatedDate:         bus.UpdatedDate,
+	}
+}
+
+func toBusTransferOrderLine(db transferOrderLine) transferorderbus.TransferOrderLine {
+	return transferorderbus.TransferOrderLine{
+		ID:                  db.ID,
+		TransferID:          db.TransferID,
+		LineNumber:          db.LineNumber,
+		ProductID:           db.ProductID,
+		Quantity:            db.Quantity,
+		QuantityShipped:     db.QuantityShipped,
+		QuantityReceived:    db.QuantityReceived,
+		DiscrepancyQuantity: db.DiscrepancyQuantity,
+		DiscrepancyReason:   db.DiscrepancyReason.String,
+		CreatedDate:         db.CreatedDate,
+		UpdatedDate:         db.UpdatedDate,
+	}
+}
+
+func toBusTransferOrderLines(dbs []transferOrderLine) []transferorderbus.TransferOrderLine {
+	lines := make([]transferorderbus.TransferOrderLine, len(dbs))
+	for i, db := range dbs {
+		lines[i] = toBusTransferOrderLine(db)
+	}
+	return lines
+}
@@ -15,6 +15,9 @@ import (
 	"github.com/timmaaaz/ichor/foundation/logger"
 )
 
+// scopeColumns places a transfer order in the data scope of either end.
+var scopeColumns = sqldb.ScopeColumns{Locations: []string{"from_location_id", "to_location_id"}}
+
 type Store struct {
 	log *logger.Logger
 	db  sqlx.ExtContext
@@ -40,14 +43,18 @@ func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (transferorderbus.Storer, e
 }
 
 func (s *Store) Create(ctx context.Context, transferOrder transferorderbus.TransferOrder) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, scopeColumns, map[string]uuid.UUID{"from_location_id": transferOrder.FromLocationID, "to_location_id": transferOrder.ToLocationID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
 	INSERT INTO inventory.transfer_orders (
-	    id, transfer_number, product_id, from_location_id, to_location_id, requested_by,
+	    id, transfer_number, product_id, from_location_id, to_location_id, transit_location_id, requested_by,
 		approved_by, rejected_by_id, approval_reason, rejection_reason,
 		claimed_by, claimed_at, completed_by, completed_at,
 		quantity, status, transfer_date, created_date, updated_date, scenario_id
     ) VALUES (
-        :id, :transfer_number, :product_id, :from_location_id, :to_location_id, :requested_by,
+        :id, :transfer_number, :product_id, :from_location_id, :to_location_id, :transit_location_id, :requested_by,
         :approved_by, :rejected_by_id, :approval_reason, :rejection_reason,
         :claimed_by, :claimed_at, :completed_by, :completed_at,
         :quantity, :status, :transfer_date, :created_date, :updated_date, :scenario_id
@@ -69,6 +76,10 @@ func (s *Store) Create(ctx context.Context, transferOrder transferorderbus.Trans
 }
 
 func (s *Store) Update(ctx context.Context, transferOrder transferorderbus.TransferOrder) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, scopeColumns, map[string]uuid.UUID{"from_location_id": transferOrder.FromLocationID, "to_location_id": transferOrder.ToLocationID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
     UPDATE
         inventory.transfer_orders
@@ -77,6 +88,7 @@ func (s *Store) Update(ctx context.Context, transferOrder transferorderbus.Trans
         product_id = :product_id,
 		from_location_id = :from_location_id,
 		to_location_id = :to_location_id,
+		transit_location_id = :transit_location_id,
         requested_by = :requested_by,
 		approved_by = :approved_by,
 		rejected_by_id = :rejected_by_id,
@@ -120,6 +132,7 @@ func (s *Store) UpdateWithStatusGuard(ctx context.Context, to transferorderbus.T
         product_id = :product_id,
 		from_location_id = :from_location_id,
 		to_location_id = :to_location_id,
+		transit_location_id = :transit_location_id,
         requested_by = :requested_by,
 		approved_by = :approved_by,
 		rejected_by_id = :rejected_by_id,
@@ -180,7 +193,7 @@ func (s *Store) Query(ctx context.Context, filter transferorderbus.QueryFilter,
 
 	const q = `
 	SELECT
-		id, transfer_number, product_id, from_location_id, to_location_id, requested_by, approved_by,
+		id, transfer_number, product_id, from_location_id, to_location_id, transit_location_id, requested_by, approved_by,
 		rejected_by_id, approval_reason, rejection_reason,
 		claimed_by, claimed_at, completed_by, completed_at,
 		quantity, status, transfer_date, created_date, updated_date, scenario_id
@@ -191,6 +204,7 @@ func (s *Store) Query(ctx context.Context, filter transferorderbus.QueryFilter,
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
 
 	orderByClause, err := orderByClause(orderBy)
 	if err != nil {
@@ -221,6 +235,7 @@ func (s *Store) Count(ctx context.Context, filter transferorderbus.QueryFilter)
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
 
 	var count struct {
 		Count int `db:"count"`
@@ -239,7 +254,7 @@ func (s *Store) QueryByID(ctx context.Context, transferOrderID uuid.UUID) (trans
 
 	const q = `
     SELECT
-        id, transfer_number, product_id, from_location_id, to_location_id, requested_by, approved_by,
+        id, transfer_number, product_id, from_location_id, to_location_id, transit_location_id, requested_by, approved_by,
         rejected_by_id, approval_reason, rejection_reason,
         claimed_by, claimed_at, completed_by, completed_at,
         quantity, status, transfer_date, created_date, updated_date, scenario_id
@@ -251,6 +266,7 @@ func (s *Store) QueryByID(ctx context.Context, transferOrderID uuid.UUID) (trans
 
 	buf := bytes.NewBufferString(q)
 	sqldb.ApplyScenarioFilter(ctx, buf, data)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
 
 	var dbTO transferOrder
 	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &dbTO); err != nil {
@@ -262,3 +278,153 @@ func (s *Store) QueryByID(ctx context.Context, transferOrderID uuid.UUID) (trans
 
 	return toBusTransferOrder(dbTO), nil
 }
+
+// =============================================================================
+// Lines
+
+func (s *Store) CreateLines(ctx context.Context, lines []transferorderbus.TransferOrderLine) error {
+	const q = `
+	INSERT INTO inventory.transfer_order_lines (
+		id, transfer_id, line_number, product_id, quantity, quantity_shipped, quantity_received,
+		discrepancy_quantity, discrepancy_reason, created_date, updated_date
+	) VALUES (
+		:id, :transfer_id, :line_number, :product_id, :quantity, :quantity_shipped, :quantity_received,
+		:discrepancy_quantity, :discrepancy_reason, :created_date, :updated_date
+	)`
+
+	for _, line := range lines {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBTransferOrderLine(line)); err != nil {
+			if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+				return fmt.Errorf("namedexeccontext: line: %w", transferorderbus.ErrForeignKeyViolation)
+			}
+			if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+				return fmt.Errorf("namedexeccontext: line: %w", transferorderbus.ErrUniqueEntry)
+			}
+			return fmt.Errorf("namedexeccontext: line: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) UpdateLineWithGuard(ctx context.Context, line transferorderbus.TransferOrderLine, before transferorderbus.TransferOrderLine) (int64, error) {
+	const q = `
+	UPDATE
+		inventory.transfer_order_lines
+	SET
+		product_id = :product_id,
+		quantity = :quantity,
+		quantity_shipped = :quantity_shipped,
+		quantity_received = :quantity_received,
+		discrepancy_quantity = :discrepancy_quantity,
+		discrepancy_reason = :discrepancy_reason,
+		updated_date = :updated_date
+	WHERE
+		id = :id
+		AND quantity_shipped = :expected_shipped
+		AND quantity_received = :expected_received
+		AND discrepancy_quantity = :expected_discrepancy`
+
+	data := struct {
+		transferOrderLine
+		ExpectedShipped     int `db:"expected_shipped"`
+		ExpectedReceived    int `db:"expected_received"`
+		ExpectedDiscrepancy int `db:"expected_discrepancy"`
+	}{
+		transferOrderLine:   toDBTransferOrderLine(line),
+		ExpectedShipped:     before.QuantityShipped,
+		ExpectedReceived:    before.QuantityReceived,
+		ExpectedDiscrepancy: before.DiscrepancyQuantity,
+	}
+
+	rows, err := sqldb.NamedExecContextWithCount(ctx, s.log, s.db, q, data)
+	if err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return 0, fmt.Errorf("namedexeccontext %w", transferorderbus.ErrForeignKeyViolation)
+		}
+		return 0, fmt.Errorf("namedexeccontext %w", err)
+	}
+
+	return rows, nil
+}
+
+func (s *Store) QueryLines(ctx context.Context, transferOrderID uuid.UUID) ([]transferorderbus.TransferOrderLine, error) {
+	data := struct {
+		TransferID string `db:"transfer_id"`
+	}{
+		TransferID: transferOrderID.String(),
+	}
+
+	const q = `
+	SELECT
+		id, transfer_id, line_number, product_id, quantity, quantity_shipped, quantity_received,
+		discrepancy_quantity, discrepancy_reason, created_date, updated_date
+	FROM
+		inventory.transfer_order_lines
+	WHERE
+		transfer_id = :transfer_id
+	ORDER BY
+		line_number`
+
+	var dbLines []transferOrderLine
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbLines); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusTransferOrderLines(dbLines), nil
+}
+
+// TransitLocation returns the in-transit location of the warehouse holding
+// locationID. The location is created in the same zone on first use; the
+// partial unique index on is_transit keeps it one per warehouse when two
+// transfers ship concurrently.
+func (s *Store) TransitLocation(ctx context.Context, locationID uuid.UUID) (uuid.UUID, error) {
+	data := struct {
+		ID         uuid.UUID `db:"id"`
+		LocationID uuid.UUID `db:"location_id"`
+	}{
+		ID:         uuid.New(),
+		LocationID: locationID,
+	}
+
+	const ins = `
+	INSERT INTO inventory.inventory_locations (
+		id, zone_id, warehouse_id, aisle, rack, shelf, bin, location_code, is_pick_location,
+		is_reserve_location, is_transit, max_capacity, current_utilization, created_date, updated_date
+	)
+	SELECT
+		:id, zone_id, warehouse_id, 'TRANSIT', 'TRANSIT', 'TRANSIT', 'TRANSIT', 'IN-TRANSIT', false,
+		false, true, 0, 0, NOW(), NOW()
+	FROM
+		inventory.inventory_locations
+	WHERE
+		id = :location_id
+	ON CONFLICT (warehouse_id) WHERE is_transit DO NOTHING`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, ins, data); err != nil {
+		return uuid.Nil, fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	const q = `
+	SELECT
+		t.id
+	FROM
+		inventory.inventory_locations t
+	JOIN
+		inventory.inventory_locations l ON l.warehouse_id = t.warehouse_id
+	WHERE
+		l.id = :location_id
+		AND t.is_transit`
+
+	var dest struct {
+		ID uuid.UUID `db:"id"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dest); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return uuid.Nil, fmt.Errorf("location %s: %w", locationID, transferorderbus.ErrForeignKeyViolation)
+		}
+		return uuid.Nil, fmt.Errorf("namedquerystruct: %w", err)
+	}
+
+	return dest.ID, nil
+}
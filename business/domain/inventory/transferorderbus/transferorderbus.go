@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,6 +24,11 @@ var (
 	ErrUniqueEntry           = errors.New("transferOrder entry is not unique")
 	ErrForeignKeyViolation   = errors.New("foreign key violation")
 	ErrInvalidTransferStatus = errors.New("transfer order is not in a state that allows this transition")
+	ErrNoLines               = errors.New("transfer order has no lines")
+	ErrInvalidQuantity       = errors.New("transfer line quantity must be positive")
+	ErrLineNotFound          = errors.New("transfer order line not found")
+	ErrOverReceipt           = errors.New("received quantity exceeds the quantity outstanding")
+	ErrEmptyReceipt          = errors.New("receipt does not receive anything")
 )
 
 // Transfer order status values.
@@ -32,6 +38,8 @@ const (
 	StatusRejected  = "rejected"
 	StatusInTransit = "in_transit"
 	StatusCompleted = "completed"
+
+	StatusPartiallyReceived = "partially_received"
 )
 
 // Storer interface declares the behavior this package needs to persist and
@@ -48,6 +56,16 @@ type Storer interface {
 	Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]TransferOrder, error)
 	Count(ctx context.Context, filter QueryFilter) (int, error)
 	QueryByID(ctx context.Context, transferOrderID uuid.UUID) (TransferOrder, error)
+
+	CreateLines(ctx context.Context, lines []TransferOrderLine) error
+	// UpdateLineWithGuard writes line only when the row's shipped, received and
+	// discrepancy quantities still equal those of before. It returns the number of
+	// rows affected (0 means a concurrent receipt won).
+	UpdateLineWithGuard(ctx context.Context, line TransferOrderLine, before TransferOrderLine) (int64, error)
+	QueryLines(ctx context.Context, transferOrderID uuid.UUID) ([]TransferOrderLine, error)
+	// TransitLocation returns the virtual in-transit location of the warehouse
+	// holding locationID, creating it on first use.
+	TransitLocation(ctx context.Context, locationID uuid.UUID) (uuid.UUID, error)
 }
 
 // Business manages the set of APIs for brand access.
@@ -97,21 +115,60 @@ func (b *Business) Create(ctx context.Context, nto NewTransferOrder) (TransferOr
 		func(ctx context.Context, b *Business) (TransferOrder, error) {
 			now := time.Now()
 
+			nls := nto.Lines
+			if len(nls) == 0 {
+				if nto.ProductID == uuid.Nil {
+					return TransferOrder{}, fmt.Errorf("create: %w", ErrNoLines)
+				}
+				nls = []NewTransferOrderLine{{ProductID: nto.ProductID, Quantity: nto.Quantity}}
+			}
+
 			transferOrder := TransferOrder{
 				TransferID:     uuid.New(),
 				TransferNumber: nto.TransferNumber,
-				ProductID:      nto.ProductID,
 				FromLocationID: nto.FromLocationID,
 				ToLocationID:   nto.ToLocationID,
 				RequestedByID:  nto.RequestedByID,
 				ApprovedByID:   nto.ApprovedByID,
-				Quantity:       nto.Quantity,
 				Status:         nto.Status,
 				TransferDate:   nto.TransferDate,
 				CreatedDate:    now,
 				UpdatedDate:    now,
 			}
 
+			lines := make([]TransferOrderLine, len(nls))
+			for i, nl := range nls {
+				if nl.Quantity <= 0 {
+					return TransferOrder{}, fmt.Errorf("create: line %d: %w", i+1, ErrInvalidQuantity)
+				}
+
+				lines[i] = TransferOrderLine{
+					ID:          uuid.New(),
+					TransferID:  transferOrder.TransferID,
+					LineNumber:  i + 1,
+					ProductID:   nl.ProductID,
+					Quantity:    nl.Quantity,
+					CreatedDate: now,
+					UpdatedDate: now,
+				}
+
+				// A transfer created past the claim (imports, seeds) starts with its
+				// lines already shipped, and a completed one with them received.
+				switch transferOrder.Status {
+				case StatusInTransit, StatusPartiallyReceived:
+					lines[i].QuantityShipped = nl.Quantity
+				case StatusCompleted:
+					lines[i].QuantityShipped = nl.Quantity
+					lines[i].QuantityReceived = nl.Quantity
+				}
+
+				transferOrder.Quantity += nl.Quantity
+			}
+
+			if len(lines) == 1 {
+				transferOrder.ProductID = lines[0].ProductID
+			}
+
 			if sid, ok := sqldb.GetScenarioFilter(ctx); ok {
 				transferOrder.ScenarioID = &sid
 			}
@@ -120,6 +177,10 @@ func (b *Business) Create(ctx context.Context, nto NewTransferOrder) (TransferOr
 				return TransferOrder{}, fmt.Errorf("create: %w", err)
 			}
 
+			if err := b.storer.CreateLines(ctx, lines); err != nil {
+				return TransferOrder{}, fmt.Errorf("create: lines: %w", err)
+			}
+
 			// Fire delegate event for workflow automation
 			evtData := ActionCreatedData(transferOrder)
 			if err := b.outbox.Emit(ctx, evtData); err != nil {
@@ -188,6 +249,12 @@ func (b *Business) Update(ctx context.Context, to TransferOrder, ut UpdateTransf
 				return TransferOrder{}, fmt.Errorf("update: %w", err)
 			}
 
+			if ut.ProductID != nil || ut.Quantity != nil {
+				if err := b.syncSingleLine(ctx, to); err != nil {
+					return TransferOrder{}, fmt.Errorf("update: %w", err)
+				}
+			}
+
 			// Fire delegate event for workflow automation
 			evtData := ActionUpdatedData(before, to)
 			if err := b.outbox.Emit(ctx, evtData); err != nil {
@@ -294,22 +361,42 @@ func (b *Business) Approve(ctx context.Context, to TransferOrder, approvedBy uui
 		})
 }
 
-// Claim marks an approved transfer order as in_transit, recording who claimed it.
-func (b *Business) Claim(ctx context.Context, to TransferOrder, claimedBy uuid.UUID) (TransferOrder, error) {
+// Claim ships an approved transfer order: it moves to in_transit, recording
+// who claimed it, every line ships its full quantity and the stock is bound
+// for the in-transit location of the source warehouse. The returned movements
+// are the stock the caller must move from the source to that location in the
+// same transaction.
+func (b *Business) Claim(ctx context.Context, to TransferOrder, claimedBy uuid.UUID) (TransferOrder, []Movement, error) {
 	ctx, span := otel.AddSpan(ctx, "business.transferorderbus.claim")
 	defer span.End()
 
 	if to.Status != StatusApproved {
-		return TransferOrder{}, fmt.Errorf("claim: %w: must be approved, got %s", ErrInvalidTransferStatus, to.Status)
+		return TransferOrder{}, nil, fmt.Errorf("claim: %w: must be approved, got %s", ErrInvalidTransferStatus, to.Status)
 	}
 
-	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+	var moves []Movement
+
+	claimed, err := outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
 		func(ctx context.Context, b *Business) (TransferOrder, error) {
+			lines, err := b.storer.QueryLines(ctx, to.TransferID)
+			if err != nil {
+				return TransferOrder{}, fmt.Errorf("claim: querylines: %w", err)
+			}
+			if len(lines) == 0 {
+				return TransferOrder{}, fmt.Errorf("claim: %w", ErrNoLines)
+			}
+
+			transitID, err := b.storer.TransitLocation(ctx, to.FromLocationID)
+			if err != nil {
+				return TransferOrder{}, fmt.Errorf("claim: transit location: %w", err)
+			}
+
 			before := to
 
 			now := time.Now()
 			to.ClaimedByID = &claimedBy
 			to.ClaimedAt = &now
+			to.TransitLocationID = &transitID
 			to.Status = StatusInTransit
 			to.UpdatedDate = now
 
@@ -324,6 +411,19 @@ func (b *Business) Claim(ctx context.Context, to TransferOrder, claimedBy uuid.U
 				return TransferOrder{}, fmt.Errorf("claim: %w: status changed concurrently", ErrInvalidTransferStatus)
 			}
 
+			moves = make([]Movement, 0, len(lines))
+			for _, line := range lines {
+				prev := line
+				line.QuantityShipped = line.Quantity
+				line.UpdatedDate = now
+
+				if err := b.updateLine(ctx, line, prev); err != nil {
+					return TransferOrder{}, fmt.Errorf("claim: %w", err)
+				}
+
+				moves = append(moves, Movement{LineID: line.ID, ProductID: line.ProductID, Quantity: line.QuantityShipped})
+			}
+
 			evtData := ActionUpdatedData(before, to)
 			if err := b.outbox.Emit(ctx, evtData); err != nil {
 				return TransferOrder{}, fmt.Errorf("emit cascade event: %w", err)
@@ -334,37 +434,124 @@ func (b *Business) Claim(ctx context.Context, to TransferOrder, claimedBy uuid.U
 
 			return to, nil
 		})
+	if err != nil {
+		return TransferOrder{}, nil, err
+	}
+
+	return claimed, moves, nil
 }
 
-// Execute marks an in_transit transfer order as completed, recording who completed it.
-// This is a simple status transition — the atomic stock move happens at the app layer.
-func (b *Business) Execute(ctx context.Context, to TransferOrder, completedBy uuid.UUID) (TransferOrder, error) {
-	ctx, span := otel.AddSpan(ctx, "business.transferorderbus.execute")
+// Receive books goods arriving at the destination of a shipped transfer. The
+// transfer completes, recording who completed it, once nothing is outstanding
+// (or the receipt closes it short); otherwise it is partially_received. The
+// returned movements are the stock the caller must move from the in-transit
+// location to the destination, and write off in transit, in the same
+// transaction.
+func (b *Business) Receive(ctx context.Context, to TransferOrder, rc Receipt) (TransferOrder, []Movement, error) {
+	ctx, span := otel.AddSpan(ctx, "business.transferorderbus.receive")
 	defer span.End()
 
-	if to.Status != StatusInTransit {
-		return TransferOrder{}, fmt.Errorf("execute: %w: must be in_transit, got %s", ErrInvalidTransferStatus, to.Status)
+	if to.Status != StatusInTransit && to.Status != StatusPartiallyReceived {
+		return TransferOrder{}, nil, fmt.Errorf("receive: %w: must be in_transit or partially_received, got %s", ErrInvalidTransferStatus, to.Status)
 	}
 
-	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+	var moves []Movement
+
+	received, err := outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
 		func(ctx context.Context, b *Business) (TransferOrder, error) {
-			before := to
+			lines, err := b.storer.QueryLines(ctx, to.TransferID)
+			if err != nil {
+				return TransferOrder{}, fmt.Errorf("receive: querylines: %w", err)
+			}
+
+			receipt := make(map[uuid.UUID]ReceiptLine, len(rc.Lines))
+			for _, rl := range rc.Lines {
+				if rl.Quantity < 0 {
+					return TransferOrder{}, fmt.Errorf("receive: line %s: %w", rl.LineID, ErrInvalidQuantity)
+				}
+				r := receipt[rl.LineID]
+				r.Quantity += rl.Quantity
+				if rl.Reason != "" {
+					r.Reason = rl.Reason
+				}
+				receipt[rl.LineID] = r
+			}
+
+			for id := range receipt {
+				if !slices.ContainsFunc(lines, func(l TransferOrderLine) bool { return l.ID == id }) {
+					return TransferOrder{}, fmt.Errorf("receive: line %s: %w", id, ErrLineNotFound)
+				}
+			}
 
 			now := time.Now()
-			to.CompletedByID = &completedBy
-			to.CompletedAt = &now
-			to.Status = StatusCompleted
+
+			type change struct {
+				line TransferOrderLine
+				prev TransferOrderLine
+			}
+
+			var changes []change
+			outstanding := 0
+			for _, line := range lines {
+				prev := line
+				r := receipt[line.ID]
+
+				if r.Quantity > line.Outstanding() {
+					return TransferOrder{}, fmt.Errorf("receive: line %d: %w: received %d, outstanding %d", line.LineNumber, ErrOverReceipt, r.Quantity, line.Outstanding())
+				}
+				line.QuantityReceived += r.Quantity
+
+				discrepancy := 0
+				if rc.Close && line.Outstanding() > 0 {
+					discrepancy = line.Outstanding()
+					line.DiscrepancyQuantity += discrepancy
+					line.DiscrepancyReason = r.Reason
+					if line.DiscrepancyReason == "" {
+						line.DiscrepancyReason = rc.Reason
+					}
+				}
+
+				outstanding += line.Outstanding()
+
+				if r.Quantity == 0 && discrepancy == 0 {
+					continue
+				}
+
+				line.UpdatedDate = now
+				changes = append(changes, change{line: line, prev: prev})
+				moves = append(moves, Movement{LineID: line.ID, ProductID: line.ProductID, Quantity: r.Quantity, Discrepancy: discrepancy})
+			}
+
+			if len(changes) == 0 && outstanding > 0 {
+				return TransferOrder{}, fmt.Errorf("receive: %w", ErrEmptyReceipt)
+			}
+
+			before := to
+
+			to.Status = StatusPartiallyReceived
+			if outstanding == 0 {
+				to.Status = StatusCompleted
+				to.CompletedByID = &rc.ReceivedBy
+				to.CompletedAt = &now
+			}
 			to.UpdatedDate = now
 
-			// Guard on the still-in_transit DB state to close the read-check-write race: if a
-			// concurrent execute already completed the row, 0 rows match and we reject rather
-			// than silently overwriting the winner's completed_by.
-			rows, err := b.storer.UpdateWithStatusGuard(ctx, to, StatusInTransit)
+			// Guard on the status we read to close the read-check-write race: if a
+			// concurrent receipt completed the row, 0 rows match and we reject rather
+			// than silently overwriting the winner's completed_by. Concurrent partial
+			// receipts are caught by the per-line guard below.
+			rows, err := b.storer.UpdateWithStatusGuard(ctx, to, before.Status)
 			if err != nil {
-				return TransferOrder{}, fmt.Errorf("execute: %w", err)
+				return TransferOrder{}, fmt.Errorf("receive: %w", err)
 			}
 			if rows == 0 {
-				return TransferOrder{}, fmt.Errorf("execute: %w: status changed concurrently", ErrInvalidTransferStatus)
+				return TransferOrder{}, fmt.Errorf("receive: %w: status changed concurrently", ErrInvalidTransferStatus)
+			}
+
+			for _, c := range changes {
+				if err := b.updateLine(ctx, c.line, c.prev); err != nil {
+					return TransferOrder{}, fmt.Errorf("receive: %w", err)
+				}
 			}
 
 			evtData := ActionUpdatedData(before, to)
@@ -377,6 +564,41 @@ func (b *Business) Execute(ctx context.Context, to TransferOrder, completedBy uu
 
 			return to, nil
 		})
+	if err != nil {
+		return TransferOrder{}, nil, err
+	}
+
+	return received, moves, nil
+}
+
+// Execute completes a shipped transfer order by receiving everything still
+// outstanding on its lines, recording who completed it.
+func (b *Business) Execute(ctx context.Context, to TransferOrder, completedBy uuid.UUID) (TransferOrder, []Movement, error) {
+	ctx, span := otel.AddSpan(ctx, "business.transferorderbus.execute")
+	defer span.End()
+
+	if to.Status != StatusInTransit && to.Status != StatusPartiallyReceived {
+		return TransferOrder{}, nil, fmt.Errorf("execute: %w: must be in_transit or partially_received, got %s", ErrInvalidTransferStatus, to.Status)
+	}
+
+	lines, err := b.storer.QueryLines(ctx, to.TransferID)
+	if err != nil {
+		return TransferOrder{}, nil, fmt.Errorf("execute: querylines: %w", err)
+	}
+
+	rc := Receipt{ReceivedBy: completedBy}
+	for _, line := range lines {
+		if n := line.Outstanding(); n > 0 {
+			rc.Lines = append(rc.Lines, ReceiptLine{LineID: line.ID, Quantity: n})
+		}
+	}
+
+	completed, moves, err := b.Receive(ctx, to, rc)
+	if err != nil {
+		return TransferOrder{}, nil, fmt.Errorf("execute: %w", err)
+	}
+
+	return completed, moves, nil
 }
 
 // Reject sets the rejector and marks the transfer order as rejected.
@@ -413,3 +635,57 @@ func (b *Business) Reject(ctx context.Context, to TransferOrder, rejectedBy uuid
 			return to, nil
 		})
 }
+
+// QueryLines returns the lines of a transfer order ordered by line number.
+func (b *Business) QueryLines(ctx context.Context, transferOrderID uuid.UUID) ([]TransferOrderLine, error) {
+	ctx, span := otel.AddSpan(ctx, "business.transferorderbus.querylines")
+	defer span.End()
+
+	lines, err := b.storer.QueryLines(ctx, transferOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("querylines: %w", err)
+	}
+
+	return lines, nil
+}
+
+// updateLine writes a line guarded on the quantities it had when read.
+func (b *Business) updateLine(ctx context.Context, line TransferOrderLine, before TransferOrderLine) error {
+	rows, err := b.storer.UpdateLineWithGuard(ctx, line, before)
+	if err != nil {
+		return fmt.Errorf("update line %d: %w", line.LineNumber, err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("update line %d: %w: line changed concurrently", line.LineNumber, ErrInvalidTransferStatus)
+	}
+
+	return nil
+}
+
+// syncSingleLine carries a header product or quantity change onto the line of
+// a single-line transfer that has not shipped, keeping the original
+// single-product shape and its line in step.
+func (b *Business) syncSingleLine(ctx context.Context, to TransferOrder) error {
+	lines, err := b.storer.QueryLines(ctx, to.TransferID)
+	if err != nil {
+		return fmt.Errorf("querylines: %w", err)
+	}
+	if len(lines) != 1 || lines[0].QuantityShipped != 0 {
+		return nil
+	}
+
+	line := lines[0]
+	if line.ProductID == to.ProductID && line.Quantity == to.Quantity {
+		return nil
+	}
+	if to.Quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+
+	prev := line
+	line.ProductID = to.ProductID
+	line.Quantity = to.Quantity
+	line.UpdatedDate = to.UpdatedDate
+
+	return b.updateLine(ctx, line, prev)
+}
@@ -397,7 +397,7 @@ func claim(busDomain dbtest.BusDomain, sd unitest.SeedData, lifecycleID uuid.UUI
 			Name:    "claim-pending-fails",
 			ExpResp: transferorderbus.ErrInvalidTransferStatus,
 			ExcFunc: func(ctx context.Context) any {
-				_, err := busDomain.TransferOrder.Claim(ctx, failTO, claimerID)
+				_, _, err := busDomain.TransferOrder.Claim(ctx, failTO, claimerID)
 				return err
 			},
 			CmpFunc: func(got, exp any) string {
@@ -421,7 +421,7 @@ func claim(busDomain dbtest.BusDomain, sd unitest.SeedData, lifecycleID uuid.UUI
 				if err != nil {
 					return fmt.Errorf("query: %w", err)
 				}
-				claimed, err := busDomain.TransferOrder.Claim(ctx, to, claimerID)
+				claimed, _, err := busDomain.TransferOrder.Claim(ctx, to, claimerID)
 				if err != nil {
 					return fmt.Errorf("claiming transfer order: %w", err)
 				}
@@ -451,7 +451,7 @@ func execute(busDomain dbtest.BusDomain, sd unitest.SeedData, lifecycleID uuid.U
 			Name:    "execute-pending-fails",
 			ExpResp: transferorderbus.ErrInvalidTransferStatus,
 			ExcFunc: func(ctx context.Context) any {
-				_, err := busDomain.TransferOrder.Execute(ctx, failTO, executorID)
+				_, _, err := busDomain.TransferOrder.Execute(ctx, failTO, executorID)
 				return err
 			},
 			CmpFunc: func(got, exp any) string {
@@ -475,7 +475,7 @@ func execute(busDomain dbtest.BusDomain, sd unitest.SeedData, lifecycleID uuid.U
 				if err != nil {
 					return fmt.Errorf("query: %w", err)
 				}
-				completed, err := busDomain.TransferOrder.Execute(ctx, to, executorID)
+				completed, _, err := busDomain.TransferOrder.Execute(ctx, to, executorID)
 				if err != nil {
 					return fmt.Errorf("executing transfer order: %w", err)
 				}
@@ -642,12 +642,12 @@ func Test_TransferOrder_ConcurrentClaimExecuteGuard(t *testing.T) {
 		t.Fatalf("approve: %s", err)
 	}
 
-	if _, err := db.BusDomain.TransferOrder.Claim(ctx, approvedSnap, winner); err != nil {
+	if _, _, err := db.BusDomain.TransferOrder.Claim(ctx, approvedSnap, winner); err != nil {
 		t.Fatalf("winning claim: %s", err)
 	}
 
 	// Loser replays the SAME stale approved snapshot; the row is already in_transit.
-	if _, err := db.BusDomain.TransferOrder.Claim(ctx, approvedSnap, loser); !errors.Is(err, transferorderbus.ErrInvalidTransferStatus) {
+	if _, _, err := db.BusDomain.TransferOrder.Claim(ctx, approvedSnap, loser); !errors.Is(err, transferorderbus.ErrInvalidTransferStatus) {
 		t.Fatalf("stale double-claim: want ErrInvalidTransferStatus, got %v", err)
 	}
 
@@ -662,11 +662,11 @@ func Test_TransferOrder_ConcurrentClaimExecuteGuard(t *testing.T) {
 	// --- Execute race -----------------------------------------------------------------
 	// inTransitSnap is the status='in_transit' snapshot BOTH concurrent executors hold.
 	inTransitSnap := claimed
-	if _, err := db.BusDomain.TransferOrder.Execute(ctx, inTransitSnap, winner); err != nil {
+	if _, _, err := db.BusDomain.TransferOrder.Execute(ctx, inTransitSnap, winner); err != nil {
 		t.Fatalf("winning execute: %s", err)
 	}
 
-	if _, err := db.BusDomain.TransferOrder.Execute(ctx, inTransitSnap, loser); !errors.Is(err, transferorderbus.ErrInvalidTransferStatus) {
+	if _, _, err := db.BusDomain.TransferOrder.Execute(ctx, inTransitSnap, loser); !errors.Is(err, transferorderbus.ErrInvalidTransferStatus) {
 		t.Fatalf("stale double-execute: want ErrInvalidTransferStatus, got %v", err)
 	}
 
@@ -679,6 +679,117 @@ func Test_TransferOrder_ConcurrentClaimExecuteGuard(t *testing.T) {
 	}
 }
 
+// Test_TransferOrder_LinesAndPartialReceipt walks a multi-line transfer through
+// claim and two receipts: the first receives part of the shipment and leaves the
+// transfer partially_received, the second closes it, booking the short-shipped
+// remainder as a discrepancy. Over-receipts and unknown lines are rejected.
+func Test_TransferOrder_LinesAndPartialReceipt(t *testing.T) {
+	t.Parallel()
+
+	db := dbtest.NewDatabase(t, "Test_TransferOrder_LinesAndPartialReceipt")
+
+	sd, err := insertSeedData(db.BusDomain)
+	if err != nil {
+		t.Fatalf("seeding: %s", err)
+	}
+
+	ctx := context.Background()
+	bus := db.BusDomain.TransferOrder
+	userID := sd.Admins[0].ID
+
+	to, err := bus.Create(ctx, transferorderbus.NewTransferOrder{
+		FromLocationID: sd.InventoryLocations[0].LocationID,
+		ToLocationID:   sd.InventoryLocations[1].LocationID,
+		RequestedByID:  userID,
+		Status:         transferorderbus.StatusApproved,
+		TransferDate:   time.Now(),
+		Lines: []transferorderbus.NewTransferOrderLine{
+			{ProductID: sd.Products[0].ProductID, Quantity: 10},
+			{ProductID: sd.Products[1].ProductID, Quantity: 4},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+	if to.ProductID != uuid.Nil || to.Quantity != 14 {
+		t.Fatalf("multi-line header: want nil product and quantity 14, got %s/%d", to.ProductID, to.Quantity)
+	}
+
+	claimed, moves, err := bus.Claim(ctx, to, userID)
+	if err != nil {
+		t.Fatalf("claim: %s", err)
+	}
+	if claimed.TransitLocationID == nil || *claimed.TransitLocationID == to.FromLocationID {
+		t.Fatalf("claim: want a transit location distinct from the source, got %v", claimed.TransitLocationID)
+	}
+	if len(moves) != 2 || moves[0].Quantity != 10 || moves[1].Quantity != 4 {
+		t.Fatalf("claim: unexpected moves %+v", moves)
+	}
+
+	lines, err := bus.QueryLines(ctx, to.TransferID)
+	if err != nil {
+		t.Fatalf("query lines: %s", err)
+	}
+
+	if _, _, err := bus.Receive(ctx, claimed, transferorderbus.Receipt{
+		ReceivedBy: userID,
+		Lines:      []transferorderbus.ReceiptLine{{LineID: lines[1].ID, Quantity: 5}},
+	}); !errors.Is(err, transferorderbus.ErrOverReceipt) {
+		t.Fatalf("over-receipt: want ErrOverReceipt, got %v", err)
+	}
+	if _, _, err := bus.Receive(ctx, claimed, transferorderbus.Receipt{
+		ReceivedBy: userID,
+		Lines:      []transferorderbus.ReceiptLine{{LineID: uuid.New(), Quantity: 1}},
+	}); !errors.Is(err, transferorderbus.ErrLineNotFound) {
+		t.Fatalf("unknown line: want ErrLineNotFound, got %v", err)
+	}
+
+	partial, moves, err := bus.Receive(ctx, claimed, transferorderbus.Receipt{
+		ReceivedBy: userID,
+		Lines:      []transferorderbus.ReceiptLine{{LineID: lines[0].ID, Quantity: 6}},
+	})
+	if err != nil {
+		t.Fatalf("partial receipt: %s", err)
+	}
+	if partial.Status != transferorderbus.StatusPartiallyReceived {
+		t.Fatalf("partial receipt: want status %s, got %s", transferorderbus.StatusPartiallyReceived, partial.Status)
+	}
+	if len(moves) != 1 || moves[0].Quantity != 6 {
+		t.Fatalf("partial receipt: unexpected moves %+v", moves)
+	}
+
+	completed, moves, err := bus.Receive(ctx, partial, transferorderbus.Receipt{
+		ReceivedBy: userID,
+		Lines:      []transferorderbus.ReceiptLine{{LineID: lines[1].ID, Quantity: 4}},
+		Close:      true,
+		Reason:     "short shipped",
+	})
+	if err != nil {
+		t.Fatalf("closing receipt: %s", err)
+	}
+	if completed.Status != transferorderbus.StatusCompleted || completed.CompletedByID == nil {
+		t.Fatalf("closing receipt: want completed with completed_by, got %s/%v", completed.Status, completed.CompletedByID)
+	}
+	if len(moves) != 2 {
+		t.Fatalf("closing receipt: unexpected moves %+v", moves)
+	}
+
+	lines, err = bus.QueryLines(ctx, to.TransferID)
+	if err != nil {
+		t.Fatalf("query lines: %s", err)
+	}
+	exp := []struct{ shipped, received, discrepancy int }{{10, 6, 4}, {4, 4, 0}}
+	for i, line := range lines {
+		if line.QuantityShipped != exp[i].shipped || line.QuantityReceived != exp[i].received || line.DiscrepancyQuantity != exp[i].discrepancy {
+			t.Errorf("line %d: want shipped/received/discrepancy %v, got %d/%d/%d", line.LineNumber, exp[i],
+				line.QuantityShipped, line.QuantityReceived, line.DiscrepancyQuantity)
+		}
+	}
+	if lines[0].DiscrepancyReason != "short shipped" {
+		t.Errorf("line 1: want discrepancy reason %q, got %q", "short shipped", lines[0].DiscrepancyReason)
+	}
+}
+
 func delete(busDomain dbtest.BusDomain, sd unitest.SeedData) []unitest.Table {
 	return []unitest.Table{{
 		Name: "delete",
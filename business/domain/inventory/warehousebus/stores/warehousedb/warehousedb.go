@@ -15,6 +15,9 @@ import (
 	"github.com/timmaaaz/ichor/foundation/logger"
 )
 
+// scopeColumns places a warehouse in a data scope.
+var scopeColumns = sqldb.ScopeColumns{Warehouse: "id"}
+
 // Store manages the set of APIs for warehouse database access.
 type Store struct {
 	log *logger.Logger
@@ -47,6 +50,10 @@ func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (warehousebus.Storer, error
 
 // Create inserts a new warehouse into the database.
 func (s *Store) Create(ctx context.Context, bus warehousebus.Warehouse) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, scopeColumns, map[string]uuid.UUID{"id": bus.ID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
 		INSERT INTO inventory.warehouses
 			(id, code, street_id, name, is_active, created_date, updated_date, created_by, updated_by)
@@ -66,6 +73,10 @@ func (s *Store) Create(ctx context.Context, bus warehousebus.Warehouse) error {
 
 // Update replaces a warehouse document in the database.
 func (s *Store) Update(ctx context.Context, bus warehousebus.Warehouse) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, scopeColumns, map[string]uuid.UUID{"id": bus.ID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
 		UPDATE
 			inventory.warehouses
@@ -129,6 +140,7 @@ func (s *Store) Query(ctx context.Context, filter warehousebus.QueryFilter, orde
 
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
 
 	orderByClause, err := orderByClause(orderBy)
 	if err != nil {
@@ -158,6 +170,7 @@ func (s *Store) Count(ctx context.Context, filter warehousebus.QueryFilter) (int
 
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
 
 	var count struct {
 		Count int `db:"count"`
@@ -171,10 +184,8 @@ func (s *Store) Count(ctx context.Context, filter warehousebus.QueryFilter) (int
 
 // QueryByID gets the specified warehouse from the database.
 func (s *Store) QueryByID(ctx context.Context, wID uuid.UUID) (warehousebus.Warehouse, error) {
-	data := struct {
-		WarehouseID uuid.UUID `db:"id"`
-	}{
-		WarehouseID: wID,
+	data := map[string]any{
+		"id": wID,
 	}
 
 	const q = `
@@ -193,8 +204,11 @@ func (s *Store) QueryByID(ctx context.Context, wID uuid.UUID) (warehousebus.Ware
 	WHERE
 		id = :id`
 
+	buf := bytes.NewBufferString(q)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
+
 	var dbW warehouse
-	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbW); err != nil {
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &dbW); err != nil {
 		if errors.Is(err, sqldb.ErrDBNotFound) {
 			return warehousebus.Warehouse{}, fmt.Errorf("db: %w", warehousebus.ErrNotFound)
 		}
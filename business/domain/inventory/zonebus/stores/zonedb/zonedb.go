@@ -15,6 +15,9 @@ import (
 	"github.com/timmaaaz/ichor/foundation/logger"
 )
 
+// scopeColumns places a zone in a data scope.
+var scopeColumns = sqldb.ScopeColumns{Warehouse: "warehouse_id", Zone: "id"}
+
 // Store manages the set of APIs for zone database access.
 type Store struct {
 	log *logger.Logger
@@ -46,6 +49,10 @@ func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (zonebus.Storer, error) {
 }
 
 func (s *Store) Create(ctx context.Context, zone zonebus.Zone) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, scopeColumns, map[string]uuid.UUID{"warehouse_id": zone.WarehouseID, "id": zone.ZoneID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
 	INSERT INTO inventory.zones (
 		id, warehouse_id, name, zone_code, description, stage, created_date, updated_date
@@ -68,6 +75,10 @@ func (s *Store) Create(ctx context.Context, zone zonebus.Zone) error {
 }
 
 func (s *Store) Update(ctx context.Context, zone zonebus.Zone) error {
+	if err := sqldb.CheckDataScope(ctx, s.log, s.db, scopeColumns, map[string]uuid.UUID{"warehouse_id": zone.WarehouseID, "id": zone.ZoneID}); err != nil {
+		return fmt.Errorf("checkdatascope: %w", err)
+	}
+
 	const q = `
     UPDATE
         inventory.zones
@@ -125,6 +136,7 @@ func (s *Store) Query(ctx context.Context, filter zonebus.QueryFilter, orderBy o
 
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
 
 	orderByClause, err := orderByClause(orderBy)
 	if err != nil {
@@ -154,6 +166,7 @@ func (s *Store) Count(ctx context.Context, filter zonebus.QueryFilter) (int, err
 
 	buf := bytes.NewBufferString(q)
 	applyFilter(filter, data, buf)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
 	var count struct {
 		Count int `db:"count"`
 	}
@@ -166,10 +179,8 @@ func (s *Store) Count(ctx context.Context, filter zonebus.QueryFilter) (int, err
 }
 
 func (s *Store) QueryByID(ctx context.Context, zoneID uuid.UUID) (zonebus.Zone, error) {
-	data := struct {
-		ID string `db:"id"`
-	}{
-		ID: zoneID.String(),
+	data := map[string]any{
+		"id": zoneID.String(),
 	}
 
 	const q = `
@@ -181,9 +192,12 @@ func (s *Store) QueryByID(ctx context.Context, zoneID uuid.UUID) (zonebus.Zone,
 		id = :id
 	`
 
+	buf := bytes.NewBufferString(q)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
+
 	var dbZone zone
 
-	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbZone); err != nil {
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &dbZone); err != nil {
 		if errors.Is(err, sqldb.ErrDBNotFound) {
 			return zonebus.Zone{}, fmt.Errorf("namedexeccontext: %w", zonebus.ErrNotFound)
 		}
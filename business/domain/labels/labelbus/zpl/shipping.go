@@ -0,0 +1,47 @@
+package zpl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Shipping renders a 4"×6" @ 203 DPI ZPL carrier shipping label for one
+// carton of a shipment.
+//
+// Layout (203 DPI: 1" = 203 dots; label is 812w × 1218h):
+//
+//	Carrier + service level banner — top, underlined
+//	SHIP TO block (name, street lines, city/region/postal) — upper half
+//	Postal code Code128 barcode — middle
+//	Tracking number Code128 barcode (with human-readable) — lower third
+//	Shipment number, package n of N, weight — footer
+func Shipping(d ShippingData) string {
+	var b strings.Builder
+	b.WriteString("^XA\n")
+	b.WriteString(fmt.Sprintf("^FO40,40^A0N,70,70^FD%s %s^FS\n", strings.ToUpper(d.Carrier), strings.ToUpper(d.ServiceLevel)))
+	b.WriteString("^FO40,130^GB732,4,4^FS\n")
+
+	y := 160
+	b.WriteString(fmt.Sprintf("^FO40,%d^A0N,30,30^FDSHIP TO:^FS\n", y))
+	y += 45
+	b.WriteString(fmt.Sprintf("^FO40,%d^A0N,45,45^FD%s^FS\n", y, d.ShipToName))
+	y += 55
+	b.WriteString(fmt.Sprintf("^FO40,%d^A0N,45,45^FD%s^FS\n", y, d.ShipToLine1))
+	if d.ShipToLine2 != "" {
+		y += 55
+		b.WriteString(fmt.Sprintf("^FO40,%d^A0N,45,45^FD%s^FS\n", y, d.ShipToLine2))
+	}
+	y += 55
+	b.WriteString(fmt.Sprintf("^FO40,%d^A0N,45,45^FD%s, %s %s^FS\n", y, d.ShipToCity, d.ShipToRegion, d.ShipToPostal))
+
+	b.WriteString("^FO40,500^GB732,4,4^FS\n")
+	b.WriteString(fmt.Sprintf("^FO40,530^BY3^BCN,120,Y,N,N^FD%s^FS\n", d.ShipToPostal))
+	b.WriteString("^FO40,720^GB732,4,4^FS\n")
+	b.WriteString(fmt.Sprintf("^FO40,750^BY3^BCN,220,Y,N,N^FD%s^FS\n", d.TrackingNumber))
+
+	b.WriteString(fmt.Sprintf("^FO40,1060^A0N,35,35^FDSHIPMENT: %s^FS\n", d.ShipmentNumber))
+	b.WriteString(fmt.Sprintf("^FO40,1110^A0N,35,35^FDPKG %d OF %d^FS\n", d.Package, d.PackageCount))
+	b.WriteString(fmt.Sprintf("^FO420,1110^A0N,35,35^FDWT: %.2f %s^FS\n", d.Weight, strings.ToUpper(d.WeightUnit)))
+	b.WriteString("^XZ\n")
+	return b.String()
+}
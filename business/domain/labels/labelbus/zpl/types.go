@@ -21,3 +21,22 @@ type ProductData struct {
 	UPC         string  `json:"upc"`
 	LotNumber   *string `json:"lotNumber"`
 }
+
+// ShippingData is for carrier shipping labels, one per carton. Package and
+// PackageCount render as "PKG 1 OF 3".
+type ShippingData struct {
+	Carrier        string  `json:"carrier"`
+	ServiceLevel   string  `json:"serviceLevel"`
+	ShipToName     string  `json:"shipToName"`
+	ShipToLine1    string  `json:"shipToLine1"`
+	ShipToLine2    string  `json:"shipToLine2"`
+	ShipToCity     string  `json:"shipToCity"`
+	ShipToRegion   string  `json:"shipToRegion"`
+	ShipToPostal   string  `json:"shipToPostal"`
+	ShipmentNumber string  `json:"shipmentNumber"`
+	TrackingNumber string  `json:"trackingNumber"`
+	Weight         float64 `json:"weight"`
+	WeightUnit     string  `json:"weightUnit"`
+	Package        int     `json:"package"`
+	PackageCount   int     `json:"packageCount"`
+}
@@ -98,3 +98,39 @@ func Test_Location_Snapshot_MaxSafeCode(t *testing.T) {
 		t.Fatalf("location max-safe snapshot drift.\nwant:\n%q\ngot:\n%q\n", want, got)
 	}
 }
+
+func Test_Shipping_Snapshot(t *testing.T) {
+	got := zpl.Shipping(zpl.ShippingData{
+		Carrier:        "flatrate",
+		ServiceLevel:   "ground",
+		ShipToName:     "Acme, Inc.",
+		ShipToLine1:    "123 Main St",
+		ShipToCity:     "Springfield",
+		ShipToRegion:   "IL",
+		ShipToPostal:   "62701",
+		ShipmentNumber: "SHP-1001",
+		TrackingNumber: "FR1234567890",
+		Weight:         2.5,
+		WeightUnit:     "lb",
+		Package:        1,
+		PackageCount:   2,
+	})
+	want := "^XA\n" +
+		"^FO40,40^A0N,70,70^FDFLATRATE GROUND^FS\n" +
+		"^FO40,130^GB732,4,4^FS\n" +
+		"^FO40,160^A0N,30,30^FDSHIP TO:^FS\n" +
+		"^FO40,205^A0N,45,45^FDAcme, Inc.^FS\n" +
+		"^FO40,260^A0N,45,45^FD123 Main St^FS\n" +
+		"^FO40,315^A0N,45,45^FDSpringfield, IL 62701^FS\n" +
+		"^FO40,500^GB732,4,4^FS\n" +
+		"^FO40,530^BY3^BCN,120,Y,N,N^FD62701^FS\n" +
+		"^FO40,720^GB732,4,4^FS\n" +
+		"^FO40,750^BY3^BCN,220,Y,N,N^FDFR1234567890^FS\n" +
+		"^FO40,1060^A0N,35,35^FDSHIPMENT: SHP-1001^FS\n" +
+		"^FO40,1110^A0N,35,35^FDPKG 1 OF 2^FS\n" +
+		"^FO420,1110^A0N,35,35^FDWT: 2.50 LB^FS\n" +
+		"^XZ\n"
+	if got != want {
+		t.Fatalf("shipping snapshot drift.\nwant:\n%q\ngot:\n%q\n", want, got)
+	}
+}
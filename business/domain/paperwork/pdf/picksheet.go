@@ -34,10 +34,18 @@ func PickSheet(data PickSheetData) ([]byte, error) {
 	}
 
 	pdfDoc.SetFont("Helvetica", "", 10)
-	pdfDoc.Cell(0, 5, fmt.Sprintf("Order: %s", data.OrderNumber))
-	pdfDoc.Ln(5)
-	pdfDoc.Cell(0, 5, fmt.Sprintf("Customer: %s", data.CustomerName))
-	pdfDoc.Ln(5)
+	batch := len(data.Totes) > 0
+	if batch {
+		for _, tote := range data.Totes {
+			pdfDoc.Cell(0, 5, fmt.Sprintf("Tote %d: %s  %s", tote.Tote, tote.OrderNumber, tote.CustomerName))
+			pdfDoc.Ln(5)
+		}
+	} else {
+		pdfDoc.Cell(0, 5, fmt.Sprintf("Order: %s", data.OrderNumber))
+		pdfDoc.Ln(5)
+		pdfDoc.Cell(0, 5, fmt.Sprintf("Customer: %s", data.CustomerName))
+		pdfDoc.Ln(5)
+	}
 	if data.Zone != "" {
 		pdfDoc.Cell(0, 5, fmt.Sprintf("Zone: %s", data.Zone))
 		pdfDoc.Ln(5)
@@ -46,19 +54,33 @@ func PickSheet(data PickSheetData) ([]byte, error) {
 
 	// Column widths sum to 180mm (letter width 215.9mm minus 2×10mm
 	// margins = 195.9mm, with 15.9mm right slack to keep cell text
-	// from running into the right margin).
+	// from running into the right margin). A batch sheet takes its Stop
+	// and Tote columns out of the product column.
+	productWidth := 85.0
+	if batch {
+		productWidth = 61
+	}
+
 	pdfDoc.SetFont("Helvetica", "B", 9)
+	if batch {
+		pdfDoc.Cell(12, 6, "Stop")
+		pdfDoc.Cell(12, 6, "Tote")
+	}
 	pdfDoc.Cell(35, 6, "Location")
 	pdfDoc.Cell(40, 6, "SKU")
-	pdfDoc.Cell(85, 6, "Product")
+	pdfDoc.Cell(productWidth, 6, "Product")
 	pdfDoc.Cell(20, 6, "Qty")
 	pdfDoc.Ln(6)
 
 	pdfDoc.SetFont("Helvetica", "", 9)
 	for _, line := range data.Lines {
+		if batch {
+			pdfDoc.Cell(12, 6, fmt.Sprintf("%d", line.Stop))
+			pdfDoc.Cell(12, 6, fmt.Sprintf("%d", line.Tote))
+		}
 		pdfDoc.Cell(35, 6, line.LocationCode)
 		pdfDoc.Cell(40, 6, line.SKU)
-		pdfDoc.Cell(85, 6, line.ProductName)
+		pdfDoc.Cell(productWidth, 6, line.ProductName)
 		pdfDoc.Cell(20, 6, fmt.Sprintf("%d", line.Quantity))
 		pdfDoc.Ln(6)
 	}
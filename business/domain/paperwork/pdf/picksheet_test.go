@@ -60,3 +60,34 @@ func TestPickSheet_EmptyTaskCode(t *testing.T) {
 		t.Fatal("PickSheet with empty TaskCode returned nil error; want failure")
 	}
 }
+
+func TestPickSheet_Batch(t *testing.T) {
+	t.Parallel()
+
+	data := pdf.PickSheetData{
+		TaskCode: "BATCH-WAVE-1-B1",
+		Zone:     "Pick Face",
+		Totes: []pdf.PickSheetTote{
+			{Tote: 1, OrderNumber: "SO-1001", CustomerName: "ACME Co"},
+			{Tote: 2, OrderNumber: "SO-1002", CustomerName: "Globex"},
+		},
+		Lines: []pdf.PickSheetLine{
+			{Stop: 1, Tote: 2, LocationCode: "A1-R1-S1-B1", SKU: "SKU-0001", ProductName: "Widget", Quantity: 3},
+			{Stop: 2, Tote: 1, LocationCode: "A1-R4-S1-B2", SKU: "SKU-0042", ProductName: "Gadget", Quantity: 1},
+		},
+	}
+
+	got, err := pdf.PickSheet(data)
+	if err != nil {
+		t.Fatalf("PickSheet: %v", err)
+	}
+
+	for _, want := range []string{"BATCH-WAVE-1-B1", "Stop", "Tote", "SO-1002", "Globex", "A1-R4-S1-B2"} {
+		if !pdfContainsText(t, got, want) {
+			t.Errorf("PDF missing expected text %q", want)
+		}
+	}
+	if pdfContainsText(t, got, "Customer:") {
+		t.Error("batch sheet should replace the order/customer header with the tote legend")
+	}
+}
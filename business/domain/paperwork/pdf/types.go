@@ -12,16 +12,31 @@ type PickSheetData struct {
 	CustomerName string
 	Zone         string // optional; empty = all zones
 	Lines        []PickSheetLine
+
+	// Totes is set for a batch pick sheet covering several orders. When
+	// present it replaces the order/customer header with a tote legend and
+	// the table gains Stop and Tote columns; lines are in route order.
+	Totes []PickSheetTote
 }
 
-// PickSheetLine is one row in the pick sheet table.
+// PickSheetLine is one row in the pick sheet table. Stop and Tote are only
+// rendered on batch pick sheets.
 type PickSheetLine struct {
+	Stop         int
+	Tote         int
 	LocationCode string
 	SKU          string
 	ProductName  string
 	Quantity     int
 }
 
+// PickSheetTote maps a tote on a batch pick cart to the order picked into it.
+type PickSheetTote struct {
+	Tote         int
+	OrderNumber  string
+	CustomerName string
+}
+
 // ReceiveCoverData holds the rendering inputs for a receive-cover PDF.
 type ReceiveCoverData struct {
 	TaskCode   string // e.g. "PO-1"
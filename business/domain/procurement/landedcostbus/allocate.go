@@ -0,0 +1,217 @@
+package landedcostbus
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// kilograms converts the weight units physical attributes are recorded in to
+// kilograms, so lines weighed in different units share one basis.
+var kilograms = map[string]float64{
+	"kg":        1,
+	"kgs":       1,
+	"kilogram":  1,
+	"kilograms": 1,
+	"g":         0.001,
+	"gram":      0.001,
+	"grams":     0.001,
+	"lb":        0.45359237,
+	"lbs":       0.45359237,
+	"pound":     0.45359237,
+	"pounds":    0.45359237,
+	"oz":        0.028349523125,
+	"ounce":     0.028349523125,
+	"ounces":    0.028349523125,
+}
+
+// Allocate splits each charge of the landed cost across the received lines
+// of its purchase order, or of its line items when it names some, in
+// proportion to each line's basis for the charge's allocation method. Amounts
+// are split in cents and the cents left over by rounding go to the lines with
+// the largest remainders, so the allocations of a charge always add up to it.
+// Lines with no basis for a method carry none of its charges. Returns
+// ErrNothingReceived when no line in scope has been received and
+// ErrNoAllocationBasis when no line has a basis for a charge's method.
+func Allocate(lc LandedCost, lines []ReceivedLine) ([]Allocation, error) {
+	received := inScope(lc, lines)
+	if len(received) == 0 {
+		return nil, ErrNothingReceived
+	}
+
+	var allocations []Allocation
+	for _, c := range lc.Charges {
+		bases := make([]float64, len(received))
+		var total float64
+		for i, l := range received {
+			b, err := basis(c.AllocationMethod, l)
+			if err != nil {
+				return nil, err
+			}
+			bases[i] = b
+			total += b
+		}
+
+		if total <= 0 {
+			return nil, fmt.Errorf("%w: no received line has a %s for the %s charge", ErrNoAllocationBasis, c.AllocationMethod, c.ChargeType)
+		}
+
+		cents := split(int64(math.Round(c.Amount*100)), bases, total)
+		for i, l := range received {
+			if bases[i] <= 0 {
+				continue
+			}
+
+			allocations = append(allocations, Allocation{
+				ID:                      uuid.New(),
+				LandedCostID:            lc.ID,
+				ChargeID:                c.ID,
+				PurchaseOrderLineItemID: l.ID,
+				ProductID:               l.ProductID,
+				Quantity:                l.QuantityReceived,
+				Basis:                   math.Round(bases[i]*10000) / 10000,
+				Amount:                  float64(cents[i]) / 100,
+			})
+		}
+	}
+
+	return allocations, nil
+}
+
+// ProductLandedCosts works out the landed unit cost of each product the
+// allocations touch: the received value of its lines at the ordered cost plus
+// every charge applied to them, this landed cost's included, over the
+// quantity received. Products come back in the order of their lines.
+func ProductLandedCosts(lines []ReceivedLine, allocations []Allocation) []ProductLandedCost {
+	charged := make(map[uuid.UUID]float64)
+	for _, a := range allocations {
+		charged[a.PurchaseOrderLineItemID] += a.Amount
+	}
+
+	var products []ProductLandedCost
+	byProduct := make(map[uuid.UUID]int)
+	for _, l := range lines {
+		amount, ok := charged[l.ID]
+		if !ok {
+			continue
+		}
+
+		i, ok := byProduct[l.ProductID]
+		if !ok {
+			i = len(products)
+			byProduct[l.ProductID] = i
+			products = append(products, ProductLandedCost{ProductID: l.ProductID})
+		}
+
+		p := &products[i]
+		p.Quantity += l.QuantityReceived
+		p.Value += float64(l.QuantityReceived) * netUnitCost(l)
+		p.Charges += l.Applied + amount
+	}
+
+	for i := range products {
+		p := &products[i]
+		p.Value = roundCents(p.Value)
+		p.Charges = roundCents(p.Charges)
+		p.UnitCost = roundCents((p.Value + p.Charges) / float64(p.Quantity))
+	}
+
+	return products
+}
+
+// =============================================================================
+
+// inScope returns the received lines of the landed cost's purchase order,
+// limited to its line items when it names some.
+func inScope(lc LandedCost, lines []ReceivedLine) []ReceivedLine {
+	named := make(map[uuid.UUID]bool, len(lc.LineItemIDs))
+	for _, id := range lc.LineItemIDs {
+		named[id] = true
+	}
+
+	var received []ReceivedLine
+	for _, l := range lines {
+		switch {
+		case l.PurchaseOrderID != lc.PurchaseOrderID,
+			l.QuantityReceived <= 0,
+			len(named) > 0 && !named[l.ID]:
+			continue
+		}
+		received = append(received, l)
+	}
+
+	return received
+}
+
+// basis returns the share weight of a line for an allocation method.
+func basis(method string, l ReceivedLine) (float64, error) {
+	qty := float64(l.QuantityReceived)
+
+	switch method {
+	case AllocateByValue:
+		return qty * netUnitCost(l), nil
+
+	case AllocateByQuantity:
+		return qty, nil
+
+	case AllocateByWeight:
+		if l.Weight == 0 {
+			return 0, nil
+		}
+		kg, ok := kilograms[strings.ToLower(strings.TrimSpace(l.WeightUnit))]
+		if !ok {
+			return 0, fmt.Errorf("%w: unknown weight unit %q on line item %s", ErrNoAllocationBasis, l.WeightUnit, l.ID)
+		}
+		return qty * l.Weight * kg, nil
+
+	case AllocateByVolume:
+		return qty * l.Length * l.Width * l.Height, nil
+	}
+
+	return 0, fmt.Errorf("%w: unknown allocation method %q", ErrInvalidLandedCost, method)
+}
+
+// netUnitCost is the ordered unit cost of a line net of its discount spread
+// over the quantity ordered.
+func netUnitCost(l ReceivedLine) float64 {
+	if l.QuantityOrdered <= 0 {
+		return l.UnitCost
+	}
+	return l.UnitCost - l.Discount/float64(l.QuantityOrdered)
+}
+
+// split divides cents in proportion to bases by the largest remainder
+// method.
+func split(cents int64, bases []float64, total float64) []int64 {
+	shares := make([]int64, len(bases))
+	remainders := make([]float64, len(bases))
+
+	var given int64
+	for i, b := range bases {
+		exact := float64(cents) * b / total
+		shares[i] = int64(math.Floor(exact))
+		remainders[i] = exact - float64(shares[i])
+		given += shares[i]
+	}
+
+	order := make([]int, len(bases))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+
+	for _, i := range order[:min(int(cents-given), len(order))] {
+		shares[i]++
+	}
+
+	return shares
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,123 @@
+package landedcostbus
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAllocate(t *testing.T) {
+	po, other := uuid.New(), uuid.New()
+	widget, gadget := uuid.New(), uuid.New()
+	small, large, pending, foreign := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	lines := []ReceivedLine{
+		{ID: small, PurchaseOrderID: po, ProductID: widget, QuantityOrdered: 10, QuantityReceived: 10, UnitCost: 5,
+			Length: 1, Width: 1, Height: 1, Weight: 500, WeightUnit: "g"},
+		{ID: large, PurchaseOrderID: po, ProductID: gadget, QuantityOrdered: 20, QuantityReceived: 20, UnitCost: 10, Discount: 20,
+			Length: 2, Width: 1, Height: 1, Weight: 2.2046226218, WeightUnit: "lb"},
+		{ID: pending, PurchaseOrderID: po, ProductID: gadget, QuantityOrdered: 5, UnitCost: 10},
+		{ID: foreign, PurchaseOrderID: other, ProductID: widget, QuantityOrdered: 5, QuantityReceived: 5, UnitCost: 1},
+	}
+
+	charge := func(method string, amount float64) Charge {
+		return Charge{ID: uuid.New(), ChargeType: ChargeFreight, AllocationMethod: method, Amount: amount}
+	}
+
+	tests := []struct {
+		name   string
+		charge Charge
+		want   []float64 // small, large
+	}{
+		{"value", charge(AllocateByValue, 100), []float64{21.74, 78.26}},
+		{"quantity", charge(AllocateByQuantity, 100), []float64{33.33, 66.67}},
+		{"weight", charge(AllocateByWeight, 100), []float64{20, 80}},
+		{"volume", charge(AllocateByVolume, 10), []float64{2, 8}},
+		{"odd cents", charge(AllocateByQuantity, 0.01), []float64{0, 0.01}},
+	}
+
+	for _, tt := range tests {
+		lc := LandedCost{ID: uuid.New(), PurchaseOrderID: po, Charges: []Charge{tt.charge}}
+
+		got, err := Allocate(lc, lines)
+		if err != nil {
+			t.Fatalf("%s: allocate: %s", tt.name, err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("%s: allocations = %d, want only the received lines of the order", tt.name, len(got))
+		}
+
+		var sum float64
+		for i, a := range got {
+			if a.Amount != tt.want[i] {
+				t.Fatalf("%s: allocation %d = %v, want %v", tt.name, i, a.Amount, tt.want[i])
+			}
+			if a.ChargeID != tt.charge.ID || a.LandedCostID != lc.ID {
+				t.Fatalf("%s: allocation %d is not tied to its charge", tt.name, i)
+			}
+			sum += a.Amount
+		}
+		if math.Abs(sum-tt.charge.Amount) > 1e-9 {
+			t.Fatalf("%s: allocations add up to %v, want %v", tt.name, sum, tt.charge.Amount)
+		}
+	}
+
+	receipt := LandedCost{PurchaseOrderID: po, LineItemIDs: []uuid.UUID{large}, Charges: []Charge{charge(AllocateByQuantity, 50)}}
+	got, err := Allocate(receipt, lines)
+	if err != nil {
+		t.Fatalf("receipt: allocate: %s", err)
+	}
+	if len(got) != 1 || got[0].PurchaseOrderLineItemID != large || got[0].Amount != 50 {
+		t.Fatalf("receipt: allocations = %+v, want the whole charge on the named line", got)
+	}
+
+	if _, err := Allocate(LandedCost{PurchaseOrderID: po, LineItemIDs: []uuid.UUID{pending}, Charges: []Charge{charge(AllocateByQuantity, 1)}}, lines); !errors.Is(err, ErrNothingReceived) {
+		t.Fatalf("nothing received: err = %v, want %v", err, ErrNothingReceived)
+	}
+
+	unmeasured := []ReceivedLine{{ID: uuid.New(), PurchaseOrderID: po, QuantityReceived: 3, UnitCost: 1}}
+	if _, err := Allocate(LandedCost{PurchaseOrderID: po, Charges: []Charge{charge(AllocateByVolume, 1)}}, unmeasured); !errors.Is(err, ErrNoAllocationBasis) {
+		t.Fatalf("no volume: err = %v, want %v", err, ErrNoAllocationBasis)
+	}
+
+	stones := []ReceivedLine{{ID: uuid.New(), PurchaseOrderID: po, QuantityReceived: 3, Weight: 1, WeightUnit: "stone"}}
+	if _, err := Allocate(LandedCost{PurchaseOrderID: po, Charges: []Charge{charge(AllocateByWeight, 1)}}, stones); !errors.Is(err, ErrNoAllocationBasis) {
+		t.Fatalf("unknown unit: err = %v, want %v", err, ErrNoAllocationBasis)
+	}
+}
+
+func TestProductLandedCosts(t *testing.T) {
+	po, product := uuid.New(), uuid.New()
+	first, second, untouched := uuid.New(), uuid.New(), uuid.New()
+
+	lines := []ReceivedLine{
+		{ID: first, PurchaseOrderID: po, ProductID: product, QuantityOrdered: 10, QuantityReceived: 10, UnitCost: 4, Applied: 5},
+		{ID: second, PurchaseOrderID: po, ProductID: product, QuantityOrdered: 5, QuantityReceived: 5, UnitCost: 5, Discount: 5},
+		{ID: untouched, PurchaseOrderID: po, ProductID: uuid.New(), QuantityOrdered: 1, QuantityReceived: 1, UnitCost: 1},
+	}
+
+	allocations := []Allocation{
+		{PurchaseOrderLineItemID: first, Amount: 6},
+		{PurchaseOrderLineItemID: second, Amount: 3},
+		{PurchaseOrderLineItemID: second, Amount: 1.5},
+	}
+
+	got := ProductLandedCosts(lines, allocations)
+	if len(got) != 1 {
+		t.Fatalf("products = %d, want only the product the allocations touch", len(got))
+	}
+
+	p := got[0]
+	switch {
+	case p.ProductID != product || p.Quantity != 15:
+		t.Fatalf("product/quantity = %s/%d, want %s/15", p.ProductID, p.Quantity, product)
+	case p.Value != 60:
+		t.Fatalf("value = %v, want 60", p.Value)
+	case p.Charges != 15.5:
+		t.Fatalf("charges = %v, want 15.5 with the charges applied before", p.Charges)
+	case p.UnitCost != 5.03:
+		t.Fatalf("unit cost = %v, want 5.03", p.UnitCost)
+	}
+}
@@ -0,0 +1,88 @@
+package landedcostbus
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/delegate"
+)
+
+// DomainName represents the name of this domain for delegate events.
+const DomainName = "landedcost"
+
+// EntityName is the workflow entity name used for event matching.
+const EntityName = "landed_costs"
+
+// Delegate action constants.
+const (
+	ActionCreated = "created"
+	ActionUpdated = "updated"
+)
+
+// =============================================================================
+// Created Event
+// =============================================================================
+
+type ActionCreatedParms struct {
+	EntityID uuid.UUID  `json:"entityID"`
+	UserID   uuid.UUID  `json:"userID"`
+	Entity   LandedCost `json:"entity"`
+}
+
+func (p *ActionCreatedParms) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func ActionCreatedData(lc LandedCost) delegate.Data {
+	params := ActionCreatedParms{
+		EntityID: lc.ID,
+		UserID:   lc.CreatedBy,
+		Entity:   lc,
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    DomainName,
+		Action:    ActionCreated,
+		RawParams: rawParams,
+	}
+}
+
+// =============================================================================
+// Updated Event
+// =============================================================================
+
+type ActionUpdatedParms struct {
+	EntityID     uuid.UUID  `json:"entityID"`
+	UserID       uuid.UUID  `json:"userID"`
+	Entity       LandedCost `json:"entity"`
+	BeforeEntity LandedCost `json:"beforeEntity,omitempty"`
+}
+
+func (p *ActionUpdatedParms) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func ActionUpdatedData(before, after LandedCost) delegate.Data {
+	params := ActionUpdatedParms{
+		EntityID:     after.ID,
+		UserID:       after.UpdatedBy,
+		Entity:       after,
+		BeforeEntity: before,
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    DomainName,
+		Action:    ActionUpdated,
+		RawParams: rawParams,
+	}
+}
@@ -0,0 +1,11 @@
+package landedcostbus
+
+import "github.com/google/uuid"
+
+// QueryFilter holds optional filters for querying landed costs.
+type QueryFilter struct {
+	ID              *uuid.UUID
+	PurchaseOrderID *uuid.UUID
+	ReferenceNumber *string
+	Status          *string
+}
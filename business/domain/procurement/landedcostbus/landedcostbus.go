@@ -0,0 +1,535 @@
+// Package landedcostbus provides business access to landed costs: the
+// freight, duty, brokerage, insurance and other charges of a purchase order,
+// allocated across its received lines by value, quantity, weight or volume.
+// Applying a landed cost folds its charges into the landed cost of the
+// products received and records the new cost in the product's cost history.
+package landedcostbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus"
+	"github.com/timmaaaz/ichor/business/domain/products/costhistorybus"
+	costhistorytypes "github.com/timmaaaz/ichor/business/domain/products/costhistorybus/types"
+	"github.com/timmaaaz/ichor/business/domain/products/productcostbus"
+	productcosttypes "github.com/timmaaaz/ichor/business/domain/products/productcostbus/types"
+	"github.com/timmaaaz/ichor/business/sdk/delegate"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/outbox"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+	"github.com/timmaaaz/ichor/foundation/otel"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound            = errors.New("landed cost not found")
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+	ErrInvalidLandedCost   = errors.New("invalid landed cost")
+	ErrNotDraft            = errors.New("landed cost is already applied or cancelled")
+	ErrNothingReceived     = errors.New("nothing has been received to allocate charges to")
+	ErrNoAllocationBasis   = errors.New("no basis to allocate a charge on")
+	ErrNoProductCost       = errors.New("product has no cost record to land the cost on")
+)
+
+// openEnded is the end date of the cost history entry of a product's current
+// landed cost; the next landed cost applied to the product ends it.
+var openEnded = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	NewWithTx(tx sqldb.CommitRollbacker) (Storer, error)
+	Create(ctx context.Context, lc LandedCost) error
+	Update(ctx context.Context, lc LandedCost) error
+	ReplaceCharges(ctx context.Context, landedCostID uuid.UUID, charges []Charge) error
+	CreateAllocations(ctx context.Context, allocations []Allocation) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]LandedCost, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, landedCostID uuid.UUID) (LandedCost, error)
+	QueryReceivedLines(ctx context.Context, purchaseOrderID uuid.UUID, landedCostID uuid.UUID) ([]ReceivedLine, error)
+	LockPurchaseOrder(ctx context.Context, purchaseOrderID uuid.UUID) error
+}
+
+// Business manages the set of APIs for landed cost access.
+type Business struct {
+	log              *logger.Logger
+	storer           Storer
+	delegate         *delegate.Delegate
+	outbox           *outbox.Writer
+	purchaseOrderBus *purchaseorderbus.Business
+	productCostBus   *productcostbus.Business
+	costHistoryBus   *costhistorybus.Business
+}
+
+// NewBusiness constructs a landed cost business API for use. The purchase
+// order bus checks the order charges are landed on; the product cost and
+// cost history buses receive the landed unit costs when one is applied.
+func NewBusiness(log *logger.Logger, delegate *delegate.Delegate, storer Storer, purchaseOrderBus *purchaseorderbus.Business, productCostBus *productcostbus.Business, costHistoryBus *costhistorybus.Business) *Business {
+	return &Business{
+		log:              log,
+		delegate:         delegate,
+		storer:           storer,
+		purchaseOrderBus: purchaseOrderBus,
+		productCostBus:   productCostBus,
+		costHistoryBus:   costHistoryBus,
+	}
+}
+
+// WithOutbox returns a copy of the Business wired to the cascade outbox Writer.
+// Inert until the Writer is injected at the F2 cutover (nil Writer -> Emit no-ops).
+func (b *Business) WithOutbox(w *outbox.Writer) *Business {
+	nb := *b
+	nb.outbox = w
+	return &nb
+}
+
+// NewWithTx constructs a new Business value replacing the Storer
+// value with a Storer value that is currently inside a transaction.
+func (b *Business) NewWithTx(tx sqldb.CommitRollbacker) (*Business, error) {
+	storer, err := b.storer.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	purchaseOrderBus, err := b.purchaseOrderBus.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	productCostBus, err := b.productCostBus.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	costHistoryBus, err := b.costHistoryBus.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	nb := *b
+	nb.storer = storer
+	nb.purchaseOrderBus = purchaseOrderBus
+	nb.productCostBus = productCostBus
+	nb.costHistoryBus = costHistoryBus
+	return &nb, nil
+}
+
+// Create enters a draft landed cost on a purchase order. Its line items, when
+// it names any, must be on the order; without a currency it takes the
+// order's.
+func (b *Business) Create(ctx context.Context, nlc NewLandedCost, now time.Time) (LandedCost, error) {
+	ctx, span := otel.AddSpan(ctx, "business.landedcostbus.create")
+	defer span.End()
+
+	if err := validateCharges(nlc.Charges); err != nil {
+		return LandedCost{}, fmt.Errorf("create: %w", err)
+	}
+
+	po, err := b.purchaseOrderBus.QueryByID(ctx, nlc.PurchaseOrderID)
+	if err != nil {
+		if errors.Is(err, purchaseorderbus.ErrNotFound) {
+			return LandedCost{}, fmt.Errorf("create: %w: purchase order not found", ErrInvalidLandedCost)
+		}
+		return LandedCost{}, fmt.Errorf("create: purchase order: %w", err)
+	}
+
+	if err := b.validateLineItems(ctx, po.ID, nlc.LineItemIDs); err != nil {
+		return LandedCost{}, fmt.Errorf("create: %w", err)
+	}
+
+	currencyID := po.CurrencyID
+	if nlc.CurrencyID != nil {
+		currencyID = *nlc.CurrencyID
+	}
+
+	lc := LandedCost{
+		ID:              uuid.New(),
+		PurchaseOrderID: po.ID,
+		ReferenceNumber: nlc.ReferenceNumber,
+		CurrencyID:      currencyID,
+		LineItemIDs:     lineItemIDs(nlc.LineItemIDs),
+		Status:          StatusDraft,
+		Notes:           nlc.Notes,
+		CreatedBy:       nlc.CreatedBy,
+		UpdatedBy:       nlc.CreatedBy,
+		CreatedDate:     now,
+		UpdatedDate:     now,
+		Allocations:     []Allocation{},
+	}
+	lc.setCharges(nlc.Charges)
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (LandedCost, error) {
+			if err := b.storer.Create(ctx, lc); err != nil {
+				return LandedCost{}, fmt.Errorf("create: %w", err)
+			}
+
+			evtData := ActionCreatedData(lc)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return LandedCost{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionCreatedData(lc)); err != nil {
+				b.log.Error(ctx, "landedcostbus: delegate call failed", "action", ActionCreated, "err", err)
+			}
+
+			return lc, nil
+		})
+}
+
+// Update modifies a draft landed cost. Returns ErrNotDraft when it has been
+// applied or cancelled.
+func (b *Business) Update(ctx context.Context, lc LandedCost, ulc UpdateLandedCost, now time.Time) (LandedCost, error) {
+	ctx, span := otel.AddSpan(ctx, "business.landedcostbus.update")
+	defer span.End()
+
+	if lc.Status != StatusDraft {
+		return LandedCost{}, fmt.Errorf("update: %w", ErrNotDraft)
+	}
+
+	if ulc.Charges != nil {
+		if err := validateCharges(ulc.Charges); err != nil {
+			return LandedCost{}, fmt.Errorf("update: %w", err)
+		}
+	}
+
+	if ulc.LineItemIDs != nil {
+		if err := b.validateLineItems(ctx, lc.PurchaseOrderID, ulc.LineItemIDs); err != nil {
+			return LandedCost{}, fmt.Errorf("update: %w", err)
+		}
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (LandedCost, error) {
+			return b.update(ctx, lc, func(lc *LandedCost) error {
+				if ulc.ReferenceNumber != nil {
+					lc.ReferenceNumber = *ulc.ReferenceNumber
+				}
+				if ulc.LineItemIDs != nil {
+					lc.LineItemIDs = lineItemIDs(ulc.LineItemIDs)
+				}
+				if ulc.Notes != nil {
+					lc.Notes = *ulc.Notes
+				}
+				if ulc.Charges != nil {
+					lc.setCharges(ulc.Charges)
+					if err := b.storer.ReplaceCharges(ctx, lc.ID, lc.Charges); err != nil {
+						return fmt.Errorf("charges: %w", err)
+					}
+				}
+				lc.UpdatedBy = ulc.UpdatedBy
+				lc.UpdatedDate = now
+				return nil
+			})
+		})
+}
+
+// Preview allocates the charges of a draft across what has been received so
+// far without storing anything, and returns the landed cost with those
+// allocations and the landed unit cost each product would get.
+func (b *Business) Preview(ctx context.Context, lc LandedCost) (LandedCost, []ProductLandedCost, error) {
+	ctx, span := otel.AddSpan(ctx, "business.landedcostbus.preview")
+	defer span.End()
+
+	if lc.Status != StatusDraft {
+		return LandedCost{}, nil, fmt.Errorf("preview: %w", ErrNotDraft)
+	}
+
+	lines, err := b.storer.QueryReceivedLines(ctx, lc.PurchaseOrderID, lc.ID)
+	if err != nil {
+		return LandedCost{}, nil, fmt.Errorf("preview: received lines: %w", err)
+	}
+
+	allocations, err := Allocate(lc, lines)
+	if err != nil {
+		return LandedCost{}, nil, fmt.Errorf("preview: %w", err)
+	}
+
+	lc.Allocations = allocations
+	return lc, ProductLandedCosts(lines, allocations), nil
+}
+
+// Apply allocates the charges of a draft across what has been received,
+// stores the allocations and sets the landed cost of each product received to
+// its unit cost with every applied charge, closing its previous landed cost in
+// the cost history and recording the new one. The product's latest cost
+// record takes the new landed cost. Landed costs on one purchase order are
+// applied one at a time so each sees the charges applied before it. Returns
+// ErrNotDraft when the landed cost has been applied or cancelled and
+// ErrNoProductCost when a product received has no cost record, so nothing is
+// applied until one is entered.
+func (b *Business) Apply(ctx context.Context, lc LandedCost, appliedBy uuid.UUID, now time.Time) (LandedCost, error) {
+	ctx, span := otel.AddSpan(ctx, "business.landedcostbus.apply")
+	defer span.End()
+
+	if lc.Status != StatusDraft {
+		return LandedCost{}, fmt.Errorf("apply: %w", ErrNotDraft)
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (LandedCost, error) {
+			if err := b.storer.LockPurchaseOrder(ctx, lc.PurchaseOrderID); err != nil {
+				return LandedCost{}, fmt.Errorf("apply: lock: %w", err)
+			}
+
+			lines, err := b.storer.QueryReceivedLines(ctx, lc.PurchaseOrderID, lc.ID)
+			if err != nil {
+				return LandedCost{}, fmt.Errorf("apply: received lines: %w", err)
+			}
+
+			allocations, err := Allocate(lc, lines)
+			if err != nil {
+				return LandedCost{}, fmt.Errorf("apply: %w", err)
+			}
+
+			if err := b.storer.CreateAllocations(ctx, allocations); err != nil {
+				return LandedCost{}, fmt.Errorf("apply: allocations: %w", err)
+			}
+
+			for _, plc := range ProductLandedCosts(lines, allocations) {
+				if err := b.landProductCost(ctx, plc, lc.CurrencyID, now); err != nil {
+					return LandedCost{}, fmt.Errorf("apply: product[%s]: %w", plc.ProductID, err)
+				}
+			}
+
+			return b.update(ctx, lc, func(lc *LandedCost) error {
+				lc.Status = StatusApplied
+				lc.Allocations = allocations
+				lc.AppliedBy = &appliedBy
+				lc.AppliedDate = &now
+				lc.UpdatedBy = appliedBy
+				lc.UpdatedDate = now
+				return nil
+			})
+		})
+}
+
+// Cancel abandons a draft landed cost. Returns ErrNotDraft when it has been
+// applied or cancelled.
+func (b *Business) Cancel(ctx context.Context, lc LandedCost, cancelledBy uuid.UUID, now time.Time) (LandedCost, error) {
+	ctx, span := otel.AddSpan(ctx, "business.landedcostbus.cancel")
+	defer span.End()
+
+	if lc.Status != StatusDraft {
+		return LandedCost{}, fmt.Errorf("cancel: %w", ErrNotDraft)
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (LandedCost, error) {
+			return b.update(ctx, lc, func(lc *LandedCost) error {
+				lc.Status = StatusCancelled
+				lc.UpdatedBy = cancelledBy
+				lc.UpdatedDate = now
+				return nil
+			})
+		})
+}
+
+// Query retrieves a list of landed costs, with their charges and
+// allocations, from the system.
+func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]LandedCost, error) {
+	ctx, span := otel.AddSpan(ctx, "business.landedcostbus.query")
+	defer span.End()
+
+	lcs, err := b.storer.Query(ctx, filter, orderBy, page)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return lcs, nil
+}
+
+// Count returns the total number of landed costs matching the filter.
+func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.landedcostbus.count")
+	defer span.End()
+
+	return b.storer.Count(ctx, filter)
+}
+
+// QueryByID retrieves a single landed cost, with its charges and
+// allocations, by its ID.
+func (b *Business) QueryByID(ctx context.Context, landedCostID uuid.UUID) (LandedCost, error) {
+	ctx, span := otel.AddSpan(ctx, "business.landedcostbus.querybyid")
+	defer span.End()
+
+	lc, err := b.storer.QueryByID(ctx, landedCostID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return LandedCost{}, err
+		}
+		return LandedCost{}, fmt.Errorf("queryByID: landedCostID[%s]: %w", landedCostID, err)
+	}
+
+	return lc, nil
+}
+
+// =============================================================================
+
+// update applies fn to the landed cost, stores its header and emits the
+// updated event. It must run inside WriteAtomic.
+func (b *Business) update(ctx context.Context, lc LandedCost, fn func(*LandedCost) error) (LandedCost, error) {
+	before := lc
+	if err := fn(&lc); err != nil {
+		return LandedCost{}, fmt.Errorf("update: %w", err)
+	}
+
+	if err := b.storer.Update(ctx, lc); err != nil {
+		return LandedCost{}, fmt.Errorf("update: %w", err)
+	}
+
+	evtData := ActionUpdatedData(before, lc)
+	if err := b.outbox.Emit(ctx, evtData); err != nil {
+		return LandedCost{}, fmt.Errorf("emit cascade event: %w", err)
+	}
+	if err := b.delegate.Call(ctx, ActionUpdatedData(before, lc)); err != nil {
+		b.log.Error(ctx, "landedcostbus: delegate call failed", "action", ActionUpdated, "err", err)
+	}
+
+	return lc, nil
+}
+
+// landProductCost sets a product's landed cost on its latest cost record and
+// moves its cost history on to the new landed cost. Returns ErrNoProductCost
+// when the product has no cost record.
+func (b *Business) landProductCost(ctx context.Context, plc ProductLandedCost, currencyID uuid.UUID, now time.Time) error {
+	amount := fmt.Sprintf("%.2f", plc.UnitCost)
+
+	costs, err := b.productCostBus.Query(ctx, productcostbus.QueryFilter{ProductID: &plc.ProductID},
+		order.NewBy(productcostbus.OrderByEffectiveDate, order.DESC), page.MustParse("1", "1"))
+	if err != nil {
+		return fmt.Errorf("product cost: %w", err)
+	}
+
+	if len(costs) == 0 {
+		return ErrNoProductCost
+	}
+
+	landed, err := productcosttypes.ParseMoney(amount)
+	if err != nil {
+		return fmt.Errorf("landed cost: %w", err)
+	}
+
+	if _, err := b.productCostBus.Update(ctx, costs[0], productcostbus.UpdateProductCost{LandedCost: &landed}); err != nil {
+		return fmt.Errorf("product cost: %w", err)
+	}
+
+	costType := CostType
+	history, err := b.costHistoryBus.Query(ctx, costhistorybus.QueryFilter{ProductID: &plc.ProductID, CostType: &costType},
+		order.NewBy(costhistorybus.OrderByEffectiveDate, order.DESC), page.MustParse("1", "100"))
+	if err != nil {
+		return fmt.Errorf("cost history: %w", err)
+	}
+
+	for _, ch := range history {
+		if !ch.EndDate.After(now) {
+			continue
+		}
+		if _, err := b.costHistoryBus.Update(ctx, ch, costhistorybus.UpdateCostHistory{EndDate: &now}); err != nil {
+			return fmt.Errorf("close cost history: %w", err)
+		}
+	}
+
+	money, err := costhistorytypes.ParseMoney(amount)
+	if err != nil {
+		return fmt.Errorf("cost history amount: %w", err)
+	}
+
+	nch := costhistorybus.NewCostHistory{
+		ProductID:     plc.ProductID,
+		CostType:      CostType,
+		Amount:        money,
+		CurrencyID:    currencyID,
+		EffectiveDate: now,
+		EndDate:       openEnded,
+		CreatedDate:   &now,
+	}
+
+	if _, err := b.costHistoryBus.Create(ctx, nch); err != nil {
+		return fmt.Errorf("cost history: %w", err)
+	}
+
+	return nil
+}
+
+// validateLineItems checks that every named line item is on the purchase
+// order.
+func (b *Business) validateLineItems(ctx context.Context, purchaseOrderID uuid.UUID, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	lines, err := b.storer.QueryReceivedLines(ctx, purchaseOrderID, uuid.Nil)
+	if err != nil {
+		return fmt.Errorf("line items: %w", err)
+	}
+
+	onOrder := make(map[uuid.UUID]bool, len(lines))
+	for _, l := range lines {
+		onOrder[l.ID] = true
+	}
+
+	for _, id := range ids {
+		if !onOrder[id] {
+			return fmt.Errorf("%w: line item %s is not on the purchase order", ErrInvalidLandedCost, id)
+		}
+	}
+
+	return nil
+}
+
+// setCharges replaces the charges of the landed cost and totals them.
+func (lc *LandedCost) setCharges(ncs []NewCharge) {
+	lc.Charges = make([]Charge, len(ncs))
+	var total float64
+	for i, nc := range ncs {
+		lc.Charges[i] = Charge{
+			ID:               uuid.New(),
+			LandedCostID:     lc.ID,
+			ChargeType:       nc.ChargeType,
+			AllocationMethod: nc.AllocationMethod,
+			Amount:           roundCents(nc.Amount),
+			Description:      nc.Description,
+		}
+		total += lc.Charges[i].Amount
+	}
+	lc.TotalCharges = roundCents(total)
+}
+
+func lineItemIDs(ids []uuid.UUID) []uuid.UUID {
+	if ids == nil {
+		return []uuid.UUID{}
+	}
+	return ids
+}
+
+func validateCharges(ncs []NewCharge) error {
+	if len(ncs) == 0 {
+		return fmt.Errorf("%w: a landed cost needs at least one charge", ErrInvalidLandedCost)
+	}
+
+	for _, nc := range ncs {
+		switch nc.ChargeType {
+		case ChargeFreight, ChargeDuty, ChargeBrokerage, ChargeInsurance, ChargeOther:
+		default:
+			return fmt.Errorf("%w: unknown charge_type %q", ErrInvalidLandedCost, nc.ChargeType)
+		}
+
+		switch nc.AllocationMethod {
+		case AllocateByValue, AllocateByQuantity, AllocateByWeight, AllocateByVolume:
+		default:
+			return fmt.Errorf("%w: unknown allocation_method %q", ErrInvalidLandedCost, nc.AllocationMethod)
+		}
+
+		if roundCents(nc.Amount) <= 0 {
+			return fmt.Errorf("%w: charge amount must be positive", ErrInvalidLandedCost)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package landedcostbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JSON tags are required for workflow event serialization. The workflow system
+// marshals business models to JSON for RawData in TriggerEvents.
+
+// Landed cost statuses. A landed cost is a draft until it is applied to the
+// product costs or cancelled; only drafts can change.
+const (
+	StatusDraft     = "draft"
+	StatusApplied   = "applied"
+	StatusCancelled = "cancelled"
+)
+
+// Charge types.
+const (
+	ChargeFreight   = "freight"
+	ChargeDuty      = "duty"
+	ChargeBrokerage = "brokerage"
+	ChargeInsurance = "insurance"
+	ChargeOther     = "other"
+)
+
+// Allocation methods say what share of a charge each received line carries:
+// its received value at the ordered cost, its received quantity, or its
+// received weight or volume from the product's physical attributes.
+const (
+	AllocateByValue    = "value"
+	AllocateByQuantity = "quantity"
+	AllocateByWeight   = "weight"
+	AllocateByVolume   = "volume"
+)
+
+// CostType is the cost_history cost type of landed unit costs.
+const CostType = "landed_cost"
+
+// LandedCost is a set of charges on a purchase order, or on the receipt of
+// some of its line items, to allocate across what was received.
+type LandedCost struct {
+	ID              uuid.UUID    `json:"id"`
+	PurchaseOrderID uuid.UUID    `json:"purchase_order_id"`
+	ReferenceNumber string       `json:"reference_number"`
+	CurrencyID      uuid.UUID    `json:"currency_id"`
+	LineItemIDs     []uuid.UUID  `json:"line_item_ids"` // empty means every line item of the order
+	Status          string       `json:"status"`
+	TotalCharges    float64      `json:"total_charges"`
+	Notes           string       `json:"notes"`
+	AppliedBy       *uuid.UUID   `json:"applied_by,omitempty"`
+	AppliedDate     *time.Time   `json:"applied_date,omitempty"`
+	CreatedBy       uuid.UUID    `json:"created_by"`
+	UpdatedBy       uuid.UUID    `json:"updated_by"`
+	CreatedDate     time.Time    `json:"created_date"`
+	UpdatedDate     time.Time    `json:"updated_date"`
+	Charges         []Charge     `json:"charges"`
+	Allocations     []Allocation `json:"allocations"`
+}
+
+// Charge is one freight, duty, brokerage, insurance or other charge and how
+// to allocate it.
+type Charge struct {
+	ID               uuid.UUID `json:"id"`
+	LandedCostID     uuid.UUID `json:"landed_cost_id"`
+	ChargeType       string    `json:"charge_type"`
+	AllocationMethod string    `json:"allocation_method"`
+	Amount           float64   `json:"amount"`
+	Description      string    `json:"description"`
+}
+
+// Allocation is the share of a charge carried by one received line. Basis is
+// the line's value, quantity, weight in kilograms or volume.
+type Allocation struct {
+	ID                      uuid.UUID `json:"id"`
+	LandedCostID            uuid.UUID `json:"landed_cost_id"`
+	ChargeID                uuid.UUID `json:"charge_id"`
+	PurchaseOrderLineItemID uuid.UUID `json:"purchase_order_line_item_id"`
+	ProductID               uuid.UUID `json:"product_id"`
+	Quantity                int       `json:"quantity"`
+	Basis                   float64   `json:"basis"`
+	Amount                  float64   `json:"amount"`
+}
+
+// NewLandedCost is what we require from clients when creating a landed cost.
+// CurrencyID defaults to the purchase order's currency.
+type NewLandedCost struct {
+	PurchaseOrderID uuid.UUID
+	ReferenceNumber string
+	CurrencyID      *uuid.UUID
+	LineItemIDs     []uuid.UUID
+	Charges         []NewCharge
+	Notes           string
+	CreatedBy       uuid.UUID
+}
+
+// NewCharge is what we require from clients for each charge.
+type NewCharge struct {
+	ChargeType       string
+	AllocationMethod string
+	Amount           float64
+	Description      string
+}
+
+// UpdateLandedCost defines what can change on a draft. Charges and
+// LineItemIDs replace the existing ones when not nil.
+type UpdateLandedCost struct {
+	ReferenceNumber *string
+	LineItemIDs     []uuid.UUID
+	Charges         []NewCharge
+	Notes           *string
+	UpdatedBy       uuid.UUID
+}
+
+// ReceivedLine is a purchase order line item with what allocation needs: the
+// received quantity, the ordered cost, the product's physical attributes and
+// the charges already applied to it by other landed costs.
+type ReceivedLine struct {
+	ID               uuid.UUID
+	PurchaseOrderID  uuid.UUID
+	ProductID        uuid.UUID
+	QuantityOrdered  int
+	QuantityReceived int
+	UnitCost         float64
+	Discount         float64
+	Length           float64
+	Width            float64
+	Height           float64
+	Weight           float64
+	WeightUnit       string
+	Applied          float64
+}
+
+// ProductLandedCost is the landed unit cost of a product over the received
+// lines a landed cost was allocated to.
+type ProductLandedCost struct {
+	ProductID uuid.UUID
+	Quantity  int
+	Value     float64 // received quantity at the ordered cost
+	Charges   float64 // every charge applied to those lines, this one included
+	UnitCost  float64
+}
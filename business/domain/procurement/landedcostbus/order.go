@@ -0,0 +1,16 @@
+package landedcostbus
+
+import "github.com/timmaaaz/ichor/business/sdk/order"
+
+// DefaultOrderBy represents the default ordering for landed cost queries.
+var DefaultOrderBy = order.NewBy(OrderByCreatedDate, order.DESC)
+
+const (
+	OrderByID              = "id"
+	OrderByPurchaseOrderID = "purchase_order_id"
+	OrderByReferenceNumber = "reference_number"
+	OrderByStatus          = "status"
+	OrderByTotalCharges    = "total_charges"
+	OrderByAppliedDate     = "applied_date"
+	OrderByCreatedDate     = "created_date"
+)
@@ -0,0 +1,37 @@
+package landedcostdb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/timmaaaz/ichor/business/domain/procurement/landedcostbus"
+)
+
+func applyFilter(filter landedcostbus.QueryFilter, data map[string]any, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["id"] = *filter.ID
+		wc = append(wc, "id = :id")
+	}
+
+	if filter.PurchaseOrderID != nil {
+		data["purchase_order_id"] = *filter.PurchaseOrderID
+		wc = append(wc, "purchase_order_id = :purchase_order_id")
+	}
+
+	if filter.ReferenceNumber != nil {
+		data["reference_number"] = "%" + *filter.ReferenceNumber + "%"
+		wc = append(wc, "reference_number ILIKE :reference_number")
+	}
+
+	if filter.Status != nil {
+		data["status"] = *filter.Status
+		wc = append(wc, "status = :status")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
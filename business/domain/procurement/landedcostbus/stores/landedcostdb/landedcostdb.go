@@ -0,0 +1,381 @@
+// Package landedcostdb contains landed cost related CRUD functionality.
+package landedcostdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/business/domain/procurement/landedcostbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// Store manages the set of APIs for landed cost database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// NewWithTx constructs a new Store value replacing the sqlx.DB
+// value with a sqlx.Tx value that is currently inside a transaction.
+func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (landedcostbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		log: s.log,
+		db:  ec,
+	}, nil
+}
+
+// Create inserts a new landed cost and its charges into the database.
+func (s *Store) Create(ctx context.Context, lc landedcostbus.LandedCost) error {
+	const q = `
+	INSERT INTO procurement.landed_costs
+		(id, purchase_order_id, reference_number, currency_id, line_item_ids, status, total_charges, notes,
+		 applied_by, applied_date, created_by, updated_by, created_date, updated_date)
+	VALUES
+		(:id, :purchase_order_id, :reference_number, :currency_id, :line_item_ids, :status, :total_charges, :notes,
+		 :applied_by, :applied_date, :created_by, :updated_by, :created_date, :updated_date)
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBLandedCost(lc)); err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return fmt.Errorf("namedexeccontext: %w", landedcostbus.ErrForeignKeyViolation)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return s.createCharges(ctx, lc.Charges)
+}
+
+// Update modifies the header of an existing landed cost in the database.
+func (s *Store) Update(ctx context.Context, lc landedcostbus.LandedCost) error {
+	const q = `
+	UPDATE procurement.landed_costs
+	SET
+		reference_number = :reference_number,
+		currency_id      = :currency_id,
+		line_item_ids    = :line_item_ids,
+		status           = :status,
+		total_charges    = :total_charges,
+		notes            = :notes,
+		applied_by       = :applied_by,
+		applied_date     = :applied_date,
+		updated_by       = :updated_by,
+		updated_date     = :updated_date
+	WHERE
+		id = :id
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBLandedCost(lc)); err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return fmt.Errorf("namedexeccontext: %w", landedcostbus.ErrForeignKeyViolation)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceCharges deletes the charges of a landed cost and inserts new ones.
+func (s *Store) ReplaceCharges(ctx context.Context, landedCostID uuid.UUID, charges []landedcostbus.Charge) error {
+	data := map[string]any{
+		"landed_cost_id": landedCostID,
+	}
+
+	const q = `DELETE FROM procurement.landed_cost_charges WHERE landed_cost_id = :landed_cost_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, data); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return s.createCharges(ctx, charges)
+}
+
+// CreateAllocations inserts the allocations of an applied landed cost.
+func (s *Store) CreateAllocations(ctx context.Context, allocations []landedcostbus.Allocation) error {
+	const q = `
+	INSERT INTO procurement.landed_cost_allocations
+		(id, landed_cost_id, charge_id, purchase_order_line_item_id, product_id, quantity, basis, amount)
+	VALUES
+		(:id, :landed_cost_id, :charge_id, :purchase_order_line_item_id, :product_id, :quantity, :basis, :amount)
+	`
+
+	for _, a := range allocations {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBAllocation(a)); err != nil {
+			if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+				return fmt.Errorf("namedexeccontext: %w", landedcostbus.ErrForeignKeyViolation)
+			}
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Query retrieves a list of landed costs, with their charges and
+// allocations, from the database.
+func (s *Store) Query(ctx context.Context, filter landedcostbus.QueryFilter, orderBy order.By, page page.Page) ([]landedcostbus.LandedCost, error) {
+	data := map[string]any{
+		"offset":        (page.Number() - 1) * page.RowsPerPage(),
+		"rows_per_page": page.RowsPerPage(),
+	}
+
+	const q = `
+	SELECT
+		id, purchase_order_id, reference_number, currency_id, line_item_ids, status, total_charges, notes,
+		applied_by, applied_date, created_by, updated_by, created_date, updated_date
+	FROM
+		procurement.landed_costs
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbLCs []landedCost
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbLCs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	lcs, err := toBusLandedCosts(dbLCs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachDetails(ctx, lcs); err != nil {
+		return nil, err
+	}
+
+	return lcs, nil
+}
+
+// Count returns the total number of landed costs matching the filter.
+func (s *Store) Count(ctx context.Context, filter landedcostbus.QueryFilter) (int, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		COUNT(1) AS count
+	FROM
+		procurement.landed_costs
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID retrieves a single landed cost, with its charges and
+// allocations, by its ID.
+func (s *Store) QueryByID(ctx context.Context, landedCostID uuid.UUID) (landedcostbus.LandedCost, error) {
+	data := map[string]any{
+		"id": landedCostID.String(),
+	}
+
+	const q = `
+	SELECT
+		id, purchase_order_id, reference_number, currency_id, line_item_ids, status, total_charges, notes,
+		applied_by, applied_date, created_by, updated_by, created_date, updated_date
+	FROM
+		procurement.landed_costs
+	WHERE
+		id = :id
+	`
+
+	var dbLC landedCost
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbLC); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return landedcostbus.LandedCost{}, landedcostbus.ErrNotFound
+		}
+		return landedcostbus.LandedCost{}, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	lc, err := toBusLandedCost(dbLC)
+	if err != nil {
+		return landedcostbus.LandedCost{}, err
+	}
+
+	lcs := []landedcostbus.LandedCost{lc}
+	if err := s.attachDetails(ctx, lcs); err != nil {
+		return landedcostbus.LandedCost{}, err
+	}
+
+	return lcs[0], nil
+}
+
+// QueryReceivedLines retrieves the line items of a purchase order with their
+// product, the product's latest physical attributes and the charges landed
+// costs other than landedCostID have applied to each.
+func (s *Store) QueryReceivedLines(ctx context.Context, purchaseOrderID uuid.UUID, landedCostID uuid.UUID) ([]landedcostbus.ReceivedLine, error) {
+	data := map[string]any{
+		"purchase_order_id": purchaseOrderID,
+		"landed_cost_id":    landedCostID,
+		"status":            landedcostbus.StatusApplied,
+	}
+
+	const q = `
+	SELECT
+		li.id, li.purchase_order_id, sp.product_id, li.quantity_ordered, li.quantity_received, li.unit_cost,
+		COALESCE(li.discount, 0) AS discount,
+		COALESCE(pa.length, 0) AS length,
+		COALESCE(pa.width, 0) AS width,
+		COALESCE(pa.height, 0) AS height,
+		COALESCE(pa.weight, 0) AS weight,
+		COALESCE(pa.weight_unit, '') AS weight_unit,
+		COALESCE((
+			SELECT SUM(a.amount)
+			FROM procurement.landed_cost_allocations a
+			JOIN procurement.landed_costs lc ON lc.id = a.landed_cost_id
+			WHERE a.purchase_order_line_item_id = li.id
+				AND lc.id <> :landed_cost_id
+				AND lc.status = :status
+		), 0) AS applied
+	FROM procurement.purchase_order_line_items li
+	JOIN procurement.supplier_products sp ON sp.id = li.supplier_product_id
+	LEFT JOIN LATERAL (
+		SELECT length, width, height, weight, weight_unit
+		FROM products.physical_attributes
+		WHERE product_id = sp.product_id
+		ORDER BY updated_date DESC
+		LIMIT 1
+	) pa ON true
+	WHERE li.purchase_order_id = :purchase_order_id
+	ORDER BY li.id`
+
+	var dbLines []receivedLine
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbLines); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusReceivedLines(dbLines), nil
+}
+
+// LockPurchaseOrder takes a transaction-scoped advisory lock on a purchase
+// order, so two landed costs on it cannot be applied at once.
+func (s *Store) LockPurchaseOrder(ctx context.Context, purchaseOrderID uuid.UUID) error {
+	data := map[string]any{
+		"key": "procurement.landed_costs:" + purchaseOrderID.String(),
+	}
+
+	const q = `SELECT pg_advisory_xact_lock(hashtext(:key))`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, data); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// =============================================================================
+
+func (s *Store) createCharges(ctx context.Context, charges []landedcostbus.Charge) error {
+	const q = `
+	INSERT INTO procurement.landed_cost_charges
+		(id, landed_cost_id, charge_type, allocation_method, amount, description)
+	VALUES
+		(:id, :landed_cost_id, :charge_type, :allocation_method, :amount, :description)
+	`
+
+	for _, c := range charges {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBCharge(c)); err != nil {
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// attachDetails loads the charges and allocations of the landed costs.
+func (s *Store) attachDetails(ctx context.Context, lcs []landedcostbus.LandedCost) error {
+	if len(lcs) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(lcs))
+	byID := make(map[uuid.UUID]int, len(lcs))
+	for i, lc := range lcs {
+		ids[i] = lc.ID
+		byID[lc.ID] = i
+		lcs[i].Charges = []landedcostbus.Charge{}
+		lcs[i].Allocations = []landedcostbus.Allocation{}
+	}
+
+	data := map[string]any{
+		"ids": ids,
+	}
+
+	const qc = `
+	SELECT
+		id, landed_cost_id, charge_type, allocation_method, amount, description
+	FROM
+		procurement.landed_cost_charges
+	WHERE
+		landed_cost_id = ANY(:ids)
+	ORDER BY
+		charge_type, id
+	`
+
+	var dbCharges []charge
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, qc, data, &dbCharges); err != nil {
+		return fmt.Errorf("namedqueryslice: charges: %w", err)
+	}
+	for _, c := range dbCharges {
+		i := byID[c.LandedCostID]
+		lcs[i].Charges = append(lcs[i].Charges, toBusCharge(c))
+	}
+
+	const qa = `
+	SELECT
+		id, landed_cost_id, charge_id, purchase_order_line_item_id, product_id, quantity, basis, amount
+	FROM
+		procurement.landed_cost_allocations
+	WHERE
+		landed_cost_id = ANY(:ids)
+	ORDER BY
+		charge_id, purchase_order_line_item_id
+	`
+
+	var dbAllocations []allocation
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, qa, data, &dbAllocations); err != nil {
+		return fmt.Errorf("namedqueryslice: allocations: %w", err)
+	}
+	for _, a := range dbAllocations {
+		i := byID[a.LandedCostID]
+		lcs[i].Allocations = append(lcs[i].Allocations, toBusAllocation(a))
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package landedcostdb
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/procurement/landedcostbus"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb/dbarray"
+)
+
+// landedCost mirrors the procurement.landed_costs DB row.
+type landedCost struct {
+	ID              uuid.UUID      `db:"id"`
+	PurchaseOrderID uuid.UUID      `db:"purchase_order_id"`
+	ReferenceNumber sql.NullString `db:"reference_number"`
+	CurrencyID      uuid.UUID      `db:"currency_id"`
+	LineItemIDs     dbarray.String `db:"line_item_ids"`
+	Status          string         `db:"status"`
+	TotalCharges    float64        `db:"total_charges"`
+	Notes           sql.NullString `db:"notes"`
+	AppliedBy       uuid.NullUUID  `db:"applied_by"`
+	AppliedDate     sql.NullTime   `db:"applied_date"`
+	CreatedBy       uuid.UUID      `db:"created_by"`
+	UpdatedBy       uuid.UUID      `db:"updated_by"`
+	CreatedDate     time.Time      `db:"created_date"`
+	UpdatedDate     time.Time      `db:"updated_date"`
+}
+
+func toDBLandedCost(bus landedcostbus.LandedCost) landedCost {
+	lineItemIDs := make(dbarray.String, len(bus.LineItemIDs))
+	for i, id := range bus.LineItemIDs {
+		lineItemIDs[i] = id.String()
+	}
+
+	return landedCost{
+		ID:              bus.ID,
+		PurchaseOrderID: bus.PurchaseOrderID,
+		ReferenceNumber: sql.NullString{String: bus.ReferenceNumber, Valid: bus.ReferenceNumber != ""},
+		CurrencyID:      bus.CurrencyID,
+		LineItemIDs:     lineItemIDs,
+		Status:          bus.Status,
+		TotalCharges:    bus.TotalCharges,
+		Notes:           sql.NullString{String: bus.Notes, Valid: bus.Notes != ""},
+		AppliedBy:       toNullUUID(bus.AppliedBy),
+		AppliedDate:     toNullTime(bus.AppliedDate),
+		CreatedBy:       bus.CreatedBy,
+		UpdatedBy:       bus.UpdatedBy,
+		CreatedDate:     bus.CreatedDate.UTC(),
+		UpdatedDate:     bus.UpdatedDate.UTC(),
+	}
+}
+
+func toBusLandedCost(db landedCost) (landedcostbus.LandedCost, error) {
+	lineItemIDs := make([]uuid.UUID, len(db.LineItemIDs))
+	for i, s := range db.LineItemIDs {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return landedcostbus.LandedCost{}, fmt.Errorf("parse line item UUID at index %d: %w", i, err)
+		}
+		lineItemIDs[i] = id
+	}
+
+	return landedcostbus.LandedCost{
+		ID:              db.ID,
+		PurchaseOrderID: db.PurchaseOrderID,
+		ReferenceNumber: db.ReferenceNumber.String,
+		CurrencyID:      db.CurrencyID,
+		LineItemIDs:     lineItemIDs,
+		Status:          db.Status,
+		TotalCharges:    db.TotalCharges,
+		Notes:           db.Notes.String,
+		AppliedBy:       fromNullUUID(db.AppliedBy),
+		AppliedDate:     fromNullTime(db.AppliedDate),
+		CreatedBy:       db.CreatedBy,
+		UpdatedBy:       db.UpdatedBy,
+		CreatedDate:     db.CreatedDate.In(time.Local),
+		UpdatedDate:     db.UpdatedDate.In(time.Local),
+	}, nil
+}
+
+func toBusLandedCosts(dbs []landedCost) ([]landedcostbus.LandedCost, error) {
+	lcs := make([]landedcostbus.LandedCost, len(dbs))
+	for i, db := range dbs {
+		lc, err := toBusLandedCost(db)
+		if err != nil {
+			return nil, err
+		}
+		lcs[i] = lc
+	}
+	return lcs, nil
+}
+
+// charge mirrors the procurement.landed_cost_charges DB row.
+type charge struct {
+	ID               uuid.UUID      `db:"id"`
+	LandedCostID     uuid.UUID      `db:"landed_cost_id"`
+	ChargeType       string         `db:"charge_type"`
+	AllocationMethod string         `db:"allocation_method"`
+	Amount           float64        `db:"amount"`
+	Description      sql.NullString `db:"description"`
+}
+
+func toDBCharge(bus landedcostbus.Charge) charge {
+	return charge{
+		ID:               bus.ID,
+		LandedCostID:     bus.LandedCostID,
+		ChargeType:       bus.ChargeType,
+		AllocationMethod: bus.AllocationMethod,
+		Amount:           bus.Amount,
+		Description:      sql.NullString{String: bus.Description, Valid: bus.Description != ""},
+	}
+}
+
+func toBusCharge(db charge) landedcostbus.Charge {
+	return landedcostbus.Charge{
+		ID:               db.ID,
+		LandedCostID:     db.LandedCostID,
+		ChargeType:       db.ChargeType,
+		AllocationMethod: db.AllocationMethod,
+		Amount:           db.Amount,
+		Description:      db.Description.String,
+	}
+}
+
+// allocation mirrors the procurement.landed_cost_allocations DB row.
+type allocation struct {
+	ID                      uuid.UUID `db:"id"`
+	LandedCostID            uuid.UUID `db:"landed_cost_id"`
+	ChargeID                uuid.UUID `db:"charge_id"`
+	PurchaseOrderLineItemID uuid.UUID `db:"purchase_order_line_item_id"`
+	ProductID               uuid.UUID `db:"product_id"`
+	Quantity                int       `db:"quantity"`
+	Basis                   float64   `db:"basis"`
+	Amount                  float64   `db:"amount"`
+}
+
+func toDBAllocation(bus landedcostbus.Allocation) allocation {
+	return allocation{
+		ID:                      bus.ID,
+		LandedCostID:            bus.LandedCostID,
+		ChargeID:                bus.ChargeID,
+		PurchaseOrderLineItemID: bus.PurchaseOrderLineItemID,
+		ProductID:               bus.ProductID,
+		Quantity:                bus.Quantity,
+		Basis:                   bus.Basis,
+		Amount:                  bus.Amount,
+	}
+}
+
+func toBusAllocation(db allocation) landedcostbus.Allocation {
+	return landedcostbus.Allocation{
+		ID:                      db.ID,
+		LandedCostID:            db.LandedCostID,
+		ChargeID:                db.ChargeID,
+		PurchaseOrderLineItemID: db.PurchaseOrderLineItemID,
+		ProductID:               db.ProductID,
+		Quantity:                db.Quantity,
+		Basis:                   db.Basis,
+		Amount:                  db.Amount,
+	}
+}
+
+// receivedLine is a purchase order line item with its product's physical
+// attributes and the charges other applied landed costs put on it.
+type receivedLine struct {
+	ID               uuid.UUID `db:"id"`
+	PurchaseOrderID  uuid.UUID `db:"purchase_order_id"`
+	ProductID        uuid.UUID `db:"product_id"`
+	QuantityOrdered  int       `db:"quantity_ordered"`
+	QuantityReceived int       `db:"quantity_received"`
+	UnitCost         float64   `db:"unit_cost"`
+	Discount         float64   `db:"discount"`
+	Length           float64   `db:"length"`
+	Width            float64   `db:"width"`
+	Height           float64   `db:"height"`
+	Weight           float64   `db:"weight"`
+	WeightUnit       string    `db:"weight_unit"`
+	Applied          float64   `db:"applied"`
+}
+
+func toBusReceivedLines(dbs []receivedLine) []landedcostbus.ReceivedLine {
+	lines := make([]landedcostbus.ReceivedLine, len(dbs))
+	for i, db := range dbs {
+		lines[i] = landedcostbus.ReceivedLine{
+			ID:               db.ID,
+			PurchaseOrderID:  db.PurchaseOrderID,
+			ProductID:        db.ProductID,
+			QuantityOrdered:  db.QuantityOrdered,
+			QuantityReceived: db.QuantityReceived,
+			UnitCost:         db.UnitCost,
+			Discount:         db.Discount,
+			Length:           db.Length,
+			Width:            db.Width,
+			Height:           db.Height,
+			Weight:           db.Weight,
+			WeightUnit:       db.WeightUnit,
+			Applied:          db.Applied,
+		}
+	}
+	return lines
+}
+
+// =============================================================================
+
+func toNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func fromNullUUID(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	v := id.UUID
+	return &v
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time.In(time.Local)
+	return &v
+}
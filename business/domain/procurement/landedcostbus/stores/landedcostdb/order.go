@@ -0,0 +1,27 @@
+package landedcostdb
+
+import (
+	"fmt"
+
+	"github.com/timmaaaz/ichor/business/domain/procurement/landedcostbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+)
+
+var orderByFields = map[string]string{
+	landedcostbus.OrderByID:              "id",
+	landedcostbus.OrderByPurchaseOrderID: "purchase_order_id",
+	landedcostbus.OrderByReferenceNumber: "reference_number",
+	landedcostbus.OrderByStatus:          "status",
+	landedcostbus.OrderByTotalCharges:    "total_charges",
+	landedcostbus.OrderByAppliedDate:     "applied_date",
+	landedcostbus.OrderByCreatedDate:     "created_date",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
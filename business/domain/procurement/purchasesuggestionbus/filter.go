@@ -0,0 +1,13 @@
+package purchasesuggestionbus
+
+import "github.com/google/uuid"
+
+// QueryFilter holds optional filters for querying suggestions.
+type QueryFilter struct {
+	ID                  *uuid.UUID
+	SupplierID          *uuid.UUID
+	DeliveryWarehouseID *uuid.UUID
+	ProductID           *uuid.UUID
+	PurchaseOrderID     *uuid.UUID
+	Status              *string
+}
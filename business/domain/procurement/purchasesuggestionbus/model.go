@@ -0,0 +1,204 @@
+package purchasesuggestionbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JSON tags are required for workflow event serialization. The workflow system
+// marshals business models to JSON for RawData in TriggerEvents.
+
+// Suggestion statuses. A suggestion is a draft while buyers review and edit
+// it, released once it has become a purchase order and dismissed when a buyer
+// drops it.
+const (
+	StatusDraft     = "draft"
+	StatusReleased  = "released"
+	StatusDismissed = "dismissed"
+)
+
+// Weights says how much each factor counts when a run picks the supplier of a
+// product. Each factor scores a supplier product from 0 to 1 against the other
+// candidates: Price the cheapest unit cost over its own, LeadTime the shortest
+// lead time over its own, MinOrder the quantity needed over the quantity its
+// minimum order and case pack make us buy, and Rating its supplier's rating
+// over the best one.
+type Weights struct {
+	Price    float64 `json:"price"`
+	LeadTime float64 `json:"lead_time"`
+	MinOrder float64 `json:"min_order"`
+	Rating   float64 `json:"rating"`
+}
+
+// DefaultWeights are used when a run leaves every weight zero.
+var DefaultWeights = Weights{
+	Price:    0.4,
+	LeadTime: 0.25,
+	MinOrder: 0.15,
+	Rating:   0.2,
+}
+
+// IsZero reports whether no weight is set.
+func (w Weights) IsZero() bool {
+	return w == Weights{}
+}
+
+// Suggestion is a draft purchase order for one supplier delivering to one
+// warehouse. LeadTimeDays is the longest lead time of its lines and sets the
+// expected delivery date when it is released.
+type Suggestion struct {
+	ID                  uuid.UUID  `json:"id"`
+	SupplierID          uuid.UUID  `json:"supplier_id"`
+	DeliveryWarehouseID uuid.UUID  `json:"delivery_warehouse_id"`
+	Status              string     `json:"status"`
+	LeadTimeDays        int        `json:"lead_time_days"`
+	Subtotal            float64    `json:"subtotal"`
+	Notes               string     `json:"notes"`
+	PurchaseOrderID     *uuid.UUID `json:"purchase_order_id,omitempty"`
+	ReleasedBy          *uuid.UUID `json:"released_by,omitempty"`
+	ReleasedDate        *time.Time `json:"released_date,omitempty"`
+	CreatedBy           uuid.UUID  `json:"created_by"`
+	UpdatedBy           uuid.UUID  `json:"updated_by"`
+	CreatedDate         time.Time  `json:"created_date"`
+	UpdatedDate         time.Time  `json:"updated_date"`
+	Lines               []Line     `json:"lines"`
+}
+
+// Line is one product on a suggestion. Available, OnOrder and ReorderPoint
+// are the product's totals at the warehouse when the run took it; Target is
+// the level the run orders up to and Needed what it takes to get there.
+// SuggestedQuantity is Needed rounded up to the minimum order quantity and
+// whole cases; Quantity starts there and is what buyers edit.
+type Line struct {
+	ID                uuid.UUID `json:"id"`
+	SuggestionID      uuid.UUID `json:"suggestion_id"`
+	ProductID         uuid.UUID `json:"product_id"`
+	SupplierProductID uuid.UUID `json:"supplier_product_id"`
+	AvailableQuantity int       `json:"available_quantity"`
+	OnOrderQuantity   int       `json:"on_order_quantity"`
+	ReorderPoint      int       `json:"reorder_point"`
+	TargetQuantity    int       `json:"target_quantity"`
+	NeededQuantity    int       `json:"needed_quantity"`
+	UnitsPerCase      int       `json:"units_per_case"`
+	MinOrderQuantity  int       `json:"min_order_quantity"`
+	SuggestedQuantity int       `json:"suggested_quantity"`
+	Quantity          int       `json:"quantity"`
+	UnitCost          float64   `json:"unit_cost"`
+	LineTotal         float64   `json:"line_total"`
+	LeadTimeDays      int       `json:"lead_time_days"`
+	Score             float64   `json:"score"`
+	CreatedDate       time.Time `json:"created_date"`
+	UpdatedDate       time.Time `json:"updated_date"`
+}
+
+// UpdateSuggestion defines what information may be provided to modify a
+// draft suggestion. All fields are optional.
+type UpdateSuggestion struct {
+	Notes     *string
+	UpdatedBy uuid.UUID
+}
+
+// UpdateLine defines what information may be provided to modify a line of a
+// draft suggestion. All fields are optional.
+type UpdateLine struct {
+	Quantity  *int
+	UnitCost  *float64
+	UpdatedBy uuid.UUID
+}
+
+// RunRequest describes the inventory items a run considers. WarehouseID,
+// CategoryID and ProductID narrow them. Zero Weights means DefaultWeights.
+type RunRequest struct {
+	WarehouseID *uuid.UUID
+	CategoryID  *uuid.UUID
+	ProductID   *uuid.UUID
+	Weights     Weights
+	CreatedBy   uuid.UUID
+}
+
+// RunResult is what a run suggested, and the needs it could not place
+// because no active supplier sells the product.
+type RunResult struct {
+	Suggestions []Suggestion
+	Unsourced   []Need
+}
+
+// ReleaseRequest carries what a purchase order needs that a suggestion does
+// not: its status, the status of its line items and its currency. An empty
+// OrderNumber is generated; DeliveryLocationID is optional.
+type ReleaseRequest struct {
+	PurchaseOrderStatusID uuid.UUID
+	LineItemStatusID      uuid.UUID
+	CurrencyID            uuid.UUID
+	DeliveryLocationID    *uuid.UUID
+	OrderNumber           string
+	ReleasedBy            uuid.UUID
+}
+
+// ItemFilter narrows the inventory items a run considers.
+type ItemFilter struct {
+	WarehouseID *uuid.UUID
+	CategoryID  *uuid.UUID
+	ProductID   *uuid.UUID
+}
+
+// Item is an inventory item below its reorder point as a run sees it, with the
+// warehouse of its location and its product's case pack. Available is on hand
+// less reserved and allocated.
+type Item struct {
+	ID                    uuid.UUID
+	ProductID             uuid.UUID
+	WarehouseID           uuid.UUID
+	Available             int
+	ReorderPoint          int
+	MaximumStock          int
+	EconomicOrderQuantity int
+	UnitsPerCase          int
+}
+
+// OnOrder is what open purchase orders still bring of a product to a
+// warehouse.
+type OnOrder struct {
+	ProductID   uuid.UUID
+	WarehouseID uuid.UUID
+	Quantity    int
+}
+
+// Need is what one product needs at one warehouse: the totals of its items
+// below their reorder point there and the quantity that takes them to their
+// target, less what is already on order.
+type Need struct {
+	ProductID    uuid.UUID `json:"product_id"`
+	WarehouseID  uuid.UUID `json:"warehouse_id"`
+	Available    int       `json:"available"`
+	OnOrder      int       `json:"on_order"`
+	ReorderPoint int       `json:"reorder_point"`
+	Target       int       `json:"target"`
+	Needed       int       `json:"needed"`
+	UnitsPerCase int       `json:"units_per_case"`
+}
+
+// Candidate is a supplier product an active supplier sells a product
+// through. LeadTimeDays is the supplier product's, or its supplier's when the
+// supplier product has none. A zero MaxOrderQuantity means no maximum.
+type Candidate struct {
+	SupplierProductID uuid.UUID
+	SupplierID        uuid.UUID
+	ProductID         uuid.UUID
+	UnitCost          float64
+	LeadTimeDays      int
+	MinOrderQuantity  int
+	MaxOrderQuantity  int
+	Rating            float64
+	IsPrimary         bool
+}
+
+// Choice is the candidate a run picked for a need, the quantity to buy from
+// it and its score.
+type Choice struct {
+	Need      Need
+	Candidate Candidate
+	Quantity  int
+	Score     float64
+}
@@ -0,0 +1,16 @@
+package purchasesuggestionbus
+
+import "github.com/timmaaaz/ichor/business/sdk/order"
+
+// DefaultOrderBy represents the default ordering for suggestion queries.
+var DefaultOrderBy = order.NewBy(OrderByCreatedDate, order.DESC)
+
+const (
+	OrderByID                  = "id"
+	OrderBySupplierID          = "supplier_id"
+	OrderByDeliveryWarehouseID = "delivery_warehouse_id"
+	OrderByStatus              = "status"
+	OrderBySubtotal            = "subtotal"
+	OrderByCreatedDate         = "created_date"
+	OrderByUpdatedDate         = "updated_date"
+)
@@ -0,0 +1,486 @@
+// Package purchasesuggestionbus provides business access to purchase
+// suggestions: draft purchase orders, one per supplier and delivery warehouse,
+// that a run builds from every inventory item below its reorder point. The run
+// picks each product's supplier from its supplier products and rounds the
+// quantity to the minimum order and the product's case pack; buyers review and
+// edit the drafts and release them into purchase orders.
+package purchasesuggestionbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitembus"
+	"github.com/timmaaaz/ichor/business/sdk/delegate"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/outbox"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+	"github.com/timmaaaz/ichor/foundation/otel"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound            = errors.New("purchase suggestion not found")
+	ErrLineNotFound        = errors.New("purchase suggestion line not found")
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+	ErrNothingToSuggest    = errors.New("no inventory item is below its reorder point")
+	ErrSuggestionClosed    = errors.New("purchase suggestion is not a draft")
+	ErrInvalidLine         = errors.New("invalid purchase suggestion line")
+	ErrInvalidWeights      = errors.New("invalid supplier weights")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	NewWithTx(tx sqldb.CommitRollbacker) (Storer, error)
+	Create(ctx context.Context, suggestion Suggestion) error
+	UpdateWithStatusGuard(ctx context.Context, suggestion Suggestion, expectedStatus string) (int64, error)
+	UpdateLine(ctx context.Context, line Line) error
+	DeleteLine(ctx context.Context, line Line) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]Suggestion, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, suggestionID uuid.UUID) (Suggestion, error)
+	QueryItems(ctx context.Context, filter ItemFilter) ([]Item, error)
+	QueryOnOrder(ctx context.Context, productIDs []uuid.UUID) ([]OnOrder, error)
+	QueryCandidates(ctx context.Context, productIDs []uuid.UUID) ([]Candidate, error)
+	LockRun(ctx context.Context) error
+}
+
+// Business manages the set of APIs for purchase suggestion access.
+type Business struct {
+	log              *logger.Logger
+	storer           Storer
+	delegate         *delegate.Delegate
+	outbox           *outbox.Writer
+	purchaseOrderBus *purchaseorderbus.Business
+	lineItemBus      *purchaseorderlineitembus.Business
+}
+
+// NewBusiness constructs a purchase suggestion business API for use. The
+// purchase order buses receive the suggestions buyers release.
+func NewBusiness(log *logger.Logger, delegate *delegate.Delegate, storer Storer, purchaseOrderBus *purchaseorderbus.Business, lineItemBus *purchaseorderlineitembus.Business) *Business {
+	return &Business{
+		log:              log,
+		delegate:         delegate,
+		storer:           storer,
+		purchaseOrderBus: purchaseOrderBus,
+		lineItemBus:      lineItemBus,
+	}
+}
+
+// WithOutbox returns a copy of the Business wired to the cascade outbox Writer.
+// Inert until the Writer is injected at the F2 cutover (nil Writer -> Emit no-ops).
+func (b *Business) WithOutbox(w *outbox.Writer) *Business {
+	nb := *b
+	nb.outbox = w
+	return &nb
+}
+
+// NewWithTx constructs a new Business value replacing the Storer
+// value with a Storer value that is currently inside a transaction.
+func (b *Business) NewWithTx(tx sqldb.CommitRollbacker) (*Business, error) {
+	storer, err := b.storer.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	purchaseOrderBus, err := b.purchaseOrderBus.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	lineItemBus, err := b.lineItemBus.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	nb := *b
+	nb.storer = storer
+	nb.purchaseOrderBus = purchaseOrderBus
+	nb.lineItemBus = lineItemBus
+	return &nb, nil
+}
+
+// Run builds draft suggestions from every inventory item the request selects
+// whose available quantity is below its reorder point. Products a draft
+// suggestion already holds for the warehouse are left out, as are needs open
+// purchase orders already cover. Each remaining need goes to the best scoring
+// supplier product and the choices are consolidated into one suggestion per
+// supplier and delivery warehouse. Needs no active supplier can fill come
+// back as unsourced. Runs are serialized so two cannot suggest the same
+// product. Returns ErrNothingToSuggest when no selected item needs ordering.
+func (b *Business) Run(ctx context.Context, req RunRequest, now time.Time) (RunResult, error) {
+	ctx, span := otel.AddSpan(ctx, "business.purchasesuggestionbus.run")
+	defer span.End()
+
+	if err := validateWeights(req.Weights); err != nil {
+		return RunResult{}, fmt.Errorf("run: %w", err)
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (RunResult, error) {
+			if err := b.storer.LockRun(ctx); err != nil {
+				return RunResult{}, fmt.Errorf("run: lock: %w", err)
+			}
+
+			items, err := b.storer.QueryItems(ctx, ItemFilter{
+				WarehouseID: req.WarehouseID,
+				CategoryID:  req.CategoryID,
+				ProductID:   req.ProductID,
+			})
+			if err != nil {
+				return RunResult{}, fmt.Errorf("run: items: %w", err)
+			}
+
+			var productIDs []uuid.UUID
+			seen := make(map[uuid.UUID]bool)
+			for _, item := range items {
+				if !seen[item.ProductID] {
+					seen[item.ProductID] = true
+					productIDs = append(productIDs, item.ProductID)
+				}
+			}
+
+			var onOrder []OnOrder
+			if len(productIDs) > 0 {
+				if onOrder, err = b.storer.QueryOnOrder(ctx, productIDs); err != nil {
+					return RunResult{}, fmt.Errorf("run: on order: %w", err)
+				}
+			}
+
+			needs := BuildNeeds(items, onOrder)
+			if len(needs) == 0 {
+				return RunResult{}, fmt.Errorf("run: %w", ErrNothingToSuggest)
+			}
+
+			candidates, err := b.storer.QueryCandidates(ctx, productIDs)
+			if err != nil {
+				return RunResult{}, fmt.Errorf("run: candidates: %w", err)
+			}
+
+			byProduct := make(map[uuid.UUID][]Candidate)
+			for _, c := range candidates {
+				byProduct[c.ProductID] = append(byProduct[c.ProductID], c)
+			}
+
+			var result RunResult
+			var choices []Choice
+			for _, need := range needs {
+				choice, ok := ChooseSupplier(need, byProduct[need.ProductID], req.Weights)
+				if !ok {
+					result.Unsourced = append(result.Unsourced, need)
+					continue
+				}
+				choices = append(choices, choice)
+			}
+
+			for _, s := range Consolidate(choices, req.CreatedBy, now) {
+				if err := b.storer.Create(ctx, s); err != nil {
+					return RunResult{}, fmt.Errorf("run: create: %w", err)
+				}
+
+				evtData := ActionCreatedData(s)
+				if err := b.outbox.Emit(ctx, evtData); err != nil {
+					return RunResult{}, fmt.Errorf("emit cascade event: %w", err)
+				}
+				if err := b.delegate.Call(ctx, ActionCreatedData(s)); err != nil {
+					b.log.Error(ctx, "purchasesuggestionbus: delegate call failed", "action", ActionCreated, "err", err)
+				}
+
+				result.Suggestions = append(result.Suggestions, s)
+			}
+
+			return result, nil
+		})
+}
+
+// Update modifies a draft suggestion. Returns ErrSuggestionClosed when the
+// suggestion is not a draft.
+func (b *Business) Update(ctx context.Context, suggestion Suggestion, us UpdateSuggestion, now time.Time) (Suggestion, error) {
+	ctx, span := otel.AddSpan(ctx, "business.purchasesuggestionbus.update")
+	defer span.End()
+
+	if suggestion.Status != StatusDraft {
+		return Suggestion{}, fmt.Errorf("update: %w", ErrSuggestionClosed)
+	}
+
+	return b.update(ctx, suggestion, func(s *Suggestion) {
+		if us.Notes != nil {
+			s.Notes = *us.Notes
+		}
+		s.UpdatedBy = us.UpdatedBy
+		s.UpdatedDate = now
+	})
+}
+
+// UpdateLine modifies a line of a draft suggestion and retotals the
+// suggestion. The quantity must reach the line's minimum order quantity; it
+// need not be whole cases. Returns ErrSuggestionClosed when the suggestion is
+// not a draft.
+func (b *Business) UpdateLine(ctx context.Context, suggestion Suggestion, lineID uuid.UUID, ul UpdateLine, now time.Time) (Suggestion, error) {
+	ctx, span := otel.AddSpan(ctx, "business.purchasesuggestionbus.updateline")
+	defer span.End()
+
+	if suggestion.Status != StatusDraft {
+		return Suggestion{}, fmt.Errorf("updateline: %w", ErrSuggestionClosed)
+	}
+
+	i, err := lineIndex(suggestion, lineID)
+	if err != nil {
+		return Suggestion{}, fmt.Errorf("updateline: %w", err)
+	}
+
+	line := suggestion.Lines[i]
+	if ul.Quantity != nil {
+		line.Quantity = *ul.Quantity
+	}
+	if ul.UnitCost != nil {
+		line.UnitCost = *ul.UnitCost
+	}
+
+	switch {
+	case line.Quantity <= 0 || line.Quantity < line.MinOrderQuantity:
+		return Suggestion{}, fmt.Errorf("updateline: %w: quantity must be positive and at least the minimum order quantity %d", ErrInvalidLine, line.MinOrderQuantity)
+	case line.UnitCost < 0:
+		return Suggestion{}, fmt.Errorf("updateline: %w: unit_cost must not be negative", ErrInvalidLine)
+	}
+
+	line.LineTotal = lineTotal(line.Quantity, line.UnitCost)
+	line.UpdatedDate = now
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Suggestion, error) {
+			if err := b.storer.UpdateLine(ctx, line); err != nil {
+				return Suggestion{}, fmt.Errorf("updateline: %w", err)
+			}
+
+			return b.update(ctx, suggestion, func(s *Suggestion) {
+				s.Lines = append([]Line(nil), s.Lines...)
+				s.Lines[i] = line
+				s.total()
+				s.UpdatedBy = ul.UpdatedBy
+				s.UpdatedDate = now
+			})
+		})
+}
+
+// RemoveLine takes a line off a draft suggestion. Removing the last line
+// dismisses the suggestion. Returns ErrSuggestionClosed when the suggestion is
+// not a draft.
+func (b *Business) RemoveLine(ctx context.Context, suggestion Suggestion, lineID uuid.UUID, userID uuid.UUID, now time.Time) (Suggestion, error) {
+	ctx, span := otel.AddSpan(ctx, "business.purchasesuggestionbus.removeline")
+	defer span.End()
+
+	if suggestion.Status != StatusDraft {
+		return Suggestion{}, fmt.Errorf("removeline: %w", ErrSuggestionClosed)
+	}
+
+	i, err := lineIndex(suggestion, lineID)
+	if err != nil {
+		return Suggestion{}, fmt.Errorf("removeline: %w", err)
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Suggestion, error) {
+			if err := b.storer.DeleteLine(ctx, suggestion.Lines[i]); err != nil {
+				return Suggestion{}, fmt.Errorf("removeline: %w", err)
+			}
+
+			return b.update(ctx, suggestion, func(s *Suggestion) {
+				s.Lines = append(append([]Line(nil), s.Lines[:i]...), s.Lines[i+1:]...)
+				s.total()
+				if len(s.Lines) == 0 {
+					s.Status = StatusDismissed
+				}
+				s.UpdatedBy = userID
+				s.UpdatedDate = now
+			})
+		})
+}
+
+// Dismiss drops a draft suggestion, freeing its products for later runs.
+// Returns ErrSuggestionClosed when the suggestion is not a draft.
+func (b *Business) Dismiss(ctx context.Context, suggestion Suggestion, userID uuid.UUID, now time.Time) (Suggestion, error) {
+	ctx, span := otel.AddSpan(ctx, "business.purchasesuggestionbus.dismiss")
+	defer span.End()
+
+	if suggestion.Status != StatusDraft {
+		return Suggestion{}, fmt.Errorf("dismiss: %w", ErrSuggestionClosed)
+	}
+
+	return b.update(ctx, suggestion, func(s *Suggestion) {
+		s.Status = StatusDismissed
+		s.UpdatedBy = userID
+		s.UpdatedDate = now
+	})
+}
+
+// Release turns a draft suggestion into a purchase order with one line item
+// per line, expected the suggestion's lead time from now, and records the
+// order on the suggestion. Returns ErrSuggestionClosed when the suggestion is
+// not a draft.
+func (b *Business) Release(ctx context.Context, suggestion Suggestion, req ReleaseRequest, now time.Time) (Suggestion, error) {
+	ctx, span := otel.AddSpan(ctx, "business.purchasesuggestionbus.release")
+	defer span.End()
+
+	if suggestion.Status != StatusDraft {
+		return Suggestion{}, fmt.Errorf("release: %w", ErrSuggestionClosed)
+	}
+
+	orderNumber := req.OrderNumber
+	if orderNumber == "" {
+		orderNumber = fmt.Sprintf("PO-%s", uuid.New().String()[:8])
+	}
+
+	var locationID uuid.UUID
+	if req.DeliveryLocationID != nil {
+		locationID = *req.DeliveryLocationID
+	}
+
+	expected := now.AddDate(0, 0, suggestion.LeadTimeDays)
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Suggestion, error) {
+			po, err := b.purchaseOrderBus.Create(ctx, purchaseorderbus.NewPurchaseOrder{
+				OrderNumber:           orderNumber,
+				SupplierID:            suggestion.SupplierID,
+				PurchaseOrderStatusID: req.PurchaseOrderStatusID,
+				DeliveryWarehouseID:   suggestion.DeliveryWarehouseID,
+				DeliveryLocationID:    locationID,
+				OrderDate:             now,
+				ExpectedDeliveryDate:  expected,
+				Subtotal:              suggestion.Subtotal,
+				TotalAmount:           suggestion.Subtotal,
+				CurrencyID:            req.CurrencyID,
+				RequestedBy:           req.ReleasedBy,
+				Notes:                 suggestion.Notes,
+				CreatedBy:             req.ReleasedBy,
+			})
+			if err != nil {
+				return Suggestion{}, fmt.Errorf("release: create purchase order: %w", err)
+			}
+
+			for _, l := range suggestion.Lines {
+				if _, err := b.lineItemBus.Create(ctx, purchaseorderlineitembus.NewPurchaseOrderLineItem{
+					PurchaseOrderID:      po.ID,
+					SupplierProductID:    l.SupplierProductID,
+					QuantityOrdered:      l.Quantity,
+					UnitCost:             l.UnitCost,
+					LineTotal:            l.LineTotal,
+					LineItemStatusID:     req.LineItemStatusID,
+					ExpectedDeliveryDate: now.AddDate(0, 0, l.LeadTimeDays),
+					CreatedBy:            req.ReleasedBy,
+				}); err != nil {
+					return Suggestion{}, fmt.Errorf("release: create line item: %w", err)
+				}
+			}
+
+			// The header update is guarded on the draft status, so when a
+			// second release of the same suggestion gets here it fails and
+			// its purchase order rolls back with it.
+			released, err := b.update(ctx, suggestion, func(s *Suggestion) {
+				s.Status = StatusReleased
+				s.PurchaseOrderID = &po.ID
+				s.ReleasedBy = &req.ReleasedBy
+				s.ReleasedDate = &now
+				s.UpdatedBy = req.ReleasedBy
+				s.UpdatedDate = now
+			})
+			if err != nil {
+				return Suggestion{}, fmt.Errorf("release: %w", err)
+			}
+
+			return released, nil
+		})
+}
+
+// Query retrieves a list of suggestions, with their lines, from the system.
+func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]Suggestion, error) {
+	ctx, span := otel.AddSpan(ctx, "business.purchasesuggestionbus.query")
+	defer span.End()
+
+	suggestions, err := b.storer.Query(ctx, filter, orderBy, page)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// Count returns the total number of suggestions matching the filter.
+func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.purchasesuggestionbus.count")
+	defer span.End()
+
+	return b.storer.Count(ctx, filter)
+}
+
+// QueryByID retrieves a single suggestion, with its lines, by its ID.
+func (b *Business) QueryByID(ctx context.Context, suggestionID uuid.UUID) (Suggestion, error) {
+	ctx, span := otel.AddSpan(ctx, "business.purchasesuggestionbus.querybyid")
+	defer span.End()
+
+	suggestion, err := b.storer.QueryByID(ctx, suggestionID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return Suggestion{}, err
+		}
+		return Suggestion{}, fmt.Errorf("queryByID: suggestionID[%s]: %w", suggestionID, err)
+	}
+
+	return suggestion, nil
+}
+
+// =============================================================================
+
+// update applies fn to the suggestion, stores its header and emits the
+// updated event. The store only takes the write while the suggestion still
+// has the status it was read with; when another change got there first,
+// update returns ErrSuggestionClosed and the caller's transaction rolls back.
+func (b *Business) update(ctx context.Context, suggestion Suggestion, fn func(*Suggestion)) (Suggestion, error) {
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Suggestion, error) {
+			before := suggestion
+			fn(&suggestion)
+
+			rows, err := b.storer.UpdateWithStatusGuard(ctx, suggestion, before.Status)
+			if err != nil {
+				return Suggestion{}, fmt.Errorf("update: %w", err)
+			}
+			if rows == 0 {
+				return Suggestion{}, fmt.Errorf("update: %w", ErrSuggestionClosed)
+			}
+
+			evtData := ActionUpdatedData(before, suggestion)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return Suggestion{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionUpdatedData(before, suggestion)); err != nil {
+				b.log.Error(ctx, "purchasesuggestionbus: delegate call failed", "action", ActionUpdated, "err", err)
+			}
+
+			return suggestion, nil
+		})
+}
+
+func lineIndex(suggestion Suggestion, lineID uuid.UUID) (int, error) {
+	for i, l := range suggestion.Lines {
+		if l.ID == lineID {
+			return i, nil
+		}
+	}
+	return 0, ErrLineNotFound
+}
+
+func validateWeights(w Weights) error {
+	if w.Price < 0 || w.LeadTime < 0 || w.MinOrder < 0 || w.Rating < 0 {
+		return fmt.Errorf("%w: weights must not be negative", ErrInvalidWeights)
+	}
+	return nil
+}
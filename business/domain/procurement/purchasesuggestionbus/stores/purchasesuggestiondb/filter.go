@@ -0,0 +1,47 @@
+package purchasesuggestiondb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
+)
+
+func applyFilter(filter purchasesuggestionbus.QueryFilter, data map[string]any, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["id"] = *filter.ID
+		wc = append(wc, "id = :id")
+	}
+
+	if filter.SupplierID != nil {
+		data["supplier_id"] = *filter.SupplierID
+		wc = append(wc, "supplier_id = :supplier_id")
+	}
+
+	if filter.DeliveryWarehouseID != nil {
+		data["delivery_warehouse_id"] = *filter.DeliveryWarehouseID
+		wc = append(wc, "delivery_warehouse_id = :delivery_warehouse_id")
+	}
+
+	if filter.ProductID != nil {
+		data["product_id"] = *filter.ProductID
+		wc = append(wc, "id IN (SELECT suggestion_id FROM procurement.purchase_suggestion_lines WHERE product_id = :product_id)")
+	}
+
+	if filter.PurchaseOrderID != nil {
+		data["purchase_order_id"] = *filter.PurchaseOrderID
+		wc = append(wc, "purchase_order_id = :purchase_order_id")
+	}
+
+	if filter.Status != nil {
+		data["status"] = *filter.Status
+		wc = append(wc, "status = :status")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
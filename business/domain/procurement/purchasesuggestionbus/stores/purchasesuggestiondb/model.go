@@ -0,0 +1,255 @@
+package purchasesuggestiondb
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
+)
+
+// suggestion mirrors the procurement.purchase_suggestions DB row.
+type suggestion struct {
+	ID                  uuid.UUID      `db:"id"`
+	SupplierID          uuid.UUID      `db:"supplier_id"`
+	DeliveryWarehouseID uuid.UUID      `db:"delivery_warehouse_id"`
+	Status              string         `db:"status"`
+	LeadTimeDays        int            `db:"lead_time_days"`
+	Subtotal            float64        `db:"subtotal"`
+	Notes               sql.NullString `db:"notes"`
+	PurchaseOrderID     uuid.NullUUID  `db:"purchase_order_id"`
+	ReleasedBy          uuid.NullUUID  `db:"released_by"`
+	ReleasedDate        sql.NullTime   `db:"released_date"`
+	CreatedBy           uuid.UUID      `db:"created_by"`
+	UpdatedBy           uuid.UUID      `db:"updated_by"`
+	CreatedDate         time.Time      `db:"created_date"`
+	UpdatedDate         time.Time      `db:"updated_date"`
+}
+
+func toDBSuggestion(bus purchasesuggestionbus.Suggestion) suggestion {
+	return suggestion{
+		ID:                  bus.ID,
+		SupplierID:          bus.SupplierID,
+		DeliveryWarehouseID: bus.DeliveryWarehouseID,
+		Status:              bus.Status,
+		LeadTimeDays:        bus.LeadTimeDays,
+		Subtotal:            bus.Subtotal,
+		Notes:               sql.NullString{String: bus.Notes, Valid: bus.Notes != ""},
+		PurchaseOrderID:     toNullUUID(bus.PurchaseOrderID),
+		ReleasedBy:          toNullUUID(bus.ReleasedBy),
+		ReleasedDate:        toNullTime(bus.ReleasedDate),
+		CreatedBy:           bus.CreatedBy,
+		UpdatedBy:           bus.UpdatedBy,
+		CreatedDate:         bus.CreatedDate.UTC(),
+		UpdatedDate:         bus.UpdatedDate.UTC(),
+	}
+}
+
+func toBusSuggestion(db suggestion) purchasesuggestionbus.Suggestion {
+	return purchasesuggestionbus.Suggestion{
+		ID:                  db.ID,
+		SupplierID:          db.SupplierID,
+		DeliveryWarehouseID: db.DeliveryWarehouseID,
+		Status:              db.Status,
+		LeadTimeDays:        db.LeadTimeDays,
+		Subtotal:            db.Subtotal,
+		Notes:               db.Notes.String,
+		PurchaseOrderID:     fromNullUUID(db.PurchaseOrderID),
+		ReleasedBy:          fromNullUUID(db.ReleasedBy),
+		ReleasedDate:        fromNullTime(db.ReleasedDate),
+		CreatedBy:           db.CreatedBy,
+		UpdatedBy:           db.UpdatedBy,
+		CreatedDate:         db.CreatedDate.In(time.Local),
+		UpdatedDate:         db.UpdatedDate.In(time.Local),
+	}
+}
+
+func toBusSuggestions(dbs []suggestion) []purchasesuggestionbus.Suggestion {
+	suggestions := make([]purchasesuggestionbus.Suggestion, len(dbs))
+	for i, db := range dbs {
+		suggestions[i] = toBusSuggestion(db)
+	}
+	return suggestions
+}
+
+// line mirrors the procurement.purchase_suggestion_lines DB row.
+type line struct {
+	ID                uuid.UUID `db:"id"`
+	SuggestionID      uuid.UUID `db:"suggestion_id"`
+	ProductID         uuid.UUID `db:"product_id"`
+	SupplierProductID uuid.UUID `db:"supplier_product_id"`
+	AvailableQuantity int       `db:"available_quantity"`
+	OnOrderQuantity   int       `db:"on_order_quantity"`
+	ReorderPoint      int       `db:"reorder_point"`
+	TargetQuantity    int       `db:"target_quantity"`
+	NeededQuantity    int       `db:"needed_quantity"`
+	UnitsPerCase      int       `db:"units_per_case"`
+	MinOrderQuantity  int       `db:"min_order_quantity"`
+	SuggestedQuantity int       `db:"suggested_quantity"`
+	Quantity          int       `db:"quantity"`
+	UnitCost          float64   `db:"unit_cost"`
+	LineTotal         float64   `db:"line_total"`
+	LeadTimeDays      int       `db:"lead_time_days"`
+	Score             float64   `db:"score"`
+	CreatedDate       time.Time `db:"created_date"`
+	UpdatedDate       time.Time `db:"updated_date"`
+}
+
+func toDBLine(bus purchasesuggestionbus.Line) line {
+	return line{
+		ID:                bus.ID,
+		SuggestionID:      bus.SuggestionID,
+		ProductID:         bus.ProductID,
+		SupplierProductID: bus.SupplierProductID,
+		AvailableQuantity: bus.AvailableQuantity,
+		OnOrderQuantity:   bus.OnOrderQuantity,
+		ReorderPoint:      bus.ReorderPoint,
+		TargetQuantity:    bus.TargetQuantity,
+		NeededQuantity:    bus.NeededQuantity,
+		UnitsPerCase:      bus.UnitsPerCase,
+		MinOrderQuantity:  bus.MinOrderQuantity,
+		SuggestedQuantity: bus.SuggestedQuantity,
+		Quantity:          bus.Quantity,
+		UnitCost:          bus.UnitCost,
+		LineTotal:         bus.LineTotal,
+		LeadTimeDays:      bus.LeadTimeDays,
+		Score:             bus.Score,
+		CreatedDate:       bus.CreatedDate.UTC(),
+		UpdatedDate:       bus.UpdatedDate.UTC(),
+	}
+}
+
+func toBusLine(db line) purchasesuggestionbus.Line {
+	return purchasesuggestionbus.Line{
+		ID:                db.ID,
+		SuggestionID:      db.SuggestionID,
+		ProductID:         db.ProductID,
+		SupplierProductID: db.SupplierProductID,
+		AvailableQuantity: db.AvailableQuantity,
+		OnOrderQuantity:   db.OnOrderQuantity,
+		ReorderPoint:      db.ReorderPoint,
+		TargetQuantity:    db.TargetQuantity,
+		NeededQuantity:    db.NeededQuantity,
+		UnitsPerCase:      db.UnitsPerCase,
+		MinOrderQuantity:  db.MinOrderQuantity,
+		SuggestedQuantity: db.SuggestedQuantity,
+		Quantity:          db.Quantity,
+		UnitCost:          db.UnitCost,
+		LineTotal:         db.LineTotal,
+		LeadTimeDays:      db.LeadTimeDays,
+		Score:             db.Score,
+		CreatedDate:       db.CreatedDate.In(time.Local),
+		UpdatedDate:       db.UpdatedDate.In(time.Local),
+	}
+}
+
+// item is an inventory item below its reorder point as a run reads it.
+type item struct {
+	ID                    uuid.UUID `db:"id"`
+	ProductID             uuid.UUID `db:"product_id"`
+	WarehouseID           uuid.UUID `db:"warehouse_id"`
+	Available             int       `db:"available"`
+	ReorderPoint          int       `db:"reorder_point"`
+	MaximumStock          int       `db:"maximum_stock"`
+	EconomicOrderQuantity int       `db:"economic_order_quantity"`
+	UnitsPerCase          int       `db:"units_per_case"`
+}
+
+func toBusItems(dbs []item) []purchasesuggestionbus.Item {
+	items := make([]purchasesuggestionbus.Item, len(dbs))
+	for i, db := range dbs {
+		items[i] = purchasesuggestionbus.Item{
+			ID:                    db.ID,
+			ProductID:             db.ProductID,
+			WarehouseID:           db.WarehouseID,
+			Available:             db.Available,
+			ReorderPoint:          db.ReorderPoint,
+			MaximumStock:          db.MaximumStock,
+			EconomicOrderQuantity: db.EconomicOrderQuantity,
+			UnitsPerCase:          db.UnitsPerCase,
+		}
+	}
+	return items
+}
+
+// onOrder is the open purchase order quantity of a product for a warehouse.
+type onOrder struct {
+	ProductID   uuid.UUID `db:"product_id"`
+	WarehouseID uuid.UUID `db:"warehouse_id"`
+	Quantity    int       `db:"quantity"`
+}
+
+func toBusOnOrder(dbs []onOrder) []purchasesuggestionbus.OnOrder {
+	on := make([]purchasesuggestionbus.OnOrder, len(dbs))
+	for i, db := range dbs {
+		on[i] = purchasesuggestionbus.OnOrder{
+			ProductID:   db.ProductID,
+			WarehouseID: db.WarehouseID,
+			Quantity:    db.Quantity,
+		}
+	}
+	return on
+}
+
+// candidate is a supplier product of an active supplier.
+type candidate struct {
+	SupplierProductID uuid.UUID `db:"supplier_product_id"`
+	SupplierID        uuid.UUID `db:"supplier_id"`
+	ProductID         uuid.UUID `db:"product_id"`
+	UnitCost          float64   `db:"unit_cost"`
+	LeadTimeDays      int       `db:"lead_time_days"`
+	MinOrderQuantity  int       `db:"min_order_quantity"`
+	MaxOrderQuantity  int       `db:"max_order_quantity"`
+	Rating            float64   `db:"rating"`
+	IsPrimary         bool      `db:"is_primary_supplier"`
+}
+
+func toBusCandidates(dbs []candidate) []purchasesuggestionbus.Candidate {
+	candidates := make([]purchasesuggestionbus.Candidate, len(dbs))
+	for i, db := range dbs {
+		candidates[i] = purchasesuggestionbus.Candidate{
+			SupplierProductID: db.SupplierProductID,
+			SupplierID:        db.SupplierID,
+			ProductID:         db.ProductID,
+			UnitCost:          db.UnitCost,
+			LeadTimeDays:      db.LeadTimeDays,
+			MinOrderQuantity:  db.MinOrderQuantity,
+			MaxOrderQuantity:  db.MaxOrderQuantity,
+			Rating:            db.Rating,
+			IsPrimary:         db.IsPrimary,
+		}
+	}
+	return candidates
+}
+
+// =============================================================================
+
+func toNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func fromNullUUID(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	v := id.UUID
+	return &v
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time.In(time.Local)
+	return &v
+}
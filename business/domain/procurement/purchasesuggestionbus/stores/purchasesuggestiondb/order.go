@@ -0,0 +1,27 @@
+package purchasesuggestiondb
+
+import (
+	"fmt"
+
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+)
+
+var orderByFields = map[string]string{
+	purchasesuggestionbus.OrderByID:                  "id",
+	purchasesuggestionbus.OrderBySupplierID:          "supplier_id",
+	purchasesuggestionbus.OrderByDeliveryWarehouseID: "delivery_warehouse_id",
+	purchasesuggestionbus.OrderByStatus:              "status",
+	purchasesuggestionbus.OrderBySubtotal:            "subtotal",
+	purchasesuggestionbus.OrderByCreatedDate:         "created_date",
+	purchasesuggestionbus.OrderByUpdatedDate:         "updated_date",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
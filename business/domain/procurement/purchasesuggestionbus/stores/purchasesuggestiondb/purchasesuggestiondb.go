@@ -0,0 +1,435 @@
+// Package purchasesuggestiondb contains purchase suggestion related CRUD
+// functionality.
+package purchasesuggestiondb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+var scopeColumns = sqldb.ScopeColumns{Warehouse: "delivery_warehouse_id"}
+
+// Store manages the set of APIs for purchase suggestion database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// NewWithTx constructs a new Store value replacing the sqlx.DB
+// value with a sqlx.Tx value that is currently inside a transaction.
+func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (purchasesuggestionbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		log: s.log,
+		db:  ec,
+	}, nil
+}
+
+// Create inserts a new suggestion and its lines into the database.
+func (s *Store) Create(ctx context.Context, sg purchasesuggestionbus.Suggestion) error {
+	const q = `
+	INSERT INTO procurement.purchase_suggestions
+		(id, supplier_id, delivery_warehouse_id, status, lead_time_days, subtotal, notes, purchase_order_id,
+		 released_by, released_date, created_by, updated_by, created_date, updated_date)
+	VALUES
+		(:id, :supplier_id, :delivery_warehouse_id, :status, :lead_time_days, :subtotal, :notes, :purchase_order_id,
+		 :released_by, :released_date, :created_by, :updated_by, :created_date, :updated_date)
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBSuggestion(sg)); err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return fmt.Errorf("namedexeccontext: %w", purchasesuggestionbus.ErrForeignKeyViolation)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	const ql = `
+	INSERT INTO procurement.purchase_suggestion_lines
+		(id, suggestion_id, product_id, supplier_product_id, available_quantity, on_order_quantity, reorder_point,
+		 target_quantity, needed_quantity, units_per_case, min_order_quantity, suggested_quantity, quantity,
+		 unit_cost, line_total, lead_time_days, score, created_date, updated_date)
+	VALUES
+		(:id, :suggestion_id, :product_id, :supplier_product_id, :available_quantity, :on_order_quantity, :reorder_point,
+		 :target_quantity, :needed_quantity, :units_per_case, :min_order_quantity, :suggested_quantity, :quantity,
+		 :unit_cost, :line_total, :lead_time_days, :score, :created_date, :updated_date)
+	`
+
+	for _, l := range sg.Lines {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, ql, toDBLine(l)); err != nil {
+			if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+				return fmt.Errorf("namedexeccontext: %w", purchasesuggestionbus.ErrForeignKeyViolation)
+			}
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// UpdateWithStatusGuard modifies the header of a suggestion in the database
+// only while its status is still expectedStatus, returning the rows affected.
+func (s *Store) UpdateWithStatusGuard(ctx context.Context, sg purchasesuggestionbus.Suggestion, expectedStatus string) (int64, error) {
+	const q = `
+	UPDATE procurement.purchase_suggestions
+	SET
+		status            = :status,
+		lead_time_days    = :lead_time_days,
+		subtotal          = :subtotal,
+		notes             = :notes,
+		purchase_order_id = :purchase_order_id,
+		released_by       = :released_by,
+		released_date     = :released_date,
+		updated_by        = :updated_by,
+		updated_date      = :updated_date
+	WHERE
+		id = :id AND status = :expected_status
+	`
+
+	data := struct {
+		suggestion
+		ExpectedStatus string `db:"expected_status"`
+	}{
+		suggestion:     toDBSuggestion(sg),
+		ExpectedStatus: expectedStatus,
+	}
+
+	rows, err := sqldb.NamedExecContextWithCount(ctx, s.log, s.db, q, data)
+	if err != nil {
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return 0, fmt.Errorf("namedexeccontextwithcount: %w", purchasesuggestionbus.ErrForeignKeyViolation)
+		}
+		return 0, fmt.Errorf("namedexeccontextwithcount: %w", err)
+	}
+
+	return rows, nil
+}
+
+// UpdateLine modifies an existing suggestion line in the database.
+func (s *Store) UpdateLine(ctx context.Context, l purchasesuggestionbus.Line) error {
+	const q = `
+	UPDATE procurement.purchase_suggestion_lines
+	SET
+		quantity     = :quantity,
+		unit_cost    = :unit_cost,
+		line_total   = :line_total,
+		updated_date = :updated_date
+	WHERE
+		id = :id
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBLine(l)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteLine removes a suggestion line from the database.
+func (s *Store) DeleteLine(ctx context.Context, l purchasesuggestionbus.Line) error {
+	const q = `
+	DELETE FROM
+		procurement.purchase_suggestion_lines
+	WHERE
+		id = :id
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBLine(l)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of suggestions, with their lines, from the database.
+func (s *Store) Query(ctx context.Context, filter purchasesuggestionbus.QueryFilter, orderBy order.By, page page.Page) ([]purchasesuggestionbus.Suggestion, error) {
+	data := map[string]any{
+		"offset":        (page.Number() - 1) * page.RowsPerPage(),
+		"rows_per_page": page.RowsPerPage(),
+	}
+
+	const q = `
+	SELECT
+		id, supplier_id, delivery_warehouse_id, status, lead_time_days, subtotal, notes, purchase_order_id,
+		released_by, released_date, created_by, updated_by, created_date, updated_date
+	FROM
+		procurement.purchase_suggestions
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbSuggestions []suggestion
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbSuggestions); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	suggestions := toBusSuggestions(dbSuggestions)
+	if err := s.attachLines(ctx, suggestions); err != nil {
+		return nil, err
+	}
+
+	return suggestions, nil
+}
+
+// Count returns the total number of suggestions matching the filter.
+func (s *Store) Count(ctx context.Context, filter purchasesuggestionbus.QueryFilter) (int, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		COUNT(1) AS count
+	FROM
+		procurement.purchase_suggestions
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID retrieves a single suggestion, with its lines, by its ID.
+func (s *Store) QueryByID(ctx context.Context, suggestionID uuid.UUID) (purchasesuggestionbus.Suggestion, error) {
+	data := map[string]any{
+		"id": suggestionID.String(),
+	}
+
+	const q = `
+	SELECT
+		id, supplier_id, delivery_warehouse_id, status, lead_time_days, subtotal, notes, purchase_order_id,
+		released_by, released_date, created_by, updated_by, created_date, updated_date
+	FROM
+		procurement.purchase_suggestions
+	WHERE
+		id = :id
+	`
+
+	buf := bytes.NewBufferString(q)
+	sqldb.ApplyDataScope(ctx, buf, data, scopeColumns)
+
+	var dbSuggestion suggestion
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &dbSuggestion); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return purchasesuggestionbus.Suggestion{}, purchasesuggestionbus.ErrNotFound
+		}
+		return purchasesuggestionbus.Suggestion{}, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	suggestions := []purchasesuggestionbus.Suggestion{toBusSuggestion(dbSuggestion)}
+	if err := s.attachLines(ctx, suggestions); err != nil {
+		return purchasesuggestionbus.Suggestion{}, err
+	}
+
+	return suggestions[0], nil
+}
+
+// QueryItems retrieves the inventory items of active products whose available
+// quantity is below their reorder point, with the warehouse of their location
+// and their product's case pack. Products a draft suggestion already holds for
+// the warehouse are left out.
+func (s *Store) QueryItems(ctx context.Context, filter purchasesuggestionbus.ItemFilter) ([]purchasesuggestionbus.Item, error) {
+	data := map[string]any{
+		"status": purchasesuggestionbus.StatusDraft,
+	}
+
+	const q = `
+	SELECT
+		ii.id, ii.product_id, il.warehouse_id,
+		ii.quantity - ii.reserved_quantity - ii.allocated_quantity AS available,
+		ii.reorder_point, ii.maximum_stock, ii.economic_order_quantity, p.units_per_case
+	FROM inventory.inventory_items ii
+	JOIN inventory.inventory_locations il ON il.id = ii.location_id
+	JOIN products.products p ON p.id = ii.product_id
+	WHERE p.is_active
+		AND ii.quantity - ii.reserved_quantity - ii.allocated_quantity < ii.reorder_point
+		AND NOT EXISTS (
+			SELECT 1
+			FROM procurement.purchase_suggestion_lines psl
+			JOIN procurement.purchase_suggestions ps ON ps.id = psl.suggestion_id
+			WHERE psl.product_id = ii.product_id
+				AND ps.delivery_warehouse_id = il.warehouse_id
+				AND ps.status = :status
+		)`
+
+	buf := bytes.NewBufferString(q)
+	if filter.WarehouseID != nil {
+		data["warehouse_id"] = *filter.WarehouseID
+		buf.WriteString(" AND il.warehouse_id = :warehouse_id")
+	}
+	if filter.CategoryID != nil {
+		data["category_id"] = *filter.CategoryID
+		buf.WriteString(" AND p.category_id = :category_id")
+	}
+	if filter.ProductID != nil {
+		data["product_id"] = *filter.ProductID
+		buf.WriteString(" AND ii.product_id = :product_id")
+	}
+	if id, ok := sqldb.GetScenarioFilter(ctx); ok {
+		data["scenario_id"] = id
+		buf.WriteString(" AND (ii.scenario_id IS NULL OR ii.scenario_id = :scenario_id)")
+	}
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeColumns{Warehouse: "il.warehouse_id", Zone: "il.zone_id"})
+	buf.WriteString(" ORDER BY ii.product_id, il.warehouse_id")
+
+	var dbItems []item
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbItems); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusItems(dbItems), nil
+}
+
+// QueryOnOrder retrieves what open purchase orders still bring of the products
+// to each warehouse: ordered less received and cancelled on the line items of
+// orders that are neither rejected nor delivered.
+func (s *Store) QueryOnOrder(ctx context.Context, productIDs []uuid.UUID) ([]purchasesuggestionbus.OnOrder, error) {
+	data := map[string]any{
+		"product_ids": productIDs,
+	}
+
+	const q = `
+	SELECT
+		sp.product_id, po.delivery_warehouse_id AS warehouse_id,
+		SUM(li.quantity_ordered - li.quantity_received - li.quantity_cancelled) AS quantity
+	FROM procurement.purchase_order_line_items li
+	JOIN procurement.purchase_orders po ON po.id = li.purchase_order_id
+	JOIN procurement.supplier_products sp ON sp.id = li.supplier_product_id
+	WHERE sp.product_id = ANY(:product_ids)
+		AND po.rejected_date IS NULL
+		AND po.actual_delivery_date IS NULL
+		AND li.quantity_ordered > li.quantity_received + li.quantity_cancelled
+	GROUP BY sp.product_id, po.delivery_warehouse_id`
+
+	var dbOnOrder []onOrder
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbOnOrder); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusOnOrder(dbOnOrder), nil
+}
+
+// QueryCandidates retrieves the supplier products of active suppliers for the
+// products. The supplier product's lead time wins over the supplier's; a
+// negative minimum order quantity reads as none.
+func (s *Store) QueryCandidates(ctx context.Context, productIDs []uuid.UUID) ([]purchasesuggestionbus.Candidate, error) {
+	data := map[string]any{
+		"product_ids": productIDs,
+	}
+
+	const q = `
+	SELECT
+		sp.id AS supplier_product_id, sp.supplier_id, sp.product_id, sp.unit_cost,
+		COALESCE(NULLIF(sp.lead_time_days, 0), s.lead_time_days) AS lead_time_days,
+		GREATEST(sp.min_order_quantity, 0) AS min_order_quantity, sp.max_order_quantity, COALESCE(s.rating, 0)::float8 AS rating, sp.is_primary_supplier
+	FROM procurement.supplier_products sp
+	JOIN procurement.suppliers s ON s.id = sp.supplier_id
+	WHERE sp.product_id = ANY(:product_ids)
+		AND s.is_active
+	ORDER BY sp.product_id, sp.id`
+
+	var dbCandidates []candidate
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbCandidates); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusCandidates(dbCandidates), nil
+}
+
+// LockRun takes a transaction-scoped advisory lock that serializes suggestion
+// runs, so two cannot suggest the same product.
+func (s *Store) LockRun(ctx context.Context) error {
+	const q = `SELECT pg_advisory_xact_lock(hashtext('procurement.purchase_suggestions'))`
+
+	if err := sqldb.ExecContext(ctx, s.log, s.db, q); err != nil {
+		return fmt.Errorf("execcontext: %w", err)
+	}
+
+	return nil
+}
+
+// =============================================================================
+
+// attachLines loads the lines of the suggestions, in product order.
+func (s *Store) attachLines(ctx context.Context, suggestions []purchasesuggestionbus.Suggestion) error {
+	if len(suggestions) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(suggestions))
+	for i, sg := range suggestions {
+		ids[i] = sg.ID
+	}
+
+	data := map[string]any{
+		"ids": ids,
+	}
+
+	const q = `
+	SELECT
+		id, suggestion_id, product_id, supplier_product_id, available_quantity, on_order_quantity, reorder_point,
+		target_quantity, needed_quantity, units_per_case, min_order_quantity, suggested_quantity, quantity,
+		unit_cost, line_total, lead_time_days, score, created_date, updated_date
+	FROM
+		procurement.purchase_suggestion_lines
+	WHERE
+		suggestion_id = ANY(:ids)
+	ORDER BY
+		product_id
+	`
+
+	var dbLines []line
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbLines); err != nil {
+		return fmt.Errorf("namedqueryslice: lines: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]int, len(suggestions))
+	for i, sg := range suggestions {
+		byID[sg.ID] = i
+		suggestions[i].Lines = []purchasesuggestionbus.Line{}
+	}
+	for _, l := range dbLines {
+		i := byID[l.SuggestionID]
+		suggestions[i].Lines = append(suggestions[i].Lines, toBusLine(l))
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package purchasesuggestionbus
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BuildNeeds totals the items below their reorder point per product and
+// warehouse. Each item is ordered up to its maximum stock, or to its reorder
+// point plus its economic order quantity when it has no maximum above the
+// reorder point; what open purchase orders bring counts against that. Needs
+// the open orders already cover are dropped. Needs keep the order in which
+// their product and warehouse first appear in items.
+func BuildNeeds(items []Item, onOrder []OnOrder) []Need {
+	type key struct{ productID, warehouseID uuid.UUID }
+
+	incoming := make(map[key]int)
+	for _, o := range onOrder {
+		incoming[key{o.ProductID, o.WarehouseID}] += o.Quantity
+	}
+
+	var keys []key
+	byKey := make(map[key]*Need)
+	for _, item := range items {
+		k := key{item.ProductID, item.WarehouseID}
+		n, ok := byKey[k]
+		if !ok {
+			n = &Need{
+				ProductID:    item.ProductID,
+				WarehouseID:  item.WarehouseID,
+				UnitsPerCase: max(item.UnitsPerCase, 1),
+			}
+			byKey[k] = n
+			keys = append(keys, k)
+		}
+
+		target := item.ReorderPoint + max(item.EconomicOrderQuantity, 0)
+		if item.MaximumStock > item.ReorderPoint {
+			target = item.MaximumStock
+		}
+
+		n.Available += item.Available
+		n.ReorderPoint += item.ReorderPoint
+		n.Target += target
+	}
+
+	needs := make([]Need, 0, len(keys))
+	for _, k := range keys {
+		n := byKey[k]
+		n.OnOrder = incoming[k]
+		n.Needed = n.Target - n.Available - n.OnOrder
+		if n.Needed <= 0 {
+			continue
+		}
+		needs = append(needs, *n)
+	}
+
+	return needs
+}
+
+// OrderQuantity is the quantity to buy of a product from a candidate to cover
+// needed: at least the candidate's minimum order quantity, rounded up to whole
+// cases, and no more than its maximum order quantity in whole cases.
+func OrderQuantity(needed int, c Candidate, unitsPerCase int) int {
+	pack := max(unitsPerCase, 1)
+
+	q := max(needed, c.MinOrderQuantity, 1)
+	q = (q + pack - 1) / pack * pack
+
+	if c.MaxOrderQuantity > 0 && q > c.MaxOrderQuantity {
+		q = c.MaxOrderQuantity / pack * pack
+		if q == 0 {
+			q = c.MaxOrderQuantity
+		}
+	}
+
+	return q
+}
+
+// ChooseSupplier scores each candidate for the need with the weights and
+// returns the best one with the quantity to buy from it. Ties go to the
+// primary supplier, then the cheaper unit cost. Returns false when there is
+// no candidate.
+func ChooseSupplier(need Need, candidates []Candidate, w Weights) (Choice, bool) {
+	if len(candidates) == 0 {
+		return Choice{}, false
+	}
+
+	if w.IsZero() {
+		w = DefaultWeights
+	}
+	total := w.Price + w.LeadTime + w.MinOrder + w.Rating
+
+	minCost, minLead, maxRating := math.Inf(1), math.MaxInt, 0.0
+	for _, c := range candidates {
+		minCost = min(minCost, c.UnitCost)
+		minLead = min(minLead, c.LeadTimeDays)
+		maxRating = max(maxRating, c.Rating)
+	}
+
+	var best Choice
+	for i, c := range candidates {
+		qty := OrderQuantity(need.Needed, c, need.UnitsPerCase)
+
+		price := 1.0
+		if c.UnitCost > minCost {
+			price = minCost / c.UnitCost
+		}
+
+		lead := float64(minLead+1) / float64(c.LeadTimeDays+1)
+
+		minOrder := min(float64(need.Needed)/float64(qty), 1)
+
+		rating := 1.0
+		if maxRating > 0 {
+			rating = c.Rating / maxRating
+		}
+
+		score := (w.Price*price + w.LeadTime*lead + w.MinOrder*minOrder + w.Rating*rating) / total
+		score = math.Round(score*10000) / 10000
+
+		choice := Choice{Need: need, Candidate: c, Quantity: qty, Score: score}
+		if i == 0 || better(choice, best) {
+			best = choice
+		}
+	}
+
+	return best, true
+}
+
+// Consolidate groups the choices into one draft suggestion per supplier and
+// delivery warehouse, in the order the pairs first appear.
+func Consolidate(choices []Choice, createdBy uuid.UUID, now time.Time) []Suggestion {
+	type key struct{ supplierID, warehouseID uuid.UUID }
+
+	var keys []key
+	byKey := make(map[key]*Suggestion)
+	for _, ch := range choices {
+		k := key{ch.Candidate.SupplierID, ch.Need.WarehouseID}
+		s, ok := byKey[k]
+		if !ok {
+			s = &Suggestion{
+				ID:                  uuid.New(),
+				SupplierID:          ch.Candidate.SupplierID,
+				DeliveryWarehouseID: ch.Need.WarehouseID,
+				Status:              StatusDraft,
+				CreatedBy:           createdBy,
+				UpdatedBy:           createdBy,
+				CreatedDate:         now,
+				UpdatedDate:         now,
+			}
+			byKey[k] = s
+			keys = append(keys, k)
+		}
+
+		s.Lines = append(s.Lines, Line{
+			ID:                uuid.New(),
+			SuggestionID:      s.ID,
+			ProductID:         ch.Need.ProductID,
+			SupplierProductID: ch.Candidate.SupplierProductID,
+			AvailableQuantity: ch.Need.Available,
+			OnOrderQuantity:   ch.Need.OnOrder,
+			ReorderPoint:      ch.Need.ReorderPoint,
+			TargetQuantity:    ch.Need.Target,
+			NeededQuantity:    ch.Need.Needed,
+			UnitsPerCase:      ch.Need.UnitsPerCase,
+			MinOrderQuantity:  ch.Candidate.MinOrderQuantity,
+			SuggestedQuantity: ch.Quantity,
+			Quantity:          ch.Quantity,
+			UnitCost:          ch.Candidate.UnitCost,
+			LineTotal:         lineTotal(ch.Quantity, ch.Candidate.UnitCost),
+			LeadTimeDays:      ch.Candidate.LeadTimeDays,
+			Score:             ch.Score,
+			CreatedDate:       now,
+			UpdatedDate:       now,
+		})
+	}
+
+	suggestions := make([]Suggestion, len(keys))
+	for i, k := range keys {
+		s := byKey[k]
+		s.total()
+		suggestions[i] = *s
+	}
+
+	return suggestions
+}
+
+// =============================================================================
+
+// total recomputes the suggestion's subtotal and lead time from its lines.
+func (s *Suggestion) total() {
+	var subtotal float64
+	var lead int
+	for _, l := range s.Lines {
+		subtotal += l.LineTotal
+		lead = max(lead, l.LeadTimeDays)
+	}
+
+	s.Subtotal = math.Round(subtotal*100) / 100
+	s.LeadTimeDays = lead
+}
+
+// better reports whether a beats b: the higher score, then the primary
+// supplier, then the cheaper unit cost.
+func better(a, b Choice) bool {
+	switch {
+	case a.Score != b.Score:
+		return a.Score > b.Score
+	case a.Candidate.IsPrimary != b.Candidate.IsPrimary:
+		return a.Candidate.IsPrimary
+	}
+	return a.Candidate.UnitCost < b.Candidate.UnitCost
+}
+
+func lineTotal(quantity int, unitCost float64) float64 {
+	return math.Round(float64(quantity)*unitCost*100) / 100
+}
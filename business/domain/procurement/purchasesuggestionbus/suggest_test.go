@@ -0,0 +1,124 @@
+package purchasesuggestionbus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var now = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func TestBuildNeeds(t *testing.T) {
+	product, warehouse, covered := uuid.New(), uuid.New(), uuid.New()
+	items := []Item{
+		{ID: uuid.New(), ProductID: product, WarehouseID: warehouse, Available: 4, ReorderPoint: 10, MaximumStock: 50, UnitsPerCase: 12},
+		{ID: uuid.New(), ProductID: product, WarehouseID: warehouse, Available: 2, ReorderPoint: 5, EconomicOrderQuantity: 20, UnitsPerCase: 12},
+		{ID: uuid.New(), ProductID: covered, WarehouseID: warehouse, Available: 0, ReorderPoint: 10, EconomicOrderQuantity: 10},
+	}
+	onOrder := []OnOrder{
+		{ProductID: product, WarehouseID: warehouse, Quantity: 9},
+		{ProductID: covered, WarehouseID: warehouse, Quantity: 20},
+	}
+
+	needs := BuildNeeds(items, onOrder)
+
+	if len(needs) != 1 {
+		t.Fatalf("needs = %d, want the covered product dropped", len(needs))
+	}
+
+	n := needs[0]
+	switch {
+	case n.Target != 75:
+		t.Fatalf("target = %d, want the maximum stock plus reorder point and EOQ", n.Target)
+	case n.Available != 6 || n.OnOrder != 9:
+		t.Fatalf("available/on order = %d/%d, want 6/9", n.Available, n.OnOrder)
+	case n.Needed != 60:
+		t.Fatalf("needed = %d, want 60", n.Needed)
+	case n.UnitsPerCase != 12:
+		t.Fatalf("units per case = %d, want 12", n.UnitsPerCase)
+	}
+}
+
+func TestOrderQuantity(t *testing.T) {
+	tests := []struct {
+		name   string
+		needed int
+		c      Candidate
+		pack   int
+		want   int
+	}{
+		{"rounds up to cases", 13, Candidate{}, 12, 24},
+		{"minimum order", 5, Candidate{MinOrderQuantity: 30}, 12, 36},
+		{"maximum in whole cases", 100, Candidate{MaxOrderQuantity: 50}, 12, 48},
+		{"maximum below a case", 100, Candidate{MaxOrderQuantity: 5}, 12, 5},
+		{"no case pack", 7, Candidate{}, 0, 7},
+	}
+
+	for _, tt := range tests {
+		if got := OrderQuantity(tt.needed, tt.c, tt.pack); got != tt.want {
+			t.Fatalf("%s: quantity = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestChooseSupplier(t *testing.T) {
+	need := Need{ProductID: uuid.New(), WarehouseID: uuid.New(), Needed: 10, UnitsPerCase: 1}
+
+	cheap := Candidate{SupplierProductID: uuid.New(), SupplierID: uuid.New(), UnitCost: 5, LeadTimeDays: 20, Rating: 3}
+	fast := Candidate{SupplierProductID: uuid.New(), SupplierID: uuid.New(), UnitCost: 10, LeadTimeDays: 1, Rating: 5}
+
+	choice, ok := ChooseSupplier(need, []Candidate{fast, cheap}, Weights{Price: 1})
+	if !ok || choice.Candidate.SupplierProductID != cheap.SupplierProductID {
+		t.Fatalf("price only: chose %v, want the cheaper supplier", choice.Candidate.SupplierProductID)
+	}
+	if choice.Score != 1 || choice.Quantity != 10 {
+		t.Fatalf("price only: score/quantity = %v/%d, want 1/10", choice.Score, choice.Quantity)
+	}
+
+	choice, _ = ChooseSupplier(need, []Candidate{cheap, fast}, Weights{LeadTime: 1, Rating: 1})
+	if choice.Candidate.SupplierProductID != fast.SupplierProductID {
+		t.Fatalf("lead time and rating: chose %v, want the faster supplier", choice.Candidate.SupplierProductID)
+	}
+
+	primary := cheap
+	primary.SupplierProductID = uuid.New()
+	primary.IsPrimary = true
+	choice, _ = ChooseSupplier(need, []Candidate{cheap, primary}, Weights{})
+	if choice.Candidate.SupplierProductID != primary.SupplierProductID {
+		t.Fatalf("tie: chose %v, want the primary supplier", choice.Candidate.SupplierProductID)
+	}
+
+	if _, ok := ChooseSupplier(need, nil, Weights{}); ok {
+		t.Fatal("no candidates: want no choice")
+	}
+}
+
+func TestConsolidate(t *testing.T) {
+	supplier, warehouse, user := uuid.New(), uuid.New(), uuid.New()
+	choices := []Choice{
+		{Need: Need{ProductID: uuid.New(), WarehouseID: warehouse, Needed: 10}, Candidate: Candidate{SupplierID: supplier, UnitCost: 1.25, LeadTimeDays: 3}, Quantity: 12},
+		{Need: Need{ProductID: uuid.New(), WarehouseID: uuid.New(), Needed: 4}, Candidate: Candidate{SupplierID: supplier, UnitCost: 2, LeadTimeDays: 5}, Quantity: 4},
+		{Need: Need{ProductID: uuid.New(), WarehouseID: warehouse, Needed: 1}, Candidate: Candidate{SupplierID: supplier, UnitCost: 3.5, LeadTimeDays: 7}, Quantity: 2},
+	}
+
+	suggestions := Consolidate(choices, user, now)
+
+	if len(suggestions) != 2 {
+		t.Fatalf("suggestions = %d, want one per supplier and warehouse", len(suggestions))
+	}
+
+	s := suggestions[0]
+	switch {
+	case s.DeliveryWarehouseID != warehouse || len(s.Lines) != 2:
+		t.Fatalf("first suggestion = %v with %d lines, want the first warehouse with 2", s.DeliveryWarehouseID, len(s.Lines))
+	case s.Status != StatusDraft || s.CreatedBy != user:
+		t.Fatalf("status/created by = %s/%v, want a draft by the user", s.Status, s.CreatedBy)
+	case s.Subtotal != 22:
+		t.Fatalf("subtotal = %v, want 22", s.Subtotal)
+	case s.LeadTimeDays != 7:
+		t.Fatalf("lead time = %d, want the longest line's", s.LeadTimeDays)
+	case s.Lines[0].SuggestionID != s.ID || s.Lines[0].Quantity != s.Lines[0].SuggestedQuantity:
+		t.Fatal("lines must belong to the suggestion and start at the suggested quantity")
+	}
+}
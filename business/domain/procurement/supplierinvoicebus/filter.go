@@ -0,0 +1,13 @@
+package supplierinvoicebus
+
+import "github.com/google/uuid"
+
+// QueryFilter holds optional filters for querying supplier invoices.
+type QueryFilter struct {
+	ID              *uuid.UUID
+	InvoiceNumber   *string
+	SupplierID      *uuid.UUID
+	PurchaseOrderID *uuid.UUID
+	Status          *string
+	MatchStatus     *string
+}
@@ -0,0 +1,105 @@
+package supplierinvoicebus
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// epsilon absorbs float rounding when amounts are compared.
+const epsilon = 1e-9
+
+// Match compares each line of the invoice with its purchase order line and
+// returns the invoice with its line and header match statuses set. The unit
+// price is checked against the ordered unit cost net of discount and the
+// quantity against what was received less what other invoices already
+// billed. A line whose order line is missing, or belongs to another purchase
+// order, has nothing billable and is a quantity variance.
+func Match(invoice SupplierInvoice, orderLines []OrderLine, now time.Time) SupplierInvoice {
+	byID := make(map[uuid.UUID]OrderLine, len(orderLines))
+	for _, ol := range orderLines {
+		byID[ol.ID] = ol
+	}
+
+	lines := make([]Line, len(invoice.Lines))
+	status := MatchMatched
+	for i, l := range invoice.Lines {
+		ol, ok := byID[l.PurchaseOrderLineItemID]
+		if !ok || ol.PurchaseOrderID != invoice.PurchaseOrderID {
+			ol = OrderLine{}
+		}
+
+		l = matchLine(l, ol, invoice.Tolerance)
+		l.UpdatedDate = now
+		lines[i] = l
+
+		status = worse(status, l.MatchStatus)
+	}
+
+	invoice.Lines = lines
+	invoice.MatchStatus = status
+	invoice.MatchedDate = &now
+
+	return invoice
+}
+
+// ExpectedUnitCost is the ordered unit cost of an order line net of its
+// discount spread over the quantity ordered.
+func ExpectedUnitCost(ol OrderLine) float64 {
+	if ol.QuantityOrdered <= 0 {
+		return ol.UnitCost
+	}
+	return ol.UnitCost - ol.Discount/float64(ol.QuantityOrdered)
+}
+
+// =============================================================================
+
+func matchLine(l Line, ol OrderLine, t Tolerance) Line {
+	l.ExpectedUnitCost = math.Round(ExpectedUnitCost(ol)*10000) / 10000
+	l.ReceivedQuantity = ol.QuantityReceived
+	l.PreviouslyInvoiced = ol.Invoiced
+
+	billable := max(ol.QuantityReceived-ol.Invoiced, 0)
+	l.QuantityVariance = l.Quantity - billable
+	l.PriceVariance = math.Round((l.UnitPrice-l.ExpectedUnitCost)*float64(l.Quantity)*100) / 100
+
+	switch {
+	case t.QuantityExceeded(billable, l.Quantity):
+		l.MatchStatus = MatchQuantityVariance
+	case t.PriceExceeded(l.ExpectedUnitCost, l.UnitPrice):
+		l.MatchStatus = MatchPriceVariance
+	default:
+		l.MatchStatus = MatchMatched
+	}
+
+	return l
+}
+
+// worse returns the more severe of two match statuses.
+func worse(a, b string) string {
+	rank := map[string]int{
+		MatchMatched:          0,
+		MatchPriceVariance:    1,
+		MatchQuantityVariance: 2,
+	}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// total sums the line totals into the subtotal and adds tax and freight.
+func (inv *SupplierInvoice) total() {
+	var subtotal float64
+	for _, l := range inv.Lines {
+		subtotal += l.LineTotal
+	}
+
+	inv.Subtotal = math.Round(subtotal*100) / 100
+	inv.TotalAmount = math.Round((inv.Subtotal+inv.TaxAmount+inv.FreightAmount)*100) / 100
+}
+
+func lineTotal(quantity int, unitPrice float64) float64 {
+	return math.Round(float64(quantity)*unitPrice*100) / 100
+}
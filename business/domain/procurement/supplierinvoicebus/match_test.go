@@ -0,0 +1,182 @@
+package supplierinvoicebus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var now = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func TestTolerance(t *testing.T) {
+	tol := Tolerance{PricePercent: 2, PriceAmount: 0.05, QuantityPercent: 10, QuantityUnits: 1}
+
+	prices := []struct {
+		name      string
+		expected  float64
+		unitPrice float64
+		want      bool
+	}{
+		{"exact", 10, 10, false},
+		{"under", 10, 9, false},
+		{"within percent", 10, 10.2, false},
+		{"over percent", 10, 10.21, true},
+		{"amount wins on cheap items", 1, 1.05, false},
+		{"over amount", 1, 1.06, true},
+	}
+	for _, tt := range prices {
+		if got := tol.PriceExceeded(tt.expected, tt.unitPrice); got != tt.want {
+			t.Fatalf("price %s: exceeded = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+
+	quantities := []struct {
+		name     string
+		billable int
+		quantity int
+		want     bool
+	}{
+		{"exact", 50, 50, false},
+		{"partial", 50, 20, false},
+		{"within percent", 50, 55, false},
+		{"over percent", 50, 56, true},
+		{"units win on small lines", 3, 4, false},
+		{"nothing billable", 0, 2, true},
+	}
+	for _, tt := range quantities {
+		if got := tol.QuantityExceeded(tt.billable, tt.quantity); got != tt.want {
+			t.Fatalf("quantity %s: exceeded = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExpectedUnitCost(t *testing.T) {
+	ol := OrderLine{QuantityOrdered: 10, UnitCost: 5, Discount: 2}
+	if got := ExpectedUnitCost(ol); got != 4.8 {
+		t.Fatalf("expected unit cost = %v, want the discount spread over the order", got)
+	}
+
+	if got := ExpectedUnitCost(OrderLine{UnitCost: 5, Discount: 2}); got != 5 {
+		t.Fatalf("expected unit cost = %v, want the unit cost without a quantity", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	po, other := uuid.New(), uuid.New()
+	priced, received, billedBefore, foreign := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	orderLines := []OrderLine{
+		{ID: priced, PurchaseOrderID: po, QuantityOrdered: 10, QuantityReceived: 10, UnitCost: 5},
+		{ID: received, PurchaseOrderID: po, QuantityOrdered: 20, QuantityReceived: 12, UnitCost: 2},
+		{ID: billedBefore, PurchaseOrderID: po, QuantityOrdered: 8, QuantityReceived: 8, UnitCost: 3, Invoiced: 6},
+		{ID: foreign, PurchaseOrderID: other, QuantityOrdered: 5, QuantityReceived: 5, UnitCost: 1},
+	}
+
+	line := func(orderLine uuid.UUID, quantity int, unitPrice float64) Line {
+		return Line{ID: uuid.New(), PurchaseOrderLineItemID: orderLine, Quantity: quantity, UnitPrice: unitPrice}
+	}
+
+	tests := []struct {
+		name       string
+		lines      []Line
+		wantLines  []string
+		wantStatus string
+	}{
+		{
+			name:       "matched",
+			lines:      []Line{line(priced, 10, 5.05), line(received, 12, 2)},
+			wantLines:  []string{MatchMatched, MatchMatched},
+			wantStatus: MatchMatched,
+		},
+		{
+			name:       "price variance",
+			lines:      []Line{line(priced, 10, 5.5), line(received, 10, 2)},
+			wantLines:  []string{MatchPriceVariance, MatchMatched},
+			wantStatus: MatchPriceVariance,
+		},
+		{
+			name:       "billed beyond receipt",
+			lines:      []Line{line(priced, 10, 5.5), line(received, 15, 2)},
+			wantLines:  []string{MatchPriceVariance, MatchQuantityVariance},
+			wantStatus: MatchQuantityVariance,
+		},
+		{
+			name:       "already billed",
+			lines:      []Line{line(billedBefore, 4, 3)},
+			wantLines:  []string{MatchQuantityVariance},
+			wantStatus: MatchQuantityVariance,
+		},
+		{
+			name:       "another order's line",
+			lines:      []Line{line(foreign, 1, 1)},
+			wantLines:  []string{MatchQuantityVariance},
+			wantStatus: MatchQuantityVariance,
+		},
+	}
+
+	for _, tt := range tests {
+		invoice := SupplierInvoice{
+			PurchaseOrderID: po,
+			Tolerance:       DefaultTolerance,
+			Lines:           tt.lines,
+		}
+
+		got := Match(invoice, orderLines, now)
+
+		if got.MatchStatus != tt.wantStatus {
+			t.Fatalf("%s: match status = %s, want %s", tt.name, got.MatchStatus, tt.wantStatus)
+		}
+		if got.MatchedDate == nil || !got.MatchedDate.Equal(now) {
+			t.Fatalf("%s: matched date = %v, want %v", tt.name, got.MatchedDate, now)
+		}
+		for i, want := range tt.wantLines {
+			if got.Lines[i].MatchStatus != want {
+				t.Fatalf("%s: line %d match status = %s, want %s", tt.name, i, got.Lines[i].MatchStatus, want)
+			}
+		}
+	}
+
+	invoice := SupplierInvoice{
+		PurchaseOrderID: po,
+		Tolerance:       DefaultTolerance,
+		Lines:           []Line{line(received, 15, 2.5), line(billedBefore, 2, 3)},
+	}
+
+	got := Match(invoice, orderLines, now)
+
+	l := got.Lines[0]
+	switch {
+	case l.ExpectedUnitCost != 2 || l.ReceivedQuantity != 12:
+		t.Fatalf("expected cost/received = %v/%d, want 2/12", l.ExpectedUnitCost, l.ReceivedQuantity)
+	case l.PriceVariance != 7.5:
+		t.Fatalf("price variance = %v, want 7.5", l.PriceVariance)
+	case l.QuantityVariance != 3:
+		t.Fatalf("quantity variance = %d, want 3", l.QuantityVariance)
+	}
+
+	if l := got.Lines[1]; l.PreviouslyInvoiced != 6 || l.QuantityVariance != 0 {
+		t.Fatalf("previously invoiced/variance = %d/%d, want 6/0", l.PreviouslyInvoiced, l.QuantityVariance)
+	}
+
+	if invoice.Lines[0].MatchStatus != "" {
+		t.Fatal("match must not modify the caller's lines")
+	}
+}
+
+func TestTotal(t *testing.T) {
+	invoice := SupplierInvoice{
+		TaxAmount:     1.5,
+		FreightAmount: 10,
+		Lines: []Line{
+			{LineTotal: lineTotal(3, 3.333)},
+			{LineTotal: lineTotal(2, 0.125)},
+		},
+	}
+
+	invoice.total()
+
+	if invoice.Subtotal != 10.25 || invoice.TotalAmount != 21.75 {
+		t.Fatalf("subtotal/total = %v/%v, want 10.25/21.75", invoice.Subtotal, invoice.TotalAmount)
+	}
+}
@@ -0,0 +1,165 @@
+package supplierinvoicebus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JSON tags are required for workflow event serialization. The workflow system
+// marshals business models to JSON for RawData in TriggerEvents.
+
+// Invoice statuses. An invoice is open until it is matched, then approved
+// for payment or, when it does not match, pending approval until the approval
+// workflow approves or rejects it.
+const (
+	StatusOpen            = "open"
+	StatusPendingApproval = "pending_approval"
+	StatusApproved        = "approved"
+	StatusRejected        = "rejected"
+)
+
+// Match statuses of an invoice and its lines. An invoice takes the worst
+// status of its lines: a quantity variance over a price variance over a match.
+const (
+	MatchUnmatched        = "unmatched"
+	MatchMatched          = "matched"
+	MatchPriceVariance    = "price_variance"
+	MatchQuantityVariance = "quantity_variance"
+)
+
+// Tolerance says how far over its purchase order an invoice may bill and
+// still match. A line's unit price may exceed the ordered unit cost by the
+// larger of PricePercent of that cost and PriceAmount; its quantity may exceed
+// the billable quantity by the larger of QuantityPercent of it and
+// QuantityUnits. Billing under the order never causes a variance.
+type Tolerance struct {
+	PricePercent    float64 `json:"price_percent"`
+	PriceAmount     float64 `json:"price_amount"`
+	QuantityPercent float64 `json:"quantity_percent"`
+	QuantityUnits   int     `json:"quantity_units"`
+}
+
+// DefaultTolerance is used when an invoice is created without tolerances.
+var DefaultTolerance = Tolerance{
+	PricePercent: 2,
+	PriceAmount:  0.01,
+}
+
+// PriceExceeded reports whether unitPrice is over expected by more than the
+// tolerance allows.
+func (t Tolerance) PriceExceeded(expected, unitPrice float64) bool {
+	allowed := max(expected*t.PricePercent/100, t.PriceAmount)
+	return unitPrice-expected > allowed+epsilon
+}
+
+// QuantityExceeded reports whether quantity is over billable by more than the
+// tolerance allows.
+func (t Tolerance) QuantityExceeded(billable, quantity int) bool {
+	allowed := max(float64(billable)*t.QuantityPercent/100, float64(t.QuantityUnits))
+	return float64(quantity-billable) > allowed+epsilon
+}
+
+// SupplierInvoice is a supplier's bill against one purchase order.
+type SupplierInvoice struct {
+	ID              uuid.UUID  `json:"id"`
+	InvoiceNumber   string     `json:"invoice_number"`
+	SupplierID      uuid.UUID  `json:"supplier_id"`
+	PurchaseOrderID uuid.UUID  `json:"purchase_order_id"`
+	InvoiceDate     time.Time  `json:"invoice_date"`
+	DueDate         time.Time  `json:"due_date"`
+	Subtotal        float64    `json:"subtotal"`
+	TaxAmount       float64    `json:"tax_amount"`
+	FreightAmount   float64    `json:"freight_amount"`
+	TotalAmount     float64    `json:"total_amount"`
+	Status          string     `json:"status"`
+	MatchStatus     string     `json:"match_status"`
+	Tolerance       Tolerance  `json:"tolerance"`
+	MatchedDate     *time.Time `json:"matched_date,omitempty"`
+	ApprovedBy      *uuid.UUID `json:"approved_by,omitempty"`
+	ApprovedDate    *time.Time `json:"approved_date,omitempty"`
+	ApprovalReason  string     `json:"approval_reason"`
+	RejectedBy      *uuid.UUID `json:"rejected_by,omitempty"`
+	RejectedDate    *time.Time `json:"rejected_date,omitempty"`
+	RejectionReason string     `json:"rejection_reason"`
+	Notes           string     `json:"notes"`
+	CreatedBy       uuid.UUID  `json:"created_by"`
+	UpdatedBy       uuid.UUID  `json:"updated_by"`
+	CreatedDate     time.Time  `json:"created_date"`
+	UpdatedDate     time.Time  `json:"updated_date"`
+	Lines           []Line     `json:"lines"`
+}
+
+// Line bills one purchase order line item. The match fields record what it
+// was compared with: ExpectedUnitCost is the ordered unit cost net of
+// discount, ReceivedQuantity what was received and PreviouslyInvoiced what
+// other live invoices already billed of it. PriceVariance is the amount billed
+// over the expected cost and QuantityVariance the units billed over what was
+// left to bill; both are zero or negative when the line bills under.
+type Line struct {
+	ID                      uuid.UUID `json:"id"`
+	InvoiceID               uuid.UUID `json:"invoice_id"`
+	PurchaseOrderLineItemID uuid.UUID `json:"purchase_order_line_item_id"`
+	Description             string    `json:"description"`
+	Quantity                int       `json:"quantity"`
+	UnitPrice               float64   `json:"unit_price"`
+	LineTotal               float64   `json:"line_total"`
+	MatchStatus             string    `json:"match_status"`
+	ExpectedUnitCost        float64   `json:"expected_unit_cost"`
+	ReceivedQuantity        int       `json:"received_quantity"`
+	PreviouslyInvoiced      int       `json:"previously_invoiced"`
+	PriceVariance           float64   `json:"price_variance"`
+	QuantityVariance        int       `json:"quantity_variance"`
+	CreatedDate             time.Time `json:"created_date"`
+	UpdatedDate             time.Time `json:"updated_date"`
+}
+
+// NewSupplierInvoice contains the information needed to enter an invoice.
+// A nil Tolerance uses DefaultTolerance.
+type NewSupplierInvoice struct {
+	InvoiceNumber   string
+	SupplierID      uuid.UUID
+	PurchaseOrderID uuid.UUID
+	InvoiceDate     time.Time
+	DueDate         time.Time
+	TaxAmount       float64
+	FreightAmount   float64
+	Tolerance       *Tolerance
+	Notes           string
+	Lines           []NewLine
+	CreatedBy       uuid.UUID
+}
+
+// NewLine contains the information needed to bill a purchase order line item.
+type NewLine struct {
+	PurchaseOrderLineItemID uuid.UUID
+	Description             string
+	Quantity                int
+	UnitPrice               float64
+}
+
+// UpdateSupplierInvoice contains the header fields that can change while an
+// invoice is unresolved. Any change puts the invoice back to open and
+// unmatched.
+type UpdateSupplierInvoice struct {
+	InvoiceNumber *string
+	InvoiceDate   *time.Time
+	DueDate       *time.Time
+	TaxAmount     *float64
+	FreightAmount *float64
+	Tolerance     *Tolerance
+	Notes         *string
+	UpdatedBy     uuid.UUID
+}
+
+// OrderLine is a purchase order line item as matching reads it. Invoiced is
+// the quantity live invoices other than the one being matched bill of it.
+type OrderLine struct {
+	ID               uuid.UUID
+	PurchaseOrderID  uuid.UUID
+	QuantityOrdered  int
+	QuantityReceived int
+	UnitCost         float64
+	Discount         float64
+	Invoiced         int
+}
@@ -0,0 +1,19 @@
+package supplierinvoicebus
+
+import "github.com/timmaaaz/ichor/business/sdk/order"
+
+// DefaultOrderBy represents the default ordering for supplier invoice queries.
+var DefaultOrderBy = order.NewBy(OrderByInvoiceDate, order.DESC)
+
+const (
+	OrderByID              = "id"
+	OrderByInvoiceNumber   = "invoice_number"
+	OrderBySupplierID      = "supplier_id"
+	OrderByPurchaseOrderID = "purchase_order_id"
+	OrderByInvoiceDate     = "invoice_date"
+	OrderByDueDate         = "due_date"
+	OrderByTotalAmount     = "total_amount"
+	OrderByStatus          = "status"
+	OrderByMatchStatus     = "match_status"
+	OrderByCreatedDate     = "created_date"
+)
@@ -0,0 +1,47 @@
+package supplierinvoicedb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+)
+
+func applyFilter(filter supplierinvoicebus.QueryFilter, data map[string]any, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["id"] = *filter.ID
+		wc = append(wc, "id = :id")
+	}
+
+	if filter.InvoiceNumber != nil {
+		data["invoice_number"] = "%" + *filter.InvoiceNumber + "%"
+		wc = append(wc, "invoice_number ILIKE :invoice_number")
+	}
+
+	if filter.SupplierID != nil {
+		data["supplier_id"] = *filter.SupplierID
+		wc = append(wc, "supplier_id = :supplier_id")
+	}
+
+	if filter.PurchaseOrderID != nil {
+		data["purchase_order_id"] = *filter.PurchaseOrderID
+		wc = append(wc, "purchase_order_id = :purchase_order_id")
+	}
+
+	if filter.Status != nil {
+		data["status"] = *filter.Status
+		wc = append(wc, "status = :status")
+	}
+
+	if filter.MatchStatus != nil {
+		data["match_status"] = *filter.MatchStatus
+		wc = append(wc, "match_status = :match_status")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
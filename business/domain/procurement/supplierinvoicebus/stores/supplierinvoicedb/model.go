@@ -0,0 +1,236 @@
+package supplierinvoicedb
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+)
+
+// invoice mirrors the procurement.supplier_invoices DB row.
+type invoice struct {
+	ID                       uuid.UUID      `db:"id"`
+	InvoiceNumber            string         `db:"invoice_number"`
+	SupplierID               uuid.UUID      `db:"supplier_id"`
+	PurchaseOrderID          uuid.UUID      `db:"purchase_order_id"`
+	InvoiceDate              time.Time      `db:"invoice_date"`
+	DueDate                  time.Time      `db:"due_date"`
+	Subtotal                 float64        `db:"subtotal"`
+	TaxAmount                float64        `db:"tax_amount"`
+	FreightAmount            float64        `db:"freight_amount"`
+	TotalAmount              float64        `db:"total_amount"`
+	Status                   string         `db:"status"`
+	MatchStatus              string         `db:"match_status"`
+	PriceTolerancePercent    float64        `db:"price_tolerance_percent"`
+	PriceToleranceAmount     float64        `db:"price_tolerance_amount"`
+	QuantityTolerancePercent float64        `db:"quantity_tolerance_percent"`
+	QuantityToleranceUnits   int            `db:"quantity_tolerance_units"`
+	MatchedDate              sql.NullTime   `db:"matched_date"`
+	ApprovedBy               uuid.NullUUID  `db:"approved_by"`
+	ApprovedDate             sql.NullTime   `db:"approved_date"`
+	ApprovalReason           sql.NullString `db:"approval_reason"`
+	RejectedBy               uuid.NullUUID  `db:"rejected_by"`
+	RejectedDate             sql.NullTime   `db:"rejected_date"`
+	RejectionReason          sql.NullString `db:"rejection_reason"`
+	Notes                    sql.NullString `db:"notes"`
+	CreatedBy                uuid.UUID      `db:"created_by"`
+	UpdatedBy                uuid.UUID      `db:"updated_by"`
+	CreatedDate              time.Time      `db:"created_date"`
+	UpdatedDate              time.Time      `db:"updated_date"`
+}
+
+func toDBInvoice(bus supplierinvoicebus.SupplierInvoice) invoice {
+	return invoice{
+		ID:                       bus.ID,
+		InvoiceNumber:            bus.InvoiceNumber,
+		SupplierID:               bus.SupplierID,
+		PurchaseOrderID:          bus.PurchaseOrderID,
+		InvoiceDate:              bus.InvoiceDate.UTC(),
+		DueDate:                  bus.DueDate.UTC(),
+		Subtotal:                 bus.Subtotal,
+		TaxAmount:                bus.TaxAmount,
+		FreightAmount:            bus.FreightAmount,
+		TotalAmount:              bus.TotalAmount,
+		Status:                   bus.Status,
+		MatchStatus:              bus.MatchStatus,
+		PriceTolerancePercent:    bus.Tolerance.PricePercent,
+		PriceToleranceAmount:     bus.Tolerance.PriceAmount,
+		QuantityTolerancePercent: bus.Tolerance.QuantityPercent,
+		QuantityToleranceUnits:   bus.Tolerance.QuantityUnits,
+		MatchedDate:              toNullTime(bus.MatchedDate),
+		ApprovedBy:               toNullUUID(bus.ApprovedBy),
+		ApprovedDate:             toNullTime(bus.ApprovedDate),
+		ApprovalReason:           sql.NullString{String: bus.ApprovalReason, Valid: bus.ApprovalReason != ""},
+		RejectedBy:               toNullUUID(bus.RejectedBy),
+		RejectedDate:             toNullTime(bus.RejectedDate),
+		RejectionReason:          sql.NullString{String: bus.RejectionReason, Valid: bus.RejectionReason != ""},
+		Notes:                    sql.NullString{String: bus.Notes, Valid: bus.Notes != ""},
+		CreatedBy:                bus.CreatedBy,
+		UpdatedBy:                bus.UpdatedBy,
+		CreatedDate:              bus.CreatedDate.UTC(),
+		UpdatedDate:              bus.UpdatedDate.UTC(),
+	}
+}
+
+func toBusInvoice(db invoice) supplierinvoicebus.SupplierInvoice {
+	return supplierinvoicebus.SupplierInvoice{
+		ID:              db.ID,
+		InvoiceNumber:   db.InvoiceNumber,
+		SupplierID:      db.SupplierID,
+		PurchaseOrderID: db.PurchaseOrderID,
+		InvoiceDate:     db.InvoiceDate.In(time.Local),
+		DueDate:         db.DueDate.In(time.Local),
+		Subtotal:        db.Subtotal,
+		TaxAmount:       db.TaxAmount,
+		FreightAmount:   db.FreightAmount,
+		TotalAmount:     db.TotalAmount,
+		Status:          db.Status,
+		MatchStatus:     db.MatchStatus,
+		Tolerance: supplierinvoicebus.Tolerance{
+			PricePercent:    db.PriceTolerancePercent,
+			PriceAmount:     db.PriceToleranceAmount,
+			QuantityPercent: db.QuantityTolerancePercent,
+			QuantityUnits:   db.QuantityToleranceUnits,
+		},
+		MatchedDate:     fromNullTime(db.MatchedDate),
+		ApprovedBy:      fromNullUUID(db.ApprovedBy),
+		ApprovedDate:    fromNullTime(db.ApprovedDate),
+		ApprovalReason:  db.ApprovalReason.String,
+		RejectedBy:      fromNullUUID(db.RejectedBy),
+		RejectedDate:    fromNullTime(db.RejectedDate),
+		RejectionReason: db.RejectionReason.String,
+		Notes:           db.Notes.String,
+		CreatedBy:       db.CreatedBy,
+		UpdatedBy:       db.UpdatedBy,
+		CreatedDate:     db.CreatedDate.In(time.Local),
+		UpdatedDate:     db.UpdatedDate.In(time.Local),
+	}
+}
+
+func toBusInvoices(dbs []invoice) []supplierinvoicebus.SupplierInvoice {
+	invoices := make([]supplierinvoicebus.SupplierInvoice, len(dbs))
+	for i, db := range dbs {
+		invoices[i] = toBusInvoice(db)
+	}
+	return invoices
+}
+
+// line mirrors the procurement.supplier_invoice_lines DB row.
+type line struct {
+	ID                      uuid.UUID      `db:"id"`
+	InvoiceID               uuid.UUID      `db:"invoice_id"`
+	PurchaseOrderLineItemID uuid.UUID      `db:"purchase_order_line_item_id"`
+	Description             sql.NullString `db:"description"`
+	Quantity                int            `db:"quantity"`
+	UnitPrice               float64        `db:"unit_price"`
+	LineTotal               float64        `db:"line_total"`
+	MatchStatus             string         `db:"match_status"`
+	ExpectedUnitCost        float64        `db:"expected_unit_cost"`
+	ReceivedQuantity        int            `db:"received_quantity"`
+	PreviouslyInvoiced      int            `db:"previously_invoiced"`
+	PriceVariance           float64        `db:"price_variance"`
+	QuantityVariance        int            `db:"quantity_variance"`
+	CreatedDate             time.Time      `db:"created_date"`
+	UpdatedDate             time.Time      `db:"updated_date"`
+}
+
+func toDBLine(bus supplierinvoicebus.Line) line {
+	return line{
+		ID:                      bus.ID,
+		InvoiceID:               bus.InvoiceID,
+		PurchaseOrderLineItemID: bus.PurchaseOrderLineItemID,
+		Description:             sql.NullString{String: bus.Description, Valid: bus.Description != ""},
+		Quantity:                bus.Quantity,
+		UnitPrice:               bus.UnitPrice,
+		LineTotal:               bus.LineTotal,
+		MatchStatus:             bus.MatchStatus,
+		ExpectedUnitCost:        bus.ExpectedUnitCost,
+		ReceivedQuantity:        bus.ReceivedQuantity,
+		PreviouslyInvoiced:      bus.PreviouslyInvoiced,
+		PriceVariance:           bus.PriceVariance,
+		QuantityVariance:        bus.QuantityVariance,
+		CreatedDate:             bus.CreatedDate.UTC(),
+		UpdatedDate:             bus.UpdatedDate.UTC(),
+	}
+}
+
+func toBusLine(db line) supplierinvoicebus.Line {
+	return supplierinvoicebus.Line{
+		ID:                      db.ID,
+		InvoiceID:               db.InvoiceID,
+		PurchaseOrderLineItemID: db.PurchaseOrderLineItemID,
+		Description:             db.Description.String,
+		Quantity:                db.Quantity,
+		UnitPrice:               db.UnitPrice,
+		LineTotal:               db.LineTotal,
+		MatchStatus:             db.MatchStatus,
+		ExpectedUnitCost:        db.ExpectedUnitCost,
+		ReceivedQuantity:        db.ReceivedQuantity,
+		PreviouslyInvoiced:      db.PreviouslyInvoiced,
+		PriceVariance:           db.PriceVariance,
+		QuantityVariance:        db.QuantityVariance,
+		CreatedDate:             db.CreatedDate.In(time.Local),
+		UpdatedDate:             db.UpdatedDate.In(time.Local),
+	}
+}
+
+// orderLine is a purchase order line item with what other live invoices
+// already bill of it.
+type orderLine struct {
+	ID               uuid.UUID `db:"id"`
+	PurchaseOrderID  uuid.UUID `db:"purchase_order_id"`
+	QuantityOrdered  int       `db:"quantity_ordered"`
+	QuantityReceived int       `db:"quantity_received"`
+	UnitCost         float64   `db:"unit_cost"`
+	Discount         float64   `db:"discount"`
+	Invoiced         int       `db:"invoiced"`
+}
+
+func toBusOrderLines(dbs []orderLine) []supplierinvoicebus.OrderLine {
+	lines := make([]supplierinvoicebus.OrderLine, len(dbs))
+	for i, db := range dbs {
+		lines[i] = supplierinvoicebus.OrderLine{
+			ID:               db.ID,
+			PurchaseOrderID:  db.PurchaseOrderID,
+			QuantityOrdered:  db.QuantityOrdered,
+			QuantityReceived: db.QuantityReceived,
+			UnitCost:         db.UnitCost,
+			Discount:         db.Discount,
+			Invoiced:         db.Invoiced,
+		}
+	}
+	return lines
+}
+
+// =============================================================================
+
+func toNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func fromNullUUID(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	v := id.UUID
+	return &v
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time.In(time.Local)
+	return &v
+}
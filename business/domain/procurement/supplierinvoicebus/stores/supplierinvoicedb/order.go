@@ -0,0 +1,30 @@
+package supplierinvoicedb
+
+import (
+	"fmt"
+
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+)
+
+var orderByFields = map[string]string{
+	supplierinvoicebus.OrderByID:              "id",
+	supplierinvoicebus.OrderByInvoiceNumber:   "invoice_number",
+	supplierinvoicebus.OrderBySupplierID:      "supplier_id",
+	supplierinvoicebus.OrderByPurchaseOrderID: "purchase_order_id",
+	supplierinvoicebus.OrderByInvoiceDate:     "invoice_date",
+	supplierinvoicebus.OrderByDueDate:         "due_date",
+	supplierinvoicebus.OrderByTotalAmount:     "total_amount",
+	supplierinvoicebus.OrderByStatus:          "status",
+	supplierinvoicebus.OrderByMatchStatus:     "match_status",
+	supplierinvoicebus.OrderByCreatedDate:     "created_date",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
@@ -0,0 +1,365 @@
+// Package supplierinvoicedb contains supplier invoice related CRUD
+// functionality.
+package supplierinvoicedb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// Store manages the set of APIs for supplier invoice database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// NewWithTx constructs a new Store value replacing the sqlx.DB
+// value with a sqlx.Tx value that is currently inside a transaction.
+func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (supplierinvoicebus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		log: s.log,
+		db:  ec,
+	}, nil
+}
+
+// Create inserts a new invoice and its lines into the database.
+func (s *Store) Create(ctx context.Context, inv supplierinvoicebus.SupplierInvoice) error {
+	const q = `
+	INSERT INTO procurement.supplier_invoices
+		(id, invoice_number, supplier_id, purchase_order_id, invoice_date, due_date, subtotal, tax_amount,
+		 freight_amount, total_amount, status, match_status, price_tolerance_percent, price_tolerance_amount,
+		 quantity_tolerance_percent, quantity_tolerance_units, matched_date, approved_by, approved_date,
+		 approval_reason, rejected_by, rejected_date, rejection_reason, notes, created_by, updated_by,
+		 created_date, updated_date)
+	VALUES
+		(:id, :invoice_number, :supplier_id, :purchase_order_id, :invoice_date, :due_date, :subtotal, :tax_amount,
+		 :freight_amount, :total_amount, :status, :match_status, :price_tolerance_percent, :price_tolerance_amount,
+		 :quantity_tolerance_percent, :quantity_tolerance_units, :matched_date, :approved_by, :approved_date,
+		 :approval_reason, :rejected_by, :rejected_date, :rejection_reason, :notes, :created_by, :updated_by,
+		 :created_date, :updated_date)
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBInvoice(inv)); err != nil {
+		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+			return fmt.Errorf("namedexeccontext: %w", supplierinvoicebus.ErrUnique)
+		}
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return fmt.Errorf("namedexeccontext: %w", supplierinvoicebus.ErrForeignKeyViolation)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	const ql = `
+	INSERT INTO procurement.supplier_invoice_lines
+		(id, invoice_id, purchase_order_line_item_id, description, quantity, unit_price, line_total, match_status,
+		 expected_unit_cost, received_quantity, previously_invoiced, price_variance, quantity_variance,
+		 created_date, updated_date)
+	VALUES
+		(:id, :invoice_id, :purchase_order_line_item_id, :description, :quantity, :unit_price, :line_total, :match_status,
+		 :expected_unit_cost, :received_quantity, :previously_invoiced, :price_variance, :quantity_variance,
+		 :created_date, :updated_date)
+	`
+
+	for _, l := range inv.Lines {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, ql, toDBLine(l)); err != nil {
+			if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+				return fmt.Errorf("namedexeccontext: %w", supplierinvoicebus.ErrForeignKeyViolation)
+			}
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Update modifies the header of an existing invoice in the database.
+func (s *Store) Update(ctx context.Context, inv supplierinvoicebus.SupplierInvoice) error {
+	const q = `
+	UPDATE procurement.supplier_invoices
+	SET
+		invoice_number             = :invoice_number,
+		invoice_date               = :invoice_date,
+		due_date                   = :due_date,
+		subtotal                   = :subtotal,
+		tax_amount                 = :tax_amount,
+		freight_amount             = :freight_amount,
+		total_amount               = :total_amount,
+		status                     = :status,
+		match_status               = :match_status,
+		price_tolerance_percent    = :price_tolerance_percent,
+		price_tolerance_amount     = :price_tolerance_amount,
+		quantity_tolerance_percent = :quantity_tolerance_percent,
+		quantity_tolerance_units   = :quantity_tolerance_units,
+		matched_date               = :matched_date,
+		approved_by                = :approved_by,
+		approved_date              = :approved_date,
+		approval_reason            = :approval_reason,
+		rejected_by                = :rejected_by,
+		rejected_date              = :rejected_date,
+		rejection_reason           = :rejection_reason,
+		notes                      = :notes,
+		updated_by                 = :updated_by,
+		updated_date               = :updated_date
+	WHERE
+		id = :id
+	`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBInvoice(inv)); err != nil {
+		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+			return fmt.Errorf("namedexeccontext: %w", supplierinvoicebus.ErrUnique)
+		}
+		if errors.Is(err, sqldb.ErrForeignKeyViolation) {
+			return fmt.Errorf("namedexeccontext: %w", supplierinvoicebus.ErrForeignKeyViolation)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateLines stores the match results of invoice lines.
+func (s *Store) UpdateLines(ctx context.Context, lines []supplierinvoicebus.Line) error {
+	const q = `
+	UPDATE procurement.supplier_invoice_lines
+	SET
+		match_status        = :match_status,
+		expected_unit_cost  = :expected_unit_cost,
+		received_quantity   = :received_quantity,
+		previously_invoiced = :previously_invoiced,
+		price_variance      = :price_variance,
+		quantity_variance   = :quantity_variance,
+		updated_date        = :updated_date
+	WHERE
+		id = :id
+	`
+
+	for _, l := range lines {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBLine(l)); err != nil {
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Query retrieves a list of invoices, with their lines, from the database.
+func (s *Store) Query(ctx context.Context, filter supplierinvoicebus.QueryFilter, orderBy order.By, page page.Page) ([]supplierinvoicebus.SupplierInvoice, error) {
+	data := map[string]any{
+		"offset":        (page.Number() - 1) * page.RowsPerPage(),
+		"rows_per_page": page.RowsPerPage(),
+	}
+
+	const q = `
+	SELECT
+		id, invoice_number, supplier_id, purchase_order_id, invoice_date, due_date, subtotal, tax_amount,
+		freight_amount, total_amount, status, match_status, price_tolerance_percent, price_tolerance_amount,
+		quantity_tolerance_percent, quantity_tolerance_units, matched_date, approved_by, approved_date,
+		approval_reason, rejected_by, rejected_date, rejection_reason, notes, created_by, updated_by,
+		created_date, updated_date
+	FROM
+		procurement.supplier_invoices
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbInvoices []invoice
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbInvoices); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	invoices := toBusInvoices(dbInvoices)
+	if err := s.attachLines(ctx, invoices); err != nil {
+		return nil, err
+	}
+
+	return invoices, nil
+}
+
+// Count returns the total number of invoices matching the filter.
+func (s *Store) Count(ctx context.Context, filter supplierinvoicebus.QueryFilter) (int, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		COUNT(1) AS count
+	FROM
+		procurement.supplier_invoices
+	`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID retrieves a single invoice, with its lines, by its ID.
+func (s *Store) QueryByID(ctx context.Context, invoiceID uuid.UUID) (supplierinvoicebus.SupplierInvoice, error) {
+	data := map[string]any{
+		"id": invoiceID.String(),
+	}
+
+	const q = `
+	SELECT
+		id, invoice_number, supplier_id, purchase_order_id, invoice_date, due_date, subtotal, tax_amount,
+		freight_amount, total_amount, status, match_status, price_tolerance_percent, price_tolerance_amount,
+		quantity_tolerance_percent, quantity_tolerance_units, matched_date, approved_by, approved_date,
+		approval_reason, rejected_by, rejected_date, rejection_reason, notes, created_by, updated_by,
+		created_date, updated_date
+	FROM
+		procurement.supplier_invoices
+	WHERE
+		id = :id
+	`
+
+	var dbInvoice invoice
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbInvoice); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return supplierinvoicebus.SupplierInvoice{}, supplierinvoicebus.ErrNotFound
+		}
+		return supplierinvoicebus.SupplierInvoice{}, fmt.Errorf("namedqueryrow: %w", err)
+	}
+
+	invoices := []supplierinvoicebus.SupplierInvoice{toBusInvoice(dbInvoice)}
+	if err := s.attachLines(ctx, invoices); err != nil {
+		return supplierinvoicebus.SupplierInvoice{}, err
+	}
+
+	return invoices[0], nil
+}
+
+// QueryOrderLines retrieves the line items of a purchase order with the
+// quantity that invoices other than invoiceID already bill of each. Only
+// matched invoices count: pending approval or approved.
+func (s *Store) QueryOrderLines(ctx context.Context, purchaseOrderID uuid.UUID, invoiceID uuid.UUID) ([]supplierinvoicebus.OrderLine, error) {
+	data := map[string]any{
+		"purchase_order_id": purchaseOrderID,
+		"invoice_id":        invoiceID,
+		"statuses":          []string{supplierinvoicebus.StatusPendingApproval, supplierinvoicebus.StatusApproved},
+	}
+
+	const q = `
+	SELECT
+		li.id, li.purchase_order_id, li.quantity_ordered, li.quantity_received, li.unit_cost,
+		COALESCE(li.discount, 0) AS discount,
+		COALESCE((
+			SELECT SUM(sil.quantity)
+			FROM procurement.supplier_invoice_lines sil
+			JOIN procurement.supplier_invoices si ON si.id = sil.invoice_id
+			WHERE sil.purchase_order_line_item_id = li.id
+				AND si.id <> :invoice_id
+				AND si.status = ANY(:statuses)
+		), 0) AS invoiced
+	FROM procurement.purchase_order_line_items li
+	WHERE li.purchase_order_id = :purchase_order_id
+	ORDER BY li.id`
+
+	var dbLines []orderLine
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbLines); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusOrderLines(dbLines), nil
+}
+
+// LockPurchaseOrder takes a transaction-scoped advisory lock on a purchase
+// order, so two invoices against it cannot be matched at once.
+func (s *Store) LockPurchaseOrder(ctx context.Context, purchaseOrderID uuid.UUID) error {
+	data := map[string]any{
+		"key": "procurement.supplier_invoices:" + purchaseOrderID.String(),
+	}
+
+	const q = `SELECT pg_advisory_xact_lock(hashtext(:key))`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, data); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// =============================================================================
+
+// attachLines loads the lines of the invoices, in creation order.
+func (s *Store) attachLines(ctx context.Context, invoices []supplierinvoicebus.SupplierInvoice) error {
+	if len(invoices) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(invoices))
+	for i, inv := range invoices {
+		ids[i] = inv.ID
+	}
+
+	data := map[string]any{
+		"ids": ids,
+	}
+
+	const q = `
+	SELECT
+		id, invoice_id, purchase_order_line_item_id, description, quantity, unit_price, line_total, match_status,
+		expected_unit_cost, received_quantity, previously_invoiced, price_variance, quantity_variance,
+		created_date, updated_date
+	FROM
+		procurement.supplier_invoice_lines
+	WHERE
+		invoice_id = ANY(:ids)
+	ORDER BY
+		created_date, id
+	`
+
+	var dbLines []line
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbLines); err != nil {
+		return fmt.Errorf("namedqueryslice: lines: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]int, len(invoices))
+	for i, inv := range invoices {
+		byID[inv.ID] = i
+		invoices[i].Lines = []supplierinvoicebus.Line{}
+	}
+	for _, l := range dbLines {
+		i := byID[l.InvoiceID]
+		invoices[i].Lines = append(invoices[i].Lines, toBusLine(l))
+	}
+
+	return nil
+}
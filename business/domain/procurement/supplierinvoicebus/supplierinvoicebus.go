@@ -0,0 +1,458 @@
+// Package supplierinvoicebus provides business access to supplier invoices
+// and their three-way match: each invoice line is compared with the price
+// its purchase order line was ordered at and the quantity received against
+// it. Matched invoices are approved for payment; mismatched ones wait for the
+// approval workflow to approve or reject them.
+package supplierinvoicebus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus"
+	"github.com/timmaaaz/ichor/business/sdk/delegate"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/outbox"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+	"github.com/timmaaaz/ichor/foundation/otel"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound            = errors.New("supplier invoice not found")
+	ErrUnique              = errors.New("supplier invoice number already exists for the supplier")
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+	ErrInvalidInvoice      = errors.New("invalid supplier invoice")
+	ErrInvoiceClosed       = errors.New("supplier invoice is already approved or rejected")
+	ErrNotMatched          = errors.New("supplier invoice has not been matched")
+	ErrAlreadyApproved     = errors.New("supplier invoice already approved")
+	ErrAlreadyRejected     = errors.New("supplier invoice already rejected")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	NewWithTx(tx sqldb.CommitRollbacker) (Storer, error)
+	Create(ctx context.Context, invoice SupplierInvoice) error
+	Update(ctx context.Context, invoice SupplierInvoice) error
+	UpdateLines(ctx context.Context, lines []Line) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]SupplierInvoice, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, invoiceID uuid.UUID) (SupplierInvoice, error)
+	QueryOrderLines(ctx context.Context, purchaseOrderID uuid.UUID, invoiceID uuid.UUID) ([]OrderLine, error)
+	LockPurchaseOrder(ctx context.Context, purchaseOrderID uuid.UUID) error
+}
+
+// Business manages the set of APIs for supplier invoice access.
+type Business struct {
+	log              *logger.Logger
+	storer           Storer
+	delegate         *delegate.Delegate
+	outbox           *outbox.Writer
+	purchaseOrderBus *purchaseorderbus.Business
+}
+
+// NewBusiness constructs a supplier invoice business API for use. The
+// purchase order bus checks the order an invoice bills.
+func NewBusiness(log *logger.Logger, delegate *delegate.Delegate, storer Storer, purchaseOrderBus *purchaseorderbus.Business) *Business {
+	return &Business{
+		log:              log,
+		delegate:         delegate,
+		storer:           storer,
+		purchaseOrderBus: purchaseOrderBus,
+	}
+}
+
+// WithOutbox returns a copy of the Business wired to the cascade outbox Writer.
+// Inert until the Writer is injected at the F2 cutover (nil Writer -> Emit no-ops).
+func (b *Business) WithOutbox(w *outbox.Writer) *Business {
+	nb := *b
+	nb.outbox = w
+	return &nb
+}
+
+// NewWithTx constructs a new Business value replacing the Storer
+// value with a Storer value that is currently inside a transaction.
+func (b *Business) NewWithTx(tx sqldb.CommitRollbacker) (*Business, error) {
+	storer, err := b.storer.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	purchaseOrderBus, err := b.purchaseOrderBus.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	nb := *b
+	nb.storer = storer
+	nb.purchaseOrderBus = purchaseOrderBus
+	return &nb, nil
+}
+
+// Create enters an open, unmatched invoice. The purchase order must belong to
+// the supplier and every line must bill a different line item of it.
+func (b *Business) Create(ctx context.Context, nsi NewSupplierInvoice, now time.Time) (SupplierInvoice, error) {
+	ctx, span := otel.AddSpan(ctx, "business.supplierinvoicebus.create")
+	defer span.End()
+
+	if err := validateNew(nsi); err != nil {
+		return SupplierInvoice{}, fmt.Errorf("create: %w", err)
+	}
+
+	po, err := b.purchaseOrderBus.QueryByID(ctx, nsi.PurchaseOrderID)
+	if err != nil {
+		if errors.Is(err, purchaseorderbus.ErrNotFound) {
+			return SupplierInvoice{}, fmt.Errorf("create: %w: purchase order not found", ErrInvalidInvoice)
+		}
+		return SupplierInvoice{}, fmt.Errorf("create: purchase order: %w", err)
+	}
+	if po.SupplierID != nsi.SupplierID {
+		return SupplierInvoice{}, fmt.Errorf("create: %w: purchase order belongs to another supplier", ErrInvalidInvoice)
+	}
+
+	orderLines, err := b.storer.QueryOrderLines(ctx, nsi.PurchaseOrderID, uuid.Nil)
+	if err != nil {
+		return SupplierInvoice{}, fmt.Errorf("create: order lines: %w", err)
+	}
+
+	onOrder := make(map[uuid.UUID]bool, len(orderLines))
+	for _, ol := range orderLines {
+		onOrder[ol.ID] = true
+	}
+
+	tolerance := DefaultTolerance
+	if nsi.Tolerance != nil {
+		tolerance = *nsi.Tolerance
+	}
+
+	invoice := SupplierInvoice{
+		ID:              uuid.New(),
+		InvoiceNumber:   nsi.InvoiceNumber,
+		SupplierID:      nsi.SupplierID,
+		PurchaseOrderID: nsi.PurchaseOrderID,
+		InvoiceDate:     nsi.InvoiceDate,
+		DueDate:         nsi.DueDate,
+		TaxAmount:       nsi.TaxAmount,
+		FreightAmount:   nsi.FreightAmount,
+		Status:          StatusOpen,
+		MatchStatus:     MatchUnmatched,
+		Tolerance:       tolerance,
+		Notes:           nsi.Notes,
+		CreatedBy:       nsi.CreatedBy,
+		UpdatedBy:       nsi.CreatedBy,
+		CreatedDate:     now,
+		UpdatedDate:     now,
+	}
+
+	billed := make(map[uuid.UUID]bool, len(nsi.Lines))
+	for _, nl := range nsi.Lines {
+		if !onOrder[nl.PurchaseOrderLineItemID] {
+			return SupplierInvoice{}, fmt.Errorf("create: %w: line item %s is not on the purchase order", ErrInvalidInvoice, nl.PurchaseOrderLineItemID)
+		}
+		if billed[nl.PurchaseOrderLineItemID] {
+			return SupplierInvoice{}, fmt.Errorf("create: %w: line item %s is billed twice", ErrInvalidInvoice, nl.PurchaseOrderLineItemID)
+		}
+		billed[nl.PurchaseOrderLineItemID] = true
+
+		invoice.Lines = append(invoice.Lines, Line{
+			ID:                      uuid.New(),
+			InvoiceID:               invoice.ID,
+			PurchaseOrderLineItemID: nl.PurchaseOrderLineItemID,
+			Description:             nl.Description,
+			Quantity:                nl.Quantity,
+			UnitPrice:               nl.UnitPrice,
+			LineTotal:               lineTotal(nl.Quantity, nl.UnitPrice),
+			MatchStatus:             MatchUnmatched,
+			CreatedDate:             now,
+			UpdatedDate:             now,
+		})
+	}
+	invoice.total()
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (SupplierInvoice, error) {
+			if err := b.storer.Create(ctx, invoice); err != nil {
+				return SupplierInvoice{}, fmt.Errorf("create: %w", err)
+			}
+
+			evtData := ActionCreatedData(invoice)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return SupplierInvoice{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionCreatedData(invoice)); err != nil {
+				b.log.Error(ctx, "supplierinvoicebus: delegate call failed", "action", ActionCreated, "err", err)
+			}
+
+			return invoice, nil
+		})
+}
+
+// Update modifies the header of an unresolved invoice and puts it back to
+// open and unmatched, so it must be matched again. Returns ErrInvoiceClosed
+// when the invoice is approved or rejected.
+func (b *Business) Update(ctx context.Context, invoice SupplierInvoice, usi UpdateSupplierInvoice, now time.Time) (SupplierInvoice, error) {
+	ctx, span := otel.AddSpan(ctx, "business.supplierinvoicebus.update")
+	defer span.End()
+
+	if resolved(invoice) {
+		return SupplierInvoice{}, fmt.Errorf("update: %w", ErrInvoiceClosed)
+	}
+
+	if err := validateUpdate(usi); err != nil {
+		return SupplierInvoice{}, fmt.Errorf("update: %w", err)
+	}
+
+	return b.update(ctx, invoice, func(inv *SupplierInvoice) {
+		if usi.InvoiceNumber != nil {
+			inv.InvoiceNumber = *usi.InvoiceNumber
+		}
+		if usi.InvoiceDate != nil {
+			inv.InvoiceDate = *usi.InvoiceDate
+		}
+		if usi.DueDate != nil {
+			inv.DueDate = *usi.DueDate
+		}
+		if usi.TaxAmount != nil {
+			inv.TaxAmount = *usi.TaxAmount
+		}
+		if usi.FreightAmount != nil {
+			inv.FreightAmount = *usi.FreightAmount
+		}
+		if usi.Tolerance != nil {
+			inv.Tolerance = *usi.Tolerance
+		}
+		if usi.Notes != nil {
+			inv.Notes = *usi.Notes
+		}
+		inv.total()
+		inv.Status = StatusOpen
+		inv.MatchStatus = MatchUnmatched
+		inv.MatchedDate = nil
+		inv.UpdatedBy = usi.UpdatedBy
+		inv.UpdatedDate = now
+	})
+}
+
+// Match runs the three-way match of an unresolved invoice against its
+// purchase order's prices and received quantities. A matched invoice is
+// approved for payment on the spot; a mismatched one becomes pending approval
+// and its updated event routes it to the approval workflow. An invoice
+// pending approval can be matched again once more goods are received.
+// Matches against one purchase order are serialized so two invoices cannot
+// both bill the same received units. Returns ErrInvoiceClosed when the
+// invoice is approved or rejected.
+func (b *Business) Match(ctx context.Context, invoice SupplierInvoice, userID uuid.UUID, now time.Time) (SupplierInvoice, error) {
+	ctx, span := otel.AddSpan(ctx, "business.supplierinvoicebus.match")
+	defer span.End()
+
+	if resolved(invoice) {
+		return SupplierInvoice{}, fmt.Errorf("match: %w", ErrInvoiceClosed)
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (SupplierInvoice, error) {
+			if err := b.storer.LockPurchaseOrder(ctx, invoice.PurchaseOrderID); err != nil {
+				return SupplierInvoice{}, fmt.Errorf("match: lock: %w", err)
+			}
+
+			orderLines, err := b.storer.QueryOrderLines(ctx, invoice.PurchaseOrderID, invoice.ID)
+			if err != nil {
+				return SupplierInvoice{}, fmt.Errorf("match: order lines: %w", err)
+			}
+
+			matched := Match(invoice, orderLines, now)
+			if err := b.storer.UpdateLines(ctx, matched.Lines); err != nil {
+				return SupplierInvoice{}, fmt.Errorf("match: lines: %w", err)
+			}
+
+			return b.update(ctx, invoice, func(inv *SupplierInvoice) {
+				*inv = matched
+				if inv.MatchStatus == MatchMatched {
+					inv.Status = StatusApproved
+					inv.ApprovedBy = &userID
+					inv.ApprovedDate = &now
+					inv.ApprovalReason = "matched within tolerance"
+				} else {
+					inv.Status = StatusPendingApproval
+				}
+				inv.UpdatedBy = userID
+				inv.UpdatedDate = now
+			})
+		})
+}
+
+// Approve accepts a mismatched invoice for payment. Returns ErrNotMatched
+// when the invoice has not been matched, ErrAlreadyApproved or
+// ErrAlreadyRejected when it is resolved.
+func (b *Business) Approve(ctx context.Context, invoice SupplierInvoice, approvedBy uuid.UUID, reason string, now time.Time) (SupplierInvoice, error) {
+	ctx, span := otel.AddSpan(ctx, "business.supplierinvoicebus.approve")
+	defer span.End()
+
+	switch invoice.Status {
+	case StatusApproved:
+		return SupplierInvoice{}, fmt.Errorf("approve: %w", ErrAlreadyApproved)
+	case StatusRejected:
+		return SupplierInvoice{}, fmt.Errorf("approve: %w", ErrAlreadyRejected)
+	case StatusOpen:
+		return SupplierInvoice{}, fmt.Errorf("approve: %w", ErrNotMatched)
+	}
+
+	return b.update(ctx, invoice, func(inv *SupplierInvoice) {
+		inv.Status = StatusApproved
+		inv.ApprovedBy = &approvedBy
+		inv.ApprovedDate = &now
+		inv.ApprovalReason = reason
+		inv.UpdatedBy = approvedBy
+		inv.UpdatedDate = now
+	})
+}
+
+// Reject refuses an unresolved invoice; it will not be paid and its lines no
+// longer count as billed. Returns ErrAlreadyApproved or ErrAlreadyRejected
+// when the invoice is resolved.
+func (b *Business) Reject(ctx context.Context, invoice SupplierInvoice, rejectedBy uuid.UUID, reason string, now time.Time) (SupplierInvoice, error) {
+	ctx, span := otel.AddSpan(ctx, "business.supplierinvoicebus.reject")
+	defer span.End()
+
+	switch invoice.Status {
+	case StatusApproved:
+		return SupplierInvoice{}, fmt.Errorf("reject: %w", ErrAlreadyApproved)
+	case StatusRejected:
+		return SupplierInvoice{}, fmt.Errorf("reject: %w", ErrAlreadyRejected)
+	}
+
+	return b.update(ctx, invoice, func(inv *SupplierInvoice) {
+		inv.Status = StatusRejected
+		inv.RejectedBy = &rejectedBy
+		inv.RejectedDate = &now
+		inv.RejectionReason = reason
+		inv.UpdatedBy = rejectedBy
+		inv.UpdatedDate = now
+	})
+}
+
+// Query retrieves a list of invoices, with their lines, from the system.
+func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]SupplierInvoice, error) {
+	ctx, span := otel.AddSpan(ctx, "business.supplierinvoicebus.query")
+	defer span.End()
+
+	invoices, err := b.storer.Query(ctx, filter, orderBy, page)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return invoices, nil
+}
+
+// Count returns the total number of invoices matching the filter.
+func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.supplierinvoicebus.count")
+	defer span.End()
+
+	return b.storer.Count(ctx, filter)
+}
+
+// QueryByID retrieves a single invoice, with its lines, by its ID.
+func (b *Business) QueryByID(ctx context.Context, invoiceID uuid.UUID) (SupplierInvoice, error) {
+	ctx, span := otel.AddSpan(ctx, "business.supplierinvoicebus.querybyid")
+	defer span.End()
+
+	invoice, err := b.storer.QueryByID(ctx, invoiceID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return SupplierInvoice{}, err
+		}
+		return SupplierInvoice{}, fmt.Errorf("queryByID: invoiceID[%s]: %w", invoiceID, err)
+	}
+
+	return invoice, nil
+}
+
+// =============================================================================
+
+// update applies fn to the invoice, stores its header and emits the updated
+// event.
+func (b *Business) update(ctx context.Context, invoice SupplierInvoice, fn func(*SupplierInvoice)) (SupplierInvoice, error) {
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (SupplierInvoice, error) {
+			before := invoice
+			fn(&invoice)
+
+			if err := b.storer.Update(ctx, invoice); err != nil {
+				return SupplierInvoice{}, fmt.Errorf("update: %w", err)
+			}
+
+			evtData := ActionUpdatedData(before, invoice)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return SupplierInvoice{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionUpdatedData(before, invoice)); err != nil {
+				b.log.Error(ctx, "supplierinvoicebus: delegate call failed", "action", ActionUpdated, "err", err)
+			}
+
+			return invoice, nil
+		})
+}
+
+func resolved(invoice SupplierInvoice) bool {
+	return invoice.Status == StatusApproved || invoice.Status == StatusRejected
+}
+
+func validateNew(nsi NewSupplierInvoice) error {
+	switch {
+	case nsi.InvoiceNumber == "":
+		return fmt.Errorf("%w: invoice_number is required", ErrInvalidInvoice)
+	case nsi.DueDate.Before(nsi.InvoiceDate):
+		return fmt.Errorf("%w: due_date must not be before invoice_date", ErrInvalidInvoice)
+	case nsi.TaxAmount < 0 || nsi.FreightAmount < 0:
+		return fmt.Errorf("%w: tax_amount and freight_amount must not be negative", ErrInvalidInvoice)
+	case len(nsi.Lines) == 0:
+		return fmt.Errorf("%w: an invoice needs at least one line", ErrInvalidInvoice)
+	}
+
+	if nsi.Tolerance != nil {
+		if err := validateTolerance(*nsi.Tolerance); err != nil {
+			return err
+		}
+	}
+
+	for _, nl := range nsi.Lines {
+		if nl.Quantity <= 0 {
+			return fmt.Errorf("%w: line quantity must be positive", ErrInvalidInvoice)
+		}
+		if nl.UnitPrice < 0 {
+			return fmt.Errorf("%w: line unit_price must not be negative", ErrInvalidInvoice)
+		}
+	}
+
+	return nil
+}
+
+func validateUpdate(usi UpdateSupplierInvoice) error {
+	switch {
+	case usi.InvoiceNumber != nil && *usi.InvoiceNumber == "":
+		return fmt.Errorf("%w: invoice_number is required", ErrInvalidInvoice)
+	case usi.TaxAmount != nil && *usi.TaxAmount < 0,
+		usi.FreightAmount != nil && *usi.FreightAmount < 0:
+		return fmt.Errorf("%w: tax_amount and freight_amount must not be negative", ErrInvalidInvoice)
+	}
+
+	if usi.Tolerance != nil {
+		return validateTolerance(*usi.Tolerance)
+	}
+
+	return nil
+}
+
+func validateTolerance(t Tolerance) error {
+	if t.PricePercent < 0 || t.PriceAmount < 0 || t.QuantityPercent < 0 || t.QuantityUnits < 0 {
+		return fmt.Errorf("%w: tolerances must not be negative", ErrInvalidInvoice)
+	}
+	return nil
+}
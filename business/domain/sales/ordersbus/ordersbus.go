@@ -402,3 +402,16 @@ func (b *Business) QueryActiveBindingsByOrder(ctx context.Context, orderID uuid.
 
 	return bindings, nil
 }
+
+// QueryBindingByID returns a single container binding, active or released.
+func (b *Business) QueryBindingByID(ctx context.Context, bindingID uuid.UUID) (OrderContainerBinding, error) {
+	ctx, span := otel.AddSpan(ctx, "business.ordersbus.querybindingbyid")
+	defer span.End()
+
+	binding, err := b.storer.QueryBindingByID(ctx, bindingID)
+	if err != nil {
+		return OrderContainerBinding{}, fmt.Errorf("querybindingbyid: %w", err)
+	}
+
+	return binding, nil
+}
@@ -0,0 +1,102 @@
+package shipmentbus
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/timmaaaz/ichor/business/domain/labels/labelbus/zpl"
+)
+
+// Set of carrier error variables.
+var (
+	ErrNoCarrier           = errors.New("no carrier configured")
+	ErrUnknownServiceLevel = errors.New("carrier does not offer the requested service level")
+	ErrLabelExists         = errors.New("shipment already has a label")
+	ErrNoLabel             = errors.New("shipment has no label")
+)
+
+// Carrier declares the behavior for quoting and buying shipping labels. The
+// flatrate subpackage is an offline implementation; httpcarrier forwards to a
+// rating/label service over HTTP.
+type Carrier interface {
+	Name() string
+	Rates(ctx context.Context, req RateRequest) ([]Rate, error)
+	CreateLabel(ctx context.Context, req LabelRequest) (Label, error)
+	VoidLabel(ctx context.Context, trackingNumber string) error
+}
+
+// Address is the ship-to destination handed to a carrier.
+type Address struct {
+	Name       string `json:"name"`
+	Line1      string `json:"line_1"`
+	Line2      string `json:"line_2"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postal_code"`
+}
+
+// Parcel is one physical package (one carton) being rated or labelled.
+type Parcel struct {
+	Weight     float64 `json:"weight"`
+	WeightUnit string  `json:"weight_unit"`
+}
+
+// RateRequest asks a carrier to price a shipment.
+type RateRequest struct {
+	ShipmentNumber string   `json:"shipment_number"`
+	ShipTo         Address  `json:"ship_to"`
+	Parcels        []Parcel `json:"parcels"`
+}
+
+// Rate is one priced service level offered by a carrier.
+type Rate struct {
+	Carrier       string  `json:"carrier"`
+	ServiceLevel  string  `json:"service_level"`
+	Cost          float64 `json:"cost"`
+	Currency      string  `json:"currency"`
+	EstimatedDays int     `json:"estimated_days"`
+}
+
+// LabelRequest asks a carrier to buy a label for a chosen service level.
+type LabelRequest struct {
+	RateRequest
+	ServiceLevel string `json:"service_level"`
+}
+
+// Label is a purchased shipping label. ZPL holds one 4x6 label per parcel,
+// ready for a labelbus.Printer.
+type Label struct {
+	Carrier        string  `json:"carrier"`
+	ServiceLevel   string  `json:"service_level"`
+	TrackingNumber string  `json:"tracking_number"`
+	Cost           float64 `json:"cost"`
+	Currency       string  `json:"currency"`
+	ZPL            []byte  `json:"-"`
+}
+
+// RenderLabelZPL renders one 4x6 shipping label per parcel for carriers that
+// do not return printable labels themselves. All address fields are
+// sanitized before they reach the template.
+func RenderLabelZPL(req LabelRequest, carrier string, trackingNumber string) []byte {
+	var buf bytes.Buffer
+	for i, p := range req.Parcels {
+		buf.WriteString(zpl.Shipping(zpl.ShippingData{
+			Carrier:        zpl.Sanitize(carrier),
+			ServiceLevel:   zpl.Sanitize(req.ServiceLevel),
+			ShipToName:     zpl.Sanitize(req.ShipTo.Name),
+			ShipToLine1:    zpl.Sanitize(req.ShipTo.Line1),
+			ShipToLine2:    zpl.Sanitize(req.ShipTo.Line2),
+			ShipToCity:     zpl.Sanitize(req.ShipTo.City),
+			ShipToRegion:   zpl.Sanitize(req.ShipTo.Region),
+			ShipToPostal:   zpl.Sanitize(req.ShipTo.PostalCode),
+			ShipmentNumber: zpl.Sanitize(req.ShipmentNumber),
+			TrackingNumber: zpl.Sanitize(trackingNumber),
+			Weight:         p.Weight,
+			WeightUnit:     zpl.Sanitize(p.WeightUnit),
+			Package:        i + 1,
+			PackageCount:   len(req.Parcels),
+		}))
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,135 @@
+package shipmentbus
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/delegate"
+)
+
+// DomainName represents the name of this domain for delegate events.
+const DomainName = "shipment"
+
+// EntityName is the workflow entity name used for event matching.
+// This should match the entity name in workflow.entities table.
+// The entity is stored as just the table name (not schema-qualified).
+const EntityName = "shipments"
+
+// Delegate action constants.
+const (
+	ActionCreated = "created"
+	ActionUpdated = "updated"
+	ActionDeleted = "deleted"
+)
+
+// =============================================================================
+// Created Event
+// =============================================================================
+
+// ActionCreatedParms represents the parameters for the created action.
+type ActionCreatedParms struct {
+	EntityID uuid.UUID `json:"entityID"`
+	UserID   uuid.UUID `json:"userID"`
+	Entity   Shipment  `json:"entity"`
+}
+
+// Marshal returns the event parameters encoded as JSON.
+func (p *ActionCreatedParms) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// ActionCreatedData constructs delegate data for shipment creation events.
+func ActionCreatedData(shipment Shipment) delegate.Data {
+	params := ActionCreatedParms{
+		EntityID: shipment.ID,
+		UserID:   shipment.CreatedBy,
+		Entity:   shipment,
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    DomainName,
+		Action:    ActionCreated,
+		RawParams: rawParams,
+	}
+}
+
+// =============================================================================
+// Updated Event
+// =============================================================================
+
+// ActionUpdatedParms represents the parameters for the updated action.
+type ActionUpdatedParms struct {
+	EntityID     uuid.UUID `json:"entityID"`
+	UserID       uuid.UUID `json:"userID"`
+	Entity       Shipment  `json:"entity"`
+	BeforeEntity Shipment  `json:"beforeEntity,omitempty"`
+}
+
+// Marshal returns the event parameters encoded as JSON.
+func (p *ActionUpdatedParms) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// ActionUpdatedData constructs delegate data for shipment update events.
+// before is the entity state before the update (used for FieldChanges diff).
+func ActionUpdatedData(before, after Shipment) delegate.Data {
+	params := ActionUpdatedParms{
+		EntityID:     after.ID,
+		UserID:       after.UpdatedBy,
+		Entity:       after,
+		BeforeEntity: before,
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    DomainName,
+		Action:    ActionUpdated,
+		RawParams: rawParams,
+	}
+}
+
+// =============================================================================
+// Deleted Event
+// =============================================================================
+
+// ActionDeletedParms represents the parameters for the deleted action.
+type ActionDeletedParms struct {
+	EntityID uuid.UUID `json:"entityID"`
+	UserID   uuid.UUID `json:"userID"`
+	Entity   Shipment  `json:"entity"`
+}
+
+// Marshal returns the event parameters encoded as JSON.
+func (p *ActionDeletedParms) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// ActionDeletedData constructs delegate data for shipment deletion events.
+// Note: For delete, we use UpdatedBy as the user who performed the delete.
+func ActionDeletedData(shipment Shipment) delegate.Data {
+	params := ActionDeletedParms{
+		EntityID: shipment.ID,
+		UserID:   shipment.UpdatedBy, // UpdatedBy tracks who performed the delete
+		Entity:   shipment,
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    DomainName,
+		Action:    ActionDeleted,
+		RawParams: rawParams,
+	}
+}
@@ -0,0 +1,21 @@
+package shipmentbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QueryFilter holds the available fields a query can be filtered on.
+type QueryFilter struct {
+	ID               *uuid.UUID
+	ShipmentNumber   *string
+	OrderID          *uuid.UUID
+	Status           *string
+	Carrier          *string
+	TrackingNumber   *string
+	StartShipDate    *time.Time
+	EndShipDate      *time.Time
+	StartCreatedDate *time.Time
+	EndCreatedDate   *time.Time
+}
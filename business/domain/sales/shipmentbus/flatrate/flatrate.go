@@ -0,0 +1,125 @@
+// Package flatrate is an offline shipmentbus.Carrier for manual shipping:
+// rates come from a fixed service table, tracking numbers are generated
+// locally, and labels are rendered as ZPL without calling out to anyone.
+package flatrate
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+)
+
+// Name is the carrier name recorded on shipments labelled by this carrier.
+const Name = "flatrate"
+
+// Service is one priced service level. A parcel costs BaseCost plus
+// PerWeight for each unit of its weight.
+type Service struct {
+	Level         string
+	BaseCost      float64
+	PerWeight     float64
+	EstimatedDays int
+}
+
+// DefaultServices is the service table used when none is configured.
+var DefaultServices = []Service{
+	{Level: "ground", BaseCost: 8.50, PerWeight: 0.75, EstimatedDays: 5},
+	{Level: "express", BaseCost: 18.00, PerWeight: 1.25, EstimatedDays: 2},
+	{Level: "overnight", BaseCost: 35.00, PerWeight: 2.00, EstimatedDays: 1},
+}
+
+// Carrier rates and labels shipments from a fixed service table.
+type Carrier struct {
+	services []Service
+	currency string
+}
+
+// New constructs a flat-rate carrier. An empty services slice uses
+// DefaultServices.
+func New(services []Service) *Carrier {
+	if len(services) == 0 {
+		services = DefaultServices
+	}
+	return &Carrier{services: services, currency: "USD"}
+}
+
+// Name implements shipmentbus.Carrier.
+func (c *Carrier) Name() string {
+	return Name
+}
+
+// Rates prices the request at every configured service level.
+func (c *Carrier) Rates(ctx context.Context, req shipmentbus.RateRequest) ([]shipmentbus.Rate, error) {
+	rates := make([]shipmentbus.Rate, len(c.services))
+	for i, svc := range c.services {
+		rates[i] = c.rate(svc, req.Parcels)
+	}
+	return rates, nil
+}
+
+// CreateLabel assigns a locally generated tracking number and renders one
+// label per parcel.
+func (c *Carrier) CreateLabel(ctx context.Context, req shipmentbus.LabelRequest) (shipmentbus.Label, error) {
+	svc, ok := c.service(req.ServiceLevel)
+	if !ok {
+		return shipmentbus.Label{}, fmt.Errorf("service level %q: %w", req.ServiceLevel, shipmentbus.ErrUnknownServiceLevel)
+	}
+
+	tracking, err := trackingNumber()
+	if err != nil {
+		return shipmentbus.Label{}, fmt.Errorf("tracking number: %w", err)
+	}
+
+	rate := c.rate(svc, req.Parcels)
+
+	return shipmentbus.Label{
+		Carrier:        Name,
+		ServiceLevel:   svc.Level,
+		TrackingNumber: tracking,
+		Cost:           rate.Cost,
+		Currency:       rate.Currency,
+		ZPL:            shipmentbus.RenderLabelZPL(req, Name, tracking),
+	}, nil
+}
+
+// VoidLabel is a no-op: nothing was bought, so there is nothing to refund.
+func (c *Carrier) VoidLabel(ctx context.Context, trackingNumber string) error {
+	return nil
+}
+
+func (c *Carrier) service(level string) (Service, bool) {
+	for _, svc := range c.services {
+		if svc.Level == level {
+			return svc, true
+		}
+	}
+	return Service{}, false
+}
+
+func (c *Carrier) rate(svc Service, parcels []shipmentbus.Parcel) shipmentbus.Rate {
+	var cost float64
+	for _, p := range parcels {
+		cost += svc.BaseCost + svc.PerWeight*p.Weight
+	}
+
+	return shipmentbus.Rate{
+		Carrier:       Name,
+		ServiceLevel:  svc.Level,
+		Cost:          math.Round(cost*100) / 100,
+		Currency:      c.currency,
+		EstimatedDays: svc.EstimatedDays,
+	}
+}
+
+// trackingNumber returns "FR" followed by 12 random digits.
+func trackingNumber() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000_000_000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("FR%012d", n.Int64()), nil
+}
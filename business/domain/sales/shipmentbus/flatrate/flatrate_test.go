@@ -0,0 +1,86 @@
+package flatrate_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus/flatrate"
+)
+
+func request() shipmentbus.RateRequest {
+	return shipmentbus.RateRequest{
+		ShipmentNumber: "SHP-1001",
+		ShipTo: shipmentbus.Address{
+			Name:       "Acme, Inc.",
+			Line1:      "123 Main St",
+			City:       "Springfield",
+			Region:     "IL",
+			PostalCode: "62701",
+		},
+		Parcels: []shipmentbus.Parcel{
+			{Weight: 2, WeightUnit: "lb"},
+			{Weight: 4, WeightUnit: "lb"},
+		},
+	}
+}
+
+func Test_FlatRate_Rates(t *testing.T) {
+	c := flatrate.New([]flatrate.Service{
+		{Level: "ground", BaseCost: 5, PerWeight: 1, EstimatedDays: 4},
+	})
+
+	rates, err := c.Rates(context.Background(), request())
+	if err != nil {
+		t.Fatalf("rates: %v", err)
+	}
+	if len(rates) != 1 {
+		t.Fatalf("expected 1 rate, got %d", len(rates))
+	}
+
+	// (5 + 1*2) + (5 + 1*4)
+	if rates[0].Cost != 16 {
+		t.Errorf("expected cost 16, got %v", rates[0].Cost)
+	}
+	if rates[0].Carrier != flatrate.Name || rates[0].ServiceLevel != "ground" || rates[0].EstimatedDays != 4 {
+		t.Errorf("unexpected rate: %+v", rates[0])
+	}
+}
+
+func Test_FlatRate_CreateLabel(t *testing.T) {
+	c := flatrate.New(nil)
+
+	label, err := c.CreateLabel(context.Background(), shipmentbus.LabelRequest{RateRequest: request(), ServiceLevel: "express"})
+	if err != nil {
+		t.Fatalf("createlabel: %v", err)
+	}
+
+	if !strings.HasPrefix(label.TrackingNumber, "FR") || len(label.TrackingNumber) != 14 {
+		t.Errorf("unexpected tracking number %q", label.TrackingNumber)
+	}
+	if label.ServiceLevel != "express" || label.Carrier != flatrate.Name {
+		t.Errorf("unexpected label: %+v", label)
+	}
+
+	zpl := string(label.ZPL)
+	if n := strings.Count(zpl, "^XA"); n != 2 {
+		t.Errorf("expected one label per parcel (2), got %d", n)
+	}
+	if !strings.Contains(zpl, "PKG 2 OF 2") {
+		t.Errorf("expected package count on label, got:\n%s", zpl)
+	}
+	if !strings.Contains(zpl, "^FD"+label.TrackingNumber+"^FS") {
+		t.Errorf("expected tracking barcode on label, got:\n%s", zpl)
+	}
+}
+
+func Test_FlatRate_UnknownServiceLevel(t *testing.T) {
+	c := flatrate.New(nil)
+
+	_, err := c.CreateLabel(context.Background(), shipmentbus.LabelRequest{RateRequest: request(), ServiceLevel: "teleport"})
+	if !errors.Is(err, shipmentbus.ErrUnknownServiceLevel) {
+		t.Fatalf("expected ErrUnknownServiceLevel, got %v", err)
+	}
+}
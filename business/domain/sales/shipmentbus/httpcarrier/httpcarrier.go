@@ -0,0 +1,172 @@
+// Package httpcarrier is a shipmentbus.Carrier that forwards rating and label
+// requests to a carrier service over JSON/HTTP. It can be pointed at a
+// carrier aggregator or at a local stub for development.
+//
+// The service is expected to expose:
+//
+//	POST {base}/rates                    RateRequest  -> {"rates": [Rate]}
+//	POST {base}/labels                   LabelRequest -> Label (+ optional "zpl")
+//	POST {base}/labels/{tracking}/void   -> 2xx
+//
+// When a label response carries no ZPL, the label is rendered locally.
+package httpcarrier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+)
+
+// Config holds the carrier service configuration.
+type Config struct {
+	Name    string
+	BaseURL string
+	APIKey  string
+	Timeout time.Duration
+}
+
+// Carrier calls a carrier service over HTTP.
+type Carrier struct {
+	name    string
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+// New constructs an HTTP carrier. Returns nil if BaseURL is empty so callers
+// can fall back to another carrier.
+func New(cfg Config) *Carrier {
+	if cfg.BaseURL == "" {
+		return nil
+	}
+	if cfg.Name == "" {
+		cfg.Name = "http"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	return &Carrier{
+		name:    cfg.Name,
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		apiKey:  cfg.APIKey,
+		client:  &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// Name implements shipmentbus.Carrier.
+func (c *Carrier) Name() string {
+	return c.name
+}
+
+// Rates asks the service to price the request.
+func (c *Carrier) Rates(ctx context.Context, req shipmentbus.RateRequest) ([]shipmentbus.Rate, error) {
+	var resp struct {
+		Rates []shipmentbus.Rate `json:"rates"`
+	}
+	if err := c.post(ctx, "/rates", req, &resp); err != nil {
+		return nil, fmt.Errorf("rates: %w", err)
+	}
+
+	for i := range resp.Rates {
+		if resp.Rates[i].Carrier == "" {
+			resp.Rates[i].Carrier = c.name
+		}
+	}
+
+	return resp.Rates, nil
+}
+
+// CreateLabel buys a label from the service.
+func (c *Carrier) CreateLabel(ctx context.Context, req shipmentbus.LabelRequest) (shipmentbus.Label, error) {
+	var resp struct {
+		shipmentbus.Label
+		ZPL string `json:"zpl"`
+	}
+	if err := c.post(ctx, "/labels", req, &resp); err != nil {
+		return shipmentbus.Label{}, fmt.Errorf("createlabel: %w", err)
+	}
+
+	label := resp.Label
+	if label.TrackingNumber == "" {
+		return shipmentbus.Label{}, errors.New("createlabel: response has no tracking number")
+	}
+	if label.Carrier == "" {
+		label.Carrier = c.name
+	}
+	if label.ServiceLevel == "" {
+		label.ServiceLevel = req.ServiceLevel
+	}
+
+	switch resp.ZPL {
+	case "":
+		label.ZPL = shipmentbus.RenderLabelZPL(req, label.Carrier, label.TrackingNumber)
+	default:
+		label.ZPL = []byte(resp.ZPL)
+	}
+
+	return label, nil
+}
+
+// VoidLabel asks the service to cancel a label.
+func (c *Carrier) VoidLabel(ctx context.Context, trackingNumber string) error {
+	if err := c.post(ctx, "/labels/"+url.PathEscape(trackingNumber)+"/void", nil, nil); err != nil {
+		return fmt.Errorf("voidlabel: %w", err)
+	}
+	return nil
+}
+
+// post sends body as JSON and decodes a 2xx response into out. A 422 from
+// the service is reported as shipmentbus.ErrUnknownServiceLevel.
+func (c *Carrier) post(ctx context.Context, path string, body any, out any) error {
+	var reqBody io.Reader = http.NoBody
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		if resp.StatusCode == http.StatusUnprocessableEntity {
+			return fmt.Errorf("%s: %w", strings.TrimSpace(string(msg)), shipmentbus.ErrUnknownServiceLevel)
+		}
+		return fmt.Errorf("%s %s: status %d: %s", http.MethodPost, path, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+
+	return nil
+}
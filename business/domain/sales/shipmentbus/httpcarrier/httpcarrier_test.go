@@ -0,0 +1,155 @@
+package httpcarrier_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus/httpcarrier"
+)
+
+func stub(t *testing.T, zpl string) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var voided []string
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /rates", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var req shipmentbus.RateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"rates": []shipmentbus.Rate{
+				{ServiceLevel: "ground", Cost: 4.25 * float64(len(req.Parcels)), Currency: "USD", EstimatedDays: 3},
+			},
+		})
+	})
+
+	mux.HandleFunc("POST /labels", func(w http.ResponseWriter, r *http.Request) {
+		var req shipmentbus.LabelRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.ServiceLevel != "ground" {
+			http.Error(w, "unknown service level", http.StatusUnprocessableEntity)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"service_level":   req.ServiceLevel,
+			"tracking_number": "1Z999",
+			"cost":            4.25,
+			"currency":        "USD",
+			"zpl":             zpl,
+		})
+	})
+
+	mux.HandleFunc("POST /labels/{tracking}/void", func(w http.ResponseWriter, r *http.Request) {
+		voided = append(voided, r.PathValue("tracking"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv, &voided
+}
+
+func labelRequest(level string) shipmentbus.LabelRequest {
+	return shipmentbus.LabelRequest{
+		RateRequest: shipmentbus.RateRequest{
+			ShipmentNumber: "SHP-1001",
+			ShipTo:         shipmentbus.Address{Name: "Acme", Line1: "1 Main St", City: "Springfield", Region: "IL", PostalCode: "62701"},
+			Parcels:        []shipmentbus.Parcel{{Weight: 1, WeightUnit: "lb"}},
+		},
+		ServiceLevel: level,
+	}
+}
+
+func Test_HTTPCarrier_NewUnconfigured(t *testing.T) {
+	if c := httpcarrier.New(httpcarrier.Config{}); c != nil {
+		t.Fatal("expected nil carrier without a base URL")
+	}
+}
+
+func Test_HTTPCarrier_Rates(t *testing.T) {
+	srv, _ := stub(t, "")
+	c := httpcarrier.New(httpcarrier.Config{Name: "stub", BaseURL: srv.URL, APIKey: "secret"})
+
+	rates, err := c.Rates(context.Background(), labelRequest("ground").RateRequest)
+	if err != nil {
+		t.Fatalf("rates: %v", err)
+	}
+	if len(rates) != 1 || rates[0].Carrier != "stub" || rates[0].Cost != 4.25 {
+		t.Fatalf("unexpected rates: %+v", rates)
+	}
+
+	bad := httpcarrier.New(httpcarrier.Config{BaseURL: srv.URL, APIKey: "wrong"})
+	if _, err := bad.Rates(context.Background(), labelRequest("ground").RateRequest); err == nil {
+		t.Fatal("expected error on unauthorized response")
+	}
+}
+
+func Test_HTTPCarrier_CreateLabel(t *testing.T) {
+	t.Run("rendered-locally", func(t *testing.T) {
+		srv, _ := stub(t, "")
+		c := httpcarrier.New(httpcarrier.Config{Name: "stub", BaseURL: srv.URL, APIKey: "secret"})
+
+		label, err := c.CreateLabel(context.Background(), labelRequest("ground"))
+		if err != nil {
+			t.Fatalf("createlabel: %v", err)
+		}
+		if label.TrackingNumber != "1Z999" || label.Carrier != "stub" {
+			t.Fatalf("unexpected label: %+v", label)
+		}
+		if !strings.Contains(string(label.ZPL), "^FD1Z999^FS") {
+			t.Fatalf("expected locally rendered label, got:\n%s", label.ZPL)
+		}
+	})
+
+	t.Run("carrier-zpl", func(t *testing.T) {
+		srv, _ := stub(t, "^XA^FDCARRIER^FS^XZ")
+		c := httpcarrier.New(httpcarrier.Config{BaseURL: srv.URL, APIKey: "secret"})
+
+		label, err := c.CreateLabel(context.Background(), labelRequest("ground"))
+		if err != nil {
+			t.Fatalf("createlabel: %v", err)
+		}
+		if string(label.ZPL) != "^XA^FDCARRIER^FS^XZ" {
+			t.Fatalf("expected carrier ZPL, got %q", label.ZPL)
+		}
+	})
+
+	t.Run("unknown-service-level", func(t *testing.T) {
+		srv, _ := stub(t, "")
+		c := httpcarrier.New(httpcarrier.Config{BaseURL: srv.URL})
+
+		_, err := c.CreateLabel(context.Background(), labelRequest("teleport"))
+		if !errors.Is(err, shipmentbus.ErrUnknownServiceLevel) {
+			t.Fatalf("expected ErrUnknownServiceLevel, got %v", err)
+		}
+	})
+}
+
+func Test_HTTPCarrier_VoidLabel(t *testing.T) {
+	srv, voided := stub(t, "")
+	c := httpcarrier.New(httpcarrier.Config{BaseURL: srv.URL})
+
+	if err := c.VoidLabel(context.Background(), "1Z999"); err != nil {
+		t.Fatalf("voidlabel: %v", err)
+	}
+	if len(*voided) != 1 || (*voided)[0] != "1Z999" {
+		t.Fatalf("expected void of 1Z999, got %v", *voided)
+	}
+}
@@ -0,0 +1,112 @@
+package shipmentbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JSON tags are required for workflow event serialization. The workflow system
+// (via EventPublisher) marshals business models to JSON for RawData in TriggerEvents.
+// Without these tags, Go defaults to PascalCase keys, but workflow action handlers
+// expect snake_case keys to match API conventions.
+
+// Shipment is a consignment of an order's goods handed to a carrier. A
+// shipment is packed into cartons while in the packing status; ship
+// confirmation moves it to shipped and records the carrier hand-off.
+type Shipment struct {
+	ID             uuid.UUID  `json:"id"`
+	ShipmentNumber string     `json:"shipment_number"`
+	OrderID        uuid.UUID  `json:"order_id"`
+	Status         string     `json:"status"` // packing|shipped|cancelled
+	Carrier        string     `json:"carrier"`
+	ServiceLevel   string     `json:"service_level"`
+	TrackingNumber string     `json:"tracking_number"`
+	ShipDate       *time.Time `json:"ship_date,omitempty"`
+	ShippedBy      *uuid.UUID `json:"shipped_by,omitempty"`
+	Notes          string     `json:"notes"`
+	CreatedBy      uuid.UUID  `json:"created_by"`
+	UpdatedBy      uuid.UUID  `json:"updated_by"`
+	CreatedDate    time.Time  `json:"created_date"`
+	UpdatedDate    time.Time  `json:"updated_date"`
+	ScenarioID     *uuid.UUID `json:"scenario_id,omitempty"`
+}
+
+// NewShipment is what we require from clients when opening a shipment.
+type NewShipment struct {
+	ShipmentNumber string    `json:"shipment_number"`
+	OrderID        uuid.UUID `json:"order_id"`
+	Carrier        string    `json:"carrier"`
+	ServiceLevel   string    `json:"service_level"`
+	Notes          string    `json:"notes"`
+	CreatedBy      uuid.UUID `json:"created_by"`
+}
+
+// UpdateShipment contains the shipment fields that may change while it is
+// being packed. Status moves only through Ship and Cancel.
+type UpdateShipment struct {
+	ShipmentNumber *string    `json:"shipment_number,omitempty"`
+	Carrier        *string    `json:"carrier,omitempty"`
+	ServiceLevel   *string    `json:"service_level,omitempty"`
+	TrackingNumber *string    `json:"tracking_number,omitempty"`
+	Notes          *string    `json:"notes,omitempty"`
+	UpdatedBy      *uuid.UUID `json:"updated_by,omitempty"`
+}
+
+// ShipConfirmation records the carrier hand-off of a shipment. Carrier and
+// TrackingNumber override the values on the shipment when set.
+type ShipConfirmation struct {
+	ShippedBy      uuid.UUID
+	ShipDate       time.Time
+	Carrier        *string
+	TrackingNumber *string
+}
+
+// =============================================================================
+
+// Carton is one physical package of a shipment. ContainerBindingID links the
+// carton to the order's container binding (tote or box label) it was packed
+// from, when there is one.
+type Carton struct {
+	ID                 uuid.UUID    `json:"id"`
+	ShipmentID         uuid.UUID    `json:"shipment_id"`
+	CartonNumber       int          `json:"carton_number"`
+	ContainerBindingID *uuid.UUID   `json:"container_binding_id,omitempty"`
+	Weight             float64      `json:"weight"`
+	WeightUnit         string       `json:"weight_unit"`
+	TrackingNumber     string       `json:"tracking_number"`
+	Lines              []CartonLine `json:"lines"`
+	CreatedDate        time.Time    `json:"created_date"`
+}
+
+// CartonLine is the quantity of one order line packed in a carton and the
+// location it was picked from, whose allocation is released when the
+// shipment is confirmed.
+type CartonLine struct {
+	ID              uuid.UUID  `json:"id"`
+	CartonID        uuid.UUID  `json:"carton_id"`
+	OrderLineItemID uuid.UUID  `json:"order_line_item_id"`
+	ProductID       uuid.UUID  `json:"product_id"`
+	LocationID      uuid.UUID  `json:"location_id"`
+	LotID           *uuid.UUID `json:"lot_id,omitempty"`
+	Quantity        int        `json:"quantity"`
+}
+
+// NewCarton is what we require to add a carton to a shipment. Carton numbers
+// are assigned in sequence per shipment.
+type NewCarton struct {
+	ContainerBindingID *uuid.UUID
+	Weight             float64
+	WeightUnit         string
+	TrackingNumber     string
+	Lines              []NewCartonLine
+}
+
+// NewCartonLine is one line of a NewCarton.
+type NewCartonLine struct {
+	OrderLineItemID uuid.UUID
+	ProductID       uuid.UUID
+	LocationID      uuid.UUID
+	LotID           *uuid.UUID
+	Quantity        int
+}
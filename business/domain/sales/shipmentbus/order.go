@@ -0,0 +1,18 @@
+package shipmentbus
+
+import "github.com/timmaaaz/ichor/business/sdk/order"
+
+// DefaultOrderBy represents the default way we sort.
+var DefaultOrderBy = order.NewBy(OrderByCreatedDate, order.DESC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID             = "id"
+	OrderByShipmentNumber = "shipment_number"
+	OrderByOrderID        = "order_id"
+	OrderByStatus         = "status"
+	OrderByCarrier        = "carrier"
+	OrderByShipDate       = "ship_date"
+	OrderByCreatedDate    = "created_date"
+	OrderByUpdatedDate    = "updated_date"
+)
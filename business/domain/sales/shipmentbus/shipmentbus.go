@@ -0,0 +1,618 @@
+// Package shipmentbus provides business access to shipments: the cartons an
+// order is packed into and the confirmation that they left the building.
+package shipmentbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/delegate"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/outbox"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+	"github.com/timmaaaz/ichor/foundation/otel"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound              = errors.New("shipment not found")
+	ErrUniqueEntry           = errors.New("shipment entry is not unique")
+	ErrForeignKeyViolation   = errors.New("foreign key violation")
+	ErrInvalidShipmentStatus = errors.New("shipment is not in a state that allows this transition")
+	ErrCartonNotFound        = errors.New("carton not found")
+	ErrEmptyCarton           = errors.New("carton must contain at least one line with a positive quantity")
+	ErrNoCartons             = errors.New("shipment has no cartons")
+)
+
+// Shipment status values.
+const (
+	StatusPacking   = "packing"
+	StatusShipped   = "shipped"
+	StatusCancelled = "cancelled"
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	NewWithTx(tx sqldb.CommitRollbacker) (Storer, error)
+	Create(ctx context.Context, shipment Shipment) error
+	Update(ctx context.Context, shipment Shipment) error
+	// UpdateWithStatusGuard performs the same column update as Update, but only when
+	// the row's current status equals expectedStatus. It returns the number of rows
+	// affected (0 means the guard did not match — e.g. a concurrent transition won).
+	UpdateWithStatusGuard(ctx context.Context, shipment Shipment, expectedStatus string) (int64, error)
+	Delete(ctx context.Context, shipment Shipment) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]Shipment, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, shipmentID uuid.UUID) (Shipment, error)
+	CreateCarton(ctx context.Context, carton Carton) error
+	DeleteCarton(ctx context.Context, shipmentID uuid.UUID, cartonID uuid.UUID) error
+	QueryCartons(ctx context.Context, shipmentID uuid.UUID) ([]Carton, error)
+	QueryShipTo(ctx context.Context, orderID uuid.UUID) (Address, error)
+}
+
+// Business manages the set of APIs for shipment access.
+type Business struct {
+	log      *logger.Logger
+	storer   Storer
+	delegate *delegate.Delegate
+	outbox   *outbox.Writer
+	carrier  Carrier
+}
+
+// NewBusiness constructs a shipment business API for use.
+func NewBusiness(log *logger.Logger, delegate *delegate.Delegate, storer Storer) *Business {
+	return &Business{
+		log:      log,
+		delegate: delegate,
+		storer:   storer,
+	}
+}
+
+// WithOutbox returns a copy of the Business wired to the cascade outbox Writer.
+func (b *Business) WithOutbox(w *outbox.Writer) *Business {
+	nb := *b
+	nb.outbox = w
+	return &nb
+}
+
+// WithCarrier returns a copy of the Business that rates and buys labels
+// through the given Carrier.
+func (b *Business) WithCarrier(c Carrier) *Business {
+	nb := *b
+	nb.carrier = c
+	return &nb
+}
+
+// NewWithTx constructs a new Business value replacing the Storer
+// value with a Storer value that is currently inside a transaction.
+func (b *Business) NewWithTx(tx sqldb.CommitRollbacker) (*Business, error) {
+	storer, err := b.storer.NewWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	nb := *b
+	nb.storer = storer
+	return &nb, nil
+}
+
+// Create opens a new shipment for an order in the packing status.
+func (b *Business) Create(ctx context.Context, ns NewShipment) (Shipment, error) {
+	ctx, span := otel.AddSpan(ctx, "business.shipmentbus.create")
+	defer span.End()
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Shipment, error) {
+			now := time.Now().UTC()
+
+			shipment := Shipment{
+				ID:             uuid.New(),
+				ShipmentNumber: ns.ShipmentNumber,
+				OrderID:        ns.OrderID,
+				Status:         StatusPacking,
+				Carrier:        ns.Carrier,
+				ServiceLevel:   ns.ServiceLevel,
+				Notes:          ns.Notes,
+				CreatedBy:      ns.CreatedBy,
+				UpdatedBy:      ns.CreatedBy,
+				CreatedDate:    now,
+				UpdatedDate:    now,
+			}
+
+			if sid, ok := sqldb.GetScenarioFilter(ctx); ok {
+				shipment.ScenarioID = &sid
+			}
+
+			if err := b.storer.Create(ctx, shipment); err != nil {
+				return Shipment{}, fmt.Errorf("create: %w", err)
+			}
+
+			evtData := ActionCreatedData(shipment)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return Shipment{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionCreatedData(shipment)); err != nil {
+				b.log.Error(ctx, "shipmentbus: delegate call failed", "action", ActionCreated, "err", err)
+			}
+
+			return shipment, nil
+		})
+}
+
+// Update modifies the carrier details of a shipment. Cancelled shipments
+// cannot be changed; a shipped shipment can still have its tracking number
+// and notes corrected.
+func (b *Business) Update(ctx context.Context, shipment Shipment, us UpdateShipment) (Shipment, error) {
+	ctx, span := otel.AddSpan(ctx, "business.shipmentbus.update")
+	defer span.End()
+
+	if shipment.Status == StatusCancelled {
+		return Shipment{}, fmt.Errorf("update: %w: shipment is cancelled", ErrInvalidShipmentStatus)
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Shipment, error) {
+			before := shipment
+
+			if us.ShipmentNumber != nil {
+				shipment.ShipmentNumber = *us.ShipmentNumber
+			}
+			if us.Carrier != nil {
+				shipment.Carrier = *us.Carrier
+			}
+			if us.ServiceLevel != nil {
+				shipment.ServiceLevel = *us.ServiceLevel
+			}
+			if us.TrackingNumber != nil {
+				shipment.TrackingNumber = *us.TrackingNumber
+			}
+			if us.Notes != nil {
+				shipment.Notes = *us.Notes
+			}
+			if us.UpdatedBy != nil {
+				shipment.UpdatedBy = *us.UpdatedBy
+			}
+
+			shipment.UpdatedDate = time.Now().UTC()
+
+			if err := b.storer.Update(ctx, shipment); err != nil {
+				return Shipment{}, fmt.Errorf("update: %w", err)
+			}
+
+			evtData := ActionUpdatedData(before, shipment)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return Shipment{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionUpdatedData(before, shipment)); err != nil {
+				b.log.Error(ctx, "shipmentbus: delegate call failed", "action", ActionUpdated, "err", err)
+			}
+
+			return shipment, nil
+		})
+}
+
+// Delete removes a shipment that has not shipped, along with its cartons.
+func (b *Business) Delete(ctx context.Context, shipment Shipment) error {
+	ctx, span := otel.AddSpan(ctx, "business.shipmentbus.delete")
+	defer span.End()
+
+	if shipment.Status == StatusShipped {
+		return fmt.Errorf("delete: %w: shipment has shipped", ErrInvalidShipmentStatus)
+	}
+
+	return outbox.WriteAtomicVoid(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) error {
+			if err := b.storer.Delete(ctx, shipment); err != nil {
+				return fmt.Errorf("delete: %w", err)
+			}
+
+			evtData := ActionDeletedData(shipment)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionDeletedData(shipment)); err != nil {
+				b.log.Error(ctx, "shipmentbus: delegate call failed", "action", ActionDeleted, "err", err)
+			}
+
+			return nil
+		})
+}
+
+// Query retrieves a list of shipments from the system.
+func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.By, page page.Page) ([]Shipment, error) {
+	ctx, span := otel.AddSpan(ctx, "business.shipmentbus.query")
+	defer span.End()
+
+	shipments, err := b.storer.Query(ctx, filter, orderBy, page)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return shipments, nil
+}
+
+// Count returns the total number of shipments.
+func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.shipmentbus.count")
+	defer span.End()
+
+	return b.storer.Count(ctx, filter)
+}
+
+// QueryByID finds the shipment by the specified ID.
+func (b *Business) QueryByID(ctx context.Context, shipmentID uuid.UUID) (Shipment, error) {
+	ctx, span := otel.AddSpan(ctx, "business.shipmentbus.querybyid")
+	defer span.End()
+
+	shipment, err := b.storer.QueryByID(ctx, shipmentID)
+	if err != nil {
+		return Shipment{}, fmt.Errorf("querybyid: shipmentID[%s]: %w", shipmentID, err)
+	}
+
+	return shipment, nil
+}
+
+// =============================================================================
+// Cartons
+
+// AddCarton packs a new carton into a shipment that is still being packed.
+// The carton is numbered after the shipment's existing cartons.
+func (b *Business) AddCarton(ctx context.Context, shipment Shipment, nc NewCarton) (Carton, error) {
+	ctx, span := otel.AddSpan(ctx, "business.shipmentbus.addcarton")
+	defer span.End()
+
+	if shipment.Status != StatusPacking {
+		return Carton{}, fmt.Errorf("addcarton: %w: must be packing, got %s", ErrInvalidShipmentStatus, shipment.Status)
+	}
+
+	if len(nc.Lines) == 0 {
+		return Carton{}, fmt.Errorf("addcarton: %w", ErrEmptyCarton)
+	}
+	for _, line := range nc.Lines {
+		if line.Quantity <= 0 {
+			return Carton{}, fmt.Errorf("addcarton: %w", ErrEmptyCarton)
+		}
+	}
+
+	cartons, err := b.storer.QueryCartons(ctx, shipment.ID)
+	if err != nil {
+		return Carton{}, fmt.Errorf("addcarton: querycartons: %w", err)
+	}
+
+	number := 1
+	for _, c := range cartons {
+		number = max(number, c.CartonNumber+1)
+	}
+
+	carton := Carton{
+		ID:                 uuid.New(),
+		ShipmentID:         shipment.ID,
+		CartonNumber:       number,
+		ContainerBindingID: nc.ContainerBindingID,
+		Weight:             nc.Weight,
+		WeightUnit:         nc.WeightUnit,
+		TrackingNumber:     nc.TrackingNumber,
+		Lines:              make([]CartonLine, len(nc.Lines)),
+		CreatedDate:        time.Now().UTC(),
+	}
+
+	for i, line := range nc.Lines {
+		carton.Lines[i] = CartonLine{
+			ID:              uuid.New(),
+			CartonID:        carton.ID,
+			OrderLineItemID: line.OrderLineItemID,
+			ProductID:       line.ProductID,
+			LocationID:      line.LocationID,
+			LotID:           line.LotID,
+			Quantity:        line.Quantity,
+		}
+	}
+
+	if err := b.storer.CreateCarton(ctx, carton); err != nil {
+		return Carton{}, fmt.Errorf("addcarton: %w", err)
+	}
+
+	return carton, nil
+}
+
+// RemoveCarton unpacks a carton from a shipment that is still being packed.
+func (b *Business) RemoveCarton(ctx context.Context, shipment Shipment, cartonID uuid.UUID) error {
+	ctx, span := otel.AddSpan(ctx, "business.shipmentbus.removecarton")
+	defer span.End()
+
+	if shipment.Status != StatusPacking {
+		return fmt.Errorf("removecarton: %w: must be packing, got %s", ErrInvalidShipmentStatus, shipment.Status)
+	}
+
+	if err := b.storer.DeleteCarton(ctx, shipment.ID, cartonID); err != nil {
+		return fmt.Errorf("removecarton: %w", err)
+	}
+
+	return nil
+}
+
+// QueryCartons returns the cartons of a shipment with their lines, ordered
+// by carton number.
+func (b *Business) QueryCartons(ctx context.Context, shipmentID uuid.UUID) ([]Carton, error) {
+	ctx, span := otel.AddSpan(ctx, "business.shipmentbus.querycartons")
+	defer span.End()
+
+	cartons, err := b.storer.QueryCartons(ctx, shipmentID)
+	if err != nil {
+		return nil, fmt.Errorf("querycartons: %w", err)
+	}
+
+	return cartons, nil
+}
+
+// =============================================================================
+// Status transitions
+
+// Ship confirms a packed shipment was handed to the carrier. This is a status
+// transition only — relieving the packed stock happens at the app layer in
+// the same transaction.
+func (b *Business) Ship(ctx context.Context, shipment Shipment, sc ShipConfirmation) (Shipment, error) {
+	ctx, span := otel.AddSpan(ctx, "business.shipmentbus.ship")
+	defer span.End()
+
+	if shipment.Status != StatusPacking {
+		return Shipment{}, fmt.Errorf("ship: %w: must be packing, got %s", ErrInvalidShipmentStatus, shipment.Status)
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Shipment, error) {
+			cartons, err := b.storer.QueryCartons(ctx, shipment.ID)
+			if err != nil {
+				return Shipment{}, fmt.Errorf("ship: querycartons: %w", err)
+			}
+			if len(cartons) == 0 {
+				return Shipment{}, fmt.Errorf("ship: %w", ErrNoCartons)
+			}
+
+			before := shipment
+
+			if sc.Carrier != nil {
+				shipment.Carrier = *sc.Carrier
+			}
+			if sc.TrackingNumber != nil {
+				shipment.TrackingNumber = *sc.TrackingNumber
+			}
+
+			shipDate := sc.ShipDate.UTC()
+			shipment.Status = StatusShipped
+			shipment.ShipDate = &shipDate
+			shipment.ShippedBy = &sc.ShippedBy
+			shipment.UpdatedBy = sc.ShippedBy
+			shipment.UpdatedDate = time.Now().UTC()
+
+			// Guard on the still-packing DB state so two concurrent confirmations
+			// cannot both relieve the packed stock.
+			rows, err := b.storer.UpdateWithStatusGuard(ctx, shipment, StatusPacking)
+			if err != nil {
+				return Shipment{}, fmt.Errorf("ship: %w", err)
+			}
+			if rows == 0 {
+				return Shipment{}, fmt.Errorf("ship: %w: status changed concurrently", ErrInvalidShipmentStatus)
+			}
+
+			evtData := ActionUpdatedData(before, shipment)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return Shipment{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionUpdatedData(before, shipment)); err != nil {
+				b.log.Error(ctx, "shipmentbus: delegate call failed", "action", ActionUpdated, "err", err)
+			}
+
+			return shipment, nil
+		})
+}
+
+// Cancel abandons a shipment that has not shipped.
+func (b *Business) Cancel(ctx context.Context, shipment Shipment, cancelledBy uuid.UUID) (Shipment, error) {
+	ctx, span := otel.AddSpan(ctx, "business.shipmentbus.cancel")
+	defer span.End()
+
+	if shipment.Status != StatusPacking {
+		return Shipment{}, fmt.Errorf("cancel: %w: must be packing, got %s", ErrInvalidShipmentStatus, shipment.Status)
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Shipment, error) {
+			before := shipment
+
+			shipment.Status = StatusCancelled
+			shipment.UpdatedBy = cancelledBy
+			shipment.UpdatedDate = time.Now().UTC()
+
+			rows, err := b.storer.UpdateWithStatusGuard(ctx, shipment, StatusPacking)
+			if err != nil {
+				return Shipment{}, fmt.Errorf("cancel: %w", err)
+			}
+			if rows == 0 {
+				return Shipment{}, fmt.Errorf("cancel: %w: status changed concurrently", ErrInvalidShipmentStatus)
+			}
+
+			evtData := ActionUpdatedData(before, shipment)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return Shipment{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionUpdatedData(before, shipment)); err != nil {
+				b.log.Error(ctx, "shipmentbus: delegate call failed", "action", ActionUpdated, "err", err)
+			}
+
+			return shipment, nil
+		})
+}
+
+// =============================================================================
+// Carrier
+
+// RateShop asks the configured carrier to price a packed shipment, one
+// parcel per carton.
+func (b *Business) RateShop(ctx context.Context, shipment Shipment) ([]Rate, error) {
+	ctx, span := otel.AddSpan(ctx, "business.shipmentbus.rateshop")
+	defer span.End()
+
+	if b.carrier == nil {
+		return nil, fmt.Errorf("rateshop: %w", ErrNoCarrier)
+	}
+
+	req, err := b.rateRequest(ctx, shipment)
+	if err != nil {
+		return nil, fmt.Errorf("rateshop: %w", err)
+	}
+
+	rates, err := b.carrier.Rates(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("rateshop: carrier[%s]: %w", b.carrier.Name(), err)
+	}
+
+	return rates, nil
+}
+
+// CreateLabel buys a label for the shipment at the given service level and
+// records the carrier, service level and tracking number on it. The returned
+// Label carries the ZPL to print. If recording the label fails, the label is
+// voided with the carrier so it is not paid for twice.
+func (b *Business) CreateLabel(ctx context.Context, shipment Shipment, serviceLevel string, userID uuid.UUID) (Shipment, Label, error) {
+	ctx, span := otel.AddSpan(ctx, "business.shipmentbus.createlabel")
+	defer span.End()
+
+	if b.carrier == nil {
+		return Shipment{}, Label{}, fmt.Errorf("createlabel: %w", ErrNoCarrier)
+	}
+	if shipment.Status != StatusPacking {
+		return Shipment{}, Label{}, fmt.Errorf("createlabel: %w: must be packing, got %s", ErrInvalidShipmentStatus, shipment.Status)
+	}
+	if shipment.TrackingNumber != "" {
+		return Shipment{}, Label{}, fmt.Errorf("createlabel: %w: tracking number %s", ErrLabelExists, shipment.TrackingNumber)
+	}
+
+	req, err := b.rateRequest(ctx, shipment)
+	if err != nil {
+		return Shipment{}, Label{}, fmt.Errorf("createlabel: %w", err)
+	}
+
+	label, err := b.carrier.CreateLabel(ctx, LabelRequest{RateRequest: req, ServiceLevel: serviceLevel})
+	if err != nil {
+		return Shipment{}, Label{}, fmt.Errorf("createlabel: carrier[%s]: %w", b.carrier.Name(), err)
+	}
+
+	updated, err := outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Shipment, error) {
+			before := shipment
+
+			shipment.Carrier = label.Carrier
+			shipment.ServiceLevel = label.ServiceLevel
+			shipment.TrackingNumber = label.TrackingNumber
+			shipment.UpdatedBy = userID
+			shipment.UpdatedDate = time.Now().UTC()
+
+			rows, err := b.storer.UpdateWithStatusGuard(ctx, shipment, StatusPacking)
+			if err != nil {
+				return Shipment{}, fmt.Errorf("createlabel: %w", err)
+			}
+			if rows == 0 {
+				return Shipment{}, fmt.Errorf("createlabel: %w: status changed concurrently", ErrInvalidShipmentStatus)
+			}
+
+			evtData := ActionUpdatedData(before, shipment)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return Shipment{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionUpdatedData(before, shipment)); err != nil {
+				b.log.Error(ctx, "shipmentbus: delegate call failed", "action", ActionUpdated, "err", err)
+			}
+
+			return shipment, nil
+		})
+	if err != nil {
+		if vErr := b.carrier.VoidLabel(ctx, label.TrackingNumber); vErr != nil {
+			b.log.Error(ctx, "shipmentbus: void orphaned label failed", "tracking_number", label.TrackingNumber, "err", vErr)
+		}
+		return Shipment{}, Label{}, err
+	}
+
+	return updated, label, nil
+}
+
+// VoidLabel cancels the shipment's label with the carrier and clears the
+// tracking number so a new label can be bought. Only labels of shipments
+// that have not shipped can be voided.
+func (b *Business) VoidLabel(ctx context.Context, shipment Shipment, userID uuid.UUID) (Shipment, error) {
+	ctx, span := otel.AddSpan(ctx, "business.shipmentbus.voidlabel")
+	defer span.End()
+
+	if b.carrier == nil {
+		return Shipment{}, fmt.Errorf("voidlabel: %w", ErrNoCarrier)
+	}
+	if shipment.Status != StatusPacking {
+		return Shipment{}, fmt.Errorf("voidlabel: %w: must be packing, got %s", ErrInvalidShipmentStatus, shipment.Status)
+	}
+	if shipment.TrackingNumber == "" {
+		return Shipment{}, fmt.Errorf("voidlabel: %w", ErrNoLabel)
+	}
+
+	if err := b.carrier.VoidLabel(ctx, shipment.TrackingNumber); err != nil {
+		return Shipment{}, fmt.Errorf("voidlabel: carrier[%s]: %w", b.carrier.Name(), err)
+	}
+
+	return outbox.WriteAtomic(ctx, b.outbox, b, (*Business).NewWithTx,
+		func(ctx context.Context, b *Business) (Shipment, error) {
+			before := shipment
+
+			shipment.TrackingNumber = ""
+			shipment.UpdatedBy = userID
+			shipment.UpdatedDate = time.Now().UTC()
+
+			rows, err := b.storer.UpdateWithStatusGuard(ctx, shipment, StatusPacking)
+			if err != nil {
+				return Shipment{}, fmt.Errorf("voidlabel: %w", err)
+			}
+			if rows == 0 {
+				return Shipment{}, fmt.Errorf("voidlabel: %w: status changed concurrently", ErrInvalidShipmentStatus)
+			}
+
+			evtData := ActionUpdatedData(before, shipment)
+			if err := b.outbox.Emit(ctx, evtData); err != nil {
+				return Shipment{}, fmt.Errorf("emit cascade event: %w", err)
+			}
+			if err := b.delegate.Call(ctx, ActionUpdatedData(before, shipment)); err != nil {
+				b.log.Error(ctx, "shipmentbus: delegate call failed", "action", ActionUpdated, "err", err)
+			}
+
+			return shipment, nil
+		})
+}
+
+func (b *Business) rateRequest(ctx context.Context, shipment Shipment) (RateRequest, error) {
+	cartons, err := b.storer.QueryCartons(ctx, shipment.ID)
+	if err != nil {
+		return RateRequest{}, fmt.Errorf("querycartons: %w", err)
+	}
+	if len(cartons) == 0 {
+		return RateRequest{}, ErrNoCartons
+	}
+
+	shipTo, err := b.storer.QueryShipTo(ctx, shipment.OrderID)
+	if err != nil {
+		return RateRequest{}, fmt.Errorf("queryshipto: %w", err)
+	}
+
+	parcels := make([]Parcel, len(cartons))
+	for i, c := range cartons {
+		parcels[i] = Parcel{Weight: c.Weight, WeightUnit: c.WeightUnit}
+	}
+
+	return RateRequest{
+		ShipmentNumber: shipment.ShipmentNumber,
+		ShipTo:         shipTo,
+		Parcels:        parcels,
+	}, nil
+}
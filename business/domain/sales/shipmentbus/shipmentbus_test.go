@@ -0,0 +1,717 @@
+package shipmentbus_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/core/contactinfosbus"
+	"github.com/timmaaaz/ichor/business/domain/core/currencybus"
+	"github.com/timmaaaz/ichor/business/domain/core/userbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/citybus"
+	"github.com/timmaaaz/ichor/business/domain/geography/regionbus"
+	"github.com/timmaaaz/ichor/business/domain/geography/streetbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/warehousebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/zonebus"
+	"github.com/timmaaaz/ichor/business/domain/products/brandbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productbus"
+	"github.com/timmaaaz/ichor/business/domain/products/productcategorybus"
+	"github.com/timmaaaz/ichor/business/domain/sales/customersbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/lineitemfulfillmentstatusbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/orderfulfillmentstatusbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/orderlineitemsbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/ordersbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus/flatrate"
+	"github.com/timmaaaz/ichor/business/sdk/dbtest"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/unitest"
+)
+
+func Test_Shipment(t *testing.T) {
+	t.Parallel()
+
+	db := dbtest.NewDatabase(t, "Test_Shipment")
+
+	sd, err := insertSeedData(db.BusDomain)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	unitest.Run(t, query(db.BusDomain, sd), "query")
+	unitest.Run(t, create(db.BusDomain, sd), "create")
+	unitest.Run(t, update(db.BusDomain, sd), "update")
+	unitest.Run(t, cartons(db.BusDomain, sd), "cartons")
+	unitest.Run(t, label(db.BusDomain, sd), "label")
+	unitest.Run(t, ship(db.BusDomain, sd), "ship")
+	unitest.Run(t, cancel(db.BusDomain, sd), "cancel")
+	unitest.Run(t, delete(db.BusDomain, sd), "delete")
+}
+
+func insertSeedData(busDomain dbtest.BusDomain) (unitest.SeedData, error) {
+	ctx := context.Background()
+
+	admins, err := userbus.TestSeedUsersWithNoFKs(ctx, 1, userbus.Roles.Admin, busDomain.User)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding user : %w", err)
+	}
+	userIDs := make([]uuid.UUID, 0, len(admins))
+	for _, a := range admins {
+		userIDs = append(userIDs, a.ID)
+	}
+
+	count := 5
+
+	// ADDRESSES
+	regions, err := busDomain.Region.Query(ctx, regionbus.QueryFilter{}, regionbus.DefaultOrderBy, page.MustParse("1", "5"))
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("querying regions : %w", err)
+	}
+	ids := make([]uuid.UUID, 0, len(regions))
+	for _, r := range regions {
+		ids = append(ids, r.ID)
+	}
+
+	ctys, err := citybus.TestSeedCities(ctx, count, ids, busDomain.City)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding cities : %w", err)
+	}
+	ctyIDs := make([]uuid.UUID, 0, len(ctys))
+	for _, c := range ctys {
+		ctyIDs = append(ctyIDs, c.ID)
+	}
+
+	strs, err := streetbus.TestSeedStreets(ctx, count, ctyIDs, busDomain.Street)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding streets : %w", err)
+	}
+	strIDs := make([]uuid.UUID, 0, len(strs))
+	for _, s := range strs {
+		strIDs = append(strIDs, s.ID)
+	}
+
+	tzs, err := busDomain.Timezone.QueryAll(ctx)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("querying timezones : %w", err)
+	}
+	tzIDs := make([]uuid.UUID, 0, len(tzs))
+	for _, tz := range tzs {
+		tzIDs = append(tzIDs, tz.ID)
+	}
+
+	contactInfos, err := contactinfosbus.TestSeedContactInfos(ctx, count, strIDs, tzIDs, busDomain.ContactInfos)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding contact info : %w", err)
+	}
+	contactInfoIDs := make([]uuid.UUID, 0, len(contactInfos))
+	for _, ci := range contactInfos {
+		contactInfoIDs = append(contactInfoIDs, ci.ID)
+	}
+
+	// ORDERS
+	customers, err := customersbus.TestSeedCustomers(ctx, count, strIDs, contactInfoIDs, uuid.UUIDs{admins[0].ID}, busDomain.Customers)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding customers : %w", err)
+	}
+	customerIDs := make([]uuid.UUID, 0, len(customers))
+	for _, c := range customers {
+		customerIDs = append(customerIDs, c.ID)
+	}
+
+	ofls, err := orderfulfillmentstatusbus.TestSeedOrderFulfillmentStatuses(ctx, busDomain.OrderFulfillmentStatus)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding order fulfillment statuses: %w", err)
+	}
+	oflIDs := make([]uuid.UUID, 0, len(ofls))
+	for _, ofl := range ofls {
+		oflIDs = append(oflIDs, ofl.ID)
+	}
+
+	currencies, err := currencybus.TestSeedCurrencies(ctx, 5, busDomain.Currency)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding currencies: %w", err)
+	}
+	currencyIDs := make(uuid.UUIDs, len(currencies))
+	for i, c := range currencies {
+		currencyIDs[i] = c.ID
+	}
+
+	orders, err := ordersbus.TestSeedOrders(ctx, count, uuid.UUIDs{admins[0].ID}, customerIDs, oflIDs, currencyIDs, busDomain.Order)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding Orders: %w", err)
+	}
+	orderIDs := make([]uuid.UUID, 0, len(orders))
+	for _, o := range orders {
+		orderIDs = append(orderIDs, o.ID)
+	}
+
+	// PRODUCTS
+	brand, err := brandbus.TestSeedBrands(ctx, 5, contactInfoIDs, busDomain.Brand)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding brand : %w", err)
+	}
+	brandIDs := make(uuid.UUIDs, len(brand))
+	for i, b := range brand {
+		brandIDs[i] = b.BrandID
+	}
+
+	productCategories, err := productcategorybus.TestSeedProductCategories(ctx, 10, busDomain.ProductCategory)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding product category : %w", err)
+	}
+	productCategoryIDs := make(uuid.UUIDs, len(productCategories))
+	for i, pc := range productCategories {
+		productCategoryIDs[i] = pc.ProductCategoryID
+	}
+
+	products, err := productbus.TestSeedProducts(ctx, 20, brandIDs, productCategoryIDs, busDomain.Product)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding product : %w", err)
+	}
+	productIDs := make([]uuid.UUID, 0, len(products))
+	for _, p := range products {
+		productIDs = append(productIDs, p.ProductID)
+	}
+
+	olStatuses, err := lineitemfulfillmentstatusbus.TestSeedLineItemFulfillmentStatuses(ctx, busDomain.LineItemFulfillmentStatus)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding line item fulfillment statuses: %w", err)
+	}
+	olStatusIDs := make([]uuid.UUID, 0, len(olStatuses))
+	for _, ols := range olStatuses {
+		olStatusIDs = append(olStatusIDs, ols.ID)
+	}
+
+	ols, err := orderlineitemsbus.TestSeedOrderLineItems(ctx, count, orderIDs, productIDs, olStatusIDs, userIDs, busDomain.OrderLineItem)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding Order Line Items: %w", err)
+	}
+
+	// LOCATIONS
+	warehouses, err := warehousebus.TestSeedWarehouses(ctx, 2, admins[0].ID, strIDs, busDomain.Warehouse)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding warehouses : %w", err)
+	}
+	warehouseIDs := make(uuid.UUIDs, len(warehouses))
+	for i, w := range warehouses {
+		warehouseIDs[i] = w.ID
+	}
+
+	zones, err := zonebus.TestSeedZone(ctx, 4, warehouseIDs, busDomain.Zones)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding zones : %w", err)
+	}
+
+	inventoryLocations, err := inventorylocationbus.TestSeedInventoryLocations(ctx, 4, warehouseIDs, zones, busDomain.InventoryLocation)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding inventory locations : %w", err)
+	}
+
+	// SHIPMENTS
+	shipments, err := shipmentbus.TestSeedShipments(ctx, count, orderIDs, admins[0].ID, busDomain.Shipment)
+	if err != nil {
+		return unitest.SeedData{}, fmt.Errorf("seeding shipments : %w", err)
+	}
+
+	return unitest.SeedData{
+		Admins:             []unitest.User{{User: admins[0]}},
+		Orders:             orders,
+		OrderLineItems:     ols,
+		InventoryLocations: inventoryLocations,
+		Shipments:          shipments,
+	}, nil
+}
+
+func query(busDomain dbtest.BusDomain, sd unitest.SeedData) []unitest.Table {
+	return []unitest.Table{
+		{
+			Name:    "Query",
+			ExpResp: sd.Shipments,
+			ExcFunc: func(ctx context.Context) any {
+				got, err := busDomain.Shipment.Query(ctx, shipmentbus.QueryFilter{}, shipmentbus.DefaultOrderBy, page.MustParse("1", "10"))
+				if err != nil {
+					return err
+				}
+				return got
+			},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.([]shipmentbus.Shipment)
+				if !exists {
+					return fmt.Sprintf("expected []shipmentbus.Shipment, got %T", got)
+				}
+
+				expResp := exp.([]shipmentbus.Shipment)
+				sort.Slice(gotResp, func(i, j int) bool {
+					return gotResp[i].ID.String() < gotResp[j].ID.String()
+				})
+
+				if len(gotResp) != len(expResp) {
+					return fmt.Sprintf("expected %d shipments, got %d", len(expResp), len(gotResp))
+				}
+
+				for i := range gotResp {
+					expResp[i].CreatedDate = gotResp[i].CreatedDate
+					expResp[i].UpdatedDate = gotResp[i].UpdatedDate
+				}
+
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func create(busDomain dbtest.BusDomain, sd unitest.SeedData) []unitest.Table {
+	return []unitest.Table{
+		{
+			Name: "Create",
+			ExpResp: shipmentbus.Shipment{
+				ShipmentNumber: "SHP-CREATE-1",
+				OrderID:        sd.Orders[0].ID,
+				Status:         shipmentbus.StatusPacking,
+				Carrier:        "UPS",
+				ServiceLevel:   "Next Day Air",
+				Notes:          "Fragile",
+				CreatedBy:      sd.Admins[0].ID,
+				UpdatedBy:      sd.Admins[0].ID,
+			},
+			ExcFunc: func(ctx context.Context) any {
+				got, err := busDomain.Shipment.Create(ctx, shipmentbus.NewShipment{
+					ShipmentNumber: "SHP-CREATE-1",
+					OrderID:        sd.Orders[0].ID,
+					Carrier:        "UPS",
+					ServiceLevel:   "Next Day Air",
+					Notes:          "Fragile",
+					CreatedBy:      sd.Admins[0].ID,
+				})
+				if err != nil {
+					return err
+				}
+				return got
+			},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(shipmentbus.Shipment)
+				if !exists {
+					return fmt.Sprintf("expected shipmentbus.Shipment, got %T", got)
+				}
+
+				expResp := exp.(shipmentbus.Shipment)
+				expResp.ID = gotResp.ID
+				expResp.CreatedDate = gotResp.CreatedDate
+				expResp.UpdatedDate = gotResp.UpdatedDate
+
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+		{
+			Name:    "Create-duplicate-number",
+			ExpResp: shipmentbus.ErrUniqueEntry,
+			ExcFunc: func(ctx context.Context) any {
+				_, err := busDomain.Shipment.Create(ctx, shipmentbus.NewShipment{
+					ShipmentNumber: sd.Shipments[0].ShipmentNumber,
+					OrderID:        sd.Orders[0].ID,
+					CreatedBy:      sd.Admins[0].ID,
+				})
+				return err
+			},
+			CmpFunc: cmpErr,
+		},
+	}
+}
+
+func update(busDomain dbtest.BusDomain, sd unitest.SeedData) []unitest.Table {
+	tracking := "1Z999AA10123456784"
+	notes := "Updated notes"
+
+	return []unitest.Table{
+		{
+			Name: "Update",
+			ExpResp: func() shipmentbus.Shipment {
+				s := sd.Shipments[0]
+				s.TrackingNumber = tracking
+				s.Notes = notes
+				return s
+			}(),
+			ExcFunc: func(ctx context.Context) any {
+				got, err := busDomain.Shipment.Update(ctx, sd.Shipments[0], shipmentbus.UpdateShipment{
+					TrackingNumber: &tracking,
+					Notes:          &notes,
+				})
+				if err != nil {
+					return err
+				}
+				return got
+			},
+			CmpFunc: func(got, exp any) string {
+				gotResp, exists := got.(shipmentbus.Shipment)
+				if !exists {
+					return fmt.Sprintf("expected shipmentbus.Shipment, got %T", got)
+				}
+
+				expResp := exp.(shipmentbus.Shipment)
+				expResp.CreatedDate = gotResp.CreatedDate
+				expResp.UpdatedDate = gotResp.UpdatedDate
+
+				return cmp.Diff(gotResp, expResp)
+			},
+		},
+	}
+}
+
+func cartons(busDomain dbtest.BusDomain, sd unitest.SeedData) []unitest.Table {
+	line := sd.OrderLineItems[0]
+
+	newCarton := shipmentbus.NewCarton{
+		Weight:     2.5,
+		WeightUnit: "kg",
+		Lines: []shipmentbus.NewCartonLine{
+			{
+				OrderLineItemID: line.ID,
+				ProductID:       line.ProductID,
+				LocationID:      sd.InventoryLocations[0].LocationID,
+				Quantity:        1,
+			},
+		},
+	}
+
+	return []unitest.Table{
+		{
+			Name:    "AddCarton-empty",
+			ExpResp: shipmentbus.ErrEmptyCarton,
+			ExcFunc: func(ctx context.Context) any {
+				_, err := busDomain.Shipment.AddCarton(ctx, sd.Shipments[0], shipmentbus.NewCarton{})
+				return err
+			},
+			CmpFunc: cmpErr,
+		},
+		{
+			Name:    "AddCarton",
+			ExpResp: []int{1, 2},
+			ExcFunc: func(ctx context.Context) any {
+				for range 2 {
+					if _, err := busDomain.Shipment.AddCarton(ctx, sd.Shipments[0], newCarton); err != nil {
+						return err
+					}
+				}
+
+				got, err := busDomain.Shipment.QueryCartons(ctx, sd.Shipments[0].ID)
+				if err != nil {
+					return err
+				}
+
+				numbers := make([]int, len(got))
+				for i, c := range got {
+					if len(c.Lines) != 1 || c.Lines[0].Quantity != 1 {
+						return fmt.Errorf("carton %d: unexpected lines %+v", c.CartonNumber, c.Lines)
+					}
+					numbers[i] = c.CartonNumber
+				}
+				return numbers
+			},
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "RemoveCarton",
+			ExpResp: []int{2},
+			ExcFunc: func(ctx context.Context) any {
+				got, err := busDomain.Shipment.QueryCartons(ctx, sd.Shipments[0].ID)
+				if err != nil {
+					return err
+				}
+
+				if err := busDomain.Shipment.RemoveCarton(ctx, sd.Shipments[0], got[0].ID); err != nil {
+					return err
+				}
+
+				got, err = busDomain.Shipment.QueryCartons(ctx, sd.Shipments[0].ID)
+				if err != nil {
+					return err
+				}
+
+				numbers := make([]int, len(got))
+				for i, c := range got {
+					numbers[i] = c.CartonNumber
+				}
+				return numbers
+			},
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "RemoveCarton-not-found",
+			ExpResp: shipmentbus.ErrCartonNotFound,
+			ExcFunc: func(ctx context.Context) any {
+				return busDomain.Shipment.RemoveCarton(ctx, sd.Shipments[0], uuid.New())
+			},
+			CmpFunc: cmpErr,
+		},
+	}
+}
+
+func label(busDomain dbtest.BusDomain, sd unitest.SeedData) []unitest.Table {
+	carrierBus := busDomain.Shipment.WithCarrier(flatrate.New(nil))
+	userID := sd.Admins[0].ID
+
+	return []unitest.Table{
+		{
+			Name:    "RateShop-no-carrier",
+			ExpResp: shipmentbus.ErrNoCarrier,
+			ExcFunc: func(ctx context.Context) any {
+				_, err := busDomain.Shipment.RateShop(ctx, sd.Shipments[0])
+				return err
+			},
+			CmpFunc: cmpErr,
+		},
+		{
+			Name:    "RateShop-no-cartons",
+			ExpResp: shipmentbus.ErrNoCartons,
+			ExcFunc: func(ctx context.Context) any {
+				_, err := carrierBus.RateShop(ctx, sd.Shipments[1])
+				return err
+			},
+			CmpFunc: cmpErr,
+		},
+		{
+			Name:    "RateShop",
+			ExpResp: []string{"ground", "express", "overnight"},
+			ExcFunc: func(ctx context.Context) any {
+				rates, err := carrierBus.RateShop(ctx, sd.Shipments[0])
+				if err != nil {
+					return err
+				}
+
+				levels := make([]string, len(rates))
+				for i, r := range rates {
+					if r.Cost <= 0 {
+						return fmt.Errorf("rate %s: expected positive cost, got %v", r.ServiceLevel, r.Cost)
+					}
+					levels[i] = r.ServiceLevel
+				}
+				return levels
+			},
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "CreateLabel-exists",
+			ExpResp: shipmentbus.ErrLabelExists,
+			ExcFunc: func(ctx context.Context) any {
+				// The update test recorded a tracking number by hand.
+				sh, err := carrierBus.QueryByID(ctx, sd.Shipments[0].ID)
+				if err != nil {
+					return err
+				}
+				_, _, err = carrierBus.CreateLabel(ctx, sh, "ground", userID)
+				return err
+			},
+			CmpFunc: cmpErr,
+		},
+		{
+			Name:    "VoidLabel",
+			ExpResp: "",
+			ExcFunc: func(ctx context.Context) any {
+				sh, err := carrierBus.QueryByID(ctx, sd.Shipments[0].ID)
+				if err != nil {
+					return err
+				}
+				got, err := carrierBus.VoidLabel(ctx, sh, userID)
+				if err != nil {
+					return err
+				}
+				return got.TrackingNumber
+			},
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "VoidLabel-no-label",
+			ExpResp: shipmentbus.ErrNoLabel,
+			ExcFunc: func(ctx context.Context) any {
+				sh, err := carrierBus.QueryByID(ctx, sd.Shipments[0].ID)
+				if err != nil {
+					return err
+				}
+				_, err = carrierBus.VoidLabel(ctx, sh, userID)
+				return err
+			},
+			CmpFunc: cmpErr,
+		},
+		{
+			Name:    "CreateLabel-unknown-service-level",
+			ExpResp: shipmentbus.ErrUnknownServiceLevel,
+			ExcFunc: func(ctx context.Context) any {
+				sh, err := carrierBus.QueryByID(ctx, sd.Shipments[0].ID)
+				if err != nil {
+					return err
+				}
+				_, _, err = carrierBus.CreateLabel(ctx, sh, "teleport", userID)
+				return err
+			},
+			CmpFunc: cmpErr,
+		},
+		{
+			Name:    "CreateLabel",
+			ExpResp: "ground",
+			ExcFunc: func(ctx context.Context) any {
+				sh, err := carrierBus.QueryByID(ctx, sd.Shipments[0].ID)
+				if err != nil {
+					return err
+				}
+
+				got, lbl, err := carrierBus.CreateLabel(ctx, sh, "ground", userID)
+				if err != nil {
+					return err
+				}
+				if got.TrackingNumber == "" || got.TrackingNumber != lbl.TrackingNumber || got.Carrier != flatrate.Name {
+					return fmt.Errorf("label not recorded on shipment: %+v", got)
+				}
+				if len(lbl.ZPL) == 0 {
+					return errors.New("expected label ZPL")
+				}
+
+				stored, err := carrierBus.QueryByID(ctx, got.ID)
+				if err != nil {
+					return err
+				}
+				if stored.TrackingNumber != lbl.TrackingNumber {
+					return fmt.Errorf("stored tracking number %q, exp %q", stored.TrackingNumber, lbl.TrackingNumber)
+				}
+				return stored.ServiceLevel
+			},
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func ship(busDomain dbtest.BusDomain, sd unitest.SeedData) []unitest.Table {
+	shippedBy := sd.Admins[0].ID
+
+	return []unitest.Table{
+		{
+			Name:    "Ship-no-cartons",
+			ExpResp: shipmentbus.ErrNoCartons,
+			ExcFunc: func(ctx context.Context) any {
+				_, err := busDomain.Shipment.Ship(ctx, sd.Shipments[1], shipmentbus.ShipConfirmation{
+					ShippedBy: shippedBy,
+					ShipDate:  time.Now(),
+				})
+				return err
+			},
+			CmpFunc: cmpErr,
+		},
+		{
+			Name:    "Ship",
+			ExpResp: shipmentbus.StatusShipped,
+			ExcFunc: func(ctx context.Context) any {
+				sh, err := busDomain.Shipment.QueryByID(ctx, sd.Shipments[0].ID)
+				if err != nil {
+					return err
+				}
+
+				got, err := busDomain.Shipment.Ship(ctx, sh, shipmentbus.ShipConfirmation{
+					ShippedBy: shippedBy,
+					ShipDate:  time.Now(),
+				})
+				if err != nil {
+					return err
+				}
+				if got.ShippedBy == nil || *got.ShippedBy != shippedBy || got.ShipDate == nil {
+					return fmt.Errorf("ship confirmation not recorded: %+v", got)
+				}
+				return got.Status
+			},
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "Ship-twice",
+			ExpResp: shipmentbus.ErrInvalidShipmentStatus,
+			ExcFunc: func(ctx context.Context) any {
+				sh, err := busDomain.Shipment.QueryByID(ctx, sd.Shipments[0].ID)
+				if err != nil {
+					return err
+				}
+
+				_, err = busDomain.Shipment.Ship(ctx, sh, shipmentbus.ShipConfirmation{
+					ShippedBy: shippedBy,
+					ShipDate:  time.Now(),
+				})
+				return err
+			},
+			CmpFunc: cmpErr,
+		},
+	}
+}
+
+func cancel(busDomain dbtest.BusDomain, sd unitest.SeedData) []unitest.Table {
+	return []unitest.Table{
+		{
+			Name:    "Cancel",
+			ExpResp: shipmentbus.StatusCancelled,
+			ExcFunc: func(ctx context.Context) any {
+				got, err := busDomain.Shipment.Cancel(ctx, sd.Shipments[2], sd.Admins[0].ID)
+				if err != nil {
+					return err
+				}
+				return got.Status
+			},
+			CmpFunc: func(got, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+}
+
+func delete(busDomain dbtest.BusDomain, sd unitest.SeedData) []unitest.Table {
+	return []unitest.Table{
+		{
+			Name:    "Delete-shipped",
+			ExpResp: shipmentbus.ErrInvalidShipmentStatus,
+			ExcFunc: func(ctx context.Context) any {
+				sh, err := busDomain.Shipment.QueryByID(ctx, sd.Shipments[0].ID)
+				if err != nil {
+					return err
+				}
+				return busDomain.Shipment.Delete(ctx, sh)
+			},
+			CmpFunc: cmpErr,
+		},
+		{
+			Name:    "Delete",
+			ExpResp: shipmentbus.ErrNotFound,
+			ExcFunc: func(ctx context.Context) any {
+				if err := busDomain.Shipment.Delete(ctx, sd.Shipments[3]); err != nil {
+					return err
+				}
+				_, err := busDomain.Shipment.QueryByID(ctx, sd.Shipments[3].ID)
+				return err
+			},
+			CmpFunc: cmpErr,
+		},
+	}
+}
+
+func cmpErr(got, exp any) string {
+	gotErr, ok := got.(error)
+	if !ok {
+		return fmt.Sprintf("expected error, got %T", got)
+	}
+	expErr := exp.(error)
+	if !errors.Is(gotErr, expErr) {
+		return fmt.Sprintf("got %v, exp %v", gotErr, expErr)
+	}
+	return ""
+}
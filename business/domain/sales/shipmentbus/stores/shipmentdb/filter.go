@@ -0,0 +1,67 @@
+package shipmentdb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+)
+
+func applyFilter(filter shipmentbus.QueryFilter, data map[string]any, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["id"] = *filter.ID
+		wc = append(wc, "id = :id")
+	}
+
+	if filter.ShipmentNumber != nil {
+		data["shipment_number"] = *filter.ShipmentNumber
+		wc = append(wc, "shipment_number = :shipment_number")
+	}
+
+	if filter.OrderID != nil {
+		data["order_id"] = *filter.OrderID
+		wc = append(wc, "order_id = :order_id")
+	}
+
+	if filter.Status != nil {
+		data["status"] = *filter.Status
+		wc = append(wc, "status = :status")
+	}
+
+	if filter.Carrier != nil {
+		data["carrier"] = *filter.Carrier
+		wc = append(wc, "carrier = :carrier")
+	}
+
+	if filter.TrackingNumber != nil {
+		data["tracking_number"] = *filter.TrackingNumber
+		wc = append(wc, "tracking_number = :tracking_number")
+	}
+
+	if filter.StartShipDate != nil {
+		data["start_ship_date"] = filter.StartShipDate.UTC()
+		wc = append(wc, "ship_date >= :start_ship_date")
+	}
+
+	if filter.EndShipDate != nil {
+		data["end_ship_date"] = filter.EndShipDate.UTC()
+		wc = append(wc, "ship_date <= :end_ship_date")
+	}
+
+	if filter.StartCreatedDate != nil {
+		data["start_created_date"] = filter.StartCreatedDate.UTC()
+		wc = append(wc, "created_date >= :start_created_date")
+	}
+
+	if filter.EndCreatedDate != nil {
+		data["end_created_date"] = filter.EndCreatedDate.UTC()
+		wc = append(wc, "created_date <= :end_created_date")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
@@ -0,0 +1,209 @@
+package shipmentdb
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+)
+
+type shipment struct {
+	ID             uuid.UUID      `db:"id"`
+	ShipmentNumber string         `db:"shipment_number"`
+	OrderID        uuid.UUID      `db:"order_id"`
+	Status         string         `db:"status" protected:"true"`
+	Carrier        sql.NullString `db:"carrier"`
+	ServiceLevel   sql.NullString `db:"service_level"`
+	TrackingNumber sql.NullString `db:"tracking_number"`
+	ShipDate       sql.NullTime   `db:"ship_date" protected:"true"`
+	ShippedBy      uuid.NullUUID  `db:"shipped_by" protected:"true"`
+	Notes          sql.NullString `db:"notes"`
+	CreatedBy      uuid.UUID      `db:"created_by"`
+	UpdatedBy      uuid.UUID      `db:"updated_by"`
+	CreatedDate    time.Time      `db:"created_date"`
+	UpdatedDate    time.Time      `db:"updated_date"`
+	ScenarioID     *uuid.UUID     `db:"scenario_id"`
+}
+
+func toDBShipment(bus shipmentbus.Shipment) shipment {
+	db := shipment{
+		ID:             bus.ID,
+		ShipmentNumber: bus.ShipmentNumber,
+		OrderID:        bus.OrderID,
+		Status:         bus.Status,
+		Carrier:        sql.NullString{String: bus.Carrier, Valid: bus.Carrier != ""},
+		ServiceLevel:   sql.NullString{String: bus.ServiceLevel, Valid: bus.ServiceLevel != ""},
+		TrackingNumber: sql.NullString{String: bus.TrackingNumber, Valid: bus.TrackingNumber != ""},
+		Notes:          sql.NullString{String: bus.Notes, Valid: bus.Notes != ""},
+		CreatedBy:      bus.CreatedBy,
+		UpdatedBy:      bus.UpdatedBy,
+		CreatedDate:    bus.CreatedDate.UTC(),
+		UpdatedDate:    bus.UpdatedDate.UTC(),
+		ScenarioID:     bus.ScenarioID,
+	}
+
+	if bus.ShipDate != nil {
+		db.ShipDate = sql.NullTime{Time: bus.ShipDate.UTC(), Valid: true}
+	}
+	if bus.ShippedBy != nil {
+		db.ShippedBy = uuid.NullUUID{UUID: *bus.ShippedBy, Valid: true}
+	}
+
+	return db
+}
+
+func toBusShipment(db shipment) shipmentbus.Shipment {
+	bus := shipmentbus.Shipment{
+		ID:             db.ID,
+		ShipmentNumber: db.ShipmentNumber,
+		OrderID:        db.OrderID,
+		Status:         db.Status,
+		Carrier:        db.Carrier.String,
+		ServiceLevel:   db.ServiceLevel.String,
+		TrackingNumber: db.TrackingNumber.String,
+		Notes:          db.Notes.String,
+		CreatedBy:      db.CreatedBy,
+		UpdatedBy:      db.UpdatedBy,
+		CreatedDate:    db.CreatedDate.In(time.Local),
+		UpdatedDate:    db.UpdatedDate.In(time.Local),
+		ScenarioID:     db.ScenarioID,
+	}
+
+	if db.ShipDate.Valid {
+		t := db.ShipDate.Time.In(time.Local)
+		bus.ShipDate = &t
+	}
+	if db.ShippedBy.Valid {
+		id := db.ShippedBy.UUID
+		bus.ShippedBy = &id
+	}
+
+	return bus
+}
+
+func toBusShipments(dbs []shipment) []shipmentbus.Shipment {
+	bus := make([]shipmentbus.Shipment, len(dbs))
+	for i, db := range dbs {
+		bus[i] = toBusShipment(db)
+	}
+	return bus
+}
+
+// =============================================================================
+
+type carton struct {
+	ID                 uuid.UUID       `db:"id"`
+	ShipmentID         uuid.UUID       `db:"shipment_id"`
+	CartonNumber       int             `db:"carton_number"`
+	ContainerBindingID uuid.NullUUID   `db:"container_binding_id"`
+	Weight             sql.NullFloat64 `db:"weight"`
+	WeightUnit         sql.NullString  `db:"weight_unit"`
+	TrackingNumber     sql.NullString  `db:"tracking_number"`
+	CreatedDate        time.Time       `db:"created_date"`
+}
+
+type cartonLine struct {
+	ID              uuid.UUID     `db:"id"`
+	CartonID        uuid.UUID     `db:"carton_id"`
+	OrderLineItemID uuid.UUID     `db:"order_line_item_id"`
+	ProductID       uuid.UUID     `db:"product_id"`
+	LocationID      uuid.UUID     `db:"location_id"`
+	LotID           uuid.NullUUID `db:"lot_id"`
+	Quantity        int           `db:"quantity"`
+}
+
+func toDBCarton(bus shipmentbus.Carton) carton {
+	db := carton{
+		ID:             bus.ID,
+		ShipmentID:     bus.ShipmentID,
+		CartonNumber:   bus.CartonNumber,
+		Weight:         sql.NullFloat64{Float64: bus.Weight, Valid: bus.Weight != 0},
+		WeightUnit:     sql.NullString{String: bus.WeightUnit, Valid: bus.WeightUnit != ""},
+		TrackingNumber: sql.NullString{String: bus.TrackingNumber, Valid: bus.TrackingNumber != ""},
+		CreatedDate:    bus.CreatedDate.UTC(),
+	}
+
+	if bus.ContainerBindingID != nil {
+		db.ContainerBindingID = uuid.NullUUID{UUID: *bus.ContainerBindingID, Valid: true}
+	}
+
+	return db
+}
+
+func toDBCartonLine(bus shipmentbus.CartonLine) cartonLine {
+	db := cartonLine{
+		ID:              bus.ID,
+		CartonID:        bus.CartonID,
+		OrderLineItemID: bus.OrderLineItemID,
+		ProductID:       bus.ProductID,
+		LocationID:      bus.LocationID,
+		Quantity:        bus.Quantity,
+	}
+
+	if bus.LotID != nil {
+		db.LotID = uuid.NullUUID{UUID: *bus.LotID, Valid: true}
+	}
+
+	return db
+}
+
+func toBusCarton(db carton) shipmentbus.Carton {
+	bus := shipmentbus.Carton{
+		ID:             db.ID,
+		ShipmentID:     db.ShipmentID,
+		CartonNumber:   db.CartonNumber,
+		Weight:         db.Weight.Float64,
+		WeightUnit:     db.WeightUnit.String,
+		TrackingNumber: db.TrackingNumber.String,
+		Lines:          []shipmentbus.CartonLine{},
+		CreatedDate:    db.CreatedDate.In(time.Local),
+	}
+
+	if db.ContainerBindingID.Valid {
+		id := db.ContainerBindingID.UUID
+		bus.ContainerBindingID = &id
+	}
+
+	return bus
+}
+
+func toBusCartonLine(db cartonLine) shipmentbus.CartonLine {
+	bus := shipmentbus.CartonLine{
+		ID:              db.ID,
+		CartonID:        db.CartonID,
+		OrderLineItemID: db.OrderLineItemID,
+		ProductID:       db.ProductID,
+		LocationID:      db.LocationID,
+		Quantity:        db.Quantity,
+	}
+
+	if db.LotID.Valid {
+		id := db.LotID.UUID
+		bus.LotID = &id
+	}
+
+	return bus
+}
+
+// =============================================================================
+
+type address struct {
+	Name       string `db:"name"`
+	Line1      string `db:"line_1"`
+	Line2      string `db:"line_2"`
+	City       string `db:"city"`
+	Region     string `db:"region"`
+	PostalCode string `db:"postal_code"`
+}
+
+func toBusAddress(db address) shipmentbus.Address {
+	return shipmentbus.Address{
+		Name:       db.Name,
+		Line1:      db.Line1,
+		Line2:      db.Line2,
+		City:       db.City,
+		Region:     db.Region,
+		PostalCode: db.PostalCode,
+	}
+}
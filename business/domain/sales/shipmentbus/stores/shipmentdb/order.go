@@ -0,0 +1,28 @@
+package shipmentdb
+
+import (
+	"fmt"
+
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+)
+
+var orderByFields = map[string]string{
+	shipmentbus.OrderByID:             "id",
+	shipmentbus.OrderByShipmentNumber: "shipment_number",
+	shipmentbus.OrderByOrderID:        "order_id",
+	shipmentbus.OrderByStatus:         "status",
+	shipmentbus.OrderByCarrier:        "carrier",
+	shipmentbus.OrderByShipDate:       "ship_date",
+	shipmentbus.OrderByCreatedDate:    "created_date",
+	shipmentbus.OrderByUpdatedDate:    "updated_date",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
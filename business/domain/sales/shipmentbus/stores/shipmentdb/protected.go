@@ -0,0 +1,11 @@
+package shipmentdb
+
+import "github.com/timmaaaz/ichor/business/sdk/workflow/protected"
+
+// RegisterProtected declares the sales.shipments columns generic workflow writes must
+// not set directly. status/ship_date/shipped_by belong to ship confirmation, which
+// relieves the packed stock in the same transaction; setting them directly would mark
+// a shipment shipped without moving inventory.
+func RegisterProtected(reg *protected.Registry) {
+	protected.CollectStructTags(reg, "sales.shipments", "", shipment{})
+}
@@ -0,0 +1,392 @@
+// Package shipmentdb contains shipment related CRUD functionality.
+package shipmentdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+const shipmentColumns = `
+	id, shipment_number, order_id, status, carrier, service_level, tracking_number,
+	ship_date, shipped_by, notes, created_by, updated_by, created_date, updated_date, scenario_id`
+
+// Store manages the set of APIs for shipment database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the api for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// NewWithTx constructs a new Store value replacing the sqlx DB
+// value with a sqlx DB value that is currently inside a transaction.
+func (s *Store) NewWithTx(tx sqldb.CommitRollbacker) (shipmentbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		log: s.log,
+		db:  ec,
+	}, nil
+}
+
+// Create inserts a new shipment into the database.
+func (s *Store) Create(ctx context.Context, sh shipmentbus.Shipment) error {
+	const q = `
+	INSERT INTO sales.shipments (` + shipmentColumns + `
+	) VALUES (
+		:id, :shipment_number, :order_id, :status, :carrier, :service_level, :tracking_number,
+		:ship_date, :shipped_by, :notes, :created_by, :updated_by, :created_date, :updated_date, :scenario_id
+	)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBShipment(sh)); err != nil {
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// Update replaces a shipment document in the database.
+func (s *Store) Update(ctx context.Context, sh shipmentbus.Shipment) error {
+	const q = `
+	UPDATE
+		sales.shipments
+	SET
+		shipment_number = :shipment_number,
+		status = :status,
+		carrier = :carrier,
+		service_level = :service_level,
+		tracking_number = :tracking_number,
+		ship_date = :ship_date,
+		shipped_by = :shipped_by,
+		notes = :notes,
+		updated_by = :updated_by,
+		updated_date = :updated_date
+	WHERE
+		id = :id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBShipment(sh)); err != nil {
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// UpdateWithStatusGuard applies the same update as Update only while the
+// row still holds expectedStatus, returning the number of rows changed.
+func (s *Store) UpdateWithStatusGuard(ctx context.Context, sh shipmentbus.Shipment, expectedStatus string) (int64, error) {
+	const q = `
+	UPDATE
+		sales.shipments
+	SET
+		shipment_number = :shipment_number,
+		status = :status,
+		carrier = :carrier,
+		service_level = :service_level,
+		tracking_number = :tracking_number,
+		ship_date = :ship_date,
+		shipped_by = :shipped_by,
+		notes = :notes,
+		updated_by = :updated_by,
+		updated_date = :updated_date
+	WHERE
+		id = :id AND status = :expected_status`
+
+	data := struct {
+		shipment
+		ExpectedStatus string `db:"expected_status"`
+	}{
+		shipment:       toDBShipment(sh),
+		ExpectedStatus: expectedStatus,
+	}
+
+	rows, err := sqldb.NamedExecContextWithCount(ctx, s.log, s.db, q, data)
+	if err != nil {
+		return 0, translateError(err)
+	}
+
+	return rows, nil
+}
+
+// Delete removes a shipment and, by cascade, its cartons from the database.
+func (s *Store) Delete(ctx context.Context, sh shipmentbus.Shipment) error {
+	const q = `
+	DELETE FROM
+		sales.shipments
+	WHERE
+		id = :id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBShipment(sh)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of shipments from the database.
+func (s *Store) Query(ctx context.Context, filter shipmentbus.QueryFilter, orderBy order.By, page page.Page) ([]shipmentbus.Shipment, error) {
+	data := map[string]any{
+		"offset":        (page.Number() - 1) * page.RowsPerPage(),
+		"rows_per_page": page.RowsPerPage(),
+	}
+
+	const q = `
+	SELECT` + shipmentColumns + `
+	FROM
+		sales.shipments`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+	sqldb.ApplyScenarioFilter(ctx, buf, data)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbShipments []shipment
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbShipments); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusShipments(dbShipments), nil
+}
+
+// Count returns the number of shipments matching the filter.
+func (s *Store) Count(ctx context.Context, filter shipmentbus.QueryFilter) (int, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		COUNT(1) AS count
+	FROM
+		sales.shipments`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+	sqldb.ApplyScenarioFilter(ctx, buf, data)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("namedquerystruct: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID gets the specified shipment from the database.
+func (s *Store) QueryByID(ctx context.Context, shipmentID uuid.UUID) (shipmentbus.Shipment, error) {
+	data := map[string]any{
+		"id": shipmentID,
+	}
+
+	const q = `
+	SELECT` + shipmentColumns + `
+	FROM
+		sales.shipments
+	WHERE
+		id = :id`
+
+	buf := bytes.NewBufferString(q)
+	sqldb.ApplyScenarioFilter(ctx, buf, data)
+
+	var dbShipment shipment
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &dbShipment); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return shipmentbus.Shipment{}, fmt.Errorf("namedquerystruct: %w", shipmentbus.ErrNotFound)
+		}
+		return shipmentbus.Shipment{}, fmt.Errorf("namedquerystruct: %w", err)
+	}
+
+	return toBusShipment(dbShipment), nil
+}
+
+// =============================================================================
+// Cartons
+
+// CreateCarton inserts a carton and its lines.
+func (s *Store) CreateCarton(ctx context.Context, c shipmentbus.Carton) error {
+	const qCarton = `
+	INSERT INTO sales.shipment_cartons (
+		id, shipment_id, carton_number, container_binding_id, weight, weight_unit, tracking_number, created_date
+	) VALUES (
+		:id, :shipment_id, :carton_number, :container_binding_id, :weight, :weight_unit, :tracking_number, :created_date
+	)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, qCarton, toDBCarton(c)); err != nil {
+		return translateError(err)
+	}
+
+	const qLine = `
+	INSERT INTO sales.shipment_carton_lines (
+		id, carton_id, order_line_item_id, product_id, location_id, lot_id, quantity
+	) VALUES (
+		:id, :carton_id, :order_line_item_id, :product_id, :location_id, :lot_id, :quantity
+	)`
+
+	for _, line := range c.Lines {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, qLine, toDBCartonLine(line)); err != nil {
+			return translateError(err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteCarton removes a carton of the given shipment and, by cascade, its lines.
+func (s *Store) DeleteCarton(ctx context.Context, shipmentID uuid.UUID, cartonID uuid.UUID) error {
+	data := struct {
+		ID         uuid.UUID `db:"id"`
+		ShipmentID uuid.UUID `db:"shipment_id"`
+	}{
+		ID:         cartonID,
+		ShipmentID: shipmentID,
+	}
+
+	const q = `
+	DELETE FROM
+		sales.shipment_cartons
+	WHERE
+		id = :id AND shipment_id = :shipment_id`
+
+	rows, err := sqldb.NamedExecContextWithCount(ctx, s.log, s.db, q, data)
+	if err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+	if rows == 0 {
+		return shipmentbus.ErrCartonNotFound
+	}
+
+	return nil
+}
+
+// QueryCartons returns the cartons of a shipment with their lines.
+func (s *Store) QueryCartons(ctx context.Context, shipmentID uuid.UUID) ([]shipmentbus.Carton, error) {
+	data := struct {
+		ShipmentID uuid.UUID `db:"shipment_id"`
+	}{
+		ShipmentID: shipmentID,
+	}
+
+	const qCartons = `
+	SELECT
+		id, shipment_id, carton_number, container_binding_id, weight, weight_unit, tracking_number, created_date
+	FROM
+		sales.shipment_cartons
+	WHERE
+		shipment_id = :shipment_id
+	ORDER BY
+		carton_number`
+
+	var dbCartons []carton
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, qCartons, data, &dbCartons); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: cartons: %w", err)
+	}
+
+	const qLines = `
+	SELECT
+		l.id, l.carton_id, l.order_line_item_id, l.product_id, l.location_id, l.lot_id, l.quantity
+	FROM
+		sales.shipment_carton_lines l
+	JOIN
+		sales.shipment_cartons c ON c.id = l.carton_id
+	WHERE
+		c.shipment_id = :shipment_id
+	ORDER BY
+		c.carton_number, l.id`
+
+	var dbLines []cartonLine
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, qLines, data, &dbLines); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: lines: %w", err)
+	}
+
+	cartons := make([]shipmentbus.Carton, len(dbCartons))
+	index := make(map[uuid.UUID]int, len(dbCartons))
+	for i, c := range dbCartons {
+		cartons[i] = toBusCarton(c)
+		index[c.ID] = i
+	}
+
+	for _, line := range dbLines {
+		i := index[line.CartonID]
+		cartons[i].Lines = append(cartons[i].Lines, toBusCartonLine(line))
+	}
+
+	return cartons, nil
+}
+
+// =============================================================================
+// Carrier
+
+// QueryShipTo resolves the destination of an order's shipments: the order's
+// shipping address, falling back to the customer's delivery address.
+func (s *Store) QueryShipTo(ctx context.Context, orderID uuid.UUID) (shipmentbus.Address, error) {
+	data := struct {
+		OrderID uuid.UUID `db:"order_id"`
+	}{
+		OrderID: orderID,
+	}
+
+	const q = `
+	SELECT
+		cu.name, st.line_1, COALESCE(st.line_2, '') AS line_2, ci.name AS city,
+		r.code AS region, COALESCE(st.postal_code, '') AS postal_code
+	FROM
+		sales.orders o
+	JOIN
+		sales.customers cu ON cu.id = o.customer_id
+	JOIN
+		geography.streets st ON st.id = COALESCE(o.shipping_address_id, cu.delivery_address_id)
+	JOIN
+		geography.cities ci ON ci.id = st.city_id
+	JOIN
+		geography.regions r ON r.id = ci.region_id
+	WHERE
+		o.id = :order_id`
+
+	var dbAddr address
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbAddr); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return shipmentbus.Address{}, fmt.Errorf("namedquerystruct: %w", shipmentbus.ErrNotFound)
+		}
+		return shipmentbus.Address{}, fmt.Errorf("namedquerystruct: %w", err)
+	}
+
+	return toBusAddress(dbAddr), nil
+}
+
+// =============================================================================
+
+func translateError(err error) error {
+	switch {
+	case errors.Is(err, sqldb.ErrDBDuplicatedEntry):
+		return fmt.Errorf("namedexeccontext: %w", shipmentbus.ErrUniqueEntry)
+	case errors.Is(err, sqldb.ErrForeignKeyViolation):
+		return fmt.Errorf("namedexeccontext: %w", shipmentbus.ErrForeignKeyViolation)
+	default:
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+}
@@ -0,0 +1,52 @@
+package shipmentbus
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// TestNewShipments is a helper method for testing.
+func TestNewShipments(n int, orderIDs uuid.UUIDs, createdBy uuid.UUID) []NewShipment {
+	newShipments := make([]NewShipment, n)
+
+	carriers := []string{"UPS", "FedEx", "USPS", "DHL"}
+
+	idx := rand.Intn(10000)
+	for i := range n {
+		idx++
+		newShipments[i] = NewShipment{
+			ShipmentNumber: fmt.Sprintf("SHP-%05d", idx),
+			OrderID:        orderIDs[i%len(orderIDs)],
+			Carrier:        carriers[idx%len(carriers)],
+			ServiceLevel:   "Ground",
+			Notes:          fmt.Sprintf("Test shipment %d", idx),
+			CreatedBy:      createdBy,
+		}
+	}
+
+	return newShipments
+}
+
+// TestSeedShipments is a helper method for testing.
+func TestSeedShipments(ctx context.Context, n int, orderIDs uuid.UUIDs, createdBy uuid.UUID, api *Business) ([]Shipment, error) {
+	newShipments := TestNewShipments(n, orderIDs, createdBy)
+
+	shipments := make([]Shipment, len(newShipments))
+	for i, ns := range newShipments {
+		sh, err := api.Create(ctx, ns)
+		if err != nil {
+			return nil, fmt.Errorf("seeding shipment: idx: %d : %w", i, err)
+		}
+		shipments[i] = sh
+	}
+
+	sort.Slice(shipments, func(i, j int) bool {
+		return shipments[i].ID.String() < shipments[j].ID.String()
+	})
+
+	return shipments, nil
+}
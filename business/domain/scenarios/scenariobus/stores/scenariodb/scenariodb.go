@@ -314,7 +314,10 @@ func (s *Store) SetActive(ctx context.Context, id uuid.UUID) error {
 
 // scopedTables is the ordered list of floor-scoped tables that carry a
 // scenario_id column (migration 2.35 added 18 tables; migration 2.39 added
-// procurement.supplier_products). Order matters for FK constraints —
+// procurement.supplier_products; migration 2.48 created
+// inventory.inventory_reservations with one; migration 2.51 created
+// sales.shipments, whose cartons cascade with it; migration 2.57 created
+// inventory.replenishment_tasks with one). Order matters for FK constraints —
 // more dependent child tables are listed before their parents.
 //
 // This slice is the single source of truth for FK ordering: DeleteScopedRows
@@ -326,7 +329,9 @@ var scopedTables = []string{
 	"inventory.cycle_count_sessions",
 	"inventory.quality_inspections",
 	"inventory.put_away_tasks",
+	"inventory.replenishment_tasks",
 	"inventory.pick_tasks",
+	"inventory.inventory_reservations",
 	"inventory.inventory_transactions",
 	"inventory.lot_locations",
 	"inventory.serial_numbers",
@@ -336,6 +341,7 @@ var scopedTables = []string{
 	"procurement.purchase_order_line_items",
 	"procurement.purchase_orders",
 	"procurement.supplier_products",
+	"sales.shipments",
 	"sales.order_fulfillment_statuses",
 	"sales.order_line_items",
 	"sales.orders",
@@ -69,7 +69,7 @@ func Test_ActionPermissions_ButtonVerbGrantsSeeded(t *testing.T) {
 		t.Fatalf("seed admin user: %s", err)
 	}
 
-	for _, verb := range []string{"release_to_picking", "claim_transfer_order", "execute_transfer_order"} {
+	for _, verb := range []string{"release_to_picking", "claim_transfer_order", "execute_transfer_order", "create_shipping_label", "generate_cycle_counts"} {
 		can, err := db.BusDomain.ActionPermissions.CanUserExecuteAction(ctx, admins[0].ID, verb, []uuid.UUID{adminRoleID})
 		if err != nil {
 			t.Fatalf("CanUserExecuteAction(%s): %s", verb, err)
@@ -20,6 +20,7 @@ var (
 	ErrNotFound        = errors.New("approval request not found")
 	ErrAlreadyResolved = errors.New("approval request already resolved")
 	ErrNotApprover     = errors.New("user is not an approver for this request")
+	ErrAlreadyVoted    = errors.New("approver has already voted on this request")
 )
 
 // Storer interface declares the behavior this package needs to persist and
@@ -33,6 +34,12 @@ type Storer interface {
 	Count(ctx context.Context, filter QueryFilter) (int, error)
 	IsApprover(ctx context.Context, approvalID, userID uuid.UUID) (bool, error)
 	ClearTaskToken(ctx context.Context, id uuid.UUID) error
+	CreateVote(ctx context.Context, vote Vote) error
+	QueryVotes(ctx context.Context, approvalID uuid.UUID) ([]Vote, error)
+	QueryDueEscalations(ctx context.Context, now time.Time, limit int) ([]ApprovalRequest, error)
+	QueryDueTimeouts(ctx context.Context, now time.Time, limit int) ([]ApprovalRequest, error)
+	Escalate(ctx context.Context, id uuid.UUID, approvers []uuid.UUID, escalatedDate time.Time) (ApprovalRequest, error)
+	TimeOut(ctx context.Context, id uuid.UUID, reason string) (ApprovalRequest, error)
 }
 
 // Business manages approval request operations.
@@ -82,11 +89,21 @@ func (b *Business) Create(ctx context.Context, na NewApprovalRequest) (ApprovalR
 		ActionName:      na.ActionName,
 		Approvers:       na.Approvers,
 		ApprovalType:    na.ApprovalType,
+		Required:        RequiredApprovals(na.ApprovalType, na.Approvers),
 		Status:          StatusPending,
 		TimeoutHours:    na.TimeoutHours,
 		TaskToken:       na.TaskToken,
 		ApprovalMessage: na.ApprovalMessage,
 		CreatedDate:     now,
+
+		EscalateAfterHours: na.EscalateAfterHours,
+		EscalationTarget:   na.EscalationTarget,
+		EscalationRole:     na.EscalationRole,
+		TimeoutAction:      na.TimeoutAction,
+	}
+
+	if req.TimeoutAction == "" {
+		req.TimeoutAction = TimeoutActionReject
 	}
 
 	// Phase 0d: tag the row with the active scenario (if any) so scenario
@@ -177,3 +194,149 @@ func (b *Business) IsApprover(ctx context.Context, approvalID, userID uuid.UUID)
 	return b.storer.IsApprover(ctx, approvalID, userID)
 }
 
+// CastVote records one approver's decision and resolves the request once its
+// quorum is met or becomes impossible. The returned bool reports whether THIS
+// vote resolved the request; only then should the caller complete the waiting
+// Temporal activity.
+//
+// The vote insert and the tally read are separate autocommit statements, so
+// concurrent voters each count every vote committed before their read: the
+// last voter to read always sees the full set. If two voters both reach the
+// deciding tally, the conditional Resolve lets exactly one of them win; the
+// other gets the resolved request back with resolved=false.
+//
+// Returns ErrAlreadyVoted if the approver already voted and ErrAlreadyResolved
+// if the request is no longer pending.
+func (b *Business) CastVote(ctx context.Context, id, approverID uuid.UUID, decision, reason string) (ApprovalRequest, Tally, bool, error) {
+	ctx, span := otel.AddSpan(ctx, "business.approvalrequestbus.castvote")
+	defer span.End()
+
+	req, err := b.storer.QueryByID(ctx, id)
+	if err != nil {
+		return ApprovalRequest{}, Tally{}, false, fmt.Errorf("query approval request: id[%s]: %w", id, err)
+	}
+
+	if req.Status != StatusPending {
+		return req, Tally{}, false, ErrAlreadyResolved
+	}
+
+	vote := Vote{
+		ID:                uuid.New(),
+		ApprovalRequestID: id,
+		ApproverID:        approverID,
+		Decision:          decision,
+		Reason:            reason,
+		VotedDate:         time.Now(),
+	}
+
+	if err := b.storer.CreateVote(ctx, vote); err != nil {
+		return req, Tally{}, false, fmt.Errorf("create vote: %w", err)
+	}
+
+	votes, err := b.storer.QueryVotes(ctx, id)
+	if err != nil {
+		return req, Tally{}, false, fmt.Errorf("query votes: %w", err)
+	}
+
+	tally := EvaluateQuorum(req.ApprovalType, req.Required, req.Approvers, votes)
+	if tally.Outcome == StatusPending {
+		return req, tally, false, nil
+	}
+
+	resolved, err := b.Resolve(ctx, id, approverID, tally.Outcome, reason)
+	if err != nil {
+		if errors.Is(err, ErrAlreadyResolved) {
+			current, qErr := b.storer.QueryByID(ctx, id)
+			if qErr != nil {
+				return req, tally, false, fmt.Errorf("query approval request: id[%s]: %w", id, qErr)
+			}
+			return current, tally, false, nil
+		}
+		return req, tally, false, err
+	}
+
+	return resolved, tally, true, nil
+}
+
+// QueryVotes returns the votes cast on an approval request, oldest first.
+func (b *Business) QueryVotes(ctx context.Context, approvalID uuid.UUID) ([]Vote, error) {
+	ctx, span := otel.AddSpan(ctx, "business.approvalrequestbus.queryvotes")
+	defer span.End()
+
+	votes, err := b.storer.QueryVotes(ctx, approvalID)
+	if err != nil {
+		return nil, fmt.Errorf("query votes: id[%s]: %w", approvalID, err)
+	}
+
+	return votes, nil
+}
+
+// QueryDueEscalations returns pending requests whose escalation window has
+// lapsed and that have not been escalated yet.
+func (b *Business) QueryDueEscalations(ctx context.Context, now time.Time, limit int) ([]ApprovalRequest, error) {
+	ctx, span := otel.AddSpan(ctx, "business.approvalrequestbus.querydueescalations")
+	defer span.End()
+
+	reqs, err := b.storer.QueryDueEscalations(ctx, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query due escalations: %w", err)
+	}
+
+	return reqs, nil
+}
+
+// QueryDueTimeouts returns requests that need timeout handling: pending
+// requests past their final timeout, plus timed-out requests whose Temporal
+// activity was never completed (task token still present).
+func (b *Business) QueryDueTimeouts(ctx context.Context, now time.Time, limit int) ([]ApprovalRequest, error) {
+	ctx, span := otel.AddSpan(ctx, "business.approvalrequestbus.queryduetimeouts")
+	defer span.End()
+
+	reqs, err := b.storer.QueryDueTimeouts(ctx, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query due timeouts: %w", err)
+	}
+
+	return reqs, nil
+}
+
+// Escalate reassigns a pending request to a new approver set. It happens at
+// most once per request; returns ErrAlreadyResolved if the request is no
+// longer pending or was already escalated (e.g. by another replica).
+func (b *Business) Escalate(ctx context.Context, id uuid.UUID, approvers []uuid.UUID) (ApprovalRequest, error) {
+	ctx, span := otel.AddSpan(ctx, "business.approvalrequestbus.escalate")
+	defer span.End()
+
+	req, err := b.storer.Escalate(ctx, id, approvers, time.Now())
+	if err != nil {
+		return ApprovalRequest{}, fmt.Errorf("escalate approval request: %w", err)
+	}
+
+	if b.delegate != nil {
+		if err := b.delegate.Call(ctx, ActionUpdatedData(ApprovalRequest{ID: id}, req)); err != nil {
+			b.log.Error(ctx, "approvalrequestbus: delegate call failed on escalate", "err", err)
+		}
+	}
+
+	return req, nil
+}
+
+// TimeOut atomically transitions a pending request to StatusTimedOut with no
+// resolver. Returns ErrAlreadyResolved if the request is no longer pending.
+func (b *Business) TimeOut(ctx context.Context, id uuid.UUID, reason string) (ApprovalRequest, error) {
+	ctx, span := otel.AddSpan(ctx, "business.approvalrequestbus.timeout")
+	defer span.End()
+
+	req, err := b.storer.TimeOut(ctx, id, reason)
+	if err != nil {
+		return ApprovalRequest{}, fmt.Errorf("time out approval request: %w", err)
+	}
+
+	if b.delegate != nil {
+		if err := b.delegate.Call(ctx, ActionUpdatedData(ApprovalRequest{ID: id}, req)); err != nil {
+			b.log.Error(ctx, "approvalrequestbus: delegate call failed on timeout", "err", err)
+		}
+	}
+
+	return req, nil
+}
@@ -50,6 +50,24 @@ func (m *mockApprovalStorer) IsApprover(_ context.Context, _, _ uuid.UUID) (bool
 func (m *mockApprovalStorer) ClearTaskToken(_ context.Context, _ uuid.UUID) error {
 	return nil
 }
+func (m *mockApprovalStorer) CreateVote(_ context.Context, _ approvalrequestbus.Vote) error {
+	return nil
+}
+func (m *mockApprovalStorer) QueryVotes(_ context.Context, _ uuid.UUID) ([]approvalrequestbus.Vote, error) {
+	return nil, nil
+}
+func (m *mockApprovalStorer) QueryDueEscalations(_ context.Context, _ time.Time, _ int) ([]approvalrequestbus.ApprovalRequest, error) {
+	return nil, nil
+}
+func (m *mockApprovalStorer) QueryDueTimeouts(_ context.Context, _ time.Time, _ int) ([]approvalrequestbus.ApprovalRequest, error) {
+	return nil, nil
+}
+func (m *mockApprovalStorer) Escalate(_ context.Context, _ uuid.UUID, _ []uuid.UUID, _ time.Time) (approvalrequestbus.ApprovalRequest, error) {
+	return approvalrequestbus.ApprovalRequest{}, nil
+}
+func (m *mockApprovalStorer) TimeOut(_ context.Context, _ uuid.UUID, _ string) (approvalrequestbus.ApprovalRequest, error) {
+	return approvalrequestbus.ApprovalRequest{}, nil
+}
 
 // =============================================================================
 
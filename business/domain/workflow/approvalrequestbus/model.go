@@ -23,6 +23,19 @@ const (
 	ApprovalTypeMajority = "majority"
 )
 
+// EscalationTarget constants: who a pending request is reassigned to.
+const (
+	EscalationTargetManager = "manager" // each approver's boss from hr.reports_to
+	EscalationTargetRole    = "role"    // every member of a named role
+)
+
+// TimeoutAction constants: how a request is auto-resolved at its final timeout.
+// Either way the workflow leaves through the "timed_out" output port.
+const (
+	TimeoutActionApprove = "approve"
+	TimeoutActionReject  = "reject"
+)
+
 // ApprovalRequest represents a workflow approval request in the system.
 type ApprovalRequest struct {
 	ID               uuid.UUID
@@ -32,6 +45,7 @@ type ApprovalRequest struct {
 	ActionName       string
 	Approvers        []uuid.UUID
 	ApprovalType     string
+	Required         int // approvals needed, fixed at creation; see EvaluateQuorum
 	Status           string
 	TimeoutHours     int
 	TaskToken        string
@@ -41,6 +55,13 @@ type ApprovalRequest struct {
 	CreatedDate      time.Time
 	ResolvedDate     *time.Time
 	ScenarioID       *uuid.UUID `json:"scenario_id,omitempty"`
+
+	// Escalation policy. EscalateAfterHours == 0 means no escalation.
+	EscalateAfterHours int
+	EscalationTarget   string
+	EscalationRole     string
+	EscalatedDate      *time.Time
+	TimeoutAction      string
 }
 
 // NewApprovalRequest contains information needed to create a new approval request.
@@ -53,4 +74,9 @@ type NewApprovalRequest struct {
 	TimeoutHours    int
 	TaskToken       string
 	ApprovalMessage string
+
+	EscalateAfterHours int
+	EscalationTarget   string
+	EscalationRole     string
+	TimeoutAction      string
 }
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -13,6 +14,7 @@ import (
 	"github.com/timmaaaz/ichor/business/sdk/order"
 	"github.com/timmaaaz/ichor/business/sdk/page"
 	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb/dbarray"
 	"github.com/timmaaaz/ichor/foundation/logger"
 )
 
@@ -51,12 +53,14 @@ func (s *Store) Create(ctx context.Context, req approvalrequestbus.ApprovalReque
 	const q = `
 	INSERT INTO workflow.approval_requests (
 		approval_request_id, execution_id, rule_id, action_name,
-		approvers, approval_type, status, timeout_hours,
-		task_token, approval_message, created_date, scenario_id
+		approvers, approval_type, required_approvals, status, timeout_hours,
+		task_token, approval_message, created_date, scenario_id,
+		escalate_after_hours, escalation_target, escalation_role, timeout_action
 	) VALUES (
 		:approval_request_id, :execution_id, :rule_id, :action_name,
-		:approvers, :approval_type, :status, :timeout_hours,
-		:task_token, :approval_message, :created_date, :scenario_id
+		:approvers, :approval_type, :required_approvals, :status, :timeout_hours,
+		:task_token, :approval_message, :created_date, :scenario_id,
+		:escalate_after_hours, :escalation_target, :escalation_role, :timeout_action
 	)`
 
 	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBApprovalRequest(req)); err != nil {
@@ -75,9 +79,11 @@ func (s *Store) QueryByID(ctx context.Context, id uuid.UUID) (approvalrequestbus
 	const q = `
 	SELECT
 		ar.approval_request_id, ar.execution_id, ar.rule_id, ar.action_name,
-		ar.approvers, ar.approval_type, ar.status, ar.timeout_hours, ar.task_token,
+		ar.approvers, ar.approval_type, ar.required_approvals, ar.status, ar.timeout_hours, ar.task_token,
 		ar.approval_message, ar.resolved_by, ar.resolution_reason,
 		ar.created_date, ar.resolved_date, ar.scenario_id,
+		ar.escalate_after_hours, ar.escalation_target, ar.escalation_role,
+		ar.escalated_date, ar.timeout_action,
 		r.name AS rule_name
 	FROM workflow.approval_requests ar
 	LEFT JOIN workflow.automation_rules r ON ar.rule_id = r.id
@@ -119,9 +125,11 @@ func (s *Store) Resolve(ctx context.Context, id, resolvedBy uuid.UUID, status, r
 	WHERE approval_request_id = :id AND status = 'pending'
 	RETURNING
 		approval_request_id, execution_id, rule_id, action_name,
-		approvers, approval_type, status, timeout_hours, task_token,
+		approvers, approval_type, required_approvals, status, timeout_hours, task_token,
 		approval_message, resolved_by, resolution_reason,
-		created_date, resolved_date, scenario_id`
+		created_date, resolved_date, scenario_id,
+		escalate_after_hours, escalation_target, escalation_role,
+		escalated_date, timeout_action`
 
 	var dbReq dbApprovalRequest
 	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbReq); err != nil {
@@ -148,9 +156,11 @@ func (s *Store) Query(ctx context.Context, filter approvalrequestbus.QueryFilter
 	const q = `
 	SELECT
 		ar.approval_request_id, ar.execution_id, ar.rule_id, ar.action_name,
-		ar.approvers, ar.approval_type, ar.status, ar.timeout_hours, ar.task_token,
+		ar.approvers, ar.approval_type, ar.required_approvals, ar.status, ar.timeout_hours, ar.task_token,
 		ar.approval_message, ar.resolved_by, ar.resolution_reason,
 		ar.created_date, ar.resolved_date, ar.scenario_id,
+		ar.escalate_after_hours, ar.escalation_target, ar.escalation_role,
+		ar.escalated_date, ar.timeout_action,
 		r.name AS rule_name
 	FROM workflow.approval_requests ar
 	LEFT JOIN workflow.automation_rules r ON ar.rule_id = r.id
@@ -235,3 +245,203 @@ func (s *Store) IsApprover(ctx context.Context, approvalID, userID uuid.UUID) (b
 	}
 	return result.Exists, nil
 }
+
+// CreateVote records an approver's vote. The insert is conditional on the
+// request still being pending, so a vote can never land on a resolved request.
+// Returns ErrAlreadyVoted on a repeat vote (unique approver per request) and
+// ErrAlreadyResolved when the request is no longer pending.
+func (s *Store) CreateVote(ctx context.Context, vote approvalrequestbus.Vote) error {
+	const q = `
+	INSERT INTO workflow.approval_votes (
+		vote_id, approval_request_id, approver_id, decision, reason, voted_date
+	)
+	SELECT
+		:vote_id, :approval_request_id, :approver_id, :decision, :reason, :voted_date
+	WHERE EXISTS (
+		SELECT 1 FROM workflow.approval_requests
+		WHERE approval_request_id = :approval_request_id AND status = 'pending'
+	)`
+
+	n, err := sqldb.NamedExecContextWithCount(ctx, s.log, s.db, q, toDBVote(vote))
+	if err != nil {
+		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+			return approvalrequestbus.ErrAlreadyVoted
+		}
+		return fmt.Errorf("namedexeccontextwithcount: %w", err)
+	}
+
+	if n == 0 {
+		return approvalrequestbus.ErrAlreadyResolved
+	}
+
+	return nil
+}
+
+// QueryVotes returns the votes cast on an approval request, oldest first.
+func (s *Store) QueryVotes(ctx context.Context, approvalID uuid.UUID) ([]approvalrequestbus.Vote, error) {
+	data := struct {
+		ID string `db:"id"`
+	}{
+		ID: approvalID.String(),
+	}
+
+	const q = `
+	SELECT
+		vote_id, approval_request_id, approver_id, decision, reason, voted_date
+	FROM workflow.approval_votes
+	WHERE approval_request_id = :id
+	ORDER BY voted_date, vote_id`
+
+	var dbVotes []dbVote
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbVotes); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusVotes(dbVotes), nil
+}
+
+// pendingSelect is the column list and join shared by the escalation and
+// timeout sweeps. Callers append their WHERE predicate.
+const pendingSelect = `
+	SELECT
+		ar.approval_request_id, ar.execution_id, ar.rule_id, ar.action_name,
+		ar.approvers, ar.approval_type, ar.required_approvals, ar.status, ar.timeout_hours, ar.task_token,
+		ar.approval_message, ar.resolved_by, ar.resolution_reason,
+		ar.created_date, ar.resolved_date, ar.scenario_id,
+		ar.escalate_after_hours, ar.escalation_target, ar.escalation_role,
+		ar.escalated_date, ar.timeout_action,
+		r.name AS rule_name
+	FROM workflow.approval_requests ar
+	LEFT JOIN workflow.automation_rules r ON ar.rule_id = r.id`
+
+// QueryDueEscalations returns up to limit pending requests whose escalation
+// window (created_date + escalate_after_hours) has lapsed and that have not
+// been escalated yet, oldest first. Not scenario-scoped: the sweep is global.
+func (s *Store) QueryDueEscalations(ctx context.Context, now time.Time, limit int) ([]approvalrequestbus.ApprovalRequest, error) {
+	data := struct {
+		Now   time.Time `db:"now"`
+		Limit int       `db:"limit"`
+	}{
+		Now:   now,
+		Limit: limit,
+	}
+
+	const q = pendingSelect + `
+	WHERE ar.status = 'pending'
+	  AND ar.escalate_after_hours IS NOT NULL
+	  AND ar.escalated_date IS NULL
+	  AND ar.created_date + make_interval(hours => ar.escalate_after_hours) <= :now
+	ORDER BY ar.created_date ASC
+	LIMIT :limit`
+
+	var dbReqs []dbApprovalRequest
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbReqs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusApprovalRequests(dbReqs)
+}
+
+// QueryDueTimeouts returns up to limit requests needing timeout handling,
+// oldest first: pending requests past created_date + timeout_hours, and
+// timed-out requests whose task token was never cleared (the Temporal
+// completion failed and must be retried).
+func (s *Store) QueryDueTimeouts(ctx context.Context, now time.Time, limit int) ([]approvalrequestbus.ApprovalRequest, error) {
+	data := struct {
+		Now   time.Time `db:"now"`
+		Limit int       `db:"limit"`
+	}{
+		Now:   now,
+		Limit: limit,
+	}
+
+	const q = pendingSelect + `
+	WHERE (ar.status = 'pending' AND ar.created_date + make_interval(hours => ar.timeout_hours) <= :now)
+	   OR (ar.status = 'timed_out' AND ar.task_token <> '')
+	ORDER BY ar.created_date ASC
+	LIMIT :limit`
+
+	var dbReqs []dbApprovalRequest
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbReqs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusApprovalRequests(dbReqs)
+}
+
+// Escalate replaces the approver set of a pending, not-yet-escalated request
+// and stamps escalated_date. The conditional UPDATE makes escalation happen at
+// most once even with several replicas sweeping. Returns ErrAlreadyResolved if
+// zero rows updated.
+func (s *Store) Escalate(ctx context.Context, id uuid.UUID, approvers []uuid.UUID, escalatedDate time.Time) (approvalrequestbus.ApprovalRequest, error) {
+	ids := make(dbarray.String, len(approvers))
+	for i, a := range approvers {
+		ids[i] = a.String()
+	}
+
+	data := struct {
+		ID            string         `db:"id"`
+		Approvers     dbarray.String `db:"approvers"`
+		EscalatedDate time.Time      `db:"escalated_date"`
+	}{
+		ID:            id.String(),
+		Approvers:     ids,
+		EscalatedDate: escalatedDate,
+	}
+
+	const q = `
+	UPDATE workflow.approval_requests
+	SET approvers = :approvers, escalated_date = :escalated_date
+	WHERE approval_request_id = :id AND status = 'pending' AND escalated_date IS NULL
+	RETURNING
+		approval_request_id, execution_id, rule_id, action_name,
+		approvers, approval_type, required_approvals, status, timeout_hours, task_token,
+		approval_message, resolved_by, resolution_reason,
+		created_date, resolved_date, scenario_id,
+		escalate_after_hours, escalation_target, escalation_role,
+		escalated_date, timeout_action`
+
+	var dbReq dbApprovalRequest
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbReq); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return approvalrequestbus.ApprovalRequest{}, approvalrequestbus.ErrAlreadyResolved
+		}
+		return approvalrequestbus.ApprovalRequest{}, fmt.Errorf("escalate: %w", err)
+	}
+
+	return toBusApprovalRequest(dbReq)
+}
+
+// TimeOut atomically transitions a pending request to timed_out with no
+// resolver. Returns ErrAlreadyResolved if zero rows updated.
+func (s *Store) TimeOut(ctx context.Context, id uuid.UUID, reason string) (approvalrequestbus.ApprovalRequest, error) {
+	data := struct {
+		ID               string `db:"id"`
+		ResolutionReason string `db:"resolution_reason"`
+	}{
+		ID:               id.String(),
+		ResolutionReason: reason,
+	}
+
+	const q = `
+	UPDATE workflow.approval_requests
+	SET status = 'timed_out', resolution_reason = :resolution_reason, resolved_date = NOW()
+	WHERE approval_request_id = :id AND status = 'pending'
+	RETURNING
+		approval_request_id, execution_id, rule_id, action_name,
+		approvers, approval_type, required_approvals, status, timeout_hours, task_token,
+		approval_message, resolved_by, resolution_reason,
+		created_date, resolved_date, scenario_id,
+		escalate_after_hours, escalation_target, escalation_role,
+		escalated_date, timeout_action`
+
+	var dbReq dbApprovalRequest
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbReq); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return approvalrequestbus.ApprovalRequest{}, approvalrequestbus.ErrAlreadyResolved
+		}
+		return approvalrequestbus.ApprovalRequest{}, fmt.Errorf("timeout: %w", err)
+	}
+
+	return toBusApprovalRequest(dbReq)
+}
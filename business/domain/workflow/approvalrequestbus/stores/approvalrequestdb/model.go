@@ -18,6 +18,7 @@ type dbApprovalRequest struct {
 	ActionName       string         `db:"action_name"`
 	Approvers        dbarray.String `db:"approvers"`
 	ApprovalType     string         `db:"approval_type"`
+	Required         int            `db:"required_approvals"`
 	Status           string         `db:"status"`
 	TimeoutHours     int            `db:"timeout_hours"`
 	TaskToken        string         `db:"task_token"`
@@ -27,6 +28,12 @@ type dbApprovalRequest struct {
 	CreatedDate      time.Time      `db:"created_date"`
 	ResolvedDate     sql.NullTime   `db:"resolved_date"`
 	ScenarioID       *uuid.UUID     `db:"scenario_id"`
+
+	EscalateAfterHours sql.NullInt32  `db:"escalate_after_hours"`
+	EscalationTarget   sql.NullString `db:"escalation_target"`
+	EscalationRole     sql.NullString `db:"escalation_role"`
+	EscalatedDate      sql.NullTime   `db:"escalated_date"`
+	TimeoutAction      string         `db:"timeout_action"`
 }
 
 func toDBApprovalRequest(req approvalrequestbus.ApprovalRequest) dbApprovalRequest {
@@ -42,13 +49,24 @@ func toDBApprovalRequest(req approvalrequestbus.ApprovalRequest) dbApprovalReque
 		ActionName:   req.ActionName,
 		Approvers:    approvers,
 		ApprovalType: req.ApprovalType,
+		Required:     req.Required,
 		Status:       req.Status,
 		TimeoutHours: req.TimeoutHours,
 		TaskToken:    req.TaskToken,
 		CreatedDate:  req.CreatedDate,
 		ScenarioID:   req.ScenarioID,
+
+		TimeoutAction: req.TimeoutAction,
 	}
 
+	if req.EscalateAfterHours > 0 {
+		db.EscalateAfterHours = sql.NullInt32{Int32: int32(req.EscalateAfterHours), Valid: true}
+		db.EscalationTarget = sql.NullString{String: req.EscalationTarget, Valid: req.EscalationTarget != ""}
+		db.EscalationRole = sql.NullString{String: req.EscalationRole, Valid: req.EscalationRole != ""}
+	}
+	if req.EscalatedDate != nil {
+		db.EscalatedDate = sql.NullTime{Time: *req.EscalatedDate, Valid: true}
+	}
 	if req.ApprovalMessage != "" {
 		db.ApprovalMessage = sql.NullString{String: req.ApprovalMessage, Valid: true}
 	}
@@ -82,11 +100,21 @@ func toBusApprovalRequest(db dbApprovalRequest) (approvalrequestbus.ApprovalRequ
 		ActionName:   db.ActionName,
 		Approvers:    approvers,
 		ApprovalType: db.ApprovalType,
+		Required:     db.Required,
 		Status:       db.Status,
 		TimeoutHours: db.TimeoutHours,
 		TaskToken:    db.TaskToken,
 		CreatedDate:  db.CreatedDate,
 		ScenarioID:   db.ScenarioID,
+
+		EscalateAfterHours: int(db.EscalateAfterHours.Int32),
+		EscalationTarget:   db.EscalationTarget.String,
+		EscalationRole:     db.EscalationRole.String,
+		TimeoutAction:      db.TimeoutAction,
+	}
+
+	if db.EscalatedDate.Valid {
+		req.EscalatedDate = &db.EscalatedDate.Time
 	}
 
 	if db.RuleName.Valid {
@@ -124,3 +152,47 @@ func toBusApprovalRequests(dbs []dbApprovalRequest) ([]approvalrequestbus.Approv
 	}
 	return reqs, nil
 }
+
+type dbVote struct {
+	ID                uuid.UUID      `db:"vote_id"`
+	ApprovalRequestID uuid.UUID      `db:"approval_request_id"`
+	ApproverID        uuid.UUID      `db:"approver_id"`
+	Decision          string         `db:"decision"`
+	Reason            sql.NullString `db:"reason"`
+	VotedDate         time.Time      `db:"voted_date"`
+}
+
+func toDBVote(v approvalrequestbus.Vote) dbVote {
+	db := dbVote{
+		ID:                v.ID,
+		ApprovalRequestID: v.ApprovalRequestID,
+		ApproverID:        v.ApproverID,
+		Decision:          v.Decision,
+		VotedDate:         v.VotedDate,
+	}
+
+	if v.Reason != "" {
+		db.Reason = sql.NullString{String: v.Reason, Valid: true}
+	}
+
+	return db
+}
+
+func toBusVote(db dbVote) approvalrequestbus.Vote {
+	return approvalrequestbus.Vote{
+		ID:                db.ID,
+		ApprovalRequestID: db.ApprovalRequestID,
+		ApproverID:        db.ApproverID,
+		Decision:          db.Decision,
+		Reason:            db.Reason.String,
+		VotedDate:         db.VotedDate,
+	}
+}
+
+func toBusVotes(dbs []dbVote) []approvalrequestbus.Vote {
+	votes := make([]approvalrequestbus.Vote, len(dbs))
+	for i, db := range dbs {
+		votes[i] = toBusVote(db)
+	}
+	return votes
+}
@@ -0,0 +1,112 @@
+package approvalrequestbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Vote is one approver's decision on a multi-approver request. Each approver
+// votes at most once per request; the decision is StatusApproved or
+// StatusRejected.
+type Vote struct {
+	ID                uuid.UUID
+	ApprovalRequestID uuid.UUID
+	ApproverID        uuid.UUID
+	Decision          string
+	Reason            string
+	VotedDate         time.Time
+}
+
+// Tally is the quorum state of an approval request after counting its votes.
+// Outcome stays StatusPending until the quorum is met (StatusApproved) or can
+// no longer be met (StatusRejected).
+type Tally struct {
+	ApprovalType string
+	Required     int // approvals needed to approve, fixed at creation
+	Eligible     int // distinct approvers on the request
+	Approvals    int
+	Rejections   int
+	Remaining    int // eligible approvers who have not voted
+	Outcome      string
+}
+
+// RequiredApprovals is the number of approvals a request of the given type
+// needs from its approvers, counting each distinct approver once.
+//
+//	any      — one.
+//	all      — every approver.
+//	majority — more than half.
+func RequiredApprovals(approvalType string, approvers []uuid.UUID) int {
+	distinct := make(map[uuid.UUID]bool, len(approvers))
+	for _, id := range approvers {
+		distinct[id] = true
+	}
+
+	switch approvalType {
+	case ApprovalTypeAll:
+		return max(len(distinct), 1)
+	case ApprovalTypeMajority:
+		return len(distinct)/2 + 1
+	default:
+		return 1
+	}
+}
+
+// EvaluateQuorum counts votes against the request's approvers and approval
+// type. Votes from users who are not on the approver list are ignored, as are
+// repeat votes from the same approver (the first one counts).
+//
+// required is the approval count fixed when the request was created, so
+// escalation changing the approver list does not change the quorum. Zero
+// derives it from approvers with RequiredApprovals.
+//
+//	any      — the first vote decides, either way.
+//	all      — required approvals approve; a single rejection rejects.
+//	majority — required approvals approve; rejects once that is unreachable.
+func EvaluateQuorum(approvalType string, required int, approvers []uuid.UUID, votes []Vote) Tally {
+	eligible := make(map[uuid.UUID]bool, len(approvers))
+	for _, id := range approvers {
+		eligible[id] = true
+	}
+
+	t := Tally{
+		ApprovalType: approvalType,
+		Required:     required,
+		Eligible:     len(eligible),
+		Outcome:      StatusPending,
+	}
+
+	if t.Required <= 0 {
+		t.Required = RequiredApprovals(approvalType, approvers)
+	}
+
+	counted := make(map[uuid.UUID]bool, len(votes))
+	for _, v := range votes {
+		if !eligible[v.ApproverID] || counted[v.ApproverID] {
+			continue
+		}
+		counted[v.ApproverID] = true
+
+		switch v.Decision {
+		case StatusApproved:
+			t.Approvals++
+		case StatusRejected:
+			t.Rejections++
+		}
+	}
+	t.Remaining = t.Eligible - t.Approvals - t.Rejections
+
+	switch {
+	case t.Approvals >= t.Required:
+		t.Outcome = StatusApproved
+	case approvalType == ApprovalTypeAny || approvalType == "" || approvalType == ApprovalTypeAll:
+		if t.Rejections > 0 {
+			t.Outcome = StatusRejected
+		}
+	case t.Approvals+t.Remaining < t.Required:
+		t.Outcome = StatusRejected
+	}
+
+	return t
+}
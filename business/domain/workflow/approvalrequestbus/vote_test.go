@@ -0,0 +1,184 @@
+package approvalrequestbus_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/workflow/approvalrequestbus"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+func Test_EvaluateQuorum(t *testing.T) {
+	a, b, c, d, e := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	outsider := uuid.New()
+
+	approve := func(id uuid.UUID) approvalrequestbus.Vote {
+		return approvalrequestbus.Vote{ApproverID: id, Decision: approvalrequestbus.StatusApproved}
+	}
+	reject := func(id uuid.UUID) approvalrequestbus.Vote {
+		return approvalrequestbus.Vote{ApproverID: id, Decision: approvalrequestbus.StatusRejected}
+	}
+
+	tests := []struct {
+		name         string
+		approvalType string
+		approvers    []uuid.UUID
+		votes        []approvalrequestbus.Vote
+		wantOutcome  string
+		wantRequired int
+	}{
+		{"any: no votes", approvalrequestbus.ApprovalTypeAny, []uuid.UUID{a, b}, nil, approvalrequestbus.StatusPending, 1},
+		{"any: first approve decides", approvalrequestbus.ApprovalTypeAny, []uuid.UUID{a, b}, []approvalrequestbus.Vote{approve(a)}, approvalrequestbus.StatusApproved, 1},
+		{"any: first reject decides", approvalrequestbus.ApprovalTypeAny, []uuid.UUID{a, b}, []approvalrequestbus.Vote{reject(b)}, approvalrequestbus.StatusRejected, 1},
+
+		{"all: partial", approvalrequestbus.ApprovalTypeAll, []uuid.UUID{a, b}, []approvalrequestbus.Vote{approve(a)}, approvalrequestbus.StatusPending, 2},
+		{"all: everyone approved", approvalrequestbus.ApprovalTypeAll, []uuid.UUID{a, b}, []approvalrequestbus.Vote{approve(a), approve(b)}, approvalrequestbus.StatusApproved, 2},
+		{"all: one reject", approvalrequestbus.ApprovalTypeAll, []uuid.UUID{a, b}, []approvalrequestbus.Vote{approve(a), reject(b)}, approvalrequestbus.StatusRejected, 2},
+		{"all: duplicate approver counted once", approvalrequestbus.ApprovalTypeAll, []uuid.UUID{a, a, b}, []approvalrequestbus.Vote{approve(a)}, approvalrequestbus.StatusPending, 2},
+
+		{"majority: 2 of 5", approvalrequestbus.ApprovalTypeMajority, []uuid.UUID{a, b, c, d, e}, []approvalrequestbus.Vote{approve(a), approve(b)}, approvalrequestbus.StatusPending, 3},
+		{"majority: 3 of 5", approvalrequestbus.ApprovalTypeMajority, []uuid.UUID{a, b, c, d, e}, []approvalrequestbus.Vote{approve(a), reject(b), approve(c), approve(d)}, approvalrequestbus.StatusApproved, 3},
+		{"majority: unreachable", approvalrequestbus.ApprovalTypeMajority, []uuid.UUID{a, b, c, d, e}, []approvalrequestbus.Vote{reject(a), reject(b), reject(c)}, approvalrequestbus.StatusRejected, 3},
+		{"majority: even split needs more than half", approvalrequestbus.ApprovalTypeMajority, []uuid.UUID{a, b, c, d}, []approvalrequestbus.Vote{approve(a), approve(b), reject(c)}, approvalrequestbus.StatusPending, 3},
+		{"majority: outsider ignored", approvalrequestbus.ApprovalTypeMajority, []uuid.UUID{a, b, c}, []approvalrequestbus.Vote{approve(a), approve(outsider)}, approvalrequestbus.StatusPending, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := approvalrequestbus.EvaluateQuorum(tt.approvalType, 0, tt.approvers, tt.votes)
+			if got.Outcome != tt.wantOutcome {
+				t.Errorf("outcome = %q, want %q (tally %+v)", got.Outcome, tt.wantOutcome, got)
+			}
+			if got.Required != tt.wantRequired {
+				t.Errorf("required = %d, want %d", got.Required, tt.wantRequired)
+			}
+		})
+	}
+}
+
+func Test_EvaluateQuorum_FixedRequired(t *testing.T) {
+	a, b, m := uuid.New(), uuid.New(), uuid.New()
+	role := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+
+	approve := func(id uuid.UUID) approvalrequestbus.Vote {
+		return approvalrequestbus.Vote{ApproverID: id, Decision: approvalrequestbus.StatusApproved}
+	}
+	reject := func(id uuid.UUID) approvalrequestbus.Vote {
+		return approvalrequestbus.Vote{ApproverID: id, Decision: approvalrequestbus.StatusRejected}
+	}
+
+	escalatedToRole := append([]uuid.UUID{a}, role...)
+
+	tests := []struct {
+		name         string
+		approvalType string
+		required     int
+		approvers    []uuid.UUID
+		votes        []approvalrequestbus.Vote
+		wantOutcome  string
+	}{
+		{"all: role seats do not raise the quorum", approvalrequestbus.ApprovalTypeAll, 2, escalatedToRole, []approvalrequestbus.Vote{approve(a), approve(role[2])}, approvalrequestbus.StatusApproved},
+		{"all: one role member is not enough", approvalrequestbus.ApprovalTypeAll, 2, escalatedToRole, []approvalrequestbus.Vote{approve(role[0])}, approvalrequestbus.StatusPending},
+		{"all: a rejection still rejects", approvalrequestbus.ApprovalTypeAll, 2, escalatedToRole, []approvalrequestbus.Vote{approve(a), reject(role[1])}, approvalrequestbus.StatusRejected},
+		{"all: shared manager does not lower the quorum", approvalrequestbus.ApprovalTypeAll, 2, []uuid.UUID{m, b}, []approvalrequestbus.Vote{approve(m)}, approvalrequestbus.StatusPending},
+		{"all: shared manager and kept approver", approvalrequestbus.ApprovalTypeAll, 2, []uuid.UUID{m, b}, []approvalrequestbus.Vote{approve(m), approve(b)}, approvalrequestbus.StatusApproved},
+		{"majority: unreachable against the fixed count", approvalrequestbus.ApprovalTypeMajority, 3, []uuid.UUID{a, b, m}, []approvalrequestbus.Vote{reject(a)}, approvalrequestbus.StatusRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := approvalrequestbus.EvaluateQuorum(tt.approvalType, tt.required, tt.approvers, tt.votes)
+			if got.Outcome != tt.wantOutcome {
+				t.Errorf("outcome = %q, want %q (tally %+v)", got.Outcome, tt.wantOutcome, got)
+			}
+			if got.Required != tt.required {
+				t.Errorf("required = %d, want %d", got.Required, tt.required)
+			}
+		})
+	}
+}
+
+// =============================================================================
+
+// voteStorer keeps votes in memory and resolves like the real store: only a
+// pending request can be resolved.
+type voteStorer struct {
+	mockApprovalStorer
+	req   approvalrequestbus.ApprovalRequest
+	votes []approvalrequestbus.Vote
+}
+
+func (s *voteStorer) QueryByID(_ context.Context, _ uuid.UUID) (approvalrequestbus.ApprovalRequest, error) {
+	return s.req, nil
+}
+
+func (s *voteStorer) Resolve(_ context.Context, _, resolvedBy uuid.UUID, status, reason string) (approvalrequestbus.ApprovalRequest, error) {
+	if s.req.Status != approvalrequestbus.StatusPending {
+		return approvalrequestbus.ApprovalRequest{}, approvalrequestbus.ErrAlreadyResolved
+	}
+	s.req.Status = status
+	s.req.ResolvedBy = &resolvedBy
+	s.req.ResolutionReason = reason
+	return s.req, nil
+}
+
+func (s *voteStorer) CreateVote(_ context.Context, v approvalrequestbus.Vote) error {
+	for _, existing := range s.votes {
+		if existing.ApproverID == v.ApproverID {
+			return approvalrequestbus.ErrAlreadyVoted
+		}
+	}
+	s.votes = append(s.votes, v)
+	return nil
+}
+
+func (s *voteStorer) QueryVotes(_ context.Context, _ uuid.UUID) ([]approvalrequestbus.Vote, error) {
+	return s.votes, nil
+}
+
+func Test_CastVote_ResolvesOnQuorum(t *testing.T) {
+	log := logger.New(io.Discard, logger.LevelInfo, "TEST", func(context.Context) string { return "" })
+
+	first, second := uuid.New(), uuid.New()
+	storer := &voteStorer{req: approvalrequestbus.ApprovalRequest{
+		ID:           uuid.New(),
+		Approvers:    []uuid.UUID{first, second},
+		ApprovalType: approvalrequestbus.ApprovalTypeAll,
+		Status:       approvalrequestbus.StatusPending,
+	}}
+	bus := approvalrequestbus.NewBusiness(log, nil, storer)
+	ctx := context.Background()
+
+	req, tally, decided, err := bus.CastVote(ctx, storer.req.ID, first, approvalrequestbus.StatusApproved, "")
+	if err != nil {
+		t.Fatalf("first vote: %v", err)
+	}
+	if decided || req.Status != approvalrequestbus.StatusPending {
+		t.Fatalf("first of two 'all' votes must not resolve: decided=%v status=%s", decided, req.Status)
+	}
+	if tally.Approvals != 1 || tally.Remaining != 1 {
+		t.Fatalf("tally after first vote = %+v", tally)
+	}
+
+	if _, _, _, err := bus.CastVote(ctx, storer.req.ID, first, approvalrequestbus.StatusApproved, ""); !errors.Is(err, approvalrequestbus.ErrAlreadyVoted) {
+		t.Fatalf("repeat vote: err = %v, want ErrAlreadyVoted", err)
+	}
+
+	req, _, decided, err = bus.CastVote(ctx, storer.req.ID, second, approvalrequestbus.StatusApproved, "two-person sign-off")
+	if err != nil {
+		t.Fatalf("second vote: %v", err)
+	}
+	if !decided || req.Status != approvalrequestbus.StatusApproved {
+		t.Fatalf("second 'all' vote must resolve approved: decided=%v status=%s", decided, req.Status)
+	}
+	if req.ResolvedBy == nil || *req.ResolvedBy != second {
+		t.Fatalf("resolved_by = %v, want the deciding voter", req.ResolvedBy)
+	}
+
+	if _, _, _, err := bus.CastVote(ctx, storer.req.ID, uuid.New(), approvalrequestbus.StatusRejected, ""); !errors.Is(err, approvalrequestbus.ErrAlreadyResolved) {
+		t.Fatalf("vote after resolution: err = %v, want ErrAlreadyResolved", err)
+	}
+}
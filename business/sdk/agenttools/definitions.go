@@ -18,6 +18,7 @@ func schema(v any) json.RawMessage {
 // Source of truth: workflowsaveapp.SaveActionRequest validate:"oneof=..."
 var actionTypeEnum = []string{
 	"allocate_inventory",
+	"call_workflow",
 	"check_inventory",
 	"check_reorder_point",
 	"commit_allocation",
@@ -25,6 +26,7 @@ var actionTypeEnum = []string{
 	"create_entity",
 	"delay",
 	"evaluate_condition",
+	"for_each",
 	"log_audit_entry",
 	"lookup_entity",
 	"release_reservation",
@@ -149,6 +151,28 @@ var workflowPayloadSchema = map[string]any{
 			"type":        "object",
 			"description": "Optional canvas layout for the UI.",
 		},
+		"revision_notes": map[string]any{
+			"type":        "string",
+			"description": "Optional note stored on the revision this save records (max 1000 characters).",
+		},
+		"schedule": map[string]any{
+			"type":        "object",
+			"description": "Firing schedule. Required when trigger_type is 'scheduled', rejected otherwise. Set exactly one of cron_expression or interval_seconds.",
+			"properties": map[string]any{
+				"cron_expression": map[string]any{
+					"type":        "string",
+					"description": "Standard 5-field cron expression evaluated in 'timezone' (e.g. '0 2 * * *' = 02:00 daily). Descriptors like '@hourly' are accepted.",
+				},
+				"interval_seconds": map[string]any{
+					"type":        "integer",
+					"description": "Fixed interval between runs, in seconds (minimum 60).",
+				},
+				"timezone": map[string]any{
+					"type":        "string",
+					"description": "IANA timezone name (e.g. 'America/Chicago'). Defaults to UTC.",
+				},
+			},
+		},
 	},
 	"required": []string{"name", "is_active", "actions"},
 }
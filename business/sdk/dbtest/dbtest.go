@@ -29,6 +29,8 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/core/currencybus"
 	"github.com/timmaaaz/ichor/business/domain/core/currencybus/stores/currencycache"
 	"github.com/timmaaaz/ichor/business/domain/core/currencybus/stores/currencydb"
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus"
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus/stores/datascopedb"
 	"github.com/timmaaaz/ichor/business/domain/core/pagebus"
 	"github.com/timmaaaz/ichor/business/domain/core/pagebus/stores/pagedb"
 	"github.com/timmaaaz/ichor/business/domain/core/paymenttermbus"
@@ -52,10 +54,14 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/hr/commentbus"
 	"github.com/timmaaaz/ichor/business/domain/hr/commentbus/stores/commentdb"
 	"github.com/timmaaaz/ichor/business/domain/introspectionbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus/stores/countplandb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountitembus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountitembus/stores/cyclecountitemdb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountsessionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountsessionbus/stores/cyclecountsessiondb"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus/stores/demandforecastdb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inspectionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inspectionbus/stores/inspectiondb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryadjustmentbus"
@@ -64,6 +70,8 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus/stores/inventoryitemdb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus/stores/inventorylocationdb"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryreservationbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryreservationbus/stores/inventoryreservationdb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus/stores/inventorytransactiondb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/lotlocationbus"
@@ -72,8 +80,12 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/inventory/lottrackingsbus/stores/lottrackingsdb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/picktaskbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/picktaskbus/stores/picktaskdb"
+	"github.com/timmaaaz/ichor/business/domain/inventory/pickwavebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/pickwavebus/stores/pickwavedb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/putawaytaskbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/putawaytaskbus/stores/putawaytaskdb"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus/stores/replenishmenttaskdb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/serialnumberbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/serialnumberbus/stores/serialnumberdb"
 	"github.com/timmaaaz/ichor/business/domain/inventory/transferorderbus"
@@ -84,6 +96,8 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/inventory/zonebus/stores/zonedb"
 	"github.com/timmaaaz/ichor/business/domain/labels/labelbus"
 	"github.com/timmaaaz/ichor/business/domain/labels/labelbus/stores/labeldb"
+	"github.com/timmaaaz/ichor/business/domain/procurement/landedcostbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/landedcostbus/stores/landedcostdb"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus/stores/purchaseorderdb"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitembus"
@@ -92,8 +106,12 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitemstatusbus/stores/purchaseorderlineitemstatusdb"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderstatusbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderstatusbus/stores/purchaseorderstatusdb"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus/stores/purchasesuggestiondb"
 	"github.com/timmaaaz/ichor/business/domain/procurement/supplierbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/supplierbus/stores/supplierdb"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus/stores/supplierinvoicedb"
 	"github.com/timmaaaz/ichor/business/domain/procurement/supplierproductbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/supplierproductbus/stores/supplierproductdb"
 	"github.com/timmaaaz/ichor/business/domain/products/brandbus"
@@ -118,6 +136,8 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/sales/orderlineitemsbus/stores/orderlineitemsdb"
 	"github.com/timmaaaz/ichor/business/domain/sales/ordersbus"
 	"github.com/timmaaaz/ichor/business/domain/sales/ordersbus/stores/ordersdb"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus/stores/shipmentdb"
 	"github.com/timmaaaz/ichor/business/domain/scenarios/scenariobus"
 	"github.com/timmaaaz/ichor/business/domain/scenarios/scenariobus/stores/scenariodb"
 	"github.com/timmaaaz/ichor/business/domain/workflow/actionpermissionsbus"
@@ -260,6 +280,7 @@ type BusDomain struct {
 	RolePage    *rolepagebus.Business
 	UserRole    *userrolebus.Business
 	TableAccess *tableaccessbus.Business
+	DataScope   *datascopebus.Business
 	Permissions *permissionsbus.Business
 
 	// Introspection
@@ -278,6 +299,9 @@ type BusDomain struct {
 	PurchaseOrderLineItemStatus *purchaseorderlineitemstatusbus.Business
 	PurchaseOrder               *purchaseorderbus.Business
 	PurchaseOrderLineItem       *purchaseorderlineitembus.Business
+	PurchaseSuggestion          *purchasesuggestionbus.Business
+	SupplierInvoice             *supplierinvoicebus.Business
+	LandedCost                  *landedcostbus.Business
 
 	// Quality
 	Metrics    *metricsbus.Business
@@ -291,11 +315,16 @@ type BusDomain struct {
 	// Movement
 	InventoryTransaction *inventorytransactionbus.Business
 	InventoryAdjustment  *inventoryadjustmentbus.Business
+	InventoryReservation *inventoryreservationbus.Business
 	TransferOrder        *transferorderbus.Business
 	PutAwayTask          *putawaytaskbus.Business
 	PickTask             *picktaskbus.Business
 	CycleCountSession    *cyclecountsessionbus.Business
 	CycleCountItem       *cyclecountitembus.Business
+	CountPlan            *countplanbus.Business
+	PickWave             *pickwavebus.Business
+	ReplenishmentTask    *replenishmenttaskbus.Business
+	DemandForecast       *demandforecastbus.Business
 
 	// Labels
 	Label *labelbus.Business
@@ -308,6 +337,7 @@ type BusDomain struct {
 	LineItemFulfillmentStatus *lineitemfulfillmentstatusbus.Business
 	Order                     *ordersbus.Business
 	OrderLineItem             *orderlineitemsbus.Business
+	Shipment                  *shipmentbus.Business
 
 	// Workflow
 	Workflow          *workflow.Business
@@ -397,8 +427,9 @@ func newBusDomains(log *logger.Logger, db *sqlx.DB) BusDomain {
 	pageBus := pagebus.NewBusiness(log, delegate, pagedb.NewStore(log, db)).WithOutbox(outboxWriter)
 	rolePageBus := rolepagebus.NewBusiness(log, delegate, rolepagedb.NewStore(log, db)).WithOutbox(outboxWriter)
 	userRoleBus := userrolebus.NewBusiness(log, delegate, userrolecache.NewStore(log, userroledb.NewStore(log, db), 60*time.Minute)).WithOutbox(outboxWriter)
+	dataScopeBus := datascopebus.NewBusiness(log, delegate, datascopedb.NewStore(log, db)).WithOutbox(outboxWriter)
 	tableAccessBus := tableaccessbus.NewBusiness(log, delegate, tableaccesscache.NewStore(log, tableaccessdb.NewStore(log, db), 60*time.Minute)).WithOutbox(outboxWriter)
-	permissionsBus := permissionsbus.NewBusiness(log, delegate, permissionscache.NewStore(log, permissionsdb.NewStore(log, db), 60*time.Minute), userRoleBus, tableAccessBus, roleBus)
+	permissionsBus := permissionsbus.NewBusiness(log, delegate, permissionscache.NewStore(log, permissionsdb.NewStore(log, db), 60*time.Minute), userRoleBus, tableAccessBus, roleBus).WithDataScopes(dataScopeBus)
 
 	// Introspection
 	introspectionBus := introspectionbus.NewBusiness(log, db)
@@ -416,6 +447,9 @@ func newBusDomains(log *logger.Logger, db *sqlx.DB) BusDomain {
 	purchaseOrderLineItemStatusBus := purchaseorderlineitemstatusbus.NewBusiness(log, delegate, purchaseorderlineitemstatusdb.NewStore(log, db)).WithOutbox(outboxWriter)
 	purchaseOrderBus := purchaseorderbus.NewBusiness(log, delegate, purchaseorderdb.NewStore(log, db)).WithOutbox(outboxWriter)
 	purchaseOrderLineItemBus := purchaseorderlineitembus.NewBusiness(log, delegate, purchaseorderlineitemdb.NewStore(log, db)).WithOutbox(outboxWriter)
+	purchaseSuggestionBus := purchasesuggestionbus.NewBusiness(log, delegate, purchasesuggestiondb.NewStore(log, db), purchaseOrderBus, purchaseOrderLineItemBus).WithOutbox(outboxWriter)
+	supplierInvoiceBus := supplierinvoicebus.NewBusiness(log, delegate, supplierinvoicedb.NewStore(log, db), purchaseOrderBus).WithOutbox(outboxWriter)
+	landedCostBus := landedcostbus.NewBusiness(log, delegate, landedcostdb.NewStore(log, db), purchaseOrderBus, productCostBus, costHistoryBus).WithOutbox(outboxWriter)
 
 	// Quality
 	metricsBus := metricsbus.NewBusiness(log, delegate, metricsdb.NewStore(log, db)).WithOutbox(outboxWriter)
@@ -429,11 +463,16 @@ func newBusDomains(log *logger.Logger, db *sqlx.DB) BusDomain {
 	// Movement
 	inventoryTransactionBus := inventorytransactionbus.NewBusiness(log, delegate, inventorytransactiondb.NewStore(log, db)).WithOutbox(outboxWriter)
 	inventoryAdjustmentBus := inventoryadjustmentbus.NewBusiness(log, delegate, inventoryadjustmentdb.NewStore(log, db)).WithOutbox(outboxWriter)
+	inventoryReservationBus := inventoryreservationbus.NewBusiness(log, delegate, inventoryreservationdb.NewStore(log, db)).WithOutbox(outboxWriter)
 	transferOrderBus := transferorderbus.NewBusiness(log, delegate, transferorderdb.NewStore(log, db)).WithOutbox(outboxWriter)
 	putAwayTaskBus := putawaytaskbus.NewBusiness(log, delegate, putawaytaskdb.NewStore(log, db)).WithOutbox(outboxWriter)
 	pickTaskBus := picktaskbus.NewBusiness(log, delegate, picktaskdb.NewStore(log, db)).WithOutbox(outboxWriter)
 	cycleCountSessionBus := cyclecountsessionbus.NewBusiness(log, delegate, cyclecountsessiondb.NewStore(log, db)).WithOutbox(outboxWriter)
 	cycleCountItemBus := cyclecountitembus.NewBusiness(log, delegate, cyclecountitemdb.NewStore(log, db)).WithOutbox(outboxWriter)
+	countPlanBus := countplanbus.NewBusiness(log, delegate, countplandb.NewStore(log, db), cycleCountSessionBus, cycleCountItemBus).WithOutbox(outboxWriter)
+	pickWaveBus := pickwavebus.NewBusiness(log, delegate, pickwavedb.NewStore(log, db), pickTaskBus).WithOutbox(outboxWriter)
+	replenishmentTaskBus := replenishmenttaskbus.NewBusiness(log, delegate, replenishmenttaskdb.NewStore(log, db), inventoryItemBus, inventoryTransactionBus, lotLocationBus).WithOutbox(outboxWriter)
+	demandForecastBus := demandforecastbus.NewBusiness(log, delegate, demandforecastdb.NewStore(log, db), inventoryItemBus).WithOutbox(outboxWriter)
 
 	// Labels — printer is nil at the BusDomain layer; tests that exercise
 	// printing inject a recording printer through the API stack via
@@ -450,6 +489,7 @@ func newBusDomains(log *logger.Logger, db *sqlx.DB) BusDomain {
 	lineItemFulfillmentStatusBus := lineitemfulfillmentstatusbus.NewBusiness(log, delegate, lineitemfulfillmentstatusdb.NewStore(log, db)).WithOutbox(outboxWriter)
 	ordersBus := ordersbus.NewBusiness(log, delegate, ordersdb.NewStore(log, db)).WithOutbox(outboxWriter)
 	orderLineItemsBus := orderlineitemsbus.NewBusiness(log, delegate, orderlineitemsdb.NewStore(log, db)).WithOutbox(outboxWriter)
+	shipmentBus := shipmentbus.NewBusiness(log, delegate, shipmentdb.NewStore(log, db)).WithOutbox(outboxWriter)
 
 	// Workflow
 	workflowBus := workflow.NewBusiness(log, delegate, workflowdb.NewStore(log, db)).WithOutboxEmitter(outboxWriter.Emit)
@@ -505,6 +545,7 @@ func newBusDomains(log *logger.Logger, db *sqlx.DB) BusDomain {
 		UserRole:                    userRoleBus,
 		ProductCategory:             productCategoryBus,
 		TableAccess:                 tableAccessBus,
+		DataScope:                   dataScopeBus,
 		Permissions:                 permissionsBus,
 		Introspection:               introspectionBus,
 		Product:                     productBus,
@@ -518,6 +559,9 @@ func newBusDomains(log *logger.Logger, db *sqlx.DB) BusDomain {
 		PurchaseOrderLineItemStatus: purchaseOrderLineItemStatusBus,
 		PurchaseOrder:               purchaseOrderBus,
 		PurchaseOrderLineItem:       purchaseOrderLineItemBus,
+		PurchaseSuggestion:          purchaseSuggestionBus,
+		SupplierInvoice:             supplierInvoiceBus,
+		LandedCost:                  landedCostBus,
 		Metrics:                     metricsBus,
 		LotTrackings:                lotTrackingsBus,
 		LotLocation:                 lotLocationBus,
@@ -528,17 +572,23 @@ func newBusDomains(log *logger.Logger, db *sqlx.DB) BusDomain {
 		SerialNumber:                serialNumberBus,
 		InventoryTransaction:        inventoryTransactionBus,
 		InventoryAdjustment:         inventoryAdjustmentBus,
+		InventoryReservation:        inventoryReservationBus,
 		TransferOrder:               transferOrderBus,
 		PutAwayTask:                 putAwayTaskBus,
 		PickTask:                    pickTaskBus,
 		CycleCountSession:           cycleCountSessionBus,
 		CycleCountItem:              cycleCountItemBus,
+		CountPlan:                   countPlanBus,
+		PickWave:                    pickWaveBus,
+		ReplenishmentTask:           replenishmentTaskBus,
+		DemandForecast:              demandForecastBus,
 		Label:                       labelBus,
 		Scenario:                    scenarioBus,
 		OrderFulfillmentStatus:      orderFulfillmentStatusBus,
 		LineItemFulfillmentStatus:   lineItemFulfillmentStatusBus,
 		Order:                       ordersBus,
 		OrderLineItem:               orderLineItemsBus,
+		Shipment:                    shipmentBus,
 		Workflow:                    workflowBus,
 		Alert:                       alertBus,
 		ActionPermissions:           actionPermissionsBus,
@@ -160,6 +160,7 @@ func resolveTargetTable(suffix string) string {
 		"inventory_transactions":     "inventory.inventory_transactions",
 		"inventory_adjustments":      "inventory.inventory_adjustments",
 		"inventory_items":            "inventory.inventory_items",
+		"inventory_reservations":     "inventory.inventory_reservations",
 		"lot_trackings":              "inventory.lot_trackings",
 		"lot_locations":              "inventory.lot_locations",
 		"serial_numbers":             "inventory.serial_numbers",
@@ -189,6 +189,30 @@ func seedWorkflow(ctx context.Context, log *logger.Logger, busDomain BusDomain,
 			log.Error(ctx, "Failed to create delay template", "error", err)
 		}
 
+		_, err = busDomain.Workflow.CreateActionTemplate(ctx, workflow.NewActionTemplate{
+			Name:          "For Each",
+			Description:   "Run a branch once for each item in a list",
+			ActionType:    "for_each",
+			Icon:          "material-symbols:repeat",
+			DefaultConfig: json.RawMessage(`{"items": "{{line_items}}", "mode": "sequential"}`),
+			CreatedBy:     adminID,
+		})
+		if err != nil {
+			log.Error(ctx, "Failed to create for_each template", "error", err)
+		}
+
+		_, err = busDomain.Workflow.CreateActionTemplate(ctx, workflow.NewActionTemplate{
+			Name:          "Call Workflow",
+			Description:   "Run another rule's actions as a sub-workflow",
+			ActionType:    "call_workflow",
+			Icon:          "material-symbols:account-tree",
+			DefaultConfig: json.RawMessage(`{"rule_id": "", "inputs": {}, "outputs": {}}`),
+			CreatedBy:     adminID,
+		})
+		if err != nil {
+			log.Error(ctx, "Failed to create call_workflow template", "error", err)
+		}
+
 		_, err = busDomain.Workflow.CreateActionTemplate(ctx, workflow.NewActionTemplate{
 			Name:          "Evaluate Condition",
 			Description:   "Evaluates conditions and determines branch direction",
@@ -309,6 +333,30 @@ func seedWorkflow(ctx context.Context, log *logger.Logger, busDomain BusDomain,
 			log.Error(ctx, "Failed to create create_put_away_task template", "error", err)
 		}
 
+		approveSupplierInvoiceTemplate, err := busDomain.Workflow.CreateActionTemplate(ctx, workflow.NewActionTemplate{
+			Name:          "Approve Supplier Invoice",
+			Description:   "Approves a supplier invoice pending approval once its approval request is approved",
+			ActionType:    "approve_supplier_invoice",
+			Icon:          "material-symbols:order-approve",
+			DefaultConfig: json.RawMessage(`{}`),
+			CreatedBy:     adminID,
+		})
+		if err != nil {
+			log.Error(ctx, "Failed to create approve_supplier_invoice template", "error", err)
+		}
+
+		rejectSupplierInvoiceTemplate, err := busDomain.Workflow.CreateActionTemplate(ctx, workflow.NewActionTemplate{
+			Name:          "Reject Supplier Invoice",
+			Description:   "Rejects a supplier invoice once its approval request is rejected or timed out",
+			ActionType:    "reject_supplier_invoice",
+			Icon:          "material-symbols:block",
+			DefaultConfig: json.RawMessage(`{}`),
+			CreatedBy:     adminID,
+		})
+		if err != nil {
+			log.Error(ctx, "Failed to create reject_supplier_invoice template", "error", err)
+		}
+
 		// Create automation rules if we have all the required references
 		if orderLineItemsEntity.ID != uuid.Nil && wfEntityType.ID != uuid.Nil && onCreateTrigger.ID != uuid.Nil {
 			// Rule 1: Line Item Created -> Allocate Inventory
@@ -1583,6 +1631,140 @@ func seedWorkflow(ctx context.Context, log *logger.Logger, busDomain BusDomain,
 				}
 			}
 		}
+
+		// --- Default Workflow 10: Supplier Invoice Variance - Seek Approval ---
+		// Graph: start -> seek_approval
+		//          ├── [approved]  -> approve_supplier_invoice
+		//          ├── [rejected]  -> reject_supplier_invoice
+		//          └── [timed_out] -> reject_supplier_invoice
+		supplierInvoicesEntity, err := busDomain.Workflow.QueryEntityByName(ctx, "supplier_invoices")
+		if err != nil {
+			log.Error(ctx, "Failed to query supplier_invoices entity for invoice variance rule", "error", err)
+		}
+		if supplierInvoicesEntity.ID != uuid.Nil && putAwayOnUpdateTrigger.ID != uuid.Nil && seekApprovalTemplate.ID != uuid.Nil &&
+			approveSupplierInvoiceTemplate.ID != uuid.Nil && rejectSupplierInvoiceTemplate.ID != uuid.Nil {
+
+			pendingCondition := map[string]interface{}{
+				"field_conditions": []map[string]interface{}{
+					{
+						"field_name": "status",
+						"operator":   "changed_to",
+						"value":      "pending_approval",
+					},
+				},
+			}
+			pendingConditionJSON, _ := json.Marshal(pendingCondition)
+			pendingConditionRaw := json.RawMessage(pendingConditionJSON)
+
+			varianceRule, err := busDomain.Workflow.CreateRule(ctx, workflow.NewAutomationRule{
+				Name:              "Supplier Invoice Variance - Seek Approval",
+				Description:       "When a supplier invoice does not match its purchase order, seek approval before approving or rejecting it",
+				EntityID:          supplierInvoicesEntity.ID,
+				EntityTypeID:      wfEntityType.ID,
+				TriggerTypeID:     putAwayOnUpdateTrigger.ID,
+				TriggerConditions: &pendingConditionRaw,
+				IsActive:          true,
+				IsDefault:         true,
+				CreatedBy:         adminID,
+			})
+			if err != nil {
+				log.Error(ctx, "Failed to create invoice variance rule", "error", err)
+			} else {
+				seekCfg := map[string]interface{}{
+					"approvers":        []string{"5cf37266-3473-4006-984f-9325122678b7"}, // Admin Gopher
+					"approval_type":    "any",
+					"timeout_hours":    72,
+					"approval_message": "A supplier invoice does not match its purchase order. Approve it for payment or reject it.",
+				}
+				seekCfgJSON, _ := json.Marshal(seekCfg)
+
+				seekAction, err := busDomain.Workflow.CreateRuleAction(ctx, workflow.NewRuleAction{
+					AutomationRuleID: varianceRule.ID,
+					Name:             "Invoice Variance Approval",
+					Description:      "Hold the invoice for a human approve/reject decision",
+					ActionConfig:     json.RawMessage(seekCfgJSON),
+					IsActive:         true,
+					TemplateID:       &seekApprovalTemplate.ID,
+				})
+				if err != nil {
+					log.Error(ctx, "Failed to create seek approval action for invoice variance rule", "error", err)
+				}
+
+				approveCfg := map[string]interface{}{
+					"supplier_invoice_id": "{{entity_id}}",
+					"approval_request_id": "{{Invoice Variance Approval.approval_id}}",
+					"approval_reason":     "Invoice variance approved",
+				}
+				approveCfgJSON, _ := json.Marshal(approveCfg)
+
+				approveAction, err := busDomain.Workflow.CreateRuleAction(ctx, workflow.NewRuleAction{
+					AutomationRuleID: varianceRule.ID,
+					Name:             "Approve Supplier Invoice",
+					Description:      "Approve the invoice for payment once the approval request is approved",
+					ActionConfig:     json.RawMessage(approveCfgJSON),
+					IsActive:         true,
+					TemplateID:       &approveSupplierInvoiceTemplate.ID,
+				})
+				if err != nil {
+					log.Error(ctx, "Failed to create approve action for invoice variance rule", "error", err)
+				}
+
+				rejectCfg := map[string]interface{}{
+					"supplier_invoice_id": "{{entity_id}}",
+					"approval_request_id": "{{Invoice Variance Approval.approval_id}}",
+					"rejection_reason":    "Invoice variance was not approved",
+				}
+				rejectCfgJSON, _ := json.Marshal(rejectCfg)
+
+				rejectAction, err := busDomain.Workflow.CreateRuleAction(ctx, workflow.NewRuleAction{
+					AutomationRuleID: varianceRule.ID,
+					Name:             "Reject Supplier Invoice",
+					Description:      "Reject the invoice once the approval request is rejected or times out",
+					ActionConfig:     json.RawMessage(rejectCfgJSON),
+					IsActive:         true,
+					TemplateID:       &rejectSupplierInvoiceTemplate.ID,
+				})
+				if err != nil {
+					log.Error(ctx, "Failed to create reject action for invoice variance rule", "error", err)
+				}
+
+				if seekAction.ID != uuid.Nil && approveAction.ID != uuid.Nil && rejectAction.ID != uuid.Nil {
+					_, err = busDomain.Workflow.CreateActionEdge(ctx, workflow.NewActionEdge{
+						RuleID:         varianceRule.ID,
+						SourceActionID: nil,
+						TargetActionID: seekAction.ID,
+						EdgeType:       "start",
+						EdgeOrder:      0,
+					})
+					if err != nil {
+						log.Error(ctx, "Failed to create start edge for invoice variance rule", "error", err)
+					}
+
+					for i, e := range []struct {
+						output string
+						target uuid.UUID
+					}{
+						{"approved", approveAction.ID},
+						{"rejected", rejectAction.ID},
+						{"timed_out", rejectAction.ID},
+					} {
+						output := e.output
+						_, err = busDomain.Workflow.CreateActionEdge(ctx, workflow.NewActionEdge{
+							RuleID:         varianceRule.ID,
+							SourceActionID: &seekAction.ID,
+							TargetActionID: e.target,
+							EdgeType:       "sequence",
+							SourceOutput:   &output,
+							EdgeOrder:      i,
+						})
+						if err != nil {
+							log.Error(ctx, "Failed to create output edge for invoice variance rule", "output", output, "error", err)
+						}
+					}
+					log.Info(ctx, "Created 'Supplier Invoice Variance - Seek Approval' default workflow")
+				}
+			}
+		}
 	}
 
 	log.Info(ctx, "Workflow automation rules seeding complete")
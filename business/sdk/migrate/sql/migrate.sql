@@ -2732,3 +2732,851 @@ CREATE INDEX idx_automation_executions_stale
 -- don't linger as orphans (the table_name admin view reads core.table_access).
 DELETE FROM core.table_access WHERE table_name = 'workflow.notifications';
 DROP TABLE IF EXISTS workflow.notifications;
+
+-- Version: 2.45
+-- Description: Firing schedules for "scheduled" trigger-type automation rules. One row per rule;
+--   exactly one of cron_expression (standard 5-field, evaluated in timezone) or interval_seconds.
+--   timezone references geography.timezones by IANA name (NULL = UTC). next_run_at is the
+--   compare-and-set key the server-side scheduler advances after dispatching each occurrence.
+CREATE TABLE workflow.rule_schedules (
+    id                UUID        PRIMARY KEY,
+    rule_id           UUID        NOT NULL UNIQUE REFERENCES workflow.automation_rules(id) ON DELETE CASCADE,
+    cron_expression   TEXT        NULL,
+    interval_seconds  INTEGER     NULL CHECK (interval_seconds >= 60),
+    timezone          TEXT        NULL REFERENCES geography.timezones(name),
+    next_run_at       TIMESTAMPTZ NOT NULL,
+    last_run_at       TIMESTAMPTZ NULL,
+    created_date      TIMESTAMP   NOT NULL DEFAULT NOW(),
+    updated_date      TIMESTAMP   NOT NULL DEFAULT NOW(),
+    CONSTRAINT rule_schedules_one_spec CHECK ((cron_expression IS NULL) <> (interval_seconds IS NULL))
+);
+CREATE INDEX idx_rule_schedules_next_run_at ON workflow.rule_schedules (next_run_at);
+
+-- Version: 2.46
+-- Description: Per-approver votes for multi-approver seek_approval requests ("all" / "majority").
+--   One row per (request, approver); the request resolves once the quorum is met or becomes
+--   unreachable. "any" requests also record their single deciding vote.
+CREATE TABLE workflow.approval_votes (
+    vote_id             UUID        PRIMARY KEY,
+    approval_request_id UUID        NOT NULL REFERENCES workflow.approval_requests(approval_request_id) ON DELETE CASCADE,
+    approver_id         UUID        NOT NULL REFERENCES core.users(id),
+    decision            VARCHAR(20) NOT NULL CHECK (decision IN ('approved', 'rejected')),
+    reason              TEXT        NULL,
+    voted_date          TIMESTAMP   NOT NULL DEFAULT NOW(),
+    CONSTRAINT approval_votes_one_per_approver UNIQUE (approval_request_id, approver_id)
+);
+
+-- Version: 2.47
+-- Description: Escalation and timeout policy for seek_approval requests. After escalate_after_hours
+--   a still-pending request is reassigned once (escalated_date records when) to each approver's
+--   manager (hr.reports_to) or to the members of escalation_role. At timeout_hours the request is
+--   auto-resolved per timeout_action and the workflow continues through the "timed_out" port.
+ALTER TABLE workflow.approval_requests
+    ADD COLUMN escalate_after_hours INT         NULL CHECK (escalate_after_hours > 0),
+    ADD COLUMN escalation_target    VARCHAR(20) NULL CHECK (escalation_target IN ('manager', 'role')),
+    ADD COLUMN escalation_role      TEXT        NULL,
+    ADD COLUMN escalated_date       TIMESTAMP   NULL,
+    ADD COLUMN timeout_action       VARCHAR(20) NOT NULL DEFAULT 'reject' CHECK (timeout_action IN ('approve', 'reject'));
+CREATE INDEX idx_approval_requests_pending_created
+    ON workflow.approval_requests (created_date)
+    WHERE status = 'pending';
+
+-- Version: 2.48
+-- Description: Persist inventory reservations with their expiry and reference. reserve_inventory and
+--   allocate_inventory (reserve mode) record one active row per inventory item they hold stock on;
+--   commit_allocation / release_reservation consume active rows, and the reservation reaper releases
+--   rows whose expires_at has passed (status expired) and returns the quantity to available stock.
+CREATE TABLE inventory.inventory_reservations (
+    id                 UUID         NOT NULL,
+    inventory_item_id  UUID         NOT NULL REFERENCES inventory.inventory_items(id),
+    product_id         UUID         NOT NULL REFERENCES products.products(id),
+    location_id        UUID         NOT NULL REFERENCES inventory.inventory_locations(id),
+    quantity           INT          NOT NULL CHECK (quantity > 0),
+    status             VARCHAR(20)  NOT NULL DEFAULT 'active'
+                           CHECK (status IN ('active', 'released', 'committed', 'expired')),
+    source             VARCHAR(50)  NOT NULL,
+    reference_id       VARCHAR(100) NULL,
+    reference_type     VARCHAR(50)  NULL,
+    idempotency_key    VARCHAR(255) NULL,
+    expires_at         TIMESTAMP    NOT NULL,
+    released_date      TIMESTAMP    NULL,
+    created_by         UUID         NULL REFERENCES core.users(id),
+    created_date       TIMESTAMP    NOT NULL,
+    updated_date       TIMESTAMP    NOT NULL,
+    scenario_id        UUID         NULL REFERENCES inventory.scenarios(id) ON DELETE SET NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_inventory_reservations_active_expiry ON inventory.inventory_reservations(expires_at) WHERE status = 'active';
+CREATE INDEX idx_inventory_reservations_active_item ON inventory.inventory_reservations(inventory_item_id) WHERE status = 'active';
+CREATE INDEX idx_inventory_reservations_reference ON inventory.inventory_reservations(reference_type, reference_id);
+CREATE INDEX idx_inventory_reservations_scenario ON inventory.inventory_reservations(scenario_id);
+
+INSERT INTO core.table_access (id, role_id, table_name, can_create, can_read, can_update, can_delete)
+SELECT gen_random_uuid(), id, 'inventory.inventory_reservations', true, true, true, true FROM core.roles;
+
+-- Version: 2.49
+-- Description: Column-level table access. hidden_columns are stripped from every read of the
+--   table (CRUD responses and table builder queries) and may not be written; read_only_columns
+--   are returned but may not be written. Across a user's roles a column stays restricted only
+--   if every role restricts it.
+ALTER TABLE core.table_access
+    ADD COLUMN hidden_columns    TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN read_only_columns TEXT[] NOT NULL DEFAULT '{}';
+
+-- Version: 2.50
+-- Description: Row-level data scoping. A scope limits the warehouses and zones a role, or a single
+--   user, can reach; an empty list leaves that dimension open. A user's own scope replaces their
+--   roles' scopes, otherwise roles combine by union and a role without a scope is unrestricted.
+--   Enforced on reads of location-bound inventory, pick, put-away, transfer and cycle count rows.
+CREATE TABLE core.data_scopes (
+    id             UUID        NOT NULL,
+    role_id        UUID        NULL REFERENCES core.roles(id) ON DELETE CASCADE,
+    user_id        UUID        NULL REFERENCES core.users(id) ON DELETE CASCADE,
+    warehouse_ids  UUID[]      NOT NULL DEFAULT '{}',
+    zone_ids       UUID[]      NOT NULL DEFAULT '{}',
+    created_date   TIMESTAMP   NOT NULL,
+    updated_date   TIMESTAMP   NOT NULL,
+    PRIMARY KEY (id),
+    CHECK ((role_id IS NULL) <> (user_id IS NULL)),
+    UNIQUE (role_id),
+    UNIQUE (user_id)
+);
+
+INSERT INTO core.table_access (id, role_id, table_name, can_create, can_read, can_update, can_delete)
+SELECT gen_random_uuid(), id, 'core.data_scopes', true, true, true, true FROM core.roles WHERE name = 'ZZZADMIN';
+
+-- Version: 2.51
+-- Description: Shipments. A shipment is a consignment of one order handed to a carrier; it is packed
+--   into numbered cartons whose lines record the order line, quantity and the location the stock
+--   is relieved from. Ship confirmation moves packing -> shipped, decrements on-hand at each line's
+--   location and writes a SHIP inventory transaction per line. Cartons may reference the order
+--   container binding (tote/box label) they were packed from.
+CREATE TABLE sales.shipments (
+    id               UUID          NOT NULL,
+    shipment_number  VARCHAR(50)   NOT NULL,
+    order_id         UUID          NOT NULL REFERENCES sales.orders(id) ON DELETE RESTRICT,
+    status           VARCHAR(20)   NOT NULL DEFAULT 'packing'
+                         CHECK (status IN ('packing', 'shipped', 'cancelled')),
+    carrier          VARCHAR(100)  NULL,
+    service_level    VARCHAR(100)  NULL,
+    tracking_number  VARCHAR(100)  NULL,
+    ship_date        TIMESTAMP     NULL,
+    shipped_by       UUID          NULL REFERENCES core.users(id),
+    notes            TEXT          NULL,
+    created_by       UUID          NOT NULL REFERENCES core.users(id),
+    updated_by       UUID          NOT NULL REFERENCES core.users(id),
+    created_date     TIMESTAMP     NOT NULL,
+    updated_date     TIMESTAMP     NOT NULL,
+    scenario_id      UUID          NULL REFERENCES inventory.scenarios(id) ON DELETE SET NULL,
+    PRIMARY KEY (id),
+    UNIQUE (shipment_number)
+);
+CREATE INDEX idx_shipments_order ON sales.shipments(order_id);
+CREATE INDEX idx_shipments_tracking ON sales.shipments(tracking_number) WHERE tracking_number IS NOT NULL;
+CREATE INDEX idx_shipments_scenario ON sales.shipments(scenario_id);
+
+CREATE TABLE sales.shipment_cartons (
+    id                    UUID           NOT NULL,
+    shipment_id           UUID           NOT NULL REFERENCES sales.shipments(id) ON DELETE CASCADE,
+    carton_number         INT            NOT NULL CHECK (carton_number > 0),
+    container_binding_id  UUID           NULL REFERENCES inventory.order_container_bindings(id) ON DELETE SET NULL,
+    weight                NUMERIC(10, 4) NULL CHECK (weight >= 0),
+    weight_unit           VARCHAR(10)    NULL,
+    tracking_number       VARCHAR(100)   NULL,
+    created_date          TIMESTAMP      NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (shipment_id, carton_number)
+);
+
+CREATE TABLE sales.shipment_carton_lines (
+    id                  UUID  NOT NULL,
+    carton_id           UUID  NOT NULL REFERENCES sales.shipment_cartons(id) ON DELETE CASCADE,
+    order_line_item_id  UUID  NOT NULL REFERENCES sales.order_line_items(id) ON DELETE CASCADE,
+    product_id          UUID  NOT NULL REFERENCES products.products(id),
+    location_id         UUID  NOT NULL REFERENCES inventory.inventory_locations(id),
+    lot_id              UUID  NULL REFERENCES inventory.lot_trackings(id),
+    quantity            INT   NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_shipment_carton_lines_carton ON sales.shipment_carton_lines(carton_id);
+
+INSERT INTO core.table_access (id, role_id, table_name, can_create, can_read, can_update, can_delete)
+SELECT gen_random_uuid(), id, t.table_name, true, true, true, true
+FROM core.roles
+CROSS JOIN (VALUES ('sales.shipments'), ('sales.shipment_cartons'), ('sales.shipment_carton_lines')) AS t(table_name);
+
+-- Version: 2.52
+-- Description: Grant the admin role permission to manually execute create_shipping_label.
+--   Same fresh-install / upgrade semantics as Version 2.41: seed.sql owns the grant on a fresh
+--   database; this re-grants idempotently on an upgrade.
+INSERT INTO workflow.action_permissions (role_id, action_type, is_allowed)
+SELECT r.id, action_type, true
+FROM core.roles r
+CROSS JOIN (VALUES
+    ('create_shipping_label')
+) AS actions(action_type)
+WHERE r.name = 'ZZZADMIN'
+ON CONFLICT (role_id, action_type) DO NOTHING;
+
+-- Version: 2.53
+-- Description: Multi-line transfer orders. A transfer order becomes a header over
+--   inventory.transfer_order_lines, one product per line. Claiming a transfer ships it: every line's
+--   quantity moves from the source location into the in-transit location of the source warehouse
+--   (an inventory location flagged is_transit, one per warehouse, never allocated from). Receiving
+--   moves stock from there to the destination, partially if need be (status partially_received);
+--   closing a receipt short writes what is still outstanding off as a discrepancy on the line.
+--   product_id/quantity stay on the header for single-line transfers; existing transfers are
+--   backfilled as one line each, already shipped or received according to their status.
+ALTER TABLE inventory.inventory_locations
+    ADD COLUMN is_transit BOOLEAN NOT NULL DEFAULT false;
+CREATE UNIQUE INDEX idx_inventory_locations_transit ON inventory.inventory_locations(warehouse_id) WHERE is_transit;
+
+ALTER TABLE inventory.transfer_orders
+    ALTER COLUMN product_id DROP NOT NULL,
+    ADD COLUMN transit_location_id UUID NULL REFERENCES inventory.inventory_locations(id);
+
+CREATE TABLE inventory.transfer_order_lines (
+    id                    UUID       NOT NULL,
+    transfer_id           UUID       NOT NULL REFERENCES inventory.transfer_orders(id) ON DELETE CASCADE,
+    line_number           INT        NOT NULL CHECK (line_number > 0),
+    product_id            UUID       NOT NULL REFERENCES products.products(id),
+    quantity              INT        NOT NULL CHECK (quantity > 0),
+    quantity_shipped      INT        NOT NULL DEFAULT 0 CHECK (quantity_shipped >= 0),
+    quantity_received     INT        NOT NULL DEFAULT 0 CHECK (quantity_received >= 0),
+    discrepancy_quantity  INT        NOT NULL DEFAULT 0 CHECK (discrepancy_quantity >= 0),
+    discrepancy_reason    TEXT       NULL,
+    created_date          TIMESTAMP  NOT NULL,
+    updated_date          TIMESTAMP  NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (transfer_id, line_number),
+    CHECK (quantity_received + discrepancy_quantity <= quantity_shipped)
+);
+CREATE INDEX idx_transfer_order_lines_product ON inventory.transfer_order_lines(product_id);
+
+INSERT INTO inventory.transfer_order_lines (
+    id, transfer_id, line_number, product_id, quantity, quantity_shipped, quantity_received,
+    created_date, updated_date
+)
+SELECT
+    gen_random_uuid(), id, 1, product_id, quantity,
+    CASE WHEN status IN ('in_transit', 'completed') THEN quantity ELSE 0 END,
+    CASE WHEN status = 'completed' THEN quantity ELSE 0 END,
+    created_date, updated_date
+FROM inventory.transfer_orders
+WHERE product_id IS NOT NULL AND quantity > 0;
+
+INSERT INTO core.table_access (id, role_id, table_name, can_create, can_read, can_update, can_delete)
+SELECT gen_random_uuid(), id, 'inventory.transfer_order_lines', true, true, true, true FROM core.roles;
+
+-- Version: 2.54
+-- Description: Cycle count plans. A plan is a standing policy that generates cycle count sessions:
+--   'abc' counts each item when its ABC class (products.product_costs.abc_classification) is due —
+--   a_days/b_days/c_days after it was last counted in a completed session; 'zero_on_hand' counts
+--   items with no stock; 'negative_adjustment' counts every item at a location with a negative
+--   adjustment in the last lookback_days; 'random_sample' counts sample_size locations per zone.
+--   Generation snapshots system quantities, skips locations already in an open (draft or
+--   in_progress) session and records a run, so the count program leaves an audit trail. Also grants
+--   the admin role manual execution of generate_cycle_counts (seed.sql owns it on a fresh database).
+CREATE TABLE inventory.count_plans (
+    id                   UUID          NOT NULL,
+    name                 VARCHAR(200)  NOT NULL,
+    rule                 VARCHAR(30)   NOT NULL
+                             CHECK (rule IN ('abc','zero_on_hand','negative_adjustment','random_sample')),
+    warehouse_id         UUID          NULL REFERENCES inventory.warehouses(id),
+    zone_id              UUID          NULL REFERENCES inventory.zones(id),
+    a_days               INT           NOT NULL DEFAULT 30 CHECK (a_days > 0),
+    b_days               INT           NOT NULL DEFAULT 90 CHECK (b_days > 0),
+    c_days               INT           NOT NULL DEFAULT 365 CHECK (c_days > 0),
+    lookback_days        INT           NOT NULL DEFAULT 30 CHECK (lookback_days > 0),
+    sample_size          INT           NOT NULL DEFAULT 5 CHECK (sample_size > 0),
+    max_items            INT           NOT NULL DEFAULT 0 CHECK (max_items >= 0),
+    is_active            BOOLEAN       NOT NULL DEFAULT true,
+    last_generated_date  TIMESTAMP     NULL,
+    created_by           UUID          NOT NULL REFERENCES core.users(id),
+    created_date         TIMESTAMP     NOT NULL,
+    updated_date         TIMESTAMP     NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (name)
+);
+
+CREATE TABLE inventory.count_plan_runs (
+    id              UUID       NOT NULL,
+    plan_id         UUID       NOT NULL REFERENCES inventory.count_plans(id),
+    session_id      UUID       NULL REFERENCES inventory.cycle_count_sessions(id) ON DELETE SET NULL,
+    item_count      INT        NOT NULL CHECK (item_count >= 0),
+    generated_by    UUID       NOT NULL REFERENCES core.users(id),
+    generated_date  TIMESTAMP  NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_count_plan_runs_plan ON inventory.count_plan_runs(plan_id, generated_date);
+
+INSERT INTO core.table_access (id, role_id, table_name, can_create, can_read, can_update, can_delete)
+SELECT gen_random_uuid(), id, t.table_name, true, true, true, true
+FROM core.roles
+CROSS JOIN (VALUES ('inventory.count_plans'), ('inventory.count_plan_runs')) AS t(table_name);
+
+INSERT INTO workflow.action_permissions (role_id, action_type, is_allowed)
+SELECT r.id, action_type, true
+FROM core.roles r
+CROSS JOIN (VALUES
+    ('generate_cycle_counts')
+) AS actions(action_type)
+WHERE r.name = 'ZZZADMIN'
+ON CONFLICT (role_id, action_type) DO NOTHING;
+
+-- Version: 2.55
+-- Description: Blind counts, recounts and posted cycle count variances. A blind session hides
+--   system quantities from counters until it completes. A session's recount thresholds (units,
+--   percent of the system quantity, or value at the item's current cost) turn an out-of-tolerance
+--   count into a recount: the item becomes 'recount' and a new pending item pointing back at it
+--   (recount_of) is assigned to a different worker. Completing a session posts every approved
+--   variance as a cycle_count adjustment and freezes the session's financial impact.
+ALTER TABLE inventory.cycle_count_sessions
+    ADD COLUMN blind                 BOOLEAN        NOT NULL DEFAULT false,
+    ADD COLUMN recount_units         INT            NULL CHECK (recount_units >= 0),
+    ADD COLUMN recount_percent       NUMERIC(6,2)   NULL CHECK (recount_percent >= 0),
+    ADD COLUMN recount_value         NUMERIC(12,2)  NULL CHECK (recount_value >= 0),
+    ADD COLUMN adjustment_count      INT            NULL,
+    ADD COLUMN net_variance_units    INT            NULL,
+    ADD COLUMN net_variance_value    NUMERIC(14,2)  NULL,
+    ADD COLUMN gross_variance_value  NUMERIC(14,2)  NULL;
+
+ALTER TABLE inventory.cycle_count_items
+    ADD COLUMN assigned_to  UUID  NULL REFERENCES core.users(id),
+    ADD COLUMN recount_of   UUID  NULL REFERENCES inventory.cycle_count_items(id) ON DELETE CASCADE;
+
+ALTER TABLE inventory.cycle_count_items DROP CONSTRAINT cycle_count_items_status_check;
+ALTER TABLE inventory.cycle_count_items ADD CONSTRAINT cycle_count_items_status_check
+    CHECK (status IN ('pending','counted','recount','variance_approved','variance_rejected'));
+
+CREATE INDEX idx_cycle_count_items_assigned_to ON inventory.cycle_count_items(assigned_to);
+
+-- Version: 2.56
+-- Description: Pick waves and batches. The wave planner groups pending, unassigned pick tasks
+--   across orders by carrier cutoff (from each order's open shipment), then by zone, and orders
+--   them by priority and due date. Each zone's orders are split into batches of at most
+--   tote_count orders (one tote per order) and each batch's stops are sequenced along a
+--   serpentine walk through aisle/rack/shelf/bin. A worker claims a whole batch; directed work
+--   then serves the batch's stops in route order. A wave completes when all its batches have.
+CREATE TABLE inventory.pick_waves (
+    id              UUID          NOT NULL,
+    wave_number     VARCHAR(50)   NOT NULL,
+    status          VARCHAR(20)   NOT NULL DEFAULT 'open'
+                        CHECK (status IN ('open','completed')),
+    warehouse_id    UUID          NULL REFERENCES inventory.warehouses(id),
+    carrier_cutoff  TIMESTAMP     NULL,
+    tote_count      INT           NOT NULL CHECK (tote_count > 0),
+    created_by      UUID          NOT NULL REFERENCES core.users(id),
+    created_date    TIMESTAMP     NOT NULL,
+    updated_date    TIMESTAMP     NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (wave_number)
+);
+
+CREATE TABLE inventory.pick_batches (
+    id            UUID          NOT NULL,
+    wave_id       UUID          NOT NULL REFERENCES inventory.pick_waves(id) ON DELETE CASCADE,
+    batch_number  VARCHAR(60)   NOT NULL,
+    zone_id       UUID          NOT NULL REFERENCES inventory.zones(id),
+    status        VARCHAR(20)   NOT NULL DEFAULT 'open'
+                      CHECK (status IN ('open','in_progress','completed')),
+    order_count   INT           NOT NULL CHECK (order_count > 0),
+    stop_count    INT           NOT NULL CHECK (stop_count > 0),
+    assigned_to   UUID          NULL REFERENCES core.users(id),
+    assigned_at   TIMESTAMP     NULL,
+    created_date  TIMESTAMP     NOT NULL,
+    updated_date  TIMESTAMP     NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (batch_number)
+);
+CREATE INDEX idx_pick_batches_wave ON inventory.pick_batches(wave_id);
+CREATE INDEX idx_pick_batches_assigned ON inventory.pick_batches(assigned_to) WHERE assigned_to IS NOT NULL;
+
+ALTER TABLE inventory.pick_tasks
+    ADD COLUMN batch_id        UUID  NULL REFERENCES inventory.pick_batches(id) ON DELETE SET NULL,
+    ADD COLUMN batch_sequence  INT   NULL CHECK (batch_sequence > 0),
+    ADD COLUMN tote_number     INT   NULL CHECK (tote_number > 0);
+CREATE INDEX idx_pick_tasks_batch ON inventory.pick_tasks(batch_id, batch_sequence) WHERE batch_id IS NOT NULL;
+
+INSERT INTO core.table_access (id, role_id, table_name, can_create, can_read, can_update, can_delete)
+SELECT gen_random_uuid(), id, t.table_name, true, true, true, true
+FROM core.roles
+CROSS JOIN (VALUES ('inventory.pick_waves'), ('inventory.pick_batches')) AS t(table_name);
+
+-- Version: 2.57
+-- Description: Replenishment tasks. Moves of stock from reserve bins to forward pick locations,
+--   planned from each pick face's inventory item: min/max (on hand plus inbound at or below
+--   minimum_stock, filled to maximum_stock) and demand (on hand plus inbound short of its open
+--   pick tasks plus avg_daily_usage for the cover window). Generation runs on a schedule through
+--   the generate_replenishment action and on demand when a picker short picks a face. Reserve
+--   stock is drawn FEFO: lots (lot_id) by earliest expiration, then untracked stock. Completing a
+--   task records quantity_moved and moves the stock with a REPLENISH_OUT/REPLENISH_IN ledger pair.
+--   Also grants the admin role manual execution of generate_replenishment (seed.sql owns it on a
+--   fresh database).
+CREATE TABLE inventory.replenishment_tasks (
+    id                UUID          NOT NULL,
+    product_id        UUID          NOT NULL REFERENCES products.products(id),
+    lot_id            UUID          NULL REFERENCES inventory.lot_trackings(id),
+    from_location_id  UUID          NOT NULL REFERENCES inventory.inventory_locations(id),
+    to_location_id    UUID          NOT NULL REFERENCES inventory.inventory_locations(id),
+    quantity          INT           NOT NULL CHECK (quantity > 0),
+    quantity_moved    INT           NOT NULL DEFAULT 0 CHECK (quantity_moved >= 0),
+    reason            VARCHAR(20)   NOT NULL CHECK (reason IN ('min_max','demand','short_pick')),
+    status            VARCHAR(20)   NOT NULL DEFAULT 'pending'
+                          CHECK (status IN ('pending','in_progress','completed','cancelled')),
+    assigned_to       UUID          NULL REFERENCES core.users(id),
+    assigned_at       TIMESTAMP     NULL,
+    completed_by      UUID          NULL REFERENCES core.users(id),
+    completed_at      TIMESTAMP     NULL,
+    created_by        UUID          NOT NULL REFERENCES core.users(id),
+    created_date      TIMESTAMP     NOT NULL,
+    updated_date      TIMESTAMP     NOT NULL,
+    scenario_id       UUID          NULL REFERENCES inventory.scenarios(id) ON DELETE SET NULL,
+    PRIMARY KEY (id),
+    CHECK (from_location_id <> to_location_id)
+);
+CREATE INDEX idx_replenishment_tasks_open_to ON inventory.replenishment_tasks(to_location_id, product_id)
+    WHERE status IN ('pending','in_progress');
+CREATE INDEX idx_replenishment_tasks_open_from ON inventory.replenishment_tasks(from_location_id, product_id)
+    WHERE status IN ('pending','in_progress');
+CREATE INDEX idx_replenishment_tasks_assigned ON inventory.replenishment_tasks(assigned_to) WHERE assigned_to IS NOT NULL;
+CREATE INDEX idx_replenishment_tasks_scenario ON inventory.replenishment_tasks(scenario_id);
+
+INSERT INTO core.table_access (id, role_id, table_name, can_create, can_read, can_update, can_delete)
+SELECT gen_random_uuid(), id, 'inventory.replenishment_tasks', true, true, true, true FROM core.roles;
+
+INSERT INTO workflow.action_permissions (role_id, action_type, is_allowed)
+SELECT r.id, action_type, true
+FROM core.roles r
+CROSS JOIN (VALUES
+    ('generate_replenishment')
+) AS actions(action_type)
+WHERE r.name = 'ZZZADMIN'
+ON CONFLICT (role_id, action_type) DO NOTHING;
+
+-- Version: 2.58
+-- Description: Demand forecasting. forecast_policies choose, per product category (category_id
+--   NULL is the default for every other category), how an inventory item's daily demand is
+--   forecast from its outbound inventory_transactions history: moving_average over window_days,
+--   exponential_smoothing with smoothing_alpha, or seasonal_naive repeating the last
+--   season_length_days. Safety stock comes from the variability of daily demand over the supplier
+--   lead time (the primary supplier product's lead_time_days, then suppliers.lead_time_days, then
+--   the policy's lead_time_days) at service_level; the reorder point adds lead-time demand; the
+--   economic order quantity needs ordering_cost and holding_cost_rate. demand_forecasts records
+--   every forecast with the item's values before and the recommended values, applied at once
+--   when the policy auto-applies and otherwise pending approval. Forecasts are kept after they
+--   are applied so they can be scored against the demand that followed. Also grants the admin
+--   role manual execution of forecast_demand (seed.sql owns it on a fresh database).
+CREATE TABLE inventory.forecast_policies (
+    id                  UUID           NOT NULL,
+    category_id         UUID           NULL REFERENCES products.product_categories(id) ON DELETE CASCADE,
+    method              VARCHAR(30)    NOT NULL
+                            CHECK (method IN ('moving_average','exponential_smoothing','seasonal_naive')),
+    history_days        INT            NOT NULL CHECK (history_days > 0),
+    window_days         INT            NOT NULL CHECK (window_days > 0),
+    smoothing_alpha     NUMERIC(4,3)   NOT NULL CHECK (smoothing_alpha > 0 AND smoothing_alpha <= 1),
+    season_length_days  INT            NOT NULL CHECK (season_length_days > 0),
+    service_level       NUMERIC(5,4)   NOT NULL CHECK (service_level >= 0.5 AND service_level < 1),
+    lead_time_days      INT            NOT NULL CHECK (lead_time_days >= 0),
+    ordering_cost       NUMERIC(10,2)  NOT NULL DEFAULT 0 CHECK (ordering_cost >= 0),
+    holding_cost_rate   NUMERIC(5,4)   NOT NULL DEFAULT 0 CHECK (holding_cost_rate >= 0),
+    auto_apply          BOOLEAN        NOT NULL DEFAULT FALSE,
+    created_by          UUID           NOT NULL REFERENCES core.users(id),
+    created_date        TIMESTAMP      NOT NULL,
+    updated_date        TIMESTAMP      NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX idx_forecast_policies_category ON inventory.forecast_policies(category_id)
+    WHERE category_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_forecast_policies_default ON inventory.forecast_policies((category_id IS NULL))
+    WHERE category_id IS NULL;
+
+CREATE TABLE inventory.demand_forecasts (
+    id                           UUID           NOT NULL,
+    inventory_item_id            UUID           NOT NULL REFERENCES inventory.inventory_items(id) ON DELETE CASCADE,
+    product_id                   UUID           NOT NULL REFERENCES products.products(id),
+    location_id                  UUID           NOT NULL REFERENCES inventory.inventory_locations(id),
+    policy_id                    UUID           NULL REFERENCES inventory.forecast_policies(id) ON DELETE SET NULL,
+    method                       VARCHAR(30)    NOT NULL,
+    history_days                 INT            NOT NULL,
+    forecast_daily_usage         NUMERIC(12,4)  NOT NULL,
+    demand_std_dev               NUMERIC(12,4)  NOT NULL,
+    lead_time_days               INT            NOT NULL,
+    current_avg_daily_usage      INT            NOT NULL,
+    current_safety_stock         INT            NOT NULL,
+    current_reorder_point        INT            NOT NULL,
+    current_eoq                  INT            NOT NULL,
+    recommended_avg_daily_usage  INT            NOT NULL,
+    recommended_safety_stock     INT            NOT NULL,
+    recommended_reorder_point    INT            NOT NULL,
+    recommended_eoq              INT            NOT NULL,
+    status                       VARCHAR(20)    NOT NULL
+                                     CHECK (status IN ('pending','applied','rejected','superseded','unchanged')),
+    reviewed_by                  UUID           NULL REFERENCES core.users(id),
+    reviewed_date                TIMESTAMP      NULL,
+    created_by                   UUID           NOT NULL REFERENCES core.users(id),
+    created_date                 TIMESTAMP      NOT NULL,
+    updated_date                 TIMESTAMP      NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_demand_forecasts_item ON inventory.demand_forecasts(inventory_item_id, created_date);
+CREATE INDEX idx_demand_forecasts_pending ON inventory.demand_forecasts(inventory_item_id) WHERE status = 'pending';
+CREATE INDEX idx_inventory_transactions_demand ON inventory.inventory_transactions(product_id, location_id, transaction_date);
+
+INSERT INTO core.table_access (id, role_id, table_name, can_create, can_read, can_update, can_delete)
+SELECT gen_random_uuid(), id, t.table_name, true, true, true, true
+FROM core.roles
+CROSS JOIN (VALUES ('inventory.forecast_policies'), ('inventory.demand_forecasts')) AS t(table_name);
+
+INSERT INTO workflow.action_permissions (role_id, action_type, is_allowed)
+SELECT r.id, action_type, true
+FROM core.roles r
+CROSS JOIN (VALUES
+    ('forecast_demand')
+) AS actions(action_type)
+WHERE r.name = 'ZZZADMIN'
+ON CONFLICT (role_id, action_type) DO NOTHING;
+
+-- Version: 2.59
+-- Description: Purchase suggestions. A suggestion run collects every inventory item whose
+--   available quantity (on hand less reserved and allocated) is below its reorder point, totals
+--   what each product needs per warehouse to reach its maximum_stock (or reorder_point plus
+--   economic_order_quantity) less what open purchase orders already bring, and picks a supplier
+--   from supplier_products by unit_cost, lead time, how far min_order_quantity overbuys and
+--   suppliers.rating. Quantities are rounded up to the minimum order quantity and then to whole
+--   cases of products.units_per_case. purchase_suggestions is one draft purchase order per
+--   supplier and delivery warehouse; buyers edit its purchase_suggestion_lines and release it
+--   into procurement.purchase_orders, or dismiss it. A product stays off later runs for a
+--   warehouse while a draft suggestion holds it. Also grants the admin role manual execution of
+--   suggest_purchases (seed.sql owns it on a fresh database).
+CREATE TABLE procurement.purchase_suggestions (
+    id                     UUID           NOT NULL,
+    supplier_id            UUID           NOT NULL REFERENCES procurement.suppliers(id),
+    delivery_warehouse_id  UUID           NOT NULL REFERENCES inventory.warehouses(id),
+    status                 VARCHAR(20)    NOT NULL CHECK (status IN ('draft','released','dismissed')),
+    lead_time_days         INT            NOT NULL CHECK (lead_time_days >= 0),
+    subtotal               NUMERIC(12,2)  NOT NULL DEFAULT 0,
+    notes                  TEXT           NULL,
+    purchase_order_id      UUID           NULL REFERENCES procurement.purchase_orders(id) ON DELETE SET NULL,
+    released_by            UUID           NULL REFERENCES core.users(id),
+    released_date          TIMESTAMP      NULL,
+    created_by             UUID           NOT NULL REFERENCES core.users(id),
+    updated_by             UUID           NOT NULL REFERENCES core.users(id),
+    created_date           TIMESTAMP      NOT NULL,
+    updated_date           TIMESTAMP      NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_purchase_suggestions_status ON procurement.purchase_suggestions(status, delivery_warehouse_id);
+CREATE INDEX idx_purchase_suggestions_supplier ON procurement.purchase_suggestions(supplier_id);
+
+CREATE TABLE procurement.purchase_suggestion_lines (
+    id                   UUID           NOT NULL,
+    suggestion_id        UUID           NOT NULL REFERENCES procurement.purchase_suggestions(id) ON DELETE CASCADE,
+    product_id           UUID           NOT NULL REFERENCES products.products(id),
+    supplier_product_id  UUID           NOT NULL REFERENCES procurement.supplier_products(id),
+    available_quantity   INT            NOT NULL,
+    on_order_quantity    INT            NOT NULL CHECK (on_order_quantity >= 0),
+    reorder_point        INT            NOT NULL,
+    target_quantity      INT            NOT NULL,
+    needed_quantity      INT            NOT NULL CHECK (needed_quantity > 0),
+    units_per_case       INT            NOT NULL CHECK (units_per_case > 0),
+    min_order_quantity   INT            NOT NULL CHECK (min_order_quantity >= 0),
+    suggested_quantity   INT            NOT NULL CHECK (suggested_quantity > 0),
+    quantity             INT            NOT NULL CHECK (quantity > 0),
+    unit_cost            NUMERIC(10,2)  NOT NULL CHECK (unit_cost >= 0),
+    line_total           NUMERIC(12,2)  NOT NULL,
+    lead_time_days       INT            NOT NULL CHECK (lead_time_days >= 0),
+    score                NUMERIC(6,4)   NOT NULL,
+    created_date         TIMESTAMP      NOT NULL,
+    updated_date         TIMESTAMP      NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (suggestion_id, product_id)
+);
+CREATE INDEX idx_purchase_suggestion_lines_product ON procurement.purchase_suggestion_lines(product_id);
+
+INSERT INTO core.table_access (id, role_id, table_name, can_create, can_read, can_update, can_delete)
+SELECT gen_random_uuid(), id, t.table_name, true, true, true, true
+FROM core.roles
+CROSS JOIN (VALUES ('procurement.purchase_suggestions'), ('procurement.purchase_suggestion_lines')) AS t(table_name);
+
+INSERT INTO workflow.action_permissions (role_id, action_type, is_allowed)
+SELECT r.id, action_type, true
+FROM core.roles r
+CROSS JOIN (VALUES
+    ('suggest_purchases')
+) AS actions(action_type)
+WHERE r.name = 'ZZZADMIN'
+ON CONFLICT (role_id, action_type) DO NOTHING;
+
+-- Version: 2.60
+-- Description: Supplier invoices and three-way match. A supplier_invoice is the bill a supplier
+--   sends against one purchase order: a header with tax and freight and one
+--   supplier_invoice_line per purchase order line item billed. Matching compares each line with
+--   its purchase order line item: the unit price against the ordered unit cost net of discount,
+--   and the quantity against what was received less what other live invoices already billed.
+--   The invoice's tolerances (a percent and an amount for price, a percent and units for
+--   quantity) say how far over the invoice may go. A line is 'matched', 'price_variance' or
+--   'quantity_variance' and the invoice takes the worst of its lines. A matched invoice is
+--   approved for payment; a mismatched one waits in 'pending_approval' for the approval workflow
+--   to approve or reject it. Invoice numbers are unique per supplier among invoices not
+--   rejected. Also grants the admin role manual execution of the supplier invoice actions
+--   (seed.sql owns them on a fresh database).
+CREATE TABLE procurement.supplier_invoices (
+    id                          UUID           NOT NULL,
+    invoice_number              VARCHAR(100)   NOT NULL,
+    supplier_id                 UUID           NOT NULL REFERENCES procurement.suppliers(id),
+    purchase_order_id           UUID           NOT NULL REFERENCES procurement.purchase_orders(id),
+    invoice_date                TIMESTAMP      NOT NULL,
+    due_date                    TIMESTAMP      NOT NULL,
+    subtotal                    NUMERIC(12,2)  NOT NULL DEFAULT 0,
+    tax_amount                  NUMERIC(12,2)  NOT NULL DEFAULT 0 CHECK (tax_amount >= 0),
+    freight_amount              NUMERIC(12,2)  NOT NULL DEFAULT 0 CHECK (freight_amount >= 0),
+    total_amount                NUMERIC(12,2)  NOT NULL DEFAULT 0,
+    status                      VARCHAR(20)    NOT NULL CHECK (status IN ('open','pending_approval','approved','rejected')),
+    match_status                VARCHAR(20)    NOT NULL CHECK (match_status IN ('unmatched','matched','price_variance','quantity_variance')),
+    price_tolerance_percent     NUMERIC(6,2)   NOT NULL DEFAULT 0 CHECK (price_tolerance_percent >= 0),
+    price_tolerance_amount      NUMERIC(10,2)  NOT NULL DEFAULT 0 CHECK (price_tolerance_amount >= 0),
+    quantity_tolerance_percent  NUMERIC(6,2)   NOT NULL DEFAULT 0 CHECK (quantity_tolerance_percent >= 0),
+    quantity_tolerance_units    INT            NOT NULL DEFAULT 0 CHECK (quantity_tolerance_units >= 0),
+    matched_date                TIMESTAMP      NULL,
+    approved_by                 UUID           NULL REFERENCES core.users(id),
+    approved_date               TIMESTAMP      NULL,
+    approval_reason             TEXT           NULL,
+    rejected_by                 UUID           NULL REFERENCES core.users(id),
+    rejected_date               TIMESTAMP      NULL,
+    rejection_reason            TEXT           NULL,
+    notes                       TEXT           NULL,
+    created_by                  UUID           NOT NULL REFERENCES core.users(id),
+    updated_by                  UUID           NOT NULL REFERENCES core.users(id),
+    created_date                TIMESTAMP      NOT NULL,
+    updated_date                TIMESTAMP      NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX idx_supplier_invoices_number ON procurement.supplier_invoices(supplier_id, invoice_number) WHERE status <> 'rejected';
+CREATE INDEX idx_supplier_invoices_purchase_order ON procurement.supplier_invoices(purchase_order_id);
+CREATE INDEX idx_supplier_invoices_status ON procurement.supplier_invoices(status, match_status);
+
+CREATE TABLE procurement.supplier_invoice_lines (
+    id                           UUID           NOT NULL,
+    invoice_id                   UUID           NOT NULL REFERENCES procurement.supplier_invoices(id) ON DELETE CASCADE,
+    purchase_order_line_item_id  UUID           NOT NULL REFERENCES procurement.purchase_order_line_items(id),
+    description                  TEXT           NULL,
+    quantity                     INT            NOT NULL CHECK (quantity > 0),
+    unit_price                   NUMERIC(10,2)  NOT NULL CHECK (unit_price >= 0),
+    line_total                   NUMERIC(12,2)  NOT NULL,
+    match_status                 VARCHAR(20)    NOT NULL CHECK (match_status IN ('unmatched','matched','price_variance','quantity_variance')),
+    expected_unit_cost           NUMERIC(10,4)  NOT NULL DEFAULT 0,
+    received_quantity            INT            NOT NULL DEFAULT 0,
+    previously_invoiced          INT            NOT NULL DEFAULT 0,
+    price_variance               NUMERIC(12,2)  NOT NULL DEFAULT 0,
+    quantity_variance            INT            NOT NULL DEFAULT 0,
+    created_date                 TIMESTAMP      NOT NULL,
+    updated_date                 TIMESTAMP      NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (invoice_id, purchase_order_line_item_id)
+);
+CREATE INDEX idx_supplier_invoice_lines_po_line ON procurement.supplier_invoice_lines(purchase_order_line_item_id);
+
+INSERT INTO core.table_access (id, role_id, table_name, can_create, can_read, can_update, can_delete)
+SELECT gen_random_uuid(), id, t.table_name, true, true, true, true
+FROM core.roles
+CROSS JOIN (VALUES ('procurement.supplier_invoices'), ('procurement.supplier_invoice_lines')) AS t(table_name);
+
+INSERT INTO workflow.action_permissions (role_id, action_type, is_allowed)
+SELECT r.id, action_type, true
+FROM core.roles r
+CROSS JOIN (VALUES
+    ('match_supplier_invoice'),
+    ('approve_supplier_invoice'),
+    ('reject_supplier_invoice')
+) AS actions(action_type)
+WHERE r.name = 'ZZZADMIN'
+ON CONFLICT (role_id, action_type) DO NOTHING;
+
+-- Version: 2.61
+-- Description: Landed costs. A landed_cost collects the freight, duty, brokerage, insurance and
+--   other charges of one purchase order, or of the receipt of some of its line items when
+--   line_item_ids is set. Each landed_cost_charge is allocated across the received lines by
+--   value (quantity received times the ordered unit cost net of discount), quantity, weight or
+--   volume (from products.physical_attributes), in cents, largest remainder first. Applying a
+--   draft stores its landed_cost_allocations, sets products.product_costs.landed_cost to the
+--   received unit cost plus every applied charge per unit, and writes a 'landed_cost' entry to
+--   products.cost_history.
+CREATE TABLE procurement.landed_costs (
+    id                 UUID           NOT NULL,
+    purchase_order_id  UUID           NOT NULL REFERENCES procurement.purchase_orders(id),
+    reference_number   VARCHAR(100)   NULL,
+    currency_id        UUID           NOT NULL REFERENCES core.currencies(id),
+    line_item_ids      UUID[]         NOT NULL DEFAULT '{}',
+    status             VARCHAR(20)    NOT NULL CHECK (status IN ('draft','applied','cancelled')),
+    total_charges      NUMERIC(12,2)  NOT NULL DEFAULT 0,
+    notes              TEXT           NULL,
+    applied_by         UUID           NULL REFERENCES core.users(id),
+    applied_date       TIMESTAMP      NULL,
+    created_by         UUID           NOT NULL REFERENCES core.users(id),
+    updated_by         UUID           NOT NULL REFERENCES core.users(id),
+    created_date       TIMESTAMP      NOT NULL,
+    updated_date       TIMESTAMP      NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_landed_costs_purchase_order ON procurement.landed_costs(purchase_order_id);
+CREATE INDEX idx_landed_costs_status ON procurement.landed_costs(status);
+
+CREATE TABLE procurement.landed_cost_charges (
+    id                 UUID           NOT NULL,
+    landed_cost_id     UUID           NOT NULL REFERENCES procurement.landed_costs(id) ON DELETE CASCADE,
+    charge_type        VARCHAR(20)    NOT NULL CHECK (charge_type IN ('freight','duty','brokerage','insurance','other')),
+    allocation_method  VARCHAR(20)    NOT NULL CHECK (allocation_method IN ('value','quantity','weight','volume')),
+    amount             NUMERIC(12,2)  NOT NULL CHECK (amount > 0),
+    description        TEXT           NULL,
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_landed_cost_charges_landed_cost ON procurement.landed_cost_charges(landed_cost_id);
+
+CREATE TABLE procurement.landed_cost_allocations (
+    id                           UUID           NOT NULL,
+    landed_cost_id               UUID           NOT NULL REFERENCES procurement.landed_costs(id) ON DELETE CASCADE,
+    charge_id                    UUID           NOT NULL REFERENCES procurement.landed_cost_charges(id) ON DELETE CASCADE,
+    purchase_order_line_item_id  UUID           NOT NULL REFERENCES procurement.purchase_order_line_items(id),
+    product_id                   UUID           NOT NULL REFERENCES products.products(id),
+    quantity                     INT            NOT NULL,
+    basis                        NUMERIC(16,4)  NOT NULL,
+    amount                       NUMERIC(12,2)  NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (charge_id, purchase_order_line_item_id)
+);
+CREATE INDEX idx_landed_cost_allocations_line_item ON procurement.landed_cost_allocations(purchase_order_line_item_id);
+
+INSERT INTO core.table_access (id, role_id, table_name, can_create, can_read, can_update, can_delete)
+SELECT gen_random_uuid(), id, t.table_name, true, true, true, true
+FROM core.roles
+CROSS JOIN (VALUES ('procurement.landed_costs'), ('procurement.landed_cost_charges'), ('procurement.landed_cost_allocations')) AS t(table_name);
+
+-- Version: 2.62
+-- Description: Immutable revisions of automation rules. Every save snapshots the rule, its
+--   actions, edges and schedule into definition, which is never updated afterwards; only the
+--   status moves (draft -> published -> superseded). At most one revision per rule is published:
+--   the one the live rule_actions/action_edges rows currently mirror. Rolling back copies an
+--   older definition into a new revision (restored_from_id) and publishes it. Executions record
+--   the revision that was published when they started, so history shows the graph that ran.
+CREATE TABLE workflow.rule_revisions (
+    id                UUID        PRIMARY KEY,
+    rule_id           UUID        NOT NULL REFERENCES workflow.automation_rules(id) ON DELETE CASCADE,
+    revision_number   INTEGER     NOT NULL CHECK (revision_number > 0),
+    status            VARCHAR(20) NOT NULL CHECK (status IN ('draft', 'published', 'superseded')),
+    definition        JSONB       NOT NULL,
+    restored_from_id  UUID        NULL REFERENCES workflow.rule_revisions(id),
+    notes             TEXT        NULL,
+    created_by        UUID        NOT NULL REFERENCES core.users(id),
+    created_date      TIMESTAMP   NOT NULL,
+    published_by      UUID        NULL REFERENCES core.users(id),
+    published_date    TIMESTAMP   NULL,
+    UNIQUE (rule_id, revision_number)
+);
+CREATE UNIQUE INDEX idx_rule_revisions_published ON workflow.rule_revisions (rule_id) WHERE status = 'published';
+
+ALTER TABLE workflow.automation_executions ADD COLUMN revision_id UUID NULL REFERENCES workflow.rule_revisions(id);
+CREATE INDEX idx_automation_executions_revision ON workflow.automation_executions (revision_id);
+
+-- Version: 2.63
+-- Description: Published cascade_outbox rows can be kept as event history (published_at set)
+--   for a retention window instead of being deleted, so the workflow simulator can replay a rule
+--   against past events. Index the history by entity and time for those replays.
+CREATE INDEX idx_cascade_outbox_history
+    ON workflow.cascade_outbox (entity_name, created_at);
+
+-- Version: 2.64
+-- Description: Backfill a published revision for every automation rule saved before revisions
+--   existed, snapshotting its live rule, actions, edges and schedule, so the trigger has a
+--   published revision to load each rule's graph from and pin its executions to. Actions that
+--   are inactive and no edge reaches are ones an earlier save removed, and are left out.
+INSERT INTO workflow.rule_revisions (
+    id, rule_id, revision_number, status, definition, restored_from_id, notes,
+    created_by, created_date, published_by, published_date
+)
+SELECT
+    gen_random_uuid(),
+    ar.id,
+    1,
+    'published',
+    jsonb_build_object(
+        'name', ar.name,
+        'description', COALESCE(ar.description, ''),
+        'is_active', ar.is_active,
+        'entity_id', ar.entity_id,
+        'trigger_type_id', ar.trigger_type_id,
+        'actions', COALESCE((
+            SELECT jsonb_agg(
+                jsonb_build_object(
+                    'id', ra.id,
+                    'name', ra.name,
+                    'description', COALESCE(ra.description, ''),
+                    'action_config', ra.action_config,
+                    'is_active', COALESCE(ra.is_active, false)
+                ) || CASE WHEN ra.template_id IS NULL THEN '{}'::jsonb
+                          ELSE jsonb_build_object('template_id', ra.template_id) END
+                ORDER BY ra.name, ra.id)
+            FROM workflow.rule_actions ra
+            WHERE ra.automation_rules_id = ar.id
+              AND (ra.is_active OR EXISTS (
+                  SELECT 1 FROM workflow.action_edges e
+                  WHERE e.source_action_id = ra.id OR e.target_action_id = ra.id))
+        ), '[]'::jsonb),
+        'edges', COALESCE((
+            SELECT jsonb_agg(
+                jsonb_strip_nulls(jsonb_build_object(
+                    'source_action_id', e.source_action_id,
+                    'target_action_id', e.target_action_id,
+                    'edge_type', e.edge_type,
+                    'source_output', e.source_output,
+                    'edge_order', COALESCE(e.edge_order, 0)
+                ))
+                ORDER BY e.edge_order, e.id)
+            FROM workflow.action_edges e
+            WHERE e.rule_id = ar.id
+        ), '[]'::jsonb)
+    )
+    || CASE WHEN ar.trigger_conditions IS NULL THEN '{}'::jsonb
+            ELSE jsonb_build_object('trigger_conditions', ar.trigger_conditions) END
+    || CASE WHEN ar.canvas_layout IS NULL THEN '{}'::jsonb
+            ELSE jsonb_build_object('canvas_layout', ar.canvas_layout) END
+    || COALESCE((
+        SELECT jsonb_build_object('schedule', jsonb_strip_nulls(jsonb_build_object(
+            'cron_expression', rs.cron_expression,
+            'interval_seconds', rs.interval_seconds,
+            'timezone', rs.timezone
+        )))
+        FROM workflow.rule_schedules rs
+        WHERE rs.rule_id = ar.id
+    ), '{}'::jsonb),
+    NULL,
+    'snapshot of the rule as it stood before revisions were recorded',
+    ar.created_by,
+    NOW(),
+    ar.created_by,
+    NOW()
+FROM
+    workflow.automation_rules ar
+WHERE
+    NOT EXISTS (SELECT 1 FROM workflow.rule_revisions rr WHERE rr.rule_id = ar.id);
+
+-- Version: 2.65
+-- Description: Fix the number of approvals an approval request needs when it is created, so
+--   escalation reassigning the approvers cannot change its quorum. Existing requests are
+--   backfilled from their current approvers and approval type.
+ALTER TABLE workflow.approval_requests
+    ADD COLUMN required_approvals INT NULL CHECK (required_approvals > 0);
+UPDATE workflow.approval_requests ar
+SET required_approvals = CASE ar.approval_type
+        WHEN 'all' THEN GREATEST(d.distinct_approvers, 1)
+        WHEN 'majority' THEN d.distinct_approvers / 2 + 1
+        ELSE 1
+    END
+FROM (
+    SELECT approval_request_id, (SELECT COUNT(DISTINCT a) FROM unnest(approvers) a)::INT AS distinct_approvers
+    FROM workflow.approval_requests
+) d
+WHERE d.approval_request_id = ar.approval_request_id;
+ALTER TABLE workflow.approval_requests
+    ALTER COLUMN required_approvals SET NOT NULL;
@@ -490,6 +490,7 @@ INSERT INTO core.table_access (id, role_id, table_name, can_create, can_read, ca
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'core.role_pages', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'core.roles', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'core.table_access', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'core.data_scopes', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'core.user_roles', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'core.users', true, true, true, true),
     -- geography schema
@@ -510,10 +511,18 @@ INSERT INTO core.table_access (id, role_id, table_name, can_create, can_read, ca
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'introspection', true, true, true, true),
     -- inventory schema
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.cycle_count_items', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.count_plans', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.count_plan_runs', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.pick_waves', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.pick_batches', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.replenishment_tasks', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.forecast_policies', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.demand_forecasts', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.cycle_count_sessions', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.inventory_adjustments', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.inventory_items', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.inventory_locations', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.inventory_reservations', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.inventory_transactions', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.label_catalog', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.lot_trackings', true, true, true, true),
@@ -524,13 +533,21 @@ INSERT INTO core.table_access (id, role_id, table_name, can_create, can_read, ca
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.scenarios', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.serial_numbers', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.transfer_orders', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.transfer_order_lines', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.warehouses', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'inventory.zones', true, true, true, true),
     -- procurement schema
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'procurement.landed_costs', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'procurement.landed_cost_charges', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'procurement.landed_cost_allocations', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'procurement.purchase_order_line_item_statuses', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'procurement.purchase_order_line_items', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'procurement.purchase_order_statuses', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'procurement.purchase_orders', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'procurement.purchase_suggestions', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'procurement.purchase_suggestion_lines', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'procurement.supplier_invoices', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'procurement.supplier_invoice_lines', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'procurement.supplier_products', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'procurement.suppliers', true, true, true, true),
     -- products schema
@@ -547,6 +564,9 @@ INSERT INTO core.table_access (id, role_id, table_name, can_create, can_read, ca
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'sales.order_fulfillment_statuses', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'sales.order_line_items', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'sales.orders', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'sales.shipments', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'sales.shipment_cartons', true, true, true, true),
+    (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'sales.shipment_carton_lines', true, true, true, true),
     -- workflow schema
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'workflow.action_edges', true, true, true, true),
     (gen_random_uuid(), '54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'workflow.action_permissions', true, true, true, true),
@@ -584,7 +604,15 @@ VALUES
     ('54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'log_audit_entry', true),
     ('54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'release_to_picking', true),
     ('54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'claim_transfer_order', true),
-    ('54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'execute_transfer_order', true)
+    ('54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'execute_transfer_order', true),
+    ('54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'create_shipping_label', true),
+    ('54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'generate_cycle_counts', true),
+    ('54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'generate_replenishment', true),
+    ('54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'forecast_demand', true),
+    ('54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'suggest_purchases', true),
+    ('54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'match_supplier_invoice', true),
+    ('54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'approve_supplier_invoice', true),
+    ('54bb2165-71e1-41a6-af3e-7da4a0e1e2c1', 'reject_supplier_invoice', true)
 ON CONFLICT DO NOTHING;
 
 -- ============================================================================
@@ -618,6 +646,8 @@ INSERT INTO core.table_access (id, role_id, table_name, can_create, can_read, ca
     (gen_random_uuid(), 'b0000000-0000-4000-8000-000000000001', 'products.products', false, true, false, false),
     (gen_random_uuid(), 'b0000000-0000-4000-8000-000000000001', 'sales.order_line_items', false, true, false, false),
     (gen_random_uuid(), 'b0000000-0000-4000-8000-000000000001', 'sales.orders', false, true, false, false),
+    -- Shipments: pack cartons and confirm ship at the dock
+    (gen_random_uuid(), 'b0000000-0000-4000-8000-000000000001', 'sales.shipments', true, true, true, false),
     -- Config settings: read-only so the floor UI can load lever overrides (pick.lotScan etc.)
     (gen_random_uuid(), 'b0000000-0000-4000-8000-000000000001', 'config.settings', false, true, false, false)
 ON CONFLICT DO NOTHING;
@@ -44,6 +44,16 @@ type Outbox struct {
 	Dead        bool
 }
 
+// EventFilter selects outbox rows for QueryEvents. Empty fields do not filter;
+// Since is inclusive and Until exclusive on created_at.
+type EventFilter struct {
+	EntityName string
+	EventType  string
+	Since      *time.Time
+	Until      *time.Time
+	Limit      int
+}
+
 // eventTypeForAction maps a delegate action to the workflow trigger event type,
 // mirroring the action→event_type wiring DelegateHandler.RegisterDomain hard-codes
 // (delegatehandler.go). Cascade buses only ever emit the three CRUD actions; an
@@ -1,10 +1,12 @@
 package outbox
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -166,6 +168,26 @@ func (s *Store) DeletePublished(ctx context.Context, ec sqlx.ExtContext, id uuid
 	return nil
 }
 
+// MarkPublished stamps published_at on a row after its event dispatched
+// successfully, keeping it as event history instead of deleting it. Published rows
+// are skipped by FetchPending and removed by ReapPublished once they age out.
+func (s *Store) MarkPublished(ctx context.Context, ec sqlx.ExtContext, id uuid.UUID, at time.Time) error {
+	data := struct {
+		ID          string    `db:"id"`
+		PublishedAt time.Time `db:"published_at"`
+	}{
+		ID:          id.String(),
+		PublishedAt: at,
+	}
+
+	const q = `UPDATE workflow.cascade_outbox SET published_at = :published_at WHERE id = :id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, ec, q, data); err != nil {
+		return fmt.Errorf("mark published cascade_outbox: %w", err)
+	}
+	return nil
+}
+
 // MarkAttempt records a failed dispatch: it increments attempts, stores the last
 // error, and sets dead when the relay has exhausted its retry budget (dead rows are
 // skipped by FetchPending so they never head-of-line block the queue).
@@ -209,3 +231,74 @@ func (s *Store) Reap(ctx context.Context, ec sqlx.ExtContext, olderThan time.Tim
 	}
 	return n, nil
 }
+
+// ReapPublished deletes published rows older than olderThan (the event history
+// retention window) and returns how many were removed.
+func (s *Store) ReapPublished(ctx context.Context, ec sqlx.ExtContext, olderThan time.Time) (int64, error) {
+	data := struct {
+		OlderThan time.Time `db:"older_than"`
+	}{
+		OlderThan: olderThan,
+	}
+
+	const q = `DELETE FROM workflow.cascade_outbox WHERE published_at IS NOT NULL AND created_at < :older_than`
+
+	n, err := sqldb.NamedExecContextWithCount(ctx, s.log, ec, q, data)
+	if err != nil {
+		return 0, fmt.Errorf("reap published cascade_outbox: %w", err)
+	}
+	return n, nil
+}
+
+// QueryEvents returns the rows matching filter in seq order, whatever their
+// dispatch state, for replaying past events. How far back it reaches depends on
+// the relay keeping published rows (RelayConfig.PublishedRowWindow).
+func (s *Store) QueryEvents(ctx context.Context, ec sqlx.ExtContext, filter EventFilter) ([]Outbox, error) {
+	data := map[string]any{
+		"limit": filter.Limit,
+	}
+
+	var wc []string
+	if filter.EntityName != "" {
+		data["entity_name"] = filter.EntityName
+		wc = append(wc, "entity_name = :entity_name")
+	}
+	if filter.EventType != "" {
+		data["event_type"] = filter.EventType
+		wc = append(wc, "event_type = :event_type")
+	}
+	if filter.Since != nil {
+		data["since"] = *filter.Since
+		wc = append(wc, "created_at >= :since")
+	}
+	if filter.Until != nil {
+		data["until"] = *filter.Until
+		wc = append(wc, "created_at < :until")
+	}
+
+	const q = `
+	SELECT
+		id, seq, domain, action, event_type, entity_name, payload,
+		COALESCE(lineage, CAST('null' AS jsonb)) AS lineage,
+		created_at, attempts, last_error, published_at, dead
+	FROM
+		workflow.cascade_outbox`
+
+	buf := bytes.NewBufferString(q)
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+	buf.WriteString(" ORDER BY seq LIMIT :limit")
+
+	var rows []dbOutbox
+	if err := sqldb.NamedQuerySlice(ctx, s.log, ec, buf.String(), data, &rows); err != nil {
+		return nil, fmt.Errorf("query cascade_outbox events: %w", err)
+	}
+
+	out := make([]Outbox, len(rows))
+	for i, r := range rows {
+		out[i] = toOutbox(r)
+	}
+	return out, nil
+}
@@ -0,0 +1,200 @@
+package sqldb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// ErrOutOfDataScope is returned by CheckDataScope when a write would place a
+// row outside the caller's data scope.
+var ErrOutOfDataScope = errors.New("outside the caller's data scope")
+
+type dataScopeKey struct{}
+
+// DataScope limits reads to rows at the listed warehouses and zones. A nil
+// list leaves that dimension unrestricted.
+type DataScope struct {
+	WarehouseIDs []uuid.UUID
+	ZoneIDs      []uuid.UUID
+}
+
+// Restricted reports whether the scope limits any dimension.
+func (s DataScope) Restricted() bool {
+	return len(s.WarehouseIDs) > 0 || len(s.ZoneIDs) > 0
+}
+
+// ScopeColumns names the columns of a table that place its rows in a
+// warehouse or zone. Location-bound tables name their inventory location
+// columns instead; a row is in scope when any of its locations is.
+type ScopeColumns struct {
+	Warehouse string
+	Zone      string
+	Locations []string
+}
+
+// ScopeByLocation is the ScopeColumns of tables bound to one location through
+// location_id, e.g. inventory items, pick tasks and cycle count items.
+var ScopeByLocation = ScopeColumns{Locations: []string{"location_id"}}
+
+// SetDataScope returns a new context carrying the caller's data scope. The mid
+// layer populates this from the caller's roles after authorization;
+// location-bound repositories read it via ApplyDataScope at the same call
+// sites as ApplyScenarioFilter. An unrestricted scope is not stored.
+func SetDataScope(ctx context.Context, scope DataScope) context.Context {
+	if !scope.Restricted() {
+		return ctx
+	}
+	return context.WithValue(ctx, dataScopeKey{}, scope)
+}
+
+// GetDataScope returns the data scope carried by ctx and a bool indicating
+// whether the caller is restricted at all.
+func GetDataScope(ctx context.Context) (DataScope, bool) {
+	v, ok := ctx.Value(dataScopeKey{}).(DataScope)
+	if !ok || !v.Restricted() {
+		return DataScope{}, false
+	}
+	return v, true
+}
+
+// ApplyDataScope appends a WHERE or AND clause to buf restricting the rows to
+// the warehouses and zones of the data scope in ctx. When ctx carries no
+// scope, buf and data are untouched. Columns may be alias-qualified for
+// multi-table reads.
+//
+// Call site convention: invoke immediately after ApplyScenarioFilter, before
+// any ORDER BY or paging is written.
+func ApplyDataScope(ctx context.Context, buf *bytes.Buffer, data map[string]any, cols ScopeColumns) {
+	scope, ok := GetDataScope(ctx)
+	if !ok {
+		return
+	}
+
+	clause := DataScopeClause(scope, cols, data)
+	if clause == "" {
+		return
+	}
+
+	if hasWhereRe.MatchString(buf.String()) {
+		buf.WriteString(" AND " + clause)
+		return
+	}
+	buf.WriteString(" WHERE " + clause)
+}
+
+// DataScopeClause returns the predicate restricting cols to scope, binding its
+// parameters into data, or "" when cols cannot be restricted by scope.
+func DataScopeClause(scope DataScope, cols ScopeColumns, data map[string]any) string {
+	if len(scope.WarehouseIDs) > 0 {
+		data["scope_warehouse_ids"] = scope.WarehouseIDs
+	}
+	if len(scope.ZoneIDs) > 0 {
+		data["scope_zone_ids"] = scope.ZoneIDs
+	}
+
+	if len(cols.Locations) > 0 {
+		var locConds []string
+		if len(scope.WarehouseIDs) > 0 {
+			locConds = append(locConds, "warehouse_id = ANY(:scope_warehouse_ids)")
+		}
+		if len(scope.ZoneIDs) > 0 {
+			locConds = append(locConds, "zone_id = ANY(:scope_zone_ids)")
+		}
+
+		inScope := "SELECT id FROM inventory.inventory_locations WHERE " + strings.Join(locConds, " AND ")
+
+		conds := make([]string, len(cols.Locations))
+		for i, col := range cols.Locations {
+			conds[i] = col + " IN (" + inScope + ")"
+		}
+		return "(" + strings.Join(conds, " OR ") + ")"
+	}
+
+	var conds []string
+	if cols.Warehouse != "" && len(scope.WarehouseIDs) > 0 {
+		conds = append(conds, cols.Warehouse+" = ANY(:scope_warehouse_ids)")
+	}
+	if cols.Zone != "" && len(scope.ZoneIDs) > 0 {
+		conds = append(conds, cols.Zone+" = ANY(:scope_zone_ids)")
+	}
+	if len(conds) == 0 {
+		return ""
+	}
+	return "(" + strings.Join(conds, " AND ") + ")"
+}
+
+// CheckDataScope returns ErrOutOfDataScope when ctx carries a data scope and a
+// row with the given values would fall outside it, so a caller cannot create
+// or move a row they could not read back. values maps each of cols' columns
+// to the row's id; uuid.Nil stands for NULL. The row is judged by the same
+// predicate ApplyDataScope reads with. When ctx carries no scope the database
+// is not queried.
+//
+// Call site convention: invoke at the top of a location-bound store's Create
+// and Update, with the columns the store reads with.
+func CheckDataScope(ctx context.Context, log *logger.Logger, db sqlx.ExtContext, cols ScopeColumns, values map[string]uuid.UUID) error {
+	scope, ok := GetDataScope(ctx)
+	if !ok {
+		return nil
+	}
+
+	q, data := dataScopeCheckQuery(scope, cols, values)
+	if q == "" {
+		return nil
+	}
+
+	var row struct {
+		InScope bool `db:"in_scope"`
+	}
+	if err := NamedQueryStruct(ctx, log, db, q, data, &row); err != nil {
+		return fmt.Errorf("check data scope: %w", err)
+	}
+
+	if !row.InScope {
+		return ErrOutOfDataScope
+	}
+
+	return nil
+}
+
+// dataScopeCheckQuery builds the query CheckDataScope runs: the scope
+// predicate applied to a single row made of values. It returns "" when cols
+// cannot be restricted by scope.
+func dataScopeCheckQuery(scope DataScope, cols ScopeColumns, values map[string]uuid.UUID) (string, map[string]any) {
+	data := make(map[string]any)
+
+	clause := DataScopeClause(scope, cols, data)
+	if clause == "" {
+		return "", nil
+	}
+
+	var names []string
+	if cols.Warehouse != "" {
+		names = append(names, cols.Warehouse)
+	}
+	if cols.Zone != "" {
+		names = append(names, cols.Zone)
+	}
+	names = append(names, cols.Locations...)
+
+	selects := make([]string, len(names))
+	for i, name := range names {
+		key := fmt.Sprintf("scope_row_%d", i)
+		data[key] = nil
+		if id := values[name]; id != uuid.Nil {
+			data[key] = id.String()
+		}
+		selects[i] = fmt.Sprintf("CAST(:%s AS uuid) AS %s", key, name)
+	}
+
+	q := "SELECT EXISTS (SELECT 1 FROM (SELECT " + strings.Join(selects, ", ") + ") AS scoped_row WHERE " + clause + ") AS in_scope"
+
+	return q, data
+}
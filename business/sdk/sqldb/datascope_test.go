@@ -0,0 +1,132 @@
+package sqldb_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+)
+
+func TestApplyDataScope_NoCtx(t *testing.T) {
+	var buf bytes.Buffer
+	data := map[string]any{}
+
+	sqldb.ApplyDataScope(context.Background(), &buf, data, sqldb.ScopeByLocation)
+
+	if buf.Len() != 0 || len(data) != 0 {
+		t.Fatalf("expected no clause with no scope in ctx, got %q %v", buf.String(), data)
+	}
+}
+
+func TestApplyDataScope_Unrestricted(t *testing.T) {
+	ctx := sqldb.SetDataScope(context.Background(), sqldb.DataScope{})
+
+	if _, ok := sqldb.GetDataScope(ctx); ok {
+		t.Fatal("expected an unrestricted scope not to be carried")
+	}
+}
+
+func TestApplyDataScope_ByLocation(t *testing.T) {
+	warehouseID := uuid.New()
+	ctx := sqldb.SetDataScope(context.Background(), sqldb.DataScope{WarehouseIDs: []uuid.UUID{warehouseID}})
+
+	buf := bytes.NewBufferString("SELECT id FROM inventory.pick_tasks WHERE status = :status")
+	data := map[string]any{"status": "pending"}
+
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeByLocation)
+
+	want := "SELECT id FROM inventory.pick_tasks WHERE status = :status AND " +
+		"(location_id IN (SELECT id FROM inventory.inventory_locations WHERE warehouse_id = ANY(:scope_warehouse_ids)))"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected query\ngot:  %s\nwant: %s", got, want)
+	}
+	if ids, ok := data["scope_warehouse_ids"].([]uuid.UUID); !ok || len(ids) != 1 || ids[0] != warehouseID {
+		t.Fatalf("expected data[scope_warehouse_ids] == [%s], got %v", warehouseID, data["scope_warehouse_ids"])
+	}
+	if _, ok := data["scope_zone_ids"]; ok {
+		t.Fatalf("expected no scope_zone_ids key, got %v", data)
+	}
+}
+
+func TestApplyDataScope_Columns(t *testing.T) {
+	ctx := sqldb.SetDataScope(context.Background(), sqldb.DataScope{
+		WarehouseIDs: []uuid.UUID{uuid.New()},
+		ZoneIDs:      []uuid.UUID{uuid.New()},
+	})
+
+	buf := bytes.NewBufferString("SELECT id FROM inventory.zones")
+	data := map[string]any{}
+
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeColumns{Warehouse: "warehouse_id", Zone: "id"})
+
+	want := "SELECT id FROM inventory.zones WHERE (warehouse_id = ANY(:scope_warehouse_ids) AND id = ANY(:scope_zone_ids))"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected query\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestApplyDataScope_MultipleLocations(t *testing.T) {
+	ctx := sqldb.SetDataScope(context.Background(), sqldb.DataScope{ZoneIDs: []uuid.UUID{uuid.New()}})
+
+	buf := bytes.NewBufferString("SELECT id FROM inventory.transfer_orders")
+	data := map[string]any{}
+
+	sqldb.ApplyDataScope(ctx, buf, data, sqldb.ScopeColumns{Locations: []string{"from_location_id", "to_location_id"}})
+
+	inScope := "SELECT id FROM inventory.inventory_locations WHERE zone_id = ANY(:scope_zone_ids)"
+	want := "SELECT id FROM inventory.transfer_orders WHERE " +
+		"(from_location_id IN (" + inScope + ") OR to_location_id IN (" + inScope + "))"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected query\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestCheckDataScope_NoScope(t *testing.T) {
+	// Without a scope in ctx the database is never touched.
+	err := sqldb.CheckDataScope(context.Background(), nil, nil, sqldb.ScopeByLocation, map[string]uuid.UUID{"location_id": uuid.New()})
+	if err != nil {
+		t.Fatalf("expected no error without a scope, got %v", err)
+	}
+}
+
+func TestDataScopeCheckQuery_Columns(t *testing.T) {
+	warehouseID, zoneID := uuid.New(), uuid.New()
+	scope := sqldb.DataScope{WarehouseIDs: []uuid.UUID{uuid.New()}, ZoneIDs: []uuid.UUID{uuid.New()}}
+
+	q, data := sqldb.DataScopeCheckQuery(scope, sqldb.ScopeColumns{Warehouse: "warehouse_id", Zone: "id"},
+		map[string]uuid.UUID{"warehouse_id": warehouseID, "id": zoneID})
+
+	want := "SELECT EXISTS (SELECT 1 FROM (SELECT CAST(:scope_row_0 AS uuid) AS warehouse_id, CAST(:scope_row_1 AS uuid) AS id) AS scoped_row " +
+		"WHERE (warehouse_id = ANY(:scope_warehouse_ids) AND id = ANY(:scope_zone_ids))) AS in_scope"
+	if q != want {
+		t.Fatalf("unexpected query\ngot:  %s\nwant: %s", q, want)
+	}
+	if data["scope_row_0"] != warehouseID.String() || data["scope_row_1"] != zoneID.String() {
+		t.Fatalf("expected the row's ids bound, got %v", data)
+	}
+}
+
+func TestDataScopeCheckQuery_NullLocation(t *testing.T) {
+	scope := sqldb.DataScope{ZoneIDs: []uuid.UUID{uuid.New()}}
+
+	q, data := sqldb.DataScopeCheckQuery(scope, sqldb.ScopeColumns{Locations: []string{"from_location_id", "to_location_id"}},
+		map[string]uuid.UUID{"from_location_id": uuid.New()})
+
+	if q == "" {
+		t.Fatal("expected a query for location columns")
+	}
+	if v, ok := data["scope_row_1"]; !ok || v != nil {
+		t.Fatalf("expected a missing location bound as NULL, got %v", data)
+	}
+}
+
+func TestDataScopeCheckQuery_Unrestrictable(t *testing.T) {
+	// A zone-only scope does not restrict warehouses.
+	scope := sqldb.DataScope{ZoneIDs: []uuid.UUID{uuid.New()}}
+
+	if q, _ := sqldb.DataScopeCheckQuery(scope, sqldb.ScopeColumns{Warehouse: "id"}, map[string]uuid.UUID{"id": uuid.New()}); q != "" {
+		t.Fatalf("expected no query, got %q", q)
+	}
+}
@@ -0,0 +1,4 @@
+package sqldb
+
+// DataScopeCheckQuery exposes the query CheckDataScope runs.
+var DataScopeCheckQuery = dataScopeCheckQuery
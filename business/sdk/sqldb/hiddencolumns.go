@@ -0,0 +1,25 @@
+package sqldb
+
+import "context"
+
+type hiddenColumnsKey struct{}
+
+// SetHiddenColumns returns a new context carrying the columns the caller may
+// not read, keyed by schema-qualified table name ("products.product_costs").
+// The mid layer populates this from the caller's table access after the
+// permission check; generic readers that build their own column lists (e.g.
+// tablebuilder) read it via GetHiddenColumns so masked columns cannot leak
+// through them.
+func SetHiddenColumns(ctx context.Context, hidden map[string][]string) context.Context {
+	return context.WithValue(ctx, hiddenColumnsKey{}, hidden)
+}
+
+// GetHiddenColumns returns the hidden columns carried by ctx and a bool
+// indicating whether any table has hidden columns.
+func GetHiddenColumns(ctx context.Context) (map[string][]string, bool) {
+	v, ok := ctx.Value(hiddenColumnsKey{}).(map[string][]string)
+	if !ok || len(v) == 0 {
+		return nil, false
+	}
+	return v, true
+}
@@ -50,6 +50,7 @@ func (qb *QueryBuilder) BuildQuery(ds *DataSource, params QueryParams, isPrimary
 
 	// Apply filters
 	query = qb.applyFilters(query, ds.Filters, params)
+	query = qb.applyDataScope(query, ds, params.scope)
 
 	// Apply sorting (only for primary data source)
 	if isPrimary {
@@ -104,6 +105,7 @@ func (qb *QueryBuilder) BuildCountQuery(ds *DataSource, params QueryParams) (str
 
 	// Apply filters
 	query = qb.applyFilters(query, ds.Filters, params)
+	query = qb.applyDataScope(query, ds, params.scope)
 
 	// Select COUNT(*)
 	query = query.Select(goqu.COUNT("*").As("count"))
@@ -483,6 +485,7 @@ func (qb *QueryBuilder) buildMetricQuery(ds *DataSource, params QueryParams, isP
 
 	// 5. Apply filters
 	query = qb.applyFilters(query, ds.Filters, params)
+	query = qb.applyDataScope(query, ds, params.scope)
 
 	// 6. Build GROUP BY if present
 	if len(ds.GroupBy) > 0 {
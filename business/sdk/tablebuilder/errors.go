@@ -45,4 +45,5 @@ var (
 	// Permission errors
 	ErrUnauthorized     = errors.New("unauthorized access")
 	ErrInsufficientRole = errors.New("insufficient role permissions")
+	ErrHiddenColumn     = errors.New("column is hidden by table access")
 )
@@ -0,0 +1,10 @@
+package tablebuilder
+
+import "github.com/timmaaaz/ichor/business/sdk/sqldb"
+
+// WithDataScope returns params limited to scope, as the store does from the
+// request context.
+func WithDataScope(params QueryParams, scope sqldb.DataScope) QueryParams {
+	params.scope = scope
+	return params
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
 	"github.com/timmaaaz/ichor/business/sdk/sqldb/nulltypes"
 )
 
@@ -346,6 +347,10 @@ type QueryParams struct {
 	Page    int            `json:"page,omitempty"`
 	Rows    int            `json:"rows,omitempty"`
 	Dynamic map[string]any `json:"dynamic,omitempty"` // Dynamic filter values
+
+	// scope limits warehouse and zone bound sources to the caller's data
+	// scope. It is set by the store from the request context, never by clients.
+	scope sqldb.DataScope
 }
 
 // =============================================================================
@@ -0,0 +1,178 @@
+package tablebuilder
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RestrictConfig returns a copy of config with the columns in hidden removed
+// from every select list, so a dashboard cannot return a column its caller's
+// table access hides. hidden is keyed by schema-qualified table name
+// ("products.product_costs"); a data source or foreign table without a schema
+// matches on table name alone.
+//
+// Filters, sorts, metrics and group-bys cannot be stripped without changing
+// the result, so a config or query that references a hidden column fails with
+// ErrHiddenColumn instead. config itself is never modified; it is usually a
+// cached, shared value.
+func RestrictConfig(config *Config, params QueryParams, hidden map[string][]string) (*Config, error) {
+	if len(hidden) == 0 {
+		return config, nil
+	}
+
+	r := restriction{hidden: hidden, blocked: make(map[string]bool)}
+
+	restricted := *config
+	restricted.DataSource = make([]DataSource, len(config.DataSource))
+	for i, ds := range config.DataSource {
+		// Views like "orders_base" expose the columns of their table.
+		table := strings.TrimSuffix(ds.Source, "_base")
+
+		cols := r.hiddenColumns(ds.Schema, table)
+		ds.Select.Columns = r.strip(ds.Select.Columns, table, cols, true)
+		ds.Select.ForeignTables = r.stripForeign(ds.Select.ForeignTables)
+
+		restricted.DataSource[i] = ds
+	}
+
+	for _, ds := range restricted.DataSource {
+		for _, f := range ds.Filters {
+			if err := r.check("filter", f.Column); err != nil {
+				return nil, err
+			}
+		}
+		for _, s := range ds.Sort {
+			if err := r.check("sort", s.Column); err != nil {
+				return nil, err
+			}
+		}
+		for _, m := range ds.Metrics {
+			if err := r.check("metric", m.Column); err != nil {
+				return nil, err
+			}
+			if m.Expression != nil {
+				for _, c := range m.Expression.Columns {
+					if err := r.check("metric", c); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+		for _, g := range ds.GroupBy {
+			if err := r.checkGroupBy(g); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, f := range params.Filters {
+		if err := r.check("filter", f.Column); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range params.Sort {
+		if err := r.check("sort", s.Column); err != nil {
+			return nil, err
+		}
+	}
+
+	return &restricted, nil
+}
+
+// restriction collects every name a hidden column can be referenced by while
+// the select lists are stripped.
+type restriction struct {
+	hidden  map[string][]string
+	blocked map[string]bool
+}
+
+// hiddenColumns returns the hidden columns of schema.table, or of any schema's
+// table of that name when schema is empty.
+func (r restriction) hiddenColumns(schema, table string) []string {
+	if schema != "" {
+		return r.hidden[schema+"."+table]
+	}
+
+	var cols []string
+	for key, c := range r.hidden {
+		if strings.HasSuffix(key, "."+table) {
+			cols = append(cols, c...)
+		}
+	}
+	return cols
+}
+
+// strip drops the hidden columns of a table referenced as ref. Base table
+// columns may also be referenced unqualified.
+func (r restriction) strip(cols []ColumnDefinition, ref string, hidden []string, base bool) []ColumnDefinition {
+	if len(hidden) == 0 {
+		return cols
+	}
+
+	for _, h := range hidden {
+		r.blocked[ref+"."+h] = true
+		if base {
+			r.blocked[h] = true
+		}
+	}
+
+	kept := make([]ColumnDefinition, 0, len(cols))
+	for _, col := range cols {
+		if !slices.Contains(hidden, col.Name) {
+			kept = append(kept, col)
+			continue
+		}
+
+		if col.Alias != "" {
+			r.blocked[col.Alias] = true
+		}
+		if col.TableColumn != "" {
+			r.blocked[col.TableColumn] = true
+		}
+	}
+	return kept
+}
+
+func (r restriction) stripForeign(fts []ForeignTable) []ForeignTable {
+	if len(fts) == 0 {
+		return fts
+	}
+
+	out := make([]ForeignTable, len(fts))
+	for i, ft := range fts {
+		ref := getTableOrAlias(ft)
+		ft.Columns = r.strip(ft.Columns, ref, r.hiddenColumns(ft.Schema, ft.Table), false)
+		if ref != ft.Table {
+			// Hidden columns can also be referenced through the table name.
+			for _, h := range r.hiddenColumns(ft.Schema, ft.Table) {
+				r.blocked[ft.Table+"."+h] = true
+			}
+		}
+		ft.ForeignTables = r.stripForeign(ft.ForeignTables)
+		out[i] = ft
+	}
+	return out
+}
+
+func (r restriction) check(use, column string) error {
+	if column != "" && r.blocked[column] {
+		return fmt.Errorf("%s on %q: %w", use, column, ErrHiddenColumn)
+	}
+	return nil
+}
+
+// checkGroupBy rejects group-bys on a hidden column, including raw SQL
+// expressions that mention one.
+func (r restriction) checkGroupBy(g GroupByConfig) error {
+	if !g.Expression {
+		return r.check("group by", g.Column)
+	}
+
+	for ref := range r.blocked {
+		if strings.Contains(ref, ".") && strings.Contains(g.Column, ref) {
+			return fmt.Errorf("group by on %q: %w", ref, ErrHiddenColumn)
+		}
+	}
+	return nil
+}
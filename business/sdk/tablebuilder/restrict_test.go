@@ -0,0 +1,114 @@
+package tablebuilder_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/timmaaaz/ichor/business/sdk/tablebuilder"
+)
+
+func costConfig() *tablebuilder.Config {
+	return &tablebuilder.Config{
+		Title: "Products",
+		DataSource: []tablebuilder.DataSource{{
+			Type:   "query",
+			Source: "products",
+			Schema: "products",
+			Select: tablebuilder.SelectConfig{
+				Columns: []tablebuilder.ColumnDefinition{
+					{Name: "id", TableColumn: "products.id"},
+					{Name: "name", TableColumn: "products.name"},
+				},
+				ForeignTables: []tablebuilder.ForeignTable{{
+					Table:            "product_costs",
+					Schema:           "products",
+					RelationshipFrom: "products.id",
+					RelationshipTo:   "product_costs.product_id",
+					Columns: []tablebuilder.ColumnDefinition{
+						{Name: "selling_price", TableColumn: "product_costs.selling_price"},
+						{Name: "purchase_cost", Alias: "cost", TableColumn: "product_costs.purchase_cost"},
+					},
+				}},
+			},
+		}},
+	}
+}
+
+var hiddenCosts = map[string][]string{"products.product_costs": {"purchase_cost", "landed_cost"}}
+
+func TestRestrictConfig_StripsHiddenColumns(t *testing.T) {
+	config := costConfig()
+
+	restricted, err := tablebuilder.RestrictConfig(config, tablebuilder.QueryParams{}, hiddenCosts)
+	if err != nil {
+		t.Fatalf("restrict: %v", err)
+	}
+
+	cols := restricted.DataSource[0].Select.ForeignTables[0].Columns
+	if len(cols) != 1 || cols[0].Name != "selling_price" {
+		t.Errorf("foreign columns = %+v, want only selling_price", cols)
+	}
+	if len(restricted.DataSource[0].Select.Columns) != 2 {
+		t.Errorf("base columns changed: %+v", restricted.DataSource[0].Select.Columns)
+	}
+
+	// The shared config must not be modified.
+	if len(config.DataSource[0].Select.ForeignTables[0].Columns) != 2 {
+		t.Error("RestrictConfig modified its input")
+	}
+
+	qb := tablebuilder.NewQueryBuilder()
+	sql, _, err := qb.BuildQuery(&restricted.DataSource[0], tablebuilder.QueryParams{}, true)
+	if err != nil {
+		t.Fatalf("build query: %v", err)
+	}
+	assertNoSQL(t, sql, "purchase_cost")
+	assertSQL(t, sql, "selling_price")
+}
+
+func TestRestrictConfig_RejectsHiddenReferences(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*tablebuilder.Config)
+		params tablebuilder.QueryParams
+	}{
+		{
+			name:   "runtime filter on alias",
+			params: tablebuilder.QueryParams{Filters: []tablebuilder.Filter{{Column: "cost", Operator: "gt", Value: 1}}},
+		},
+		{
+			name:   "runtime sort on table column",
+			params: tablebuilder.QueryParams{Sort: []tablebuilder.Sort{{Column: "product_costs.purchase_cost", Direction: "desc"}}},
+		},
+		{
+			name: "metric expression",
+			mutate: func(c *tablebuilder.Config) {
+				c.DataSource[0].Metrics = []tablebuilder.MetricConfig{{
+					Name:       "margin",
+					Function:   "sum",
+					Expression: &tablebuilder.ExpressionConfig{Operator: "subtract", Columns: []string{"product_costs.selling_price", "product_costs.landed_cost"}},
+				}}
+			},
+		},
+		{
+			name: "raw group by expression",
+			mutate: func(c *tablebuilder.Config) {
+				c.DataSource[0].GroupBy = []tablebuilder.GroupByConfig{{Column: "round(product_costs.purchase_cost)", Expression: true}}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := costConfig()
+			if tt.mutate != nil {
+				tt.mutate(config)
+			}
+
+			_, err := tablebuilder.RestrictConfig(config, tt.params, hiddenCosts)
+			if !errors.Is(err, tablebuilder.ErrHiddenColumn) {
+				t.Errorf("err = %v, want ErrHiddenColumn", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package tablebuilder
+
+import (
+	"github.com/doug-martin/goqu/v9"
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+)
+
+// scopedSources are the tables whose rows are limited to the caller's data
+// scope when they are the base table of a data source, keyed by
+// schema-qualified name. Joined foreign tables are not limited separately:
+// they only contribute columns to base rows that are already in scope.
+var scopedSources = map[string]sqldb.ScopeColumns{
+	"inventory.warehouses":             {Warehouse: "id"},
+	"inventory.zones":                  {Warehouse: "warehouse_id", Zone: "id"},
+	"inventory.inventory_locations":    {Warehouse: "warehouse_id", Zone: "zone_id"},
+	"inventory.inventory_items":        sqldb.ScopeByLocation,
+	"inventory.inventory_reservations": sqldb.ScopeByLocation,
+	"inventory.inventory_transactions": sqldb.ScopeByLocation,
+	"inventory.inventory_adjustments":  sqldb.ScopeByLocation,
+	"inventory.lot_locations":          sqldb.ScopeByLocation,
+	"inventory.pick_tasks":             sqldb.ScopeByLocation,
+	"inventory.put_away_tasks":         sqldb.ScopeByLocation,
+	"inventory.cycle_count_items":      sqldb.ScopeByLocation,
+	"inventory.transfer_orders":        {Locations: []string{"from_location_id", "to_location_id"}},
+	"inventory.replenishment_tasks":    {Locations: []string{"from_location_id", "to_location_id"}},
+	"inventory.demand_forecasts":       sqldb.ScopeByLocation,
+	"procurement.purchase_suggestions": {Warehouse: "delivery_warehouse_id"},
+}
+
+// applyDataScope limits the base table of ds to scope.
+func (qb *QueryBuilder) applyDataScope(query *goqu.SelectDataset, ds *DataSource, scope sqldb.DataScope) *goqu.SelectDataset {
+	if !scope.Restricted() {
+		return query
+	}
+
+	schema := ds.Schema
+	if schema == "" {
+		schema = "public"
+	}
+
+	cols, ok := scopedSources[schema+"."+ds.Source]
+	if !ok {
+		return query
+	}
+
+	warehouses := toStrings(scope.WarehouseIDs)
+	zones := toStrings(scope.ZoneIDs)
+
+	if len(cols.Locations) > 0 {
+		inScope := qb.dialect.From("inventory.inventory_locations").Select("id")
+		if len(warehouses) > 0 {
+			inScope = inScope.Where(goqu.C("warehouse_id").In(warehouses))
+		}
+		if len(zones) > 0 {
+			inScope = inScope.Where(goqu.C("zone_id").In(zones))
+		}
+
+		conds := make([]goqu.Expression, len(cols.Locations))
+		for i, name := range cols.Locations {
+			conds[i] = goqu.T(ds.Source).Col(name).In(inScope)
+		}
+		return query.Where(goqu.Or(conds...))
+	}
+
+	if cols.Warehouse != "" && len(warehouses) > 0 {
+		query = query.Where(goqu.T(ds.Source).Col(cols.Warehouse).In(warehouses))
+	}
+	if cols.Zone != "" && len(zones) > 0 {
+		query = query.Where(goqu.T(ds.Source).Col(cols.Zone).In(zones))
+	}
+	return query
+}
+
+func toStrings(ids []uuid.UUID) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.String()
+	}
+	return strs
+}
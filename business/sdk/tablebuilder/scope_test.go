@@ -0,0 +1,83 @@
+package tablebuilder_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/business/sdk/tablebuilder"
+)
+
+func TestBuildQuery_DataScope(t *testing.T) {
+	warehouseID := uuid.MustParse("11111111-1111-4111-8111-111111111111")
+	zoneID := uuid.MustParse("22222222-2222-4222-8222-222222222222")
+
+	tests := []struct {
+		name    string
+		ds      tablebuilder.DataSource
+		scope   sqldb.DataScope
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "unrestricted",
+			ds:      minimalDS("inventory_items", "inventory"),
+			notWant: []string{"inventory_locations"},
+		},
+		{
+			name:  "location bound by warehouse",
+			ds:    minimalDS("inventory_items", "inventory"),
+			scope: sqldb.DataScope{WarehouseIDs: []uuid.UUID{warehouseID}},
+			want: []string{
+				`"inventory_items"."location_id" IN ((SELECT "id" FROM "inventory"."inventory_locations" WHERE ("warehouse_id" IN ('` + warehouseID.String() + `')))))`,
+			},
+			notWant: []string{"zone_id"},
+		},
+		{
+			name:  "transfer orders match either end",
+			ds:    minimalDS("transfer_orders", "inventory"),
+			scope: sqldb.DataScope{ZoneIDs: []uuid.UUID{zoneID}},
+			want: []string{
+				`"transfer_orders"."from_location_id" IN`,
+				` OR ("transfer_orders"."to_location_id" IN`,
+				`"zone_id" IN ('` + zoneID.String() + `')`,
+			},
+		},
+		{
+			name:  "zones by warehouse and id",
+			ds:    minimalDS("zones", "inventory"),
+			scope: sqldb.DataScope{WarehouseIDs: []uuid.UUID{warehouseID}, ZoneIDs: []uuid.UUID{zoneID}},
+			want: []string{
+				`"zones"."warehouse_id" IN ('` + warehouseID.String() + `')`,
+				`"zones"."id" IN ('` + zoneID.String() + `')`,
+			},
+		},
+		{
+			name:    "unscoped table",
+			ds:      minimalDS("products", "products"),
+			scope:   sqldb.DataScope{WarehouseIDs: []uuid.UUID{warehouseID}},
+			notWant: []string{"inventory_locations", warehouseID.String()},
+		},
+	}
+
+	qb := tablebuilder.NewQueryBuilder()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tablebuilder.WithDataScope(tablebuilder.QueryParams{}, tt.scope)
+
+			sql, _, err := qb.BuildQuery(&tt.ds, params, true)
+			if err != nil {
+				t.Fatalf("BuildQuery: %v", err)
+			}
+			assertSQL(t, sql, tt.want...)
+			assertNoSQL(t, sql, tt.notWant...)
+
+			count, _, err := qb.BuildCountQuery(&tt.ds, params)
+			if err != nil {
+				t.Fatalf("BuildCountQuery: %v", err)
+			}
+			assertSQL(t, count, tt.want...)
+			assertNoSQL(t, count, tt.notWant...)
+		})
+	}
+}
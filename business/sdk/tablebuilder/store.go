@@ -10,6 +10,7 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/timmaaaz/ichor/business/domain/introspectionbus"
 	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
 	"github.com/timmaaaz/ichor/foundation/logger"
 )
 
@@ -56,6 +57,13 @@ func (s *Store) FetchTableDataCount(ctx context.Context, config *Config, params
 		return 0, fmt.Errorf("validate config: %w", err)
 	}
 
+	params.scope, _ = sqldb.GetDataScope(ctx)
+
+	config, err := restrictToCaller(ctx, config, params)
+	if err != nil {
+		return 0, err
+	}
+
 	if len(config.DataSource) == 0 {
 		return 0, fmt.Errorf("no data sources defined")
 	}
@@ -68,6 +76,7 @@ func (s *Store) FetchTableDataCount(ctx context.Context, config *Config, params
 		Filters: params.Filters,
 		Sort:    params.Sort, // Sort doesn't affect count, but keep for consistency
 		Dynamic: params.Dynamic,
+		scope:   params.scope,
 		// Explicitly exclude Page and Rows
 	}
 
@@ -79,6 +88,22 @@ func (s *Store) FetchTableDataCount(ctx context.Context, config *Config, params
 	return count, nil
 }
 
+// restrictToCaller removes the columns hidden from the caller, as carried on
+// ctx by the authorization middleware, from config.
+func restrictToCaller(ctx context.Context, config *Config, params QueryParams) (*Config, error) {
+	hidden, ok := sqldb.GetHiddenColumns(ctx)
+	if !ok {
+		return config, nil
+	}
+
+	restricted, err := RestrictConfig(config, params, hidden)
+	if err != nil {
+		return nil, fmt.Errorf("restrict columns: %w", err)
+	}
+
+	return restricted, nil
+}
+
 // FetchTableData executes the table configuration and returns the data
 func (s *Store) FetchTableData(ctx context.Context, config *Config, params QueryParams) (*TableData, error) {
 	startTime := time.Now()
@@ -87,6 +112,13 @@ func (s *Store) FetchTableData(ctx context.Context, config *Config, params Query
 		return nil, fmt.Errorf("validate config: %w", err)
 	}
 
+	params.scope, _ = sqldb.GetDataScope(ctx)
+
+	config, err := restrictToCaller(ctx, config, params)
+	if err != nil {
+		return nil, err
+	}
+
 	result := &TableData{
 		Data: make([]TableRow, 0),
 		Meta: MetaData{
@@ -60,6 +60,7 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/sales/orderfulfillmentstatusbus"
 	"github.com/timmaaaz/ichor/business/domain/sales/orderlineitemsbus"
 	"github.com/timmaaaz/ichor/business/domain/sales/ordersbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
 	"github.com/timmaaaz/ichor/business/domain/workflow/alertbus"
 	"github.com/timmaaaz/ichor/business/sdk/tablebuilder"
 
@@ -141,6 +142,7 @@ type SeedData struct {
 	Customers                     []customersbus.Customers
 	Orders                        []ordersbus.Order
 	OrderLineItems                []orderlineitemsbus.OrderLineItem
+	Shipments                     []shipmentbus.Shipment
 	TableBuilderConfigs           []tablebuilder.StoredConfig
 	Forms                         []formbus.Form
 	FormFields                    []formfieldbus.FormField
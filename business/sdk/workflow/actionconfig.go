@@ -0,0 +1,96 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ActionTemplateContext builds the context an action's config templates are
+// resolved against: the execution context, the triggering row's data, and its
+// field changes as old_<field> and new_<field>.
+func ActionTemplateContext(execCtx ActionExecutionContext) TemplateContext {
+	tmplCtx := make(TemplateContext)
+
+	tmplCtx["entity_id"] = execCtx.EntityID
+	tmplCtx["entity_name"] = execCtx.EntityName
+	tmplCtx["event_type"] = execCtx.EventType
+	tmplCtx["timestamp"] = execCtx.Timestamp
+	tmplCtx["user_id"] = execCtx.UserID
+	if execCtx.RuleID != nil {
+		tmplCtx["rule_id"] = *execCtx.RuleID
+	}
+	tmplCtx["rule_name"] = execCtx.RuleName
+	tmplCtx["execution_id"] = execCtx.ExecutionID
+
+	for k, v := range execCtx.RawData {
+		tmplCtx[k] = v
+	}
+
+	for fieldName, change := range execCtx.FieldChanges {
+		tmplCtx["old_"+fieldName] = change.OldValue
+		tmplCtx["new_"+fieldName] = change.NewValue
+	}
+
+	return tmplCtx
+}
+
+// ValidateConfigID checks an optional id in action config at save time. Empty
+// and templated values pass; templates are checked by ResolveConfigID when
+// the action runs.
+func ValidateConfigID(name string, value string) error {
+	if value == "" || strings.Contains(value, "{{") {
+		return nil
+	}
+	if _, err := uuid.Parse(value); err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return nil
+}
+
+// ResolveConfigID resolves an optional id in action config. Empty means none.
+// A templated value, such as {{entity_id}} or {{product_id}}, is resolved
+// against the execution context and must come out as a UUID.
+func ResolveConfigID(name string, value string, execCtx ActionExecutionContext) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	resolved := value
+	if strings.Contains(value, "{{") {
+		opts := DefaultTemplateProcessingOptions()
+		opts.StrictMode = true
+
+		result := NewTemplateProcessor(opts).ProcessTemplate(value, ActionTemplateContext(execCtx))
+		if len(result.Errors) > 0 {
+			return nil, fmt.Errorf("resolve %s %q: %s", name, value, strings.Join(result.Errors, "; "))
+		}
+		resolved = fmt.Sprint(result.Processed)
+	}
+
+	id, err := uuid.Parse(resolved)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", name, resolved, err)
+	}
+	return &id, nil
+}
+
+// ActingUser returns the user an action's writes are attributed to: the
+// triggering user, or the configured fallback for triggers that carry none,
+// as scheduled triggers do. The fallback field is named by name.
+func ActingUser(execCtx ActionExecutionContext, name string, fallback string) (uuid.UUID, error) {
+	if execCtx.UserID != uuid.Nil {
+		return execCtx.UserID, nil
+	}
+
+	if fallback == "" {
+		return uuid.Nil, fmt.Errorf("no user to attribute the action to; set %s", name)
+	}
+
+	id, err := uuid.Parse(fallback)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return id, nil
+}
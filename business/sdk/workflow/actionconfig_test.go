@@ -0,0 +1,79 @@
+package workflow_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+)
+
+func TestResolveConfigID(t *testing.T) {
+	t.Parallel()
+
+	entityID, productID, static := uuid.New(), uuid.New(), uuid.New()
+	execCtx := workflow.ActionExecutionContext{
+		EntityID: entityID,
+		RawData: map[string]any{
+			"product_id": productID.String(),
+			"name":       "not an id",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		value     string
+		want      *uuid.UUID
+		errSubstr string
+	}{
+		{name: "empty", value: ""},
+		{name: "static", value: static.String(), want: &static},
+		{name: "entity", value: "{{entity_id}}", want: &entityID},
+		{name: "row field", value: "{{product_id}}", want: &productID},
+		{name: "not a uuid", value: "{{name}}", errSubstr: "invalid product_id"},
+		{name: "missing variable", value: "{{location_id}}", errSubstr: "Missing variable: location_id"},
+		{name: "bad static", value: "nope", errSubstr: "invalid product_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := workflow.ResolveConfigID("product_id", tt.value, execCtx)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActingUser(t *testing.T) {
+	t.Parallel()
+
+	trigger, fallback := uuid.New(), uuid.New()
+
+	got, err := workflow.ActingUser(workflow.ActionExecutionContext{UserID: trigger}, "created_by", fallback.String())
+	if err != nil || got != trigger {
+		t.Fatalf("triggering user: got %s, %v; want %s", got, err, trigger)
+	}
+
+	got, err = workflow.ActingUser(workflow.ActionExecutionContext{}, "created_by", fallback.String())
+	if err != nil || got != fallback {
+		t.Fatalf("fallback: got %s, %v; want %s", got, err, fallback)
+	}
+
+	if _, err := workflow.ActingUser(workflow.ActionExecutionContext{}, "created_by", ""); err == nil || !strings.Contains(err.Error(), "set created_by") {
+		t.Fatalf("no user: got %v", err)
+	}
+
+	if _, err := workflow.ActingUser(workflow.ActionExecutionContext{}, "created_by", "nope"); err == nil || !strings.Contains(err.Error(), "invalid created_by") {
+		t.Fatalf("bad fallback: got %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+package workflow
+
+// CALL EDGES
+//
+// A call_workflow action starts another rule's graph as a child workflow. That is an
+// inter-rule edge the trigger-based detector in cascade_detect.go cannot see: the called
+// rule runs whether or not its own trigger would match, and whether or not it is active.
+// Two things follow:
+//
+//	Call cycles — A calls B calls A recurses with no database write in between, so no
+//	trigger gate can stop it. The runtime refuses such a call (WorkflowLineage.CallStack
+//	in the temporal package), so a call cycle through the candidate can never run and is
+//	reported as an ERROR. A call to a rule that does not exist is an ERROR too.
+//
+//	Cascades through a callee — whatever the called rule writes, the caller effectively
+//	writes. A rule's node therefore carries its callees' modifications, transitively, so
+//	a trigger cascade that runs through a call still closes a loop in the inter-rule graph.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// CallWorkflowActionType is the action type that starts another rule's graph.
+const CallWorkflowActionType = "call_workflow"
+
+// CalledRuleID returns the rule a call_workflow action starts. It reports false for any
+// other action type and for a config without a valid rule_id.
+func CalledRuleID(actionType string, config json.RawMessage) (uuid.UUID, bool) {
+	if actionType != CallWorkflowActionType {
+		return uuid.Nil, false
+	}
+
+	var cfg struct {
+		RuleID string `json:"rule_id"`
+	}
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(cfg.RuleID)
+	if err != nil || id == uuid.Nil {
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+// =============================================================================
+// Call graph classification (pure)
+// =============================================================================
+
+// callGraph maps a rule to the rules its active call_workflow actions start.
+type callGraph map[uuid.UUID][]uuid.UUID
+
+// classifyCalls reports the call-edge errors for the candidate: every call path that
+// leads back to the candidate, and every call to a rule that does not exist. missing
+// holds the called rule IDs that could not be found.
+func classifyCalls(calls callGraph, names map[uuid.UUID]string, missing map[uuid.UUID]bool, candidateID uuid.UUID) []CascadeFinding {
+	var findings []CascadeFinding
+
+	nameOf := func(id uuid.UUID) string {
+		if n, ok := names[id]; ok {
+			return n
+		}
+		return id.String()
+	}
+
+	for _, callee := range calls[candidateID] {
+		if missing[callee] {
+			findings = append(findings, CascadeFinding{
+				RuleIDs: []uuid.UUID{candidateID, callee},
+				Reason:  fmt.Sprintf("call_workflow references rule %s, which does not exist", callee),
+			})
+		}
+	}
+
+	all := map[uuid.UUID]bool{}
+	for from, tos := range calls {
+		all[from] = true
+		for _, to := range tos {
+			all[to] = true
+		}
+	}
+
+	if path := findCyclePath(candidateID, calls, all); path != nil {
+		ruleNames := make([]string, len(path))
+		for i, id := range path {
+			ruleNames[i] = nameOf(id)
+		}
+		findings = append(findings, CascadeFinding{
+			RuleIDs:   path,
+			RuleNames: ruleNames,
+			Reason:    pathString(ruleNames) + " — call_workflow cycle: a rule would call itself, which the runtime refuses",
+		})
+	}
+
+	return findings
+}
+
+// =============================================================================
+// Called-rule loading (the impure boundary)
+// =============================================================================
+
+// calledRule is a rule started by call_workflow, loaded once per analysis.
+type calledRule struct {
+	name    string
+	found   bool
+	actions []RuleActionView // active actions only
+}
+
+// calledRules lazily loads the rules that call_workflow actions start, so a rule called
+// from several places is queried once.
+type calledRules struct {
+	b     *Business
+	rules map[uuid.UUID]calledRule
+}
+
+func newCalledRules(b *Business) *calledRules {
+	return &calledRules{b: b, rules: map[uuid.UUID]calledRule{}}
+}
+
+// get loads a called rule. A rule that does not exist comes back with found == false.
+func (c *calledRules) get(ctx context.Context, id uuid.UUID) (calledRule, error) {
+	if r, ok := c.rules[id]; ok {
+		return r, nil
+	}
+
+	rule, err := c.b.QueryRuleByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			c.rules[id] = calledRule{}
+			return calledRule{}, nil
+		}
+		return calledRule{}, fmt.Errorf("detectcascadeloops: query called rule[%s]: %w", id, err)
+	}
+
+	views, err := c.b.QueryRoleActionsViewByRuleID(ctx, id)
+	if err != nil {
+		return calledRule{}, fmt.Errorf("detectcascadeloops: query actions for called rule[%s]: %w", id, err)
+	}
+
+	actions := make([]RuleActionView, 0, len(views))
+	for _, v := range views {
+		if v.IsActive {
+			actions = append(actions, v)
+		}
+	}
+
+	r := calledRule{name: rule.Name, found: true, actions: actions}
+	c.rules[id] = r
+	return r, nil
+}
+
+// analyze walks call_workflow actions outward from the candidate and classifies the
+// resulting call graph.
+func (c *calledRules) analyze(ctx context.Context, cand CandidateRule) ([]CascadeFinding, error) {
+	calls := callGraph{}
+	names := map[uuid.UUID]string{cand.RuleID: cand.Name}
+	missing := map[uuid.UUID]bool{}
+
+	for _, a := range cand.Actions {
+		if id, ok := CalledRuleID(a.ActionType, a.Config); ok {
+			calls[cand.RuleID] = append(calls[cand.RuleID], id)
+		}
+	}
+
+	queue := append([]uuid.UUID(nil), calls[cand.RuleID]...)
+	seen := map[uuid.UUID]bool{cand.RuleID: true}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		r, err := c.get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !r.found {
+			missing[id] = true
+			continue
+		}
+		names[id] = r.name
+
+		for _, a := range r.actions {
+			if callee, ok := CalledRuleID(a.TemplateActionType, a.ActionConfig); ok {
+				calls[id] = append(calls[id], callee)
+				queue = append(queue, callee)
+			}
+		}
+	}
+
+	return classifyCalls(calls, names, missing, cand.RuleID), nil
+}
+
+// mods returns the modifications a called rule makes, including those of the rules it
+// calls in turn. visiting holds the rules on the current call path so a cycle ends the
+// walk instead of recursing forever (the cycle itself is reported by analyze).
+func (c *calledRules) mods(ctx context.Context, reg *ActionRegistry, id uuid.UUID, visiting map[uuid.UUID]bool) ([]EntityModification, error) {
+	if visiting[id] {
+		return nil, nil
+	}
+	visiting[id] = true
+	defer delete(visiting, id)
+
+	r, err := c.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var mods []EntityModification
+	for _, a := range r.actions {
+		mods = append(mods, modsFor(reg, a.TemplateActionType, a.ActionConfig)...)
+
+		if callee, ok := CalledRuleID(a.TemplateActionType, a.ActionConfig); ok {
+			calleeMods, err := c.mods(ctx, reg, callee, visiting)
+			if err != nil {
+				return nil, err
+			}
+			mods = append(mods, calleeMods...)
+		}
+	}
+
+	return mods, nil
+}
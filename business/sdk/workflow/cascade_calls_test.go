@@ -0,0 +1,77 @@
+package workflow
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCalledRuleID(t *testing.T) {
+	id := uuid.New()
+
+	got, ok := CalledRuleID(CallWorkflowActionType, json.RawMessage(`{"rule_id": "`+id.String()+`"}`))
+	if !ok || got != id {
+		t.Fatalf("CalledRuleID = %s, %v; want %s, true", got, ok, id)
+	}
+
+	for name, tc := range map[string]struct {
+		actionType string
+		config     string
+	}{
+		"other action type": {"create_alert", `{"rule_id": "` + id.String() + `"}`},
+		"missing rule_id":   {CallWorkflowActionType, `{}`},
+		"invalid rule_id":   {CallWorkflowActionType, `{"rule_id": "not-a-uuid"}`},
+		"nil rule_id":       {CallWorkflowActionType, `{"rule_id": "` + uuid.Nil.String() + `"}`},
+		"invalid json":      {CallWorkflowActionType, `{`},
+	} {
+		if _, ok := CalledRuleID(tc.actionType, json.RawMessage(tc.config)); ok {
+			t.Errorf("%s: CalledRuleID should report false", name)
+		}
+	}
+}
+
+func TestClassifyCalls(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	names := map[uuid.UUID]string{a: "A", b: "B", c: "C"}
+
+	t.Run("chain without cycle", func(t *testing.T) {
+		findings := classifyCalls(callGraph{a: {b}, b: {c}}, names, nil, a)
+		if len(findings) != 0 {
+			t.Fatalf("expected no findings, got %+v", findings)
+		}
+	})
+
+	t.Run("self call", func(t *testing.T) {
+		findings := classifyCalls(callGraph{a: {a}}, names, nil, a)
+		if len(findings) != 1 || !strings.Contains(findings[0].Reason, "A -> A") {
+			t.Fatalf("expected a self-call cycle, got %+v", findings)
+		}
+	})
+
+	t.Run("cycle through callee", func(t *testing.T) {
+		findings := classifyCalls(callGraph{a: {b}, b: {c}, c: {a}}, names, nil, a)
+		if len(findings) != 1 {
+			t.Fatalf("expected one finding, got %+v", findings)
+		}
+		if got := strings.Join(findings[0].RuleNames, ","); got != "A,B,C" {
+			t.Errorf("cycle path = %s, want A,B,C", got)
+		}
+	})
+
+	t.Run("cycle not involving candidate", func(t *testing.T) {
+		findings := classifyCalls(callGraph{a: {b}, b: {c}, c: {b}}, names, nil, a)
+		if len(findings) != 0 {
+			t.Fatalf("a pre-existing callee cycle should not block the candidate, got %+v", findings)
+		}
+	})
+
+	t.Run("missing callee", func(t *testing.T) {
+		missing := uuid.New()
+		findings := classifyCalls(callGraph{a: {missing}}, names, map[uuid.UUID]bool{missing: true}, a)
+		if len(findings) != 1 || !strings.Contains(findings[0].Reason, "does not exist") {
+			t.Fatalf("expected a missing-rule finding, got %+v", findings)
+		}
+	})
+}
@@ -139,75 +139,13 @@ type fieldProduction struct {
 }
 
 // =============================================================================
-// Value comparison — mirrors TriggerProcessor.compareValues / toFloat64 exactly
+// Value comparison — shared with the runtime evaluator (conditions.go)
 // =============================================================================
 
-func valuesEqual(a, b any) bool {
-	if a == nil || b == nil {
-		return a == b
-	}
-	return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b)
-}
-
-// numFloat64 mirrors TriggerProcessor.toFloat64 (numeric types only, no string parsing),
-// distinct from the package's template toFloat64 which coerces strings.
-func numFloat64(v any) (float64, bool) {
-	switch val := v.(type) {
-	case float64:
-		return val, true
-	case float32:
-		return float64(val), true
-	case int:
-		return float64(val), true
-	case int32:
-		return float64(val), true
-	case int64:
-		return float64(val), true
-	case json.Number:
-		f, err := val.Float64()
-		return f, err == nil
-	default:
-		return 0, false
-	}
-}
-
-// matchKnown evaluates a non-change operator against a statically-known produced value,
-// mirroring the runtime evaluator's per-operator semantics.
+// matchKnown evaluates a non-change operator against a statically-known produced value
+// with the runtime evaluator's own operator semantics.
 func matchKnown(op string, produced, condVal any) bool {
-	switch op {
-	case OperatorEquals:
-		return valuesEqual(produced, condVal)
-	case OperatorNotEquals:
-		return !valuesEqual(produced, condVal)
-	case OperatorGreaterThan, OperatorLessThan:
-		af, aok := numFloat64(produced)
-		bf, bok := numFloat64(condVal)
-		if aok && bok {
-			if op == OperatorGreaterThan {
-				return af > bf
-			}
-			return af < bf
-		}
-		if op == OperatorGreaterThan {
-			return fmt.Sprintf("%v", produced) > fmt.Sprintf("%v", condVal)
-		}
-		return fmt.Sprintf("%v", produced) < fmt.Sprintf("%v", condVal)
-	case OperatorContains:
-		ps, ok1 := produced.(string)
-		cs, ok2 := condVal.(string)
-		return ok1 && ok2 && strings.Contains(ps, cs)
-	case OperatorIn:
-		if vals, ok := condVal.([]any); ok {
-			for _, v := range vals {
-				if valuesEqual(produced, v) {
-					return true
-				}
-			}
-		}
-		return false
-	default:
-		return false
-	}
+	return matchOperator(op, produced, condVal)
 }
 
 // isConvergentLatch reports whether an operator is a fixed-point latch — the only operators
@@ -314,6 +252,7 @@ func evalGate(c FieldCondition, prod map[string]fieldProduction, consumerEvent s
 	default:
 		// equals / not_equals / greater_than / less_than / contains / in read the current
 		// value, which may be pre-existing state when the producer doesn't write the field.
+		// An expression condition names no single field, so it is always indeterminate.
 		if !fp.writes {
 			return gateIndeterminate
 		}
@@ -736,19 +675,27 @@ func pathString(names []string) string {
 
 // DetectCascadeLoops builds the inter-rule cascade graph over the currently-active rules
 // overlaid with the candidate (the rule being saved/activated) and returns the three-tier
-// analysis. Active-only scope (DESIGN §10): an inactive candidate is a no-op. The registry
-// supplies each action's mutation manifest (GetEntityModifications).
+// analysis. Active-only scope (DESIGN §10): an inactive candidate is a no-op apart from the
+// call_workflow checks (cascade_calls.go), which apply to inactive rules too because a called
+// rule runs whether or not it is active. The registry supplies each action's mutation
+// manifest (GetEntityModifications).
 func (b *Business) DetectCascadeLoops(ctx context.Context, reg *ActionRegistry, cand CandidateRule) (CascadeAnalysis, error) {
 	ctx, span := otel.AddSpan(ctx, "business.workflowbus.detectcascadeloops")
 	defer span.End()
 
+	calls := newCalledRules(b)
+	callErrors, err := calls.analyze(ctx, cand)
+	if err != nil {
+		return CascadeAnalysis{}, err
+	}
+
 	// Inactive candidate (draft save / deactivation) cannot close a loop against the active set.
 	if !cand.IsActive {
-		return CascadeAnalysis{}, nil
+		return CascadeAnalysis{Errors: callErrors}, nil
 	}
 	if reg == nil {
 		// No registry → no manifests → no edges to analyze. Fail-soft (don't block saves).
-		return CascadeAnalysis{}, nil
+		return CascadeAnalysis{Errors: callErrors}, nil
 	}
 
 	views, err := b.QueryAutomationRulesView(ctx) // active-only (WHERE is_active = true)
@@ -761,24 +708,28 @@ func (b *Business) DetectCascadeLoops(ctx context.Context, reg *ActionRegistry,
 		if v.ID == cand.RuleID {
 			continue // overlay: the candidate replaces its persisted version
 		}
-		n, err := b.nodeFromView(ctx, reg, v)
+		n, err := b.nodeFromView(ctx, reg, calls, v)
 		if err != nil {
 			return CascadeAnalysis{}, err
 		}
 		nodes = append(nodes, n)
 	}
 
-	candNode, err := b.candidateNode(ctx, reg, cand)
+	candNode, err := b.candidateNode(ctx, reg, calls, cand)
 	if err != nil {
 		return CascadeAnalysis{}, err
 	}
 	nodes = append(nodes, candNode)
 
-	return classifyCascades(nodes, cand.RuleID), nil
+	analysis := classifyCascades(nodes, cand.RuleID)
+	analysis.Errors = append(callErrors, analysis.Errors...)
+
+	return analysis, nil
 }
 
-// nodeFromView resolves an active rule view into a graph node (trigger + outgoing manifests).
-func (b *Business) nodeFromView(ctx context.Context, reg *ActionRegistry, v AutomationRuleView) (ruleNode, error) {
+// nodeFromView resolves an active rule view into a graph node (trigger + outgoing manifests,
+// including those of any rules it calls).
+func (b *Business) nodeFromView(ctx context.Context, reg *ActionRegistry, calls *calledRules, v AutomationRuleView) (ruleNode, error) {
 	actions, err := b.QueryRoleActionsViewByRuleID(ctx, v.ID)
 	if err != nil {
 		return ruleNode{}, fmt.Errorf("detectcascadeloops: query actions for rule[%s]: %w", v.ID, err)
@@ -790,6 +741,14 @@ func (b *Business) nodeFromView(ctx context.Context, reg *ActionRegistry, v Auto
 			continue
 		}
 		mods = append(mods, modsFor(reg, a.TemplateActionType, a.ActionConfig)...)
+
+		if callee, ok := CalledRuleID(a.TemplateActionType, a.ActionConfig); ok {
+			calleeMods, err := calls.mods(ctx, reg, callee, map[uuid.UUID]bool{v.ID: true})
+			if err != nil {
+				return ruleNode{}, err
+			}
+			mods = append(mods, calleeMods...)
+		}
 	}
 
 	return ruleNode{
@@ -803,7 +762,7 @@ func (b *Business) nodeFromView(ctx context.Context, reg *ActionRegistry, v Auto
 }
 
 // candidateNode resolves the in-flight candidate into a graph node.
-func (b *Business) candidateNode(ctx context.Context, reg *ActionRegistry, cand CandidateRule) (ruleNode, error) {
+func (b *Business) candidateNode(ctx context.Context, reg *ActionRegistry, calls *calledRules, cand CandidateRule) (ruleNode, error) {
 	entityName, err := b.entityTableName(ctx, cand.EntityID)
 	if err != nil {
 		return ruleNode{}, err
@@ -816,6 +775,14 @@ func (b *Business) candidateNode(ctx context.Context, reg *ActionRegistry, cand
 	var mods []EntityModification
 	for _, a := range cand.Actions {
 		mods = append(mods, modsFor(reg, a.ActionType, a.Config)...)
+
+		if callee, ok := CalledRuleID(a.ActionType, a.Config); ok {
+			calleeMods, err := calls.mods(ctx, reg, callee, map[uuid.UUID]bool{cand.RuleID: true})
+			if err != nil {
+				return ruleNode{}, err
+			}
+			mods = append(mods, calleeMods...)
+		}
 	}
 
 	return ruleNode{
@@ -876,5 +843,5 @@ func parseConds(raw *json.RawMessage) []FieldCondition {
 	if err := json.Unmarshal(*raw, &tc); err != nil {
 		return nil
 	}
-	return tc.FieldConditions
+	return tc.Conditions()
 }
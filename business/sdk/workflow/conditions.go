@@ -0,0 +1,255 @@
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ConditionData is what a condition is evaluated against: the entity's data and, for an
+// on_update event, the fields that changed.
+type ConditionData struct {
+	EventType    string
+	RawData      map[string]any
+	FieldChanges map[string]FieldChange
+
+	// Now is the time now() returns in expressions. When zero, the current time is used.
+	Now time.Time
+}
+
+// values returns a field's current and previous value. On an update, a changed field
+// reports its new and old values and an unchanged field reports its value as both; on
+// any other event there is no previous value.
+func (d ConditionData) values(field string) (current any, previous any) {
+	if d.EventType == "on_update" && d.FieldChanges != nil {
+		if fc, ok := d.FieldChanges[field]; ok {
+			return fc.NewValue, fc.OldValue
+		}
+		current = d.RawData[field]
+		return current, current
+	}
+	return d.RawData[field], nil
+}
+
+// exprVars builds the variables an expression condition sees: every field by name, with
+// changed fields at their new value, plus
+//
+//	$old      the fields before the update (equal to the current data on other events)
+//	$changed  the names of the fields the update changed, sorted
+//	$event    the event type
+func (d ConditionData) exprVars() map[string]any {
+	vars := make(map[string]any, len(d.RawData)+3)
+	maps.Copy(vars, d.RawData)
+
+	old := make(map[string]any, len(d.RawData))
+	maps.Copy(old, d.RawData)
+
+	changed := []any{}
+	if d.EventType == "on_update" {
+		for _, name := range slices.Sorted(maps.Keys(d.FieldChanges)) {
+			fc := d.FieldChanges[name]
+			vars[name] = fc.NewValue
+			old[name] = fc.OldValue
+			changed = append(changed, name)
+		}
+	}
+
+	vars["$old"] = old
+	vars["$changed"] = changed
+	vars["$event"] = d.EventType
+
+	return vars
+}
+
+// EvaluateFieldCondition evaluates one condition. It is the single evaluator behind
+// trigger conditions and the evaluate_condition action, and the static cascade detector
+// reuses its operator semantics (see matchOperator). An unknown operator or a failing
+// expression is reported in Error with Matched false.
+func EvaluateFieldCondition(cond FieldCondition, data ConditionData) ConditionEvaluationResult {
+	result := ConditionEvaluationResult{
+		Condition: cond,
+	}
+
+	if cond.Operator == OperatorExpression {
+		expr, err := ParseExpr(cond.Expression)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		v, err := expr.Eval(ExprEnv{Vars: data.exprVars(), Now: data.Now})
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.ActualValue = v
+		result.Matched = exprTruthy(v)
+		return result
+	}
+
+	current, previous := data.values(cond.FieldName)
+	result.ActualValue = current
+	result.PreviousValue = previous
+
+	switch cond.Operator {
+	case OperatorChangedFrom:
+		result.Matched = data.EventType == "on_update" &&
+			valuesEqual(previous, cond.PreviousValue)
+
+	case OperatorChangedTo:
+		result.Matched = data.EventType == "on_update" &&
+			valuesEqual(current, cond.Value) &&
+			!valuesEqual(previous, cond.Value)
+
+	case OperatorEquals, OperatorNotEquals, OperatorGreaterThan, OperatorLessThan,
+		OperatorContains, OperatorIn, OperatorIsNull, OperatorIsNotNull:
+		result.Matched = matchOperator(cond.Operator, current, cond.Value)
+
+	default:
+		result.Error = fmt.Sprintf("Unknown operator: %s", cond.Operator)
+	}
+
+	return result
+}
+
+// matchOperator applies an operator that only reads the field's current value. The
+// static cascade detector evaluates produced values through it too, so a gate it
+// proves open is one the runtime opens.
+func matchOperator(op string, current, condVal any) bool {
+	switch op {
+	case OperatorEquals:
+		return valuesEqual(current, condVal)
+
+	case OperatorNotEquals:
+		return !valuesEqual(current, condVal)
+
+	case OperatorGreaterThan, OperatorLessThan:
+		if current == nil || condVal == nil {
+			return false
+		}
+		c, ok := exprCompare(current, condVal)
+		if !ok {
+			c = strings.Compare(fmt.Sprintf("%v", current), fmt.Sprintf("%v", condVal))
+		}
+		if op == OperatorGreaterThan {
+			return c > 0
+		}
+		return c < 0
+
+	case OperatorContains:
+		switch cur := exprValue(current).(type) {
+		case string:
+			search, ok := condVal.(string)
+			return ok && strings.Contains(cur, search)
+		case []any:
+			found, _ := exprIn(condVal, cur)
+			return found
+		}
+		return false
+
+	case OperatorIn:
+		values, ok := exprValue(condVal).([]any)
+		if !ok {
+			return false
+		}
+		for _, v := range values {
+			if valuesEqual(current, v) {
+				return true
+			}
+		}
+		return false
+
+	case OperatorIsNull:
+		return exprValue(current) == nil
+
+	case OperatorIsNotNull:
+		return exprValue(current) != nil
+	}
+
+	return false
+}
+
+// valuesEqual is the equality conditions use: numbers, times and durations compare by
+// value, so 5, 5.0 and "5" are equal, and anything else compares by its text.
+func valuesEqual(a, b any) bool {
+	return exprEqual(a, b)
+}
+
+// =============================================================================
+// Validation
+// =============================================================================
+
+// ValidateFieldCondition checks that a condition names a known operator and has what
+// that operator needs. A malformed expression is reported as an *ExprError, which gives
+// the position of the problem.
+func ValidateFieldCondition(cond FieldCondition) error {
+	switch cond.Operator {
+	case "":
+		return errors.New("operator is required")
+
+	case OperatorExpression:
+		if strings.TrimSpace(cond.Expression) == "" {
+			return errors.New("expression is required")
+		}
+		if _, err := ParseExpr(cond.Expression); err != nil {
+			return fmt.Errorf("expression: %w", err)
+		}
+		return nil
+
+	case OperatorEquals, OperatorNotEquals, OperatorChangedFrom, OperatorChangedTo,
+		OperatorGreaterThan, OperatorLessThan, OperatorContains, OperatorIn,
+		OperatorIsNull, OperatorIsNotNull:
+
+	default:
+		return fmt.Errorf("invalid operator '%s'", cond.Operator)
+	}
+
+	if cond.FieldName == "" {
+		return errors.New("field_name is required")
+	}
+
+	return nil
+}
+
+// ValidateTriggerConditions checks a rule's trigger_conditions document.
+func ValidateTriggerConditions(raw json.RawMessage) error {
+	var tc TriggerConditions
+	if err := json.Unmarshal(raw, &tc); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	for i, cond := range tc.FieldConditions {
+		if err := ValidateFieldCondition(cond); err != nil {
+			return fmt.Errorf("field_conditions[%d]: %w", i, err)
+		}
+	}
+
+	if tc.Expression != "" {
+		if _, err := ParseExpr(tc.Expression); err != nil {
+			return fmt.Errorf("expression: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Conditions returns the conditions a rule's trigger requires, all of which must match:
+// the field conditions, followed by the expression when one is set.
+func (tc TriggerConditions) Conditions() []FieldCondition {
+	conds := slices.Clone(tc.FieldConditions)
+	if tc.Expression != "" {
+		conds = append(conds, FieldCondition{Operator: OperatorExpression, Expression: tc.Expression})
+	}
+	return conds
+}
+
+// conditionLabel names a condition in match reasons: its field, or its expression.
+func conditionLabel(cond FieldCondition) string {
+	if cond.Operator == OperatorExpression {
+		return cond.Expression
+	}
+	return cond.FieldName
+}
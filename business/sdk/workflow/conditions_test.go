@@ -0,0 +1,109 @@
+package workflow_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+)
+
+func TestEvaluateFieldCondition(t *testing.T) {
+	t.Parallel()
+
+	update := workflow.ConditionData{
+		EventType: "on_update",
+		RawData: map[string]any{
+			"status":   "shipped",
+			"total":    "250.00",
+			"due_date": "2026-03-11",
+			"tags":     []any{"rush"},
+			"line_items": []any{
+				map[string]any{"sku": "A-1", "quantity": 4},
+				map[string]any{"sku": "B-2", "quantity": 12},
+			},
+		},
+		FieldChanges: map[string]workflow.FieldChange{
+			"status": {OldValue: "packed", NewValue: "shipped"},
+		},
+		Now: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name string
+		cond workflow.FieldCondition
+		want bool
+	}{
+		{"equals", workflow.FieldCondition{FieldName: "status", Operator: "equals", Value: "shipped"}, true},
+		{"changed_to", workflow.FieldCondition{FieldName: "status", Operator: "changed_to", Value: "shipped"}, true},
+		{"changed_from", workflow.FieldCondition{FieldName: "status", Operator: "changed_from", PreviousValue: "packed"}, true},
+		{"numeric string greater_than", workflow.FieldCondition{FieldName: "total", Operator: "greater_than", Value: 99}, true},
+		{"number equals numeric string", workflow.FieldCondition{FieldName: "total", Operator: "equals", Value: 250}, true},
+		{"contains in list", workflow.FieldCondition{FieldName: "tags", Operator: "contains", Value: "rush"}, true},
+		{"is_null", workflow.FieldCondition{FieldName: "notes", Operator: "is_null"}, true},
+		{"is_not_null", workflow.FieldCondition{FieldName: "status", Operator: "is_not_null"}, true},
+		{"expression", workflow.FieldCondition{Operator: "expression", Expression: "any(line_items, .quantity > 10) and due_date < now() + 2d"}, true},
+		{"expression old value", workflow.FieldCondition{Operator: "expression", Expression: "$old.status == 'packed' and status == 'shipped'"}, true},
+		{"expression changed fields", workflow.FieldCondition{Operator: "expression", Expression: "'status' in $changed and not ('total' in $changed)"}, true},
+		{"expression false", workflow.FieldCondition{Operator: "expression", Expression: "(status == 'packed' or total > 1000) and $event == 'on_update'"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := workflow.EvaluateFieldCondition(tt.cond, update)
+			if res.Error != "" {
+				t.Fatalf("EvaluateFieldCondition() error: %s", res.Error)
+			}
+			if res.Matched != tt.want {
+				t.Errorf("EvaluateFieldCondition() matched = %v, want %v", res.Matched, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateFieldCondition_ExpressionError(t *testing.T) {
+	t.Parallel()
+
+	data := workflow.ConditionData{EventType: "on_create", RawData: map[string]any{"qty": 2}}
+
+	res := workflow.EvaluateFieldCondition(workflow.FieldCondition{Operator: "expression", Expression: "qty * price > 10"}, data)
+	if res.Matched {
+		t.Error("a failing expression should not match")
+	}
+	if !strings.Contains(res.Error, `position 4: "price" is null or missing`) {
+		t.Errorf("Error = %q, want the position of the failing operator", res.Error)
+	}
+}
+
+func TestValidateTriggerConditions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		raw     string
+		wantErr string
+	}{
+		{"empty", `{}`, ""},
+		{"field and expression", `{"field_conditions":[{"field_name":"status","operator":"equals","value":"x"}],"expression":"total > 5"}`, ""},
+		{"expression operator without expression", `{"field_conditions":[{"operator":"expression"}]}`, "field_conditions[0]: expression is required"},
+		{"bad nested expression", `{"field_conditions":[{"operator":"expression","expression":"total >"}]}`, "field_conditions[0]: expression: position 7: unexpected end of expression"},
+		{"bad top-level expression", `{"expression":"total >> 5"}`, "expression: position 7: unexpected \">\""},
+		{"invalid json", `[`, "invalid JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := workflow.ValidateTriggerConditions(json.RawMessage(tt.raw))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
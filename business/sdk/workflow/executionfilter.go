@@ -10,6 +10,7 @@ import (
 type ExecutionFilter struct {
 	ID            *uuid.UUID       // Filter by specific execution ID
 	RuleID        *uuid.UUID       // Filter by automation rule (maps to automation_rules_id column)
+	RevisionID    *uuid.UUID       // Filter by the rule revision the execution ran
 	Status        *ExecutionStatus // Filter by status (completed, failed, running, etc.)
 	TriggerSource *string          // Filter by trigger source ("automation" or "manual")
 	DateFrom      *time.Time       // Filter executions after this date
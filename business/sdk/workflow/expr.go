@@ -1,160 +1,127 @@
 package workflow
 
+// EXPRESSION LANGUAGE
+//
+// One small language is shared by trigger conditions, the evaluate_condition action and
+// {{expr: ...}} template blocks. An expression is parsed once into a tree (ParseExpr), which
+// reports syntax errors, unknown functions and wrong argument counts with the byte offset
+// they occur at, and can then be evaluated against any number of variable sets.
+//
+//	Literals     42, 3.5, 'text', "text", true, false, null, [1, 2, 3]
+//	Durations    30s, 15m, 4h, 2d, 1w (and 500ms)
+//	Variables    status, order.customer.name, lines[0], $old.status
+//	Arithmetic   + - * / %           (+ also joins strings and lists)
+//	Dates        due_date < now() + 2d, (shipped_at - ordered_at) / 1d
+//	Comparison   == != < <= > >=     in, not in
+//	Boolean      and or not          (&& || ! also accepted)
+//	Null         price ?? 0          (the left side unless it is null or missing)
+//	Lists        any(line_items, .quantity > 10), sum(line_items, .quantity * .unit_price)
+//
+// Inside the second argument of a list function, ".field" reads a field of the current
+// element and "." is the element itself.
+//
+// A missing variable is null rather than an error, so conditions can test for it and ??
+// can supply a default; arithmetic on null is an error. Numeric strings take part in
+// arithmetic and comparison as numbers, and strings that hold a date or timestamp compare
+// against times as times.
+
 import (
 	"fmt"
 	"math"
-	"strings"
-	"unicode"
+	"time"
 )
 
-// EvalExpr evaluates a simple arithmetic expression with variables from vars.
-// Supports: +, -, *, /, %, parentheses, float/integer literals, and variable names.
-// Variable names resolve from vars; values must be numeric or string-numeric.
-// On any error (unknown variable, division by zero, parse error), returns (0, err).
-func EvalExpr(expr string, vars map[string]any) (float64, error) {
-	p := &exprParser{input: strings.TrimSpace(expr), vars: vars}
-	result, err := p.parseAddSub()
-	if err != nil {
-		return 0, err
-	}
-	p.skipWhitespace()
-	if p.pos < len(p.input) {
-		return 0, fmt.Errorf("unexpected character %q at position %d", p.input[p.pos], p.pos)
-	}
-	return result, nil
+// ExprError is a parse or evaluation error at a position in an expression. Pos is the
+// 0-based byte offset the error points at.
+type ExprError struct {
+	Expr string
+	Pos  int
+	Msg  string
 }
 
-type exprParser struct {
-	input string
-	pos   int
-	vars  map[string]any
+// Error implements the error interface.
+func (e *ExprError) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
 }
 
-func (p *exprParser) parseAddSub() (float64, error) {
-	left, err := p.parseMulDiv()
-	if err != nil {
-		return 0, err
-	}
-	for {
-		p.skipWhitespace()
-		if p.pos >= len(p.input) {
-			break
-		}
-		op := p.input[p.pos]
-		if op != '+' && op != '-' {
-			break
-		}
-		p.pos++
-		right, err := p.parseMulDiv()
-		if err != nil {
-			return 0, err
-		}
-		if op == '+' {
-			left += right
-		} else {
-			left -= right
-		}
-	}
-	return left, nil
+// Expr is a parsed expression. It is immutable and safe for concurrent use.
+type Expr struct {
+	src  string
+	root exprNode
 }
 
-func (p *exprParser) parseMulDiv() (float64, error) {
-	left, err := p.parseUnary()
+// ExprEnv is what an expression is evaluated against. Vars holds the variables; a dotted
+// key such as "create_alert.alert_id" is matched before nested maps are walked, the same
+// way templates resolve variables. Now is the time now() returns; when zero, the current
+// time is used.
+type ExprEnv struct {
+	Vars map[string]any
+	Now  time.Time
+}
+
+// ParseExpr parses an expression. A syntax error is returned as an *ExprError.
+func ParseExpr(src string) (*Expr, error) {
+	p, err := newExprParser(src)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	for {
-		p.skipWhitespace()
-		if p.pos >= len(p.input) {
-			break
-		}
-		op := p.input[p.pos]
-		if op != '*' && op != '/' && op != '%' {
-			break
-		}
-		p.pos++
-		right, err := p.parseUnary()
-		if err != nil {
-			return 0, err
-		}
-		switch op {
-		case '*':
-			left *= right
-		case '/':
-			if right == 0 {
-				return 0, fmt.Errorf("division by zero")
-			}
-			left /= right
-		case '%':
-			if right == 0 {
-				return 0, fmt.Errorf("modulo by zero")
-			}
-			left = math.Mod(left, right)
-		}
+
+	root, err := p.parse()
+	if err != nil {
+		return nil, err
 	}
-	return left, nil
+
+	return &Expr{src: src, root: root}, nil
 }
 
-func (p *exprParser) parseUnary() (float64, error) {
-	p.skipWhitespace()
-	if p.pos < len(p.input) && p.input[p.pos] == '-' {
-		p.pos++
-		val, err := p.parsePrimary()
-		return -val, err
-	}
-	return p.parsePrimary()
+// String returns the expression's source.
+func (e *Expr) String() string {
+	return e.src
 }
 
-func (p *exprParser) parsePrimary() (float64, error) {
-	p.skipWhitespace()
-	if p.pos >= len(p.input) {
-		return 0, fmt.Errorf("unexpected end of expression")
+// Eval evaluates the expression. The result is nil, a bool, a float64, a string, a
+// time.Time, a time.Duration, a []any or a map[string]any. An evaluation error is
+// returned as an *ExprError.
+func (e *Expr) Eval(env ExprEnv) (any, error) {
+	ev := &exprEvaluator{src: e.src, env: env}
+	return ev.eval(e.root)
+}
+
+// EvalBool evaluates the expression as a condition. null, false, 0, "" and empty lists
+// are false; every other value is true.
+func (e *Expr) EvalBool(env ExprEnv) (bool, error) {
+	v, err := e.Eval(env)
+	if err != nil {
+		return false, err
 	}
-	ch := p.input[p.pos]
-
-	// Parenthesized expression.
-	if ch == '(' {
-		p.pos++
-		val, err := p.parseAddSub()
-		if err != nil {
-			return 0, err
-		}
-		p.skipWhitespace()
-		if p.pos >= len(p.input) || p.input[p.pos] != ')' {
-			return 0, fmt.Errorf("missing closing parenthesis")
-		}
-		p.pos++
-		return val, nil
+	return exprTruthy(v), nil
+}
+
+// EvalExpr evaluates an arithmetic expression with variables from vars and returns its
+// numeric result. It accepts the full expression language; a result that is not a number
+// is an error. On any error (missing variable, division by zero, parse error), it
+// returns (0, err).
+func EvalExpr(expr string, vars map[string]any) (float64, error) {
+	e, err := ParseExpr(expr)
+	if err != nil {
+		return 0, err
 	}
 
-	// Number literal.
-	if unicode.IsDigit(rune(ch)) || ch == '.' {
-		start := p.pos
-		for p.pos < len(p.input) && (unicode.IsDigit(rune(p.input[p.pos])) || p.input[p.pos] == '.') {
-			p.pos++
-		}
-		return toFloat64(p.input[start:p.pos])
+	v, err := e.Eval(ExprEnv{Vars: vars})
+	if err != nil {
+		return 0, err
 	}
 
-	// Variable name (letters, digits, underscores; must start with letter or underscore).
-	if unicode.IsLetter(rune(ch)) || ch == '_' {
-		start := p.pos
-		for p.pos < len(p.input) && (unicode.IsLetter(rune(p.input[p.pos])) || unicode.IsDigit(rune(p.input[p.pos])) || p.input[p.pos] == '_') {
-			p.pos++
+	f, ok := v.(float64)
+	if !ok {
+		if v == nil {
+			return 0, fmt.Errorf("expression %q is null", expr)
 		}
-		name := p.input[start:p.pos]
-		val, ok := p.vars[name]
-		if !ok {
-			return 0, fmt.Errorf("unknown variable %q", name)
-		}
-		return toFloat64(val)
+		return 0, fmt.Errorf("expression %q is a %s, not a number", expr, exprTypeName(v))
 	}
-
-	return 0, fmt.Errorf("unexpected character %q at position %d", ch, p.pos)
-}
-
-func (p *exprParser) skipWhitespace() {
-	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
-		p.pos++
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("expression %q is not a finite number", expr)
 	}
+
+	return f, nil
 }
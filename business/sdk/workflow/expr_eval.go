@@ -0,0 +1,765 @@
+package workflow
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exprEvaluator evaluates one parsed expression against an environment.
+type exprEvaluator struct {
+	src string
+	env ExprEnv
+	now time.Time
+
+	// elems is the stack of list elements ".field" refers to, innermost last.
+	elems []any
+}
+
+func (ev *exprEvaluator) errorf(pos int, format string, args ...any) error {
+	return &ExprError{Expr: ev.src, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// currentTime is the time now() returns; it is fixed for the whole evaluation.
+func (ev *exprEvaluator) currentTime() time.Time {
+	if ev.now.IsZero() {
+		ev.now = ev.env.Now
+		if ev.now.IsZero() {
+			ev.now = time.Now().UTC()
+		}
+	}
+	return ev.now
+}
+
+func (ev *exprEvaluator) eval(n exprNode) (any, error) {
+	switch n := n.(type) {
+	case *exprLiteral:
+		return n.val, nil
+
+	case *exprList:
+		items := make([]any, len(n.items))
+		for i, item := range n.items {
+			v, err := ev.eval(item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = v
+		}
+		return items, nil
+
+	case *exprPath:
+		v, _ := exprLookup(ev.env.Vars, n.segs)
+		return v, nil
+
+	case *exprElem:
+		if len(ev.elems) == 0 {
+			return nil, ev.errorf(n.pos, `".field" used outside a list function`)
+		}
+		v := ev.elems[len(ev.elems)-1]
+		for _, seg := range n.segs {
+			v, _ = exprField(v, seg)
+		}
+		return v, nil
+
+	case *exprMember:
+		obj, err := ev.eval(n.obj)
+		if err != nil {
+			return nil, err
+		}
+		v, _ := exprField(obj, n.name)
+		return v, nil
+
+	case *exprIndex:
+		return ev.evalIndex(n)
+
+	case *exprUnary:
+		x, err := ev.eval(n.x)
+		if err != nil {
+			return nil, err
+		}
+		if n.op == "not" {
+			return !exprTruthy(x), nil
+		}
+		switch x := x.(type) {
+		case float64:
+			return -x, nil
+		case time.Duration:
+			return -x, nil
+		case nil:
+			return nil, ev.errorf(n.pos, "cannot negate %s", exprDescribe(n.x))
+		}
+		if f, ok := exprNumber(x); ok {
+			return -f, nil
+		}
+		return nil, ev.errorf(n.pos, "cannot negate a %s", exprTypeName(x))
+
+	case *exprBinary:
+		return ev.evalBinary(n)
+
+	case *exprCall:
+		return ev.evalCall(n)
+	}
+
+	return nil, ev.errorf(n.position(), "unsupported expression")
+}
+
+func (ev *exprEvaluator) evalIndex(n *exprIndex) (any, error) {
+	obj, err := ev.eval(n.obj)
+	if err != nil {
+		return nil, err
+	}
+	index, err := ev.eval(n.index)
+	if err != nil {
+		return nil, err
+	}
+
+	switch obj := obj.(type) {
+	case nil:
+		return nil, nil
+
+	case []any:
+		f, ok := exprNumber(index)
+		if !ok || f != math.Trunc(f) {
+			return nil, ev.errorf(n.pos, "list index must be a whole number, got %s", exprTypeName(index))
+		}
+		i := int(f)
+		if i < 0 {
+			i += len(obj)
+		}
+		if i < 0 || i >= len(obj) {
+			return nil, nil
+		}
+		return exprValue(obj[i]), nil
+
+	case map[string]any:
+		return exprValue(obj[exprString(index)]), nil
+	}
+
+	return nil, ev.errorf(n.pos, "cannot index a %s", exprTypeName(obj))
+}
+
+func (ev *exprEvaluator) evalBinary(n *exprBinary) (any, error) {
+	l, err := ev.eval(n.l)
+	if err != nil {
+		return nil, err
+	}
+
+	// Short-circuit operators evaluate the right side only when it is needed.
+	switch n.op {
+	case "and":
+		if !exprTruthy(l) {
+			return false, nil
+		}
+		r, err := ev.eval(n.r)
+		if err != nil {
+			return nil, err
+		}
+		return exprTruthy(r), nil
+
+	case "or":
+		if exprTruthy(l) {
+			return true, nil
+		}
+		r, err := ev.eval(n.r)
+		if err != nil {
+			return nil, err
+		}
+		return exprTruthy(r), nil
+
+	case "??":
+		if l != nil {
+			return l, nil
+		}
+		return ev.eval(n.r)
+	}
+
+	r, err := ev.eval(n.r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return exprEqual(l, r), nil
+
+	case "!=":
+		return !exprEqual(l, r), nil
+
+	case "<", "<=", ">", ">=":
+		if l == nil || r == nil {
+			return nil, ev.errorf(n.pos, "cannot compare %s", exprDescribe(exprNullSide(n, l)))
+		}
+		c, ok := exprCompare(l, r)
+		if !ok {
+			return nil, ev.errorf(n.pos, "cannot compare a %s with a %s", exprTypeName(l), exprTypeName(r))
+		}
+		switch n.op {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		}
+		return c >= 0, nil
+
+	case "in", "not in":
+		found, ok := exprIn(l, r)
+		if !ok {
+			return nil, ev.errorf(n.pos, "%q needs a list, map or string on the right, got a %s", n.op, exprTypeName(r))
+		}
+		return found == (n.op == "in"), nil
+	}
+
+	if l == nil || r == nil {
+		return nil, ev.errorf(n.pos, "%s is null or missing", exprDescribe(exprNullSide(n, l)))
+	}
+
+	v, msg := exprArith(n.op, l, r)
+	if msg != "" {
+		return nil, ev.errorf(n.pos, "%s", msg)
+	}
+	return v, nil
+}
+
+func (ev *exprEvaluator) evalCall(n *exprCall) (any, error) {
+	if n.fn.list != nil {
+		first, err := ev.eval(n.args[0])
+		if err != nil {
+			return nil, err
+		}
+		items, ok := exprItems(first)
+		if !ok {
+			return nil, ev.errorf(n.args[0].position(), "%s() needs a list, got a %s", n.name, exprTypeName(first))
+		}
+		var body exprNode
+		if len(n.args) > 1 {
+			body = n.args[1]
+		}
+		return n.fn.list(ev, n, items, body)
+	}
+
+	args := make([]any, len(n.args))
+	for i, arg := range n.args {
+		v, err := ev.eval(arg)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	return n.fn.call(ev, n, args)
+}
+
+// evalElem evaluates body with item as the element ".field" refers to. A nil body
+// yields the item itself.
+func (ev *exprEvaluator) evalElem(body exprNode, item any) (any, error) {
+	if body == nil {
+		return item, nil
+	}
+	ev.elems = append(ev.elems, item)
+	v, err := ev.eval(body)
+	ev.elems = ev.elems[:len(ev.elems)-1]
+	return v, err
+}
+
+// =============================================================================
+// Values
+// =============================================================================
+
+// exprValue normalizes a Go value into the expression's value domain: nil, bool,
+// float64, string, time.Time, time.Duration, []any or map[string]any.
+func exprValue(v any) any {
+	switch x := v.(type) {
+	case nil, bool, float64, string, time.Time, time.Duration, []any, map[string]any:
+		return x
+	case int:
+		return float64(x)
+	case int8:
+		return float64(x)
+	case int16:
+		return float64(x)
+	case int32:
+		return float64(x)
+	case int64:
+		return float64(x)
+	case uint:
+		return float64(x)
+	case uint8:
+		return float64(x)
+	case uint16:
+		return float64(x)
+	case uint32:
+		return float64(x)
+	case uint64:
+		return float64(x)
+	case float32:
+		return float64(x)
+	case json.Number:
+		if f, err := x.Float64(); err == nil {
+			return f
+		}
+		return x.String()
+	case *time.Time:
+		if x == nil {
+			return nil
+		}
+		return *x
+	case TemplateContext:
+		return map[string]any(x)
+	case fmt.Stringer:
+		rv := reflect.ValueOf(x)
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return nil
+		}
+		return x.String()
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return exprValue(rv.Elem().Interface())
+
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil
+		}
+		items := make([]any, rv.Len())
+		for i := range items {
+			items[i] = rv.Index(i).Interface()
+		}
+		return items
+
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			break
+		}
+		if rv.IsNil() {
+			return nil
+		}
+		m := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = iter.Value().Interface()
+		}
+		return m
+
+	case reflect.Struct:
+		data, err := json.Marshal(v)
+		if err != nil {
+			break
+		}
+		var m map[string]any
+		if err := json.Unmarshal(data, &m); err == nil {
+			return m
+		}
+
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.String:
+		return rv.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	}
+
+	return fmt.Sprint(v)
+}
+
+// exprLookup resolves a variable path. The longest dotted prefix that is a key of vars
+// wins, and the rest of the path walks nested maps.
+func exprLookup(vars map[string]any, segs []string) (any, bool) {
+	for k := len(segs); k > 0; k-- {
+		v, ok := vars[strings.Join(segs[:k], ".")]
+		if !ok {
+			continue
+		}
+
+		cur := exprValue(v)
+		for _, seg := range segs[k:] {
+			if cur, ok = exprField(cur, seg); !ok {
+				break
+			}
+		}
+		if ok {
+			return cur, true
+		}
+	}
+	return nil, false
+}
+
+// exprField reads a field of a map value.
+func exprField(v any, name string) (any, bool) {
+	m, ok := exprValue(v).(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	field, ok := m[name]
+	return exprValue(field), ok
+}
+
+// exprItems returns the elements of a list value; null is an empty list.
+func exprItems(v any) ([]any, bool) {
+	switch v := v.(type) {
+	case nil:
+		return nil, true
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = exprValue(item)
+		}
+		return items, true
+	}
+	return nil, false
+}
+
+// exprNumber reads a number, parsing numeric strings.
+func exprNumber(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// exprTimeLayouts are the layouts a string is tried against when it is used as a time.
+var exprTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// exprTime reads a time, parsing strings that hold a date or timestamp.
+func exprTime(v any) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		s := strings.TrimSpace(v)
+		for _, layout := range exprTimeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// exprTruthy reports whether a value counts as true in a condition.
+func exprTruthy(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	case time.Duration:
+		return v != 0
+	case []any:
+		return len(v) > 0
+	case map[string]any:
+		return len(v) > 0
+	}
+	return true
+}
+
+// exprTypeName names a value's type for error messages.
+func exprTypeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case time.Time:
+		return "time"
+	case time.Duration:
+		return "duration"
+	case []any:
+		return "list"
+	case map[string]any:
+		return "map"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// exprDescribe names the operand an error is about.
+func exprDescribe(n exprNode) string {
+	switch n := n.(type) {
+	case *exprPath:
+		return strconv.Quote(exprPathString(n.segs))
+	case *exprElem:
+		return strconv.Quote("." + exprPathString(n.segs))
+	case *exprCall:
+		return n.name + "()"
+	}
+	return "null"
+}
+
+// exprNullSide returns the operand of n that evaluated to null, given the left value.
+func exprNullSide(n *exprBinary, l any) exprNode {
+	if l == nil {
+		return n.l
+	}
+	return n.r
+}
+
+// exprString formats a value as text.
+func exprString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+			return strconv.FormatInt(int64(v), 10)
+		}
+		return strconv.FormatFloat(v, 'g', 10, 64)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case time.Duration:
+		return v.String()
+	case []any, map[string]any:
+		data, err := json.Marshal(v)
+		if err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprint(v)
+}
+
+// =============================================================================
+// Operators
+// =============================================================================
+
+// exprEqual reports whether two values are equal. Numbers, times and durations compare
+// by value even when one side is a string; anything else compares by its text.
+func exprEqual(a, b any) bool {
+	a, b = exprValue(a), exprValue(b)
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if c, ok := exprCompare(a, b); ok {
+		return c == 0
+	}
+	switch a.(type) {
+	case []any, map[string]any:
+		return reflect.DeepEqual(a, b)
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+// exprCompare orders two values of the same kind: numbers, times, durations or strings.
+// A string is read as a number or time when the other side is one.
+func exprCompare(a, b any) (int, bool) {
+	a, b = exprValue(a), exprValue(b)
+
+	_, aTime := a.(time.Time)
+	_, bTime := b.(time.Time)
+	if aTime || bTime {
+		ta, okA := exprTime(a)
+		tb, okB := exprTime(b)
+		if !okA || !okB {
+			return 0, false
+		}
+		return ta.Compare(tb), true
+	}
+
+	da, aDur := a.(time.Duration)
+	db, bDur := b.(time.Duration)
+	if aDur || bDur {
+		if !aDur || !bDur {
+			return 0, false
+		}
+		return cmp.Compare(da, db), true
+	}
+
+	_, aNum := a.(float64)
+	_, bNum := b.(float64)
+	if aNum || bNum {
+		fa, okA := exprNumber(a)
+		fb, okB := exprNumber(b)
+		if !okA || !okB {
+			return 0, false
+		}
+		return cmp.Compare(fa, fb), true
+	}
+
+	sa, aStr := a.(string)
+	sb, bStr := b.(string)
+	if aStr && bStr {
+		return strings.Compare(sa, sb), true
+	}
+
+	return 0, false
+}
+
+// exprIn reports whether item is an element of a list, a key of a map or a substring of
+// a string. ok is false when coll is none of those; null contains nothing.
+func exprIn(item, coll any) (found bool, ok bool) {
+	switch coll := exprValue(coll).(type) {
+	case nil:
+		return false, true
+	case []any:
+		for _, elem := range coll {
+			if exprEqual(item, elem) {
+				return true, true
+			}
+		}
+		return false, true
+	case map[string]any:
+		_, found := coll[exprString(exprValue(item))]
+		return found, true
+	case string:
+		return strings.Contains(coll, exprString(exprValue(item))), true
+	}
+	return false, false
+}
+
+// exprArith applies an arithmetic operator to two non-null values. On failure it
+// returns a message instead of a value.
+func exprArith(op string, a, b any) (any, string) {
+	switch op {
+	case "+":
+		switch a := a.(type) {
+		case time.Time:
+			if d, ok := b.(time.Duration); ok {
+				return a.Add(d), ""
+			}
+		case time.Duration:
+			switch b := b.(type) {
+			case time.Duration:
+				return a + b, ""
+			case time.Time:
+				return b.Add(a), ""
+			}
+		case []any:
+			if b, ok := b.([]any); ok {
+				return append(append([]any{}, a...), b...), ""
+			}
+		}
+
+		_, aStr := a.(string)
+		_, bStr := b.(string)
+		if !aStr || !bStr {
+			if fa, fb, ok := exprNumbers(a, b); ok {
+				return fa + fb, ""
+			}
+		}
+		if aStr || bStr {
+			return exprString(a) + exprString(b), ""
+		}
+
+	case "-":
+		if ta, ok := exprTimeOperand(a, b); ok {
+			switch b := b.(type) {
+			case time.Duration:
+				return ta.Add(-b), ""
+			default:
+				if tb, ok := exprTime(b); ok {
+					return ta.Sub(tb), ""
+				}
+			}
+		}
+		if da, ok := a.(time.Duration); ok {
+			if db, ok := b.(time.Duration); ok {
+				return da - db, ""
+			}
+		}
+		if fa, fb, ok := exprNumbers(a, b); ok {
+			return fa - fb, ""
+		}
+
+	case "*":
+		if d, ok := a.(time.Duration); ok {
+			if f, ok := exprNumber(b); ok {
+				return time.Duration(float64(d) * f), ""
+			}
+		}
+		if d, ok := b.(time.Duration); ok {
+			if f, ok := exprNumber(a); ok {
+				return time.Duration(float64(d) * f), ""
+			}
+		}
+		if fa, fb, ok := exprNumbers(a, b); ok {
+			return fa * fb, ""
+		}
+
+	case "/":
+		if d, ok := a.(time.Duration); ok {
+			switch b := b.(type) {
+			case time.Duration:
+				if b == 0 {
+					return nil, "division by zero"
+				}
+				return float64(d) / float64(b), ""
+			default:
+				if f, ok := exprNumber(b); ok {
+					if f == 0 {
+						return nil, "division by zero"
+					}
+					return time.Duration(float64(d) / f), ""
+				}
+			}
+		}
+		if fa, fb, ok := exprNumbers(a, b); ok {
+			if fb == 0 {
+				return nil, "division by zero"
+			}
+			return fa / fb, ""
+		}
+
+	case "%":
+		if fa, fb, ok := exprNumbers(a, b); ok {
+			if fb == 0 {
+				return nil, "modulo by zero"
+			}
+			return math.Mod(fa, fb), ""
+		}
+	}
+
+	return nil, fmt.Sprintf("cannot apply %q to a %s and a %s", op, exprTypeName(a), exprTypeName(b))
+}
+
+// exprNumbers reads both operands as numbers.
+func exprNumbers(a, b any) (float64, float64, bool) {
+	fa, okA := exprNumber(a)
+	fb, okB := exprNumber(b)
+	return fa, fb, okA && okB
+}
+
+// exprTimeOperand reads a as the time on the left of a subtraction. A string only counts
+// when the right side is a time or duration, so "10" - "4" stays arithmetic.
+func exprTimeOperand(a, b any) (time.Time, bool) {
+	if t, ok := a.(time.Time); ok {
+		return t, true
+	}
+	switch b.(type) {
+	case time.Time, time.Duration:
+		return exprTime(a)
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,177 @@
+package workflow_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+)
+
+func TestExprEval(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	env := workflow.ExprEnv{
+		Now: now,
+		Vars: map[string]any{
+			"status":   "shipped",
+			"priority": 3,
+			"name":     "  Acme Supply ",
+			"due_date": "2026-03-11T09:00:00Z",
+			"shipped":  now.Add(-36 * time.Hour),
+			"notes":    nil,
+			"tags":     []string{"rush", "fragile"},
+			"customer": map[string]any{"tier": "gold", "credit": 1500.0},
+			"line_items": []any{
+				map[string]any{"sku": "A-1", "quantity": 4, "unit_price": 2.5},
+				map[string]any{"sku": "B-2", "quantity": 12, "unit_price": 1.0},
+			},
+			"create_alert.alert_id": "9d2f",
+		},
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		want any
+	}{
+		{"nested and/or", "(status == 'shipped' or status == 'delivered') and not (priority < 2)", true},
+		{"symbol operators", "status != 'open' && !(priority >= 5) || false", true},
+		{"in list literal", "status in ['shipped', 'delivered']", true},
+		{"not in", "customer.tier not in ['silver', 'bronze']", true},
+		{"in list variable", "'rush' in tags", true},
+		{"date math", "due_date < now() + 2d", true},
+		{"date math false", "due_date < now() + 12h", false},
+		{"duration between times", "(now() - shipped) / 1d", 1.5},
+		{"days_between", "days_between(shipped, now())", 1.5},
+		{"today", "today() == date('2026-03-10')", true},
+		{"string functions", "lower(trim(name))", "acme supply"},
+		{"starts_with", "starts_with(trim(name), 'Acme') and ends_with(name, ' ')", true},
+		{"len and substr", "len(substr(trim(name), 0, 4))", 4.0},
+		{"split and join", "join(split('a,b,c', ','), '-')", "a-b-c"},
+		{"matches", "matches(status, '^sh.+d$')", true},
+		{"null coalescing", "notes ?? 'none'", "none"},
+		{"coalescing missing", "discount ?? 0 > 5", false},
+		{"missing is null", "missing == null", true},
+		{"null-safe member", "customer.address.city ?? 'unknown'", "unknown"},
+		{"any", "any(line_items, .quantity > 10)", true},
+		{"all", "all(line_items, .quantity > 10)", false},
+		{"none", "none(line_items, .sku == 'C-3')", true},
+		{"count", "count(line_items, .unit_price < 2)", 1.0},
+		{"sum", "sum(line_items, .quantity * .unit_price)", 22.0},
+		{"filter and map", "map(filter(line_items, .quantity > 10), .sku)", []any{"B-2"}},
+		{"index", "line_items[1].sku", "B-2"},
+		{"negative index", "line_items[-1].quantity", 12.0},
+		{"dotted key", "create_alert.alert_id", "9d2f"},
+		{"min max", "max(line_items[0].quantity, 10) - min([3, 1, 2])", 9.0},
+		{"round", "round(10 / 3, 2)", 3.33},
+		{"numeric string", "number('4.5') + 1", 5.5},
+		{"string concat", "status + '-' + string(priority)", "shipped-3"},
+		{"unary minus", "-priority + 5", 2.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := workflow.ParseExpr(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseExpr(%q) error: %v", tt.expr, err)
+			}
+
+			got, err := expr.Eval(env)
+			if err != nil {
+				t.Fatalf("Eval(%q) error: %v", tt.expr, err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Eval(%q) mismatch (-want +got):\n%s", tt.expr, diff)
+			}
+		})
+	}
+}
+
+func TestParseExprErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		expr    string
+		wantPos int
+		wantMsg string
+	}{
+		{"status = 'open'", 7, `unexpected "=", use "==" to compare`},
+		{"lenght(name) > 3", 0, `unknown function "lenght"`},
+		{"any(line_items)", 0, "any() takes 2 arguments, got 1"},
+		{"due_date < now( + 2d", 16, `unexpected "+"`},
+		{"due_date < now(", 14, "missing closing parenthesis"},
+		{"(a + b", 0, "missing closing parenthesis"},
+		{"a < b < c", 6, "comparisons cannot be chained, combine them with and"},
+		{"qty > 10 and", 12, "unexpected end of expression"},
+		{"due_date < now() + 2y", 20, `unknown duration unit "y", use ms, s, m, h, d or w`},
+		{".quantity > 1", 0, `".field" refers to a list element and can only be used inside a list function such as any()`},
+		{"name == 'open", 8, "unterminated string"},
+		{"matches(code, '[a-')", 14, "invalid pattern: error parsing regexp: missing closing ]: `[a-`"},
+		{"", 0, "empty expression"},
+		{"a b", 2, `unexpected "b"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := workflow.ParseExpr(tt.expr)
+			if err == nil {
+				t.Fatalf("ParseExpr(%q) should fail", tt.expr)
+			}
+
+			var exprErr *workflow.ExprError
+			if !errors.As(err, &exprErr) {
+				t.Fatalf("ParseExpr(%q) error %T is not an *ExprError", tt.expr, err)
+			}
+
+			if exprErr.Pos != tt.wantPos || exprErr.Msg != tt.wantMsg {
+				t.Errorf("ParseExpr(%q) = position %d: %s, want position %d: %s", tt.expr, exprErr.Pos, exprErr.Msg, tt.wantPos, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestExprEvalErrors(t *testing.T) {
+	t.Parallel()
+
+	env := workflow.ExprEnv{Vars: map[string]any{
+		"qty":    float64(2),
+		"status": "open",
+		"lines":  []any{map[string]any{"qty": "n/a"}},
+	}}
+
+	tests := []struct {
+		expr    string
+		wantPos int
+		wantMsg string
+	}{
+		{"qty * missing", 4, `"missing" is null or missing`},
+		{"qty / (qty - 2)", 4, "division by zero"},
+		{"status > 5", 7, "cannot compare a string with a number"},
+		{"sum(lines, .qty)", 11, "sum() needs numbers, got a string"},
+		{"len(qty)", 4, "len() needs a string, list or map, got a number"},
+		{"any(status, . == 'o')", 4, "any() needs a list, got a string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := workflow.ParseExpr(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseExpr(%q) error: %v", tt.expr, err)
+			}
+
+			_, err = expr.Eval(env)
+			var exprErr *workflow.ExprError
+			if !errors.As(err, &exprErr) {
+				t.Fatalf("Eval(%q) error = %v, want an *ExprError", tt.expr, err)
+			}
+
+			if exprErr.Pos != tt.wantPos || exprErr.Msg != tt.wantMsg {
+				t.Errorf("Eval(%q) = position %d: %s, want position %d: %s", tt.expr, exprErr.Pos, exprErr.Msg, tt.wantPos, tt.wantMsg)
+			}
+		})
+	}
+}
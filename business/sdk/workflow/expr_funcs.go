@@ -0,0 +1,489 @@
+package workflow
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// exprFunc is a function callable from expressions. Ordinary functions receive their
+// evaluated arguments through call. List functions (list set) receive the elements of
+// their first argument and their second argument unevaluated, so it can be evaluated
+// once per element with ".field" bound to it.
+type exprFunc struct {
+	minArgs int
+	maxArgs int // -1 for any number
+	call    func(ev *exprEvaluator, n *exprCall, args []any) (any, error)
+	list    func(ev *exprEvaluator, n *exprCall, items []any, body exprNode) (any, error)
+
+	// check validates literal arguments at parse time, returning the position and
+	// message of the first problem.
+	check func(n *exprCall) (int, string)
+}
+
+func (f exprFunc) arity() string {
+	switch {
+	case f.minArgs == f.maxArgs && f.minArgs == 1:
+		return "1 argument"
+	case f.minArgs == f.maxArgs:
+		return fmt.Sprintf("%d arguments", f.minArgs)
+	case f.maxArgs < 0:
+		return fmt.Sprintf("at least %d arguments", f.minArgs)
+	}
+	return fmt.Sprintf("%d to %d arguments", f.minArgs, f.maxArgs)
+}
+
+// exprFuncs are the functions expressions may call, by name.
+var exprFuncs = map[string]exprFunc{
+	// Dates and times.
+	"now":          {minArgs: 0, maxArgs: 0, call: exprNow},
+	"today":        {minArgs: 0, maxArgs: 0, call: exprToday},
+	"date":         {minArgs: 1, maxArgs: 1, call: exprDate},
+	"days":         {minArgs: 1, maxArgs: 1, call: exprDurationOf(24 * time.Hour)},
+	"hours":        {minArgs: 1, maxArgs: 1, call: exprDurationOf(time.Hour)},
+	"minutes":      {minArgs: 1, maxArgs: 1, call: exprDurationOf(time.Minute)},
+	"days_between": {minArgs: 2, maxArgs: 2, call: exprDaysBetween},
+
+	// Strings.
+	"lower":       {minArgs: 1, maxArgs: 1, call: exprStringFunc(strings.ToLower)},
+	"upper":       {minArgs: 1, maxArgs: 1, call: exprStringFunc(strings.ToUpper)},
+	"trim":        {minArgs: 1, maxArgs: 1, call: exprStringFunc(strings.TrimSpace)},
+	"len":         {minArgs: 1, maxArgs: 1, call: exprLen},
+	"contains":    {minArgs: 2, maxArgs: 2, call: exprContains},
+	"starts_with": {minArgs: 2, maxArgs: 2, call: exprStringTest(strings.HasPrefix)},
+	"ends_with":   {minArgs: 2, maxArgs: 2, call: exprStringTest(strings.HasSuffix)},
+	"replace":     {minArgs: 3, maxArgs: 3, call: exprReplace},
+	"substr":      {minArgs: 2, maxArgs: 3, call: exprSubstr},
+	"split":       {minArgs: 2, maxArgs: 2, call: exprSplit},
+	"join":        {minArgs: 2, maxArgs: 2, call: exprJoin},
+	"matches":     {minArgs: 2, maxArgs: 2, call: exprMatches, check: exprCheckPattern},
+
+	// Conversions and null handling.
+	"string":   {minArgs: 1, maxArgs: 1, call: exprToString},
+	"number":   {minArgs: 1, maxArgs: 1, call: exprToNumber},
+	"coalesce": {minArgs: 1, maxArgs: -1, call: exprCoalesce},
+
+	// Numbers.
+	"abs":   {minArgs: 1, maxArgs: 1, call: exprMath(math.Abs)},
+	"floor": {minArgs: 1, maxArgs: 1, call: exprMath(math.Floor)},
+	"ceil":  {minArgs: 1, maxArgs: 1, call: exprMath(math.Ceil)},
+	"round": {minArgs: 1, maxArgs: 2, call: exprRound},
+	"min":   {minArgs: 1, maxArgs: -1, call: exprExtreme(-1)},
+	"max":   {minArgs: 1, maxArgs: -1, call: exprExtreme(1)},
+
+	// Lists.
+	"any":    {minArgs: 2, maxArgs: 2, list: exprAny},
+	"all":    {minArgs: 2, maxArgs: 2, list: exprAll},
+	"none":   {minArgs: 2, maxArgs: 2, list: exprNone},
+	"count":  {minArgs: 1, maxArgs: 2, list: exprCount},
+	"filter": {minArgs: 2, maxArgs: 2, list: exprFilter},
+	"map":    {minArgs: 2, maxArgs: 2, list: exprMap},
+	"sum":    {minArgs: 1, maxArgs: 2, list: exprSum},
+}
+
+// =============================================================================
+// Argument helpers
+// =============================================================================
+
+func exprArgNumber(ev *exprEvaluator, n *exprCall, args []any, i int) (float64, error) {
+	f, ok := exprNumber(args[i])
+	if !ok {
+		return 0, ev.errorf(n.args[i].position(), "%s() needs a number, got a %s", n.name, exprTypeName(args[i]))
+	}
+	return f, nil
+}
+
+func exprArgTime(ev *exprEvaluator, n *exprCall, args []any, i int) (time.Time, error) {
+	t, ok := exprTime(args[i])
+	if !ok {
+		return time.Time{}, ev.errorf(n.args[i].position(), "%s() needs a date or time, got %s", n.name, exprQuoteValue(args[i]))
+	}
+	return t, nil
+}
+
+// exprQuoteValue shows a value in an error message.
+func exprQuoteValue(v any) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return "a " + exprTypeName(v)
+}
+
+// =============================================================================
+// Dates and times
+// =============================================================================
+
+func exprNow(ev *exprEvaluator, _ *exprCall, _ []any) (any, error) {
+	return ev.currentTime(), nil
+}
+
+func exprToday(ev *exprEvaluator, _ *exprCall, _ []any) (any, error) {
+	now := ev.currentTime()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()), nil
+}
+
+func exprDate(ev *exprEvaluator, n *exprCall, args []any) (any, error) {
+	if args[0] == nil {
+		return nil, nil
+	}
+	return exprArgTime(ev, n, args, 0)
+}
+
+func exprDurationOf(unit time.Duration) func(*exprEvaluator, *exprCall, []any) (any, error) {
+	return func(ev *exprEvaluator, n *exprCall, args []any) (any, error) {
+		f, err := exprArgNumber(ev, n, args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return time.Duration(f * float64(unit)), nil
+	}
+}
+
+func exprDaysBetween(ev *exprEvaluator, n *exprCall, args []any) (any, error) {
+	from, err := exprArgTime(ev, n, args, 0)
+	if err != nil {
+		return nil, err
+	}
+	to, err := exprArgTime(ev, n, args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return to.Sub(from).Hours() / 24, nil
+}
+
+// =============================================================================
+// Strings
+// =============================================================================
+
+func exprStringFunc(fn func(string) string) func(*exprEvaluator, *exprCall, []any) (any, error) {
+	return func(_ *exprEvaluator, _ *exprCall, args []any) (any, error) {
+		if args[0] == nil {
+			return nil, nil
+		}
+		return fn(exprString(args[0])), nil
+	}
+}
+
+func exprStringTest(fn func(s, part string) bool) func(*exprEvaluator, *exprCall, []any) (any, error) {
+	return func(_ *exprEvaluator, _ *exprCall, args []any) (any, error) {
+		if args[0] == nil {
+			return false, nil
+		}
+		return fn(exprString(args[0]), exprString(args[1])), nil
+	}
+}
+
+func exprLen(ev *exprEvaluator, n *exprCall, args []any) (any, error) {
+	switch v := args[0].(type) {
+	case nil:
+		return 0.0, nil
+	case string:
+		return float64(utf8.RuneCountInString(v)), nil
+	case []any:
+		return float64(len(v)), nil
+	case map[string]any:
+		return float64(len(v)), nil
+	}
+	return nil, ev.errorf(n.args[0].position(), "len() needs a string, list or map, got a %s", exprTypeName(args[0]))
+}
+
+func exprContains(ev *exprEvaluator, n *exprCall, args []any) (any, error) {
+	found, ok := exprIn(args[1], args[0])
+	if !ok {
+		return nil, ev.errorf(n.args[0].position(), "contains() needs a list, map or string, got a %s", exprTypeName(args[0]))
+	}
+	return found, nil
+}
+
+func exprReplace(_ *exprEvaluator, _ *exprCall, args []any) (any, error) {
+	if args[0] == nil {
+		return nil, nil
+	}
+	return strings.ReplaceAll(exprString(args[0]), exprString(args[1]), exprString(args[2])), nil
+}
+
+func exprSubstr(ev *exprEvaluator, n *exprCall, args []any) (any, error) {
+	if args[0] == nil {
+		return nil, nil
+	}
+	runes := []rune(exprString(args[0]))
+
+	start, err := exprArgNumber(ev, n, args, 1)
+	if err != nil {
+		return nil, err
+	}
+	from := clampInt(int(start), 0, len(runes))
+
+	to := len(runes)
+	if len(args) > 2 {
+		length, err := exprArgNumber(ev, n, args, 2)
+		if err != nil {
+			return nil, err
+		}
+		to = clampInt(from+int(length), from, len(runes))
+	}
+
+	return string(runes[from:to]), nil
+}
+
+func exprSplit(_ *exprEvaluator, _ *exprCall, args []any) (any, error) {
+	if args[0] == nil {
+		return []any{}, nil
+	}
+	parts := strings.Split(exprString(args[0]), exprString(args[1]))
+	items := make([]any, len(parts))
+	for i, part := range parts {
+		items[i] = part
+	}
+	return items, nil
+}
+
+func exprJoin(ev *exprEvaluator, n *exprCall, args []any) (any, error) {
+	items, ok := exprItems(args[0])
+	if !ok {
+		return nil, ev.errorf(n.args[0].position(), "join() needs a list, got a %s", exprTypeName(args[0]))
+	}
+	parts := make([]string, len(items))
+	for i, item := range items {
+		parts[i] = exprString(item)
+	}
+	return strings.Join(parts, exprString(args[1])), nil
+}
+
+func exprMatches(ev *exprEvaluator, n *exprCall, args []any) (any, error) {
+	if args[0] == nil {
+		return false, nil
+	}
+	re, err := regexp.Compile(exprString(args[1]))
+	if err != nil {
+		return nil, ev.errorf(n.args[1].position(), "invalid pattern: %s", err)
+	}
+	return re.MatchString(exprString(args[0])), nil
+}
+
+// exprCheckPattern rejects an invalid literal pattern passed to matches().
+func exprCheckPattern(n *exprCall) (int, string) {
+	lit, ok := n.args[1].(*exprLiteral)
+	if !ok {
+		return 0, ""
+	}
+	pattern, ok := lit.val.(string)
+	if !ok {
+		return 0, ""
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return lit.pos, fmt.Sprintf("invalid pattern: %s", err)
+	}
+	return 0, ""
+}
+
+// =============================================================================
+// Conversions and null handling
+// =============================================================================
+
+func exprToString(_ *exprEvaluator, _ *exprCall, args []any) (any, error) {
+	if args[0] == nil {
+		return nil, nil
+	}
+	return exprString(args[0]), nil
+}
+
+func exprToNumber(ev *exprEvaluator, n *exprCall, args []any) (any, error) {
+	switch v := args[0].(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		if v {
+			return 1.0, nil
+		}
+		return 0.0, nil
+	case time.Duration:
+		return v.Seconds(), nil
+	}
+	f, ok := exprNumber(args[0])
+	if !ok {
+		return nil, ev.errorf(n.args[0].position(), "number() cannot convert %s", exprQuoteValue(args[0]))
+	}
+	return f, nil
+}
+
+func exprCoalesce(_ *exprEvaluator, _ *exprCall, args []any) (any, error) {
+	for _, arg := range args {
+		if arg != nil {
+			return arg, nil
+		}
+	}
+	return nil, nil
+}
+
+// =============================================================================
+// Numbers
+// =============================================================================
+
+func exprMath(fn func(float64) float64) func(*exprEvaluator, *exprCall, []any) (any, error) {
+	return func(ev *exprEvaluator, n *exprCall, args []any) (any, error) {
+		f, err := exprArgNumber(ev, n, args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return fn(f), nil
+	}
+}
+
+func exprRound(ev *exprEvaluator, n *exprCall, args []any) (any, error) {
+	f, err := exprArgNumber(ev, n, args, 0)
+	if err != nil {
+		return nil, err
+	}
+	places := 0.0
+	if len(args) > 1 {
+		if places, err = exprArgNumber(ev, n, args, 1); err != nil {
+			return nil, err
+		}
+	}
+	scale := math.Pow(10, math.Trunc(places))
+	return math.Round(f*scale) / scale, nil
+}
+
+// exprExtreme returns min (sign -1) or max (sign 1) of its arguments, or of the list
+// passed as its only argument. Nulls are skipped.
+func exprExtreme(sign int) func(*exprEvaluator, *exprCall, []any) (any, error) {
+	return func(ev *exprEvaluator, n *exprCall, args []any) (any, error) {
+		values := args
+		if len(args) == 1 {
+			if items, ok := exprItems(args[0]); ok {
+				values = items
+			}
+		}
+
+		var best any
+		for _, v := range values {
+			if v == nil {
+				continue
+			}
+			if best == nil {
+				best = v
+				continue
+			}
+			c, ok := exprCompare(v, best)
+			if !ok {
+				return nil, ev.errorf(n.pos, "%s() cannot compare a %s with a %s", n.name, exprTypeName(v), exprTypeName(best))
+			}
+			if c*sign > 0 {
+				best = v
+			}
+		}
+		return best, nil
+	}
+}
+
+// =============================================================================
+// Lists
+// =============================================================================
+
+func exprAny(ev *exprEvaluator, _ *exprCall, items []any, body exprNode) (any, error) {
+	for _, item := range items {
+		v, err := ev.evalElem(body, item)
+		if err != nil {
+			return nil, err
+		}
+		if exprTruthy(v) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func exprAll(ev *exprEvaluator, _ *exprCall, items []any, body exprNode) (any, error) {
+	for _, item := range items {
+		v, err := ev.evalElem(body, item)
+		if err != nil {
+			return nil, err
+		}
+		if !exprTruthy(v) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func exprNone(ev *exprEvaluator, n *exprCall, items []any, body exprNode) (any, error) {
+	found, err := exprAny(ev, n, items, body)
+	if err != nil {
+		return nil, err
+	}
+	return !found.(bool), nil
+}
+
+func exprCount(ev *exprEvaluator, _ *exprCall, items []any, body exprNode) (any, error) {
+	if body == nil {
+		return float64(len(items)), nil
+	}
+	count := 0
+	for _, item := range items {
+		v, err := ev.evalElem(body, item)
+		if err != nil {
+			return nil, err
+		}
+		if exprTruthy(v) {
+			count++
+		}
+	}
+	return float64(count), nil
+}
+
+func exprFilter(ev *exprEvaluator, _ *exprCall, items []any, body exprNode) (any, error) {
+	kept := []any{}
+	for _, item := range items {
+		v, err := ev.evalElem(body, item)
+		if err != nil {
+			return nil, err
+		}
+		if exprTruthy(v) {
+			kept = append(kept, item)
+		}
+	}
+	return kept, nil
+}
+
+func exprMap(ev *exprEvaluator, _ *exprCall, items []any, body exprNode) (any, error) {
+	mapped := make([]any, len(items))
+	for i, item := range items {
+		v, err := ev.evalElem(body, item)
+		if err != nil {
+			return nil, err
+		}
+		mapped[i] = v
+	}
+	return mapped, nil
+}
+
+func exprSum(ev *exprEvaluator, n *exprCall, items []any, body exprNode) (any, error) {
+	total := 0.0
+	for _, item := range items {
+		v, err := ev.evalElem(body, item)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			continue
+		}
+		f, ok := exprNumber(v)
+		if !ok {
+			pos := n.pos
+			if body != nil {
+				pos = body.position()
+			}
+			return nil, ev.errorf(pos, "sum() needs numbers, got a %s", exprTypeName(v))
+		}
+		total += f
+	}
+	return total, nil
+}
+
+func clampInt(v, lo, hi int) int {
+	return min(max(v, lo), hi)
+}
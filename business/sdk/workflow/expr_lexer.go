@@ -0,0 +1,197 @@
+package workflow
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// exprTokenKind classifies a lexical token of the expression language.
+type exprTokenKind int
+
+const (
+	tokEOF exprTokenKind = iota
+	tokNumber
+	tokDuration
+	tokString
+	tokIdent
+	tokOp
+)
+
+// exprToken is a lexical token and the byte offset it starts at.
+type exprToken struct {
+	kind exprTokenKind
+	pos  int
+	text string        // identifier name, operator, or the source of a literal
+	num  float64       // tokNumber
+	dur  time.Duration // tokDuration
+	str  string        // tokString, unescaped
+}
+
+// exprDurationUnits are the suffixes a number may carry to make it a duration literal.
+var exprDurationUnits = map[string]time.Duration{
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+}
+
+// exprOperators are the operator tokens, longest first so "<=" wins over "<".
+var exprOperators = []string{
+	"==", "!=", "<=", ">=", "&&", "||", "??",
+	"+", "-", "*", "/", "%", "<", ">", "!", "(", ")", "[", "]", ",", ".",
+}
+
+// lexExpr splits an expression into tokens, ending with a tokEOF.
+func lexExpr(src string) ([]exprToken, error) {
+	var toks []exprToken
+	pos := 0
+
+	for {
+		for pos < len(src) {
+			r, size := utf8.DecodeRuneInString(src[pos:])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			pos += size
+		}
+		if pos >= len(src) {
+			toks = append(toks, exprToken{kind: tokEOF, pos: pos})
+			return toks, nil
+		}
+
+		ch := src[pos]
+		switch {
+		case isExprDigit(ch) || (ch == '.' && pos+1 < len(src) && isExprDigit(src[pos+1])):
+			tok, next, err := lexExprNumber(src, pos)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, tok)
+			pos = next
+
+		case ch == '\'' || ch == '"':
+			tok, next, err := lexExprString(src, pos)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, tok)
+			pos = next
+
+		case isExprIdentStart(ch):
+			start := pos
+			pos++
+			for pos < len(src) && isExprIdentPart(src[pos]) {
+				pos++
+			}
+			toks = append(toks, exprToken{kind: tokIdent, pos: start, text: src[start:pos]})
+
+		default:
+			op := ""
+			for _, candidate := range exprOperators {
+				if strings.HasPrefix(src[pos:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				if ch == '=' {
+					return nil, &ExprError{Expr: src, Pos: pos, Msg: `unexpected "=", use "==" to compare`}
+				}
+				r, _ := utf8.DecodeRuneInString(src[pos:])
+				return nil, &ExprError{Expr: src, Pos: pos, Msg: fmt.Sprintf("unexpected character %q", r)}
+			}
+			toks = append(toks, exprToken{kind: tokOp, pos: pos, text: op})
+			pos += len(op)
+		}
+	}
+}
+
+// lexExprNumber reads a number literal, or a duration literal when the number is
+// directly followed by a unit such as "2d".
+func lexExprNumber(src string, start int) (exprToken, int, error) {
+	pos := start
+	seenDot := false
+	for pos < len(src) && (isExprDigit(src[pos]) || (src[pos] == '.' && !seenDot && pos+1 < len(src) && isExprDigit(src[pos+1]))) {
+		if src[pos] == '.' {
+			seenDot = true
+		}
+		pos++
+	}
+
+	text := src[start:pos]
+	num, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return exprToken{}, 0, &ExprError{Expr: src, Pos: start, Msg: fmt.Sprintf("invalid number %q", text)}
+	}
+
+	// A unit directly after the digits makes a duration: "2d", "90m", "500ms".
+	unitEnd := pos
+	for unitEnd < len(src) && isExprIdentPart(src[unitEnd]) {
+		unitEnd++
+	}
+	if unitEnd > pos {
+		unit := src[pos:unitEnd]
+		scale, ok := exprDurationUnits[unit]
+		if !ok {
+			return exprToken{}, 0, &ExprError{Expr: src, Pos: pos, Msg: fmt.Sprintf("unknown duration unit %q, use ms, s, m, h, d or w", unit)}
+		}
+		return exprToken{kind: tokDuration, pos: start, text: src[start:unitEnd], dur: time.Duration(num * float64(scale))}, unitEnd, nil
+	}
+
+	return exprToken{kind: tokNumber, pos: start, text: text, num: num}, pos, nil
+}
+
+// lexExprString reads a single- or double-quoted string literal.
+func lexExprString(src string, start int) (exprToken, int, error) {
+	quote := src[start]
+	var b strings.Builder
+
+	pos := start + 1
+	for pos < len(src) {
+		ch := src[pos]
+		switch {
+		case ch == quote:
+			return exprToken{kind: tokString, pos: start, text: src[start : pos+1], str: b.String()}, pos + 1, nil
+
+		case ch == '\\':
+			if pos+1 >= len(src) {
+				return exprToken{}, 0, &ExprError{Expr: src, Pos: pos, Msg: "unterminated escape sequence"}
+			}
+			switch esc := src[pos+1]; esc {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case '\\', '\'', '"':
+				b.WriteByte(esc)
+			default:
+				return exprToken{}, 0, &ExprError{Expr: src, Pos: pos, Msg: fmt.Sprintf("unknown escape sequence \\%c", esc)}
+			}
+			pos += 2
+
+		default:
+			b.WriteByte(ch)
+			pos++
+		}
+	}
+
+	return exprToken{}, 0, &ExprError{Expr: src, Pos: start, Msg: "unterminated string"}
+}
+
+func isExprDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isExprIdentStart(ch byte) bool {
+	return ch == '_' || ch == '$' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isExprIdentPart(ch byte) bool {
+	return ch == '_' || isExprDigit(ch) || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
@@ -0,0 +1,548 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+)
+
+// =============================================================================
+// Syntax tree
+// =============================================================================
+
+// exprNode is a node of a parsed expression. pos is the byte offset errors about the
+// node point at.
+type exprNode interface {
+	position() int
+}
+
+type (
+	// exprLiteral is a number, duration, string, boolean or null literal.
+	exprLiteral struct {
+		pos int
+		val any
+	}
+
+	// exprList is a list literal.
+	exprList struct {
+		pos   int
+		items []exprNode
+	}
+
+	// exprPath is a variable reference such as status or order.customer.name.
+	exprPath struct {
+		pos  int
+		segs []string
+	}
+
+	// exprElem refers to the current element inside a list function: ".quantity",
+	// ".product.sku", or "." for the element itself.
+	exprElem struct {
+		pos  int
+		segs []string
+	}
+
+	// exprMember reads a field of a computed value, such as (a ?? b).name.
+	exprMember struct {
+		pos  int
+		obj  exprNode
+		name string
+	}
+
+	// exprIndex reads a list element or a map entry, such as lines[0].
+	exprIndex struct {
+		pos   int
+		obj   exprNode
+		index exprNode
+	}
+
+	// exprUnary is "-x" or "not x".
+	exprUnary struct {
+		pos int
+		op  string
+		x   exprNode
+	}
+
+	// exprBinary is a binary operation; pos is the operator's offset.
+	exprBinary struct {
+		pos  int
+		op   string
+		l, r exprNode
+	}
+
+	// exprCall is a function call.
+	exprCall struct {
+		pos  int
+		name string
+		fn   *exprFunc
+		args []exprNode
+	}
+)
+
+func (n *exprLiteral) position() int { return n.pos }
+func (n *exprList) position() int    { return n.pos }
+func (n *exprPath) position() int    { return n.pos }
+func (n *exprElem) position() int    { return n.pos }
+func (n *exprMember) position() int  { return n.pos }
+func (n *exprIndex) position() int   { return n.pos }
+func (n *exprUnary) position() int   { return n.pos }
+func (n *exprBinary) position() int  { return n.pos }
+func (n *exprCall) position() int    { return n.pos }
+
+// =============================================================================
+// Parser
+// =============================================================================
+
+// exprKeywords are identifiers with a meaning of their own.
+var exprKeywords = map[string]bool{
+	"and": true, "or": true, "not": true, "in": true,
+	"true": true, "false": true, "null": true,
+}
+
+// exprComparisons are the comparison operators. They do not chain.
+var exprComparisons = map[string]bool{
+	"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true,
+}
+
+// exprParser is a recursive-descent parser. From lowest to highest precedence:
+//
+//	or, ||  ·  and, &&  ·  not, !  ·  comparisons and in  ·  ??  ·  + -  ·  * / %  ·
+//	unary -  ·  .field and [index]
+type exprParser struct {
+	src  string
+	toks []exprToken
+	i    int
+
+	// elemDepth counts the list-function arguments being parsed; ".field" is only
+	// meaningful inside one.
+	elemDepth int
+}
+
+func newExprParser(src string) (*exprParser, error) {
+	toks, err := lexExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	return &exprParser{src: src, toks: toks}, nil
+}
+
+func (p *exprParser) parse() (exprNode, error) {
+	if p.peek().kind == tokEOF {
+		return nil, p.errorf(p.peek().pos, "empty expression")
+	}
+
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.unexpected(tok)
+	}
+
+	return n, nil
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("||") || p.isKeyword("or") {
+		pos := p.next().pos
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &exprBinary{pos: pos, op: "or", l: left, r: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("&&") || p.isKeyword("and") {
+		pos := p.next().pos
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &exprBinary{pos: pos, op: "and", l: left, r: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseNot() (exprNode, error) {
+	if p.isOp("!") || p.isKeyword("not") {
+		pos := p.next().pos
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &exprUnary{pos: pos, op: "not", x: x}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	left, err := p.parseCoalesce()
+	if err != nil {
+		return nil, err
+	}
+
+	op, pos, ok := p.comparisonOp()
+	if !ok {
+		return left, nil
+	}
+
+	right, err := p.parseCoalesce()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, chainPos, chained := p.comparisonOp(); chained {
+		return nil, p.errorf(chainPos, "comparisons cannot be chained, combine them with and")
+	}
+
+	return &exprBinary{pos: pos, op: op, l: left, r: right}, nil
+}
+
+// comparisonOp consumes a comparison operator, including the two-word "not in".
+func (p *exprParser) comparisonOp() (string, int, bool) {
+	tok := p.peek()
+	switch {
+	case tok.kind == tokOp && exprComparisons[tok.text]:
+		p.next()
+		return tok.text, tok.pos, true
+	case p.isKeyword("in"):
+		p.next()
+		return "in", tok.pos, true
+	case p.isKeyword("not") && p.peekAt(1).kind == tokIdent && p.peekAt(1).text == "in":
+		p.next()
+		p.next()
+		return "not in", tok.pos, true
+	}
+	return "", 0, false
+}
+
+func (p *exprParser) parseCoalesce() (exprNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if !p.isOp("??") {
+		return left, nil
+	}
+
+	pos := p.next().pos
+	right, err := p.parseCoalesce()
+	if err != nil {
+		return nil, err
+	}
+	return &exprBinary{pos: pos, op: "??", l: left, r: right}, nil
+}
+
+func (p *exprParser) parseAdditive() (exprNode, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("+") || p.isOp("-") {
+		tok := p.next()
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &exprBinary{pos: tok.pos, op: tok.text, l: left, r: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseMultiplicative() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("*") || p.isOp("/") || p.isOp("%") {
+		tok := p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &exprBinary{pos: tok.pos, op: tok.text, l: left, r: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if p.isOp("-") {
+		pos := p.next().pos
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &exprUnary{pos: pos, op: "-", x: x}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *exprParser) parsePostfix() (exprNode, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch {
+		case p.isOp("."):
+			dot := p.next()
+			name := p.peek()
+			if name.kind != tokIdent {
+				return nil, p.errorf(dot.pos, `expected a field name after "."`)
+			}
+			p.next()
+
+			switch x := n.(type) {
+			case *exprPath:
+				x.segs = append(x.segs, name.text)
+			case *exprElem:
+				x.segs = append(x.segs, name.text)
+			default:
+				n = &exprMember{pos: name.pos, obj: n, name: name.text}
+			}
+
+		case p.isOp("["):
+			open := p.next()
+			index, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if !p.isOp("]") {
+				return nil, p.errorf(open.pos, `missing closing "]"`)
+			}
+			p.next()
+			n = &exprIndex{pos: open.pos, obj: n, index: index}
+
+		default:
+			return n, nil
+		}
+	}
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	tok := p.peek()
+
+	switch tok.kind {
+	case tokEOF:
+		return nil, p.errorf(tok.pos, "unexpected end of expression")
+
+	case tokNumber:
+		p.next()
+		return &exprLiteral{pos: tok.pos, val: tok.num}, nil
+
+	case tokDuration:
+		p.next()
+		return &exprLiteral{pos: tok.pos, val: tok.dur}, nil
+
+	case tokString:
+		p.next()
+		return &exprLiteral{pos: tok.pos, val: tok.str}, nil
+
+	case tokIdent:
+		switch tok.text {
+		case "true":
+			p.next()
+			return &exprLiteral{pos: tok.pos, val: true}, nil
+		case "false":
+			p.next()
+			return &exprLiteral{pos: tok.pos, val: false}, nil
+		case "null":
+			p.next()
+			return &exprLiteral{pos: tok.pos, val: nil}, nil
+		}
+		if exprKeywords[tok.text] {
+			return nil, p.unexpected(tok)
+		}
+
+		p.next()
+		if p.isOp("(") {
+			return p.parseCall(tok)
+		}
+		return &exprPath{pos: tok.pos, segs: []string{tok.text}}, nil
+	}
+
+	switch tok.text {
+	case "(":
+		p.next()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.isOp(")") {
+			return nil, p.errorf(tok.pos, "missing closing parenthesis")
+		}
+		p.next()
+		return n, nil
+
+	case "[":
+		p.next()
+		items, err := p.parseItems("]")
+		if err != nil {
+			return nil, err
+		}
+		return &exprList{pos: tok.pos, items: items}, nil
+
+	case ".":
+		if p.elemDepth == 0 {
+			return nil, p.errorf(tok.pos, `".field" refers to a list element and can only be used inside a list function such as any()`)
+		}
+		p.next()
+		elem := &exprElem{pos: tok.pos}
+		if name := p.peek(); name.kind == tokIdent {
+			p.next()
+			elem.segs = []string{name.text}
+		}
+		return elem, nil
+	}
+
+	return nil, p.unexpected(tok)
+}
+
+// parseCall parses the argument list of a call to the function named by tok.
+func (p *exprParser) parseCall(name exprToken) (exprNode, error) {
+	fn, ok := exprFuncs[name.text]
+	if !ok {
+		return nil, p.errorf(name.pos, "unknown function %q", name.text)
+	}
+
+	open := p.next() // "("
+	var args []exprNode
+
+	if !p.isOp(")") {
+		for {
+			if p.peek().kind == tokEOF {
+				return nil, p.errorf(open.pos, "missing closing parenthesis")
+			}
+			isElemArg := fn.list != nil && len(args) == 1
+			if isElemArg {
+				p.elemDepth++
+			}
+			arg, err := p.parseOr()
+			if isElemArg {
+				p.elemDepth--
+			}
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+
+			if !p.isOp(",") {
+				break
+			}
+			p.next()
+		}
+	}
+
+	if !p.isOp(")") {
+		if p.peek().kind == tokEOF {
+			return nil, p.errorf(open.pos, "missing closing parenthesis")
+		}
+		return nil, p.unexpected(p.peek())
+	}
+	p.next()
+
+	if len(args) < fn.minArgs || (fn.maxArgs >= 0 && len(args) > fn.maxArgs) {
+		return nil, p.errorf(name.pos, "%s() takes %s, got %d", name.text, fn.arity(), len(args))
+	}
+
+	call := &exprCall{pos: name.pos, name: name.text, fn: &fn, args: args}
+	if fn.check != nil {
+		if pos, msg := fn.check(call); msg != "" {
+			return nil, p.errorf(pos, "%s", msg)
+		}
+	}
+
+	return call, nil
+}
+
+// parseItems parses a comma-separated list of expressions up to the closing token.
+func (p *exprParser) parseItems(closing string) ([]exprNode, error) {
+	open := p.toks[p.i-1]
+	var items []exprNode
+
+	for !p.isOp(closing) {
+		if p.peek().kind == tokEOF {
+			return nil, p.errorf(open.pos, "missing closing %q", closing)
+		}
+
+		item, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+
+		if p.isOp(",") {
+			p.next()
+			continue
+		}
+		if !p.isOp(closing) {
+			if p.peek().kind == tokEOF {
+				return nil, p.errorf(open.pos, "missing closing %q", closing)
+			}
+			return nil, p.unexpected(p.peek())
+		}
+	}
+	p.next()
+
+	return items, nil
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.toks[p.i]
+}
+
+func (p *exprParser) peekAt(offset int) exprToken {
+	if p.i+offset >= len(p.toks) {
+		return p.toks[len(p.toks)-1]
+	}
+	return p.toks[p.i+offset]
+}
+
+func (p *exprParser) next() exprToken {
+	tok := p.toks[p.i]
+	if tok.kind != tokEOF {
+		p.i++
+	}
+	return tok
+}
+
+func (p *exprParser) isOp(op string) bool {
+	tok := p.peek()
+	return tok.kind == tokOp && tok.text == op
+}
+
+func (p *exprParser) isKeyword(kw string) bool {
+	tok := p.peek()
+	return tok.kind == tokIdent && tok.text == kw
+}
+
+func (p *exprParser) unexpected(tok exprToken) error {
+	switch tok.kind {
+	case tokEOF:
+		return p.errorf(tok.pos, "unexpected end of expression")
+	case tokNumber, tokDuration, tokString:
+		return p.errorf(tok.pos, "unexpected %s", tok.text)
+	}
+	return p.errorf(tok.pos, "unexpected %q", tok.text)
+}
+
+func (p *exprParser) errorf(pos int, format string, args ...any) error {
+	return &ExprError{Expr: p.src, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// exprPathString renders a path for error messages.
+func exprPathString(segs []string) string {
+	return strings.Join(segs, ".")
+}
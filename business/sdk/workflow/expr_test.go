@@ -239,6 +239,37 @@ func TestTemplateExprSubstitution(t *testing.T) {
 			},
 			want: "Tax: 9.2",
 		},
+		{
+			name:     "string functions and coalescing",
+			template: "{{expr: upper(name) + ' x' + string(qty ?? 1)}}",
+			context:  workflow.TemplateContext{"name": "widget"},
+			want:     "WIDGET x1",
+		},
+		{
+			name:     "pipe inside string and or are not filters",
+			template: "{{expr: replace(code, '|', '/') + string(rush || false) | uppercase}}",
+			context:  workflow.TemplateContext{"code": "a|b", "rush": true},
+			want:     "A/BTRUE",
+		},
+		{
+			name:     "list sum with filter",
+			template: "Total: {{expr: sum(lines, .qty * .price) | currency:USD}}",
+			context: workflow.TemplateContext{
+				"lines": []any{
+					map[string]any{"qty": float64(2), "price": float64(5)},
+					map[string]any{"qty": float64(1), "price": float64(2.5)},
+				},
+			},
+			want: "Total: $12.50",
+		},
+		{
+			name:     "boolean result",
+			template: "Late: {{expr: any(lines, .qty > 1) || false}}",
+			context: workflow.TemplateContext{
+				"lines": []any{map[string]any{"qty": float64(2)}},
+			},
+			want: "Late: true",
+		},
 	}
 
 	for _, tt := range tests {
@@ -48,6 +48,8 @@ var KnownEnumFields = map[string][]FieldSchema{
 	"workflow.approval_requests": {
 		{Name: "status", Type: "enum", Values: []string{"pending", "approved", "rejected", "timed_out", "expired"}, Description: "Approval request resolution state"},
 		{Name: "approval_type", Type: "enum", Values: []string{"any", "all", "majority"}, Description: "Required approval quorum type"},
+		{Name: "timeout_action", Type: "enum", Values: []string{"approve", "reject"}, Description: "Auto-decision applied when the request times out"},
+		{Name: "escalation_target", Type: "enum", Values: []string{"manager", "role"}, Description: "Who a pending request is reassigned to on escalation"},
 	},
 }
 
@@ -185,6 +185,12 @@ const (
 	OperatorLessThan    = "less_than"
 	OperatorContains    = "contains"
 	OperatorIn          = "in"
+	OperatorIsNull      = "is_null"
+	OperatorIsNotNull   = "is_not_null"
+
+	// OperatorExpression conditions carry an Expression instead of a field and value;
+	// see ParseExpr for the language.
+	OperatorExpression = "expression"
 )
 
 // ProducedChange describes a value an action produces for a single field, expressed in the
@@ -203,3 +209,56 @@ type ProducedChange struct {
 	Value         any    `json:"value,omitempty"`
 	Indeterminate bool   `json:"indeterminate,omitempty"`
 }
+
+// =============================================================================
+// Action Planner Interface (for Simulation)
+// =============================================================================
+
+// ActionPlanner is an optional interface for action handlers that can describe
+// what Execute would do without doing it. The simulator calls Plan instead of
+// Execute when replaying a rule against past events, so Plan must not write to
+// the database, send messages or call external services. Reads are allowed.
+//
+// Handlers that do not implement ActionPlanner are never executed during a
+// simulation; the simulator assumes their default output port, reports the
+// entity modifications declared through EntityModifier, and flags the step
+// unverified. A handler whose output depends on data it reads should implement
+// ActionPlanner so its branches are simulated.
+type ActionPlanner interface {
+	Plan(ctx context.Context, config json.RawMessage, execCtx ActionExecutionContext) (ActionPlan, error)
+}
+
+// Set of planned effect kinds.
+const (
+	EffectEntityModification = "entity_modification"
+	EffectEmail              = "email"
+	EffectAlert              = "alert"
+	EffectNotification       = "notification"
+	EffectWorkflowCall       = "workflow_call"
+)
+
+// ActionPlan is what an action would have done. Output names the output port
+// the action would have taken; an empty Output means execution would stop on
+// this path, for example because the action would have failed. Result is
+// merged into the workflow context for downstream templates the same way an
+// executed action's result is.
+type ActionPlan struct {
+	Output  string          `json:"output"`
+	Result  map[string]any  `json:"result,omitempty"`
+	Effects []PlannedEffect `json:"effects,omitempty"`
+	Note    string          `json:"note,omitempty"`
+}
+
+// PlannedEffect is a single side effect an action would have produced. Which
+// fields are set depends on Kind: entity modifications carry EntityName,
+// EventType and Fields; messages carry Recipients, Subject and Message.
+type PlannedEffect struct {
+	Kind       string         `json:"kind"`
+	EntityName string         `json:"entity_name,omitempty"`
+	EventType  string         `json:"event_type,omitempty"`
+	Fields     map[string]any `json:"fields,omitempty"`
+	Recipients []string       `json:"recipients,omitempty"`
+	Subject    string         `json:"subject,omitempty"`
+	Message    string         `json:"message,omitempty"`
+	Severity   string         `json:"severity,omitempty"`
+}
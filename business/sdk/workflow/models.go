@@ -50,6 +50,7 @@ const (
 const (
 	TriggerSourceAutomation = "automation"
 	TriggerSourceManual     = "manual"
+	TriggerSourceSimulation = "simulation" // Plan-only replay; see ActionPlanner
 )
 
 // EventType constants for action execution
@@ -58,6 +59,7 @@ const (
 	EventTypeOnUpdate      = "on_update"
 	EventTypeOnDelete      = "on_delete"
 	EventTypeManualTrigger = "manual_trigger"
+	EventTypeScheduled     = "scheduled" // fired by the rule scheduler, not an entity write
 )
 
 // ActionExecutionContext provides context for action execution.
@@ -269,6 +271,7 @@ type RuleAction struct {
 
 // NewRuleAction contains information needed to create a new rule action
 type NewRuleAction struct {
+	ID               uuid.UUID // Optional: zero mints a new id; publishing a revision keeps its action ids
 	AutomationRuleID uuid.UUID
 	Name             string
 	Description      string
@@ -356,6 +359,8 @@ type AutomationExecution struct {
 	TriggerSource    string     // "automation" or "manual"
 	ExecutedBy       *uuid.UUID // User who triggered manual execution
 	ActionType       string     // For manual executions: the action type that was executed
+	RevisionID       *uuid.UUID // Rule revision whose graph the execution ran (nil for manual executions and rules with no published revision)
+	RevisionNumber   int        // Revision number from LEFT JOIN (0 when RevisionID is nil)
 }
 
 // NewAutomationExecution contains information needed to record a new execution
@@ -0,0 +1,351 @@
+package workflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Set of revision statuses. A revision is saved as a draft, becomes published
+// when the live rule is made to match it, and is superseded when another
+// revision of the same rule is published after it.
+const (
+	RevisionDraft      = "draft"
+	RevisionPublished  = "published"
+	RevisionSuperseded = "superseded"
+)
+
+// Set of error variables for rule revisions.
+var (
+	ErrRevisionNotDraft      = errors.New("only a draft revision can be published")
+	ErrRevisionNotSuperseded = errors.New("only a superseded revision can be rolled back to")
+)
+
+// RuleRevision is an immutable snapshot of an automation rule's definition.
+// Definition is written once when the revision is created; only Status and
+// the publish stamp change afterwards.
+type RuleRevision struct {
+	ID             uuid.UUID
+	RuleID         uuid.UUID
+	RevisionNumber int
+	Status         string
+	Definition     RuleDefinition
+	RestoredFromID *uuid.UUID // Set when a rollback copied Definition from an older revision
+	Notes          string
+	CreatedBy      uuid.UUID
+	CreatedDate    time.Time
+	PublishedBy    *uuid.UUID
+	PublishedDate  *time.Time
+}
+
+// NewRuleRevision contains information needed to record a rule revision. The
+// revision number is assigned in sequence per rule.
+type NewRuleRevision struct {
+	RuleID         uuid.UUID
+	Definition     RuleDefinition
+	RestoredFromID *uuid.UUID
+	Notes          string
+	CreatedBy      uuid.UUID
+}
+
+// RuleDefinition is everything that decides what a rule does: its trigger,
+// its actions and the edges between them, and its schedule. It is stored as
+// JSON, so its shape is the persisted format of every revision.
+type RuleDefinition struct {
+	Name              string            `json:"name"`
+	Description       string            `json:"description"`
+	IsActive          bool              `json:"is_active"`
+	EntityID          uuid.UUID         `json:"entity_id"`
+	TriggerTypeID     uuid.UUID         `json:"trigger_type_id"`
+	TriggerConditions json.RawMessage   `json:"trigger_conditions,omitempty"`
+	CanvasLayout      json.RawMessage   `json:"canvas_layout,omitempty"`
+	Actions           []RevisionAction  `json:"actions"`
+	Edges             []RevisionEdge    `json:"edges"`
+	Schedule          *RevisionSchedule `json:"schedule,omitempty"`
+}
+
+// RevisionAction is an action node as it stood in a revision. IDs are stable
+// across revisions: an action keeps its id for as long as it stays in the
+// graph, which is what lets two revisions be compared action by action.
+type RevisionAction struct {
+	ID           uuid.UUID       `json:"id"`
+	Name         string          `json:"name"`
+	Description  string          `json:"description"`
+	ActionConfig json.RawMessage `json:"action_config"`
+	IsActive     bool            `json:"is_active"`
+	TemplateID   *uuid.UUID      `json:"template_id,omitempty"`
+}
+
+// RevisionEdge is an edge between the actions of a revision. SourceActionID
+// is nil for the start edge.
+type RevisionEdge struct {
+	SourceActionID *uuid.UUID `json:"source_action_id,omitempty"`
+	TargetActionID uuid.UUID  `json:"target_action_id"`
+	EdgeType       string     `json:"edge_type"`
+	SourceOutput   *string    `json:"source_output,omitempty"`
+	EdgeOrder      int        `json:"edge_order"`
+}
+
+// RevisionSchedule is the firing schedule of a "scheduled" rule in a revision.
+type RevisionSchedule struct {
+	CronExpression  string `json:"cron_expression,omitempty"`
+	IntervalSeconds int    `json:"interval_seconds,omitempty"`
+	Timezone        string `json:"timezone,omitempty"`
+}
+
+// NewRuleDefinition builds a revision definition from a rule and the actions,
+// edges and schedule it has.
+func NewRuleDefinition(rule AutomationRule, actions []RuleAction, edges []ActionEdge, schedule *RuleSchedule) RuleDefinition {
+	def := RuleDefinition{
+		Name:          rule.Name,
+		Description:   rule.Description,
+		IsActive:      rule.IsActive,
+		EntityID:      rule.EntityID,
+		TriggerTypeID: rule.TriggerTypeID,
+		CanvasLayout:  rule.CanvasLayout,
+		Actions:       make([]RevisionAction, len(actions)),
+		Edges:         make([]RevisionEdge, len(edges)),
+	}
+
+	if rule.TriggerConditions != nil {
+		def.TriggerConditions = *rule.TriggerConditions
+	}
+
+	for i, action := range actions {
+		def.Actions[i] = RevisionAction{
+			ID:           action.ID,
+			Name:         action.Name,
+			Description:  action.Description,
+			ActionConfig: action.ActionConfig,
+			IsActive:     action.IsActive,
+			TemplateID:   action.TemplateID,
+		}
+	}
+
+	for i, edge := range edges {
+		def.Edges[i] = RevisionEdge{
+			SourceActionID: edge.SourceActionID,
+			TargetActionID: edge.TargetActionID,
+			EdgeType:       edge.EdgeType,
+			SourceOutput:   edge.SourceOutput,
+			EdgeOrder:      edge.EdgeOrder,
+		}
+	}
+
+	if schedule != nil {
+		def.Schedule = &RevisionSchedule{
+			CronExpression:  schedule.CronExpression,
+			IntervalSeconds: schedule.IntervalSeconds,
+			Timezone:        schedule.Timezone,
+		}
+	}
+
+	return def
+}
+
+// liveActions returns the actions of a live rule that belong in its
+// definition: those that are active or that an edge still reaches. A save
+// deactivates the actions it drops and deletes their edges, so what is left
+// out is what earlier saves removed.
+func liveActions(actions []RuleAction, edges []ActionEdge) []RuleAction {
+	wired := make(map[uuid.UUID]bool, len(edges)*2)
+	for _, e := range edges {
+		wired[e.TargetActionID] = true
+		if e.SourceActionID != nil {
+			wired[*e.SourceActionID] = true
+		}
+	}
+
+	kept := make([]RuleAction, 0, len(actions))
+	for _, a := range actions {
+		if a.IsActive || wired[a.ID] {
+			kept = append(kept, a)
+		}
+	}
+
+	return kept
+}
+
+// =============================================================================
+
+// RevisionDiff is what changed from one revision of a rule to another.
+type RevisionDiff struct {
+	FromRevisionID     uuid.UUID        `json:"from_revision_id"`
+	FromRevisionNumber int              `json:"from_revision_number"`
+	ToRevisionID       uuid.UUID        `json:"to_revision_id"`
+	ToRevisionNumber   int              `json:"to_revision_number"`
+	Rule               []FieldDiff      `json:"rule"`
+	ActionsAdded       []RevisionAction `json:"actions_added"`
+	ActionsRemoved     []RevisionAction `json:"actions_removed"`
+	ActionsChanged     []ActionDiff     `json:"actions_changed"`
+	EdgesAdded         []RevisionEdge   `json:"edges_added"`
+	EdgesRemoved       []RevisionEdge   `json:"edges_removed"`
+}
+
+// Empty reports whether the two revisions define the same rule.
+func (d RevisionDiff) Empty() bool {
+	return len(d.Rule) == 0 && len(d.ActionsAdded) == 0 && len(d.ActionsRemoved) == 0 &&
+		len(d.ActionsChanged) == 0 && len(d.EdgesAdded) == 0 && len(d.EdgesRemoved) == 0
+}
+
+// FieldDiff is a single field whose value differs between two revisions.
+type FieldDiff struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// ActionDiff is an action present in both revisions whose fields differ.
+type ActionDiff struct {
+	ActionID uuid.UUID   `json:"action_id"`
+	Name     string      `json:"name"`
+	Fields   []FieldDiff `json:"fields"`
+}
+
+// DiffRuleRevisions compares two revisions of the same rule. Actions are
+// matched by id and edges by their endpoints, type, output and order, so a
+// rewired edge shows up as one removed and one added. The canvas layout is
+// presentation only and is not compared.
+func DiffRuleRevisions(from RuleRevision, to RuleRevision) RevisionDiff {
+	diff := DiffRuleDefinitions(from.Definition, to.Definition)
+	diff.FromRevisionID = from.ID
+	diff.FromRevisionNumber = from.RevisionNumber
+	diff.ToRevisionID = to.ID
+	diff.ToRevisionNumber = to.RevisionNumber
+
+	return diff
+}
+
+// DiffRuleDefinitions compares two rule definitions; see DiffRuleRevisions.
+func DiffRuleDefinitions(from RuleDefinition, to RuleDefinition) RevisionDiff {
+	diff := RevisionDiff{
+		Rule:           []FieldDiff{},
+		ActionsAdded:   []RevisionAction{},
+		ActionsRemoved: []RevisionAction{},
+		ActionsChanged: []ActionDiff{},
+		EdgesAdded:     []RevisionEdge{},
+		EdgesRemoved:   []RevisionEdge{},
+	}
+
+	fields := &diff.Rule
+	diffValue(fields, "name", from.Name, to.Name)
+	diffValue(fields, "description", from.Description, to.Description)
+	diffValue(fields, "is_active", from.IsActive, to.IsActive)
+	diffValue(fields, "entity_id", from.EntityID, to.EntityID)
+	diffValue(fields, "trigger_type_id", from.TriggerTypeID, to.TriggerTypeID)
+	diffJSON(fields, "trigger_conditions", from.TriggerConditions, to.TriggerConditions)
+	diffValue(fields, "schedule", from.Schedule, to.Schedule)
+
+	fromActions := make(map[uuid.UUID]RevisionAction, len(from.Actions))
+	for _, a := range from.Actions {
+		fromActions[a.ID] = a
+	}
+	toActions := make(map[uuid.UUID]bool, len(to.Actions))
+
+	for _, a := range to.Actions {
+		toActions[a.ID] = true
+
+		prev, ok := fromActions[a.ID]
+		if !ok {
+			diff.ActionsAdded = append(diff.ActionsAdded, a)
+			continue
+		}
+
+		var changed []FieldDiff
+		diffValue(&changed, "name", prev.Name, a.Name)
+		diffValue(&changed, "description", prev.Description, a.Description)
+		diffJSON(&changed, "action_config", prev.ActionConfig, a.ActionConfig)
+		diffValue(&changed, "is_active", prev.IsActive, a.IsActive)
+		diffValue(&changed, "template_id", prev.TemplateID, a.TemplateID)
+
+		if len(changed) > 0 {
+			diff.ActionsChanged = append(diff.ActionsChanged, ActionDiff{ActionID: a.ID, Name: a.Name, Fields: changed})
+		}
+	}
+
+	for _, a := range from.Actions {
+		if !toActions[a.ID] {
+			diff.ActionsRemoved = append(diff.ActionsRemoved, a)
+		}
+	}
+
+	fromEdges := make(map[string]int, len(from.Edges))
+	for _, e := range from.Edges {
+		fromEdges[e.key()]++
+	}
+	toEdges := make(map[string]int, len(to.Edges))
+	for _, e := range to.Edges {
+		toEdges[e.key()]++
+	}
+
+	for _, e := range to.Edges {
+		if fromEdges[e.key()] > 0 {
+			fromEdges[e.key()]--
+			continue
+		}
+		diff.EdgesAdded = append(diff.EdgesAdded, e)
+	}
+	for _, e := range from.Edges {
+		if toEdges[e.key()] > 0 {
+			toEdges[e.key()]--
+			continue
+		}
+		diff.EdgesRemoved = append(diff.EdgesRemoved, e)
+	}
+
+	return diff
+}
+
+// key identifies an edge by everything that decides where execution flows.
+func (e RevisionEdge) key() string {
+	source := "start"
+	if e.SourceActionID != nil {
+		source = e.SourceActionID.String()
+	}
+	output := ""
+	if e.SourceOutput != nil {
+		output = *e.SourceOutput
+	}
+	return fmt.Sprintf("%s|%s|%s|%s|%d", source, e.TargetActionID, e.EdgeType, output, e.EdgeOrder)
+}
+
+func diffValue(fields *[]FieldDiff, field string, from any, to any) {
+	if reflect.DeepEqual(from, to) {
+		return
+	}
+	*fields = append(*fields, FieldDiff{Field: field, From: from, To: to})
+}
+
+// diffJSON compares two JSON documents by value, so key order and whitespace
+// do not count as a change and an absent document equals JSON null.
+func diffJSON(fields *[]FieldDiff, field string, from json.RawMessage, to json.RawMessage) {
+	fromValue, fromErr := decodeJSON(from)
+	toValue, toErr := decodeJSON(to)
+
+	if fromErr == nil && toErr == nil {
+		if reflect.DeepEqual(fromValue, toValue) {
+			return
+		}
+	} else if bytes.Equal(from, to) {
+		return
+	}
+
+	*fields = append(*fields, FieldDiff{Field: field, From: from, To: to})
+}
+
+func decodeJSON(doc json.RawMessage) (any, error) {
+	if len(bytes.TrimSpace(doc)) == 0 {
+		return nil, nil
+	}
+
+	var v any
+	if err := json.Unmarshal(doc, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
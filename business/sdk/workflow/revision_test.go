@@ -0,0 +1,99 @@
+package workflow_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+)
+
+func TestDiffRuleDefinitions(t *testing.T) {
+	t.Parallel()
+
+	notify, audit, alert := uuid.New(), uuid.New(), uuid.New()
+	approved := "approved"
+
+	from := workflow.RuleDefinition{
+		Name:              "Low stock",
+		IsActive:          true,
+		TriggerConditions: json.RawMessage(`{"field":"quantity","op":"lt","value":10}`),
+		Actions: []workflow.RevisionAction{
+			{ID: notify, Name: "Notify", ActionConfig: json.RawMessage(`{"action_type":"send_notification","title":"Low"}`), IsActive: true},
+			{ID: audit, Name: "Audit", ActionConfig: json.RawMessage(`{"action_type":"log_audit_entry"}`), IsActive: true},
+		},
+		Edges: []workflow.RevisionEdge{
+			{TargetActionID: notify, EdgeType: "start"},
+			{SourceActionID: &notify, TargetActionID: audit, EdgeType: "sequence"},
+		},
+	}
+
+	t.Run("same definition", func(t *testing.T) {
+		t.Parallel()
+
+		same := from
+		same.TriggerConditions = json.RawMessage(`{ "value": 10, "op": "lt", "field": "quantity" }`)
+		same.CanvasLayout = json.RawMessage(`{"zoom":2}`)
+
+		if diff := workflow.DiffRuleDefinitions(from, same); !diff.Empty() {
+			t.Fatalf("diff = %+v, want none: key order, whitespace and layout are not changes", diff)
+		}
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		t.Parallel()
+
+		to := workflow.RuleDefinition{
+			Name:              "Low stock v2",
+			IsActive:          true,
+			TriggerConditions: from.TriggerConditions,
+			Actions: []workflow.RevisionAction{
+				{ID: notify, Name: "Notify", ActionConfig: json.RawMessage(`{"action_type":"send_notification","title":"Very low"}`), IsActive: true},
+				{ID: alert, Name: "Alert", ActionConfig: json.RawMessage(`{"action_type":"create_alert"}`), IsActive: true},
+			},
+			Edges: []workflow.RevisionEdge{
+				{TargetActionID: notify, EdgeType: "start"},
+				{SourceActionID: &notify, TargetActionID: alert, EdgeType: "sequence", SourceOutput: &approved},
+			},
+			Schedule: &workflow.RevisionSchedule{IntervalSeconds: 3600},
+		}
+
+		diff := workflow.DiffRuleDefinitions(from, to)
+
+		if len(diff.Rule) != 2 || diff.Rule[0].Field != "name" || diff.Rule[1].Field != "schedule" {
+			t.Fatalf("rule fields = %+v, want name and schedule", diff.Rule)
+		}
+		if len(diff.ActionsAdded) != 1 || diff.ActionsAdded[0].ID != alert {
+			t.Fatalf("actions added = %+v, want the alert", diff.ActionsAdded)
+		}
+		if len(diff.ActionsRemoved) != 1 || diff.ActionsRemoved[0].ID != audit {
+			t.Fatalf("actions removed = %+v, want the audit", diff.ActionsRemoved)
+		}
+		if len(diff.ActionsChanged) != 1 || diff.ActionsChanged[0].ActionID != notify ||
+			len(diff.ActionsChanged[0].Fields) != 1 || diff.ActionsChanged[0].Fields[0].Field != "action_config" {
+			t.Fatalf("actions changed = %+v, want the notify config", diff.ActionsChanged)
+		}
+		if len(diff.EdgesAdded) != 1 || diff.EdgesAdded[0].TargetActionID != alert {
+			t.Fatalf("edges added = %+v, want notify -> alert", diff.EdgesAdded)
+		}
+		if len(diff.EdgesRemoved) != 1 || diff.EdgesRemoved[0].TargetActionID != audit {
+			t.Fatalf("edges removed = %+v, want notify -> audit", diff.EdgesRemoved)
+		}
+	})
+
+	t.Run("revisions", func(t *testing.T) {
+		t.Parallel()
+
+		older := workflow.RuleRevision{ID: uuid.New(), RevisionNumber: 3, Definition: from}
+		newer := workflow.RuleRevision{ID: uuid.New(), RevisionNumber: 7, Definition: from}
+		newer.Definition.IsActive = false
+
+		diff := workflow.DiffRuleRevisions(older, newer)
+		if diff.FromRevisionNumber != 3 || diff.ToRevisionNumber != 7 || diff.FromRevisionID != older.ID || diff.ToRevisionID != newer.ID {
+			t.Fatalf("diff revisions = %d->%d, want 3->7", diff.FromRevisionNumber, diff.ToRevisionNumber)
+		}
+		if len(diff.Rule) != 1 || diff.Rule[0].Field != "is_active" || diff.Rule[0].From != true || diff.Rule[0].To != false {
+			t.Fatalf("rule fields = %+v, want is_active true -> false", diff.Rule)
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	// Embedded IANA zone database: the alpine runtime images ship without
+	// /usr/share/zoneinfo, and a schedule's timezone must resolve there too.
+	_ "time/tzdata"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron"
+)
+
+// MinScheduleInterval is the shortest fixed interval a schedule may use. The
+// scheduler polls on a sub-minute tick, so anything finer is not honored anyway.
+const MinScheduleInterval = time.Minute
+
+// Set of error variables for schedule validation.
+var (
+	ErrScheduleSpec     = errors.New("schedule requires exactly one of cron_expression or interval_seconds")
+	ErrScheduleInterval = errors.New("schedule interval is below the minimum")
+	ErrScheduleCron     = errors.New("invalid cron expression")
+	ErrScheduleTimezone = errors.New("invalid schedule timezone")
+)
+
+// RuleSchedule is the firing schedule of an automation rule whose trigger type
+// is "scheduled". Exactly one of CronExpression or IntervalSeconds is set.
+// Timezone is an IANA name from geography.timezones; empty means UTC.
+type RuleSchedule struct {
+	ID              uuid.UUID
+	RuleID          uuid.UUID
+	CronExpression  string
+	IntervalSeconds int
+	Timezone        string
+	NextRunAt       time.Time
+	LastRunAt       *time.Time
+	CreatedDate     time.Time
+	UpdatedDate     time.Time
+
+	// Read-only rule information joined in by the store for the scheduler.
+	RuleName   string
+	EntityName string
+}
+
+// NewRuleSchedule contains information needed to create a rule schedule.
+type NewRuleSchedule struct {
+	RuleID          uuid.UUID
+	CronExpression  string
+	IntervalSeconds int
+	Timezone        string
+}
+
+// UpdateRuleSchedule contains information needed to update a rule schedule.
+// Setting CronExpression clears IntervalSeconds and vice versa.
+type UpdateRuleSchedule struct {
+	CronExpression  *string
+	IntervalSeconds *int
+	Timezone        *string
+}
+
+// ValidateScheduleSpec checks a cron expression / interval / timezone triple
+// without persisting anything. Used by the business layer and by the save
+// app's dry-run so the editor can surface errors before commit.
+func ValidateScheduleSpec(cronExpr string, intervalSeconds int, timezone string) error {
+	if (cronExpr == "") == (intervalSeconds == 0) {
+		return ErrScheduleSpec
+	}
+
+	if intervalSeconds != 0 {
+		if time.Duration(intervalSeconds)*time.Second < MinScheduleInterval {
+			return fmt.Errorf("%w: %ds < %s", ErrScheduleInterval, intervalSeconds, MinScheduleInterval)
+		}
+	} else if _, err := cron.ParseStandard(cronExpr); err != nil {
+		return fmt.Errorf("%w: %q: %s", ErrScheduleCron, cronExpr, err)
+	}
+
+	if _, err := scheduleLocation(timezone); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// NextAfter returns the first firing time strictly after t. Cron expressions
+// are evaluated in the schedule's timezone (so "0 2 * * *" means 02:00 local,
+// across DST changes); fixed intervals are timezone-independent. The result is
+// always in UTC.
+func (rs RuleSchedule) NextAfter(t time.Time) (time.Time, error) {
+	if rs.IntervalSeconds > 0 {
+		return t.Add(time.Duration(rs.IntervalSeconds) * time.Second).UTC(), nil
+	}
+
+	loc, err := scheduleLocation(rs.Timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	sched, err := cron.ParseStandard(rs.CronExpression)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q: %s", ErrScheduleCron, rs.CronExpression, err)
+	}
+
+	next := sched.Next(t.In(loc))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("%w: %q never fires", ErrScheduleCron, rs.CronExpression)
+	}
+
+	return next.UTC(), nil
+}
+
+// Advance returns the next firing time after a run that was due at NextRunAt
+// and observed at now. Missed occurrences (server down, long outage) are
+// coalesced into the single run that is firing now rather than replayed one by
+// one: the result is the first occurrence strictly after now. Fixed intervals
+// stay phase-aligned to their original NextRunAt.
+func (rs RuleSchedule) Advance(now time.Time) (time.Time, error) {
+	if rs.IntervalSeconds > 0 {
+		step := time.Duration(rs.IntervalSeconds) * time.Second
+		next := rs.NextRunAt
+		if !next.After(now) {
+			missed := now.Sub(next)/step + 1
+			next = next.Add(missed * step)
+		}
+		return next.UTC(), nil
+	}
+
+	return rs.NextAfter(now)
+}
+
+func scheduleLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %s", ErrScheduleTimezone, timezone, err)
+	}
+
+	return loc, nil
+}
@@ -0,0 +1,104 @@
+package workflow_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+)
+
+func TestValidateScheduleSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		cron     string
+		interval int
+		tz       string
+		wantErr  error
+	}{
+		{name: "cron", cron: "0 2 * * *", tz: "America/Chicago"},
+		{name: "interval", interval: 900},
+		{name: "descriptor", cron: "@hourly"},
+		{name: "neither", wantErr: workflow.ErrScheduleSpec},
+		{name: "both", cron: "* * * * *", interval: 60, wantErr: workflow.ErrScheduleSpec},
+		{name: "interval too short", interval: 30, wantErr: workflow.ErrScheduleInterval},
+		{name: "bad cron", cron: "every tuesday", wantErr: workflow.ErrScheduleCron},
+		{name: "bad timezone", cron: "0 * * * *", tz: "Mars/Olympus_Mons", wantErr: workflow.ErrScheduleTimezone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := workflow.ValidateScheduleSpec(tt.cron, tt.interval, tt.tz)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuleSchedule_NextAfter_CronHonorsTimezone(t *testing.T) {
+	t.Parallel()
+
+	rs := workflow.RuleSchedule{CronExpression: "0 2 * * *", Timezone: "America/New_York"}
+
+	// Winter: EST is UTC-5, so 02:00 local is 07:00 UTC.
+	got, err := rs.NextAfter(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	if want := time.Date(2026, 1, 11, 7, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("winter next = %v, want %v", got, want)
+	}
+
+	// Summer: EDT is UTC-4, so the same local time lands an hour earlier in UTC.
+	got, err = rs.NextAfter(time.Date(2026, 7, 10, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	if want := time.Date(2026, 7, 11, 6, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("summer next = %v, want %v", got, want)
+	}
+}
+
+func TestRuleSchedule_Advance_CoalescesMissedRuns(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	// Interval: three slots were missed; the next run stays on the 10-minute grid.
+	rs := workflow.RuleSchedule{IntervalSeconds: 600, NextRunAt: due}
+	got, err := rs.Advance(due.Add(35 * time.Minute))
+	if err != nil {
+		t.Fatalf("advance: %v", err)
+	}
+	if want := due.Add(40 * time.Minute); !got.Equal(want) {
+		t.Fatalf("interval advance = %v, want %v", got, want)
+	}
+
+	// On-time interval run moves exactly one step.
+	got, err = rs.Advance(due)
+	if err != nil {
+		t.Fatalf("advance: %v", err)
+	}
+	if want := due.Add(10 * time.Minute); !got.Equal(want) {
+		t.Fatalf("on-time advance = %v, want %v", got, want)
+	}
+
+	// Cron: several hourly slots missed; the next run is the first slot after now.
+	rs = workflow.RuleSchedule{CronExpression: "0 * * * *", NextRunAt: due}
+	got, err = rs.Advance(due.Add(3*time.Hour + 20*time.Minute))
+	if err != nil {
+		t.Fatalf("advance: %v", err)
+	}
+	if want := due.Add(4 * time.Hour); !got.Equal(want) {
+		t.Fatalf("cron advance = %v, want %v", got, want)
+	}
+}
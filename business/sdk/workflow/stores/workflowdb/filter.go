@@ -20,6 +20,11 @@ func applyExecutionFilter(filter workflow.ExecutionFilter, data map[string]any,
 		wc = append(wc, "ae.automation_rules_id = :automation_rules_id")
 	}
 
+	if filter.RevisionID != nil {
+		data["revision_id"] = filter.RevisionID.String()
+		wc = append(wc, "ae.revision_id = :revision_id")
+	}
+
 	if filter.Status != nil {
 		data["status"] = string(*filter.Status)
 		wc = append(wc, "ae.status = :status")
@@ -3,6 +3,7 @@ package workflowdb
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -459,6 +460,8 @@ type automationExecution struct {
 	ExecutedBy      sql.NullString           `db:"executed_by"`      // User who triggered manual execution
 	ExecutedByName  sql.NullString           `db:"executed_by_name"` // From LEFT JOIN with core.users (detail query only)
 	ActionType      sql.NullString           `db:"action_type"`      // For manual executions
+	RevisionID      sql.NullString           `db:"revision_id"`      // Revision whose graph the execution ran
+	RevisionNumber  sql.NullInt32            `db:"revision_number"`  // From LEFT JOIN with rule_revisions
 }
 
 // toCoreAutomationExecution converts a store automationExecution to core AutomationExecution
@@ -500,6 +503,13 @@ func toCoreAutomationExecution(dbExec automationExecution) workflow.AutomationEx
 	if dbExec.ActionType.Valid {
 		ae.ActionType = dbExec.ActionType.String
 	}
+	if dbExec.RevisionID.Valid {
+		revisionID := uuid.MustParse(dbExec.RevisionID.String)
+		ae.RevisionID = &revisionID
+	}
+	if dbExec.RevisionNumber.Valid {
+		ae.RevisionNumber = int(dbExec.RevisionNumber.Int32)
+	}
 	return ae
 }
 
@@ -544,6 +554,9 @@ func toDBAutomationExecution(ae workflow.AutomationExecution) automationExecutio
 	if ae.ActionType != "" {
 		dbExec.ActionType = sql.NullString{String: ae.ActionType, Valid: true}
 	}
+	if ae.RevisionID != nil {
+		dbExec.RevisionID = sql.NullString{String: ae.RevisionID.String(), Valid: true}
+	}
 	return dbExec
 }
 
@@ -938,3 +951,156 @@ func toDBNewActionEdge(edge workflow.NewActionEdge) actionEdge {
 
 	return dbEdge
 }
+
+// ruleSchedule is the firing schedule of a "scheduled" trigger-type rule.
+type ruleSchedule struct {
+	ID              string         `db:"id"`
+	RuleID          string         `db:"rule_id"`
+	CronExpression  sql.NullString `db:"cron_expression"`
+	IntervalSeconds sql.NullInt32  `db:"interval_seconds"`
+	Timezone        sql.NullString `db:"timezone"`
+	NextRunAt       time.Time      `db:"next_run_at"`
+	LastRunAt       sql.NullTime   `db:"last_run_at"`
+	CreatedDate     time.Time      `db:"created_date"`
+	UpdatedDate     time.Time      `db:"updated_date"`
+	RuleName        sql.NullString `db:"rule_name"`   // From JOIN with automation_rules (read only)
+	EntityName      sql.NullString `db:"entity_name"` // From JOIN with entities (read only)
+}
+
+// toCoreRuleSchedule converts a store ruleSchedule to core RuleSchedule
+func toCoreRuleSchedule(dbRS ruleSchedule) workflow.RuleSchedule {
+	rs := workflow.RuleSchedule{
+		ID:             uuid.MustParse(dbRS.ID),
+		RuleID:         uuid.MustParse(dbRS.RuleID),
+		CronExpression: dbRS.CronExpression.String,
+		Timezone:       dbRS.Timezone.String,
+		NextRunAt:      dbRS.NextRunAt.UTC(),
+		CreatedDate:    dbRS.CreatedDate,
+		UpdatedDate:    dbRS.UpdatedDate,
+		RuleName:       dbRS.RuleName.String,
+		EntityName:     dbRS.EntityName.String,
+	}
+	if dbRS.IntervalSeconds.Valid {
+		rs.IntervalSeconds = int(dbRS.IntervalSeconds.Int32)
+	}
+	if dbRS.LastRunAt.Valid {
+		t := dbRS.LastRunAt.Time.UTC()
+		rs.LastRunAt = &t
+	}
+	return rs
+}
+
+func toCoreRuleScheduleSlice(dbRSs []ruleSchedule) []workflow.RuleSchedule {
+	rss := make([]workflow.RuleSchedule, len(dbRSs))
+	for i, dbRS := range dbRSs {
+		rss[i] = toCoreRuleSchedule(dbRS)
+	}
+	return rss
+}
+
+// toDBRuleSchedule converts a core RuleSchedule to store values
+func toDBRuleSchedule(rs workflow.RuleSchedule) ruleSchedule {
+	dbRS := ruleSchedule{
+		ID:          rs.ID.String(),
+		RuleID:      rs.RuleID.String(),
+		NextRunAt:   rs.NextRunAt,
+		LastRunAt:   nulltypes.ToNullTime(rs.LastRunAt),
+		CreatedDate: rs.CreatedDate,
+		UpdatedDate: rs.UpdatedDate,
+	}
+	if rs.CronExpression != "" {
+		dbRS.CronExpression = sql.NullString{String: rs.CronExpression, Valid: true}
+	}
+	if rs.IntervalSeconds > 0 {
+		dbRS.IntervalSeconds = sql.NullInt32{Int32: int32(rs.IntervalSeconds), Valid: true}
+	}
+	if rs.Timezone != "" {
+		dbRS.Timezone = sql.NullString{String: rs.Timezone, Valid: true}
+	}
+	return dbRS
+}
+
+// ruleRevision is an immutable snapshot of an automation rule's definition.
+type ruleRevision struct {
+	ID             string          `db:"id"`
+	RuleID         string          `db:"rule_id"`
+	RevisionNumber int             `db:"revision_number"`
+	Status         string          `db:"status"`
+	Definition     json.RawMessage `db:"definition"`
+	RestoredFromID sql.NullString  `db:"restored_from_id"`
+	Notes          sql.NullString  `db:"notes"`
+	CreatedBy      string          `db:"created_by"`
+	CreatedDate    time.Time       `db:"created_date"`
+	PublishedBy    sql.NullString  `db:"published_by"`
+	PublishedDate  sql.NullTime    `db:"published_date"`
+}
+
+// toCoreRuleRevision converts a store ruleRevision to core RuleRevision
+func toCoreRuleRevision(dbRR ruleRevision) (workflow.RuleRevision, error) {
+	var def workflow.RuleDefinition
+	if err := json.Unmarshal(dbRR.Definition, &def); err != nil {
+		return workflow.RuleRevision{}, fmt.Errorf("unmarshal definition: revisionID[%s]: %w", dbRR.ID, err)
+	}
+
+	rr := workflow.RuleRevision{
+		ID:             uuid.MustParse(dbRR.ID),
+		RuleID:         uuid.MustParse(dbRR.RuleID),
+		RevisionNumber: dbRR.RevisionNumber,
+		Status:         dbRR.Status,
+		Definition:     def,
+		Notes:          dbRR.Notes.String,
+		CreatedBy:      uuid.MustParse(dbRR.CreatedBy),
+		CreatedDate:    dbRR.CreatedDate,
+		PublishedDate:  nulltypes.TimePtr(dbRR.PublishedDate),
+	}
+	if dbRR.RestoredFromID.Valid {
+		id := uuid.MustParse(dbRR.RestoredFromID.String)
+		rr.RestoredFromID = &id
+	}
+	if dbRR.PublishedBy.Valid {
+		id := uuid.MustParse(dbRR.PublishedBy.String)
+		rr.PublishedBy = &id
+	}
+	return rr, nil
+}
+
+func toCoreRuleRevisionSlice(dbRRs []ruleRevision) ([]workflow.RuleRevision, error) {
+	rrs := make([]workflow.RuleRevision, len(dbRRs))
+	for i, dbRR := range dbRRs {
+		rr, err := toCoreRuleRevision(dbRR)
+		if err != nil {
+			return nil, err
+		}
+		rrs[i] = rr
+	}
+	return rrs, nil
+}
+
+// toDBRuleRevision converts a core RuleRevision to store values
+func toDBRuleRevision(rr workflow.RuleRevision) (ruleRevision, error) {
+	def, err := json.Marshal(rr.Definition)
+	if err != nil {
+		return ruleRevision{}, fmt.Errorf("marshal definition: %w", err)
+	}
+
+	dbRR := ruleRevision{
+		ID:             rr.ID.String(),
+		RuleID:         rr.RuleID.String(),
+		RevisionNumber: rr.RevisionNumber,
+		Status:         rr.Status,
+		Definition:     def,
+		CreatedBy:      rr.CreatedBy.String(),
+		CreatedDate:    rr.CreatedDate,
+		PublishedDate:  nulltypes.ToNullTime(rr.PublishedDate),
+	}
+	if rr.RestoredFromID != nil {
+		dbRR.RestoredFromID = sql.NullString{String: rr.RestoredFromID.String(), Valid: true}
+	}
+	if rr.Notes != "" {
+		dbRR.Notes = sql.NullString{String: rr.Notes, Valid: true}
+	}
+	if rr.PublishedBy != nil {
+		dbRR.PublishedBy = sql.NullString{String: rr.PublishedBy.String(), Valid: true}
+	}
+	return dbRR, nil
+}
@@ -974,17 +974,19 @@ func (s *Store) QueryActiveTemplates(ctx context.Context) ([]workflow.ActionTemp
 // =============================================================================
 // Automation Executions
 
-// CreateExecution inserts a new automation execution into the database.
+// CreateExecution inserts a new automation execution into the database. The
+// revision is the one the caller loaded the execution's graph from; the
+// trigger reads both in one query, so the pin matches the graph that runs.
 func (s *Store) CreateExecution(ctx context.Context, exec workflow.AutomationExecution) error {
 	const q = `
 	INSERT INTO workflow.automation_executions (
 		id, automation_rules_id, entity_type, trigger_data, actions_executed,
 		status, error_message, execution_time_ms, executed_at,
-		trigger_source, executed_by, action_type
+		trigger_source, executed_by, action_type, revision_id
 	) VALUES (
 		:id, :automation_rules_id, :entity_type, :trigger_data, :actions_executed,
 		:status, :error_message, :execution_time_ms, :executed_at,
-		:trigger_source, :executed_by, :action_type
+		:trigger_source, :executed_by, :action_type, :revision_id
 	)`
 
 	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBAutomationExecution(exec)); err != nil {
@@ -1094,7 +1096,7 @@ func (s *Store) QueryExecutionHistory(ctx context.Context, ruleID uuid.UUID, lim
 	SELECT
 		id, automation_rules_id, entity_type, trigger_data, actions_executed,
 		status, error_message, execution_time_ms, executed_at,
-		trigger_source, executed_by, action_type
+		trigger_source, executed_by, action_type, revision_id
 	FROM
 		workflow.automation_executions
 	WHERE
@@ -1475,11 +1477,15 @@ func (s *Store) QueryExecutionsPaginated(
 		ae.executed_at,
 		ae.trigger_source,
 		ae.executed_by,
-		ae.action_type
+		ae.action_type,
+		ae.revision_id,
+		rr.revision_number
 	FROM
 		workflow.automation_executions ae
 	LEFT JOIN
-		workflow.automation_rules ar ON ae.automation_rules_id = ar.id`
+		workflow.automation_rules ar ON ae.automation_rules_id = ar.id
+	LEFT JOIN
+		workflow.rule_revisions rr ON ae.revision_id = rr.id`
 
 	buf := bytes.NewBufferString(baseQuery)
 
@@ -1549,9 +1555,12 @@ func (s *Store) QueryExecutionByID(ctx context.Context, id uuid.UUID) (workflow.
 		ae.trigger_source,
 		ae.executed_by,
 		u.first_name || ' ' || u.last_name AS executed_by_name,
-		ae.action_type
+		ae.action_type,
+		ae.revision_id,
+		rr.revision_number
 	FROM workflow.automation_executions ae
 	LEFT JOIN workflow.automation_rules ar ON ae.automation_rules_id = ar.id
+	LEFT JOIN workflow.rule_revisions rr ON ae.revision_id = rr.id
 	LEFT JOIN core.users u ON ae.executed_by = u.id
 	WHERE ae.id = :id`
 
@@ -1668,3 +1677,309 @@ func (s *Store) DeleteEdgesByRuleID(ctx context.Context, ruleID uuid.UUID) error
 
 	return nil
 }
+
+// =============================================================================
+// Rule Schedules (for "scheduled" trigger-type rules)
+
+const ruleScheduleColumns = `
+		rs.id, rs.rule_id, rs.cron_expression, rs.interval_seconds, rs.timezone,
+		rs.next_run_at, rs.last_run_at, rs.created_date, rs.updated_date`
+
+// CreateRuleSchedule inserts a new rule schedule into the database.
+func (s *Store) CreateRuleSchedule(ctx context.Context, rs workflow.RuleSchedule) error {
+	const q = `
+	INSERT INTO workflow.rule_schedules (
+		id, rule_id, cron_expression, interval_seconds, timezone,
+		next_run_at, last_run_at, created_date, updated_date
+	) VALUES (
+		:id, :rule_id, :cron_expression, :interval_seconds, :timezone,
+		:next_run_at, :last_run_at, :created_date, :updated_date
+	)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBRuleSchedule(rs)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateRuleSchedule replaces a rule schedule's spec and next/last run times.
+func (s *Store) UpdateRuleSchedule(ctx context.Context, rs workflow.RuleSchedule) error {
+	const q = `
+	UPDATE workflow.rule_schedules
+	SET
+		cron_expression = :cron_expression,
+		interval_seconds = :interval_seconds,
+		timezone = :timezone,
+		next_run_at = :next_run_at,
+		last_run_at = :last_run_at,
+		updated_date = :updated_date
+	WHERE id = :id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBRuleSchedule(rs)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRuleSchedule removes a rule schedule.
+func (s *Store) DeleteRuleSchedule(ctx context.Context, rs workflow.RuleSchedule) error {
+	data := struct {
+		ID string `db:"id"`
+	}{
+		ID: rs.ID.String(),
+	}
+
+	const q = `DELETE FROM workflow.rule_schedules WHERE id = :id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, data); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// QueryRuleScheduleByRuleID retrieves the schedule attached to a rule.
+func (s *Store) QueryRuleScheduleByRuleID(ctx context.Context, ruleID uuid.UUID) (workflow.RuleSchedule, error) {
+	data := struct {
+		RuleID string `db:"rule_id"`
+	}{
+		RuleID: ruleID.String(),
+	}
+
+	const q = `
+	SELECT` + ruleScheduleColumns + `,
+		ar.name AS rule_name, e.name AS entity_name
+	FROM workflow.rule_schedules rs
+	JOIN workflow.automation_rules ar ON ar.id = rs.rule_id
+	LEFT JOIN workflow.entities e ON e.id = ar.entity_id
+	WHERE rs.rule_id = :rule_id`
+
+	var dbRS ruleSchedule
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbRS); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return workflow.RuleSchedule{}, workflow.ErrNotFound
+		}
+		return workflow.RuleSchedule{}, fmt.Errorf("namedquerystruct: %w", err)
+	}
+
+	return toCoreRuleSchedule(dbRS), nil
+}
+
+// QueryDueRuleSchedules returns up to limit schedules whose next_run_at is at or
+// before now, oldest first. Only schedules of active rules whose trigger type is
+// still "scheduled" are returned: a rule switched to on_create keeps its row
+// until the next save, but must not fire. Used by temporal.Scheduler.
+func (s *Store) QueryDueRuleSchedules(ctx context.Context, now time.Time, limit int) ([]workflow.RuleSchedule, error) {
+	data := struct {
+		Now       time.Time `db:"now"`
+		Limit     int       `db:"limit"`
+		Scheduled string    `db:"scheduled"`
+	}{
+		Now:       now,
+		Limit:     limit,
+		Scheduled: workflow.EventTypeScheduled,
+	}
+
+	const q = `
+	SELECT` + ruleScheduleColumns + `,
+		ar.name AS rule_name, e.name AS entity_name
+	FROM workflow.rule_schedules rs
+	JOIN workflow.automation_rules ar ON ar.id = rs.rule_id
+	JOIN workflow.trigger_types tt ON tt.id = ar.trigger_type_id
+	LEFT JOIN workflow.entities e ON e.id = ar.entity_id
+	WHERE rs.next_run_at <= :now
+	  AND ar.is_active = TRUE
+	  AND tt.name = :scheduled
+	ORDER BY rs.next_run_at ASC
+	LIMIT :limit`
+
+	var dbRSs []ruleSchedule
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbRSs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreRuleScheduleSlice(dbRSs), nil
+}
+
+// AdvanceRuleSchedule moves a schedule from the occurrence it just fired
+// (expected) to next, stamping last_run_at. It is a compare-and-set on
+// next_run_at: when several replicas tick the same due schedule, exactly one
+// advances it and the others see false. Returns whether this call advanced it.
+func (s *Store) AdvanceRuleSchedule(ctx context.Context, id uuid.UUID, expected time.Time, next time.Time, ranAt time.Time) (bool, error) {
+	data := struct {
+		ID        string    `db:"id"`
+		Expected  time.Time `db:"expected"`
+		NextRunAt time.Time `db:"next_run_at"`
+		LastRunAt time.Time `db:"last_run_at"`
+	}{
+		ID:        id.String(),
+		Expected:  expected,
+		NextRunAt: next,
+		LastRunAt: ranAt,
+	}
+
+	const q = `
+	UPDATE workflow.rule_schedules
+	SET next_run_at = :next_run_at, last_run_at = :last_run_at, updated_date = NOW()
+	WHERE id = :id AND next_run_at = :expected`
+
+	n, err := sqldb.NamedExecContextWithCount(ctx, s.log, s.db, q, data)
+	if err != nil {
+		return false, fmt.Errorf("namedexeccontextwithcount: %w", err)
+	}
+
+	return n == 1, nil
+}
+
+// =============================================================================
+// Rule Revisions (immutable snapshots of a rule's definition)
+
+const ruleRevisionColumns = `
+		id, rule_id, revision_number, status, definition, restored_from_id, notes,
+		created_by, created_date, published_by, published_date`
+
+// NextRuleRevisionNumber locks the rule row and returns the number its next
+// revision takes. The lock is held until the surrounding transaction ends, so
+// two saves of the same rule cannot claim the same number.
+func (s *Store) NextRuleRevisionNumber(ctx context.Context, ruleID uuid.UUID) (int, error) {
+	data := struct {
+		RuleID string `db:"rule_id"`
+	}{
+		RuleID: ruleID.String(),
+	}
+
+	const q = `
+	SELECT COALESCE(MAX(rr.revision_number), 0) + 1 AS next
+	FROM (
+		SELECT id FROM workflow.automation_rules WHERE id = :rule_id FOR UPDATE
+	) ar
+	LEFT JOIN workflow.rule_revisions rr ON rr.rule_id = ar.id`
+
+	var result struct {
+		Next int `db:"next"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &result); err != nil {
+		return 0, fmt.Errorf("namedquerystruct: %w", err)
+	}
+
+	return result.Next, nil
+}
+
+// CreateRuleRevision inserts a new rule revision into the database.
+func (s *Store) CreateRuleRevision(ctx context.Context, rr workflow.RuleRevision) error {
+	dbRR, err := toDBRuleRevision(rr)
+	if err != nil {
+		return err
+	}
+
+	const q = `
+	INSERT INTO workflow.rule_revisions (
+		id, rule_id, revision_number, status, definition, restored_from_id, notes,
+		created_by, created_date, published_by, published_date
+	) VALUES (
+		:id, :rule_id, :revision_number, :status, :definition, :restored_from_id, :notes,
+		:created_by, :created_date, :published_by, :published_date
+	)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, dbRR); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateRuleRevisionStatus writes a revision's status and publish stamp. The
+// definition is never written after the revision is created.
+func (s *Store) UpdateRuleRevisionStatus(ctx context.Context, rr workflow.RuleRevision) error {
+	dbRR, err := toDBRuleRevision(rr)
+	if err != nil {
+		return err
+	}
+
+	const q = `
+	UPDATE workflow.rule_revisions
+	SET
+		status = :status,
+		published_by = :published_by,
+		published_date = :published_date
+	WHERE id = :id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, dbRR); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// SupersedeRuleRevisions moves the rule's published revision, if any, to
+// superseded.
+func (s *Store) SupersedeRuleRevisions(ctx context.Context, ruleID uuid.UUID) error {
+	data := struct {
+		RuleID     string `db:"rule_id"`
+		Published  string `db:"published"`
+		Superseded string `db:"superseded"`
+	}{
+		RuleID:     ruleID.String(),
+		Published:  workflow.RevisionPublished,
+		Superseded: workflow.RevisionSuperseded,
+	}
+
+	const q = `
+	UPDATE workflow.rule_revisions
+	SET status = :superseded
+	WHERE rule_id = :rule_id AND status = :published`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, data); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// QueryRuleRevisionsByRuleID returns every revision of a rule, newest first.
+func (s *Store) QueryRuleRevisionsByRuleID(ctx context.Context, ruleID uuid.UUID) ([]workflow.RuleRevision, error) {
+	data := struct {
+		RuleID string `db:"rule_id"`
+	}{
+		RuleID: ruleID.String(),
+	}
+
+	const q = `
+	SELECT` + ruleRevisionColumns + `
+	FROM workflow.rule_revisions
+	WHERE rule_id = :rule_id
+	ORDER BY revision_number DESC`
+
+	var dbRRs []ruleRevision
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbRRs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreRuleRevisionSlice(dbRRs)
+}
+
+// QueryRuleRevisionByID retrieves a single rule revision.
+func (s *Store) QueryRuleRevisionByID(ctx context.Context, id uuid.UUID) (workflow.RuleRevision, error) {
+	data := struct {
+		ID string `db:"id"`
+	}{
+		ID: id.String(),
+	}
+
+	const q = `
+	SELECT` + ruleRevisionColumns + `
+	FROM workflow.rule_revisions
+	WHERE id = :id`
+
+	var dbRR ruleRevision
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbRR); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return workflow.RuleRevision{}, workflow.ErrNotFound
+		}
+		return workflow.RuleRevision{}, fmt.Errorf("namedquerystruct: %w", err)
+	}
+
+	return toCoreRuleRevision(dbRR)
+}
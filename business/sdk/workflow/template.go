@@ -2,15 +2,18 @@ package workflow
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// exprPattern matches {{expr: <arithmetic expression>}} blocks, including optional pipe filters.
+// exprPattern matches {{expr: <expression>}} blocks, including optional pipe filters.
 // Example: {{expr: quantity * unit_price}} or {{expr: subtotal + tax | currency:USD}}
 var exprPattern = regexp.MustCompile(`\{\{expr:([^}]+)\}\}`)
 
@@ -165,10 +168,54 @@ func (tp *TemplateProcessor) ProcessTemplateObject(obj interface{}, context Temp
 	return result
 }
 
+// ResolveValue resolves a template that is a single variable reference, such
+// as "{{line_items}}", to the variable's value rather than its string form, so
+// lists and objects come back intact. Filters are applied. It reports false
+// when the template is not a single reference or the variable is missing.
+//
+// A template that is a single {{expr: ...}} block resolves to the expression's
+// value, so "{{expr: filter(line_items, .quantity > 0)}}" yields a list.
+func (tp *TemplateProcessor) ResolveValue(template string, context TemplateContext) (interface{}, bool) {
+	template = strings.TrimSpace(template)
+
+	if m := exprPattern.FindStringSubmatch(template); m != nil && m[0] == template {
+		val, filters, err := tp.evalExprBlock(m[1], context)
+		if err != nil || val == nil {
+			return nil, false
+		}
+		if filters != "" {
+			_, specs := tp.parseVariablePath("x| " + filters)
+			filtered, err := tp.applyFilters(val, specs)
+			if err != nil {
+				return nil, false
+			}
+			return filtered, true
+		}
+		return val, true
+	}
+
+	match := tp.variableRegex.FindStringSubmatch(template)
+	if match == nil || match[0] != template {
+		return nil, false
+	}
+
+	variablePath := strings.TrimSpace(match[1])
+	if err := tp.validateVariable(variablePath); err != nil {
+		return nil, false
+	}
+
+	resolution := tp.resolve(variablePath, context)
+	if !resolution.Found {
+		return nil, false
+	}
+
+	return resolution.Value, true
+}
+
 // processExprBlocks evaluates all {{expr: <expression>}} blocks in the template string.
-// Expressions support arithmetic (+, -, *, /, %) with variables resolved from context.
+// See ParseExpr for the expression language; variables are resolved from context.
 // Pipe filters (e.g. {{expr: qty * price | currency:USD}}) are applied after evaluation.
-// On eval error, the original {{expr: ...}} block is preserved (fail-open).
+// On eval error or a null result, the original {{expr: ...}} block is preserved (fail-open).
 func (tp *TemplateProcessor) processExprBlocks(template string, context TemplateContext, result *TemplateProcessingResult) string {
 	return exprPattern.ReplaceAllStringFunc(template, func(match string) string {
 		submatches := exprPattern.FindStringSubmatch(match)
@@ -176,37 +223,117 @@ func (tp *TemplateProcessor) processExprBlocks(template string, context Template
 			return match
 		}
 
-		content := strings.TrimSpace(submatches[1])
-
-		// Split on first | to separate the arithmetic expression from any pipe filters.
-		parts := strings.SplitN(content, "|", 2)
-		expression := strings.TrimSpace(parts[0])
-
-		val, err := EvalExpr(expression, map[string]any(context))
+		val, filters, err := tp.evalExprBlock(submatches[1], context)
+		if err == nil && val == nil {
+			err = errors.New("expression is null")
+		}
 		if err != nil {
+			expression, _ := splitExprFilters(submatches[1])
 			result.Warnings = append(result.Warnings, fmt.Sprintf("expr eval failed %q: %v", expression, err))
 			return match // fail-open: preserve original {{expr: ...}}
 		}
 
 		// Apply pipe filters if present (e.g. "| currency:USD").
-		if len(parts) > 1 {
-			filterStr := "| " + strings.TrimSpace(parts[1])
-			_, filters := tp.parseVariablePath("x" + filterStr)
-			if filtered, ferr := tp.applyFilters(val, filters); ferr == nil {
+		if filters != "" {
+			_, specs := tp.parseVariablePath("x| " + filters)
+			if filtered, ferr := tp.applyFilters(val, specs); ferr == nil {
 				return tp.valueToString(filtered)
 			}
 		}
 
-		// Format as integer when the result has no fractional part.
-		if val == float64(int64(val)) {
-			return strconv.FormatInt(int64(val), 10)
-		}
-		// Use 10 significant digits with 'g' format to strip floating-point noise
-		// (e.g. 9.200000000000001 → "9.2") while preserving meaningful precision.
-		return strconv.FormatFloat(val, 'g', 10, 64)
+		// Whole numbers print without a fraction; other numbers use 10 significant
+		// digits to strip floating-point noise (9.200000000000001 → "9.2").
+		return exprString(val)
 	})
 }
 
+// evalExprBlock evaluates the content of an {{expr: ...}} block and returns its value
+// and any pipe filters that follow the expression. $me and $now are available when
+// built-ins are set, and now() is then the built-in timestamp.
+func (tp *TemplateProcessor) evalExprBlock(content string, context TemplateContext) (any, string, error) {
+	expression, filters := splitExprFilters(content)
+
+	expr, err := ParseExpr(expression)
+	if err != nil {
+		return nil, filters, err
+	}
+
+	env := ExprEnv{Vars: map[string]any(context)}
+	if tp.builtins != nil {
+		vars := make(map[string]any, len(context)+2)
+		maps.Copy(vars, context)
+		vars["$me"] = tp.builtins.UserID
+		vars["$now"] = tp.builtins.Timestamp
+		env = ExprEnv{Vars: vars, Now: tp.builtins.Timestamp}
+	}
+
+	val, err := expr.Eval(env)
+	return val, filters, err
+}
+
+// splitExprFilters splits an {{expr: ...}} block's content into the expression and the
+// pipe filters after it. A "|" inside a string literal or as part of "||" is part of the
+// expression.
+func splitExprFilters(content string) (string, string) {
+	var quote byte
+	for i := 0; i < len(content); i++ {
+		ch := content[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == '|':
+			if i+1 < len(content) && content[i+1] == '|' {
+				i++
+				continue
+			}
+			return strings.TrimSpace(content[:i]), strings.TrimSpace(content[i+1:])
+		}
+	}
+	return strings.TrimSpace(content), ""
+}
+
+// ValidateTemplateExprs parses every {{expr: ...}} block in an action config, so a
+// malformed expression is reported when the workflow is saved instead of being left
+// unevaluated at run time. The error gives the position within the expression.
+func ValidateTemplateExprs(config json.RawMessage) error {
+	var v any
+	if err := json.Unmarshal(config, &v); err != nil {
+		return nil // malformed JSON is reported by the config's own validation
+	}
+	return validateTemplateExprValue(v)
+}
+
+func validateTemplateExprValue(v any) error {
+	switch v := v.(type) {
+	case string:
+		for _, m := range exprPattern.FindAllStringSubmatch(v, -1) {
+			expression, _ := splitExprFilters(m[1])
+			if _, err := ParseExpr(expression); err != nil {
+				return fmt.Errorf("{{expr: %s}}: %w", expression, err)
+			}
+		}
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			if err := validateTemplateExprValue(v[key]); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if err := validateTemplateExprValue(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // processValue recursively processes a value
 func (tp *TemplateProcessor) processValue(value interface{}, context TemplateContext, result *TemplateProcessingResult) interface{} {
 	if value == nil {
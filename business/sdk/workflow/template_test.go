@@ -827,6 +827,49 @@ func TestTemplateProcessor_EdgeCases(t *testing.T) {
 	}
 }
 
+func TestTemplateProcessor_ResolveValue(t *testing.T) {
+	t.Parallel()
+
+	lines := []any{
+		map[string]any{"product_id": "p1", "quantity": 2.0},
+		map[string]any{"product_id": "p2", "quantity": 1.0},
+	}
+	context := workflow.TemplateContext{
+		"line_items": lines,
+		"raw_data":   map[string]any{"line_items": lines},
+		"name":       "order",
+	}
+
+	tests := []struct {
+		name      string
+		template  string
+		want      any
+		wantFound bool
+	}{
+		{name: "list", template: "{{line_items}}", want: lines, wantFound: true},
+		{name: "nested list", template: " {{ raw_data.line_items }} ", want: lines, wantFound: true},
+		{name: "scalar", template: "{{name}}", want: "order", wantFound: true},
+		{name: "missing", template: "{{missing}}", wantFound: false},
+		{name: "surrounding text", template: "items: {{line_items}}", wantFound: false},
+		{name: "two references", template: "{{name}}{{name}}", wantFound: false},
+		{name: "not a reference", template: "line_items", wantFound: false},
+	}
+
+	processor := workflow.NewTemplateProcessor(workflow.DefaultTemplateProcessingOptions())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := processor.ResolveValue(tt.template, context)
+			if found != tt.wantFound {
+				t.Fatalf("ResolveValue() found = %v, want %v", found, tt.wantFound)
+			}
+			if diff := cmp.Diff(tt.want, got); found && diff != "" {
+				t.Errorf("ResolveValue() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 // Benchmark tests
 func BenchmarkTemplateProcessor_Simple(b *testing.B) {
 	processor := workflow.NewTemplateProcessor(workflow.DefaultTemplateProcessingOptions())
@@ -3,11 +3,13 @@ package temporal
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
 
 	"github.com/timmaaaz/ichor/business/sdk/workflow"
 )
@@ -29,6 +31,11 @@ type Activities struct {
 	// registrations that don't care about the record table), those activities no-op so the
 	// workflow still runs.
 	ExecutionStore ExecutionLifecycleStore
+
+	// GraphStore loads the graph of the rule a call_workflow action starts, via the
+	// LoadRuleGraph activity. Unlike ExecutionStore it is not optional for that
+	// activity: without it a call_workflow action fails.
+	GraphStore RuleGraphStore
 }
 
 // ExecutionLifecycleStore advances an execution record's status. Satisfied by
@@ -38,6 +45,13 @@ type ExecutionLifecycleStore interface {
 	UpdateExecutionStatus(ctx context.Context, id uuid.UUID, status workflow.ExecutionStatus, errMsg string) error
 }
 
+// RuleGraphStore loads a rule's name and graph for call_workflow. Satisfied by
+// stores/edgedb.Store. Named distinctly from trigger.go's EdgeStore, which loads
+// only the actions and edges the trigger already knows the rule for.
+type RuleGraphStore interface {
+	QueryRuleGraph(ctx context.Context, ruleID uuid.UUID) (RuleGraph, error)
+}
+
 // MarkExecutionFailedInput carries the failure detail for the MarkExecutionFailed activity.
 type MarkExecutionFailedInput struct {
 	ExecutionID  uuid.UUID
@@ -73,6 +87,27 @@ func (a *Activities) MarkExecutionFailed(ctx context.Context, in MarkExecutionFa
 	return a.ExecutionStore.UpdateExecutionStatus(ctx, in.ExecutionID, workflow.StatusFailed, in.ErrorMessage)
 }
 
+// LoadRuleGraph loads the graph of the rule a call_workflow action starts. It runs as an
+// activity because the interpreter may not read the database directly; the loaded graph is
+// recorded in history, so a replay runs the graph the call originally saw. A missing rule is
+// a non-retryable failure.
+func (a *Activities) LoadRuleGraph(ctx context.Context, ruleID uuid.UUID) (RuleGraph, error) {
+	if a.GraphStore == nil {
+		return RuleGraph{}, temporal.NewNonRetryableApplicationError(
+			"rule graph store not configured", "GraphStoreMissing", nil)
+	}
+
+	rg, err := a.GraphStore.QueryRuleGraph(ctx, ruleID)
+	if err != nil {
+		if errors.Is(err, ErrRuleNotFound) {
+			return RuleGraph{}, temporal.NewNonRetryableApplicationError(err.Error(), "RuleNotFound", err)
+		}
+		return RuleGraph{}, fmt.Errorf("load rule graph %s: %w", ruleID, err)
+	}
+
+	return rg, nil
+}
+
 // =============================================================================
 // Synchronous Activity
 // =============================================================================
@@ -0,0 +1,147 @@
+package temporal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// =============================================================================
+// Call-Workflow Execution
+// =============================================================================
+
+// ExecuteCalledWorkflow runs the graph of a rule started by a call_workflow
+// action. It is a child workflow of the caller and returns every action result
+// the called graph produced, from which the caller picks its outputs.
+//
+// Unlike ExecuteGraphWorkflow it does not advance an execution record (the
+// called graph runs under the caller's execution) and never continues as new.
+func ExecuteCalledWorkflow(ctx workflow.Context, input WorkflowInput) (BranchOutput, error) {
+	logger := workflow.GetLogger(ctx)
+
+	if err := input.Validate(); err != nil {
+		return BranchOutput{}, fmt.Errorf("validate called workflow input: %w", err)
+	}
+
+	logger.Info("Starting called workflow",
+		"rule_id", input.RuleID,
+		"rule_name", input.RuleName,
+		"execution_id", input.ExecutionID,
+	)
+
+	mergedCtx := NewMergedContext(input.TriggerData)
+	executor := NewGraphExecutor(input.Graph)
+
+	if err := executeActions(ctx, executor, executor.GetStartActions(), mergedCtx, input); err != nil {
+		return BranchOutput{}, err
+	}
+
+	return BranchOutput{
+		ActionResults: mergedCtx.ActionResults,
+	}, nil
+}
+
+// runCallWorkflow runs the rule a call_workflow node names as a child workflow
+// and returns the node's result. A failure inside the called graph is routed to
+// the "failure" port; a call that cannot start at all (bad config, a rule
+// already on the call stack, too deep, missing rule) fails the caller.
+//
+// The called rule starts with the caller's event metadata plus the configured
+// inputs. The call extends the cascade lineage: the caller joins the call stack,
+// which is how recursion is refused, and writes made by the called rule carry
+// the caller's chain forward like any other action's writes.
+func runCallWorkflow(ctx workflow.Context, action ActionNode, mergedCtx *MergedContext, ruleID uuid.UUID, executionID uuid.UUID) (map[string]any, error) {
+	logger := workflow.GetLogger(ctx)
+
+	cfg, err := parseCallWorkflowConfig(action.Config)
+	if err != nil {
+		return nil, fmt.Errorf("call_workflow action %s: %w", action.Name, err)
+	}
+
+	lineage := lineageFromContextMap(mergedCtx.Flattened)
+	if cfg.RuleID == ruleID || lineage.InCallStack(cfg.RuleID) {
+		return nil, fmt.Errorf("call_workflow action %s: rule %s is already running in this call chain", action.Name, cfg.RuleID)
+	}
+	if len(lineage.CallStack) >= MaxCallDepth {
+		return nil, fmt.Errorf("call_workflow action %s: calls nested more than %d deep", action.Name, MaxCallDepth)
+	}
+
+	loadCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    10 * time.Second,
+			MaximumAttempts:    3,
+		},
+	})
+
+	var rg RuleGraph
+	if err := workflow.ExecuteActivity(loadCtx, "LoadRuleGraph", cfg.RuleID).Get(ctx, &rg); err != nil {
+		return nil, fmt.Errorf("call_workflow action %s: load rule %s: %w", action.Name, cfg.RuleID, err)
+	}
+
+	childLineage := lineage.WithCall(ruleID, cfg.RuleID, calledEntityID(mergedCtx))
+	triggerData := calledTriggerData(cfg, mergedCtx, childLineage)
+
+	logger.Info("Call-workflow action - starting called rule",
+		"action_name", action.Name,
+		"called_rule_id", rg.RuleID,
+		"called_rule_name", rg.RuleName,
+		"depth", len(childLineage.CallStack),
+	)
+
+	// A rule with no actions has nothing to run; it completes with no outputs.
+	if len(rg.Graph.Actions) == 0 {
+		return callWorkflowResult(rg, callWorkflowConfig{}, triggerData, BranchOutput{}, nil), nil
+	}
+
+	childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+		WorkflowID: fmt.Sprintf("%s-call-%s",
+			workflow.GetInfo(ctx).WorkflowExecution.ID,
+			action.ID,
+		),
+	})
+
+	var output BranchOutput
+	runErr := workflow.ExecuteChildWorkflow(childCtx, ExecuteCalledWorkflow,
+		WorkflowInput{
+			RuleID:      rg.RuleID,
+			RuleName:    rg.RuleName,
+			ExecutionID: executionID,
+			Graph:       rg.Graph,
+			TriggerData: triggerData,
+		},
+	).Get(ctx, &output)
+
+	if runErr != nil {
+		logger.Warn("Called rule failed",
+			"action_name", action.Name,
+			"called_rule_id", rg.RuleID,
+			"error", runErr,
+		)
+	}
+
+	return callWorkflowResult(rg, cfg, triggerData, output, runErr), nil
+}
+
+// executeCallWorkflow runs a call_workflow node in the main workflow and
+// continues from the port its result chose.
+func executeCallWorkflow(ctx workflow.Context, executor *GraphExecutor, action ActionNode, mergedCtx *MergedContext, input WorkflowInput) error {
+	result, err := runCallWorkflow(ctx, action, mergedCtx, input.RuleID, input.ExecutionID)
+	if err != nil {
+		return err
+	}
+
+	mergedCtx.MergeResult(action.Name, result)
+
+	nextActions := executor.GetNextActions(action.ID, result)
+	if len(nextActions) == 0 {
+		return nil
+	}
+
+	return executeActions(ctx, executor, nextActions, mergedCtx, input)
+}
@@ -0,0 +1,173 @@
+package temporal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+)
+
+// =============================================================================
+// Call-Workflow Action Support
+// =============================================================================
+
+const (
+	// callWorkflowActionType is intercepted by the interpreter: the node is not an
+	// activity, it runs another rule's graph as a child workflow.
+	callWorkflowActionType = workflow.CallWorkflowActionType
+
+	// callWorkflowPortCompleted is taken when the called rule's graph finished.
+	// callWorkflowPortFailure is taken when it failed.
+	callWorkflowPortCompleted = "completed"
+	callWorkflowPortFailure   = "failure"
+
+	// MaxCallDepth bounds how deeply call_workflow actions may nest. Recursion is
+	// refused outright (WorkflowLineage.CallStack); this is the backstop for a
+	// long chain of distinct rules.
+	MaxCallDepth = 5
+
+	// calledWorkflowTypeName is the registered name of ExecuteCalledWorkflow.
+	calledWorkflowTypeName = "ExecuteCalledWorkflow"
+)
+
+// ErrRuleNotFound is returned by a RuleGraphStore when the called rule does not exist.
+var ErrRuleNotFound = errors.New("rule not found")
+
+// RuleGraph is a rule's name and graph, as loaded for a call_workflow action.
+// The graph is the rule's published revision; RevisionID is nil when the rule
+// has none and its live graph was loaded instead.
+type RuleGraph struct {
+	RuleID     uuid.UUID       `json:"rule_id"`
+	RuleName   string          `json:"rule_name"`
+	RevisionID *uuid.UUID      `json:"revision_id,omitempty"`
+	Graph      GraphDefinition `json:"graph"`
+}
+
+// callWorkflowEventKeys are the trigger fields a called rule inherits from its
+// caller, so its actions act on the same entity and user. They are the keys
+// buildTriggerData writes for event metadata.
+var callWorkflowEventKeys = []string{"event_type", "entity_name", "entity_id", "user_id", "timestamp", "field_changes"}
+
+// callWorkflowConfig is used to parse call_workflow action configuration.
+type callWorkflowConfig struct {
+	RuleID  uuid.UUID         `json:"rule_id"`
+	Inputs  map[string]any    `json:"inputs"`
+	Outputs map[string]string `json:"outputs"`
+}
+
+// parseCallWorkflowConfig parses a call_workflow action's config.
+func parseCallWorkflowConfig(config json.RawMessage) (callWorkflowConfig, error) {
+	var cfg callWorkflowConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return callWorkflowConfig{}, fmt.Errorf("invalid call_workflow config: %w", err)
+	}
+
+	if cfg.RuleID == uuid.Nil {
+		return callWorkflowConfig{}, errors.New("call_workflow rule_id is required")
+	}
+
+	return cfg, nil
+}
+
+// calledTriggerData builds the trigger data the called rule starts with: the
+// caller's event metadata, the configured inputs resolved against the caller's
+// context, and the cascade lineage for the call. The called rule sees nothing
+// else of the caller's context.
+func calledTriggerData(cfg callWorkflowConfig, mergedCtx *MergedContext, lineage WorkflowLineage) map[string]any {
+	data := make(map[string]any, len(callWorkflowEventKeys)+len(cfg.Inputs)+1)
+
+	for _, k := range callWorkflowEventKeys {
+		if v, ok := mergedCtx.TriggerData[k]; ok {
+			data[k] = v
+		}
+	}
+
+	processor := workflow.NewTemplateProcessor(workflow.DefaultTemplateProcessingOptions())
+	tmplCtx := templateContext(mergedCtx)
+	for name, v := range cfg.Inputs {
+		data[name] = resolveTemplateValue(processor, v, tmplCtx)
+	}
+
+	data[CascadeLineageKey] = lineage
+
+	return data
+}
+
+// calledEntityID returns the entity the caller's chain is about, if any.
+func calledEntityID(mergedCtx *MergedContext) uuid.UUID {
+	s, _ := mergedCtx.TriggerData["entity_id"].(string)
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil
+	}
+	return id
+}
+
+// resolveTemplateValue resolves templates in an input value. A string that is a
+// single reference such as "{{lookup.lines}}" keeps the referenced value's type;
+// other strings are interpolated; maps and lists are resolved element by element.
+func resolveTemplateValue(processor *workflow.TemplateProcessor, v any, tmplCtx workflow.TemplateContext) any {
+	switch val := v.(type) {
+	case string:
+		if !strings.Contains(val, "{{") {
+			return val
+		}
+		if resolved, ok := processor.ResolveValue(val, tmplCtx); ok {
+			return resolved
+		}
+		return processor.ProcessTemplate(val, tmplCtx).Processed
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, e := range val {
+			out[k] = resolveTemplateValue(processor, e, tmplCtx)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, e := range val {
+			out[i] = resolveTemplateValue(processor, e, tmplCtx)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// callWorkflowResult builds the call_workflow node's result. On success each
+// configured output is resolved against the called rule's final context (its
+// trigger data plus every action result it produced) and set on the result by
+// name, so later actions read it as {{node_name.output_name}}.
+func callWorkflowResult(rg RuleGraph, cfg callWorkflowConfig, triggerData map[string]any, output BranchOutput, runErr error) map[string]any {
+	result := map[string]any{
+		"rule_id":   rg.RuleID.String(),
+		"rule_name": rg.RuleName,
+	}
+	if rg.RevisionID != nil {
+		result["revision_id"] = rg.RevisionID.String()
+	}
+
+	if runErr != nil {
+		result["output"] = callWorkflowPortFailure
+		result["error"] = runErr.Error()
+		return result
+	}
+
+	calledCtx := NewMergedContext(triggerData)
+	for name, r := range output.ActionResults {
+		calledCtx.MergeResult(name, r)
+	}
+
+	processor := workflow.NewTemplateProcessor(workflow.DefaultTemplateProcessingOptions())
+	tmplCtx := templateContext(calledCtx)
+	for name, tmpl := range cfg.Outputs {
+		result[name] = resolveTemplateValue(processor, tmpl, tmplCtx)
+	}
+
+	result["output"] = callWorkflowPortCompleted
+
+	return result
+}
@@ -0,0 +1,225 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+)
+
+// fakeRuleGraphStore serves called rules from memory.
+type fakeRuleGraphStore map[uuid.UUID]RuleGraph
+
+func (s fakeRuleGraphStore) QueryRuleGraph(_ context.Context, ruleID uuid.UUID) (RuleGraph, error) {
+	rg, ok := s[ruleID]
+	if !ok {
+		return RuleGraph{}, fmt.Errorf("rule %s: %w", ruleID, ErrRuleNotFound)
+	}
+	return rg, nil
+}
+
+// callGraph builds: start -> call -(completed)-> after, call -(failure)-> failed.
+func callGraph(config string) GraphDefinition {
+	callID, afterID, failedID := uuid.New(), uuid.New(), uuid.New()
+	completed, failure := callWorkflowPortCompleted, callWorkflowPortFailure
+
+	return GraphDefinition{
+		Actions: []ActionNode{
+			{ID: callID, Name: "call", ActionType: callWorkflowActionType, Config: json.RawMessage(config), IsActive: true},
+			{ID: afterID, Name: "after", ActionType: "after_type", Config: json.RawMessage(`{}`), IsActive: true},
+			{ID: failedID, Name: "failed", ActionType: "failed_type", Config: json.RawMessage(`{}`), IsActive: true},
+		},
+		Edges: []ActionEdge{
+			{ID: uuid.New(), TargetActionID: callID, EdgeType: EdgeTypeStart, SortOrder: 1},
+			{ID: uuid.New(), SourceActionID: &callID, TargetActionID: afterID, EdgeType: EdgeTypeSequence, SourceOutput: &completed, SortOrder: 1},
+			{ID: uuid.New(), SourceActionID: &callID, TargetActionID: failedID, EdgeType: EdgeTypeSequence, SourceOutput: &failure, SortOrder: 2},
+		},
+	}
+}
+
+// singleActionGraph builds: start -> action.
+func singleActionGraph(name, actionType, config string) GraphDefinition {
+	id := uuid.New()
+	return GraphDefinition{
+		Actions: []ActionNode{{ID: id, Name: name, ActionType: actionType, Config: json.RawMessage(config), IsActive: true}},
+		Edges:   []ActionEdge{{ID: uuid.New(), TargetActionID: id, EdgeType: EdgeTypeStart, SortOrder: 1}},
+	}
+}
+
+func setupCallTestEnv(t *testing.T, store fakeRuleGraphStore, handlers ...workflow.ActionHandler) *testsuite.TestWorkflowEnvironment {
+	t.Helper()
+
+	reg := workflow.NewActionRegistry()
+	for _, h := range handlers {
+		reg.Register(h)
+	}
+
+	suite := &testsuite.WorkflowTestSuite{}
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(ExecuteGraphWorkflow)
+	env.RegisterWorkflow(ExecuteBranchUntilConvergence)
+	env.RegisterWorkflow(ExecuteCalledWorkflow)
+	env.RegisterActivity(&Activities{
+		Registry:      reg,
+		AsyncRegistry: NewAsyncRegistry(),
+		GraphStore:    store,
+	})
+
+	return env
+}
+
+func runCallWorkflowTest(t *testing.T, env *testsuite.TestWorkflowEnvironment, ruleID uuid.UUID, graph GraphDefinition, triggerData map[string]any) error {
+	t.Helper()
+
+	env.ExecuteWorkflow(ExecuteGraphWorkflow, WorkflowInput{
+		RuleID:      ruleID,
+		RuleName:    "caller",
+		ExecutionID: uuid.New(),
+		Graph:       graph,
+		TriggerData: triggerData,
+	})
+	require.True(t, env.IsWorkflowCompleted())
+
+	return env.GetWorkflowError()
+}
+
+// =============================================================================
+// Workflow Tests
+// =============================================================================
+
+func TestWorkflow_CallWorkflow_InputsAndOutputs(t *testing.T) {
+	calleeID := uuid.New()
+	entityID := uuid.New()
+
+	notify := &recordingHandler{actionType: "notify_type", itemKey: "order"}
+	after := &recordingHandler{actionType: "after_type"}
+	failed := &recordingHandler{actionType: "failed_type"}
+
+	revisionID := uuid.New()
+
+	store := fakeRuleGraphStore{calleeID: {
+		RuleID:     calleeID,
+		RuleName:   "notify fragment",
+		RevisionID: &revisionID,
+		Graph:      singleActionGraph("notify", "notify_type", `{}`),
+	}}
+	env := setupCallTestEnv(t, store, notify, after, failed)
+
+	err := runCallWorkflowTest(t, env, uuid.New(),
+		callGraph(`{
+			"rule_id": "`+calleeID.String()+`",
+			"inputs": {"order": "{{order_number}}", "note": "order {{order_number}}", "fixed": 7},
+			"outputs": {"notified": "{{notify.seen}}"}
+		}`),
+		map[string]any{"order_number": "SO-1", "entity_id": entityID.String(), "private": "caller only"})
+	require.NoError(t, err)
+
+	require.Len(t, notify.seen, 1)
+	seen := notify.seen[0]
+	require.Equal(t, "SO-1", seen["order"])
+	require.Equal(t, "order SO-1", seen["note"])
+	require.Equal(t, float64(7), seen["fixed"])
+	require.Equal(t, entityID.String(), seen["entity_id"], "event metadata is inherited")
+	_, leaked := seen["private"]
+	require.False(t, leaked, "only inputs and event metadata reach the called rule")
+
+	require.Len(t, after.seen, 1, "completed port is taken")
+	require.Empty(t, failed.seen)
+	require.Equal(t, "SO-1", after.seen[0]["call.notified"])
+	require.Equal(t, "notify fragment", after.seen[0]["call.rule_name"])
+	require.Equal(t, revisionID.String(), after.seen[0]["call.revision_id"], "the call records the called rule's revision")
+}
+
+func TestWorkflow_CallWorkflow_FailureRoutesToFailurePort(t *testing.T) {
+	calleeID := uuid.New()
+
+	notify := &recordingHandler{actionType: "notify_type", itemKey: "order", failOn: map[string]bool{"SO-1": true}}
+	after := &recordingHandler{actionType: "after_type"}
+	failed := &recordingHandler{actionType: "failed_type"}
+
+	store := fakeRuleGraphStore{calleeID: {
+		RuleID: calleeID,
+		Graph:  singleActionGraph("notify", "notify_type", `{}`),
+	}}
+	env := setupCallTestEnv(t, store, notify, after, failed)
+
+	err := runCallWorkflowTest(t, env, uuid.New(),
+		callGraph(`{"rule_id": "`+calleeID.String()+`", "inputs": {"order": "SO-1"}}`),
+		map[string]any{})
+	require.NoError(t, err)
+
+	require.Empty(t, after.seen)
+	require.Len(t, failed.seen, 1, "failure port is taken")
+	require.Contains(t, failed.seen[0]["call.error"], "item SO-1 rejected")
+}
+
+func TestWorkflow_CallWorkflow_RecursionRefused(t *testing.T) {
+	callerID, calleeID := uuid.New(), uuid.New()
+
+	after := &recordingHandler{actionType: "after_type"}
+	failed := &recordingHandler{actionType: "failed_type"}
+
+	// The callee calls the caller back.
+	store := fakeRuleGraphStore{calleeID: {
+		RuleID: calleeID,
+		Graph:  singleActionGraph("call_back", callWorkflowActionType, `{"rule_id": "`+callerID.String()+`"}`),
+	}}
+	env := setupCallTestEnv(t, store, after, failed)
+
+	err := runCallWorkflowTest(t, env, callerID,
+		callGraph(`{"rule_id": "`+calleeID.String()+`"}`),
+		map[string]any{})
+	require.NoError(t, err)
+
+	require.Len(t, failed.seen, 1)
+	require.Contains(t, failed.seen[0]["call.error"], "already running in this call chain")
+}
+
+func TestWorkflow_CallWorkflow_SelfCallFails(t *testing.T) {
+	ruleID := uuid.New()
+	env := setupCallTestEnv(t, fakeRuleGraphStore{}, &recordingHandler{actionType: "after_type"}, &recordingHandler{actionType: "failed_type"})
+
+	err := runCallWorkflowTest(t, env, ruleID, callGraph(`{"rule_id": "`+ruleID.String()+`"}`), map[string]any{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already running in this call chain")
+}
+
+func TestWorkflow_CallWorkflow_MissingRuleFails(t *testing.T) {
+	env := setupCallTestEnv(t, fakeRuleGraphStore{}, &recordingHandler{actionType: "after_type"}, &recordingHandler{actionType: "failed_type"})
+
+	err := runCallWorkflowTest(t, env, uuid.New(), callGraph(`{"rule_id": "`+uuid.NewString()+`"}`), map[string]any{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "rule not found")
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestCallWorkflowResult(t *testing.T) {
+	rg := RuleGraph{RuleID: uuid.New(), RuleName: "fragment"}
+	cfg := callWorkflowConfig{Outputs: map[string]string{
+		"alert_id": "{{create_alert.alert_id}}",
+		"lines":    "{{lines}}",
+	}}
+	output := BranchOutput{ActionResults: map[string]map[string]any{
+		"create_alert": {"alert_id": "a-1"},
+	}}
+
+	result := callWorkflowResult(rg, cfg, map[string]any{"lines": []any{"x", "y"}}, output, nil)
+	require.Equal(t, callWorkflowPortCompleted, result["output"])
+	require.Equal(t, "a-1", result["alert_id"])
+	require.Equal(t, []any{"x", "y"}, result["lines"], "single references keep their type")
+
+	failed := callWorkflowResult(rg, cfg, nil, BranchOutput{}, fmt.Errorf("boom"))
+	require.Equal(t, callWorkflowPortFailure, failed["output"])
+	require.Equal(t, "boom", failed["error"])
+	_, hasOutput := failed["alert_id"]
+	require.False(t, hasOutput, "outputs are only resolved on success")
+}
@@ -3,6 +3,7 @@ package temporal
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/timmaaaz/ichor/business/sdk/outbox"
 	"github.com/timmaaaz/ichor/business/sdk/workflow"
@@ -41,3 +42,14 @@ func BuildTriggerData(event workflow.TriggerEvent) map[string]any {
 func ReconstructTriggerEvent(triggerData json.RawMessage) (workflow.TriggerEvent, error) {
 	return reconstructTriggerEvent(triggerData)
 }
+
+// TickAt runs one scheduler tick with the clock pinned to now.
+func (s *Scheduler) TickAt(ctx context.Context, now time.Time) (int, error) {
+	s.now = func() time.Time { return now }
+	return s.tick(ctx)
+}
+
+// ScheduledEvent exposes the unexported scheduledEvent for the scheduler test.
+func ScheduledEvent(rs workflow.RuleSchedule, firedAt time.Time) workflow.TriggerEvent {
+	return scheduledEvent(rs, firedAt)
+}
@@ -0,0 +1,172 @@
+package temporal
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/workflow"
+)
+
+// =============================================================================
+// For-Each Execution
+// =============================================================================
+
+// runForEach runs the body of a for_each node once per item and returns the
+// node's result. Each item runs as an ExecuteBranchUntilConvergence child
+// workflow with its own copy of the context, in which the item is available
+// as {{item}} and its position as {{item_index}} (or the configured
+// item_name). Sequential mode runs one item at a time; parallel mode runs up
+// to max_concurrency at once.
+//
+// Determinism: items keep their list order, child workflow IDs are derived
+// from the node and item index, and outcomes are stored by index, so the
+// result does not depend on the order in which children finish.
+func runForEach(ctx workflow.Context, executor *GraphExecutor, action ActionNode, mergedCtx *MergedContext, ruleID uuid.UUID, executionID uuid.UUID, ruleName string) (map[string]any, error) {
+	logger := workflow.GetLogger(ctx)
+
+	cfg, err := parseForEachConfig(action.Config)
+	if err != nil {
+		return nil, fmt.Errorf("for_each action %s: %w", action.Name, err)
+	}
+
+	items, err := resolveForEachItems(cfg, mergedCtx)
+	if err != nil {
+		return nil, fmt.Errorf("for_each action %s: %w", action.Name, err)
+	}
+
+	body := executor.GetOutputActions(action.ID, forEachPortEach)
+	if len(body) > 1 {
+		return nil, fmt.Errorf("for_each action %s: %q port must lead to a single action, found %d", action.Name, forEachPortEach, len(body))
+	}
+
+	logger.Info("For-each action - iterating",
+		"action_name", action.Name,
+		"item_count", len(items),
+		"mode", cfg.Mode,
+		"max_concurrency", cfg.MaxConcurrency,
+	)
+
+	outcomes := make([]forEachItemOutcome, len(items))
+	if len(body) == 1 && len(items) > 0 {
+		outcomes = runForEachItems(ctx, executor, action, body[0], items, cfg, mergedCtx, ruleID, executionID, ruleName)
+	}
+
+	if !cfg.ContinueOnError {
+		for i, o := range outcomes {
+			if o.err != nil {
+				return nil, fmt.Errorf("for_each action %s: item %d: %w", action.Name, i, o.err)
+			}
+		}
+	}
+
+	var bodyNames map[string]bool
+	if len(body) == 1 {
+		bodyNames = make(map[string]bool)
+		for id := range executor.findReachableNodes(body[0].ID) {
+			if node, ok := executor.GetAction(id); ok {
+				bodyNames[node.Name] = true
+			}
+		}
+	}
+
+	result := forEachResult(outcomes, bodyNames)
+
+	logger.Info("For-each completed",
+		"action_name", action.Name,
+		"item_count", len(items),
+		"failed", result["failed"],
+	)
+
+	return result, nil
+}
+
+// runForEachItems starts a child workflow per item, keeping at most
+// cfg.MaxConcurrency running. Without continue_on_error the first failure
+// stops further items from starting; those already running are waited for.
+func runForEachItems(
+	ctx workflow.Context,
+	executor *GraphExecutor,
+	action ActionNode,
+	start ActionNode,
+	items []any,
+	cfg forEachConfig,
+	mergedCtx *MergedContext,
+	ruleID uuid.UUID,
+	executionID uuid.UUID,
+	ruleName string,
+) []forEachItemOutcome {
+	outcomes := make([]forEachItemOutcome, len(items))
+	selector := workflow.NewSelector(ctx)
+
+	next := 0
+	running := 0
+	stopped := false
+
+	for next < len(items) || running > 0 {
+		for !stopped && running < cfg.MaxConcurrency && next < len(items) {
+			index := next
+
+			itemCtx := mergedCtx.Clone()
+			itemCtx.Flattened[cfg.ItemName] = items[index]
+			itemCtx.Flattened[cfg.ItemName+"_index"] = index
+
+			childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+				WorkflowID: fmt.Sprintf("%s-foreach-%s-%d",
+					workflow.GetInfo(ctx).WorkflowExecution.ID,
+					action.ID,
+					index,
+				),
+			})
+
+			future := workflow.ExecuteChildWorkflow(childCtx, ExecuteBranchUntilConvergence,
+				BranchInput{
+					StartAction:      start,
+					ConvergencePoint: uuid.Nil, // The body runs to the end of its path
+					Graph:            executor.Graph(),
+					InitialContext:   itemCtx,
+					RuleID:           ruleID,
+					ExecutionID:      executionID,
+					RuleName:         ruleName,
+				},
+			)
+
+			selector.AddFuture(future, func(f workflow.Future) {
+				var output BranchOutput
+				err := f.Get(ctx, &output)
+				outcomes[index] = forEachItemOutcome{output: output, err: err}
+				running--
+				if err != nil && !cfg.ContinueOnError {
+					stopped = true
+				}
+			})
+
+			running++
+			next++
+		}
+
+		if running == 0 {
+			break
+		}
+		selector.Select(ctx)
+	}
+
+	return outcomes
+}
+
+// executeForEach runs a for_each node in the main workflow and continues
+// from its "done" port.
+func executeForEach(ctx workflow.Context, executor *GraphExecutor, action ActionNode, mergedCtx *MergedContext, input WorkflowInput) error {
+	result, err := runForEach(ctx, executor, action, mergedCtx, input.RuleID, input.ExecutionID, input.RuleName)
+	if err != nil {
+		return err
+	}
+
+	mergedCtx.MergeResult(action.Name, result)
+
+	nextActions := executor.GetNextActions(action.ID, result)
+	if len(nextActions) == 0 {
+		return nil
+	}
+
+	return executeActions(ctx, executor, nextActions, mergedCtx, input)
+}
@@ -0,0 +1,191 @@
+package temporal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+)
+
+// =============================================================================
+// For-Each Action Support
+// =============================================================================
+
+const (
+	// forEachActionType is intercepted by the interpreter: the node is not an
+	// activity, it runs the branch wired to its "each" port once per item.
+	forEachActionType = "for_each"
+
+	// forEachPortEach leads into the per-item body. forEachPortDone is taken
+	// once every item has run.
+	forEachPortEach = "each"
+	forEachPortDone = "done"
+
+	forEachModeSequential = "sequential"
+	forEachModeParallel   = "parallel"
+
+	// MaxForEachItems bounds the items a single for_each node may iterate.
+	// Every item is a child workflow, and each adds events to the parent's
+	// history, so an unbounded list could exhaust it.
+	MaxForEachItems = 500
+
+	// MaxForEachConcurrency bounds how many items run at once in parallel mode.
+	MaxForEachConcurrency = 20
+
+	defaultForEachConcurrency = 5
+	defaultForEachItemName    = "item"
+)
+
+// forEachConfig is used to parse for_each action configuration.
+type forEachConfig struct {
+	Items           json.RawMessage `json:"items"`
+	ItemName        string          `json:"item_name"`
+	Mode            string          `json:"mode"`
+	MaxConcurrency  int             `json:"max_concurrency"`
+	ContinueOnError bool            `json:"continue_on_error"`
+}
+
+// parseForEachConfig parses a for_each action's config and applies defaults.
+func parseForEachConfig(config json.RawMessage) (forEachConfig, error) {
+	var cfg forEachConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return forEachConfig{}, fmt.Errorf("invalid for_each config: %w", err)
+	}
+
+	if len(cfg.Items) == 0 {
+		return forEachConfig{}, errors.New("for_each items is required")
+	}
+
+	if cfg.ItemName == "" {
+		cfg.ItemName = defaultForEachItemName
+	}
+
+	switch cfg.Mode {
+	case "", forEachModeSequential:
+		cfg.Mode = forEachModeSequential
+		cfg.MaxConcurrency = 1
+	case forEachModeParallel:
+		if cfg.MaxConcurrency == 0 {
+			cfg.MaxConcurrency = defaultForEachConcurrency
+		}
+		if cfg.MaxConcurrency < 1 || cfg.MaxConcurrency > MaxForEachConcurrency {
+			return forEachConfig{}, fmt.Errorf("for_each max_concurrency must be between 1 and %d, got %d", MaxForEachConcurrency, cfg.MaxConcurrency)
+		}
+	default:
+		return forEachConfig{}, fmt.Errorf("for_each mode must be %s or %s, got %q", forEachModeSequential, forEachModeParallel, cfg.Mode)
+	}
+
+	return cfg, nil
+}
+
+// resolveForEachItems turns the configured items into the list to iterate.
+// Items is either a literal JSON array or a template reference such as
+// "{{line_items}}" or "{{lookup_order.lines}}". Trigger fields are also
+// reachable as raw_data.<field>. A reference to a missing or null value
+// iterates nothing; a reference to anything other than a list is an error.
+func resolveForEachItems(cfg forEachConfig, mergedCtx *MergedContext) ([]any, error) {
+	var items any
+	if err := json.Unmarshal(cfg.Items, &items); err != nil {
+		return nil, fmt.Errorf("invalid for_each items: %w", err)
+	}
+
+	if ref, ok := items.(string); ok {
+		processor := workflow.NewTemplateProcessor(workflow.DefaultTemplateProcessingOptions())
+		items, _ = processor.ResolveValue(ref, templateContext(mergedCtx))
+	}
+
+	if items == nil {
+		return nil, nil
+	}
+
+	rv := reflect.ValueOf(items)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("for_each items must resolve to a list, got %T", items)
+	}
+
+	if rv.Len() > MaxForEachItems {
+		return nil, fmt.Errorf("for_each items has %d entries, more than the maximum of %d", rv.Len(), MaxForEachItems)
+	}
+
+	list := make([]any, rv.Len())
+	for i := range list {
+		list[i] = rv.Index(i).Interface()
+	}
+
+	return list, nil
+}
+
+// templateContext builds the context templates in control-node configs resolve
+// against: everything in the merged context, with the trigger data also
+// reachable as raw_data.<field>.
+func templateContext(mergedCtx *MergedContext) workflow.TemplateContext {
+	tmplCtx := make(workflow.TemplateContext, len(mergedCtx.Flattened)+1)
+	for k, v := range mergedCtx.Flattened {
+		tmplCtx[k] = v
+	}
+	if _, exists := tmplCtx["raw_data"]; !exists {
+		tmplCtx["raw_data"] = mergedCtx.TriggerData
+	}
+	return tmplCtx
+}
+
+// forEachItemOutcome is what one run of the body produced for one item.
+type forEachItemOutcome struct {
+	output BranchOutput
+	err    error
+}
+
+// forEachResult builds the for_each node's result. Each item contributes the
+// results of the body actions it ran, in item order, until the list would
+// exceed MaxResultValueSize; the rest are left out and items_truncated is set
+// rather than letting MergeResult replace the whole list.
+func forEachResult(outcomes []forEachItemOutcome, bodyNames map[string]bool) map[string]any {
+	items := make([]any, 0, len(outcomes))
+	size := len("[]")
+	truncated := false
+	failed := 0
+
+	for i, o := range outcomes {
+		entry := map[string]any{"index": i}
+
+		if o.err != nil {
+			failed++
+			entry["error"] = o.err.Error()
+		} else {
+			results := make(map[string]any)
+			for name, r := range o.output.ActionResults {
+				if bodyNames[name] {
+					results[name] = r
+				}
+			}
+			entry["results"] = results
+		}
+
+		if truncated {
+			continue
+		}
+
+		data, err := json.Marshal(entry)
+		if err != nil || size+len(data)+1 > MaxResultValueSize {
+			truncated = true
+			continue
+		}
+		size += len(data) + 1
+		items = append(items, entry)
+	}
+
+	result := map[string]any{
+		"output":    forEachPortDone,
+		"count":     len(outcomes),
+		"succeeded": len(outcomes) - failed,
+		"failed":    failed,
+		"items":     items,
+	}
+	if truncated {
+		result["items_truncated"] = true
+	}
+
+	return result
+}
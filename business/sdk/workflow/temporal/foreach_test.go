@@ -0,0 +1,275 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+)
+
+// recordingHandler records the context each call saw and can fail for chosen items.
+type recordingHandler struct {
+	actionType string
+	itemKey    string
+	failOn     map[string]bool
+
+	mu    sync.Mutex
+	items []any
+	seen  []map[string]any
+}
+
+func (h *recordingHandler) Execute(_ context.Context, _ json.RawMessage, execCtx workflow.ActionExecutionContext) (any, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	item := execCtx.RawData[h.itemKey]
+	h.items = append(h.items, item)
+	h.seen = append(h.seen, execCtx.RawData)
+
+	if s, ok := item.(string); ok && h.failOn[s] {
+		return nil, fmt.Errorf("item %v rejected", item)
+	}
+	return map[string]any{"seen": item}, nil
+}
+
+func (h *recordingHandler) Validate(_ json.RawMessage) error { return nil }
+func (h *recordingHandler) GetType() string                  { return h.actionType }
+func (h *recordingHandler) SupportsManualExecution() bool    { return false }
+func (h *recordingHandler) IsAsync() bool                    { return false }
+func (h *recordingHandler) GetDescription() string           { return "recording handler" }
+
+// forEachGraph builds: start -> loop -(each)-> body, loop -(done)-> after.
+func forEachGraph(config string) GraphDefinition {
+	loopID, bodyID, afterID := uuid.New(), uuid.New(), uuid.New()
+	each, done := forEachPortEach, forEachPortDone
+
+	return GraphDefinition{
+		Actions: []ActionNode{
+			{ID: loopID, Name: "loop", ActionType: forEachActionType, Config: json.RawMessage(config), IsActive: true},
+			{ID: bodyID, Name: "body", ActionType: "body_type", Config: json.RawMessage(`{}`), IsActive: true},
+			{ID: afterID, Name: "after", ActionType: "after_type", Config: json.RawMessage(`{}`), IsActive: true},
+		},
+		Edges: []ActionEdge{
+			{ID: uuid.New(), TargetActionID: loopID, EdgeType: EdgeTypeStart, SortOrder: 1},
+			{ID: uuid.New(), SourceActionID: &loopID, TargetActionID: bodyID, EdgeType: EdgeTypeSequence, SourceOutput: &each, SortOrder: 1},
+			{ID: uuid.New(), SourceActionID: &loopID, TargetActionID: afterID, EdgeType: EdgeTypeSequence, SourceOutput: &done, SortOrder: 2},
+		},
+	}
+}
+
+func runForEachWorkflow(t *testing.T, config string, triggerData map[string]any, body *recordingHandler, after *recordingHandler) error {
+	t.Helper()
+
+	reg := workflow.NewActionRegistry()
+	reg.Register(body)
+	reg.Register(after)
+	env := setupTestEnv(t, reg)
+
+	env.ExecuteWorkflow(ExecuteGraphWorkflow, WorkflowInput{
+		RuleID:      uuid.New(),
+		RuleName:    "for-each-test",
+		ExecutionID: uuid.New(),
+		Graph:       forEachGraph(config),
+		TriggerData: triggerData,
+	})
+	require.True(t, env.IsWorkflowCompleted())
+
+	return env.GetWorkflowError()
+}
+
+// =============================================================================
+// Workflow Tests
+// =============================================================================
+
+func TestWorkflow_ForEach_Sequential(t *testing.T) {
+	body := &recordingHandler{actionType: "body_type", itemKey: "line"}
+	after := &recordingHandler{actionType: "after_type"}
+
+	err := runForEachWorkflow(t,
+		`{"items": "{{line_items}}", "item_name": "line"}`,
+		map[string]any{"line_items": []any{"a", "b", "c"}},
+		body, after)
+	require.NoError(t, err)
+
+	require.Equal(t, []any{"a", "b", "c"}, body.items, "items run in list order")
+	require.Equal(t, float64(1), body.seen[1]["line_index"])
+
+	require.Len(t, after.seen, 1, "done path runs once after all items")
+	result := after.seen[0]
+	require.Equal(t, float64(3), result["loop.count"])
+	require.Equal(t, float64(0), result["loop.failed"])
+
+	items := result["loop.items"].([]any)
+	require.Len(t, items, 3)
+	first := items[0].(map[string]any)
+	require.Equal(t, map[string]any{"body": map[string]any{"seen": "a", "output": "success"}}, first["results"])
+
+	_, leaked := result["body"]
+	require.False(t, leaked, "body results stay inside the loop result")
+}
+
+func TestWorkflow_ForEach_RawDataPath(t *testing.T) {
+	body := &recordingHandler{actionType: "body_type", itemKey: "item"}
+	after := &recordingHandler{actionType: "after_type"}
+
+	err := runForEachWorkflow(t,
+		`{"items": "{{raw_data.line_items}}"}`,
+		map[string]any{"line_items": []any{map[string]any{"sku": "x"}, map[string]any{"sku": "y"}}},
+		body, after)
+	require.NoError(t, err)
+
+	require.Len(t, body.items, 2)
+	require.Equal(t, map[string]any{"sku": "y"}, body.items[1])
+}
+
+func TestWorkflow_ForEach_ParallelBounded(t *testing.T) {
+	body := &recordingHandler{actionType: "body_type", itemKey: "item"}
+	after := &recordingHandler{actionType: "after_type"}
+
+	err := runForEachWorkflow(t,
+		`{"items": ["a", "b", "c", "d", "e"], "mode": "parallel", "max_concurrency": 2}`,
+		map[string]any{},
+		body, after)
+	require.NoError(t, err)
+
+	got := make([]string, len(body.items))
+	for i, item := range body.items {
+		got[i] = item.(string)
+	}
+	sort.Strings(got)
+	require.Equal(t, []string{"a", "b", "c", "d", "e"}, got)
+
+	// Results are stored by index regardless of completion order.
+	items := after.seen[0]["loop.items"].([]any)
+	for i, want := range []string{"a", "b", "c", "d", "e"} {
+		entry := items[i].(map[string]any)
+		require.Equal(t, float64(i), entry["index"])
+		require.Equal(t, want, entry["results"].(map[string]any)["body"].(map[string]any)["seen"])
+	}
+}
+
+func TestWorkflow_ForEach_EmptyList(t *testing.T) {
+	body := &recordingHandler{actionType: "body_type", itemKey: "item"}
+	after := &recordingHandler{actionType: "after_type"}
+
+	err := runForEachWorkflow(t, `{"items": "{{missing}}"}`, map[string]any{}, body, after)
+	require.NoError(t, err)
+
+	require.Empty(t, body.items)
+	require.Len(t, after.seen, 1)
+	require.Equal(t, float64(0), after.seen[0]["loop.count"])
+}
+
+func TestWorkflow_ForEach_ItemFailure(t *testing.T) {
+	body := &recordingHandler{actionType: "body_type", itemKey: "item", failOn: map[string]bool{"b": true}}
+	after := &recordingHandler{actionType: "after_type"}
+
+	err := runForEachWorkflow(t, `{"items": ["a", "b", "c"]}`, map[string]any{}, body, after)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "item 1")
+
+	require.NotContains(t, body.items, "c", "sequential loop stops at the first failure")
+	require.Empty(t, after.seen, "done path does not run")
+}
+
+func TestWorkflow_ForEach_ContinueOnError(t *testing.T) {
+	body := &recordingHandler{actionType: "body_type", itemKey: "item", failOn: map[string]bool{"b": true}}
+	after := &recordingHandler{actionType: "after_type"}
+
+	err := runForEachWorkflow(t, `{"items": ["a", "b", "c"], "continue_on_error": true}`, map[string]any{}, body, after)
+	require.NoError(t, err)
+
+	require.Contains(t, body.items, "c")
+
+	result := after.seen[0]
+	require.Equal(t, float64(2), result["loop.succeeded"])
+	require.Equal(t, float64(1), result["loop.failed"])
+
+	failed := result["loop.items"].([]any)[1].(map[string]any)
+	require.Contains(t, failed["error"], "item b rejected")
+}
+
+func TestWorkflow_ForEach_NotAList(t *testing.T) {
+	body := &recordingHandler{actionType: "body_type", itemKey: "item"}
+	after := &recordingHandler{actionType: "after_type"}
+
+	err := runForEachWorkflow(t, `{"items": "{{order_id}}"}`, map[string]any{"order_id": "o-1"}, body, after)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "must resolve to a list")
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestParseForEachConfig(t *testing.T) {
+	cfg, err := parseForEachConfig(json.RawMessage(`{"items": "{{x}}"}`))
+	require.NoError(t, err)
+	require.Equal(t, forEachModeSequential, cfg.Mode)
+	require.Equal(t, 1, cfg.MaxConcurrency)
+	require.Equal(t, defaultForEachItemName, cfg.ItemName)
+
+	cfg, err = parseForEachConfig(json.RawMessage(`{"items": "{{x}}", "mode": "parallel"}`))
+	require.NoError(t, err)
+	require.Equal(t, defaultForEachConcurrency, cfg.MaxConcurrency)
+
+	for _, bad := range []string{
+		`{}`,
+		`{"items": "{{x}}", "mode": "batch"}`,
+		`{"items": "{{x}}", "mode": "parallel", "max_concurrency": 21}`,
+	} {
+		_, err := parseForEachConfig(json.RawMessage(bad))
+		require.Error(t, err, bad)
+	}
+}
+
+func TestResolveForEachItems_TooMany(t *testing.T) {
+	cfg, err := parseForEachConfig(json.RawMessage(`{"items": "{{lines}}"}`))
+	require.NoError(t, err)
+
+	mergedCtx := NewMergedContext(map[string]any{"lines": make([]any, MaxForEachItems+1)})
+	_, err = resolveForEachItems(cfg, mergedCtx)
+	require.Error(t, err)
+}
+
+func TestForEachResult_RespectsMaxResultValueSize(t *testing.T) {
+	big := strings.Repeat("x", 1024)
+	outcomes := make([]forEachItemOutcome, 100)
+	for i := range outcomes {
+		outcomes[i] = forEachItemOutcome{output: BranchOutput{ActionResults: map[string]map[string]any{
+			"body":    {"payload": big},
+			"earlier": {"ignored": true},
+		}}}
+	}
+	outcomes[99].err = errors.New("boom")
+
+	result := forEachResult(outcomes, map[string]bool{"body": true})
+
+	require.Equal(t, 100, result["count"])
+	require.Equal(t, 1, result["failed"])
+	require.Equal(t, true, result["items_truncated"])
+
+	items := result["items"].([]any)
+	require.NotEmpty(t, items)
+	require.Less(t, len(items), 100)
+
+	data, err := json.Marshal(items)
+	require.NoError(t, err)
+	require.LessOrEqual(t, len(data), MaxResultValueSize)
+
+	_, hasEarlier := items[0].(map[string]any)["results"].(map[string]any)["earlier"]
+	require.False(t, hasEarlier, "only body actions are collected")
+
+	sanitized, truncated := sanitizeResult(result)
+	require.False(t, truncated, "MergeResult keeps the collected items")
+	require.Equal(t, items, sanitized["items"])
+}
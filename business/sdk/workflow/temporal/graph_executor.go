@@ -138,6 +138,25 @@ func (e *GraphExecutor) GetNextActions(sourceActionID uuid.UUID, result map[stri
 	return nextActions
 }
 
+// GetOutputActions returns the actions wired to the named output port of
+// sourceActionID, in SortOrder. Unlike GetNextActions it ignores edges with no
+// SourceOutput, so it yields only what that port leads to. The for_each node
+// uses it to find the entry of its per-item body.
+func (e *GraphExecutor) GetOutputActions(sourceActionID uuid.UUID, output string) []ActionNode {
+	var actions []ActionNode
+
+	for _, edge := range e.edgesBySource[sourceActionID] {
+		if edge.EdgeType == EdgeTypeStart || edge.SourceOutput == nil || *edge.SourceOutput != output {
+			continue
+		}
+		if action, ok := e.actionsByID[edge.TargetActionID]; ok {
+			actions = append(actions, action)
+		}
+	}
+
+	return actions
+}
+
 // FindConvergencePoint detects if multiple branches converge to a common node.
 // Returns the closest common node reachable by ALL branches, or nil if no
 // such node exists (fire-and-forget branches with no common downstream node).
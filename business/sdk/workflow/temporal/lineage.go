@@ -57,6 +57,12 @@ type WorkflowLineage struct {
 	// Set once, on the first dispatched hop, and preserved across every
 	// subsequent hop. Useful for correlating an entire chain (F8).
 	OriginatingExecutionID uuid.UUID `json:"originating_execution_id,omitempty"`
+
+	// CallStack lists the rules (as ID strings) that are waiting on a
+	// call_workflow child in this chain, outermost first. It guards against a
+	// rule calling itself directly or through other rules. It is scoped to one
+	// chain of calls: a cascade hop (With) starts with an empty stack.
+	CallStack []string `json:"call_stack,omitempty"`
 }
 
 // lineagePairKey encodes a (ruleID, entityID) pair as a stable string key.
@@ -71,7 +77,8 @@ func (l WorkflowLineage) Contains(ruleID, entityID uuid.UUID) bool {
 
 // With returns a copy of the lineage extended with (ruleID, entityID).
 // The receiver is never mutated, so a parent lineage can safely seed multiple
-// child dispatches (multiple matched rules off one event).
+// child dispatches (multiple matched rules off one event). The call stack is
+// not carried over.
 func (l WorkflowLineage) With(ruleID, entityID uuid.UUID) WorkflowLineage {
 	next := WorkflowLineage{
 		Visited:                make([]string, len(l.Visited), len(l.Visited)+1),
@@ -82,6 +89,29 @@ func (l WorkflowLineage) With(ruleID, entityID uuid.UUID) WorkflowLineage {
 	return next
 }
 
+// InCallStack reports whether ruleID is already waiting on a call_workflow
+// child in this chain, so calling it again would recurse.
+func (l WorkflowLineage) InCallStack(ruleID uuid.UUID) bool {
+	return slices.Contains(l.CallStack, ruleID.String())
+}
+
+// WithCall returns a copy of the lineage for a rule started by call_workflow:
+// callerRuleID joins the call stack, and (calleeRuleID, entityID) joins the
+// visited set so the callee's writes cannot re-fire its own trigger for the
+// same entity. entityID may be uuid.Nil when the chain has no entity.
+func (l WorkflowLineage) WithCall(callerRuleID, calleeRuleID, entityID uuid.UUID) WorkflowLineage {
+	next := l
+	if entityID != uuid.Nil {
+		next = l.With(calleeRuleID, entityID)
+	}
+
+	next.CallStack = make([]string, len(l.CallStack), len(l.CallStack)+1)
+	copy(next.CallStack, l.CallStack)
+	next.CallStack = append(next.CallStack, callerRuleID.String())
+
+	return next
+}
+
 // =============================================================================
 // Context transport
 // =============================================================================
@@ -120,7 +150,7 @@ func lineageFromContext(ctx context.Context) WorkflowLineage {
 // (all.go / the worker) injects this exported helper as a func(context.Context)[]byte.
 func MarshalLineageFromContext(ctx context.Context) []byte {
 	l := lineageFromContext(ctx)
-	if len(l.Visited) == 0 && l.OriginatingExecutionID == uuid.Nil {
+	if len(l.Visited) == 0 && l.OriginatingExecutionID == uuid.Nil && len(l.CallStack) == 0 {
 		return nil
 	}
 	b, err := json.Marshal(l)
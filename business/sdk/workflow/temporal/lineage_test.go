@@ -82,12 +82,8 @@ func newStubEdgeStore() *stubEdgeStore {
 	}
 }
 
-func (s *stubEdgeStore) QueryActionsByRule(_ context.Context, ruleID uuid.UUID) ([]ActionNode, error) {
-	return s.actions[ruleID], nil
-}
-
-func (s *stubEdgeStore) QueryEdgesByRule(_ context.Context, ruleID uuid.UUID) ([]ActionEdge, error) {
-	return s.edges[ruleID], nil
+func (s *stubEdgeStore) QueryPublishedGraph(_ context.Context, ruleID uuid.UUID) (PublishedGraph, error) {
+	return PublishedGraph{Graph: GraphDefinition{Actions: s.actions[ruleID], Edges: s.edges[ruleID]}}, nil
 }
 
 // registerGraph gives a rule a minimal non-empty graph (one action + start edge)
@@ -412,6 +408,33 @@ func TestWorkflowLineage_With_IsImmutable(t *testing.T) {
 	require.Equal(t, parent.OriginatingExecutionID, child.OriginatingExecutionID)
 }
 
+func TestWorkflowLineage_WithCall(t *testing.T) {
+	caller, callee, other := uuid.New(), uuid.New(), uuid.New()
+	entity := uuid.New()
+
+	parent := WorkflowLineage{
+		Visited:                []string{lineagePairKey(caller, entity)},
+		OriginatingExecutionID: uuid.New(),
+	}
+	child := parent.WithCall(caller, callee, entity)
+
+	// The caller joins the call stack; the callee joins the visited set.
+	require.True(t, child.InCallStack(caller))
+	require.False(t, child.InCallStack(callee))
+	require.True(t, child.Contains(callee, entity))
+	require.Equal(t, parent.OriginatingExecutionID, child.OriginatingExecutionID)
+
+	// Parent unchanged.
+	require.Empty(t, parent.CallStack)
+	require.False(t, parent.Contains(callee, entity))
+
+	// Nested calls stack up; a cascade hop starts a new call chain.
+	nested := child.WithCall(callee, other, uuid.Nil)
+	require.Equal(t, []string{caller.String(), callee.String()}, nested.CallStack)
+	require.Len(t, nested.Visited, 2, "no entity, no visited pair")
+	require.Empty(t, nested.With(other, entity).CallStack)
+}
+
 func TestLineageContextRoundTrip(t *testing.T) {
 	r, e := uuid.New(), uuid.New()
 	l := WorkflowLineage{Visited: []string{lineagePairKey(r, e)}, OriginatingExecutionID: uuid.New()}
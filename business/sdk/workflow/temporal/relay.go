@@ -25,9 +25,10 @@ import (
 // enrichment the DelegateHandler used to do inline (extractEntityData /
 // computeFieldChanges, now sourced here from the persisted payload) — re-hydrates
 // the cascade loop-guard lineage onto the dispatch context, and calls
-// WorkflowTrigger.OnEntityEvent. On success the row is deleted (delete-on-publish);
-// on failure attempts is bumped and the row goes dead after MaxAttempts so it never
-// head-of-line blocks the queue. A reaper sweeps aged dead rows.
+// WorkflowTrigger.OnEntityEvent. On success the row is deleted (delete-on-publish),
+// or stamped published_at and kept as replayable history when PublishedRowWindow is
+// set; on failure attempts is bumped and the row goes dead after MaxAttempts so it
+// never head-of-line blocks the queue. A reaper sweeps aged dead and published rows.
 //
 // At-least-once + dedup: dispatch happens before the delete commits. A crash between
 // a successful ExecuteWorkflow and the commit leaves the row pending; it is
@@ -47,6 +48,11 @@ type RelayConfig struct {
 	MaxAttempts   int           // dispatch attempts before a row is marked dead (default 5)
 	DeadRowWindow time.Duration // how long dead rows are retained before reaping (default 7d)
 	ReapInterval  time.Duration // how often to reap aged dead rows (default 1h)
+
+	// PublishedRowWindow keeps dispatched rows as event history for this long,
+	// stamping published_at instead of deleting them, so past events can be
+	// replayed by the simulator. Zero keeps delete-on-publish.
+	PublishedRowWindow time.Duration
 }
 
 // EventDispatcher dispatches a rebuilt cascade event into the workflow engine.
@@ -197,6 +203,14 @@ func (r *Relay) dispatchRow(ctx context.Context, tx sqlx.ExtContext, row outbox.
 		return
 	}
 
+	if r.cfg.PublishedRowWindow > 0 {
+		if err := r.store.MarkPublished(ctx, tx, row.ID, time.Now()); err != nil {
+			// Same recovery as a failed delete below.
+			r.log.Error(ctx, "cascade relay: mark published failed", "id", row.ID, "error", err)
+		}
+		return
+	}
+
 	if err := r.store.DeletePublished(ctx, tx, row.ID); err != nil {
 		// Delete failed after a successful dispatch: the row stays pending and will be
 		// re-dispatched, but the deterministic workflow id + REJECT_DUPLICATE makes the
@@ -205,21 +219,30 @@ func (r *Relay) dispatchRow(ctx context.Context, tx sqlx.ExtContext, row outbox.
 	}
 }
 
-// buildEvent reconstructs the TriggerEvent from a persisted outbox row, mirroring
-// DelegateHandler.handleEvent exactly but sourcing the delegate.Data from the row's
-// payload instead of a live ctx. ok is false only when the payload itself cannot be
-// decoded (a corrupt row), which the caller retires as dead.
+// buildEvent reconstructs the TriggerEvent from a persisted outbox row (see
+// OutboxEvent). ok is false only when the payload itself cannot be decoded (a
+// corrupt row), which the caller retires as dead.
 func (r *Relay) buildEvent(ctx context.Context, row outbox.Outbox) (workflow.TriggerEvent, bool) {
+	event, err := OutboxEvent(row)
+	if err != nil {
+		r.log.Error(ctx, "cascade relay: rebuild event failed", "id", row.ID, "error", err)
+		return workflow.TriggerEvent{}, false
+	}
+	return event, true
+}
+
+// OutboxEvent reconstructs the TriggerEvent an outbox row carries, mirroring
+// DelegateHandler.handleEvent exactly but sourcing the delegate.Data from the row's
+// payload instead of a live ctx. The relay dispatches it; the simulator replays it.
+func OutboxEvent(row outbox.Outbox) (workflow.TriggerEvent, error) {
 	var data delegate.Data
 	if err := json.Unmarshal(row.Payload, &data); err != nil {
-		r.log.Error(ctx, "cascade relay: unmarshal payload failed", "id", row.ID, "error", err)
-		return workflow.TriggerEvent{}, false
+		return workflow.TriggerEvent{}, fmt.Errorf("unmarshal payload: %w", err)
 	}
 
 	var params workflow.DelegateEventParams
 	if err := json.Unmarshal(data.RawParams, &params); err != nil {
-		r.log.Error(ctx, "cascade relay: unmarshal params failed", "id", row.ID, "error", err)
-		return workflow.TriggerEvent{}, false
+		return workflow.TriggerEvent{}, fmt.Errorf("unmarshal params: %w", err)
 	}
 
 	event := workflow.TriggerEvent{
@@ -250,10 +273,11 @@ func (r *Relay) buildEvent(ctx context.Context, row outbox.Outbox) (workflow.Tri
 		}
 	}
 
-	return event, true
+	return event, nil
 }
 
-// Reap deletes dead rows older than the retention window and returns the count.
+// Reap deletes dead rows older than the retention window, and published rows older
+// than PublishedRowWindow when history is kept, and returns the count.
 func (r *Relay) Reap(ctx context.Context) (int64, error) {
 	n, err := r.store.Reap(ctx, r.db, time.Now().Add(-r.cfg.DeadRowWindow))
 	if err != nil {
@@ -262,6 +286,18 @@ func (r *Relay) Reap(ctx context.Context) (int64, error) {
 	if n > 0 {
 		r.log.Info(ctx, "cascade relay: reaped dead outbox rows", "count", n)
 	}
+
+	if r.cfg.PublishedRowWindow > 0 {
+		p, err := r.store.ReapPublished(ctx, r.db, time.Now().Add(-r.cfg.PublishedRowWindow))
+		if err != nil {
+			return n, err
+		}
+		if p > 0 {
+			r.log.Info(ctx, "cascade relay: reaped published outbox rows", "count", p)
+		}
+		n += p
+	}
+
 	return n, nil
 }
 
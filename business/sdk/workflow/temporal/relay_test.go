@@ -13,6 +13,7 @@ import (
 	"io"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -160,6 +161,44 @@ func TestRelay_ReapsAgedDeadRowsOnly(t *testing.T) {
 	require.Equal(t, 1, countOutbox(t, db), "young dead row retained within the window")
 }
 
+func TestRelay_KeepsPublishedRowsAsHistory(t *testing.T) {
+	t.Parallel()
+	db := dbtest.NewDatabase(t, "Test_RelayHistory")
+	ctx := context.Background()
+	store := outbox.NewStore(db.Log)
+
+	old := outboxRow(t, "alpha", workflow.ActionCreated, workflow.EventTypeOnCreate, uuid.New(), nil, nil)
+	young := outboxRow(t, "alpha", workflow.ActionUpdated, workflow.EventTypeOnUpdate, uuid.New(), nil, nil)
+	require.NoError(t, store.Insert(ctx, db.DB, old))
+	require.NoError(t, store.Insert(ctx, db.DB, young))
+
+	relay := temporal.NewRelay(db.Log, db.DB, &fakeDispatcher{}, temporal.RelayConfig{PublishedRowWindow: 7 * 24 * time.Hour})
+
+	n, err := relay.ProcessBatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, 2, countOutbox(t, db), "published rows kept as history")
+
+	n, err = relay.ProcessBatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, n, "published rows are not re-dispatched")
+
+	rows, err := store.QueryEvents(ctx, db.DB, outbox.EventFilter{EntityName: "alpha", EventType: workflow.EventTypeOnUpdate, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, young.ID, rows[0].ID)
+	require.NotNil(t, rows[0].PublishedAt)
+
+	_, err = db.DB.ExecContext(ctx,
+		`UPDATE workflow.cascade_outbox SET created_at = now() - interval '30 days' WHERE id = $1`, old.ID)
+	require.NoError(t, err)
+
+	reaped, err := relay.Reap(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), reaped)
+	require.Equal(t, 1, countOutbox(t, db), "published row within the window retained")
+}
+
 // TestRelay_BuildEventEnrichment is the golden check: the relay reconstructs the
 // TriggerEvent from a stored row identically to the old DelegateHandler path
 // (it reuses the same extractEntityData / computeFieldChanges).
@@ -0,0 +1,185 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// ScheduleStore reads due rule schedules and advances them after dispatch.
+// Satisfied by workflow/stores/workflowdb.Store.
+type ScheduleStore interface {
+	QueryDueRuleSchedules(ctx context.Context, now time.Time, limit int) ([]workflow.RuleSchedule, error)
+	AdvanceRuleSchedule(ctx context.Context, id uuid.UUID, expected time.Time, next time.Time, ranAt time.Time) (bool, error)
+}
+
+// ScheduleDispatcher starts the graph of one scheduled rule.
+// Satisfied by *WorkflowTrigger.
+type ScheduleDispatcher interface {
+	DispatchScheduled(ctx context.Context, ruleID uuid.UUID, ruleName string, event workflow.TriggerEvent) (uuid.UUID, error)
+}
+
+// SchedulerConfig tunes the scheduler. Zero-value fields fall back to defaults.
+type SchedulerConfig struct {
+	Interval  time.Duration // how often to look for due schedules (default 30s)
+	BatchSize int           // max schedules fired per tick (default 100)
+}
+
+func (c SchedulerConfig) withDefaults() SchedulerConfig {
+	if c.Interval <= 0 {
+		c.Interval = 30 * time.Second
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 100
+	}
+	return c
+}
+
+// scheduleEventNamespace seeds the deterministic EventID of a scheduled
+// occurrence (UUIDv5 over schedule id + occurrence time).
+var scheduleEventNamespace = uuid.MustParse("6f1c7a52-3d0e-4b8e-9a55-1f0f1b7c2e90")
+
+// Scheduler fires "scheduled" trigger-type rules. Each tick it loads the
+// schedules whose next_run_at has passed, dispatches one synthetic "scheduled"
+// TriggerEvent per schedule through ExecuteGraphWorkflow, then advances
+// next_run_at. It mirrors ExecutionReaper's ticker shape and is SERVER-ONLY
+// (started by the composition root next to the relay).
+//
+// Delivery is at-least-once with effectively-once execution, the same contract
+// as the cascade relay: dispatch happens before the advance (a crash in between
+// re-fires on the next tick), and the event id is derived from (schedule,
+// occurrence) so a re-fire — or a second replica racing on the same occurrence —
+// lands on the same Temporal workflow id and is rejected as a duplicate. The
+// advance itself is a compare-and-set, so exactly one replica moves the schedule.
+//
+// Missed occurrences (e.g. the server was down across several cron slots) are
+// coalesced into a single firing; see workflow.RuleSchedule.Advance.
+type Scheduler struct {
+	log        *logger.Logger
+	store      ScheduleStore
+	dispatcher ScheduleDispatcher
+	cfg        SchedulerConfig
+	now        func() time.Time
+}
+
+// NewScheduler constructs a Scheduler.
+func NewScheduler(log *logger.Logger, store ScheduleStore, dispatcher ScheduleDispatcher, cfg SchedulerConfig) *Scheduler {
+	return &Scheduler{
+		log:        log,
+		store:      store,
+		dispatcher: dispatcher,
+		cfg:        cfg.withDefaults(),
+		now:        func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Run ticks every cfg.Interval until ctx is cancelled. Intended to be launched
+// in a goroutine by the composition root. Returns ctx.Err() when stopped.
+func (s *Scheduler) Run(ctx context.Context) error {
+	s.log.Info(ctx, "rule scheduler starting", "interval", s.cfg.Interval, "batch_size", s.cfg.BatchSize)
+
+	t := time.NewTicker(s.cfg.Interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info(ctx, "rule scheduler stopping", "reason", ctx.Err())
+			return ctx.Err()
+		case <-t.C:
+			n, err := s.tick(ctx)
+			if err != nil {
+				s.log.Error(ctx, "rule scheduler: tick failed", "error", err)
+				continue
+			}
+			if n > 0 {
+				s.log.Info(ctx, "rule scheduler: fired scheduled rules", "count", n)
+			}
+		}
+	}
+}
+
+// tick fires every due schedule once and returns how many it advanced. A
+// failed dispatch leaves that schedule un-advanced so it is retried next tick;
+// it does not stop the rest of the batch.
+func (s *Scheduler) tick(ctx context.Context) (int, error) {
+	now := s.now()
+
+	due, err := s.store.QueryDueRuleSchedules(ctx, now, s.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("query due schedules: %w", err)
+	}
+
+	fired := 0
+	for _, rs := range due {
+		next, err := rs.Advance(now)
+		if err != nil {
+			s.log.Error(ctx, "rule scheduler: cannot compute next run; schedule skipped",
+				"schedule_id", rs.ID,
+				"rule_id", rs.RuleID,
+				"error", err,
+			)
+			continue
+		}
+
+		if _, err := s.dispatcher.DispatchScheduled(ctx, rs.RuleID, rs.RuleName, scheduledEvent(rs, now)); err != nil {
+			s.log.Error(ctx, "rule scheduler: dispatch failed; will retry",
+				"schedule_id", rs.ID,
+				"rule_id", rs.RuleID,
+				"error", err,
+			)
+			continue
+		}
+
+		advanced, err := s.store.AdvanceRuleSchedule(ctx, rs.ID, rs.NextRunAt, next, now)
+		if err != nil {
+			s.log.Error(ctx, "rule scheduler: advance failed",
+				"schedule_id", rs.ID,
+				"rule_id", rs.RuleID,
+				"error", err,
+			)
+			continue
+		}
+		if advanced {
+			fired++
+		}
+	}
+
+	return fired, nil
+}
+
+// scheduledEvent builds the synthetic TriggerEvent for one occurrence of a
+// schedule. RawData carries the schedule metadata so templates can reference
+// {{scheduled_for}} and friends; EntityID is zero because no single entity
+// triggered the run.
+func scheduledEvent(rs workflow.RuleSchedule, firedAt time.Time) workflow.TriggerEvent {
+	occurrence := rs.NextRunAt.UTC()
+
+	raw := map[string]any{
+		"schedule_id":   rs.ID.String(),
+		"scheduled_for": occurrence.Format(time.RFC3339),
+		"fired_at":      firedAt.UTC().Format(time.RFC3339),
+	}
+	if rs.CronExpression != "" {
+		raw["cron_expression"] = rs.CronExpression
+	}
+	if rs.IntervalSeconds > 0 {
+		raw["interval_seconds"] = rs.IntervalSeconds
+	}
+	if rs.Timezone != "" {
+		raw["timezone"] = rs.Timezone
+	}
+
+	return workflow.TriggerEvent{
+		EventType:  workflow.EventTypeScheduled,
+		EntityName: rs.EntityName,
+		Timestamp:  firedAt,
+		RawData:    raw,
+		EventID:    uuid.NewSHA1(scheduleEventNamespace, []byte(fmt.Sprintf("%s|%d", rs.ID, occurrence.Unix()))),
+	}
+}
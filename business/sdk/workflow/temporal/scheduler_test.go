@@ -0,0 +1,184 @@
+package temporal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/stores/workflowdb"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/temporal"
+)
+
+// Compile-time assertions for the scheduler's collaborators.
+var (
+	_ temporal.ScheduleStore      = (*workflowdb.Store)(nil)
+	_ temporal.ScheduleDispatcher = (*temporal.WorkflowTrigger)(nil)
+)
+
+// =============================================================================
+// Fakes
+// =============================================================================
+
+type advanceCall struct {
+	id       uuid.UUID
+	expected time.Time
+	next     time.Time
+	ranAt    time.Time
+}
+
+type fakeScheduleStore struct {
+	due      []workflow.RuleSchedule
+	queryErr error
+	advanced []advanceCall
+	casLost  bool // AdvanceRuleSchedule reports another replica won
+}
+
+func (f *fakeScheduleStore) QueryDueRuleSchedules(_ context.Context, _ time.Time, _ int) ([]workflow.RuleSchedule, error) {
+	return f.due, f.queryErr
+}
+
+func (f *fakeScheduleStore) AdvanceRuleSchedule(_ context.Context, id uuid.UUID, expected, next, ranAt time.Time) (bool, error) {
+	f.advanced = append(f.advanced, advanceCall{id: id, expected: expected, next: next, ranAt: ranAt})
+	return !f.casLost, nil
+}
+
+type fakeScheduleDispatcher struct {
+	failFor map[uuid.UUID]bool
+	events  []workflow.TriggerEvent
+}
+
+func (f *fakeScheduleDispatcher) DispatchScheduled(_ context.Context, ruleID uuid.UUID, _ string, event workflow.TriggerEvent) (uuid.UUID, error) {
+	if f.failFor[ruleID] {
+		return uuid.Nil, errors.New("temporal unavailable")
+	}
+	f.events = append(f.events, event)
+	return uuid.New(), nil
+}
+
+// =============================================================================
+// Tests
+// =============================================================================
+
+func TestScheduler_Tick_DispatchesAndAdvances(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 30, 0, time.UTC)
+
+	rs := workflow.RuleSchedule{
+		ID:              uuid.New(),
+		RuleID:          uuid.New(),
+		IntervalSeconds: 300,
+		NextRunAt:       now.Add(-30 * time.Second),
+		EntityName:      "orders",
+	}
+
+	store := &fakeScheduleStore{due: []workflow.RuleSchedule{rs}}
+	disp := &fakeScheduleDispatcher{}
+
+	n, err := temporal.NewScheduler(testLogger(), store, disp, temporal.SchedulerConfig{}).TickAt(context.Background(), now)
+	if err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("fired = %d, want 1", n)
+	}
+
+	if len(disp.events) != 1 {
+		t.Fatalf("dispatched %d events, want 1", len(disp.events))
+	}
+	ev := disp.events[0]
+	if ev.EventType != workflow.EventTypeScheduled {
+		t.Errorf("event type = %q, want %q", ev.EventType, workflow.EventTypeScheduled)
+	}
+	if ev.EntityName != "orders" {
+		t.Errorf("entity name = %q, want orders", ev.EntityName)
+	}
+
+	if len(store.advanced) != 1 {
+		t.Fatalf("advanced %d schedules, want 1", len(store.advanced))
+	}
+	got := store.advanced[0]
+	if !got.expected.Equal(rs.NextRunAt) {
+		t.Errorf("CAS expected = %v, want %v", got.expected, rs.NextRunAt)
+	}
+	if want := rs.NextRunAt.Add(5 * time.Minute); !got.next.Equal(want) {
+		t.Errorf("next = %v, want %v", got.next, want)
+	}
+}
+
+func TestScheduler_Tick_DispatchFailureSkipsAdvance(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	failing := workflow.RuleSchedule{ID: uuid.New(), RuleID: uuid.New(), IntervalSeconds: 60, NextRunAt: now}
+	healthy := workflow.RuleSchedule{ID: uuid.New(), RuleID: uuid.New(), IntervalSeconds: 60, NextRunAt: now}
+
+	store := &fakeScheduleStore{due: []workflow.RuleSchedule{failing, healthy}}
+	disp := &fakeScheduleDispatcher{failFor: map[uuid.UUID]bool{failing.RuleID: true}}
+
+	n, err := temporal.NewScheduler(testLogger(), store, disp, temporal.SchedulerConfig{}).TickAt(context.Background(), now)
+	if err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("fired = %d, want 1", n)
+	}
+	if len(store.advanced) != 1 || store.advanced[0].id != healthy.ID {
+		t.Fatalf("advanced = %+v, want only the healthy schedule", store.advanced)
+	}
+}
+
+func TestScheduler_Tick_LostCASNotCounted(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	store := &fakeScheduleStore{
+		due:     []workflow.RuleSchedule{{ID: uuid.New(), RuleID: uuid.New(), IntervalSeconds: 60, NextRunAt: now}},
+		casLost: true,
+	}
+
+	n, err := temporal.NewScheduler(testLogger(), store, &fakeScheduleDispatcher{}, temporal.SchedulerConfig{}).TickAt(context.Background(), now)
+	if err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	if n != 0 {
+		t.Fatalf("fired = %d, want 0 when another replica advanced first", n)
+	}
+}
+
+func TestScheduler_Tick_QueryError(t *testing.T) {
+	store := &fakeScheduleStore{queryErr: errors.New("db down")}
+
+	if _, err := temporal.NewScheduler(testLogger(), store, &fakeScheduleDispatcher{}, temporal.SchedulerConfig{}).TickAt(context.Background(), time.Now()); err == nil {
+		t.Fatal("expected error from failing query")
+	}
+}
+
+func TestScheduledEvent_DeterministicEventID(t *testing.T) {
+	rs := workflow.RuleSchedule{
+		ID:             uuid.New(),
+		RuleID:         uuid.New(),
+		CronExpression: "0 2 * * *",
+		Timezone:       "America/New_York",
+		NextRunAt:      time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC),
+	}
+
+	a := temporal.ScheduledEvent(rs, rs.NextRunAt.Add(5*time.Second))
+	b := temporal.ScheduledEvent(rs, rs.NextRunAt.Add(40*time.Second))
+	if a.EventID != b.EventID {
+		t.Fatal("same occurrence must yield the same event id regardless of when it fired")
+	}
+
+	rs.NextRunAt = rs.NextRunAt.Add(24 * time.Hour)
+	c := temporal.ScheduledEvent(rs, rs.NextRunAt)
+	if c.EventID == a.EventID {
+		t.Fatal("different occurrences must yield different event ids")
+	}
+
+	if got := a.RawData["scheduled_for"]; got != "2026-03-02T07:00:00Z" {
+		t.Errorf("scheduled_for = %v", got)
+	}
+	if got := a.RawData["timezone"]; got != "America/New_York" {
+		t.Errorf("timezone = %v", got)
+	}
+}
@@ -0,0 +1,715 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// =============================================================================
+// Simulation — replaying a rule against past events without side effects
+// =============================================================================
+//
+// The simulator answers "what would this rule have done?" for a rule that may
+// never have run: a draft revision, an older revision, or the live rule. For
+// each event it evaluates the trigger the way TriggerProcessor does and, on a
+// match, walks the graph in-process the way ExecuteGraphWorkflow does, with
+// the same GraphExecutor routing and MergedContext. No activity, child
+// workflow or timer is started.
+//
+// Actions are never executed. A handler that implements workflow.ActionPlanner
+// is asked for its plan, which decides the output port and lists the effects;
+// any other handler is assumed to take its default output port, and reports
+// the entity modifications it declares through workflow.EntityModifier. An
+// asynchronous or human action without a planner stops its path, since its
+// outcome depends on something that has not happened. Assumed outcomes are
+// flagged unverified on the step, the event and the summary, so a path that
+// rests on one is not mistaken for what the rule would have done.
+//
+// Control nodes are interpreted here: a delay proceeds at once, a for_each
+// runs its body once per item, and a call_workflow simulates the called rule
+// when a RuleGraphStore is configured.
+
+// MaxSimulationEvents bounds the events a single simulation replays.
+const MaxSimulationEvents = 5000
+
+// SimulationRule is the rule a simulation replays events against.
+type SimulationRule struct {
+	RuleID            uuid.UUID
+	RuleName          string
+	EntityName        string
+	TriggerType       string
+	TriggerConditions json.RawMessage
+	Graph             GraphDefinition
+}
+
+// SimulationReport is the outcome of replaying a set of events.
+// UnverifiedCount is how many matched events ran an unverified step.
+type SimulationReport struct {
+	EventCount      int               `json:"event_count"`
+	MatchedCount    int               `json:"matched_count"`
+	FailedCount     int               `json:"failed_count"`
+	UnverifiedCount int               `json:"unverified_count"`
+	Events          []SimulatedEvent  `json:"events"`
+	Summary         SimulationSummary `json:"summary"`
+}
+
+// SimulatedEvent is what the rule would have done for one event. Steps and
+// Effects are empty when the event did not match. Error is set when the run
+// would have failed; Steps then ends at the failing action. Unverified is set
+// when any step's outcome was assumed, so the real run may have taken another
+// path.
+type SimulatedEvent struct {
+	EntityName  string                               `json:"entity_name"`
+	EntityID    uuid.UUID                            `json:"entity_id"`
+	EventType   string                               `json:"event_type"`
+	Timestamp   time.Time                            `json:"timestamp"`
+	Matched     bool                                 `json:"matched"`
+	MatchReason string                               `json:"match_reason"`
+	Unverified  bool                                 `json:"unverified"`
+	Conditions  []workflow.ConditionEvaluationResult `json:"conditions"`
+	Steps       []SimulatedStep                      `json:"steps"`
+	Effects     []workflow.PlannedEffect             `json:"effects"`
+	Error       string                               `json:"error,omitempty"`
+}
+
+// SimulatedStep is one action the run would have reached. Planned is true
+// when the handler's Plan decided the output; otherwise the output was
+// assumed and Unverified is set, with Note naming the outputs that were not
+// checked. Iteration names the for_each item or called rule the step ran
+// under, such as "split_lines[2]" or "call:Reserve stock".
+type SimulatedStep struct {
+	ActionID   uuid.UUID                `json:"action_id"`
+	ActionName string                   `json:"action_name"`
+	ActionType string                   `json:"action_type"`
+	Output     string                   `json:"output,omitempty"`
+	Planned    bool                     `json:"planned"`
+	Unverified bool                     `json:"unverified,omitempty"`
+	Skipped    bool                     `json:"skipped,omitempty"`
+	Iteration  string                   `json:"iteration,omitempty"`
+	Effects    []workflow.PlannedEffect `json:"effects,omitempty"`
+	Note       string                   `json:"note,omitempty"`
+	Error      string                   `json:"error,omitempty"`
+}
+
+// SimulationSummary totals the report across events.
+type SimulationSummary struct {
+	Branches   []BranchCount      `json:"branches"`
+	Effects    map[string]int     `json:"effects"`
+	Unverified []UnverifiedAction `json:"unverified"`
+}
+
+// BranchCount is how often an action would have taken an output port.
+type BranchCount struct {
+	ActionID   uuid.UUID `json:"action_id"`
+	ActionName string    `json:"action_name"`
+	Output     string    `json:"output"`
+	Count      int       `json:"count"`
+}
+
+// UnverifiedAction is an action whose outcome was assumed, and how often.
+type UnverifiedAction struct {
+	ActionID   uuid.UUID `json:"action_id"`
+	ActionName string    `json:"action_name"`
+	ActionType string    `json:"action_type"`
+	Count      int       `json:"count"`
+}
+
+// Simulator replays rules against past events.
+type Simulator struct {
+	log        *logger.Logger
+	registry   *workflow.ActionRegistry
+	graphStore RuleGraphStore
+}
+
+// NewSimulator creates a simulator that plans actions with the handlers in
+// registry. graphStore loads the rules call_workflow actions call; it may be
+// nil, in which case called rules are reported but not simulated.
+func NewSimulator(log *logger.Logger, registry *workflow.ActionRegistry, graphStore RuleGraphStore) *Simulator {
+	return &Simulator{
+		log:        log,
+		registry:   registry,
+		graphStore: graphStore,
+	}
+}
+
+// Simulate replays events against rule in the order given.
+func (s *Simulator) Simulate(ctx context.Context, rule SimulationRule, events []workflow.TriggerEvent) (SimulationReport, error) {
+	if len(events) > MaxSimulationEvents {
+		return SimulationReport{}, fmt.Errorf("simulation has %d events, more than the maximum of %d", len(events), MaxSimulationEvents)
+	}
+
+	var conditions workflow.TriggerConditions
+	if len(rule.TriggerConditions) > 0 && string(rule.TriggerConditions) != "null" {
+		if err := json.Unmarshal(rule.TriggerConditions, &conditions); err != nil {
+			return SimulationReport{}, fmt.Errorf("invalid trigger conditions: %w", err)
+		}
+	}
+
+	executor := NewGraphExecutor(rule.Graph)
+
+	report := SimulationReport{
+		EventCount: len(events),
+		Events:     make([]SimulatedEvent, 0, len(events)),
+	}
+	tally := newSimulationTally()
+
+	for _, event := range events {
+		if err := ctx.Err(); err != nil {
+			return SimulationReport{}, err
+		}
+
+		se := matchSimulatedEvent(rule, conditions.Conditions(), event)
+		if se.Matched {
+			report.MatchedCount++
+
+			run := &simulationRun{
+				sim:       s,
+				rule:      rule,
+				timestamp: event.Timestamp,
+				callStack: []uuid.UUID{rule.RuleID},
+			}
+			if err := run.actions(ctx, executor, executor.GetStartActions(), NewMergedContext(buildTriggerData(event)), ""); err != nil {
+				se.Error = err.Error()
+				report.FailedCount++
+			}
+
+			se.Steps = append(se.Steps, run.steps...)
+			for _, step := range run.steps {
+				se.Effects = append(se.Effects, step.Effects...)
+				se.Unverified = se.Unverified || step.Unverified
+				tally.add(step)
+			}
+			if se.Unverified {
+				report.UnverifiedCount++
+			}
+		}
+
+		report.Events = append(report.Events, se)
+	}
+
+	report.Summary = tally.summary()
+
+	return report, nil
+}
+
+// matchSimulatedEvent applies the rule's trigger to an event with the checks
+// TriggerProcessor.checkRuleMatch makes.
+func matchSimulatedEvent(rule SimulationRule, conditions []workflow.FieldCondition, event workflow.TriggerEvent) SimulatedEvent {
+	se := SimulatedEvent{
+		EntityName: event.EntityName,
+		EntityID:   event.EntityID,
+		EventType:  event.EventType,
+		Timestamp:  event.Timestamp,
+		Conditions: []workflow.ConditionEvaluationResult{},
+		Steps:      []SimulatedStep{},
+		Effects:    []workflow.PlannedEffect{},
+	}
+
+	if rule.EntityName != event.EntityName {
+		se.MatchReason = fmt.Sprintf("Entity mismatch: rule for %s, event for %s", rule.EntityName, event.EntityName)
+		return se
+	}
+
+	if rule.TriggerType != event.EventType {
+		se.MatchReason = fmt.Sprintf("Trigger type mismatch: rule for %s, event for %s", rule.TriggerType, event.EventType)
+		return se
+	}
+
+	data := workflow.ConditionData{
+		EventType:    event.EventType,
+		RawData:      event.RawData,
+		FieldChanges: event.FieldChanges,
+		Now:          event.Timestamp,
+	}
+
+	var failed []string
+	for _, cond := range conditions {
+		res := workflow.EvaluateFieldCondition(cond, data)
+		se.Conditions = append(se.Conditions, res)
+		if !res.Matched || res.Error != "" {
+			failed = append(failed, conditionName(cond))
+		}
+	}
+
+	switch {
+	case len(failed) > 0:
+		se.MatchReason = fmt.Sprintf("Failed conditions: %s", strings.Join(failed, ", "))
+	case len(conditions) > 0:
+		se.Matched = true
+		se.MatchReason = "All field conditions satisfied"
+	default:
+		se.Matched = true
+		se.MatchReason = "No conditions specified (auto-match)"
+	}
+
+	return se
+}
+
+// conditionName describes a condition in a match reason.
+func conditionName(cond workflow.FieldCondition) string {
+	if cond.Operator == workflow.OperatorExpression {
+		return cond.Expression
+	}
+	return cond.FieldName
+}
+
+// =============================================================================
+// Graph walk
+// =============================================================================
+
+// simulationRun walks one event through a rule's graph, recording steps.
+type simulationRun struct {
+	sim       *Simulator
+	rule      SimulationRule
+	timestamp time.Time
+	callStack []uuid.UUID
+	steps     []SimulatedStep
+}
+
+// actions mirrors executeActions: one action runs in sequence, several run as
+// parallel branches that either converge or run to the end of their paths.
+func (r *simulationRun) actions(ctx context.Context, executor *GraphExecutor, actions []ActionNode, mergedCtx *MergedContext, iteration string) error {
+	if len(actions) == 0 {
+		return nil
+	}
+
+	if len(actions) == 1 {
+		return r.single(ctx, executor, actions[0], mergedCtx, iteration)
+	}
+
+	convergencePoint := executor.FindConvergencePoint(actions)
+
+	if convergencePoint == nil {
+		// Fire-and-forget branches do not fail the run that started them.
+		for _, branch := range actions {
+			if _, err := r.branch(ctx, executor, branch, uuid.Nil, mergedCtx, iteration); err != nil {
+				r.sim.log.Info(ctx, "simulate: fire-and-forget branch would fail", "action_name", branch.Name, "error", err)
+			}
+		}
+		return nil
+	}
+
+	for i, branch := range actions {
+		results, err := r.branch(ctx, executor, branch, convergencePoint.ID, mergedCtx, iteration)
+		if err != nil {
+			return fmt.Errorf("branch %d (%s) failed: %w", i, branch.Name, err)
+		}
+		for name, result := range results {
+			mergedCtx.MergeResult(name, result)
+		}
+	}
+
+	return r.single(ctx, executor, *convergencePoint, mergedCtx, iteration)
+}
+
+// single mirrors executeSingleAction.
+func (r *simulationRun) single(ctx context.Context, executor *GraphExecutor, action ActionNode, mergedCtx *MergedContext, iteration string) error {
+	result, next, err := r.node(ctx, executor, action, mergedCtx, iteration)
+	if err != nil || !next {
+		return err
+	}
+
+	return r.actions(ctx, executor, executor.GetNextActions(action.ID, result), mergedCtx, iteration)
+}
+
+// branch mirrors ExecuteBranchUntilConvergence: it follows a single path on a
+// copy of the context until the convergence point or the end of the path, and
+// returns the results of the actions it ran.
+func (r *simulationRun) branch(ctx context.Context, executor *GraphExecutor, start ActionNode, convergencePoint uuid.UUID, initial *MergedContext, iteration string) (map[string]map[string]any, error) {
+	mergedCtx := initial.Clone()
+	current := start
+
+	for current.ID != convergencePoint {
+		result, next, err := r.node(ctx, executor, current, mergedCtx, iteration)
+		if err != nil {
+			return nil, err
+		}
+		if !next {
+			break
+		}
+
+		nextActions := executor.GetNextActions(current.ID, result)
+		if len(nextActions) == 0 {
+			break
+		}
+		current = nextActions[0]
+	}
+
+	return mergedCtx.ActionResults, nil
+}
+
+// node simulates one action, records its step and merges its result. next is
+// false when the path stops at this action.
+func (r *simulationRun) node(ctx context.Context, executor *GraphExecutor, action ActionNode, mergedCtx *MergedContext, iteration string) (result map[string]any, next bool, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	step := SimulatedStep{
+		ActionID:   action.ID,
+		ActionName: action.Name,
+		ActionType: action.ActionType,
+		Iteration:  iteration,
+	}
+
+	defer func() {
+		if err != nil {
+			step.Error = err.Error()
+		}
+		if out, ok := result["output"].(string); ok && next {
+			step.Output = out
+		}
+		r.steps = append(r.steps, step)
+	}()
+
+	if !action.IsActive {
+		step.Skipped = true
+		step.Note = "action is inactive"
+		result = map[string]any{"output": "success"}
+		switch action.ActionType {
+		case forEachActionType:
+			result["output"] = forEachPortDone
+		case callWorkflowActionType:
+			result["output"] = callWorkflowPortCompleted
+		}
+		return result, true, nil
+	}
+
+	switch action.ActionType {
+	case "delay":
+		duration, err := parseDelayConfig(action.Config)
+		if err != nil {
+			return nil, false, fmt.Errorf("delay action %s: %w", action.Name, err)
+		}
+		step.Planned = true
+		step.Note = fmt.Sprintf("would wait %s", duration)
+		result = map[string]any{"delayed": true, "duration": duration.String()}
+
+	case forEachActionType:
+		step.Planned = true
+		// The body's steps are recorded before the for_each step itself.
+		result, err = r.forEach(ctx, executor, action, mergedCtx, iteration)
+		if err != nil {
+			return nil, false, err
+		}
+		step.Note = fmt.Sprintf("ran %v items", result["count"])
+
+	case callWorkflowActionType:
+		step.Planned = r.sim.graphStore != nil
+		step.Unverified = !step.Planned
+		result, step.Effects, step.Note, err = r.callWorkflow(ctx, action, mergedCtx, iteration)
+		if err != nil {
+			return nil, false, err
+		}
+
+	default:
+		plan, planned, err := r.plan(ctx, action, mergedCtx)
+		if err != nil {
+			return nil, false, fmt.Errorf("execute action %s (%s): %w", action.Name, action.ID, err)
+		}
+		step.Planned = planned
+		step.Unverified = !planned
+		step.Effects = plan.Effects
+		step.Note = plan.Note
+		if plan.Output == "" {
+			return nil, false, nil
+		}
+
+		result = make(map[string]any, len(plan.Result)+1)
+		for k, v := range plan.Result {
+			result[k] = v
+		}
+		result["output"] = plan.Output
+	}
+
+	mergedCtx.MergeResult(action.Name, result)
+
+	return result, true, nil
+}
+
+// plan asks the action's handler what it would do. planned is false when the
+// handler has no planner and the outcome was assumed.
+func (r *simulationRun) plan(ctx context.Context, action ActionNode, mergedCtx *MergedContext) (plan workflow.ActionPlan, planned bool, err error) {
+	handler, exists := r.sim.registry.Get(action.ActionType)
+	if !exists {
+		return workflow.ActionPlan{}, false, fmt.Errorf("no handler registered for type %s", action.ActionType)
+	}
+
+	execCtx := buildExecContext(ActionActivityInput{
+		ActionID:   action.ID,
+		ActionName: action.Name,
+		ActionType: action.ActionType,
+		Config:     action.Config,
+		Context:    mergedCtx.Flattened,
+		RuleID:     r.rule.RuleID,
+		RuleName:   r.rule.RuleName,
+	})
+	execCtx.TriggerSource = workflow.TriggerSourceSimulation
+	execCtx.Timestamp = r.timestamp
+
+	if planner, ok := handler.(workflow.ActionPlanner); ok {
+		plan, err := planner.Plan(ctx, action.Config, execCtx)
+		if err != nil {
+			return workflow.ActionPlan{}, true, err
+		}
+		return plan, true, nil
+	}
+
+	if handler.IsAsync() || isHumanAction(action.ActionType) {
+		return workflow.ActionPlan{Note: "waits for an outcome that cannot be simulated; path not followed"}, false, nil
+	}
+
+	ports := r.sim.registry.GetOutputPorts(action.ActionType)
+	output := defaultOutputPort(ports)
+
+	plan = workflow.ActionPlan{
+		Output: output,
+		Note:   assumedNote(output, ports),
+	}
+
+	if modifier, ok := handler.(workflow.EntityModifier); ok {
+		for _, mod := range modifier.GetEntityModifications(action.Config) {
+			plan.Effects = append(plan.Effects, modificationEffect(mod))
+		}
+	}
+
+	return plan, false, nil
+}
+
+// forEach mirrors runForEach, running the body once per item in item order.
+func (r *simulationRun) forEach(ctx context.Context, executor *GraphExecutor, action ActionNode, mergedCtx *MergedContext, iteration string) (map[string]any, error) {
+	cfg, err := parseForEachConfig(action.Config)
+	if err != nil {
+		return nil, fmt.Errorf("for_each action %s: %w", action.Name, err)
+	}
+
+	items, err := resolveForEachItems(cfg, mergedCtx)
+	if err != nil {
+		return nil, fmt.Errorf("for_each action %s: %w", action.Name, err)
+	}
+
+	body := executor.GetOutputActions(action.ID, forEachPortEach)
+	if len(body) > 1 {
+		return nil, fmt.Errorf("for_each action %s: %q port must lead to a single action, found %d", action.Name, forEachPortEach, len(body))
+	}
+
+	outcomes := make([]forEachItemOutcome, len(items))
+	if len(body) == 1 {
+		for i, item := range items {
+			itemCtx := mergedCtx.Clone()
+			itemCtx.Flattened[cfg.ItemName] = item
+			itemCtx.Flattened[cfg.ItemName+"_index"] = i
+
+			results, err := r.branch(ctx, executor, body[0], uuid.Nil, itemCtx, fmt.Sprintf("%s%s[%d]", iterationPrefix(iteration), action.Name, i))
+			outcomes[i] = forEachItemOutcome{output: BranchOutput{ActionResults: results}, err: err}
+
+			if err != nil && !cfg.ContinueOnError {
+				return nil, fmt.Errorf("for_each action %s: item %d: %w", action.Name, i, err)
+			}
+		}
+	}
+
+	var bodyNames map[string]bool
+	if len(body) == 1 {
+		bodyNames = make(map[string]bool)
+		for id := range executor.findReachableNodes(body[0].ID) {
+			if node, ok := executor.GetAction(id); ok {
+				bodyNames[node.Name] = true
+			}
+		}
+	}
+
+	return forEachResult(outcomes, bodyNames), nil
+}
+
+// callWorkflow mirrors runCallWorkflow. Without a graph store, or when the
+// called rule cannot be loaded, the call is reported and assumed to complete.
+func (r *simulationRun) callWorkflow(ctx context.Context, action ActionNode, mergedCtx *MergedContext, iteration string) (map[string]any, []workflow.PlannedEffect, string, error) {
+	cfg, err := parseCallWorkflowConfig(action.Config)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("call_workflow action %s: %w", action.Name, err)
+	}
+
+	for _, id := range r.callStack {
+		if id == cfg.RuleID {
+			return nil, nil, "", fmt.Errorf("call_workflow action %s: rule %s is already running in this call chain", action.Name, cfg.RuleID)
+		}
+	}
+	if len(r.callStack) > MaxCallDepth {
+		return nil, nil, "", fmt.Errorf("call_workflow action %s: calls nested more than %d deep", action.Name, MaxCallDepth)
+	}
+
+	triggerData := calledTriggerData(cfg, mergedCtx, WorkflowLineage{})
+	delete(triggerData, CascadeLineageKey)
+
+	effect := workflow.PlannedEffect{
+		Kind:   workflow.EffectWorkflowCall,
+		Fields: map[string]any{"rule_id": cfg.RuleID.String()},
+	}
+
+	if r.sim.graphStore == nil {
+		result := map[string]any{"rule_id": cfg.RuleID.String(), "output": callWorkflowPortCompleted}
+		return result, []workflow.PlannedEffect{effect}, "called rule not simulated; assumed to complete", nil
+	}
+
+	rg, err := r.sim.graphStore.QueryRuleGraph(ctx, cfg.RuleID)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("call_workflow action %s: load rule %s: %w", action.Name, cfg.RuleID, err)
+	}
+	effect.Subject = rg.RuleName
+
+	called := &simulationRun{
+		sim:       r.sim,
+		rule:      SimulationRule{RuleID: rg.RuleID, RuleName: rg.RuleName},
+		timestamp: r.timestamp,
+		callStack: append(append([]uuid.UUID{}, r.callStack...), rg.RuleID),
+	}
+
+	calledExecutor := NewGraphExecutor(rg.Graph)
+	calledCtx := NewMergedContext(triggerData)
+	runErr := called.actions(ctx, calledExecutor, calledExecutor.GetStartActions(), calledCtx, fmt.Sprintf("%scall:%s", iterationPrefix(iteration), rg.RuleName))
+
+	// The called rule's steps come before the call_workflow step that started it.
+	r.steps = append(r.steps, called.steps...)
+
+	result := callWorkflowResult(rg, cfg, triggerData, BranchOutput{ActionResults: calledCtx.ActionResults}, runErr)
+
+	var note string
+	if runErr != nil {
+		note = fmt.Sprintf("called rule would fail: %s", runErr)
+	}
+
+	return result, []workflow.PlannedEffect{effect}, note, nil
+}
+
+// iterationPrefix nests an iteration label under the one it runs in.
+func iterationPrefix(iteration string) string {
+	if iteration == "" {
+		return ""
+	}
+	return iteration + "/"
+}
+
+// defaultOutputPort returns the port an action takes when it succeeds.
+func defaultOutputPort(ports []workflow.OutputPort) string {
+	for _, p := range ports {
+		if p.IsDefault {
+			return p.Name
+		}
+	}
+	return "success"
+}
+
+// assumedNote explains an assumed outcome, naming the outputs the action could
+// have taken instead, which the simulation did not check.
+func assumedNote(output string, ports []workflow.OutputPort) string {
+	var others []string
+	for _, p := range ports {
+		if p.Name != output {
+			others = append(others, p.Name)
+		}
+	}
+
+	if len(others) == 0 {
+		return fmt.Sprintf("no plan mode; assumed to take %s", output)
+	}
+
+	return fmt.Sprintf("no plan mode; assumed to take %s, unverified: %s not checked", output, strings.Join(others, ", "))
+}
+
+// modificationEffect describes a declared entity modification as an effect.
+// Fields whose value is not statically known are reported as null.
+func modificationEffect(mod workflow.EntityModification) workflow.PlannedEffect {
+	effect := workflow.PlannedEffect{
+		Kind:       workflow.EffectEntityModification,
+		EntityName: mod.EntityName,
+		EventType:  mod.EventType,
+	}
+
+	if len(mod.Fields) > 0 || len(mod.Changes) > 0 {
+		effect.Fields = make(map[string]any, len(mod.Fields))
+		for _, f := range mod.Fields {
+			effect.Fields[f] = nil
+		}
+		for _, c := range mod.Changes {
+			if !c.Indeterminate {
+				effect.Fields[c.FieldName] = c.Value
+			}
+		}
+	}
+
+	return effect
+}
+
+// =============================================================================
+// Summary
+// =============================================================================
+
+// simulationTally counts branches, effects and unverified actions across events.
+type simulationTally struct {
+	branches        map[string]*BranchCount
+	order           []string
+	effects         map[string]int
+	unverified      map[uuid.UUID]*UnverifiedAction
+	unverifiedOrder []uuid.UUID
+}
+
+func newSimulationTally() *simulationTally {
+	return &simulationTally{
+		branches:   make(map[string]*BranchCount),
+		effects:    make(map[string]int),
+		unverified: make(map[uuid.UUID]*UnverifiedAction),
+	}
+}
+
+func (t *simulationTally) add(step SimulatedStep) {
+	for _, e := range step.Effects {
+		t.effects[e.Kind]++
+	}
+
+	if step.Unverified {
+		ua, ok := t.unverified[step.ActionID]
+		if !ok {
+			ua = &UnverifiedAction{ActionID: step.ActionID, ActionName: step.ActionName, ActionType: step.ActionType}
+			t.unverified[step.ActionID] = ua
+			t.unverifiedOrder = append(t.unverifiedOrder, step.ActionID)
+		}
+		ua.Count++
+	}
+
+	if step.Output == "" || step.Skipped {
+		return
+	}
+
+	key := step.ActionID.String() + "|" + step.Output
+	bc, ok := t.branches[key]
+	if !ok {
+		bc = &BranchCount{ActionID: step.ActionID, ActionName: step.ActionName, Output: step.Output}
+		t.branches[key] = bc
+		t.order = append(t.order, key)
+	}
+	bc.Count++
+}
+
+func (t *simulationTally) summary() SimulationSummary {
+	summary := SimulationSummary{
+		Branches:   make([]BranchCount, 0, len(t.order)),
+		Effects:    t.effects,
+		Unverified: make([]UnverifiedAction, 0, len(t.unverifiedOrder)),
+	}
+	for _, key := range t.order {
+		summary.Branches = append(summary.Branches, *t.branches[key])
+	}
+	for _, id := range t.unverifiedOrder {
+		summary.Unverified = append(summary.Unverified, *t.unverified[id])
+	}
+	return summary
+}
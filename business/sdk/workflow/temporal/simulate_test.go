@@ -0,0 +1,441 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+)
+
+// planningHandler plans from the trigger data and fails the test if executed.
+// It takes the "true" port when RawData[field] equals value, else "false", and
+// plans an email to the entity's owner. failOn makes Plan fail for a value.
+type planningHandler struct {
+	t          *testing.T
+	actionType string
+	field      string
+	value      any
+	failOn     any
+	seen       []workflow.ActionExecutionContext
+}
+
+func (h *planningHandler) Plan(_ context.Context, _ json.RawMessage, execCtx workflow.ActionExecutionContext) (workflow.ActionPlan, error) {
+	h.seen = append(h.seen, execCtx)
+
+	v := execCtx.RawData[h.field]
+	if h.failOn != nil && v == h.failOn {
+		return workflow.ActionPlan{}, fmt.Errorf("%v rejected", v)
+	}
+
+	output := "false"
+	if v == h.value {
+		output = "true"
+	}
+	return workflow.ActionPlan{
+		Output: output,
+		Result: map[string]any{"checked": v},
+		Effects: []workflow.PlannedEffect{{
+			Kind:       workflow.EffectEmail,
+			Recipients: []string{fmt.Sprintf("%v", execCtx.RawData["owner"])},
+			Subject:    fmt.Sprintf("%s is %v", execCtx.EntityName, v),
+		}},
+	}, nil
+}
+
+func (h *planningHandler) Execute(context.Context, json.RawMessage, workflow.ActionExecutionContext) (any, error) {
+	h.t.Errorf("%s executed during a simulation", h.actionType)
+	return nil, errors.New("executed")
+}
+
+func (h *planningHandler) Validate(json.RawMessage) error { return nil }
+func (h *planningHandler) GetType() string                { return h.actionType }
+func (h *planningHandler) SupportsManualExecution() bool  { return false }
+func (h *planningHandler) IsAsync() bool                  { return false }
+func (h *planningHandler) GetDescription() string         { return "planning handler" }
+
+func (h *planningHandler) GetOutputPorts() []workflow.OutputPort {
+	return []workflow.OutputPort{{Name: "true", IsDefault: true}, {Name: "false"}}
+}
+
+// plainHandler has no planner; it declares the entity it modifies.
+type plainHandler struct {
+	t          *testing.T
+	actionType string
+	async      bool
+}
+
+func (h *plainHandler) Execute(context.Context, json.RawMessage, workflow.ActionExecutionContext) (any, error) {
+	h.t.Errorf("%s executed during a simulation", h.actionType)
+	return nil, errors.New("executed")
+}
+
+func (h *plainHandler) GetEntityModifications(json.RawMessage) []workflow.EntityModification {
+	return []workflow.EntityModification{{
+		EntityName: "sales.orders",
+		EventType:  workflow.EventTypeOnUpdate,
+		Fields:     []string{"status", "notes"},
+		Changes:    []workflow.ProducedChange{{FieldName: "status", Operator: workflow.OperatorChangedTo, Value: "held"}},
+	}}
+}
+
+func (h *plainHandler) Validate(json.RawMessage) error { return nil }
+func (h *plainHandler) GetType() string                { return h.actionType }
+func (h *plainHandler) SupportsManualExecution() bool  { return false }
+func (h *plainHandler) IsAsync() bool                  { return h.async }
+func (h *plainHandler) GetDescription() string         { return "plain handler" }
+
+func (h *plainHandler) GetOutputPorts() []workflow.OutputPort {
+	return []workflow.OutputPort{{Name: "success", IsDefault: true}, {Name: "insufficient_stock"}}
+}
+
+// simGraph builds a graph from a chain of nodes: each node follows the one
+// before it on the given port ("" for an unconditional edge).
+type simNode struct {
+	name       string
+	actionType string
+	config     string
+	after      string
+	port       string
+	inactive   bool
+}
+
+func simGraph(nodes ...simNode) GraphDefinition {
+	var g GraphDefinition
+	ids := make(map[string]uuid.UUID)
+
+	for i, n := range nodes {
+		id := uuid.New()
+		ids[n.name] = id
+
+		config := n.config
+		if config == "" {
+			config = `{}`
+		}
+		g.Actions = append(g.Actions, ActionNode{ID: id, Name: n.name, ActionType: n.actionType, Config: json.RawMessage(config), IsActive: !n.inactive})
+
+		edge := ActionEdge{ID: uuid.New(), TargetActionID: id, EdgeType: EdgeTypeStart, SortOrder: i}
+		if n.after != "" {
+			src := ids[n.after]
+			edge.SourceActionID = &src
+			edge.EdgeType = "sequence"
+			if n.port != "" {
+				edge.SourceOutput = strPtr(n.port)
+			}
+		}
+		g.Edges = append(g.Edges, edge)
+	}
+
+	return g
+}
+
+func simEvent(eventType string, data map[string]any) workflow.TriggerEvent {
+	return workflow.TriggerEvent{
+		EventType:  eventType,
+		EntityName: "orders",
+		EntityID:   uuid.New(),
+		Timestamp:  time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC),
+		RawData:    data,
+	}
+}
+
+func newTestSimulator(store RuleGraphStore, handlers ...workflow.ActionHandler) *Simulator {
+	registry := workflow.NewActionRegistry()
+	for _, h := range handlers {
+		registry.Register(h)
+	}
+	return NewSimulator(guardLogger(), registry, store)
+}
+
+func stepNames(steps []SimulatedStep) []string {
+	names := make([]string, len(steps))
+	for i, s := range steps {
+		names[i] = s.ActionName
+		if s.Iteration != "" {
+			names[i] = s.Iteration + ":" + s.ActionName
+		}
+	}
+	return names
+}
+
+func TestSimulate_TriggerMatching(t *testing.T) {
+	check := &planningHandler{t: t, actionType: "check", field: "status", value: "open"}
+	sim := newTestSimulator(nil, check)
+
+	rule := SimulationRule{
+		RuleID:            uuid.New(),
+		EntityName:        "orders",
+		TriggerType:       workflow.EventTypeOnCreate,
+		TriggerConditions: json.RawMessage(`{"expression": "total > 100"}`),
+		Graph:             simGraph(simNode{name: "check", actionType: "check"}),
+	}
+
+	other := simEvent(workflow.EventTypeOnCreate, map[string]any{"total": 500})
+	other.EntityName = "customers"
+
+	events := []workflow.TriggerEvent{
+		simEvent(workflow.EventTypeOnCreate, map[string]any{"total": 500, "status": "open"}),
+		simEvent(workflow.EventTypeOnCreate, map[string]any{"total": 50}),
+		simEvent(workflow.EventTypeOnUpdate, map[string]any{"total": 500}),
+		other,
+	}
+
+	report, err := sim.Simulate(context.Background(), rule, events)
+	require.NoError(t, err)
+
+	require.Equal(t, 4, report.EventCount)
+	require.Equal(t, 1, report.MatchedCount)
+	require.Equal(t, 0, report.FailedCount)
+
+	require.True(t, report.Events[0].Matched)
+	require.Equal(t, []string{"check"}, stepNames(report.Events[0].Steps))
+	require.Len(t, report.Events[0].Effects, 1)
+
+	require.False(t, report.Events[1].Matched)
+	require.Contains(t, report.Events[1].MatchReason, "Failed conditions")
+	require.Len(t, report.Events[1].Conditions, 1)
+	require.Empty(t, report.Events[1].Steps)
+
+	require.Contains(t, report.Events[2].MatchReason, "Trigger type mismatch")
+	require.Contains(t, report.Events[3].MatchReason, "Entity mismatch")
+
+	require.Len(t, check.seen, 1)
+	require.Equal(t, workflow.TriggerSourceSimulation, check.seen[0].TriggerSource)
+	require.Equal(t, events[0].Timestamp, check.seen[0].Timestamp)
+	require.Equal(t, events[0].EntityID, check.seen[0].EntityID)
+}
+
+func TestSimulate_InvalidTriggerConditions(t *testing.T) {
+	sim := newTestSimulator(nil)
+
+	rule := SimulationRule{EntityName: "orders", TriggerType: workflow.EventTypeOnCreate, TriggerConditions: json.RawMessage(`[`)}
+
+	_, err := sim.Simulate(context.Background(), rule, nil)
+	require.Error(t, err)
+}
+
+func TestSimulate_Branches(t *testing.T) {
+	check := &planningHandler{t: t, actionType: "check", field: "status", value: "open"}
+	hold := &plainHandler{t: t, actionType: "hold"}
+	sim := newTestSimulator(nil, check, hold)
+
+	rule := SimulationRule{
+		EntityName:  "orders",
+		TriggerType: workflow.EventTypeOnCreate,
+		Graph: simGraph(
+			simNode{name: "check", actionType: "check"},
+			simNode{name: "hold", actionType: "hold", after: "check", port: "true"},
+			simNode{name: "recheck", actionType: "check", after: "check", port: "false"},
+		),
+	}
+
+	events := []workflow.TriggerEvent{
+		simEvent(workflow.EventTypeOnCreate, map[string]any{"status": "open"}),
+		simEvent(workflow.EventTypeOnCreate, map[string]any{"status": "closed"}),
+		simEvent(workflow.EventTypeOnCreate, map[string]any{"status": "open"}),
+	}
+
+	report, err := sim.Simulate(context.Background(), rule, events)
+	require.NoError(t, err)
+	require.Equal(t, 3, report.MatchedCount)
+
+	require.Equal(t, []string{"check", "hold"}, stepNames(report.Events[0].Steps))
+	require.Equal(t, []string{"check", "recheck"}, stepNames(report.Events[1].Steps))
+
+	// The handler without a planner is assumed to succeed and reports what it declares.
+	holdStep := report.Events[0].Steps[1]
+	require.False(t, holdStep.Planned)
+	require.True(t, holdStep.Unverified)
+	require.Equal(t, "success", holdStep.Output)
+	require.Contains(t, holdStep.Note, "insufficient_stock not checked")
+	require.Equal(t, []workflow.PlannedEffect{{
+		Kind:       workflow.EffectEntityModification,
+		EntityName: "sales.orders",
+		EventType:  workflow.EventTypeOnUpdate,
+		Fields:     map[string]any{"status": "held", "notes": nil},
+	}}, holdStep.Effects)
+
+	counts := make(map[string]int)
+	for _, b := range report.Summary.Branches {
+		counts[b.ActionName+"."+b.Output] = b.Count
+	}
+	require.Equal(t, map[string]int{"check.true": 2, "check.false": 1, "hold.success": 2, "recheck.false": 1}, counts)
+	require.Equal(t, map[string]int{workflow.EffectEmail: 4, workflow.EffectEntityModification: 2}, report.Summary.Effects)
+
+	// Only the events that ran the assumed action are flagged.
+	require.True(t, report.Events[0].Unverified)
+	require.False(t, report.Events[1].Unverified)
+	require.True(t, report.Events[2].Unverified)
+	require.Equal(t, 2, report.UnverifiedCount)
+	require.Equal(t, []UnverifiedAction{{ActionID: holdStep.ActionID, ActionName: "hold", ActionType: "hold", Count: 2}}, report.Summary.Unverified)
+}
+
+func TestSimulate_PlanResultsReachLaterActions(t *testing.T) {
+	first := &planningHandler{t: t, actionType: "first", field: "status", value: "open"}
+	second := &planningHandler{t: t, actionType: "second", field: "first.checked", value: "open"}
+	sim := newTestSimulator(nil, first, second)
+
+	rule := SimulationRule{
+		EntityName:  "orders",
+		TriggerType: workflow.EventTypeOnCreate,
+		Graph: simGraph(
+			simNode{name: "first", actionType: "first"},
+			simNode{name: "second", actionType: "second", after: "first", port: "true"},
+		),
+	}
+
+	report, err := sim.Simulate(context.Background(), rule, []workflow.TriggerEvent{
+		simEvent(workflow.EventTypeOnCreate, map[string]any{"status": "open"}),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "true", report.Events[0].Steps[1].Output)
+}
+
+func TestSimulate_AsyncWithoutPlannerStopsPath(t *testing.T) {
+	approve := &plainHandler{t: t, actionType: "seek_approval", async: true}
+	after := &plainHandler{t: t, actionType: "after"}
+	sim := newTestSimulator(nil, approve, after)
+
+	rule := SimulationRule{
+		EntityName:  "orders",
+		TriggerType: workflow.EventTypeOnCreate,
+		Graph: simGraph(
+			simNode{name: "approve", actionType: "seek_approval"},
+			simNode{name: "after", actionType: "after", after: "approve"},
+		),
+	}
+
+	report, err := sim.Simulate(context.Background(), rule, []workflow.TriggerEvent{simEvent(workflow.EventTypeOnCreate, nil)})
+	require.NoError(t, err)
+	require.Equal(t, []string{"approve"}, stepNames(report.Events[0].Steps))
+	require.Empty(t, report.Events[0].Steps[0].Output)
+	require.True(t, report.Events[0].Steps[0].Unverified)
+	require.Equal(t, 1, report.UnverifiedCount)
+	require.Equal(t, 0, report.FailedCount)
+}
+
+func TestSimulate_PlanErrorFailsRun(t *testing.T) {
+	check := &planningHandler{t: t, actionType: "check", field: "status", value: "open", failOn: "bad"}
+	sim := newTestSimulator(nil, check)
+
+	rule := SimulationRule{
+		EntityName:  "orders",
+		TriggerType: workflow.EventTypeOnCreate,
+		Graph: simGraph(
+			simNode{name: "check", actionType: "check"},
+			simNode{name: "again", actionType: "check", after: "check"},
+		),
+	}
+
+	report, err := sim.Simulate(context.Background(), rule, []workflow.TriggerEvent{
+		simEvent(workflow.EventTypeOnCreate, map[string]any{"status": "bad"}),
+		simEvent(workflow.EventTypeOnCreate, map[string]any{"status": "missing_handler"}),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, report.FailedCount)
+
+	failed := report.Events[0]
+	require.Contains(t, failed.Error, "bad rejected")
+	require.Equal(t, []string{"check"}, stepNames(failed.Steps))
+	require.NotEmpty(t, failed.Steps[0].Error)
+	require.Empty(t, failed.Steps[0].Output)
+}
+
+func TestSimulate_UnknownActionTypeFails(t *testing.T) {
+	sim := newTestSimulator(nil)
+
+	rule := SimulationRule{
+		EntityName:  "orders",
+		TriggerType: workflow.EventTypeOnCreate,
+		Graph:       simGraph(simNode{name: "mystery", actionType: "mystery"}),
+	}
+
+	report, err := sim.Simulate(context.Background(), rule, []workflow.TriggerEvent{simEvent(workflow.EventTypeOnCreate, nil)})
+	require.NoError(t, err)
+	require.Equal(t, 1, report.FailedCount)
+	require.Contains(t, report.Events[0].Error, "no handler registered")
+}
+
+func TestSimulate_ControlNodes(t *testing.T) {
+	check := &planningHandler{t: t, actionType: "check", field: "line", value: "A"}
+	hold := &plainHandler{t: t, actionType: "hold"}
+	sim := newTestSimulator(nil, check, hold)
+
+	rule := SimulationRule{
+		EntityName:  "orders",
+		TriggerType: workflow.EventTypeOnCreate,
+		Graph: simGraph(
+			simNode{name: "wait", actionType: "delay", config: `{"duration": "24h"}`},
+			simNode{name: "lines", actionType: forEachActionType, config: `{"items": "{{line_items}}", "item_name": "line"}`, after: "wait"},
+			simNode{name: "check_line", actionType: "check", after: "lines", port: forEachPortEach},
+			simNode{name: "skipped", actionType: "hold", after: "lines", port: forEachPortDone, inactive: true},
+			simNode{name: "hold", actionType: "hold", after: "skipped"},
+		),
+	}
+
+	report, err := sim.Simulate(context.Background(), rule, []workflow.TriggerEvent{
+		simEvent(workflow.EventTypeOnCreate, map[string]any{"line_items": []any{"A", "B"}}),
+	})
+	require.NoError(t, err)
+
+	steps := report.Events[0].Steps
+	require.Equal(t, []string{"wait", "lines[0]:check_line", "lines[1]:check_line", "lines", "skipped", "hold"}, stepNames(steps))
+	require.Equal(t, "would wait 24h0m0s", steps[0].Note)
+	require.Equal(t, "true", steps[1].Output)
+	require.Equal(t, "false", steps[2].Output)
+	require.Equal(t, forEachPortDone, steps[3].Output)
+	require.True(t, steps[4].Skipped)
+	require.Empty(t, steps[4].Effects)
+}
+
+func TestSimulate_CallWorkflow(t *testing.T) {
+	hold := &plainHandler{t: t, actionType: "hold"}
+
+	calledID := uuid.New()
+	store := fakeRuleGraphStore{
+		calledID: {RuleID: calledID, RuleName: "Hold order", Graph: simGraph(simNode{name: "hold", actionType: "hold"})},
+	}
+
+	rule := SimulationRule{
+		RuleID:      uuid.New(),
+		EntityName:  "orders",
+		TriggerType: workflow.EventTypeOnCreate,
+		Graph: simGraph(
+			simNode{name: "call", actionType: callWorkflowActionType, config: fmt.Sprintf(`{"rule_id": %q}`, calledID)},
+		),
+	}
+	events := []workflow.TriggerEvent{simEvent(workflow.EventTypeOnCreate, nil)}
+
+	t.Run("simulated", func(t *testing.T) {
+		report, err := newTestSimulator(store, hold).Simulate(context.Background(), rule, events)
+		require.NoError(t, err)
+
+		steps := report.Events[0].Steps
+		require.Equal(t, []string{"call:Hold order:hold", "call"}, stepNames(steps))
+		require.Equal(t, callWorkflowPortCompleted, steps[1].Output)
+		require.Equal(t, workflow.EffectWorkflowCall, steps[1].Effects[0].Kind)
+		require.Len(t, report.Events[0].Effects, 2)
+	})
+
+	t.Run("no store", func(t *testing.T) {
+		report, err := newTestSimulator(nil, hold).Simulate(context.Background(), rule, events)
+		require.NoError(t, err)
+		require.Equal(t, []string{"call"}, stepNames(report.Events[0].Steps))
+		require.Equal(t, callWorkflowPortCompleted, report.Events[0].Steps[0].Output)
+	})
+
+	t.Run("recursive", func(t *testing.T) {
+		self := rule
+		self.RuleID = calledID
+
+		report, err := newTestSimulator(store, hold).Simulate(context.Background(), self, events)
+		require.NoError(t, err)
+		require.Contains(t, report.Events[0].Error, "already running in this call chain")
+	})
+}
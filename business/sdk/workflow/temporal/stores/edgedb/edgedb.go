@@ -1,13 +1,16 @@
 // Package edgedb implements the EdgeStore interface for loading graph
 // definitions from PostgreSQL. Used by the Temporal trigger system
-// to build GraphDefinition from rule_actions and action_edges tables.
+// to build GraphDefinition from a rule's published revision, or from the
+// rule_actions and action_edges tables for a rule that has none.
 package edgedb
 
 import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -18,8 +21,11 @@ import (
 	"github.com/timmaaaz/ichor/foundation/logger"
 )
 
-// Verify Store implements EdgeStore interface at compile time.
-var _ temporal.EdgeStore = (*Store)(nil)
+// Verify Store implements EdgeStore and RuleGraphStore interfaces at compile time.
+var (
+	_ temporal.EdgeStore      = (*Store)(nil)
+	_ temporal.RuleGraphStore = (*Store)(nil)
+)
 
 // Store implements the temporal.EdgeStore interface by loading
 // graph definitions from PostgreSQL.
@@ -157,6 +163,164 @@ func (s *Store) QueryEdgesByRule(ctx context.Context, ruleID uuid.UUID) ([]tempo
 	return edges, nil
 }
 
+// QueryPublishedGraph returns the graph of the rule's published revision and
+// the revision's id, read from the revision row itself so the graph and the
+// id an execution is pinned to always agree. Action types are resolved from
+// the action templates as they stand now, like the live graph's. A rule with
+// no published revision, such as one written straight through the business
+// layer by seeding, runs its live actions and edges and the returned revision
+// id is nil.
+func (s *Store) QueryPublishedGraph(ctx context.Context, ruleID uuid.UUID) (temporal.PublishedGraph, error) {
+	data := struct {
+		RuleID string `db:"rule_id"`
+		Status string `db:"status"`
+	}{
+		RuleID: ruleID.String(),
+		Status: workflow.RevisionPublished,
+	}
+
+	const q = `
+	SELECT
+		id,
+		definition
+	FROM
+		workflow.rule_revisions
+	WHERE
+		rule_id = :rule_id AND status = :status`
+
+	var rev struct {
+		ID         uuid.UUID       `db:"id"`
+		Definition json.RawMessage `db:"definition"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &rev); err != nil {
+		if !errors.Is(err, sqldb.ErrDBNotFound) {
+			return temporal.PublishedGraph{}, fmt.Errorf("namedquerystruct[revision]: %w", err)
+		}
+
+		actions, err := s.QueryActionsByRule(ctx, ruleID)
+		if err != nil {
+			return temporal.PublishedGraph{}, err
+		}
+
+		edges, err := s.QueryEdgesByRule(ctx, ruleID)
+		if err != nil {
+			return temporal.PublishedGraph{}, err
+		}
+
+		return temporal.PublishedGraph{
+			Graph: temporal.GraphDefinition{
+				Actions: actions,
+				Edges:   edges,
+			},
+		}, nil
+	}
+
+	var def workflow.RuleDefinition
+	if err := json.Unmarshal(rev.Definition, &def); err != nil {
+		return temporal.PublishedGraph{}, fmt.Errorf("unmarshal revision[%s] definition: %w", rev.ID, err)
+	}
+
+	actionTypes, err := s.queryTemplateActionTypes(ctx, def.Actions)
+	if err != nil {
+		return temporal.PublishedGraph{}, err
+	}
+
+	s.log.Info(ctx, "edgedb.QueryPublishedGraph", "rule_id", ruleID, "revision_id", rev.ID, "actions", len(def.Actions), "edges", len(def.Edges))
+
+	return temporal.PublishedGraph{
+		RevisionID: &rev.ID,
+		Graph:      toGraphDefinition(def, actionTypes),
+	}, nil
+}
+
+// queryTemplateActionTypes returns the action type of each template the
+// actions are linked to, keyed by template id.
+func (s *Store) queryTemplateActionTypes(ctx context.Context, actions []workflow.RevisionAction) (map[uuid.UUID]string, error) {
+	var ids []uuid.UUID
+	for _, a := range actions {
+		if a.TemplateID != nil {
+			ids = append(ids, *a.TemplateID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return map[uuid.UUID]string{}, nil
+	}
+
+	data := struct {
+		IDs []uuid.UUID `db:"ids"`
+	}{
+		IDs: ids,
+	}
+
+	const q = `
+	SELECT
+		id,
+		action_type
+	FROM
+		workflow.action_templates
+	WHERE
+		id = ANY(:ids)`
+
+	var templates []struct {
+		ID         uuid.UUID `db:"id"`
+		ActionType string    `db:"action_type"`
+	}
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &templates); err != nil {
+		return nil, fmt.Errorf("namedqueryslice[templates]: %w", err)
+	}
+
+	actionTypes := make(map[uuid.UUID]string, len(templates))
+	for _, t := range templates {
+		actionTypes[t.ID] = t.ActionType
+	}
+
+	return actionTypes, nil
+}
+
+// QueryRuleGraph returns the name and the published graph of a rule, for the
+// call_workflow action, with the revision the graph was read from so the call
+// records which version of the called rule ran. The rule's active flag is not
+// checked: a rule that is only ever called is normally kept inactive so its own
+// trigger never fires. Returns temporal.ErrRuleNotFound when no such rule exists.
+func (s *Store) QueryRuleGraph(ctx context.Context, ruleID uuid.UUID) (temporal.RuleGraph, error) {
+	data := struct {
+		ID string `db:"id"`
+	}{
+		ID: ruleID.String(),
+	}
+
+	const q = `
+	SELECT
+		name
+	FROM
+		workflow.automation_rules
+	WHERE
+		id = :id`
+
+	var rule struct {
+		Name string `db:"name"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &rule); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return temporal.RuleGraph{}, fmt.Errorf("rule %s: %w", ruleID, temporal.ErrRuleNotFound)
+		}
+		return temporal.RuleGraph{}, fmt.Errorf("namedquerystruct[rule]: %w", err)
+	}
+
+	published, err := s.QueryPublishedGraph(ctx, ruleID)
+	if err != nil {
+		return temporal.RuleGraph{}, err
+	}
+
+	return temporal.RuleGraph{
+		RuleID:     ruleID,
+		RuleName:   rule.Name,
+		RevisionID: published.RevisionID,
+		Graph:      published.Graph,
+	}, nil
+}
+
 // =============================================================================
 // Conversion Functions
 // =============================================================================
@@ -194,6 +358,52 @@ func toActionNode(dba dbAction) temporal.ActionNode {
 	return node
 }
 
+// toGraphDefinition converts a revision definition to the graph the workflow
+// walks. An action without a template takes its type from action_config, as
+// toActionNode does. Revision edges carry no ids, so each gets a fresh one;
+// edges are ordered by edge_order like QueryEdgesByRule's.
+func toGraphDefinition(def workflow.RuleDefinition, actionTypes map[uuid.UUID]string) temporal.GraphDefinition {
+	graph := temporal.GraphDefinition{
+		Actions: make([]temporal.ActionNode, len(def.Actions)),
+		Edges:   make([]temporal.ActionEdge, len(def.Edges)),
+	}
+
+	for i, a := range def.Actions {
+		actionType := workflow.ConfigActionType(a.ActionConfig)
+		if a.TemplateID != nil {
+			if t, ok := actionTypes[*a.TemplateID]; ok {
+				actionType = t
+			}
+		}
+
+		graph.Actions[i] = temporal.ActionNode{
+			ID:          a.ID,
+			Name:        a.Name,
+			Description: a.Description,
+			ActionType:  actionType,
+			Config:      a.ActionConfig,
+			IsActive:    a.IsActive,
+		}
+	}
+
+	for i, e := range def.Edges {
+		graph.Edges[i] = temporal.ActionEdge{
+			ID:             uuid.New(),
+			SourceActionID: e.SourceActionID,
+			TargetActionID: e.TargetActionID,
+			EdgeType:       e.EdgeType,
+			SourceOutput:   e.SourceOutput,
+			SortOrder:      e.EdgeOrder,
+		}
+	}
+
+	sort.SliceStable(graph.Edges, func(i, j int) bool {
+		return graph.Edges[i].SortOrder < graph.Edges[j].SortOrder
+	})
+
+	return graph
+}
+
 // toActionEdge converts a database edge row to a temporal.ActionEdge.
 func toActionEdge(dbe dbEdge) temporal.ActionEdge {
 	edge := temporal.ActionEdge{
@@ -3,6 +3,7 @@ package edgedb_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/google/uuid"
@@ -169,4 +170,96 @@ func Test_EdgeDB(t *testing.T) {
 			t.Error("GraphDefinition should have actions and edges")
 		}
 	})
+
+	// -------------------------------------------------------------------------
+
+	t.Run("query-rule-graph-success", func(t *testing.T) {
+		rg, err := store.QueryRuleGraph(ctx, ruleID)
+		if err != nil {
+			t.Fatalf("QueryRuleGraph: %s", err)
+		}
+		if rg.RuleID != ruleID {
+			t.Errorf("RuleID = %s, want %s", rg.RuleID, ruleID)
+		}
+		if rg.RuleName != wfData.AutomationRules[0].Name {
+			t.Errorf("RuleName = %q, want %q", rg.RuleName, wfData.AutomationRules[0].Name)
+		}
+		if len(rg.Graph.Actions) == 0 || len(rg.Graph.Edges) == 0 {
+			t.Error("rule graph should have actions and edges")
+		}
+	})
+
+	// -------------------------------------------------------------------------
+
+	t.Run("query-rule-graph-not-found", func(t *testing.T) {
+		_, err := store.QueryRuleGraph(ctx, uuid.New())
+		if !errors.Is(err, temporal.ErrRuleNotFound) {
+			t.Fatalf("QueryRuleGraph: expected ErrRuleNotFound, got %v", err)
+		}
+	})
+
+	// -------------------------------------------------------------------------
+
+	t.Run("query-published-graph-no-revision", func(t *testing.T) {
+		pg, err := store.QueryPublishedGraph(ctx, ruleID)
+		if err != nil {
+			t.Fatalf("QueryPublishedGraph: %s", err)
+		}
+		if pg.RevisionID != nil {
+			t.Errorf("RevisionID = %s, want nil for a rule with no revision", *pg.RevisionID)
+		}
+
+		actions, err := store.QueryActionsByRule(ctx, ruleID)
+		if err != nil {
+			t.Fatalf("QueryActionsByRule: %s", err)
+		}
+		if len(pg.Graph.Actions) != len(actions) {
+			t.Errorf("actions = %d, want the %d live actions", len(pg.Graph.Actions), len(actions))
+		}
+	})
+
+	// -------------------------------------------------------------------------
+
+	t.Run("query-published-graph-revision", func(t *testing.T) {
+		rev, err := db.BusDomain.Workflow.PublishLiveRevision(ctx, ruleID, users[0].ID, "edgedb test")
+		if err != nil {
+			t.Fatalf("PublishLiveRevision: %s", err)
+		}
+
+		pg, err := store.QueryPublishedGraph(ctx, ruleID)
+		if err != nil {
+			t.Fatalf("QueryPublishedGraph: %s", err)
+		}
+		if pg.RevisionID == nil || *pg.RevisionID != rev.ID {
+			t.Fatalf("RevisionID = %v, want %s", pg.RevisionID, rev.ID)
+		}
+		if len(pg.Graph.Actions) != len(rev.Definition.Actions) {
+			t.Errorf("actions = %d, want %d", len(pg.Graph.Actions), len(rev.Definition.Actions))
+		}
+		if len(pg.Graph.Edges) != len(rev.Definition.Edges) {
+			t.Errorf("edges = %d, want %d", len(pg.Graph.Edges), len(rev.Definition.Edges))
+		}
+		for _, a := range pg.Graph.Actions {
+			if a.ActionType == "" {
+				t.Errorf("action %s: ActionType should be resolved from template", a.ID)
+			}
+		}
+		for i := 1; i < len(pg.Graph.Edges); i++ {
+			if pg.Graph.Edges[i-1].SortOrder > pg.Graph.Edges[i].SortOrder {
+				t.Errorf("edges should be ordered by edge_order")
+				break
+			}
+		}
+
+		rg, err := store.QueryRuleGraph(ctx, ruleID)
+		if err != nil {
+			t.Fatalf("QueryRuleGraph: %s", err)
+		}
+		if rg.RevisionID == nil || *rg.RevisionID != rev.ID {
+			t.Errorf("QueryRuleGraph RevisionID = %v, want %s", rg.RevisionID, rev.ID)
+		}
+		if len(rg.Graph.Actions) != len(pg.Graph.Actions) {
+			t.Errorf("QueryRuleGraph actions = %d, want the %d published actions", len(rg.Graph.Actions), len(pg.Graph.Actions))
+		}
+	})
 }
@@ -42,11 +42,17 @@ type ExecutionStore interface {
 // EdgeStore loads graph definitions (actions + edges) from the database.
 // Implemented by stores/edgedb.Store in Phase 8.
 type EdgeStore interface {
-	// QueryActionsByRule returns all action nodes for a given automation rule.
-	QueryActionsByRule(ctx context.Context, ruleID uuid.UUID) ([]ActionNode, error)
+	// QueryPublishedGraph returns the graph of the rule's published revision
+	// together with that revision's id.
+	QueryPublishedGraph(ctx context.Context, ruleID uuid.UUID) (PublishedGraph, error)
+}
 
-	// QueryEdgesByRule returns all action edges for a given automation rule.
-	QueryEdgesByRule(ctx context.Context, ruleID uuid.UUID) ([]ActionEdge, error)
+// PublishedGraph is the graph a rule runs and the revision it was read from.
+// The two are loaded together so an execution is pinned to the revision whose
+// graph it was handed, even when a publish lands in between.
+type PublishedGraph struct {
+	RevisionID *uuid.UUID // nil when the rule has no published revision
+	Graph      GraphDefinition
 }
 
 // RuleMatcher matches entity events against automation rules.
@@ -282,6 +288,33 @@ func (t *WorkflowTrigger) RerunExecution(ctx context.Context, executionID uuid.U
 	return newID, nil
 }
 
+// DispatchScheduled starts the graph of a single scheduled rule for a synthetic
+// "scheduled" event built by the Scheduler. Rule matching is bypassed: the
+// schedule already identifies exactly one rule, and entity-driven trigger
+// conditions have nothing to evaluate against. The event's EventID must be
+// deterministic per (schedule, occurrence) so that two replicas firing the same
+// occurrence collapse into one run via the REJECT_DUPLICATE workflow id.
+//
+// Each scheduled firing starts a fresh cascade chain. Returns the new execution
+// id, or uuid.Nil when the rule has no active graph or the occurrence was
+// already dispatched.
+func (t *WorkflowTrigger) DispatchScheduled(ctx context.Context, ruleID uuid.UUID, ruleName string, event workflow.TriggerEvent) (uuid.UUID, error) {
+	t.log.Info(ctx, "Dispatching scheduled rule",
+		"rule_id", ruleID,
+		"rule_name", ruleName,
+		"event_id", event.EventID,
+	)
+
+	rm := workflow.RuleMatchResult{
+		Rule:         workflow.AutomationRuleView{ID: ruleID, Name: ruleName, EntityName: event.EntityName, TriggerTypeName: event.EventType},
+		Matched:      true,
+		TriggerEvent: event,
+		MatchReason:  "Scheduled occurrence",
+	}
+
+	return t.startWorkflowForRule(ctx, event, rm, WorkflowLineage{})
+}
+
 // startWorkflowForRule loads the graph definition and starts a Temporal workflow
 // for a single matched rule.
 //
@@ -293,11 +326,12 @@ func (t *WorkflowTrigger) startWorkflowForRule(
 	rm workflow.RuleMatchResult,
 	lineage WorkflowLineage,
 ) (uuid.UUID, error) {
-	// Load graph definition from database.
-	graph, err := t.loadGraphDefinition(ctx, rm.Rule.ID)
+	// Load the published graph definition from database.
+	published, err := t.edgeStore.QueryPublishedGraph(ctx, rm.Rule.ID)
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("load graph for rule %s: %w", rm.Rule.ID, err)
 	}
+	graph := published.Graph
 
 	// Skip rules with empty graphs (no actions configured).
 	if len(graph.Actions) == 0 {
@@ -357,6 +391,7 @@ func (t *WorkflowTrigger) startWorkflowForRule(
 		TriggerData:      triggerDataJSON,
 		Status:           workflow.StatusPending,
 		TriggerSource:    workflow.TriggerSourceAutomation,
+		RevisionID:       published.RevisionID,
 	}); err != nil {
 		return uuid.Nil, fmt.Errorf("creating execution record: %w", err)
 	}
@@ -430,25 +465,6 @@ func (t *WorkflowTrigger) startWorkflowForRule(
 	return executionID, nil
 }
 
-// loadGraphDefinition loads actions and edges from the EdgeStore
-// and assembles them into a GraphDefinition.
-func (t *WorkflowTrigger) loadGraphDefinition(ctx context.Context, ruleID uuid.UUID) (GraphDefinition, error) {
-	actions, err := t.edgeStore.QueryActionsByRule(ctx, ruleID)
-	if err != nil {
-		return GraphDefinition{}, fmt.Errorf("query actions: %w", err)
-	}
-
-	edges, err := t.edgeStore.QueryEdgesByRule(ctx, ruleID)
-	if err != nil {
-		return GraphDefinition{}, fmt.Errorf("query edges: %w", err)
-	}
-
-	return GraphDefinition{
-		Actions: actions,
-		Edges:   edges,
-	}, nil
-}
-
 // buildTriggerData converts a TriggerEvent into a map suitable for
 // WorkflowInput.TriggerData. This populates the initial MergedContext
 // in the workflow, making event data available for template resolution.
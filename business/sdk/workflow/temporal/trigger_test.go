@@ -26,30 +26,36 @@ var _ temporal.ExecutionStore = (*workflowdb.Store)(nil)
 // =============================================================================
 
 type mockEdgeStore struct {
-	actions map[uuid.UUID][]temporal.ActionNode
-	edges   map[uuid.UUID][]temporal.ActionEdge
-	err     error // If set, all calls return this error
+	actions   map[uuid.UUID][]temporal.ActionNode
+	edges     map[uuid.UUID][]temporal.ActionEdge
+	revisions map[uuid.UUID]uuid.UUID // published revision per rule; absent means none
+	err       error                   // If set, all calls return this error
 }
 
 func newMockEdgeStore() *mockEdgeStore {
 	return &mockEdgeStore{
-		actions: make(map[uuid.UUID][]temporal.ActionNode),
-		edges:   make(map[uuid.UUID][]temporal.ActionEdge),
+		actions:   make(map[uuid.UUID][]temporal.ActionNode),
+		edges:     make(map[uuid.UUID][]temporal.ActionEdge),
+		revisions: make(map[uuid.UUID]uuid.UUID),
 	}
 }
 
-func (m *mockEdgeStore) QueryActionsByRule(_ context.Context, ruleID uuid.UUID) ([]temporal.ActionNode, error) {
+func (m *mockEdgeStore) QueryPublishedGraph(_ context.Context, ruleID uuid.UUID) (temporal.PublishedGraph, error) {
 	if m.err != nil {
-		return nil, m.err
+		return temporal.PublishedGraph{}, m.err
 	}
-	return m.actions[ruleID], nil
-}
 
-func (m *mockEdgeStore) QueryEdgesByRule(_ context.Context, ruleID uuid.UUID) ([]temporal.ActionEdge, error) {
-	if m.err != nil {
-		return nil, m.err
+	pg := temporal.PublishedGraph{
+		Graph: temporal.GraphDefinition{
+			Actions: m.actions[ruleID],
+			Edges:   m.edges[ruleID],
+		},
+	}
+	if revisionID, ok := m.revisions[ruleID]; ok {
+		pg.RevisionID = &revisionID
 	}
-	return m.edges[ruleID], nil
+
+	return pg, nil
 }
 
 // =============================================================================
@@ -554,6 +560,51 @@ func TestOnEntityEvent_EdgeStoreError(t *testing.T) {
 	}
 }
 
+// TestOnEntityEvent_PinsPublishedRevision checks the execution record is pinned
+// to the revision the graph was loaded from, and left unpinned for a rule with
+// no published revision.
+func TestOnEntityEvent_PinsPublishedRevision(t *testing.T) {
+	ruleID := testRuleID()
+	revisionID := uuid.New()
+
+	tests := []struct {
+		name      string
+		published bool
+	}{
+		{name: "published revision", published: true},
+		{name: "no revision", published: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edgeStore := newMockEdgeStore()
+			actions, edges := testGraph(ruleID)
+			edgeStore.actions[ruleID] = actions
+			edgeStore.edges[ruleID] = edges
+			if tt.published {
+				edgeStore.revisions[ruleID] = revisionID
+			}
+
+			starter := newMockWorkflowStarter()
+			matcher := &mockRuleMatcher{result: matchedResult(ruleID, "test-rule")}
+			execStore := &mockExecutionStore{}
+
+			trigger := temporal.NewWorkflowTrigger(testLogger(), starter, matcher, edgeStore, execStore)
+			if err := trigger.OnEntityEvent(context.Background(), testEvent()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := execStore.lastCreated.RevisionID
+			switch {
+			case !tt.published && got != nil:
+				t.Errorf("expected no revision, got %s", *got)
+			case tt.published && (got == nil || *got != revisionID):
+				t.Errorf("expected revision %s, got %v", revisionID, got)
+			}
+		})
+	}
+}
+
 func TestOnEntityEvent_TemporalError(t *testing.T) {
 	edgeStore := newMockEdgeStore()
 	ruleID := testRuleID()
@@ -146,6 +146,13 @@ func shouldContinueAsNew(currentHistoryLength int) bool {
 // structured ActionResults map and Flattened template resolution data.
 func checkContinueAsNew(ctx workflow.Context, input WorkflowInput, mergedCtx *MergedContext) error {
 	info := workflow.GetInfo(ctx)
+
+	// A called rule's graph shares executeActions but must not continue as a
+	// top-level ExecuteGraphWorkflow; it runs to completion in its child.
+	if info.WorkflowType.Name == calledWorkflowTypeName {
+		return nil
+	}
+
 	if shouldContinueAsNew(int(info.GetCurrentHistoryLength())) {
 		logger := workflow.GetLogger(ctx)
 		logger.Info("History threshold exceeded, continuing as new workflow",
@@ -204,7 +211,16 @@ func executeSingleAction(ctx workflow.Context, executor *GraphExecutor, action A
 			"action_id", action.ID,
 			"action_name", action.Name,
 		)
-		nextActions := executor.GetNextActions(action.ID, map[string]any{"output": "success"})
+		// An inactive for_each runs no items but still continues from "done";
+		// an inactive call_workflow calls nothing but continues from "completed".
+		skipped := map[string]any{"output": "success"}
+		switch action.ActionType {
+		case forEachActionType:
+			skipped["output"] = forEachPortDone
+		case callWorkflowActionType:
+			skipped["output"] = callWorkflowPortCompleted
+		}
+		nextActions := executor.GetNextActions(action.ID, skipped)
 		if len(nextActions) == 0 {
 			return nil
 		}
@@ -222,6 +238,16 @@ func executeSingleAction(ctx workflow.Context, executor *GraphExecutor, action A
 		return executeDelay(ctx, executor, action, mergedCtx, input)
 	}
 
+	// Intercept for_each actions - the body runs as a child workflow per item.
+	if action.ActionType == forEachActionType {
+		return executeForEach(ctx, executor, action, mergedCtx, input)
+	}
+
+	// Intercept call_workflow actions - the called rule runs as a child workflow.
+	if action.ActionType == callWorkflowActionType {
+		return executeCallWorkflow(ctx, executor, action, mergedCtx, input)
+	}
+
 	// Prepare activity input.
 	activityInput := ActionActivityInput{
 		ActionID:    action.ID,
@@ -499,6 +525,64 @@ func ExecuteBranchUntilConvergence(ctx workflow.Context, input BranchInput) (Bra
 			continue
 		}
 
+		// Intercept for_each actions in branches, so loops nest.
+		if currentAction.ActionType == forEachActionType {
+			forEachResult, err := runForEach(ctx, executor, currentAction, mergedCtx, input.RuleID, input.ExecutionID, input.RuleName)
+			if err != nil {
+				return BranchOutput{}, err
+			}
+
+			mergedCtx.MergeResult(currentAction.Name, forEachResult)
+
+			nextActions := executor.GetNextActions(currentAction.ID, forEachResult)
+			if len(nextActions) == 0 {
+				if input.ConvergencePoint != uuid.Nil {
+					logger.Warn("Branch ended before reaching convergence point",
+						"last_action", currentAction.Name,
+						"convergence_point", input.ConvergencePoint,
+					)
+				}
+				break
+			}
+			if len(nextActions) > 1 {
+				logger.Warn("Multiple next actions in branch - following first only",
+					"action", currentAction.Name,
+					"next_count", len(nextActions),
+				)
+			}
+			currentAction = nextActions[0]
+			continue
+		}
+
+		// Intercept call_workflow actions in branches.
+		if currentAction.ActionType == callWorkflowActionType {
+			callResult, err := runCallWorkflow(ctx, currentAction, mergedCtx, input.RuleID, input.ExecutionID)
+			if err != nil {
+				return BranchOutput{}, err
+			}
+
+			mergedCtx.MergeResult(currentAction.Name, callResult)
+
+			nextActions := executor.GetNextActions(currentAction.ID, callResult)
+			if len(nextActions) == 0 {
+				if input.ConvergencePoint != uuid.Nil {
+					logger.Warn("Branch ended before reaching convergence point",
+						"last_action", currentAction.Name,
+						"convergence_point", input.ConvergencePoint,
+					)
+				}
+				break
+			}
+			if len(nextActions) > 1 {
+				logger.Warn("Multiple next actions in branch - following first only",
+					"action", currentAction.Name,
+					"next_count", len(nextActions),
+				)
+			}
+			currentAction = nextActions[0]
+			continue
+		}
+
 		// Execute action.
 		activityInput := ActionActivityInput{
 			ActionID:    currentAction.ID,
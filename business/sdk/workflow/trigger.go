@@ -13,17 +13,21 @@ import (
 	"github.com/timmaaaz/ichor/foundation/logger"
 )
 
-// FieldCondition represents a condition for field evaluation
+// FieldCondition represents a condition for field evaluation. A condition with the
+// "expression" operator carries an Expression instead of a field and value.
 type FieldCondition struct {
 	FieldName     string      `json:"field_name"`
 	Operator      string      `json:"operator"`
 	Value         interface{} `json:"value,omitempty"`
 	PreviousValue interface{} `json:"previous_value,omitempty"`
+	Expression    string      `json:"expression,omitempty"`
 }
 
-// TriggerConditions represents the conditions for triggering a rule
+// TriggerConditions represents the conditions for triggering a rule. The field
+// conditions and the expression must all match.
 type TriggerConditions struct {
 	FieldConditions []FieldCondition `json:"field_conditions,omitempty"`
+	Expression      string           `json:"expression,omitempty"`
 }
 
 // ConditionEvaluationResult represents the result of evaluating a condition
@@ -258,7 +262,7 @@ func (tp *TriggerProcessor) checkRuleMatch(rule AutomationRuleView, event Trigge
 		failedConditions := make([]string, 0)
 		for _, cr := range result.ConditionResults {
 			if !cr.Matched || cr.Error != "" {
-				failedConditions = append(failedConditions, cr.Condition.FieldName)
+				failedConditions = append(failedConditions, conditionLabel(cr.Condition))
 			}
 		}
 		result.MatchReason = fmt.Sprintf("Failed conditions: %s", strings.Join(failedConditions, ", "))
@@ -283,14 +287,15 @@ func (tp *TriggerProcessor) evaluateRuleConditions(rule AutomationRuleView, even
 		return []ConditionEvaluationResult{}
 	}
 
-	// If after unmarshaling there are still no field conditions, return empty (auto-match)
-	if len(conditions.FieldConditions) == 0 {
+	// If after unmarshaling there are still no conditions, return empty (auto-match)
+	conds := conditions.Conditions()
+	if len(conds) == 0 {
 		return []ConditionEvaluationResult{}
 	}
 
-	// Evaluate each field condition
-	results := make([]ConditionEvaluationResult, 0, len(conditions.FieldConditions))
-	for _, condition := range conditions.FieldConditions {
+	// Evaluate each condition
+	results := make([]ConditionEvaluationResult, 0, len(conds))
+	for _, condition := range conds {
 		result := tp.evaluateFieldCondition(condition, event)
 		results = append(results, result)
 	}
@@ -298,147 +303,20 @@ func (tp *TriggerProcessor) evaluateRuleConditions(rule AutomationRuleView, even
 	return results
 }
 
-// evaluateFieldCondition evaluates a single field condition
+// evaluateFieldCondition evaluates a single field condition against the event.
 func (tp *TriggerProcessor) evaluateFieldCondition(condition FieldCondition, event TriggerEvent) ConditionEvaluationResult {
-	result := ConditionEvaluationResult{
-		Condition: condition,
-		Matched:   false,
-	}
-
-	fieldName := condition.FieldName
-	var currentValue, previousValue interface{}
-
-	// Get current and previous values
-	if event.EventType == "on_update" && event.FieldChanges != nil {
-		if fieldChange, exists := event.FieldChanges[fieldName]; exists {
-			currentValue = fieldChange.NewValue
-			previousValue = fieldChange.OldValue
-		} else {
-			// Field wasn't changed in this update
-			if event.RawData != nil {
-				currentValue = event.RawData[fieldName]
-			}
-			previousValue = currentValue
-		}
-	} else {
-		// For create/delete, use raw data
-		if event.RawData != nil {
-			currentValue = event.RawData[fieldName]
-		}
-		previousValue = nil
-	}
-
-	result.ActualValue = currentValue
-	result.PreviousValue = previousValue
-
-	// Evaluate condition based on operator
-	switch condition.Operator {
-	case "equals":
-		result.Matched = tp.compareValues(currentValue, condition.Value, "==")
-
-	case "not_equals":
-		result.Matched = !tp.compareValues(currentValue, condition.Value, "==")
-
-	case "changed_from":
-		result.Matched = event.EventType == "on_update" &&
-			tp.compareValues(previousValue, condition.PreviousValue, "==")
-
-	case "changed_to":
-		result.Matched = event.EventType == "on_update" &&
-			tp.compareValues(currentValue, condition.Value, "==") &&
-			!tp.compareValues(previousValue, condition.Value, "==")
-
-	case "greater_than":
-		result.Matched = tp.compareValues(currentValue, condition.Value, ">")
-
-	case "less_than":
-		result.Matched = tp.compareValues(currentValue, condition.Value, "<")
-
-	case "contains":
-		if strVal, ok := currentValue.(string); ok {
-			if searchVal, ok := condition.Value.(string); ok {
-				result.Matched = strings.Contains(strVal, searchVal)
-			}
-		}
-
-	case "in":
-		if values, ok := condition.Value.([]interface{}); ok {
-			for _, v := range values {
-				if tp.compareValues(currentValue, v, "==") {
-					result.Matched = true
-					break
-				}
-			}
-		}
-
-	default:
-		result.Error = fmt.Sprintf("Unknown operator: %s", condition.Operator)
-		result.Matched = false
-	}
-
-	return result
-}
-
-// compareValues compares two values based on the operator
-func (tp *TriggerProcessor) compareValues(a, b interface{}, op string) bool {
-	// Handle nil cases
-	if a == nil || b == nil {
-		if op == "==" {
-			return a == b
-		}
-		return false
-	}
-
-	// Try to convert to comparable types
-	switch op {
-	case "==":
-		return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b)
-	case ">":
-		// Try numeric comparison
-		aFloat, aOk := tp.toFloat64(a)
-		bFloat, bOk := tp.toFloat64(b)
-		if aOk && bOk {
-			return aFloat > bFloat
-		}
-		// Fall back to string comparison
-		return fmt.Sprintf("%v", a) > fmt.Sprintf("%v", b)
-	case "<":
-		aFloat, aOk := tp.toFloat64(a)
-		bFloat, bOk := tp.toFloat64(b)
-		if aOk && bOk {
-			return aFloat < bFloat
-		}
-		return fmt.Sprintf("%v", a) < fmt.Sprintf("%v", b)
-	default:
-		return false
-	}
-}
-
-// toFloat64 attempts to convert a value to float64
-func (tp *TriggerProcessor) toFloat64(v interface{}) (float64, bool) {
-	switch val := v.(type) {
-	case float64:
-		return val, true
-	case float32:
-		return float64(val), true
-	case int:
-		return float64(val), true
-	case int32:
-		return float64(val), true
-	case int64:
-		return float64(val), true
-	case json.Number:
-		f, err := val.Float64()
-		return f, err == nil
-	default:
-		return 0, false
-	}
+	return EvaluateFieldCondition(condition, ConditionData{
+		EventType:    event.EventType,
+		RawData:      event.RawData,
+		FieldChanges: event.FieldChanges,
+		Now:          event.Timestamp,
+	})
 }
 
 // Helper methods
 
 func (tp *TriggerProcessor) isSupportedEventType(eventType string) bool {
-	supportedTypes := []string{"on_create", "on_update", "on_delete", "scheduled"}
+	supportedTypes := []string{EventTypeOnCreate, EventTypeOnUpdate, EventTypeOnDelete, EventTypeScheduled}
 	for _, t := range supportedTypes {
 		if t == eventType {
 			return true
@@ -1,6 +1,7 @@
 package workflow
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -111,3 +112,47 @@ func TestEvaluateFieldCondition_NilRawData(t *testing.T) {
 		t.Error("expected Matched=false when RawData is nil")
 	}
 }
+
+// TestEvaluateRuleConditions_Expression verifies that a trigger's expression is
+// evaluated after its field conditions and that both must match.
+func TestEvaluateRuleConditions_Expression(t *testing.T) {
+	t.Parallel()
+
+	tp := &TriggerProcessor{}
+
+	raw := json.RawMessage(`{
+		"field_conditions": [{"field_name": "status", "operator": "changed_to", "value": "shipped"}],
+		"expression": "any(line_items, .quantity > 10) and $old.status != 'cancelled'"
+	}`)
+	rule := AutomationRuleView{ID: uuid.New(), TriggerConditions: &raw}
+
+	event := TriggerEvent{
+		EventType:  "on_update",
+		EntityName: "orders",
+		EntityID:   uuid.New(),
+		Timestamp:  time.Now(),
+		RawData: map[string]any{
+			"status":     "shipped",
+			"line_items": []any{map[string]any{"quantity": 12}},
+		},
+		FieldChanges: map[string]FieldChange{
+			"status": {OldValue: "packed", NewValue: "shipped"},
+		},
+	}
+
+	results := tp.evaluateRuleConditions(rule, event)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 condition results, got %d", len(results))
+	}
+	for _, r := range results {
+		if !r.Matched || r.Error != "" {
+			t.Errorf("condition %q: matched=%v error=%q, want a match", conditionLabel(r.Condition), r.Matched, r.Error)
+		}
+	}
+
+	event.RawData["line_items"] = []any{map[string]any{"quantity": 2}}
+	results = tp.evaluateRuleConditions(rule, event)
+	if results[1].Matched {
+		t.Error("expression should not match when no line item exceeds the quantity")
+	}
+}
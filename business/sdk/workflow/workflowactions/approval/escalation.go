@@ -0,0 +1,632 @@
+package approval
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/business/domain/core/rolebus"
+	"github.com/timmaaaz/ichor/business/domain/core/userrolebus"
+	"github.com/timmaaaz/ichor/business/domain/hr/reportstobus"
+	"github.com/timmaaaz/ichor/business/domain/workflow/alertbus"
+	"github.com/timmaaaz/ichor/business/domain/workflow/approvalrequestbus"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/temporal"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/communication"
+	"github.com/timmaaaz/ichor/foundation/logger"
+	"github.com/timmaaaz/ichor/foundation/rabbitmq"
+)
+
+// Audit log actions written by the Escalator.
+const (
+	AuditActionEscalated = "approval_escalated"
+	AuditActionTimedOut  = "approval_timed_out"
+)
+
+// ApproverDirectory resolves escalation targets.
+type ApproverDirectory interface {
+	// Managers returns each user's boss from hr.reports_to. Users without a
+	// boss are absent from the map.
+	Managers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]uuid.UUID, error)
+
+	// RoleMembers returns the users holding the named role.
+	RoleMembers(ctx context.Context, roleName string) ([]uuid.UUID, error)
+}
+
+// AuditEntry is one row of workflow.audit_log written by the Escalator.
+type AuditEntry struct {
+	Action      string
+	Message     string
+	ApprovalID  uuid.UUID
+	RuleID      uuid.UUID
+	ExecutionID uuid.UUID
+	Metadata    map[string]any
+}
+
+// AuditRecorder writes escalation and timeout audit entries.
+type AuditRecorder interface {
+	Record(ctx context.Context, entry AuditEntry) error
+}
+
+// ActivityCompleter completes the parked seek_approval Temporal activity.
+// Satisfied by *temporal.AsyncCompleter.
+type ActivityCompleter interface {
+	Complete(ctx context.Context, taskToken []byte, output temporal.ActionActivityOutput) error
+}
+
+// EscalatorConfig holds the Escalator's collaborators and tuning. AlertBus,
+// WorkflowQueue and Completer may be nil; Interval and BatchSize fall back to
+// defaults (1m / 100).
+type EscalatorConfig struct {
+	Log           *logger.Logger
+	ApprovalBus   *approvalrequestbus.Business
+	AlertBus      *alertbus.Business
+	WorkflowQueue *rabbitmq.WorkflowQueue
+	Directory     ApproverDirectory
+	Audit         AuditRecorder
+	Completer     ActivityCompleter
+	Interval      time.Duration
+	BatchSize     int
+}
+
+// Escalator enforces the escalation and timeout policy of pending approval
+// requests. Each tick it:
+//
+//  1. reassigns requests whose escalate_after_hours has lapsed — approvers who
+//     have not voted are replaced by their manager or by the members of the
+//     escalation role, approvers who already voted keep their vote;
+//  2. auto-resolves requests whose timeout_hours has lapsed (status
+//     timed_out) and completes the parked Temporal activity with output
+//     "timed_out", carrying the timeout_action as the resolution.
+//
+// Every escalation and timeout is written to workflow.audit_log and raises an
+// alert. Both transitions are conditional UPDATEs, so several replicas can
+// sweep concurrently; a timed-out request keeps its task token until the
+// Temporal completion succeeds and is retried on later ticks. SERVER-ONLY,
+// started by the composition root next to the relay.
+type Escalator struct {
+	log           *logger.Logger
+	approvalBus   *approvalrequestbus.Business
+	alertBus      *alertbus.Business
+	workflowQueue *rabbitmq.WorkflowQueue
+	directory     ApproverDirectory
+	audit         AuditRecorder
+	completer     ActivityCompleter
+	interval      time.Duration
+	batchSize     int
+	now           func() time.Time
+}
+
+// NewEscalator constructs an Escalator.
+func NewEscalator(cfg EscalatorConfig) *Escalator {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Minute
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+
+	return &Escalator{
+		log:           cfg.Log,
+		approvalBus:   cfg.ApprovalBus,
+		alertBus:      cfg.AlertBus,
+		workflowQueue: cfg.WorkflowQueue,
+		directory:     cfg.Directory,
+		audit:         cfg.Audit,
+		completer:     cfg.Completer,
+		interval:      cfg.Interval,
+		batchSize:     cfg.BatchSize,
+		now:           func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Run ticks every interval until ctx is cancelled. Returns ctx.Err() when stopped.
+func (e *Escalator) Run(ctx context.Context) error {
+	e.log.Info(ctx, "approval escalator starting", "interval", e.interval, "batch_size", e.batchSize)
+
+	t := time.NewTicker(e.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.log.Info(ctx, "approval escalator stopping", "reason", ctx.Err())
+			return ctx.Err()
+		case <-t.C:
+			escalated, timedOut, err := e.tick(ctx)
+			if err != nil {
+				e.log.Error(ctx, "approval escalator: tick failed", "error", err)
+				continue
+			}
+			if escalated > 0 || timedOut > 0 {
+				e.log.Info(ctx, "approval escalator: processed requests", "escalated", escalated, "timed_out", timedOut)
+			}
+		}
+	}
+}
+
+// tick runs one escalation sweep and one timeout sweep. A failure on one
+// request is logged and does not stop the rest of the batch.
+func (e *Escalator) tick(ctx context.Context) (int, int, error) {
+	now := e.now()
+
+	due, err := e.approvalBus.QueryDueEscalations(ctx, now, e.batchSize)
+	if err != nil {
+		return 0, 0, fmt.Errorf("query due escalations: %w", err)
+	}
+
+	escalated := 0
+	for _, req := range due {
+		ok, err := e.escalate(ctx, req)
+		if err != nil {
+			e.log.Error(ctx, "approval escalator: escalation failed", "approval_id", req.ID, "error", err)
+			continue
+		}
+		if ok {
+			escalated++
+		}
+	}
+
+	expired, err := e.approvalBus.QueryDueTimeouts(ctx, now, e.batchSize)
+	if err != nil {
+		return escalated, 0, fmt.Errorf("query due timeouts: %w", err)
+	}
+
+	timedOut := 0
+	for _, req := range expired {
+		ok, err := e.timeOut(ctx, req)
+		if err != nil {
+			e.log.Error(ctx, "approval escalator: timeout failed", "approval_id", req.ID, "error", err)
+			continue
+		}
+		if ok {
+			timedOut++
+		}
+	}
+
+	return escalated, timedOut, nil
+}
+
+// escalate reassigns one request. Approvers who already voted keep their
+// seat; the rest are replaced by the escalation target. When no target can be
+// found the approver set is left unchanged but the request is still marked
+// escalated so it is not retried every tick. Returns false when the request
+// was resolved or escalated elsewhere first.
+func (e *Escalator) escalate(ctx context.Context, req approvalrequestbus.ApprovalRequest) (bool, error) {
+	votes, err := e.approvalBus.QueryVotes(ctx, req.ID)
+	if err != nil {
+		return false, fmt.Errorf("query votes: %w", err)
+	}
+
+	voted := make(map[uuid.UUID]bool, len(votes))
+	for _, v := range votes {
+		voted[v.ApproverID] = true
+	}
+
+	var waiting []uuid.UUID
+	for _, id := range req.Approvers {
+		if !voted[id] {
+			waiting = append(waiting, id)
+		}
+	}
+
+	approvers, err := e.escalationTargets(ctx, req, waiting)
+	if err != nil {
+		return false, err
+	}
+
+	next := make([]uuid.UUID, 0, len(req.Approvers)+len(approvers))
+	seen := make(map[uuid.UUID]bool)
+	add := func(id uuid.UUID) {
+		if !seen[id] {
+			seen[id] = true
+			next = append(next, id)
+		}
+	}
+	for _, id := range req.Approvers {
+		if voted[id] {
+			add(id)
+		}
+	}
+	for _, id := range approvers {
+		add(id)
+	}
+
+	// The quorum was fixed when the request was created, so escalation must
+	// leave at least as many open seats as there were waiting approvers. When
+	// targets collapse (two approvers share a manager, a manager already voted,
+	// a role has fewer members), the waiting approvers keep the seats that
+	// would otherwise be lost.
+	open := len(next) - countVoted(next, voted)
+	for _, id := range waiting {
+		if open >= len(waiting) {
+			break
+		}
+		if !seen[id] {
+			add(id)
+			open++
+		}
+	}
+
+	updated, err := e.approvalBus.Escalate(ctx, req.ID, next)
+	if err != nil {
+		if errors.Is(err, approvalrequestbus.ErrAlreadyResolved) {
+			return false, nil
+		}
+		return false, fmt.Errorf("escalate: %w", err)
+	}
+
+	added := diffApprovers(next, req.Approvers)
+
+	e.record(ctx, AuditEntry{
+		Action:      AuditActionEscalated,
+		Message:     fmt.Sprintf("Approval request escalated to %s after %d hours", escalationLabel(req), req.EscalateAfterHours),
+		ApprovalID:  req.ID,
+		RuleID:      req.RuleID,
+		ExecutionID: req.ExecutionID,
+		Metadata: map[string]any{
+			"escalation_target": req.EscalationTarget,
+			"escalation_role":   req.EscalationRole,
+			"previous":          req.Approvers,
+			"approvers":         next,
+			"added":             added,
+		},
+	})
+
+	if len(added) == 0 {
+		e.log.Info(ctx, "approval escalator: no escalation target found; approvers unchanged",
+			"approval_id", req.ID,
+			"escalation_target", req.EscalationTarget)
+		return true, nil
+	}
+
+	title := fmt.Sprintf("Approval Escalated: %s", req.RuleName)
+	message := req.ApprovalMessage
+	if message == "" {
+		message = fmt.Sprintf("An approval request for workflow '%s' has been escalated to you", req.RuleName)
+	}
+	e.raiseAlert(ctx, updated, "approval_escalated", title, message, added)
+
+	if e.workflowQueue != nil {
+		for _, msg := range buildApprovalRequestMessages(req.ID, req.RuleID, req.ActionName, added) {
+			if err := e.workflowQueue.Publish(ctx, rabbitmq.QueueTypeAlert, msg); err != nil {
+				e.log.Error(ctx, "failed to publish approval_request event", "approval_id", req.ID, "error", err)
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// countVoted returns how many of ids have voted.
+func countVoted(ids []uuid.UUID, voted map[uuid.UUID]bool) int {
+	n := 0
+	for _, id := range ids {
+		if voted[id] {
+			n++
+		}
+	}
+	return n
+}
+
+// escalationTargets returns the users who replace the waiting approvers. A
+// role escalation seats every member of the role; the request's quorum does
+// not grow with it, since the required count was fixed at creation.
+func (e *Escalator) escalationTargets(ctx context.Context, req approvalrequestbus.ApprovalRequest, waiting []uuid.UUID) ([]uuid.UUID, error) {
+	if e.directory == nil || len(waiting) == 0 {
+		return waiting, nil
+	}
+
+	switch req.EscalationTarget {
+	case approvalrequestbus.EscalationTargetRole:
+		members, err := e.directory.RoleMembers(ctx, req.EscalationRole)
+		if err != nil {
+			return nil, fmt.Errorf("role members %q: %w", req.EscalationRole, err)
+		}
+		if len(members) == 0 {
+			return waiting, nil
+		}
+		return members, nil
+
+	default:
+		managers, err := e.directory.Managers(ctx, waiting)
+		if err != nil {
+			return nil, fmt.Errorf("managers: %w", err)
+		}
+		targets := make([]uuid.UUID, len(waiting))
+		for i, id := range waiting {
+			targets[i] = id
+			if boss, ok := managers[id]; ok {
+				targets[i] = boss
+			}
+		}
+		return targets, nil
+	}
+}
+
+// timeOut auto-resolves one request and completes its Temporal activity.
+// Requests already timed out (a previous completion failed) only retry the
+// completion. Returns false when another resolver got there first.
+func (e *Escalator) timeOut(ctx context.Context, req approvalrequestbus.ApprovalRequest) (bool, error) {
+	if req.Status == approvalrequestbus.StatusPending {
+		reason := fmt.Sprintf("No decision within %d hours; auto-%s", req.TimeoutHours, timeoutVerb(req.TimeoutAction))
+
+		updated, err := e.approvalBus.TimeOut(ctx, req.ID, reason)
+		if err != nil {
+			if errors.Is(err, approvalrequestbus.ErrAlreadyResolved) {
+				return false, nil
+			}
+			return false, fmt.Errorf("time out: %w", err)
+		}
+		updated.RuleName = req.RuleName
+		req = updated
+
+		e.record(ctx, AuditEntry{
+			Action:      AuditActionTimedOut,
+			Message:     reason,
+			ApprovalID:  req.ID,
+			RuleID:      req.RuleID,
+			ExecutionID: req.ExecutionID,
+			Metadata: map[string]any{
+				"timeout_hours":  req.TimeoutHours,
+				"timeout_action": req.TimeoutAction,
+				"approvers":      req.Approvers,
+			},
+		})
+
+		title := fmt.Sprintf("Approval Timed Out: %s", req.RuleName)
+		e.raiseAlert(ctx, req, "approval_timed_out", title, reason, req.Approvers)
+	}
+
+	return true, e.complete(ctx, req)
+}
+
+// complete resumes the workflow through the "timed_out" port and clears the
+// task token. Kept token = retried next tick.
+func (e *Escalator) complete(ctx context.Context, req approvalrequestbus.ApprovalRequest) error {
+	if e.completer == nil || req.TaskToken == "" {
+		return nil
+	}
+
+	taskToken, err := base64.StdEncoding.DecodeString(req.TaskToken)
+	if err != nil {
+		return fmt.Errorf("decode task token: %w", err)
+	}
+
+	output := temporal.ActionActivityOutput{
+		ActionName: req.ActionName,
+		Result:     buildTimeoutResult(req),
+		Success:    true,
+	}
+	if err := e.completer.Complete(ctx, taskToken, output); err != nil {
+		return fmt.Errorf("complete activity: %w", err)
+	}
+
+	if err := e.approvalBus.ClearTaskToken(ctx, req.ID); err != nil {
+		return fmt.Errorf("clear task token: %w", err)
+	}
+
+	return nil
+}
+
+// buildTimeoutResult is the activity result for a timed-out request. Output
+// routes the graph to "timed_out"; resolution carries the auto-decision.
+func buildTimeoutResult(req approvalrequestbus.ApprovalRequest) map[string]any {
+	resolution := approvalrequestbus.StatusRejected
+	if req.TimeoutAction == approvalrequestbus.TimeoutActionApprove {
+		resolution = approvalrequestbus.StatusApproved
+	}
+
+	return map[string]any{
+		"output":         approvalrequestbus.StatusTimedOut,
+		"approval_id":    req.ID.String(),
+		"timeout_action": req.TimeoutAction,
+		"resolution":     resolution,
+		"reason":         req.ResolutionReason,
+	}
+}
+
+// raiseAlert creates an alert for the given recipients and pushes it over
+// WebSocket. Best-effort: failures are logged.
+func (e *Escalator) raiseAlert(ctx context.Context, req approvalrequestbus.ApprovalRequest, alertType, title, message string, recipientIDs []uuid.UUID) {
+	if e.alertBus == nil || len(recipientIDs) == 0 {
+		return
+	}
+
+	now := time.Now()
+
+	alertCtx, _ := json.Marshal(map[string]any{"approval_id": req.ID.String()})
+
+	alert := alertbus.Alert{
+		ID:               uuid.New(),
+		AlertType:        alertType,
+		Severity:         alertbus.SeverityHigh,
+		Title:            title,
+		Message:          message,
+		Context:          alertCtx,
+		SourceEntityName: "workflow.approval_requests",
+		SourceEntityID:   req.ID,
+		SourceRuleID:     req.RuleID,
+		Status:           alertbus.StatusActive,
+		CreatedDate:      now,
+		UpdatedDate:      now,
+	}
+
+	if err := e.alertBus.Create(ctx, alert); err != nil {
+		e.log.Error(ctx, "failed to create approval escalation alert", "approval_id", req.ID, "error", err)
+		return
+	}
+
+	recipients := make([]alertbus.AlertRecipient, 0, len(recipientIDs))
+	for _, id := range recipientIDs {
+		recipients = append(recipients, alertbus.AlertRecipient{
+			ID:            uuid.New(),
+			AlertID:       alert.ID,
+			RecipientType: "user",
+			RecipientID:   id,
+			CreatedDate:   now,
+		})
+	}
+
+	if err := e.alertBus.CreateRecipients(ctx, recipients); err != nil {
+		e.log.Error(ctx, "failed to create approval escalation alert recipients", "approval_id", req.ID, "error", err)
+		return
+	}
+
+	communication.PublishAlertToRecipients(ctx, e.workflowQueue, e.log, alert, recipients)
+}
+
+// record writes an audit entry. Best-effort: failures are logged.
+func (e *Escalator) record(ctx context.Context, entry AuditEntry) {
+	if e.audit == nil {
+		return
+	}
+	if err := e.audit.Record(ctx, entry); err != nil {
+		e.log.Error(ctx, "failed to write approval audit entry", "approval_id", entry.ApprovalID, "action", entry.Action, "error", err)
+	}
+}
+
+func escalationLabel(req approvalrequestbus.ApprovalRequest) string {
+	if req.EscalationTarget == approvalrequestbus.EscalationTargetRole {
+		return fmt.Sprintf("role %q", req.EscalationRole)
+	}
+	return "managers"
+}
+
+func timeoutVerb(action string) string {
+	if action == approvalrequestbus.TimeoutActionApprove {
+		return "approved"
+	}
+	return "rejected"
+}
+
+// diffApprovers returns the ids in next that are not in prev.
+func diffApprovers(next, prev []uuid.UUID) []uuid.UUID {
+	had := make(map[uuid.UUID]bool, len(prev))
+	for _, id := range prev {
+		had[id] = true
+	}
+
+	var added []uuid.UUID
+	for _, id := range next {
+		if !had[id] {
+			added = append(added, id)
+		}
+	}
+	return added
+}
+
+// =============================================================================
+// Bus-backed collaborators
+// =============================================================================
+
+// BusDirectory resolves escalation targets from hr.reports_to and core roles.
+type BusDirectory struct {
+	reportsToBus *reportstobus.Business
+	roleBus      *rolebus.Business
+	userRoleBus  *userrolebus.Business
+}
+
+// NewBusDirectory constructs a BusDirectory.
+func NewBusDirectory(reportsToBus *reportstobus.Business, roleBus *rolebus.Business, userRoleBus *userrolebus.Business) *BusDirectory {
+	return &BusDirectory{
+		reportsToBus: reportsToBus,
+		roleBus:      roleBus,
+		userRoleBus:  userRoleBus,
+	}
+}
+
+// Managers implements ApproverDirectory.
+func (d *BusDirectory) Managers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	managers := make(map[uuid.UUID]uuid.UUID, len(userIDs))
+	for _, id := range userIDs {
+		reporterID := id
+		rts, err := d.reportsToBus.Query(ctx, reportstobus.QueryFilter{ReporterID: &reporterID}, reportstobus.DefaultOrderBy, page.MustParse("1", "1"))
+		if err != nil {
+			return nil, fmt.Errorf("query reports_to for %s: %w", id, err)
+		}
+		if len(rts) > 0 {
+			managers[id] = rts[0].BossID
+		}
+	}
+	return managers, nil
+}
+
+// RoleMembers implements ApproverDirectory. Role names match case-insensitively.
+func (d *BusDirectory) RoleMembers(ctx context.Context, roleName string) ([]uuid.UUID, error) {
+	roles, err := d.roleBus.QueryAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query roles: %w", err)
+	}
+
+	var roleID *uuid.UUID
+	for _, r := range roles {
+		if strings.EqualFold(r.Name, roleName) {
+			id := r.ID
+			roleID = &id
+			break
+		}
+	}
+	if roleID == nil {
+		return nil, nil
+	}
+
+	urs, err := d.userRoleBus.Query(ctx, userrolebus.QueryFilter{RoleID: roleID}, userrolebus.DefaultOrderBy, page.MustParse("1", "1000"))
+	if err != nil {
+		return nil, fmt.Errorf("query role members: %w", err)
+	}
+
+	members := make([]uuid.UUID, len(urs))
+	for i, ur := range urs {
+		members[i] = ur.UserID
+	}
+	return members, nil
+}
+
+// DBAuditRecorder writes AuditEntry rows to workflow.audit_log.
+type DBAuditRecorder struct {
+	log *logger.Logger
+	db  *sqlx.DB
+}
+
+// NewDBAuditRecorder constructs a DBAuditRecorder.
+func NewDBAuditRecorder(log *logger.Logger, db *sqlx.DB) *DBAuditRecorder {
+	return &DBAuditRecorder{log: log, db: db}
+}
+
+// Record implements AuditRecorder.
+func (r *DBAuditRecorder) Record(ctx context.Context, entry AuditEntry) error {
+	metadata, err := json.Marshal(entry.Metadata)
+	if err != nil {
+		return fmt.Errorf("marshal metadata: %w", err)
+	}
+
+	const q = `INSERT INTO workflow.audit_log
+		(id, entity_name, entity_id, action, message, metadata, rule_id, execution_id, user_id, created_date)
+		VALUES (:id, :entity_name, :entity_id, :action, :message, :metadata, :rule_id, :execution_id, NULL, :created_date)`
+
+	args := map[string]any{
+		"id":           uuid.New(),
+		"entity_name":  "workflow.approval_requests",
+		"entity_id":    entry.ApprovalID,
+		"action":       entry.Action,
+		"message":      entry.Message,
+		"metadata":     metadata,
+		"rule_id":      uuid.NullUUID{UUID: entry.RuleID, Valid: entry.RuleID != uuid.Nil},
+		"execution_id": entry.ExecutionID,
+		"created_date": time.Now().UTC(),
+	}
+
+	if _, err := sqldb.NamedExecContextWithCount(ctx, r.log, r.db, q, args); err != nil {
+		return fmt.Errorf("audit log insert: %w", err)
+	}
+
+	return nil
+}
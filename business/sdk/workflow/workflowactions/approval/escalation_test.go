@@ -0,0 +1,376 @@
+package approval_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/workflow/approvalrequestbus"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/temporal"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/approval"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// Compile-time assertions for the escalator's production collaborators.
+var (
+	_ approval.ActivityCompleter = (*temporal.AsyncCompleter)(nil)
+	_ approval.ApproverDirectory = (*approval.BusDirectory)(nil)
+	_ approval.AuditRecorder     = (*approval.DBAuditRecorder)(nil)
+)
+
+// escalationStorer holds a single request in memory and applies the same
+// conditional transitions as the real store.
+type escalationStorer struct {
+	noopApprovalStorer
+	req          approvalrequestbus.ApprovalRequest
+	votes        []approvalrequestbus.Vote
+	dueEscalate  bool
+	dueTimeout   bool
+	tokenCleared bool
+}
+
+func (s *escalationStorer) QueryVotes(_ context.Context, _ uuid.UUID) ([]approvalrequestbus.Vote, error) {
+	return s.votes, nil
+}
+
+func (s *escalationStorer) QueryDueEscalations(_ context.Context, _ time.Time, _ int) ([]approvalrequestbus.ApprovalRequest, error) {
+	if !s.dueEscalate || s.req.EscalatedDate != nil || s.req.Status != approvalrequestbus.StatusPending {
+		return nil, nil
+	}
+	return []approvalrequestbus.ApprovalRequest{s.req}, nil
+}
+
+func (s *escalationStorer) QueryDueTimeouts(_ context.Context, _ time.Time, _ int) ([]approvalrequestbus.ApprovalRequest, error) {
+	switch {
+	case s.dueTimeout && s.req.Status == approvalrequestbus.StatusPending:
+	case s.req.Status == approvalrequestbus.StatusTimedOut && s.req.TaskToken != "":
+	default:
+		return nil, nil
+	}
+	return []approvalrequestbus.ApprovalRequest{s.req}, nil
+}
+
+func (s *escalationStorer) Escalate(_ context.Context, _ uuid.UUID, approvers []uuid.UUID, at time.Time) (approvalrequestbus.ApprovalRequest, error) {
+	if s.req.Status != approvalrequestbus.StatusPending || s.req.EscalatedDate != nil {
+		return approvalrequestbus.ApprovalRequest{}, approvalrequestbus.ErrAlreadyResolved
+	}
+	s.req.Approvers = approvers
+	s.req.EscalatedDate = &at
+	return s.req, nil
+}
+
+func (s *escalationStorer) TimeOut(_ context.Context, _ uuid.UUID, reason string) (approvalrequestbus.ApprovalRequest, error) {
+	if s.req.Status != approvalrequestbus.StatusPending {
+		return approvalrequestbus.ApprovalRequest{}, approvalrequestbus.ErrAlreadyResolved
+	}
+	s.req.Status = approvalrequestbus.StatusTimedOut
+	s.req.ResolutionReason = reason
+	return s.req, nil
+}
+
+func (s *escalationStorer) ClearTaskToken(_ context.Context, _ uuid.UUID) error {
+	s.req.TaskToken = ""
+	s.tokenCleared = true
+	return nil
+}
+
+type fakeDirectory struct {
+	managers map[uuid.UUID]uuid.UUID
+	roles    map[string][]uuid.UUID
+}
+
+func (d *fakeDirectory) Managers(_ context.Context, ids []uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	out := make(map[uuid.UUID]uuid.UUID)
+	for _, id := range ids {
+		if boss, ok := d.managers[id]; ok {
+			out[id] = boss
+		}
+	}
+	return out, nil
+}
+
+func (d *fakeDirectory) RoleMembers(_ context.Context, role string) ([]uuid.UUID, error) {
+	return d.roles[role], nil
+}
+
+type fakeAudit struct {
+	entries []approval.AuditEntry
+}
+
+func (a *fakeAudit) Record(_ context.Context, e approval.AuditEntry) error {
+	a.entries = append(a.entries, e)
+	return nil
+}
+
+type fakeCompleter struct {
+	fail    bool
+	outputs []temporal.ActionActivityOutput
+}
+
+func (c *fakeCompleter) Complete(_ context.Context, _ []byte, out temporal.ActionActivityOutput) error {
+	if c.fail {
+		return context.DeadlineExceeded
+	}
+	c.outputs = append(c.outputs, out)
+	return nil
+}
+
+func newTestEscalator(storer *escalationStorer, dir *fakeDirectory, audit *fakeAudit, completer *fakeCompleter) *approval.Escalator {
+	log := logger.New(io.Discard, logger.LevelInfo, "TEST", func(context.Context) string { return "" })
+	return approval.NewEscalator(approval.EscalatorConfig{
+		Log:         log,
+		ApprovalBus: approvalrequestbus.NewBusiness(log, nil, storer),
+		Directory:   dir,
+		Audit:       audit,
+		Completer:   completer,
+	})
+}
+
+// =============================================================================
+
+func Test_Escalator_ManagerKeepsVotedApprovers(t *testing.T) {
+	voted, waiting, boss := uuid.New(), uuid.New(), uuid.New()
+
+	storer := &escalationStorer{
+		req: approvalrequestbus.ApprovalRequest{
+			ID:                 uuid.New(),
+			Approvers:          []uuid.UUID{voted, waiting},
+			ApprovalType:       approvalrequestbus.ApprovalTypeAll,
+			Status:             approvalrequestbus.StatusPending,
+			EscalateAfterHours: 24,
+			EscalationTarget:   approvalrequestbus.EscalationTargetManager,
+		},
+		votes:       []approvalrequestbus.Vote{{ApproverID: voted, Decision: approvalrequestbus.StatusApproved}},
+		dueEscalate: true,
+	}
+	audit := &fakeAudit{}
+
+	esc := newTestEscalator(storer, &fakeDirectory{managers: map[uuid.UUID]uuid.UUID{waiting: boss}}, audit, &fakeCompleter{})
+
+	escalated, _, err := esc.Tick(context.Background())
+	if err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	if escalated != 1 {
+		t.Fatalf("escalated = %d, want 1", escalated)
+	}
+
+	got := storer.req.Approvers
+	if len(got) != 2 || got[0] != voted || got[1] != boss {
+		t.Fatalf("approvers = %v, want [voted boss]", got)
+	}
+	if len(audit.entries) != 1 || audit.entries[0].Action != approval.AuditActionEscalated {
+		t.Fatalf("audit = %+v, want one escalation entry", audit.entries)
+	}
+
+	// Escalation happens once.
+	if escalated, _, _ := esc.Tick(context.Background()); escalated != 0 {
+		t.Fatalf("second tick escalated = %d, want 0", escalated)
+	}
+}
+
+func Test_Escalator_RoleReplacesWaitingApprovers(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	lead1, lead2 := uuid.New(), uuid.New()
+
+	storer := &escalationStorer{
+		req: approvalrequestbus.ApprovalRequest{
+			ID:                 uuid.New(),
+			Approvers:          []uuid.UUID{a, b},
+			ApprovalType:       approvalrequestbus.ApprovalTypeAny,
+			Status:             approvalrequestbus.StatusPending,
+			EscalateAfterHours: 4,
+			EscalationTarget:   approvalrequestbus.EscalationTargetRole,
+			EscalationRole:     "FLOOR_LEAD",
+		},
+		dueEscalate: true,
+	}
+
+	esc := newTestEscalator(storer, &fakeDirectory{roles: map[string][]uuid.UUID{"FLOOR_LEAD": {lead1, lead2}}}, &fakeAudit{}, &fakeCompleter{})
+
+	if _, _, err := esc.Tick(context.Background()); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+
+	got := storer.req.Approvers
+	if len(got) != 2 || got[0] != lead1 || got[1] != lead2 {
+		t.Fatalf("approvers = %v, want the role members", got)
+	}
+}
+
+func Test_Escalator_RoleKeepsQuorum(t *testing.T) {
+	voted, waiting := uuid.New(), uuid.New()
+	role := make([]uuid.UUID, 10)
+	for i := range role {
+		role[i] = uuid.New()
+	}
+
+	approvers := []uuid.UUID{voted, waiting}
+	storer := &escalationStorer{
+		req: approvalrequestbus.ApprovalRequest{
+			ID:                 uuid.New(),
+			Approvers:          approvers,
+			ApprovalType:       approvalrequestbus.ApprovalTypeAll,
+			Required:           approvalrequestbus.RequiredApprovals(approvalrequestbus.ApprovalTypeAll, approvers),
+			Status:             approvalrequestbus.StatusPending,
+			EscalateAfterHours: 4,
+			EscalationTarget:   approvalrequestbus.EscalationTargetRole,
+			EscalationRole:     "FINANCE",
+		},
+		votes:       []approvalrequestbus.Vote{{ApproverID: voted, Decision: approvalrequestbus.StatusApproved}},
+		dueEscalate: true,
+	}
+
+	esc := newTestEscalator(storer, &fakeDirectory{roles: map[string][]uuid.UUID{"FINANCE": role}}, &fakeAudit{}, &fakeCompleter{})
+
+	if _, _, err := esc.Tick(context.Background()); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+
+	if got := len(storer.req.Approvers); got != 11 {
+		t.Fatalf("approvers = %d, want the voted approver and 10 role members", got)
+	}
+
+	// One role member fills the waiting seat: two approvals, not eleven.
+	votes := append(storer.votes, approvalrequestbus.Vote{ApproverID: role[3], Decision: approvalrequestbus.StatusApproved})
+	tally := approvalrequestbus.EvaluateQuorum(storer.req.ApprovalType, storer.req.Required, storer.req.Approvers, votes)
+	if tally.Required != 2 || tally.Outcome != approvalrequestbus.StatusApproved {
+		t.Fatalf("tally = %+v, want approved with 2 required", tally)
+	}
+}
+
+func Test_Escalator_SharedManagerKeepsSeats(t *testing.T) {
+	a, b, boss := uuid.New(), uuid.New(), uuid.New()
+
+	approvers := []uuid.UUID{a, b}
+	storer := &escalationStorer{
+		req: approvalrequestbus.ApprovalRequest{
+			ID:                 uuid.New(),
+			Approvers:          approvers,
+			ApprovalType:       approvalrequestbus.ApprovalTypeAll,
+			Required:           approvalrequestbus.RequiredApprovals(approvalrequestbus.ApprovalTypeAll, approvers),
+			Status:             approvalrequestbus.StatusPending,
+			EscalateAfterHours: 24,
+			EscalationTarget:   approvalrequestbus.EscalationTargetManager,
+		},
+		dueEscalate: true,
+	}
+
+	dir := &fakeDirectory{managers: map[uuid.UUID]uuid.UUID{a: boss, b: boss}}
+	esc := newTestEscalator(storer, dir, &fakeAudit{}, &fakeCompleter{})
+
+	if _, _, err := esc.Tick(context.Background()); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+
+	got := storer.req.Approvers
+	if len(got) != 2 || got[0] != boss || got[1] != a {
+		t.Fatalf("approvers = %v, want [boss a]: the second seat stays with a waiting approver", got)
+	}
+
+	// The manager alone cannot complete a two-person sign-off.
+	votes := []approvalrequestbus.Vote{{ApproverID: boss, Decision: approvalrequestbus.StatusApproved}}
+	tally := approvalrequestbus.EvaluateQuorum(storer.req.ApprovalType, storer.req.Required, storer.req.Approvers, votes)
+	if tally.Required != 2 || tally.Outcome != approvalrequestbus.StatusPending {
+		t.Fatalf("tally = %+v, want pending with 2 required", tally)
+	}
+
+	votes = append(votes, approvalrequestbus.Vote{ApproverID: a, Decision: approvalrequestbus.StatusApproved})
+	tally = approvalrequestbus.EvaluateQuorum(storer.req.ApprovalType, storer.req.Required, storer.req.Approvers, votes)
+	if tally.Outcome != approvalrequestbus.StatusApproved {
+		t.Fatalf("tally = %+v, want approved", tally)
+	}
+}
+
+func Test_Escalator_TimeoutCompletesThroughTimedOutPort(t *testing.T) {
+	storer := &escalationStorer{
+		req: approvalrequestbus.ApprovalRequest{
+			ID:            uuid.New(),
+			ActionName:    "manager_sign_off",
+			Approvers:     []uuid.UUID{uuid.New()},
+			Status:        approvalrequestbus.StatusPending,
+			TimeoutHours:  48,
+			TimeoutAction: approvalrequestbus.TimeoutActionApprove,
+			TaskToken:     base64.StdEncoding.EncodeToString([]byte("token")),
+		},
+		dueTimeout: true,
+	}
+	audit := &fakeAudit{}
+	completer := &fakeCompleter{fail: true}
+
+	esc := newTestEscalator(storer, &fakeDirectory{}, audit, completer)
+
+	// First tick: the request times out but Temporal is unreachable, so the
+	// token is kept for a retry.
+	if _, _, err := esc.Tick(context.Background()); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	if storer.req.Status != approvalrequestbus.StatusTimedOut {
+		t.Fatalf("status = %q, want timed_out", storer.req.Status)
+	}
+	if storer.tokenCleared {
+		t.Fatal("token must be kept when completion fails")
+	}
+	if len(audit.entries) != 1 || audit.entries[0].Action != approval.AuditActionTimedOut {
+		t.Fatalf("audit = %+v, want one timeout entry", audit.entries)
+	}
+
+	// Second tick: completion succeeds; no second audit entry.
+	completer.fail = false
+	_, timedOut, err := esc.Tick(context.Background())
+	if err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	if timedOut != 1 || !storer.tokenCleared {
+		t.Fatalf("retry: timed_out=%d cleared=%v", timedOut, storer.tokenCleared)
+	}
+	if len(audit.entries) != 1 {
+		t.Fatalf("retry must not re-audit, got %d entries", len(audit.entries))
+	}
+
+	res := completer.outputs[0].Result
+	if res["output"] != approvalrequestbus.StatusTimedOut {
+		t.Errorf("output = %v, want timed_out", res["output"])
+	}
+	if res["resolution"] != approvalrequestbus.StatusApproved {
+		t.Errorf("resolution = %v, want approved", res["resolution"])
+	}
+}
+
+func Test_SeekApprovalHandler_ValidatePolicy(t *testing.T) {
+	handler := approval.NewSeekApprovalHandler(logger.New(io.Discard, logger.LevelInfo, "TEST", nil), nil, nil, nil, nil)
+
+	tests := []struct {
+		name    string
+		policy  map[string]any
+		wantErr bool
+	}{
+		{"no policy", map[string]any{}, false},
+		{"manager escalation", map[string]any{"timeout_hours": 48, "escalation": map[string]any{"after_hours": 24, "to": "manager"}}, false},
+		{"role escalation", map[string]any{"escalation": map[string]any{"after_hours": 8, "to": "role", "role": "FLOOR_LEAD"}, "timeout_action": "approve"}, false},
+		{"role without name", map[string]any{"escalation": map[string]any{"after_hours": 8, "to": "role"}}, true},
+		{"escalation after timeout", map[string]any{"timeout_hours": 12, "escalation": map[string]any{"after_hours": 12, "to": "manager"}}, true},
+		{"unknown target", map[string]any{"escalation": map[string]any{"after_hours": 8, "to": "ceo"}}, true},
+		{"bad timeout action", map[string]any{"timeout_action": "ignore"}, true},
+		{"timeout beyond activity limit", map[string]any{"timeout_hours": 200}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := map[string]any{"approvers": []string{uuid.NewString()}, "approval_type": "any"}
+			for k, v := range tt.policy {
+				cfg[k] = v
+			}
+			raw, _ := json.Marshal(cfg)
+
+			err := handler.Validate(raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
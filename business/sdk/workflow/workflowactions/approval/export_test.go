@@ -0,0 +1,8 @@
+package approval
+
+import "context"
+
+// Tick exposes one escalation/timeout sweep to the external test package.
+func (e *Escalator) Tick(ctx context.Context) (int, int, error) {
+	return e.tick(ctx)
+}
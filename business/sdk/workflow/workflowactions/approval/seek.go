@@ -17,12 +17,92 @@ import (
 	"github.com/timmaaaz/ichor/foundation/rabbitmq"
 )
 
+// defaultTimeoutHours applies when timeout_hours is omitted.
+const defaultTimeoutHours = 72
+
+// maxTimeoutHours caps timeout_hours at the human-action activity's
+// StartToCloseTimeout (7 days): past that Temporal fails the activity before
+// the escalator can route it to "timed_out".
+const maxTimeoutHours = 7 * 24
+
 // seekApprovalConfig represents the configuration for a seek_approval action.
 type seekApprovalConfig struct {
-	Approvers       []string `json:"approvers"`
-	ApprovalType    string   `json:"approval_type"`
-	TimeoutHours    int      `json:"timeout_hours"`
-	ApprovalMessage string   `json:"approval_message"`
+	Approvers       []string          `json:"approvers"`
+	ApprovalType    string            `json:"approval_type"`
+	TimeoutHours    int               `json:"timeout_hours"`
+	ApprovalMessage string            `json:"approval_message"`
+	Escalation      *escalationConfig `json:"escalation,omitempty"`
+	TimeoutAction   string            `json:"timeout_action,omitempty"` // "approve" | "reject" (default)
+}
+
+// escalationConfig reassigns a still-pending request after AfterHours, either
+// to each approver's manager (hr.reports_to) or to every member of Role.
+type escalationConfig struct {
+	AfterHours int    `json:"after_hours"`
+	To         string `json:"to"` // "manager" | "role"
+	Role       string `json:"role,omitempty"`
+}
+
+// timeoutHours returns the configured timeout or the default.
+func (c seekApprovalConfig) timeoutHours() int {
+	if c.TimeoutHours <= 0 {
+		return defaultTimeoutHours
+	}
+	return c.TimeoutHours
+}
+
+// newApprovalRequest maps the config onto a NewApprovalRequest; the caller
+// fills in the execution fields and task token.
+func (c seekApprovalConfig) newApprovalRequest(approvers []uuid.UUID) approvalrequestbus.NewApprovalRequest {
+	nar := approvalrequestbus.NewApprovalRequest{
+		Approvers:       approvers,
+		ApprovalType:    c.ApprovalType,
+		TimeoutHours:    c.timeoutHours(),
+		ApprovalMessage: c.ApprovalMessage,
+		TimeoutAction:   c.TimeoutAction,
+	}
+
+	if c.Escalation != nil {
+		nar.EscalateAfterHours = c.Escalation.AfterHours
+		nar.EscalationTarget = c.Escalation.To
+		nar.EscalationRole = c.Escalation.Role
+	}
+
+	return nar
+}
+
+// validatePolicy checks the timeout and escalation settings.
+func (c seekApprovalConfig) validatePolicy() error {
+	if c.TimeoutHours < 0 || c.TimeoutHours > maxTimeoutHours {
+		return fmt.Errorf("timeout_hours must be between 1 and %d", maxTimeoutHours)
+	}
+
+	switch c.TimeoutAction {
+	case "", approvalrequestbus.TimeoutActionApprove, approvalrequestbus.TimeoutActionReject:
+	default:
+		return fmt.Errorf("invalid timeout_action %q, must be: approve or reject", c.TimeoutAction)
+	}
+
+	if c.Escalation == nil {
+		return nil
+	}
+
+	esc := c.Escalation
+	if esc.AfterHours <= 0 || esc.AfterHours >= c.timeoutHours() {
+		return fmt.Errorf("escalation.after_hours must be positive and less than timeout_hours (%d)", c.timeoutHours())
+	}
+
+	switch esc.To {
+	case approvalrequestbus.EscalationTargetManager:
+	case approvalrequestbus.EscalationTargetRole:
+		if esc.Role == "" {
+			return fmt.Errorf("escalation.role is required when escalation.to is \"role\"")
+		}
+	default:
+		return fmt.Errorf("invalid escalation.to %q, must be: manager or role", esc.To)
+	}
+
+	return nil
 }
 
 // SeekApprovalHandler handles seek_approval actions.
@@ -83,7 +163,7 @@ func (h *SeekApprovalHandler) Validate(config json.RawMessage) error {
 		return fmt.Errorf("invalid approval_type, must be: any, all, or majority")
 	}
 
-	return nil
+	return cfg.validatePolicy()
 }
 
 // GetOutputPorts implements workflow.OutputPortProvider.
@@ -91,7 +171,7 @@ func (h *SeekApprovalHandler) GetOutputPorts() []workflow.OutputPort {
 	return []workflow.OutputPort{
 		{Name: "approved", Description: "Approval was granted", IsDefault: true},
 		{Name: "rejected", Description: "Approval was denied"},
-		{Name: "timed_out", Description: "Approval request timed out"},
+		{Name: "timed_out", Description: "Approval request timed out and was auto-resolved per timeout_action"},
 	}
 }
 
@@ -118,18 +198,6 @@ func (h *SeekApprovalHandler) StartAsync(ctx context.Context, config json.RawMes
 		approvers[i] = id
 	}
 
-	// Log warning for unimplemented approval types.
-	if cfg.ApprovalType != approvalrequestbus.ApprovalTypeAny {
-		h.log.Warn(ctx, "approval_type not fully implemented, treating as 'any'",
-			"approval_type", cfg.ApprovalType)
-	}
-
-	// Default timeout.
-	timeoutHours := cfg.TimeoutHours
-	if timeoutHours <= 0 {
-		timeoutHours = 72
-	}
-
 	// Determine rule ID.
 	ruleID := uuid.Nil
 	if execCtx.RuleID != nil {
@@ -137,16 +205,13 @@ func (h *SeekApprovalHandler) StartAsync(ctx context.Context, config json.RawMes
 	}
 
 	// Create approval request with base64-encoded task token.
-	req, err := h.approvalRequestBus.Create(ctx, approvalrequestbus.NewApprovalRequest{
-		ExecutionID:     execCtx.ExecutionID,
-		RuleID:          ruleID,
-		ActionName:      execCtx.ActionName,
-		Approvers:       approvers,
-		ApprovalType:    cfg.ApprovalType,
-		TimeoutHours:    timeoutHours,
-		TaskToken:       base64.StdEncoding.EncodeToString(taskToken),
-		ApprovalMessage: cfg.ApprovalMessage,
-	})
+	nar := cfg.newApprovalRequest(approvers)
+	nar.ExecutionID = execCtx.ExecutionID
+	nar.RuleID = ruleID
+	nar.ActionName = execCtx.ActionName
+	nar.TaskToken = base64.StdEncoding.EncodeToString(taskToken)
+
+	req, err := h.approvalRequestBus.Create(ctx, nar)
 	if err != nil {
 		return fmt.Errorf("create approval request: %w", err)
 	}
@@ -158,6 +223,7 @@ func (h *SeekApprovalHandler) StartAsync(ctx context.Context, config json.RawMes
 		"approval_request_id", req.ID,
 		"execution_id", execCtx.ExecutionID,
 		"approvers", len(approvers),
+		"approval_type", cfg.ApprovalType,
 		"rule_name", execCtx.RuleName)
 
 	return nil
@@ -187,27 +253,18 @@ func (h *SeekApprovalHandler) Execute(ctx context.Context, config json.RawMessag
 		approvers[i] = id
 	}
 
-	timeoutHours := cfg.TimeoutHours
-	if timeoutHours <= 0 {
-		timeoutHours = 72
-	}
-
 	ruleID := uuid.Nil
 	if execCtx.RuleID != nil {
 		ruleID = *execCtx.RuleID
 	}
 
 	// Create approval request with empty task token (manual execution, no Temporal).
-	req, err := h.approvalRequestBus.Create(ctx, approvalrequestbus.NewApprovalRequest{
-		ExecutionID:     execCtx.ExecutionID,
-		RuleID:          ruleID,
-		ActionName:      execCtx.ActionName,
-		Approvers:       approvers,
-		ApprovalType:    cfg.ApprovalType,
-		TimeoutHours:    timeoutHours,
-		TaskToken:       "",
-		ApprovalMessage: cfg.ApprovalMessage,
-	})
+	nar := cfg.newApprovalRequest(approvers)
+	nar.ExecutionID = execCtx.ExecutionID
+	nar.RuleID = ruleID
+	nar.ActionName = execCtx.ActionName
+
+	req, err := h.approvalRequestBus.Create(ctx, nar)
 	if err != nil {
 		return nil, fmt.Errorf("create approval request: %w", err)
 	}
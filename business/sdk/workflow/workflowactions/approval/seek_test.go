@@ -647,6 +647,24 @@ func (s *noopApprovalStorer) IsApprover(_ context.Context, _, _ uuid.UUID) (bool
 	return false, nil
 }
 func (s *noopApprovalStorer) ClearTaskToken(_ context.Context, _ uuid.UUID) error { return nil }
+func (s *noopApprovalStorer) CreateVote(_ context.Context, _ approvalrequestbus.Vote) error {
+	return nil
+}
+func (s *noopApprovalStorer) QueryVotes(_ context.Context, _ uuid.UUID) ([]approvalrequestbus.Vote, error) {
+	return nil, nil
+}
+func (s *noopApprovalStorer) QueryDueEscalations(_ context.Context, _ time.Time, _ int) ([]approvalrequestbus.ApprovalRequest, error) {
+	return nil, nil
+}
+func (s *noopApprovalStorer) QueryDueTimeouts(_ context.Context, _ time.Time, _ int) ([]approvalrequestbus.ApprovalRequest, error) {
+	return nil, nil
+}
+func (s *noopApprovalStorer) Escalate(_ context.Context, _ uuid.UUID, _ []uuid.UUID, _ time.Time) (approvalrequestbus.ApprovalRequest, error) {
+	return approvalrequestbus.ApprovalRequest{}, nil
+}
+func (s *noopApprovalStorer) TimeOut(_ context.Context, _ uuid.UUID, _ string) (approvalrequestbus.ApprovalRequest, error) {
+	return approvalrequestbus.ApprovalRequest{}, nil
+}
 
 // noopAlertStorer satisfies alertbus.Storer for unit tests.
 type noopAlertStorer struct{}
@@ -248,6 +248,58 @@ func (h *CreateAlertHandler) Execute(ctx context.Context, config json.RawMessage
 	}, nil
 }
 
+// Plan implements workflow.ActionPlanner. It renders the alert Execute would
+// create without creating it or resolving prior alerts. Recipients are reported
+// as "user:<id>" and "role:<id>".
+func (h *CreateAlertHandler) Plan(ctx context.Context, config json.RawMessage, execCtx workflow.ActionExecutionContext) (workflow.ActionPlan, error) {
+	var cfg AlertConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return workflow.ActionPlan{}, fmt.Errorf("invalid config: %w", err)
+	}
+
+	severity := cfg.Severity
+	if severity == "" {
+		severity = alertbus.SeverityMedium
+	}
+
+	tmplData := buildAlertTemplateData(execCtx.RawData, execCtx.ExecutionID, execCtx.RuleID)
+	h.resolveEntityLabels(ctx, tmplData, cfg.Title, cfg.Message, cfg.ActionURL)
+
+	recipients := make([]string, 0, len(cfg.Recipients.Users)+len(cfg.Recipients.Roles))
+	for _, u := range cfg.Recipients.Users {
+		if _, err := uuid.Parse(u); err != nil {
+			return workflow.ActionPlan{}, fmt.Errorf("invalid user UUID %q: %w", u, err)
+		}
+		recipients = append(recipients, "user:"+u)
+	}
+	for _, r := range cfg.Recipients.Roles {
+		if _, err := uuid.Parse(r); err != nil {
+			return workflow.ActionPlan{}, fmt.Errorf("invalid role UUID %q: %w", r, err)
+		}
+		recipients = append(recipients, "role:"+r)
+	}
+
+	plan := workflow.ActionPlan{
+		Output: "success",
+		Result: map[string]any{
+			"status":         "created",
+			"resolved_count": 0,
+		},
+		Effects: []workflow.PlannedEffect{{
+			Kind:       workflow.EffectAlert,
+			Recipients: recipients,
+			Subject:    resolveTemplateVars(cfg.Title, tmplData),
+			Message:    resolveTemplateVars(cfg.Message, tmplData),
+			Severity:   severity,
+		}},
+	}
+	if cfg.ResolvePrior {
+		plan.Note = fmt.Sprintf("would resolve prior %q alerts for the entity", cfg.AlertType)
+	}
+
+	return plan, nil
+}
+
 // buildAlertTemplateData returns a copy of rawData with execution_id and
 // rule_id added, so {{execution_id}} / {{rule_id}} resolve in alert templates.
 // It never mutates the caller's map.
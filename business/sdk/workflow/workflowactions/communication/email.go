@@ -3,6 +3,7 @@ package communication
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -135,3 +136,44 @@ func (h *SendEmailHandler) Execute(ctx context.Context, config json.RawMessage,
 		"subject":    subject,
 	}, nil
 }
+
+// Plan implements workflow.ActionPlanner. It renders the email Execute would
+// send without sending it.
+func (h *SendEmailHandler) Plan(ctx context.Context, config json.RawMessage, execCtx workflow.ActionExecutionContext) (workflow.ActionPlan, error) {
+	var cfg struct {
+		Recipients      []string `json:"recipients"`
+		Subject         string   `json:"subject"`
+		Body            string   `json:"body"`
+		SimulateFailure bool     `json:"simulate_failure,omitempty"`
+		FailureMessage  string   `json:"failure_message,omitempty"`
+	}
+
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return workflow.ActionPlan{}, fmt.Errorf("failed to parse email configuration: %w", err)
+	}
+
+	if cfg.SimulateFailure {
+		failureMsg := cfg.FailureMessage
+		if failureMsg == "" {
+			failureMsg = "simulated email delivery failure"
+		}
+		return workflow.ActionPlan{}, errors.New(failureMsg)
+	}
+
+	subject := resolveTemplateVars(cfg.Subject, execCtx.RawData)
+
+	return workflow.ActionPlan{
+		Output: "success",
+		Result: map[string]any{
+			"status":     "sent",
+			"recipients": cfg.Recipients,
+			"subject":    subject,
+		},
+		Effects: []workflow.PlannedEffect{{
+			Kind:       workflow.EffectEmail,
+			Recipients: cfg.Recipients,
+			Subject:    subject,
+			Message:    resolveTemplateVars(cfg.Body, execCtx.RawData),
+		}},
+	}, nil
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
 	"github.com/timmaaaz/ichor/business/sdk/dbtest"
 	"github.com/timmaaaz/ichor/business/sdk/unitest"
@@ -26,6 +27,7 @@ func Test_SendEmailHandler(t *testing.T) {
 
 	unitest.Run(t, emailValidateTests(sd), "validate")
 	unitest.Run(t, emailExecuteTests(sd), "execute")
+	unitest.Run(t, emailPlanTests(sd), "plan")
 }
 
 // =============================================================================
@@ -384,3 +386,83 @@ func emailExecuteClientError(sd emailSeedData) unitest.Table {
 		},
 	}
 }
+
+// =============================================================================
+// Plan tests
+
+func emailPlanTests(sd emailSeedData) []unitest.Table {
+	return []unitest.Table{
+		emailPlanRendersWithoutSending(sd),
+		emailPlanSimulateFailure(sd),
+	}
+}
+
+func emailPlanRendersWithoutSending(sd emailSeedData) unitest.Table {
+	return unitest.Table{
+		Name: "renders_without_sending",
+		ExpResp: workflow.ActionPlan{
+			Output: "success",
+			Result: map[string]any{
+				"status":     "sent",
+				"recipients": []string{"buyer@example.com"},
+				"subject":    "Order ORD-999 Ready",
+			},
+			Effects: []workflow.PlannedEffect{{
+				Kind:       workflow.EffectEmail,
+				Recipients: []string{"buyer@example.com"},
+				Subject:    "Order ORD-999 Ready",
+				Message:    "Order ORD-999 is ready",
+			}},
+		},
+		ExcFunc: func(ctx context.Context) any {
+			sd.Mock.Reset()
+
+			config := json.RawMessage(`{
+				"recipients": ["buyer@example.com"],
+				"subject": "Order {{order_number}} Ready",
+				"body": "Order {{order_number}} is ready"
+			}`)
+
+			execCtx := workflow.ActionExecutionContext{
+				EntityID:      uuid.New(),
+				RuleName:      "Order Ready",
+				TriggerSource: workflow.TriggerSourceSimulation,
+				RawData:       map[string]interface{}{"order_number": "ORD-999"},
+			}
+
+			plan, err := sd.Handler.Plan(ctx, config, execCtx)
+			if err != nil {
+				return err
+			}
+			if sd.Mock.CallCount() != 0 {
+				return fmt.Sprintf("expected no Send call, got %d", sd.Mock.CallCount())
+			}
+			return plan
+		},
+		CmpFunc: func(got, exp any) string {
+			return cmp.Diff(got, exp)
+		},
+	}
+}
+
+func emailPlanSimulateFailure(sd emailSeedData) unitest.Table {
+	return unitest.Table{
+		Name:    "simulate_failure",
+		ExpResp: "SMTP server unavailable",
+		ExcFunc: func(ctx context.Context) any {
+			config := json.RawMessage(`{"simulate_failure": true, "failure_message": "SMTP server unavailable"}`)
+
+			_, err := sd.Handler.Plan(ctx, config, workflow.ActionExecutionContext{})
+			if err == nil {
+				return nil
+			}
+			return err.Error()
+		},
+		CmpFunc: func(got, exp any) string {
+			if got != exp {
+				return fmt.Sprintf("got %v, want %v", got, exp)
+			}
+			return ""
+		},
+	}
+}
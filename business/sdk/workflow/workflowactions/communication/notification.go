@@ -182,3 +182,43 @@ func (h *SendNotificationHandler) Execute(ctx context.Context, config json.RawMe
 		"recipients":      len(recipients),
 	}, nil
 }
+
+// Plan implements workflow.ActionPlanner. It renders the notification Execute
+// would create without creating it.
+func (h *SendNotificationHandler) Plan(ctx context.Context, config json.RawMessage, execCtx workflow.ActionExecutionContext) (workflow.ActionPlan, error) {
+	var cfg struct {
+		Recipients []string `json:"recipients"`
+		Priority   string   `json:"priority"`
+		Message    string   `json:"message"`
+		Title      string   `json:"title,omitempty"`
+	}
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return workflow.ActionPlan{}, fmt.Errorf("failed to parse notification config: %w", err)
+	}
+
+	for _, u := range cfg.Recipients {
+		if _, err := uuid.Parse(u); err != nil {
+			return workflow.ActionPlan{}, fmt.Errorf("invalid recipient UUID %q: %w", u, err)
+		}
+	}
+
+	severity := cfg.Priority
+	if severity == "" {
+		severity = alertbus.SeverityLow
+	}
+
+	return workflow.ActionPlan{
+		Output: "success",
+		Result: map[string]any{
+			"status":     "sent",
+			"recipients": len(cfg.Recipients),
+		},
+		Effects: []workflow.PlannedEffect{{
+			Kind:       workflow.EffectNotification,
+			Recipients: cfg.Recipients,
+			Subject:    resolveTemplateVars(cfg.Title, execCtx.RawData),
+			Message:    resolveTemplateVars(cfg.Message, execCtx.RawData),
+			Severity:   severity,
+		}},
+	}, nil
+}
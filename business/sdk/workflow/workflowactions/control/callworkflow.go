@@ -0,0 +1,139 @@
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// reservedCallInputs are trigger fields the called rule inherits from its
+// caller. An input may not shadow them.
+var reservedCallInputs = map[string]bool{
+	"event_type":    true,
+	"entity_name":   true,
+	"entity_id":     true,
+	"user_id":       true,
+	"timestamp":     true,
+	"field_changes": true,
+}
+
+// reservedCallOutputs are fields every call_workflow result carries. An
+// output may not shadow them.
+var reservedCallOutputs = map[string]bool{
+	"output":      true,
+	"error":       true,
+	"rule_id":     true,
+	"rule_name":   true,
+	"revision_id": true,
+}
+
+// CallWorkflowConfig represents configuration for call_workflow actions.
+//
+// RuleID names the rule whose graph runs; it need not be active, so a rule
+// kept inactive serves as a reusable fragment. Inputs become the called rule's
+// trigger data and may use templates against the caller's context; a value
+// that is a single reference such as "{{lookup.lines}}" keeps its type.
+// Outputs maps a name to a template resolved against the called rule's final
+// context, read by later actions as {{<action_name>.<name>}}.
+type CallWorkflowConfig struct {
+	RuleID  string            `json:"rule_id"`
+	Inputs  map[string]any    `json:"inputs"`
+	Outputs map[string]string `json:"outputs"`
+}
+
+// CallWorkflowHandler handles call_workflow actions. The call itself is made
+// at the Temporal workflow level: the called rule's graph runs as a child
+// workflow and execution continues from "completed" or "failure" depending on
+// how it ended. This handler only provides validation, output ports and a
+// fallback Execute.
+type CallWorkflowHandler struct {
+	log *logger.Logger
+}
+
+// NewCallWorkflowHandler creates a new call_workflow handler.
+func NewCallWorkflowHandler(log *logger.Logger) *CallWorkflowHandler {
+	return &CallWorkflowHandler{log: log}
+}
+
+// GetType returns the action type.
+func (h *CallWorkflowHandler) GetType() string {
+	return workflow.CallWorkflowActionType
+}
+
+// SupportsManualExecution returns false - the call runs another rule's graph.
+func (h *CallWorkflowHandler) SupportsManualExecution() bool {
+	return false
+}
+
+// IsAsync returns false - call_workflow is handled at the workflow level, not the activity level.
+func (h *CallWorkflowHandler) IsAsync() bool {
+	return false
+}
+
+// GetDescription returns a human-readable description.
+func (h *CallWorkflowHandler) GetDescription() string {
+	return "Run another automation rule's actions as a sub-workflow, passing inputs and reading back outputs"
+}
+
+// GetOutputPorts implements workflow.OutputPortProvider.
+func (h *CallWorkflowHandler) GetOutputPorts() []workflow.OutputPort {
+	return []workflow.OutputPort{
+		{Name: "completed", Description: "The called rule finished; its outputs are in context", IsDefault: true},
+		{Name: "failure", Description: "The called rule failed"},
+	}
+}
+
+// Validate validates the call_workflow configuration.
+func (h *CallWorkflowHandler) Validate(config json.RawMessage) error {
+	var cfg CallWorkflowConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return fmt.Errorf("invalid configuration format: %w", err)
+	}
+
+	if cfg.RuleID == "" {
+		return errors.New("rule_id is required")
+	}
+	if id, err := uuid.Parse(cfg.RuleID); err != nil || id == uuid.Nil {
+		return fmt.Errorf("rule_id must be a valid UUID, got %q", cfg.RuleID)
+	}
+
+	for name := range cfg.Inputs {
+		if !itemNamePattern.MatchString(name) {
+			return fmt.Errorf("input %q must start with a letter and contain only letters, digits and underscores", name)
+		}
+		if reservedCallInputs[name] {
+			return fmt.Errorf("input %q is reserved: the called rule inherits it from the caller", name)
+		}
+	}
+
+	for name, tmpl := range cfg.Outputs {
+		if !itemNamePattern.MatchString(name) {
+			return fmt.Errorf("output %q must start with a letter and contain only letters, digits and underscores", name)
+		}
+		if reservedCallOutputs[name] {
+			return fmt.Errorf("output %q is reserved", name)
+		}
+		if strings.TrimSpace(tmpl) == "" {
+			return fmt.Errorf("output %q needs a template", name)
+		}
+	}
+
+	return nil
+}
+
+// Execute is a fallback that should not be called in production. Running the
+// called rule needs a workflow execution to start it under, so it fails
+// rather than pretending the call completed.
+func (h *CallWorkflowHandler) Execute(ctx context.Context, config json.RawMessage, execContext workflow.ActionExecutionContext) (any, error) {
+	h.log.Error(ctx, "call_workflow action executed outside the workflow interpreter",
+		"rule_name", execContext.RuleName,
+		"action_name", execContext.ActionName)
+
+	return nil, errors.New("call_workflow must run inside a workflow execution")
+}
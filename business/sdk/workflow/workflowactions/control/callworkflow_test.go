@@ -0,0 +1,105 @@
+package control_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/control"
+)
+
+const testCalledRuleID = "5b6c1a4e-8d1f-4a39-9f3e-2a7c0d4b9e11"
+
+// =============================================================================
+// Validation Tests
+// =============================================================================
+
+func TestCallWorkflow_Validate_Valid(t *testing.T) {
+	handler := control.NewCallWorkflowHandler(newTestLogger())
+
+	configs := map[string]string{
+		"rule only":      `{"rule_id": "` + testCalledRuleID + `"}`,
+		"inputs":         `{"rule_id": "` + testCalledRuleID + `", "inputs": {"order": "{{order_number}}", "priority": 2}}`,
+		"nested inputs":  `{"rule_id": "` + testCalledRuleID + `", "inputs": {"alert": {"title": "Order {{number}}"}}}`,
+		"outputs":        `{"rule_id": "` + testCalledRuleID + `", "outputs": {"alert_id": "{{create_alert.alert_id}}"}}`,
+		"inputs+outputs": `{"rule_id": "` + testCalledRuleID + `", "inputs": {"order": "{{number}}"}, "outputs": {"sent": "{{notify.sent}}"}}`,
+	}
+
+	for name, cfg := range configs {
+		t.Run(name, func(t *testing.T) {
+			if err := handler.Validate(json.RawMessage(cfg)); err != nil {
+				t.Errorf("Validate(%s) should succeed, got error: %v", cfg, err)
+			}
+		})
+	}
+}
+
+func TestCallWorkflow_Validate_Invalid(t *testing.T) {
+	handler := control.NewCallWorkflowHandler(newTestLogger())
+
+	configs := map[string]string{
+		"invalid json":          `{invalid json}`,
+		"missing rule_id":       `{}`,
+		"bad rule_id":           `{"rule_id": "not-a-uuid"}`,
+		"nil rule_id":           `{"rule_id": "00000000-0000-0000-0000-000000000000"}`,
+		"bad input name":        `{"rule_id": "` + testCalledRuleID + `", "inputs": {"order-number": "x"}}`,
+		"reserved input":        `{"rule_id": "` + testCalledRuleID + `", "inputs": {"entity_id": "x"}}`,
+		"internal input":        `{"rule_id": "` + testCalledRuleID + `", "inputs": {"__cascade_lineage": "x"}}`,
+		"bad output name":       `{"rule_id": "` + testCalledRuleID + `", "outputs": {"alert id": "{{a.b}}"}}`,
+		"reserved output":       `{"rule_id": "` + testCalledRuleID + `", "outputs": {"output": "{{a.b}}"}}`,
+		"empty output template": `{"rule_id": "` + testCalledRuleID + `", "outputs": {"alert_id": ""}}`,
+		"output not a string":   `{"rule_id": "` + testCalledRuleID + `", "outputs": {"alert_id": 1}}`,
+	}
+
+	for name, cfg := range configs {
+		t.Run(name, func(t *testing.T) {
+			if err := handler.Validate(json.RawMessage(cfg)); err == nil {
+				t.Errorf("Validate(%s) should return error", cfg)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// Handler Metadata Tests
+// =============================================================================
+
+func TestCallWorkflow_Metadata(t *testing.T) {
+	handler := control.NewCallWorkflowHandler(newTestLogger())
+
+	if got := handler.GetType(); got != "call_workflow" {
+		t.Errorf("GetType() = %s, want call_workflow", got)
+	}
+
+	if handler.SupportsManualExecution() {
+		t.Error("SupportsManualExecution() should return false for call_workflow handler")
+	}
+
+	if handler.IsAsync() {
+		t.Error("IsAsync() should return false for call_workflow handler")
+	}
+
+	ports := handler.GetOutputPorts()
+	if len(ports) != 2 || ports[0].Name != "completed" || !ports[0].IsDefault || ports[1].Name != "failure" {
+		t.Errorf("GetOutputPorts() = %+v, want default completed and failure", ports)
+	}
+}
+
+// =============================================================================
+// Execute Fallback Test
+// =============================================================================
+
+func TestCallWorkflow_Execute_Fallback(t *testing.T) {
+	handler := control.NewCallWorkflowHandler(newTestLogger())
+
+	config := json.RawMessage(`{"rule_id": "` + testCalledRuleID + `"}`)
+	execCtx := workflow.ActionExecutionContext{
+		EntityName: "orders",
+		EventType:  "on_create",
+	}
+
+	if _, err := handler.Execute(context.Background(), config, execCtx); err == nil {
+		t.Error("Execute() should fail outside the workflow interpreter")
+	}
+}
@@ -5,25 +5,29 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/timmaaaz/ichor/business/sdk/workflow"
 	"github.com/timmaaaz/ichor/foundation/logger"
 )
 
-// FieldCondition represents a condition for field evaluation.
-// This mirrors the structure in trigger.go for consistency.
-type FieldCondition struct {
-	FieldName     string      `json:"field_name"`
-	Operator      string      `json:"operator"`
-	Value         interface{} `json:"value,omitempty"`
-	PreviousValue interface{} `json:"previous_value,omitempty"`
-}
+// FieldCondition represents a condition for field evaluation. It is the trigger
+// condition type, so both are evaluated by workflow.EvaluateFieldCondition.
+type FieldCondition = workflow.FieldCondition
 
-// ConditionConfig defines the configuration for evaluate_condition action.
+// ConditionConfig defines the configuration for evaluate_condition action. Set
+// either Conditions or Expression.
 type ConditionConfig struct {
-	Conditions []FieldCondition `json:"conditions"`
-	LogicType  string           `json:"logic_type"` // "and" (default) or "or"
+	Conditions []FieldCondition `json:"conditions,omitempty"`
+	LogicType  string           `json:"logic_type,omitempty"` // "and" (default) or "or"
+	Expression string           `json:"expression,omitempty"`
+}
+
+// conditions returns the conditions to evaluate; an expression is a single condition.
+func (c ConditionConfig) conditions() []FieldCondition {
+	if c.Expression != "" {
+		return []FieldCondition{{Operator: workflow.OperatorExpression, Expression: c.Expression}}
+	}
+	return c.Conditions
 }
 
 // EvaluateConditionHandler evaluates conditions and returns branch direction.
@@ -64,6 +68,16 @@ func (h *EvaluateConditionHandler) Validate(config json.RawMessage) error {
 		return fmt.Errorf("invalid condition config: %w", err)
 	}
 
+	if cfg.Expression != "" {
+		if len(cfg.Conditions) > 0 {
+			return fmt.Errorf("set either conditions or expression, not both")
+		}
+		if _, err := workflow.ParseExpr(cfg.Expression); err != nil {
+			return fmt.Errorf("expression: %w", err)
+		}
+		return nil
+	}
+
 	if len(cfg.Conditions) == 0 {
 		return fmt.Errorf("at least one condition is required")
 	}
@@ -73,29 +87,9 @@ func (h *EvaluateConditionHandler) Validate(config json.RawMessage) error {
 		return fmt.Errorf("invalid logic_type: must be 'and' or 'or'")
 	}
 
-	// Validate each condition has required fields
-	validOperators := map[string]bool{
-		"equals":       true,
-		"not_equals":   true,
-		"changed_from": true,
-		"changed_to":   true,
-		"greater_than": true,
-		"less_than":    true,
-		"contains":     true,
-		"in":           true,
-		"is_null":      true,
-		"is_not_null":  true,
-	}
-
 	for i, cond := range cfg.Conditions {
-		if cond.FieldName == "" {
-			return fmt.Errorf("condition %d: field_name is required", i)
-		}
-		if cond.Operator == "" {
-			return fmt.Errorf("condition %d: operator is required", i)
-		}
-		if !validOperators[cond.Operator] {
-			return fmt.Errorf("condition %d: invalid operator '%s'", i, cond.Operator)
+		if err := workflow.ValidateFieldCondition(cond); err != nil {
+			return fmt.Errorf("condition %d: %w", i, err)
 		}
 	}
 
@@ -124,7 +118,17 @@ func (h *EvaluateConditionHandler) Execute(ctx context.Context, config json.RawM
 	}
 
 	// Evaluate conditions against RawData and FieldChanges
-	result := h.evaluateConditions(cfg.Conditions, execCtx.RawData, execCtx.FieldChanges, execCtx.EventType, logicType)
+	data := workflow.ConditionData{
+		EventType:    execCtx.EventType,
+		RawData:      execCtx.RawData,
+		FieldChanges: execCtx.FieldChanges,
+	}
+	conditions := cfg.conditions()
+
+	result, err := h.evaluateConditions(conditions, data, logicType)
+	if err != nil {
+		return nil, err
+	}
 
 	output := "false"
 	if result {
@@ -132,7 +136,7 @@ func (h *EvaluateConditionHandler) Execute(ctx context.Context, config json.RawM
 	}
 
 	h.log.Info(ctx, "evaluate_condition action executed",
-		"conditions_count", len(cfg.Conditions),
+		"conditions_count", len(conditions),
 		"logic_type", logicType,
 		"result", result,
 		"output", output,
@@ -146,165 +150,69 @@ func (h *EvaluateConditionHandler) Execute(ctx context.Context, config json.RawM
 	}, nil
 }
 
-// evaluateConditions evaluates all conditions and returns the combined result.
-func (h *EvaluateConditionHandler) evaluateConditions(
-	conditions []FieldCondition,
-	data map[string]interface{},
-	fieldChanges map[string]workflow.FieldChange,
-	eventType string,
-	logicType string,
-) bool {
-	if len(conditions) == 0 {
-		return true // No conditions = always true
+// Plan implements workflow.ActionPlanner. Evaluating conditions has no side
+// effects, so the plan takes the branch Execute would take. Expressions calling
+// now() see the time of the replayed event rather than the current time.
+func (h *EvaluateConditionHandler) Plan(ctx context.Context, config json.RawMessage, execCtx workflow.ActionExecutionContext) (workflow.ActionPlan, error) {
+	var cfg ConditionConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return workflow.ActionPlan{}, fmt.Errorf("failed to parse condition config: %w", err)
 	}
 
-	for _, cond := range conditions {
-		matched := h.evaluateSingleCondition(cond, data, fieldChanges, eventType)
-
-		if logicType == "or" && matched {
-			return true // OR: any true means true
-		}
-		if logicType == "and" && !matched {
-			return false // AND: any false means false
-		}
+	logicType := cfg.LogicType
+	if logicType == "" {
+		logicType = "and"
 	}
 
-	// AND: all true means true, OR: all false means false
-	return logicType == "and"
-}
-
-// evaluateSingleCondition evaluates a single field condition.
-func (h *EvaluateConditionHandler) evaluateSingleCondition(
-	cond FieldCondition,
-	data map[string]interface{},
-	fieldChanges map[string]workflow.FieldChange,
-	eventType string,
-) bool {
-	fieldName := cond.FieldName
-	var currentValue, previousValue interface{}
-
-	// Get current and previous values
-	if eventType == "on_update" && fieldChanges != nil {
-		if fieldChange, exists := fieldChanges[fieldName]; exists {
-			currentValue = fieldChange.NewValue
-			previousValue = fieldChange.OldValue
-		} else {
-			// Field wasn't changed in this update
-			if data != nil {
-				currentValue = data[fieldName]
-			}
-			previousValue = currentValue
-		}
-	} else {
-		// For create/delete/manual, use raw data
-		if data != nil {
-			currentValue = data[fieldName]
-		}
-		previousValue = nil
+	data := workflow.ConditionData{
+		EventType:    execCtx.EventType,
+		RawData:      execCtx.RawData,
+		FieldChanges: execCtx.FieldChanges,
+		Now:          execCtx.Timestamp,
 	}
 
-	// Evaluate condition based on operator
-	switch cond.Operator {
-	case "equals":
-		return h.compareValues(currentValue, cond.Value, "==")
-
-	case "not_equals":
-		return !h.compareValues(currentValue, cond.Value, "==")
-
-	case "changed_from":
-		return eventType == "on_update" &&
-			h.compareValues(previousValue, cond.PreviousValue, "==")
-
-	case "changed_to":
-		return eventType == "on_update" &&
-			h.compareValues(currentValue, cond.Value, "==") &&
-			!h.compareValues(previousValue, cond.Value, "==")
-
-	case "greater_than":
-		return h.compareValues(currentValue, cond.Value, ">")
-
-	case "less_than":
-		return h.compareValues(currentValue, cond.Value, "<")
-
-	case "contains":
-		if strVal, ok := currentValue.(string); ok {
-			if searchVal, ok := cond.Value.(string); ok {
-				return strings.Contains(strVal, searchVal)
-			}
-		}
-		return false
-
-	case "in":
-		if values, ok := cond.Value.([]interface{}); ok {
-			for _, v := range values {
-				if h.compareValues(currentValue, v, "==") {
-					return true
-				}
-			}
-		}
-		return false
+	result, err := h.evaluateConditions(cfg.conditions(), data, logicType)
+	if err != nil {
+		return workflow.ActionPlan{}, err
+	}
 
-	case "is_null":
-		return currentValue == nil
+	output := "false"
+	if result {
+		output = "true"
+	}
 
-	case "is_not_null":
-		return currentValue != nil
+	return workflow.ActionPlan{
+		Output: output,
+		Result: map[string]any{
+			"evaluated": true,
+			"result":    result,
+			"output":    output,
+		},
+	}, nil
+}
 
-	default:
-		return false
+// evaluateConditions evaluates all conditions and returns the combined result. A
+// condition that cannot be evaluated, such as an expression doing arithmetic on a
+// missing field, is an error rather than a false branch.
+func (h *EvaluateConditionHandler) evaluateConditions(conditions []FieldCondition, data workflow.ConditionData, logicType string) (bool, error) {
+	if len(conditions) == 0 {
+		return true, nil // No conditions = always true
 	}
-}
 
-// compareValues compares two values based on the operator.
-func (h *EvaluateConditionHandler) compareValues(a, b interface{}, op string) bool {
-	// Handle nil cases
-	if a == nil || b == nil {
-		if op == "==" {
-			return a == b
+	for i, cond := range conditions {
+		res := workflow.EvaluateFieldCondition(cond, data)
+		if res.Error != "" {
+			return false, fmt.Errorf("condition %d: %s", i, res.Error)
 		}
-		return false
-	}
 
-	switch op {
-	case "==":
-		return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b)
-	case ">":
-		aFloat, aOk := h.toFloat64(a)
-		bFloat, bOk := h.toFloat64(b)
-		if aOk && bOk {
-			return aFloat > bFloat
+		if logicType == "or" && res.Matched {
+			return true, nil // OR: any true means true
 		}
-		// Fall back to string comparison
-		return fmt.Sprintf("%v", a) > fmt.Sprintf("%v", b)
-	case "<":
-		aFloat, aOk := h.toFloat64(a)
-		bFloat, bOk := h.toFloat64(b)
-		if aOk && bOk {
-			return aFloat < bFloat
+		if logicType == "and" && !res.Matched {
+			return false, nil // AND: any false means false
 		}
-		return fmt.Sprintf("%v", a) < fmt.Sprintf("%v", b)
-	default:
-		return false
 	}
-}
 
-// toFloat64 attempts to convert a value to float64.
-func (h *EvaluateConditionHandler) toFloat64(v interface{}) (float64, bool) {
-	switch val := v.(type) {
-	case float64:
-		return val, true
-	case float32:
-		return float64(val), true
-	case int:
-		return float64(val), true
-	case int32:
-		return float64(val), true
-	case int64:
-		return float64(val), true
-	case json.Number:
-		f, err := val.Float64()
-		return f, err == nil
-	default:
-		return 0, false
-	}
+	// AND: all true means true, OR: all false means false
+	return logicType == "and", nil
 }
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/timmaaaz/ichor/business/sdk/workflow"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/control"
@@ -1282,6 +1283,149 @@ func TestHandler_IsAsync(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// Expression Tests
+// =============================================================================
+
+func TestValidate_Expression(t *testing.T) {
+	handler := newTestHandler()
+
+	if err := handler.Validate(json.RawMessage(`{"expression": "status == 'active' and (amount ?? 0) > 100"}`)); err != nil {
+		t.Errorf("Validate() should accept an expression, got error: %v", err)
+	}
+
+	err := handler.Validate(json.RawMessage(`{"expression": "status == 'active' and amount >"}`))
+	if err == nil {
+		t.Fatal("Validate() should reject a malformed expression")
+	}
+	if !strings.Contains(err.Error(), "position 31: unexpected end of expression") {
+		t.Errorf("Validate() error = %q, want the position of the problem", err)
+	}
+
+	err = handler.Validate(json.RawMessage(`{
+		"conditions": [{"field_name": "status", "operator": "equals", "value": "active"}],
+		"expression": "true"
+	}`))
+	if err == nil {
+		t.Error("Validate() should reject conditions and expression together")
+	}
+
+	err = handler.Validate(json.RawMessage(`{
+		"conditions": [{"operator": "expression", "expression": "any(lines, .qty >)"}]
+	}`))
+	if err == nil || !strings.Contains(err.Error(), "condition 0: expression: position 17") {
+		t.Errorf("Validate() error = %v, want the nested expression's position", err)
+	}
+}
+
+func TestExecute_Expression(t *testing.T) {
+	handler := newTestHandler()
+	ctx := context.Background()
+
+	config := json.RawMessage(`{
+		"expression": "(status == 'active' or vip) and any(line_items, .quantity > 10) and $old.status != status"
+	}`)
+
+	execCtx := workflow.ActionExecutionContext{
+		EntityName: "orders",
+		EventType:  "on_update",
+		RawData: map[string]interface{}{
+			"status": "active",
+			"vip":    false,
+			"line_items": []interface{}{
+				map[string]interface{}{"quantity": 4},
+				map[string]interface{}{"quantity": 11},
+			},
+		},
+		FieldChanges: map[string]workflow.FieldChange{
+			"status": {OldValue: "draft", NewValue: "active"},
+		},
+	}
+
+	result, err := handler.Execute(ctx, config, execCtx)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	condResult := result.(map[string]any)
+	if condResult["output"] != "true" {
+		t.Errorf("output = %v, want true", condResult["output"])
+	}
+}
+
+func TestExecute_ExpressionError(t *testing.T) {
+	handler := newTestHandler()
+	ctx := context.Background()
+
+	config := json.RawMessage(`{"expression": "amount * rate > 100"}`)
+	execCtx := workflow.ActionExecutionContext{
+		EntityName: "orders",
+		EventType:  "on_create",
+		RawData:    map[string]interface{}{"amount": 50},
+	}
+
+	_, err := handler.Execute(ctx, config, execCtx)
+	if err == nil {
+		t.Fatal("Execute() should fail when the expression cannot be evaluated")
+	}
+	if !strings.Contains(err.Error(), `position 7: "rate" is null or missing`) {
+		t.Errorf("Execute() error = %q", err)
+	}
+}
+
+func TestPlan_MatchesExecute(t *testing.T) {
+	handler := newTestHandler()
+	ctx := context.Background()
+
+	config := json.RawMessage(`{"conditions": [{"field_name": "status", "operator": "equals", "value": "active"}]}`)
+
+	for _, status := range []string{"active", "closed"} {
+		execCtx := workflow.ActionExecutionContext{
+			EntityName: "orders",
+			EventType:  "on_create",
+			RawData:    map[string]interface{}{"status": status},
+		}
+
+		result, err := handler.Execute(ctx, config, execCtx)
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+
+		plan, err := handler.Plan(ctx, config, execCtx)
+		if err != nil {
+			t.Fatalf("Plan() error = %v", err)
+		}
+
+		if plan.Output != result.(map[string]any)["output"] {
+			t.Errorf("status %s: plan output = %q, execute output = %v", status, plan.Output, result.(map[string]any)["output"])
+		}
+		if len(plan.Effects) != 0 {
+			t.Errorf("status %s: plan effects = %v, want none", status, plan.Effects)
+		}
+	}
+}
+
+func TestPlan_NowIsEventTime(t *testing.T) {
+	handler := newTestHandler()
+	ctx := context.Background()
+
+	config := json.RawMessage(`{"expression": "due_date < now() + 2d"}`)
+	execCtx := workflow.ActionExecutionContext{
+		EntityName: "orders",
+		EventType:  "on_create",
+		RawData:    map[string]interface{}{"due_date": "2026-03-11"},
+		Timestamp:  time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC),
+	}
+
+	plan, err := handler.Plan(ctx, config, execCtx)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if plan.Output != "true" {
+		t.Errorf("output = %q, want true for a due date within two days of the event", plan.Output)
+	}
+}
+
 // =============================================================================
 // Helper Functions
 // =============================================================================
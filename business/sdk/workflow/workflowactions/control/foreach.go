@@ -0,0 +1,136 @@
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// MaxForEachConcurrency is the most items a parallel for_each may run at
+// once. It matches the limit enforced by the Temporal interpreter.
+const MaxForEachConcurrency = 20
+
+// itemNamePattern restricts item_name to a plain template variable name.
+var itemNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
+// ForEachConfig represents configuration for for_each actions.
+//
+// Items is either a literal JSON array or a single template reference to a
+// list, such as "{{line_items}}", "{{raw_data.line_items}}" or
+// "{{lookup_order.lines}}", or an {{expr: ...}} block that evaluates to a list,
+// such as "{{expr: filter(line_items, .quantity > 0)}}". ItemName (default
+// "item") names the variable the
+// body sees the current element as; its position is <item_name>_index.
+type ForEachConfig struct {
+	Items           json.RawMessage `json:"items"`
+	ItemName        string          `json:"item_name"`
+	Mode            string          `json:"mode"`            // "sequential" (default) or "parallel"
+	MaxConcurrency  int             `json:"max_concurrency"` // parallel only, default 5
+	ContinueOnError bool            `json:"continue_on_error"`
+}
+
+// ForEachHandler handles for_each actions. The iteration itself is done at
+// the Temporal workflow level: the branch wired to the "each" port runs as a
+// child workflow once per item, and execution continues from "done" once
+// every item has run. This handler only provides validation, output ports and
+// a fallback Execute.
+type ForEachHandler struct {
+	log *logger.Logger
+}
+
+// NewForEachHandler creates a new for_each handler.
+func NewForEachHandler(log *logger.Logger) *ForEachHandler {
+	return &ForEachHandler{log: log}
+}
+
+// GetType returns the action type.
+func (h *ForEachHandler) GetType() string {
+	return "for_each"
+}
+
+// SupportsManualExecution returns false - iteration drives a branch of the graph.
+func (h *ForEachHandler) SupportsManualExecution() bool {
+	return false
+}
+
+// IsAsync returns false - for_each is handled at the workflow level, not the activity level.
+func (h *ForEachHandler) IsAsync() bool {
+	return false
+}
+
+// GetDescription returns a human-readable description.
+func (h *ForEachHandler) GetDescription() string {
+	return "Run a branch of the workflow once for each item in a list, sequentially or in parallel"
+}
+
+// GetOutputPorts implements workflow.OutputPortProvider.
+func (h *ForEachHandler) GetOutputPorts() []workflow.OutputPort {
+	return []workflow.OutputPort{
+		{Name: "each", Description: "Runs once per item with the item in context"},
+		{Name: "done", Description: "All items have run", IsDefault: true},
+	}
+}
+
+// Validate validates the for_each configuration.
+func (h *ForEachHandler) Validate(config json.RawMessage) error {
+	var cfg ForEachConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return fmt.Errorf("invalid configuration format: %w", err)
+	}
+
+	if len(cfg.Items) == 0 {
+		return errors.New("items is required")
+	}
+
+	var items any
+	if err := json.Unmarshal(cfg.Items, &items); err != nil {
+		return fmt.Errorf("invalid items: %w", err)
+	}
+
+	switch v := items.(type) {
+	case []any:
+	case string:
+		ref := strings.TrimSpace(v)
+		if !strings.HasPrefix(ref, "{{") || !strings.HasSuffix(ref, "}}") || strings.Count(ref, "{{") != 1 {
+			return fmt.Errorf("items must be a list or a single {{variable}} reference, got %q", v)
+		}
+	default:
+		return errors.New("items must be a list or a single {{variable}} reference")
+	}
+
+	if cfg.ItemName != "" && !itemNamePattern.MatchString(cfg.ItemName) {
+		return fmt.Errorf("item_name %q must start with a letter and contain only letters, digits and underscores", cfg.ItemName)
+	}
+
+	switch cfg.Mode {
+	case "", "sequential":
+		if cfg.MaxConcurrency != 0 {
+			return errors.New("max_concurrency only applies to parallel mode")
+		}
+	case "parallel":
+		if cfg.MaxConcurrency < 0 || cfg.MaxConcurrency > MaxForEachConcurrency {
+			return fmt.Errorf("max_concurrency must be between 1 and %d", MaxForEachConcurrency)
+		}
+	default:
+		return fmt.Errorf("mode must be sequential or parallel, got %q", cfg.Mode)
+	}
+
+	return nil
+}
+
+// Execute is a fallback that should not be called in production. Running the
+// body needs the workflow graph, so unlike delay there is nothing sensible to
+// do here; it fails rather than silently skipping every item.
+func (h *ForEachHandler) Execute(ctx context.Context, config json.RawMessage, execContext workflow.ActionExecutionContext) (any, error) {
+	h.log.Error(ctx, "for_each action executed outside the workflow interpreter",
+		"rule_name", execContext.RuleName,
+		"action_name", execContext.ActionName)
+
+	return nil, errors.New("for_each must run inside a workflow execution")
+}
@@ -0,0 +1,106 @@
+package control_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/control"
+)
+
+// =============================================================================
+// Validation Tests
+// =============================================================================
+
+func TestForEach_Validate_Valid(t *testing.T) {
+	handler := control.NewForEachHandler(newTestLogger())
+
+	configs := map[string]string{
+		"template":          `{"items": "{{line_items}}"}`,
+		"nested template":   `{"items": "{{raw_data.line_items}}"}`,
+		"literal list":      `{"items": [1, 2, 3]}`,
+		"item name":         `{"items": "{{line_items}}", "item_name": "line"}`,
+		"sequential":        `{"items": "{{line_items}}", "mode": "sequential"}`,
+		"parallel default":  `{"items": "{{line_items}}", "mode": "parallel"}`,
+		"parallel bounded":  `{"items": "{{line_items}}", "mode": "parallel", "max_concurrency": 20}`,
+		"continue on error": `{"items": "{{line_items}}", "continue_on_error": true}`,
+	}
+
+	for name, cfg := range configs {
+		t.Run(name, func(t *testing.T) {
+			if err := handler.Validate(json.RawMessage(cfg)); err != nil {
+				t.Errorf("Validate(%s) should succeed, got error: %v", cfg, err)
+			}
+		})
+	}
+}
+
+func TestForEach_Validate_Invalid(t *testing.T) {
+	handler := control.NewForEachHandler(newTestLogger())
+
+	configs := map[string]string{
+		"invalid json":              `{invalid json}`,
+		"missing items":             `{}`,
+		"items not a reference":     `{"items": "line_items"}`,
+		"items with text":           `{"items": "lines: {{line_items}}"}`,
+		"items two references":      `{"items": "{{a}}{{b}}"}`,
+		"items an object":           `{"items": {"a": 1}}`,
+		"bad item name":             `{"items": "{{line_items}}", "item_name": "line-item"}`,
+		"unknown mode":              `{"items": "{{line_items}}", "mode": "batch"}`,
+		"concurrency in sequential": `{"items": "{{line_items}}", "max_concurrency": 2}`,
+		"concurrency above cap":     `{"items": "{{line_items}}", "mode": "parallel", "max_concurrency": 21}`,
+		"negative concurrency":      `{"items": "{{line_items}}", "mode": "parallel", "max_concurrency": -1}`,
+	}
+
+	for name, cfg := range configs {
+		t.Run(name, func(t *testing.T) {
+			if err := handler.Validate(json.RawMessage(cfg)); err == nil {
+				t.Errorf("Validate(%s) should return error", cfg)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// Handler Metadata Tests
+// =============================================================================
+
+func TestForEach_Metadata(t *testing.T) {
+	handler := control.NewForEachHandler(newTestLogger())
+
+	if got := handler.GetType(); got != "for_each" {
+		t.Errorf("GetType() = %s, want for_each", got)
+	}
+
+	if handler.SupportsManualExecution() {
+		t.Error("SupportsManualExecution() should return false for for_each handler")
+	}
+
+	if handler.IsAsync() {
+		t.Error("IsAsync() should return false for for_each handler")
+	}
+
+	ports := handler.GetOutputPorts()
+	if len(ports) != 2 || ports[0].Name != "each" || ports[1].Name != "done" || !ports[1].IsDefault {
+		t.Errorf("GetOutputPorts() = %+v, want each and default done", ports)
+	}
+}
+
+// =============================================================================
+// Execute Fallback Test
+// =============================================================================
+
+func TestForEach_Execute_Fallback(t *testing.T) {
+	handler := control.NewForEachHandler(newTestLogger())
+
+	config := json.RawMessage(`{"items": "{{line_items}}"}`)
+	execCtx := workflow.ActionExecutionContext{
+		EntityName: "orders",
+		EventType:  "on_create",
+	}
+
+	if _, err := handler.Execute(context.Background(), config, execCtx); err == nil {
+		t.Error("Execute() should fail outside the workflow interpreter")
+	}
+}
@@ -88,7 +88,7 @@ func (h *AuditLogHandler) Execute(ctx context.Context, config json.RawMessage, e
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
-	templateContext := buildTemplateContext(execContext)
+	templateContext := workflow.ActionTemplateContext(execContext)
 
 	// Process templates
 	resolvedMessage := processTemplateValue(h.templateProc, ctx, h.log, cfg.Message, templateContext)
@@ -98,24 +98,11 @@ func (h *CreateEntityHandler) Execute(ctx context.Context, config json.RawMessag
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
-	// Reject creates into a whole-table-protected entity (e.g. an append-only ledger)
-	// or that set any protected field directly (DESIGN §10 protected-list).
-	fieldNames := make([]string, 0, len(cfg.Fields))
-	for k := range cfg.Fields {
-		fieldNames = append(fieldNames, k)
-	}
-	if err := checkProtectedEntity(h.protected, cfg.TargetEntity, fieldNames); err != nil {
+	processedFields, err := h.processFields(ctx, cfg, execContext)
+	if err != nil {
 		return nil, err
 	}
 
-	templateContext := buildTemplateContext(execContext)
-
-	// Process template variables in all field values
-	processedFields := make(map[string]any, len(cfg.Fields))
-	for k, v := range cfg.Fields {
-		processedFields[k] = processTemplateValue(h.templateProc, ctx, h.log, v, templateContext)
-	}
-
 	// Auto-generate ID if not provided
 	if _, hasID := processedFields["id"]; !hasID {
 		processedFields["id"] = uuid.New()
@@ -192,6 +179,58 @@ func (h *CreateEntityHandler) Execute(ctx context.Context, config json.RawMessag
 	return result, nil
 }
 
+// Plan implements workflow.ActionPlanner. It resolves the field values the
+// same way Execute does but issues no INSERT.
+func (h *CreateEntityHandler) Plan(ctx context.Context, config json.RawMessage, execContext workflow.ActionExecutionContext) (workflow.ActionPlan, error) {
+	var cfg CreateEntityConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return workflow.ActionPlan{}, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	fields, err := h.processFields(ctx, cfg, execContext)
+	if err != nil {
+		return workflow.ActionPlan{}, err
+	}
+
+	return workflow.ActionPlan{
+		Output: "success",
+		Result: map[string]any{
+			"target_entity": cfg.TargetEntity,
+			"status":        "success",
+		},
+		Effects: []workflow.PlannedEffect{{
+			Kind:       workflow.EffectEntityModification,
+			EntityName: cfg.TargetEntity,
+			EventType:  workflow.EventTypeOnCreate,
+			Fields:     fields,
+		}},
+	}, nil
+}
+
+// processFields rejects protected writes and resolves the templates in the
+// configured field values.
+func (h *CreateEntityHandler) processFields(ctx context.Context, cfg CreateEntityConfig, execContext workflow.ActionExecutionContext) (map[string]any, error) {
+	// Reject creates into a whole-table-protected entity (e.g. an append-only ledger)
+	// or that set any protected field directly (DESIGN §10 protected-list).
+	fieldNames := make([]string, 0, len(cfg.Fields))
+	for k := range cfg.Fields {
+		fieldNames = append(fieldNames, k)
+	}
+	if err := checkProtectedEntity(h.protected, cfg.TargetEntity, fieldNames); err != nil {
+		return nil, err
+	}
+
+	templateContext := workflow.ActionTemplateContext(execContext)
+
+	// Process template variables in all field values
+	processedFields := make(map[string]any, len(cfg.Fields))
+	for k, v := range cfg.Fields {
+		processedFields[k] = processTemplateValue(h.templateProc, ctx, h.log, v, templateContext)
+	}
+
+	return processedFields, nil
+}
+
 // GetEntityModifications implements workflow.EntityModifier for cascade visualization.
 func (h *CreateEntityHandler) GetEntityModifications(config json.RawMessage) []workflow.EntityModification {
 	var cfg CreateEntityConfig
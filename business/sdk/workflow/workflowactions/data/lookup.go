@@ -102,7 +102,7 @@ func (h *LookupEntityHandler) Execute(ctx context.Context, config json.RawMessag
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
-	templateContext := buildTemplateContext(execContext)
+	templateContext := workflow.ActionTemplateContext(execContext)
 
 	// Build SELECT query
 	fields := strings.Join(cfg.Fields, ", ")
@@ -176,42 +176,29 @@ func (h *LookupEntityHandler) Execute(ctx context.Context, config json.RawMessag
 	return result, nil
 }
 
-// =============================================================================
-// Shared Template Helpers
-// =============================================================================
-
-// buildTemplateContext creates template context from execution context.
-// Shared across data action handlers.
-func buildTemplateContext(execContext workflow.ActionExecutionContext) workflow.TemplateContext {
-	context := make(workflow.TemplateContext)
-
-	context["entity_id"] = execContext.EntityID
-	context["entity_name"] = execContext.EntityName
-	context["event_type"] = execContext.EventType
-	context["timestamp"] = execContext.Timestamp
-	context["user_id"] = execContext.UserID
-	if execContext.RuleID != nil {
-		context["rule_id"] = *execContext.RuleID
-	}
-	context["rule_name"] = execContext.RuleName
-	context["execution_id"] = execContext.ExecutionID
-
-	if execContext.RawData != nil {
-		for k, v := range execContext.RawData {
-			context[k] = v
-		}
+// Plan implements workflow.ActionPlanner. A lookup only reads, so the plan runs
+// it. The data read is the data as it is now, not as it was when a replayed
+// event happened.
+func (h *LookupEntityHandler) Plan(ctx context.Context, config json.RawMessage, execContext workflow.ActionExecutionContext) (workflow.ActionPlan, error) {
+	res, err := h.Execute(ctx, config, execContext)
+	if err != nil {
+		return workflow.ActionPlan{}, err
 	}
 
-	if execContext.FieldChanges != nil {
-		for fieldName, change := range execContext.FieldChanges {
-			context["old_"+fieldName] = change.OldValue
-			context["new_"+fieldName] = change.NewValue
-		}
-	}
+	result := res.(map[string]any)
+	output, _ := result["output"].(string)
 
-	return context
+	return workflow.ActionPlan{
+		Output: output,
+		Result: result,
+		Note:   "looked up current data",
+	}, nil
 }
 
+// =============================================================================
+// Shared Template Helpers
+// =============================================================================
+
 // processTemplateValue processes template variables in a string value.
 // Shared across data action handlers.
 func processTemplateValue(proc *workflow.TemplateProcessor, ctx context.Context, log *logger.Logger, value any, templateContext workflow.TemplateContext) any {
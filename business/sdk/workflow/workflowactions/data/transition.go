@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/google/uuid"
@@ -127,7 +128,7 @@ func (h *TransitionStatusHandler) Execute(ctx context.Context, config json.RawMe
 		return nil, err
 	}
 
-	templateContext := buildTemplateContext(execContext)
+	templateContext := workflow.ActionTemplateContext(execContext)
 
 	// Resolve template values
 	targetID := processTemplateValue(h.templateProc, ctx, h.log, cfg.TargetID, templateContext)
@@ -229,6 +230,66 @@ func (h *TransitionStatusHandler) Execute(ctx context.Context, config json.RawMe
 	}, nil
 }
 
+// Plan implements workflow.ActionPlanner. It reads the current status to pick
+// the output port Execute would take but issues no UPDATE. The status read is
+// the status as it is now, not as it was when a replayed event happened.
+func (h *TransitionStatusHandler) Plan(ctx context.Context, config json.RawMessage, execContext workflow.ActionExecutionContext) (workflow.ActionPlan, error) {
+	var cfg TransitionStatusConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return workflow.ActionPlan{}, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	if err := checkProtectedField(h.protected, cfg.TargetEntity, cfg.StatusField); err != nil {
+		return workflow.ActionPlan{}, err
+	}
+
+	templateContext := workflow.ActionTemplateContext(execContext)
+	targetID := processTemplateValue(h.templateProc, ctx, h.log, cfg.TargetID, templateContext)
+	toStatus := processTemplateValue(h.templateProc, ctx, h.log, cfg.ToStatus, templateContext)
+
+	selectQuery := fmt.Sprintf("SELECT %s AS val FROM %s WHERE id = :target_id", cfg.StatusField, cfg.TargetEntity)
+	var statusDest struct {
+		Val string `db:"val"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, h.log, h.db, selectQuery, map[string]any{"target_id": targetID}, &statusDest); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return workflow.ActionPlan{}, fmt.Errorf("entity not found: %s with id %v", cfg.TargetEntity, targetID)
+		}
+		return workflow.ActionPlan{}, fmt.Errorf("failed to read current status: %w", err)
+	}
+	currentStatus := statusDest.Val
+
+	if !slices.Contains(cfg.ValidFromStatuses, currentStatus) {
+		return workflow.ActionPlan{
+			Output: "invalid_transition",
+			Result: map[string]any{
+				"transitioned": false,
+				"from_status":  currentStatus,
+				"to_status":    toStatus,
+				"output":       "invalid_transition",
+			},
+			Note: "checked against the current status",
+		}, nil
+	}
+
+	return workflow.ActionPlan{
+		Output: "success",
+		Result: map[string]any{
+			"transitioned": true,
+			"from_status":  currentStatus,
+			"to_status":    toStatus,
+			"output":       "success",
+		},
+		Effects: []workflow.PlannedEffect{{
+			Kind:       workflow.EffectEntityModification,
+			EntityName: cfg.TargetEntity,
+			EventType:  workflow.EventTypeOnUpdate,
+			Fields:     map[string]any{"id": targetID, cfg.StatusField: toStatus},
+		}},
+		Note: "checked against the current status",
+	}, nil
+}
+
 // GetEntityModifications implements workflow.EntityModifier for cascade visualization.
 func (h *TransitionStatusHandler) GetEntityModifications(config json.RawMessage) []workflow.EntityModification {
 	var cfg TransitionStatusConfig
@@ -186,7 +186,7 @@ func (h *UpdateFieldHandler) Execute(ctx context.Context, config json.RawMessage
 	}
 
 	// Process template variables
-	templateContext := buildTemplateContext(execContext)
+	templateContext := workflow.ActionTemplateContext(execContext)
 	processedValue := processTemplateValue(h.templateProc, ctx, h.log, cfg.NewValue, templateContext)
 	resolvedValue := processedValue
 
@@ -435,6 +435,46 @@ func (h *UpdateFieldHandler) resolveForeignKey(ctx context.Context, value any, f
 	return nil, fmt.Errorf("referenced record not found: %v in %s.%s", value, fkConfig.ReferenceTable, fkConfig.LookupField)
 }
 
+// Plan implements workflow.ActionPlanner. It resolves the new value the same
+// way Execute does but issues no UPDATE. A foreign key is not resolved, since
+// resolution may create the referenced record, so the value is reported as
+// configured.
+func (h *UpdateFieldHandler) Plan(ctx context.Context, config json.RawMessage, execContext workflow.ActionExecutionContext) (workflow.ActionPlan, error) {
+	var cfg UpdateFieldConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return workflow.ActionPlan{}, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	if err := checkProtectedField(h.protected, cfg.TargetEntity, cfg.TargetField); err != nil {
+		return workflow.ActionPlan{}, err
+	}
+
+	templateContext := workflow.ActionTemplateContext(execContext)
+	value := processTemplateValue(h.templateProc, ctx, h.log, cfg.NewValue, templateContext)
+
+	plan := workflow.ActionPlan{
+		Output: "success",
+		Result: map[string]any{
+			"status":        "success",
+			"target_entity": cfg.TargetEntity,
+			"target_field":  cfg.TargetField,
+			"new_value":     value,
+		},
+		Effects: []workflow.PlannedEffect{{
+			Kind:       workflow.EffectEntityModification,
+			EntityName: cfg.TargetEntity,
+			EventType:  workflow.EventTypeOnUpdate,
+			Fields:     map[string]any{cfg.TargetField: value},
+		}},
+	}
+
+	if cfg.FieldType == "foreign_key" && cfg.ForeignKeyConfig != nil {
+		plan.Note = fmt.Sprintf("foreign key into %s is resolved at run time", cfg.ForeignKeyConfig.ReferenceTable)
+	}
+
+	return plan, nil
+}
+
 // GetEntityModifications implements workflow.EntityModifier for cascade visualization.
 // Returns the entity and field that this action will modify based on its configuration.
 func (h *UpdateFieldHandler) GetEntityModifications(config json.RawMessage) []workflow.EntityModification {
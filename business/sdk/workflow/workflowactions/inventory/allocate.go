@@ -12,6 +12,7 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryreservationbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
 	"github.com/timmaaaz/ichor/business/domain/products/productbus"
 	"github.com/timmaaaz/ichor/business/sdk/sqldb"
@@ -19,19 +20,16 @@ import (
 	"github.com/timmaaaz/ichor/foundation/logger"
 )
 
-// TODO: Add to AllocationStrategy options:
-// - "nearest_location"     // Minimize shipping distance
-// - "lowest_cost"          // Choose cheapest warehouse
-// - "nearest_expiry"       // FEFO for perishables
-// - "load_balancing"       // Distribute across warehouses
-// - "priority_zone"        // VIP customers get premium stock
+// allocationCandidatePage is how many inventory items are locked per read.
+// Pages are read in the strategy's order until they hold enough usable stock.
+const allocationCandidatePage = 50
 
 // AllocateInventoryConfig represents the configuration for inventory allocation
 type AllocateInventoryConfig struct {
 	InventoryItems     []AllocationItem `json:"inventory_items"`
 	SourceFromLineItem bool             `json:"source_from_line_item"` // If true, extract product_id/quantity from line item RawData
 	AllocationMode     string           `json:"allocation_mode"`       // 'reserve' or 'allocate'
-	AllocationStrategy string           `json:"allocation_strategy"`   // 'fifo', 'lifo', 'nearest_expiry', 'lowest_cost'
+	AllocationStrategy string           `json:"allocation_strategy"`   // see the Strategy* constants
 	AllowPartial       bool             `json:"allow_partial"`
 	ReservationHours   int              `json:"reservation_duration_hours,omitempty"`
 	Priority           string           `json:"priority"` // 'low', 'medium', 'high', 'critical'
@@ -77,15 +75,19 @@ type InventoryAllocationResult struct {
 	CompletedAt     time.Time       `json:"completed_at"`
 }
 
-// AllocatedItem represents a successfully allocated item
+// AllocatedItem represents a successfully allocated item. LocationID and
+// InventoryID name the first location drawn from; Locations holds the full
+// per-location and per-lot breakdown.
 type AllocatedItem struct {
-	ProductID         uuid.UUID  `json:"product_id"`
-	LocationID        uuid.UUID  `json:"location_id"`
-	RequestedQuantity int        `json:"requested_quantity"`
-	AllocatedQuantity int        `json:"allocated_quantity"`
-	InventoryID       uuid.UUID  `json:"inventory_item_id"`
-	AllocationMode    string     `json:"allocation_mode"`
-	ExpiresAt         *time.Time `json:"expires_at,omitempty"` // For reservations
+	ProductID          uuid.UUID            `json:"product_id"`
+	LocationID         uuid.UUID            `json:"location_id"`
+	RequestedQuantity  int                  `json:"requested_quantity"`
+	AllocatedQuantity  int                  `json:"allocated_quantity"`
+	InventoryID        uuid.UUID            `json:"inventory_item_id"`
+	AllocationMode     string               `json:"allocation_mode"`
+	AllocationStrategy string               `json:"allocation_strategy"`
+	Locations          []LocationAllocation `json:"locations"`
+	ExpiresAt          *time.Time           `json:"expires_at,omitempty"` // For reservations
 }
 
 // FailedItem represents an item that couldn't be allocated
@@ -145,6 +147,7 @@ type AllocateInventoryHandler struct {
 	transactionBus   *inventorytransactionbus.Business
 	productBus       *productbus.Business
 	workflowBus      *workflow.Business
+	reservationBus   *inventoryreservationbus.Business
 }
 
 // NewAllocateInventoryHandler creates a new allocate inventory handler
@@ -156,6 +159,7 @@ func NewAllocateInventoryHandler(
 	transactionBus *inventorytransactionbus.Business,
 	productBus *productbus.Business,
 	workflowBus *workflow.Business,
+	reservationBus *inventoryreservationbus.Business,
 ) *AllocateInventoryHandler {
 	return &AllocateInventoryHandler{
 		log:              log,
@@ -165,6 +169,7 @@ func NewAllocateInventoryHandler(
 		transactionBus:   transactionBus,
 		productBus:       productBus,
 		workflowBus:      workflowBus,
+		reservationBus:   reservationBus,
 	}
 }
 
@@ -210,10 +215,7 @@ func (h *AllocateInventoryHandler) Validate(config json.RawMessage) error {
 		return errors.New("inventory_items list is required and must not be empty")
 	}
 
-	validStrategies := map[string]bool{
-		"fifo": true, "lifo": true, "nearest_expiry": true, "lowest_cost": true,
-	}
-	if !validStrategies[cfg.AllocationStrategy] {
+	if !allocationStrategies[cfg.AllocationStrategy] {
 		return fmt.Errorf("invalid allocation_strategy: %s", cfg.AllocationStrategy)
 	}
 
@@ -384,6 +386,8 @@ func (h *AllocateInventoryHandler) Execute(ctx context.Context, config json.RawM
 		"message":         fmt.Sprintf("Allocation completed: %s", result.Status),
 		"reference_id":    cfg.ReferenceID,
 		"reference_type":  cfg.ReferenceType,
+		"allocated_items": result.AllocatedItems,
+		"failed_items":    result.FailedItems,
 		"output":          output,
 	}, nil
 }
@@ -525,100 +529,114 @@ func (h *AllocateInventoryHandler) allocateItem(
 		}
 	}
 
-	// Use the specialized query method for allocation
-	items, err := txItemBus.QueryAvailableForAllocation(
-		ctx,
-		item.ProductID,
-		item.LocationID,  // Optional: specific location
-		item.WarehouseID, // Optional: specific warehouse
-		config.AllocationStrategy,
-		10, // Process in batches to avoid locking too many rows
-	)
-
-	/* TODO: Advanced Allocation Strategy Requirements
-	 *
-	 * For nearest_expiry:
-	 *   - Fetch lot tracking data along with inventory
-	 *   - Prioritize lots nearing expiration
-	 *   - May need to split allocation across multiple lots
-	 *
-	 * For lowest_cost:
-	 *   - Factor in warehouse-specific costs (storage, labor, shipping)
-	 *   - Calculate total landed cost including shipping to customer
-	 *   - May need real-time shipping rate calculations
-	 *
-	 * For nearest_location:
-	 *   - Get customer delivery address from order/context
-	 *   - Calculate distances from available warehouses
-	 *   - Consider shipping zones and transit times
-	 *   - Cache distance calculations for performance
-	 *
-	 * For load_balancing:
-	 *   - Query current warehouse utilization levels
-	 *   - Factor in pending picks and current workload
-	 *   - Distribute evenly across warehouses with capacity
-	 *
-	 * For priority_zone:
-	 *   - Check customer tier/priority level
-	 *   - Route VIP orders to pick locations vs reserve
-	 *   - Consider zone-specific SLAs and handling requirements
-	 *
-	 * Additional considerations:
-	 *   - Multi-warehouse allocation for large orders
-	 *   - Minimum order quantities per warehouse
-	 *   - Shipping cutoff times by warehouse
-	 *   - Weekend/holiday warehouse schedules
-	 */
+	var order inventoryitembus.AllocationOrder
+	if config.ReferenceType == "order" {
+		if orderID, err := uuid.Parse(config.ReferenceID); err == nil {
+			order, err = txItemBus.QueryAllocationOrder(ctx, orderID)
+			if err != nil && !errors.Is(err, inventoryitembus.ErrNotFound) {
+				return nil, &FailedItem{
+					ProductID:         item.ProductID,
+					RequestedQuantity: item.Quantity,
+					Reason:            "query_failed",
+					ErrorMessage:      err.Error(),
+				}
+			}
+		}
+	}
 
-	if err != nil {
+	now := time.Now()
+	priority := effectivePriority(config.Priority, order.Priority)
+
+	query := inventoryitembus.AllocationQuery{
+		ProductID:   item.ProductID,
+		LocationID:  item.LocationID,  // Optional: specific location
+		WarehouseID: item.WarehouseID, // Optional: specific warehouse
+		Sort:        candidateSort(config.AllocationStrategy, priority),
+		Destination: order.Destination,
+		Limit:       allocationCandidatePage,
+	}
+
+	// Locks the candidate rows for the rest of the transaction.
+	var cands []inventoryitembus.AllocationCandidate
+	for available := 0; ; query.Offset += allocationCandidatePage {
+		page, err := txItemBus.QueryAllocationCandidates(ctx, query)
+		if err != nil {
+			return nil, &FailedItem{
+				ProductID:         item.ProductID,
+				RequestedQuantity: item.Quantity,
+				Reason:            "query_failed",
+				ErrorMessage:      err.Error(),
+			}
+		}
+
+		cands = append(cands, page...)
+		for _, c := range page {
+			available += stockOf(c, now).available
+		}
+
+		if available >= item.Quantity || len(page) < allocationCandidatePage {
+			break
+		}
+	}
+
+	ranked := rankCandidates(config.AllocationStrategy, cands, order.Destination, priority, now)
+	plan, totalAllocated := planAllocation(ranked, item.Quantity, now)
+
+	if totalAllocated == 0 {
 		return nil, &FailedItem{
 			ProductID:         item.ProductID,
 			RequestedQuantity: item.Quantity,
-			Reason:            "query_failed",
-			ErrorMessage:      err.Error(),
+			AvailableQuantity: 0,
+			Reason:            "insufficient_inventory",
+			ErrorMessage:      "No inventory available",
 		}
 	}
 
-	if len(items) == 0 {
+	// Check if we can allocate enough before touching any rows
+	if totalAllocated < item.Quantity && !config.AllowPartial {
 		return nil, &FailedItem{
 			ProductID:         item.ProductID,
 			RequestedQuantity: item.Quantity,
-			AvailableQuantity: 0,
+			AvailableQuantity: totalAllocated,
 			Reason:            "insufficient_inventory",
-			ErrorMessage:      "No inventory available",
+			ErrorMessage:      fmt.Sprintf("Only %d available, %d requested", totalAllocated, item.Quantity),
 		}
 	}
 
-	// Rest of allocation logic remains the same...
-	remaining := item.Quantity
-	totalAllocated := 0
-	var allocatedItem *AllocatedItem
+	items := make(map[uuid.UUID]inventoryitembus.InventoryItem, len(ranked))
+	for _, c := range ranked {
+		items[c.Item.ID] = c.Item
+	}
 
-	// Process available inventory using selected strategy
-	for _, invItem := range items {
-		if remaining <= 0 {
-			break
-		}
+	var txReservationBus *inventoryreservationbus.Business
+	var expiresAt time.Time
+	if config.AllocationMode == "reserve" {
+		expiresAt = now.Add(time.Duration(config.ReservationHours) * time.Hour)
 
-		available := invItem.Quantity - invItem.ReservedQuantity - invItem.AllocatedQuantity
-		if available <= 0 {
-			continue
+		txReservationBus, err = reservationsWithTx(h.reservationBus, tx)
+		if err != nil {
+			return nil, &FailedItem{
+				ProductID:    item.ProductID,
+				Reason:       "transaction_setup_failed",
+				ErrorMessage: err.Error(),
+			}
 		}
+	}
 
-		toAllocate := min(remaining, available)
+	for _, la := range plan {
+		invItem := items[la.InventoryID]
 
 		// Update inventory based on allocation mode
 		var update inventoryitembus.UpdateInventoryItem
 		if config.AllocationMode == "reserve" {
-			newReserved := invItem.ReservedQuantity + toAllocate
+			newReserved := invItem.ReservedQuantity + la.Quantity
 			update.ReservedQuantity = &newReserved
 		} else {
-			newAllocated := invItem.AllocatedQuantity + toAllocate
+			newAllocated := invItem.AllocatedQuantity + la.Quantity
 			update.AllocatedQuantity = &newAllocated
 		}
 
-		_, err := txItemBus.Update(ctx, invItem, update)
-		if err != nil {
+		if _, err := txItemBus.Update(ctx, invItem, update); err != nil {
 			return nil, &FailedItem{
 				ProductID:         item.ProductID,
 				RequestedQuantity: item.Quantity,
@@ -627,40 +645,37 @@ func (h *AllocateInventoryHandler) allocateItem(
 			}
 		}
 
-		// Track allocation details
-		if allocatedItem == nil {
-			allocatedItem = &AllocatedItem{
-				ProductID:         item.ProductID,
-				LocationID:        invItem.LocationID,
-				RequestedQuantity: item.Quantity,
-				AllocatedQuantity: 0,
-				InventoryID:       invItem.ID,
-				AllocationMode:    config.AllocationMode,
+		if config.AllocationMode == "reserve" {
+			hold := holdRequest{
+				source:        h.GetType(),
+				referenceID:   config.ReferenceID,
+				referenceType: config.ReferenceType,
+				expiresAt:     expiresAt,
 			}
-
-			if config.AllocationMode == "reserve" {
-				expiresAt := time.Now().Add(time.Duration(config.ReservationHours) * time.Hour)
-				allocatedItem.ExpiresAt = &expiresAt
+			if err := recordHold(ctx, txReservationBus, invItem, la.Quantity, hold, execContext); err != nil {
+				return nil, &FailedItem{
+					ProductID:         item.ProductID,
+					RequestedQuantity: item.Quantity,
+					Reason:            "update_failed",
+					ErrorMessage:      err.Error(),
+				}
 			}
 		}
-
-		remaining -= toAllocate
-		totalAllocated += toAllocate
 	}
 
-	// Check if we allocated enough
-	if remaining > 0 && !config.AllowPartial {
-		return nil, &FailedItem{
-			ProductID:         item.ProductID,
-			RequestedQuantity: item.Quantity,
-			AvailableQuantity: item.Quantity - remaining,
-			Reason:            "insufficient_inventory",
-			ErrorMessage:      fmt.Sprintf("Only %d available, %d requested", item.Quantity-remaining, item.Quantity),
-		}
+	allocatedItem := &AllocatedItem{
+		ProductID:          item.ProductID,
+		LocationID:         plan[0].LocationID,
+		RequestedQuantity:  item.Quantity,
+		AllocatedQuantity:  totalAllocated,
+		InventoryID:        plan[0].InventoryID,
+		AllocationMode:     config.AllocationMode,
+		AllocationStrategy: config.AllocationStrategy,
+		Locations:          plan,
 	}
 
-	if allocatedItem != nil {
-		allocatedItem.AllocatedQuantity = totalAllocated
+	if config.AllocationMode == "reserve" {
+		allocatedItem.ExpiresAt = &expiresAt
 	}
 
 	return allocatedItem, nil
@@ -702,7 +717,8 @@ func (h *AllocateInventoryHandler) GetEntityModifications(config json.RawMessage
 	// Inventory allocation always:
 	// 1. Updates inventory_items (reserved_quantity or allocated_quantity)
 	// 2. Creates an allocation_results record (which fires on_create event)
-	return []workflow.EntityModification{
+	// In reserve mode it also records an inventory_reservations row per location.
+	mods := []workflow.EntityModification{
 		{
 			EntityName: "inventory.inventory_items",
 			EventType:  "on_update",
@@ -714,4 +730,15 @@ func (h *AllocateInventoryHandler) GetEntityModifications(config json.RawMessage
 			Fields:     nil, // New record, all fields are "created"
 		},
 	}
+
+	var cfg AllocateInventoryConfig
+	if err := json.Unmarshal(config, &cfg); err == nil && cfg.AllocationMode == "reserve" {
+		mods = append(mods, workflow.EntityModification{
+			EntityName: "inventory.inventory_reservations",
+			EventType:  "on_create",
+			Fields:     nil,
+		})
+	}
+
+	return mods
 }
@@ -54,6 +54,7 @@ func Test_AllocateInventory(t *testing.T) {
 		db.BusDomain.InventoryTransaction,
 		db.BusDomain.Product,
 		db.BusDomain.Workflow,
+		db.BusDomain.InventoryReservation,
 	)
 
 	// -------------------------------------------------------------------------
@@ -275,6 +276,7 @@ func allocateInventoryTests(busDomain dbtest.BusDomain, db *sqlx.DB, sd allocate
 		testFIFOStrategy(busDomain, db, sd),
 		testSourceFromLineItem(busDomain, db, sd),
 		testOrderGroupedAllocation(busDomain, db, sd),
+		testAllocationReadsPastFirstPage(busDomain, sd),
 	}
 }
 
@@ -870,3 +872,121 @@ func testOrderGroupedAllocation(busDomain dbtest.BusDomain, db *sqlx.DB, sd allo
 	}
 }
 
+// =============================================================================
+// Candidate Paging Tests
+//
+// Candidates are read a page at a time in the strategy's order. These tests
+// seed more than one page of stock for a product to check that a strategy's
+// preferred stock is found wherever it was created, and that a request larger
+// than the first page is still covered.
+// =============================================================================
+
+func testAllocationReadsPastFirstPage(busDomain dbtest.BusDomain, sd allocateSeedData) unitest.Table {
+	type pagingResult struct {
+		PickFaceLocation uuid.UUID
+		FIFOAllocated    int
+	}
+
+	const reserveItems = 55
+
+	var pickFace uuid.UUID
+
+	return unitest.Table{
+		Name: "allocation_reads_past_first_page",
+		ExcFunc: func(ctx context.Context) any {
+			products, err := productbus.TestSeedProducts(ctx, 1,
+				uuid.UUIDs{sd.Products[0].BrandID}, uuid.UUIDs{sd.Products[0].ProductCategoryID}, busDomain.Product)
+			if err != nil {
+				return err
+			}
+			productID := products[0].ProductID
+
+			zones, err := zonebus.TestSeedZone(ctx, 1, []uuid.UUID{sd.Warehouses[0].ID}, busDomain.Zones)
+			if err != nil {
+				return err
+			}
+
+			locations, err := inventorylocationbus.TestSeedInventoryLocations(ctx, reserveItems+1, []uuid.UUID{sd.Warehouses[0].ID}, zones, busDomain.InventoryLocation)
+			if err != nil {
+				return err
+			}
+
+			// The reserve stock is created first, so the only pick face is
+			// the newest item and falls outside the first page by age.
+			for i, loc := range locations {
+				isPick := i == reserveItems
+				isReserve := !isPick
+				if _, err := busDomain.InventoryLocation.Update(ctx, loc, inventorylocationbus.UpdateInventoryLocation{
+					IsPickLocation:    &isPick,
+					IsReserveLocation: &isReserve,
+				}); err != nil {
+					return err
+				}
+
+				if _, err := busDomain.InventoryItem.Create(ctx, inventoryitembus.NewInventoryItem{
+					ProductID:    productID,
+					LocationID:   loc.LocationID,
+					Quantity:     1,
+					MaximumStock: 10,
+				}); err != nil {
+					return err
+				}
+			}
+			pickFace = locations[reserveItems].LocationID
+
+			allocate := func(strategy, priority string, quantity int) (inventory.InventoryAllocationResult, error) {
+				result, err := sd.Handler.ProcessAllocation(ctx, inventory.AllocationRequest{
+					ID:          uuid.New(),
+					ExecutionID: uuid.New(),
+					Config: inventory.AllocateInventoryConfig{
+						InventoryItems:     []inventory.AllocationItem{{ProductID: productID, Quantity: quantity}},
+						AllocationMode:     "allocate",
+						AllocationStrategy: strategy,
+						Priority:           priority,
+						AllowPartial:       true,
+					},
+					Context:   sd.ExecutionContext,
+					Status:    "processing",
+					Priority:  5,
+					CreatedAt: time.Now(),
+				})
+				if err != nil {
+					return inventory.InventoryAllocationResult{}, err
+				}
+				return *result, nil
+			}
+
+			urgent, err := allocate("priority_zone", "critical", 1)
+			if err != nil {
+				return err
+			}
+			if len(urgent.AllocatedItems) != 1 {
+				return fmt.Errorf("priority_zone allocated %d items, want 1", len(urgent.AllocatedItems))
+			}
+
+			fifo, err := allocate("fifo", "medium", reserveItems)
+			if err != nil {
+				return err
+			}
+
+			return pagingResult{
+				PickFaceLocation: urgent.AllocatedItems[0].LocationID,
+				FIFOAllocated:    fifo.TotalAllocated,
+			}
+		},
+		ExpResp: nil,
+		CmpFunc: func(got any, exp any) string {
+			res, ok := got.(pagingResult)
+			if !ok {
+				return fmt.Sprintf("got %v, want a paging result", got)
+			}
+			if res.PickFaceLocation != pickFace {
+				return fmt.Sprintf("urgent allocation drew from %s, want the pick face %s", res.PickFaceLocation, pickFace)
+			}
+			if res.FIFOAllocated != reserveItems {
+				return fmt.Sprintf("fifo allocated %d, want %d from past the first page", res.FIFOAllocated, reserveItems)
+			}
+			return ""
+		},
+	}
+}
@@ -0,0 +1,320 @@
+package inventory
+
+import (
+	"cmp"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+)
+
+// Allocation strategies accepted by allocate_inventory.
+const (
+	StrategyFIFO            = "fifo"             // oldest stock first
+	StrategyLIFO            = "lifo"             // newest stock first
+	StrategyNearestExpiry   = "nearest_expiry"   // FEFO: earliest-expiring usable lot first
+	StrategyLowestCost      = "lowest_cost"      // cheapest landed cost first
+	StrategyNearestLocation = "nearest_location" // warehouse closest to the delivery address first
+	StrategyLoadBalancing   = "load_balancing"   // least busy, least full location first
+	StrategyPriorityZone    = "priority_zone"    // pick faces for urgent orders, reserve for the rest
+)
+
+var allocationStrategies = map[string]bool{
+	StrategyFIFO:            true,
+	StrategyLIFO:            true,
+	StrategyNearestExpiry:   true,
+	StrategyLowestCost:      true,
+	StrategyNearestLocation: true,
+	StrategyLoadBalancing:   true,
+	StrategyPriorityZone:    true,
+}
+
+// Lot quality statuses that may be allocated.
+var allocatableLotStatuses = map[string]bool{"good": true, "released": true}
+
+// priorityRank orders allocation/order priorities; unknown values rank lowest.
+var priorityRank = map[string]int{"low": 1, "medium": 2, "high": 3, "critical": 4}
+
+// LotAllocation is the share of one lot in a location allocation.
+type LotAllocation struct {
+	LotID          uuid.UUID `json:"lot_id"`
+	LotNumber      string    `json:"lot_number"`
+	ExpirationDate time.Time `json:"expiration_date"`
+	Quantity       int       `json:"quantity"`
+	UnitCost       *float64  `json:"unit_cost,omitempty"`
+}
+
+// LocationAllocation is the quantity taken from one inventory item (product at
+// a location). Lots is empty for locations that are not lot-tracked.
+type LocationAllocation struct {
+	InventoryID uuid.UUID       `json:"inventory_item_id"`
+	LocationID  uuid.UUID       `json:"location_id"`
+	WarehouseID uuid.UUID       `json:"warehouse_id"`
+	Quantity    int             `json:"quantity"`
+	Lots        []LotAllocation `json:"lots,omitempty"`
+}
+
+// effectivePriority returns the more urgent of the configured priority and the
+// order's own priority.
+func effectivePriority(configured, order string) string {
+	if priorityRank[order] > priorityRank[configured] {
+		return order
+	}
+	return configured
+}
+
+// candidateStock is what can still be allocated from a candidate: the free
+// quantity and, for lot-tracked locations, the usable lots it comes from.
+type candidateStock struct {
+	available int
+	lots      []inventoryitembus.AllocationLot
+}
+
+// stockOf works out the allocatable stock of a candidate. A location holding
+// any lot of the product is lot-tracked: its stock is capped at the quantity of
+// usable lots (good or released, not expired), so expired or quarantined stock
+// is never allocated. Existing reservations and allocations are assumed to
+// have consumed the earliest-expiring usable lots, which is how FEFO would
+// have placed them.
+func stockOf(c inventoryitembus.AllocationCandidate, now time.Time) candidateStock {
+	free := c.Item.Quantity - c.Item.ReservedQuantity - c.Item.AllocatedQuantity
+	if len(c.Lots) == 0 {
+		return candidateStock{available: max(free, 0)}
+	}
+
+	committed := c.Item.ReservedQuantity + c.Item.AllocatedQuantity
+
+	var lots []inventoryitembus.AllocationLot
+	total := 0
+	for _, lot := range c.Lots {
+		if !allocatableLotStatuses[lot.QualityStatus] || !lot.ExpirationDate.After(now) {
+			continue
+		}
+
+		qty := lot.Quantity
+		if committed > 0 {
+			skip := min(committed, qty)
+			committed -= skip
+			qty -= skip
+		}
+		if qty <= 0 {
+			continue
+		}
+
+		lot.Quantity = qty
+		lots = append(lots, lot)
+		total += qty
+	}
+
+	return candidateStock{available: max(min(free, total), 0), lots: lots}
+}
+
+// proximity scores how close a warehouse is to the destination by the deepest
+// geography level they share: 0 same postal code, 1 same city, 2 same region,
+// 3 same country, 4 unknown or further.
+func proximity(from inventoryitembus.AllocationAddress, to *inventoryitembus.AllocationAddress) int {
+	switch {
+	case to == nil:
+		return 4
+	case to.PostalCode != "" && from.PostalCode == to.PostalCode && from.CityID == to.CityID:
+		return 0
+	case to.CityID != uuid.Nil && from.CityID == to.CityID:
+		return 1
+	case to.RegionID != uuid.Nil && from.RegionID == to.RegionID:
+		return 2
+	case to.CountryID != uuid.Nil && from.CountryID == to.CountryID:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// candidateSort is the order candidates are read in for a strategy, so the
+// candidates read before the quantity is covered are the ones it would rank
+// first.
+func candidateSort(strategy, priority string) inventoryitembus.AllocationSort {
+	switch strategy {
+	case StrategyLIFO:
+		return inventoryitembus.AllocationSortNewest
+	case StrategyNearestExpiry:
+		return inventoryitembus.AllocationSortExpiry
+	case StrategyLowestCost:
+		return inventoryitembus.AllocationSortCost
+	case StrategyNearestLocation:
+		return inventoryitembus.AllocationSortNearest
+	case StrategyLoadBalancing:
+		return inventoryitembus.AllocationSortLeastBusy
+	case StrategyPriorityZone:
+		if priorityRank[priority] >= priorityRank["high"] {
+			return inventoryitembus.AllocationSortPickFaceFirst
+		}
+		return inventoryitembus.AllocationSortReserveFirst
+	default:
+		return inventoryitembus.AllocationSortOldest
+	}
+}
+
+// rankCandidates returns the candidates in the order the strategy allocates
+// from them. Ties, and candidates the strategy cannot score (no lots, no cost),
+// fall back to FIFO so the order is always deterministic.
+func rankCandidates(strategy string, cands []inventoryitembus.AllocationCandidate, dest *inventoryitembus.AllocationAddress, priority string, now time.Time) []inventoryitembus.AllocationCandidate {
+	ranked := make([]inventoryitembus.AllocationCandidate, len(cands))
+	copy(ranked, cands)
+
+	stock := make(map[uuid.UUID]candidateStock, len(ranked))
+	for _, c := range ranked {
+		stock[c.Item.ID] = stockOf(c, now)
+	}
+
+	fifo := func(a, b inventoryitembus.AllocationCandidate) bool {
+		if !a.Item.CreatedDate.Equal(b.Item.CreatedDate) {
+			return a.Item.CreatedDate.Before(b.Item.CreatedDate)
+		}
+		return a.Item.ID.String() < b.Item.ID.String()
+	}
+
+	var less func(a, b inventoryitembus.AllocationCandidate) (decided, before bool)
+
+	switch strategy {
+	case StrategyLIFO:
+		less = func(a, b inventoryitembus.AllocationCandidate) (bool, bool) {
+			if a.Item.CreatedDate.Equal(b.Item.CreatedDate) {
+				return false, false
+			}
+			return true, a.Item.CreatedDate.After(b.Item.CreatedDate)
+		}
+
+	case StrategyNearestExpiry:
+		expiry := func(c inventoryitembus.AllocationCandidate) *time.Time {
+			if lots := stock[c.Item.ID].lots; len(lots) > 0 {
+				return &lots[0].ExpirationDate
+			}
+			return nil
+		}
+		less = func(a, b inventoryitembus.AllocationCandidate) (bool, bool) {
+			return compareOptional(expiry(a), expiry(b), func(x, y time.Time) int { return x.Compare(y) })
+		}
+
+	case StrategyLowestCost:
+		cost := func(c inventoryitembus.AllocationCandidate) *float64 {
+			if lots := stock[c.Item.ID].lots; len(lots) > 0 && lots[0].UnitCost != nil {
+				return lots[0].UnitCost
+			}
+			return c.UnitCost
+		}
+		less = func(a, b inventoryitembus.AllocationCandidate) (bool, bool) {
+			return compareOptional(cost(a), cost(b), cmp.Compare[float64])
+		}
+
+	case StrategyNearestLocation:
+		less = func(a, b inventoryitembus.AllocationCandidate) (bool, bool) {
+			pa, pb := proximity(a.WarehouseAddress, dest), proximity(b.WarehouseAddress, dest)
+			return pa != pb, pa < pb
+		}
+
+	case StrategyLoadBalancing:
+		less = func(a, b inventoryitembus.AllocationCandidate) (bool, bool) {
+			if a.PendingPickQuantity != b.PendingPickQuantity {
+				return true, a.PendingPickQuantity < b.PendingPickQuantity
+			}
+			if a.CurrentUtilization != b.CurrentUtilization {
+				return true, a.CurrentUtilization < b.CurrentUtilization
+			}
+			return false, false
+		}
+
+	case StrategyPriorityZone:
+		// Urgent orders take pick faces first so they ship without a
+		// replenishment move; everything else draws down reserve stock first
+		// to keep pick faces free for the urgent ones.
+		urgent := priorityRank[priority] >= priorityRank["high"]
+		zoneRank := func(c inventoryitembus.AllocationCandidate) int {
+			if urgent && c.IsPickLocation || !urgent && c.IsReserveLocation {
+				return 0
+			}
+			return 1
+		}
+		less = func(a, b inventoryitembus.AllocationCandidate) (bool, bool) {
+			ra, rb := zoneRank(a), zoneRank(b)
+			return ra != rb, ra < rb
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if less != nil {
+			if decided, before := less(ranked[i], ranked[j]); decided {
+				return before
+			}
+		}
+		return fifo(ranked[i], ranked[j])
+	})
+
+	return ranked
+}
+
+// compareOptional orders present values before absent ones and present values
+// by compare. It reports whether the pair was decided and, if so, whether a comes
+// first.
+func compareOptional[T any](a, b *T, compare func(x, y T) int) (decided, before bool) {
+	switch {
+	case a == nil && b == nil:
+		return false, false
+	case a == nil:
+		return true, false
+	case b == nil:
+		return true, true
+	}
+
+	c := compare(*a, *b)
+	return c != 0, c < 0
+}
+
+// planAllocation splits quantity across the ranked candidates, and within a
+// lot-tracked location across its lots earliest expiry first. It returns the
+// per-location breakdown and the total planned, which is less than quantity
+// when stock runs out.
+func planAllocation(ranked []inventoryitembus.AllocationCandidate, quantity int, now time.Time) ([]LocationAllocation, int) {
+	var plan []LocationAllocation
+	remaining := quantity
+
+	for _, c := range ranked {
+		if remaining <= 0 {
+			break
+		}
+
+		stock := stockOf(c, now)
+		take := min(remaining, stock.available)
+		if take <= 0 {
+			continue
+		}
+
+		la := LocationAllocation{
+			InventoryID: c.Item.ID,
+			LocationID:  c.Item.LocationID,
+			WarehouseID: c.WarehouseID,
+			Quantity:    take,
+		}
+
+		left := take
+		for _, lot := range stock.lots {
+			if left <= 0 {
+				break
+			}
+			q := min(left, lot.Quantity)
+			la.Lots = append(la.Lots, LotAllocation{
+				LotID:          lot.LotID,
+				LotNumber:      lot.LotNumber,
+				ExpirationDate: lot.ExpirationDate,
+				Quantity:       q,
+				UnitCost:       lot.UnitCost,
+			})
+			left -= q
+		}
+
+		plan = append(plan, la)
+		remaining -= take
+	}
+
+	return plan, quantity - remaining
+}
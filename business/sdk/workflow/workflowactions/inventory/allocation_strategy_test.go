@@ -0,0 +1,227 @@
+package inventory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/inventory"
+)
+
+var strategyNow = time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func candidate(name string, age time.Duration, qty int) inventoryitembus.AllocationCandidate {
+	return inventoryitembus.AllocationCandidate{
+		Item: inventoryitembus.InventoryItem{
+			ID:          uuid.NewSHA1(uuid.NameSpaceOID, []byte(name)),
+			LocationID:  uuid.NewSHA1(uuid.NameSpaceURL, []byte(name)),
+			Quantity:    qty,
+			CreatedDate: strategyNow.Add(-age),
+		},
+	}
+}
+
+func lot(number, status string, qty int, expiresIn time.Duration) inventoryitembus.AllocationLot {
+	return inventoryitembus.AllocationLot{
+		LotID:          uuid.NewSHA1(uuid.NameSpaceOID, []byte("lot-"+number)),
+		LotNumber:      number,
+		Quantity:       qty,
+		QualityStatus:  status,
+		ExpirationDate: strategyNow.Add(expiresIn),
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func order(ranked []inventoryitembus.AllocationCandidate, names map[uuid.UUID]string) []string {
+	out := make([]string, len(ranked))
+	for i, c := range ranked {
+		out[i] = names[c.Item.ID]
+	}
+	return out
+}
+
+func Test_RankCandidates(t *testing.T) {
+	const day = 24 * time.Hour
+
+	old := candidate("old", 30*day, 10)
+	mid := candidate("mid", 20*day, 10)
+	young := candidate("young", 10*day, 10)
+
+	names := map[uuid.UUID]string{old.Item.ID: "old", mid.Item.ID: "mid", young.Item.ID: "young"}
+
+	city, region, country := uuid.New(), uuid.New(), uuid.New()
+	dest := &inventoryitembus.AllocationAddress{PostalCode: "10001", CityID: city, RegionID: region, CountryID: country}
+
+	tests := []struct {
+		name     string
+		strategy string
+		priority string
+		setup    func(o, m, y *inventoryitembus.AllocationCandidate)
+		want     []string
+	}{
+		{
+			name:     "fifo",
+			strategy: inventory.StrategyFIFO,
+			want:     []string{"old", "mid", "young"},
+		},
+		{
+			name:     "lifo",
+			strategy: inventory.StrategyLIFO,
+			want:     []string{"young", "mid", "old"},
+		},
+		{
+			name:     "nearest_expiry: earliest usable lot first, untracked last",
+			strategy: inventory.StrategyNearestExpiry,
+			setup: func(o, m, y *inventoryitembus.AllocationCandidate) {
+				m.Lots = []inventoryitembus.AllocationLot{lot("M1", "good", 10, 40*day)}
+				y.Lots = []inventoryitembus.AllocationLot{
+					lot("Y0", "quarantined", 5, 1*day),
+					lot("Y1", "released", 10, 5*day),
+				}
+			},
+			want: []string{"young", "mid", "old"},
+		},
+		{
+			name:     "lowest_cost: cheapest first, unknown cost last",
+			strategy: inventory.StrategyLowestCost,
+			setup: func(o, m, y *inventoryitembus.AllocationCandidate) {
+				m.UnitCost = ptr(4.50)
+				y.UnitCost = ptr(3.75)
+			},
+			want: []string{"young", "mid", "old"},
+		},
+		{
+			name:     "nearest_location: postal code beats city beats region",
+			strategy: inventory.StrategyNearestLocation,
+			setup: func(o, m, y *inventoryitembus.AllocationCandidate) {
+				o.WarehouseAddress = inventoryitembus.AllocationAddress{PostalCode: "94105", CityID: uuid.New(), RegionID: region, CountryID: country}
+				m.WarehouseAddress = inventoryitembus.AllocationAddress{PostalCode: "10001", CityID: city, RegionID: region, CountryID: country}
+				y.WarehouseAddress = inventoryitembus.AllocationAddress{PostalCode: "10002", CityID: city, RegionID: region, CountryID: country}
+			},
+			want: []string{"mid", "young", "old"},
+		},
+		{
+			name:     "load_balancing: fewest pending picks, then least utilized",
+			strategy: inventory.StrategyLoadBalancing,
+			setup: func(o, m, y *inventoryitembus.AllocationCandidate) {
+				o.PendingPickQuantity = 40
+				m.PendingPickQuantity, m.CurrentUtilization = 0, 0.9
+				y.PendingPickQuantity, y.CurrentUtilization = 0, 0.2
+			},
+			want: []string{"young", "mid", "old"},
+		},
+		{
+			name:     "priority_zone: urgent orders take pick locations first",
+			strategy: inventory.StrategyPriorityZone,
+			priority: "critical",
+			setup: func(o, m, y *inventoryitembus.AllocationCandidate) {
+				o.IsReserveLocation = true
+				m.IsReserveLocation = true
+				y.IsPickLocation = true
+			},
+			want: []string{"young", "old", "mid"},
+		},
+		{
+			name:     "priority_zone: routine orders draw down reserve first",
+			strategy: inventory.StrategyPriorityZone,
+			priority: "low",
+			setup: func(o, m, y *inventoryitembus.AllocationCandidate) {
+				o.IsPickLocation = true
+				m.IsReserveLocation = true
+				y.IsPickLocation = true
+			},
+			want: []string{"mid", "old", "young"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, m, y := old, mid, young
+			if tt.setup != nil {
+				tt.setup(&o, &m, &y)
+			}
+
+			ranked := inventory.RankCandidates(tt.strategy, []inventoryitembus.AllocationCandidate{y, o, m}, dest, tt.priority, strategyNow)
+
+			got := order(ranked, names)
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("order = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func Test_CandidateSort(t *testing.T) {
+	tests := []struct {
+		strategy string
+		priority string
+		want     inventoryitembus.AllocationSort
+	}{
+		{"fifo", "medium", inventoryitembus.AllocationSortOldest},
+		{"lifo", "medium", inventoryitembus.AllocationSortNewest},
+		{"nearest_expiry", "medium", inventoryitembus.AllocationSortExpiry},
+		{"lowest_cost", "medium", inventoryitembus.AllocationSortCost},
+		{"nearest_location", "medium", inventoryitembus.AllocationSortNearest},
+		{"load_balancing", "medium", inventoryitembus.AllocationSortLeastBusy},
+		{"priority_zone", "high", inventoryitembus.AllocationSortPickFaceFirst},
+		{"priority_zone", "medium", inventoryitembus.AllocationSortReserveFirst},
+	}
+
+	for _, tt := range tests {
+		if got := inventory.CandidateSort(tt.strategy, tt.priority); got != tt.want {
+			t.Errorf("CandidateSort(%q, %q) = %q, want %q", tt.strategy, tt.priority, got, tt.want)
+		}
+	}
+}
+
+func Test_PlanAllocation_SplitsAcrossLocationsAndLots(t *testing.T) {
+	const day = 24 * time.Hour
+
+	// 12 on hand, 2 already reserved. The reservation is taken from the
+	// earliest usable lot (A), the expired lot is never allocated.
+	first := candidate("first", 10*day, 12)
+	first.Item.ReservedQuantity = 2
+	first.Lots = []inventoryitembus.AllocationLot{
+		lot("X", "good", 3, -1*day),
+		lot("A", "good", 4, 3*day),
+		lot("B", "released", 5, 9*day),
+	}
+
+	second := candidate("second", 5*day, 20)
+
+	plan, total := inventory.PlanAllocation([]inventoryitembus.AllocationCandidate{first, second}, 10, strategyNow)
+
+	if total != 10 {
+		t.Fatalf("total = %d, want 10", total)
+	}
+	if len(plan) != 2 {
+		t.Fatalf("locations = %d, want 2: %+v", len(plan), plan)
+	}
+
+	if plan[0].InventoryID != first.Item.ID || plan[0].Quantity != 7 {
+		t.Fatalf("first location = %+v, want 7 from the lot-tracked item", plan[0])
+	}
+	if len(plan[0].Lots) != 2 ||
+		plan[0].Lots[0].LotNumber != "A" || plan[0].Lots[0].Quantity != 2 ||
+		plan[0].Lots[1].LotNumber != "B" || plan[0].Lots[1].Quantity != 5 {
+		t.Fatalf("lots = %+v, want A:2 then B:5", plan[0].Lots)
+	}
+
+	if plan[1].InventoryID != second.Item.ID || plan[1].Quantity != 3 || len(plan[1].Lots) != 0 {
+		t.Fatalf("second location = %+v, want 3 with no lots", plan[1])
+	}
+}
+
+func Test_PlanAllocation_ShortWhenOnlyUnusableLots(t *testing.T) {
+	c := candidate("quarantined", time.Hour, 8)
+	c.Lots = []inventoryitembus.AllocationLot{lot("Q", "quarantined", 8, 30*24*time.Hour)}
+
+	plan, total := inventory.PlanAllocation([]inventoryitembus.AllocationCandidate{c}, 5, strategyNow)
+	if total != 0 || len(plan) != 0 {
+		t.Fatalf("plan = %+v total = %d, want nothing from a quarantined-only location", plan, total)
+	}
+}
@@ -126,5 +126,6 @@ func (h *ApproveTransferOrderHandler) Execute(ctx context.Context, config json.R
 		"transfer_order_id": approved.TransferID.String(),
 		"approved_by":       execCtx.UserID.String(),
 		"approval_reason":   cfg.ApprovalReason,
+		"total_quantity":    approved.Quantity,
 	}, nil
 }
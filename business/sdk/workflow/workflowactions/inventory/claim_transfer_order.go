@@ -2,13 +2,18 @@ package inventory
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/transferorderbus"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
 	"github.com/timmaaaz/ichor/business/sdk/workflow"
 	"github.com/timmaaaz/ichor/foundation/logger"
 )
@@ -18,16 +23,34 @@ type ClaimTransferOrderConfig struct {
 	TransferOrderID string `json:"transfer_order_id"`
 }
 
-// ClaimTransferOrderHandler handles claim_transfer_order actions: it moves an
-// approved transfer order to in_transit, recording who claimed it.
+// ClaimTransferOrderHandler handles claim_transfer_order actions: it ships an
+// approved transfer order — status -> in_transit, recording who claimed it, and
+// every line's stock moved from the source location into the in-transit
+// location with a TRANSFER_OUT/TRANSFER_IN ledger pair — in a single
+// transaction, mirroring transferorderapp.Claim.
 type ClaimTransferOrderHandler struct {
-	log              *logger.Logger
-	transferOrderBus *transferorderbus.Business
+	log               *logger.Logger
+	db                *sqlx.DB
+	transferOrderBus  *transferorderbus.Business
+	invTransactionBus *inventorytransactionbus.Business
+	invItemBus        *inventoryitembus.Business
 }
 
 // NewClaimTransferOrderHandler creates a new claim transfer order handler.
-func NewClaimTransferOrderHandler(log *logger.Logger, transferOrderBus *transferorderbus.Business) *ClaimTransferOrderHandler {
-	return &ClaimTransferOrderHandler{log: log, transferOrderBus: transferOrderBus}
+func NewClaimTransferOrderHandler(
+	log *logger.Logger,
+	db *sqlx.DB,
+	transferOrderBus *transferorderbus.Business,
+	invTransactionBus *inventorytransactionbus.Business,
+	invItemBus *inventoryitembus.Business,
+) *ClaimTransferOrderHandler {
+	return &ClaimTransferOrderHandler{
+		log:               log,
+		db:                db,
+		transferOrderBus:  transferOrderBus,
+		invTransactionBus: invTransactionBus,
+		invItemBus:        invItemBus,
+	}
 }
 
 // GetType returns the action type.
@@ -41,7 +64,7 @@ func (h *ClaimTransferOrderHandler) SupportsManualExecution() bool { return true
 
 // GetDescription returns a human-readable description.
 func (h *ClaimTransferOrderHandler) GetDescription() string {
-	return "Claim an approved transfer order, moving it to in_transit and recording the claimer"
+	return "Claim an approved transfer order, shipping its lines into transit and recording the claimer"
 }
 
 // Validate validates the claim transfer order configuration.
@@ -66,11 +89,13 @@ func (h *ClaimTransferOrderHandler) Validate(config json.RawMessage) error {
 // GetOutputPorts implements workflow.OutputPortProvider.
 func (h *ClaimTransferOrderHandler) GetOutputPorts() []workflow.OutputPort {
 	return []workflow.OutputPort{
-		{Name: "claimed", Description: "Transfer order claimed (now in_transit)", IsDefault: true},
+		{Name: "claimed", Description: "Transfer order claimed and shipped (now in_transit)", IsDefault: true},
 		{Name: "not_found", Description: "Transfer order not found"},
 		{Name: "already_in_transit", Description: "Transfer order was already in_transit (idempotent)"},
 		{Name: "already_completed", Description: "Transfer order was already completed — cannot claim"},
 		{Name: "not_approved", Description: "Transfer order is not approved — cannot claim"},
+		{Name: "no_lines", Description: "Transfer order has no lines to ship"},
+		{Name: "insufficient_stock", Description: "Source location has insufficient stock to ship a line"},
 		{Name: "failure", Description: "Unexpected error"},
 	}
 }
@@ -81,9 +106,10 @@ func (h *ClaimTransferOrderHandler) GetEntityModifications(config json.RawMessag
 		{
 			EntityName: "inventory.transfer_orders",
 			EventType:  "on_update",
-			Fields:     []string{"status", "claimed_by", "claimed_at"},
-			// status moves to the fixed enum constant. claimed_by (runtime user) and
-			// claimed_at (now) are left indeterminate (no Change entry).
+			Fields:     []string{"status", "claimed_by", "claimed_at", "transit_location_id"},
+			// status moves to the fixed enum constant. claimed_by (runtime user), claimed_at
+			// (now) and transit_location_id (the source warehouse's transit location) are left
+			// indeterminate (no Change entry).
 			Changes: []workflow.ProducedChange{
 				{FieldName: "status", Operator: workflow.OperatorChangedTo, Value: transferorderbus.StatusInTransit},
 			},
@@ -113,6 +139,10 @@ func (h *ClaimTransferOrderHandler) Execute(ctx context.Context, config json.Raw
 		return map[string]any{"output": "failure", "error": "no transfer order id"}, nil
 	}
 
+	if h.transferOrderBus == nil || h.invTransactionBus == nil || h.invItemBus == nil || h.db == nil {
+		return map[string]any{"output": "failure", "error": "transfer order buses not configured"}, nil
+	}
+
 	to, err := h.transferOrderBus.QueryByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, transferorderbus.ErrNotFound) {
@@ -122,7 +152,7 @@ func (h *ClaimTransferOrderHandler) Execute(ctx context.Context, config json.Raw
 	}
 
 	switch to.Status {
-	case transferorderbus.StatusInTransit:
+	case transferorderbus.StatusInTransit, transferorderbus.StatusPartiallyReceived:
 		return map[string]any{"output": "already_in_transit", "transfer_order_id": id.String()}, nil
 	case transferorderbus.StatusCompleted:
 		return map[string]any{"output": "already_completed", "transfer_order_id": id.String()}, nil
@@ -131,17 +161,58 @@ func (h *ClaimTransferOrderHandler) Execute(ctx context.Context, config json.Raw
 		return map[string]any{"output": "not_approved", "transfer_order_id": id.String(), "current_status": to.Status}, nil
 	}
 
-	claimed, err := h.transferOrderBus.Claim(ctx, to, execCtx.UserID)
+	// Ship the transfer and move the stock atomically: status -> in_transit and, per line, a
+	// TRANSFER_OUT/TRANSFER_IN ledger pair, source decrement and transit increment — all in
+	// one transaction, mirroring transferorderapp.Claim so the button equals the REST path.
+	tx, err := h.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Carry the tx on ctx so each cascade bus's WriteAtomic JOINs THIS transaction
+	// instead of opening its own.
+	ctx = sqldb.WithTx(ctx, tx)
+
+	txTransferBus, err := h.transferOrderBus.NewWithTx(tx)
+	if err != nil {
+		return nil, fmt.Errorf("tx transfer order bus: %w", err)
+	}
+	claimed, moves, err := txTransferBus.Claim(ctx, to, execCtx.UserID)
 	if err != nil {
-		if errors.Is(err, transferorderbus.ErrInvalidTransferStatus) {
+		switch {
+		case errors.Is(err, transferorderbus.ErrNoLines):
+			return map[string]any{"output": "no_lines", "transfer_order_id": id.String()}, nil
+		case errors.Is(err, transferorderbus.ErrInvalidTransferStatus):
 			return map[string]any{"output": "failure", "error": "transfer order status changed concurrently"}, nil
 		}
 		return nil, fmt.Errorf("claim transfer order: %w", err)
 	}
 
+	txInvTxnBus, err := h.invTransactionBus.NewWithTx(tx)
+	if err != nil {
+		return nil, fmt.Errorf("tx inventory transaction bus: %w", err)
+	}
+	txItemBus, err := h.invItemBus.NewWithTx(tx)
+	if err != nil {
+		return nil, fmt.Errorf("tx inventory item bus: %w", err)
+	}
+	if err := moveTransferStock(ctx, txInvTxnBus, txItemBus, claimed, execCtx.UserID, to.FromLocationID, claimed.InTransitLocationID(), moves); err != nil {
+		if errors.Is(err, inventoryitembus.ErrInsufficientStock) {
+			return map[string]any{"output": "insufficient_stock", "transfer_order_id": id.String()}, nil
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
 	return map[string]any{
-		"output":            "claimed",
-		"transfer_order_id": claimed.TransferID.String(),
-		"claimed_by":        execCtx.UserID.String(),
+		"output":              "claimed",
+		"transfer_order_id":   claimed.TransferID.String(),
+		"claimed_by":          execCtx.UserID.String(),
+		"transit_location_id": claimed.InTransitLocationID().String(),
+		"line_count":          len(moves),
 	}, nil
 }
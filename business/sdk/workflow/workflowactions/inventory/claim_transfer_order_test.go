@@ -12,7 +12,7 @@ import (
 )
 
 func TestClaimTransferOrder_Validate(t *testing.T) {
-	handler := inventory.NewClaimTransferOrderHandler(nil, nil)
+	handler := inventory.NewClaimTransferOrderHandler(nil, nil, nil, nil, nil)
 
 	tests := []struct {
 		name      string
@@ -50,7 +50,7 @@ func TestClaimTransferOrder_Validate(t *testing.T) {
 }
 
 func TestClaimTransferOrder_Metadata(t *testing.T) {
-	handler := inventory.NewClaimTransferOrderHandler(nil, nil)
+	handler := inventory.NewClaimTransferOrderHandler(nil, nil, nil, nil, nil)
 
 	if got := handler.GetType(); got != "claim_transfer_order" {
 		t.Fatalf("expected claim_transfer_order, got %s", got)
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryreservationbus"
 	"github.com/timmaaaz/ichor/business/sdk/order"
 	"github.com/timmaaaz/ichor/business/sdk/page"
 	"github.com/timmaaaz/ichor/business/sdk/sqldb"
@@ -40,6 +41,7 @@ type CommitAllocationHandler struct {
 	log              *logger.Logger
 	db               *sqlx.DB
 	inventoryItemBus *inventoryitembus.Business
+	reservationBus   *inventoryreservationbus.Business
 }
 
 // NewCommitAllocationHandler creates a new commit allocation handler.
@@ -47,11 +49,13 @@ func NewCommitAllocationHandler(
 	log *logger.Logger,
 	db *sqlx.DB,
 	inventoryItemBus *inventoryitembus.Business,
+	reservationBus *inventoryreservationbus.Business,
 ) *CommitAllocationHandler {
 	return &CommitAllocationHandler{
 		log:              log,
 		db:               db,
 		inventoryItemBus: inventoryItemBus,
+		reservationBus:   reservationBus,
 	}
 }
 
@@ -155,7 +159,12 @@ func (h *CommitAllocationHandler) Execute(ctx context.Context, config json.RawMe
 		return CommitAllocationResult{}, fmt.Errorf("no inventory item found for product %s at location %s", productID, locationID)
 	}
 
-	item := items[0]
+	// Lock the row: the reservation reaper updates reserved_quantity
+	// concurrently, and the update below writes an absolute value.
+	item, err := txItemBus.QueryByIDForUpdate(ctx, items[0].ID)
+	if err != nil {
+		return CommitAllocationResult{}, fmt.Errorf("lock inventory item: %w", err)
+	}
 
 	// Validate sufficient reserved quantity.
 	if item.ReservedQuantity < cfg.Quantity {
@@ -176,6 +185,15 @@ func (h *CommitAllocationHandler) Execute(ctx context.Context, config json.RawMe
 		return CommitAllocationResult{}, fmt.Errorf("update inventory item: %w", err)
 	}
 
+	txReservationBus, err := reservationsWithTx(h.reservationBus, tx)
+	if err != nil {
+		return CommitAllocationResult{}, err
+	}
+
+	if err := consumeHolds(ctx, txReservationBus, item.ID, cfg.Quantity, inventoryreservationbus.StatusCommitted); err != nil {
+		return CommitAllocationResult{}, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return CommitAllocationResult{}, fmt.Errorf("commit transaction: %w", err)
 	}
@@ -44,7 +44,7 @@ func Test_CommitAllocation(t *testing.T) {
 		return otel.GetTraceID(context.Background())
 	})
 
-	sd.Handler = inventory.NewCommitAllocationHandler(log, db.DB, db.BusDomain.InventoryItem)
+	sd.Handler = inventory.NewCommitAllocationHandler(log, db.DB, db.BusDomain.InventoryItem, db.BusDomain.InventoryReservation)
 
 	unitest.Run(t, commitAllocationTests(db.BusDomain, db.DB, sd), "commitAllocation")
 }
@@ -10,7 +10,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/putawaytaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/zonebus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/supplierproductbus"
 	"github.com/timmaaaz/ichor/business/sdk/sqldb"
@@ -30,11 +32,33 @@ type CreatePutAwayTaskConfig struct {
 	// LocationStrategy determines how the destination location is resolved.
 	// "po_delivery" — use the PO's delivery_location_id (requires PO lookup via RawData["purchase_order_id"])
 	// "static"      — use LocationID field below
+	// "directed"    — slot the stock into the best-ranked bin (see the directed fields below)
 	LocationStrategy string `json:"location_strategy"`
 
 	// LocationID is a static location UUID. Used when LocationStrategy is "static".
 	LocationID string `json:"location_id,omitempty"`
 
+	// The fields below apply to the "directed" strategy.
+
+	// WarehouseID limits slotting to one warehouse. Defaults to the warehouse of
+	// the PO's delivery location when purchase_order_id is in RawData.
+	WarehouseID string `json:"warehouse_id,omitempty"`
+
+	// BinPreference is "pick_face", "reserve" or empty for no preference.
+	BinPreference string `json:"bin_preference,omitempty"`
+
+	// ZoneStages are the zone stages stock may be put away into besides
+	// unstaged zones. Defaults to ["received"].
+	ZoneStages []string `json:"zone_stages,omitempty"`
+
+	// MaxBinWeight is the weight an empty bin holds, in the product's weight
+	// unit. Weight is not checked when unset.
+	MaxBinWeight *float64 `json:"max_bin_weight,omitempty"`
+
+	// FallbackLocationID receives the stock when no bin has room for all of it.
+	// Without one, the action routes to no_location with the ranked candidates.
+	FallbackLocationID string `json:"fallback_location_id,omitempty"`
+
 	// ReferenceNumber is a template string. Supports {{variable}} substitution from RawData.
 	// Example: "PO-RCV-{{purchase_order_id}}"
 	// Defaults to "PO-<purchase_order_id>" when empty and purchase_order_id is in RawData.
@@ -47,15 +71,17 @@ type CreatePutAwayTaskConfig struct {
 // Execute returns map[string]any with key "output" (string) and one of:
 //   - "created"  — task created; also includes "task_id" string
 //   - "skipped"  — delta <= 0, no task needed
-//   - "no_location"      — po_delivery strategy but PO has no delivery_location_id
+//   - "no_location"      — po_delivery strategy but PO has no delivery_location_id, or
+//     directed strategy found no bin with room and has no fallback location
 //   - "product_not_found" — supplier_product_id lookup failed
 //   - "failure"           — unexpected error
 type CreatePutAwayTaskHandler struct {
-	log                *logger.Logger
-	db                 *sqlx.DB
-	putAwayTaskBus     *putawaytaskbus.Business
-	supplierProductBus *supplierproductbus.Business
-	purchaseOrderBus   *purchaseorderbus.Business
+	log                  *logger.Logger
+	db                   *sqlx.DB
+	putAwayTaskBus       *putawaytaskbus.Business
+	supplierProductBus   *supplierproductbus.Business
+	purchaseOrderBus     *purchaseorderbus.Business
+	inventoryLocationBus *inventorylocationbus.Business
 }
 
 // NewCreatePutAwayTaskHandler creates a new CreatePutAwayTaskHandler.
@@ -66,13 +92,15 @@ func NewCreatePutAwayTaskHandler(
 	putAwayTaskBus *putawaytaskbus.Business,
 	supplierProductBus *supplierproductbus.Business,
 	purchaseOrderBus *purchaseorderbus.Business,
+	inventoryLocationBus *inventorylocationbus.Business,
 ) *CreatePutAwayTaskHandler {
 	return &CreatePutAwayTaskHandler{
-		log:                log,
-		db:                 db,
-		putAwayTaskBus:     putAwayTaskBus,
-		supplierProductBus: supplierProductBus,
-		purchaseOrderBus:   purchaseOrderBus,
+		log:                  log,
+		db:                   db,
+		putAwayTaskBus:       putAwayTaskBus,
+		supplierProductBus:   supplierProductBus,
+		purchaseOrderBus:     purchaseOrderBus,
+		inventoryLocationBus: inventoryLocationBus,
 	}
 }
 
@@ -103,8 +131,8 @@ func (h *CreatePutAwayTaskHandler) Validate(config json.RawMessage) error {
 		return fmt.Errorf("invalid config: %w", err)
 	}
 
-	if cfg.LocationStrategy != "po_delivery" && cfg.LocationStrategy != "static" {
-		return fmt.Errorf("location_strategy must be 'po_delivery' or 'static', got %q", cfg.LocationStrategy)
+	if cfg.LocationStrategy != "po_delivery" && cfg.LocationStrategy != "static" && cfg.LocationStrategy != "directed" {
+		return fmt.Errorf("location_strategy must be 'po_delivery', 'static' or 'directed', got %q", cfg.LocationStrategy)
 	}
 
 	if !cfg.SourceFromPO {
@@ -125,15 +153,70 @@ func (h *CreatePutAwayTaskHandler) Validate(config json.RawMessage) error {
 		}
 	}
 
+	if cfg.LocationStrategy == "directed" {
+		if _, err := cfg.slotRequest(uuid.Nil, 1, nil, nil); err != nil {
+			return err
+		}
+		if cfg.FallbackLocationID != "" {
+			if _, err := uuid.Parse(cfg.FallbackLocationID); err != nil {
+				return fmt.Errorf("invalid fallback_location_id: %w", err)
+			}
+		}
+	}
+
 	return nil
 }
 
+// slotRequest builds the slotting request for the directed strategy. The
+// configured warehouse wins over the one resolved from the trigger.
+func (cfg CreatePutAwayTaskConfig) slotRequest(productID uuid.UUID, quantity int, lotID, warehouseID *uuid.UUID) (inventorylocationbus.SlotRequest, error) {
+	req := inventorylocationbus.SlotRequest{
+		ProductID:     productID,
+		Quantity:      quantity,
+		LotID:         lotID,
+		WarehouseID:   warehouseID,
+		BinPreference: cfg.BinPreference,
+		MaxBinWeight:  cfg.MaxBinWeight,
+	}
+
+	if cfg.WarehouseID != "" {
+		id, err := uuid.Parse(cfg.WarehouseID)
+		if err != nil {
+			return inventorylocationbus.SlotRequest{}, fmt.Errorf("invalid warehouse_id: %w", err)
+		}
+		req.WarehouseID = &id
+	}
+
+	if cfg.ZoneStages != nil {
+		req.Stages = make([]zonebus.Stage, len(cfg.ZoneStages))
+		for i, name := range cfg.ZoneStages {
+			st, err := zonebus.ParseStage(name)
+			if err != nil {
+				return inventorylocationbus.SlotRequest{}, fmt.Errorf("invalid zone_stages: %w", err)
+			}
+			req.Stages[i] = st
+		}
+	}
+
+	if req.BinPreference != inventorylocationbus.BinPreferenceNone &&
+		req.BinPreference != inventorylocationbus.BinPreferencePickFace &&
+		req.BinPreference != inventorylocationbus.BinPreferenceReserve {
+		return inventorylocationbus.SlotRequest{}, fmt.Errorf("bin_preference must be 'pick_face', 'reserve' or empty, got %q", req.BinPreference)
+	}
+
+	if req.MaxBinWeight != nil && *req.MaxBinWeight <= 0 {
+		return inventorylocationbus.SlotRequest{}, fmt.Errorf("max_bin_weight must be positive")
+	}
+
+	return req, nil
+}
+
 // GetOutputPorts implements workflow.OutputPortProvider.
 func (h *CreatePutAwayTaskHandler) GetOutputPorts() []workflow.OutputPort {
 	return []workflow.OutputPort{
 		{Name: "created", Description: "Put-away task created successfully", IsDefault: true},
 		{Name: "skipped", Description: "Delta <= 0, no task needed"},
-		{Name: "no_location", Description: "PO delivery strategy but PO has no delivery_location_id"},
+		{Name: "no_location", Description: "No destination: PO has no delivery_location_id, or no bin has room and no fallback is set"},
 		{Name: "product_not_found", Description: "Supplier product lookup failed"},
 		{Name: "failure", Description: "Unexpected error during task creation"},
 	}
@@ -206,7 +289,15 @@ func (h *CreatePutAwayTaskHandler) Execute(ctx context.Context, config json.RawM
 
 	// Step 3: Resolve location_id.
 	var locationID uuid.UUID
-	if cfg.LocationStrategy == "po_delivery" {
+	var slotted map[string]any
+	switch cfg.LocationStrategy {
+	case "directed":
+		var result map[string]any
+		locationID, slotted, result = h.slot(ctx, cfg, productID, delta, execCtx)
+		if result != nil {
+			return result, nil
+		}
+	case "po_delivery":
 		if h.purchaseOrderBus == nil {
 			return map[string]any{"output": "failure", "error": "purchase order bus not configured"}, nil
 		}
@@ -228,7 +319,7 @@ func (h *CreatePutAwayTaskHandler) Execute(ctx context.Context, config json.RawM
 			return map[string]any{"output": "no_location"}, nil
 		}
 		locationID = po.DeliveryLocationID
-	} else {
+	default:
 		locationID, _ = uuid.Parse(cfg.LocationID)
 	}
 
@@ -292,10 +383,97 @@ func (h *CreatePutAwayTaskHandler) Execute(ctx context.Context, config json.RawM
 	h.log.Info(ctx, "create_put_away_task: task created",
 		"task_id", task.ID, "product_id", productID, "location_id", locationID, "quantity", delta)
 
-	return map[string]any{
-		"output":  "created",
-		"task_id": task.ID.String(),
-	}, nil
+	result := map[string]any{
+		"output":      "created",
+		"task_id":     task.ID.String(),
+		"location_id": locationID.String(),
+	}
+	for k, v := range slotted {
+		result[k] = v
+	}
+
+	return result, nil
+}
+
+// slot picks the destination bin for the directed strategy. It returns the
+// chosen location and the slotting details to add to the created result, or a
+// terminal result when no location could be chosen.
+func (h *CreatePutAwayTaskHandler) slot(ctx context.Context, cfg CreatePutAwayTaskConfig, productID uuid.UUID, quantity int, execCtx workflow.ActionExecutionContext) (uuid.UUID, map[string]any, map[string]any) {
+	if h.inventoryLocationBus == nil {
+		return uuid.Nil, nil, map[string]any{"output": "failure", "error": "inventory location bus not configured"}
+	}
+
+	var lotID *uuid.UUID
+	if s, ok := execCtx.RawData["lot_id"].(string); ok {
+		if id, err := uuid.Parse(s); err == nil {
+			lotID = &id
+		}
+	}
+
+	req, err := cfg.slotRequest(productID, quantity, lotID, h.deliveryWarehouse(ctx, execCtx))
+	if err != nil {
+		return uuid.Nil, nil, map[string]any{"output": "failure", "error": err.Error()}
+	}
+
+	slotting, err := h.inventoryLocationBus.Slot(ctx, req)
+	if err != nil {
+		h.log.Error(ctx, "create_put_away_task: slotting failed", "product_id", productID, "error", err)
+		return uuid.Nil, nil, map[string]any{"output": "failure", "error": err.Error()}
+	}
+
+	if best, ok := slotting.Best(); ok {
+		return best.LocationID, map[string]any{
+			"candidates": slotting.Candidates,
+			"reasons":    best.Reasons,
+		}, nil
+	}
+
+	reason := fmt.Sprintf("no bin has room for all %d units", quantity)
+	if cfg.FallbackLocationID != "" {
+		fallbackID, _ := uuid.Parse(cfg.FallbackLocationID)
+		h.log.Info(ctx, "create_put_away_task: no bin fits, using fallback location",
+			"product_id", productID, "quantity", quantity, "fallback_location_id", fallbackID)
+		return fallbackID, map[string]any{
+			"fallback":   true,
+			"reason":     reason,
+			"candidates": slotting.Candidates,
+		}, nil
+	}
+
+	h.log.Info(ctx, "create_put_away_task: no bin fits and no fallback location",
+		"product_id", productID, "quantity", quantity, "rejected", slotting.Rejected)
+	return uuid.Nil, nil, map[string]any{
+		"output":     "no_location",
+		"reason":     reason,
+		"candidates": slotting.Candidates,
+		"rejected":   slotting.Rejected,
+	}
+}
+
+// deliveryWarehouse returns the warehouse of the triggering PO's delivery
+// location, or nil when it cannot be resolved.
+func (h *CreatePutAwayTaskHandler) deliveryWarehouse(ctx context.Context, execCtx workflow.ActionExecutionContext) *uuid.UUID {
+	if h.purchaseOrderBus == nil {
+		return nil
+	}
+
+	poIDStr, _ := execCtx.RawData["purchase_order_id"].(string)
+	poID, err := uuid.Parse(poIDStr)
+	if err != nil {
+		return nil
+	}
+
+	po, err := h.purchaseOrderBus.QueryByID(ctx, poID)
+	if err != nil || po.DeliveryLocationID == uuid.Nil {
+		return nil
+	}
+
+	loc, err := h.inventoryLocationBus.QueryByID(ctx, po.DeliveryLocationID)
+	if err != nil {
+		return nil
+	}
+
+	return &loc.WarehouseID
 }
 
 // resolveTemplate replaces {{key}} placeholders in tmpl with values from data.
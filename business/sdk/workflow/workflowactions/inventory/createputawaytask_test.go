@@ -57,6 +57,7 @@ func Test_CreatePutAwayTask(t *testing.T) {
 		db.BusDomain.PutAwayTask,
 		db.BusDomain.SupplierProduct,
 		db.BusDomain.PurchaseOrder,
+		db.BusDomain.InventoryLocation,
 	)
 
 	unitest.Run(t, createPutAwayTaskValidateTests(sd), "validate")
@@ -99,6 +100,7 @@ func Test_CreatePutAwayTask_AtomicCascadeEmit(t *testing.T) {
 		putAwayBus,
 		db.BusDomain.SupplierProduct,
 		db.BusDomain.PurchaseOrder,
+		db.BusDomain.InventoryLocation,
 	)
 
 	ctx := context.Background()
@@ -433,6 +435,8 @@ func createPutAwayTaskValidateTests(sd createPutAwayTaskSeedData) []unitest.Tabl
 		putAwayValidateMissingLocationIDWhenStatic(sd),
 		putAwayValidateInvalidLocationID(sd),
 		putAwayValidateSourceFromPOPODeliveryValid(sd),
+		putAwayValidateDirectedInvalidBinPreference(sd),
+		putAwayValidateDirectedInvalidZoneStage(sd),
 	}
 }
 
@@ -580,6 +584,48 @@ func putAwayValidateSourceFromPOPODeliveryValid(sd createPutAwayTaskSeedData) un
 	}
 }
 
+func putAwayValidateDirectedInvalidBinPreference(sd createPutAwayTaskSeedData) unitest.Table {
+	return unitest.Table{
+		Name:    "directed_invalid_bin_preference",
+		ExpResp: true,
+		ExcFunc: func(ctx context.Context) any {
+			config := json.RawMessage(`{"location_strategy":"directed","product_id":"` + uuid.New().String() + `","bin_preference":"floor"}`)
+			err := sd.Handler.Validate(config)
+			if err == nil {
+				return false
+			}
+			return strings.Contains(err.Error(), "bin_preference")
+		},
+		CmpFunc: func(got, exp any) string {
+			if got != exp {
+				return fmt.Sprintf("got %v, want %v", got, exp)
+			}
+			return ""
+		},
+	}
+}
+
+func putAwayValidateDirectedInvalidZoneStage(sd createPutAwayTaskSeedData) unitest.Table {
+	return unitest.Table{
+		Name:    "directed_invalid_zone_stage",
+		ExpResp: true,
+		ExcFunc: func(ctx context.Context) any {
+			config := json.RawMessage(`{"location_strategy":"directed","product_id":"` + uuid.New().String() + `","zone_stages":["attic"]}`)
+			err := sd.Handler.Validate(config)
+			if err == nil {
+				return false
+			}
+			return strings.Contains(err.Error(), "zone_stages")
+		},
+		CmpFunc: func(got, exp any) string {
+			if got != exp {
+				return fmt.Sprintf("got %v, want %v", got, exp)
+			}
+			return ""
+		},
+	}
+}
+
 // =============================================================================
 // Execute Tests
 
@@ -592,6 +638,8 @@ func createPutAwayTaskExecuteTests(busDomain dbtest.BusDomain, sd createPutAwayT
 		putAwayExecuteZeroDelta(sd),
 		putAwayExecuteNegativeDelta(sd),
 		putAwayExecuteTemplateReferenceNumber(busDomain, sd),
+		putAwayExecuteDirected(busDomain, sd),
+		putAwayExecuteDirectedFallback(sd),
 	}
 }
 
@@ -957,3 +1005,121 @@ func putAwayExecuteTemplateReferenceNumber(busDomain dbtest.BusDomain, sd create
 		},
 	}
 }
+
+func putAwayExecuteDirected(busDomain dbtest.BusDomain, sd createPutAwayTaskSeedData) unitest.Table {
+	return unitest.Table{
+		Name:    "directed",
+		ExpResp: true,
+		ExcFunc: func(ctx context.Context) any {
+			product := sd.Products[2]
+			delta := 10
+
+			cfg := inventory.CreatePutAwayTaskConfig{
+				ProductID:        product.ProductID.String(),
+				LocationStrategy: "directed",
+				BinPreference:    "reserve",
+			}
+			configJSON, _ := json.Marshal(cfg)
+
+			execCtx := sd.ExecutionContext
+			execCtx.ExecutionID = uuid.New()
+			execCtx.FieldChanges = map[string]workflow.FieldChange{
+				"quantity_received": {OldValue: float64(0), NewValue: float64(delta)},
+			}
+
+			result, err := sd.Handler.Execute(ctx, configJSON, execCtx)
+			if err != nil {
+				return fmt.Errorf("execute failed: %w", err)
+			}
+			resultMap, ok := result.(map[string]any)
+			if !ok {
+				return fmt.Errorf("expected map[string]any, got %T", result)
+			}
+			if resultMap["output"] != "created" {
+				return fmt.Errorf("expected output=created, got %v", resultMap["output"])
+			}
+			if _, ok := resultMap["candidates"]; !ok {
+				return fmt.Errorf("expected ranked candidates in result")
+			}
+
+			taskID, err := uuid.Parse(fmt.Sprint(resultMap["task_id"]))
+			if err != nil {
+				return fmt.Errorf("task_id not a valid UUID: %v", resultMap["task_id"])
+			}
+			task, err := busDomain.PutAwayTask.QueryByID(ctx, taskID)
+			if err != nil {
+				return fmt.Errorf("querying created task: %w", err)
+			}
+
+			// Seeded bins hold 100 units each; reserve bins should be preferred.
+			loc, err := busDomain.InventoryLocation.QueryByID(ctx, task.LocationID)
+			if err != nil {
+				return fmt.Errorf("querying slotted location: %w", err)
+			}
+			if !loc.IsReserveLocation {
+				return fmt.Errorf("expected a reserve bin, got location %s", loc.LocationID)
+			}
+
+			return true
+		},
+		CmpFunc: func(got, exp any) string {
+			if got != exp {
+				return fmt.Sprintf("got %v, want %v", got, exp)
+			}
+			return ""
+		},
+	}
+}
+
+func putAwayExecuteDirectedFallback(sd createPutAwayTaskSeedData) unitest.Table {
+	return unitest.Table{
+		Name:    "directed_fallback",
+		ExpResp: true,
+		ExcFunc: func(ctx context.Context) any {
+			product := sd.Products[2]
+			fallback := sd.InventoryLocations[0]
+
+			// No seeded bin has room for this many units.
+			delta := 100000
+
+			cfg := inventory.CreatePutAwayTaskConfig{
+				ProductID:          product.ProductID.String(),
+				LocationStrategy:   "directed",
+				FallbackLocationID: fallback.LocationID.String(),
+			}
+			configJSON, _ := json.Marshal(cfg)
+
+			execCtx := sd.ExecutionContext
+			execCtx.ExecutionID = uuid.New()
+			execCtx.FieldChanges = map[string]workflow.FieldChange{
+				"quantity_received": {OldValue: float64(0), NewValue: float64(delta)},
+			}
+
+			result, err := sd.Handler.Execute(ctx, configJSON, execCtx)
+			if err != nil {
+				return fmt.Errorf("execute failed: %w", err)
+			}
+			resultMap, ok := result.(map[string]any)
+			if !ok {
+				return fmt.Errorf("expected map[string]any, got %T", result)
+			}
+			if resultMap["output"] != "created" {
+				return fmt.Errorf("expected output=created, got %v", resultMap["output"])
+			}
+			if resultMap["fallback"] != true {
+				return fmt.Errorf("expected fallback=true, got %v", resultMap["fallback"])
+			}
+			if resultMap["location_id"] != fallback.LocationID.String() {
+				return fmt.Errorf("expected fallback location %s, got %v", fallback.LocationID, resultMap["location_id"])
+			}
+
+			return true
+		},
+		CmpFunc: func(got, exp any) string {
+			if got != exp {
+				return fmt.Sprintf("got %v, want %v", got, exp)
+			}
+			return ""
+		},
+	}
+}
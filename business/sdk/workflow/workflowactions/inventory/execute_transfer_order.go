@@ -7,7 +7,6 @@ import (
 	"errors"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -20,15 +19,29 @@ import (
 )
 
 // ExecuteTransferOrderConfig holds the config for the execute transfer order handler.
+// With no Lines the transfer receives everything outstanding and completes. Lines book
+// a partial receipt instead; Close writes off whatever is then still outstanding (with
+// Reason) and completes the transfer.
 type ExecuteTransferOrderConfig struct {
-	TransferOrderID string `json:"transfer_order_id"`
+	TransferOrderID string                     `json:"transfer_order_id"`
+	Lines           []ExecuteTransferOrderLine `json:"lines,omitempty"`
+	Close           bool                       `json:"close,omitempty"`
+	Reason          string                     `json:"reason,omitempty"`
 }
 
-// ExecuteTransferOrderHandler handles execute_transfer_order actions: it completes an
-// in_transit transfer order and performs the atomic stock move — status -> completed, a
-// TRANSFER_OUT/TRANSFER_IN ledger pair, and source-decrement/destination-increment of
-// inventory_items — in a single transaction, mirroring transferorderapp.Execute so the
-// button path is equivalent to the REST execute endpoint.
+// ExecuteTransferOrderLine is the quantity of one transfer line received.
+type ExecuteTransferOrderLine struct {
+	LineID   string `json:"line_id"`
+	Quantity int    `json:"quantity"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// ExecuteTransferOrderHandler handles execute_transfer_order actions: it receives a
+// shipped transfer order at its destination and performs the atomic stock move — per
+// line a TRANSFER_OUT/TRANSFER_IN ledger pair and transit-decrement/destination-increment
+// of inventory_items, plus an ADJUSTMENT for stock written off in transit — together with
+// the status change in a single transaction, mirroring transferorderapp.Execute/Receive
+// so the button path is equivalent to the REST endpoints.
 type ExecuteTransferOrderHandler struct {
 	log               *logger.Logger
 	db                *sqlx.DB
@@ -84,6 +97,14 @@ func (h *ExecuteTransferOrderHandler) Validate(config json.RawMessage) error {
 			return fmt.Errorf("invalid transfer_order_id: %w", err)
 		}
 	}
+	for i, line := range cfg.Lines {
+		if _, err := uuid.Parse(line.LineID); err != nil {
+			return fmt.Errorf("invalid lines[%d].line_id: %w", i, err)
+		}
+		if line.Quantity < 0 {
+			return fmt.Errorf("lines[%d].quantity must not be negative", i)
+		}
+	}
 	return nil
 }
 
@@ -91,28 +112,37 @@ func (h *ExecuteTransferOrderHandler) Validate(config json.RawMessage) error {
 func (h *ExecuteTransferOrderHandler) GetOutputPorts() []workflow.OutputPort {
 	return []workflow.OutputPort{
 		{Name: "executed", Description: "Transfer order completed and stock moved", IsDefault: true},
+		{Name: "partially_received", Description: "Receipt booked; stock is still outstanding in transit"},
 		{Name: "not_found", Description: "Transfer order not found"},
 		{Name: "already_completed", Description: "Transfer order was already completed (idempotent)"},
-		{Name: "not_in_transit", Description: "Transfer order is not in_transit — cannot execute"},
-		{Name: "insufficient_stock", Description: "Source location has insufficient stock to complete the move"},
+		{Name: "not_in_transit", Description: "Transfer order is not in_transit or partially_received — cannot execute"},
+		{Name: "invalid_receipt", Description: "Receipt names an unknown line, receives more than is outstanding, or receives nothing"},
+		{Name: "insufficient_stock", Description: "Transit location has insufficient stock to complete the move"},
 		{Name: "failure", Description: "Unexpected error"},
 	}
 }
 
 // GetEntityModifications implements workflow.EntityModifier.
 func (h *ExecuteTransferOrderHandler) GetEntityModifications(config json.RawMessage) []workflow.EntityModification {
-	return []workflow.EntityModification{
-		{
-			EntityName: "inventory.transfer_orders",
-			EventType:  "on_update",
-			Fields:     []string{"status", "completed_by", "completed_at"},
-			// status moves to the fixed enum constant. completed_by (runtime user) and
-			// completed_at (now) are left indeterminate (no Change entry).
-			Changes: []workflow.ProducedChange{
-				{FieldName: "status", Operator: workflow.OperatorChangedTo, Value: transferorderbus.StatusCompleted},
-			},
+	mod := workflow.EntityModification{
+		EntityName: "inventory.transfer_orders",
+		EventType:  "on_update",
+		Fields:     []string{"status", "completed_by", "completed_at"},
+		// status moves to the fixed enum constant. completed_by (runtime user) and
+		// completed_at (now) are left indeterminate (no Change entry).
+		Changes: []workflow.ProducedChange{
+			{FieldName: "status", Operator: workflow.OperatorChangedTo, Value: transferorderbus.StatusCompleted},
 		},
 	}
+
+	// A partial receipt that does not close the transfer may leave it partially_received
+	// or complete it, depending on what is outstanding at runtime.
+	var cfg ExecuteTransferOrderConfig
+	if err := json.Unmarshal(config, &cfg); err == nil && len(cfg.Lines) > 0 && !cfg.Close {
+		mod.Changes = nil
+	}
+
+	return []workflow.EntityModification{mod}
 }
 
 // Execute completes an in-transit transfer order.
@@ -137,6 +167,10 @@ func (h *ExecuteTransferOrderHandler) Execute(ctx context.Context, config json.R
 		return map[string]any{"output": "failure", "error": "no transfer order id"}, nil
 	}
 
+	if h.transferOrderBus == nil || h.invTransactionBus == nil || h.invItemBus == nil || h.db == nil {
+		return map[string]any{"output": "failure", "error": "transfer order buses not configured"}, nil
+	}
+
 	to, err := h.transferOrderBus.QueryByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, transferorderbus.ErrNotFound) {
@@ -148,13 +182,25 @@ func (h *ExecuteTransferOrderHandler) Execute(ctx context.Context, config json.R
 	if to.Status == transferorderbus.StatusCompleted {
 		return map[string]any{"output": "already_completed", "transfer_order_id": id.String()}, nil
 	}
-	if to.Status != transferorderbus.StatusInTransit {
+	if to.Status != transferorderbus.StatusInTransit && to.Status != transferorderbus.StatusPartiallyReceived {
 		return map[string]any{"output": "not_in_transit", "transfer_order_id": id.String(), "current_status": to.Status}, nil
 	}
 
-	// Complete the transfer and move the stock atomically: status -> completed, a
-	// TRANSFER_OUT/TRANSFER_IN ledger pair, source decrement, destination increment — all in
-	// one transaction, mirroring transferorderapp.Execute so the button equals the REST path.
+	var rc *transferorderbus.Receipt
+	if len(cfg.Lines) > 0 {
+		rc = &transferorderbus.Receipt{ReceivedBy: execCtx.UserID, Close: cfg.Close, Reason: cfg.Reason}
+		for _, line := range cfg.Lines {
+			lineID, err := uuid.Parse(line.LineID)
+			if err != nil {
+				return map[string]any{"output": "failure", "error": "invalid line_id"}, nil
+			}
+			rc.Lines = append(rc.Lines, transferorderbus.ReceiptLine{LineID: lineID, Quantity: line.Quantity, Reason: line.Reason})
+		}
+	}
+
+	// Receive the transfer and move the stock atomically: the status change and, per line, a
+	// TRANSFER_OUT/TRANSFER_IN ledger pair, transit decrement and destination increment — all
+	// in one transaction, mirroring transferorderapp.Execute so the button equals the REST path.
 	tx, err := h.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
 	if err != nil {
 		return nil, fmt.Errorf("begin tx: %w", err)
@@ -170,9 +216,22 @@ func (h *ExecuteTransferOrderHandler) Execute(ctx context.Context, config json.R
 	if err != nil {
 		return nil, fmt.Errorf("tx transfer order bus: %w", err)
 	}
-	executed, err := txTransferBus.Execute(ctx, to, execCtx.UserID)
+
+	var received transferorderbus.TransferOrder
+	var moves []transferorderbus.Movement
+	if rc != nil {
+		received, moves, err = txTransferBus.Receive(ctx, to, *rc)
+	} else {
+		received, moves, err = txTransferBus.Execute(ctx, to, execCtx.UserID)
+	}
 	if err != nil {
-		if errors.Is(err, transferorderbus.ErrInvalidTransferStatus) {
+		switch {
+		case errors.Is(err, transferorderbus.ErrLineNotFound),
+			errors.Is(err, transferorderbus.ErrInvalidQuantity),
+			errors.Is(err, transferorderbus.ErrOverReceipt),
+			errors.Is(err, transferorderbus.ErrEmptyReceipt):
+			return map[string]any{"output": "invalid_receipt", "transfer_order_id": id.String(), "error": err.Error()}, nil
+		case errors.Is(err, transferorderbus.ErrInvalidTransferStatus):
 			return map[string]any{"output": "failure", "error": "transfer order status changed concurrently"}, nil
 		}
 		return nil, fmt.Errorf("execute transfer order: %w", err)
@@ -182,52 +241,38 @@ func (h *ExecuteTransferOrderHandler) Execute(ctx context.Context, config json.R
 	if err != nil {
 		return nil, fmt.Errorf("tx inventory transaction bus: %w", err)
 	}
-	refNum := to.TransferID.String()
-	now := time.Now()
-	if _, err := txInvTxnBus.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
-		ProductID:       to.ProductID,
-		LocationID:      to.FromLocationID,
-		UserID:          execCtx.UserID,
-		Quantity:        -to.Quantity,
-		TransactionType: "TRANSFER_OUT",
-		ReferenceNumber: refNum,
-		TransactionDate: now,
-	}); err != nil {
-		return nil, fmt.Errorf("create transfer_out transaction: %w", err)
-	}
-	if _, err := txInvTxnBus.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
-		ProductID:       to.ProductID,
-		LocationID:      to.ToLocationID,
-		UserID:          execCtx.UserID,
-		Quantity:        to.Quantity,
-		TransactionType: "TRANSFER_IN",
-		ReferenceNumber: refNum,
-		TransactionDate: now,
-	}); err != nil {
-		return nil, fmt.Errorf("create transfer_in transaction: %w", err)
-	}
-
 	txItemBus, err := h.invItemBus.NewWithTx(tx)
 	if err != nil {
 		return nil, fmt.Errorf("tx inventory item bus: %w", err)
 	}
-	if err := txItemBus.DecrementQuantity(ctx, to.ProductID, to.FromLocationID, to.Quantity); err != nil {
+	if err := moveTransferStock(ctx, txInvTxnBus, txItemBus, received, execCtx.UserID, to.InTransitLocationID(), to.ToLocationID, moves); err != nil {
 		if errors.Is(err, inventoryitembus.ErrInsufficientStock) {
 			return map[string]any{"output": "insufficient_stock", "transfer_order_id": id.String()}, nil
 		}
-		return nil, fmt.Errorf("decrement source inventory: %w", err)
-	}
-	if err := txItemBus.UpsertQuantity(ctx, to.ProductID, to.ToLocationID, to.Quantity); err != nil {
-		return nil, fmt.Errorf("upsert destination inventory: %w", err)
+		return nil, err
 	}
 
 	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("commit tx: %w", err)
 	}
 
+	quantity, discrepancy := 0, 0
+	for _, m := range moves {
+		quantity += m.Quantity
+		discrepancy += m.Discrepancy
+	}
+
+	output := "executed"
+	if received.Status == transferorderbus.StatusPartiallyReceived {
+		output = "partially_received"
+	}
+
 	return map[string]any{
-		"output":            "executed",
-		"transfer_order_id": executed.TransferID.String(),
-		"completed_by":      execCtx.UserID.String(),
+		"output":               output,
+		"transfer_order_id":    received.TransferID.String(),
+		"status":               received.Status,
+		"completed_by":         execCtx.UserID.String(),
+		"quantity_received":    quantity,
+		"discrepancy_quantity": discrepancy,
 	}, nil
 }
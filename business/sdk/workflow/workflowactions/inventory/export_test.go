@@ -0,0 +1,19 @@
+package inventory
+
+import (
+	"context"
+	"time"
+)
+
+// Exported for tests in package inventory_test.
+var (
+	RankCandidates = rankCandidates
+	PlanAllocation = planAllocation
+	CandidateSort  = candidateSort
+)
+
+// SweepAt runs one reaper sweep as if the clock read now.
+func (r *ReservationReaper) SweepAt(ctx context.Context, now time.Time) (int, error) {
+	r.now = func() time.Time { return now }
+	return r.sweep(ctx)
+}
@@ -0,0 +1,165 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// ForecastDemandConfig holds the config for the forecast demand handler.
+type ForecastDemandConfig struct {
+	// CategoryID, ProductID and LocationID narrow the inventory items
+	// forecast. Empty forecasts every item with a policy.
+	CategoryID string `json:"category_id,omitempty"`
+	ProductID  string `json:"product_id,omitempty"`
+	LocationID string `json:"location_id,omitempty"`
+
+	// CreatedBy attributes the forecasts when the trigger carries no user, as
+	// scheduled triggers do.
+	CreatedBy string `json:"created_by,omitempty"`
+}
+
+// ForecastDemandHandler handles forecast_demand actions: it forecasts the
+// daily demand of inventory items from their outbound transaction history
+// with the policy of each product's category and recommends avg daily usage,
+// safety stock, reorder point and economic order quantity. Recommendations
+// are written to the items at once when the policy auto-applies and queued
+// for approval otherwise. Attach it to a scheduled rule so check_reorder_point
+// acts on current numbers.
+type ForecastDemandHandler struct {
+	log               *logger.Logger
+	demandForecastBus *demandforecastbus.Business
+}
+
+// NewForecastDemandHandler creates a new forecast demand handler.
+func NewForecastDemandHandler(log *logger.Logger, demandForecastBus *demandforecastbus.Business) *ForecastDemandHandler {
+	return &ForecastDemandHandler{
+		log:               log,
+		demandForecastBus: demandForecastBus,
+	}
+}
+
+// GetType returns the action type.
+func (h *ForecastDemandHandler) GetType() string { return "forecast_demand" }
+
+// IsAsync returns false — forecasting completes inline.
+func (h *ForecastDemandHandler) IsAsync() bool { return false }
+
+// SupportsManualExecution returns true.
+func (h *ForecastDemandHandler) SupportsManualExecution() bool { return true }
+
+// GetDescription returns a human-readable description.
+func (h *ForecastDemandHandler) GetDescription() string {
+	return "Forecast item demand and recommend avg daily usage, safety stock, reorder point and EOQ"
+}
+
+// Validate validates the forecast demand configuration.
+func (h *ForecastDemandHandler) Validate(config json.RawMessage) error {
+	var cfg ForecastDemandConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	ids := []struct {
+		name  string
+		value string
+	}{
+		{"category_id", cfg.CategoryID},
+		{"product_id", cfg.ProductID},
+		{"location_id", cfg.LocationID},
+	}
+	for _, id := range ids {
+		if err := workflow.ValidateConfigID(id.name, id.value); err != nil {
+			return err
+		}
+	}
+	if cfg.CreatedBy != "" {
+		if _, err := uuid.Parse(cfg.CreatedBy); err != nil {
+			return fmt.Errorf("invalid created_by: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetOutputPorts implements workflow.OutputPortProvider.
+func (h *ForecastDemandHandler) GetOutputPorts() []workflow.OutputPort {
+	return []workflow.OutputPort{
+		{Name: "forecasted", Description: "The items were forecast", IsDefault: true},
+		{Name: "nothing_to_forecast", Description: "No selected inventory item has a forecast policy"},
+		{Name: "failure", Description: "Unexpected error"},
+	}
+}
+
+// GetEntityModifications implements workflow.EntityModifier.
+func (h *ForecastDemandHandler) GetEntityModifications(config json.RawMessage) []workflow.EntityModification {
+	return []workflow.EntityModification{
+		{EntityName: "inventory.demand_forecasts", EventType: "on_create"},
+		{EntityName: "inventory.inventory_items", EventType: "on_update", Fields: []string{"avg_daily_usage", "safety_stock", "reorder_point", "economic_order_quantity"}},
+	}
+}
+
+// Execute forecasts the selected inventory items.
+func (h *ForecastDemandHandler) Execute(ctx context.Context, config json.RawMessage, execCtx workflow.ActionExecutionContext) (any, error) {
+	var cfg ForecastDemandConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	if h.demandForecastBus == nil {
+		return map[string]any{"output": "failure", "error": "demand forecast bus not configured"}, nil
+	}
+
+	createdBy, err := workflow.ActingUser(execCtx, "created_by", cfg.CreatedBy)
+	if err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	req := demandforecastbus.RunRequest{
+		CreatedBy: createdBy,
+	}
+	if req.CategoryID, err = workflow.ResolveConfigID("category_id", cfg.CategoryID, execCtx); err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+	if req.ProductID, err = workflow.ResolveConfigID("product_id", cfg.ProductID, execCtx); err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+	if req.LocationID, err = workflow.ResolveConfigID("location_id", cfg.LocationID, execCtx); err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	forecasts, err := h.demandForecastBus.Run(ctx, req, time.Now())
+	if err != nil {
+		if errors.Is(err, demandforecastbus.ErrNothingToForecast) {
+			return map[string]any{"output": "nothing_to_forecast"}, nil
+		}
+		return nil, fmt.Errorf("run: %w", err)
+	}
+
+	forecastIDs := make([]string, len(forecasts))
+	var applied, pending int
+	for i, f := range forecasts {
+		forecastIDs[i] = f.ID.String()
+		switch f.Status {
+		case demandforecastbus.StatusApplied:
+			applied++
+		case demandforecastbus.StatusPending:
+			pending++
+		}
+	}
+
+	return map[string]any{
+		"output":         "forecasted",
+		"forecast_ids":   forecastIDs,
+		"forecast_count": len(forecasts),
+		"applied_count":  applied,
+		"pending_count":  pending,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package inventory_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/inventory"
+)
+
+func TestForecastDemand_Validate(t *testing.T) {
+	handler := inventory.NewForecastDemandHandler(nil, nil)
+
+	tests := []struct {
+		name      string
+		raw       json.RawMessage
+		wantErr   bool
+		errSubstr string
+	}{
+		{name: "every item", raw: json.RawMessage(`{}`), wantErr: false},
+		{name: "good uuids", raw: json.RawMessage(`{"category_id":"` + uuid.NewString() + `","created_by":"` + uuid.NewString() + `"}`), wantErr: false},
+		{name: "templated id ok", raw: json.RawMessage(`{"product_id":"{{entity_id}}"}`), wantErr: false},
+		{name: "bad location", raw: json.RawMessage(`{"location_id":"nope"}`), wantErr: true, errSubstr: "invalid location_id"},
+		{name: "bad created_by", raw: json.RawMessage(`{"created_by":"nope"}`), wantErr: true, errSubstr: "invalid created_by"},
+		{name: "invalid json", raw: json.RawMessage(`{bad`), wantErr: true, errSubstr: "invalid config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handler.Validate(tt.raw)
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.errSubstr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tt.wantErr && err != nil && !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error %q does not contain %q", err.Error(), tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestForecastDemand_Metadata(t *testing.T) {
+	handler := inventory.NewForecastDemandHandler(nil, nil)
+
+	if got := handler.GetType(); got != "forecast_demand" {
+		t.Fatalf("expected forecast_demand, got %s", got)
+	}
+	if !handler.SupportsManualExecution() {
+		t.Fatal("expected SupportsManualExecution true")
+	}
+
+	var defaults []workflow.OutputPort
+	for _, p := range handler.GetOutputPorts() {
+		if p.IsDefault {
+			defaults = append(defaults, p)
+		}
+	}
+	if len(defaults) != 1 || defaults[0].Name != "forecasted" {
+		t.Fatalf("expected single default port 'forecasted', got %+v", defaults)
+	}
+
+	if mods := handler.GetEntityModifications(nil); len(mods) != 2 {
+		t.Fatalf("expected 2 entity modifications, got %d", len(mods))
+	}
+}
+
+func TestForecastDemand_NilBusFails(t *testing.T) {
+	handler := inventory.NewForecastDemandHandler(nil, nil)
+
+	result, err := handler.Execute(context.Background(), json.RawMessage(`{}`), workflow.ActionExecutionContext{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if out := result.(map[string]any)["output"]; out != "failure" {
+		t.Fatalf("expected failure output, got %v", out)
+	}
+}
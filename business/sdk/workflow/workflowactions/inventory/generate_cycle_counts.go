@@ -0,0 +1,189 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+	"github.com/timmaaaz/ichor/business/sdk/order"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// GenerateCycleCountsConfig holds the config for the generate cycle counts handler.
+type GenerateCycleCountsConfig struct {
+	// PlanID selects a single count plan. Empty runs every active plan.
+	PlanID string `json:"plan_id,omitempty"`
+}
+
+// GenerateCycleCountsHandler handles generate_cycle_counts actions: it turns a
+// count plan's due locations into a cycle count session with one item per
+// product/location, snapshotting the system quantity, and records the run.
+// Attach it to a scheduled rule to run a systematic count program.
+type GenerateCycleCountsHandler struct {
+	log          *logger.Logger
+	countPlanBus *countplanbus.Business
+}
+
+// NewGenerateCycleCountsHandler creates a new generate cycle counts handler.
+func NewGenerateCycleCountsHandler(log *logger.Logger, countPlanBus *countplanbus.Business) *GenerateCycleCountsHandler {
+	return &GenerateCycleCountsHandler{
+		log:          log,
+		countPlanBus: countPlanBus,
+	}
+}
+
+// GetType returns the action type.
+func (h *GenerateCycleCountsHandler) GetType() string { return "generate_cycle_counts" }
+
+// IsAsync returns false — generation completes inline.
+func (h *GenerateCycleCountsHandler) IsAsync() bool { return false }
+
+// SupportsManualExecution returns true.
+func (h *GenerateCycleCountsHandler) SupportsManualExecution() bool { return true }
+
+// GetDescription returns a human-readable description.
+func (h *GenerateCycleCountsHandler) GetDescription() string {
+	return "Generate cycle count sessions from a count plan, or from every active plan"
+}
+
+// Validate validates the generate cycle counts configuration.
+func (h *GenerateCycleCountsHandler) Validate(config json.RawMessage) error {
+	var cfg GenerateCycleCountsConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	if cfg.PlanID != "" && !strings.Contains(cfg.PlanID, "{{") {
+		if _, err := uuid.Parse(cfg.PlanID); err != nil {
+			return fmt.Errorf("invalid plan_id: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetOutputPorts implements workflow.OutputPortProvider.
+func (h *GenerateCycleCountsHandler) GetOutputPorts() []workflow.OutputPort {
+	return []workflow.OutputPort{
+		{Name: "generated", Description: "At least one cycle count session was generated", IsDefault: true},
+		{Name: "nothing_due", Description: "No location was due for counting"},
+		{Name: "not_found", Description: "Count plan not found"},
+		{Name: "inactive", Description: "Count plan is not active"},
+		{Name: "failure", Description: "Unexpected error"},
+	}
+}
+
+// GetEntityModifications implements workflow.EntityModifier.
+func (h *GenerateCycleCountsHandler) GetEntityModifications(config json.RawMessage) []workflow.EntityModification {
+	return []workflow.EntityModification{
+		{EntityName: "inventory.cycle_count_sessions", EventType: "on_create"},
+		{EntityName: "inventory.cycle_count_items", EventType: "on_create"},
+		{
+			EntityName: "inventory.count_plans",
+			EventType:  "on_update",
+			Fields:     []string{"last_generated_date"},
+		},
+	}
+}
+
+// Execute generates cycle count sessions.
+func (h *GenerateCycleCountsHandler) Execute(ctx context.Context, config json.RawMessage, execCtx workflow.ActionExecutionContext) (any, error) {
+	var cfg GenerateCycleCountsConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	if h.countPlanBus == nil {
+		return map[string]any{"output": "failure", "error": "count plan bus not configured"}, nil
+	}
+
+	now := time.Now()
+
+	// A single plan: a static plan_id wins; a templated one falls back to the
+	// execution-context entity (a rule on inventory.count_plans).
+	if cfg.PlanID != "" {
+		id := execCtx.EntityID
+		if !strings.Contains(cfg.PlanID, "{{") {
+			parsed, err := uuid.Parse(cfg.PlanID)
+			if err != nil {
+				return map[string]any{"output": "failure", "error": "invalid plan_id"}, nil
+			}
+			id = parsed
+		}
+
+		plan, err := h.countPlanBus.QueryByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, countplanbus.ErrNotFound) {
+				return map[string]any{"output": "not_found", "plan_id": id.String()}, nil
+			}
+			return nil, fmt.Errorf("query count plan: %w", err)
+		}
+
+		run, err := h.countPlanBus.Generate(ctx, plan, generatedBy(execCtx, plan), now)
+		if err != nil {
+			switch {
+			case errors.Is(err, countplanbus.ErrInactive):
+				return map[string]any{"output": "inactive", "plan_id": id.String()}, nil
+			case errors.Is(err, countplanbus.ErrNothingDue):
+				return map[string]any{"output": "nothing_due", "plan_id": id.String()}, nil
+			}
+			return nil, fmt.Errorf("generate: %w", err)
+		}
+
+		return map[string]any{
+			"output":        "generated",
+			"plan_id":       id.String(),
+			"session_ids":   []string{run.SessionID.String()},
+			"session_count": 1,
+			"item_count":    run.ItemCount,
+		}, nil
+	}
+
+	// Every active plan. A plan with nothing due is skipped, not an error.
+	active := true
+	plans, err := h.countPlanBus.Query(ctx, countplanbus.QueryFilter{IsActive: &active},
+		order.NewBy(countplanbus.OrderByName, order.ASC), page.MustParse("1", "1000"))
+	if err != nil {
+		return nil, fmt.Errorf("query count plans: %w", err)
+	}
+
+	sessionIDs := []string{}
+	items := 0
+	for _, plan := range plans {
+		run, err := h.countPlanBus.Generate(ctx, plan, generatedBy(execCtx, plan), now)
+		if err != nil {
+			if errors.Is(err, countplanbus.ErrNothingDue) {
+				continue
+			}
+			return nil, fmt.Errorf("generate plan %s: %w", plan.ID, err)
+		}
+		sessionIDs = append(sessionIDs, run.SessionID.String())
+		items += run.ItemCount
+	}
+
+	if len(sessionIDs) == 0 {
+		return map[string]any{"output": "nothing_due", "plan_count": len(plans)}, nil
+	}
+
+	return map[string]any{
+		"output":        "generated",
+		"plan_count":    len(plans),
+		"session_ids":   sessionIDs,
+		"session_count": len(sessionIDs),
+		"item_count":    items,
+	}, nil
+}
+
+// generatedBy attributes a run to the executing user. Scheduled triggers carry
+// no user, so those runs are attributed to the plan's owner.
+func generatedBy(execCtx workflow.ActionExecutionContext, plan countplanbus.CountPlan) uuid.UUID {
+	if execCtx.UserID != uuid.Nil {
+		return execCtx.UserID
+	}
+	return plan.CreatedBy
+}
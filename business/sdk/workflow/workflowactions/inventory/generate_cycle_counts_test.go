@@ -0,0 +1,81 @@
+package inventory_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/inventory"
+)
+
+func TestGenerateCycleCounts_Validate(t *testing.T) {
+	handler := inventory.NewGenerateCycleCountsHandler(nil, nil)
+
+	tests := []struct {
+		name      string
+		raw       json.RawMessage
+		wantErr   bool
+		errSubstr string
+	}{
+		{name: "all active plans", raw: json.RawMessage(`{}`), wantErr: false},
+		{name: "good uuid", raw: json.RawMessage(`{"plan_id":"` + uuid.NewString() + `"}`), wantErr: false},
+		{name: "templated id ok", raw: json.RawMessage(`{"plan_id":"{{entity_id}}"}`), wantErr: false},
+		{name: "bad uuid", raw: json.RawMessage(`{"plan_id":"nope"}`), wantErr: true, errSubstr: "invalid plan_id"},
+		{name: "invalid json", raw: json.RawMessage(`{bad`), wantErr: true, errSubstr: "invalid config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handler.Validate(tt.raw)
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.errSubstr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tt.wantErr && err != nil && !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error %q does not contain %q", err.Error(), tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestGenerateCycleCounts_Metadata(t *testing.T) {
+	handler := inventory.NewGenerateCycleCountsHandler(nil, nil)
+
+	if got := handler.GetType(); got != "generate_cycle_counts" {
+		t.Fatalf("expected generate_cycle_counts, got %s", got)
+	}
+	if !handler.SupportsManualExecution() {
+		t.Fatal("expected SupportsManualExecution true")
+	}
+
+	var defaults []workflow.OutputPort
+	for _, p := range handler.GetOutputPorts() {
+		if p.IsDefault {
+			defaults = append(defaults, p)
+		}
+	}
+	if len(defaults) != 1 || defaults[0].Name != "generated" {
+		t.Fatalf("expected single default port 'generated', got %+v", defaults)
+	}
+
+	if mods := handler.GetEntityModifications(nil); len(mods) != 3 {
+		t.Fatalf("expected 3 entity modifications, got %d", len(mods))
+	}
+}
+
+func TestGenerateCycleCounts_NilBusFails(t *testing.T) {
+	handler := inventory.NewGenerateCycleCountsHandler(nil, nil)
+
+	result, err := handler.Execute(context.Background(), json.RawMessage(`{}`), workflow.ActionExecutionContext{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if out := result.(map[string]any)["output"]; out != "failure" {
+		t.Fatalf("expected failure output, got %v", out)
+	}
+}
@@ -0,0 +1,166 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// GenerateReplenishmentConfig holds the config for the generate replenishment
+// handler.
+type GenerateReplenishmentConfig struct {
+	// WarehouseID, ProductID and LocationID narrow the pick faces considered.
+	// Empty considers every pick face.
+	WarehouseID string `json:"warehouse_id,omitempty"`
+	ProductID   string `json:"product_id,omitempty"`
+	LocationID  string `json:"location_id,omitempty"`
+
+	// CoverDays is how many days of average usage a pick face should hold on
+	// top of its open picks. Zero uses the default.
+	CoverDays int `json:"cover_days,omitempty"`
+
+	// CreatedBy attributes the tasks when the trigger carries no user, as
+	// scheduled triggers do.
+	CreatedBy string `json:"created_by,omitempty"`
+}
+
+// GenerateReplenishmentHandler handles generate_replenishment actions: it
+// finds forward pick locations at or below their minimum, or short of their
+// expected demand, and creates replenishment tasks that move stock to them
+// from reserve locations, earliest-expiring lots first. Attach it to a
+// scheduled rule to keep pick faces topped up.
+type GenerateReplenishmentHandler struct {
+	log                  *logger.Logger
+	replenishmentTaskBus *replenishmenttaskbus.Business
+}
+
+// NewGenerateReplenishmentHandler creates a new generate replenishment handler.
+func NewGenerateReplenishmentHandler(log *logger.Logger, replenishmentTaskBus *replenishmenttaskbus.Business) *GenerateReplenishmentHandler {
+	return &GenerateReplenishmentHandler{
+		log:                  log,
+		replenishmentTaskBus: replenishmentTaskBus,
+	}
+}
+
+// GetType returns the action type.
+func (h *GenerateReplenishmentHandler) GetType() string { return "generate_replenishment" }
+
+// IsAsync returns false — generation completes inline.
+func (h *GenerateReplenishmentHandler) IsAsync() bool { return false }
+
+// SupportsManualExecution returns true.
+func (h *GenerateReplenishmentHandler) SupportsManualExecution() bool { return true }
+
+// GetDescription returns a human-readable description.
+func (h *GenerateReplenishmentHandler) GetDescription() string {
+	return "Generate replenishment tasks that move stock from reserve to pick locations running low"
+}
+
+// Validate validates the generate replenishment configuration.
+func (h *GenerateReplenishmentHandler) Validate(config json.RawMessage) error {
+	var cfg GenerateReplenishmentConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	ids := []struct {
+		name  string
+		value string
+	}{
+		{"warehouse_id", cfg.WarehouseID},
+		{"product_id", cfg.ProductID},
+		{"location_id", cfg.LocationID},
+	}
+	for _, id := range ids {
+		if err := workflow.ValidateConfigID(id.name, id.value); err != nil {
+			return err
+		}
+	}
+	if cfg.CreatedBy != "" {
+		if _, err := uuid.Parse(cfg.CreatedBy); err != nil {
+			return fmt.Errorf("invalid created_by: %w", err)
+		}
+	}
+
+	if cfg.CoverDays < 0 {
+		return fmt.Errorf("cover_days must not be negative")
+	}
+
+	return nil
+}
+
+// GetOutputPorts implements workflow.OutputPortProvider.
+func (h *GenerateReplenishmentHandler) GetOutputPorts() []workflow.OutputPort {
+	return []workflow.OutputPort{
+		{Name: "generated", Description: "At least one replenishment task was generated", IsDefault: true},
+		{Name: "nothing_to_replenish", Description: "No pick location needed stock, or no reserve stock was available"},
+		{Name: "failure", Description: "Unexpected error"},
+	}
+}
+
+// GetEntityModifications implements workflow.EntityModifier.
+func (h *GenerateReplenishmentHandler) GetEntityModifications(config json.RawMessage) []workflow.EntityModification {
+	return []workflow.EntityModification{
+		{EntityName: "inventory.replenishment_tasks", EventType: "on_create"},
+	}
+}
+
+// Execute generates replenishment tasks.
+func (h *GenerateReplenishmentHandler) Execute(ctx context.Context, config json.RawMessage, execCtx workflow.ActionExecutionContext) (any, error) {
+	var cfg GenerateReplenishmentConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	if h.replenishmentTaskBus == nil {
+		return map[string]any{"output": "failure", "error": "replenishment task bus not configured"}, nil
+	}
+
+	createdBy, err := workflow.ActingUser(execCtx, "created_by", cfg.CreatedBy)
+	if err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	req := replenishmenttaskbus.PlanRequest{
+		CoverDays: cfg.CoverDays,
+		CreatedBy: createdBy,
+	}
+	if req.WarehouseID, err = workflow.ResolveConfigID("warehouse_id", cfg.WarehouseID, execCtx); err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+	if req.ProductID, err = workflow.ResolveConfigID("product_id", cfg.ProductID, execCtx); err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+	if req.LocationID, err = workflow.ResolveConfigID("location_id", cfg.LocationID, execCtx); err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	tasks, err := h.replenishmentTaskBus.Generate(ctx, req, time.Now())
+	if err != nil {
+		if errors.Is(err, replenishmenttaskbus.ErrNothingToReplenish) {
+			return map[string]any{"output": "nothing_to_replenish"}, nil
+		}
+		return nil, fmt.Errorf("generate: %w", err)
+	}
+
+	taskIDs := make([]string, len(tasks))
+	quantity := 0
+	for i, task := range tasks {
+		taskIDs[i] = task.ID.String()
+		quantity += task.Quantity
+	}
+
+	return map[string]any{
+		"output":     "generated",
+		"task_ids":   taskIDs,
+		"task_count": len(tasks),
+		"quantity":   quantity,
+	}, nil
+}
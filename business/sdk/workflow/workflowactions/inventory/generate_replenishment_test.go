@@ -0,0 +1,83 @@
+package inventory_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/inventory"
+)
+
+func TestGenerateReplenishment_Validate(t *testing.T) {
+	handler := inventory.NewGenerateReplenishmentHandler(nil, nil)
+
+	tests := []struct {
+		name      string
+		raw       json.RawMessage
+		wantErr   bool
+		errSubstr string
+	}{
+		{name: "every pick face", raw: json.RawMessage(`{}`), wantErr: false},
+		{name: "good uuids", raw: json.RawMessage(`{"warehouse_id":"` + uuid.NewString() + `","created_by":"` + uuid.NewString() + `","cover_days":3}`), wantErr: false},
+		{name: "templated id ok", raw: json.RawMessage(`{"location_id":"{{entity_id}}"}`), wantErr: false},
+		{name: "bad product", raw: json.RawMessage(`{"product_id":"nope"}`), wantErr: true, errSubstr: "invalid product_id"},
+		{name: "bad created_by", raw: json.RawMessage(`{"created_by":"nope"}`), wantErr: true, errSubstr: "invalid created_by"},
+		{name: "negative cover", raw: json.RawMessage(`{"cover_days":-1}`), wantErr: true, errSubstr: "cover_days"},
+		{name: "invalid json", raw: json.RawMessage(`{bad`), wantErr: true, errSubstr: "invalid config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handler.Validate(tt.raw)
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.errSubstr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tt.wantErr && err != nil && !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error %q does not contain %q", err.Error(), tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestGenerateReplenishment_Metadata(t *testing.T) {
+	handler := inventory.NewGenerateReplenishmentHandler(nil, nil)
+
+	if got := handler.GetType(); got != "generate_replenishment" {
+		t.Fatalf("expected generate_replenishment, got %s", got)
+	}
+	if !handler.SupportsManualExecution() {
+		t.Fatal("expected SupportsManualExecution true")
+	}
+
+	var defaults []workflow.OutputPort
+	for _, p := range handler.GetOutputPorts() {
+		if p.IsDefault {
+			defaults = append(defaults, p)
+		}
+	}
+	if len(defaults) != 1 || defaults[0].Name != "generated" {
+		t.Fatalf("expected single default port 'generated', got %+v", defaults)
+	}
+
+	if mods := handler.GetEntityModifications(nil); len(mods) != 1 {
+		t.Fatalf("expected 1 entity modification, got %d", len(mods))
+	}
+}
+
+func TestGenerateReplenishment_NilBusFails(t *testing.T) {
+	handler := inventory.NewGenerateReplenishmentHandler(nil, nil)
+
+	result, err := handler.Execute(context.Background(), json.RawMessage(`{}`), workflow.ActionExecutionContext{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if out := result.(map[string]any)["output"]; out != "failure" {
+		t.Fatalf("expected failure output, got %v", out)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryreservationbus"
 	"github.com/timmaaaz/ichor/business/sdk/order"
 	"github.com/timmaaaz/ichor/business/sdk/page"
 	"github.com/timmaaaz/ichor/business/sdk/sqldb"
@@ -38,6 +39,7 @@ type ReleaseReservationHandler struct {
 	log              *logger.Logger
 	db               *sqlx.DB
 	inventoryItemBus *inventoryitembus.Business
+	reservationBus   *inventoryreservationbus.Business
 }
 
 // NewReleaseReservationHandler creates a new release reservation handler.
@@ -45,11 +47,13 @@ func NewReleaseReservationHandler(
 	log *logger.Logger,
 	db *sqlx.DB,
 	inventoryItemBus *inventoryitembus.Business,
+	reservationBus *inventoryreservationbus.Business,
 ) *ReleaseReservationHandler {
 	return &ReleaseReservationHandler{
 		log:              log,
 		db:               db,
 		inventoryItemBus: inventoryItemBus,
+		reservationBus:   reservationBus,
 	}
 }
 
@@ -152,7 +156,12 @@ func (h *ReleaseReservationHandler) Execute(ctx context.Context, config json.Raw
 		return ReleaseReservationResult{}, fmt.Errorf("no inventory item found for product %s at location %s", productID, locationID)
 	}
 
-	item := items[0]
+	// Lock the row: the reservation reaper updates reserved_quantity
+	// concurrently, and the update below writes an absolute value.
+	item, err := txItemBus.QueryByIDForUpdate(ctx, items[0].ID)
+	if err != nil {
+		return ReleaseReservationResult{}, fmt.Errorf("lock inventory item: %w", err)
+	}
 
 	// Validate sufficient reserved quantity.
 	if item.ReservedQuantity < cfg.Quantity {
@@ -170,6 +179,15 @@ func (h *ReleaseReservationHandler) Execute(ctx context.Context, config json.Raw
 		return ReleaseReservationResult{}, fmt.Errorf("update inventory item: %w", err)
 	}
 
+	txReservationBus, err := reservationsWithTx(h.reservationBus, tx)
+	if err != nil {
+		return ReleaseReservationResult{}, err
+	}
+
+	if err := consumeHolds(ctx, txReservationBus, item.ID, cfg.Quantity, inventoryreservationbus.StatusReleased); err != nil {
+		return ReleaseReservationResult{}, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return ReleaseReservationResult{}, fmt.Errorf("commit transaction: %w", err)
 	}
@@ -44,7 +44,7 @@ func Test_ReleaseReservation(t *testing.T) {
 		return otel.GetTraceID(context.Background())
 	})
 
-	sd.Handler = inventory.NewReleaseReservationHandler(log, db.DB, db.BusDomain.InventoryItem)
+	sd.Handler = inventory.NewReleaseReservationHandler(log, db.DB, db.BusDomain.InventoryItem, db.BusDomain.InventoryReservation)
 
 	unitest.Run(t, releaseReservationTests(db.BusDomain, db.DB, sd), "releaseReservation")
 }
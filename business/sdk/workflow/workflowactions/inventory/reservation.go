@@ -0,0 +1,84 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryreservationbus"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+)
+
+// reservationsWithTx binds the reservation bus to tx. Handlers built without
+// a reservation bus (nil) keep the legacy behaviour of only moving
+// reserved_quantity, so it returns nil for them.
+func reservationsWithTx(bus *inventoryreservationbus.Business, tx sqldb.CommitRollbacker) (*inventoryreservationbus.Business, error) {
+	if bus == nil {
+		return nil, nil
+	}
+
+	txBus, err := bus.NewWithTx(tx)
+	if err != nil {
+		return nil, fmt.Errorf("create transactional reservation bus: %w", err)
+	}
+
+	return txBus, nil
+}
+
+// holdRequest describes a reservation placed by an action on one inventory item.
+type holdRequest struct {
+	source         string
+	referenceID    string
+	referenceType  string
+	idempotencyKey string
+	expiresAt      time.Time
+}
+
+// recordHold records the reservation behind a reserved_quantity increase so
+// the reservation reaper can release it once it expires. No-op when bus is nil.
+func recordHold(ctx context.Context, bus *inventoryreservationbus.Business, item inventoryitembus.InventoryItem, quantity int, req holdRequest, execContext workflow.ActionExecutionContext) error {
+	if bus == nil {
+		return nil
+	}
+
+	nr := inventoryreservationbus.NewReservation{
+		InventoryItemID: item.ID,
+		ProductID:       item.ProductID,
+		LocationID:      item.LocationID,
+		Quantity:        quantity,
+		Source:          req.source,
+		ReferenceID:     req.referenceID,
+		ReferenceType:   req.referenceType,
+		IdempotencyKey:  req.idempotencyKey,
+		ExpiresAt:       req.expiresAt,
+	}
+
+	if execContext.UserID != uuid.Nil {
+		userID := execContext.UserID
+		nr.CreatedBy = &userID
+	}
+
+	if _, err := bus.Create(ctx, nr); err != nil {
+		return fmt.Errorf("record reservation: %w", err)
+	}
+
+	return nil
+}
+
+// consumeHolds closes the recorded reservations behind a reserved_quantity
+// decrease, so the reaper does not release the same stock a second time.
+// No-op when bus is nil.
+func consumeHolds(ctx context.Context, bus *inventoryreservationbus.Business, inventoryItemID uuid.UUID, quantity int, status string) error {
+	if bus == nil {
+		return nil
+	}
+
+	if _, err := bus.Consume(ctx, inventoryItemID, quantity, status); err != nil {
+		return fmt.Errorf("consume reservations: %w", err)
+	}
+
+	return nil
+}
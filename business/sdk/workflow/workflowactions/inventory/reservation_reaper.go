@@ -0,0 +1,230 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryreservationbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
+	"github.com/timmaaaz/ichor/business/sdk/sqldb"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// TransactionTypeReservationExpired is the inventory_transactions type written
+// when the reaper gives an expired reservation back to available stock.
+const TransactionTypeReservationExpired = "RESERVATION_EXPIRED"
+
+// ReservationReaperConfig tunes the reaper. Zero-value fields fall back to defaults.
+type ReservationReaperConfig struct {
+	Interval  time.Duration // how often to sweep (default 1m)
+	BatchSize int           // max reservations released per sweep (default 100)
+
+	// SystemUserID is the user the ledger row is attributed to when the
+	// reservation has no creator, e.g. one made by a scheduled rule. Without
+	// it such reservations are left active rather than released unaudited.
+	SystemUserID uuid.UUID
+}
+
+func (c ReservationReaperConfig) withDefaults() ReservationReaperConfig {
+	if c.Interval <= 0 {
+		c.Interval = time.Minute
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 100
+	}
+	return c
+}
+
+// ReservationReaper periodically releases reservations whose expires_at has
+// passed. Each one is released in its own transaction: the inventory item is
+// locked, its reserved_quantity reduced, the reservation marked expired (which
+// emits inventoryreservation.updated through the outbox so rules can react)
+// and a RESERVATION_EXPIRED row written to inventory_transactions.
+//
+// Every writer of a reservation locks its inventory item first, so the reaper
+// cannot race commit_allocation or release_reservation into releasing stock
+// twice. SERVER-ONLY (started by the composition root next to the execution
+// reaper); the worker does not run it.
+type ReservationReaper struct {
+	log            *logger.Logger
+	db             *sqlx.DB
+	reservationBus *inventoryreservationbus.Business
+	itemBus        *inventoryitembus.Business
+	transactionBus *inventorytransactionbus.Business
+	cfg            ReservationReaperConfig
+	now            func() time.Time
+}
+
+// NewReservationReaper constructs a ReservationReaper.
+func NewReservationReaper(
+	log *logger.Logger,
+	db *sqlx.DB,
+	reservationBus *inventoryreservationbus.Business,
+	itemBus *inventoryitembus.Business,
+	transactionBus *inventorytransactionbus.Business,
+	cfg ReservationReaperConfig,
+) *ReservationReaper {
+	return &ReservationReaper{
+		log:            log,
+		db:             db,
+		reservationBus: reservationBus,
+		itemBus:        itemBus,
+		transactionBus: transactionBus,
+		cfg:            cfg.withDefaults(),
+		now:            time.Now,
+	}
+}
+
+// Run sweeps every cfg.Interval until ctx is cancelled. Intended to be launched in a goroutine
+// by the composition root. Returns ctx.Err() when stopped.
+func (r *ReservationReaper) Run(ctx context.Context) error {
+	r.log.Info(ctx, "reservation reaper starting", "interval", r.cfg.Interval, "batch_size", r.cfg.BatchSize)
+
+	t := time.NewTicker(r.cfg.Interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.log.Info(ctx, "reservation reaper stopping", "reason", ctx.Err())
+			return ctx.Err()
+		case <-t.C:
+			n, err := r.sweep(ctx)
+			if err != nil {
+				r.log.Error(ctx, "reservation reaper: sweep failed", "error", err)
+				continue
+			}
+			if n > 0 {
+				r.log.Info(ctx, "reservation reaper: released expired reservations", "count", n)
+			}
+		}
+	}
+}
+
+// sweep releases up to one batch of expired reservations. A failure on one
+// reservation is logged and does not stop the rest of the batch.
+func (r *ReservationReaper) sweep(ctx context.Context) (int, error) {
+	now := r.now()
+
+	expired, err := r.reservationBus.QueryExpired(ctx, now, r.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("query expired: %w", err)
+	}
+
+	released := 0
+	for _, res := range expired {
+		ok, err := r.release(ctx, res, now)
+		if err != nil {
+			r.log.Error(ctx, "reservation reaper: release failed", "reservation_id", res.ID, "error", err)
+			continue
+		}
+		if ok {
+			released++
+		}
+	}
+
+	return released, nil
+}
+
+// release expires one reservation in its own transaction. Returns false when
+// the reservation was committed, released or extended since it was listed.
+func (r *ReservationReaper) release(ctx context.Context, res inventoryreservationbus.Reservation, now time.Time) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+	if err != nil {
+		return false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Carry the tx on ctx so the reservation's outbox event is written in
+	// this transaction and dispatched only after commit.
+	ctx = sqldb.WithTx(ctx, tx)
+
+	txItemBus, err := r.itemBus.NewWithTx(tx)
+	if err != nil {
+		return false, fmt.Errorf("create transactional item bus: %w", err)
+	}
+
+	txReservationBus, err := r.reservationBus.NewWithTx(tx)
+	if err != nil {
+		return false, fmt.Errorf("create transactional reservation bus: %w", err)
+	}
+
+	txTransactionBus, err := r.transactionBus.NewWithTx(tx)
+	if err != nil {
+		return false, fmt.Errorf("create transactional transaction bus: %w", err)
+	}
+
+	// Lock the item before re-reading the reservation: writers of the item's
+	// reservations hold the same lock, so the re-read is current.
+	item, err := txItemBus.QueryByIDForUpdate(ctx, res.InventoryItemID)
+	if err != nil {
+		return false, fmt.Errorf("lock inventory item: %w", err)
+	}
+
+	res, err = txReservationBus.QueryByID(ctx, res.ID)
+	if err != nil {
+		return false, fmt.Errorf("reload reservation: %w", err)
+	}
+
+	if res.Status != inventoryreservationbus.StatusActive || res.ExpiresAt.After(now) {
+		return false, nil
+	}
+
+	// Never drive reserved_quantity negative, e.g. after a manual correction.
+	qty := min(res.Quantity, item.ReservedQuantity)
+
+	if qty > 0 {
+		newReserved := item.ReservedQuantity - qty
+		if _, err := txItemBus.Update(ctx, item, inventoryitembus.UpdateInventoryItem{
+			ReservedQuantity: &newReserved,
+		}); err != nil {
+			return false, fmt.Errorf("update inventory item: %w", err)
+		}
+	}
+
+	if _, err := txReservationBus.Expire(ctx, res, now); err != nil {
+		return false, fmt.Errorf("expire reservation: %w", err)
+	}
+
+	if qty > 0 {
+		userID := r.cfg.SystemUserID
+		if res.CreatedBy != nil {
+			userID = *res.CreatedBy
+		}
+		if userID == uuid.Nil {
+			// inventory_transactions.user_id is required; roll back rather
+			// than release stock with no ledger entry.
+			return false, fmt.Errorf("no user to attribute the release to: reservation has no creator and no system user is configured")
+		}
+
+		ref := res.ReferenceID
+		if ref == "" {
+			ref = res.ID.String()
+		}
+
+		if _, err := txTransactionBus.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
+			ProductID:       res.ProductID,
+			LocationID:      res.LocationID,
+			UserID:          userID,
+			Quantity:        qty,
+			TransactionType: TransactionTypeReservationExpired,
+			ReferenceNumber: ref,
+			TransactionDate: now,
+		}); err != nil {
+			return false, fmt.Errorf("create inventory transaction: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return true, nil
+}
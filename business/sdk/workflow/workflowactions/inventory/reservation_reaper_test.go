@@ -0,0 +1,153 @@
+package inventory_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryreservationbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
+	"github.com/timmaaaz/ichor/business/sdk/dbtest"
+	"github.com/timmaaaz/ichor/business/sdk/page"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/inventory"
+)
+
+func Test_ReservationReaper(t *testing.T) {
+	db := dbtest.NewDatabase(t, "Test_ReservationReaper")
+	ctx := context.Background()
+
+	sd, err := insertReserveInventorySeedData(db.BusDomain)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	handler := inventory.NewReserveInventoryHandler(db.Log, db.DB, db.BusDomain.InventoryItem, db.BusDomain.Workflow, db.BusDomain.InventoryReservation)
+	reaper := inventory.NewReservationReaper(db.Log, db.DB, db.BusDomain.InventoryReservation, db.BusDomain.InventoryItem, db.BusDomain.InventoryTransaction, inventory.ReservationReaperConfig{})
+
+	item := sd.InventoryItems[len(sd.InventoryItems)-1]
+
+	cfg, _ := json.Marshal(inventory.ReserveInventoryConfig{
+		ProductID:              item.ProductID.String(),
+		LocationID:             item.LocationID.String(),
+		Quantity:               7,
+		ReservationDurationHrs: 1,
+		ReferenceID:            "SO-REAPER-1",
+		ReferenceType:          "order",
+	})
+
+	ruleID := uuid.New()
+	execCtx := workflow.ActionExecutionContext{
+		UserID:        sd.Admins[0].ID,
+		RuleID:        &ruleID,
+		ExecutionID:   uuid.New(),
+		Timestamp:     time.Now().UTC(),
+		TriggerSource: workflow.TriggerSourceAutomation,
+	}
+
+	result, err := handler.Execute(ctx, cfg, execCtx)
+	if err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	if r := result.(inventory.ReserveInventoryResult); r.TotalReserved != 7 {
+		t.Fatalf("reserved %d, want 7", r.TotalReserved)
+	}
+
+	active, err := db.BusDomain.InventoryReservation.QueryActiveByItem(ctx, item.ID)
+	if err != nil {
+		t.Fatalf("query active: %v", err)
+	}
+	if len(active) != 1 || active[0].Quantity != 7 || active[0].ReferenceID != "SO-REAPER-1" {
+		t.Fatalf("active reservations = %+v, want one hold of 7 for SO-REAPER-1", active)
+	}
+
+	// Not expired yet: nothing to release.
+	n, err := reaper.SweepAt(ctx, time.Now())
+	if err != nil || n != 0 {
+		t.Fatalf("early sweep released %d (err %v), want 0", n, err)
+	}
+
+	n, err = reaper.SweepAt(ctx, time.Now().Add(2*time.Hour))
+	if err != nil || n != 1 {
+		t.Fatalf("sweep released %d (err %v), want 1", n, err)
+	}
+
+	got, err := db.BusDomain.InventoryItem.QueryByID(ctx, item.ID)
+	if err != nil {
+		t.Fatalf("query item: %v", err)
+	}
+	if got.ReservedQuantity != item.ReservedQuantity {
+		t.Fatalf("reserved_quantity = %d, want %d after release", got.ReservedQuantity, item.ReservedQuantity)
+	}
+
+	res, err := db.BusDomain.InventoryReservation.QueryByID(ctx, active[0].ID)
+	if err != nil {
+		t.Fatalf("query reservation: %v", err)
+	}
+	if res.Status != inventoryreservationbus.StatusExpired || res.ReleasedDate == nil {
+		t.Fatalf("reservation status = %s released=%v, want expired with a release date", res.Status, res.ReleasedDate)
+	}
+
+	txType := inventory.TransactionTypeReservationExpired
+	txs, err := db.BusDomain.InventoryTransaction.Query(ctx, inventorytransactionbus.QueryFilter{ProductID: &item.ProductID, TransactionType: &txType}, inventorytransactionbus.DefaultOrderBy, page.MustParse("1", "10"))
+	if err != nil {
+		t.Fatalf("query transactions: %v", err)
+	}
+	if len(txs) != 1 || txs[0].Quantity != 7 || txs[0].ReferenceNumber != "SO-REAPER-1" {
+		t.Fatalf("ledger = %+v, want one RESERVATION_EXPIRED row of 7", txs)
+	}
+
+	// Already expired: a second sweep is a no-op.
+	n, err = reaper.SweepAt(ctx, time.Now().Add(2*time.Hour))
+	if err != nil || n != 0 {
+		t.Fatalf("second sweep released %d (err %v), want 0", n, err)
+	}
+
+	// A reservation from a run with no user: without a system user it stays
+	// held, with one it is released and the ledger row attributed to it.
+	cfg, _ = json.Marshal(inventory.ReserveInventoryConfig{
+		ProductID:              item.ProductID.String(),
+		LocationID:             item.LocationID.String(),
+		Quantity:               3,
+		ReservationDurationHrs: 1,
+		ReferenceID:            "SO-REAPER-2",
+		ReferenceType:          "order",
+	})
+
+	execCtx.UserID = uuid.Nil
+	if _, err := handler.Execute(ctx, cfg, execCtx); err != nil {
+		t.Fatalf("reserve without user: %v", err)
+	}
+
+	n, err = reaper.SweepAt(ctx, time.Now().Add(2*time.Hour))
+	if err != nil || n != 0 {
+		t.Fatalf("sweep without system user released %d (err %v), want 0", n, err)
+	}
+
+	systemUser := sd.Admins[0].ID
+	systemReaper := inventory.NewReservationReaper(db.Log, db.DB, db.BusDomain.InventoryReservation, db.BusDomain.InventoryItem, db.BusDomain.InventoryTransaction, inventory.ReservationReaperConfig{
+		SystemUserID: systemUser,
+	})
+
+	n, err = systemReaper.SweepAt(ctx, time.Now().Add(2*time.Hour))
+	if err != nil || n != 1 {
+		t.Fatalf("sweep with system user released %d (err %v), want 1", n, err)
+	}
+
+	txs, err = db.BusDomain.InventoryTransaction.Query(ctx, inventorytransactionbus.QueryFilter{ProductID: &item.ProductID, TransactionType: &txType}, inventorytransactionbus.DefaultOrderBy, page.MustParse("1", "10"))
+	if err != nil {
+		t.Fatalf("query transactions: %v", err)
+	}
+
+	var found bool
+	for _, tx := range txs {
+		if tx.ReferenceNumber == "SO-REAPER-2" {
+			found = tx.Quantity == 3 && tx.UserID == systemUser
+		}
+	}
+	if !found {
+		t.Fatalf("ledger = %+v, want a RESERVATION_EXPIRED row of 3 by the system user", txs)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryreservationbus"
 	"github.com/timmaaaz/ichor/business/sdk/sqldb"
 	"github.com/timmaaaz/ichor/business/sdk/workflow"
 	"github.com/timmaaaz/ichor/foundation/logger"
@@ -63,6 +64,7 @@ type ReserveInventoryHandler struct {
 	db               *sqlx.DB
 	inventoryItemBus *inventoryitembus.Business
 	workflowBus      *workflow.Business
+	reservationBus   *inventoryreservationbus.Business
 }
 
 // NewReserveInventoryHandler creates a new reserve inventory handler.
@@ -71,12 +73,14 @@ func NewReserveInventoryHandler(
 	db *sqlx.DB,
 	inventoryItemBus *inventoryitembus.Business,
 	workflowBus *workflow.Business,
+	reservationBus *inventoryreservationbus.Business,
 ) *ReserveInventoryHandler {
 	return &ReserveInventoryHandler{
 		log:              log,
 		db:               db,
 		inventoryItemBus: inventoryItemBus,
 		workflowBus:      workflowBus,
+		reservationBus:   reservationBus,
 	}
 }
 
@@ -278,6 +282,11 @@ func (h *ReserveInventoryHandler) processReservation(
 		return nil, fmt.Errorf("create transactional item bus: %w", err)
 	}
 
+	txReservationBus, err := reservationsWithTx(h.reservationBus, tx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Query available inventory (FOR UPDATE via the specialized method).
 	items, err := txItemBus.QueryAvailableForAllocation(
 		ctx,
@@ -344,6 +353,17 @@ func (h *ReserveInventoryHandler) processReservation(
 				continue
 			}
 
+			hold := holdRequest{
+				source:         h.GetType(),
+				referenceID:    cfg.ReferenceID,
+				referenceType:  cfg.ReferenceType,
+				idempotencyKey: idempotencyKey,
+				expiresAt:      expiresAt,
+			}
+			if err := recordHold(ctx, txReservationBus, invItem, toReserve, hold, execContext); err != nil {
+				return nil, err
+			}
+
 			result.ReservedItems = append(result.ReservedItems, ReservedItem{
 				ProductID:         productID,
 				LocationID:        invItem.LocationID,
@@ -484,5 +504,10 @@ func (h *ReserveInventoryHandler) GetEntityModifications(config json.RawMessage)
 			EventType:  "on_create",
 			Fields:     nil, // New record, all fields are "created"
 		},
+		{
+			EntityName: "inventory.inventory_reservations",
+			EventType:  "on_create",
+			Fields:     nil,
+		},
 	}
 }
@@ -44,7 +44,7 @@ func Test_ReserveInventory(t *testing.T) {
 		return otel.GetTraceID(context.Background())
 	})
 
-	sd.Handler = inventory.NewReserveInventoryHandler(log, db.DB, db.BusDomain.InventoryItem, db.BusDomain.Workflow)
+	sd.Handler = inventory.NewReserveInventoryHandler(log, db.DB, db.BusDomain.InventoryItem, db.BusDomain.Workflow, db.BusDomain.InventoryReservation)
 
 	unitest.Run(t, reserveInventoryTests(db.BusDomain, db.DB, sd), "reserveInventory")
 }
@@ -54,7 +54,7 @@ func Test_ReserveInventory(t *testing.T) {
 // edge wired to that port is valid at save time. Mirrors the GetOutputPorts
 // tests on sibling inventory handlers. Pure metadata — no DB needed.
 func TestReserveInventory_GetOutputPorts(t *testing.T) {
-	handler := inventory.NewReserveInventoryHandler(nil, nil, nil, nil)
+	handler := inventory.NewReserveInventoryHandler(nil, nil, nil, nil, nil)
 	ports := handler.GetOutputPorts()
 
 	want := map[string]bool{"success": false, "partial": false, "insufficient_stock": false, "failure": false}
@@ -0,0 +1,86 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/transferorderbus"
+)
+
+// moveTransferStock applies the movements of a transfer claim or receipt,
+// mirroring transferorderapp so the button path equals the REST path: each
+// line's quantity is decremented at from and incremented at dest with a
+// TRANSFER_OUT/TRANSFER_IN ledger pair, and any discrepancy is written off at
+// from with an ADJUSTMENT. The buses must already be bound to the caller's
+// transaction. A shortfall at from is returned wrapping
+// inventoryitembus.ErrInsufficientStock.
+func moveTransferStock(
+	ctx context.Context,
+	invTransactionBus *inventorytransactionbus.Business,
+	invItemBus *inventoryitembus.Business,
+	to transferorderbus.TransferOrder,
+	userID uuid.UUID,
+	from uuid.UUID,
+	dest uuid.UUID,
+	moves []transferorderbus.Movement,
+) error {
+	refNum := to.TransferID.String()
+	now := time.Now()
+
+	for _, m := range moves {
+		if m.Quantity > 0 {
+			if _, err := invTransactionBus.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
+				ProductID:       m.ProductID,
+				LocationID:      from,
+				UserID:          userID,
+				Quantity:        -m.Quantity,
+				TransactionType: "TRANSFER_OUT",
+				ReferenceNumber: refNum,
+				TransactionDate: now,
+			}); err != nil {
+				return fmt.Errorf("create transfer_out transaction: %w", err)
+			}
+			if _, err := invTransactionBus.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
+				ProductID:       m.ProductID,
+				LocationID:      dest,
+				UserID:          userID,
+				Quantity:        m.Quantity,
+				TransactionType: "TRANSFER_IN",
+				ReferenceNumber: refNum,
+				TransactionDate: now,
+			}); err != nil {
+				return fmt.Errorf("create transfer_in transaction: %w", err)
+			}
+
+			if err := invItemBus.DecrementQuantity(ctx, m.ProductID, from, m.Quantity); err != nil {
+				return fmt.Errorf("decrement source inventory: %w", err)
+			}
+			if err := invItemBus.UpsertQuantity(ctx, m.ProductID, dest, m.Quantity); err != nil {
+				return fmt.Errorf("upsert destination inventory: %w", err)
+			}
+		}
+
+		if m.Discrepancy > 0 {
+			if _, err := invTransactionBus.Create(ctx, inventorytransactionbus.NewInventoryTransaction{
+				ProductID:       m.ProductID,
+				LocationID:      from,
+				UserID:          userID,
+				Quantity:        -m.Discrepancy,
+				TransactionType: "ADJUSTMENT",
+				ReferenceNumber: refNum,
+				TransactionDate: now,
+			}); err != nil {
+				return fmt.Errorf("create discrepancy transaction: %w", err)
+			}
+			if err := invItemBus.DecrementQuantity(ctx, m.ProductID, from, m.Discrepancy); err != nil {
+				return fmt.Errorf("write off in-transit inventory: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
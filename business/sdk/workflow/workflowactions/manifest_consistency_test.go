@@ -22,10 +22,16 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryadjustmentbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/putawaytaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/transferorderbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
 	"github.com/timmaaaz/ichor/business/sdk/workflow"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions"
 	"github.com/timmaaaz/ichor/foundation/logger"
@@ -78,6 +84,12 @@ func buildFullRegistry(t *testing.T) *workflow.ActionRegistry {
 			TransferOrder:       &transferorderbus.Business{},
 			PutAwayTask:         &putawaytaskbus.Business{},
 			PurchaseOrder:       &purchaseorderbus.Business{},
+			Shipment:            &shipmentbus.Business{},
+			CountPlan:           &countplanbus.Business{},
+			ReplenishmentTask:   &replenishmenttaskbus.Business{},
+			DemandForecast:      &demandforecastbus.Business{},
+			PurchaseSuggestion:  &purchasesuggestionbus.Business{},
+			SupplierInvoice:     &supplierinvoicebus.Business{},
 		},
 	})
 	return reg
@@ -0,0 +1,152 @@
+package procurement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+	"github.com/timmaaaz/ichor/business/domain/workflow/approvalrequestbus"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// ApproveSupplierInvoiceConfig holds the config for the approve supplier
+// invoice handler.
+type ApproveSupplierInvoiceConfig struct {
+	SupplierInvoiceID string `json:"supplier_invoice_id"`
+
+	// ApprovalRequestID is the approval request the invoice was routed to,
+	// usually {{<seek_approval action name>.approval_id}}. The invoice is
+	// only approved once that request has been.
+	ApprovalRequestID string `json:"approval_request_id"`
+	ApprovalReason    string `json:"approval_reason,omitempty"`
+}
+
+// ApproveSupplierInvoiceHandler handles approve_supplier_invoice actions. It
+// accepts an invoice left pending approval by a variance after the
+// seek_approval step it was routed to has been approved, and attributes the
+// approval to whoever resolved that request.
+type ApproveSupplierInvoiceHandler struct {
+	log                *logger.Logger
+	supplierInvoiceBus *supplierinvoicebus.Business
+	approvalRequestBus *approvalrequestbus.Business
+}
+
+// NewApproveSupplierInvoiceHandler creates a new approve supplier invoice
+// handler.
+func NewApproveSupplierInvoiceHandler(log *logger.Logger, supplierInvoiceBus *supplierinvoicebus.Business, approvalRequestBus *approvalrequestbus.Business) *ApproveSupplierInvoiceHandler {
+	return &ApproveSupplierInvoiceHandler{
+		log:                log,
+		supplierInvoiceBus: supplierInvoiceBus,
+		approvalRequestBus: approvalRequestBus,
+	}
+}
+
+// GetType returns the action type.
+func (h *ApproveSupplierInvoiceHandler) GetType() string { return "approve_supplier_invoice" }
+
+// IsAsync returns false — approve completes inline.
+func (h *ApproveSupplierInvoiceHandler) IsAsync() bool { return false }
+
+// SupportsManualExecution returns true.
+func (h *ApproveSupplierInvoiceHandler) SupportsManualExecution() bool { return true }
+
+// GetDescription returns a human-readable description.
+func (h *ApproveSupplierInvoiceHandler) GetDescription() string {
+	return "Approve a supplier invoice pending approval for payment"
+}
+
+// Validate validates the approve supplier invoice configuration.
+func (h *ApproveSupplierInvoiceHandler) Validate(config json.RawMessage) error {
+	var cfg ApproveSupplierInvoiceConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	if err := validateSupplierInvoiceID(cfg.SupplierInvoiceID); err != nil {
+		return err
+	}
+	return validateApprovalRequestID(cfg.ApprovalRequestID)
+}
+
+// GetOutputPorts implements workflow.OutputPortProvider.
+func (h *ApproveSupplierInvoiceHandler) GetOutputPorts() []workflow.OutputPort {
+	return []workflow.OutputPort{
+		{Name: "approved", Description: "Supplier invoice approved successfully", IsDefault: true},
+		{Name: "not_found", Description: "Supplier invoice not found"},
+		{Name: "not_matched", Description: "Supplier invoice has not been matched yet"},
+		{Name: "not_approved", Description: "The approval request has not been approved"},
+		{Name: "already_approved", Description: "Supplier invoice was already approved (idempotent)"},
+		{Name: "already_rejected", Description: "Supplier invoice was already rejected — cannot approve"},
+		{Name: "failure", Description: "Unexpected error"},
+	}
+}
+
+// GetEntityModifications implements workflow.EntityModifier.
+func (h *ApproveSupplierInvoiceHandler) GetEntityModifications(config json.RawMessage) []workflow.EntityModification {
+	return []workflow.EntityModification{
+		{EntityName: "procurement.supplier_invoices", EventType: "on_update", Fields: []string{"status", "approved_by", "approved_date", "approval_reason"}},
+	}
+}
+
+// Execute approves a supplier invoice.
+func (h *ApproveSupplierInvoiceHandler) Execute(ctx context.Context, config json.RawMessage, execCtx workflow.ActionExecutionContext) (any, error) {
+	var cfg ApproveSupplierInvoiceConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	if h.supplierInvoiceBus == nil || h.approvalRequestBus == nil {
+		return map[string]any{"output": "failure", "error": "supplier invoice and approval request buses not configured"}, nil
+	}
+
+	id, err := workflow.ResolveConfigID("supplier_invoice_id", cfg.SupplierInvoiceID, execCtx)
+	if err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	approval, err := queryApproval(ctx, h.approvalRequestBus, cfg.ApprovalRequestID, execCtx)
+	if err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+	if approval.Status != approvalrequestbus.StatusApproved {
+		return map[string]any{"output": "not_approved", "supplier_invoice_id": id.String(), "approval_request_id": approval.ID.String(), "approval_status": approval.Status}, nil
+	}
+
+	approvedBy, err := approvalActor(approval, execCtx)
+	if err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	invoice, err := h.supplierInvoiceBus.QueryByID(ctx, *id)
+	if err != nil {
+		if errors.Is(err, supplierinvoicebus.ErrNotFound) {
+			return map[string]any{"output": "not_found", "supplier_invoice_id": id.String()}, nil
+		}
+		return nil, fmt.Errorf("query supplier invoice: %w", err)
+	}
+
+	approved, err := h.supplierInvoiceBus.Approve(ctx, invoice, approvedBy, cfg.ApprovalReason, time.Now())
+	if err != nil {
+		switch {
+		case errors.Is(err, supplierinvoicebus.ErrNotMatched):
+			return map[string]any{"output": "not_matched", "supplier_invoice_id": id.String()}, nil
+		case errors.Is(err, supplierinvoicebus.ErrAlreadyApproved):
+			return map[string]any{"output": "already_approved", "supplier_invoice_id": id.String()}, nil
+		case errors.Is(err, supplierinvoicebus.ErrAlreadyRejected):
+			return map[string]any{"output": "already_rejected", "supplier_invoice_id": id.String()}, nil
+		}
+		return nil, fmt.Errorf("approve supplier invoice: %w", err)
+	}
+
+	return map[string]any{
+		"output":              "approved",
+		"supplier_invoice_id": approved.ID.String(),
+		"approved_by":         approvedBy.String(),
+		"approval_request_id": approval.ID.String(),
+		"approval_reason":     cfg.ApprovalReason,
+		"match_status":        approved.MatchStatus,
+	}, nil
+}
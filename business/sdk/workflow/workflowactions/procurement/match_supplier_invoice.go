@@ -0,0 +1,203 @@
+package procurement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+	"github.com/timmaaaz/ichor/business/domain/workflow/approvalrequestbus"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// MatchSupplierInvoiceConfig holds the config for the match supplier invoice
+// handler.
+type MatchSupplierInvoiceConfig struct {
+	// SupplierInvoiceID is the invoice to match. A templated value, such as
+	// {{entity_id}} on a rule over supplier_invoices, is resolved against the
+	// execution context.
+	SupplierInvoiceID string `json:"supplier_invoice_id"`
+
+	// MatchedBy attributes the match when the trigger carries no user, as
+	// scheduled triggers do.
+	MatchedBy string `json:"matched_by,omitempty"`
+}
+
+// MatchSupplierInvoiceHandler handles match_supplier_invoice actions: it runs
+// the three-way match of an invoice against its purchase order and receipts.
+// A matched invoice is approved for payment; a price or quantity variance
+// leaves it pending approval and leaves through the port of that variance so
+// the rule can route it to an approver.
+type MatchSupplierInvoiceHandler struct {
+	log                *logger.Logger
+	supplierInvoiceBus *supplierinvoicebus.Business
+}
+
+// NewMatchSupplierInvoiceHandler creates a new match supplier invoice handler.
+func NewMatchSupplierInvoiceHandler(log *logger.Logger, supplierInvoiceBus *supplierinvoicebus.Business) *MatchSupplierInvoiceHandler {
+	return &MatchSupplierInvoiceHandler{
+		log:                log,
+		supplierInvoiceBus: supplierInvoiceBus,
+	}
+}
+
+// GetType returns the action type.
+func (h *MatchSupplierInvoiceHandler) GetType() string { return "match_supplier_invoice" }
+
+// IsAsync returns false — matching completes inline.
+func (h *MatchSupplierInvoiceHandler) IsAsync() bool { return false }
+
+// SupportsManualExecution returns true.
+func (h *MatchSupplierInvoiceHandler) SupportsManualExecution() bool { return true }
+
+// GetDescription returns a human-readable description.
+func (h *MatchSupplierInvoiceHandler) GetDescription() string {
+	return "Three-way match a supplier invoice against its purchase order and receipts"
+}
+
+// Validate validates the match supplier invoice configuration.
+func (h *MatchSupplierInvoiceHandler) Validate(config json.RawMessage) error {
+	var cfg MatchSupplierInvoiceConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	if err := validateSupplierInvoiceID(cfg.SupplierInvoiceID); err != nil {
+		return err
+	}
+	if cfg.MatchedBy != "" {
+		if _, err := uuid.Parse(cfg.MatchedBy); err != nil {
+			return fmt.Errorf("invalid matched_by: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetOutputPorts implements workflow.OutputPortProvider.
+func (h *MatchSupplierInvoiceHandler) GetOutputPorts() []workflow.OutputPort {
+	return []workflow.OutputPort{
+		{Name: "matched", Description: "The invoice matched within tolerance and was approved", IsDefault: true},
+		{Name: "price_variance", Description: "A line is priced over its order line; the invoice awaits approval"},
+		{Name: "quantity_variance", Description: "A line bills more than was received; the invoice awaits approval"},
+		{Name: "not_found", Description: "Supplier invoice not found"},
+		{Name: "closed", Description: "The invoice is already approved or rejected"},
+		{Name: "failure", Description: "Unexpected error"},
+	}
+}
+
+// GetEntityModifications implements workflow.EntityModifier.
+func (h *MatchSupplierInvoiceHandler) GetEntityModifications(config json.RawMessage) []workflow.EntityModification {
+	return []workflow.EntityModification{
+		{EntityName: "procurement.supplier_invoices", EventType: "on_update", Fields: []string{"status", "match_status", "matched_date"}},
+	}
+}
+
+// Execute matches a supplier invoice.
+func (h *MatchSupplierInvoiceHandler) Execute(ctx context.Context, config json.RawMessage, execCtx workflow.ActionExecutionContext) (any, error) {
+	var cfg MatchSupplierInvoiceConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	if h.supplierInvoiceBus == nil {
+		return map[string]any{"output": "failure", "error": "supplier invoice bus not configured"}, nil
+	}
+
+	userID, err := workflow.ActingUser(execCtx, "matched_by", cfg.MatchedBy)
+	if err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	id, err := workflow.ResolveConfigID("supplier_invoice_id", cfg.SupplierInvoiceID, execCtx)
+	if err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	invoice, err := h.supplierInvoiceBus.QueryByID(ctx, *id)
+	if err != nil {
+		if errors.Is(err, supplierinvoicebus.ErrNotFound) {
+			return map[string]any{"output": "not_found", "supplier_invoice_id": id.String()}, nil
+		}
+		return nil, fmt.Errorf("query supplier invoice: %w", err)
+	}
+
+	matched, err := h.supplierInvoiceBus.Match(ctx, invoice, userID, time.Now())
+	if err != nil {
+		if errors.Is(err, supplierinvoicebus.ErrInvoiceClosed) {
+			return map[string]any{"output": "closed", "supplier_invoice_id": id.String(), "status": invoice.Status}, nil
+		}
+		return nil, fmt.Errorf("match supplier invoice: %w", err)
+	}
+
+	var priceVariance float64
+	var quantityVariance int
+	for _, l := range matched.Lines {
+		priceVariance += max(l.PriceVariance, 0)
+		quantityVariance += max(l.QuantityVariance, 0)
+	}
+
+	return map[string]any{
+		"output":              matched.MatchStatus,
+		"supplier_invoice_id": matched.ID.String(),
+		"purchase_order_id":   matched.PurchaseOrderID.String(),
+		"status":              matched.Status,
+		"match_status":        matched.MatchStatus,
+		"total_amount":        matched.TotalAmount,
+		"price_variance":      priceVariance,
+		"quantity_variance":   quantityVariance,
+	}, nil
+}
+
+// =============================================================================
+
+// validateSupplierInvoiceID checks the required invoice id of the supplier
+// invoice handlers; a templated value is resolved at execution.
+func validateSupplierInvoiceID(value string) error {
+	if value == "" {
+		return fmt.Errorf("supplier_invoice_id is required")
+	}
+	return workflow.ValidateConfigID("supplier_invoice_id", value)
+}
+
+// validateApprovalRequestID checks the required approval request id of the
+// approve and reject handlers.
+func validateApprovalRequestID(value string) error {
+	if value == "" {
+		return fmt.Errorf("approval_request_id is required")
+	}
+	return workflow.ValidateConfigID("approval_request_id", value)
+}
+
+// queryApproval resolves and loads the approval request a supplier invoice
+// was routed to.
+func queryApproval(ctx context.Context, approvalRequestBus *approvalrequestbus.Business, value string, execCtx workflow.ActionExecutionContext) (approvalrequestbus.ApprovalRequest, error) {
+	id, err := workflow.ResolveConfigID("approval_request_id", value, execCtx)
+	if err != nil {
+		return approvalrequestbus.ApprovalRequest{}, err
+	}
+	if id == nil {
+		return approvalrequestbus.ApprovalRequest{}, fmt.Errorf("approval_request_id is required")
+	}
+
+	approval, err := approvalRequestBus.QueryByID(ctx, *id)
+	if err != nil {
+		return approvalrequestbus.ApprovalRequest{}, err
+	}
+	return approval, nil
+}
+
+// approvalActor returns the user a decision on a routed invoice is
+// attributed to: whoever resolved the approval request, or the triggering
+// user when it timed out.
+func approvalActor(approval approvalrequestbus.ApprovalRequest, execCtx workflow.ActionExecutionContext) (uuid.UUID, error) {
+	if approval.ResolvedBy != nil {
+		return *approval.ResolvedBy, nil
+	}
+	if execCtx.UserID == uuid.Nil {
+		return uuid.Nil, fmt.Errorf("approval request %s has no resolver and the trigger no user", approval.ID)
+	}
+	return execCtx.UserID, nil
+}
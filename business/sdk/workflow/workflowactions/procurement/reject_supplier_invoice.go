@@ -0,0 +1,154 @@
+package procurement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
+	"github.com/timmaaaz/ichor/business/domain/workflow/approvalrequestbus"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// RejectSupplierInvoiceConfig holds the config for the reject supplier invoice
+// handler.
+type RejectSupplierInvoiceConfig struct {
+	SupplierInvoiceID string `json:"supplier_invoice_id"`
+
+	// ApprovalRequestID is the approval request the invoice was routed to,
+	// usually {{<seek_approval action name>.approval_id}}. The invoice is
+	// only rejected once that request has been rejected or has timed out.
+	ApprovalRequestID string `json:"approval_request_id"`
+	RejectionReason   string `json:"rejection_reason"`
+}
+
+// RejectSupplierInvoiceHandler handles reject_supplier_invoice actions. A
+// rejected invoice will not be paid and its lines no longer count as billed.
+// The rejection follows the seek_approval step the invoice was routed to and
+// is attributed to whoever resolved that request.
+type RejectSupplierInvoiceHandler struct {
+	log                *logger.Logger
+	supplierInvoiceBus *supplierinvoicebus.Business
+	approvalRequestBus *approvalrequestbus.Business
+}
+
+// NewRejectSupplierInvoiceHandler creates a new reject supplier invoice
+// handler.
+func NewRejectSupplierInvoiceHandler(log *logger.Logger, supplierInvoiceBus *supplierinvoicebus.Business, approvalRequestBus *approvalrequestbus.Business) *RejectSupplierInvoiceHandler {
+	return &RejectSupplierInvoiceHandler{
+		log:                log,
+		supplierInvoiceBus: supplierInvoiceBus,
+		approvalRequestBus: approvalRequestBus,
+	}
+}
+
+// GetType returns the action type.
+func (h *RejectSupplierInvoiceHandler) GetType() string { return "reject_supplier_invoice" }
+
+// IsAsync returns false — reject completes inline.
+func (h *RejectSupplierInvoiceHandler) IsAsync() bool { return false }
+
+// SupportsManualExecution returns true.
+func (h *RejectSupplierInvoiceHandler) SupportsManualExecution() bool { return true }
+
+// GetDescription returns a human-readable description.
+func (h *RejectSupplierInvoiceHandler) GetDescription() string {
+	return "Reject an unresolved supplier invoice with a reason"
+}
+
+// Validate validates the reject supplier invoice configuration.
+func (h *RejectSupplierInvoiceHandler) Validate(config json.RawMessage) error {
+	var cfg RejectSupplierInvoiceConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	if err := validateSupplierInvoiceID(cfg.SupplierInvoiceID); err != nil {
+		return err
+	}
+	if err := validateApprovalRequestID(cfg.ApprovalRequestID); err != nil {
+		return err
+	}
+	if cfg.RejectionReason == "" {
+		return fmt.Errorf("rejection_reason is required")
+	}
+	return nil
+}
+
+// GetOutputPorts implements workflow.OutputPortProvider.
+func (h *RejectSupplierInvoiceHandler) GetOutputPorts() []workflow.OutputPort {
+	return []workflow.OutputPort{
+		{Name: "rejected", Description: "Supplier invoice rejected successfully", IsDefault: true},
+		{Name: "not_found", Description: "Supplier invoice not found"},
+		{Name: "not_rejected", Description: "The approval request has not been rejected or timed out"},
+		{Name: "already_approved", Description: "Supplier invoice was already approved — cannot reject"},
+		{Name: "already_rejected", Description: "Supplier invoice was already rejected (idempotent)"},
+		{Name: "failure", Description: "Unexpected error"},
+	}
+}
+
+// GetEntityModifications implements workflow.EntityModifier.
+func (h *RejectSupplierInvoiceHandler) GetEntityModifications(config json.RawMessage) []workflow.EntityModification {
+	return []workflow.EntityModification{
+		{EntityName: "procurement.supplier_invoices", EventType: "on_update", Fields: []string{"status", "rejected_by", "rejected_date", "rejection_reason"}},
+	}
+}
+
+// Execute rejects a supplier invoice.
+func (h *RejectSupplierInvoiceHandler) Execute(ctx context.Context, config json.RawMessage, execCtx workflow.ActionExecutionContext) (any, error) {
+	var cfg RejectSupplierInvoiceConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	if h.supplierInvoiceBus == nil || h.approvalRequestBus == nil {
+		return map[string]any{"output": "failure", "error": "supplier invoice and approval request buses not configured"}, nil
+	}
+
+	id, err := workflow.ResolveConfigID("supplier_invoice_id", cfg.SupplierInvoiceID, execCtx)
+	if err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	approval, err := queryApproval(ctx, h.approvalRequestBus, cfg.ApprovalRequestID, execCtx)
+	if err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+	if approval.Status != approvalrequestbus.StatusRejected && approval.Status != approvalrequestbus.StatusTimedOut {
+		return map[string]any{"output": "not_rejected", "supplier_invoice_id": id.String(), "approval_request_id": approval.ID.String(), "approval_status": approval.Status}, nil
+	}
+
+	rejectedBy, err := approvalActor(approval, execCtx)
+	if err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	invoice, err := h.supplierInvoiceBus.QueryByID(ctx, *id)
+	if err != nil {
+		if errors.Is(err, supplierinvoicebus.ErrNotFound) {
+			return map[string]any{"output": "not_found", "supplier_invoice_id": id.String()}, nil
+		}
+		return nil, fmt.Errorf("query supplier invoice: %w", err)
+	}
+
+	rejected, err := h.supplierInvoiceBus.Reject(ctx, invoice, rejectedBy, cfg.RejectionReason, time.Now())
+	if err != nil {
+		switch {
+		case errors.Is(err, supplierinvoicebus.ErrAlreadyApproved):
+			return map[string]any{"output": "already_approved", "supplier_invoice_id": id.String()}, nil
+		case errors.Is(err, supplierinvoicebus.ErrAlreadyRejected):
+			return map[string]any{"output": "already_rejected", "supplier_invoice_id": id.String()}, nil
+		}
+		return nil, fmt.Errorf("reject supplier invoice: %w", err)
+	}
+
+	return map[string]any{
+		"output":              "rejected",
+		"supplier_invoice_id": rejected.ID.String(),
+		"rejected_by":         rejectedBy.String(),
+		"approval_request_id": approval.ID.String(),
+		"rejection_reason":    cfg.RejectionReason,
+	}, nil
+}
@@ -0,0 +1,183 @@
+package procurement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// SuggestPurchasesConfig holds the config for the suggest purchases handler.
+type SuggestPurchasesConfig struct {
+	// WarehouseID, CategoryID and ProductID narrow the inventory items
+	// considered. Empty considers every item below its reorder point.
+	WarehouseID string `json:"warehouse_id,omitempty"`
+	CategoryID  string `json:"category_id,omitempty"`
+	ProductID   string `json:"product_id,omitempty"`
+
+	// The weights of price, lead time, minimum order overbuy and supplier
+	// rating when a supplier is picked. All zero uses the defaults.
+	PriceWeight    float64 `json:"price_weight,omitempty"`
+	LeadTimeWeight float64 `json:"lead_time_weight,omitempty"`
+	MinOrderWeight float64 `json:"min_order_weight,omitempty"`
+	RatingWeight   float64 `json:"rating_weight,omitempty"`
+
+	// CreatedBy attributes the suggestions when the trigger carries no user,
+	// as scheduled triggers do.
+	CreatedBy string `json:"created_by,omitempty"`
+}
+
+// SuggestPurchasesHandler handles suggest_purchases actions: it collects the
+// inventory items below their reorder point, picks a supplier for each
+// product by price, lead time, minimum order and rating, rounds the quantity
+// to the minimum order and case pack, and consolidates the lines into one
+// draft purchase suggestion per supplier and delivery warehouse for buyers to
+// review and release.
+type SuggestPurchasesHandler struct {
+	log                   *logger.Logger
+	purchaseSuggestionBus *purchasesuggestionbus.Business
+}
+
+// NewSuggestPurchasesHandler creates a new suggest purchases handler.
+func NewSuggestPurchasesHandler(log *logger.Logger, purchaseSuggestionBus *purchasesuggestionbus.Business) *SuggestPurchasesHandler {
+	return &SuggestPurchasesHandler{
+		log:                   log,
+		purchaseSuggestionBus: purchaseSuggestionBus,
+	}
+}
+
+// GetType returns the action type.
+func (h *SuggestPurchasesHandler) GetType() string { return "suggest_purchases" }
+
+// IsAsync returns false — suggestion runs complete inline.
+func (h *SuggestPurchasesHandler) IsAsync() bool { return false }
+
+// SupportsManualExecution returns true.
+func (h *SuggestPurchasesHandler) SupportsManualExecution() bool { return true }
+
+// GetDescription returns a human-readable description.
+func (h *SuggestPurchasesHandler) GetDescription() string {
+	return "Suggest draft purchase orders per supplier for inventory below its reorder point"
+}
+
+// Validate validates the suggest purchases configuration.
+func (h *SuggestPurchasesHandler) Validate(config json.RawMessage) error {
+	var cfg SuggestPurchasesConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	ids := []struct {
+		name  string
+		value string
+	}{
+		{"warehouse_id", cfg.WarehouseID},
+		{"category_id", cfg.CategoryID},
+		{"product_id", cfg.ProductID},
+	}
+	for _, id := range ids {
+		if err := workflow.ValidateConfigID(id.name, id.value); err != nil {
+			return err
+		}
+	}
+	if cfg.CreatedBy != "" {
+		if _, err := uuid.Parse(cfg.CreatedBy); err != nil {
+			return fmt.Errorf("invalid created_by: %w", err)
+		}
+	}
+
+	if cfg.PriceWeight < 0 || cfg.LeadTimeWeight < 0 || cfg.MinOrderWeight < 0 || cfg.RatingWeight < 0 {
+		return fmt.Errorf("weights must not be negative")
+	}
+
+	return nil
+}
+
+// GetOutputPorts implements workflow.OutputPortProvider.
+func (h *SuggestPurchasesHandler) GetOutputPorts() []workflow.OutputPort {
+	return []workflow.OutputPort{
+		{Name: "suggested", Description: "The purchases were suggested", IsDefault: true},
+		{Name: "nothing_to_suggest", Description: "No selected inventory item needs ordering"},
+		{Name: "failure", Description: "Unexpected error"},
+	}
+}
+
+// GetEntityModifications implements workflow.EntityModifier.
+func (h *SuggestPurchasesHandler) GetEntityModifications(config json.RawMessage) []workflow.EntityModification {
+	return []workflow.EntityModification{
+		{EntityName: "procurement.purchase_suggestions", EventType: "on_create"},
+	}
+}
+
+// Execute suggests purchases for the selected inventory items.
+func (h *SuggestPurchasesHandler) Execute(ctx context.Context, config json.RawMessage, execCtx workflow.ActionExecutionContext) (any, error) {
+	var cfg SuggestPurchasesConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	if h.purchaseSuggestionBus == nil {
+		return map[string]any{"output": "failure", "error": "purchase suggestion bus not configured"}, nil
+	}
+
+	createdBy, err := workflow.ActingUser(execCtx, "created_by", cfg.CreatedBy)
+	if err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	req := purchasesuggestionbus.RunRequest{
+		Weights: purchasesuggestionbus.Weights{
+			Price:    cfg.PriceWeight,
+			LeadTime: cfg.LeadTimeWeight,
+			MinOrder: cfg.MinOrderWeight,
+			Rating:   cfg.RatingWeight,
+		},
+		CreatedBy: createdBy,
+	}
+	if req.WarehouseID, err = workflow.ResolveConfigID("warehouse_id", cfg.WarehouseID, execCtx); err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+	if req.CategoryID, err = workflow.ResolveConfigID("category_id", cfg.CategoryID, execCtx); err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+	if req.ProductID, err = workflow.ResolveConfigID("product_id", cfg.ProductID, execCtx); err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	result, err := h.purchaseSuggestionBus.Run(ctx, req, time.Now())
+	if err != nil {
+		if errors.Is(err, purchasesuggestionbus.ErrNothingToSuggest) {
+			return map[string]any{"output": "nothing_to_suggest"}, nil
+		}
+		return nil, fmt.Errorf("run: %w", err)
+	}
+
+	suggestionIDs := make([]string, len(result.Suggestions))
+	var lines int
+	var total float64
+	for i, s := range result.Suggestions {
+		suggestionIDs[i] = s.ID.String()
+		lines += len(s.Lines)
+		total += s.Subtotal
+	}
+
+	unsourced := make([]string, len(result.Unsourced))
+	for i, n := range result.Unsourced {
+		unsourced[i] = n.ProductID.String()
+	}
+
+	return map[string]any{
+		"output":                "suggested",
+		"suggestion_ids":        suggestionIDs,
+		"suggestion_count":      len(result.Suggestions),
+		"line_count":            lines,
+		"total_amount":          total,
+		"unsourced_product_ids": unsourced,
+	}, nil
+}
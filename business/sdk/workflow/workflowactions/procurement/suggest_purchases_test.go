@@ -0,0 +1,84 @@
+package procurement_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/procurement"
+)
+
+func TestSuggestPurchases_Validate(t *testing.T) {
+	handler := procurement.NewSuggestPurchasesHandler(nil, nil)
+
+	tests := []struct {
+		name      string
+		raw       json.RawMessage
+		wantErr   bool
+		errSubstr string
+	}{
+		{name: "every item", raw: json.RawMessage(`{}`), wantErr: false},
+		{name: "good uuids", raw: json.RawMessage(`{"warehouse_id":"` + uuid.NewString() + `","created_by":"` + uuid.NewString() + `"}`), wantErr: false},
+		{name: "templated id ok", raw: json.RawMessage(`{"product_id":"{{entity_id}}"}`), wantErr: false},
+		{name: "weights ok", raw: json.RawMessage(`{"price_weight":1,"rating_weight":0.5}`), wantErr: false},
+		{name: "bad category", raw: json.RawMessage(`{"category_id":"nope"}`), wantErr: true, errSubstr: "invalid category_id"},
+		{name: "bad created_by", raw: json.RawMessage(`{"created_by":"nope"}`), wantErr: true, errSubstr: "invalid created_by"},
+		{name: "negative weight", raw: json.RawMessage(`{"lead_time_weight":-1}`), wantErr: true, errSubstr: "must not be negative"},
+		{name: "invalid json", raw: json.RawMessage(`{bad`), wantErr: true, errSubstr: "invalid config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handler.Validate(tt.raw)
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.errSubstr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tt.wantErr && err != nil && !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error %q does not contain %q", err.Error(), tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestSuggestPurchases_Metadata(t *testing.T) {
+	handler := procurement.NewSuggestPurchasesHandler(nil, nil)
+
+	if got := handler.GetType(); got != "suggest_purchases" {
+		t.Fatalf("expected suggest_purchases, got %s", got)
+	}
+	if !handler.SupportsManualExecution() {
+		t.Fatal("expected SupportsManualExecution true")
+	}
+
+	var defaults []workflow.OutputPort
+	for _, p := range handler.GetOutputPorts() {
+		if p.IsDefault {
+			defaults = append(defaults, p)
+		}
+	}
+	if len(defaults) != 1 || defaults[0].Name != "suggested" {
+		t.Fatalf("expected single default port 'suggested', got %+v", defaults)
+	}
+
+	if mods := handler.GetEntityModifications(nil); len(mods) != 1 {
+		t.Fatalf("expected 1 entity modification, got %d", len(mods))
+	}
+}
+
+func TestSuggestPurchases_NilBusFails(t *testing.T) {
+	handler := procurement.NewSuggestPurchasesHandler(nil, nil)
+
+	result, err := handler.Execute(context.Background(), json.RawMessage(`{}`), workflow.ActionExecutionContext{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if out := result.(map[string]any)["output"]; out != "failure" {
+		t.Fatalf("expected failure output, got %v", out)
+	}
+}
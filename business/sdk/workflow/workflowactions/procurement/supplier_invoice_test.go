@@ -0,0 +1,127 @@
+package procurement_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/procurement"
+)
+
+type supplierInvoiceHandler interface {
+	workflow.ActionHandler
+	workflow.OutputPortProvider
+	workflow.EntityModifier
+}
+
+func TestSupplierInvoiceHandlers_Validate(t *testing.T) {
+	id := uuid.NewString()
+
+	tests := []struct {
+		name      string
+		handler   supplierInvoiceHandler
+		raw       json.RawMessage
+		wantErr   bool
+		errSubstr string
+	}{
+		{name: "match ok", handler: procurement.NewMatchSupplierInvoiceHandler(nil, nil), raw: json.RawMessage(`{"supplier_invoice_id":"` + id + `"}`), wantErr: false},
+		{name: "match templated id", handler: procurement.NewMatchSupplierInvoiceHandler(nil, nil), raw: json.RawMessage(`{"supplier_invoice_id":"{{entity_id}}"}`), wantErr: false},
+		{name: "match missing id", handler: procurement.NewMatchSupplierInvoiceHandler(nil, nil), raw: json.RawMessage(`{}`), wantErr: true, errSubstr: "supplier_invoice_id is required"},
+		{name: "match bad id", handler: procurement.NewMatchSupplierInvoiceHandler(nil, nil), raw: json.RawMessage(`{"supplier_invoice_id":"nope"}`), wantErr: true, errSubstr: "invalid supplier_invoice_id"},
+		{name: "match bad matched_by", handler: procurement.NewMatchSupplierInvoiceHandler(nil, nil), raw: json.RawMessage(`{"supplier_invoice_id":"` + id + `","matched_by":"nope"}`), wantErr: true, errSubstr: "invalid matched_by"},
+		{name: "approve ok", handler: procurement.NewApproveSupplierInvoiceHandler(nil, nil, nil), raw: json.RawMessage(`{"supplier_invoice_id":"` + id + `","approval_request_id":"{{Invoice Variance Approval.approval_id}}"}`), wantErr: false},
+		{name: "approve missing id", handler: procurement.NewApproveSupplierInvoiceHandler(nil, nil, nil), raw: json.RawMessage(`{}`), wantErr: true, errSubstr: "supplier_invoice_id is required"},
+		{name: "approve missing approval request", handler: procurement.NewApproveSupplierInvoiceHandler(nil, nil, nil), raw: json.RawMessage(`{"supplier_invoice_id":"` + id + `"}`), wantErr: true, errSubstr: "approval_request_id is required"},
+		{name: "approve bad approval request", handler: procurement.NewApproveSupplierInvoiceHandler(nil, nil, nil), raw: json.RawMessage(`{"supplier_invoice_id":"` + id + `","approval_request_id":"nope"}`), wantErr: true, errSubstr: "invalid approval_request_id"},
+		{name: "reject ok", handler: procurement.NewRejectSupplierInvoiceHandler(nil, nil, nil), raw: json.RawMessage(`{"supplier_invoice_id":"` + id + `","approval_request_id":"` + id + `","rejection_reason":"overbilled"}`), wantErr: false},
+		{name: "reject missing approval request", handler: procurement.NewRejectSupplierInvoiceHandler(nil, nil, nil), raw: json.RawMessage(`{"supplier_invoice_id":"` + id + `","rejection_reason":"overbilled"}`), wantErr: true, errSubstr: "approval_request_id is required"},
+		{name: "reject missing reason", handler: procurement.NewRejectSupplierInvoiceHandler(nil, nil, nil), raw: json.RawMessage(`{"supplier_invoice_id":"` + id + `","approval_request_id":"` + id + `"}`), wantErr: true, errSubstr: "rejection_reason is required"},
+		{name: "invalid json", handler: procurement.NewRejectSupplierInvoiceHandler(nil, nil, nil), raw: json.RawMessage(`{bad`), wantErr: true, errSubstr: "invalid config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.handler.Validate(tt.raw)
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.errSubstr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tt.wantErr && err != nil && !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error %q does not contain %q", err.Error(), tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestSupplierInvoiceHandlers_Metadata(t *testing.T) {
+	tests := []struct {
+		handler     supplierInvoiceHandler
+		wantType    string
+		wantDefault string
+	}{
+		{procurement.NewMatchSupplierInvoiceHandler(nil, nil), "match_supplier_invoice", "matched"},
+		{procurement.NewApproveSupplierInvoiceHandler(nil, nil, nil), "approve_supplier_invoice", "approved"},
+		{procurement.NewRejectSupplierInvoiceHandler(nil, nil, nil), "reject_supplier_invoice", "rejected"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.handler.GetType(); got != tt.wantType {
+			t.Fatalf("expected %s, got %s", tt.wantType, got)
+		}
+		if !tt.handler.SupportsManualExecution() {
+			t.Fatalf("%s: expected SupportsManualExecution true", tt.wantType)
+		}
+
+		var defaults []workflow.OutputPort
+		for _, p := range tt.handler.GetOutputPorts() {
+			if p.IsDefault {
+				defaults = append(defaults, p)
+			}
+		}
+		if len(defaults) != 1 || defaults[0].Name != tt.wantDefault {
+			t.Fatalf("%s: expected single default port %q, got %+v", tt.wantType, tt.wantDefault, defaults)
+		}
+
+		mods := tt.handler.GetEntityModifications(nil)
+		if len(mods) != 1 || mods[0].EntityName != "procurement.supplier_invoices" {
+			t.Fatalf("%s: expected a supplier_invoices modification, got %+v", tt.wantType, mods)
+		}
+	}
+}
+
+func TestMatchSupplierInvoice_VariancePorts(t *testing.T) {
+	ports := make(map[string]bool)
+	for _, p := range procurement.NewMatchSupplierInvoiceHandler(nil, nil).GetOutputPorts() {
+		ports[p.Name] = true
+	}
+
+	// The match status is the output, so every status needs a port.
+	for _, status := range []string{"matched", "price_variance", "quantity_variance"} {
+		if !ports[status] {
+			t.Fatalf("expected a %s port", status)
+		}
+	}
+}
+
+func TestSupplierInvoiceHandlers_NilBusFails(t *testing.T) {
+	raw := json.RawMessage(`{"supplier_invoice_id":"` + uuid.NewString() + `","rejection_reason":"x"}`)
+
+	for _, h := range []supplierInvoiceHandler{
+		procurement.NewMatchSupplierInvoiceHandler(nil, nil),
+		procurement.NewApproveSupplierInvoiceHandler(nil, nil, nil),
+		procurement.NewRejectSupplierInvoiceHandler(nil, nil, nil),
+	} {
+		result, err := h.Execute(context.Background(), raw, workflow.ActionExecutionContext{})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", h.GetType(), err)
+		}
+		if out := result.(map[string]any)["output"]; out != "failure" {
+			t.Fatalf("%s: expected failure output, got %v", h.GetType(), out)
+		}
+	}
+}
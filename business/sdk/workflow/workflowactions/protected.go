@@ -7,6 +7,7 @@ import (
 	purchaseorderlineitemdb "github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitembus/stores/purchaseorderlineitemdb"
 	orderlineitemsdb "github.com/timmaaaz/ichor/business/domain/sales/orderlineitemsbus/stores/orderlineitemsdb"
 	ordersdb "github.com/timmaaaz/ichor/business/domain/sales/ordersbus/stores/ordersdb"
+	shipmentdb "github.com/timmaaaz/ichor/business/domain/sales/shipmentbus/stores/shipmentdb"
 	"github.com/timmaaaz/ichor/business/sdk/workflow"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/protected"
 )
@@ -62,6 +63,7 @@ func PopulateProtected(reg *protected.Registry, registry *workflow.ActionRegistr
 	transferorderdb.RegisterProtected(reg)
 	ordersdb.RegisterProtected(reg)
 	orderlineitemsdb.RegisterProtected(reg)
+	shipmentdb.RegisterProtected(reg)
 	purchaseorderlineitemdb.RegisterProtected(reg)
 	userdb.RegisterProtected(reg)
 
@@ -3,20 +3,28 @@ package workflowactions
 
 import (
 	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryadjustmentbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryreservationbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/picktaskbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/putawaytaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/transferorderbus"
+	"github.com/timmaaaz/ichor/business/domain/labels/labelbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitembus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/supplierproductbus"
 	"github.com/timmaaaz/ichor/business/domain/products/productbus"
 	"github.com/timmaaaz/ichor/business/domain/sales/orderfulfillmentstatusbus"
 	"github.com/timmaaaz/ichor/business/domain/sales/orderlineitemsbus"
 	"github.com/timmaaaz/ichor/business/domain/sales/ordersbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
 	"github.com/timmaaaz/ichor/business/domain/workflow/alertbus"
 	"github.com/timmaaaz/ichor/business/domain/workflow/approvalrequestbus"
 	"github.com/timmaaaz/ichor/business/sdk/delegate"
@@ -30,6 +38,7 @@ import (
 	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/integration"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/inventory"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/procurement"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/shipping"
 	"github.com/timmaaaz/ichor/foundation/logger"
 	"github.com/timmaaaz/ichor/foundation/rabbitmq"
 )
@@ -75,9 +84,13 @@ type BusDependencies struct {
 	InventoryLocation    *inventorylocationbus.Business
 	InventoryTransaction *inventorytransactionbus.Business
 	InventoryAdjustment  *inventoryadjustmentbus.Business
+	InventoryReservation *inventoryreservationbus.Business
 	TransferOrder        *transferorderbus.Business
 	PutAwayTask          *putawaytaskbus.Business
 	PickTask             *picktaskbus.Business
+	CountPlan            *countplanbus.Business
+	ReplenishmentTask    *replenishmenttaskbus.Business
+	DemandForecast       *demandforecastbus.Business
 	Product              *productbus.Business
 	Workflow             *workflow.Business
 
@@ -85,10 +98,16 @@ type BusDependencies struct {
 	Orders                 *ordersbus.Business
 	OrderLineItems         *orderlineitemsbus.Business
 	OrderFulfillmentStatus *orderfulfillmentstatusbus.Business
+	Shipment               *shipmentbus.Business
+
+	// Labels domain
+	Label *labelbus.Business
 
 	// Procurement domain
 	PurchaseOrder         *purchaseorderbus.Business
 	PurchaseOrderLineItem *purchaseorderlineitembus.Business
+	PurchaseSuggestion    *purchasesuggestionbus.Business
+	SupplierInvoice       *supplierinvoicebus.Business
 	SupplierProduct       *supplierproductbus.Business
 
 	// Workflow domain
@@ -108,6 +127,12 @@ func RegisterAll(registry *workflow.ActionRegistry, config ActionConfig) {
 	// Control flow - delay
 	registry.Register(control.NewDelayHandler(config.Log))
 
+	// Control flow - for_each (iteration is run by the Temporal interpreter)
+	registry.Register(control.NewForEachHandler(config.Log))
+
+	// Control flow - call_workflow (the called rule runs as a Temporal child workflow)
+	registry.Register(control.NewCallWorkflowHandler(config.Log))
+
 	// Data actions - only need log and db. The three generic raw-SQL handlers also take
 	// the protected registry so they reject writes to guarded fields, and the delegate +
 	// entity-registry so a successful write cascades to downstream automation (P4 M1).
@@ -142,6 +167,7 @@ func RegisterAll(registry *workflow.ActionRegistry, config ActionConfig) {
 		config.Buses.InventoryTransaction,
 		config.Buses.Product,
 		config.Buses.Workflow,
+		config.Buses.InventoryReservation,
 	))
 
 	// Granular inventory actions
@@ -150,6 +176,9 @@ func RegisterAll(registry *workflow.ActionRegistry, config ActionConfig) {
 	// Procurement actions
 	RegisterProcurementActions(registry, config)
 
+	// Shipping actions
+	RegisterShippingActions(registry, config)
+
 	// Integration actions
 	registry.Register(integration.NewCallWebhookHandler(config.Log))
 
@@ -168,6 +197,7 @@ func RegisterInventoryActions(registry *workflow.ActionRegistry, config ActionCo
 		config.Buses.InventoryTransaction,
 		config.Buses.Product,
 		config.Buses.Workflow,
+		config.Buses.InventoryReservation,
 	))
 }
 
@@ -176,9 +206,9 @@ func RegisterInventoryActions(registry *workflow.ActionRegistry, config ActionCo
 func RegisterGranularInventoryActions(registry *workflow.ActionRegistry, config ActionConfig) {
 	registry.Register(inventory.NewCheckInventoryHandler(config.Log, config.Buses.InventoryItem))
 	registry.Register(inventory.NewCheckReorderPointHandler(config.Log, config.Buses.InventoryItem))
-	registry.Register(inventory.NewReleaseReservationHandler(config.Log, config.DB, config.Buses.InventoryItem))
-	registry.Register(inventory.NewCommitAllocationHandler(config.Log, config.DB, config.Buses.InventoryItem))
-	registry.Register(inventory.NewReserveInventoryHandler(config.Log, config.DB, config.Buses.InventoryItem, config.Buses.Workflow))
+	registry.Register(inventory.NewReleaseReservationHandler(config.Log, config.DB, config.Buses.InventoryItem, config.Buses.InventoryReservation))
+	registry.Register(inventory.NewCommitAllocationHandler(config.Log, config.DB, config.Buses.InventoryItem, config.Buses.InventoryReservation))
+	registry.Register(inventory.NewReserveInventoryHandler(config.Log, config.DB, config.Buses.InventoryItem, config.Buses.Workflow, config.Buses.InventoryReservation))
 	registry.Register(inventory.NewReceiveInventoryHandler(config.Log, config.DB, config.Buses.InventoryItem, config.Buses.InventoryTransaction, config.Buses.SupplierProduct))
 
 	// release_to_picking flips a customer order PENDING/PROCESSING->PICKING and fans its
@@ -204,10 +234,17 @@ func RegisterGranularInventoryActions(registry *workflow.ActionRegistry, config
 	if config.Buses.TransferOrder != nil {
 		registry.Register(inventory.NewApproveTransferOrderHandler(config.Log, config.Buses.TransferOrder))
 		registry.Register(inventory.NewRejectTransferOrderHandler(config.Log, config.Buses.TransferOrder))
-		registry.Register(inventory.NewClaimTransferOrderHandler(config.Log, config.Buses.TransferOrder))
-		// execute_transfer_order performs the atomic stock move (TRANSFER_OUT/IN + source
-		// decrement + destination increment), so it needs the DB + inventory buses — the same
-		// dependencies the REST transferorderapp.Execute path uses.
+		// claim_transfer_order and execute_transfer_order perform the atomic stock moves
+		// (TRANSFER_OUT/IN + decrement + increment) into and out of the in-transit location,
+		// so they need the DB + inventory buses — the same dependencies the REST
+		// transferorderapp.Claim/Execute paths use.
+		registry.Register(inventory.NewClaimTransferOrderHandler(
+			config.Log,
+			config.DB,
+			config.Buses.TransferOrder,
+			config.Buses.InventoryTransaction,
+			config.Buses.InventoryItem,
+		))
 		registry.Register(inventory.NewExecuteTransferOrderHandler(
 			config.Log,
 			config.DB,
@@ -224,8 +261,27 @@ func RegisterGranularInventoryActions(registry *workflow.ActionRegistry, config
 			config.Buses.PutAwayTask,
 			config.Buses.SupplierProduct,
 			config.Buses.PurchaseOrder,
+			config.Buses.InventoryLocation,
 		))
 	}
+
+	// generate_cycle_counts turns count plans into cycle count sessions; a
+	// scheduled rule calling it is what makes the count program systematic.
+	if config.Buses.CountPlan != nil {
+		registry.Register(inventory.NewGenerateCycleCountsHandler(config.Log, config.Buses.CountPlan))
+	}
+
+	// generate_replenishment tops up pick faces from reserve; run it on a
+	// schedule alongside the short-pick trigger in the picking app.
+	if config.Buses.ReplenishmentTask != nil {
+		registry.Register(inventory.NewGenerateReplenishmentHandler(config.Log, config.Buses.ReplenishmentTask))
+	}
+
+	// forecast_demand refreshes the planning values check_reorder_point reads;
+	// run it on a schedule ahead of the reorder checks.
+	if config.Buses.DemandForecast != nil {
+		registry.Register(inventory.NewForecastDemandHandler(config.Log, config.Buses.DemandForecast))
+	}
 }
 
 // RegisterProcurementActions registers procurement-domain action handlers.
@@ -242,6 +298,29 @@ func RegisterProcurementActions(registry *workflow.ActionRegistry, config Action
 		registry.Register(procurement.NewApprovePurchaseOrderHandler(config.Log, config.Buses.PurchaseOrder))
 		registry.Register(procurement.NewRejectPurchaseOrderHandler(config.Log, config.Buses.PurchaseOrder))
 	}
+
+	// suggest_purchases consolidates every reorder need into draft purchase
+	// orders per supplier; run it on a schedule after forecast_demand.
+	if config.Buses.PurchaseSuggestion != nil {
+		registry.Register(procurement.NewSuggestPurchasesHandler(config.Log, config.Buses.PurchaseSuggestion))
+	}
+
+	// match_supplier_invoice leaves through its variance ports so a rule can
+	// seek approval before approve_supplier_invoice or reject_supplier_invoice.
+	if config.Buses.SupplierInvoice != nil {
+		registry.Register(procurement.NewMatchSupplierInvoiceHandler(config.Log, config.Buses.SupplierInvoice))
+		registry.Register(procurement.NewApproveSupplierInvoiceHandler(config.Log, config.Buses.SupplierInvoice, config.Buses.ApprovalRequest))
+		registry.Register(procurement.NewRejectSupplierInvoiceHandler(config.Log, config.Buses.SupplierInvoice, config.Buses.ApprovalRequest))
+	}
+}
+
+// RegisterShippingActions registers outbound-shipment action handlers. The
+// Shipment bus must carry a Carrier (shipmentbus.WithCarrier) for labels to be
+// bought; Label is optional and only needed when a rule asks to print.
+func RegisterShippingActions(registry *workflow.ActionRegistry, config ActionConfig) {
+	if config.Buses.Shipment != nil {
+		registry.Register(shipping.NewCreateShippingLabelHandler(config.Log, config.Buses.Shipment, config.Buses.Label))
+	}
 }
 
 // RegisterCoreActions registers action handlers that don't require RabbitMQ or heavy dependencies.
@@ -259,6 +338,8 @@ func RegisterCoreActions(registry *workflow.ActionRegistry, log *logger.Logger,
 	// Control flow actions - only need log
 	registry.Register(control.NewEvaluateConditionHandler(log))
 	registry.Register(control.NewDelayHandler(log))
+	registry.Register(control.NewForEachHandler(log))
+	registry.Register(control.NewCallWorkflowHandler(log))
 
 	// Data actions - only need log and db, implements EntityModifier for cascade
 	registry.Register(data.NewUpdateFieldHandler(log, db))
@@ -277,7 +358,10 @@ func RegisterCoreActions(registry *workflow.ActionRegistry, log *logger.Logger,
 	registry.Register(communication.NewCreateAlertHandler(log, nil, nil, nil, nil))
 
 	// Inventory actions - nil buses for core path (cascade detection via EntityModifier)
-	registry.Register(inventory.NewCreatePutAwayTaskHandler(log, db, nil, nil, nil))
+	registry.Register(inventory.NewCreatePutAwayTaskHandler(log, db, nil, nil, nil, nil))
+
+	// Shipping actions - nil buses for core path (cascade detection via EntityModifier)
+	registry.Register(shipping.NewCreateShippingLabelHandler(log, nil, nil))
 
 	// Integration actions - no bus/DB/queue dependencies
 	registry.Register(integration.NewCallWebhookHandler(log))
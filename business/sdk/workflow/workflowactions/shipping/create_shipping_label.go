@@ -0,0 +1,191 @@
+// Package shipping contains workflow actions for outbound shipments.
+package shipping
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/domain/labels/labelbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/foundation/logger"
+)
+
+// CreateShippingLabelConfig holds the config for the create_shipping_label handler.
+type CreateShippingLabelConfig struct {
+	// ShipmentID is the shipment to label. Use "{{entity_id}}" when the action is
+	// wired to a button or a sales.shipments rule; empty also defaults to the entity.
+	ShipmentID string `json:"shipment_id"`
+
+	// ServiceLevel is the carrier service to buy (e.g. "ground").
+	ServiceLevel string `json:"service_level"`
+
+	// Print sends the label ZPL to the configured label printer.
+	Print bool `json:"print"`
+}
+
+// CreateShippingLabelHandler buys a carrier label for a packed shipment through
+// the configured shipmentbus.Carrier, records the carrier, service level and
+// tracking number on the shipment and optionally prints the 4x6 label.
+//
+// Execute returns map[string]any with key "output" (string) and one of:
+//   - "created"        — label bought and recorded on the shipment
+//   - "not_found"      — shipment not found
+//   - "invalid_status" — shipment is not packing
+//   - "no_cartons"     — shipment has no cartons to label
+//   - "label_exists"   — shipment already has a tracking number
+//   - "carrier_error"  — the carrier rejected or failed the request
+//   - "failure"        — unexpected error
+type CreateShippingLabelHandler struct {
+	log         *logger.Logger
+	shipmentBus *shipmentbus.Business
+	labelBus    *labelbus.Business
+}
+
+// NewCreateShippingLabelHandler creates a new create_shipping_label handler.
+func NewCreateShippingLabelHandler(log *logger.Logger, shipmentBus *shipmentbus.Business, labelBus *labelbus.Business) *CreateShippingLabelHandler {
+	return &CreateShippingLabelHandler{
+		log:         log,
+		shipmentBus: shipmentBus,
+		labelBus:    labelBus,
+	}
+}
+
+// GetType returns the action type.
+func (h *CreateShippingLabelHandler) GetType() string { return "create_shipping_label" }
+
+// IsAsync returns false — the carrier call completes inline.
+func (h *CreateShippingLabelHandler) IsAsync() bool { return false }
+
+// SupportsManualExecution returns true.
+func (h *CreateShippingLabelHandler) SupportsManualExecution() bool { return true }
+
+// GetDescription returns a human-readable description.
+func (h *CreateShippingLabelHandler) GetDescription() string {
+	return "Buy a carrier shipping label for a packed shipment, record its tracking number and optionally print it"
+}
+
+// Validate validates the create_shipping_label configuration.
+func (h *CreateShippingLabelHandler) Validate(config json.RawMessage) error {
+	var cfg CreateShippingLabelConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	if cfg.ServiceLevel == "" {
+		return fmt.Errorf("service_level is required")
+	}
+	if cfg.ShipmentID != "" && !strings.Contains(cfg.ShipmentID, "{{") {
+		if _, err := uuid.Parse(cfg.ShipmentID); err != nil {
+			return fmt.Errorf("invalid shipment_id: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetOutputPorts implements workflow.OutputPortProvider.
+func (h *CreateShippingLabelHandler) GetOutputPorts() []workflow.OutputPort {
+	return []workflow.OutputPort{
+		{Name: "created", Description: "Label bought and recorded on the shipment", IsDefault: true},
+		{Name: "not_found", Description: "Shipment not found"},
+		{Name: "invalid_status", Description: "Shipment is not packing"},
+		{Name: "no_cartons", Description: "Shipment has no cartons to label"},
+		{Name: "label_exists", Description: "Shipment already has a tracking number"},
+		{Name: "carrier_error", Description: "Carrier rejected or failed the request"},
+		{Name: "failure", Description: "Unexpected error"},
+	}
+}
+
+// GetEntityModifications implements workflow.EntityModifier.
+func (h *CreateShippingLabelHandler) GetEntityModifications(config json.RawMessage) []workflow.EntityModification {
+	return []workflow.EntityModification{
+		{
+			EntityName: "sales.shipments",
+			EventType:  "on_update",
+			Fields:     []string{"carrier", "service_level", "tracking_number"},
+			// The carrier assigns the tracking number and may normalise the carrier
+			// name and service level, so none are statically knowable.
+			Changes: []workflow.ProducedChange{
+				{FieldName: "tracking_number", Operator: workflow.OperatorChangedTo, Indeterminate: true},
+			},
+		},
+	}
+}
+
+// Execute buys and records a shipping label.
+func (h *CreateShippingLabelHandler) Execute(ctx context.Context, config json.RawMessage, execCtx workflow.ActionExecutionContext) (any, error) {
+	if h.shipmentBus == nil {
+		return map[string]any{"output": "failure", "error": "create_shipping_label dependencies not configured"}, nil
+	}
+
+	var cfg CreateShippingLabelConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return map[string]any{"output": "failure", "error": err.Error()}, nil
+	}
+
+	// A static config UUID overrides the execution-context entity; a templated
+	// value (unresolved "{{...}}") falls back to it.
+	shipmentID := execCtx.EntityID
+	if cfg.ShipmentID != "" && !strings.Contains(cfg.ShipmentID, "{{") {
+		parsed, err := uuid.Parse(cfg.ShipmentID)
+		if err != nil {
+			return map[string]any{"output": "failure", "error": "invalid shipment_id"}, nil
+		}
+		shipmentID = parsed
+	}
+	if shipmentID == uuid.Nil {
+		return map[string]any{"output": "failure", "error": "no shipment id"}, nil
+	}
+
+	sh, err := h.shipmentBus.QueryByID(ctx, shipmentID)
+	if err != nil {
+		if errors.Is(err, shipmentbus.ErrNotFound) {
+			return map[string]any{"output": "not_found", "shipment_id": shipmentID.String()}, nil
+		}
+		return nil, fmt.Errorf("query shipment: %w", err)
+	}
+
+	sh, lbl, err := h.shipmentBus.CreateLabel(ctx, sh, cfg.ServiceLevel, execCtx.UserID)
+	if err != nil {
+		result := map[string]any{"shipment_id": shipmentID.String(), "error": err.Error()}
+		switch {
+		case errors.Is(err, shipmentbus.ErrInvalidShipmentStatus):
+			result["output"] = "invalid_status"
+		case errors.Is(err, shipmentbus.ErrNoCartons):
+			result["output"] = "no_cartons"
+		case errors.Is(err, shipmentbus.ErrLabelExists):
+			result["output"] = "label_exists"
+		case errors.Is(err, shipmentbus.ErrNoCarrier):
+			result["output"] = "failure"
+		default:
+			result["output"] = "carrier_error"
+		}
+		return result, nil
+	}
+
+	printed := false
+	if cfg.Print && h.labelBus != nil {
+		if err := h.labelBus.PrintZPL(ctx, lbl.ZPL); err != nil {
+			h.log.Error(ctx, "create_shipping_label: print failed", "shipment_id", sh.ID, "tracking_number", lbl.TrackingNumber, "err", err)
+		} else {
+			printed = true
+		}
+	}
+
+	h.log.Info(ctx, "create_shipping_label: label created",
+		"shipment_id", sh.ID, "carrier", lbl.Carrier, "service_level", lbl.ServiceLevel, "tracking_number", lbl.TrackingNumber)
+
+	return map[string]any{
+		"output":          "created",
+		"shipment_id":     sh.ID.String(),
+		"carrier":         lbl.Carrier,
+		"service_level":   lbl.ServiceLevel,
+		"tracking_number": lbl.TrackingNumber,
+		"cost":            lbl.Cost,
+		"currency":        lbl.Currency,
+		"printed":         printed,
+	}, nil
+}
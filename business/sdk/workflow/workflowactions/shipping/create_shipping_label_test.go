@@ -0,0 +1,101 @@
+package shipping_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/timmaaaz/ichor/business/sdk/workflow"
+	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/shipping"
+)
+
+func TestCreateShippingLabel_Validate(t *testing.T) {
+	handler := shipping.NewCreateShippingLabelHandler(nil, nil, nil)
+
+	tests := []struct {
+		name      string
+		cfg       shipping.CreateShippingLabelConfig
+		raw       json.RawMessage
+		wantErr   bool
+		errSubstr string
+	}{
+		{name: "missing service level", cfg: shipping.CreateShippingLabelConfig{ShipmentID: uuid.NewString()}, wantErr: true, errSubstr: "service_level is required"},
+		{name: "bad uuid", cfg: shipping.CreateShippingLabelConfig{ShipmentID: "nope", ServiceLevel: "ground"}, wantErr: true, errSubstr: "invalid shipment_id"},
+		{name: "good uuid", cfg: shipping.CreateShippingLabelConfig{ShipmentID: uuid.NewString(), ServiceLevel: "ground"}},
+		{name: "templated id ok", cfg: shipping.CreateShippingLabelConfig{ShipmentID: "{{entity_id}}", ServiceLevel: "ground"}},
+		{name: "empty id defaults to entity", cfg: shipping.CreateShippingLabelConfig{ServiceLevel: "ground", Print: true}},
+		{name: "invalid json", raw: json.RawMessage(`{bad`), wantErr: true, errSubstr: "invalid config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.raw
+			if config == nil {
+				data, _ := json.Marshal(tt.cfg)
+				config = data
+			}
+			err := handler.Validate(config)
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.errSubstr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tt.wantErr && err != nil && tt.errSubstr != "" && !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error %q does not contain %q", err.Error(), tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestCreateShippingLabel_Metadata(t *testing.T) {
+	handler := shipping.NewCreateShippingLabelHandler(nil, nil, nil)
+
+	if got := handler.GetType(); got != "create_shipping_label" {
+		t.Fatalf("expected create_shipping_label, got %s", got)
+	}
+	if !handler.SupportsManualExecution() {
+		t.Fatal("expected SupportsManualExecution true")
+	}
+	if handler.IsAsync() {
+		t.Fatal("expected IsAsync false")
+	}
+
+	var defaults []workflow.OutputPort
+	for _, p := range handler.GetOutputPorts() {
+		if p.IsDefault {
+			defaults = append(defaults, p)
+		}
+	}
+	if len(defaults) != 1 || defaults[0].Name != "created" {
+		t.Fatalf("expected single default port 'created', got %+v", defaults)
+	}
+
+	mods := handler.GetEntityModifications(nil)
+	if len(mods) != 1 {
+		t.Fatalf("expected 1 entity modification, got %d", len(mods))
+	}
+	if mods[0].EntityName != "sales.shipments" || mods[0].EventType != "on_update" {
+		t.Fatalf("unexpected modification target: %+v", mods[0])
+	}
+	if len(mods[0].Changes) != 1 || mods[0].Changes[0].FieldName != "tracking_number" || !mods[0].Changes[0].Indeterminate {
+		t.Fatalf("expected indeterminate tracking_number change, got %+v", mods[0].Changes)
+	}
+}
+
+func TestCreateShippingLabel_Execute_NotConfigured(t *testing.T) {
+	handler := shipping.NewCreateShippingLabelHandler(nil, nil, nil)
+
+	cfg, _ := json.Marshal(shipping.CreateShippingLabelConfig{ServiceLevel: "ground"})
+	got, err := handler.Execute(context.Background(), cfg, workflow.ActionExecutionContext{EntityID: uuid.New()})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	result := got.(map[string]any)
+	if result["output"] != "failure" {
+		t.Fatalf("expected failure output without buses, got %+v", result)
+	}
+}
@@ -104,6 +104,20 @@ type Storer interface {
 	QueryExecutionsPaginated(ctx context.Context, filter ExecutionFilter, orderBy order.By, page page.Page) ([]AutomationExecution, error)
 	CountExecutions(ctx context.Context, filter ExecutionFilter) (int, error)
 	QueryExecutionByID(ctx context.Context, id uuid.UUID) (AutomationExecution, error)
+
+	// Rule schedule methods (for "scheduled" trigger-type rules)
+	CreateRuleSchedule(ctx context.Context, rs RuleSchedule) error
+	UpdateRuleSchedule(ctx context.Context, rs RuleSchedule) error
+	DeleteRuleSchedule(ctx context.Context, rs RuleSchedule) error
+	QueryRuleScheduleByRuleID(ctx context.Context, ruleID uuid.UUID) (RuleSchedule, error)
+
+	// Rule revision methods (immutable snapshots of a rule's definition)
+	NextRuleRevisionNumber(ctx context.Context, ruleID uuid.UUID) (int, error)
+	CreateRuleRevision(ctx context.Context, rr RuleRevision) error
+	UpdateRuleRevisionStatus(ctx context.Context, rr RuleRevision) error
+	SupersedeRuleRevisions(ctx context.Context, ruleID uuid.UUID) error
+	QueryRuleRevisionsByRuleID(ctx context.Context, ruleID uuid.UUID) ([]RuleRevision, error)
+	QueryRuleRevisionByID(ctx context.Context, id uuid.UUID) (RuleRevision, error)
 }
 
 // Set of error variables for CRUD operations.
@@ -653,8 +667,13 @@ func (b *Business) CreateRuleAction(ctx context.Context, nra NewRuleAction) (Rul
 		return RuleAction{}, err
 	}
 
+	id := nra.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
 	action := RuleAction{
-		ID:               uuid.New(),
+		ID:               id,
 		AutomationRuleID: nra.AutomationRuleID,
 		Name:             nra.Name,
 		Description:      nra.Description,
@@ -1311,3 +1330,256 @@ func (b *Business) DeleteEdgesByRuleID(ctx context.Context, ruleID uuid.UUID) er
 
 	return nil
 }
+
+// =============================================================================
+// Rule Schedules
+
+// CreateRuleSchedule attaches a firing schedule to a "scheduled" automation rule.
+// The first NextRunAt is computed from the current time.
+func (b *Business) CreateRuleSchedule(ctx context.Context, nrs NewRuleSchedule) (RuleSchedule, error) {
+	ctx, span := otel.AddSpan(ctx, "business.workflowbus.createruleschedule")
+	defer span.End()
+
+	if err := ValidateScheduleSpec(nrs.CronExpression, nrs.IntervalSeconds, nrs.Timezone); err != nil {
+		return RuleSchedule{}, fmt.Errorf("validate: %w", err)
+	}
+
+	// Second precision keeps interval occurrences on whole seconds; next_run_at is
+	// the compare-and-set key the scheduler advances on.
+	now := time.Now().UTC().Truncate(time.Second)
+
+	rs := RuleSchedule{
+		ID:              uuid.New(),
+		RuleID:          nrs.RuleID,
+		CronExpression:  nrs.CronExpression,
+		IntervalSeconds: nrs.IntervalSeconds,
+		Timezone:        nrs.Timezone,
+		CreatedDate:     now,
+		UpdatedDate:     now,
+	}
+
+	next, err := rs.NextAfter(now)
+	if err != nil {
+		return RuleSchedule{}, fmt.Errorf("next run: %w", err)
+	}
+	rs.NextRunAt = next
+
+	if err := b.storer.CreateRuleSchedule(ctx, rs); err != nil {
+		return RuleSchedule{}, fmt.Errorf("create: %w", err)
+	}
+
+	return rs, nil
+}
+
+// UpdateRuleSchedule modifies a rule's schedule. NextRunAt is recomputed from
+// the current time whenever the spec or timezone changes, so an edited
+// schedule never fires on its stale occurrence.
+func (b *Business) UpdateRuleSchedule(ctx context.Context, rs RuleSchedule, urs UpdateRuleSchedule) (RuleSchedule, error) {
+	ctx, span := otel.AddSpan(ctx, "business.workflowbus.updateruleschedule")
+	defer span.End()
+
+	before := rs
+
+	if urs.CronExpression != nil {
+		rs.CronExpression = *urs.CronExpression
+		if rs.CronExpression != "" {
+			rs.IntervalSeconds = 0
+		}
+	}
+	if urs.IntervalSeconds != nil {
+		rs.IntervalSeconds = *urs.IntervalSeconds
+		if rs.IntervalSeconds != 0 {
+			rs.CronExpression = ""
+		}
+	}
+	if urs.Timezone != nil {
+		rs.Timezone = *urs.Timezone
+	}
+
+	if err := ValidateScheduleSpec(rs.CronExpression, rs.IntervalSeconds, rs.Timezone); err != nil {
+		return RuleSchedule{}, fmt.Errorf("validate: %w", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	if rs.CronExpression != before.CronExpression || rs.IntervalSeconds != before.IntervalSeconds || rs.Timezone != before.Timezone {
+		next, err := rs.NextAfter(now)
+		if err != nil {
+			return RuleSchedule{}, fmt.Errorf("next run: %w", err)
+		}
+		rs.NextRunAt = next
+	}
+	rs.UpdatedDate = now
+
+	if err := b.storer.UpdateRuleSchedule(ctx, rs); err != nil {
+		return RuleSchedule{}, fmt.Errorf("update: %w", err)
+	}
+
+	return rs, nil
+}
+
+// DeleteRuleSchedule removes a rule's schedule.
+func (b *Business) DeleteRuleSchedule(ctx context.Context, rs RuleSchedule) error {
+	ctx, span := otel.AddSpan(ctx, "business.workflowbus.deleteruleschedule")
+	defer span.End()
+
+	if err := b.storer.DeleteRuleSchedule(ctx, rs); err != nil {
+		return fmt.Errorf("delete: ruleID[%s]: %w", rs.RuleID, err)
+	}
+
+	return nil
+}
+
+// QueryRuleScheduleByRuleID retrieves the schedule attached to a rule.
+// Returns ErrNotFound when the rule has no schedule.
+func (b *Business) QueryRuleScheduleByRuleID(ctx context.Context, ruleID uuid.UUID) (RuleSchedule, error) {
+	ctx, span := otel.AddSpan(ctx, "business.workflowbus.queryruleschedulebyruleid")
+	defer span.End()
+
+	rs, err := b.storer.QueryRuleScheduleByRuleID(ctx, ruleID)
+	if err != nil {
+		return RuleSchedule{}, fmt.Errorf("query: ruleID[%s]: %w", ruleID, err)
+	}
+
+	return rs, nil
+}
+
+// =============================================================================
+// Rule Revisions
+
+// CreateRuleRevision records a draft snapshot of a rule's definition under the
+// rule's next revision number. Run it in the transaction that produced the
+// definition: the store locks the rule row so concurrent saves of the same
+// rule number their revisions one after the other.
+func (b *Business) CreateRuleRevision(ctx context.Context, nrr NewRuleRevision) (RuleRevision, error) {
+	ctx, span := otel.AddSpan(ctx, "business.workflowbus.createrulerevision")
+	defer span.End()
+
+	number, err := b.storer.NextRuleRevisionNumber(ctx, nrr.RuleID)
+	if err != nil {
+		return RuleRevision{}, fmt.Errorf("next revision number: ruleID[%s]: %w", nrr.RuleID, err)
+	}
+
+	rr := RuleRevision{
+		ID:             uuid.New(),
+		RuleID:         nrr.RuleID,
+		RevisionNumber: number,
+		Status:         RevisionDraft,
+		Definition:     nrr.Definition,
+		RestoredFromID: nrr.RestoredFromID,
+		Notes:          nrr.Notes,
+		CreatedBy:      nrr.CreatedBy,
+		CreatedDate:    time.Now().UTC(),
+	}
+
+	if err := b.storer.CreateRuleRevision(ctx, rr); err != nil {
+		return RuleRevision{}, fmt.Errorf("create: %w", err)
+	}
+
+	return rr, nil
+}
+
+// PublishRuleRevision marks a draft revision as the one the live rule runs,
+// superseding whichever revision of the rule was published before. It only
+// records the fact; the caller makes the live actions and edges match the
+// revision in the same transaction.
+func (b *Business) PublishRuleRevision(ctx context.Context, rr RuleRevision, publishedBy uuid.UUID) (RuleRevision, error) {
+	ctx, span := otel.AddSpan(ctx, "business.workflowbus.publishrulerevision")
+	defer span.End()
+
+	if rr.Status != RevisionDraft {
+		return RuleRevision{}, fmt.Errorf("publish: revision[%s] is %s: %w", rr.ID, rr.Status, ErrRevisionNotDraft)
+	}
+
+	if err := b.storer.SupersedeRuleRevisions(ctx, rr.RuleID); err != nil {
+		return RuleRevision{}, fmt.Errorf("supersede: ruleID[%s]: %w", rr.RuleID, err)
+	}
+
+	now := time.Now().UTC()
+	rr.Status = RevisionPublished
+	rr.PublishedBy = &publishedBy
+	rr.PublishedDate = &now
+
+	if err := b.storer.UpdateRuleRevisionStatus(ctx, rr); err != nil {
+		return RuleRevision{}, fmt.Errorf("update: %w", err)
+	}
+
+	return rr, nil
+}
+
+// PublishLiveRevision snapshots the live rule, its actions, edges and schedule
+// into a new revision and publishes it. The rule, action and edge endpoints
+// edit the live rows one at a time instead of saving a whole definition; they
+// call this after each edit so the published revision, which is the graph the
+// trigger runs, takes the edit.
+func (b *Business) PublishLiveRevision(ctx context.Context, ruleID uuid.UUID, userID uuid.UUID, notes string) (RuleRevision, error) {
+	ctx, span := otel.AddSpan(ctx, "business.workflowbus.publishliverevision")
+	defer span.End()
+
+	rule, err := b.storer.QueryRuleByID(ctx, ruleID)
+	if err != nil {
+		return RuleRevision{}, fmt.Errorf("query rule: ruleID[%s]: %w", ruleID, err)
+	}
+
+	actions, err := b.storer.QueryActionsByRule(ctx, ruleID)
+	if err != nil {
+		return RuleRevision{}, fmt.Errorf("query actions: ruleID[%s]: %w", ruleID, err)
+	}
+
+	edges, err := b.storer.QueryEdgesByRuleID(ctx, ruleID)
+	if err != nil {
+		return RuleRevision{}, fmt.Errorf("query edges: ruleID[%s]: %w", ruleID, err)
+	}
+
+	var schedule *RuleSchedule
+	rs, err := b.storer.QueryRuleScheduleByRuleID(ctx, ruleID)
+	switch {
+	case err == nil:
+		schedule = &rs
+	case !errors.Is(err, ErrNotFound):
+		return RuleRevision{}, fmt.Errorf("query schedule: ruleID[%s]: %w", ruleID, err)
+	}
+
+	rr, err := b.CreateRuleRevision(ctx, NewRuleRevision{
+		RuleID:     ruleID,
+		Definition: NewRuleDefinition(rule, liveActions(actions, edges), edges, schedule),
+		Notes:      notes,
+		CreatedBy:  userID,
+	})
+	if err != nil {
+		return RuleRevision{}, fmt.Errorf("publishliverevision: %w", err)
+	}
+
+	published, err := b.PublishRuleRevision(ctx, rr, userID)
+	if err != nil {
+		return RuleRevision{}, fmt.Errorf("publishliverevision: %w", err)
+	}
+
+	return published, nil
+}
+
+// QueryRuleRevisionsByRuleID retrieves every revision of a rule, newest first.
+func (b *Business) QueryRuleRevisionsByRuleID(ctx context.Context, ruleID uuid.UUID) ([]RuleRevision, error) {
+	ctx, span := otel.AddSpan(ctx, "business.workflowbus.queryrulerevisionsbyruleid")
+	defer span.End()
+
+	revisions, err := b.storer.QueryRuleRevisionsByRuleID(ctx, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("query: ruleID[%s]: %w", ruleID, err)
+	}
+
+	return revisions, nil
+}
+
+// QueryRuleRevisionByID retrieves a single rule revision.
+func (b *Business) QueryRuleRevisionByID(ctx context.Context, id uuid.UUID) (RuleRevision, error) {
+	ctx, span := otel.AddSpan(ctx, "business.workflowbus.queryrulerevisionbyid")
+	defer span.End()
+
+	rr, err := b.storer.QueryRuleRevisionByID(ctx, id)
+	if err != nil {
+		return RuleRevision{}, fmt.Errorf("query: revisionID[%s]: %w", id, err)
+	}
+
+	return rr, nil
+}
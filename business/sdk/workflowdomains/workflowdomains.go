@@ -24,6 +24,7 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/config/pagecontentbus"
 	"github.com/timmaaaz/ichor/business/domain/core/contactinfosbus"
 	"github.com/timmaaaz/ichor/business/domain/core/currencybus"
+	"github.com/timmaaaz/ichor/business/domain/core/datascopebus"
 	"github.com/timmaaaz/ichor/business/domain/core/pagebus"
 	"github.com/timmaaaz/ichor/business/domain/core/paymenttermbus"
 	"github.com/timmaaaz/ichor/business/domain/core/rolebus"
@@ -40,27 +41,35 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/hr/officebus"
 	"github.com/timmaaaz/ichor/business/domain/hr/reportstobus"
 	"github.com/timmaaaz/ichor/business/domain/hr/titlebus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/countplanbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountitembus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/cyclecountsessionbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/demandforecastbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inspectionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryadjustmentbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryitembus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorylocationbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/inventoryreservationbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/inventorytransactionbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/lotlocationbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/lottrackingsbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/picktaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/pickwavebus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/putawaytaskbus"
+	"github.com/timmaaaz/ichor/business/domain/inventory/replenishmenttaskbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/serialnumberbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/transferorderbus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/warehousebus"
 	"github.com/timmaaaz/ichor/business/domain/inventory/zonebus"
 	"github.com/timmaaaz/ichor/business/domain/labels/labelbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/landedcostbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitembus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderlineitemstatusbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/purchaseorderstatusbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/purchasesuggestionbus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/supplierbus"
+	"github.com/timmaaaz/ichor/business/domain/procurement/supplierinvoicebus"
 	"github.com/timmaaaz/ichor/business/domain/procurement/supplierproductbus"
 	"github.com/timmaaaz/ichor/business/domain/products/brandbus"
 	"github.com/timmaaaz/ichor/business/domain/products/costhistorybus"
@@ -74,6 +83,7 @@ import (
 	"github.com/timmaaaz/ichor/business/domain/sales/orderfulfillmentstatusbus"
 	"github.com/timmaaaz/ichor/business/domain/sales/orderlineitemsbus"
 	"github.com/timmaaaz/ichor/business/domain/sales/ordersbus"
+	"github.com/timmaaaz/ichor/business/domain/sales/shipmentbus"
 	"github.com/timmaaaz/ichor/business/domain/scenarios/scenariobus"
 	"github.com/timmaaaz/ichor/business/sdk/workflow"
 	"github.com/timmaaaz/ichor/business/sdk/workflow/workflowactions/data"
@@ -103,6 +113,7 @@ func Registrations() []EntityReg {
 		{"sales", orderlineitemsbus.DomainName, orderlineitemsbus.EntityName},
 		{"sales", orderfulfillmentstatusbus.DomainName, orderfulfillmentstatusbus.EntityName},
 		{"sales", lineitemfulfillmentstatusbus.DomainName, lineitemfulfillmentstatusbus.EntityName},
+		{"sales", shipmentbus.DomainName, shipmentbus.EntityName},
 
 		// Assets domain
 		{"assets", assetbus.DomainName, assetbus.EntityName},
@@ -120,6 +131,7 @@ func Registrations() []EntityReg {
 		{"core", rolebus.DomainName, rolebus.EntityName},
 		{"core", userrolebus.DomainName, userrolebus.EntityName},
 		{"core", tableaccessbus.DomainName, tableaccessbus.EntityName},
+		{"core", datascopebus.DomainName, datascopebus.EntityName},
 		{"core", pagebus.DomainName, pagebus.EntityName},
 		{"core", paymenttermbus.DomainName, paymenttermbus.EntityName},
 		{"core", currencybus.DomainName, currencybus.EntityName},
@@ -153,6 +165,9 @@ func Registrations() []EntityReg {
 		{"procurement", supplierproductbus.DomainName, supplierproductbus.EntityName},
 		{"procurement", purchaseorderbus.DomainName, purchaseorderbus.EntityName},
 		{"procurement", purchaseorderlineitembus.DomainName, purchaseorderlineitembus.EntityName},
+		{"procurement", purchasesuggestionbus.DomainName, purchasesuggestionbus.EntityName},
+		{"procurement", supplierinvoicebus.DomainName, supplierinvoicebus.EntityName},
+		{"procurement", landedcostbus.DomainName, landedcostbus.EntityName},
 		{"procurement", purchaseorderstatusbus.DomainName, purchaseorderstatusbus.EntityName},
 		{"procurement", purchaseorderlineitemstatusbus.DomainName, purchaseorderlineitemstatusbus.EntityName},
 
@@ -161,12 +176,17 @@ func Registrations() []EntityReg {
 		{"inventory", zonebus.DomainName, zonebus.EntityName},
 		{"inventory", inventorylocationbus.DomainName, inventorylocationbus.EntityName},
 		{"inventory", inventoryitembus.DomainName, inventoryitembus.EntityName},
+		{"inventory", inventoryreservationbus.DomainName, inventoryreservationbus.EntityName},
 		{"inventory", inventorytransactionbus.DomainName, inventorytransactionbus.EntityName},
 		{"inventory", inventoryadjustmentbus.DomainName, inventoryadjustmentbus.EntityName},
 		{"inventory", putawaytaskbus.DomainName, putawaytaskbus.EntityName},
 		{"inventory", picktaskbus.DomainName, picktaskbus.EntityName},
 		{"inventory", cyclecountsessionbus.DomainName, cyclecountsessionbus.EntityName},
 		{"inventory", cyclecountitembus.DomainName, cyclecountitembus.EntityName},
+		{"inventory", countplanbus.DomainName, countplanbus.EntityName},
+		{"inventory", pickwavebus.DomainName, pickwavebus.EntityName},
+		{"inventory", replenishmenttaskbus.DomainName, replenishmenttaskbus.EntityName},
+		{"inventory", demandforecastbus.DomainName, demandforecastbus.EntityName},
 		{"inventory", transferorderbus.DomainName, transferorderbus.EntityName},
 		{"inventory", inspectionbus.DomainName, inspectionbus.EntityName},
 		{"inventory", lottrackingsbus.DomainName, lottrackingsbus.EntityName},
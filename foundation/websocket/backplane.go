@@ -0,0 +1,96 @@
+package websocket
+
+import (
+	"context"
+	"sync"
+)
+
+// EventKind identifies what a backplane Event asks the other replicas to do.
+type EventKind string
+
+const (
+	// EventBroadcastID delivers Message to the local clients registered under ID.
+	EventBroadcastID EventKind = "broadcast_id"
+	// EventBroadcastAll delivers Message to every local client.
+	EventBroadcastAll EventKind = "broadcast_all"
+	// EventPresence replaces the set of IDs connected to the Origin replica.
+	EventPresence EventKind = "presence"
+)
+
+// Event is a hub operation relayed between replicas by a Backplane.
+type Event struct {
+	Origin  string    `json:"origin"`
+	Kind    EventKind `json:"kind"`
+	ID      string    `json:"id,omitempty"`
+	IDs     []string  `json:"ids,omitempty"`
+	Message []byte    `json:"message,omitempty"`
+}
+
+// Backplane relays hub events between the replicas of a service so that
+// broadcasts and presence reach clients connected to any replica. Every event
+// published is delivered to every subscriber, including the publishing hub,
+// which ignores its own events by Origin.
+type Backplane interface {
+	// Publish sends evt to every subscriber.
+	Publish(ctx context.Context, evt Event) error
+
+	// Subscribe calls handle for each event published until ctx is cancelled
+	// or the subscription fails. Events are handled one at a time.
+	Subscribe(ctx context.Context, handle func(Event)) error
+}
+
+// =============================================================================
+
+// LocalBackplane is an in-memory Backplane connecting hubs in one process. It
+// is meant for tests and single-replica deployments.
+type LocalBackplane struct {
+	mu   sync.RWMutex
+	subs map[chan Event]struct{}
+}
+
+// NewLocalBackplane constructs an in-memory backplane.
+func NewLocalBackplane() *LocalBackplane {
+	return &LocalBackplane{
+		subs: make(map[chan Event]struct{}),
+	}
+}
+
+// Publish sends evt to every current subscriber, waiting while a subscriber's
+// buffer is full.
+func (b *LocalBackplane) Publish(ctx context.Context, evt Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subs {
+		select {
+		case ch <- evt:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Subscribe calls handle for each event published until ctx is cancelled.
+func (b *LocalBackplane) Subscribe(ctx context.Context, handle func(Event)) error {
+	ch := make(chan Event, sendBufferSize)
+
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	defer func() {
+		b.mu.Lock()
+		delete(b.subs, ch)
+		b.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case evt := <-ch:
+			handle(evt)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
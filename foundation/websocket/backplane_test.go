@@ -0,0 +1,144 @@
+package websocket_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	ws "github.com/timmaaaz/ichor/foundation/websocket"
+)
+
+// newReplicas starts two test servers sharing one in-memory backplane and
+// waits until each hub sees the other's presence, which proves both are
+// subscribed.
+func newReplicas(t *testing.T) (*TestServer, *TestServer) {
+	t.Helper()
+
+	bp := ws.NewLocalBackplane()
+	a := NewTestServer(t, ws.WithBackplane(bp))
+	b := NewTestServer(t, ws.WithBackplane(bp))
+
+	connA := a.ConnectClient(t, "replica:a")
+	connB := b.ConnectClient(t, "replica:b")
+	t.Cleanup(func() {
+		connA.Close(websocket.StatusNormalClosure, "")
+		connB.Close(websocket.StatusNormalClosure, "")
+	})
+
+	if !waitForCondition(t, 2*time.Second, func() bool {
+		return slices.Contains(a.Hub.ConnectedIDs(), "replica:b") && slices.Contains(b.Hub.ConnectedIDs(), "replica:a")
+	}) {
+		t.Fatal("Timeout waiting for replicas to see each other")
+	}
+
+	return a, b
+}
+
+// readWithin reads one message from conn or fails the test.
+func readWithin(t *testing.T, conn *websocket.Conn, timeout time.Duration) []byte {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	_, msg, err := conn.Read(ctx)
+	if err != nil {
+		t.Fatalf("Failed to read message: %v", err)
+	}
+	return msg
+}
+
+func TestBackplane_BroadcastToIDAcrossReplicas(t *testing.T) {
+	a, b := newReplicas(t)
+
+	conn := a.ConnectClient(t, "user:123")
+	defer conn.Close(websocket.StatusNormalClosure, "")
+
+	if !waitForCondition(t, 2*time.Second, func() bool {
+		return a.Hub.ClientsForID("user:123") == 1
+	}) {
+		t.Fatal("Timeout waiting for registration")
+	}
+
+	testMsg := []byte(`{"type":"alert","payload":"remote"}`)
+	if delivered := b.Hub.BroadcastToID("user:123", testMsg); delivered != 0 {
+		t.Errorf("Expected 0 local deliveries on the publishing replica, got %d", delivered)
+	}
+
+	if msg := readWithin(t, conn, 2*time.Second); string(msg) != string(testMsg) {
+		t.Errorf("Expected %s, got %s", testMsg, msg)
+	}
+}
+
+func TestBackplane_BroadcastAllAcrossReplicas(t *testing.T) {
+	a, b := newReplicas(t)
+
+	conn := a.ConnectClient(t, "user:456")
+	defer conn.Close(websocket.StatusNormalClosure, "")
+
+	if !waitForCondition(t, 2*time.Second, func() bool {
+		return a.Hub.ClientsForID("user:456") == 1
+	}) {
+		t.Fatal("Timeout waiting for registration")
+	}
+
+	testMsg := []byte(`{"type":"alert","payload":"everyone"}`)
+	b.Hub.BroadcastAll(testMsg)
+
+	if msg := readWithin(t, conn, 2*time.Second); string(msg) != string(testMsg) {
+		t.Errorf("Expected %s, got %s", testMsg, msg)
+	}
+}
+
+func TestBackplane_PresenceAcrossReplicas(t *testing.T) {
+	a, b := newReplicas(t)
+
+	conn := a.ConnectClient(t, "user:789", "role:picker")
+
+	if !waitForCondition(t, 2*time.Second, func() bool {
+		ids := b.Hub.ConnectedIDs()
+		return slices.Contains(ids, "user:789") && slices.Contains(ids, "role:picker")
+	}) {
+		t.Fatalf("Expected remote IDs in ConnectedIDs, got %v", b.Hub.ConnectedIDs())
+	}
+
+	if n := b.Hub.ClientsForID("user:789"); n != 0 {
+		t.Errorf("Expected ClientsForID to count only local clients, got %d", n)
+	}
+
+	conn.Close(websocket.StatusNormalClosure, "")
+
+	if !waitForCondition(t, 2*time.Second, func() bool {
+		return !slices.Contains(b.Hub.ConnectedIDs(), "user:789")
+	}) {
+		t.Fatalf("Expected remote ID to be withdrawn after disconnect, got %v", b.Hub.ConnectedIDs())
+	}
+}
+
+func TestBackplane_IgnoresOwnEvents(t *testing.T) {
+	a, _ := newReplicas(t)
+
+	conn := a.ConnectClient(t, "user:own")
+	defer conn.Close(websocket.StatusNormalClosure, "")
+
+	if !waitForCondition(t, 2*time.Second, func() bool {
+		return a.Hub.ClientsForID("user:own") == 1
+	}) {
+		t.Fatal("Timeout waiting for registration")
+	}
+
+	first := []byte(`{"n":1}`)
+	second := []byte(`{"n":2}`)
+	a.Hub.BroadcastToID("user:own", first)
+	a.Hub.BroadcastToID("user:own", second)
+
+	// A hub that re-delivered its own events would send first twice.
+	if msg := readWithin(t, conn, 2*time.Second); string(msg) != string(first) {
+		t.Fatalf("Expected %s, got %s", first, msg)
+	}
+	if msg := readWithin(t, conn, 2*time.Second); string(msg) != string(second) {
+		t.Fatalf("Expected %s, got %s", second, msg)
+	}
+}
@@ -26,4 +26,12 @@
 //
 //	// Broadcast to all
 //	hub.BroadcastAll(messageBytes)
+//
+// Multiple replicas:
+//
+//	A Hub only reaches the clients connected to its own process. Give every
+//	replica a Backplane (e.g. pgbackplane over Postgres LISTEN/NOTIFY) and
+//	broadcasts and ConnectedIDs span the whole cluster:
+//
+//	hub := websocket.NewHub(log, websocket.WithBackplane(bp))
 package websocket
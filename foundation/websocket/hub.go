@@ -5,9 +5,29 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/timmaaaz/ichor/foundation/logger"
 )
 
+const (
+	// metricsInterval is how often Run logs connection metrics and, with a
+	// backplane, re-announces the replica's connected IDs.
+	metricsInterval = 30 * time.Second
+
+	// presenceTTL is how long the IDs announced by another replica are kept
+	// without a fresh announcement. Three missed heartbeats drop a replica
+	// that died without saying goodbye.
+	presenceTTL = 3 * metricsInterval
+
+	// publishTimeout bounds each backplane publish made on behalf of a
+	// fire-and-forget broadcast.
+	publishTimeout = 5 * time.Second
+
+	// resubscribeDelay is the pause before Run retries a failed backplane
+	// subscription.
+	resubscribeDelay = 2 * time.Second
+)
+
 // Hub manages WebSocket connections and message broadcasting.
 // Uses string-based IDs for maximum flexibility - the calling application
 // defines the semantics (e.g., "user:{uuid}", "role:{uuid}").
@@ -20,25 +40,70 @@ type Hub struct {
 	// Used for efficient unregistration and ID updates.
 	clientIDs map[*Client][]string
 
+	// remote holds the IDs connected to other replicas, keyed by the
+	// replica's origin. Only populated when a backplane is configured.
+	remote map[string]remotePresence
+
+	// origin identifies this hub on the backplane.
+	origin    string
+	backplane Backplane
+
+	// announce signals Run to publish this replica's connected IDs.
+	announce chan struct{}
+
 	mu  sync.RWMutex
 	log *logger.Logger
 }
 
+// remotePresence is the set of IDs last announced by another replica.
+type remotePresence struct {
+	ids  map[string]struct{}
+	seen time.Time
+}
+
+// HubOption configures optional Hub behavior.
+type HubOption func(*Hub)
+
+// WithBackplane relays broadcasts and presence through bp so they span every
+// replica subscribed to it. Run must be running for remote events to be
+// received.
+func WithBackplane(bp Backplane) HubOption {
+	return func(h *Hub) {
+		h.backplane = bp
+	}
+}
+
 // NewHub creates a new Hub instance.
-func NewHub(log *logger.Logger) *Hub {
-	return &Hub{
+func NewHub(log *logger.Logger, opts ...HubOption) *Hub {
+	h := &Hub{
 		clients:   make(map[string]map[*Client]bool),
 		clientIDs: make(map[*Client][]string),
+		remote:    make(map[string]remotePresence),
+		origin:    uuid.NewString(),
+		announce:  make(chan struct{}, 1),
 		log:       log,
 	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
 }
 
 // Run starts the hub and blocks until context is cancelled.
-// Periodically logs connection metrics for monitoring.
+// Periodically logs connection metrics for monitoring. With a backplane it
+// also receives the events of other replicas and announces this replica's
+// connected IDs whenever they change and on every metrics tick.
 func (h *Hub) Run(ctx context.Context) error {
-	ticker := time.NewTicker(30 * time.Second)
+	ticker := time.NewTicker(metricsInterval)
 	defer ticker.Stop()
 
+	if h.backplane != nil {
+		go h.subscribe(ctx)
+		h.signalPresence()
+	}
+
 	for {
 		select {
 		case <-ticker.C:
@@ -50,8 +115,20 @@ func (h *Hub) Run(ctx context.Context) error {
 			h.log.Debug(ctx, "websocket metrics",
 				"total_connections", connCount,
 				"unique_ids", idCount)
+
+			if h.backplane != nil {
+				h.expireRemote()
+				h.publishPresence(ctx)
+			}
+		case <-h.announce:
+			h.publishPresence(ctx)
 		case <-ctx.Done():
 			h.log.Info(ctx, "websocket hub shutting down")
+			if h.backplane != nil {
+				// Withdraw this replica's IDs so other replicas do not wait
+				// for them to expire.
+				h.publish(Event{Kind: EventPresence})
+			}
 			return ctx.Err()
 		}
 	}
@@ -74,6 +151,8 @@ func (h *Hub) Register(ctx context.Context, client *Client, ids []string) {
 		h.clients[id][client] = true
 	}
 
+	h.signalPresence()
+
 	h.log.Info(ctx, "websocket client registered",
 		"id_count", len(ids),
 		"total_connections", len(h.clientIDs))
@@ -100,6 +179,7 @@ func (h *Hub) Unregister(ctx context.Context, client *Client) {
 	}
 
 	delete(h.clientIDs, client)
+	h.signalPresence()
 
 	h.log.Info(ctx, "websocket client unregistered",
 		"total_connections", len(h.clientIDs))
@@ -108,8 +188,25 @@ func (h *Hub) Unregister(ctx context.Context, client *Client) {
 // BroadcastToID sends a message to all connections registered under the given ID.
 // Uses context.Background() intentionally - broadcasts should not be cancelled by
 // the caller's context since they're fire-and-forget operations. Individual client
-// Send() calls handle their own timeouts via the write pump.
+// Send() calls handle their own timeouts via the write pump. With a backplane the
+// message is also relayed to the other replicas; the count returned covers only
+// the local connections.
 func (h *Hub) BroadcastToID(id string, message []byte) int {
+	n := h.sendToID(id, message)
+	h.publish(Event{Kind: EventBroadcastID, ID: id, Message: message})
+	return n
+}
+
+// BroadcastAll sends a message to all connected clients. With a backplane the
+// message is also relayed to the other replicas; the count returned covers only
+// the local connections.
+func (h *Hub) BroadcastAll(message []byte) int {
+	n := h.sendToAll(message)
+	h.publish(Event{Kind: EventBroadcastAll, Message: message})
+	return n
+}
+
+func (h *Hub) sendToID(id string, message []byte) int {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
@@ -120,8 +217,7 @@ func (h *Hub) BroadcastToID(id string, message []byte) int {
 	return len(clients)
 }
 
-// BroadcastAll sends a message to all connected clients.
-func (h *Hub) BroadcastAll(message []byte) int {
+func (h *Hub) sendToAll(message []byte) int {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
@@ -158,6 +254,8 @@ func (h *Hub) UpdateClientIDs(ctx context.Context, client *Client, newIDs []stri
 		}
 		h.clients[id][client] = true
 	}
+
+	h.signalPresence()
 }
 
 // UpdateClientIDsForID updates IDs for all clients registered under the given ID.
@@ -197,6 +295,10 @@ func (h *Hub) UpdateClientIDsForID(ctx context.Context, id string, newIDs []stri
 			h.clients[newID][client] = true
 		}
 	}
+
+	if len(clientsToUpdate) > 0 {
+		h.signalPresence()
+	}
 }
 
 // CloseAll closes all connected clients. Used for graceful shutdown.
@@ -220,7 +322,8 @@ func (h *Hub) ConnectionCount() int {
 	return len(h.clientIDs)
 }
 
-// ConnectedIDs returns all unique IDs currently registered in the hub.
+// ConnectedIDs returns all unique IDs currently registered in the hub. With a
+// backplane this includes the IDs connected to the other replicas.
 func (h *Hub) ConnectedIDs() []string {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
@@ -229,12 +332,145 @@ func (h *Hub) ConnectedIDs() []string {
 	for id := range h.clients {
 		ids = append(ids, id)
 	}
+
+	if len(h.remote) == 0 {
+		return ids
+	}
+
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		seen[id] = struct{}{}
+	}
+
+	now := time.Now()
+	for _, rp := range h.remote {
+		if now.Sub(rp.seen) > presenceTTL {
+			continue
+		}
+		for id := range rp.ids {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				ids = append(ids, id)
+			}
+		}
+	}
+
 	return ids
 }
 
-// ClientsForID returns the number of clients registered under the given ID.
+// ClientsForID returns the number of local clients registered under the given ID.
 func (h *Hub) ClientsForID(id string) int {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 	return len(h.clients[id])
 }
+
+// =============================================================================
+// Backplane
+
+// publish relays evt to the other replicas. Failures are logged: local
+// delivery has already happened and broadcasts are fire-and-forget.
+func (h *Hub) publish(evt Event) {
+	if h.backplane == nil {
+		return
+	}
+
+	evt.Origin = h.origin
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	if err := h.backplane.Publish(ctx, evt); err != nil {
+		h.log.Error(ctx, "websocket backplane publish failed", "kind", evt.Kind, "error", err)
+	}
+}
+
+// signalPresence asks Run to announce the local IDs. Signals coalesce, so a
+// burst of registrations results in a single announcement.
+func (h *Hub) signalPresence() {
+	if h.backplane == nil {
+		return
+	}
+
+	select {
+	case h.announce <- struct{}{}:
+	default:
+	}
+}
+
+// publishPresence announces the IDs connected to this replica.
+func (h *Hub) publishPresence(ctx context.Context) {
+	h.mu.RLock()
+	ids := make([]string, 0, len(h.clients))
+	for id := range h.clients {
+		ids = append(ids, id)
+	}
+	h.mu.RUnlock()
+
+	h.publish(Event{Kind: EventPresence, IDs: ids})
+}
+
+// subscribe receives the events of other replicas until ctx is cancelled,
+// resubscribing after failures.
+func (h *Hub) subscribe(ctx context.Context) {
+	for {
+		err := h.backplane.Subscribe(ctx, h.handleEvent)
+		if ctx.Err() != nil {
+			return
+		}
+
+		h.log.Error(ctx, "websocket backplane subscription failed", "error", err)
+
+		select {
+		case <-time.After(resubscribeDelay):
+		case <-ctx.Done():
+			return
+		}
+
+		// Other replicas may have missed our presence while we were away.
+		h.signalPresence()
+	}
+}
+
+// handleEvent applies an event published by another replica.
+func (h *Hub) handleEvent(evt Event) {
+	if evt.Origin == h.origin {
+		return
+	}
+
+	switch evt.Kind {
+	case EventBroadcastID:
+		h.sendToID(evt.ID, evt.Message)
+
+	case EventBroadcastAll:
+		h.sendToAll(evt.Message)
+
+	case EventPresence:
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		if len(evt.IDs) == 0 {
+			delete(h.remote, evt.Origin)
+			return
+		}
+
+		ids := make(map[string]struct{}, len(evt.IDs))
+		for _, id := range evt.IDs {
+			ids[id] = struct{}{}
+		}
+		h.remote[evt.Origin] = remotePresence{ids: ids, seen: time.Now()}
+	}
+}
+
+// expireRemote forgets replicas that stopped announcing their IDs.
+func (h *Hub) expireRemote() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	for origin, rp := range h.remote {
+		if now.Sub(rp.seen) > presenceTTL {
+			delete(h.remote, origin)
+		}
+	}
+}
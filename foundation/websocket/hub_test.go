@@ -28,12 +28,12 @@ type TestServer struct {
 }
 
 // NewTestServer creates a test server with WebSocket upgrade handler.
-func NewTestServer(t *testing.T) *TestServer {
+func NewTestServer(t *testing.T, opts ...ws.HubOption) *TestServer {
 	t.Helper()
 
 	log := logger.New(os.Stdout, logger.LevelInfo, "TEST",
 		func(context.Context) string { return otel.GetTraceID(context.Background()) })
-	hub := ws.NewHub(log)
+	hub := ws.NewHub(log, opts...)
 
 	// Start hub in background
 	ctx, cancel := context.WithCancel(context.Background())
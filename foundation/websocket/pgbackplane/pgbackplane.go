@@ -0,0 +1,226 @@
+// Package pgbackplane provides a websocket.Backplane over Postgres
+// LISTEN/NOTIFY, letting every replica connected to the same database share
+// hub broadcasts and presence without extra infrastructure.
+package pgbackplane
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+	"github.com/timmaaaz/ichor/foundation/logger"
+	"github.com/timmaaaz/ichor/foundation/websocket"
+)
+
+const (
+	// chunkSize is the largest slice of an encoded event sent in one
+	// notification. NOTIFY payloads are limited to 8000 bytes; base64 and the
+	// frame envelope grow a chunk by roughly a third.
+	chunkSize = 5000
+
+	// partialTTL is how long the frames of an incomplete event are kept.
+	partialTTL = time.Minute
+)
+
+// frame is one notification carrying part of an encoded event.
+type frame struct {
+	ID    string `json:"id"`
+	Seq   int    `json:"seq"`
+	Total int    `json:"total"`
+	Data  []byte `json:"data"`
+}
+
+// Backplane relays hub events through a Postgres notification channel.
+type Backplane struct {
+	log     *logger.Logger
+	db      *sqlx.DB
+	channel string
+}
+
+// New constructs a backplane publishing and listening on channel. Each
+// subscription holds one connection from db's pool for its lifetime.
+func New(log *logger.Logger, db *sqlx.DB, channel string) *Backplane {
+	return &Backplane{
+		log:     log,
+		db:      db,
+		channel: channel,
+	}
+}
+
+// Publish notifies every listener of evt, split across as many
+// notifications as the payload limit requires.
+func (b *Backplane) Publish(ctx context.Context, evt websocket.Event) error {
+	frames, err := encode(evt)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range frames {
+		if _, err := b.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", b.channel, f); err != nil {
+			return fmt.Errorf("notify: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Subscribe listens on the channel and calls handle for each complete event
+// until ctx is cancelled or the connection fails.
+func (b *Backplane) Subscribe(ctx context.Context, handle func(websocket.Event)) error {
+	conn, err := b.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		sc, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("unexpected driver connection %T", driverConn)
+		}
+		pc := sc.Conn()
+
+		listenErr := b.listen(ctx, pc, handle)
+
+		// Never hand a listening connection back to the pool.
+		unlistenCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := pc.Exec(unlistenCtx, "UNLISTEN *"); err != nil {
+			return errors.Join(listenErr, driver.ErrBadConn)
+		}
+
+		return listenErr
+	})
+}
+
+func (b *Backplane) listen(ctx context.Context, pc *pgx.Conn, handle func(websocket.Event)) error {
+	if _, err := pc.Exec(ctx, "LISTEN "+pgx.Identifier{b.channel}.Sanitize()); err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	asm := newAssembler()
+	for {
+		n, err := pc.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("wait for notification: %w", err)
+		}
+
+		evt, ok, err := asm.add(n.Payload, time.Now())
+		if err != nil {
+			b.log.Warn(ctx, "websocket backplane: dropping malformed notification", "error", err)
+			continue
+		}
+		if ok {
+			handle(evt)
+		}
+	}
+}
+
+// =============================================================================
+
+// encode splits evt into notification payloads.
+func encode(evt websocket.Event) ([]string, error) {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return nil, fmt.Errorf("marshal event: %w", err)
+	}
+
+	total := (len(data) + chunkSize - 1) / chunkSize
+	id := uuid.NewString()
+
+	payloads := make([]string, 0, total)
+	for seq := 0; seq < total; seq++ {
+		end := min((seq+1)*chunkSize, len(data))
+
+		f, err := json.Marshal(frame{ID: id, Seq: seq, Total: total, Data: data[seq*chunkSize : end]})
+		if err != nil {
+			return nil, fmt.Errorf("marshal frame: %w", err)
+		}
+		payloads = append(payloads, string(f))
+	}
+
+	return payloads, nil
+}
+
+// assembler rebuilds events from their frames.
+type assembler struct {
+	mu       sync.Mutex
+	partials map[string]*partial
+}
+
+type partial struct {
+	parts    [][]byte
+	received int
+	started  time.Time
+}
+
+func newAssembler() *assembler {
+	return &assembler{
+		partials: make(map[string]*partial),
+	}
+}
+
+// add records one notification payload and returns the event once all of
+// its frames have arrived.
+func (a *assembler) add(payload string, now time.Time) (websocket.Event, bool, error) {
+	var f frame
+	if err := json.Unmarshal([]byte(payload), &f); err != nil {
+		return websocket.Event{}, false, fmt.Errorf("unmarshal frame: %w", err)
+	}
+	if f.Total < 1 || f.Seq < 0 || f.Seq >= f.Total {
+		return websocket.Event{}, false, fmt.Errorf("frame %d of %d out of range", f.Seq, f.Total)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for id, p := range a.partials {
+		if now.Sub(p.started) > partialTTL {
+			delete(a.partials, id)
+		}
+	}
+
+	data := f.Data
+	if f.Total > 1 {
+		p, ok := a.partials[f.ID]
+		if !ok {
+			p = &partial{parts: make([][]byte, f.Total), started: now}
+			a.partials[f.ID] = p
+		}
+		if len(p.parts) != f.Total {
+			return websocket.Event{}, false, fmt.Errorf("frame total changed for %s", f.ID)
+		}
+		if p.parts[f.Seq] == nil {
+			p.parts[f.Seq] = f.Data
+			p.received++
+		}
+		if p.received < f.Total {
+			return websocket.Event{}, false, nil
+		}
+
+		delete(a.partials, f.ID)
+
+		data = nil
+		for _, part := range p.parts {
+			data = append(data, part...)
+		}
+	}
+
+	var evt websocket.Event
+	if err := json.Unmarshal(data, &evt); err != nil {
+		return websocket.Event{}, false, fmt.Errorf("unmarshal event: %w", err)
+	}
+
+	return evt, true, nil
+}
@@ -0,0 +1,98 @@
+package pgbackplane
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/timmaaaz/ichor/foundation/websocket"
+)
+
+func TestEncode_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		evt        websocket.Event
+		multiFrame bool
+	}{
+		{
+			name:       "small",
+			evt:        websocket.Event{Origin: "a", Kind: websocket.EventBroadcastID, ID: "user:1", Message: []byte(`{"type":"alert"}`)},
+			multiFrame: false,
+		},
+		{
+			name:       "larger than a notification",
+			evt:        websocket.Event{Origin: "a", Kind: websocket.EventBroadcastAll, Message: bytes.Repeat([]byte("é"), 3*chunkSize)},
+			multiFrame: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payloads, err := encode(tt.evt)
+			if err != nil {
+				t.Fatalf("encode: %v", err)
+			}
+			if got := len(payloads) > 1; got != tt.multiFrame {
+				t.Fatalf("expected multiple frames = %t, got %d frames", tt.multiFrame, len(payloads))
+			}
+
+			asm := newAssembler()
+			now := time.Now()
+
+			// Deliver out of order; only the last frame completes the event.
+			for i := len(payloads) - 1; i >= 0; i-- {
+				if len(payloads[i]) >= 8000 {
+					t.Fatalf("frame %d is %d bytes, over the NOTIFY limit", i, len(payloads[i]))
+				}
+
+				got, ok, err := asm.add(payloads[i], now)
+				if err != nil {
+					t.Fatalf("add: %v", err)
+				}
+				if ok != (i == 0) {
+					t.Fatalf("frame %d: complete = %t", i, ok)
+				}
+				if ok {
+					if diff := cmp.Diff(tt.evt, got); diff != "" {
+						t.Errorf("event mismatch (-want +got):\n%s", diff)
+					}
+				}
+			}
+
+			if len(asm.partials) != 0 {
+				t.Errorf("expected no partial events left, got %d", len(asm.partials))
+			}
+		})
+	}
+}
+
+func TestAssembler_DropsStalePartials(t *testing.T) {
+	payloads, err := encode(websocket.Event{Kind: websocket.EventBroadcastAll, Message: []byte(strings.Repeat("x", 2*chunkSize))})
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	asm := newAssembler()
+	start := time.Now()
+
+	if _, ok, err := asm.add(payloads[0], start); err != nil || ok {
+		t.Fatalf("first frame: ok=%t err=%v", ok, err)
+	}
+
+	// The rest arrive after the partial expired and no longer complete it.
+	if _, ok, err := asm.add(payloads[1], start.Add(2*partialTTL)); err != nil || ok {
+		t.Fatalf("late frame: ok=%t err=%v", ok, err)
+	}
+}
+
+func TestAssembler_RejectsMalformed(t *testing.T) {
+	asm := newAssembler()
+
+	for _, payload := range []string{"not json", `{"id":"x","seq":2,"total":1,"data":""}`} {
+		if _, _, err := asm.add(payload, time.Now()); err == nil {
+			t.Errorf("expected error for %q", payload)
+		}
+	}
+}
@@ -166,6 +166,23 @@ func TestMCPServer_ToolsAndResources(t *testing.T) {
 		"analyze_workflow",
 		"suggest_templates",
 		"show_cascade",
+		// Procurement tools
+		"list_purchase_suggestions",
+		"get_purchase_suggestion",
+		"run_purchase_suggestions",
+		"update_purchase_suggestion_line",
+		"remove_purchase_suggestion_line",
+		"release_purchase_suggestion",
+		"dismiss_purchase_suggestion",
+		"list_supplier_invoices",
+		"get_supplier_invoice",
+		"match_supplier_invoice",
+		"approve_supplier_invoice",
+		"reject_supplier_invoice",
+		"list_landed_costs",
+		"get_landed_cost",
+		"preview_landed_cost",
+		"apply_landed_cost",
 	}
 
 	toolNames := make(map[string]bool)
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -77,6 +78,11 @@ func (c *Client) put(ctx context.Context, path string, payload json.RawMessage)
 	return c.doRequest(ctx, http.MethodPut, path, bytes.NewReader(payload))
 }
 
+// delete performs a DELETE request.
+func (c *Client) delete(ctx context.Context, path string) (json.RawMessage, error) {
+	return c.doRequest(ctx, http.MethodDelete, path, nil)
+}
+
 // GetCatalog calls GET /v1/agent/catalog.
 func (c *Client) GetCatalog(ctx context.Context) (json.RawMessage, error) {
 	return c.get(ctx, "/v1/agent/catalog")
@@ -335,3 +341,103 @@ func (c *Client) UpdateTableConfig(ctx context.Context, id string, payload json.
 func (c *Client) ValidateTableConfig(ctx context.Context, payload json.RawMessage) (json.RawMessage, error) {
 	return c.post(ctx, "/v1/data/validate", payload)
 }
+
+// ListPurchaseSuggestions calls GET /v1/procurement/purchase-suggestions with
+// the given filters.
+func (c *Client) ListPurchaseSuggestions(ctx context.Context, filters url.Values) (json.RawMessage, error) {
+	path := "/v1/procurement/purchase-suggestions"
+	if len(filters) > 0 {
+		path += "?" + filters.Encode()
+	}
+	return c.get(ctx, path)
+}
+
+// GetPurchaseSuggestion calls GET /v1/procurement/purchase-suggestions/{suggestion_id}.
+func (c *Client) GetPurchaseSuggestion(ctx context.Context, id string) (json.RawMessage, error) {
+	return c.get(ctx, "/v1/procurement/purchase-suggestions/"+id)
+}
+
+// RunPurchaseSuggestions calls POST /v1/procurement/purchase-suggestions/run.
+func (c *Client) RunPurchaseSuggestions(ctx context.Context, payload json.RawMessage) (json.RawMessage, error) {
+	return c.post(ctx, "/v1/procurement/purchase-suggestions/run", payload)
+}
+
+// UpdatePurchaseSuggestion calls PUT /v1/procurement/purchase-suggestions/{suggestion_id}.
+func (c *Client) UpdatePurchaseSuggestion(ctx context.Context, id string, payload json.RawMessage) (json.RawMessage, error) {
+	return c.put(ctx, "/v1/procurement/purchase-suggestions/"+id, payload)
+}
+
+// UpdatePurchaseSuggestionLine calls PUT /v1/procurement/purchase-suggestions/{suggestion_id}/lines/{line_id}.
+func (c *Client) UpdatePurchaseSuggestionLine(ctx context.Context, id string, lineID string, payload json.RawMessage) (json.RawMessage, error) {
+	return c.put(ctx, "/v1/procurement/purchase-suggestions/"+id+"/lines/"+lineID, payload)
+}
+
+// RemovePurchaseSuggestionLine calls DELETE /v1/procurement/purchase-suggestions/{suggestion_id}/lines/{line_id}.
+func (c *Client) RemovePurchaseSuggestionLine(ctx context.Context, id string, lineID string) (json.RawMessage, error) {
+	return c.delete(ctx, "/v1/procurement/purchase-suggestions/"+id+"/lines/"+lineID)
+}
+
+// ReleasePurchaseSuggestion calls POST /v1/procurement/purchase-suggestions/{suggestion_id}/release.
+func (c *Client) ReleasePurchaseSuggestion(ctx context.Context, id string, payload json.RawMessage) (json.RawMessage, error) {
+	return c.post(ctx, "/v1/procurement/purchase-suggestions/"+id+"/release", payload)
+}
+
+// DismissPurchaseSuggestion calls POST /v1/procurement/purchase-suggestions/{suggestion_id}/dismiss.
+func (c *Client) DismissPurchaseSuggestion(ctx context.Context, id string) (json.RawMessage, error) {
+	return c.post(ctx, "/v1/procurement/purchase-suggestions/"+id+"/dismiss", json.RawMessage(`{}`))
+}
+
+// ListSupplierInvoices calls GET /v1/procurement/supplier-invoices with the
+// given filters.
+func (c *Client) ListSupplierInvoices(ctx context.Context, filters url.Values) (json.RawMessage, error) {
+	path := "/v1/procurement/supplier-invoices"
+	if len(filters) > 0 {
+		path += "?" + filters.Encode()
+	}
+	return c.get(ctx, path)
+}
+
+// GetSupplierInvoice calls GET /v1/procurement/supplier-invoices/{invoice_id}.
+func (c *Client) GetSupplierInvoice(ctx context.Context, id string) (json.RawMessage, error) {
+	return c.get(ctx, "/v1/procurement/supplier-invoices/"+id)
+}
+
+// MatchSupplierInvoice calls POST /v1/procurement/supplier-invoices/{invoice_id}/match.
+func (c *Client) MatchSupplierInvoice(ctx context.Context, id string) (json.RawMessage, error) {
+	return c.post(ctx, "/v1/procurement/supplier-invoices/"+id+"/match", json.RawMessage(`{}`))
+}
+
+// ApproveSupplierInvoice calls POST /v1/procurement/supplier-invoices/{invoice_id}/approve.
+func (c *Client) ApproveSupplierInvoice(ctx context.Context, id string, payload json.RawMessage) (json.RawMessage, error) {
+	return c.post(ctx, "/v1/procurement/supplier-invoices/"+id+"/approve", payload)
+}
+
+// RejectSupplierInvoice calls POST /v1/procurement/supplier-invoices/{invoice_id}/reject.
+func (c *Client) RejectSupplierInvoice(ctx context.Context, id string, payload json.RawMessage) (json.RawMessage, error) {
+	return c.post(ctx, "/v1/procurement/supplier-invoices/"+id+"/reject", payload)
+}
+
+// ListLandedCosts calls GET /v1/procurement/landed-costs with the given
+// filters.
+func (c *Client) ListLandedCosts(ctx context.Context, filters url.Values) (json.RawMessage, error) {
+	path := "/v1/procurement/landed-costs"
+	if len(filters) > 0 {
+		path += "?" + filters.Encode()
+	}
+	return c.get(ctx, path)
+}
+
+// GetLandedCost calls GET /v1/procurement/landed-costs/{landed_cost_id}.
+func (c *Client) GetLandedCost(ctx context.Context, id string) (json.RawMessage, error) {
+	return c.get(ctx, "/v1/procurement/landed-costs/"+id)
+}
+
+// PreviewLandedCost calls GET /v1/procurement/landed-costs/{landed_cost_id}/preview.
+func (c *Client) PreviewLandedCost(ctx context.Context, id string) (json.RawMessage, error) {
+	return c.get(ctx, "/v1/procurement/landed-costs/"+id+"/preview")
+}
+
+// ApplyLandedCost calls POST /v1/procurement/landed-costs/{landed_cost_id}/apply.
+func (c *Client) ApplyLandedCost(ctx context.Context, id string) (json.RawMessage, error) {
+	return c.post(ctx, "/v1/procurement/landed-costs/"+id+"/apply", json.RawMessage(`{}`))
+}
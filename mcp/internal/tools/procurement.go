@@ -0,0 +1,384 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/timmaaaz/ichor/mcp/internal/client"
+)
+
+// RegisterProcurementTools adds purchase suggestion review and supplier
+// invoice matching tools to the MCP server.
+func RegisterProcurementTools(s *mcp.Server, c *client.Client) {
+	// list_purchase_suggestions — list suggested draft purchase orders.
+	type ListPurchaseSuggestionsArgs struct {
+		Status              string `json:"status,omitempty" jsonschema:"Filter by status: draft, released or dismissed"`
+		SupplierID          string `json:"supplier_id,omitempty" jsonschema:"Filter by supplier UUID"`
+		DeliveryWarehouseID string `json:"delivery_warehouse_id,omitempty" jsonschema:"Filter by delivery warehouse UUID"`
+		ProductID           string `json:"product_id,omitempty" jsonschema:"Only suggestions with a line for this product UUID"`
+		Page                string `json:"page,omitempty" jsonschema:"Page number (default 1)"`
+		Rows                string `json:"rows,omitempty" jsonschema:"Rows per page (default 10)"`
+	}
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "list_purchase_suggestions",
+		Description: "List purchase suggestions: draft purchase orders consolidated per supplier and delivery warehouse for items below their reorder point. Each suggestion includes its lines with the needed, suggested and editable quantities.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListPurchaseSuggestionsArgs) (*mcp.CallToolResult, any, error) {
+		filters := url.Values{}
+		for k, v := range map[string]string{
+			"status":                args.Status,
+			"supplier_id":           args.SupplierID,
+			"delivery_warehouse_id": args.DeliveryWarehouseID,
+			"product_id":            args.ProductID,
+			"page":                  args.Page,
+			"rows":                  args.Rows,
+		} {
+			if v != "" {
+				filters.Set(k, v)
+			}
+		}
+		data, err := c.ListPurchaseSuggestions(ctx, filters)
+		if err != nil {
+			return errorResult("Failed to list purchase suggestions: " + err.Error()), nil, nil
+		}
+		return jsonResult(data), nil, nil
+	})
+
+	// get_purchase_suggestion — get a single suggestion with its lines.
+	type GetPurchaseSuggestionArgs struct {
+		ID string `json:"id" jsonschema:"UUID of the purchase suggestion,required"`
+	}
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "get_purchase_suggestion",
+		Description: "Get a single purchase suggestion by ID with its lines, including the chosen supplier product, unit cost, lead time and score of each line.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetPurchaseSuggestionArgs) (*mcp.CallToolResult, any, error) {
+		if args.ID == "" {
+			return errorResult("id is required"), nil, nil
+		}
+		data, err := c.GetPurchaseSuggestion(ctx, args.ID)
+		if err != nil {
+			return errorResult("Failed to fetch purchase suggestion: " + err.Error()), nil, nil
+		}
+		return jsonResult(data), nil, nil
+	})
+
+	// run_purchase_suggestions — suggest purchases for items below their reorder point.
+	type RunPurchaseSuggestionsArgs struct {
+		WarehouseID    string `json:"warehouse_id,omitempty" jsonschema:"Only consider inventory in this warehouse UUID"`
+		CategoryID     string `json:"category_id,omitempty" jsonschema:"Only consider products in this category UUID"`
+		ProductID      string `json:"product_id,omitempty" jsonschema:"Only consider this product UUID"`
+		PriceWeight    string `json:"price_weight,omitempty" jsonschema:"Weight of unit price when picking a supplier"`
+		LeadTimeWeight string `json:"lead_time_weight,omitempty" jsonschema:"Weight of lead time when picking a supplier"`
+		MinOrderWeight string `json:"min_order_weight,omitempty" jsonschema:"Weight of minimum order overbuy when picking a supplier"`
+		RatingWeight   string `json:"rating_weight,omitempty" jsonschema:"Weight of supplier rating when picking a supplier"`
+	}
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "run_purchase_suggestions",
+		Description: "Collect every inventory item below its reorder point, pick a supplier per product by price, lead time, minimum order and rating, round to case packs, and consolidate into one draft suggestion per supplier and delivery warehouse. Products already on a draft suggestion are skipped. Returns the new suggestions and the products no active supplier sells.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args RunPurchaseSuggestionsArgs) (*mcp.CallToolResult, any, error) {
+		payload, err := json.Marshal(args)
+		if err != nil {
+			return errorResult("Failed to encode run request: " + err.Error()), nil, nil
+		}
+		data, err := c.RunPurchaseSuggestions(ctx, payload)
+		if err != nil {
+			return errorResult("Failed to run purchase suggestions: " + err.Error()), nil, nil
+		}
+		return jsonResult(data), nil, nil
+	})
+
+	// update_purchase_suggestion_line — edit the quantity or unit cost of a draft line.
+	type UpdatePurchaseSuggestionLineArgs struct {
+		ID       string `json:"id" jsonschema:"UUID of the purchase suggestion,required"`
+		LineID   string `json:"line_id" jsonschema:"UUID of the suggestion line,required"`
+		Quantity string `json:"quantity,omitempty" jsonschema:"New order quantity; must be positive and at least the minimum order quantity"`
+		UnitCost string `json:"unit_cost,omitempty" jsonschema:"New unit cost"`
+	}
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "update_purchase_suggestion_line",
+		Description: "Edit the quantity or unit cost of a line on a draft purchase suggestion. The suggestion subtotal is recalculated. Returns the updated suggestion.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args UpdatePurchaseSuggestionLineArgs) (*mcp.CallToolResult, any, error) {
+		if args.ID == "" || args.LineID == "" {
+			return errorResult("id and line_id are required"), nil, nil
+		}
+		update := map[string]string{}
+		if args.Quantity != "" {
+			update["quantity"] = args.Quantity
+		}
+		if args.UnitCost != "" {
+			update["unit_cost"] = args.UnitCost
+		}
+		payload, err := json.Marshal(update)
+		if err != nil {
+			return errorResult("Failed to encode line update: " + err.Error()), nil, nil
+		}
+		data, err := c.UpdatePurchaseSuggestionLine(ctx, args.ID, args.LineID, payload)
+		if err != nil {
+			return errorResult("Failed to update suggestion line: " + err.Error()), nil, nil
+		}
+		return jsonResult(data), nil, nil
+	})
+
+	// remove_purchase_suggestion_line — take a line off a draft suggestion.
+	type RemovePurchaseSuggestionLineArgs struct {
+		ID     string `json:"id" jsonschema:"UUID of the purchase suggestion,required"`
+		LineID string `json:"line_id" jsonschema:"UUID of the suggestion line,required"`
+	}
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "remove_purchase_suggestion_line",
+		Description: "Remove a line from a draft purchase suggestion. Returns the updated suggestion.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args RemovePurchaseSuggestionLineArgs) (*mcp.CallToolResult, any, error) {
+		if args.ID == "" || args.LineID == "" {
+			return errorResult("id and line_id are required"), nil, nil
+		}
+		data, err := c.RemovePurchaseSuggestionLine(ctx, args.ID, args.LineID)
+		if err != nil {
+			return errorResult("Failed to remove suggestion line: " + err.Error()), nil, nil
+		}
+		return jsonResult(data), nil, nil
+	})
+
+	// release_purchase_suggestion — turn a draft suggestion into a purchase order.
+	type ReleasePurchaseSuggestionArgs struct {
+		ID                    string `json:"id" jsonschema:"UUID of the purchase suggestion,required"`
+		PurchaseOrderStatusID string `json:"purchase_order_status_id" jsonschema:"UUID of the status for the new purchase order,required"`
+		LineItemStatusID      string `json:"line_item_status_id" jsonschema:"UUID of the status for the new line items,required"`
+		CurrencyID            string `json:"currency_id" jsonschema:"UUID of the purchase order currency,required"`
+		DeliveryLocationID    string `json:"delivery_location_id,omitempty" jsonschema:"UUID of the delivery location"`
+		OrderNumber           string `json:"order_number,omitempty" jsonschema:"Order number; generated when omitted"`
+	}
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "release_purchase_suggestion",
+		Description: "Release a draft purchase suggestion: creates the purchase order and its line items from the suggestion lines and marks the suggestion released. Returns the released suggestion with its purchase_order_id.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ReleasePurchaseSuggestionArgs) (*mcp.CallToolResult, any, error) {
+		if args.ID == "" || args.PurchaseOrderStatusID == "" || args.LineItemStatusID == "" || args.CurrencyID == "" {
+			return errorResult("id, purchase_order_status_id, line_item_status_id and currency_id are required"), nil, nil
+		}
+		payload, err := json.Marshal(map[string]string{
+			"purchase_order_status_id": args.PurchaseOrderStatusID,
+			"line_item_status_id":      args.LineItemStatusID,
+			"currency_id":              args.CurrencyID,
+			"delivery_location_id":     args.DeliveryLocationID,
+			"order_number":             args.OrderNumber,
+		})
+		if err != nil {
+			return errorResult("Failed to encode release request: " + err.Error()), nil, nil
+		}
+		data, err := c.ReleasePurchaseSuggestion(ctx, args.ID, payload)
+		if err != nil {
+			return errorResult("Failed to release purchase suggestion: " + err.Error()), nil, nil
+		}
+		return jsonResult(data), nil, nil
+	})
+
+	// dismiss_purchase_suggestion — drop a draft suggestion.
+	type DismissPurchaseSuggestionArgs struct {
+		ID string `json:"id" jsonschema:"UUID of the purchase suggestion,required"`
+	}
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "dismiss_purchase_suggestion",
+		Description: "Dismiss a draft purchase suggestion without ordering. Its products become eligible for the next suggestion run.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args DismissPurchaseSuggestionArgs) (*mcp.CallToolResult, any, error) {
+		if args.ID == "" {
+			return errorResult("id is required"), nil, nil
+		}
+		data, err := c.DismissPurchaseSuggestion(ctx, args.ID)
+		if err != nil {
+			return errorResult("Failed to dismiss purchase suggestion: " + err.Error()), nil, nil
+		}
+		return jsonResult(data), nil, nil
+	})
+
+	// list_supplier_invoices — list supplier invoices and their match results.
+	type ListSupplierInvoicesArgs struct {
+		Status          string `json:"status,omitempty" jsonschema:"Filter by status: open, pending_approval, approved or rejected"`
+		MatchStatus     string `json:"match_status,omitempty" jsonschema:"Filter by match status: unmatched, matched, price_variance or quantity_variance"`
+		SupplierID      string `json:"supplier_id,omitempty" jsonschema:"Filter by supplier UUID"`
+		PurchaseOrderID string `json:"purchase_order_id,omitempty" jsonschema:"Filter by purchase order UUID"`
+		InvoiceNumber   string `json:"invoice_number,omitempty" jsonschema:"Filter by invoice number (partial match)"`
+		Page            string `json:"page,omitempty" jsonschema:"Page number (default 1)"`
+		Rows            string `json:"rows,omitempty" jsonschema:"Rows per page (default 10)"`
+	}
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "list_supplier_invoices",
+		Description: "List supplier invoices billed against purchase orders, with their status, three-way match status and lines. Filter by pending_approval to find invoices awaiting a decision.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListSupplierInvoicesArgs) (*mcp.CallToolResult, any, error) {
+		filters := url.Values{}
+		for k, v := range map[string]string{
+			"status":            args.Status,
+			"match_status":      args.MatchStatus,
+			"supplier_id":       args.SupplierID,
+			"purchase_order_id": args.PurchaseOrderID,
+			"invoice_number":    args.InvoiceNumber,
+			"page":              args.Page,
+			"rows":              args.Rows,
+		} {
+			if v != "" {
+				filters.Set(k, v)
+			}
+		}
+		data, err := c.ListSupplierInvoices(ctx, filters)
+		if err != nil {
+			return errorResult("Failed to list supplier invoices: " + err.Error()), nil, nil
+		}
+		return jsonResult(data), nil, nil
+	})
+
+	// get_supplier_invoice — get a single invoice with its line match results.
+	type GetSupplierInvoiceArgs struct {
+		ID string `json:"id" jsonschema:"UUID of the supplier invoice,required"`
+	}
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "get_supplier_invoice",
+		Description: "Get a single supplier invoice by ID with its lines, including each line's expected unit cost, received and previously invoiced quantities, and price and quantity variances.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetSupplierInvoiceArgs) (*mcp.CallToolResult, any, error) {
+		if args.ID == "" {
+			return errorResult("id is required"), nil, nil
+		}
+		data, err := c.GetSupplierInvoice(ctx, args.ID)
+		if err != nil {
+			return errorResult("Failed to fetch supplier invoice: " + err.Error()), nil, nil
+		}
+		return jsonResult(data), nil, nil
+	})
+
+	// match_supplier_invoice — run the three-way match.
+	type MatchSupplierInvoiceArgs struct {
+		ID string `json:"id" jsonschema:"UUID of the supplier invoice,required"`
+	}
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "match_supplier_invoice",
+		Description: "Three-way match a supplier invoice against its purchase order prices and received quantities. An invoice within tolerance is approved for payment; a price or quantity variance leaves it pending approval. Returns the matched invoice.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args MatchSupplierInvoiceArgs) (*mcp.CallToolResult, any, error) {
+		if args.ID == "" {
+			return errorResult("id is required"), nil, nil
+		}
+		data, err := c.MatchSupplierInvoice(ctx, args.ID)
+		if err != nil {
+			return errorResult("Failed to match supplier invoice: " + err.Error()), nil, nil
+		}
+		return jsonResult(data), nil, nil
+	})
+
+	// approve_supplier_invoice — accept an invoice pending approval.
+	type DecideSupplierInvoiceArgs struct {
+		ID     string `json:"id" jsonschema:"UUID of the supplier invoice,required"`
+		Reason string `json:"reason,omitempty" jsonschema:"Reason recorded with the decision"`
+	}
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "approve_supplier_invoice",
+		Description: "Approve a supplier invoice that is pending approval after a price or quantity variance, accepting it for payment.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args DecideSupplierInvoiceArgs) (*mcp.CallToolResult, any, error) {
+		if args.ID == "" {
+			return errorResult("id is required"), nil, nil
+		}
+		payload, err := json.Marshal(map[string]string{"reason": args.Reason})
+		if err != nil {
+			return errorResult("Failed to encode approval: " + err.Error()), nil, nil
+		}
+		data, err := c.ApproveSupplierInvoice(ctx, args.ID, payload)
+		if err != nil {
+			return errorResult("Failed to approve supplier invoice: " + err.Error()), nil, nil
+		}
+		return jsonResult(data), nil, nil
+	})
+
+	// reject_supplier_invoice — refuse an unresolved invoice.
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "reject_supplier_invoice",
+		Description: "Reject an open or pending supplier invoice. It will not be paid and its lines no longer count as billed against the purchase order.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args DecideSupplierInvoiceArgs) (*mcp.CallToolResult, any, error) {
+		if args.ID == "" {
+			return errorResult("id is required"), nil, nil
+		}
+		payload, err := json.Marshal(map[string]string{"reason": args.Reason})
+		if err != nil {
+			return errorResult("Failed to encode rejection: " + err.Error()), nil, nil
+		}
+		data, err := c.RejectSupplierInvoice(ctx, args.ID, payload)
+		if err != nil {
+			return errorResult("Failed to reject supplier invoice: " + err.Error()), nil, nil
+		}
+		return jsonResult(data), nil, nil
+	})
+
+	// list_landed_costs — list landed costs and their charges.
+	type ListLandedCostsArgs struct {
+		Status          string `json:"status,omitempty" jsonschema:"Filter by status: draft, applied or cancelled"`
+		PurchaseOrderID string `json:"purchase_order_id,omitempty" jsonschema:"Filter by purchase order UUID"`
+		ReferenceNumber string `json:"reference_number,omitempty" jsonschema:"Filter by reference number (partial match)"`
+		Page            string `json:"page,omitempty" jsonschema:"Page number (default 1)"`
+		Rows            string `json:"rows,omitempty" jsonschema:"Rows per page (default 10)"`
+	}
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "list_landed_costs",
+		Description: "List landed costs: the freight, duty, brokerage, insurance and other charges entered against purchase orders, with how each charge is allocated (value, quantity, weight or volume) and, once applied, the allocations.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListLandedCostsArgs) (*mcp.CallToolResult, any, error) {
+		filters := url.Values{}
+		for k, v := range map[string]string{
+			"status":            args.Status,
+			"purchase_order_id": args.PurchaseOrderID,
+			"reference_number":  args.ReferenceNumber,
+			"page":              args.Page,
+			"rows":              args.Rows,
+		} {
+			if v != "" {
+				filters.Set(k, v)
+			}
+		}
+		data, err := c.ListLandedCosts(ctx, filters)
+		if err != nil {
+			return errorResult("Failed to list landed costs: " + err.Error()), nil, nil
+		}
+		return jsonResult(data), nil, nil
+	})
+
+	// get_landed_cost — get a single landed cost with its allocations.
+	type LandedCostArgs struct {
+		ID string `json:"id" jsonschema:"UUID of the landed cost,required"`
+	}
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "get_landed_cost",
+		Description: "Get a single landed cost by ID with its charges and, once applied, the share of each charge carried by each received purchase order line.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args LandedCostArgs) (*mcp.CallToolResult, any, error) {
+		if args.ID == "" {
+			return errorResult("id is required"), nil, nil
+		}
+		data, err := c.GetLandedCost(ctx, args.ID)
+		if err != nil {
+			return errorResult("Failed to fetch landed cost: " + err.Error()), nil, nil
+		}
+		return jsonResult(data), nil, nil
+	})
+
+	// preview_landed_cost — allocate a draft without applying it.
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "preview_landed_cost",
+		Description: "Preview a draft landed cost: how its charges would be allocated across what has been received so far and the landed unit cost each product would get. Changes nothing.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args LandedCostArgs) (*mcp.CallToolResult, any, error) {
+		if args.ID == "" {
+			return errorResult("id is required"), nil, nil
+		}
+		data, err := c.PreviewLandedCost(ctx, args.ID)
+		if err != nil {
+			return errorResult("Failed to preview landed cost: " + err.Error()), nil, nil
+		}
+		return jsonResult(data), nil, nil
+	})
+
+	// apply_landed_cost — fold a draft's charges into product costs.
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "apply_landed_cost",
+		Description: "Apply a draft landed cost: allocate its charges across the received lines, update each product's landed cost and record it in the product's cost history. Returns the applied landed cost with its allocations.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args LandedCostArgs) (*mcp.CallToolResult, any, error) {
+		if args.ID == "" {
+			return errorResult("id is required"), nil, nil
+		}
+		data, err := c.ApplyLandedCost(ctx, args.ID)
+		if err != nil {
+			return errorResult("Failed to apply landed cost: " + err.Error()), nil, nil
+		}
+		return jsonResult(data), nil, nil
+	})
+}
@@ -0,0 +1,296 @@
+package tools_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/timmaaaz/ichor/mcp/internal/tools"
+)
+
+func TestProcurementTools_ListPurchaseSuggestions_Filters(t *testing.T) {
+	response := `{"items":[{"id":"ps-1","status":"draft","lines":[]}],"total":1,"page":1,"rows_per_page":10}`
+
+	session, ctx := setupToolTest(t,
+		pathRouter(map[string]string{
+			"/v1/procurement/purchase-suggestions?status=draft&supplier_id=sup-1": response,
+		}),
+		tools.RegisterProcurementTools,
+	)
+
+	result := callTool(t, session, ctx, "list_purchase_suggestions", map[string]any{
+		"status":      "draft",
+		"supplier_id": "sup-1",
+	})
+
+	if result.IsError {
+		t.Errorf("list_purchase_suggestions returned error: %s", getTextContent(t, result))
+	}
+	text := getTextContent(t, result)
+	if text != response {
+		t.Errorf("got %q, want %q", text, response)
+	}
+}
+
+func TestProcurementTools_ListSupplierInvoices_Filters(t *testing.T) {
+	response := `{"items":[{"id":"si-1","status":"pending_approval","match_status":"price_variance","lines":[]}],"total":1,"page":1,"rows_per_page":10}`
+
+	session, ctx := setupToolTest(t,
+		pathRouter(map[string]string{
+			"/v1/procurement/supplier-invoices?match_status=price_variance&status=pending_approval": response,
+		}),
+		tools.RegisterProcurementTools,
+	)
+
+	result := callTool(t, session, ctx, "list_supplier_invoices", map[string]any{
+		"status":       "pending_approval",
+		"match_status": "price_variance",
+	})
+
+	if result.IsError {
+		t.Errorf("list_supplier_invoices returned error: %s", getTextContent(t, result))
+	}
+	text := getTextContent(t, result)
+	if text != response {
+		t.Errorf("got %q, want %q", text, response)
+	}
+}
+
+func TestProcurementTools_ListLandedCosts_Filters(t *testing.T) {
+	response := `{"items":[{"id":"lc-1","status":"draft","charges":[],"allocations":[]}],"total":1,"page":1,"rows_per_page":10}`
+
+	session, ctx := setupToolTest(t,
+		pathRouter(map[string]string{
+			"/v1/procurement/landed-costs?purchase_order_id=po-1&status=draft": response,
+		}),
+		tools.RegisterProcurementTools,
+	)
+
+	result := callTool(t, session, ctx, "list_landed_costs", map[string]any{
+		"status":            "draft",
+		"purchase_order_id": "po-1",
+	})
+
+	if result.IsError {
+		t.Errorf("list_landed_costs returned error: %s", getTextContent(t, result))
+	}
+	text := getTextContent(t, result)
+	if text != response {
+		t.Errorf("got %q, want %q", text, response)
+	}
+}
+
+func TestProcurementTools_GetPurchaseSuggestion_Success(t *testing.T) {
+	response := `{"id":"ps-1","status":"draft","lines":[{"id":"ln-1","quantity":"24"}]}`
+
+	session, ctx := setupToolTest(t,
+		pathRouter(map[string]string{
+			"/v1/procurement/purchase-suggestions/ps-1": response,
+		}),
+		tools.RegisterProcurementTools,
+	)
+
+	result := callTool(t, session, ctx, "get_purchase_suggestion", map[string]any{
+		"id": "ps-1",
+	})
+
+	if result.IsError {
+		t.Errorf("get_purchase_suggestion returned error: %s", getTextContent(t, result))
+	}
+	text := getTextContent(t, result)
+	if text != response {
+		t.Errorf("got %q, want %q", text, response)
+	}
+}
+
+func TestProcurementTools_RequestBodies(t *testing.T) {
+	type request struct {
+		method string
+		path   string
+		body   map[string]any
+	}
+	var got request
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = request{method: r.Method, path: r.URL.Path}
+		data, _ := io.ReadAll(r.Body)
+		if len(data) > 0 {
+			json.Unmarshal(data, &got.body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"ps-1"}`))
+	})
+
+	session, ctx := setupToolTest(t, handler, tools.RegisterProcurementTools)
+
+	tests := []struct {
+		toolName   string
+		args       map[string]any
+		wantMethod string
+		wantPath   string
+		wantBody   map[string]any
+	}{
+		{
+			toolName:   "run_purchase_suggestions",
+			args:       map[string]any{"warehouse_id": "wh-1", "price_weight": "2"},
+			wantMethod: http.MethodPost,
+			wantPath:   "/v1/procurement/purchase-suggestions/run",
+			wantBody:   map[string]any{"warehouse_id": "wh-1", "price_weight": "2"},
+		},
+		{
+			toolName:   "update_purchase_suggestion_line",
+			args:       map[string]any{"id": "ps-1", "line_id": "ln-1", "quantity": "48"},
+			wantMethod: http.MethodPut,
+			wantPath:   "/v1/procurement/purchase-suggestions/ps-1/lines/ln-1",
+			wantBody:   map[string]any{"quantity": "48"},
+		},
+		{
+			toolName:   "remove_purchase_suggestion_line",
+			args:       map[string]any{"id": "ps-1", "line_id": "ln-1"},
+			wantMethod: http.MethodDelete,
+			wantPath:   "/v1/procurement/purchase-suggestions/ps-1/lines/ln-1",
+		},
+		{
+			toolName: "release_purchase_suggestion",
+			args: map[string]any{
+				"id":                       "ps-1",
+				"purchase_order_status_id": "pos-1",
+				"line_item_status_id":      "lis-1",
+				"currency_id":              "cur-1",
+			},
+			wantMethod: http.MethodPost,
+			wantPath:   "/v1/procurement/purchase-suggestions/ps-1/release",
+			wantBody: map[string]any{
+				"purchase_order_status_id": "pos-1",
+				"line_item_status_id":      "lis-1",
+				"currency_id":              "cur-1",
+				"delivery_location_id":     "",
+				"order_number":             "",
+			},
+		},
+		{
+			toolName:   "dismiss_purchase_suggestion",
+			args:       map[string]any{"id": "ps-1"},
+			wantMethod: http.MethodPost,
+			wantPath:   "/v1/procurement/purchase-suggestions/ps-1/dismiss",
+			wantBody:   map[string]any{},
+		},
+		{
+			toolName:   "match_supplier_invoice",
+			args:       map[string]any{"id": "si-1"},
+			wantMethod: http.MethodPost,
+			wantPath:   "/v1/procurement/supplier-invoices/si-1/match",
+			wantBody:   map[string]any{},
+		},
+		{
+			toolName:   "approve_supplier_invoice",
+			args:       map[string]any{"id": "si-1", "reason": "freight agreed"},
+			wantMethod: http.MethodPost,
+			wantPath:   "/v1/procurement/supplier-invoices/si-1/approve",
+			wantBody:   map[string]any{"reason": "freight agreed"},
+		},
+		{
+			toolName:   "reject_supplier_invoice",
+			args:       map[string]any{"id": "si-1", "reason": "overbilled"},
+			wantMethod: http.MethodPost,
+			wantPath:   "/v1/procurement/supplier-invoices/si-1/reject",
+			wantBody:   map[string]any{"reason": "overbilled"},
+		},
+		{
+			toolName:   "apply_landed_cost",
+			args:       map[string]any{"id": "lc-1"},
+			wantMethod: http.MethodPost,
+			wantPath:   "/v1/procurement/landed-costs/lc-1/apply",
+			wantBody:   map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.toolName, func(t *testing.T) {
+			got = request{}
+			result := callTool(t, session, ctx, tt.toolName, tt.args)
+			if result.IsError {
+				t.Fatalf("%s returned error: %s", tt.toolName, getTextContent(t, result))
+			}
+			if got.method != tt.wantMethod || got.path != tt.wantPath {
+				t.Errorf("got %s %s, want %s %s", got.method, got.path, tt.wantMethod, tt.wantPath)
+			}
+			if len(got.body) != len(tt.wantBody) {
+				t.Fatalf("got body %v, want %v", got.body, tt.wantBody)
+			}
+			for k, v := range tt.wantBody {
+				if got.body[k] != v {
+					t.Errorf("body[%s]: got %v, want %v", k, got.body[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestProcurementTools_MissingRequiredArgs(t *testing.T) {
+	session, ctx := setupToolTest(t,
+		staticHandler(`{}`),
+		tools.RegisterProcurementTools,
+	)
+
+	// Empty string passes SDK schema validation but triggers handler validation.
+	tests := []struct {
+		toolName string
+		args     map[string]any
+	}{
+		{"get_purchase_suggestion", map[string]any{"id": ""}},
+		{"update_purchase_suggestion_line", map[string]any{"id": "ps-1", "line_id": ""}},
+		{"remove_purchase_suggestion_line", map[string]any{"id": "", "line_id": "ln-1"}},
+		{"release_purchase_suggestion", map[string]any{
+			"id": "ps-1", "purchase_order_status_id": "", "line_item_status_id": "lis-1", "currency_id": "cur-1",
+		}},
+		{"dismiss_purchase_suggestion", map[string]any{"id": ""}},
+		{"get_supplier_invoice", map[string]any{"id": ""}},
+		{"match_supplier_invoice", map[string]any{"id": ""}},
+		{"approve_supplier_invoice", map[string]any{"id": ""}},
+		{"reject_supplier_invoice", map[string]any{"id": ""}},
+		{"get_landed_cost", map[string]any{"id": ""}},
+		{"preview_landed_cost", map[string]any{"id": ""}},
+		{"apply_landed_cost", map[string]any{"id": ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.toolName, func(t *testing.T) {
+			result := callTool(t, session, ctx, tt.toolName, tt.args)
+			if !result.IsError {
+				t.Errorf("%s should return error when required args missing", tt.toolName)
+			}
+		})
+	}
+}
+
+func TestProcurementTools_APIError(t *testing.T) {
+	session, ctx := setupToolTest(t,
+		errorHandler(500),
+		tools.RegisterProcurementTools,
+	)
+
+	tests := []struct {
+		toolName string
+		args     map[string]any
+	}{
+		{"list_purchase_suggestions", map[string]any{}},
+		{"get_purchase_suggestion", map[string]any{"id": "ps-1"}},
+		{"run_purchase_suggestions", map[string]any{}},
+		{"dismiss_purchase_suggestion", map[string]any{"id": "ps-1"}},
+		{"list_supplier_invoices", map[string]any{}},
+		{"match_supplier_invoice", map[string]any{"id": "si-1"}},
+		{"list_landed_costs", map[string]any{}},
+		{"preview_landed_cost", map[string]any{"id": "lc-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.toolName, func(t *testing.T) {
+			result := callTool(t, session, ctx, tt.toolName, tt.args)
+			if !result.IsError {
+				t.Errorf("%s should return error for 500 response", tt.toolName)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ func RegisterAllTools(s *mcp.Server, c *client.Client) {
 	RegisterUIWriteTools(s, c)
 	RegisterValidationTools(s, c)
 	RegisterAnalysisTools(s, c)
+	RegisterProcurementTools(s, c)
 }
 
 // RegisterToolsForContext registers only the tools that belong to the given